@@ -8,6 +8,15 @@ export interface QueryParams {
   Limit: number /* int */;
   Cursor: string;
 }
+/**
+ * FilterParams ... Optional constraints on the listed bridge transfers. Zero values do not constrain the transfers.
+ */
+export interface FilterParams {
+  Token: any /* common.Address */;
+  FromTimestamp: number /* uint64 */;
+  ToTimestamp: number /* uint64 */;
+  Status: WithdrawalStatus;
+}
 /**
  * DepositItem ... Deposit item model for API responses
  */
@@ -31,6 +40,26 @@ export interface DepositResponse {
   hasNextPage: boolean;
   items: DepositItem[];
 }
+/**
+ * WithdrawalStatus ... Stage of a withdrawal in the multi-step withdrawal process
+ */
+export type WithdrawalStatus = string;
+/**
+ * WithdrawalStatusInitiated ... Withdrawal was initiated on L2, but is not proven on L1 yet
+ */
+export const WithdrawalStatusInitiated: WithdrawalStatus = "initiated";
+/**
+ * WithdrawalStatusProven ... Withdrawal was proven on L1, and waits for the finalization period to pass
+ */
+export const WithdrawalStatusProven: WithdrawalStatus = "proven";
+/**
+ * WithdrawalStatusReady ... Withdrawal was proven on L1 and the finalization period has passed
+ */
+export const WithdrawalStatusReady: WithdrawalStatus = "ready";
+/**
+ * WithdrawalStatusFinalized ... Withdrawal was finalized on L1
+ */
+export const WithdrawalStatusFinalized: WithdrawalStatus = "finalized";
 /**
  * WithdrawalItem ... Data model for API JSON response
  */
@@ -47,6 +76,15 @@ export interface WithdrawalItem {
   l1FinalizedTxHash: string;
   l1TokenAddress: string;
   l2TokenAddress: string;
+  status: WithdrawalStatus;
+  /**
+   * L1ProvenTimestamp is zero until the withdrawal is proven
+   */
+  l1ProvenTimestamp: number /* uint64 */;
+  /**
+   * L1FinalizableTimestamp is the time the withdrawal can be finalized at, zero until the withdrawal is proven
+   */
+  l1FinalizableTimestamp: number /* uint64 */;
 }
 /**
  * WithdrawalResponse ... Data model for API JSON response
@@ -56,6 +94,74 @@ export interface WithdrawalResponse {
   hasNextPage: boolean;
   items: WithdrawalItem[];
 }
+/**
+ * CrossDomainTransferItem ... Data model for API JSON response. The transfer is initiated on L1 and finalized on L2
+ * for deposits, and the other way around for withdrawals.
+ */
+export interface CrossDomainTransferItem {
+  crossDomainMessageHash: string;
+  from: string;
+  to: string;
+  amount: string;
+  l1TokenAddress: string;
+  l2TokenAddress: string;
+  l1TxHash: string;
+  l2TxHash: string;
+  /**
+   * Timestamp is the time the transfer was initiated at
+   */
+  timestamp: number /* uint64 */;
+  /**
+   * FinalizedTimestamp is zero until the transfer is finalized
+   */
+  finalizedTimestamp: number /* uint64 */;
+  /**
+   * Latency is the seconds from the initiation to the finalization of the transfer, zero until the transfer is finalized
+   */
+  latency: number /* uint64 */;
+  /**
+   * Unmatched is set when the transfer is not finalized within the unmatched threshold of its initiation
+   */
+  unmatched: boolean;
+}
+/**
+ * CrossDomainTransferResponse ... Data model for API JSON response
+ */
+export interface CrossDomainTransferResponse {
+  cursor: string;
+  hasNextPage: boolean;
+  items: CrossDomainTransferItem[];
+}
+/**
+ * ERC721TransferItem ... Data model for API JSON response. The token is bridged from L1 to L2 for deposits, and the
+ * other way around for withdrawals.
+ */
+export interface ERC721TransferItem {
+  crossDomainMessageHash: string;
+  from: string;
+  to: string;
+  tokenId: string;
+  l1TokenAddress: string;
+  l2TokenAddress: string;
+  l1TxHash: string;
+  l2TxHash: string;
+  /**
+   * Timestamp is the time the transfer was initiated at
+   */
+  timestamp: number /* uint64 */;
+  /**
+   * FinalizedTimestamp is zero until the transfer is finalized
+   */
+  finalizedTimestamp: number /* uint64 */;
+}
+/**
+ * ERC721TransferResponse ... Data model for API JSON response
+ */
+export interface ERC721TransferResponse {
+  cursor: string;
+  hasNextPage: boolean;
+  items: ERC721TransferItem[];
+}
 export interface BridgeSupplyView {
   l1DepositSum: number /* float64 */;
   l2WithdrawalSum: number /* float64 */;
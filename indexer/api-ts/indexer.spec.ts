@@ -1,12 +1,41 @@
 import { test, expect } from 'vitest'
-import { depositEndpoint, withdrawalEndoint } from './indexer.ts'
+import { crossDomainDepositEndpoint, crossDomainWithdrawalEndpoint, depositEndpoint, erc721CollectionDepositEndpoint, erc721CollectionWithdrawalEndpoint, erc721DepositEndpoint, erc721WithdrawalEndpoint, withdrawalEndoint } from './indexer.ts'
 
 test(depositEndpoint.name, () => {
   expect(depositEndpoint({ baseUrl: 'http://localhost:8080/api/v0', address: '0x1234', cursor: '0x1235', limit: 10 })).toMatchInlineSnapshot('"http://localhost:8080/api/v0/deposits/0x1234?cursor=0x1235&limit=10"')
   expect(depositEndpoint({ baseUrl: 'http://localhost:8080/api/v0', address: '0x1234' })).toMatchInlineSnapshot('"http://localhost:8080/api/v0/deposits/0x1234"')
+  expect(depositEndpoint({ baseUrl: 'http://localhost:8080/api/v0', address: '0x1234', token: '0x4200', fromTimestamp: 100, toTimestamp: 200 })).toMatchInlineSnapshot('"http://localhost:8080/api/v0/deposits/0x1234?token=0x4200&fromTimestamp=100&toTimestamp=200"')
 })
 
 test(withdrawalEndoint.name, () => {
   expect(withdrawalEndoint({ baseUrl: 'http://localhost:8080/api/v0', address: '0x1234', cursor: '0x1235', limit: 10 })).toMatchInlineSnapshot('"http://localhost:8080/api/v0/withdrawals/0x1234?cursor=0x1235&limit=10"')
   expect(withdrawalEndoint({ baseUrl: 'http://localhost:8080/api/v0', address: '0x1234' })).toMatchInlineSnapshot('"http://localhost:8080/api/v0/withdrawals/0x1234"')
+  expect(withdrawalEndoint({ baseUrl: 'http://localhost:8080/api/v0', address: '0x1234', cursor: 'AAA', status: 'ready' })).toMatchInlineSnapshot('"http://localhost:8080/api/v0/withdrawals/0x1234?cursor=AAA&status=ready"')
+})
+
+test(crossDomainDepositEndpoint.name, () => {
+  expect(crossDomainDepositEndpoint({ baseUrl: 'http://localhost:8080/api/v0', address: '0x1234', cursor: '0x1235', limit: 10 })).toMatchInlineSnapshot('"http://localhost:8080/api/v0/transfers/deposits/0x1234?cursor=0x1235&limit=10"')
+  expect(crossDomainDepositEndpoint({ baseUrl: 'http://localhost:8080/api/v0', address: '0x1234', token: '0x4200' })).toMatchInlineSnapshot('"http://localhost:8080/api/v0/transfers/deposits/0x1234?token=0x4200"')
+})
+
+test(crossDomainWithdrawalEndpoint.name, () => {
+  expect(crossDomainWithdrawalEndpoint({ baseUrl: 'http://localhost:8080/api/v0', address: '0x1234' })).toMatchInlineSnapshot('"http://localhost:8080/api/v0/transfers/withdrawals/0x1234"')
+  expect(crossDomainWithdrawalEndpoint({ baseUrl: 'http://localhost:8080/api/v0', address: '0x1234', fromTimestamp: 100, toTimestamp: 200 })).toMatchInlineSnapshot('"http://localhost:8080/api/v0/transfers/withdrawals/0x1234?fromTimestamp=100&toTimestamp=200"')
+})
+
+test(erc721DepositEndpoint.name, () => {
+  expect(erc721DepositEndpoint({ baseUrl: 'http://localhost:8080/api/v0', address: '0x1234', cursor: '0x1235', limit: 10 })).toMatchInlineSnapshot('"http://localhost:8080/api/v0/erc721/deposits/0x1234?cursor=0x1235&limit=10"')
+  expect(erc721DepositEndpoint({ baseUrl: 'http://localhost:8080/api/v0', address: '0x1234', token: '0x4200' })).toMatchInlineSnapshot('"http://localhost:8080/api/v0/erc721/deposits/0x1234?token=0x4200"')
+})
+
+test(erc721WithdrawalEndpoint.name, () => {
+  expect(erc721WithdrawalEndpoint({ baseUrl: 'http://localhost:8080/api/v0', address: '0x1234' })).toMatchInlineSnapshot('"http://localhost:8080/api/v0/erc721/withdrawals/0x1234"')
+})
+
+test(erc721CollectionDepositEndpoint.name, () => {
+  expect(erc721CollectionDepositEndpoint({ baseUrl: 'http://localhost:8080/api/v0', address: '0x1234', limit: 10 })).toMatchInlineSnapshot('"http://localhost:8080/api/v0/erc721/collections/0x1234/deposits?limit=10"')
+})
+
+test(erc721CollectionWithdrawalEndpoint.name, () => {
+  expect(erc721CollectionWithdrawalEndpoint({ baseUrl: 'http://localhost:8080/api/v0', address: '0x1234', fromTimestamp: 100 })).toMatchInlineSnapshot('"http://localhost:8080/api/v0/erc721/collections/0x1234/withdrawals?fromTimestamp=100"')
 })
@@ -1,3 +1,5 @@
+import type { WithdrawalStatus } from './generated'
+
 export * from './generated'
 
 type PaginationOptions = {
@@ -5,30 +7,62 @@ type PaginationOptions = {
   cursor?: string
 }
 
+type FilterOptions = {
+  token?: `0x${string}`
+  fromTimestamp?: number
+  toTimestamp?: number
+}
+
 type Options = {
   baseUrl?: string
   address: `0x${string}`
-} & PaginationOptions
+} & PaginationOptions & FilterOptions
 
-const createQueryString = ({ cursor, limit }: PaginationOptions): string => {
-  if (cursor === undefined && limit === undefined) {
-    return ''
-  }
+type WithdrawalOptions = Options & {
+  status?: WithdrawalStatus
+}
+
+const createQueryString = (params: Record<string, string | number | undefined>): string => {
   const queries: string[] = []
-  if (cursor) {
-    queries.push(`cursor=${cursor}`)
+  for (const [key, value] of Object.entries(params)) {
+    if (value) {
+      queries.push(`${key}=${value}`)
+    }
   }
-  if (limit) {
-    queries.push(`limit=${limit}`)
+  if (queries.length === 0) {
+    return ''
   }
   return `?${queries.join('&')}`
 }
 
-export const depositEndpoint = ({ baseUrl = '', address, cursor, limit }: Options): string => {
-  return [baseUrl, 'deposits', `${address}${createQueryString({ cursor, limit })}`].join('/')
+export const depositEndpoint = ({ baseUrl = '', address, cursor, limit, token, fromTimestamp, toTimestamp }: Options): string => {
+  return [baseUrl, 'deposits', `${address}${createQueryString({ cursor, limit, token, fromTimestamp, toTimestamp })}`].join('/')
+}
+
+export const withdrawalEndoint = ({ baseUrl = '', address, cursor, limit, token, fromTimestamp, toTimestamp, status }: WithdrawalOptions): string => {
+  return [baseUrl, 'withdrawals', `${address}${createQueryString({ cursor, limit, token, fromTimestamp, toTimestamp, status })}`].join('/')
 }
 
-export const withdrawalEndoint = ({ baseUrl = '', address, cursor, limit }: Options): string => {
-  return [baseUrl, 'withdrawals', `${address}${createQueryString({ cursor, limit })}`].join('/')
+export const crossDomainDepositEndpoint = ({ baseUrl = '', address, cursor, limit, token, fromTimestamp, toTimestamp }: Options): string => {
+  return [baseUrl, 'transfers', 'deposits', `${address}${createQueryString({ cursor, limit, token, fromTimestamp, toTimestamp })}`].join('/')
 }
 
+export const crossDomainWithdrawalEndpoint = ({ baseUrl = '', address, cursor, limit, token, fromTimestamp, toTimestamp }: Options): string => {
+  return [baseUrl, 'transfers', 'withdrawals', `${address}${createQueryString({ cursor, limit, token, fromTimestamp, toTimestamp })}`].join('/')
+}
+
+export const erc721DepositEndpoint = ({ baseUrl = '', address, cursor, limit, token, fromTimestamp, toTimestamp }: Options): string => {
+  return [baseUrl, 'erc721', 'deposits', `${address}${createQueryString({ cursor, limit, token, fromTimestamp, toTimestamp })}`].join('/')
+}
+
+export const erc721WithdrawalEndpoint = ({ baseUrl = '', address, cursor, limit, token, fromTimestamp, toTimestamp }: Options): string => {
+  return [baseUrl, 'erc721', 'withdrawals', `${address}${createQueryString({ cursor, limit, token, fromTimestamp, toTimestamp })}`].join('/')
+}
+
+export const erc721CollectionDepositEndpoint = ({ baseUrl = '', address, cursor, limit, fromTimestamp, toTimestamp }: Options): string => {
+  return [baseUrl, 'erc721', 'collections', address, `deposits${createQueryString({ cursor, limit, fromTimestamp, toTimestamp })}`].join('/')
+}
+
+export const erc721CollectionWithdrawalEndpoint = ({ baseUrl = '', address, cursor, limit, fromTimestamp, toTimestamp }: Options): string => {
+  return [baseUrl, 'erc721', 'collections', address, `withdrawals${createQueryString({ cursor, limit, fromTimestamp, toTimestamp })}`].join('/')
+}
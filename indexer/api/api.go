@@ -37,6 +37,13 @@ const (
 	DepositsPath    = "/api/v0/deposits/"
 	WithdrawalsPath = "/api/v0/withdrawals/"
 
+	CrossDomainDepositsPath    = "/api/v0/transfers/deposits/"
+	CrossDomainWithdrawalsPath = "/api/v0/transfers/withdrawals/"
+
+	ERC721DepositsPath    = "/api/v0/erc721/deposits/"
+	ERC721WithdrawalsPath = "/api/v0/erc721/withdrawals/"
+	ERC721CollectionsPath = "/api/v0/erc721/collections/"
+
 	SupplyPath = "/api/v0/supply"
 )
 
@@ -80,7 +87,7 @@ func (a *APIService) initFromConfig(ctx context.Context, cfg *Config) error {
 	if err := a.startMetricsServer(cfg.MetricsServer); err != nil {
 		return fmt.Errorf("failed to start metrics server: %w", err)
 	}
-	a.initRouter(cfg.HTTPServer)
+	a.initRouter(cfg)
 	if err := a.startServer(cfg.HTTPServer); err != nil {
 		return fmt.Errorf("failed to start API server: %w", err)
 	}
@@ -137,22 +144,28 @@ func (a *APIService) initDB(ctx context.Context, connector DBConnector) error {
 	return nil
 }
 
-func (a *APIService) initRouter(apiConfig config.ServerConfig) {
+func (a *APIService) initRouter(cfg *Config) {
 	v := new(service.Validator)
 
-	svc := service.New(v, a.bv, a.log)
+	svc := service.New(v, a.bv, a.log, cfg.FinalizationPeriodSeconds, cfg.UnmatchedDepositThresholdSeconds, cfg.UnmatchedWithdrawalThresholdSeconds)
 	apiRouter := chi.NewRouter()
 	h := routes.NewRoutes(a.log, apiRouter, svc)
 
 	promRecorder := metrics.NewPromHTTPRecorder(a.metricsRegistry, MetricsNamespace)
 
 	apiRouter.Use(chiMetricsMiddleware(promRecorder))
-	apiRouter.Use(middleware.Timeout(time.Duration(apiConfig.WriteTimeout) * time.Second))
+	apiRouter.Use(middleware.Timeout(time.Duration(cfg.HTTPServer.WriteTimeout) * time.Second))
 	apiRouter.Use(middleware.Recoverer)
 	apiRouter.Use(middleware.Heartbeat(HealthPath))
 
 	apiRouter.Get(fmt.Sprintf(DepositsPath+addressParam, ethereumAddressRegex), h.L1DepositsHandler)
 	apiRouter.Get(fmt.Sprintf(WithdrawalsPath+addressParam, ethereumAddressRegex), h.L2WithdrawalsHandler)
+	apiRouter.Get(fmt.Sprintf(CrossDomainDepositsPath+addressParam, ethereumAddressRegex), h.CrossDomainDepositsHandler)
+	apiRouter.Get(fmt.Sprintf(CrossDomainWithdrawalsPath+addressParam, ethereumAddressRegex), h.CrossDomainWithdrawalsHandler)
+	apiRouter.Get(fmt.Sprintf(ERC721DepositsPath+addressParam, ethereumAddressRegex), h.ERC721DepositsHandler)
+	apiRouter.Get(fmt.Sprintf(ERC721WithdrawalsPath+addressParam, ethereumAddressRegex), h.ERC721WithdrawalsHandler)
+	apiRouter.Get(fmt.Sprintf(ERC721CollectionsPath+addressParam+"/deposits", ethereumAddressRegex), h.ERC721CollectionDepositsHandler)
+	apiRouter.Get(fmt.Sprintf(ERC721CollectionsPath+addressParam+"/withdrawals", ethereumAddressRegex), h.ERC721CollectionWithdrawalsHandler)
 	apiRouter.Get(SupplyPath, h.SupplyView)
 	a.router = apiRouter
 }
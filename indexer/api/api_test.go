@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -19,7 +20,15 @@ import (
 )
 
 // MockBridgeTransfersView mocks the BridgeTransfersView interface
-type MockBridgeTransfersView struct{}
+type MockBridgeTransfersView struct {
+	// The parameters of the last listing of the bridge transfers of an address
+	cursor            string
+	limit             int
+	depositsFilter    database.BridgeTransfersFilter
+	withdrawalsFilter database.L2BridgeWithdrawalsFilter
+	transfersFilter   database.BridgeTransfersFilter
+	erc721Filter      database.ERC721BridgeTransfersFilter
+}
 
 var mockAddress = "0x4204204204204204204204204204204204204204"
 
@@ -53,6 +62,43 @@ var (
 	}
 )
 
+var (
+	// ETH deposited from L1 & finalized on L2
+	crossDomainDeposit = database.CrossDomainTransferWithTransactionHashes{
+		CrossDomainTransfer: database.CrossDomainTransfer{
+			CrossDomainMessageHash: common.HexToHash("0xd1"),
+			Tx:                     database.Transaction{Amount: big.NewInt(100), Timestamp: 1000},
+			TokenPair:              database.ETHTokenPair,
+		},
+		InitiatedTransactionHash: common.HexToHash("0xd2"),
+		FinalizedTransactionHash: common.HexToHash("0xd3"),
+		FinalizedTimestamp:       1060,
+	}
+
+	// ERC20 withdrawn from L2 & not finalized on L1
+	crossDomainWithdrawal = database.CrossDomainTransferWithTransactionHashes{
+		CrossDomainTransfer: database.CrossDomainTransfer{
+			CrossDomainMessageHash: common.HexToHash("0xe1"),
+			Tx:                     database.Transaction{Amount: big.NewInt(200), Timestamp: 1000},
+			TokenPair:              database.TokenPair{LocalTokenAddress: common.HexToAddress("0xe2"), RemoteTokenAddress: common.HexToAddress("0xe3")},
+		},
+		InitiatedTransactionHash: common.HexToHash("0xe4"),
+	}
+
+	// ERC721 token deposited from L1 & finalized on L2
+	erc721Deposit = database.ERC721BridgeTransferWithTransactionHashes{
+		ERC721BridgeTransfer: database.ERC721BridgeTransfer{
+			CrossDomainMessageHash: common.HexToHash("0xf1"),
+			TokenPair:              database.TokenPair{LocalTokenAddress: common.HexToAddress("0xf2"), RemoteTokenAddress: common.HexToAddress("0xf3")},
+			TokenID:                big.NewInt(7),
+			Timestamp:              1000,
+		},
+		InitiatedTransactionHash: common.HexToHash("0xf4"),
+		FinalizedTransactionHash: common.HexToHash("0xf5"),
+		FinalizedTimestamp:       1060,
+	}
+)
+
 func (mbv *MockBridgeTransfersView) L1BridgeDeposit(hash common.Hash) (*database.L1BridgeDeposit, error) {
 	return &deposit, nil
 }
@@ -69,7 +115,8 @@ func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalWithFilter(filter database
 	return &withdrawal, nil
 }
 
-func (mbv *MockBridgeTransfersView) L1BridgeDepositsByAddress(address common.Address, cursor string, limit int) (*database.L1BridgeDepositsResponse, error) {
+func (mbv *MockBridgeTransfersView) L1BridgeDepositsByAddress(address common.Address, cursor string, limit int, filter database.BridgeTransfersFilter) (*database.L1BridgeDepositsResponse, error) {
+	mbv.cursor, mbv.limit, mbv.depositsFilter = cursor, limit, filter
 	return &database.L1BridgeDepositsResponse{
 		Deposits: []database.L1BridgeDepositWithTransactionHashes{
 			{
@@ -82,7 +129,8 @@ func (mbv *MockBridgeTransfersView) L1BridgeDepositsByAddress(address common.Add
 	}, nil
 }
 
-func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalsByAddress(address common.Address, cursor string, limit int) (*database.L2BridgeWithdrawalsResponse, error) {
+func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalsByAddress(address common.Address, cursor string, limit int, filter database.L2BridgeWithdrawalsFilter) (*database.L2BridgeWithdrawalsResponse, error) {
+	mbv.cursor, mbv.limit, mbv.withdrawalsFilter = cursor, limit, filter
 	return &database.L2BridgeWithdrawalsResponse{
 		Withdrawals: []database.L2BridgeWithdrawalWithTransactionHashes{
 			{
@@ -96,6 +144,34 @@ func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalsByAddress(address common.
 	}, nil
 }
 
+func (mbv *MockBridgeTransfersView) CrossDomainTransfer(hash common.Hash) (*database.CrossDomainTransfer, error) {
+	return &crossDomainDeposit.CrossDomainTransfer, nil
+}
+
+func (mbv *MockBridgeTransfersView) CrossDomainDepositsByAddress(address common.Address, cursor string, limit int, filter database.BridgeTransfersFilter) (*database.CrossDomainTransfersResponse, error) {
+	mbv.cursor, mbv.limit, mbv.transfersFilter = cursor, limit, filter
+	return &database.CrossDomainTransfersResponse{Transfers: []database.CrossDomainTransferWithTransactionHashes{crossDomainDeposit}}, nil
+}
+
+func (mbv *MockBridgeTransfersView) CrossDomainWithdrawalsByAddress(address common.Address, cursor string, limit int, filter database.BridgeTransfersFilter) (*database.CrossDomainTransfersResponse, error) {
+	mbv.cursor, mbv.limit, mbv.transfersFilter = cursor, limit, filter
+	return &database.CrossDomainTransfersResponse{Transfers: []database.CrossDomainTransferWithTransactionHashes{crossDomainWithdrawal}}, nil
+}
+
+func (mbv *MockBridgeTransfersView) ERC721BridgeTransfer(hash common.Hash) (*database.ERC721BridgeTransfer, error) {
+	return &erc721Deposit.ERC721BridgeTransfer, nil
+}
+
+func (mbv *MockBridgeTransfersView) ERC721BridgeDeposits(cursor string, limit int, filter database.ERC721BridgeTransfersFilter) (*database.ERC721BridgeTransfersResponse, error) {
+	mbv.cursor, mbv.limit, mbv.erc721Filter = cursor, limit, filter
+	return &database.ERC721BridgeTransfersResponse{Transfers: []database.ERC721BridgeTransferWithTransactionHashes{erc721Deposit}}, nil
+}
+
+func (mbv *MockBridgeTransfersView) ERC721BridgeWithdrawals(cursor string, limit int, filter database.ERC721BridgeTransfersFilter) (*database.ERC721BridgeTransfersResponse, error) {
+	mbv.cursor, mbv.limit, mbv.erc721Filter = cursor, limit, filter
+	return &database.ERC721BridgeTransfersResponse{Transfers: []database.ERC721BridgeTransferWithTransactionHashes{}}, nil
+}
+
 func (mbv *MockBridgeTransfersView) L1TxDepositSum() (float64, error) {
 	return 69, nil
 }
@@ -184,3 +260,193 @@ func TestL2BridgeWithdrawalsByAddressHandler(t *testing.T) {
 	assert.Equal(t, resp.Items[0].Timestamp, withdrawal.Tx.Timestamp)
 
 }
+
+func TestCrossDomainTransfersHandlers(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	view := &MockBridgeTransfersView{}
+	cfg := &Config{
+		DB:            &TestDBConnector{BridgeTransfers: view},
+		HTTPServer:    apiConfig,
+		MetricsServer: metricsConfig,
+
+		UnmatchedDepositThresholdSeconds:    3600,
+		UnmatchedWithdrawalThresholdSeconds: 3600,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+
+	get := func(path string) models.CrossDomainTransferResponse {
+		request, err := http.NewRequest("GET", "http://"+api.Addr()+path, nil)
+		require.NoError(t, err)
+		responseRecorder := httptest.NewRecorder()
+		api.router.ServeHTTP(responseRecorder, request)
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resp models.CrossDomainTransferResponse
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+		require.Len(t, resp.Items, 1)
+		return resp
+	}
+
+	t.Run("Deposits", func(t *testing.T) {
+		token := common.HexToAddress("0x4200000000000000000000000000000000000010")
+		item := get(fmt.Sprintf("/api/v0/transfers/deposits/%s?limit=10&token=%s", mockAddress, token)).Items[0]
+		require.Equal(t, 10, view.limit)
+		require.Equal(t, database.BridgeTransfersFilter{TokenAddress: token}, view.transfersFilter)
+
+		require.Equal(t, crossDomainDeposit.CrossDomainTransfer.CrossDomainMessageHash.String(), item.CrossDomainMessageHash)
+		require.Equal(t, "100", item.Amount)
+		require.Equal(t, crossDomainDeposit.InitiatedTransactionHash.String(), item.L1TxHash)
+		require.Equal(t, crossDomainDeposit.FinalizedTransactionHash.String(), item.L2TxHash)
+		require.Equal(t, database.ETHTokenPair.LocalTokenAddress.String(), item.L1TokenAddress)
+		require.Equal(t, database.ETHTokenPair.RemoteTokenAddress.String(), item.L2TokenAddress)
+		require.Equal(t, uint64(1000), item.Timestamp)
+		require.Equal(t, uint64(1060), item.FinalizedTimestamp)
+		require.Equal(t, uint64(60), item.Latency)
+		require.False(t, item.Unmatched)
+	})
+
+	t.Run("Withdrawals", func(t *testing.T) {
+		item := get("/api/v0/transfers/withdrawals/" + mockAddress).Items[0]
+		require.Equal(t, 100, view.limit)
+		require.Equal(t, database.BridgeTransfersFilter{}, view.transfersFilter)
+
+		require.Equal(t, crossDomainWithdrawal.CrossDomainTransfer.CrossDomainMessageHash.String(), item.CrossDomainMessageHash)
+		require.Equal(t, "200", item.Amount)
+		require.Equal(t, crossDomainWithdrawal.InitiatedTransactionHash.String(), item.L2TxHash)
+		require.Equal(t, common.Hash{}.String(), item.L1TxHash)
+		require.Equal(t, common.HexToAddress("0xe3").String(), item.L1TokenAddress)
+		require.Equal(t, common.HexToAddress("0xe2").String(), item.L2TokenAddress)
+		require.Zero(t, item.FinalizedTimestamp)
+		require.Zero(t, item.Latency)
+		require.True(t, item.Unmatched)
+	})
+}
+
+func TestERC721TransfersHandlers(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	view := &MockBridgeTransfersView{}
+	cfg := &Config{
+		DB:            &TestDBConnector{BridgeTransfers: view},
+		HTTPServer:    apiConfig,
+		MetricsServer: metricsConfig,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+
+	get := func(path string) models.ERC721TransferResponse {
+		request, err := http.NewRequest("GET", "http://"+api.Addr()+path, nil)
+		require.NoError(t, err)
+		responseRecorder := httptest.NewRecorder()
+		api.router.ServeHTTP(responseRecorder, request)
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resp models.ERC721TransferResponse
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+		return resp
+	}
+
+	address, collection := common.HexToAddress(mockAddress), common.HexToAddress("0xf2")
+	t.Run("Deposits", func(t *testing.T) {
+		resp := get(fmt.Sprintf("/api/v0/erc721/deposits/%s?token=%s", mockAddress, collection))
+		require.Equal(t, database.BridgeTransfersFilter{TokenAddress: collection}, view.erc721Filter.BridgeTransfersFilter)
+		require.Equal(t, address, view.erc721Filter.FromAddress)
+
+		require.Len(t, resp.Items, 1)
+		item := resp.Items[0]
+		require.Equal(t, erc721Deposit.ERC721BridgeTransfer.CrossDomainMessageHash.String(), item.CrossDomainMessageHash)
+		require.Equal(t, "7", item.TokenID)
+		require.Equal(t, erc721Deposit.InitiatedTransactionHash.String(), item.L1TxHash)
+		require.Equal(t, erc721Deposit.FinalizedTransactionHash.String(), item.L2TxHash)
+		require.Equal(t, collection.String(), item.L1TokenAddress)
+		require.Equal(t, common.HexToAddress("0xf3").String(), item.L2TokenAddress)
+		require.Equal(t, uint64(1000), item.Timestamp)
+		require.Equal(t, uint64(1060), item.FinalizedTimestamp)
+	})
+
+	t.Run("CollectionDeposits", func(t *testing.T) {
+		resp := get(fmt.Sprintf("/api/v0/erc721/collections/%s/deposits?limit=10", collection))
+		require.Equal(t, 10, view.limit)
+		require.Equal(t, database.ERC721BridgeTransfersFilter{BridgeTransfersFilter: database.BridgeTransfersFilter{TokenAddress: collection}}, view.erc721Filter)
+		require.Len(t, resp.Items, 1)
+	})
+
+	t.Run("Withdrawals", func(t *testing.T) {
+		resp := get("/api/v0/erc721/withdrawals/" + mockAddress)
+		require.Equal(t, database.ERC721BridgeTransfersFilter{FromAddress: address}, view.erc721Filter)
+		require.Empty(t, resp.Items)
+	})
+
+	t.Run("CollectionWithdrawals", func(t *testing.T) {
+		resp := get(fmt.Sprintf("/api/v0/erc721/collections/%s/withdrawals?fromTimestamp=500", collection))
+		require.Equal(t, database.BridgeTransfersFilter{TokenAddress: collection, FromTimestamp: 500}, view.erc721Filter.BridgeTransfersFilter)
+		require.Equal(t, common.Address{}, view.erc721Filter.FromAddress)
+		require.Empty(t, resp.Items)
+	})
+}
+
+func TestBridgeTransfersQueryParams(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	view := &MockBridgeTransfersView{}
+	cfg := &Config{
+		DB:            &TestDBConnector{BridgeTransfers: view},
+		HTTPServer:    apiConfig,
+		MetricsServer: metricsConfig,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+
+	token := common.HexToAddress("0x4200000000000000000000000000000000000010")
+	cursor := database.BridgeTransfersCursor{BlockNumber: 12, LogIndex: 2}.String()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		request, err := http.NewRequest("GET", "http://"+api.Addr()+path, nil)
+		require.NoError(t, err)
+		responseRecorder := httptest.NewRecorder()
+		api.router.ServeHTTP(responseRecorder, request)
+		return responseRecorder
+	}
+
+	t.Run("DefaultFirstPage", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, get("/api/v0/deposits/"+mockAddress).Code)
+		require.Equal(t, "", view.cursor)
+		require.Equal(t, 100, view.limit)
+		require.Equal(t, database.BridgeTransfersFilter{}, view.depositsFilter)
+
+		require.Equal(t, http.StatusOK, get("/api/v0/withdrawals/"+mockAddress).Code)
+		require.Equal(t, database.AnyWithdrawalStatus, view.withdrawalsFilter.Status)
+		require.Equal(t, database.BridgeTransfersFilter{}, view.withdrawalsFilter.BridgeTransfersFilter)
+	})
+
+	t.Run("DepositFilters", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v0/deposits/%s?cursor=%s&limit=10&token=%s&fromTimestamp=100&toTimestamp=200", mockAddress, cursor, token)
+		require.Equal(t, http.StatusOK, get(path).Code)
+		require.Equal(t, cursor, view.cursor)
+		require.Equal(t, 10, view.limit)
+		require.Equal(t, database.BridgeTransfersFilter{TokenAddress: token, FromTimestamp: 100, ToTimestamp: 200}, view.depositsFilter)
+	})
+
+	t.Run("WithdrawalFilters", func(t *testing.T) {
+		path := fmt.Sprintf("/api/v0/withdrawals/%s?cursor=%s&token=%s&fromTimestamp=100&status=ready", mockAddress, cursor, token)
+		require.Equal(t, http.StatusOK, get(path).Code)
+		require.Equal(t, cursor, view.cursor)
+		require.Equal(t, database.BridgeTransfersFilter{TokenAddress: token, FromTimestamp: 100}, view.withdrawalsFilter.BridgeTransfersFilter)
+		require.Equal(t, database.ReadyWithdrawalStatus, view.withdrawalsFilter.Status)
+		require.NotZero(t, view.withdrawalsFilter.ReadyProofTimestamp)
+	})
+
+	t.Run("InvalidParams", func(t *testing.T) {
+		for _, query := range []string{
+			"cursor=0x123",
+			"limit=0",
+			"limit=1001",
+			"token=0x42",
+			"fromTimestamp=yesterday",
+			"fromTimestamp=200&toTimestamp=100",
+			"status=pending",
+		} {
+			require.Equal(t, http.StatusBadRequest, get("/api/v0/withdrawals/"+mockAddress+"?"+query).Code, query)
+		}
+		require.Equal(t, http.StatusBadRequest, get("/api/v0/deposits/"+mockAddress+"?limit=1001").Code)
+	})
+}
@@ -57,4 +57,12 @@ type Config struct {
 	DB            DBConnector
 	HTTPServer    config.ServerConfig
 	MetricsServer config.ServerConfig
+
+	// FinalizationPeriodSeconds determines when proven withdrawals are reported as ready to finalize
+	FinalizationPeriodSeconds uint64
+
+	// UnmatchedDepositThresholdSeconds and UnmatchedWithdrawalThresholdSeconds determine when cross domain
+	// transfers that are not finalized are reported as unmatched
+	UnmatchedDepositThresholdSeconds    uint64
+	UnmatchedWithdrawalThresholdSeconds uint64
 }
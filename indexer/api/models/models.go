@@ -10,6 +10,14 @@ type QueryParams struct {
 	Cursor  string
 }
 
+// FilterParams ... Optional constraints on the listed bridge transfers. Zero values do not constrain the transfers.
+type FilterParams struct {
+	Token         common.Address
+	FromTimestamp uint64
+	ToTimestamp   uint64
+	Status        WithdrawalStatus
+}
+
 // DepositItem ... Deposit item model for API responses
 type DepositItem struct {
 	Guid           string `json:"guid"`
@@ -31,6 +39,20 @@ type DepositResponse struct {
 	Items       []DepositItem `json:"items"`
 }
 
+// WithdrawalStatus ... Stage of a withdrawal in the multi-step withdrawal process
+type WithdrawalStatus string
+
+const (
+	// WithdrawalStatusInitiated ... Withdrawal was initiated on L2, but is not proven on L1 yet
+	WithdrawalStatusInitiated WithdrawalStatus = "initiated"
+	// WithdrawalStatusProven ... Withdrawal was proven on L1, and waits for the finalization period to pass
+	WithdrawalStatusProven WithdrawalStatus = "proven"
+	// WithdrawalStatusReady ... Withdrawal was proven on L1 and the finalization period has passed
+	WithdrawalStatusReady WithdrawalStatus = "ready"
+	// WithdrawalStatusFinalized ... Withdrawal was finalized on L1
+	WithdrawalStatusFinalized WithdrawalStatus = "finalized"
+)
+
 // WithdrawalItem ... Data model for API JSON response
 type WithdrawalItem struct {
 	Guid                   string `json:"guid"`
@@ -45,6 +67,12 @@ type WithdrawalItem struct {
 	L1FinalizedTxHash      string `json:"l1FinalizedTxHash"`
 	L1TokenAddress         string `json:"l1TokenAddress"`
 	L2TokenAddress         string `json:"l2TokenAddress"`
+
+	Status WithdrawalStatus `json:"status"`
+	// L1ProvenTimestamp is zero until the withdrawal is proven
+	L1ProvenTimestamp uint64 `json:"l1ProvenTimestamp"`
+	// L1FinalizableTimestamp is the time the withdrawal can be finalized at, zero until the withdrawal is proven
+	L1FinalizableTimestamp uint64 `json:"l1FinalizableTimestamp"`
 }
 
 // WithdrawalResponse ... Data model for API JSON response
@@ -54,6 +82,58 @@ type WithdrawalResponse struct {
 	Items       []WithdrawalItem `json:"items"`
 }
 
+// CrossDomainTransferItem ... Data model for API JSON response. The transfer is initiated on L1 and finalized on L2
+// for deposits, and the other way around for withdrawals.
+type CrossDomainTransferItem struct {
+	CrossDomainMessageHash string `json:"crossDomainMessageHash"`
+	From                   string `json:"from"`
+	To                     string `json:"to"`
+	Amount                 string `json:"amount"`
+	L1TokenAddress         string `json:"l1TokenAddress"`
+	L2TokenAddress         string `json:"l2TokenAddress"`
+	L1TxHash               string `json:"l1TxHash"`
+	L2TxHash               string `json:"l2TxHash"`
+	// Timestamp is the time the transfer was initiated at
+	Timestamp uint64 `json:"timestamp"`
+	// FinalizedTimestamp is zero until the transfer is finalized
+	FinalizedTimestamp uint64 `json:"finalizedTimestamp"`
+	// Latency is the seconds from the initiation to the finalization of the transfer, zero until the transfer is finalized
+	Latency uint64 `json:"latency"`
+	// Unmatched is set when the transfer is not finalized within the unmatched threshold of its initiation
+	Unmatched bool `json:"unmatched"`
+}
+
+// CrossDomainTransferResponse ... Data model for API JSON response
+type CrossDomainTransferResponse struct {
+	Cursor      string                    `json:"cursor"`
+	HasNextPage bool                      `json:"hasNextPage"`
+	Items       []CrossDomainTransferItem `json:"items"`
+}
+
+// ERC721TransferItem ... Data model for API JSON response. The token is bridged from L1 to L2 for deposits, and the
+// other way around for withdrawals.
+type ERC721TransferItem struct {
+	CrossDomainMessageHash string `json:"crossDomainMessageHash"`
+	From                   string `json:"from"`
+	To                     string `json:"to"`
+	TokenID                string `json:"tokenId"`
+	L1TokenAddress         string `json:"l1TokenAddress"`
+	L2TokenAddress         string `json:"l2TokenAddress"`
+	L1TxHash               string `json:"l1TxHash"`
+	L2TxHash               string `json:"l2TxHash"`
+	// Timestamp is the time the transfer was initiated at
+	Timestamp uint64 `json:"timestamp"`
+	// FinalizedTimestamp is zero until the transfer is finalized
+	FinalizedTimestamp uint64 `json:"finalizedTimestamp"`
+}
+
+// ERC721TransferResponse ... Data model for API JSON response
+type ERC721TransferResponse struct {
+	Cursor      string               `json:"cursor"`
+	HasNextPage bool                 `json:"hasNextPage"`
+	Items       []ERC721TransferItem `json:"items"`
+}
+
 type BridgeSupplyView struct {
 	L1DepositSum         float64 `json:"l1DepositSum"`
 	InitWithdrawalSum    float64 `json:"l2WithdrawalSum"`
@@ -11,6 +11,9 @@ func (h Routes) L1DepositsHandler(w http.ResponseWriter, r *http.Request) {
 	address := chi.URLParam(r, "address")
 	cursor := r.URL.Query().Get("cursor")
 	limit := r.URL.Query().Get("limit")
+	token := r.URL.Query().Get("token")
+	fromTimestamp := r.URL.Query().Get("fromTimestamp")
+	toTimestamp := r.URL.Query().Get("toTimestamp")
 
 	params, err := h.svc.QueryParams(address, cursor, limit)
 	if err != nil {
@@ -19,7 +22,15 @@ func (h Routes) L1DepositsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	deposits, err := h.svc.GetDeposits(params)
+	// The status of a deposit is not tracked, so the status filter does not apply
+	filter, err := h.svc.FilterParams(token, fromTimestamp, toTimestamp, "")
+	if err != nil {
+		http.Error(w, "invalid filter params", http.StatusBadRequest)
+		h.logger.Error("error reading filter params", "err", err.Error())
+		return
+	}
+
+	deposits, err := h.svc.GetDeposits(params, filter)
 	if err != nil {
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 		h.logger.Error("error fetching deposits", "err", err.Error())
@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/ethereum-optimism/optimism/indexer/api/models"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/go-chi/chi/v5"
+)
+
+// ERC721DepositsHandler ... Handles /api/v0/erc721/deposits/{address} GET requests
+func (h Routes) ERC721DepositsHandler(w http.ResponseWriter, r *http.Request) {
+	h.erc721TransfersHandler(w, r, "deposits", true, h.svc.GetERC721Deposits, h.svc.ERC721DepositResponse)
+}
+
+// ERC721WithdrawalsHandler ... Handles /api/v0/erc721/withdrawals/{address} GET requests
+func (h Routes) ERC721WithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
+	h.erc721TransfersHandler(w, r, "withdrawals", true, h.svc.GetERC721Withdrawals, h.svc.ERC721WithdrawalResponse)
+}
+
+// ERC721CollectionDepositsHandler ... Handles /api/v0/erc721/collections/{address}/deposits GET requests
+func (h Routes) ERC721CollectionDepositsHandler(w http.ResponseWriter, r *http.Request) {
+	h.erc721TransfersHandler(w, r, "deposits", false, h.svc.GetERC721CollectionDeposits, h.svc.ERC721DepositResponse)
+}
+
+// ERC721CollectionWithdrawalsHandler ... Handles /api/v0/erc721/collections/{address}/withdrawals GET requests
+func (h Routes) ERC721CollectionWithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
+	h.erc721TransfersHandler(w, r, "withdrawals", false, h.svc.GetERC721CollectionWithdrawals, h.svc.ERC721WithdrawalResponse)
+}
+
+// erc721TransfersHandler ... Lists the ERC721 transfers of the address, a sender or a collection. The collection of the
+// transfers of a sender can be filtered with the token query param.
+func (h Routes) erc721TransfersHandler(w http.ResponseWriter, r *http.Request, kind string, tokenFilter bool,
+	getTransfers func(*models.QueryParams, *models.FilterParams) (*database.ERC721BridgeTransfersResponse, error),
+	transfersResponse func(*database.ERC721BridgeTransfersResponse) models.ERC721TransferResponse) {
+	address := chi.URLParam(r, "address")
+	cursor := r.URL.Query().Get("cursor")
+	limit := r.URL.Query().Get("limit")
+	fromTimestamp := r.URL.Query().Get("fromTimestamp")
+	toTimestamp := r.URL.Query().Get("toTimestamp")
+
+	token := ""
+	if tokenFilter {
+		token = r.URL.Query().Get("token")
+	}
+
+	params, err := h.svc.QueryParams(address, cursor, limit)
+	if err != nil {
+		http.Error(w, "invalid query params", http.StatusBadRequest)
+		h.logger.Error("error reading request params", "err", err.Error())
+		return
+	}
+
+	filter, err := h.svc.FilterParams(token, fromTimestamp, toTimestamp, "")
+	if err != nil {
+		http.Error(w, "invalid filter params", http.StatusBadRequest)
+		h.logger.Error("error reading filter params", "err", err.Error())
+		return
+	}
+
+	transfers, err := getTransfers(params, filter)
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		h.logger.Error("error fetching erc721 "+kind, "err", err.Error())
+		return
+	}
+
+	resp := transfersResponse(transfers)
+	err = jsonResponse(w, resp, http.StatusOK)
+	if err != nil {
+		h.logger.Error("error writing response", "err", err)
+	}
+}
@@ -0,0 +1,83 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CrossDomainDepositsHandler ... Handles /api/v0/transfers/deposits/{address} GET requests
+func (h Routes) CrossDomainDepositsHandler(w http.ResponseWriter, r *http.Request) {
+	address := chi.URLParam(r, "address")
+	cursor := r.URL.Query().Get("cursor")
+	limit := r.URL.Query().Get("limit")
+	token := r.URL.Query().Get("token")
+	fromTimestamp := r.URL.Query().Get("fromTimestamp")
+	toTimestamp := r.URL.Query().Get("toTimestamp")
+
+	params, err := h.svc.QueryParams(address, cursor, limit)
+	if err != nil {
+		http.Error(w, "invalid query params", http.StatusBadRequest)
+		h.logger.Error("error reading request params", "err", err.Error())
+		return
+	}
+
+	// The status filter only applies to the multi-step withdrawal process
+	filter, err := h.svc.FilterParams(token, fromTimestamp, toTimestamp, "")
+	if err != nil {
+		http.Error(w, "invalid filter params", http.StatusBadRequest)
+		h.logger.Error("error reading filter params", "err", err.Error())
+		return
+	}
+
+	transfers, err := h.svc.GetCrossDomainDeposits(params, filter)
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		h.logger.Error("error fetching cross domain deposits", "err", err.Error())
+		return
+	}
+
+	resp := h.svc.CrossDomainDepositResponse(transfers)
+	err = jsonResponse(w, resp, http.StatusOK)
+	if err != nil {
+		h.logger.Error("error writing response", "err", err)
+	}
+}
+
+// CrossDomainWithdrawalsHandler ... Handles /api/v0/transfers/withdrawals/{address} GET requests
+func (h Routes) CrossDomainWithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
+	address := chi.URLParam(r, "address")
+	cursor := r.URL.Query().Get("cursor")
+	limit := r.URL.Query().Get("limit")
+	token := r.URL.Query().Get("token")
+	fromTimestamp := r.URL.Query().Get("fromTimestamp")
+	toTimestamp := r.URL.Query().Get("toTimestamp")
+
+	params, err := h.svc.QueryParams(address, cursor, limit)
+	if err != nil {
+		http.Error(w, "invalid query params", http.StatusBadRequest)
+		h.logger.Error("error reading request params", "err", err.Error())
+		return
+	}
+
+	// Matching only tracks the finalization of withdrawals, so the status filter does not apply
+	filter, err := h.svc.FilterParams(token, fromTimestamp, toTimestamp, "")
+	if err != nil {
+		http.Error(w, "invalid filter params", http.StatusBadRequest)
+		h.logger.Error("error reading filter params", "err", err.Error())
+		return
+	}
+
+	transfers, err := h.svc.GetCrossDomainWithdrawals(params, filter)
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		h.logger.Error("error fetching cross domain withdrawals", "err", err.Error())
+		return
+	}
+
+	resp := h.svc.CrossDomainWithdrawalResponse(transfers)
+	err = jsonResponse(w, resp, http.StatusOK)
+	if err != nil {
+		h.logger.Error("error writing response", "err", err)
+	}
+}
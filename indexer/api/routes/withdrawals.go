@@ -11,6 +11,10 @@ func (h Routes) L2WithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
 	address := chi.URLParam(r, "address")
 	cursor := r.URL.Query().Get("cursor")
 	limit := r.URL.Query().Get("limit")
+	token := r.URL.Query().Get("token")
+	fromTimestamp := r.URL.Query().Get("fromTimestamp")
+	toTimestamp := r.URL.Query().Get("toTimestamp")
+	status := r.URL.Query().Get("status")
 
 	params, err := h.svc.QueryParams(address, cursor, limit)
 	if err != nil {
@@ -19,7 +23,14 @@ func (h Routes) L2WithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	withdrawals, err := h.svc.GetWithdrawals(params)
+	filter, err := h.svc.FilterParams(token, fromTimestamp, toTimestamp, status)
+	if err != nil {
+		http.Error(w, "Invalid filter params", http.StatusBadRequest)
+		h.logger.Error("Invalid filter params", "err", err.Error())
+		return
+	}
+
+	withdrawals, err := h.svc.GetWithdrawals(params, filter)
 	if err != nil {
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		h.logger.Error("Error getting withdrawals", "err", err.Error())
@@ -1,6 +1,9 @@
 package service
 
 import (
+	"errors"
+	"time"
+
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/indexer/api/models"
@@ -9,26 +12,48 @@ import (
 )
 
 type Service interface {
-	GetDeposits(*models.QueryParams) (*database.L1BridgeDepositsResponse, error)
+	GetDeposits(*models.QueryParams, *models.FilterParams) (*database.L1BridgeDepositsResponse, error)
 	DepositResponse(*database.L1BridgeDepositsResponse) models.DepositResponse
-	GetWithdrawals(params *models.QueryParams) (*database.L2BridgeWithdrawalsResponse, error)
+	GetWithdrawals(params *models.QueryParams, filter *models.FilterParams) (*database.L2BridgeWithdrawalsResponse, error)
 	WithdrawResponse(*database.L2BridgeWithdrawalsResponse) models.WithdrawalResponse
+	GetCrossDomainDeposits(*models.QueryParams, *models.FilterParams) (*database.CrossDomainTransfersResponse, error)
+	CrossDomainDepositResponse(*database.CrossDomainTransfersResponse) models.CrossDomainTransferResponse
+	GetCrossDomainWithdrawals(*models.QueryParams, *models.FilterParams) (*database.CrossDomainTransfersResponse, error)
+	CrossDomainWithdrawalResponse(*database.CrossDomainTransfersResponse) models.CrossDomainTransferResponse
+	GetERC721Deposits(*models.QueryParams, *models.FilterParams) (*database.ERC721BridgeTransfersResponse, error)
+	GetERC721CollectionDeposits(*models.QueryParams, *models.FilterParams) (*database.ERC721BridgeTransfersResponse, error)
+	ERC721DepositResponse(*database.ERC721BridgeTransfersResponse) models.ERC721TransferResponse
+	GetERC721Withdrawals(*models.QueryParams, *models.FilterParams) (*database.ERC721BridgeTransfersResponse, error)
+	GetERC721CollectionWithdrawals(*models.QueryParams, *models.FilterParams) (*database.ERC721BridgeTransfersResponse, error)
+	ERC721WithdrawalResponse(*database.ERC721BridgeTransfersResponse) models.ERC721TransferResponse
 	GetSupplyInfo() (*models.BridgeSupplyView, error)
 
 	QueryParams(address, cursor, limit string) (*models.QueryParams, error)
+	FilterParams(token, fromTimestamp, toTimestamp, status string) (*models.FilterParams, error)
 }
 
 type HandlerSvc struct {
 	v      *Validator
 	db     database.BridgeTransfersView
 	logger log.Logger
+
+	// finalizationPeriod ... Seconds a proven withdrawal waits before it can be finalized
+	finalizationPeriod uint64
+
+	// unmatchedDepositThreshold & unmatchedWithdrawalThreshold ... Seconds after which a cross domain
+	// transfer that is not finalized is flagged as unmatched
+	unmatchedDepositThreshold    uint64
+	unmatchedWithdrawalThreshold uint64
 }
 
-func New(v *Validator, db database.BridgeTransfersView, l log.Logger) Service {
+func New(v *Validator, db database.BridgeTransfersView, l log.Logger, finalizationPeriod, unmatchedDepositThreshold, unmatchedWithdrawalThreshold uint64) Service {
 	return &HandlerSvc{
-		logger: l,
-		v:      v,
-		db:     db,
+		logger:                       l,
+		v:                            v,
+		db:                           db,
+		finalizationPeriod:           finalizationPeriod,
+		unmatchedDepositThreshold:    unmatchedDepositThreshold,
+		unmatchedWithdrawalThreshold: unmatchedWithdrawalThreshold,
 	}
 }
 
@@ -59,8 +84,57 @@ func (svc *HandlerSvc) QueryParams(a, c, l string) (*models.QueryParams, error)
 
 }
 
-func (svc *HandlerSvc) GetWithdrawals(params *models.QueryParams) (*database.L2BridgeWithdrawalsResponse, error) {
-	withdrawals, err := svc.db.L2BridgeWithdrawalsByAddress(params.Address, params.Cursor, params.Limit)
+// FilterParams ... Validates and parses the optional filter query parameters
+func (svc *HandlerSvc) FilterParams(token, fromTimestamp, toTimestamp, status string) (*models.FilterParams, error) {
+	tokenAddress, err := svc.v.ParseValidateToken(token)
+	if err != nil {
+		svc.logger.Error("invalid token param", "token", token, "err", err)
+		return nil, err
+	}
+
+	from, err := svc.v.ParseValidateTimestamp(fromTimestamp)
+	if err != nil {
+		svc.logger.Error("invalid fromTimestamp param", "fromTimestamp", fromTimestamp, "err", err)
+		return nil, err
+	}
+
+	to, err := svc.v.ParseValidateTimestamp(toTimestamp)
+	if err != nil {
+		svc.logger.Error("invalid toTimestamp param", "toTimestamp", toTimestamp, "err", err)
+		return nil, err
+	}
+
+	if to != 0 && from > to {
+		err := errors.New("fromTimestamp must not be after toTimestamp")
+		svc.logger.Error("invalid timestamp params", "fromTimestamp", fromTimestamp, "toTimestamp", toTimestamp, "err", err)
+		return nil, err
+	}
+
+	withdrawalStatus, err := svc.v.ParseValidateWithdrawalStatus(status)
+	if err != nil {
+		svc.logger.Error("invalid status param", "status", status, "err", err)
+		return nil, err
+	}
+
+	return &models.FilterParams{
+		Token:         tokenAddress,
+		FromTimestamp: from,
+		ToTimestamp:   to,
+		Status:        withdrawalStatus,
+	}, nil
+}
+
+func (svc *HandlerSvc) GetWithdrawals(params *models.QueryParams, filter *models.FilterParams) (*database.L2BridgeWithdrawalsResponse, error) {
+	withdrawalsFilter := database.L2BridgeWithdrawalsFilter{
+		BridgeTransfersFilter: bridgeTransfersFilter(filter),
+		Status:                withdrawalStatuses[filter.Status],
+	}
+	// Proven withdrawals are ready to be finalized once the finalization period has passed since the proof
+	if now := uint64(time.Now().Unix()); now > svc.finalizationPeriod {
+		withdrawalsFilter.ReadyProofTimestamp = now - svc.finalizationPeriod
+	}
+
+	withdrawals, err := svc.db.L2BridgeWithdrawalsByAddress(params.Address, params.Cursor, params.Limit, withdrawalsFilter)
 	if err != nil {
 		svc.logger.Error("error getting withdrawals", "err", err.Error(), "address", params.Address.String())
 		return nil, err
@@ -71,6 +145,7 @@ func (svc *HandlerSvc) GetWithdrawals(params *models.QueryParams) (*database.L2B
 }
 
 func (svc *HandlerSvc) WithdrawResponse(withdrawals *database.L2BridgeWithdrawalsResponse) models.WithdrawalResponse {
+	now := uint64(time.Now().Unix())
 	items := make([]models.WithdrawalItem, len(withdrawals.Withdrawals))
 	for i, withdrawal := range withdrawals.Withdrawals {
 
@@ -92,6 +167,19 @@ func (svc *HandlerSvc) WithdrawResponse(withdrawals *database.L2BridgeWithdrawal
 			L1FinalizedTxHash:      withdrawal.FinalizedL1TransactionHash.String(),
 			L1TokenAddress:         withdrawal.L2BridgeWithdrawal.TokenPair.RemoteTokenAddress.String(),
 			L2TokenAddress:         withdrawal.L2BridgeWithdrawal.TokenPair.LocalTokenAddress.String(),
+			Status:                 models.WithdrawalStatusInitiated,
+		}
+
+		if withdrawal.ProvenL1TransactionHash != (common.Hash{}) {
+			item.L1ProvenTimestamp = withdrawal.ProvenL1Timestamp
+			item.L1FinalizableTimestamp = withdrawal.ProvenL1Timestamp + svc.finalizationPeriod
+			item.Status = models.WithdrawalStatusProven
+			if now >= item.L1FinalizableTimestamp {
+				item.Status = models.WithdrawalStatusReady
+			}
+		}
+		if withdrawal.FinalizedL1TransactionHash != (common.Hash{}) {
+			item.Status = models.WithdrawalStatusFinalized
 		}
 		items[i] = item
 	}
@@ -103,8 +191,8 @@ func (svc *HandlerSvc) WithdrawResponse(withdrawals *database.L2BridgeWithdrawal
 	}
 }
 
-func (svc *HandlerSvc) GetDeposits(params *models.QueryParams) (*database.L1BridgeDepositsResponse, error) {
-	deposits, err := svc.db.L1BridgeDepositsByAddress(params.Address, params.Cursor, params.Limit)
+func (svc *HandlerSvc) GetDeposits(params *models.QueryParams, filter *models.FilterParams) (*database.L1BridgeDepositsResponse, error) {
+	deposits, err := svc.db.L1BridgeDepositsByAddress(params.Address, params.Cursor, params.Limit, bridgeTransfersFilter(filter))
 	if err != nil {
 		svc.logger.Error("error getting deposits", "err", err.Error(), "address", params.Address.String())
 		return nil, err
@@ -140,6 +228,187 @@ func (svc *HandlerSvc) DepositResponse(deposits *database.L1BridgeDepositsRespon
 	}
 }
 
+func (svc *HandlerSvc) GetCrossDomainDeposits(params *models.QueryParams, filter *models.FilterParams) (*database.CrossDomainTransfersResponse, error) {
+	transfers, err := svc.db.CrossDomainDepositsByAddress(params.Address, params.Cursor, params.Limit, bridgeTransfersFilter(filter))
+	if err != nil {
+		svc.logger.Error("error getting cross domain deposits", "err", err.Error(), "address", params.Address.String())
+		return nil, err
+	}
+
+	svc.logger.Debug("read cross domain deposits from db", "count", len(transfers.Transfers), "address", params.Address.String())
+	return transfers, nil
+}
+
+// CrossDomainDepositResponse ... Converts the cross domain transfers deposited from L1 to an api.CrossDomainTransferResponse
+func (svc *HandlerSvc) CrossDomainDepositResponse(transfers *database.CrossDomainTransfersResponse) models.CrossDomainTransferResponse {
+	return svc.crossDomainTransferResponse(transfers, true)
+}
+
+func (svc *HandlerSvc) GetCrossDomainWithdrawals(params *models.QueryParams, filter *models.FilterParams) (*database.CrossDomainTransfersResponse, error) {
+	transfers, err := svc.db.CrossDomainWithdrawalsByAddress(params.Address, params.Cursor, params.Limit, bridgeTransfersFilter(filter))
+	if err != nil {
+		svc.logger.Error("error getting cross domain withdrawals", "err", err.Error(), "address", params.Address.String())
+		return nil, err
+	}
+
+	svc.logger.Debug("read cross domain withdrawals from db", "count", len(transfers.Transfers), "address", params.Address.String())
+	return transfers, nil
+}
+
+// CrossDomainWithdrawalResponse ... Converts the cross domain transfers withdrawn from L2 to an api.CrossDomainTransferResponse
+func (svc *HandlerSvc) CrossDomainWithdrawalResponse(transfers *database.CrossDomainTransfersResponse) models.CrossDomainTransferResponse {
+	return svc.crossDomainTransferResponse(transfers, false)
+}
+
+func (svc *HandlerSvc) crossDomainTransferResponse(transfers *database.CrossDomainTransfersResponse, isDeposit bool) models.CrossDomainTransferResponse {
+	unmatchedThreshold := svc.unmatchedWithdrawalThreshold
+	if isDeposit {
+		unmatchedThreshold = svc.unmatchedDepositThreshold
+	}
+
+	now := uint64(time.Now().Unix())
+	items := make([]models.CrossDomainTransferItem, len(transfers.Transfers))
+	for i, transfer := range transfers.Transfers {
+		item := models.CrossDomainTransferItem{
+			CrossDomainMessageHash: transfer.CrossDomainTransfer.CrossDomainMessageHash.String(),
+			From:                   transfer.CrossDomainTransfer.Tx.FromAddress.String(),
+			To:                     transfer.CrossDomainTransfer.Tx.ToAddress.String(),
+			Amount:                 transfer.CrossDomainTransfer.Tx.Amount.String(),
+			Timestamp:              transfer.CrossDomainTransfer.Tx.Timestamp,
+		}
+
+		// The local token is the token on the initiating layer
+		if isDeposit {
+			item.L1TxHash, item.L2TxHash = transfer.InitiatedTransactionHash.String(), transfer.FinalizedTransactionHash.String()
+			item.L1TokenAddress = transfer.CrossDomainTransfer.TokenPair.LocalTokenAddress.String()
+			item.L2TokenAddress = transfer.CrossDomainTransfer.TokenPair.RemoteTokenAddress.String()
+		} else {
+			item.L1TxHash, item.L2TxHash = transfer.FinalizedTransactionHash.String(), transfer.InitiatedTransactionHash.String()
+			item.L1TokenAddress = transfer.CrossDomainTransfer.TokenPair.RemoteTokenAddress.String()
+			item.L2TokenAddress = transfer.CrossDomainTransfer.TokenPair.LocalTokenAddress.String()
+		}
+
+		if transfer.FinalizedTransactionHash != (common.Hash{}) {
+			item.FinalizedTimestamp = transfer.FinalizedTimestamp
+			if item.FinalizedTimestamp > item.Timestamp {
+				item.Latency = item.FinalizedTimestamp - item.Timestamp
+			}
+		} else if now > item.Timestamp+unmatchedThreshold {
+			item.Unmatched = true
+		}
+		items[i] = item
+	}
+
+	return models.CrossDomainTransferResponse{
+		Cursor:      transfers.Cursor,
+		HasNextPage: transfers.HasNextPage,
+		Items:       items,
+	}
+}
+
+// GetERC721Deposits ... Fetch the ERC721 tokens deposited by the address, optionally of the filtered collection
+func (svc *HandlerSvc) GetERC721Deposits(params *models.QueryParams, filter *models.FilterParams) (*database.ERC721BridgeTransfersResponse, error) {
+	erc721Filter := database.ERC721BridgeTransfersFilter{BridgeTransfersFilter: bridgeTransfersFilter(filter), FromAddress: params.Address}
+	return svc.getERC721Transfers(params, erc721Filter, true)
+}
+
+// GetERC721CollectionDeposits ... Fetch the ERC721 tokens of the collection at the address deposited by any sender
+func (svc *HandlerSvc) GetERC721CollectionDeposits(params *models.QueryParams, filter *models.FilterParams) (*database.ERC721BridgeTransfersResponse, error) {
+	erc721Filter := database.ERC721BridgeTransfersFilter{BridgeTransfersFilter: bridgeTransfersFilter(filter)}
+	erc721Filter.TokenAddress = params.Address
+	return svc.getERC721Transfers(params, erc721Filter, true)
+}
+
+// ERC721DepositResponse ... Converts the ERC721 tokens deposited from L1 to an api.ERC721TransferResponse
+func (svc *HandlerSvc) ERC721DepositResponse(transfers *database.ERC721BridgeTransfersResponse) models.ERC721TransferResponse {
+	return erc721TransferResponse(transfers, true)
+}
+
+// GetERC721Withdrawals ... Fetch the ERC721 tokens withdrawn by the address, optionally of the filtered collection
+func (svc *HandlerSvc) GetERC721Withdrawals(params *models.QueryParams, filter *models.FilterParams) (*database.ERC721BridgeTransfersResponse, error) {
+	erc721Filter := database.ERC721BridgeTransfersFilter{BridgeTransfersFilter: bridgeTransfersFilter(filter), FromAddress: params.Address}
+	return svc.getERC721Transfers(params, erc721Filter, false)
+}
+
+// GetERC721CollectionWithdrawals ... Fetch the ERC721 tokens of the collection at the address withdrawn by any sender
+func (svc *HandlerSvc) GetERC721CollectionWithdrawals(params *models.QueryParams, filter *models.FilterParams) (*database.ERC721BridgeTransfersResponse, error) {
+	erc721Filter := database.ERC721BridgeTransfersFilter{BridgeTransfersFilter: bridgeTransfersFilter(filter)}
+	erc721Filter.TokenAddress = params.Address
+	return svc.getERC721Transfers(params, erc721Filter, false)
+}
+
+// ERC721WithdrawalResponse ... Converts the ERC721 tokens withdrawn from L2 to an api.ERC721TransferResponse
+func (svc *HandlerSvc) ERC721WithdrawalResponse(transfers *database.ERC721BridgeTransfersResponse) models.ERC721TransferResponse {
+	return erc721TransferResponse(transfers, false)
+}
+
+func (svc *HandlerSvc) getERC721Transfers(params *models.QueryParams, filter database.ERC721BridgeTransfersFilter, isDeposit bool) (*database.ERC721BridgeTransfersResponse, error) {
+	kind, getTransfers := "withdrawals", svc.db.ERC721BridgeWithdrawals
+	if isDeposit {
+		kind, getTransfers = "deposits", svc.db.ERC721BridgeDeposits
+	}
+
+	transfers, err := getTransfers(params.Cursor, params.Limit, filter)
+	if err != nil {
+		svc.logger.Error("error getting erc721 "+kind, "err", err.Error(), "address", params.Address.String())
+		return nil, err
+	}
+
+	svc.logger.Debug("read erc721 "+kind+" from db", "count", len(transfers.Transfers), "address", params.Address.String())
+	return transfers, nil
+}
+
+func erc721TransferResponse(transfers *database.ERC721BridgeTransfersResponse, isDeposit bool) models.ERC721TransferResponse {
+	items := make([]models.ERC721TransferItem, len(transfers.Transfers))
+	for i, transfer := range transfers.Transfers {
+		item := models.ERC721TransferItem{
+			CrossDomainMessageHash: transfer.ERC721BridgeTransfer.CrossDomainMessageHash.String(),
+			From:                   transfer.ERC721BridgeTransfer.FromAddress.String(),
+			To:                     transfer.ERC721BridgeTransfer.ToAddress.String(),
+			TokenID:                transfer.ERC721BridgeTransfer.TokenID.String(),
+			Timestamp:              transfer.ERC721BridgeTransfer.Timestamp,
+		}
+
+		// The local collection is the collection on the initiating layer
+		if isDeposit {
+			item.L1TxHash, item.L2TxHash = transfer.InitiatedTransactionHash.String(), transfer.FinalizedTransactionHash.String()
+			item.L1TokenAddress = transfer.ERC721BridgeTransfer.TokenPair.LocalTokenAddress.String()
+			item.L2TokenAddress = transfer.ERC721BridgeTransfer.TokenPair.RemoteTokenAddress.String()
+		} else {
+			item.L1TxHash, item.L2TxHash = transfer.FinalizedTransactionHash.String(), transfer.InitiatedTransactionHash.String()
+			item.L1TokenAddress = transfer.ERC721BridgeTransfer.TokenPair.RemoteTokenAddress.String()
+			item.L2TokenAddress = transfer.ERC721BridgeTransfer.TokenPair.LocalTokenAddress.String()
+		}
+
+		if transfer.FinalizedTransactionHash != (common.Hash{}) {
+			item.FinalizedTimestamp = transfer.FinalizedTimestamp
+		}
+		items[i] = item
+	}
+
+	return models.ERC721TransferResponse{
+		Cursor:      transfers.Cursor,
+		HasNextPage: transfers.HasNextPage,
+		Items:       items,
+	}
+}
+
+// withdrawalStatuses ... Maps the API withdrawal statuses onto the database withdrawal statuses
+var withdrawalStatuses = map[models.WithdrawalStatus]database.WithdrawalStatus{
+	models.WithdrawalStatusInitiated: database.InitiatedWithdrawalStatus,
+	models.WithdrawalStatusProven:    database.ProvenWithdrawalStatus,
+	models.WithdrawalStatusReady:     database.ReadyWithdrawalStatus,
+	models.WithdrawalStatusFinalized: database.FinalizedWithdrawalStatus,
+}
+
+func bridgeTransfersFilter(filter *models.FilterParams) database.BridgeTransfersFilter {
+	return database.BridgeTransfersFilter{
+		TokenAddress:  filter.Token,
+		FromTimestamp: filter.FromTimestamp,
+		ToTimestamp:   filter.ToTimestamp,
+	}
+}
+
 // GetSupplyInfo ... Fetch native bridge supply info
 func (svc *HandlerSvc) GetSupplyInfo() (*models.BridgeSupplyView, error) {
 	depositSum, err := svc.db.L1TxDepositSum()
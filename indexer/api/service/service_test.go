@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/indexer/api/models"
 	"github.com/ethereum-optimism/optimism/indexer/api/service"
 	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum/go-ethereum/common"
@@ -31,7 +33,7 @@ func assertFieldsAreSet(t *testing.T, item any) {
 }
 
 func TestWithdrawalResponse(t *testing.T) {
-	svc := service.New(nil, nil, nil)
+	svc := service.New(nil, nil, nil, 0, 0, 0)
 	cdh := common.HexToHash("0x2")
 
 	withdraws := &database.L2BridgeWithdrawalsResponse{
@@ -52,6 +54,8 @@ func TestWithdrawalResponse(t *testing.T) {
 						},
 					},
 				},
+				ProvenL1TransactionHash: common.HexToHash("0x8"),
+				ProvenL1Timestamp:       9,
 			},
 		},
 	}
@@ -62,9 +66,68 @@ func TestWithdrawalResponse(t *testing.T) {
 	assertFieldsAreSet(t, response.Items[0])
 }
 
+func TestWithdrawalResponseStatus(t *testing.T) {
+	svc := service.New(nil, nil, nil, 100, 0, 0)
+	now := uint64(time.Now().Unix())
+
+	tests := []struct {
+		name       string
+		withdrawal database.L2BridgeWithdrawalWithTransactionHashes
+		status     models.WithdrawalStatus
+	}{
+		{
+			name:       "Initiated",
+			withdrawal: database.L2BridgeWithdrawalWithTransactionHashes{},
+			status:     models.WithdrawalStatusInitiated,
+		},
+		{
+			name: "Proven",
+			withdrawal: database.L2BridgeWithdrawalWithTransactionHashes{
+				ProvenL1TransactionHash: common.HexToHash("0x1"),
+				ProvenL1Timestamp:       now,
+			},
+			status: models.WithdrawalStatusProven,
+		},
+		{
+			name: "Ready",
+			withdrawal: database.L2BridgeWithdrawalWithTransactionHashes{
+				ProvenL1TransactionHash: common.HexToHash("0x1"),
+				ProvenL1Timestamp:       now - 100,
+			},
+			status: models.WithdrawalStatusReady,
+		},
+		{
+			name: "Finalized",
+			withdrawal: database.L2BridgeWithdrawalWithTransactionHashes{
+				ProvenL1TransactionHash:    common.HexToHash("0x1"),
+				FinalizedL1TransactionHash: common.HexToHash("0x2"),
+				ProvenL1Timestamp:          now - 100,
+			},
+			status: models.WithdrawalStatusFinalized,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			response := svc.WithdrawResponse(&database.L2BridgeWithdrawalsResponse{
+				Withdrawals: []database.L2BridgeWithdrawalWithTransactionHashes{test.withdrawal},
+			})
+			require.Len(t, response.Items, 1)
+			item := response.Items[0]
+			require.Equal(t, test.status, item.Status)
+			require.Equal(t, test.withdrawal.ProvenL1Timestamp, item.L1ProvenTimestamp)
+			if test.status != models.WithdrawalStatusInitiated {
+				require.Equal(t, test.withdrawal.ProvenL1Timestamp+100, item.L1FinalizableTimestamp)
+			} else {
+				require.Zero(t, item.L1FinalizableTimestamp)
+			}
+		})
+	}
+}
+
 func TestDepositResponse(t *testing.T) {
 	cdh := common.HexToHash("0x2")
-	svc := service.New(nil, nil, nil)
+	svc := service.New(nil, nil, nil, 0, 0, 0)
 
 	deposits := &database.L1BridgeDepositsResponse{
 		Deposits: []database.L1BridgeDepositWithTransactionHashes{
@@ -122,7 +185,7 @@ func TestQueryParams(t *testing.T) {
 	}
 
 	v := new(service.Validator)
-	svc := service.New(v, nil, log.New())
+	svc := service.New(v, nil, log.New(), 0, 0, 0)
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -130,3 +193,49 @@ func TestQueryParams(t *testing.T) {
 		})
 	}
 }
+
+func TestCrossDomainTransferResponseUnmatched(t *testing.T) {
+	svc := service.New(nil, nil, nil, 0, 100, 200)
+	now := uint64(time.Now().Unix())
+
+	tests := []struct {
+		name      string
+		timestamp uint64
+		finalized bool
+		deposit   bool
+		unmatched bool
+	}{
+		{name: "DepositPending", timestamp: now - 50, deposit: true},
+		{name: "DepositUnmatched", timestamp: now - 150, deposit: true, unmatched: true},
+		{name: "DepositFinalized", timestamp: now - 150, deposit: true, finalized: true},
+		{name: "WithdrawalPending", timestamp: now - 150},
+		{name: "WithdrawalUnmatched", timestamp: now - 250, unmatched: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			transfer := database.CrossDomainTransferWithTransactionHashes{
+				CrossDomainTransfer: database.CrossDomainTransfer{Tx: database.Transaction{Timestamp: test.timestamp}},
+			}
+			if test.finalized {
+				transfer.FinalizedTransactionHash = common.HexToHash("0x1")
+				transfer.FinalizedTimestamp = test.timestamp + 10
+			}
+
+			transfers := &database.CrossDomainTransfersResponse{Transfers: []database.CrossDomainTransferWithTransactionHashes{transfer}}
+			response := svc.CrossDomainWithdrawalResponse(transfers)
+			if test.deposit {
+				response = svc.CrossDomainDepositResponse(transfers)
+			}
+
+			require.Len(t, response.Items, 1)
+			item := response.Items[0]
+			require.Equal(t, test.unmatched, item.Unmatched)
+			if test.finalized {
+				require.Equal(t, uint64(10), item.Latency)
+			} else {
+				require.Zero(t, item.Latency)
+			}
+		})
+	}
+}
@@ -5,6 +5,16 @@ import (
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/indexer/api/models"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+)
+
+const (
+	// DefaultLimit ... Number of items returned when no limit is requested
+	DefaultLimit = 100
+	// MaxLimit ... Maximum number of items that can be requested at once
+	MaxLimit = 1000
 )
 
 // Validator ... Validates API user request parameters
@@ -30,21 +40,14 @@ func (v *Validator) ValidateCursor(cursor string) error {
 		return nil
 	}
 
-	if len(cursor) != 66 { // 0x + 64 chars
-		return errors.New("cursor must be a 32 byte hex string")
-	}
-
-	if cursor[:2] != "0x" {
-		return errors.New("cursor must begin with 0x")
-	}
-
-	return nil
+	_, err := database.ParseBridgeTransfersCursor(cursor)
+	return err
 }
 
 // ParseValidateLimit ... Validates and parses the limit query parameters
 func (v *Validator) ParseValidateLimit(limit string) (int, error) {
 	if limit == "" {
-		return 100, nil
+		return DefaultLimit, nil
 	}
 
 	val, err := strconv.Atoi(limit)
@@ -56,6 +59,46 @@ func (v *Validator) ParseValidateLimit(limit string) (int, error) {
 		return 0, errors.New("limit must be greater than 0")
 	}
 
-	// TODO - Add a check against a max limit value
+	if val > MaxLimit {
+		return 0, errors.New("limit must not be greater than " + strconv.Itoa(MaxLimit))
+	}
+
+	return val, nil
+}
+
+// ParseValidateToken ... Validates and parses the optional token query parameter
+func (v *Validator) ParseValidateToken(token string) (common.Address, error) {
+	if token == "" {
+		return common.Address{}, nil
+	}
+
+	if !common.IsHexAddress(token) {
+		return common.Address{}, errors.New("token must be represented as a valid hexadecimal string")
+	}
+
+	return common.HexToAddress(token), nil
+}
+
+// ParseValidateTimestamp ... Validates and parses an optional unix timestamp query parameter
+func (v *Validator) ParseValidateTimestamp(timestamp string) (uint64, error) {
+	if timestamp == "" {
+		return 0, nil
+	}
+
+	val, err := strconv.ParseUint(timestamp, 10, 64)
+	if err != nil {
+		return 0, errors.New("timestamp must be a unix timestamp in seconds")
+	}
+
 	return val, nil
 }
+
+// ParseValidateWithdrawalStatus ... Validates and parses the optional withdrawal status query parameter
+func (v *Validator) ParseValidateWithdrawalStatus(status string) (models.WithdrawalStatus, error) {
+	switch s := models.WithdrawalStatus(status); s {
+	case "", models.WithdrawalStatusInitiated, models.WithdrawalStatusProven, models.WithdrawalStatusReady, models.WithdrawalStatusFinalized:
+		return s, nil
+	default:
+		return "", errors.New("status must be one of initiated, proven, ready or finalized")
+	}
+}
@@ -1,9 +1,14 @@
 package service
 
 import (
+	"strconv"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/indexer/api/models"
+	"github.com/ethereum-optimism/optimism/indexer/database"
 )
 
 func TestParseValidateLimit(t *testing.T) {
@@ -23,6 +28,17 @@ func TestParseValidateLimit(t *testing.T) {
 	limit = "abc"
 	_, err = v.ParseValidateLimit(limit)
 	require.Error(t, err, "limit must be an integer value")
+
+	// (4) Default and max limit
+	val, err := v.ParseValidateLimit("")
+	require.NoError(t, err)
+	require.Equal(t, DefaultLimit, val)
+
+	_, err = v.ParseValidateLimit(strconv.Itoa(MaxLimit))
+	require.NoError(t, err, "max limit should be valid")
+
+	_, err = v.ParseValidateLimit(strconv.Itoa(MaxLimit + 1))
+	require.Error(t, err, "limit must not be greater than the max limit")
 }
 
 func TestParseValidateAddress(t *testing.T) {
@@ -48,17 +64,45 @@ func Test_ParseValidateCursor(t *testing.T) {
 	v := Validator{}
 
 	// (1) Happy case
-	cursor := "0xf3fd2eb696dab4263550b938726f9b3606e334cce6ebe27446bc26cb700b94e0"
+	cursor := database.BridgeTransfersCursor{BlockNumber: 10, LogIndex: 3}.String()
 	err := v.ValidateCursor(cursor)
 	require.NoError(t, err, "cursor should be pass")
 
+	err = v.ValidateCursor("")
+	require.NoError(t, err, "empty cursor should be pass")
+
 	// (2) Invalid length
-	cursor = "0x000"
+	cursor = cursor[:4]
 	err = v.ValidateCursor(cursor)
-	require.Error(t, err, "cursor must be 32 byte hex string")
+	require.Error(t, err, "cursor must be a previously returned cursor")
 
-	// (3) Invalid hex
+	// (3) Invalid encoding
 	cursor = "0🫡"
 	err = v.ValidateCursor(cursor)
-	require.Error(t, err, "cursor must start with 0x")
+	require.Error(t, err, "cursor must be a previously returned cursor")
+}
+
+func TestParseValidateFilters(t *testing.T) {
+	v := Validator{}
+
+	token, err := v.ParseValidateToken("")
+	require.NoError(t, err)
+	require.Equal(t, common.Address{}, token)
+	token, err = v.ParseValidateToken("0x4200000000000000000000000000000000000010")
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0x4200000000000000000000000000000000000010"), token)
+	_, err = v.ParseValidateToken("0x42")
+	require.Error(t, err, "token must be an address")
+
+	timestamp, err := v.ParseValidateTimestamp("1700000000")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1700000000), timestamp)
+	_, err = v.ParseValidateTimestamp("-1")
+	require.Error(t, err, "timestamp must be unsigned")
+
+	status, err := v.ParseValidateWithdrawalStatus("ready")
+	require.NoError(t, err)
+	require.Equal(t, models.WithdrawalStatusReady, status)
+	_, err = v.ParseValidateWithdrawalStatus("pending")
+	require.Error(t, err, "status must be a known withdrawal status")
 }
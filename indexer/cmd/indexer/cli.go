@@ -58,9 +58,13 @@ func runApi(ctx *cli.Context, _ context.CancelCauseFunc) (cliapp.Lifecycle, erro
 	}
 
 	apiCfg := &api.Config{
-		DB:            &api.DBConfigConnector{DBConfig: cfg.DB},
-		HTTPServer:    cfg.HTTPServer,
-		MetricsServer: cfg.MetricsServer,
+		DB:                        &api.DBConfigConnector{DBConfig: cfg.DB},
+		HTTPServer:                cfg.HTTPServer,
+		MetricsServer:             cfg.MetricsServer,
+		FinalizationPeriodSeconds: cfg.Chain.FinalizationPeriodSeconds,
+
+		UnmatchedDepositThresholdSeconds:    cfg.Chain.UnmatchedDepositThresholdSeconds,
+		UnmatchedWithdrawalThresholdSeconds: cfg.Chain.UnmatchedWithdrawalThresholdSeconds,
 	}
 
 	return api.NewApi(ctx.Context, log, apiCfg)
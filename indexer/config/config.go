@@ -15,6 +15,22 @@ const (
 	// default to 5 seconds
 	defaultLoopInterval     = 5000
 	defaultHeaderBufferSize = 500
+
+	// default to the 7 day withdrawal finalization period of mainnet chains
+	defaultFinalizationPeriodSeconds = 604800
+
+	// default to the two epochs it takes for an L1 block to finalize
+	defaultMaxReorgDepth = 64
+
+	// default to an hour for deposits, which are relayed on L2 within minutes, and a day past
+	// the finalization period for withdrawals, which are proven and finalized on L1 by the user
+	defaultUnmatchedDepositThresholdSeconds         = 3600
+	defaultUnmatchedWithdrawalThresholdExtraSeconds = 86400
+
+	// default to 15 minutes behind the L1 head, which includes the confirmation depth,
+	// and 5 minutes behind the L2 head
+	defaultL1MaxLagSeconds = 900
+	defaultL2MaxLagSeconds = 300
 )
 
 // In the future, presets can just be onchain config and fetched on initialization
@@ -107,16 +123,34 @@ type ChainConfig struct {
 	L1BedrockStartingHeight uint `toml:"-"`
 	L2BedrockStartingHeight uint `toml:"-"`
 
-	// These configuration options will be removed once
-	// native reorg handling is implemented
+	// Blocks are only indexed once they have the confirmation depth
 	L1ConfirmationDepth uint `toml:"l1-confirmation-depth"`
 	L2ConfirmationDepth uint `toml:"l2-confirmation-depth"`
 
+	// Reorgs of indexed blocks up to the max reorg depth are handled by removing the
+	// orphaned state. Deeper reorgs halt indexing and require manual intervention
+	L1MaxReorgDepth uint `toml:"l1-max-reorg-depth"`
+	L2MaxReorgDepth uint `toml:"l2-max-reorg-depth"`
+
 	L1PollingInterval uint `toml:"l1-polling-interval"`
 	L2PollingInterval uint `toml:"l2-polling-interval"`
 
 	L1HeaderBufferSize uint `toml:"l1-header-buffer-size"`
 	L2HeaderBufferSize uint `toml:"l2-header-buffer-size"`
+
+	// FinalizationPeriodSeconds is the time a proven withdrawal has to wait before
+	// it can be finalized, the FINALIZATION_PERIOD_SECONDS of the L2OutputOracle
+	FinalizationPeriodSeconds uint64 `toml:"finalization-period-seconds"`
+
+	// Cross domain transfers that are not finalized within the threshold since they were
+	// initiated are flagged as unmatched
+	UnmatchedDepositThresholdSeconds    uint64 `toml:"unmatched-deposit-threshold-seconds"`
+	UnmatchedWithdrawalThresholdSeconds uint64 `toml:"unmatched-withdrawal-threshold-seconds"`
+
+	// The indexer is reported unhealthy when the indexed blocks lag
+	// behind the chain head by more than the max lag
+	L1MaxLagSeconds uint64 `toml:"l1-max-lag-seconds"`
+	L2MaxLagSeconds uint64 `toml:"l2-max-lag-seconds"`
 }
 
 // RPCsConfig configures the RPC urls
@@ -214,6 +248,34 @@ func LoadConfig(log log.Logger, path string) (Config, error) {
 		cfg.Chain.L2HeaderBufferSize = defaultHeaderBufferSize
 	}
 
+	if cfg.Chain.L1MaxReorgDepth == 0 {
+		cfg.Chain.L1MaxReorgDepth = defaultMaxReorgDepth
+	}
+
+	if cfg.Chain.L2MaxReorgDepth == 0 {
+		cfg.Chain.L2MaxReorgDepth = defaultMaxReorgDepth
+	}
+
+	if cfg.Chain.FinalizationPeriodSeconds == 0 {
+		cfg.Chain.FinalizationPeriodSeconds = defaultFinalizationPeriodSeconds
+	}
+
+	if cfg.Chain.UnmatchedDepositThresholdSeconds == 0 {
+		cfg.Chain.UnmatchedDepositThresholdSeconds = defaultUnmatchedDepositThresholdSeconds
+	}
+
+	if cfg.Chain.UnmatchedWithdrawalThresholdSeconds == 0 {
+		cfg.Chain.UnmatchedWithdrawalThresholdSeconds = cfg.Chain.FinalizationPeriodSeconds + defaultUnmatchedWithdrawalThresholdExtraSeconds
+	}
+
+	if cfg.Chain.L1MaxLagSeconds == 0 {
+		cfg.Chain.L1MaxLagSeconds = defaultL1MaxLagSeconds
+	}
+
+	if cfg.Chain.L2MaxLagSeconds == 0 {
+		cfg.Chain.L2MaxLagSeconds = defaultL2MaxLagSeconds
+	}
+
 	log.Info("loaded chain config", "config", cfg.Chain)
 	return cfg, nil
 }
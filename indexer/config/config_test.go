@@ -58,6 +58,7 @@ func TestLoadConfig(t *testing.T) {
 	require.Equal(t, conf.Chain.L1Contracts.L1CrossDomainMessengerProxy.String(), Presets[420].ChainConfig.L1Contracts.L1CrossDomainMessengerProxy.String())
 	require.Equal(t, conf.Chain.L1Contracts.L1StandardBridgeProxy.String(), Presets[420].ChainConfig.L1Contracts.L1StandardBridgeProxy.String())
 	require.Equal(t, conf.Chain.L1Contracts.L2OutputOracleProxy.String(), Presets[420].ChainConfig.L1Contracts.L2OutputOracleProxy.String())
+	require.Equal(t, conf.Chain.FinalizationPeriodSeconds, uint64(12))
 	require.Equal(t, conf.RPCs.L1RPC, "https://l1.example.com")
 	require.Equal(t, conf.RPCs.L2RPC, "https://l2.example.com")
 	require.Equal(t, conf.DB.Host, "127.0.0.1")
@@ -114,6 +115,13 @@ func TestLoadConfigWithoutPreset(t *testing.T) {
 	require.Equal(t, conf.Chain.L2PollingInterval, uint(5000))
 	require.Equal(t, conf.Chain.L1HeaderBufferSize, uint(500))
 	require.Equal(t, conf.Chain.L2HeaderBufferSize, uint(500))
+	require.Equal(t, conf.Chain.L1MaxReorgDepth, uint(64))
+	require.Equal(t, conf.Chain.L2MaxReorgDepth, uint(64))
+	require.Equal(t, conf.Chain.FinalizationPeriodSeconds, uint64(604800))
+	require.Equal(t, conf.Chain.UnmatchedDepositThresholdSeconds, uint64(3600))
+	require.Equal(t, conf.Chain.UnmatchedWithdrawalThresholdSeconds, uint64(604800+86400))
+	require.Equal(t, conf.Chain.L1MaxLagSeconds, uint64(900))
+	require.Equal(t, conf.Chain.L2MaxLagSeconds, uint64(300))
 }
 
 func TestLoadConfigWithUnknownPreset(t *testing.T) {
@@ -160,7 +168,13 @@ func TestLoadConfigPollingValues(t *testing.T) {
 	l1-polling-interval = 1000
 	l2-polling-interval = 1005
 	l1-header-buffer-size = 100
-	l2-header-buffer-size = 105`
+	l2-header-buffer-size = 105
+	l1-max-reorg-depth = 10
+	l2-max-reorg-depth = 20
+	unmatched-deposit-threshold-seconds = 600
+	unmatched-withdrawal-threshold-seconds = 1200
+	l1-max-lag-seconds = 60
+	l2-max-lag-seconds = 30`
 
 	data := []byte(testData)
 	err = os.WriteFile(tmpfile.Name(), data, 0644)
@@ -178,6 +192,12 @@ func TestLoadConfigPollingValues(t *testing.T) {
 	require.Equal(t, conf.Chain.L2PollingInterval, uint(1005))
 	require.Equal(t, conf.Chain.L1HeaderBufferSize, uint(100))
 	require.Equal(t, conf.Chain.L2HeaderBufferSize, uint(105))
+	require.Equal(t, conf.Chain.L1MaxReorgDepth, uint(10))
+	require.Equal(t, conf.Chain.L2MaxReorgDepth, uint(20))
+	require.Equal(t, conf.Chain.UnmatchedDepositThresholdSeconds, uint64(600))
+	require.Equal(t, conf.Chain.UnmatchedWithdrawalThresholdSeconds, uint64(1200))
+	require.Equal(t, conf.Chain.L1MaxLagSeconds, uint64(60))
+	require.Equal(t, conf.Chain.L2MaxLagSeconds, uint64(30))
 }
 
 func TestLoadedConfigPresetPrecendence(t *testing.T) {
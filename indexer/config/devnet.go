@@ -38,8 +38,13 @@ func DevnetPreset() (*Preset, error) {
 	}
 
 	return &Preset{
-		Name:        "Local Devnet",
-		ChainConfig: ChainConfig{Preset: DevnetPresetId, L1Contracts: l1Contracts},
+		Name: "Local Devnet",
+		ChainConfig: ChainConfig{
+			Preset:      DevnetPresetId,
+			L1Contracts: l1Contracts,
+			// finalizationPeriodSeconds of the devnet deploy config
+			FinalizationPeriodSeconds: 2,
+		},
 	}, nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -54,11 +55,13 @@ type BlocksView interface {
 	L1BlockHeaderWithFilter(BlockHeader) (*L1BlockHeader, error)
 	L1BlockHeaderWithScope(func(db *gorm.DB) *gorm.DB) (*L1BlockHeader, error)
 	L1LatestBlockHeader() (*L1BlockHeader, error)
+	L1LatestBlockHeaders(int) ([]L1BlockHeader, error)
 
 	L2BlockHeader(common.Hash) (*L2BlockHeader, error)
 	L2BlockHeaderWithFilter(BlockHeader) (*L2BlockHeader, error)
 	L2BlockHeaderWithScope(func(db *gorm.DB) *gorm.DB) (*L2BlockHeader, error)
 	L2LatestBlockHeader() (*L2BlockHeader, error)
+	L2LatestBlockHeaders(int) ([]L2BlockHeader, error)
 }
 
 type BlocksDB interface {
@@ -66,6 +69,11 @@ type BlocksDB interface {
 
 	StoreL1BlockHeaders([]L1BlockHeader) error
 	StoreL2BlockHeaders([]L2BlockHeader) error
+
+	// Removal of the headers after the supplied height, orphaned by a reorg.
+	// The state derived from these headers is removed by cascading deletes
+	DeleteL1BlockHeadersAfter(*big.Int) error
+	DeleteL2BlockHeadersAfter(*big.Int) error
 }
 
 /**
@@ -128,6 +136,27 @@ func (db *blocksDB) L1LatestBlockHeader() (*L1BlockHeader, error) {
 	return &l1Header, nil
 }
 
+// L1LatestBlockHeaders retrieves the latest indexed headers, up to the supplied limit, ordered by number
+func (db *blocksDB) L1LatestBlockHeaders(limit int) ([]L1BlockHeader, error) {
+	var l1Headers []L1BlockHeader
+	result := db.gorm.Order("number DESC").Limit(limit).Find(&l1Headers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	slices.Reverse(l1Headers)
+	return l1Headers, nil
+}
+
+func (db *blocksDB) DeleteL1BlockHeadersAfter(number *big.Int) error {
+	result := db.gorm.Where("number > ?", number).Delete(&L1BlockHeader{})
+	if result.Error == nil && result.RowsAffected > 0 {
+		db.log.Warn("deleted orphaned L1 block headers", "after_block_number", number, "size", result.RowsAffected)
+	}
+
+	return result.Error
+}
+
 // L2
 
 func (db *blocksDB) StoreL2BlockHeaders(headers []L2BlockHeader) error {
@@ -173,3 +202,24 @@ func (db *blocksDB) L2LatestBlockHeader() (*L2BlockHeader, error) {
 
 	return &l2Header, nil
 }
+
+// L2LatestBlockHeaders retrieves the latest indexed headers, up to the supplied limit, ordered by number
+func (db *blocksDB) L2LatestBlockHeaders(limit int) ([]L2BlockHeader, error) {
+	var l2Headers []L2BlockHeader
+	result := db.gorm.Order("number DESC").Limit(limit).Find(&l2Headers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	slices.Reverse(l2Headers)
+	return l2Headers, nil
+}
+
+func (db *blocksDB) DeleteL2BlockHeadersAfter(number *big.Int) error {
+	result := db.gorm.Where("number > ?", number).Delete(&L2BlockHeader{})
+	if result.Error == nil && result.RowsAffected > 0 {
+		db.log.Warn("deleted orphaned L2 block headers", "after_block_number", number, "size", result.RowsAffected)
+	}
+
+	return result.Error
+}
@@ -1,8 +1,11 @@
 package database
 
 import (
+	"encoding/base64"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"math/big"
 	"strings"
 
 	"gorm.io/gorm"
@@ -10,7 +13,10 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/google/uuid"
 )
 
 var (
@@ -44,6 +50,10 @@ type L1BridgeDepositWithTransactionHashes struct {
 	L1BlockHash       common.Hash `gorm:"serializer:bytes"`
 	L1TransactionHash common.Hash `gorm:"serializer:bytes"`
 	L2TransactionHash common.Hash `gorm:"serializer:bytes"`
+
+	// Position of the initiating event, which orders the deposits
+	L1BlockNumber *big.Int `gorm:"serializer:u256"`
+	L1LogIndex    uint64
 }
 
 type L2BridgeWithdrawal struct {
@@ -58,18 +68,161 @@ type L2BridgeWithdrawalWithTransactionHashes struct {
 
 	ProvenL1TransactionHash    common.Hash `gorm:"serializer:bytes"`
 	FinalizedL1TransactionHash common.Hash `gorm:"serializer:bytes"`
+
+	// ProvenL1Timestamp is the L1 block timestamp the withdrawal was proven at, or zero if not proven yet.
+	// The withdrawal can be finalized once the finalization period has passed since.
+	ProvenL1Timestamp uint64
+
+	// Position of the initiating event, which orders the withdrawals
+	L2BlockNumber *big.Int `gorm:"serializer:u256"`
+	L2LogIndex    uint64
+}
+
+// CrossDomainTransfer ... StandardBridge transfer matched across domains by the hash of the cross domain message that carries it.
+// Deposits are initiated on L1 and finalized on L2, withdrawals are initiated on L2 and finalized on L1.
+type CrossDomainTransfer struct {
+	CrossDomainMessageHash common.Hash `gorm:"primaryKey;serializer:bytes"`
+
+	InitiatedL1EventGUID *uuid.UUID
+	InitiatedL2EventGUID *uuid.UUID
+	FinalizedL1EventGUID *uuid.UUID
+	FinalizedL2EventGUID *uuid.UUID
+
+	// The initiated transfer. The local token is the token on the initiating layer
+	Tx        Transaction `gorm:"embedded"`
+	TokenPair TokenPair   `gorm:"embedded"`
+}
+
+type CrossDomainTransferWithTransactionHashes struct {
+	CrossDomainTransfer CrossDomainTransfer `gorm:"embedded"`
+
+	InitiatedTransactionHash common.Hash `gorm:"serializer:bytes"`
+	FinalizedTransactionHash common.Hash `gorm:"serializer:bytes"`
+
+	// FinalizedTimestamp is the block timestamp the transfer was finalized at, or zero if not finalized yet
+	FinalizedTimestamp uint64
+
+	// Position of the initiating event, which orders the transfers
+	InitiatedBlockNumber *big.Int `gorm:"serializer:u256"`
+	InitiatedLogIndex    uint64
+}
+
+// ERC721BridgeTransfer ... ERC721 token bridged across domains by the ERC721Bridge, matched by the hash of the cross domain message that
+// carries it. Deposits are initiated on L1 and finalized on L2, withdrawals are initiated on L2 and finalized on L1.
+type ERC721BridgeTransfer struct {
+	CrossDomainMessageHash common.Hash `gorm:"primaryKey;serializer:bytes"`
+
+	InitiatedL1EventGUID *uuid.UUID
+	InitiatedL2EventGUID *uuid.UUID
+	FinalizedL1EventGUID *uuid.UUID
+	FinalizedL2EventGUID *uuid.UUID
+
+	// The collections of the token. The local token is the collection on the initiating layer
+	TokenPair TokenPair `gorm:"embedded"`
+	TokenID   *big.Int  `gorm:"serializer:u256"`
+
+	FromAddress common.Address `gorm:"serializer:bytes"`
+	ToAddress   common.Address `gorm:"serializer:bytes"`
+	Data        Bytes          `gorm:"serializer:bytes"`
+	Timestamp   uint64
+}
+
+type ERC721BridgeTransferWithTransactionHashes struct {
+	ERC721BridgeTransfer ERC721BridgeTransfer `gorm:"embedded"`
+
+	InitiatedTransactionHash common.Hash `gorm:"serializer:bytes"`
+	FinalizedTransactionHash common.Hash `gorm:"serializer:bytes"`
+
+	// FinalizedTimestamp is the block timestamp the transfer was finalized at, or zero if not finalized yet
+	FinalizedTimestamp uint64
+
+	// Position of the initiating event, which orders the transfers
+	InitiatedBlockNumber *big.Int `gorm:"serializer:u256"`
+	InitiatedLogIndex    uint64
+}
+
+// BridgeTransfersCursor ... Position of a bridge transfer in the transfers of an address, which are ordered by the block number
+// and log index of the event that initiated them. Transfers made after a page was read are always before its cursor, so the
+// following pages neither repeat nor skip transfers.
+type BridgeTransfersCursor struct {
+	BlockNumber uint64
+	LogIndex    uint64
+}
+
+// String ... Opaque encoding of the cursor for API users
+func (c BridgeTransfersCursor) String() string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], c.BlockNumber)
+	binary.BigEndian.PutUint64(b[8:], c.LogIndex)
+	return base64.RawURLEncoding.EncodeToString(b[:])
+}
+
+// ParseBridgeTransfersCursor ... Decodes a cursor encoded with `BridgeTransfersCursor.String`
+func ParseBridgeTransfersCursor(cursor string) (*BridgeTransfersCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(b) != 16 {
+		return nil, errors.New("cursor must be a cursor returned by a previous page")
+	}
+	return &BridgeTransfersCursor{BlockNumber: binary.BigEndian.Uint64(b[:8]), LogIndex: binary.BigEndian.Uint64(b[8:])}, nil
+}
+
+// BridgeTransfersFilter ... Optional constraints on the bridge transfers of an address. Zero values do not constrain the transfers.
+type BridgeTransfersFilter struct {
+	// TokenAddress matches either the local or the remote token of the transfer
+	TokenAddress common.Address
+
+	// FromTimestamp and ToTimestamp are the inclusive bounds of the transfer timestamp
+	FromTimestamp uint64
+	ToTimestamp   uint64
+}
+
+// ERC721BridgeTransfersFilter ... Constraints on the listed ERC721 transfers. Zero values do not constrain the transfers,
+// but either the sender or the collection must be set.
+type ERC721BridgeTransfersFilter struct {
+	// TokenAddress matches the collection of the transfer on either layer
+	BridgeTransfersFilter
+
+	FromAddress common.Address
+}
+
+// WithdrawalStatus ... Stage of a withdrawal in the multi-step withdrawal process
+type WithdrawalStatus uint8
+
+const (
+	AnyWithdrawalStatus WithdrawalStatus = iota
+	InitiatedWithdrawalStatus
+	ProvenWithdrawalStatus
+	ReadyWithdrawalStatus
+	FinalizedWithdrawalStatus
+)
+
+type L2BridgeWithdrawalsFilter struct {
+	BridgeTransfersFilter
+
+	Status WithdrawalStatus
+	// ReadyProofTimestamp is the latest proof timestamp of the withdrawals for which the finalization period has passed.
+	// It distinguishes the `ProvenWithdrawalStatus` from the `ReadyWithdrawalStatus`.
+	ReadyProofTimestamp uint64
 }
 
 type BridgeTransfersView interface {
 	L1BridgeDeposit(common.Hash) (*L1BridgeDeposit, error)
 	L1TxDepositSum() (float64, error)
 	L1BridgeDepositWithFilter(BridgeTransfer) (*L1BridgeDeposit, error)
-	L1BridgeDepositsByAddress(common.Address, string, int) (*L1BridgeDepositsResponse, error)
+	L1BridgeDepositsByAddress(common.Address, string, int, BridgeTransfersFilter) (*L1BridgeDepositsResponse, error)
 
 	L2BridgeWithdrawal(common.Hash) (*L2BridgeWithdrawal, error)
 	L2BridgeWithdrawalSum(filter WithdrawFilter) (float64, error)
 	L2BridgeWithdrawalWithFilter(BridgeTransfer) (*L2BridgeWithdrawal, error)
-	L2BridgeWithdrawalsByAddress(common.Address, string, int) (*L2BridgeWithdrawalsResponse, error)
+	L2BridgeWithdrawalsByAddress(common.Address, string, int, L2BridgeWithdrawalsFilter) (*L2BridgeWithdrawalsResponse, error)
+
+	CrossDomainTransfer(common.Hash) (*CrossDomainTransfer, error)
+	CrossDomainDepositsByAddress(common.Address, string, int, BridgeTransfersFilter) (*CrossDomainTransfersResponse, error)
+	CrossDomainWithdrawalsByAddress(common.Address, string, int, BridgeTransfersFilter) (*CrossDomainTransfersResponse, error)
+
+	ERC721BridgeTransfer(common.Hash) (*ERC721BridgeTransfer, error)
+	ERC721BridgeDeposits(string, int, ERC721BridgeTransfersFilter) (*ERC721BridgeTransfersResponse, error)
+	ERC721BridgeWithdrawals(string, int, ERC721BridgeTransfersFilter) (*ERC721BridgeTransfersResponse, error)
 }
 
 type BridgeTransfersDB interface {
@@ -77,6 +230,14 @@ type BridgeTransfersDB interface {
 
 	StoreL1BridgeDeposits([]L1BridgeDeposit) error
 	StoreL2BridgeWithdrawals([]L2BridgeWithdrawal) error
+
+	StoreCrossDomainTransfers([]CrossDomainTransfer) error
+	MarkFinalizedCrossDomainDeposit(common.Hash, uuid.UUID) error
+	MarkFinalizedCrossDomainWithdrawal(common.Hash, uuid.UUID) error
+
+	StoreERC721BridgeTransfers([]ERC721BridgeTransfer) error
+	MarkFinalizedERC721BridgeDeposit(common.Hash, uuid.UUID) error
+	MarkFinalizedERC721BridgeWithdrawal(common.Hash, uuid.UUID) error
 }
 
 /**
@@ -152,20 +313,17 @@ func (db *bridgeTransfersDB) L1TxDepositSum() (float64, error) {
 
 // L1BridgeDepositsByAddress retrieves a list of deposits initiated by the specified address,
 // coupled with the L1/L2 transaction hashes that complete the bridge transaction.
-func (db *bridgeTransfersDB) L1BridgeDepositsByAddress(address common.Address, cursor string, limit int) (*L1BridgeDepositsResponse, error) {
+func (db *bridgeTransfersDB) L1BridgeDepositsByAddress(address common.Address, cursor string, limit int, filter BridgeTransfersFilter) (*L1BridgeDepositsResponse, error) {
 	if limit <= 0 {
 		return nil, fmt.Errorf("limit must be greater than 0")
 	}
 
-	cursorClause := ""
+	var after *BridgeTransfersCursor
 	if cursor != "" {
-		sourceHash := common.HexToHash(cursor)
-		txDeposit := new(L1TransactionDeposit)
-		result := db.gorm.Model(&L1TransactionDeposit{}).Where(&L1TransactionDeposit{SourceHash: sourceHash}).Take(txDeposit)
-		if result.Error != nil || errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("unable to find transaction with supplied cursor source hash %s: %w", sourceHash, result.Error)
+		var err error
+		if after, err = ParseBridgeTransfersCursor(cursor); err != nil {
+			return nil, err
 		}
-		cursorClause = fmt.Sprintf("l1_transaction_deposits.timestamp <= %d", txDeposit.Tx.Timestamp)
 	}
 
 	ethAddressString := predeploys.LegacyERC20ETHAddr.String()
@@ -174,31 +332,31 @@ func (db *bridgeTransfersDB) L1BridgeDepositsByAddress(address common.Address, c
 	ethTransactionDeposits := db.gorm.Model(&L1TransactionDeposit{})
 	ethTransactionDeposits = ethTransactionDeposits.Where(&Transaction{FromAddress: address}).Where("amount > 0")
 	ethTransactionDeposits = ethTransactionDeposits.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = initiated_l1_event_guid")
+	ethTransactionDeposits = ethTransactionDeposits.Joins("INNER JOIN l1_block_headers ON l1_block_headers.hash = l1_contract_events.block_hash")
 	ethTransactionDeposits = ethTransactionDeposits.Select(`
 from_address, to_address, amount, data, source_hash AS transaction_source_hash,
 l2_transaction_hash, l1_contract_events.transaction_hash AS l1_transaction_hash, l1_contract_events.block_hash as l1_block_hash,
+l1_block_headers.number AS l1_block_number, l1_contract_events.log_index AS l1_log_index,
 l1_transaction_deposits.timestamp, NULL AS cross_domain_message_hash, ? AS local_token_address, ? AS remote_token_address`, ethAddressString, ethAddressString)
-	ethTransactionDeposits = ethTransactionDeposits.Order("timestamp DESC").Limit(limit + 1)
-	if cursorClause != "" {
-		ethTransactionDeposits = ethTransactionDeposits.Where(cursorClause)
-	}
+	ethTransactionDeposits = filterBridgeTransfers(ethTransactionDeposits, "l1_transaction_deposits", nil, filter)
+	ethTransactionDeposits = paginateBridgeTransfers(ethTransactionDeposits, "l1_block_headers.number", "l1_contract_events.log_index", after, limit)
 
 	depositsQuery := db.gorm.Model(&L1BridgeDeposit{})
 	depositsQuery = depositsQuery.Where(&Transaction{FromAddress: address})
 	depositsQuery = depositsQuery.Joins("INNER JOIN l1_transaction_deposits ON l1_transaction_deposits.source_hash = transaction_source_hash")
 	depositsQuery = depositsQuery.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = l1_transaction_deposits.initiated_l1_event_guid")
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_block_headers ON l1_block_headers.hash = l1_contract_events.block_hash")
 	depositsQuery = depositsQuery.Select(`
 l1_bridge_deposits.from_address, l1_bridge_deposits.to_address, l1_bridge_deposits.amount, l1_bridge_deposits.data, transaction_source_hash,
 l2_transaction_hash, l1_contract_events.transaction_hash AS l1_transaction_hash, l1_contract_events.block_hash as l1_block_hash,
+l1_block_headers.number AS l1_block_number, l1_contract_events.log_index AS l1_log_index,
 l1_bridge_deposits.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
-	depositsQuery = depositsQuery.Order("timestamp DESC").Limit(limit + 1)
-	if cursorClause != "" {
-		depositsQuery = depositsQuery.Where(cursorClause)
-	}
+	depositsQuery = filterBridgeTransfers(depositsQuery, "l1_bridge_deposits", &TokenPair{}, filter)
+	depositsQuery = paginateBridgeTransfers(depositsQuery, "l1_block_headers.number", "l1_contract_events.log_index", after, limit)
 
 	query := db.gorm.Table("(?) AS deposits", depositsQuery)
 	query = query.Joins("UNION (?)", ethTransactionDeposits)
-	query = query.Select("*").Order("timestamp DESC").Limit(limit + 1)
+	query = query.Select("*").Order("l1_block_number DESC, l1_log_index DESC").Limit(limit + 1)
 	deposits := []L1BridgeDepositWithTransactionHashes{}
 	result := query.Find(&deposits)
 	if result.Error != nil {
@@ -212,14 +370,43 @@ l1_bridge_deposits.timestamp, cross_domain_message_hash, local_token_address, re
 	hasNextPage := false
 	if len(deposits) > limit {
 		hasNextPage = true
-		nextCursor = deposits[limit].L1BridgeDeposit.TransactionSourceHash.String()
 		deposits = deposits[:limit]
+		last := deposits[limit-1]
+		nextCursor = BridgeTransfersCursor{BlockNumber: last.L1BlockNumber.Uint64(), LogIndex: last.L1LogIndex}.String()
 	}
 
 	response := &L1BridgeDepositsResponse{Deposits: deposits, Cursor: nextCursor, HasNextPage: hasNextPage}
 	return response, nil
 }
 
+// filterBridgeTransfers constrains a query of the transfers in the table to the filter. The tokens of transfers
+// in tables without token columns, where tokens is nil, are ETH.
+func filterBridgeTransfers(query *gorm.DB, table string, tokens *TokenPair, filter BridgeTransfersFilter) *gorm.DB {
+	if filter.TokenAddress != (common.Address{}) {
+		if tokens != nil {
+			token := strings.ToLower(hexutil.Encode(filter.TokenAddress.Bytes()))
+			query = query.Where(fmt.Sprintf("(%s.local_token_address = ? OR %s.remote_token_address = ?)", table, table), token, token)
+		} else if filter.TokenAddress != predeploys.LegacyERC20ETHAddr {
+			query = query.Where("FALSE")
+		}
+	}
+	if filter.FromTimestamp != 0 {
+		query = query.Where(fmt.Sprintf("%s.timestamp >= ?", table), filter.FromTimestamp)
+	}
+	if filter.ToTimestamp != 0 {
+		query = query.Where(fmt.Sprintf("%s.timestamp <= ?", table), filter.ToTimestamp)
+	}
+	return query
+}
+
+// paginateBridgeTransfers orders a query of transfers from the most recent, starting after the cursor
+func paginateBridgeTransfers(query *gorm.DB, blockNumberColumn, logIndexColumn string, after *BridgeTransfersCursor, limit int) *gorm.DB {
+	if after != nil {
+		query = query.Where(fmt.Sprintf("(%s, %s) < (?, ?)", blockNumberColumn, logIndexColumn), after.BlockNumber, after.LogIndex)
+	}
+	return query.Order(fmt.Sprintf("%s DESC, %s DESC", blockNumberColumn, logIndexColumn)).Limit(limit + 1)
+}
+
 /**
  * Tokens Bridged (Withdrawn) from L2
  */
@@ -307,23 +494,20 @@ type L2BridgeWithdrawalsResponse struct {
 	HasNextPage bool
 }
 
-// L2BridgeDepositsByAddress retrieves a list of deposits initiated by the specified address, coupled with the L1/L2 transaction hashes
+// L2BridgeWithdrawalsByAddress retrieves a list of withdrawals initiated by the specified address, coupled with the L1/L2 transaction hashes
 // that complete the bridge transaction. The hashes that correspond with the Bedrock multi-step withdrawal process are also surfaced
-func (db *bridgeTransfersDB) L2BridgeWithdrawalsByAddress(address common.Address, cursor string, limit int) (*L2BridgeWithdrawalsResponse, error) {
+func (db *bridgeTransfersDB) L2BridgeWithdrawalsByAddress(address common.Address, cursor string, limit int, filter L2BridgeWithdrawalsFilter) (*L2BridgeWithdrawalsResponse, error) {
 	if limit <= 0 {
 		return nil, fmt.Errorf("limit must be greater than 0")
 	}
 
-	// (1) Generate cursor clause provided a cursor tx hash
-	cursorClause := ""
+	// (1) Parse the cursor of the last withdrawal of the previous page
+	var after *BridgeTransfersCursor
 	if cursor != "" {
-		withdrawalHash := common.HexToHash(cursor)
-		var txWithdrawal L2TransactionWithdrawal
-		result := db.gorm.Model(&L2TransactionWithdrawal{}).Where(&L2TransactionWithdrawal{WithdrawalHash: withdrawalHash}).Take(&txWithdrawal)
-		if result.Error != nil || errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("unable to find transaction with supplied cursor withdrawal hash %s: %w", withdrawalHash, result.Error)
+		var err error
+		if after, err = ParseBridgeTransfersCursor(cursor); err != nil {
+			return nil, err
 		}
-		cursorClause = fmt.Sprintf("l2_transaction_withdrawals.timestamp <= %d", txWithdrawal.Tx.Timestamp)
 	}
 
 	// (2) Generate query for fetching ETH withdrawal data
@@ -338,35 +522,39 @@ func (db *bridgeTransfersDB) L2BridgeWithdrawalsByAddress(address common.Address
 	ethTransactionWithdrawals := db.gorm.Model(&L2TransactionWithdrawal{})
 	ethTransactionWithdrawals = ethTransactionWithdrawals.Where(&Transaction{FromAddress: address}).Where("amount > 0")
 	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
+	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("INNER JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash")
 	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
 	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
 	ethTransactionWithdrawals = ethTransactionWithdrawals.Select(`
 from_address, to_address, amount, data, withdrawal_hash AS transaction_withdrawal_hash,
 l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
+COALESCE(proven_l1_events.timestamp, 0) AS proven_l1_timestamp,
+l2_block_headers.number AS l2_block_number, l2_contract_events.log_index AS l2_log_index,
 l2_transaction_withdrawals.timestamp, NULL AS cross_domain_message_hash, ? AS local_token_address, ? AS remote_token_address`, ethAddressString, ethAddressString)
-	ethTransactionWithdrawals = ethTransactionWithdrawals.Order("timestamp DESC").Limit(limit + 1)
-	if cursorClause != "" {
-		ethTransactionWithdrawals = ethTransactionWithdrawals.Where(cursorClause)
-	}
+	ethTransactionWithdrawals = filterBridgeTransfers(ethTransactionWithdrawals, "l2_transaction_withdrawals", nil, filter.BridgeTransfersFilter)
+	ethTransactionWithdrawals = filterWithdrawalStatus(ethTransactionWithdrawals, filter)
+	ethTransactionWithdrawals = paginateBridgeTransfers(ethTransactionWithdrawals, "l2_block_headers.number", "l2_contract_events.log_index", after, limit)
 
 	withdrawalsQuery := db.gorm.Model(&L2BridgeWithdrawal{})
 	withdrawalsQuery = withdrawalsQuery.Where(&Transaction{FromAddress: address})
 	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_transaction_withdrawals ON withdrawal_hash = l2_bridge_withdrawals.transaction_withdrawal_hash")
 	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash")
 	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
 	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
 	withdrawalsQuery = withdrawalsQuery.Select(`
 l2_bridge_withdrawals.from_address, l2_bridge_withdrawals.to_address, l2_bridge_withdrawals.amount, l2_bridge_withdrawals.data, transaction_withdrawal_hash,
 l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
+COALESCE(proven_l1_events.timestamp, 0) AS proven_l1_timestamp,
+l2_block_headers.number AS l2_block_number, l2_contract_events.log_index AS l2_log_index,
 l2_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
-	withdrawalsQuery = withdrawalsQuery.Order("timestamp DESC").Limit(limit + 1)
-	if cursorClause != "" {
-		withdrawalsQuery = withdrawalsQuery.Where(cursorClause)
-	}
+	withdrawalsQuery = filterBridgeTransfers(withdrawalsQuery, "l2_bridge_withdrawals", &TokenPair{}, filter.BridgeTransfersFilter)
+	withdrawalsQuery = filterWithdrawalStatus(withdrawalsQuery, filter)
+	withdrawalsQuery = paginateBridgeTransfers(withdrawalsQuery, "l2_block_headers.number", "l2_contract_events.log_index", after, limit)
 
 	query := db.gorm.Table("(?) AS withdrawals", withdrawalsQuery)
 	query = query.Joins("UNION (?)", ethTransactionWithdrawals)
-	query = query.Select("*").Order("timestamp DESC").Limit(limit + 1)
+	query = query.Select("*").Order("l2_block_number DESC, l2_log_index DESC").Limit(limit + 1)
 	withdrawals := []L2BridgeWithdrawalWithTransactionHashes{}
 
 	// (3) Execute query and process results
@@ -383,10 +571,297 @@ l2_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address,
 	hasNextPage := false
 	if len(withdrawals) > limit {
 		hasNextPage = true
-		nextCursor = withdrawals[limit].L2BridgeWithdrawal.TransactionWithdrawalHash.String()
 		withdrawals = withdrawals[:limit]
+		last := withdrawals[limit-1]
+		nextCursor = BridgeTransfersCursor{BlockNumber: last.L2BlockNumber.Uint64(), LogIndex: last.L2LogIndex}.String()
 	}
 
 	response := &L2BridgeWithdrawalsResponse{Withdrawals: withdrawals, Cursor: nextCursor, HasNextPage: hasNextPage}
 	return response, nil
 }
+
+// filterWithdrawalStatus constrains a query of withdrawals, joined with their proven and finalized events, to the status of the filter
+func filterWithdrawalStatus(query *gorm.DB, filter L2BridgeWithdrawalsFilter) *gorm.DB {
+	switch filter.Status {
+	case InitiatedWithdrawalStatus:
+		return query.Where("l2_transaction_withdrawals.proven_l1_event_guid IS NULL")
+	case ProvenWithdrawalStatus:
+		query = query.Where("l2_transaction_withdrawals.proven_l1_event_guid IS NOT NULL AND l2_transaction_withdrawals.finalized_l1_event_guid IS NULL")
+		return query.Where("proven_l1_events.timestamp > ?", filter.ReadyProofTimestamp)
+	case ReadyWithdrawalStatus:
+		query = query.Where("l2_transaction_withdrawals.proven_l1_event_guid IS NOT NULL AND l2_transaction_withdrawals.finalized_l1_event_guid IS NULL")
+		return query.Where("proven_l1_events.timestamp <= ?", filter.ReadyProofTimestamp)
+	case FinalizedWithdrawalStatus:
+		return query.Where("l2_transaction_withdrawals.finalized_l1_event_guid IS NOT NULL")
+	default:
+		return query
+	}
+}
+
+/**
+ * Tokens Bridged across Domains
+ */
+
+func (db *bridgeTransfersDB) StoreCrossDomainTransfers(transfers []CrossDomainTransfer) error {
+	deduped := db.gorm.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "cross_domain_message_hash"}}, DoNothing: true})
+	result := deduped.Create(&transfers)
+	if result.Error == nil && int(result.RowsAffected) < len(transfers) {
+		db.log.Warn("ignored cross domain transfer duplicates", "duplicates", len(transfers)-int(result.RowsAffected))
+	}
+
+	return result.Error
+}
+
+func (db *bridgeTransfersDB) CrossDomainTransfer(crossDomainMessageHash common.Hash) (*CrossDomainTransfer, error) {
+	var transfer CrossDomainTransfer
+	result := db.gorm.Where(&CrossDomainTransfer{CrossDomainMessageHash: crossDomainMessageHash}).Take(&transfer)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &transfer, nil
+}
+
+// MarkFinalizedCrossDomainDeposit matches the deposit with the L2 event that finalized it
+func (db *bridgeTransfersDB) MarkFinalizedCrossDomainDeposit(crossDomainMessageHash common.Hash, finalizedL2Event uuid.UUID) error {
+	transfer, err := db.CrossDomainTransfer(crossDomainMessageHash)
+	if err != nil {
+		return err
+	} else if transfer == nil || transfer.InitiatedL1EventGUID == nil {
+		return fmt.Errorf("cross domain deposit %s not found", crossDomainMessageHash)
+	}
+
+	if transfer.FinalizedL2EventGUID != nil && transfer.FinalizedL2EventGUID.ID() == finalizedL2Event.ID() {
+		return nil
+	} else if transfer.FinalizedL2EventGUID != nil {
+		return fmt.Errorf("finalized deposit %s re-finalized with a different event %d", crossDomainMessageHash, finalizedL2Event)
+	}
+
+	transfer.FinalizedL2EventGUID = &finalizedL2Event
+	result := db.gorm.Save(transfer)
+	return result.Error
+}
+
+// MarkFinalizedCrossDomainWithdrawal matches the withdrawal with the L1 event that finalized it
+func (db *bridgeTransfersDB) MarkFinalizedCrossDomainWithdrawal(crossDomainMessageHash common.Hash, finalizedL1Event uuid.UUID) error {
+	transfer, err := db.CrossDomainTransfer(crossDomainMessageHash)
+	if err != nil {
+		return err
+	} else if transfer == nil || transfer.InitiatedL2EventGUID == nil {
+		return fmt.Errorf("cross domain withdrawal %s not found", crossDomainMessageHash)
+	}
+
+	if transfer.FinalizedL1EventGUID != nil && transfer.FinalizedL1EventGUID.ID() == finalizedL1Event.ID() {
+		return nil
+	} else if transfer.FinalizedL1EventGUID != nil {
+		return fmt.Errorf("finalized withdrawal %s re-finalized with a different event %d", crossDomainMessageHash, finalizedL1Event)
+	}
+
+	transfer.FinalizedL1EventGUID = &finalizedL1Event
+	result := db.gorm.Save(transfer)
+	return result.Error
+}
+
+type CrossDomainTransfersResponse struct {
+	Transfers   []CrossDomainTransferWithTransactionHashes
+	Cursor      string
+	HasNextPage bool
+}
+
+// CrossDomainDepositsByAddress retrieves a list of the cross domain transfers deposited by the specified address,
+// coupled with the L1 transaction hash that initiated and the L2 transaction hash that finalized the transfer.
+func (db *bridgeTransfersDB) CrossDomainDepositsByAddress(address common.Address, cursor string, limit int, filter BridgeTransfersFilter) (*CrossDomainTransfersResponse, error) {
+	return db.crossDomainTransfersByAddress(address, cursor, limit, filter, "l1", "l2")
+}
+
+// CrossDomainWithdrawalsByAddress retrieves a list of the cross domain transfers withdrawn by the specified address,
+// coupled with the L2 transaction hash that initiated and the L1 transaction hash that finalized the transfer.
+func (db *bridgeTransfersDB) CrossDomainWithdrawalsByAddress(address common.Address, cursor string, limit int, filter BridgeTransfersFilter) (*CrossDomainTransfersResponse, error) {
+	return db.crossDomainTransfersByAddress(address, cursor, limit, filter, "l2", "l1")
+}
+
+func (db *bridgeTransfersDB) crossDomainTransfersByAddress(address common.Address, cursor string, limit int, filter BridgeTransfersFilter, initiatedLayer, finalizedLayer string) (*CrossDomainTransfersResponse, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	var after *BridgeTransfersCursor
+	if cursor != "" {
+		var err error
+		if after, err = ParseBridgeTransfersCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	query := db.gorm.Model(&CrossDomainTransfer{})
+	query = query.Where(&Transaction{FromAddress: address})
+	query = query.Joins(fmt.Sprintf("INNER JOIN %[1]s_contract_events AS initiated_events ON initiated_events.guid = cross_domain_transfers.initiated_%[1]s_event_guid", initiatedLayer))
+	query = query.Joins(fmt.Sprintf("INNER JOIN %[1]s_block_headers ON %[1]s_block_headers.hash = initiated_events.block_hash", initiatedLayer))
+	query = query.Joins(fmt.Sprintf("LEFT JOIN %[1]s_contract_events AS finalized_events ON finalized_events.guid = cross_domain_transfers.finalized_%[1]s_event_guid", finalizedLayer))
+	query = query.Select(fmt.Sprintf(`
+cross_domain_transfers.*, initiated_events.transaction_hash AS initiated_transaction_hash, finalized_events.transaction_hash AS finalized_transaction_hash,
+COALESCE(finalized_events.timestamp, 0) AS finalized_timestamp,
+%[1]s_block_headers.number AS initiated_block_number, initiated_events.log_index AS initiated_log_index`, initiatedLayer))
+	query = filterBridgeTransfers(query, "cross_domain_transfers", &TokenPair{}, filter)
+	query = paginateBridgeTransfers(query, initiatedLayer+"_block_headers.number", "initiated_events.log_index", after, limit)
+
+	transfers := []CrossDomainTransferWithTransactionHashes{}
+	result := query.Find(&transfers)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	nextCursor := ""
+	hasNextPage := false
+	if len(transfers) > limit {
+		hasNextPage = true
+		transfers = transfers[:limit]
+		last := transfers[limit-1]
+		nextCursor = BridgeTransfersCursor{BlockNumber: last.InitiatedBlockNumber.Uint64(), LogIndex: last.InitiatedLogIndex}.String()
+	}
+
+	response := &CrossDomainTransfersResponse{Transfers: transfers, Cursor: nextCursor, HasNextPage: hasNextPage}
+	return response, nil
+}
+
+/**
+ * ERC721 Tokens Bridged across Domains
+ */
+
+func (db *bridgeTransfersDB) StoreERC721BridgeTransfers(transfers []ERC721BridgeTransfer) error {
+	deduped := db.gorm.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "cross_domain_message_hash"}}, DoNothing: true})
+	result := deduped.Create(&transfers)
+	if result.Error == nil && int(result.RowsAffected) < len(transfers) {
+		db.log.Warn("ignored erc721 bridge transfer duplicates", "duplicates", len(transfers)-int(result.RowsAffected))
+	}
+
+	return result.Error
+}
+
+func (db *bridgeTransfersDB) ERC721BridgeTransfer(crossDomainMessageHash common.Hash) (*ERC721BridgeTransfer, error) {
+	var transfer ERC721BridgeTransfer
+	result := db.gorm.Where(&ERC721BridgeTransfer{CrossDomainMessageHash: crossDomainMessageHash}).Take(&transfer)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &transfer, nil
+}
+
+// MarkFinalizedERC721BridgeDeposit matches the ERC721 deposit with the L2 event that finalized it
+func (db *bridgeTransfersDB) MarkFinalizedERC721BridgeDeposit(crossDomainMessageHash common.Hash, finalizedL2Event uuid.UUID) error {
+	transfer, err := db.ERC721BridgeTransfer(crossDomainMessageHash)
+	if err != nil {
+		return err
+	} else if transfer == nil || transfer.InitiatedL1EventGUID == nil {
+		return fmt.Errorf("erc721 deposit %s not found", crossDomainMessageHash)
+	}
+
+	if transfer.FinalizedL2EventGUID != nil && transfer.FinalizedL2EventGUID.ID() == finalizedL2Event.ID() {
+		return nil
+	} else if transfer.FinalizedL2EventGUID != nil {
+		return fmt.Errorf("finalized erc721 deposit %s re-finalized with a different event %d", crossDomainMessageHash, finalizedL2Event)
+	}
+
+	transfer.FinalizedL2EventGUID = &finalizedL2Event
+	result := db.gorm.Save(transfer)
+	return result.Error
+}
+
+// MarkFinalizedERC721BridgeWithdrawal matches the ERC721 withdrawal with the L1 event that finalized it
+func (db *bridgeTransfersDB) MarkFinalizedERC721BridgeWithdrawal(crossDomainMessageHash common.Hash, finalizedL1Event uuid.UUID) error {
+	transfer, err := db.ERC721BridgeTransfer(crossDomainMessageHash)
+	if err != nil {
+		return err
+	} else if transfer == nil || transfer.InitiatedL2EventGUID == nil {
+		return fmt.Errorf("erc721 withdrawal %s not found", crossDomainMessageHash)
+	}
+
+	if transfer.FinalizedL1EventGUID != nil && transfer.FinalizedL1EventGUID.ID() == finalizedL1Event.ID() {
+		return nil
+	} else if transfer.FinalizedL1EventGUID != nil {
+		return fmt.Errorf("finalized erc721 withdrawal %s re-finalized with a different event %d", crossDomainMessageHash, finalizedL1Event)
+	}
+
+	transfer.FinalizedL1EventGUID = &finalizedL1Event
+	result := db.gorm.Save(transfer)
+	return result.Error
+}
+
+type ERC721BridgeTransfersResponse struct {
+	Transfers   []ERC721BridgeTransferWithTransactionHashes
+	Cursor      string
+	HasNextPage bool
+}
+
+// ERC721BridgeDeposits retrieves a list of the ERC721 tokens deposited by the sender or of the collection of the filter,
+// coupled with the L1 transaction hash that initiated and the L2 transaction hash that finalized the transfer.
+func (db *bridgeTransfersDB) ERC721BridgeDeposits(cursor string, limit int, filter ERC721BridgeTransfersFilter) (*ERC721BridgeTransfersResponse, error) {
+	return db.erc721BridgeTransfers(cursor, limit, filter, "l1", "l2")
+}
+
+// ERC721BridgeWithdrawals retrieves a list of the ERC721 tokens withdrawn by the sender or of the collection of the filter,
+// coupled with the L2 transaction hash that initiated and the L1 transaction hash that finalized the transfer.
+func (db *bridgeTransfersDB) ERC721BridgeWithdrawals(cursor string, limit int, filter ERC721BridgeTransfersFilter) (*ERC721BridgeTransfersResponse, error) {
+	return db.erc721BridgeTransfers(cursor, limit, filter, "l2", "l1")
+}
+
+func (db *bridgeTransfersDB) erc721BridgeTransfers(cursor string, limit int, filter ERC721BridgeTransfersFilter, initiatedLayer, finalizedLayer string) (*ERC721BridgeTransfersResponse, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	} else if filter.FromAddress == (common.Address{}) && filter.TokenAddress == (common.Address{}) {
+		return nil, fmt.Errorf("either the sender or the collection must be filtered")
+	}
+
+	var after *BridgeTransfersCursor
+	if cursor != "" {
+		var err error
+		if after, err = ParseBridgeTransfersCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	query := db.gorm.Model(&ERC721BridgeTransfer{})
+	if filter.FromAddress != (common.Address{}) {
+		query = query.Where(&ERC721BridgeTransfer{FromAddress: filter.FromAddress})
+	}
+	query = query.Joins(fmt.Sprintf("INNER JOIN %[1]s_contract_events AS initiated_events ON initiated_events.guid = erc721_bridge_transfers.initiated_%[1]s_event_guid", initiatedLayer))
+	query = query.Joins(fmt.Sprintf("INNER JOIN %[1]s_block_headers ON %[1]s_block_headers.hash = initiated_events.block_hash", initiatedLayer))
+	query = query.Joins(fmt.Sprintf("LEFT JOIN %[1]s_contract_events AS finalized_events ON finalized_events.guid = erc721_bridge_transfers.finalized_%[1]s_event_guid", finalizedLayer))
+	query = query.Select(fmt.Sprintf(`
+erc721_bridge_transfers.*, initiated_events.transaction_hash AS initiated_transaction_hash, finalized_events.transaction_hash AS finalized_transaction_hash,
+COALESCE(finalized_events.timestamp, 0) AS finalized_timestamp,
+%[1]s_block_headers.number AS initiated_block_number, initiated_events.log_index AS initiated_log_index`, initiatedLayer))
+	query = filterBridgeTransfers(query, "erc721_bridge_transfers", &TokenPair{}, filter.BridgeTransfersFilter)
+	query = paginateBridgeTransfers(query, initiatedLayer+"_block_headers.number", "initiated_events.log_index", after, limit)
+
+	transfers := []ERC721BridgeTransferWithTransactionHashes{}
+	result := query.Find(&transfers)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	nextCursor := ""
+	hasNextPage := false
+	if len(transfers) > limit {
+		hasNextPage = true
+		transfers = transfers[:limit]
+		last := transfers[limit-1]
+		nextCursor = BridgeTransfersCursor{BlockNumber: last.InitiatedBlockNumber.Uint64(), LogIndex: last.InitiatedLogIndex}.String()
+	}
+
+	response := &ERC721BridgeTransfersResponse{Transfers: transfers, Cursor: nextCursor, HasNextPage: hasNextPage}
+	return response, nil
+}
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
 	"gorm.io/gorm"
 
@@ -42,6 +44,11 @@ func (m *MockBlocksView) L1LatestBlockHeader() (*L1BlockHeader, error) {
 	return header, args.Error(1)
 }
 
+func (m *MockBlocksView) L1LatestBlockHeaders(limit int) ([]L1BlockHeader, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]L1BlockHeader), args.Error(1)
+}
+
 func (m *MockBlocksView) L2BlockHeader(common.Hash) (*L2BlockHeader, error) {
 	args := m.Called()
 	return args.Get(0).(*L2BlockHeader), args.Error(1)
@@ -62,6 +69,11 @@ func (m *MockBlocksView) L2LatestBlockHeader() (*L2BlockHeader, error) {
 	return args.Get(0).(*L2BlockHeader), args.Error(1)
 }
 
+func (m *MockBlocksView) L2LatestBlockHeaders(limit int) ([]L2BlockHeader, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]L2BlockHeader), args.Error(1)
+}
+
 type MockBlocksDB struct {
 	MockBlocksView
 }
@@ -76,6 +88,16 @@ func (m *MockBlocksDB) StoreL2BlockHeaders(headers []L2BlockHeader) error {
 	return args.Error(1)
 }
 
+func (m *MockBlocksDB) DeleteL1BlockHeadersAfter(number *big.Int) error {
+	args := m.Called(number)
+	return args.Error(0)
+}
+
+func (m *MockBlocksDB) DeleteL2BlockHeadersAfter(number *big.Int) error {
+	args := m.Called(number)
+	return args.Error(0)
+}
+
 // MockDB is a mock database that can be used for testing
 type MockDB struct {
 	MockBlocks *MockBlocksDB
@@ -0,0 +1,113 @@
+package e2e_tests
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	e2etest_utils "github.com/ethereum-optimism/optimism/indexer/e2e_tests/utils"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testERC721Bytecode deploys a collection that accepts any call. The L1ERC721Bridge only escrows the
+// bridged token with a `transferFrom` call, so the collection does not need to track ownership.
+var testERC721Bytecode = hexutil.MustDecode("0x6001600c60003960016000f300")
+
+func TestE2EBridgeTransfersERC721Deposit(t *testing.T) {
+	testSuite := createE2ETestSuite(t)
+
+	l1ERC721Bridge, err := bindings.NewL1ERC721Bridge(testSuite.OpCfg.L1Deployments.L1ERC721BridgeProxy, testSuite.L1Client)
+	require.NoError(t, err)
+	l2ERC721Factory, err := bindings.NewOptimismMintableERC721Factory(predeploys.OptimismMintableERC721FactoryAddr, testSuite.L2Client)
+	require.NoError(t, err)
+
+	aliceAddr := testSuite.OpCfg.Secrets.Addresses().Alice
+	l1Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L1ChainIDBig())
+	require.NoError(t, err)
+	l2Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L2ChainIDBig())
+	require.NoError(t, err)
+
+	// Deploy the L1 collection & its OptimismMintableERC721 counterpart on L2
+	l1Collection, deployTx, _, err := bind.DeployContract(l1Opts, abi.ABI{}, testERC721Bytecode, testSuite.L1Client)
+	require.NoError(t, err)
+	_, err = wait.ForReceiptOK(context.Background(), testSuite.L1Client, deployTx.Hash())
+	require.NoError(t, err)
+
+	createTx, err := l2ERC721Factory.CreateOptimismMintableERC721(l2Opts, l1Collection, "TEST", "TST")
+	require.NoError(t, err)
+	createReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L2Client, createTx.Hash())
+	require.NoError(t, err)
+
+	var l2Collection common.Address
+	for _, log := range createReceipt.Logs {
+		if created, err := l2ERC721Factory.ParseOptimismMintableERC721Created(*log); err == nil {
+			l2Collection = created.LocalToken
+		}
+	}
+	require.NotEqual(t, common.Address{}, l2Collection)
+
+	// (1) Test Deposit Initiation
+	tokenID := big.NewInt(42)
+	bridgeTx, err := l1ERC721Bridge.BridgeERC721(l1Opts, l1Collection, l2Collection, tokenID, 200_000, []byte{byte(1)})
+	require.NoError(t, err)
+	bridgeReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L1Client, bridgeTx.Hash())
+	require.NoError(t, err)
+
+	depositInfo, err := e2etest_utils.ParseDepositInfo(bridgeReceipt)
+	require.NoError(t, err)
+
+	// wait for processor catchup
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l1Header := testSuite.Indexer.BridgeProcessor.LastL1Header
+		return l1Header != nil && l1Header.Number.Uint64() >= bridgeReceipt.BlockNumber.Uint64(), nil
+	}))
+
+	aliceDeposits, err := testSuite.DB.BridgeTransfers.ERC721BridgeDeposits("", 100, database.ERC721BridgeTransfersFilter{FromAddress: aliceAddr})
+	require.NoError(t, err)
+	require.Len(t, aliceDeposits.Transfers, 1)
+	require.Equal(t, bridgeTx.Hash(), aliceDeposits.Transfers[0].InitiatedTransactionHash)
+	require.Equal(t, common.Hash{}, aliceDeposits.Transfers[0].FinalizedTransactionHash)
+
+	transfer := aliceDeposits.Transfers[0].ERC721BridgeTransfer
+	require.Equal(t, l1Collection, transfer.TokenPair.LocalTokenAddress)
+	require.Equal(t, l2Collection, transfer.TokenPair.RemoteTokenAddress)
+	require.Equal(t, tokenID.Uint64(), transfer.TokenID.Uint64())
+	require.Equal(t, aliceAddr, transfer.FromAddress)
+	require.Equal(t, aliceAddr, transfer.ToAddress)
+	require.Equal(t, byte(1), transfer.Data[0])
+
+	// The bridge flows through the messenger
+	crossDomainBridgeMessage, err := testSuite.DB.BridgeMessages.L1BridgeMessage(transfer.CrossDomainMessageHash)
+	require.NoError(t, err)
+	require.NotNil(t, crossDomainBridgeMessage)
+
+	// (2) Test Deposit Finalization, as the collection on L2 mints the token
+	l2DepositReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L2Client, types.NewTx(depositInfo.DepositTx).Hash())
+	require.NoError(t, err)
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l2Header := testSuite.Indexer.BridgeProcessor.LastFinalizedL2Header
+		return l2Header != nil && l2Header.Number.Uint64() >= l2DepositReceipt.BlockNumber.Uint64(), nil
+	}))
+
+	collectionDeposits, err := testSuite.DB.BridgeTransfers.ERC721BridgeDeposits("", 100, database.ERC721BridgeTransfersFilter{
+		BridgeTransfersFilter: database.BridgeTransfersFilter{TokenAddress: l2Collection},
+	})
+	require.NoError(t, err)
+	require.Len(t, collectionDeposits.Transfers, 1)
+	require.Equal(t, transfer.CrossDomainMessageHash, collectionDeposits.Transfers[0].ERC721BridgeTransfer.CrossDomainMessageHash)
+	require.Equal(t, l2DepositReceipt.TxHash, collectionDeposits.Transfers[0].FinalizedTransactionHash)
+	require.NotNil(t, collectionDeposits.Transfers[0].ERC721BridgeTransfer.FinalizedL2EventGUID)
+	require.GreaterOrEqual(t, collectionDeposits.Transfers[0].FinalizedTimestamp, transfer.Timestamp)
+}
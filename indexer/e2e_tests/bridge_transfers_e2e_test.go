@@ -8,7 +8,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/indexer/api/models"
 	"github.com/ethereum-optimism/optimism/indexer/bigint"
+	"github.com/ethereum-optimism/optimism/indexer/database"
 	e2etest_utils "github.com/ethereum-optimism/optimism/indexer/e2e_tests/utils"
 	op_e2e "github.com/ethereum-optimism/optimism/op-e2e"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/transactions"
@@ -55,7 +57,7 @@ func TestE2EBridgeTransfersStandardBridgeETHDeposit(t *testing.T) {
 	cursor := ""
 	limit := 100
 
-	aliceDeposits, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, cursor, limit)
+	aliceDeposits, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, cursor, limit, database.BridgeTransfersFilter{})
 
 	require.NoError(t, err)
 	require.Len(t, aliceDeposits.Deposits, 1)
@@ -90,6 +92,17 @@ func TestE2EBridgeTransfersStandardBridgeETHDeposit(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, crossDomainBridgeMessage)
 	require.NotNil(t, crossDomainBridgeMessage.RelayedMessageEventGUID)
+
+	// (3) Test the L1 & L2 sides of the deposit are matched
+	aliceTransfers, err := testSuite.DB.BridgeTransfers.CrossDomainDepositsByAddress(aliceAddr, "", 100, database.BridgeTransfersFilter{})
+	require.NoError(t, err)
+	require.Len(t, aliceTransfers.Transfers, 1)
+	transfer := aliceTransfers.Transfers[0]
+	require.Equal(t, *deposit.CrossDomainMessageHash, transfer.CrossDomainTransfer.CrossDomainMessageHash)
+	require.Equal(t, depositTx.Hash(), transfer.InitiatedTransactionHash)
+	require.Equal(t, l2DepositReceipt.TxHash, transfer.FinalizedTransactionHash)
+	require.NotNil(t, transfer.CrossDomainTransfer.FinalizedL2EventGUID)
+	require.GreaterOrEqual(t, transfer.FinalizedTimestamp, transfer.CrossDomainTransfer.Tx.Timestamp)
 }
 
 func TestE2EBridgeTransfersOptimismPortalETHReceive(t *testing.T) {
@@ -119,7 +132,7 @@ func TestE2EBridgeTransfersOptimismPortalETHReceive(t *testing.T) {
 		return l1Header != nil && l1Header.Number.Uint64() >= portalDepositReceipt.BlockNumber.Uint64(), nil
 	}))
 
-	aliceDeposits, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 1)
+	aliceDeposits, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 1, database.BridgeTransfersFilter{})
 	require.NoError(t, err)
 	require.NotNil(t, aliceDeposits)
 	require.Len(t, aliceDeposits.Deposits, 1)
@@ -146,7 +159,7 @@ func TestE2EBridgeTransfersOptimismPortalETHReceive(t *testing.T) {
 	}))
 
 	// Still nil as the withdrawal did not occur through the standard bridge
-	aliceDeposits, err = testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 1)
+	aliceDeposits, err = testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 1, database.BridgeTransfersFilter{})
 	require.NoError(t, err)
 	require.Nil(t, aliceDeposits.Deposits[0].L1BridgeDeposit.CrossDomainMessageHash)
 }
@@ -188,27 +201,27 @@ func TestE2EBridgeTransfersCursoredDeposits(t *testing.T) {
 	}))
 
 	// Get All
-	aliceDeposits, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 3)
+	aliceDeposits, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 3, database.BridgeTransfersFilter{})
 	require.NotNil(t, aliceDeposits)
 	require.NoError(t, err)
 	require.Len(t, aliceDeposits.Deposits, 3)
 	require.False(t, aliceDeposits.HasNextPage)
 
 	// Respects Limits & Supplied Cursors
-	aliceDeposits, err = testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 2)
+	aliceDeposits, err = testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 2, database.BridgeTransfersFilter{})
 	require.NotNil(t, aliceDeposits)
 	require.NoError(t, err)
 	require.Len(t, aliceDeposits.Deposits, 2)
 	require.True(t, aliceDeposits.HasNextPage)
 
-	aliceDeposits, err = testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, aliceDeposits.Cursor, 1)
+	aliceDeposits, err = testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, aliceDeposits.Cursor, 1, database.BridgeTransfersFilter{})
 	require.NoError(t, err)
 	require.NotNil(t, aliceDeposits)
 	require.Len(t, aliceDeposits.Deposits, 1)
 	require.False(t, aliceDeposits.HasNextPage)
 
 	// Returns the results in the right order
-	aliceDeposits, err = testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 3)
+	aliceDeposits, err = testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 3, database.BridgeTransfersFilter{})
 	require.NotNil(t, aliceDeposits)
 	require.NoError(t, err)
 	for i := 0; i < 3; i++ {
@@ -220,6 +233,81 @@ func TestE2EBridgeTransfersCursoredDeposits(t *testing.T) {
 	}
 }
 
+func TestE2EBridgeTransfersDepositsCursorStability(t *testing.T) {
+	testSuite := createE2ETestSuite(t)
+
+	l1StandardBridge, err := bindings.NewL1StandardBridge(testSuite.OpCfg.L1Deployments.L1StandardBridgeProxy, testSuite.L1Client)
+	require.NoError(t, err)
+
+	aliceAddr := testSuite.OpCfg.Secrets.Addresses().Alice
+	l1Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L1ChainIDBig())
+	require.NoError(t, err)
+
+	deposit := func(i int) *types.Receipt {
+		l1Opts.Value = big.NewInt(int64(i+1) * params.Ether)
+		depositTx, err := transactions.PadGasEstimate(l1Opts, 1.1, func(opts *bind.TransactOpts) (*types.Transaction, error) { return l1StandardBridge.Receive(opts) })
+		require.NoError(t, err)
+		depositReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L1Client, depositTx.Hash())
+		require.NoError(t, err, fmt.Sprintf("failed on deposit %d", i))
+
+		// wait for processor catchup
+		require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+			l1Header := testSuite.Indexer.BridgeProcessor.LastL1Header
+			return l1Header != nil && l1Header.Number.Uint64() >= depositReceipt.BlockNumber.Uint64(), nil
+		}))
+		return depositReceipt
+	}
+
+	var depositReceipts []*types.Receipt
+	for i := 0; i < 3; i++ {
+		depositReceipts = append(depositReceipts, deposit(i))
+	}
+
+	// First page of the 3 deposits
+	firstPage, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 2, database.BridgeTransfersFilter{})
+	require.NoError(t, err)
+	require.Len(t, firstPage.Deposits, 2)
+	require.True(t, firstPage.HasNextPage)
+	require.Equal(t, depositReceipts[2].TxHash, firstPage.Deposits[0].L1TransactionHash)
+	require.Equal(t, depositReceipts[1].TxHash, firstPage.Deposits[1].L1TransactionHash)
+
+	// A deposit is indexed while paginating
+	depositReceipts = append(depositReceipts, deposit(3))
+
+	// The next page continues after the first page, without repeating or skipping deposits
+	secondPage, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, firstPage.Cursor, 2, database.BridgeTransfersFilter{})
+	require.NoError(t, err)
+	require.Len(t, secondPage.Deposits, 1)
+	require.False(t, secondPage.HasNextPage)
+	require.Equal(t, depositReceipts[0].TxHash, secondPage.Deposits[0].L1TransactionHash)
+
+	// The new deposit is at the start of a new first page
+	firstPage, err = testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 2, database.BridgeTransfersFilter{})
+	require.NoError(t, err)
+	require.Equal(t, depositReceipts[3].TxHash, firstPage.Deposits[0].L1TransactionHash)
+
+	// Filters are applied before paginating
+	allDeposits, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 100, database.BridgeTransfersFilter{})
+	require.NoError(t, err)
+	require.Len(t, allDeposits.Deposits, 4)
+	secondTimestamp := allDeposits.Deposits[2].L1BridgeDeposit.Tx.Timestamp
+
+	filtered, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 100, database.BridgeTransfersFilter{ToTimestamp: secondTimestamp})
+	require.NoError(t, err)
+	require.NotEmpty(t, filtered.Deposits)
+	for _, deposit := range filtered.Deposits {
+		require.LessOrEqual(t, deposit.L1BridgeDeposit.Tx.Timestamp, secondTimestamp)
+	}
+
+	filtered, err = testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 100, database.BridgeTransfersFilter{TokenAddress: predeploys.LegacyERC20ETHAddr})
+	require.NoError(t, err)
+	require.Len(t, filtered.Deposits, 4)
+
+	filtered, err = testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 100, database.BridgeTransfersFilter{TokenAddress: common.HexToAddress("0x123")})
+	require.NoError(t, err)
+	require.Empty(t, filtered.Deposits)
+}
+
 func TestE2EBridgeTransfersStandardBridgeETHWithdrawal(t *testing.T) {
 	testSuite := createE2ETestSuite(t)
 
@@ -255,7 +343,7 @@ func TestE2EBridgeTransfersStandardBridgeETHWithdrawal(t *testing.T) {
 		return l2Header != nil && l2Header.Number.Uint64() >= withdrawReceipt.BlockNumber.Uint64(), nil
 	}))
 
-	aliceWithdrawals, err := testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, "", 3)
+	aliceWithdrawals, err := testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, "", 3, database.L2BridgeWithdrawalsFilter{})
 	require.NoError(t, err)
 	require.Len(t, aliceWithdrawals.Withdrawals, 1)
 	require.Equal(t, withdrawTx.Hash().String(), aliceWithdrawals.Withdrawals[0].L2TransactionHash.String())
@@ -293,7 +381,7 @@ func TestE2EBridgeTransfersStandardBridgeETHWithdrawal(t *testing.T) {
 		return l1Header != nil && l1Header.Number.Uint64() >= finalizeReceipt.BlockNumber.Uint64(), nil
 	}))
 
-	aliceWithdrawals, err = testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, "", 100)
+	aliceWithdrawals, err = testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, "", 100, database.L2BridgeWithdrawalsFilter{})
 	require.NoError(t, err)
 	require.Equal(t, proveReceipt.TxHash, aliceWithdrawals.Withdrawals[0].ProvenL1TransactionHash)
 	require.Equal(t, finalizeReceipt.TxHash, aliceWithdrawals.Withdrawals[0].FinalizedL1TransactionHash)
@@ -303,6 +391,16 @@ func TestE2EBridgeTransfersStandardBridgeETHWithdrawal(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, crossDomainBridgeMessage)
 	require.NotNil(t, crossDomainBridgeMessage.RelayedMessageEventGUID)
+
+	// (3) Test the L2 & L1 sides of the withdrawal are matched
+	aliceTransfers, err := testSuite.DB.BridgeTransfers.CrossDomainWithdrawalsByAddress(aliceAddr, "", 100, database.BridgeTransfersFilter{})
+	require.NoError(t, err)
+	require.Len(t, aliceTransfers.Transfers, 1)
+	transfer := aliceTransfers.Transfers[0]
+	require.Equal(t, *withdrawal.CrossDomainMessageHash, transfer.CrossDomainTransfer.CrossDomainMessageHash)
+	require.Equal(t, withdrawTx.Hash(), transfer.InitiatedTransactionHash)
+	require.Equal(t, finalizeReceipt.TxHash, transfer.FinalizedTransactionHash)
+	require.NotNil(t, transfer.CrossDomainTransfer.FinalizedL1EventGUID)
 }
 
 func TestE2EBridgeTransfersL2ToL1MessagePasserETHReceive(t *testing.T) {
@@ -339,7 +437,7 @@ func TestE2EBridgeTransfersL2ToL1MessagePasserETHReceive(t *testing.T) {
 		return l2Header != nil && l2Header.Number.Uint64() >= l2ToL1WithdrawReceipt.BlockNumber.Uint64(), nil
 	}))
 
-	aliceWithdrawals, err := testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, "", 100)
+	aliceWithdrawals, err := testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, "", 100, database.L2BridgeWithdrawalsFilter{})
 	require.NoError(t, err)
 	require.Len(t, aliceWithdrawals.Withdrawals, 1)
 	require.Equal(t, l2ToL1MessagePasserWithdrawTx.Hash().String(), aliceWithdrawals.Withdrawals[0].L2TransactionHash.String())
@@ -372,12 +470,90 @@ func TestE2EBridgeTransfersL2ToL1MessagePasserETHReceive(t *testing.T) {
 		return l1Header != nil && l1Header.Number.Uint64() >= finalizeReceipt.BlockNumber.Uint64(), nil
 	}))
 
-	aliceWithdrawals, err = testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, "", 100)
+	aliceWithdrawals, err = testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, "", 100, database.L2BridgeWithdrawalsFilter{})
 	require.NoError(t, err)
 	require.Equal(t, proveReceipt.TxHash, aliceWithdrawals.Withdrawals[0].ProvenL1TransactionHash)
 	require.Equal(t, finalizeReceipt.TxHash, aliceWithdrawals.Withdrawals[0].FinalizedL1TransactionHash)
 }
 
+func TestE2EBridgeTransfersWithdrawalStatus(t *testing.T) {
+	testSuite := createE2ETestSuite(t)
+
+	optimismPortal, err := bindings.NewOptimismPortal(testSuite.OpCfg.L1Deployments.OptimismPortalProxy, testSuite.L1Client)
+	require.NoError(t, err)
+	l2ToL1MessagePasser, err := bindings.NewOptimismPortal(predeploys.L2ToL1MessagePasserAddr, testSuite.L2Client)
+	require.NoError(t, err)
+
+	aliceAddr := testSuite.OpCfg.Secrets.Addresses().Alice
+	l2Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L2ChainIDBig())
+	require.NoError(t, err)
+	l2Opts.Value = big.NewInt(params.Ether)
+
+	// Ensure L1 has enough funds for the withdrawal by depositing an equal amount into the OptimismPortal
+	l1Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L1ChainIDBig())
+	require.NoError(t, err)
+	l1Opts.Value = l2Opts.Value
+	depositTx, err := optimismPortal.Receive(l1Opts)
+	require.NoError(t, err)
+	_, err = wait.ForReceiptOK(context.Background(), testSuite.L1Client, depositTx.Hash())
+	require.NoError(t, err)
+
+	aliceWithdrawal := func() models.WithdrawalItem {
+		withdrawals, err := testSuite.Client.GetAllWithdrawalsByAddress(aliceAddr)
+		require.NoError(t, err)
+		require.Len(t, withdrawals, 1)
+		return withdrawals[0]
+	}
+
+	// (1) Initiated
+	withdrawTx, err := l2ToL1MessagePasser.Receive(l2Opts)
+	require.NoError(t, err)
+	withdrawReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L2Client, withdrawTx.Hash())
+	require.NoError(t, err)
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l2Header := testSuite.Indexer.BridgeProcessor.LastL2Header
+		return l2Header != nil && l2Header.Number.Uint64() >= withdrawReceipt.BlockNumber.Uint64(), nil
+	}))
+
+	withdrawal := aliceWithdrawal()
+	require.Equal(t, withdrawTx.Hash().String(), withdrawal.TransactionHash)
+	require.Equal(t, models.WithdrawalStatusInitiated, withdrawal.Status)
+	require.Zero(t, withdrawal.L1ProvenTimestamp)
+	require.Zero(t, withdrawal.L1FinalizableTimestamp)
+
+	// (2) Proven. The finalization period of the devnet is short, so the withdrawal may be ready already
+	proofParams, proveReceipt := op_e2e.ProveWithdrawal(t, *testSuite.OpCfg, testSuite.OpSys, "sequencer", testSuite.OpCfg.Secrets.Alice, withdrawReceipt)
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l1Header := testSuite.Indexer.BridgeProcessor.LastFinalizedL1Header
+		return l1Header != nil && l1Header.Number.Uint64() >= proveReceipt.BlockNumber.Uint64(), nil
+	}))
+	proveHeader, err := testSuite.L1Client.HeaderByHash(context.Background(), proveReceipt.BlockHash)
+	require.NoError(t, err)
+
+	withdrawal = aliceWithdrawal()
+	require.Contains(t, []models.WithdrawalStatus{models.WithdrawalStatusProven, models.WithdrawalStatusReady}, withdrawal.Status)
+	require.Equal(t, proveReceipt.TxHash.String(), withdrawal.L1ProvenTxHash)
+	require.Equal(t, proveHeader.Time, withdrawal.L1ProvenTimestamp)
+	require.Equal(t, proveHeader.Time+testSuite.OpCfg.DeployConfig.FinalizationPeriodSeconds, withdrawal.L1FinalizableTimestamp)
+
+	// (3) Ready once the finalization period has passed
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		return aliceWithdrawal().Status == models.WithdrawalStatusReady, nil
+	}))
+
+	// (4) Finalized
+	finalizeReceipt := op_e2e.FinalizeWithdrawal(t, *testSuite.OpCfg, testSuite.L1Client, testSuite.OpCfg.Secrets.Alice, proveReceipt, proofParams)
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l1Header := testSuite.Indexer.BridgeProcessor.LastFinalizedL1Header
+		return l1Header != nil && l1Header.Number.Uint64() >= finalizeReceipt.BlockNumber.Uint64(), nil
+	}))
+
+	withdrawal = aliceWithdrawal()
+	require.Equal(t, models.WithdrawalStatusFinalized, withdrawal.Status)
+	require.Equal(t, finalizeReceipt.TxHash.String(), withdrawal.L1FinalizedTxHash)
+	require.Equal(t, proveReceipt.TxHash.String(), withdrawal.L1ProvenTxHash)
+}
+
 func TestE2EBridgeTransfersCursoredWithdrawals(t *testing.T) {
 	testSuite := createE2ETestSuite(t)
 
@@ -416,27 +592,27 @@ func TestE2EBridgeTransfersCursoredWithdrawals(t *testing.T) {
 	}))
 
 	// Get All
-	aliceWithdrawals, err := testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, "", 100)
+	aliceWithdrawals, err := testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, "", 100, database.L2BridgeWithdrawalsFilter{})
 	require.NotNil(t, aliceWithdrawals)
 	require.NoError(t, err)
 	require.Len(t, aliceWithdrawals.Withdrawals, 3)
 	require.False(t, aliceWithdrawals.HasNextPage)
 
 	// Respects Limits & Supplied Cursors
-	aliceWithdrawals, err = testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, "", 2)
+	aliceWithdrawals, err = testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, "", 2, database.L2BridgeWithdrawalsFilter{})
 	require.NotNil(t, aliceWithdrawals)
 	require.NoError(t, err)
 	require.Len(t, aliceWithdrawals.Withdrawals, 2)
 	require.True(t, aliceWithdrawals.HasNextPage)
 
-	aliceWithdrawals, err = testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, aliceWithdrawals.Cursor, 1)
+	aliceWithdrawals, err = testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, aliceWithdrawals.Cursor, 1, database.L2BridgeWithdrawalsFilter{})
 	require.NotNil(t, aliceWithdrawals)
 	require.NoError(t, err)
 	require.Len(t, aliceWithdrawals.Withdrawals, 1)
 	require.False(t, aliceWithdrawals.HasNextPage)
 
 	// Returns the results in the right order
-	aliceWithdrawals, err = testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, "", 100)
+	aliceWithdrawals, err = testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByAddress(aliceAddr, "", 100, database.L2BridgeWithdrawalsFilter{})
 	require.NotNil(t, aliceWithdrawals)
 	require.NoError(t, err)
 	for i := 0; i < 3; i++ {
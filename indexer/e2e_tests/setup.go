@@ -88,9 +88,10 @@ func createE2ETestSuite(t *testing.T) E2ETestSuite {
 			L2RPC: opSys.EthInstances["sequencer"].HTTPEndpoint(),
 		},
 		Chain: config.ChainConfig{
-			L1PollingInterval: uint(opCfg.DeployConfig.L1BlockTime) * 1000,
-			L2PollingInterval: uint(opCfg.DeployConfig.L2BlockTime) * 1000,
-			L2Contracts:       config.L2ContractsFromPredeploys(),
+			L1PollingInterval:         uint(opCfg.DeployConfig.L1BlockTime) * 1000,
+			L2PollingInterval:         uint(opCfg.DeployConfig.L2BlockTime) * 1000,
+			FinalizationPeriodSeconds: opCfg.DeployConfig.FinalizationPeriodSeconds,
+			L2Contracts:               config.L2ContractsFromPredeploys(),
 			L1Contracts: config.L1Contracts{
 				AddressManager:              opCfg.L1Deployments.AddressManager,
 				SystemConfigProxy:           opCfg.L1Deployments.SystemConfigProxy,
@@ -135,6 +136,7 @@ func createE2ETestSuite(t *testing.T) E2ETestSuite {
 			Host: "127.0.0.1",
 			Port: 0,
 		},
+		FinalizationPeriodSeconds: indexerCfg.Chain.FinalizationPeriodSeconds,
 	}
 
 	apiService, err := api.NewApi(context.Background(), apiLog, apiCfg)
@@ -22,6 +22,7 @@ type Config struct {
 
 	StartHeight       *big.Int
 	ConfirmationDepth *big.Int
+	MaxReorgDepth     uint64
 }
 
 type ETL struct {
@@ -41,6 +42,12 @@ type ETL struct {
 	// in the event of failures in order to retry.
 	headers []types.Header
 
+	// The common ancestor of a detected reorg, that'll stay populated
+	// until the headers of the canonical chain after it are processed
+	reorgAncestor *types.Header
+
+	status *SyncStatus
+
 	worker *clock.LoopFn
 }
 
@@ -50,6 +57,10 @@ type ETLBatch struct {
 	Headers   []types.Header
 	HeaderMap map[common.Hash]*types.Header
 
+	// CommonAncestor is set when the indexed headers after it were reorged. The state
+	// derived from these orphaned headers must be removed before indexing the batch
+	CommonAncestor *types.Header
+
 	Logs           []types.Log
 	HeadersWithLog map[common.Hash]bool
 }
@@ -67,6 +78,11 @@ func (etl *ETL) Start() error {
 	return nil
 }
 
+// SyncStatus returns the indexing progress published by the ETL
+func (etl *ETL) SyncStatus() *SyncStatus {
+	return etl.status
+}
+
 func (etl *ETL) Close() error {
 	if etl.worker == nil {
 		return nil // worker was not running
@@ -80,7 +96,20 @@ func (etl *ETL) tick(_ context.Context) {
 		etl.log.Info("retrying previous batch")
 	} else {
 		newHeaders, err := etl.headerTraversal.NextHeaders(etl.headerBufferSize)
-		if err != nil {
+
+		var reorg *node.ReorgError
+		if errors.As(err, &reorg) {
+			etl.log.Warn("detected reorg, indexing canonical chain from common ancestor", "depth", reorg.Depth,
+				"common_ancestor_number", reorg.CommonAncestor.Number, "common_ancestor_hash", reorg.CommonAncestor.Hash())
+			etl.metrics.RecordReorg(reorg.Depth)
+			etl.reorgAncestor = reorg.CommonAncestor
+			newHeaders, err = etl.headerTraversal.NextHeaders(etl.headerBufferSize)
+		}
+
+		if errors.Is(err, node.ErrHeaderTraversalReorgTooDeep) {
+			etl.log.Error("detected reorg deeper than the max reorg depth. manual intervention required", "err", err)
+			etl.metrics.RecordReorgTooDeep()
+		} else if err != nil {
 			etl.log.Error("error querying for headers", "err", err)
 		} else if len(newHeaders) == 0 {
 			etl.log.Warn("no new headers. etl at head?")
@@ -94,10 +123,11 @@ func (etl *ETL) tick(_ context.Context) {
 		}
 	}
 
-	// only clear the reference if we were able to process this batch
+	// only clear the references if we were able to process this batch
 	err := etl.processBatch(etl.headers)
 	if err == nil {
 		etl.headers = nil
+		etl.reorgAncestor = nil
 	}
 
 	done(err)
@@ -105,6 +135,12 @@ func (etl *ETL) tick(_ context.Context) {
 
 func (etl *ETL) processBatch(headers []types.Header) error {
 	if len(headers) == 0 {
+		if etl.reorgAncestor != nil {
+			// The canonical chain may not have new headers after the common ancestor yet
+			batchLog := etl.log.New("common_ancestor_number", etl.reorgAncestor.Number)
+			batchLog.Info("no canonical headers after reorg")
+			etl.etlBatches <- &ETLBatch{Logger: batchLog, HeaderMap: map[common.Hash]*types.Header{}, HeadersWithLog: map[common.Hash]bool{}, CommonAncestor: etl.reorgAncestor}
+		}
 		return nil
 	}
 
@@ -153,6 +189,6 @@ func (etl *ETL) processBatch(headers []types.Header) error {
 
 	// ensure we use unique downstream references for the etl batch
 	headersRef := headers
-	etl.etlBatches <- &ETLBatch{Logger: batchLog, Headers: headersRef, HeaderMap: headerMap, Logs: logs.Logs, HeadersWithLog: headersWithLog}
+	etl.etlBatches <- &ETLBatch{Logger: batchLog, Headers: headersRef, HeaderMap: headerMap, Logs: logs.Logs, HeadersWithLog: headersWithLog, CommonAncestor: etl.reorgAncestor}
 	return nil
 }
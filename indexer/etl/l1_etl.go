@@ -66,9 +66,22 @@ func NewL1ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, cli
 
 	// Determine the starting height for traversal
 	var fromHeader *types.Header
+	var fromHeaders []types.Header
 	if latestHeader != nil {
 		log.Info("detected last indexed block", "number", latestHeader.Number, "hash", latestHeader.Hash)
 		fromHeader = latestHeader.RLPHeader.Header()
+		fromHeaders = []types.Header{*fromHeader}
+		if cfg.MaxReorgDepth > 0 {
+			// detect reorgs of the recently indexed blocks after restarts
+			recentHeaders, err := db.Blocks.L1LatestBlockHeaders(int(cfg.MaxReorgDepth) + 1)
+			if err != nil {
+				return nil, err
+			}
+			fromHeaders = make([]types.Header, len(recentHeaders))
+			for i := range recentHeaders {
+				fromHeaders[i] = *recentHeaders[i].RLPHeader.Header()
+			}
+		}
 	} else if cfg.StartHeight.BitLen() > 0 {
 		log.Info("no indexed state starting from supplied L1 height", "height", cfg.StartHeight.String())
 		header, err := client.BlockHeaderByNumber(cfg.StartHeight)
@@ -77,6 +90,7 @@ func NewL1ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, cli
 		}
 
 		fromHeader = header
+		fromHeaders = []types.Header{*header}
 	} else {
 		log.Info("no indexed state, starting from genesis")
 	}
@@ -92,9 +106,10 @@ func NewL1ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, cli
 
 		log:             log,
 		metrics:         metrics,
-		headerTraversal: node.NewHeaderTraversal(client, fromHeader, cfg.ConfirmationDepth),
+		headerTraversal: node.NewHeaderTraversal(client, fromHeaders, cfg.ConfirmationDepth, cfg.MaxReorgDepth),
 		contracts:       l1Contracts,
 		etlBatches:      etlBatches,
+		status:          NewSyncStatus(fromHeader),
 
 		EthClient: client,
 	}
@@ -161,8 +176,10 @@ func (l1Etl *L1ETL) handleBatch(batch *ETLBatch) error {
 		}
 	}
 
-	if len(l1BlockHeaders) == 0 {
+	if len(l1BlockHeaders) == 0 && batch.CommonAncestor == nil {
 		batch.Logger.Info("no l1 blocks with logs in batch")
+		// nothing to persist, but the headers of the batch have been traversed
+		l1Etl.status.setIndexedHeader(&batch.Headers[len(batch.Headers)-1])
 		return nil
 	}
 
@@ -177,6 +194,15 @@ func (l1Etl *L1ETL) handleBatch(batch *ETLBatch) error {
 	retryStrategy := &retry.ExponentialStrategy{Min: 1000, Max: 20_000, MaxJitter: 250}
 	if _, err := retry.Do[interface{}](l1Etl.resourceCtx, 10, retryStrategy, func() (interface{}, error) {
 		if err := l1Etl.db.Transaction(func(tx *database.DB) error {
+			// remove the orphaned state within the same transaction as the canonical blocks are indexed
+			if batch.CommonAncestor != nil {
+				if err := tx.Blocks.DeleteL1BlockHeadersAfter(batch.CommonAncestor.Number); err != nil {
+					return err
+				}
+			}
+			if len(l1BlockHeaders) == 0 {
+				return nil
+			}
 			if err := tx.Blocks.StoreL1BlockHeaders(l1BlockHeaders); err != nil {
 				return err
 			}
@@ -191,7 +217,9 @@ func (l1Etl *L1ETL) handleBatch(batch *ETLBatch) error {
 		}
 
 		l1Etl.ETL.metrics.RecordIndexedHeaders(len(l1BlockHeaders))
-		l1Etl.ETL.metrics.RecordIndexedLatestHeight(l1BlockHeaders[len(l1BlockHeaders)-1].Number)
+		if len(l1BlockHeaders) > 0 {
+			l1Etl.ETL.metrics.RecordIndexedLatestHeight(l1BlockHeaders[len(l1BlockHeaders)-1].Number)
+		}
 
 		// a-ok!
 		return nil, nil
@@ -200,7 +228,12 @@ func (l1Etl *L1ETL) handleBatch(batch *ETLBatch) error {
 	}
 
 	batch.Logger.Info("indexed batch")
-	l1Etl.LatestHeader = &batch.Headers[len(batch.Headers)-1]
+	if len(batch.Headers) > 0 {
+		l1Etl.LatestHeader = &batch.Headers[len(batch.Headers)-1]
+	} else {
+		l1Etl.LatestHeader = batch.CommonAncestor
+	}
+	l1Etl.status.setIndexedHeader(l1Etl.LatestHeader)
 
 	// Notify Listeners
 	l1Etl.mu.Lock()
@@ -23,10 +23,11 @@ func TestL1ETLConstruction(t *testing.T) {
 	etlMetrics := NewMetrics(metrics.NewRegistry(), "l1")
 
 	type testSuite struct {
-		db        *database.MockDB
-		client    *node.MockEthClient
-		start     *big.Int
-		contracts config.L1Contracts
+		db            *database.MockDB
+		client        *node.MockEthClient
+		start         *big.Int
+		maxReorgDepth uint64
+		contracts     config.L1Contracts
 	}
 
 	var tests = []struct {
@@ -46,6 +47,7 @@ func TestL1ETLConstruction(t *testing.T) {
 				client.On("BlockHeaderByNumber", mock.MatchedBy(
 					bigint.Matcher(100))).Return(
 					&types.Header{
+						Number:     testStart,
 						ParentHash: common.HexToHash("0x69"),
 					}, nil)
 
@@ -96,6 +98,37 @@ func TestL1ETLConstruction(t *testing.T) {
 				require.NoError(t, err)
 				header := etl.headerTraversal.LastTraversedHeader()
 
+				require.True(t, header.Number.Cmp(big.NewInt(69)) == 0)
+			},
+		},
+		{
+			name: "Detect reorgs of recent headers stored in DB",
+			construction: func() *testSuite {
+				client := new(node.MockEthClient)
+				db := database.NewMockDB()
+
+				recentHeaders := make([]database.L1BlockHeader, 3)
+				for i := range recentHeaders {
+					header := &types.Header{Number: big.NewInt(int64(67 + i))}
+					recentHeaders[i] = database.L1BlockHeader{BlockHeader: database.BlockHeaderFromHeader(header)}
+				}
+				db.MockBlocks.On("L1LatestBlockHeader").Return(&recentHeaders[2], nil)
+				db.MockBlocks.On("L1LatestBlockHeaders", 11).Return(recentHeaders, nil)
+
+				return &testSuite{
+					db:            db,
+					client:        client,
+					start:         big.NewInt(100),
+					maxReorgDepth: 10,
+
+					// utilize sample l1 contract configuration (optimism)
+					contracts: config.Presets[10].ChainConfig.L1Contracts,
+				}
+			},
+			assertion: func(etl *L1ETL, err error) {
+				require.NoError(t, err)
+				header := etl.headerTraversal.LastTraversedHeader()
+
 				require.True(t, header.Number.Cmp(big.NewInt(69)) == 0)
 			},
 		},
@@ -106,7 +139,7 @@ func TestL1ETLConstruction(t *testing.T) {
 			ts := test.construction()
 
 			logger := testlog.Logger(t, log.LvlInfo)
-			cfg := Config{StartHeight: ts.start}
+			cfg := Config{StartHeight: ts.start, MaxReorgDepth: ts.maxReorgDepth}
 
 			etl, err := NewL1ETL(cfg, logger, ts.db.DB, etlMetrics, ts.client, ts.contracts, func(cause error) {
 				t.Fatalf("crit error: %v", cause)
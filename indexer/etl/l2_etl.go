@@ -62,9 +62,22 @@ func NewL2ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, cli
 	}
 
 	var fromHeader *types.Header
+	var fromHeaders []types.Header
 	if latestHeader != nil {
 		log.Info("detected last indexed block", "number", latestHeader.Number, "hash", latestHeader.Hash)
 		fromHeader = latestHeader.RLPHeader.Header()
+		fromHeaders = []types.Header{*fromHeader}
+		if cfg.MaxReorgDepth > 0 {
+			// detect reorgs of the recently indexed blocks after restarts
+			recentHeaders, err := db.Blocks.L2LatestBlockHeaders(int(cfg.MaxReorgDepth) + 1)
+			if err != nil {
+				return nil, err
+			}
+			fromHeaders = make([]types.Header, len(recentHeaders))
+			for i := range recentHeaders {
+				fromHeaders[i] = *recentHeaders[i].RLPHeader.Header()
+			}
+		}
 	} else {
 		log.Info("no indexed state, starting from genesis")
 	}
@@ -76,9 +89,10 @@ func NewL2ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, cli
 
 		log:             log,
 		metrics:         metrics,
-		headerTraversal: node.NewHeaderTraversal(client, fromHeader, cfg.ConfirmationDepth),
+		headerTraversal: node.NewHeaderTraversal(client, fromHeaders, cfg.ConfirmationDepth, cfg.MaxReorgDepth),
 		contracts:       l2Contracts,
 		etlBatches:      etlBatches,
+		status:          NewSyncStatus(fromHeader),
 
 		EthClient: client,
 	}
@@ -154,6 +168,15 @@ func (l2Etl *L2ETL) handleBatch(batch *ETLBatch) error {
 	retryStrategy := &retry.ExponentialStrategy{Min: 1000, Max: 20_000, MaxJitter: 250}
 	if _, err := retry.Do[interface{}](l2Etl.resourceCtx, 10, retryStrategy, func() (interface{}, error) {
 		if err := l2Etl.db.Transaction(func(tx *database.DB) error {
+			// remove the orphaned state within the same transaction as the canonical blocks are indexed
+			if batch.CommonAncestor != nil {
+				if err := tx.Blocks.DeleteL2BlockHeadersAfter(batch.CommonAncestor.Number); err != nil {
+					return err
+				}
+			}
+			if len(l2BlockHeaders) == 0 {
+				return nil
+			}
 			if err := tx.Blocks.StoreL2BlockHeaders(l2BlockHeaders); err != nil {
 				return err
 			}
@@ -169,7 +192,9 @@ func (l2Etl *L2ETL) handleBatch(batch *ETLBatch) error {
 		}
 
 		l2Etl.ETL.metrics.RecordIndexedHeaders(len(l2BlockHeaders))
-		l2Etl.ETL.metrics.RecordIndexedLatestHeight(l2BlockHeaders[len(l2BlockHeaders)-1].Number)
+		if len(l2BlockHeaders) > 0 {
+			l2Etl.ETL.metrics.RecordIndexedLatestHeight(l2BlockHeaders[len(l2BlockHeaders)-1].Number)
+		}
 
 		// a-ok!
 		return nil, nil
@@ -178,7 +203,12 @@ func (l2Etl *L2ETL) handleBatch(batch *ETLBatch) error {
 	}
 
 	batch.Logger.Info("indexed batch")
-	l2Etl.LatestHeader = &batch.Headers[len(batch.Headers)-1]
+	if len(batch.Headers) > 0 {
+		l2Etl.LatestHeader = &batch.Headers[len(batch.Headers)-1]
+	} else {
+		l2Etl.LatestHeader = batch.CommonAncestor
+	}
+	l2Etl.status.setIndexedHeader(l2Etl.LatestHeader)
 
 	// Notify Listeners
 	l2Etl.mu.Lock()
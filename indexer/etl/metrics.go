@@ -20,6 +20,10 @@ type Metricer interface {
 	RecordIndexedLatestHeight(height *big.Int)
 	RecordIndexedHeaders(size int)
 	RecordIndexedLog(contractAddress common.Address)
+
+	// Reorgs
+	RecordReorg(depth uint64)
+	RecordReorgTooDeep()
 }
 
 type etlMetrics struct {
@@ -31,6 +35,10 @@ type etlMetrics struct {
 	indexedLatestHeight prometheus.Gauge
 	indexedHeaders      prometheus.Counter
 	indexedLogs         *prometheus.CounterVec
+
+	reorgs        prometheus.Counter
+	reorgDepth    prometheus.Gauge
+	reorgsTooDeep prometheus.Counter
 }
 
 func NewMetrics(registry *prometheus.Registry, subsystem string) Metricer {
@@ -80,6 +88,24 @@ func NewMetrics(registry *prometheus.Registry, subsystem string) Metricer {
 		}, []string{
 			"contract",
 		}),
+		reorgs: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Subsystem: subsystem,
+			Name:      "reorgs_total",
+			Help:      "number of reorgs of indexed headers detected by the etl",
+		}),
+		reorgDepth: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Subsystem: subsystem,
+			Name:      "reorg_depth",
+			Help:      "the depth of the last reorg detected by the etl",
+		}),
+		reorgsTooDeep: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Subsystem: subsystem,
+			Name:      "reorgs_too_deep_total",
+			Help:      "number of times the etl detected a reorg deeper than the max reorg depth",
+		}),
 	}
 }
 
@@ -109,3 +135,12 @@ func (m *etlMetrics) RecordIndexedHeaders(size int) {
 func (m *etlMetrics) RecordIndexedLog(addr common.Address) {
 	m.indexedLogs.WithLabelValues(addr.String()).Inc()
 }
+
+func (m *etlMetrics) RecordReorg(depth uint64) {
+	m.reorgs.Inc()
+	m.reorgDepth.Set(float64(depth))
+}
+
+func (m *etlMetrics) RecordReorgTooDeep() {
+	m.reorgsTooDeep.Inc()
+}
@@ -0,0 +1,32 @@
+package etl
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// SyncStatus is the indexing progress of an ETL. It is published by the indexing loop
+// and can be read concurrently, i.e by the health checks of the indexer.
+type SyncStatus struct {
+	mu            sync.RWMutex
+	indexedHeader *types.Header
+}
+
+// NewSyncStatus creates a SyncStatus starting from the supplied header, nil if nothing has been indexed yet
+func NewSyncStatus(indexedHeader *types.Header) *SyncStatus {
+	return &SyncStatus{indexedHeader: indexedHeader}
+}
+
+// IndexedHeader returns the last header the ETL has processed, nil if nothing has been indexed yet
+func (s *SyncStatus) IndexedHeader() *types.Header {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.indexedHeader
+}
+
+func (s *SyncStatus) setIndexedHeader(header *types.Header) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.indexedHeader = header
+}
@@ -0,0 +1,188 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethereum-optimism/optimism/indexer/etl"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const (
+	HealthMetricsNamespace = "op_indexer_health"
+
+	healthCheckInterval = 10 * time.Second
+)
+
+// ChainHealth is the sync status of an indexed chain
+type ChainHealth struct {
+	IndexedHeight    uint64 `json:"indexedHeight"`
+	IndexedTimestamp uint64 `json:"indexedTimestamp"`
+	HeadHeight       uint64 `json:"headHeight"`
+	HeadTimestamp    uint64 `json:"headTimestamp"`
+
+	LagBlocks  uint64 `json:"lagBlocks"`
+	LagSeconds uint64 `json:"lagSeconds"`
+
+	// Healthy is unset when the chain head is unknown or the
+	// indexed blocks lag behind it by more than the max lag
+	Healthy bool `json:"healthy"`
+}
+
+// HealthStatus is the sync status of the indexer served by the `/healthz` endpoint
+type HealthStatus struct {
+	L1      ChainHealth `json:"l1"`
+	L2      ChainHealth `json:"l2"`
+	Healthy bool        `json:"healthy"`
+}
+
+// chainHealthCheck compares the indexing progress of a chain with the head reported by its client
+type chainHealthCheck struct {
+	client     node.EthClient
+	syncStatus *etl.SyncStatus
+	maxLag     uint64
+
+	// head stays populated between checks in the event of failures to query the client
+	head *types.Header
+}
+
+func (c *chainHealthCheck) check(log log.Logger) ChainHealth {
+	head, err := c.client.BlockHeaderByNumber(nil)
+	if err != nil {
+		log.Warn("unable to query chain head", "err", err)
+	} else if head != nil {
+		c.head = head
+	}
+
+	var health ChainHealth
+	if indexed := c.syncStatus.IndexedHeader(); indexed != nil {
+		health.IndexedHeight, health.IndexedTimestamp = indexed.Number.Uint64(), indexed.Time
+	}
+	if c.head == nil {
+		return health
+	}
+
+	health.HeadHeight, health.HeadTimestamp = c.head.Number.Uint64(), c.head.Time
+	if health.HeadHeight > health.IndexedHeight {
+		health.LagBlocks = health.HeadHeight - health.IndexedHeight
+	}
+	if health.HeadTimestamp > health.IndexedTimestamp {
+		health.LagSeconds = health.HeadTimestamp - health.IndexedTimestamp
+	}
+	health.Healthy = health.LagSeconds <= c.maxLag
+	return health
+}
+
+// healthMonitor periodically checks how far the indexed L1 and L2 blocks lag behind the chain heads
+type healthMonitor struct {
+	log     log.Logger
+	metrics *healthMetrics
+
+	l1, l2 chainHealthCheck
+
+	mu     sync.RWMutex
+	status HealthStatus
+
+	worker *clock.LoopFn
+}
+
+func newHealthMonitor(log log.Logger, registry *prometheus.Registry, l1, l2 chainHealthCheck) *healthMonitor {
+	return &healthMonitor{log: log.New("role", "health"), metrics: newHealthMetrics(registry), l1: l1, l2: l2}
+}
+
+func (m *healthMonitor) Start() {
+	m.check(context.Background())
+	m.worker = clock.NewLoopFn(clock.SystemClock, m.check, nil, healthCheckInterval)
+}
+
+func (m *healthMonitor) Close() error {
+	if m.worker == nil {
+		return nil // worker was not running
+	}
+	return m.worker.Close()
+}
+
+func (m *healthMonitor) check(_ context.Context) {
+	status := HealthStatus{L1: m.l1.check(m.log.New("chain", "l1")), L2: m.l2.check(m.log.New("chain", "l2"))}
+	status.Healthy = status.L1.Healthy && status.L2.Healthy
+	if !status.Healthy {
+		m.log.Warn("indexer is unhealthy", "l1_lag_seconds", status.L1.LagSeconds, "l2_lag_seconds", status.L2.LagSeconds)
+	}
+
+	m.metrics.record("l1", status.L1)
+	m.metrics.record("l2", status.L2)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status = status
+}
+
+// Status returns the result of the last health check
+func (m *healthMonitor) Status() HealthStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status
+}
+
+// ServeHTTP responds with the last health check, with a 503 status code when unhealthy
+func (m *healthMonitor) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	status := m.Status()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !status.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		m.log.Error("failed to encode health status", "err", err)
+	}
+}
+
+type healthMetrics struct {
+	indexedHeight    *prometheus.GaugeVec
+	indexedTimestamp *prometheus.GaugeVec
+	headHeight       *prometheus.GaugeVec
+	headTimestamp    *prometheus.GaugeVec
+	lagBlocks        *prometheus.GaugeVec
+	lagSeconds       *prometheus.GaugeVec
+	healthy          *prometheus.GaugeVec
+}
+
+func newHealthMetrics(registry *prometheus.Registry) *healthMetrics {
+	factory := metrics.With(registry)
+	gaugeVec := func(name, help string) *prometheus.GaugeVec {
+		return factory.NewGaugeVec(prometheus.GaugeOpts{Namespace: HealthMetricsNamespace, Name: name, Help: help}, []string{"chain"})
+	}
+	return &healthMetrics{
+		indexedHeight:    gaugeVec("indexed_height", "the latest block height processed by the etl"),
+		indexedTimestamp: gaugeVec("indexed_timestamp", "the timestamp of the latest block processed by the etl"),
+		headHeight:       gaugeVec("head_height", "the height of the chain head reported by the connected client"),
+		headTimestamp:    gaugeVec("head_timestamp", "the timestamp of the chain head reported by the connected client"),
+		lagBlocks:        gaugeVec("lag_blocks", "the number of blocks the indexer lags behind the chain head"),
+		lagSeconds:       gaugeVec("lag_seconds", "the seconds the indexer lags behind the chain head"),
+		healthy:          gaugeVec("healthy", "1 if the indexer lags behind the chain head by no more than the max lag, 0 otherwise"),
+	}
+}
+
+func (m *healthMetrics) record(chain string, health ChainHealth) {
+	m.indexedHeight.WithLabelValues(chain).Set(float64(health.IndexedHeight))
+	m.indexedTimestamp.WithLabelValues(chain).Set(float64(health.IndexedTimestamp))
+	m.headHeight.WithLabelValues(chain).Set(float64(health.HeadHeight))
+	m.headTimestamp.WithLabelValues(chain).Set(float64(health.HeadTimestamp))
+	m.lagBlocks.WithLabelValues(chain).Set(float64(health.LagBlocks))
+	m.lagSeconds.WithLabelValues(chain).Set(float64(health.LagSeconds))
+	if health.Healthy {
+		m.healthy.WithLabelValues(chain).Set(1)
+	} else {
+		m.healthy.WithLabelValues(chain).Set(0)
+	}
+}
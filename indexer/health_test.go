@@ -0,0 +1,88 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/indexer/bigint"
+	"github.com/ethereum-optimism/optimism/indexer/etl"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestHealthMonitorStalledL2(t *testing.T) {
+	header := func(number, time uint64) *types.Header {
+		return &types.Header{Number: new(big.Int).SetUint64(number), Time: time}
+	}
+
+	// L1 is indexed up to the head, while the L2 indexer is stalled at block 10
+	l1Client, l2Client := &node.MockEthClient{}, &node.MockEthClient{}
+	l1Client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(header(100, 1200), nil)
+	l2Client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(header(12, 124), nil).Once()
+	l2Client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(header(500, 1100), nil)
+
+	registry := prometheus.NewRegistry()
+	monitor := newHealthMonitor(testlog.Logger(t, log.LvlInfo), registry,
+		chainHealthCheck{client: l1Client, syncStatus: etl.NewSyncStatus(header(100, 1200)), maxLag: 900},
+		chainHealthCheck{client: l2Client, syncStatus: etl.NewSyncStatus(header(10, 120)), maxLag: 300},
+	)
+
+	get := func() (int, HealthStatus) {
+		recorder := httptest.NewRecorder()
+		monitor.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+		var status HealthStatus
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+		return recorder.Code, status
+	}
+
+	// L2 head is within the max lag
+	monitor.check(context.Background())
+	code, status := get()
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, status.Healthy)
+	require.Equal(t, ChainHealth{IndexedHeight: 100, IndexedTimestamp: 1200, HeadHeight: 100, HeadTimestamp: 1200, Healthy: true}, status.L1)
+	require.Equal(t, ChainHealth{IndexedHeight: 10, IndexedTimestamp: 120, HeadHeight: 12, HeadTimestamp: 124, LagBlocks: 2, LagSeconds: 4, Healthy: true}, status.L2)
+
+	// L2 head moves past the max lag while the indexer is stalled
+	monitor.check(context.Background())
+	code, status = get()
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.False(t, status.Healthy)
+	require.True(t, status.L1.Healthy)
+	require.Equal(t, ChainHealth{IndexedHeight: 10, IndexedTimestamp: 120, HeadHeight: 500, HeadTimestamp: 1100, LagBlocks: 490, LagSeconds: 980}, status.L2)
+
+	require.Equal(t, float64(980), testutil.ToFloat64(monitor.metrics.lagSeconds.WithLabelValues("l2")))
+	require.Equal(t, float64(490), testutil.ToFloat64(monitor.metrics.lagBlocks.WithLabelValues("l2")))
+	require.Equal(t, float64(0), testutil.ToFloat64(monitor.metrics.healthy.WithLabelValues("l2")))
+	require.Equal(t, float64(1), testutil.ToFloat64(monitor.metrics.healthy.WithLabelValues("l1")))
+}
+
+func TestHealthMonitorUnknownHead(t *testing.T) {
+	l1Client, l2Client := &node.MockEthClient{}, &node.MockEthClient{}
+	l1Client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return((*types.Header)(nil), nil)
+	l2Client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&types.Header{Number: bigint.Zero}, nil)
+
+	monitor := newHealthMonitor(testlog.Logger(t, log.LvlInfo), prometheus.NewRegistry(),
+		chainHealthCheck{client: l1Client, syncStatus: etl.NewSyncStatus(nil), maxLag: 900},
+		chainHealthCheck{client: l2Client, syncStatus: etl.NewSyncStatus(nil), maxLag: 300},
+	)
+
+	// without an L1 head reported, the lag is unknown
+	monitor.check(context.Background())
+	status := monitor.Status()
+	require.False(t, status.Healthy)
+	require.False(t, status.L1.Healthy)
+	require.True(t, status.L2.Healthy)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"net/http"
 	"strconv"
 	"sync/atomic"
 
@@ -35,8 +36,9 @@ type Indexer struct {
 	l1Client node.EthClient
 	l2Client node.EthClient
 
-	// api server only really serves a /health endpoint here, but this may change in the future
+	// api server only really serves a /healthz endpoint here, but this may change in the future
 	apiServer *httputil.HTTPServer
+	health    *healthMonitor
 
 	metricsServer *httputil.HTTPServer
 
@@ -78,6 +80,7 @@ func (ix *Indexer) Start(ctx context.Context) error {
 	if err := ix.BridgeProcessor.Start(); err != nil {
 		return fmt.Errorf("failed to start bridge processor: %w", err)
 	}
+	ix.health.Start()
 	return nil
 }
 
@@ -102,6 +105,12 @@ func (ix *Indexer) Stop(ctx context.Context) error {
 		}
 	}
 
+	if ix.health != nil {
+		if err := ix.health.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close health monitor: %w", err))
+		}
+	}
+
 	// Now that the ETLs are closed, we can stop the RPC clients
 	if ix.l1Client != nil {
 		ix.l1Client.Close()
@@ -156,6 +165,7 @@ func (ix *Indexer) initFromConfig(ctx context.Context, cfg *config.Config) error
 	if err := ix.initBridgeProcessor(cfg.Chain); err != nil {
 		return fmt.Errorf("failed to init Bridge-Processor: %w", err)
 	}
+	ix.initHealthMonitor(cfg.Chain)
 	if err := ix.startHttpServer(ctx, cfg.HTTPServer); err != nil {
 		return fmt.Errorf("failed to start HTTP server: %w", err)
 	}
@@ -194,6 +204,7 @@ func (ix *Indexer) initL1ETL(chainConfig config.ChainConfig) error {
 		LoopIntervalMsec:  chainConfig.L1PollingInterval,
 		HeaderBufferSize:  chainConfig.L1HeaderBufferSize,
 		ConfirmationDepth: big.NewInt(int64(chainConfig.L1ConfirmationDepth)),
+		MaxReorgDepth:     uint64(chainConfig.L1MaxReorgDepth),
 		StartHeight:       big.NewInt(int64(chainConfig.L1StartingHeight)),
 	}
 	l1Etl, err := etl.NewL1ETL(l1Cfg, ix.log, ix.DB, etl.NewMetrics(ix.metricsRegistry, "l1"),
@@ -211,6 +222,7 @@ func (ix *Indexer) initL2ETL(chainConfig config.ChainConfig) error {
 		LoopIntervalMsec:  chainConfig.L2PollingInterval,
 		HeaderBufferSize:  chainConfig.L2HeaderBufferSize,
 		ConfirmationDepth: big.NewInt(int64(chainConfig.L2ConfirmationDepth)),
+		MaxReorgDepth:     uint64(chainConfig.L2MaxReorgDepth),
 	}
 	l2Etl, err := etl.NewL2ETL(l2Cfg, ix.log, ix.DB, etl.NewMetrics(ix.metricsRegistry, "l2"),
 		ix.l2Client, chainConfig.L2Contracts, ix.shutdown)
@@ -231,11 +243,18 @@ func (ix *Indexer) initBridgeProcessor(chainConfig config.ChainConfig) error {
 	return nil
 }
 
+func (ix *Indexer) initHealthMonitor(chainConfig config.ChainConfig) {
+	l1 := chainHealthCheck{client: ix.l1Client, syncStatus: ix.L1ETL.SyncStatus(), maxLag: chainConfig.L1MaxLagSeconds}
+	l2 := chainHealthCheck{client: ix.l2Client, syncStatus: ix.L2ETL.SyncStatus(), maxLag: chainConfig.L2MaxLagSeconds}
+	ix.health = newHealthMonitor(ix.log, ix.metricsRegistry, l1, l2)
+}
+
 func (ix *Indexer) startHttpServer(ctx context.Context, cfg config.ServerConfig) error {
 	ix.log.Debug("starting http server...", "port", cfg.Port)
 
 	r := chi.NewRouter()
-	r.Use(middleware.Heartbeat("/healthz"))
+	r.Use(middleware.Recoverer)
+	r.Method(http.MethodGet, "/healthz", ix.health)
 
 	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
 	srv, err := httputil.StartHTTPServer(addr, r)
@@ -0,0 +1,39 @@
+/**
+ * Reorgs remove the orphaned block headers, cascading to the state derived from them. The state of a
+ * withdrawal or message that is completed on the other chain must not be removed by a reorg of that
+ * completion, only the reference to the orphaned completion event is.
+ *
+ * The versioned message hashes of an orphaned L2 message are removed with it.
+ */
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'l2_transaction_withdrawals_proven_l1_event_guid_fkey' AND confdeltype = 'c') THEN
+        ALTER TABLE l2_transaction_withdrawals
+            DROP CONSTRAINT l2_transaction_withdrawals_proven_l1_event_guid_fkey,
+            ADD CONSTRAINT l2_transaction_withdrawals_proven_l1_event_guid_fkey FOREIGN KEY (proven_l1_event_guid) REFERENCES l1_contract_events(guid) ON DELETE SET NULL;
+    END IF;
+
+    IF EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'l2_transaction_withdrawals_finalized_l1_event_guid_fkey' AND confdeltype = 'c') THEN
+        ALTER TABLE l2_transaction_withdrawals
+            DROP CONSTRAINT l2_transaction_withdrawals_finalized_l1_event_guid_fkey,
+            ADD CONSTRAINT l2_transaction_withdrawals_finalized_l1_event_guid_fkey FOREIGN KEY (finalized_l1_event_guid) REFERENCES l1_contract_events(guid) ON DELETE SET NULL;
+    END IF;
+
+    IF EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'l1_bridge_messages_relayed_message_event_guid_fkey' AND confdeltype = 'c') THEN
+        ALTER TABLE l1_bridge_messages
+            DROP CONSTRAINT l1_bridge_messages_relayed_message_event_guid_fkey,
+            ADD CONSTRAINT l1_bridge_messages_relayed_message_event_guid_fkey FOREIGN KEY (relayed_message_event_guid) REFERENCES l2_contract_events(guid) ON DELETE SET NULL;
+    END IF;
+
+    IF EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'l2_bridge_messages_relayed_message_event_guid_fkey' AND confdeltype = 'c') THEN
+        ALTER TABLE l2_bridge_messages
+            DROP CONSTRAINT l2_bridge_messages_relayed_message_event_guid_fkey,
+            ADD CONSTRAINT l2_bridge_messages_relayed_message_event_guid_fkey FOREIGN KEY (relayed_message_event_guid) REFERENCES l1_contract_events(guid) ON DELETE SET NULL;
+    END IF;
+
+    IF EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'l2_bridge_message_versioned_message_hashes_message_hash_fkey' AND confdeltype = 'a') THEN
+        ALTER TABLE l2_bridge_message_versioned_message_hashes
+            DROP CONSTRAINT l2_bridge_message_versioned_message_hashes_message_hash_fkey,
+            ADD CONSTRAINT l2_bridge_message_versioned_message_hashes_message_hash_fkey FOREIGN KEY (message_hash) REFERENCES l2_bridge_messages(message_hash) ON DELETE CASCADE;
+    END IF;
+END $$;
@@ -0,0 +1,36 @@
+/**
+ * ERC721 tokens bridged across domains by the L1ERC721Bridge & L2ERC721Bridge. A transfer is initiated on one layer and
+ * finalized on the other, linked by the hash of the cross domain message that carries it: deposits are initiated on L1
+ * and finalized on L2, withdrawals are initiated on L2 and finalized on L1.
+ *
+ * A transfer is removed with its orphaned initiated event. A reorg of the finalized event only removes the match.
+ */
+CREATE TABLE IF NOT EXISTS erc721_bridge_transfers (
+    cross_domain_message_hash VARCHAR PRIMARY KEY,
+
+    initiated_l1_event_guid VARCHAR UNIQUE REFERENCES l1_contract_events(guid) ON DELETE CASCADE,
+    initiated_l2_event_guid VARCHAR UNIQUE REFERENCES l2_contract_events(guid) ON DELETE CASCADE,
+    finalized_l1_event_guid VARCHAR UNIQUE REFERENCES l1_contract_events(guid) ON DELETE SET NULL,
+    finalized_l2_event_guid VARCHAR UNIQUE REFERENCES l2_contract_events(guid) ON DELETE SET NULL,
+
+    -- Initiated transfer. The local token is the collection on the initiating layer
+    local_token_address  VARCHAR NOT NULL,
+    remote_token_address VARCHAR NOT NULL,
+    token_id             UINT256 NOT NULL,
+    from_address         VARCHAR NOT NULL,
+    to_address           VARCHAR NOT NULL,
+    data                 VARCHAR NOT NULL,
+    timestamp            INTEGER NOT NULL CHECK (timestamp > 0),
+
+    CHECK (num_nonnulls(initiated_l1_event_guid, initiated_l2_event_guid) = 1),
+    CHECK (finalized_l1_event_guid IS NULL OR initiated_l2_event_guid IS NOT NULL),
+    CHECK (finalized_l2_event_guid IS NULL OR initiated_l1_event_guid IS NOT NULL)
+);
+CREATE INDEX IF NOT EXISTS erc721_bridge_transfers_timestamp ON erc721_bridge_transfers(timestamp);
+CREATE INDEX IF NOT EXISTS erc721_bridge_transfers_initiated_l1_event_guid ON erc721_bridge_transfers(initiated_l1_event_guid);
+CREATE INDEX IF NOT EXISTS erc721_bridge_transfers_initiated_l2_event_guid ON erc721_bridge_transfers(initiated_l2_event_guid);
+CREATE INDEX IF NOT EXISTS erc721_bridge_transfers_finalized_l1_event_guid ON erc721_bridge_transfers(finalized_l1_event_guid);
+CREATE INDEX IF NOT EXISTS erc721_bridge_transfers_finalized_l2_event_guid ON erc721_bridge_transfers(finalized_l2_event_guid);
+CREATE INDEX IF NOT EXISTS erc721_bridge_transfers_from_address ON erc721_bridge_transfers(from_address);
+CREATE INDEX IF NOT EXISTS erc721_bridge_transfers_local_token_address ON erc721_bridge_transfers(local_token_address);
+CREATE INDEX IF NOT EXISTS erc721_bridge_transfers_remote_token_address ON erc721_bridge_transfers(remote_token_address);
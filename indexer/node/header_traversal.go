@@ -12,25 +12,48 @@ import (
 var (
 	ErrHeaderTraversalAheadOfProvider            = errors.New("the HeaderTraversal's internal state is ahead of the provider")
 	ErrHeaderTraversalAndProviderMismatchedState = errors.New("the HeaderTraversal and provider have diverged in state")
+	ErrHeaderTraversalReorgTooDeep               = errors.New("the HeaderTraversal detected a reorg deeper than the max reorg depth")
 )
 
+// ReorgError is returned by `NextHeaders` when traversed headers are no longer part of the canonical chain.
+// The HeaderTraversal has been rewound to the common ancestor, from which `NextHeaders` continues on the canonical chain.
+type ReorgError struct {
+	// CommonAncestor is the latest traversed header that is still canonical
+	CommonAncestor *types.Header
+	// Depth is the number of traversed blocks that were reorged
+	Depth uint64
+}
+
+func (e *ReorgError) Error() string {
+	return fmt.Sprintf("reorg of depth %d detected, common ancestor %s (%s)", e.Depth, e.CommonAncestor.Number, e.CommonAncestor.Hash())
+}
+
 type HeaderTraversal struct {
 	ethClient EthClient
 
 	latestHeader        *types.Header
 	lastTraversedHeader *types.Header
 
+	// recently traversed headers, within the max reorg depth of the last traversed header,
+	// used to find the common ancestor with the canonical chain when a reorg is detected
+	recentHeaders []types.Header
+
 	blockConfirmationDepth *big.Int
+	maxReorgDepth          uint64
 }
 
 // NewHeaderTraversal instantiates a new instance of HeaderTraversal against the supplied rpc client.
-// The HeaderTraversal will start fetching blocks starting from the supplied header unless nil, indicating genesis.
-func NewHeaderTraversal(ethClient EthClient, fromHeader *types.Header, confDepth *big.Int) *HeaderTraversal {
-	return &HeaderTraversal{
+// The HeaderTraversal will start fetching blocks after the last of the supplied headers unless empty, indicating genesis.
+// The supplied headers, ordered by number, are the recently indexed headers that reorgs up to the max reorg depth are
+// detected against. With a max reorg depth of zero, any reorg is treated as `ErrHeaderTraversalAndProviderMismatchedState`.
+func NewHeaderTraversal(ethClient EthClient, fromHeaders []types.Header, confDepth *big.Int, maxReorgDepth uint64) *HeaderTraversal {
+	f := &HeaderTraversal{
 		ethClient:              ethClient,
-		lastTraversedHeader:    fromHeader,
 		blockConfirmationDepth: confDepth,
+		maxReorgDepth:          maxReorgDepth,
 	}
+	f.addTraversedHeaders(fromHeaders)
+	return f
 }
 
 // LatestHeader returns the latest header reported by underlying eth client
@@ -90,11 +113,55 @@ func (f *HeaderTraversal) NextHeaders(maxSize uint64) ([]types.Header, error) {
 	if numHeaders == 0 {
 		return nil, nil
 	} else if f.lastTraversedHeader != nil && headers[0].ParentHash != f.lastTraversedHeader.Hash() {
-		// The indexer's state is in an irrecoverable state relative to the provider. This
-		// should never happen since the indexer is dealing with only finalized blocks.
-		return nil, ErrHeaderTraversalAndProviderMismatchedState
+		if f.maxReorgDepth == 0 {
+			// The indexer's state is in an irrecoverable state relative to the provider. Without
+			// reorg handling, this should never happen when the indexer only deals with finalized blocks.
+			return nil, ErrHeaderTraversalAndProviderMismatchedState
+		}
+		return nil, f.rewindToCommonAncestor()
 	}
 
-	f.lastTraversedHeader = &headers[numHeaders-1]
+	f.addTraversedHeaders(headers)
 	return headers, nil
 }
+
+// rewindToCommonAncestor finds the latest recently traversed header that is still canonical, and continues the
+// traversal from it. A `ReorgError` is returned when found, or `ErrHeaderTraversalReorgTooDeep` otherwise.
+func (f *HeaderTraversal) rewindToCommonAncestor() error {
+	for i := len(f.recentHeaders) - 1; i >= 0; i-- {
+		header := f.recentHeaders[i]
+		canonicalHeader, err := f.ethClient.BlockHeaderByNumber(header.Number)
+		if err != nil {
+			return fmt.Errorf("unable to query canonical header %s: %w", header.Number, err)
+		} else if canonicalHeader == nil || canonicalHeader.Hash() != header.Hash() {
+			continue
+		}
+
+		depth := new(big.Int).Sub(f.lastTraversedHeader.Number, header.Number).Uint64()
+		f.recentHeaders = f.recentHeaders[:i+1]
+		f.lastTraversedHeader = &f.recentHeaders[i]
+		return &ReorgError{CommonAncestor: f.lastTraversedHeader, Depth: depth}
+	}
+
+	return ErrHeaderTraversalReorgTooDeep
+}
+
+// addTraversedHeaders moves the traversal to the last of the headers, only keeping
+// the recent headers that are within the max reorg depth of it
+func (f *HeaderTraversal) addTraversedHeaders(headers []types.Header) {
+	if len(headers) == 0 {
+		return
+	}
+
+	lastHeader := headers[len(headers)-1]
+	minHeight := new(big.Int).Sub(lastHeader.Number, new(big.Int).SetUint64(f.maxReorgDepth))
+	recentHeaders := make([]types.Header, 0, len(f.recentHeaders)+len(headers))
+	for _, header := range append(f.recentHeaders, headers...) {
+		if header.Number.Cmp(minHeight) >= 0 {
+			recentHeaders = append(recentHeaders, header)
+		}
+	}
+
+	f.recentHeaders = recentHeaders
+	f.lastTraversedHeader = &f.recentHeaders[len(f.recentHeaders)-1]
+}
@@ -1,7 +1,9 @@
 package node
 
 import (
+	"fmt"
 	"math/big"
+	"slices"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/indexer/bigint"
@@ -38,7 +40,7 @@ func TestHeaderTraversalNextHeadersNoOp(t *testing.T) {
 
 	// start from block 10 as the latest fetched block
 	LastTraversedHeader := &types.Header{Number: big.NewInt(10)}
-	headerTraversal := NewHeaderTraversal(client, LastTraversedHeader, bigint.Zero)
+	headerTraversal := NewHeaderTraversal(client, []types.Header{*LastTraversedHeader}, bigint.Zero, 0)
 
 	require.Nil(t, headerTraversal.LatestHeader())
 	require.NotNil(t, headerTraversal.LastTraversedHeader())
@@ -58,7 +60,7 @@ func TestHeaderTraversalNextHeadersCursored(t *testing.T) {
 	client := new(MockEthClient)
 
 	// start from genesis
-	headerTraversal := NewHeaderTraversal(client, nil, bigint.Zero)
+	headerTraversal := NewHeaderTraversal(client, nil, bigint.Zero, 0)
 
 	headers := makeHeaders(10, nil)
 
@@ -85,7 +87,7 @@ func TestHeaderTraversalNextHeadersMaxSize(t *testing.T) {
 	client := new(MockEthClient)
 
 	// start from genesis
-	headerTraversal := NewHeaderTraversal(client, nil, bigint.Zero)
+	headerTraversal := NewHeaderTraversal(client, nil, bigint.Zero, 0)
 
 	// 100 "available" headers
 	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&types.Header{Number: big.NewInt(100)}, nil)
@@ -115,7 +117,7 @@ func TestHeaderTraversalMismatchedProviderStateError(t *testing.T) {
 	client := new(MockEthClient)
 
 	// start from genesis
-	headerTraversal := NewHeaderTraversal(client, nil, bigint.Zero)
+	headerTraversal := NewHeaderTraversal(client, nil, bigint.Zero, 0)
 
 	// blocks [0..4]
 	headers := makeHeaders(5, nil)
@@ -133,3 +135,113 @@ func TestHeaderTraversalMismatchedProviderStateError(t *testing.T) {
 	require.Nil(t, headers)
 	require.Equal(t, ErrHeaderTraversalAndProviderMismatchedState, err)
 }
+
+// fakeHeaderSource serves a chain of headers that can be reorged
+type fakeHeaderSource struct {
+	MockEthClient
+	headers []types.Header
+	forks   byte
+}
+
+func newFakeHeaderSource(numHeaders uint64) *fakeHeaderSource {
+	return &fakeHeaderSource{headers: makeHeaders(numHeaders, nil)}
+}
+
+// reorg replaces the latest `depth` headers with a new branch of `length` headers
+func (s *fakeHeaderSource) reorg(depth, length int) {
+	s.forks++
+	headers := slices.Clone(s.headers[:len(s.headers)-depth])
+	for i := 0; i < length; i++ {
+		parent := headers[len(headers)-1]
+		headers = append(headers, types.Header{
+			Number:     new(big.Int).Add(parent.Number, bigint.One),
+			ParentHash: parent.Hash(),
+			Extra:      []byte{s.forks}, // distinguishes the branch
+		})
+	}
+	s.headers = headers
+}
+
+func (s *fakeHeaderSource) BlockHeaderByNumber(number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return &s.headers[len(s.headers)-1], nil
+	} else if number.Uint64() >= uint64(len(s.headers)) {
+		return nil, nil
+	}
+	return &s.headers[number.Uint64()], nil
+}
+
+func (s *fakeHeaderSource) BlockHeadersByRange(from, to *big.Int) ([]types.Header, error) {
+	end := min(to.Uint64()+1, uint64(len(s.headers)))
+	return slices.Clone(s.headers[from.Uint64():end]), nil
+}
+
+func TestHeaderTraversalReorgs(t *testing.T) {
+	maxReorgDepth := 5
+	for _, depth := range []int{1, 3, maxReorgDepth} {
+		depth := depth
+		t.Run(fmt.Sprintf("Depth%d", depth), func(t *testing.T) {
+			source := newFakeHeaderSource(20)
+			headerTraversal := NewHeaderTraversal(source, nil, bigint.Zero, uint64(maxReorgDepth))
+			headers, err := headerTraversal.NextHeaders(100)
+			require.NoError(t, err)
+			require.Len(t, headers, 20)
+
+			// canonical chain is longer than the orphaned branch
+			commonAncestor := source.headers[19-depth]
+			source.reorg(depth, depth+2)
+
+			headers, err = headerTraversal.NextHeaders(100)
+			require.Nil(t, headers)
+			var reorg *ReorgError
+			require.ErrorAs(t, err, &reorg)
+			require.Equal(t, uint64(depth), reorg.Depth)
+			require.Equal(t, commonAncestor.Hash(), reorg.CommonAncestor.Hash())
+			require.Equal(t, commonAncestor.Hash(), headerTraversal.LastTraversedHeader().Hash())
+
+			// continues on the canonical branch
+			headers, err = headerTraversal.NextHeaders(100)
+			require.NoError(t, err)
+			require.Len(t, headers, depth+2)
+			require.Equal(t, commonAncestor.Hash(), headers[0].ParentHash)
+			require.Equal(t, source.headers[len(source.headers)-1].Hash(), headerTraversal.LastTraversedHeader().Hash())
+		})
+	}
+
+	t.Run("TooDeep", func(t *testing.T) {
+		source := newFakeHeaderSource(20)
+		headerTraversal := NewHeaderTraversal(source, nil, bigint.Zero, uint64(maxReorgDepth))
+		_, err := headerTraversal.NextHeaders(100)
+		require.NoError(t, err)
+		lastTraversedHeader := headerTraversal.LastTraversedHeader()
+
+		source.reorg(maxReorgDepth+1, maxReorgDepth+2)
+		headers, err := headerTraversal.NextHeaders(100)
+		require.Nil(t, headers)
+		require.ErrorIs(t, err, ErrHeaderTraversalReorgTooDeep)
+		require.Equal(t, lastTraversedHeader.Hash(), headerTraversal.LastTraversedHeader().Hash())
+
+		// remains halted
+		_, err = headerTraversal.NextHeaders(100)
+		require.ErrorIs(t, err, ErrHeaderTraversalReorgTooDeep)
+	})
+
+	t.Run("RecentlyIndexedHeaders", func(t *testing.T) {
+		// after a restart, only some of the recent headers may have been indexed
+		source := newFakeHeaderSource(20)
+		recentHeaders := []types.Header{source.headers[12], source.headers[15], source.headers[19]}
+		headerTraversal := NewHeaderTraversal(source, recentHeaders, bigint.Zero, uint64(maxReorgDepth))
+
+		source.reorg(3, 4)
+		_, err := headerTraversal.NextHeaders(100)
+		var reorg *ReorgError
+		require.ErrorAs(t, err, &reorg)
+		require.Equal(t, uint64(4), reorg.Depth)
+		require.Equal(t, source.headers[15].Hash(), reorg.CommonAncestor.Hash())
+
+		headers, err := headerTraversal.NextHeaders(100)
+		require.NoError(t, err)
+		require.Len(t, headers, 5)
+		require.Equal(t, source.headers[16].Hash(), headers[0].Hash())
+	})
+}
@@ -125,6 +125,11 @@ func (b *BridgeProcessor) onL1Data() error {
 	latestL1Header := b.l1Etl.LatestHeader
 	b.log.Info("notified of new L1 state", "l1_etl_block_number", latestL1Header.Number)
 
+	if err := b.rewindOrphanedL1Data(); err != nil {
+		b.log.Error("failed to rewind orphaned bridge state", "err", err)
+		return err
+	}
+
 	var errs error
 	if err := b.processInitiatedL1Events(); err != nil {
 		b.log.Error("failed to process initiated L1 events", "err", err)
@@ -151,6 +156,11 @@ func (b *BridgeProcessor) onL2Data() error {
 	}
 	b.log.Info("notified of new L2 state", "l2_etl_block_number", b.l2Etl.LatestHeader.Number)
 
+	if err := b.rewindOrphanedL2Data(); err != nil {
+		b.log.Error("failed to rewind orphaned bridge state", "err", err)
+		return err
+	}
+
 	var errs error
 	if err := b.processInitiatedL2Events(); err != nil {
 		b.log.Error("failed to process initiated L2 events", "err", err)
@@ -169,6 +179,94 @@ func (b *BridgeProcessor) onL2Data() error {
 	return errs
 }
 
+// Rewind Orphaned Bridge State
+
+// rewindOrphanedL1Data rewinds the state processed on new L1 data when a reorg removed the last processed headers,
+// along with the bridge state derived from them. Processing continues from the remaining indexed bridge state.
+func (b *BridgeProcessor) rewindOrphanedL1Data() error {
+	if b.LastL1Header != nil {
+		header, err := b.db.Blocks.L1BlockHeader(b.LastL1Header.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to query last processed L1 header: %w", err)
+		} else if header == nil {
+			// The finalization of the removed L1 bridge events on L2 must be processed again as well
+			latestL1Header, err := b.db.BridgeTransactions.L1LatestBlockHeader()
+			if err != nil {
+				return err
+			}
+			latestFinalizedL2Header, err := b.db.BridgeTransactions.L2LatestFinalizedBlockHeader()
+			if err != nil {
+				return err
+			}
+
+			b.log.Warn("rewinding orphaned L1 bridge state", "orphaned_l1_block", b.LastL1Header, "l1_block", latestL1Header,
+				"finalized_l2_block", latestFinalizedL2Header)
+			b.LastL1Header, b.LastFinalizedL2Header = latestL1Header, latestFinalizedL2Header
+			return nil
+		}
+	}
+
+	if b.LastFinalizedL2Header != nil {
+		header, err := b.db.Blocks.L2BlockHeader(b.LastFinalizedL2Header.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to query last finalized L2 header: %w", err)
+		} else if header == nil {
+			latestFinalizedL2Header, err := b.db.BridgeTransactions.L2LatestFinalizedBlockHeader()
+			if err != nil {
+				return err
+			}
+
+			b.log.Warn("rewinding orphaned finalized L2 bridge state", "orphaned_finalized_l2_block", b.LastFinalizedL2Header, "finalized_l2_block", latestFinalizedL2Header)
+			b.LastFinalizedL2Header = latestFinalizedL2Header
+		}
+	}
+
+	return nil
+}
+
+// rewindOrphanedL2Data rewinds the state processed on new L2 data when a reorg removed the last processed headers,
+// along with the bridge state derived from them. Processing continues from the remaining indexed bridge state.
+func (b *BridgeProcessor) rewindOrphanedL2Data() error {
+	if b.LastL2Header != nil {
+		header, err := b.db.Blocks.L2BlockHeader(b.LastL2Header.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to query last processed L2 header: %w", err)
+		} else if header == nil {
+			// The finalization of the removed L2 bridge events on L1 must be processed again as well
+			latestL2Header, err := b.db.BridgeTransactions.L2LatestBlockHeader()
+			if err != nil {
+				return err
+			}
+			latestFinalizedL1Header, err := b.db.BridgeTransactions.L1LatestFinalizedBlockHeader()
+			if err != nil {
+				return err
+			}
+
+			b.log.Warn("rewinding orphaned L2 bridge state", "orphaned_l2_block", b.LastL2Header, "l2_block", latestL2Header,
+				"finalized_l1_block", latestFinalizedL1Header)
+			b.LastL2Header, b.LastFinalizedL1Header = latestL2Header, latestFinalizedL1Header
+			return nil
+		}
+	}
+
+	if b.LastFinalizedL1Header != nil {
+		header, err := b.db.Blocks.L1BlockHeader(b.LastFinalizedL1Header.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to query last finalized L1 header: %w", err)
+		} else if header == nil {
+			latestFinalizedL1Header, err := b.db.BridgeTransactions.L1LatestFinalizedBlockHeader()
+			if err != nil {
+				return err
+			}
+
+			b.log.Warn("rewinding orphaned finalized L1 bridge state", "orphaned_finalized_l1_block", b.LastFinalizedL1Header, "finalized_l1_block", latestFinalizedL1Header)
+			b.LastFinalizedL1Header = latestFinalizedL1Header
+		}
+	}
+
+	return nil
+}
+
 // Process Initiated Bridge Events
 
 func (b *BridgeProcessor) processInitiatedL1Events() error {
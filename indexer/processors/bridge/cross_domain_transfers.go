@@ -0,0 +1,84 @@
+package bridge
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/processors/contracts"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// relayedMessagesByTransaction groups the relayed messages by the transaction relaying them, ordered by log index
+func relayedMessagesByTransaction(relayedMessages []contracts.CrossDomainMessengerRelayedMessageEvent) map[common.Hash][]*contracts.CrossDomainMessengerRelayedMessageEvent {
+	relayedMessagesByTx := make(map[common.Hash][]*contracts.CrossDomainMessengerRelayedMessageEvent)
+	for i := range relayedMessages {
+		relayedMessage := &relayedMessages[i]
+		relayedMessagesByTx[relayedMessage.Event.TransactionHash] = append(relayedMessagesByTx[relayedMessage.Event.TransactionHash], relayedMessage)
+	}
+	for _, txRelayedMessages := range relayedMessagesByTx {
+		sort.Slice(txRelayedMessages, func(i, j int) bool { return txRelayedMessages[i].Event.LogIndex < txRelayedMessages[j].Event.LogIndex })
+	}
+	return relayedMessagesByTx
+}
+
+// matchFinalizedBridge finds the cross domain transfer finalized by the bridge event. The StandardBridge finalizes a bridge while
+// the CrossDomainMessenger relays the message carrying it, which is marked by a RelayedMessage event following the bridge event in
+// the same transaction. Nil is returned when no indexed transfer matches, as for transfers initiated prior to Bedrock.
+func matchFinalizedBridge(db *database.DB, finalizedBridge contracts.StandardBridgeFinalizedEvent, txRelayedMessages []*contracts.CrossDomainMessengerRelayedMessageEvent) (*database.CrossDomainTransfer, error) {
+	for _, relayedMessage := range txRelayedMessages {
+		if relayedMessage.Event.LogIndex < finalizedBridge.Event.LogIndex {
+			continue
+		}
+
+		// The recipient of an ETH bridge may relay other messages within the same relay
+		transfer, err := db.BridgeTransfers.CrossDomainTransfer(relayedMessage.MessageHash)
+		if err != nil {
+			return nil, err
+		} else if transfer != nil && finalizesTransfer(finalizedBridge.BridgeTransfer, transfer) {
+			return transfer, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// finalizesTransfer checks that the finalized bridge completes the initiated transfer, with the tokens of the other domain
+func finalizesTransfer(finalizedBridge database.BridgeTransfer, transfer *database.CrossDomainTransfer) bool {
+	return finalizedBridge.TokenPair.LocalTokenAddress == transfer.TokenPair.RemoteTokenAddress &&
+		finalizedBridge.TokenPair.RemoteTokenAddress == transfer.TokenPair.LocalTokenAddress &&
+		finalizedBridge.Tx.FromAddress == transfer.Tx.FromAddress &&
+		finalizedBridge.Tx.ToAddress == transfer.Tx.ToAddress &&
+		finalizedBridge.Tx.Amount.Cmp(transfer.Tx.Amount) == 0 &&
+		bytes.Equal(finalizedBridge.Tx.Data, transfer.Tx.Data)
+}
+
+// matchFinalizedERC721Bridge finds the ERC721 transfer finalized by the bridge event, carried by a message relayed after the bridge
+// event in the same transaction as with the StandardBridge. Nil is returned when no indexed transfer matches.
+func matchFinalizedERC721Bridge(db *database.DB, finalizedBridge contracts.ERC721BridgeFinalizedEvent, txRelayedMessages []*contracts.CrossDomainMessengerRelayedMessageEvent) (*database.ERC721BridgeTransfer, error) {
+	for _, relayedMessage := range txRelayedMessages {
+		if relayedMessage.Event.LogIndex < finalizedBridge.Event.LogIndex {
+			continue
+		}
+
+		transfer, err := db.BridgeTransfers.ERC721BridgeTransfer(relayedMessage.MessageHash)
+		if err != nil {
+			return nil, err
+		} else if transfer != nil && finalizesERC721Transfer(finalizedBridge.BridgeTransfer, transfer) {
+			return transfer, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// finalizesERC721Transfer checks that the finalized bridge completes the initiated transfer, with the collections of the other domain
+func finalizesERC721Transfer(finalizedBridge database.ERC721BridgeTransfer, transfer *database.ERC721BridgeTransfer) bool {
+	return finalizedBridge.TokenPair.LocalTokenAddress == transfer.TokenPair.RemoteTokenAddress &&
+		finalizedBridge.TokenPair.RemoteTokenAddress == transfer.TokenPair.LocalTokenAddress &&
+		finalizedBridge.FromAddress == transfer.FromAddress &&
+		finalizedBridge.ToAddress == transfer.ToAddress &&
+		finalizedBridge.TokenID.Cmp(transfer.TokenID) == 0 &&
+		bytes.Equal(finalizedBridge.Data, transfer.Data)
+}
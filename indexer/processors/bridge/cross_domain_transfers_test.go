@@ -0,0 +1,102 @@
+package bridge
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/processors/contracts"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayedMessagesByTransaction(t *testing.T) {
+	txA, txB := common.HexToHash("0xa"), common.HexToHash("0xb")
+	relayedMessage := func(txHash common.Hash, logIndex uint64) contracts.CrossDomainMessengerRelayedMessageEvent {
+		return contracts.CrossDomainMessengerRelayedMessageEvent{Event: &database.ContractEvent{TransactionHash: txHash, LogIndex: logIndex}}
+	}
+
+	relayedMessages := relayedMessagesByTransaction([]contracts.CrossDomainMessengerRelayedMessageEvent{
+		relayedMessage(txA, 5), relayedMessage(txB, 1), relayedMessage(txA, 2),
+	})
+	require.Len(t, relayedMessages, 2)
+	require.Len(t, relayedMessages[txA], 2)
+	require.Equal(t, uint64(2), relayedMessages[txA][0].Event.LogIndex)
+	require.Equal(t, uint64(5), relayedMessages[txA][1].Event.LogIndex)
+	require.Len(t, relayedMessages[txB], 1)
+}
+
+func TestFinalizesTransfer(t *testing.T) {
+	l1Token, l2Token := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+	transfer := &database.CrossDomainTransfer{
+		Tx: database.Transaction{
+			FromAddress: common.HexToAddress("0x3"),
+			ToAddress:   common.HexToAddress("0x4"),
+			Amount:      big.NewInt(100),
+			Data:        []byte{0x5},
+		},
+		TokenPair: database.TokenPair{LocalTokenAddress: l1Token, RemoteTokenAddress: l2Token},
+	}
+
+	finalizedBridge := func() database.BridgeTransfer {
+		return database.BridgeTransfer{
+			Tx: database.Transaction{
+				FromAddress: transfer.Tx.FromAddress,
+				ToAddress:   transfer.Tx.ToAddress,
+				Amount:      big.NewInt(100),
+				Data:        []byte{0x5},
+			},
+			TokenPair: database.TokenPair{LocalTokenAddress: l2Token, RemoteTokenAddress: l1Token},
+		}
+	}
+	require.True(t, finalizesTransfer(finalizedBridge(), transfer))
+
+	tests := map[string]func(*database.BridgeTransfer){
+		"Tokens": func(b *database.BridgeTransfer) { b.TokenPair = transfer.TokenPair },
+		"From":   func(b *database.BridgeTransfer) { b.Tx.FromAddress = common.HexToAddress("0x6") },
+		"To":     func(b *database.BridgeTransfer) { b.Tx.ToAddress = common.HexToAddress("0x6") },
+		"Amount": func(b *database.BridgeTransfer) { b.Tx.Amount = big.NewInt(99) },
+		"Data":   func(b *database.BridgeTransfer) { b.Tx.Data = nil },
+	}
+	for name, mutate := range tests {
+		bridge := finalizedBridge()
+		mutate(&bridge)
+		require.False(t, finalizesTransfer(bridge, transfer), name)
+	}
+}
+
+func TestFinalizesERC721Transfer(t *testing.T) {
+	l1Collection, l2Collection := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+	transfer := &database.ERC721BridgeTransfer{
+		TokenPair:   database.TokenPair{LocalTokenAddress: l1Collection, RemoteTokenAddress: l2Collection},
+		TokenID:     big.NewInt(7),
+		FromAddress: common.HexToAddress("0x3"),
+		ToAddress:   common.HexToAddress("0x4"),
+		Data:        []byte{0x5},
+	}
+
+	finalizedBridge := func() database.ERC721BridgeTransfer {
+		return database.ERC721BridgeTransfer{
+			TokenPair:   database.TokenPair{LocalTokenAddress: l2Collection, RemoteTokenAddress: l1Collection},
+			TokenID:     big.NewInt(7),
+			FromAddress: transfer.FromAddress,
+			ToAddress:   transfer.ToAddress,
+			Data:        []byte{0x5},
+		}
+	}
+	require.True(t, finalizesERC721Transfer(finalizedBridge(), transfer))
+
+	tests := map[string]func(*database.ERC721BridgeTransfer){
+		"Collections": func(b *database.ERC721BridgeTransfer) { b.TokenPair = transfer.TokenPair },
+		"TokenID":     func(b *database.ERC721BridgeTransfer) { b.TokenID = big.NewInt(8) },
+		"From":        func(b *database.ERC721BridgeTransfer) { b.FromAddress = common.HexToAddress("0x6") },
+		"To":          func(b *database.ERC721BridgeTransfer) { b.ToAddress = common.HexToAddress("0x6") },
+		"Data":        func(b *database.ERC721BridgeTransfer) { b.Data = nil },
+	}
+	for name, mutate := range tests {
+		bridge := finalizedBridge()
+		mutate(&bridge)
+		require.False(t, finalizesERC721Transfer(bridge, transfer), name)
+	}
+}
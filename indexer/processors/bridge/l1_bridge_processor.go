@@ -20,6 +20,7 @@ import (
 //  1. OptimismPortal
 //  2. L1CrossDomainMessenger
 //  3. L1StandardBridge
+//  4. L1ERC721Bridge
 func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1Metricer, l1Contracts config.L1Contracts, fromHeight, toHeight *big.Int) error {
 	// (1) OptimismPortal
 	optimismPortalTxDeposits, err := contracts.OptimismPortalTransactionDepositEvents(l1Contracts.OptimismPortalProxy, db, fromHeight, toHeight)
@@ -103,6 +104,7 @@ func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 
 	bridgedTokens := make(map[common.Address]int)
 	bridgeDeposits := make([]database.L1BridgeDeposit, len(initiatedBridges))
+	crossDomainTransfers := make([]database.CrossDomainTransfer, len(initiatedBridges))
 	for i := range initiatedBridges {
 		initiatedBridge := initiatedBridges[i]
 
@@ -121,6 +123,14 @@ func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 			return fmt.Errorf("correlated events tx hash mismatch. bridge_tx_hash = %s, message_tx_hash = %s", initiatedBridge.Event.TransactionHash, sentMessage.Event.TransactionHash)
 		}
 
+		// the sent message must carry the bridge, hashed as the CrossDomainMessenger does
+		messageHash, err := contracts.StandardBridgeInitiatedMessageHash(initiatedBridge, *sentMessage)
+		if err != nil {
+			return err
+		} else if messageHash != sentMessage.BridgeMessage.MessageHash {
+			return fmt.Errorf("bridge message hash mismatch. tx_hash = %s, bridge_message_hash = %s, message_hash = %s", initiatedBridge.Event.TransactionHash, messageHash, sentMessage.BridgeMessage.MessageHash)
+		}
+
 		bridgedTokens[initiatedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
 
 		initiatedBridge.BridgeTransfer.CrossDomainMessageHash = &sentMessage.BridgeMessage.MessageHash
@@ -128,16 +138,70 @@ func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 			TransactionSourceHash: portalDeposit.DepositTx.SourceHash,
 			BridgeTransfer:        initiatedBridge.BridgeTransfer,
 		}
+		crossDomainTransfers[i] = database.CrossDomainTransfer{
+			CrossDomainMessageHash: messageHash,
+			InitiatedL1EventGUID:   &initiatedBridge.Event.GUID,
+			Tx:                     initiatedBridge.BridgeTransfer.Tx,
+			TokenPair:              initiatedBridge.BridgeTransfer.TokenPair,
+		}
 	}
 	if len(bridgeDeposits) > 0 {
 		if err := db.BridgeTransfers.StoreL1BridgeDeposits(bridgeDeposits); err != nil {
 			return err
 		}
+		if err := db.BridgeTransfers.StoreCrossDomainTransfers(crossDomainTransfers); err != nil {
+			return err
+		}
 		for tokenAddr, size := range bridgedTokens {
 			metrics.RecordL1InitiatedBridgeTransfers(tokenAddr, size)
 		}
 	}
 
+	// (4) L1ERC721Bridge
+	// - The ERC721Bridge emits the initiated bridge after sending the message carrying it, following the SentMessage
+	// & SentMessageExtension1 events of the messenger.
+	initiatedERC721Bridges, err := contracts.ERC721BridgeInitiatedEvents("l1", l1Contracts.L1ERC721BridgeProxy, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	if len(initiatedERC721Bridges) > 0 {
+		log.Info("detected erc721 bridge deposits", "size", len(initiatedERC721Bridges))
+	}
+
+	bridgedCollections := make(map[common.Address]int)
+	erc721BridgeTransfers := make([]database.ERC721BridgeTransfer, len(initiatedERC721Bridges))
+	for i := range initiatedERC721Bridges {
+		initiatedBridge := initiatedERC721Bridges[i]
+
+		sentMessage, ok := sentMessages[logKey{initiatedBridge.Event.BlockHash, initiatedBridge.Event.LogIndex - 2}]
+		if !ok {
+			return fmt.Errorf("expected SentMessage preceding ERC721BridgeInitiated event. tx_hash = %s", initiatedBridge.Event.TransactionHash)
+		} else if sentMessage.Event.TransactionHash != initiatedBridge.Event.TransactionHash {
+			return fmt.Errorf("correlated events tx hash mismatch. bridge_tx_hash = %s, message_tx_hash = %s", initiatedBridge.Event.TransactionHash, sentMessage.Event.TransactionHash)
+		}
+
+		messageHash, err := contracts.ERC721BridgeInitiatedMessageHash(initiatedBridge, *sentMessage)
+		if err != nil {
+			return err
+		} else if messageHash != sentMessage.BridgeMessage.MessageHash {
+			return fmt.Errorf("erc721 bridge message hash mismatch. tx_hash = %s, bridge_message_hash = %s, message_hash = %s", initiatedBridge.Event.TransactionHash, messageHash, sentMessage.BridgeMessage.MessageHash)
+		}
+
+		bridgedCollections[initiatedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+
+		erc721BridgeTransfers[i] = initiatedBridge.BridgeTransfer
+		erc721BridgeTransfers[i].CrossDomainMessageHash = messageHash
+		erc721BridgeTransfers[i].InitiatedL1EventGUID = &initiatedBridge.Event.GUID
+	}
+	if len(erc721BridgeTransfers) > 0 {
+		if err := db.BridgeTransfers.StoreERC721BridgeTransfers(erc721BridgeTransfers); err != nil {
+			return err
+		}
+		for collectionAddr, size := range bridgedCollections {
+			metrics.RecordL1InitiatedBridgeTransfers(collectionAddr, size)
+		}
+	}
+
 	return nil
 }
 
@@ -145,7 +209,8 @@ func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 // bridge events. This covers every part of the multi-layered stack:
 //  1. OptimismPortal (Bedrock prove & finalize steps)
 //  2. L1CrossDomainMessenger (relayMessage marker)
-//  3. L1StandardBridge (no-op, since this is simply a wrapper over the L1CrossDomainMessenger)
+//  3. L1StandardBridge (matches the finalized bridges with the transfers initiated on L2)
+//  4. L1ERC721Bridge (matches the finalized bridges with the erc721 transfers initiated on L2)
 func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1Metricer, l1Contracts config.L1Contracts, fromHeight, toHeight *big.Int) error {
 	// (1) OptimismPortal (proven withdrawals)
 	provenWithdrawals, err := contracts.OptimismPortalWithdrawalProvenEvents(l1Contracts.OptimismPortalProxy, db, fromHeight, toHeight)
@@ -253,24 +318,76 @@ func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 	}
 
 	// (4) L1StandardBridge
-	// - Nothing actionable on the database. Since the StandardBridge is layered ontop of the
-	// CrossDomainMessenger, there's no need for any sanity or invariant checks as the previous step
-	// ensures a relayed message (finalized bridge) can be linked with a sent message (initiated bridge).
+	// - Since the StandardBridge is layered ontop of the CrossDomainMessenger, the previous step ensures a relayed
+	// message (finalized bridge) can be linked with a sent message (initiated bridge). The finalized bridges are
+	// matched with the cross domain transfers carried by the relayed messages.
 	finalizedBridges, err := contracts.StandardBridgeFinalizedEvents("l1", l1Contracts.L1StandardBridgeProxy, db, fromHeight, toHeight)
 	if err != nil {
 		return err
 	}
 
+	relayedMessages := relayedMessagesByTransaction(crossDomainRelayedMessages)
 	finalizedTokens := make(map[common.Address]int)
+	unmatchedBridges := 0
 	for i := range finalizedBridges {
 		finalizedBridge := finalizedBridges[i]
 		finalizedTokens[finalizedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+
+		transfer, err := matchFinalizedBridge(db, finalizedBridge, relayedMessages[finalizedBridge.Event.TransactionHash])
+		if err != nil {
+			return err
+		} else if transfer == nil {
+			unmatchedBridges++
+			continue
+		}
+
+		if err := db.BridgeTransfers.MarkFinalizedCrossDomainWithdrawal(transfer.CrossDomainMessageHash, finalizedBridge.Event.GUID); err != nil {
+			return fmt.Errorf("failed to match finalized bridge. tx_hash = %s: %w", finalizedBridge.Event.TransactionHash, err)
+		}
 	}
 	if len(finalizedBridges) > 0 {
 		log.Info("detected finalized bridge withdrawals", "size", len(finalizedBridges))
 		for tokenAddr, size := range finalizedTokens {
 			metrics.RecordL1FinalizedBridgeTransfers(tokenAddr, size)
 		}
+		if unmatchedBridges > 0 {
+			log.Info("skipped finalized bridge withdrawals without indexed transfers", "size", unmatchedBridges)
+		}
+	}
+
+	// (5) L1ERC721Bridge
+	// - The finalized ERC721 bridges are matched with the transfers carried by the relayed messages, as with the StandardBridge
+	finalizedERC721Bridges, err := contracts.ERC721BridgeFinalizedEvents("l1", l1Contracts.L1ERC721BridgeProxy, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+
+	finalizedCollections := make(map[common.Address]int)
+	unmatchedERC721Bridges := 0
+	for i := range finalizedERC721Bridges {
+		finalizedBridge := finalizedERC721Bridges[i]
+		finalizedCollections[finalizedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+
+		transfer, err := matchFinalizedERC721Bridge(db, finalizedBridge, relayedMessages[finalizedBridge.Event.TransactionHash])
+		if err != nil {
+			return err
+		} else if transfer == nil {
+			unmatchedERC721Bridges++
+			continue
+		}
+
+		if err := db.BridgeTransfers.MarkFinalizedERC721BridgeWithdrawal(transfer.CrossDomainMessageHash, finalizedBridge.Event.GUID); err != nil {
+			return fmt.Errorf("failed to match finalized erc721 bridge. tx_hash = %s: %w", finalizedBridge.Event.TransactionHash, err)
+		}
+	}
+	if len(finalizedERC721Bridges) > 0 {
+		log.Info("detected finalized erc721 bridge withdrawals", "size", len(finalizedERC721Bridges))
+		for collectionAddr, size := range finalizedCollections {
+			metrics.RecordL1FinalizedBridgeTransfers(collectionAddr, size)
+		}
+		if unmatchedERC721Bridges > 0 {
+			log.Info("skipped finalized erc721 bridge withdrawals without indexed transfers", "size", unmatchedERC721Bridges)
+		}
 	}
 
 	// a-ok!
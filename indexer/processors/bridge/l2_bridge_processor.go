@@ -18,6 +18,7 @@ import (
 //  1. OptimismPortal
 //  2. L2CrossDomainMessenger
 //  3. L2StandardBridge
+//  4. L2ERC721Bridge
 func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2Metricer, l2Contracts config.L2Contracts, fromHeight, toHeight *big.Int) error {
 	// (1) L2ToL1MessagePasser
 	l2ToL1MPMessagesPassed, err := contracts.L2ToL1MessagePasserMessagePassedEvents(l2Contracts.L2ToL1MessagePasser, db, fromHeight, toHeight)
@@ -97,6 +98,7 @@ func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 
 	bridgedTokens := make(map[common.Address]int)
 	bridgeWithdrawals := make([]database.L2BridgeWithdrawal, len(initiatedBridges))
+	crossDomainTransfers := make([]database.CrossDomainTransfer, len(initiatedBridges))
 	for i := range initiatedBridges {
 		initiatedBridge := initiatedBridges[i]
 
@@ -115,6 +117,14 @@ func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 			return fmt.Errorf("correlated events tx hash mismatch. bridge_tx_hash = %s, message_tx_hash = %s", initiatedBridge.Event.TransactionHash, sentMessage.Event.TransactionHash)
 		}
 
+		// the sent message must carry the bridge, hashed as the CrossDomainMessenger does
+		messageHash, err := contracts.StandardBridgeInitiatedMessageHash(initiatedBridge, *sentMessage)
+		if err != nil {
+			return err
+		} else if messageHash != sentMessage.BridgeMessage.MessageHash {
+			return fmt.Errorf("bridge message hash mismatch. tx_hash = %s, bridge_message_hash = %s, message_hash = %s", initiatedBridge.Event.TransactionHash, messageHash, sentMessage.BridgeMessage.MessageHash)
+		}
+
 		bridgedTokens[initiatedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
 
 		initiatedBridge.BridgeTransfer.CrossDomainMessageHash = &sentMessage.BridgeMessage.MessageHash
@@ -122,16 +132,70 @@ func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 			TransactionWithdrawalHash: messagePassed.WithdrawalHash,
 			BridgeTransfer:            initiatedBridge.BridgeTransfer,
 		}
+		crossDomainTransfers[i] = database.CrossDomainTransfer{
+			CrossDomainMessageHash: messageHash,
+			InitiatedL2EventGUID:   &initiatedBridge.Event.GUID,
+			Tx:                     initiatedBridge.BridgeTransfer.Tx,
+			TokenPair:              initiatedBridge.BridgeTransfer.TokenPair,
+		}
 	}
 	if len(bridgeWithdrawals) > 0 {
 		if err := db.BridgeTransfers.StoreL2BridgeWithdrawals(bridgeWithdrawals); err != nil {
 			return err
 		}
+		if err := db.BridgeTransfers.StoreCrossDomainTransfers(crossDomainTransfers); err != nil {
+			return err
+		}
 		for tokenAddr, size := range bridgedTokens {
 			metrics.RecordL2InitiatedBridgeTransfers(tokenAddr, size)
 		}
 	}
 
+	// (4) L2ERC721Bridge
+	// - The ERC721Bridge emits the initiated bridge after sending the message carrying it, following the SentMessage
+	// & SentMessageExtension1 events of the messenger.
+	initiatedERC721Bridges, err := contracts.ERC721BridgeInitiatedEvents("l2", l2Contracts.L2ERC721Bridge, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	if len(initiatedERC721Bridges) > 0 {
+		log.Info("detected erc721 bridge withdrawals", "size", len(initiatedERC721Bridges))
+	}
+
+	bridgedCollections := make(map[common.Address]int)
+	erc721BridgeTransfers := make([]database.ERC721BridgeTransfer, len(initiatedERC721Bridges))
+	for i := range initiatedERC721Bridges {
+		initiatedBridge := initiatedERC721Bridges[i]
+
+		sentMessage, ok := sentMessages[logKey{initiatedBridge.Event.BlockHash, initiatedBridge.Event.LogIndex - 2}]
+		if !ok {
+			return fmt.Errorf("expected SentMessage preceding ERC721BridgeInitiated event. tx_hash = %s", initiatedBridge.Event.TransactionHash)
+		} else if sentMessage.Event.TransactionHash != initiatedBridge.Event.TransactionHash {
+			return fmt.Errorf("correlated events tx hash mismatch. bridge_tx_hash = %s, message_tx_hash = %s", initiatedBridge.Event.TransactionHash, sentMessage.Event.TransactionHash)
+		}
+
+		messageHash, err := contracts.ERC721BridgeInitiatedMessageHash(initiatedBridge, *sentMessage)
+		if err != nil {
+			return err
+		} else if messageHash != sentMessage.BridgeMessage.MessageHash {
+			return fmt.Errorf("erc721 bridge message hash mismatch. tx_hash = %s, bridge_message_hash = %s, message_hash = %s", initiatedBridge.Event.TransactionHash, messageHash, sentMessage.BridgeMessage.MessageHash)
+		}
+
+		bridgedCollections[initiatedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+
+		erc721BridgeTransfers[i] = initiatedBridge.BridgeTransfer
+		erc721BridgeTransfers[i].CrossDomainMessageHash = messageHash
+		erc721BridgeTransfers[i].InitiatedL2EventGUID = &initiatedBridge.Event.GUID
+	}
+	if len(erc721BridgeTransfers) > 0 {
+		if err := db.BridgeTransfers.StoreERC721BridgeTransfers(erc721BridgeTransfers); err != nil {
+			return err
+		}
+		for collectionAddr, size := range bridgedCollections {
+			metrics.RecordL2InitiatedBridgeTransfers(collectionAddr, size)
+		}
+	}
+
 	// a-ok!
 	return nil
 }
@@ -139,7 +203,8 @@ func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 // L2ProcessFinalizedBridgeEvent will query the database for all the finalization markers for all initiated
 // bridge events. This covers every part of the multi-layered stack:
 //  1. L2CrossDomainMessenger (relayMessage marker)
-//  2. L2StandardBridge (no-op, since this is simply a wrapper over the L2CrossDomainMEssenger)
+//  2. L2StandardBridge (matches the finalized bridges with the transfers initiated on L1)
+//  3. L2ERC721Bridge (matches the finalized bridges with the erc721 transfers initiated on L1)
 //
 // NOTE: Unlike L1, there's no L2ToL1MessagePasser stage since transaction deposits are apart of the block derivation process.
 func L2ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L2Metricer, l2Contracts config.L2Contracts, fromHeight, toHeight *big.Int) error {
@@ -170,24 +235,76 @@ func L2ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 	}
 
 	// (2) L2StandardBridge
-	// - Nothing actionable on the database. Since the StandardBridge is layered ontop of the
-	// CrossDomainMessenger, there's no need for any sanity or invariant checks as the previous step
-	// ensures a relayed message (finalized bridge) can be linked with a sent message (initiated bridge).
+	// - Since the StandardBridge is layered ontop of the CrossDomainMessenger, the previous step ensures a relayed
+	// message (finalized bridge) can be linked with a sent message (initiated bridge). The finalized bridges are
+	// matched with the cross domain transfers carried by the relayed messages.
 	finalizedBridges, err := contracts.StandardBridgeFinalizedEvents("l2", l2Contracts.L2StandardBridge, db, fromHeight, toHeight)
 	if err != nil {
 		return err
 	}
 
+	relayedMessages := relayedMessagesByTransaction(crossDomainRelayedMessages)
 	finalizedTokens := make(map[common.Address]int)
+	unmatchedBridges := 0
 	for i := range finalizedBridges {
 		finalizedBridge := finalizedBridges[i]
 		finalizedTokens[finalizedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+
+		transfer, err := matchFinalizedBridge(db, finalizedBridge, relayedMessages[finalizedBridge.Event.TransactionHash])
+		if err != nil {
+			return err
+		} else if transfer == nil {
+			unmatchedBridges++
+			continue
+		}
+
+		if err := db.BridgeTransfers.MarkFinalizedCrossDomainDeposit(transfer.CrossDomainMessageHash, finalizedBridge.Event.GUID); err != nil {
+			return fmt.Errorf("failed to match finalized bridge. tx_hash = %s: %w", finalizedBridge.Event.TransactionHash, err)
+		}
 	}
 	if len(finalizedBridges) > 0 {
 		log.Info("detected finalized bridge deposits", "size", len(finalizedBridges))
 		for tokenAddr, size := range finalizedTokens {
 			metrics.RecordL2FinalizedBridgeTransfers(tokenAddr, size)
 		}
+		if unmatchedBridges > 0 {
+			log.Info("skipped finalized bridge deposits without indexed transfers", "size", unmatchedBridges)
+		}
+	}
+
+	// (3) L2ERC721Bridge
+	// - The finalized ERC721 bridges are matched with the transfers carried by the relayed messages, as with the StandardBridge
+	finalizedERC721Bridges, err := contracts.ERC721BridgeFinalizedEvents("l2", l2Contracts.L2ERC721Bridge, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+
+	finalizedCollections := make(map[common.Address]int)
+	unmatchedERC721Bridges := 0
+	for i := range finalizedERC721Bridges {
+		finalizedBridge := finalizedERC721Bridges[i]
+		finalizedCollections[finalizedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+
+		transfer, err := matchFinalizedERC721Bridge(db, finalizedBridge, relayedMessages[finalizedBridge.Event.TransactionHash])
+		if err != nil {
+			return err
+		} else if transfer == nil {
+			unmatchedERC721Bridges++
+			continue
+		}
+
+		if err := db.BridgeTransfers.MarkFinalizedERC721BridgeDeposit(transfer.CrossDomainMessageHash, finalizedBridge.Event.GUID); err != nil {
+			return fmt.Errorf("failed to match finalized erc721 bridge. tx_hash = %s: %w", finalizedBridge.Event.TransactionHash, err)
+		}
+	}
+	if len(finalizedERC721Bridges) > 0 {
+		log.Info("detected finalized erc721 bridge deposits", "size", len(finalizedERC721Bridges))
+		for collectionAddr, size := range finalizedCollections {
+			metrics.RecordL2FinalizedBridgeTransfers(collectionAddr, size)
+		}
+		if unmatchedERC721Bridges > 0 {
+			log.Info("skipped finalized erc721 bridge deposits without indexed transfers", "size", unmatchedERC721Bridges)
+		}
 	}
 
 	// a-ok!
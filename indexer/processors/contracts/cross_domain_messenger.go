@@ -147,3 +147,17 @@ func CrossDomainMessengerRelayedMessageEvents(chainSelector string, contractAddr
 
 	return crossDomainRelayedMessages, nil
 }
+
+// CrossDomainMessageHash computes the hash of the message sent by the sender, exactly as the CrossDomainMessenger does. The
+// sent message provides the fields assigned by the messenger: the version, the nonce, the gas limit and the target.
+func CrossDomainMessageHash(sender common.Address, sentMessage CrossDomainMessengerSentMessageEvent, value *big.Int, message []byte) (common.Hash, error) {
+	target := sentMessage.BridgeMessage.Tx.ToAddress
+	switch sentMessage.Version {
+	case 0:
+		return crossdomain.HashCrossDomainMessageV0(target, sender, message, sentMessage.BridgeMessage.Nonce)
+	case 1:
+		return crossdomain.HashCrossDomainMessageV1(sentMessage.BridgeMessage.Nonce, sender, target, value, sentMessage.BridgeMessage.GasLimit, message)
+	default:
+		return common.Hash{}, fmt.Errorf("expected cross domain version 0 or version 1: %d", sentMessage.Version)
+	}
+}
@@ -0,0 +1,118 @@
+package contracts
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/indexer/bigint"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type ERC721BridgeInitiatedEvent struct {
+	Event          *database.ContractEvent
+	BridgeTransfer database.ERC721BridgeTransfer
+}
+
+type ERC721BridgeFinalizedEvent struct {
+	Event          *database.ContractEvent
+	BridgeTransfer database.ERC721BridgeTransfer
+}
+
+// ERC721BridgeInitiatedEvents extracts all initiated bridge events from the contracts that follow the ERC721Bridge ABI.
+// The L1ERC721Bridge & L2ERC721Bridge share the ERC721Bridge events, parsed with the L1ERC721Bridge ABI.
+func ERC721BridgeInitiatedEvents(chainSelector string, contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]ERC721BridgeInitiatedEvent, error) {
+	erc721BridgeAbi, err := bindings.L1ERC721BridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	initiatedBridgeEventAbi := erc721BridgeAbi.Events["ERC721BridgeInitiated"]
+	contractEventFilter := database.ContractEvent{ContractAddress: contractAddress, EventSignature: initiatedBridgeEventAbi.ID}
+	initiatedBridgeEvents, err := db.ContractEvents.ContractEventsWithFilter(contractEventFilter, chainSelector, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	erc721BridgeInitiatedEvents := make([]ERC721BridgeInitiatedEvent, len(initiatedBridgeEvents))
+	for i := range initiatedBridgeEvents {
+		erc721Bridge := bindings.L1ERC721BridgeERC721BridgeInitiated{Raw: *initiatedBridgeEvents[i].RLPLog}
+		err := UnpackLog(&erc721Bridge, initiatedBridgeEvents[i].RLPLog, initiatedBridgeEventAbi.Name, erc721BridgeAbi)
+		if err != nil {
+			return nil, err
+		}
+
+		erc721BridgeInitiatedEvents[i] = ERC721BridgeInitiatedEvent{
+			Event: &initiatedBridgeEvents[i],
+			BridgeTransfer: database.ERC721BridgeTransfer{
+				TokenPair:   database.TokenPair{LocalTokenAddress: erc721Bridge.LocalToken, RemoteTokenAddress: erc721Bridge.RemoteToken},
+				TokenID:     erc721Bridge.TokenId,
+				FromAddress: erc721Bridge.From,
+				ToAddress:   erc721Bridge.To,
+				Data:        erc721Bridge.ExtraData,
+				Timestamp:   initiatedBridgeEvents[i].Timestamp,
+			},
+		}
+	}
+
+	return erc721BridgeInitiatedEvents, nil
+}
+
+// ERC721BridgeFinalizedEvents extracts all finalization bridge events from the contracts that follow the ERC721Bridge ABI.
+func ERC721BridgeFinalizedEvents(chainSelector string, contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]ERC721BridgeFinalizedEvent, error) {
+	erc721BridgeAbi, err := bindings.L1ERC721BridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	bridgeFinalizedEventAbi := erc721BridgeAbi.Events["ERC721BridgeFinalized"]
+	contractEventFilter := database.ContractEvent{ContractAddress: contractAddress, EventSignature: bridgeFinalizedEventAbi.ID}
+	bridgeFinalizedEvents, err := db.ContractEvents.ContractEventsWithFilter(contractEventFilter, chainSelector, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	erc721BridgeFinalizedEvents := make([]ERC721BridgeFinalizedEvent, len(bridgeFinalizedEvents))
+	for i := range bridgeFinalizedEvents {
+		erc721Bridge := bindings.L1ERC721BridgeERC721BridgeFinalized{Raw: *bridgeFinalizedEvents[i].RLPLog}
+		err := UnpackLog(&erc721Bridge, bridgeFinalizedEvents[i].RLPLog, bridgeFinalizedEventAbi.Name, erc721BridgeAbi)
+		if err != nil {
+			return nil, err
+		}
+
+		erc721BridgeFinalizedEvents[i] = ERC721BridgeFinalizedEvent{
+			Event: &bridgeFinalizedEvents[i],
+			BridgeTransfer: database.ERC721BridgeTransfer{
+				TokenPair:   database.TokenPair{LocalTokenAddress: erc721Bridge.LocalToken, RemoteTokenAddress: erc721Bridge.RemoteToken},
+				TokenID:     erc721Bridge.TokenId,
+				FromAddress: erc721Bridge.From,
+				ToAddress:   erc721Bridge.To,
+				Data:        erc721Bridge.ExtraData,
+				Timestamp:   bridgeFinalizedEvents[i].Timestamp,
+			},
+		}
+	}
+
+	return erc721BridgeFinalizedEvents, nil
+}
+
+// ERC721BridgeInitiatedMessageHash computes the hash of the CrossDomainMessenger message that carries the initiated bridge, from
+// the fields of the bridge event exactly as the messenger does. The target of the sent message is the ERC721Bridge on the other domain.
+func ERC721BridgeInitiatedMessageHash(initiatedBridge ERC721BridgeInitiatedEvent, sentMessage CrossDomainMessengerSentMessageEvent) (common.Hash, error) {
+	erc721BridgeAbi, err := bindings.L1ERC721BridgeMetaData.GetAbi()
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	// The message calls the finalization of the bridge on the other domain, where the local & remote tokens are swapped
+	transfer := initiatedBridge.BridgeTransfer
+	message, err := erc721BridgeAbi.Pack("finalizeBridgeERC721", transfer.TokenPair.RemoteTokenAddress, transfer.TokenPair.LocalTokenAddress,
+		transfer.FromAddress, transfer.ToAddress, transfer.TokenID, []byte(transfer.Data))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("unable to pack the bridged message: %w", err)
+	}
+
+	return CrossDomainMessageHash(initiatedBridge.Event.ContractAddress, sentMessage, bigint.Zero, message)
+}
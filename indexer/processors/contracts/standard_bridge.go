@@ -1,8 +1,10 @@
 package contracts
 
 import (
+	"fmt"
 	"math/big"
 
+	"github.com/ethereum-optimism/optimism/indexer/bigint"
 	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
@@ -52,6 +54,36 @@ func StandardBridgeFinalizedEvents(chainSelector string, contractAddress common.
 	return append(ethBridgeFinalizedEvents, erc20BridgeFinalizedEvents...), nil
 }
 
+// StandardBridgeInitiatedMessageHash computes the hash of the CrossDomainMessenger message that carries the initiated bridge, from
+// the fields of the bridge event exactly as the messenger does. The correlated sent message provides the fields assigned by the
+// messenger: the nonce, the gas limit and the target, which is the StandardBridge on the other domain.
+func StandardBridgeInitiatedMessageHash(initiatedBridge StandardBridgeInitiatedEvent, sentMessage CrossDomainMessengerSentMessageEvent) (common.Hash, error) {
+	standardBridgeAbi, err := bindings.StandardBridgeMetaData.GetAbi()
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	// The message calls the finalization of the bridge on the other domain, where the local & remote tokens are swapped
+	transfer := initiatedBridge.BridgeTransfer
+	value := bigint.Zero
+	var message []byte
+	switch initiatedBridge.Event.EventSignature {
+	case standardBridgeAbi.Events["ETHBridgeInitiated"].ID:
+		value = transfer.Tx.Amount
+		message, err = standardBridgeAbi.Pack("finalizeBridgeETH", transfer.Tx.FromAddress, transfer.Tx.ToAddress, transfer.Tx.Amount, []byte(transfer.Tx.Data))
+	case standardBridgeAbi.Events["ERC20BridgeInitiated"].ID:
+		message, err = standardBridgeAbi.Pack("finalizeBridgeERC20", transfer.TokenPair.RemoteTokenAddress, transfer.TokenPair.LocalTokenAddress,
+			transfer.Tx.FromAddress, transfer.Tx.ToAddress, transfer.Tx.Amount, []byte(transfer.Tx.Data))
+	default:
+		return common.Hash{}, fmt.Errorf("not a bridge initiated event: %s", initiatedBridge.Event.EventSignature)
+	}
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return CrossDomainMessageHash(initiatedBridge.Event.ContractAddress, sentMessage, value, message)
+}
+
 // parse out eth or erc20 bridge initiated events
 func _standardBridgeInitiatedEvents[BridgeEventType bindings.StandardBridgeETHBridgeInitiated | bindings.StandardBridgeERC20BridgeInitiated](
 	contractAddress common.Address, chainSelector string, db *database.DB, fromHeight, toHeight *big.Int,
@@ -3,6 +3,7 @@ package batcher
 import (
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -25,6 +26,10 @@ type channel struct {
 	pendingTransactions map[txID]txData
 	// Set of confirmed txID -> inclusion block. For determining if the channel is timed out
 	confirmedTransactions map[txID]eth.BlockID
+	// Set of confirmed txID -> frame data. For resubmission if the tx is reorged out of L1 before it is final
+	confirmedTxData map[txID]txData
+	// Number of frames of the confirmed transactions
+	confirmedFrames int
 
 	// True if confirmed TX list is updated. Set to false after updated min/max inclusion blocks.
 	confirmedTxUpdated bool
@@ -32,6 +37,9 @@ type channel struct {
 	minInclusionBlock uint64
 	// Inclusion block number of last confirmed TX
 	maxInclusionBlock uint64
+
+	// True once the channel was reported at risk of timing out.
+	atRisk bool
 }
 
 func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rcfg *rollup.Config) (*channel, error) {
@@ -46,6 +54,7 @@ func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rcfg *
 		channelBuilder:        cb,
 		pendingTransactions:   make(map[txID]txData),
 		confirmedTransactions: make(map[txID]eth.BlockID),
+		confirmedTxData:       make(map[txID]txData),
 	}, nil
 }
 
@@ -54,16 +63,14 @@ func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rcfg *
 func (s *channel) TxFailed(id txID) {
 	if data, ok := s.pendingTransactions[id]; ok {
 		s.log.Trace("marked transaction as failed", "id", id)
-		// Note: when the batcher is changed to send multiple frames per tx,
-		// this needs to be changed to iterate over all frames of the tx data
-		// and re-queue them.
-		s.channelBuilder.PushFrame(data.Frame())
+		// re-queue all frames of the tx data, to be resubmitted
+		for _, frame := range data.Frames() {
+			s.channelBuilder.PushFrame(frame)
+		}
 		delete(s.pendingTransactions, id)
 	} else {
 		s.log.Warn("unknown transaction marked as failed", "id", id)
 	}
-
-	s.metr.RecordBatchTxFailed()
 }
 
 // TxConfirmed marks a transaction as confirmed on L1. Unfortunately even if all frames in
@@ -71,7 +78,6 @@ func (s *channel) TxFailed(id txID) {
 // resubmitted.
 // This function may reset the pending channel if the pending channel has timed out.
 func (s *channel) TxConfirmed(id txID, inclusionBlock eth.BlockID) (bool, []*types.Block) {
-	s.metr.RecordBatchTxSubmitted()
 	s.log.Debug("marked transaction as confirmed", "id", id, "block", inclusionBlock)
 	if _, ok := s.pendingTransactions[id]; !ok {
 		s.log.Warn("unknown transaction marked as confirmed", "id", id, "block", inclusionBlock)
@@ -79,6 +85,8 @@ func (s *channel) TxConfirmed(id txID, inclusionBlock eth.BlockID) (bool, []*typ
 		// We need to keep track of stale transactions instead
 		return false, nil
 	}
+	s.confirmedFrames += len(s.pendingTransactions[id].frames)
+	s.confirmedTxData[id] = s.pendingTransactions[id]
 	delete(s.pendingTransactions, id)
 	s.confirmedTransactions[id] = inclusionBlock
 	s.confirmedTxUpdated = true
@@ -103,7 +111,13 @@ func (s *channel) TxConfirmed(id txID, inclusionBlock eth.BlockID) (bool, []*typ
 
 // updateInclusionBlocks finds the first & last confirmed tx and saves its inclusion numbers
 func (s *channel) updateInclusionBlocks() {
-	if len(s.confirmedTransactions) == 0 || !s.confirmedTxUpdated {
+	if !s.confirmedTxUpdated {
+		return
+	}
+	if len(s.confirmedTransactions) == 0 {
+		// all confirmed txs got reorged out of L1
+		s.minInclusionBlock, s.maxInclusionBlock = 0, 0
+		s.confirmedTxUpdated = false
 		return
 	}
 	// If there are confirmed transactions, find the first + last confirmed block numbers
@@ -138,6 +152,88 @@ func (s *channel) isFullySubmitted() bool {
 	return s.IsFull() && len(s.pendingTransactions)+s.PendingFrames() == 0
 }
 
+// ReorgedTxs returns the number of confirmed transactions of the channel that were included in any of the given L1 blocks.
+func (s *channel) ReorgedTxs(reorged map[eth.BlockID]struct{}) int {
+	n := 0
+	for _, inclusionBlock := range s.confirmedTransactions {
+		if _, ok := reorged[inclusionBlock]; ok {
+			n++
+		}
+	}
+	return n
+}
+
+// CanResubmit returns whether the frames of the confirmed transactions that were included in any of the given
+// reorged L1 blocks can still be resubmitted at the given L1 head: the channel must not reach its channel
+// timeout or sequencing window, minus the safety margin, counting from the remaining confirmed transactions.
+func (s *channel) CanResubmit(reorged map[eth.BlockID]struct{}, l1Head uint64) bool {
+	if timeout := s.SafetyTimeout(); timeout != 0 && l1Head >= timeout {
+		return false
+	}
+	for _, inclusionBlock := range s.confirmedTransactions {
+		if _, ok := reorged[inclusionBlock]; ok {
+			continue
+		}
+		if l1Head+s.cfg.SubSafetyMargin >= inclusionBlock.Number+s.cfg.ChannelTimeout {
+			return false
+		}
+	}
+	return true
+}
+
+// ResubmitReorged re-queues the frames of the confirmed transactions that were included in any of the given
+// reorged L1 blocks, to be resubmitted in the same channel. It returns the number of re-queued frames.
+func (s *channel) ResubmitReorged(reorged map[eth.BlockID]struct{}) int {
+	n := 0
+	for id, inclusionBlock := range s.confirmedTransactions {
+		if _, ok := reorged[inclusionBlock]; !ok {
+			continue
+		}
+		frames := s.confirmedTxData[id].frames
+		for _, frame := range frames {
+			s.channelBuilder.PushFrame(frame)
+		}
+		n += len(frames)
+		s.confirmedFrames -= len(frames)
+		delete(s.confirmedTransactions, id)
+		delete(s.confirmedTxData, id)
+		s.confirmedTxUpdated = true
+	}
+	return n
+}
+
+// InclusionBlocks returns the inclusion blocks of the confirmed transactions of the channel.
+func (s *channel) InclusionBlocks() []eth.BlockID {
+	blocks := make([]eth.BlockID, 0, len(s.confirmedTransactions))
+	for _, inclusionBlock := range s.confirmedTransactions {
+		blocks = append(blocks, inclusionBlock)
+	}
+	return blocks
+}
+
+// pendingTx is a pending transaction, with the frames of the channel that it carries.
+type pendingTx struct {
+	id     txID
+	frames txData
+}
+
+// PendingTxs returns the pending transactions of the channel, ordered by the first frame that each carries.
+func (s *channel) PendingTxs() []pendingTx {
+	txs := make([]pendingTx, 0, len(s.pendingTransactions))
+	for id, td := range s.pendingTransactions {
+		txs = append(txs, pendingTx{id: id, frames: td})
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].frames.ID().frameNumber < txs[j].frames.ID().frameNumber
+	})
+	return txs
+}
+
+// ConfirmedFrames returns the number of frames of the confirmed transactions of the channel.
+func (s *channel) ConfirmedFrames() int {
+	return s.confirmedFrames
+}
+
 func (s *channel) NoneSubmitted() bool {
 	return len(s.confirmedTransactions) == 0 && len(s.pendingTransactions) == 0
 }
@@ -146,10 +242,19 @@ func (s *channel) ID() derive.ChannelID {
 	return s.channelBuilder.ID()
 }
 
-func (s *channel) NextTxData() txData {
-	frame := s.channelBuilder.NextFrame()
-
-	txdata := txData{frame}
+// NextTxData returns the next tx data with up to the configured max frames per tx.
+// If the channel is urgent at the given L1 head, the tx data is marked urgent, and
+// carries a single frame if urgent frames are split.
+// HasFrame must be called prior to check if there's a next frame available.
+func (s *channel) NextTxData(l1Head uint64) txData {
+	txdata := txData{asBlob: s.cfg.UseBlobs, urgent: s.Urgent(l1Head)}
+	maxFrames := s.cfg.framesPerTx()
+	if txdata.urgent && s.cfg.SplitUrgentFrames {
+		maxFrames = 1
+	}
+	for len(txdata.frames) < maxFrames && s.channelBuilder.HasFrame() {
+		txdata.frames = append(txdata.frames, s.channelBuilder.NextFrame())
+	}
 	id := txdata.ID()
 
 	s.log.Trace("returning next tx data", "id", id)
@@ -158,6 +263,25 @@ func (s *channel) NextTxData() txData {
 	return txdata
 }
 
+// NextFrameSize returns the data size of the next frame.
+// HasFrame must be called prior to check if there's a next frame available.
+func (s *channel) NextFrameSize() int {
+	return s.channelBuilder.NextFrameSize()
+}
+
+// NextFrame returns the next frame, to be sent in a transaction that carries frames of multiple channels.
+// The frames of the channel that the transaction carries must be recorded with TxSent.
+// HasFrame must be called prior to check if there's a next frame available.
+func (s *channel) NextFrame() frameData {
+	return s.channelBuilder.NextFrame()
+}
+
+// TxSent records the transaction with the given ID as pending, carrying the given frames of this channel.
+func (s *channel) TxSent(id txID, frames txData) {
+	s.log.Trace("recording multi-channel tx", "id", id, "frames", len(frames.Frames()))
+	s.pendingTransactions[id] = frames
+}
+
 func (s *channel) HasFrame() bool {
 	return s.channelBuilder.HasFrame()
 }
@@ -174,10 +298,32 @@ func (s *channel) RegisterL1Block(l1BlockNum uint64) {
 	s.channelBuilder.RegisterL1Block(l1BlockNum)
 }
 
+func (s *channel) SafetyTimeout() uint64 {
+	return s.channelBuilder.SafetyTimeout()
+}
+
+// Urgent returns whether the submission of the remaining frames of the channel is escalated at the given
+// L1 head, because the L1 head is within the urgency margin of the channel's safety timeout.
+func (s *channel) Urgent(l1Head uint64) bool {
+	timeout := s.SafetyTimeout()
+	return s.cfg.UrgencyMargin != 0 && timeout != 0 && !s.isFullySubmitted() && l1Head+s.cfg.UrgencyMargin >= timeout
+}
+
+// AtRisk returns whether frames of the channel risk landing after the channel timeout, which would waste
+// the entire channel: the channel isn't fully submitted, but the L1 head reached its safety timeout.
+func (s *channel) AtRisk(l1Head uint64) bool {
+	timeout := s.SafetyTimeout()
+	return timeout != 0 && !s.isFullySubmitted() && !s.NoneSubmitted() && l1Head >= timeout
+}
+
 func (s *channel) AddBlock(block *types.Block) (derive.L1BlockInfo, error) {
 	return s.channelBuilder.AddBlock(block)
 }
 
+func (s *channel) Blocks() []*types.Block {
+	return s.channelBuilder.Blocks()
+}
+
 func (s *channel) InputBytes() int {
 	return s.channelBuilder.InputBytes()
 }
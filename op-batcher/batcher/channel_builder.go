@@ -6,20 +6,29 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"sort"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// l1BlockTime is the L1 block time in seconds, to convert L1 block spans into L2 blocks.
+const l1BlockTime = 12
+
 var (
 	ErrInvalidChannelTimeout = errors.New("channel timeout is less than the safety margin")
 	ErrMaxFrameIndex         = errors.New("max frame index reached (uint16)")
 	ErrMaxDurationReached    = errors.New("max channel duration reached")
+	ErrMaxBlocksReached      = errors.New("max blocks per channel reached")
+	ErrMaxInputBytesReached  = errors.New("max channel input bytes reached")
 	ErrChannelTimeoutClose   = errors.New("close to channel timeout")
 	ErrSeqWindowClose        = errors.New("close to sequencer window timeout")
 	ErrTerminated            = errors.New("channel terminated")
+	ErrBlockGap              = errors.New("block does not extend the last block of the channel")
 )
 
 type ChannelFullError struct {
@@ -34,6 +43,26 @@ func (e *ChannelFullError) Unwrap() error {
 	return e.Err
 }
 
+// closedReason returns the metrics label of the reason why a channel is full,
+// as returned by channelBuilder.FullErr.
+func closedReason(fullErr error) string {
+	switch {
+	case errors.Is(fullErr, ErrMaxDurationReached):
+		return metrics.ClosedReasonMaxDuration
+	case errors.Is(fullErr, ErrMaxBlocksReached):
+		return metrics.ClosedReasonMaxBlocks
+	case errors.Is(fullErr, ErrMaxInputBytesReached):
+		return metrics.ClosedReasonMaxInput
+	case errors.Is(fullErr, ErrChannelTimeoutClose), errors.Is(fullErr, ErrSeqWindowClose):
+		return metrics.ClosedReasonTimeout
+	case errors.Is(fullErr, ErrTerminated):
+		return metrics.ClosedReasonShutdown
+	default:
+		// compressor full, too many RLP bytes or max frame index reached
+		return metrics.ClosedReasonFull
+	}
+}
+
 type ChannelConfig struct {
 	// Number of epochs (L1 blocks) per sequencing window, including the epoch
 	// L1 origin block itself
@@ -54,14 +83,54 @@ type ChannelConfig struct {
 	// a channel's timeout and sequencing window, to guarantee safe inclusion of
 	// a channel on L1.
 	SubSafetyMargin uint64
+	// UrgencyMargin is the number of L1 blocks before a channel's safety timeout,
+	// from which on the submission of its remaining frames is escalated, so that
+	// they land before the channel times out.
+	//
+	// If 0, the submission is not escalated.
+	UrgencyMargin uint64
+	// SplitUrgentFrames sends the remaining frames of an urgent channel in one
+	// transaction per frame, so that they are submitted in parallel with
+	// consecutive nonces, up to the max pending transactions.
+	SplitUrgentFrames bool
 	// The maximum byte-size a frame can have.
 	MaxFrameSize uint64
+	// MaxBlocksPerChannel is the maximum number of L2 blocks to add to a channel.
+	// The channel is closed once it holds this many blocks.
+	//
+	// If 0, the number of blocks is not limited.
+	MaxBlocksPerChannel uint64
+	// MaxChannelInputBytes is the maximum amount of uncompressed input data of a
+	// channel. The channel is closed once its input reaches this amount, so the
+	// last added block may exceed it.
+	//
+	// If 0, the input is only limited by the protocol's max RLP bytes per channel.
+	MaxChannelInputBytes uint64
 
 	// CompressorConfig contains the configuration for creating new compressors.
 	CompressorConfig compressor.Config
 
 	// BatchType indicates whether the channel uses SingularBatch or SpanBatch.
 	BatchType uint
+
+	// UseBlobs indicates that the frames are sent in blobs of blob transactions, one frame per blob,
+	// instead of as calldata.
+	UseBlobs bool
+	// MaxFramesPerTx is the maximum number of frames to send in a single transaction.
+	// Only blob transactions can carry more than one frame. 0 is treated as 1.
+	MaxFramesPerTx int
+	// MultiFrameTxs indicates that a transaction can carry the frames of multiple channels,
+	// e.g. the last frames of a channel and the first frames of the next channel.
+	// Calldata transactions then carry as many frames as fit into MaxTxSize.
+	MultiFrameTxs bool
+	// MaxTxSize is the maximum calldata size of a multi-frame calldata transaction,
+	// including the version byte.
+	MaxTxSize uint64
+
+	// SkipEmptyBlocks indicates that the batcher leaves out the blocks that the derivation pipeline
+	// regenerates as empty batches once the sequencing window of their epoch expires. The safe head
+	// then stalls at such a block for up to the sequencing window. Requires span batches and Delta.
+	SkipEmptyBlocks bool
 }
 
 // Check validates the [ChannelConfig] parameters.
@@ -72,6 +141,11 @@ func (cc *ChannelConfig) Check() error {
 		return ErrInvalidChannelTimeout
 	}
 
+	if cc.UrgencyMargin != 0 && cc.UrgencyMargin >= cc.ChannelTimeout-cc.SubSafetyMargin {
+		return fmt.Errorf("urgency margin %d must be less than the channel timeout minus the sub safety margin, %d",
+			cc.UrgencyMargin, cc.ChannelTimeout-cc.SubSafetyMargin)
+	}
+
 	// If the [MaxFrameSize] is set to 0, the channel builder
 	// will infinitely loop when trying to create frames in the
 	// [channelBuilder.OutputFrames] function.
@@ -87,13 +161,69 @@ func (cc *ChannelConfig) Check() error {
 		return fmt.Errorf("max frame size %d is less than the minimum 23", cc.MaxFrameSize)
 	}
 
+	if cc.MaxChannelInputBytes > derive.MaxRLPBytesPerChannel {
+		return fmt.Errorf("max channel input bytes %d exceeds the max RLP bytes per channel of %d", cc.MaxChannelInputBytes, derive.MaxRLPBytesPerChannel)
+	}
+
 	if cc.BatchType > derive.SpanBatchType {
 		return fmt.Errorf("unrecognized batch type: %d", cc.BatchType)
 	}
 
+	// Skipped blocks are only supported with span batches, which are split into channels at the gaps.
+	if cc.SkipEmptyBlocks && cc.BatchType != derive.SpanBatchType {
+		return errors.New("skipping empty blocks requires span batches")
+	}
+
+	// A frame, prefixed by the derivation version byte, must fit into a blob.
+	if cc.UseBlobs && cc.MaxFrameSize > eth.MaxBlobDataSize-1 {
+		return fmt.Errorf("max frame size %d exceeds the blob capacity of %d", cc.MaxFrameSize, eth.MaxBlobDataSize-1)
+	}
+
+	if cc.MaxFramesPerTx > 1 && !cc.UseBlobs {
+		return errors.New("multiple frames per tx are only supported with blobs")
+	}
+	if cc.MaxFramesPerTx > eth.MaxBlobsPerBlobTx {
+		return fmt.Errorf("max frames per tx %d exceeds the max blobs per tx of %d", cc.MaxFramesPerTx, eth.MaxBlobsPerBlobTx)
+	}
+
+	if err := cc.CompressorConfig.Check(); err != nil {
+		return fmt.Errorf("invalid compressor config: %w", err)
+	}
+
+	if cc.MultiFrameTxs && !cc.UseBlobs && cc.MaxTxSize < cc.MaxFrameSize+1 {
+		return fmt.Errorf("max tx size %d is less than the max frame size %d plus the version byte", cc.MaxTxSize, cc.MaxFrameSize)
+	}
+
 	return nil
 }
 
+// CheckRollupConfig validates the channel limits against the given rollup config: a channel must be
+// able to reach its max number of blocks before it's closed by the end of the sequencing window.
+func (cc *ChannelConfig) CheckRollupConfig(rcfg *rollup.Config) error {
+	// Before Delta, the derivation pipeline rejects span batches, so no block can be skipped.
+	if cc.SkipEmptyBlocks && rcfg.DeltaTime == nil {
+		return errors.New("skipping empty blocks requires the Delta upgrade to be scheduled")
+	}
+	if cc.MaxBlocksPerChannel == 0 || cc.SeqWindowSize <= cc.SubSafetyMargin || rcfg.BlockTime == 0 {
+		return nil
+	}
+	// the L2 blocks of the sequencing window, minus the safety margin, after the L1 origin of the first block
+	windowBlocks := (cc.SeqWindowSize - cc.SubSafetyMargin) * l1BlockTime / rcfg.BlockTime
+	if cc.MaxBlocksPerChannel > windowBlocks {
+		return fmt.Errorf("max blocks per channel %d exceeds the %d L2 blocks that fit into the sequencing window of %d L1 blocks minus the safety margin of %d",
+			cc.MaxBlocksPerChannel, windowBlocks, cc.SeqWindowSize, cc.SubSafetyMargin)
+	}
+	return nil
+}
+
+// framesPerTx returns the maximum number of frames to send in a single transaction.
+func (cc *ChannelConfig) framesPerTx() int {
+	if cc.MaxFramesPerTx < 1 {
+		return 1
+	}
+	return cc.MaxFramesPerTx
+}
+
 type frameID struct {
 	chID        derive.ChannelID
 	frameNumber uint16
@@ -117,6 +247,12 @@ type channelBuilder struct {
 	timeout uint64
 	// reason for currently set timeout
 	timeoutReason error
+	// L1 block number timeout of combined
+	// - consensus channel timeout,
+	// - sequencing window timeout,
+	// so excluding the channel duration timeout.
+	// 0 if no safety timeout set yet.
+	safetyTimeout uint64
 
 	// Reason for the channel being full. Set by setFullErr so it's always
 	// guaranteed to be a ChannelFullError wrapping the specific reason.
@@ -186,6 +322,7 @@ func (c *channelBuilder) Reset() error {
 	c.blocks = c.blocks[:0]
 	c.frames = c.frames[:0]
 	c.timeout = 0
+	c.safetyTimeout = 0
 	c.fullErr = nil
 	return c.co.Reset()
 }
@@ -205,6 +342,11 @@ func (c *channelBuilder) AddBlock(block *types.Block) (derive.L1BlockInfo, error
 	if c.IsFull() {
 		return derive.L1BlockInfo{}, c.FullErr()
 	}
+	// A span batch cannot represent a gap of skipped blocks, the block must go into the next channel.
+	if n := len(c.blocks); c.cfg.SkipEmptyBlocks && n > 0 && c.blocks[n-1].Hash() != block.ParentHash() {
+		c.setFullErr(ErrBlockGap)
+		return derive.L1BlockInfo{}, c.FullErr()
+	}
 
 	batch, l1info, err := derive.BlockToSingularBatch(block)
 	if err != nil {
@@ -220,9 +362,13 @@ func (c *channelBuilder) AddBlock(block *types.Block) (derive.L1BlockInfo, error
 	c.blocks = append(c.blocks, block)
 	c.updateSwTimeout(batch)
 
+	// Adding this block still worked, so don't return error, just mark as full
 	if err = c.co.FullErr(); err != nil {
 		c.setFullErr(err)
-		// Adding this block still worked, so don't return error, just mark as full
+	} else if c.cfg.MaxBlocksPerChannel != 0 && uint64(len(c.blocks)) >= c.cfg.MaxBlocksPerChannel {
+		c.setFullErr(ErrMaxBlocksReached)
+	} else if c.cfg.MaxChannelInputBytes != 0 && uint64(c.co.InputBytes()) >= c.cfg.MaxChannelInputBytes {
+		c.setFullErr(ErrMaxInputBytesReached)
 	}
 
 	return l1info, nil
@@ -245,6 +391,7 @@ func (c *channelBuilder) RegisterL1Block(l1BlockNum uint64) {
 func (c *channelBuilder) FramePublished(l1BlockNum uint64) {
 	timeout := l1BlockNum + c.cfg.ChannelTimeout - c.cfg.SubSafetyMargin
 	c.updateTimeout(timeout, ErrChannelTimeoutClose)
+	c.updateSafetyTimeout(timeout)
 }
 
 // updateDurationTimeout updates the block timeout with the channel duration
@@ -267,6 +414,23 @@ func (c *channelBuilder) updateDurationTimeout(l1BlockNum uint64) {
 func (c *channelBuilder) updateSwTimeout(batch *derive.SingularBatch) {
 	timeout := uint64(batch.EpochNum) + c.cfg.SeqWindowSize - c.cfg.SubSafetyMargin
 	c.updateTimeout(timeout, ErrSeqWindowClose)
+	c.updateSafetyTimeout(timeout)
+}
+
+// updateSafetyTimeout updates the safety timeout block to the given block number
+// if it is earlier than the current safety timeout, or if it is still unset.
+func (c *channelBuilder) updateSafetyTimeout(timeoutBlockNum uint64) {
+	if c.safetyTimeout == 0 || c.safetyTimeout > timeoutBlockNum {
+		c.safetyTimeout = timeoutBlockNum
+	}
+}
+
+// SafetyTimeout returns the L1 block number at which the channel times out
+// because of the consensus channel timeout or the sequencing window of its blocks.
+// Unlike the channel duration timeout, the batcher must not delay submission past it.
+// It returns 0 if no safety timeout is set yet.
+func (c *channelBuilder) SafetyTimeout() uint64 {
+	return c.safetyTimeout
 }
 
 // updateTimeout updates the timeout block to the given block number if it is
@@ -312,9 +476,12 @@ func (c *channelBuilder) IsFull() bool {
 //   - ErrMaxFrameIndex if the maximum number of frames has been generated
 //     (uint16),
 //   - ErrMaxDurationReached if the max channel duration got reached,
+//   - ErrMaxBlocksReached if the max number of blocks per channel got reached,
+//   - ErrMaxInputBytesReached if the max channel input bytes got reached,
 //   - ErrChannelTimeoutClose if the consensus channel timeout got too close,
 //   - ErrSeqWindowClose if the end of the sequencer window got too close,
-//   - ErrTerminated if the channel was explicitly terminated.
+//   - ErrTerminated if the channel was explicitly terminated,
+//   - ErrBlockGap if the latest AddBlock call skipped over blocks.
 func (c *channelBuilder) FullErr() error {
 	return c.fullErr
 }
@@ -430,6 +597,16 @@ func (c *channelBuilder) PendingFrames() int {
 	return len(c.frames)
 }
 
+// NextFrameSize returns the data size of the next available frame.
+// HasFrame must be called prior to check if there's a next frame available.
+// Panics if called when there's no next frame.
+func (c *channelBuilder) NextFrameSize() int {
+	if len(c.frames) == 0 {
+		panic("no next frame")
+	}
+	return len(c.frames[0].data)
+}
+
 // NextFrame returns the next available frame.
 // HasFrame must be called prior to check if there's a next frame available.
 // Panics if called when there's no next frame.
@@ -443,11 +620,17 @@ func (c *channelBuilder) NextFrame() frameData {
 	return f
 }
 
-// PushFrame adds the frame back to the internal frames queue. Panics if not of
-// the same channel.
+// PushFrame adds the frame back to the internal frames queue, in order of the
+// frame numbers, so that frames of failed txs are resubmitted in order, even if
+// concurrent txs with later frames failed before. Panics if not of the same channel.
 func (c *channelBuilder) PushFrame(frame frameData) {
 	if frame.id.chID != c.ID() {
 		panic("wrong channel")
 	}
-	c.frames = append(c.frames, frame)
+	i := sort.Search(len(c.frames), func(i int) bool {
+		return c.frames[i].id.frameNumber > frame.id.frameNumber
+	})
+	c.frames = append(c.frames, frameData{})
+	copy(c.frames[i+1:], c.frames[i:])
+	c.frames[i] = frame
 }
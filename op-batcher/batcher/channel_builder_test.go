@@ -2,6 +2,7 @@ package batcher
 
 import (
 	"bytes"
+	"compress/zlib"
 	"errors"
 	"fmt"
 	"math"
@@ -10,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	dtest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
@@ -31,6 +33,7 @@ var defaultTestChannelConfig = ChannelConfig{
 		TargetFrameSize:  100000,
 		TargetNumFrames:  1,
 		ApproxComprRatio: 0.4,
+		Level:            zlib.BestCompression,
 	},
 	BatchType: derive.SingularBatchType,
 }
@@ -53,6 +56,26 @@ func TestChannelConfig_Check(t *testing.T) {
 	timeoutChannelConfig := defaultTestChannelConfig
 	timeoutChannelConfig.ChannelTimeout = 0
 	timeoutChannelConfig.SubSafetyMargin = 1
+	multiFrameChannelConfig := defaultTestChannelConfig
+	multiFrameChannelConfig.MaxFramesPerTx = 2
+	largeBlobChannelConfig := defaultTestChannelConfig
+	largeBlobChannelConfig.UseBlobs = true
+	largeBlobChannelConfig.MaxFrameSize = eth.MaxBlobDataSize
+	manyBlobsChannelConfig := defaultTestChannelConfig
+	manyBlobsChannelConfig.UseBlobs = true
+	manyBlobsChannelConfig.MaxFrameSize = eth.MaxBlobDataSize - 1
+	manyBlobsChannelConfig.MaxFramesPerTx = eth.MaxBlobsPerBlobTx + 1
+	blobChannelConfig := manyBlobsChannelConfig
+	blobChannelConfig.MaxFramesPerTx = eth.MaxBlobsPerBlobTx
+	smallTxChannelConfig := defaultTestChannelConfig
+	smallTxChannelConfig.MultiFrameTxs = true
+	smallTxChannelConfig.MaxTxSize = smallTxChannelConfig.MaxFrameSize
+	largeInputChannelConfig := defaultTestChannelConfig
+	largeInputChannelConfig.MaxChannelInputBytes = derive.MaxRLPBytesPerChannel + 1
+	urgencyChannelConfig := defaultTestChannelConfig
+	urgencyChannelConfig.UrgencyMargin = urgencyChannelConfig.ChannelTimeout - urgencyChannelConfig.SubSafetyMargin
+	skipChannelConfig := defaultTestChannelConfig
+	skipChannelConfig.SkipEmptyBlocks = true
 	tests := []test{
 		{
 			input: defaultTestChannelConfig,
@@ -72,6 +95,54 @@ func TestChannelConfig_Check(t *testing.T) {
 				require.EqualError(t, output, "max frame size cannot be zero")
 			},
 		},
+		{
+			input: multiFrameChannelConfig,
+			assertion: func(output error) {
+				require.EqualError(t, output, "multiple frames per tx are only supported with blobs")
+			},
+		},
+		{
+			input: largeBlobChannelConfig,
+			assertion: func(output error) {
+				require.EqualError(t, output, "max frame size 126972 exceeds the blob capacity of 126971")
+			},
+		},
+		{
+			input: manyBlobsChannelConfig,
+			assertion: func(output error) {
+				require.EqualError(t, output, "max frames per tx 7 exceeds the max blobs per tx of 6")
+			},
+		},
+		{
+			input: blobChannelConfig,
+			assertion: func(output error) {
+				require.NoError(t, output)
+			},
+		},
+		{
+			input: smallTxChannelConfig,
+			assertion: func(output error) {
+				require.EqualError(t, output, "max tx size 120000 is less than the max frame size 120000 plus the version byte")
+			},
+		},
+		{
+			input: largeInputChannelConfig,
+			assertion: func(output error) {
+				require.EqualError(t, output, "max channel input bytes 10000001 exceeds the max RLP bytes per channel of 10000000")
+			},
+		},
+		{
+			input: urgencyChannelConfig,
+			assertion: func(output error) {
+				require.ErrorContains(t, output, "urgency margin")
+			},
+		},
+		{
+			input: skipChannelConfig,
+			assertion: func(output error) {
+				require.EqualError(t, output, "skipping empty blocks requires span batches")
+			},
+		},
 	}
 	for i := 1; i < derive.FrameV0OverHeadSize; i++ {
 		smallChannelConfig := defaultTestChannelConfig
@@ -91,6 +162,31 @@ func TestChannelConfig_Check(t *testing.T) {
 	}
 }
 
+// TestChannelConfig_CheckRollupConfig tests that the max blocks per channel must fit into the sequencing window.
+func TestChannelConfig_CheckRollupConfig(t *testing.T) {
+	rcfg := defaultTestRollupConfig
+	rcfg.BlockTime = 2
+	cfg := defaultTestChannelConfig // window of 15 - 4 L1 blocks, so 66 L2 blocks
+	require.NoError(t, cfg.CheckRollupConfig(&rcfg), "no limit")
+	cfg.MaxBlocksPerChannel = 66
+	require.NoError(t, cfg.CheckRollupConfig(&rcfg))
+	cfg.MaxBlocksPerChannel = 67
+	require.EqualError(t, cfg.CheckRollupConfig(&rcfg),
+		"max blocks per channel 67 exceeds the 66 L2 blocks that fit into the sequencing window of 15 L1 blocks minus the safety margin of 4")
+}
+
+// TestChannelConfig_CheckRollupConfigSkipEmptyBlocks tests that empty blocks can only be skipped with Delta scheduled.
+func TestChannelConfig_CheckRollupConfigSkipEmptyBlocks(t *testing.T) {
+	rcfg := defaultTestRollupConfig
+	cfg := defaultTestChannelConfig
+	cfg.BatchType = derive.SpanBatchType
+	cfg.SkipEmptyBlocks = true
+	require.EqualError(t, cfg.CheckRollupConfig(&rcfg), "skipping empty blocks requires the Delta upgrade to be scheduled")
+	deltaTime := uint64(100)
+	rcfg.DeltaTime = &deltaTime
+	require.NoError(t, cfg.CheckRollupConfig(&rcfg))
+}
+
 // FuzzChannelConfig_CheckTimeout tests the [ChannelConfig] [Check] function
 // with fuzzing to make sure that a [ErrInvalidChannelTimeout] is thrown when
 // the [ChannelTimeout] is less than the [SubSafetyMargin].
@@ -256,6 +352,88 @@ func FuzzDurationTimeoutMaxChannelDuration(f *testing.F) {
 	})
 }
 
+// TestChannelClosedReason tests the metrics labels of the reasons for a channel being full.
+func TestChannelClosedReason(t *testing.T) {
+	tests := []struct {
+		err    error
+		reason string
+	}{
+		{derive.CompressorFullErr, metrics.ClosedReasonFull},
+		{derive.ErrTooManyRLPBytes, metrics.ClosedReasonFull},
+		{ErrMaxFrameIndex, metrics.ClosedReasonFull},
+		{ErrMaxDurationReached, metrics.ClosedReasonMaxDuration},
+		{ErrMaxBlocksReached, metrics.ClosedReasonMaxBlocks},
+		{ErrMaxInputBytesReached, metrics.ClosedReasonMaxInput},
+		{ErrChannelTimeoutClose, metrics.ClosedReasonTimeout},
+		{ErrSeqWindowClose, metrics.ClosedReasonTimeout},
+		{ErrTerminated, metrics.ClosedReasonShutdown},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.reason, closedReason(&ChannelFullError{Err: test.err}), test.err.Error())
+	}
+}
+
+// TestChannelBuilder_MaxDurationAndChannelTimeout tests that the earliest of
+// the max channel duration and the channel timeout closes the channel.
+func TestChannelBuilder_MaxDurationAndChannelTimeout(t *testing.T) {
+	channelConfig := defaultTestChannelConfig
+	channelConfig.MaxChannelDuration = 10
+	channelConfig.ChannelTimeout = 5
+	channelConfig.SubSafetyMargin = 1
+
+	t.Run("channel timeout first", func(t *testing.T) {
+		cb, err := newChannelBuilder(channelConfig, nil)
+		require.NoError(t, err)
+		cb.RegisterL1Block(100)
+		// 102 + 5 - 1 = 106 is before the max duration at 110
+		cb.FramePublished(102)
+		cb.RegisterL1Block(105)
+		require.False(t, cb.IsFull())
+		cb.RegisterL1Block(106)
+		require.ErrorIs(t, cb.FullErr(), ErrChannelTimeoutClose)
+	})
+	t.Run("max duration first", func(t *testing.T) {
+		cb, err := newChannelBuilder(channelConfig, nil)
+		require.NoError(t, err)
+		cb.RegisterL1Block(100)
+		// 108 + 5 - 1 = 112 is after the max duration at 110
+		cb.FramePublished(108)
+		cb.RegisterL1Block(109)
+		require.False(t, cb.IsFull())
+		cb.RegisterL1Block(110)
+		require.ErrorIs(t, cb.FullErr(), ErrMaxDurationReached)
+	})
+}
+
+// TestChannelBuilder_SafetyTimeout tests that the safety timeout tracks the channel timeout
+// and the sequencing window, but not the max channel duration.
+func TestChannelBuilder_SafetyTimeout(t *testing.T) {
+	channelConfig := defaultTestChannelConfig
+	channelConfig.MaxChannelDuration = 2
+	channelConfig.ChannelTimeout = 50
+	channelConfig.SeqWindowSize = 30
+	channelConfig.SubSafetyMargin = 5
+
+	cb, err := newChannelBuilder(channelConfig, &defaultTestRollupConfig)
+	require.NoError(t, err)
+	cb.RegisterL1Block(100)
+	require.Equal(t, uint64(102), cb.timeout)
+	require.Zero(t, cb.SafetyTimeout(), "no safety timeout without blocks or published frames")
+
+	// the L1 origin of the block is 100: 100 + 30 - 5
+	_, err = cb.AddBlock(newMiniL2Block(0))
+	require.NoError(t, err)
+	require.Equal(t, uint64(125), cb.SafetyTimeout())
+
+	// 101 + 50 - 5 = 146 is after the sequencing window timeout
+	cb.FramePublished(101)
+	require.Equal(t, uint64(125), cb.SafetyTimeout())
+	require.Equal(t, uint64(102), cb.timeout)
+
+	require.NoError(t, cb.Reset())
+	require.Zero(t, cb.SafetyTimeout())
+}
+
 // FuzzChannelCloseTimeout ensures that the channel builder has a [ErrChannelTimeoutClose]
 // as long as the timeout constraint is met and the builder's timeout is greater than
 // the calculated timeout
@@ -378,6 +556,7 @@ func TestChannelBuilderBatchType(t *testing.T) {
 		{"ChannelBuilder_PendingFrames_TotalFrames", ChannelBuilder_PendingFrames_TotalFrames},
 		{"ChannelBuilder_InputBytes", ChannelBuilder_InputBytes},
 		{"ChannelBuilder_OutputBytes", ChannelBuilder_OutputBytes},
+		{"ChannelBuilder_ShadowCompressorFrameBudget", ChannelBuilder_ShadowCompressorFrameBudget},
 	}
 	for _, test := range tests {
 		test := test
@@ -434,6 +613,26 @@ func TestChannelBuilder_NextFrame(t *testing.T) {
 	require.PanicsWithValue(t, "no next frame", func() { cb.NextFrame() })
 }
 
+// TestChannelBuilder_PushFrameInOrder tests that frames pushed back into the channel builder,
+// e.g. of failed concurrent txs, are returned in order of their frame numbers.
+func TestChannelBuilder_PushFrameInOrder(t *testing.T) {
+	cb, err := newChannelBuilder(defaultTestChannelConfig, nil)
+	require.NoError(t, err)
+	frame := func(fn uint16) frameData {
+		return frameData{id: frameID{chID: cb.ID(), frameNumber: fn}, data: []byte{byte(fn)}}
+	}
+
+	cb.PushFrame(frame(3))
+	cb.PushFrame(frame(4))
+	cb.PushFrame(frame(0))
+	cb.PushFrame(frame(2))
+	cb.PushFrame(frame(1))
+	for fn := uint16(0); fn < 5; fn++ {
+		require.Equal(t, frame(fn), cb.NextFrame())
+	}
+	require.False(t, cb.HasFrame())
+}
+
 // TestChannelBuilder_OutputWrongFramePanic tests that a panic is thrown when a frame is pushed with an invalid frame id
 func TestChannelBuilder_OutputWrongFramePanic(t *testing.T) {
 	channelConfig := defaultTestChannelConfig
@@ -646,6 +845,30 @@ func ChannelBuilder_AddBlock(t *testing.T, batchType uint) {
 	require.ErrorIs(t, addMiniBlock(cb), derive.CompressorFullErr)
 }
 
+// TestChannelBuilder_AddBlockGap tests that a block that skips over blocks is not
+// added to the channel when skipping empty blocks, but closes the channel instead.
+func TestChannelBuilder_AddBlockGap(t *testing.T) {
+	channelConfig := defaultTestChannelConfig
+	channelConfig.BatchType = derive.SpanBatchType
+	channelConfig.SkipEmptyBlocks = true
+	cb, err := newChannelBuilder(channelConfig, &defaultTestRollupConfig)
+	require.NoError(t, err)
+
+	a := newMiniL2BlockWithNumberParent(0, big.NewInt(1), common.Hash{})
+	b := newMiniL2BlockWithNumberParent(0, big.NewInt(2), a.Hash())
+	c := newMiniL2BlockWithNumberParent(0, big.NewInt(4), common.Hash{0x03})
+	_, err = cb.AddBlock(a)
+	require.NoError(t, err)
+	_, err = cb.AddBlock(b)
+	require.NoError(t, err)
+	require.False(t, cb.IsFull())
+
+	_, err = cb.AddBlock(c)
+	require.ErrorIs(t, err, ErrBlockGap)
+	require.ErrorIs(t, cb.FullErr(), ErrBlockGap)
+	require.Equal(t, []*types.Block{a, b}, cb.Blocks())
+}
+
 // ChannelBuilder_Reset tests the [Reset] function
 func ChannelBuilder_Reset(t *testing.T, batchType uint) {
 	channelConfig := defaultTestChannelConfig
@@ -875,6 +1098,84 @@ func ChannelBuilder_OutputBytes(t *testing.T, batchType uint) {
 	require.Equal(cb.OutputBytes(), flen)
 }
 
+// ChannelBuilder_ShadowCompressorFrameBudget ensures that channels built with the shadow compressor
+// don't exceed their frame budget at any compression level, so that no extra tiny frame is created.
+func ChannelBuilder_ShadowCompressorFrameBudget(t *testing.T, batchType uint) {
+	const tnf = 4
+	for _, level := range []int{zlib.BestSpeed, zlib.DefaultCompression, zlib.BestCompression} {
+		rng := rand.New(rand.NewSource(5323))
+		cfg := defaultTestChannelConfig
+		cfg.MaxFrameSize = 1000
+		cfg.CompressorConfig = compressor.Config{
+			TargetFrameSize: 1000,
+			TargetNumFrames: tnf,
+			Kind:            compressor.ShadowKind,
+			Level:           level,
+		}
+		cfg.BatchType = batchType
+		cb, err := newChannelBuilder(cfg, &defaultTestRollupConfig)
+		require.NoError(t, err)
+
+		for !cb.IsFull() {
+			block := dtest.RandomL2BlockWithChainId(rng, rng.Intn(4), defaultTestRollupConfig.L2ChainID)
+			_, err := cb.AddBlock(block)
+			if !cb.IsFull() {
+				require.NoError(t, err)
+			}
+		}
+		require.ErrorIs(t, cb.FullErr(), derive.CompressorFullErr)
+		require.NoError(t, cb.OutputFrames())
+		require.Greater(t, cb.TotalFrames(), 1, "level %d", level)
+		require.LessOrEqual(t, cb.TotalFrames(), tnf, "the frame budget is not exceeded at level %d", level)
+		for cb.HasFrame() {
+			require.LessOrEqual(t, len(cb.NextFrame().data), int(cfg.MaxFrameSize))
+		}
+	}
+}
+
+// BenchmarkChannelBuilderCompression compares the compression ratios and speeds of the compressors
+// at different compression levels, on a sample of random L2 blocks that fill a blob.
+func BenchmarkChannelBuilderCompression(b *testing.B) {
+	rng := rand.New(rand.NewSource(8723))
+	blocks := make([]*types.Block, 500)
+	for i := range blocks {
+		blocks[i] = dtest.RandomL2BlockWithChainId(rng, rng.Intn(16), defaultTestRollupConfig.L2ChainID)
+	}
+	for _, kind := range []string{compressor.RatioKind, compressor.ShadowKind} {
+		for _, level := range []int{zlib.BestSpeed, zlib.DefaultCompression, zlib.BestCompression} {
+			for _, batchType := range []uint{derive.SingularBatchType, derive.SpanBatchType} {
+				cfg := defaultTestChannelConfig
+				cfg.MaxFrameSize = eth.MaxBlobDataSize - 1
+				cfg.CompressorConfig = compressor.Config{
+					TargetFrameSize:  eth.MaxBlobDataSize - 1,
+					TargetNumFrames:  1,
+					ApproxComprRatio: 0.6,
+					Kind:             kind,
+					Level:            level,
+				}
+				cfg.BatchType = batchType
+				b.Run(fmt.Sprintf("%s_level=%d_batchType=%d", kind, level, batchType), func(b *testing.B) {
+					var inputBytes, outputBytes int
+					for i := 0; i < b.N; i++ {
+						cb, err := newChannelBuilder(cfg, &defaultTestRollupConfig)
+						require.NoError(b, err)
+						for _, block := range blocks {
+							if _, err := cb.AddBlock(block); err != nil {
+								break
+							}
+						}
+						cb.Close()
+						require.NoError(b, cb.OutputFrames())
+						inputBytes, outputBytes = cb.InputBytes(), cb.OutputBytes()
+					}
+					b.ReportMetric(float64(outputBytes)/float64(inputBytes), "compr_ratio")
+					b.ReportMetric(float64(inputBytes), "input_bytes")
+				})
+			}
+		}
+	}
+}
+
 func blockBatchRlpSize(t *testing.T, b *types.Block) int {
 	t.Helper()
 	singularBatch, _, err := derive.BlockToSingularBatch(b)
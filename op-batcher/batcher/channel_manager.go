@@ -4,9 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"sync"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -38,13 +40,23 @@ type channelManager struct {
 
 	// channel to write new block data to
 	currentChannel *channel
-	// channels to read frame data from, for writing batches onchain
+	// channels to read frame data from, for writing batches onchain. Fully submitted channels stay in
+	// the queue, with their inclusion blocks tracked for L1 reorgs, until their inclusion blocks are final.
 	channelQueue []*channel
-	// used to lookup channels by tx ID upon tx success / failure
-	txChannels map[txID]*channel
+	// used to lookup channels by tx ID upon tx success / failure, in order of the frames the tx carries
+	txChannels map[txID][]*channel
+	// pending transactions by tx ID, with the L1 head they were sent at, for persisting the state
+	sentTxs map[txID]*sentTx
+
+	// last L2 block of the last channel of which all inclusion blocks are final, or of the data that landed
+	// before a restart, and the last inclusion block of that data
+	finalBlock     eth.BlockID
+	finalInclusion eth.BlockID
 
 	// if set to true, prevents production of any new channel frames
 	closed bool
+	// if set to true, the manager is closed for shutdown, and keeps channels without submitted txs
+	draining bool
 }
 
 func NewChannelManager(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rcfg *rollup.Config) *channelManager {
@@ -53,7 +65,8 @@ func NewChannelManager(log log.Logger, metr metrics.Metricer, cfg ChannelConfig,
 		metr:       metr,
 		cfg:        cfg,
 		rcfg:       rcfg,
-		txChannels: make(map[txID]*channel),
+		txChannels: make(map[txID][]*channel),
+		sentTxs:    make(map[txID]*sentTx),
 	}
 }
 
@@ -64,11 +77,16 @@ func (s *channelManager) Clear() {
 	defer s.mu.Unlock()
 	s.log.Trace("clearing channel manager state")
 	s.blocks = s.blocks[:0]
+	s.metr.RecordPendingBlocks(0)
 	s.tip = common.Hash{}
 	s.closed = false
+	s.draining = false
 	s.currentChannel = nil
 	s.channelQueue = nil
-	s.txChannels = make(map[txID]*channel)
+	s.txChannels = make(map[txID][]*channel)
+	s.sentTxs = make(map[txID]*sentTx)
+	s.finalBlock = eth.BlockID{}
+	s.finalInclusion = eth.BlockID{}
 }
 
 // TxFailed records a transaction as failed. It will attempt to resubmit the data
@@ -76,16 +94,20 @@ func (s *channelManager) Clear() {
 func (s *channelManager) TxFailed(id txID) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if channel, ok := s.txChannels[id]; ok {
+	delete(s.sentTxs, id)
+	if channels, ok := s.txChannels[id]; ok {
 		delete(s.txChannels, id)
-		channel.TxFailed(id)
-		if s.closed && channel.NoneSubmitted() {
-			s.log.Info("Channel has no submitted transactions, clearing for shutdown", "chID", channel.ID())
-			s.removePendingChannel(channel)
+		for _, channel := range channels {
+			channel.TxFailed(id)
+			if s.closed && !s.draining && channel.NoneSubmitted() {
+				s.log.Info("Channel has no submitted transactions, clearing for shutdown", "chID", channel.ID())
+				s.removePendingChannel(channel)
+			}
 		}
 	} else {
 		s.log.Warn("transaction from unknown channel marked as failed", "id", id)
 	}
+	s.metr.RecordBatchTxFailed()
 }
 
 // TxConfirmed marks a transaction as confirmed on L1. Unfortunately even if all frames in
@@ -95,13 +117,26 @@ func (s *channelManager) TxFailed(id txID) {
 func (s *channelManager) TxConfirmed(id txID, inclusionBlock eth.BlockID) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if channel, ok := s.txChannels[id]; ok {
+	delete(s.sentTxs, id)
+	if channels, ok := s.txChannels[id]; ok {
 		delete(s.txChannels, id)
-		done, blocks := channel.TxConfirmed(id, inclusionBlock)
-		s.blocks = append(blocks, s.blocks...)
-		if done {
-			s.removePendingChannel(channel)
+		// the blocks of timed out channels are requeued in order
+		var requeued []*types.Block
+		for _, channel := range channels {
+			done, blocks := channel.TxConfirmed(id, inclusionBlock)
+			requeued = append(requeued, blocks...)
+			if !done {
+				continue
+			}
+			if channel.isTimedOut() {
+				s.removePendingChannel(channel)
+			} else if s.currentChannel == channel {
+				// the fully submitted channel stays queued until its inclusion blocks are final
+				s.currentChannel = nil
+			}
 		}
+		s.blocks = append(requeued, s.blocks...)
+		s.metr.RecordPendingBlocks(len(s.blocks))
 	} else {
 		s.log.Warn("transaction from unknown channel marked as confirmed", "id", id)
 	}
@@ -109,6 +144,43 @@ func (s *channelManager) TxConfirmed(id txID, inclusionBlock eth.BlockID) {
 	s.log.Debug("marked transaction as confirmed", "id", id, "block", inclusionBlock)
 }
 
+// lastInclusionBlock returns the last inclusion block of the confirmed transactions of the given channel.
+func lastInclusionBlock(ch *channel) (last eth.BlockID) {
+	for _, b := range ch.InclusionBlocks() {
+		if b.Number >= last.Number {
+			last = b
+		}
+	}
+	return last
+}
+
+// ResumeAfter records the given L2 block as fully submitted, with the given last L1 inclusion block,
+// when resuming from a persisted state after a restart.
+func (s *channelManager) ResumeAfter(block eth.BlockID, inclusionBlock eth.BlockID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finalBlock = block
+	s.finalInclusion = inclusionBlock
+}
+
+// L1Final records the L1 blocks up to the given number as final. The fully submitted channels at the
+// front of the channel queue, of which all inclusion blocks are final, are confirmed and pruned.
+func (s *channelManager) L1Final(number uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.channelQueue) > 0 {
+		ch := s.channelQueue[0]
+		if !ch.isFullySubmitted() || ch.maxInclusionBlock > number {
+			return
+		}
+		s.channelQueue = s.channelQueue[1:]
+		if blocks := ch.Blocks(); len(blocks) > 0 {
+			s.finalBlock, s.finalInclusion = eth.ToBlockID(blocks[len(blocks)-1]), lastInclusionBlock(ch)
+		}
+		s.log.Info("Channel confirmed", "id", ch.ID(), "max_inclusion_block", ch.maxInclusionBlock, "final_l1", number)
+	}
+}
+
 // removePendingChannel removes the given completed channel from the manager's state.
 func (s *channelManager) removePendingChannel(channel *channel) {
 	if s.currentChannel == channel {
@@ -128,17 +200,165 @@ func (s *channelManager) removePendingChannel(channel *channel) {
 	s.channelQueue = append(s.channelQueue[:index], s.channelQueue[index+1:]...)
 }
 
+// InclusionBlocks returns the distinct L1 inclusion blocks of the confirmed transactions
+// of the queued channels, which are to be checked for L1 reorgs.
+func (s *channelManager) InclusionBlocks() []eth.BlockID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen := make(map[eth.BlockID]struct{})
+	var blocks []eth.BlockID
+	for _, ch := range s.channelQueue {
+		for _, b := range ch.InclusionBlocks() {
+			if _, ok := seen[b]; !ok {
+				seen[b] = struct{}{}
+				blocks = append(blocks, b)
+			}
+		}
+	}
+	return blocks
+}
+
+// L1Reorged handles the reorg of the given L1 inclusion blocks out of the canonical L1 chain.
+// The frames of the reorged transactions of a channel are resubmitted in the same channel, as long as
+// the channel can still land within its timeout at the given L1 head. Otherwise, the blocks of this
+// channel, and of all later channels, are put back into the pending blocks queue,
+// to be submitted again in new channels.
+func (s *channelManager) L1Reorged(reorged []eth.BlockID, l1Head eth.BlockID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reorgedSet := make(map[eth.BlockID]struct{}, len(reorged))
+	for _, b := range reorged {
+		reorgedSet[b] = struct{}{}
+	}
+
+	first := -1
+	for i, ch := range s.channelQueue {
+		n := ch.ReorgedTxs(reorgedSet)
+		if n == 0 {
+			continue
+		}
+		s.log.Warn("Channel transactions reorged out of L1", "id", ch.ID(), "txs", n)
+		s.metr.RecordChannelReorged(ch.ID())
+		for j := 0; j < n; j++ {
+			s.metr.RecordBatchTxReorged()
+		}
+		if !ch.CanResubmit(reorgedSet, l1Head.Number) {
+			first = i
+			break
+		}
+		frames := ch.ResubmitReorged(reorgedSet)
+		s.log.Info("Resubmitting reorged frames in the same channel", "id", ch.ID(), "frames", frames, "l1Head", l1Head)
+	}
+	if first < 0 {
+		return
+	}
+
+	// Later channels are requeued as well, to submit the blocks in order.
+	var blocks []*types.Block
+	dropped := make(map[*channel]struct{})
+	for _, ch := range s.channelQueue[first:] {
+		blocks = append(blocks, ch.Blocks()...)
+		dropped[ch] = struct{}{}
+	}
+	for id, chs := range s.txChannels {
+		var kept []*channel
+		for _, ch := range chs {
+			if _, ok := dropped[ch]; !ok {
+				kept = append(kept, ch)
+			}
+		}
+		if len(kept) == 0 {
+			delete(s.txChannels, id)
+			delete(s.sentTxs, id)
+		} else if len(kept) < len(chs) {
+			s.txChannels[id] = kept
+		}
+	}
+	s.channelQueue = s.channelQueue[:first]
+	// the current channel is always the last one, so it is requeued as well
+	s.currentChannel = nil
+	s.blocks = append(blocks, s.blocks...)
+	s.metr.RecordPendingBlocks(len(s.blocks))
+	s.log.Info("Requeued blocks of reorged channels", "channels", len(dropped), "blocks", len(blocks), "blocks_pending", len(s.blocks))
+}
+
 // nextTxData pops off s.datas & handles updating the internal state
-func (s *channelManager) nextTxData(channel *channel) (txData, error) {
-	if channel == nil || !channel.HasFrame() {
+// With multi-frame txs, the frames are taken from all pending channels instead.
+func (s *channelManager) nextTxData(ch *channel, l1Head uint64) (txData, error) {
+	if s.cfg.MultiFrameTxs {
+		return s.nextMultiFrameTxData(l1Head)
+	}
+	if ch == nil || !ch.HasFrame() {
 		s.log.Trace("no next tx data")
 		return txData{}, io.EOF // TODO: not enough data error instead
 	}
-	tx := channel.NextTxData()
-	s.txChannels[tx.ID()] = channel
+	tx := ch.NextTxData(l1Head)
+	s.txChannels[tx.ID()] = []*channel{ch}
 	return tx, nil
 }
 
+// nextMultiFrameTxData returns tx data with the next frames of the pending channels, in order,
+// so that a tx can carry the last frames of a channel and the first frames of the next channel.
+// Frames are added greedily, up to the max frames per tx for blob txs, or up to the max tx size
+// for calldata txs. It returns io.EOF if there's no pending frame.
+// The tx data is urgent if any of its channels is urgent at the given L1 head. If urgent frames are
+// split, the frames of an urgent channel are sent in tx data of a single frame.
+func (s *channelManager) nextMultiFrameTxData(l1Head uint64) (txData, error) {
+	txdata := txData{asBlob: s.cfg.UseBlobs}
+	var (
+		channels []*channel
+		parts    []txData
+	)
+	for _, ch := range s.channelQueue {
+		part := txData{asBlob: s.cfg.UseBlobs}
+		urgent := ch.HasFrame() && ch.Urgent(l1Head)
+		split := urgent && s.cfg.SplitUrgentFrames
+		if split && len(txdata.frames) > 0 {
+			// the frames of an urgent channel are sent on their own
+			break
+		}
+		for ch.HasFrame() && s.fitsFrame(&txdata, ch.NextFrameSize()) {
+			if split && len(part.frames) > 0 {
+				break
+			}
+			frame := ch.NextFrame()
+			txdata.frames = append(txdata.frames, frame)
+			part.frames = append(part.frames, frame)
+		}
+		if len(part.frames) > 0 {
+			txdata.urgent = txdata.urgent || urgent
+			channels = append(channels, ch)
+			parts = append(parts, part)
+		}
+		if split || ch.HasFrame() {
+			// the tx is full, and the frames of later channels must not overtake the remaining frames
+			break
+		}
+	}
+	if len(txdata.frames) == 0 {
+		s.log.Trace("no next tx data")
+		return txData{}, io.EOF
+	}
+	id := txdata.ID()
+	for i, ch := range channels {
+		ch.TxSent(id, parts[i])
+	}
+	s.txChannels[id] = channels
+	s.log.Trace("returning next multi-frame tx data", "id", id, "frames", len(txdata.frames), "channels", len(channels))
+	return txdata, nil
+}
+
+// fitsFrame returns whether a frame of the given size can be added to the given tx data.
+func (s *channelManager) fitsFrame(txdata *txData, frameSize int) bool {
+	if len(txdata.frames) == 0 {
+		return true
+	}
+	if s.cfg.UseBlobs {
+		return len(txdata.frames) < s.cfg.framesPerTx()
+	}
+	return uint64(txdata.Len()+frameSize) <= s.cfg.MaxTxSize
+}
+
 // TxData returns the next tx data that should be submitted to L1.
 //
 // It currently only uses one frame per transaction. If the pending channel is
@@ -147,6 +367,15 @@ func (s *channelManager) nextTxData(channel *channel) (txData, error) {
 func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	data, err := s.nextTxDataAt(l1Head)
+	if err != nil {
+		return txData{}, err
+	}
+	s.sentTxs[data.ID()] = &sentTx{l1Head: l1Head.Number, txdata: data}
+	return data, nil
+}
+
+func (s *channelManager) nextTxDataAt(l1Head eth.BlockID) (txData, error) {
 	var firstWithFrame *channel
 	for _, ch := range s.channelQueue {
 		if ch.HasFrame() {
@@ -159,24 +388,28 @@ func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 	s.log.Debug("Requested tx data", "l1Head", l1Head, "data_pending", dataPending, "blocks_pending", len(s.blocks))
 
 	// Short circuit if there is a pending frame or the channel manager is closed.
-	if dataPending || s.closed {
-		return s.nextTxData(firstWithFrame)
+	// With multi-frame txs, pending blocks are added to channels first, so that the
+	// frames of a new channel can also be sent with the remaining frames.
+	if s.closed || (dataPending && (!s.cfg.MultiFrameTxs || len(s.blocks) == 0)) {
+		return s.nextTxData(firstWithFrame, l1Head.Number)
 	}
 
 	// No pending frame, so we have to add new blocks to the channel
 
-	// If we have no saved blocks, we will not be able to create valid frames
-	if len(s.blocks) == 0 {
-		return txData{}, io.EOF
-	}
-
-	if err := s.ensureChannelWithSpace(l1Head); err != nil {
-		return txData{}, err
-	}
+	if len(s.blocks) > 0 {
+		if err := s.ensureChannelWithSpace(l1Head); err != nil {
+			return txData{}, err
+		}
 
-	if err := s.processBlocks(); err != nil {
-		return txData{}, err
+		if err := s.processBlocks(); err != nil {
+			return txData{}, err
+		}
+	} else if !s.currentChannelHasBlocks() {
+		// If we have no saved blocks, we will not be able to create valid frames
+		return txData{}, io.EOF
 	}
+	// Without new blocks, the L1 head is still registered at the current channel,
+	// so it gets closed once it reached the max channel duration, even if L2 blocks are sparse.
 
 	// Register current L1 head only after all pending blocks have been
 	// processed. Even if a timeout will be triggered now, it is better to have
@@ -187,7 +420,26 @@ func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 		return txData{}, err
 	}
 
-	return s.nextTxData(s.currentChannel)
+	// With multi-frame txs, the remaining blocks are added to new channels, so that a tx
+	// can carry the last frames of the full channel and the first frames of the next channel.
+	for s.cfg.MultiFrameTxs && len(s.blocks) > 0 {
+		pending := len(s.blocks)
+		if err := s.ensureChannelWithSpace(l1Head); err != nil {
+			return txData{}, err
+		}
+		if err := s.processBlocks(); err != nil {
+			return txData{}, err
+		}
+		s.registerL1Block(l1Head)
+		if err := s.outputFrames(); err != nil {
+			return txData{}, err
+		}
+		if len(s.blocks) == pending {
+			break
+		}
+	}
+
+	return s.nextTxData(s.currentChannel, l1Head.Number)
 }
 
 // ensureChannelWithSpace ensures currentChannel is populated with a channel that has
@@ -215,6 +467,11 @@ func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
 	return nil
 }
 
+// currentChannelHasBlocks returns whether the current channel is still open, and has blocks to output frames for.
+func (s *channelManager) currentChannelHasBlocks() bool {
+	return s.currentChannel != nil && !s.currentChannel.IsFull() && len(s.currentChannel.Blocks()) > 0
+}
+
 // registerL1Block registers the given block at the pending channel.
 func (s *channelManager) registerL1Block(l1Head eth.BlockID) {
 	s.currentChannel.RegisterL1Block(l1Head.Number)
@@ -259,6 +516,7 @@ func (s *channelManager) processBlocks() error {
 		// remove processed blocks
 		s.blocks = s.blocks[blocksAdded:]
 	}
+	s.metr.RecordPendingBlocks(len(s.blocks))
 
 	s.metr.RecordL2BlocksAdded(latestL2ref,
 		blocksAdded,
@@ -290,7 +548,7 @@ func (s *channelManager) outputFrames() error {
 		s.currentChannel.TotalFrames(),
 		inBytes,
 		outBytes,
-		s.currentChannel.FullErr(),
+		closedReason(s.currentChannel.FullErr()),
 	)
 
 	var comprRatio float64
@@ -321,11 +579,25 @@ func (s *channelManager) AddL2Block(block *types.Block) error {
 
 	s.metr.RecordL2BlockInPendingQueue(block)
 	s.blocks = append(s.blocks, block)
+	s.metr.RecordPendingBlocks(len(s.blocks))
 	s.tip = block.Hash()
 
 	return nil
 }
 
+// SkipL2Block advances the tip of the state past an L2 block without queueing it for
+// submission, for blocks that the derivation pipeline regenerates by itself. Like
+// AddL2Block, it returns ErrReorg if the block does not extend the last block.
+func (s *channelManager) SkipL2Block(block *types.Block) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tip != (common.Hash{}) && s.tip != block.ParentHash() {
+		return ErrReorg
+	}
+	s.tip = block.Hash()
+	return nil
+}
+
 func l2BlockRefFromBlockAndL1Info(block *types.Block, l1info derive.L1BlockInfo) eth.L2BlockRef {
 	return eth.L2BlockRef{
 		Hash:           block.Hash(),
@@ -364,3 +636,210 @@ func (s *channelManager) Close() error {
 
 	return s.outputFrames()
 }
+
+// Drain prepares the channel manager for a graceful shutdown: all pending blocks are
+// added to channels, the current channel is closed, and all remaining frames are output.
+// Unlike Close, channels without submitted transactions are kept, so that all
+// loaded blocks get submitted. No new channels are created for new L2 blocks afterwards,
+// but Drain may be called again to requeue blocks of channels that timed out meanwhile.
+// Any outputted frames still need to be published.
+func (s *channelManager) Drain(l1Head eth.BlockID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.draining = true
+	return s.flush(l1Head)
+}
+
+// Flush adds all pending blocks to channels, closes the current channel, and outputs all
+// remaining frames, so that all loaded blocks are submitted with the next tx data.
+// Unlike Close and Drain, new channels are still created for new L2 blocks afterwards.
+// Any outputted frames still need to be published.
+func (s *channelManager) Flush(l1Head eth.BlockID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.flush(l1Head)
+}
+
+func (s *channelManager) flush(l1Head eth.BlockID) error {
+	for len(s.blocks) > 0 {
+		if err := s.ensureChannelWithSpace(l1Head); err != nil {
+			return err
+		}
+		pending := len(s.blocks)
+		if err := s.processBlocks(); err != nil {
+			return err
+		}
+		if err := s.outputFrames(); err != nil {
+			return err
+		}
+		if len(s.blocks) == pending {
+			return fmt.Errorf("no progress flushing %d pending blocks", pending)
+		}
+	}
+
+	if s.currentChannel == nil || s.currentChannel.IsFull() {
+		return nil
+	}
+	s.currentChannel.Close()
+	return s.outputFrames()
+}
+
+// UnsubmittedBlocks returns the blocks that are not fully submitted yet, in order:
+// the blocks of the pending channels, followed by the blocks that are not in a channel yet.
+func (s *channelManager) UnsubmittedBlocks() []*types.Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var blocks []*types.Block
+	for _, ch := range s.channelQueue {
+		if ch.isFullySubmitted() {
+			continue
+		}
+		blocks = append(blocks, ch.Blocks()...)
+	}
+	return append(blocks, s.blocks...)
+}
+
+// SubmissionDeadline returns the earliest L1 block number by which the submission of the unsubmitted
+// blocks must resume, to still be included within the consensus channel timeout and the sequencing
+// window, minus the safety margin. It returns 0 if there are no unsubmitted blocks.
+func (s *channelManager) SubmissionDeadline() (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deadline uint64
+	update := func(timeout uint64) {
+		if timeout != 0 && (deadline == 0 || timeout < deadline) {
+			deadline = timeout
+		}
+	}
+	for _, ch := range s.channelQueue {
+		if !ch.isFullySubmitted() {
+			update(ch.SafetyTimeout())
+		}
+	}
+	// the L1 origins of the blocks are monotonic, so the first block closes its sequencing window first
+	if len(s.blocks) > 0 {
+		block := s.blocks[0]
+		if len(block.Transactions()) == 0 {
+			return 0, fmt.Errorf("block %v has no transactions", block.Hash())
+		}
+		l1Info, err := derive.L1InfoDepositTxData(block.Transactions()[0].Data())
+		if err != nil {
+			return 0, fmt.Errorf("parsing L1 info of block %v: %w", block.Hash(), err)
+		}
+		update(l1Info.Number + s.cfg.SeqWindowSize - s.cfg.SubSafetyMargin)
+	}
+	return deadline, nil
+}
+
+// CheckDeadlines checks the channels that aren't fully submitted against their safety timeouts at the
+// given L1 head. It records the number of urgent channels, and reports each channel of which frames risk
+// landing after the channel timeout once.
+func (s *channelManager) CheckDeadlines(l1Head uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	urgent := 0
+	for _, ch := range s.channelQueue {
+		if ch.Urgent(l1Head) {
+			urgent++
+		}
+		if !ch.atRisk && ch.AtRisk(l1Head) {
+			ch.atRisk = true
+			s.metr.RecordChannelAtRisk(ch.ID())
+			s.log.Error("Channel frames risk landing after the channel timeout, which would waste the channel",
+				"id", ch.ID(), "l1_head", l1Head, "safety_timeout", ch.SafetyTimeout(),
+				"pending_txs", len(ch.pendingTransactions), "pending_frames", ch.PendingFrames())
+		}
+	}
+	s.metr.RecordUrgentChannels(urgent)
+}
+
+// OpenChannelStatus returns the stats of the current channel, or nil if there is no current channel.
+func (s *channelManager) OpenChannelStatus() *rpc.ChannelStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := s.currentChannel
+	if ch == nil {
+		return nil
+	}
+	return &rpc.ChannelStatus{
+		ID:            ch.ID(),
+		Blocks:        len(ch.Blocks()),
+		InputBytes:    ch.InputBytes(),
+		OutputBytes:   ch.OutputBytes(),
+		PendingFrames: ch.PendingFrames(),
+		TotalFrames:   ch.TotalFrames(),
+		Full:          ch.IsFull(),
+	}
+}
+
+// TxChannels returns the channels of which the tx with the given ID carries frames, in order,
+// with the range of L2 blocks they cover.
+func (s *channelManager) TxChannels(id txID) []dryRunTxChannel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var channels []dryRunTxChannel
+	for _, ch := range s.txChannels[id] {
+		blocks := ch.Blocks()
+		if len(blocks) == 0 {
+			continue
+		}
+		channels = append(channels, dryRunTxChannel{
+			ID:         ch.ID(),
+			FirstBlock: eth.ToBlockID(blocks[0]),
+			LastBlock:  eth.ToBlockID(blocks[len(blocks)-1]),
+		})
+	}
+	return channels
+}
+
+// PersistedState returns the submission state to persist: the last final block, and the metadata
+// of the queued channels, which are pending or not final yet, with their pending txs.
+func (s *channelManager) PersistedState() (*persistedState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := &persistedState{
+		Version:            persistedStateVersion,
+		SubmittedBlock:     s.finalBlock,
+		SubmittedInclusion: s.finalInclusion,
+	}
+	for _, ch := range s.channelQueue {
+		blocks := ch.Blocks()
+		if len(blocks) == 0 {
+			continue
+		}
+		pc := persistedChannel{
+			ID:              ch.ID(),
+			FirstBlock:      eth.ToBlockID(blocks[0]),
+			LastBlock:       eth.ToBlockID(blocks[len(blocks)-1]),
+			Closed:          ch.IsFull(),
+			FramesEmitted:   ch.TotalFrames(),
+			FramesConfirmed: ch.ConfirmedFrames(),
+			InclusionBlocks: ch.InclusionBlocks(),
+		}
+		sort.Slice(pc.InclusionBlocks, func(i, j int) bool {
+			return pc.InclusionBlocks[i].Number < pc.InclusionBlocks[j].Number
+		})
+		for _, tx := range ch.PendingTxs() {
+			sent, ok := s.sentTxs[tx.id]
+			if !ok {
+				return nil, fmt.Errorf("pending tx %v without sent tx", tx.id)
+			}
+			if sent.dataHashes == nil {
+				hashes, err := txDataHashes(sent.txdata)
+				if err != nil {
+					return nil, fmt.Errorf("hashing data of tx %v: %w", tx.id, err)
+				}
+				sent.dataHashes = hashes
+			}
+			pc.PendingTxs = append(pc.PendingTxs, persistedTx{
+				FirstFrame: tx.frames.ID().frameNumber,
+				Frames:     len(tx.frames.Frames()),
+				SentAt:     sent.l1Head,
+				DataHashes: sent.dataHashes,
+			})
+		}
+		state.Channels = append(state.Channels, pc)
+	}
+	return state, nil
+}
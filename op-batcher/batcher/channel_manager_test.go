@@ -1,6 +1,9 @@
 package batcher
 
 import (
+	"compress/zlib"
+	"errors"
+	"fmt"
 	"io"
 	"math/big"
 	"math/rand"
@@ -33,6 +36,7 @@ func TestChannelManagerBatchType(t *testing.T) {
 		{"ChannelManagerCloseNoPendingChannel", ChannelManagerCloseNoPendingChannel},
 		{"ChannelManagerClosePendingChannel", ChannelManagerClosePendingChannel},
 		{"ChannelManagerCloseAllTxsFailed", ChannelManagerCloseAllTxsFailed},
+		{"ChannelManagerMaxDurationSparseBlocks", ChannelManagerMaxDurationSparseBlocks},
 	}
 	for _, test := range tests {
 		test := test
@@ -91,6 +95,7 @@ func ChannelManagerReturnsErrReorgWhenDrained(t *testing.T, batchType uint) {
 				TargetFrameSize:  1,
 				TargetNumFrames:  1,
 				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
 			},
 			BatchType: batchType,
 		},
@@ -130,6 +135,7 @@ func ChannelManager_Clear(t *testing.T, batchType uint) {
 			TargetFrameSize:  24,
 			TargetNumFrames:  1,
 			ApproxComprRatio: 1.0,
+			Level:            zlib.BestCompression,
 		},
 		BatchType: batchType,
 	},
@@ -166,7 +172,7 @@ func ChannelManager_Clear(t *testing.T, batchType uint) {
 	require.NoError(m.processBlocks())
 	require.NoError(m.currentChannel.channelBuilder.co.Flush())
 	require.NoError(m.currentChannel.OutputFrames())
-	_, err := m.nextTxData(m.currentChannel)
+	_, err := m.nextTxData(m.currentChannel, 0)
 	require.NoError(err)
 	require.Len(m.blocks, 0)
 	require.Equal(newL1Tip, m.tip)
@@ -204,6 +210,7 @@ func ChannelManager_TxResend(t *testing.T, batchType uint) {
 				TargetFrameSize:  1,
 				TargetNumFrames:  1,
 				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
 			},
 			BatchType: batchType,
 		},
@@ -217,7 +224,7 @@ func ChannelManager_TxResend(t *testing.T, batchType uint) {
 
 	txdata0, err := m.TxData(eth.BlockID{})
 	require.NoError(err)
-	txdata0bytes := txdata0.Bytes()
+	txdata0bytes := txdata0.CallData()
 	data0 := make([]byte, len(txdata0bytes))
 	// make sure we have a clone for later comparison
 	copy(data0, txdata0bytes)
@@ -232,7 +239,7 @@ func ChannelManager_TxResend(t *testing.T, batchType uint) {
 	txdata1, err := m.TxData(eth.BlockID{})
 	require.NoError(err)
 
-	data1 := txdata1.Bytes()
+	data1 := txdata1.CallData()
 	require.Equal(data1, data0)
 	fs, err := derive.ParseFrames(data1)
 	require.NoError(err)
@@ -252,6 +259,7 @@ func ChannelManagerCloseBeforeFirstUse(t *testing.T, batchType uint) {
 			CompressorConfig: compressor.Config{
 				TargetFrameSize:  0,
 				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
 			},
 			BatchType: batchType,
 		},
@@ -284,6 +292,7 @@ func ChannelManagerCloseNoPendingChannel(t *testing.T, batchType uint) {
 				TargetFrameSize:  1,
 				TargetNumFrames:  1,
 				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
 			},
 			BatchType: batchType,
 		},
@@ -330,6 +339,7 @@ func ChannelManagerClosePendingChannel(t *testing.T, batchType uint) {
 				TargetNumFrames:  1,
 				TargetFrameSize:  10000,
 				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
 			},
 			BatchType: batchType,
 		},
@@ -385,6 +395,7 @@ func ChannelManagerCloseAllTxsFailed(t *testing.T, batchType uint) {
 				TargetNumFrames:  100,
 				TargetFrameSize:  1000,
 				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
 			},
 			BatchType: batchType,
 		}, &defaultTestRollupConfig,
@@ -413,3 +424,744 @@ func ChannelManagerCloseAllTxsFailed(t *testing.T, batchType uint) {
 	_, err = m.TxData(eth.BlockID{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to produce no more tx data")
 }
+
+// ChannelManagerMaxDurationSparseBlocks ensures that a channel that is not full
+// is closed and submitted once it reached the max channel duration, even if no
+// new L2 blocks are added to it in the meantime.
+func ChannelManagerMaxDurationSparseBlocks(t *testing.T, batchType uint) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			MaxFrameSize:       120_000,
+			ChannelTimeout:     100,
+			MaxChannelDuration: 3,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  120_000,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
+			},
+			BatchType: batchType,
+		},
+		&defaultTestRollupConfig,
+	)
+	m.Clear()
+
+	// without any blocks, no channel is opened
+	_, err := m.TxData(eth.BlockID{Number: 9})
+	require.ErrorIs(err, io.EOF)
+	require.Nil(m.currentChannel)
+
+	require.NoError(m.AddL2Block(newMiniL2Block(0)))
+	_, err = m.TxData(eth.BlockID{Number: 10})
+	require.ErrorIs(err, io.EOF, "channel is not full yet")
+	require.Empty(m.blocks)
+	require.Len(m.currentChannel.Blocks(), 1)
+
+	_, err = m.TxData(eth.BlockID{Number: 12})
+	require.ErrorIs(err, io.EOF, "channel did not reach the max duration yet")
+
+	txdata, err := m.TxData(eth.BlockID{Number: 13})
+	require.NoError(err, "channel is submitted once the max duration is reached")
+	require.ErrorIs(m.currentChannel.FullErr(), ErrMaxDurationReached)
+
+	_, err = m.TxData(eth.BlockID{Number: 14})
+	require.ErrorIs(err, io.EOF, "all frames are submitted")
+	m.TxConfirmed(txdata.ID(), eth.BlockID{Number: 14})
+	require.Nil(m.currentChannel)
+	require.Empty(m.UnsubmittedBlocks())
+
+	_, err = m.TxData(eth.BlockID{Number: 20})
+	require.ErrorIs(err, io.EOF, "no new channel without new blocks")
+	require.Nil(m.currentChannel)
+}
+
+// TestChannelManager_L1ReorgRequeuesLaterChannels ensures that the blocks of a channel
+// that got reorged out of L1, and of all later channels, are requeued in order.
+func TestChannelManager_L1ReorgRequeuesLaterChannels(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			MaxFrameSize:   120_000,
+			ChannelTimeout: 100,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  1,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
+			},
+			BatchType: derive.SingularBatchType,
+		},
+		&defaultTestRollupConfig,
+	)
+	m.Clear()
+
+	a := newMiniL2Block(0)
+	b := newMiniL2BlockWithNumberParent(0, big.NewInt(1), a.Hash())
+	c := newMiniL2BlockWithNumberParent(0, big.NewInt(2), b.Hash())
+	inclusionA := eth.BlockID{Hash: common.Hash{0xa}, Number: 10}
+	inclusionB := eth.BlockID{Hash: common.Hash{0xb}, Number: 11}
+
+	// a and b are submitted in separate channels, c is pending
+	require.NoError(m.AddL2Block(a))
+	txA, err := m.TxData(eth.BlockID{Number: 9})
+	require.NoError(err)
+	m.TxConfirmed(txA.ID(), inclusionA)
+	require.NoError(m.AddL2Block(b))
+	txB, err := m.TxData(eth.BlockID{Number: 10})
+	require.NoError(err)
+	m.TxConfirmed(txB.ID(), inclusionB)
+	require.NoError(m.AddL2Block(c))
+	require.Len(m.channelQueue, 2, "fully submitted channels stay queued until final")
+	require.Equal([]eth.BlockID{inclusionA, inclusionB}, m.InclusionBlocks())
+
+	// a reorg of an unrelated L1 block does not change anything
+	m.L1Reorged([]eth.BlockID{{Hash: common.Hash{0xc}, Number: 11}}, eth.BlockID{Number: 12})
+	require.Len(m.channelQueue, 2)
+
+	// the channel cannot land within its timeout anymore, so it is not resubmitted
+	m.L1Reorged([]eth.BlockID{inclusionA}, eth.BlockID{Number: 200})
+	require.Empty(m.channelQueue)
+	require.Nil(m.currentChannel)
+	require.Equal([]*types.Block{a, b, c}, m.blocks)
+	require.Empty(m.InclusionBlocks())
+}
+
+// TestChannelManager_L1ReorgResubmitsInSameChannel ensures that the frames of a reorged tx are resubmitted
+// in the same channel, as long as the channel can still land within its timeout.
+func TestChannelManager_L1ReorgResubmitsInSameChannel(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			MaxFrameSize:    120_000,
+			ChannelTimeout:  100,
+			SubSafetyMargin: 10,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  1,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
+			},
+			BatchType: derive.SingularBatchType,
+		},
+		&defaultTestRollupConfig,
+	)
+	m.Clear()
+
+	a := newMiniL2Block(0)
+	b := newMiniL2BlockWithNumberParent(0, big.NewInt(1), a.Hash())
+	inclusionA := eth.BlockID{Hash: common.Hash{0xa}, Number: 10}
+	inclusionB := eth.BlockID{Hash: common.Hash{0xb}, Number: 11}
+	require.NoError(m.AddL2Block(a))
+	txA, err := m.TxData(eth.BlockID{Number: 9})
+	require.NoError(err)
+	m.TxConfirmed(txA.ID(), inclusionA)
+	require.NoError(m.AddL2Block(b))
+	txB, err := m.TxData(eth.BlockID{Number: 10})
+	require.NoError(err)
+	m.TxConfirmed(txB.ID(), inclusionB)
+	require.Empty(m.UnsubmittedBlocks())
+
+	m.L1Reorged([]eth.BlockID{inclusionA}, eth.BlockID{Number: 12})
+	require.Len(m.channelQueue, 2, "no channel is dropped")
+	require.Empty(m.blocks, "no block is requeued")
+	require.Equal([]*types.Block{a}, m.UnsubmittedBlocks())
+	require.Equal([]eth.BlockID{inclusionB}, m.InclusionBlocks())
+
+	txdata, err := m.TxData(eth.BlockID{Number: 12})
+	require.NoError(err)
+	require.Equal(txA.ID().chID, txdata.ID().chID, "resubmitted in the same channel")
+	require.Equal(txA.CallData(), txdata.CallData())
+	m.TxConfirmed(txdata.ID(), eth.BlockID{Hash: common.Hash{0xc}, Number: 13})
+	require.Empty(m.UnsubmittedBlocks())
+}
+
+// TestChannelManager_L1Final ensures that fully submitted channels are only confirmed and pruned
+// in order, once all their inclusion blocks are final.
+func TestChannelManager_L1Final(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			MaxFrameSize:   120_000,
+			ChannelTimeout: 100,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  1,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
+			},
+			BatchType: derive.SingularBatchType,
+		},
+		&defaultTestRollupConfig,
+	)
+	m.Clear()
+
+	a := newMiniL2Block(0)
+	b := newMiniL2BlockWithNumberParent(0, big.NewInt(1), a.Hash())
+	inclusionA := eth.BlockID{Hash: common.Hash{0xa}, Number: 10}
+	require.NoError(m.AddL2Block(a))
+	txA, err := m.TxData(eth.BlockID{Number: 9})
+	require.NoError(err)
+	m.TxConfirmed(txA.ID(), inclusionA)
+	require.NoError(m.AddL2Block(b))
+	_, err = m.TxData(eth.BlockID{Number: 10})
+	require.NoError(err)
+
+	m.L1Final(9)
+	require.Len(m.channelQueue, 2, "inclusion block is not final yet")
+
+	m.L1Final(10)
+	require.Len(m.channelQueue, 1, "inclusion block is final")
+	require.Empty(m.InclusionBlocks())
+	state, err := m.PersistedState()
+	require.NoError(err)
+	require.Equal(eth.ToBlockID(a), state.SubmittedBlock)
+	require.Equal(inclusionA, state.SubmittedInclusion)
+
+	m.L1Final(20)
+	require.Len(m.channelQueue, 1, "the pending channel is not pruned")
+}
+
+// TestChannelManager_ChannelLimits ensures that channels are closed exactly when they reach the
+// max blocks per channel, or the max channel input bytes, and new channels are opened for the next blocks.
+func TestChannelManager_ChannelLimits(t *testing.T) {
+	var blocks []*types.Block
+	parent := common.Hash{}
+	for i := 0; i < 7; i++ {
+		block := newMiniL2BlockWithNumberParent(0, big.NewInt(int64(i)), parent)
+		blocks = append(blocks, block)
+		parent = block.Hash()
+	}
+	blockSize := uint64(blockBatchRlpSize(t, blocks[0]))
+
+	tests := []struct {
+		name     string
+		cfg      func(cfg *ChannelConfig)
+		channels []int
+		reason   string
+	}{
+		{
+			name:     "max blocks",
+			cfg:      func(cfg *ChannelConfig) { cfg.MaxBlocksPerChannel = 3 },
+			channels: []int{3, 3, 1},
+			reason:   metrics.ClosedReasonMaxBlocks,
+		},
+		{
+			name:     "max input bytes reached exactly",
+			cfg:      func(cfg *ChannelConfig) { cfg.MaxChannelInputBytes = 2 * blockSize },
+			channels: []int{2, 2, 2, 1},
+			reason:   metrics.ClosedReasonMaxInput,
+		},
+		{
+			name:     "max input bytes exceeded by the last block",
+			cfg:      func(cfg *ChannelConfig) { cfg.MaxChannelInputBytes = 2*blockSize + 1 },
+			channels: []int{3, 3, 1},
+			reason:   metrics.ClosedReasonMaxInput,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := ChannelConfig{
+				SeqWindowSize:  100,
+				ChannelTimeout: 100,
+				MaxFrameSize:   120_000,
+				CompressorConfig: compressor.Config{
+					TargetFrameSize:  100_000,
+					TargetNumFrames:  1,
+					ApproxComprRatio: 1.0,
+					Level:            zlib.BestCompression,
+				},
+				BatchType: derive.SingularBatchType,
+			}
+			test.cfg(&cfg)
+			require.NoError(t, cfg.Check())
+			metr := &closedReasonMetrics{Metricer: metrics.NoopMetrics}
+			m := NewChannelManager(testlog.Logger(t, log.LvlCrit), metr, cfg, &defaultTestRollupConfig)
+			m.Clear()
+			for _, block := range blocks {
+				require.NoError(t, m.AddL2Block(block))
+			}
+
+			for {
+				txdata, err := m.TxData(eth.BlockID{Number: 1})
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				m.TxConfirmed(txdata.ID(), eth.BlockID{Number: 2})
+			}
+			require.Len(t, m.channelQueue, len(test.channels))
+			var added []*types.Block
+			for i, ch := range m.channelQueue {
+				require.Len(t, ch.Blocks(), test.channels[i], "blocks of channel %d", i)
+				added = append(added, ch.Blocks()...)
+			}
+			require.Equal(t, blocks, added, "all blocks are added in order")
+			require.False(t, m.currentChannel.IsFull(), "the last channel is still open")
+			for _, reason := range metr.reasons {
+				require.Equal(t, test.reason, reason)
+			}
+			require.Len(t, metr.reasons, len(test.channels)-1)
+		})
+	}
+}
+
+// TestChannelManager_SkipL2Block tests that skipped blocks are not submitted, and that
+// the blocks around a skipped block go into separate channels.
+func TestChannelManager_SkipL2Block(t *testing.T) {
+	var blocks []*types.Block
+	parent := common.Hash{}
+	for i := 0; i < 7; i++ {
+		block := newMiniL2BlockWithNumberParent(0, big.NewInt(int64(i)), parent)
+		blocks = append(blocks, block)
+		parent = block.Hash()
+	}
+	cfg := ChannelConfig{
+		SeqWindowSize:  100,
+		ChannelTimeout: 100,
+		MaxFrameSize:   120_000,
+		CompressorConfig: compressor.Config{
+			TargetFrameSize:  100_000,
+			TargetNumFrames:  1,
+			ApproxComprRatio: 1.0,
+			Level:            zlib.BestCompression,
+		},
+		BatchType:       derive.SpanBatchType,
+		SkipEmptyBlocks: true,
+	}
+	require.NoError(t, cfg.Check())
+	m := NewChannelManager(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	m.Clear()
+	for i, block := range blocks {
+		if i == 2 || i == 5 {
+			require.NoError(t, m.SkipL2Block(block))
+		} else {
+			require.NoError(t, m.AddL2Block(block))
+		}
+	}
+	require.ErrorIs(t, m.SkipL2Block(newMiniL2BlockWithNumberParent(0, big.NewInt(7), common.Hash{0xff})), ErrReorg)
+
+	for {
+		txdata, err := m.TxData(eth.BlockID{Number: 1})
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		m.TxConfirmed(txdata.ID(), eth.BlockID{Number: 2})
+	}
+	require.Len(t, m.channelQueue, 3)
+	require.Equal(t, blocks[0:2], m.channelQueue[0].Blocks())
+	require.Equal(t, blocks[3:5], m.channelQueue[1].Blocks())
+	require.Equal(t, blocks[6:], m.channelQueue[2].Blocks())
+	require.ErrorIs(t, m.channelQueue[0].FullErr(), ErrBlockGap)
+	require.ErrorIs(t, m.channelQueue[1].FullErr(), ErrBlockGap)
+}
+
+// closedReasonMetrics records the close reasons of channels and the pending blocks queue depth.
+type closedReasonMetrics struct {
+	metrics.Metricer
+	reasons       []string
+	pendingBlocks int
+}
+
+func (m *closedReasonMetrics) RecordChannelClosed(_ derive.ChannelID, _ int, _ int, _ int, _ int, reason string) {
+	m.reasons = append(m.reasons, reason)
+}
+
+func (m *closedReasonMetrics) RecordPendingBlocks(numPendingBlocks int) {
+	m.pendingBlocks = numPendingBlocks
+}
+
+// TestChannelManager_ClosedReasonMetrics ensures that the reason why a channel
+// got closed is recorded with the right metrics label.
+func TestChannelManager_ClosedReasonMetrics(t *testing.T) {
+	// the compressor of the open config never gets full
+	openCfg := ChannelConfig{
+		SeqWindowSize:  100,
+		ChannelTimeout: 100,
+		MaxFrameSize:   120_000,
+		CompressorConfig: compressor.Config{
+			TargetFrameSize:  100_000,
+			TargetNumFrames:  1,
+			ApproxComprRatio: 1.0,
+			Level:            zlib.BestCompression,
+		},
+		BatchType: derive.SingularBatchType,
+	}
+	tests := []struct {
+		name   string
+		cfg    func(cfg *ChannelConfig)
+		close  func(t *testing.T, m *channelManager)
+		reason string
+	}{
+		{
+			name: "full",
+			cfg: func(cfg *ChannelConfig) {
+				cfg.CompressorConfig.TargetFrameSize = 1
+			},
+			reason: metrics.ClosedReasonFull,
+		},
+		{
+			name: "max duration",
+			cfg: func(cfg *ChannelConfig) {
+				cfg.MaxChannelDuration = 2
+			},
+			close: func(t *testing.T, m *channelManager) {
+				_, err := m.TxData(eth.BlockID{Number: 2})
+				require.NoError(t, err)
+			},
+			reason: metrics.ClosedReasonMaxDuration,
+		},
+		{
+			name: "sequencing window timeout",
+			cfg: func(cfg *ChannelConfig) {
+				cfg.SeqWindowSize = 10
+				cfg.SubSafetyMargin = 5
+			},
+			close: func(t *testing.T, m *channelManager) {
+				// the sequencing window of the L1 origin 100 of the block ends at 110
+				_, err := m.TxData(eth.BlockID{Number: 105})
+				require.NoError(t, err)
+			},
+			reason: metrics.ClosedReasonTimeout,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := openCfg
+			test.cfg(&cfg)
+			metr := &closedReasonMetrics{Metricer: metrics.NoopMetrics}
+			m := NewChannelManager(testlog.Logger(t, log.LvlCrit), metr, cfg, &defaultTestRollupConfig)
+			m.Clear()
+
+			require.NoError(t, m.AddL2Block(newMiniL2Block(0)))
+			require.Equal(t, 1, metr.pendingBlocks)
+			_, err := m.TxData(eth.BlockID{Number: 0})
+			if test.close == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, io.EOF, "channel is still open")
+				require.Empty(t, metr.reasons)
+				test.close(t, m)
+			}
+			require.Equal(t, 0, metr.pendingBlocks)
+			require.Equal(t, []string{test.reason}, metr.reasons)
+		})
+	}
+}
+
+// TestChannelManager_Drain ensures that draining the channel manager submits all loaded
+// blocks, including those of open channels without any submitted txs, which Close drops.
+func TestChannelManager_Drain(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			MaxFrameSize:   120_000,
+			ChannelTimeout: 100,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  100_000,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
+			},
+			BatchType: derive.SingularBatchType,
+		},
+		&defaultTestRollupConfig,
+	)
+	m.Clear()
+
+	a := newMiniL2Block(0)
+	b := newMiniL2BlockWithNumberParent(0, big.NewInt(1), a.Hash())
+	c := newMiniL2BlockWithNumberParent(0, big.NewInt(2), b.Hash())
+
+	// a is in the open channel, b and c are still pending
+	require.NoError(m.AddL2Block(a))
+	_, err := m.TxData(eth.BlockID{})
+	require.ErrorIs(err, io.EOF, "channel is still open")
+	require.NoError(m.AddL2Block(b))
+	require.NoError(m.AddL2Block(c))
+	require.Equal([]*types.Block{a, b, c}, m.UnsubmittedBlocks())
+
+	require.NoError(m.Drain(eth.BlockID{}))
+	txdata, err := m.TxData(eth.BlockID{})
+	require.NoError(err, "the drained channel is submitted")
+	_, err = m.TxData(eth.BlockID{})
+	require.ErrorIs(err, io.EOF)
+
+	// the failed tx is resubmitted
+	m.TxFailed(txdata.ID())
+	txdata, err = m.TxData(eth.BlockID{})
+	require.NoError(err)
+	require.Equal([]*types.Block{a, b, c}, m.UnsubmittedBlocks(), "not confirmed yet")
+	m.TxConfirmed(txdata.ID(), eth.BlockID{Number: 1})
+	require.Empty(m.UnsubmittedBlocks())
+
+	// no new channels are opened after draining
+	require.NoError(m.AddL2Block(newMiniL2BlockWithNumberParent(0, big.NewInt(3), c.Hash())))
+	_, err = m.TxData(eth.BlockID{})
+	require.ErrorIs(err, io.EOF)
+}
+
+func TestChannelManager_Flush(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			MaxFrameSize:   120_000,
+			ChannelTimeout: 100,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  100_000,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
+			},
+			BatchType: derive.SingularBatchType,
+		},
+		&defaultTestRollupConfig,
+	)
+	m.Clear()
+	require.Nil(m.OpenChannelStatus())
+
+	a := newMiniL2Block(0)
+	b := newMiniL2BlockWithNumberParent(0, big.NewInt(1), a.Hash())
+	require.NoError(m.AddL2Block(a))
+	_, err := m.TxData(eth.BlockID{})
+	require.ErrorIs(err, io.EOF, "channel is still open")
+	require.NoError(m.AddL2Block(b))
+	status := m.OpenChannelStatus()
+	require.NotNil(status)
+	require.Equal(1, status.Blocks, "b is still pending")
+	require.False(status.Full)
+
+	require.NoError(m.Flush(eth.BlockID{}))
+	status = m.OpenChannelStatus()
+	require.Equal(2, status.Blocks)
+	require.True(status.Full, "the flushed channel is closed")
+	txdata, err := m.TxData(eth.BlockID{})
+	require.NoError(err, "the flushed channel is submitted")
+	m.TxConfirmed(txdata.ID(), eth.BlockID{Number: 1})
+	require.Empty(m.UnsubmittedBlocks())
+
+	// new channels are opened after flushing
+	require.NoError(m.AddL2Block(newMiniL2BlockWithNumberParent(0, big.NewInt(2), b.Hash())))
+	_, err = m.TxData(eth.BlockID{})
+	require.ErrorIs(err, io.EOF)
+	require.False(m.OpenChannelStatus().Full)
+	require.Len(m.UnsubmittedBlocks(), 1)
+}
+
+// txCountMetrics counts the failed and submitted batcher txs.
+type txCountMetrics struct {
+	metrics.Metricer
+	failed, submitted int
+}
+
+func (m *txCountMetrics) RecordBatchTxFailed()    { m.failed++ }
+func (m *txCountMetrics) RecordBatchTxSubmitted() { m.submitted++ }
+
+func newMultiFrameTxsChannelManager(t *testing.T, metr metrics.Metricer, maxTxSize uint64) *channelManager {
+	m := NewChannelManager(testlog.Logger(t, log.LvlCrit), metr,
+		ChannelConfig{
+			MaxFrameSize:   120_000,
+			ChannelTimeout: 100,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  1,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
+			},
+			BatchType:     derive.SingularBatchType,
+			MultiFrameTxs: true,
+			MaxTxSize:     maxTxSize,
+		},
+		&defaultTestRollupConfig,
+	)
+	m.Clear()
+	return m
+}
+
+// TestChannelManager_MultiFrameTxs ensures that a multi-frame tx carries the frames of two channels,
+// which derive into the batches of both blocks, and that all frames are requeued if the tx fails.
+func TestChannelManager_MultiFrameTxs(t *testing.T) {
+	require := require.New(t)
+	metr := &txCountMetrics{Metricer: metrics.NoopMetrics}
+	m := newMultiFrameTxsChannelManager(t, metr, 120_001)
+
+	a := newMiniL2Block(0)
+	b := newMiniL2BlockWithNumberParent(0, big.NewInt(1), a.Hash())
+	require.NoError(m.AddL2Block(a))
+	require.NoError(m.AddL2Block(b))
+
+	txdata, err := m.TxData(eth.BlockID{Number: 1})
+	require.NoError(err)
+	require.Len(m.channelQueue, 2, "a channel per block")
+	require.Len(txdata.Frames(), 2)
+	require.Equal(frameID{chID: m.channelQueue[0].ID(), frameNumber: 0}, txdata.ID(), "identified by the first frame")
+
+	// derive the batches of both blocks from the tx data
+	frames, err := derive.ParseFrames(txdata.CallData())
+	require.NoError(err)
+	require.Len(frames, 2)
+	l1Ref := eth.L1BlockRef{Number: 2}
+	for i, block := range []*types.Block{a, b} {
+		require.Equal(m.channelQueue[i].ID(), frames[i].ID)
+		ch := derive.NewChannel(frames[i].ID, l1Ref)
+		require.NoError(ch.AddFrame(frames[i], l1Ref))
+		require.True(ch.IsReady())
+		nextBatch, err := derive.BatchReader(ch.Reader())
+		require.NoError(err)
+		batchData, err := nextBatch()
+		require.NoError(err)
+		batch, err := derive.GetSingularBatch(batchData)
+		require.NoError(err)
+		require.Equal(block.ParentHash(), batch.ParentHash)
+		require.Equal(block.Time(), batch.Timestamp)
+	}
+
+	// the frames of both channels are requeued if the tx fails
+	m.TxFailed(txdata.ID())
+	require.Equal(1, metr.failed, "the tx fails once")
+	for _, ch := range m.channelQueue {
+		require.Equal(1, ch.PendingFrames())
+		require.True(ch.NoneSubmitted())
+	}
+	require.Empty(m.txChannels)
+
+	txdata, err = m.TxData(eth.BlockID{Number: 2})
+	require.NoError(err, "the requeued frames are sent again in a single tx")
+	require.Len(txdata.Frames(), 2)
+	_, err = m.TxData(eth.BlockID{Number: 2})
+	require.ErrorIs(err, io.EOF)
+
+	m.TxConfirmed(txdata.ID(), eth.BlockID{Number: 3})
+	require.Equal(1, metr.submitted)
+	require.Empty(m.UnsubmittedBlocks(), "both channels are fully submitted")
+	m.L1Final(3)
+	require.Empty(m.channelQueue)
+}
+
+// TestChannelManager_MultiFrameTxsMaxTxSize ensures that the frames of a multi-frame tx don't exceed the max tx size.
+func TestChannelManager_MultiFrameTxsMaxTxSize(t *testing.T) {
+	require := require.New(t)
+	a := newMiniL2Block(0)
+	b := newMiniL2BlockWithNumberParent(0, big.NewInt(1), a.Hash())
+
+	// one byte less than both frames
+	m := newMultiFrameTxsChannelManager(t, metrics.NoopMetrics, 120_001)
+	require.NoError(m.AddL2Block(a))
+	require.NoError(m.AddL2Block(b))
+	txdata, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	maxTxSize := uint64(txdata.Len() - 1)
+
+	m = newMultiFrameTxsChannelManager(t, metrics.NoopMetrics, maxTxSize)
+	require.NoError(m.AddL2Block(a))
+	require.NoError(m.AddL2Block(b))
+	for i := 0; i < 2; i++ {
+		txdata, err := m.TxData(eth.BlockID{})
+		require.NoError(err)
+		require.Len(txdata.Frames(), 1)
+		require.LessOrEqual(uint64(txdata.Len()), maxTxSize)
+		require.Len(m.txChannels[txdata.ID()], 1)
+	}
+	_, err = m.TxData(eth.BlockID{})
+	require.ErrorIs(err, io.EOF)
+}
+
+// urgencyMetrics records the urgent channels, and counts the channels at risk.
+type urgencyMetrics struct {
+	metrics.Metricer
+	urgent, atRisk int
+}
+
+func (m *urgencyMetrics) RecordUrgentChannels(n int)           { m.urgent = n }
+func (m *urgencyMetrics) RecordChannelAtRisk(derive.ChannelID) { m.atRisk++ }
+
+// TestChannelManager_UrgentSubmission ensures that the submission of a channel of which txs are
+// included slowly is escalated within the urgency margin of its safety timeout, and that the channel
+// is reported at risk once it reaches its safety timeout.
+func TestChannelManager_UrgentSubmission(t *testing.T) {
+	for _, split := range []bool{false, true} {
+		t.Run(fmt.Sprintf("split=%t", split), func(t *testing.T) {
+			require := require.New(t)
+			metr := &urgencyMetrics{Metricer: metrics.NoopMetrics}
+			m := NewChannelManager(testlog.Logger(t, log.LvlCrit), metr,
+				ChannelConfig{
+					SeqWindowSize:     1000,
+					ChannelTimeout:    100,
+					SubSafetyMargin:   10,
+					UrgencyMargin:     20,
+					SplitUrgentFrames: split,
+					MaxFrameSize:      30,
+					CompressorConfig: compressor.Config{
+						TargetFrameSize:  1,
+						TargetNumFrames:  1,
+						ApproxComprRatio: 1.0,
+						Level:            zlib.BestCompression,
+					},
+					BatchType:      derive.SingularBatchType,
+					UseBlobs:       true,
+					MaxFramesPerTx: 3,
+				},
+				&defaultTestRollupConfig,
+			)
+			m.Clear()
+			require.NoError(m.AddL2Block(newMiniL2Block(50)))
+
+			tx1, err := m.TxData(eth.BlockID{Number: 9})
+			require.NoError(err)
+			require.Len(tx1.Frames(), 3)
+			require.False(tx1.urgent)
+			m.TxConfirmed(tx1.ID(), eth.BlockID{Number: 10})
+			ch := m.channelQueue[0]
+			require.Equal(uint64(100), ch.SafetyTimeout(), "counts from the first inclusion block")
+			require.GreaterOrEqual(ch.PendingFrames(), 6)
+
+			// the next tx isn't included for a long time
+			tx2, err := m.TxData(eth.BlockID{Number: 79})
+			require.NoError(err)
+			require.Len(tx2.Frames(), 3)
+			require.False(tx2.urgent, "before the urgency margin")
+			m.CheckDeadlines(79)
+			require.Zero(metr.urgent)
+
+			tx3, err := m.TxData(eth.BlockID{Number: 80})
+			require.NoError(err)
+			require.True(tx3.urgent, "within the urgency margin")
+			if split {
+				require.Len(tx3.Frames(), 1, "urgent frames are split")
+			} else {
+				require.Len(tx3.Frames(), 3)
+			}
+			m.CheckDeadlines(80)
+			require.Equal(1, metr.urgent)
+			require.Zero(metr.atRisk)
+
+			m.CheckDeadlines(100)
+			require.Equal(1, metr.atRisk, "at risk at the safety timeout")
+			m.CheckDeadlines(101)
+			require.Equal(1, metr.atRisk, "reported once")
+
+			// the channel is no longer urgent once it is fully submitted
+			m.TxConfirmed(tx2.ID(), eth.BlockID{Number: 101})
+			m.TxConfirmed(tx3.ID(), eth.BlockID{Number: 101})
+			for {
+				txdata, err := m.TxData(eth.BlockID{Number: 101})
+				if err == io.EOF {
+					break
+				}
+				require.NoError(err)
+				m.TxConfirmed(txdata.ID(), eth.BlockID{Number: 101})
+			}
+			m.CheckDeadlines(102)
+			require.Zero(metr.urgent)
+		})
+	}
+}
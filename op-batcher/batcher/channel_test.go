@@ -1,9 +1,11 @@
 package batcher
 
 import (
+	"compress/zlib"
 	"io"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
@@ -20,7 +22,8 @@ func TestChannelTimeout(t *testing.T) {
 	// Create a new channel manager with a ChannelTimeout
 	log := testlog.Logger(t, log.LvlCrit)
 	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{
-		ChannelTimeout: 100,
+		ChannelTimeout:   100,
+		CompressorConfig: compressor.Config{Level: zlib.BestCompression},
 	}, &rollup.Config{})
 	m.Clear()
 
@@ -65,11 +68,11 @@ func TestChannelTimeout(t *testing.T) {
 // TestChannelNextTxData checks the nextTxData function.
 func TestChannelNextTxData(t *testing.T) {
 	log := testlog.Logger(t, log.LvlCrit)
-	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{}, &rollup.Config{})
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{CompressorConfig: compressor.Config{Level: zlib.BestCompression}}, &rollup.Config{})
 	m.Clear()
 
 	// Nil pending channel should return EOF
-	returnedTxData, err := m.nextTxData(nil)
+	returnedTxData, err := m.nextTxData(nil, 0)
 	require.ErrorIs(t, err, io.EOF)
 	require.Equal(t, txData{}, returnedTxData)
 
@@ -79,7 +82,7 @@ func TestChannelNextTxData(t *testing.T) {
 	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
 	channel := m.currentChannel
 	require.NotNil(t, channel)
-	returnedTxData, err = m.nextTxData(channel)
+	returnedTxData, err = m.nextTxData(channel, 0)
 	require.ErrorIs(t, err, io.EOF)
 	require.Equal(t, txData{}, returnedTxData)
 
@@ -96,8 +99,8 @@ func TestChannelNextTxData(t *testing.T) {
 	require.Equal(t, 1, channel.PendingFrames())
 
 	// Now the nextTxData function should return the frame
-	returnedTxData, err = m.nextTxData(channel)
-	expectedTxData := txData{frame}
+	returnedTxData, err = m.nextTxData(channel, 0)
+	expectedTxData := singleFrameTxData(frame)
 	expectedChannelID := expectedTxData.ID()
 	require.NoError(t, err)
 	require.Equal(t, expectedTxData, returnedTxData)
@@ -105,6 +108,47 @@ func TestChannelNextTxData(t *testing.T) {
 	require.Equal(t, expectedTxData, channel.pendingTransactions[expectedChannelID])
 }
 
+// TestChannelNextTxDataMultiFrame checks that blob tx data holds up to the max frames per tx,
+// one per blob, and that all its frames are requeued if the tx fails.
+func TestChannelNextTxDataMultiFrame(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{UseBlobs: true, MaxFramesPerTx: 2, CompressorConfig: compressor.Config{Level: zlib.BestCompression}}, &rollup.Config{})
+	m.Clear()
+	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	channel := m.currentChannel
+	for i := 0; i < 3; i++ {
+		channel.channelBuilder.PushFrame(frameData{
+			data: []byte{byte(i), 0xaa},
+			id:   frameID{chID: channel.ID(), frameNumber: uint16(i)},
+		})
+	}
+
+	txdata, err := m.nextTxData(channel, 0)
+	require.NoError(t, err)
+	require.True(t, txdata.asBlob)
+	require.Len(t, txdata.Frames(), 2)
+	require.Equal(t, frameID{chID: channel.ID(), frameNumber: 0}, txdata.ID(), "identified by the first frame")
+	require.Equal(t, 2*3, txdata.Len())
+	blobs, err := txdata.Blobs()
+	require.NoError(t, err)
+	require.Len(t, blobs, 2)
+	for i, blob := range blobs {
+		data, err := blob.ToData()
+		require.NoError(t, err)
+		require.Equal(t, eth.Data{derive.DerivationVersion0, byte(i), 0xaa}, data, "version byte and frame")
+	}
+	require.Equal(t, 1, channel.PendingFrames())
+
+	last, err := m.nextTxData(channel, 0)
+	require.NoError(t, err)
+	require.Len(t, last.Frames(), 1, "last frame of the channel")
+	require.Equal(t, 0, channel.PendingFrames())
+
+	m.TxFailed(txdata.ID())
+	require.Equal(t, 2, channel.PendingFrames(), "all frames of the failed tx are requeued")
+	require.NotContains(t, channel.pendingTransactions, txdata.ID())
+}
+
 // TestChannelTxConfirmed checks the [ChannelManager.TxConfirmed] function.
 func TestChannelTxConfirmed(t *testing.T) {
 	// Create a channel manager
@@ -113,7 +157,8 @@ func TestChannelTxConfirmed(t *testing.T) {
 		// Need to set the channel timeout here so we don't clear pending
 		// channels on confirmation. This would result in [TxConfirmed]
 		// clearing confirmed transactions, and resetting the pendingChannels map
-		ChannelTimeout: 10,
+		ChannelTimeout:   10,
+		CompressorConfig: compressor.Config{Level: zlib.BestCompression},
 	}, &rollup.Config{})
 	m.Clear()
 
@@ -130,8 +175,8 @@ func TestChannelTxConfirmed(t *testing.T) {
 	}
 	m.currentChannel.channelBuilder.PushFrame(frame)
 	require.Equal(t, 1, m.currentChannel.PendingFrames())
-	returnedTxData, err := m.nextTxData(m.currentChannel)
-	expectedTxData := txData{frame}
+	returnedTxData, err := m.nextTxData(m.currentChannel, 0)
+	expectedTxData := singleFrameTxData(frame)
 	expectedChannelID := expectedTxData.ID()
 	require.NoError(t, err)
 	require.Equal(t, expectedTxData, returnedTxData)
@@ -163,7 +208,7 @@ func TestChannelTxConfirmed(t *testing.T) {
 func TestChannelTxFailed(t *testing.T) {
 	// Create a channel manager
 	log := testlog.Logger(t, log.LvlCrit)
-	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{}, &rollup.Config{})
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{CompressorConfig: compressor.Config{Level: zlib.BestCompression}}, &rollup.Config{})
 	m.Clear()
 
 	// Let's add a valid pending transaction to the channel
@@ -179,8 +224,8 @@ func TestChannelTxFailed(t *testing.T) {
 	}
 	m.currentChannel.channelBuilder.PushFrame(frame)
 	require.Equal(t, 1, m.currentChannel.PendingFrames())
-	returnedTxData, err := m.nextTxData(m.currentChannel)
-	expectedTxData := txData{frame}
+	returnedTxData, err := m.nextTxData(m.currentChannel, 0)
+	expectedTxData := singleFrameTxData(frame)
 	expectedChannelID := expectedTxData.ID()
 	require.NoError(t, err)
 	require.Equal(t, expectedTxData, returnedTxData)
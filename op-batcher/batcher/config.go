@@ -3,12 +3,17 @@ package batcher
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/endpoint"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -17,14 +22,8 @@ import (
 )
 
 type CLIConfig struct {
-	// L1EthRpc is the HTTP provider URL for L1.
-	L1EthRpc string
-
-	// L2EthRpc is the HTTP provider URL for the L2 execution engine.
-	L2EthRpc string
-
-	// RollupRpc is the HTTP provider URL for the L2 rollup node.
-	RollupRpc string
+	// Endpoints are the RPC URLs of L1, of the L2 execution engine, and of the L2 rollup node.
+	Endpoints endpoint.CLIConfig
 
 	// MaxChannelDuration is the maximum duration (in #L1-blocks) to keep a
 	// channel open. This allows to more eagerly send batcher transactions
@@ -36,11 +35,31 @@ type CLIConfig struct {
 	// If 0, duration checks are disabled.
 	MaxChannelDuration uint64
 
+	// MaxBlocksPerChannel is the maximum number of L2 blocks per channel.
+	// If 0, the number of blocks is not limited.
+	MaxBlocksPerChannel uint64
+
+	// MaxChannelInputBytes is the maximum amount of uncompressed input data per channel.
+	// If 0, only the protocol's max RLP bytes per channel apply.
+	MaxChannelInputBytes uint64
+
 	// The batcher tx submission safety margin (in #L1-blocks) to subtract from
 	// a channel's timeout and sequencing window, to guarantee safe inclusion of
 	// a channel on L1.
 	SubSafetyMargin uint64
 
+	// UrgencyMargin is the number of L1 blocks before a channel's safety timeout, from which on the
+	// submission of its remaining frames is escalated. If 0, the submission is not escalated.
+	UrgencyMargin uint64
+
+	// SplitUrgentFrames sends the remaining frames of urgent channels in one tx per frame, so that they are
+	// submitted in parallel, up to MaxPendingTransactions.
+	SplitUrgentFrames bool
+
+	// UrgentResubmissionTimeout is the resubmission timeout of txs that carry frames of urgent channels.
+	// If 0, the resubmission timeout of the tx manager applies.
+	UrgentResubmissionTimeout time.Duration
+
 	// PollInterval is the delay between querying L2 for more transaction
 	// and creating a new batch.
 	PollInterval time.Duration
@@ -49,49 +68,151 @@ type CLIConfig struct {
 	// transactions sent to the transaction manager (0 == no limit).
 	MaxPendingTransactions uint64
 
+	// DrainTimeout is how long to keep submitting the remaining batch data
+	// when stopping, until all batcher transactions are confirmed.
+	// If 0, the batcher drains until the stop is forced.
+	DrainTimeout time.Duration
+
+	// MaxL1BaseFee is the L1 base fee (in GWei) above which batch submission pauses.
+	// If 0, the base fee is not limited.
+	MaxL1BaseFee float64
+
+	// MaxL1BlobBaseFee is the L1 blob base fee (in GWei) above which batch submission pauses,
+	// when submitting batches in blobs. If 0, the blob base fee is not limited.
+	MaxL1BlobBaseFee float64
+
+	// FeeCeilingResumePercent is the percentage of the fee ceilings that the L1 fees must fall below,
+	// for a paused batch submission to resume.
+	FeeCeilingResumePercent uint64
+
+	// FeeCeilingMaxDelay is the maximum duration that batch submission is paused for by the fee ceilings.
+	FeeCeilingMaxDelay time.Duration
+
+	// UnsafeHeadStallTimeout is the duration for which the unsafe head must not advance, for the
+	// sequencer to be reported as stalled. If 0, stall detection is disabled.
+	UnsafeHeadStallTimeout time.Duration
+
+	// MaxSafeHeadLag is the number of L2 blocks that the safe head may lag behind the unsafe head,
+	// before batcher submissions are reported as not landing. If 0, the lag is not reported.
+	MaxSafeHeadLag uint64
+
 	// MaxL1TxSize is the maximum size of a batch tx submitted to L1.
 	MaxL1TxSize uint64
 
+	// StateFile is the file to persist the submission state to, to resume after the data
+	// that already landed on L1 when restarting. If empty, the state is not persisted.
+	StateFile string
+
+	// ConfirmationMode is the way the L1 inclusion blocks of batcher txs are considered final:
+	// at the ConfirmationDepth behind the L1 head, or once included in the safe or finalized L1 block.
+	ConfirmationMode flags.ConfirmationMode
+
+	// ConfirmationDepth is the number of L1 blocks behind the L1 head at which batcher txs are considered final,
+	// with the depth confirmation mode.
+	ConfirmationDepth uint64
+
+	// DryRunDir is the dir to write the batcher txs to, with a JSON sidecar per tx, instead of sending them
+	// to L1. The batcher state advances as if the txs were confirmed. If empty, txs are sent to L1.
+	DryRunDir string
+
 	Stopped bool
 
 	BatchType uint
 
+	// DataAvailabilityType is the way the batch data is made available on L1: as calldata or in blobs.
+	DataAvailabilityType flags.DataAvailabilityType
+
+	// MaxBlobsPerTx is the maximum number of blobs per blob transaction, when submitting batches in blobs.
+	MaxBlobsPerTx int
+
+	// MultiFrameTxs enables transactions that carry the frames of multiple channels. Calldata transactions
+	// then carry as many frames as fit into MaxL1TxSize, instead of a single frame.
+	MultiFrameTxs bool
+
+	// SkipEmptyBlocks leaves out the empty blocks that the derivation pipeline regenerates by itself,
+	// at the cost of the safe head stalling at them until the sequencing window expires. Requires span batches.
+	SkipEmptyBlocks bool
+
 	TxMgrConfig      txmgr.CLIConfig
 	LogConfig        oplog.CLIConfig
 	MetricsConfig    opmetrics.CLIConfig
+	BalanceConfig    opmetrics.BalanceCLIConfig
 	PprofConfig      oppprof.CLIConfig
 	CompressorConfig compressor.CLIConfig
 	RPC              oprpc.CLIConfig
 }
 
 func (c *CLIConfig) Check() error {
-	if c.L1EthRpc == "" {
+	if c.Endpoints.L1RPC == "" && c.DryRunDir == "" {
 		return errors.New("empty L1 RPC URL")
 	}
-	if c.L2EthRpc == "" {
+	if c.Endpoints.L2RPC == "" {
 		return errors.New("empty L2 RPC URL")
 	}
-	if c.RollupRpc == "" {
+	if c.Endpoints.RollupRPC == "" {
 		return errors.New("empty rollup RPC URL")
 	}
+	if err := c.Endpoints.Check(); err != nil {
+		return err
+	}
 	if c.PollInterval == 0 {
 		return errors.New("must set PollInterval")
 	}
+	if c.DrainTimeout < 0 {
+		return errors.New("drain timeout cannot be negative")
+	}
+	if c.MaxL1BaseFee < 0 || c.MaxL1BlobBaseFee < 0 {
+		return errors.New("max L1 fees cannot be negative")
+	}
+	if c.MaxL1BaseFee > 0 || c.MaxL1BlobBaseFee > 0 {
+		if c.FeeCeilingResumePercent == 0 || c.FeeCeilingResumePercent > 100 {
+			return fmt.Errorf("fee ceiling resume percent must be between 1 and 100, got %d", c.FeeCeilingResumePercent)
+		}
+		if c.FeeCeilingMaxDelay <= 0 {
+			return errors.New("fee ceiling max delay must be positive")
+		}
+	}
+	if c.DryRunDir != "" && c.StateFile != "" {
+		return errors.New("dry-run cannot persist the submission state to a state file")
+	}
+	if c.UnsafeHeadStallTimeout < 0 {
+		return errors.New("unsafe head stall timeout cannot be negative")
+	}
 	if c.MaxL1TxSize <= 1 {
 		return errors.New("MaxL1TxSize must be greater than 0")
 	}
 	if c.BatchType > 1 {
 		return fmt.Errorf("unknown batch type: %v", c.BatchType)
 	}
+	if c.SkipEmptyBlocks && c.BatchType != derive.SpanBatchType {
+		return errors.New("skipping empty blocks requires span batches")
+	}
+	if !flags.ValidDataAvailabilityType(c.DataAvailabilityType) {
+		return fmt.Errorf("unknown data availability type: %q", c.DataAvailabilityType)
+	}
+	if !flags.ValidConfirmationMode(c.ConfirmationMode) {
+		return fmt.Errorf("unknown confirmation mode: %q", c.ConfirmationMode)
+	}
+	if c.DataAvailabilityType == flags.BlobsType && (c.MaxBlobsPerTx < 1 || c.MaxBlobsPerTx > eth.MaxBlobsPerBlobTx) {
+		return fmt.Errorf("max blobs per tx must be between 1 and %d, got %d", eth.MaxBlobsPerBlobTx, c.MaxBlobsPerTx)
+	}
 
 	if err := c.MetricsConfig.Check(); err != nil {
 		return err
 	}
+	if c.MetricsConfig.Enabled {
+		if err := c.BalanceConfig.Check(); err != nil {
+			return err
+		}
+	}
 	if err := c.PprofConfig.Check(); err != nil {
 		return err
 	}
-	if err := c.TxMgrConfig.Check(); err != nil {
-		return err
+	// a dry-run doesn't send txs
+	if c.DryRunDir == "" {
+		if err := c.TxMgrConfig.Check(); err != nil {
+			return err
+		}
 	}
 	if err := c.RPC.Check(); err != nil {
 		return err
@@ -103,23 +224,63 @@ func (c *CLIConfig) Check() error {
 func NewConfig(ctx *cli.Context) *CLIConfig {
 	return &CLIConfig{
 		/* Required Flags */
-		L1EthRpc:        ctx.String(flags.L1EthRpcFlag.Name),
-		L2EthRpc:        ctx.String(flags.L2EthRpcFlag.Name),
-		RollupRpc:       ctx.String(flags.RollupRpcFlag.Name),
+		Endpoints:       endpoint.ReadCLIConfig(ctx),
 		SubSafetyMargin: ctx.Uint64(flags.SubSafetyMarginFlag.Name),
 		PollInterval:    ctx.Duration(flags.PollIntervalFlag.Name),
 
 		/* Optional Flags */
-		MaxPendingTransactions: ctx.Uint64(flags.MaxPendingTransactionsFlag.Name),
-		MaxChannelDuration:     ctx.Uint64(flags.MaxChannelDurationFlag.Name),
-		MaxL1TxSize:            ctx.Uint64(flags.MaxL1TxSizeBytesFlag.Name),
-		Stopped:                ctx.Bool(flags.StoppedFlag.Name),
-		BatchType:              ctx.Uint(flags.BatchTypeFlag.Name),
-		TxMgrConfig:            txmgr.ReadCLIConfig(ctx),
-		LogConfig:              oplog.ReadCLIConfig(ctx),
-		MetricsConfig:          opmetrics.ReadCLIConfig(ctx),
-		PprofConfig:            oppprof.ReadCLIConfig(ctx),
-		CompressorConfig:       compressor.ReadCLIConfig(ctx),
-		RPC:                    oprpc.ReadCLIConfig(ctx),
+		MaxPendingTransactions:    ctx.Uint64(flags.MaxPendingTransactionsFlag.Name),
+		MaxChannelDuration:        ctx.Uint64(flags.MaxChannelDurationFlag.Name),
+		MaxBlocksPerChannel:       ctx.Uint64(flags.MaxBlocksPerChannelFlag.Name),
+		MaxChannelInputBytes:      ctx.Uint64(flags.MaxChannelInputBytesFlag.Name),
+		UrgencyMargin:             ctx.Uint64(flags.UrgencyMarginFlag.Name),
+		SplitUrgentFrames:         ctx.Bool(flags.SplitUrgentFramesFlag.Name),
+		UrgentResubmissionTimeout: ctx.Duration(flags.UrgentResubmissionTimeoutFlag.Name),
+		DrainTimeout:              ctx.Duration(flags.DrainTimeoutFlag.Name),
+		MaxL1BaseFee:              ctx.Float64(flags.MaxL1BaseFeeFlag.Name),
+		MaxL1BlobBaseFee:          ctx.Float64(flags.MaxL1BlobBaseFeeFlag.Name),
+		FeeCeilingResumePercent:   ctx.Uint64(flags.FeeCeilingResumePercentFlag.Name),
+		FeeCeilingMaxDelay:        ctx.Duration(flags.FeeCeilingMaxDelayFlag.Name),
+		UnsafeHeadStallTimeout:    ctx.Duration(flags.UnsafeHeadStallTimeoutFlag.Name),
+		MaxSafeHeadLag:            ctx.Uint64(flags.MaxSafeHeadLagFlag.Name),
+		MaxL1TxSize:               ctx.Uint64(flags.MaxL1TxSizeBytesFlag.Name),
+		StateFile:                 ctx.String(flags.StateFileFlag.Name),
+		ConfirmationMode:          flags.ConfirmationMode(ctx.String(flags.ConfirmationModeFlag.Name)),
+		ConfirmationDepth:         ctx.Uint64(flags.ConfirmationDepthFlag.Name),
+		DryRunDir:                 ctx.String(flags.DryRunDirFlag.Name),
+		Stopped:                   ctx.Bool(flags.StoppedFlag.Name),
+		BatchType:                 ctx.Uint(flags.BatchTypeFlag.Name),
+		DataAvailabilityType:      flags.DataAvailabilityType(ctx.String(flags.DataAvailabilityTypeFlag.Name)),
+		MaxBlobsPerTx:             ctx.Int(flags.MaxBlobsPerTxFlag.Name),
+		MultiFrameTxs:             ctx.Bool(flags.MultiFrameTxsFlag.Name),
+		SkipEmptyBlocks:           ctx.Bool(flags.SkipEmptyBlocksFlag.Name),
+		TxMgrConfig:               txmgr.ReadCLIConfig(ctx),
+		LogConfig:                 oplog.ReadCLIConfig(ctx),
+		MetricsConfig:             opmetrics.ReadCLIConfig(ctx),
+		BalanceConfig:             opmetrics.ReadBalanceCLIConfig(ctx),
+		PprofConfig:               oppprof.ReadCLIConfig(ctx),
+		CompressorConfig:          compressor.ReadCLIConfig(ctx),
+		RPC:                       oprpc.ReadCLIConfig(ctx),
+	}
+}
+
+// FeeCeilingConfig returns the L1 fee ceiling configuration, with the fees converted to wei.
+// The blob base fee is only limited when submitting batches in blobs.
+func (c *CLIConfig) FeeCeilingConfig() FeeCeilingConfig {
+	cfg := FeeCeilingConfig{
+		ResumePercent: c.FeeCeilingResumePercent,
+		MaxDelay:      c.FeeCeilingMaxDelay,
+	}
+	if c.MaxL1BaseFee > 0 {
+		cfg.MaxBaseFee = gweiToWei(c.MaxL1BaseFee)
+	}
+	if c.MaxL1BlobBaseFee > 0 && c.DataAvailabilityType == flags.BlobsType {
+		cfg.MaxBlobBaseFee = gweiToWei(c.MaxL1BlobBaseFee)
 	}
+	return cfg
+}
+
+func gweiToWei(gwei float64) *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(params.GWei)).Int(nil)
+	return wei
 }
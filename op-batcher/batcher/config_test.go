@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/batcher"
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-service/endpoint"
 	"github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -15,9 +17,11 @@ import (
 
 func validBatcherConfig() batcher.CLIConfig {
 	return batcher.CLIConfig{
-		L1EthRpc:               "fake",
-		L2EthRpc:               "fake",
-		RollupRpc:              "fake",
+		Endpoints: endpoint.CLIConfig{
+			L1RPC:     "http://l1:8545",
+			L2RPC:     "http://l2:8545",
+			RollupRPC: "http://op-node:8545",
+		},
 		MaxChannelDuration:     0,
 		SubSafetyMargin:        0,
 		PollInterval:           time.Second,
@@ -25,9 +29,12 @@ func validBatcherConfig() batcher.CLIConfig {
 		MaxL1TxSize:            10,
 		Stopped:                false,
 		BatchType:              0,
+		DataAvailabilityType:   flags.CalldataType,
+		ConfirmationMode:       flags.DepthConfirmation,
 		TxMgrConfig:            txmgr.NewCLIConfig("fake", txmgr.DefaultBatcherFlagValues),
 		LogConfig:              log.DefaultCLIConfig(),
 		MetricsConfig:          metrics.DefaultCLIConfig(),
+		BalanceConfig:          metrics.DefaultBalanceCLIConfig(),
 		PprofConfig:            pprof.DefaultCLIConfig(),
 		// The compressor config is not checked in config.Check()
 		RPC: rpc.DefaultCLIConfig(),
@@ -39,6 +46,14 @@ func TestValidBatcherConfig(t *testing.T) {
 	require.NoError(t, cfg.Check(), "valid config should pass the check function")
 }
 
+func TestDryRunBatcherConfig(t *testing.T) {
+	cfg := validBatcherConfig()
+	cfg.DryRunDir = "dry-run"
+	cfg.Endpoints.L1RPC = ""
+	cfg.TxMgrConfig = txmgr.CLIConfig{}
+	require.NoError(t, cfg.Check(), "a dry-run needs neither a L1 nor a tx manager")
+}
+
 func TestBatcherConfig(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -47,24 +62,69 @@ func TestBatcherConfig(t *testing.T) {
 	}{
 		{
 			name:      "empty L1",
-			override:  func(c *batcher.CLIConfig) { c.L1EthRpc = "" },
+			override:  func(c *batcher.CLIConfig) { c.Endpoints.L1RPC = "" },
 			errString: "empty L1 RPC URL",
 		},
 		{
 			name:      "empty L2",
-			override:  func(c *batcher.CLIConfig) { c.L2EthRpc = "" },
+			override:  func(c *batcher.CLIConfig) { c.Endpoints.L2RPC = "" },
 			errString: "empty L2 RPC URL",
 		},
 		{
 			name:      "empty rollup",
-			override:  func(c *batcher.CLIConfig) { c.RollupRpc = "" },
+			override:  func(c *batcher.CLIConfig) { c.Endpoints.RollupRPC = "" },
 			errString: "empty rollup RPC URL",
 		},
+		{
+			name:      "invalid rollup URL",
+			override:  func(c *batcher.CLIConfig) { c.Endpoints.RollupRPC = "op-node:8545" },
+			errString: "invalid rollup RPC",
+		},
 		{
 			name:      "empty poll interval",
 			override:  func(c *batcher.CLIConfig) { c.PollInterval = 0 },
 			errString: "must set PollInterval",
 		},
+		{
+			name:      "negative drain timeout",
+			override:  func(c *batcher.CLIConfig) { c.DrainTimeout = -time.Second },
+			errString: "drain timeout cannot be negative",
+		},
+		{
+			name:      "negative max L1 base fee",
+			override:  func(c *batcher.CLIConfig) { c.MaxL1BaseFee = -1 },
+			errString: "max L1 fees cannot be negative",
+		},
+		{
+			name: "fee ceiling resume percent too large",
+			override: func(c *batcher.CLIConfig) {
+				c.MaxL1BaseFee = 100
+				c.FeeCeilingResumePercent = 101
+				c.FeeCeilingMaxDelay = time.Hour
+			},
+			errString: "fee ceiling resume percent must be between 1 and 100, got 101",
+		},
+		{
+			name: "fee ceiling without max delay",
+			override: func(c *batcher.CLIConfig) {
+				c.MaxL1BlobBaseFee = 10
+				c.FeeCeilingResumePercent = 90
+			},
+			errString: "fee ceiling max delay must be positive",
+		},
+		{
+			name:      "negative unsafe head stall timeout",
+			override:  func(c *batcher.CLIConfig) { c.UnsafeHeadStallTimeout = -time.Second },
+			errString: "unsafe head stall timeout cannot be negative",
+		},
+		{
+			name: "dry-run with state file",
+			override: func(c *batcher.CLIConfig) {
+				c.DryRunDir = "dry-run"
+				c.StateFile = "batcher_state.json"
+			},
+			errString: "dry-run cannot persist the submission state to a state file",
+		},
 		{
 			name:      "max L1 tx size too small",
 			override:  func(c *batcher.CLIConfig) { c.MaxL1TxSize = 0 },
@@ -80,6 +140,37 @@ func TestBatcherConfig(t *testing.T) {
 			override:  func(c *batcher.CLIConfig) { c.BatchType = 100 },
 			errString: "unknown batch type: 100",
 		},
+		{
+			name:      "invalid data availability type",
+			override:  func(c *batcher.CLIConfig) { c.DataAvailabilityType = "foo" },
+			errString: "unknown data availability type",
+		},
+		{
+			name:      "invalid confirmation mode",
+			override:  func(c *batcher.CLIConfig) { c.ConfirmationMode = "latest" },
+			errString: "unknown confirmation mode",
+		},
+		{
+			name: "no blobs per tx",
+			override: func(c *batcher.CLIConfig) {
+				c.DataAvailabilityType = flags.BlobsType
+				c.MaxBlobsPerTx = 0
+			},
+			errString: "max blobs per tx must be between 1 and 6",
+		},
+		{
+			name: "too many blobs per tx",
+			override: func(c *batcher.CLIConfig) {
+				c.DataAvailabilityType = flags.BlobsType
+				c.MaxBlobsPerTx = 7
+			},
+			errString: "max blobs per tx must be between 1 and 6",
+		},
+		{
+			name:      "skip empty blocks without span batches",
+			override:  func(c *batcher.CLIConfig) { c.SkipEmptyBlocks = true },
+			errString: "skipping empty blocks requires span batches",
+		},
 	}
 
 	for _, test := range tests {
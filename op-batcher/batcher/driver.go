@@ -8,24 +8,36 @@ import (
 	"math/big"
 	_ "net/http/pprof"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
-var ErrBatcherNotRunning = errors.New("batcher is not running")
+var (
+	ErrBatcherNotRunning = errors.New("batcher is not running")
+	ErrBatcherPaused     = errors.New("batcher is paused")
+)
 
 type L1Client interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
 }
 
 type L2Client interface {
@@ -46,6 +58,8 @@ type DriverSetup struct {
 	L1Client         L1Client
 	EndpointProvider dial.L2EndpointProvider
 	ChannelConfig    ChannelConfig
+	// Clock times the polling of the batcher and the txs in flight. The system clock is used if nil.
+	Clock clock.Clock
 }
 
 // BatchSubmitter encapsulates a service responsible for submitting L2 tx
@@ -63,19 +77,60 @@ type BatchSubmitter struct {
 	mutex   sync.Mutex
 	running bool
 
+	// paused is set by the admin to pause the submission of new batcher txs, while blocks keep being loaded
+	paused atomic.Bool
+	// flushRequests are the flush requests to the loop, which are answered on the request channel
+	flushRequests chan chan error
+
+	lastTxMu sync.Mutex
+	// lastSubmittedTx is the hash of the last confirmed batcher tx
+	lastSubmittedTx common.Hash
+
+	inFlightMu sync.Mutex
+	// inFlight are the batcher txs that are queued for sending or pending, by the time they were queued
+	inFlight map[txID]time.Time
+
 	// lastStoredBlock is the last block loaded into `state`. If it is empty it should be set to the l2 safe head.
 	lastStoredBlock eth.BlockID
 	lastL1Tip       eth.L1BlockRef
+	// lastReorgCheck is the L1 tip at which the inclusion blocks of the batcher transactions were last checked for reorgs
+	lastReorgCheck eth.L1BlockRef
+	// lastL1Final is the number of the last L1 block that is considered final, per the confirmation mode
+	lastL1Final uint64
+	// resumedInclusions are the L1 inclusion blocks of the data that landed before a restart, beyond the safe head
+	resumedInclusions []eth.BlockID
 
 	state *channelManager
+	// feeCeiling pauses batch submission while the L1 fees are high
+	feeCeiling *feeCeiling
+	// headMonitor signals a stalled sequencer, and a safe head lagging behind the unsafe head
+	headMonitor *headMonitor
+	// persistence persists the submission state to the state file, nil if disabled
+	persistence *statePersistence
+	// dryRun writes the batcher txs to the dry-run dir instead of sending them, nil if disabled
+	dryRun *dryRunWriter
 }
 
 // NewBatchSubmitter initializes the BatchSubmitter driver from a preconfigured DriverSetup
 func NewBatchSubmitter(setup DriverSetup) *BatchSubmitter {
-	return &BatchSubmitter{
-		DriverSetup: setup,
-		state:       NewChannelManager(setup.Log, setup.Metr, setup.ChannelConfig, setup.RollupConfig),
+	if setup.Clock == nil {
+		setup.Clock = clock.SystemClock
+	}
+	l := &BatchSubmitter{
+		DriverSetup:   setup,
+		state:         NewChannelManager(setup.Log, setup.Metr, setup.ChannelConfig, setup.RollupConfig),
+		feeCeiling:    newFeeCeiling(setup.Config.FeeCeiling, setup.Log, setup.Metr),
+		headMonitor:   newHeadMonitor(setup.Config.HeadMonitor, setup.Log, setup.Metr),
+		flushRequests: make(chan chan error),
+		inFlight:      make(map[txID]time.Time),
+	}
+	if setup.Config.StateFile != "" {
+		l.persistence = newStatePersistence(setup.Config.StateFile)
 	}
+	if setup.Config.DryRunDir != "" {
+		l.dryRun = newDryRunWriter(setup.Config.DryRunDir, setup.L1Client)
+	}
+	return l
 }
 
 func (l *BatchSubmitter) StartBatchSubmitting() error {
@@ -92,7 +147,11 @@ func (l *BatchSubmitter) StartBatchSubmitting() error {
 	l.shutdownCtx, l.cancelShutdownCtx = context.WithCancel(context.Background())
 	l.killCtx, l.cancelKillCtx = context.WithCancel(context.Background())
 	l.state.Clear()
+	l.feeCeiling.Reset()
+	l.headMonitor.Reset()
 	l.lastStoredBlock = eth.BlockID{}
+	l.lastL1Final = 0
+	l.resumedInclusions = nil
 
 	l.wg.Add(1)
 	go l.loop()
@@ -121,9 +180,16 @@ func (l *BatchSubmitter) StopBatchSubmitting(ctx context.Context) error {
 	}
 	l.running = false
 
-	// go routine will call cancelKill() if the passed in ctx is ever Done
+	// go routine will call cancelKill() if the passed in ctx is ever Done,
+	// or the drain timeout passed
 	cancelKill := l.cancelKillCtx
-	wrapped, cancel := context.WithCancel(ctx)
+	var wrapped context.Context
+	var cancel context.CancelFunc
+	if l.Config.DrainTimeout > 0 {
+		wrapped, cancel = context.WithTimeout(ctx, l.Config.DrainTimeout)
+	} else {
+		wrapped, cancel = context.WithCancel(ctx)
+	}
 	defer cancel()
 	go func() {
 		<-wrapped.Done()
@@ -138,6 +204,75 @@ func (l *BatchSubmitter) StopBatchSubmitting(ctx context.Context) error {
 	return nil
 }
 
+// PauseBatchSubmitting pauses the submission of new batcher txs. Blocks keep being loaded,
+// and the receipts of in-flight txs are still handled. The pause persists until
+// ResumeBatchSubmitting is called, also across restarts of the batch-submitter loop.
+func (l *BatchSubmitter) PauseBatchSubmitting() {
+	if !l.paused.Swap(true) {
+		l.Log.Warn("Paused batch submission")
+	}
+}
+
+// ResumeBatchSubmitting resumes the submission of batcher txs after PauseBatchSubmitting.
+func (l *BatchSubmitter) ResumeBatchSubmitting() {
+	if l.paused.Swap(false) {
+		l.Log.Info("Resumed batch submission")
+	}
+}
+
+// FlushBatchSubmitting closes the open channel and submits all loaded blocks right away,
+// regardless of the L1 fee ceiling. It returns once the batcher txs are sent to the tx manager,
+// without waiting for their confirmation.
+func (l *BatchSubmitter) FlushBatchSubmitting(ctx context.Context) error {
+	l.mutex.Lock()
+	running, shutdownCtx := l.running, l.shutdownCtx
+	l.mutex.Unlock()
+	if !running {
+		return ErrBatcherNotRunning
+	}
+	if l.paused.Load() {
+		return ErrBatcherPaused
+	}
+
+	done := make(chan error, 1)
+	select {
+	case l.flushRequests <- done:
+	case <-shutdownCtx.Done():
+		return ErrBatcherNotRunning
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// BatcherStatus returns the runtime status of the batcher.
+func (l *BatchSubmitter) BatcherStatus() *rpc.BatcherStatus {
+	l.mutex.Lock()
+	running := l.running
+	l.mutex.Unlock()
+	l.lastTxMu.Lock()
+	lastTx := l.lastSubmittedTx
+	l.lastTxMu.Unlock()
+
+	status := &rpc.BatcherStatus{
+		Running:         running,
+		Paused:          l.paused.Load(),
+		OpenChannel:     l.state.OpenChannelStatus(),
+		LastSubmittedTx: lastTx,
+	}
+	if blocks := l.state.UnsubmittedBlocks(); len(blocks) > 0 {
+		status.FirstPendingBlock = eth.ToBlockID(blocks[0])
+		status.LastPendingBlock = eth.ToBlockID(blocks[len(blocks)-1])
+		status.NumPendingBlocks = len(blocks)
+	}
+	return status
+}
+
 // loadBlocksIntoState loads all blocks since the previous stored block
 // It does the following:
 // 1. Fetch the sync status of the sequencer
@@ -194,6 +329,20 @@ func (l *BatchSubmitter) loadBlockIntoState(ctx context.Context, blockNumber uin
 		return nil, fmt.Errorf("getting L2 block: %w", err)
 	}
 
+	if l.ChannelConfig.SkipEmptyBlocks {
+		skip, err := EmptyBlockRegenerable(ctx, l.RollupConfig, l.L1Client, block)
+		if err != nil {
+			return nil, fmt.Errorf("checking whether L2 block is regenerable: %w", err)
+		}
+		if skip {
+			if err := l.state.SkipL2Block(block); err != nil {
+				return nil, fmt.Errorf("skipping L2 block: %w", err)
+			}
+			l.Log.Info("skipped empty L2 block", "block", eth.ToBlockID(block), "time", block.Time())
+			return block, nil
+		}
+	}
+
 	if err := l.state.AddL2Block(block); err != nil {
 		return nil, fmt.Errorf("adding L2 block to state: %w", err)
 	}
@@ -211,13 +360,9 @@ func (l *BatchSubmitter) calculateL2BlockRangeToStore(ctx context.Context) (eth.
 	if err != nil {
 		return eth.BlockID{}, eth.BlockID{}, fmt.Errorf("getting rollup client: %w", err)
 	}
-	syncStatus, err := rollupClient.SyncStatus(ctx)
-	// Ensure that we have the sync status
+	syncStatus, err := l.syncStatus(ctx, rollupClient)
 	if err != nil {
-		return eth.BlockID{}, eth.BlockID{}, fmt.Errorf("failed to get sync status: %w", err)
-	}
-	if syncStatus.HeadL1 == (eth.L1BlockRef{}) {
-		return eth.BlockID{}, eth.BlockID{}, errors.New("empty sync status")
+		return eth.BlockID{}, eth.BlockID{}, err
 	}
 
 	// Check last stored to see if it needs to be set on startup OR set if is lagged behind.
@@ -238,6 +383,20 @@ func (l *BatchSubmitter) calculateL2BlockRangeToStore(ctx context.Context) (eth.
 	return l.lastStoredBlock, syncStatus.UnsafeL2.ID(), nil
 }
 
+// syncStatus fetches the sync status of the rollup node, and updates the head monitor with it.
+func (l *BatchSubmitter) syncStatus(ctx context.Context, rollupClient RollupClient) (*eth.SyncStatus, error) {
+	syncStatus, err := rollupClient.SyncStatus(ctx)
+	// Ensure that we have the sync status
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync status: %w", err)
+	}
+	if syncStatus.HeadL1 == (eth.L1BlockRef{}) {
+		return nil, errors.New("empty sync status")
+	}
+	l.headMonitor.Update(syncStatus)
+	return syncStatus, nil
+}
+
 // The following things occur:
 // New L2 block (reorg or not)
 // L1 transaction is confirmed
@@ -252,7 +411,9 @@ func (l *BatchSubmitter) calculateL2BlockRangeToStore(ctx context.Context) (eth.
 func (l *BatchSubmitter) loop() {
 	defer l.wg.Done()
 
-	ticker := time.NewTicker(l.Config.PollInterval)
+	l.resumeFromState(l.shutdownCtx)
+
+	ticker := l.Clock.NewTicker(l.Config.PollInterval)
 	defer ticker.Stop()
 
 	receiptsCh := make(chan txmgr.TxReceipt[txData])
@@ -260,27 +421,183 @@ func (l *BatchSubmitter) loop() {
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticker.Ch():
+			l.checkL1Reorgs(l.shutdownCtx)
+			l.updateL1Final(l.shutdownCtx)
 			if err := l.loadBlocksIntoState(l.shutdownCtx); errors.Is(err, ErrReorg) {
 				err := l.state.Close()
 				if err != nil {
 					l.Log.Error("error closing the channel manager to handle a L2 reorg", "err", err)
 				}
-				l.publishStateToL1(queue, receiptsCh, true)
+				if !l.paused.Load() {
+					l.publishStateToL1(queue, receiptsCh, true)
+				}
 				l.state.Clear()
 				continue
 			}
-			l.publishStateToL1(queue, receiptsCh, false)
+			if l.paused.Load() {
+				l.logPaused()
+				continue
+			}
+			if l.checkFeeCeiling(l.killCtx) {
+				l.publishStateToL1(queue, receiptsCh, false)
+			}
+		case done := <-l.flushRequests:
+			done <- l.flush(queue, receiptsCh)
 		case r := <-receiptsCh:
 			l.handleReceipt(r)
 		case <-l.shutdownCtx.Done():
-			err := l.state.Close()
-			if err != nil {
-				l.Log.Error("error closing the channel manager", "err", err)
-			}
-			l.publishStateToL1(queue, receiptsCh, true)
+			l.drainState(queue, receiptsCh)
+			// stop sending the txs that are still pending, e.g. because batch submission is paused
+			queue.Abandon()
+			l.persistState()
+			return
+		}
+		l.persistState()
+	}
+}
+
+// resumeFromState resumes batch submission after the data that landed on L1 according to the state file
+// of the previous run, instead of at the safe head, so that the data isn't submitted twice.
+// If the state file is missing or corrupt, or it doesn't match the L2 chain or L1 anymore,
+// batch submission starts at the safe head.
+func (l *BatchSubmitter) resumeFromState(ctx context.Context) {
+	if l.persistence == nil {
+		return
+	}
+	state, err := l.persistence.Read()
+	if err != nil {
+		l.Log.Warn("Failed to read the batcher state file, starting at the safe head", "err", err)
+		return
+	} else if state == nil {
+		l.Log.Info("No batcher state file, starting at the safe head")
+		return
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+	defer cancel()
+	rollupClient, err := l.EndpointProvider.RollupClient(tctx)
+	if err != nil {
+		l.Log.Warn("Failed to get the rollup client to reconcile the batcher state, starting at the safe head", "err", err)
+		return
+	}
+	syncStatus, err := rollupClient.SyncStatus(tctx)
+	if err != nil {
+		l.Log.Warn("Failed to get the sync status to reconcile the batcher state, starting at the safe head", "err", err)
+		return
+	}
+	l2Client, err := l.EndpointProvider.EthClient(tctx)
+	if err != nil {
+		l.Log.Warn("Failed to get the L2 client to reconcile the batcher state, starting at the safe head", "err", err)
+		return
+	}
+	l.resumeAt(l.newStateReconciler(l2Client).Reconcile(ctx, state, syncStatus.SafeL2))
+}
+
+func (l *BatchSubmitter) newStateReconciler(l2 L2Client) *stateReconciler {
+	return newStateReconciler(l.Log, l.L1Client, l2, types.LatestSignerForChainID(l.RollupConfig.L1ChainID),
+		l.Txmgr.From(), l.RollupConfig.BatchInboxAddress, l.ChannelConfig.ChannelTimeout, l.Config.NetworkTimeout)
+}
+
+// resumeAt resumes batch submission after the given resume point, if ok.
+func (l *BatchSubmitter) resumeAt(point resumePoint, ok bool) {
+	if !ok {
+		l.Log.Info("Nothing of the batcher state landed beyond the safe head, starting at the safe head")
+		return
+	}
+	l.Log.Info("Resuming batch submission after the data that landed on L1", "block", point.block, "inclusion_block", point.inclusion)
+	l.lastStoredBlock = point.block
+	l.state.ResumeAfter(point.block, point.inclusion)
+	l.resumedInclusions = point.inclusionBlocks
+}
+
+// persistState writes the submission state to the state file, if enabled.
+func (l *BatchSubmitter) persistState() {
+	if l.persistence == nil {
+		return
+	}
+	state, err := l.state.PersistedState()
+	if err != nil {
+		l.Log.Error("Failed to collect the batcher state", "err", err)
+		return
+	}
+	if err := l.persistence.Write(state); err != nil {
+		l.Log.Error("Failed to persist the batcher state", "err", err)
+	}
+}
+
+// logPaused logs that batch submission is paused, and warns once the loaded blocks reached
+// their channel timeout or sequencing window, after which they can't be submitted safely anymore.
+func (l *BatchSubmitter) logPaused() {
+	deadline, err := l.state.SubmissionDeadline()
+	if err != nil {
+		l.Log.Error("Failed to determine the submission deadline", "err", err)
+		return
+	}
+	if deadline != 0 && l.lastL1Tip.Number >= deadline {
+		l.Log.Warn("Batch submission is paused past the channel timeout or sequencing window of the loaded blocks",
+			"l1_tip", l.lastL1Tip.ID(), "deadline", deadline)
+		return
+	}
+	l.Log.Debug("Batch submission is paused", "deadline", deadline)
+}
+
+// flush closes the open channel, and submits all loaded blocks, for a flush request.
+func (l *BatchSubmitter) flush(queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) error {
+	if l.paused.Load() {
+		return ErrBatcherPaused
+	}
+	l1tip, err := l.l1Tip(l.shutdownCtx)
+	if err != nil {
+		return err
+	}
+	l.recordL1Tip(l1tip)
+	if err := l.state.Flush(l1tip.ID()); err != nil {
+		return fmt.Errorf("flushing loaded blocks: %w", err)
+	}
+	l.Log.Info("Flushing loaded blocks", "l1_tip", l1tip.ID())
+	l.publishStateToL1(queue, receiptsCh, false)
+	return nil
+}
+
+// drainState submits all remaining state to L1 when shutting down: no new L2 blocks are loaded,
+// the open channel is closed, and all remaining frames are submitted, until all batcher txs
+// are confirmed or the kill ctx is done. Failed txs are resubmitted after the poll interval.
+// If batch submission is paused, nothing is submitted.
+// The range of blocks that remains unsubmitted is logged.
+func (l *BatchSubmitter) drainState(queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) {
+	if l.paused.Load() {
+		l.Log.Warn("Batch submission is paused, not draining the batcher state before shutdown")
+	} else {
+		l.drainUntilSubmitted(queue, receiptsCh)
+	}
+
+	blocks := l.state.UnsubmittedBlocks()
+	if len(blocks) == 0 {
+		l.Log.Info("All loaded blocks are submitted")
+		return
+	}
+	first, last := blocks[0], blocks[len(blocks)-1]
+	l.Log.Error("Stopping with unsubmitted blocks, they will be submitted by the next batcher run",
+		"first", eth.ToBlockID(first), "last", eth.ToBlockID(last), "count", len(blocks))
+}
+
+// drainUntilSubmitted drains the batcher state, until all loaded blocks are submitted or the kill ctx is done.
+func (l *BatchSubmitter) drainUntilSubmitted(queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) {
+	l.Log.Info("Draining the batcher state before shutdown")
+	for {
+		if err := l.state.Drain(l.lastL1Tip.ID()); err != nil {
+			l.Log.Error("error draining the channel manager", "err", err)
+			return
+		}
+		l.publishStateToL1(queue, receiptsCh, true)
+		if len(l.state.UnsubmittedBlocks()) == 0 || l.killCtx.Err() != nil {
 			return
 		}
+		select {
+		case <-l.Clock.After(l.Config.PollInterval):
+		case <-l.killCtx.Done():
+		}
 	}
 }
 
@@ -318,6 +635,34 @@ func (l *BatchSubmitter) publishStateToL1(queue *txmgr.Queue[txData], receiptsCh
 	}
 }
 
+// checkFeeCeiling returns whether batch data should be submitted under the L1 fee ceiling.
+// If the submission is forced while the L1 fees are high, all loaded blocks are flushed into channels,
+// so that they are submitted in this round.
+func (l *BatchSubmitter) checkFeeCeiling(ctx context.Context) bool {
+	if !l.Config.FeeCeiling.Enabled() {
+		return true
+	}
+	head, err := l.l1Header(ctx)
+	if err != nil {
+		l.Log.Warn("Failed to query L1 head for the fee ceiling, submitting regardless", "err", err)
+		return true
+	}
+	deadline, err := l.state.SubmissionDeadline()
+	if err != nil {
+		l.Log.Error("Failed to determine the submission deadline, submitting regardless", "err", err)
+		return true
+	}
+	submit, forced := l.feeCeiling.ShouldSubmit(head, deadline)
+	if forced {
+		l1tip := eth.InfoToL1BlockRef(eth.HeaderBlockInfo(head))
+		l.recordL1Tip(l1tip)
+		if err := l.state.Flush(l1tip.ID()); err != nil {
+			l.Log.Error("Failed to flush the loaded blocks for forced submission", "err", err)
+		}
+	}
+	return submit
+}
+
 // publishTxToL1 submits a single state tx to the L1
 func (l *BatchSubmitter) publishTxToL1(ctx context.Context, queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) error {
 	// send all available transactions
@@ -346,39 +691,208 @@ func (l *BatchSubmitter) publishTxToL1(ctx context.Context, queue *txmgr.Queue[t
 // It currently uses the underlying `txmgr` to handle transaction sending & price management.
 // This is a blocking method. It should not be called concurrently.
 func (l *BatchSubmitter) sendTransaction(txdata txData, queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) {
+	candidate := txmgr.TxCandidate{
+		To: &l.RollupConfig.BatchInboxAddress,
+	}
+	if txdata.urgent && l.Config.UrgentResubmissionTimeout != 0 {
+		l.Log.Warn("Escalating the submission of urgent tx data", "id", txdata.ID(), "resubmission_timeout", l.Config.UrgentResubmissionTimeout)
+		candidate.ResubmissionTimeout = l.Config.UrgentResubmissionTimeout
+	}
+	if txdata.asBlob {
+		blobs, err := txdata.Blobs()
+		if err != nil {
+			l.Log.Error("Failed to encode tx data into blobs", "error", err)
+			return
+		}
+		candidate.Blobs = blobs
+	} else {
+		candidate.TxData = txdata.CallData()
+	}
+
 	// Do the gas estimation offline. A value of 0 will cause the [txmgr] to estimate the gas limit.
-	data := txdata.Bytes()
-	intrinsicGas, err := core.IntrinsicGas(data, nil, false, true, true, false)
+	intrinsicGas, err := core.IntrinsicGas(candidate.TxData, nil, false, true, true, false)
 	if err != nil {
 		l.Log.Error("Failed to calculate intrinsic gas", "error", err)
 		return
 	}
-
-	candidate := txmgr.TxCandidate{
-		To:       &l.RollupConfig.BatchInboxAddress,
-		TxData:   data,
-		GasLimit: intrinsicGas,
+	candidate.GasLimit = intrinsicGas
+	if txdata.asBlob {
+		l.Metr.RecordBatchTxDataGas(flags.BlobsType, uint64(len(candidate.Blobs))*params.BlobTxBlobGasPerBlob)
+	} else {
+		l.Metr.RecordBatchTxDataGas(flags.CalldataType, intrinsicGas)
+	}
+	l.txQueued(txdata.ID())
+	if l.dryRun != nil {
+		l.writeDryRunTx(txdata, intrinsicGas, receiptsCh)
+		return
 	}
 	queue.Send(txdata, candidate, receiptsCh)
 }
 
+// writeDryRunTx writes the tx data to the dry-run dir instead of sending it, and returns the receipt
+// of the tx as if it was confirmed, so that the batcher state advances as if the tx landed on L1.
+func (l *BatchSubmitter) writeDryRunTx(txdata txData, intrinsicGas uint64, receiptsCh chan txmgr.TxReceipt[txData]) {
+	ctx, cancel := context.WithTimeout(l.killCtx, l.Config.NetworkTimeout)
+	defer cancel()
+	receipt, err := l.dryRun.Write(ctx, txdata, l.state.TxChannels(txdata.ID()), intrinsicGas)
+	if err == nil {
+		l.Log.Info("Wrote dry-run tx", "id", txdata.ID(), "inclusion_block", receipt.BlockNumber, "dir", l.Config.DryRunDir)
+	}
+	receiptsCh <- txmgr.TxReceipt[txData]{ID: txdata, Receipt: receipt, Err: err}
+}
+
+// txQueued records the tx with the given ID as in flight.
+func (l *BatchSubmitter) txQueued(id txID) {
+	l.inFlightMu.Lock()
+	defer l.inFlightMu.Unlock()
+	l.inFlight[id] = l.Clock.Now()
+	l.Metr.RecordBatchTxsInFlight(len(l.inFlight))
+}
+
+// txDone records the tx with the given ID as no longer in flight, and returns the duration since it was queued.
+func (l *BatchSubmitter) txDone(id txID) (time.Duration, bool) {
+	l.inFlightMu.Lock()
+	defer l.inFlightMu.Unlock()
+	queuedAt, ok := l.inFlight[id]
+	if !ok {
+		return 0, false
+	}
+	delete(l.inFlight, id)
+	l.Metr.RecordBatchTxsInFlight(len(l.inFlight))
+	return l.Clock.Now().Sub(queuedAt), true
+}
+
 func (l *BatchSubmitter) handleReceipt(r txmgr.TxReceipt[txData]) {
+	latency, inFlight := l.txDone(r.ID.ID())
 	// Record TX Status
 	if r.Err != nil {
 		l.Log.Warn("unable to publish tx", "err", r.Err, "data_size", r.ID.Len())
 		l.recordFailedTx(r.ID.ID(), r.Err)
 	} else {
-		l.Log.Info("tx successfully published", "tx_hash", r.Receipt.TxHash, "data_size", r.ID.Len())
+		l.Log.Info("tx successfully published", "tx_hash", r.Receipt.TxHash, "data_size", r.ID.Len(), "as_blob", r.ID.asBlob, "latency", latency)
+		if inFlight {
+			l.Metr.RecordBatchTxConfirmationLatency(latency)
+		}
 		l.recordConfirmedTx(r.ID.ID(), r.Receipt)
+		l.recordPostedTxData(r.ID, r.Receipt)
 	}
 }
 
+// checkL1Reorgs checks whether the L1 inclusion blocks of the confirmed batcher transactions are still canonical,
+// whenever the L1 tip changed. The blocks of channels with transactions that were reorged out of L1 are
+// put back into the local state, to be submitted again.
+func (l *BatchSubmitter) checkL1Reorgs(ctx context.Context) {
+	if l.lastL1Tip == l.lastReorgCheck {
+		return
+	}
+	if !l.checkResumedL1Reorgs(ctx) {
+		return
+	}
+	var reorged []eth.BlockID
+	for _, inclusionBlock := range l.state.InclusionBlocks() {
+		tctx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+		header, err := l.L1Client.HeaderByNumber(tctx, new(big.Int).SetUint64(inclusionBlock.Number))
+		cancel()
+		if errors.Is(err, ethereum.NotFound) {
+			// the L1 chain got shorter in the reorg
+			reorged = append(reorged, inclusionBlock)
+			continue
+		} else if err != nil {
+			l.Log.Warn("Failed to check L1 inclusion block of batcher transactions", "block", inclusionBlock, "err", err)
+			return
+		}
+		if header.Hash() != inclusionBlock.Hash {
+			reorged = append(reorged, inclusionBlock)
+		}
+	}
+	l.lastReorgCheck = l.lastL1Tip
+	if len(reorged) > 0 {
+		l.Log.Warn("Batcher transactions were reorged out of L1, resubmitting their blocks", "inclusion_blocks", reorged)
+		l.state.L1Reorged(reorged, l.lastL1Tip.ID())
+	}
+}
+
+// updateL1Final updates the last final L1 block per the confirmation mode, and confirms the fully submitted
+// channels of which all inclusion blocks are final, so that they are no longer tracked for L1 reorgs.
+func (l *BatchSubmitter) updateL1Final(ctx context.Context) {
+	var final uint64
+	switch l.Config.ConfirmationMode {
+	case flags.SafeConfirmation, flags.FinalizedConfirmation:
+		tag := gethrpc.SafeBlockNumber
+		if l.Config.ConfirmationMode == flags.FinalizedConfirmation {
+			tag = gethrpc.FinalizedBlockNumber
+		}
+		tctx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+		header, err := l.L1Client.HeaderByNumber(tctx, big.NewInt(tag.Int64()))
+		cancel()
+		if err != nil {
+			l.Log.Warn("Failed to get the final L1 block", "mode", l.Config.ConfirmationMode, "err", err)
+			return
+		}
+		final = header.Number.Uint64()
+	default:
+		if l.lastL1Tip.Number > l.Config.ConfirmationDepth {
+			final = l.lastL1Tip.Number - l.Config.ConfirmationDepth
+		}
+	}
+	if final < l.lastL1Final {
+		// the final L1 block never moves back, e.g. when the safe block of another L1 node lags behind
+		return
+	}
+	l.lastL1Final = final
+	l.state.L1Final(final)
+}
+
+// checkResumedL1Reorgs checks whether the L1 inclusion blocks of the data that landed before a restart are still
+// canonical, until they are final per the confirmation mode. If any got reorged out, the local
+// state is cleared, to start again at the safe head. It returns false if the check failed.
+func (l *BatchSubmitter) checkResumedL1Reorgs(ctx context.Context) bool {
+	var tracked []eth.BlockID
+	for _, inclusionBlock := range l.resumedInclusions {
+		if inclusionBlock.Number > l.lastL1Final {
+			tracked = append(tracked, inclusionBlock)
+		}
+	}
+	l.resumedInclusions = tracked
+	for _, inclusionBlock := range tracked {
+		tctx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+		header, err := l.L1Client.HeaderByNumber(tctx, new(big.Int).SetUint64(inclusionBlock.Number))
+		cancel()
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			l.Log.Warn("Failed to check L1 inclusion block of batcher transactions before the restart", "block", inclusionBlock, "err", err)
+			return false
+		}
+		if err != nil || header.Hash() != inclusionBlock.Hash {
+			l.Log.Warn("Batcher transactions from before the restart were reorged out of L1, starting again at the safe head", "inclusion_block", inclusionBlock)
+			l.state.Clear()
+			l.lastStoredBlock = eth.BlockID{}
+			l.resumedInclusions = nil
+			return true
+		}
+	}
+	return true
+}
+
 func (l *BatchSubmitter) recordL1Tip(l1tip eth.L1BlockRef) {
 	if l.lastL1Tip == l1tip {
 		return
 	}
 	l.lastL1Tip = l1tip
 	l.Metr.RecordLatestL1Block(l1tip)
+	l.state.CheckDeadlines(l1tip.Number)
+}
+
+// recordPostedTxData records the batch data posted to L1 by a confirmed tx, by data availability type,
+// and the blob fee paid for it.
+func (l *BatchSubmitter) recordPostedTxData(txdata txData, receipt *types.Receipt) {
+	if !txdata.asBlob {
+		l.Metr.RecordBatchDataPosted(flags.CalldataType, txdata.Len())
+		return
+	}
+	l.Metr.RecordBatchDataPosted(flags.BlobsType, txdata.Len())
+	if receipt.BlobGasPrice != nil {
+		l.Metr.RecordBlobFeePaid(receipt.BlobGasUsed, receipt.BlobGasPrice)
+	}
 }
 
 func (l *BatchSubmitter) recordFailedTx(id txID, err error) {
@@ -390,16 +904,29 @@ func (l *BatchSubmitter) recordConfirmedTx(id txID, receipt *types.Receipt) {
 	l.Log.Info("Transaction confirmed", "tx_hash", receipt.TxHash, "status", receipt.Status, "block_hash", receipt.BlockHash, "block_number", receipt.BlockNumber)
 	l1block := eth.BlockID{Number: receipt.BlockNumber.Uint64(), Hash: receipt.BlockHash}
 	l.state.TxConfirmed(id, l1block)
+	l.lastTxMu.Lock()
+	l.lastSubmittedTx = receipt.TxHash
+	l.lastTxMu.Unlock()
 }
 
 // l1Tip gets the current L1 tip as a L1BlockRef. The passed context is assumed
 // to be a lifetime context, so it is internally wrapped with a network timeout.
 func (l *BatchSubmitter) l1Tip(ctx context.Context) (eth.L1BlockRef, error) {
+	head, err := l.l1Header(ctx)
+	if err != nil {
+		return eth.L1BlockRef{}, err
+	}
+	return eth.InfoToL1BlockRef(eth.HeaderBlockInfo(head)), nil
+}
+
+// l1Header gets the header of the current L1 tip. The passed context is assumed
+// to be a lifetime context, so it is internally wrapped with a network timeout.
+func (l *BatchSubmitter) l1Header(ctx context.Context) (*types.Header, error) {
 	tctx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
 	defer cancel()
 	head, err := l.L1Client.HeaderByNumber(tctx, nil)
 	if err != nil {
-		return eth.L1BlockRef{}, fmt.Errorf("getting latest L1 block: %w", err)
+		return nil, fmt.Errorf("getting latest L1 block: %w", err)
 	}
-	return eth.InfoToL1BlockRef(eth.HeaderBlockInfo(head)), nil
+	return head, nil
 }
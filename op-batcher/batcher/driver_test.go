@@ -0,0 +1,556 @@
+package batcher
+
+import (
+	"compress/zlib"
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// fakeL1 is a L1 chain of headers by number, which can be reorged.
+type fakeL1 struct {
+	headers []*types.Header
+	// txs are the transactions of the blocks, by block hash
+	txs map[common.Hash][]*types.Transaction
+	// baseFee is the base fee of new blocks
+	baseFee *big.Int
+	// safe and finalized are the numbers of the safe and finalized blocks
+	safe, finalized uint64
+}
+
+func (f *fakeL1) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return f.headers[len(f.headers)-1], nil
+	}
+	switch number.Int64() {
+	case int64(gethrpc.SafeBlockNumber):
+		return f.headers[f.safe], nil
+	case int64(gethrpc.FinalizedBlockNumber):
+		return f.headers[f.finalized], nil
+	}
+	if n := number.Uint64(); n < uint64(len(f.headers)) {
+		return f.headers[n], nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func (f *fakeL1) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	h, err := f.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewBlockWithHeader(h).WithBody(f.txs[h.Hash()], nil), nil
+}
+
+// extend adds a block to the chain, and returns its ID.
+// The extra data makes the blocks of different forks distinct.
+func (f *fakeL1) extend(extra byte) eth.BlockID {
+	return f.extendWithTxs(extra)
+}
+
+// extendWithTxs adds a block with the given transactions to the chain, and returns its ID.
+func (f *fakeL1) extendWithTxs(extra byte, txs ...*types.Transaction) eth.BlockID {
+	h := &types.Header{Number: big.NewInt(int64(len(f.headers))), Extra: []byte{extra}, BaseFee: f.baseFee}
+	if len(f.headers) > 0 {
+		h.ParentHash = f.headers[len(f.headers)-1].Hash()
+	}
+	f.headers = append(f.headers, h)
+	if len(txs) > 0 {
+		if f.txs == nil {
+			f.txs = make(map[common.Hash][]*types.Transaction)
+		}
+		f.txs[h.Hash()] = txs
+	}
+	return eth.BlockID{Hash: h.Hash(), Number: h.Number.Uint64()}
+}
+
+// reorg rewinds the chain to the given block number, and builds a new fork up to the previous length.
+func (f *fakeL1) reorg(to uint64) {
+	length := len(f.headers)
+	f.headers = f.headers[:to+1]
+	for len(f.headers) < length {
+		f.extend(0xff)
+	}
+}
+
+func (f *fakeL1) tip() eth.L1BlockRef {
+	return eth.InfoToL1BlockRef(eth.HeaderBlockInfo(f.headers[len(f.headers)-1]))
+}
+
+func newReorgTestBatchSubmitter(t *testing.T, l1 *fakeL1, cfg BatcherConfig) *BatchSubmitter {
+	cfg.NetworkTimeout = time.Second
+	return NewBatchSubmitter(DriverSetup{
+		Log:          testlog.Logger(t, log.LvlCrit),
+		Metr:         metrics.NoopMetrics,
+		RollupConfig: &defaultTestRollupConfig,
+		Config:       cfg,
+		L1Client:     l1,
+		ChannelConfig: ChannelConfig{
+			MaxFrameSize:    120_000,
+			ChannelTimeout:  100,
+			SubSafetyMargin: 10,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  1,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
+			},
+			BatchType: derive.SingularBatchType,
+		},
+	})
+}
+
+// submitTestBlock loads the given L2 block, and confirms its single frame tx in a new L1 block.
+func submitTestBlock(t *testing.T, l *BatchSubmitter, l1 *fakeL1, block *types.Block) (txData, eth.BlockID) {
+	require.NoError(t, l.state.AddL2Block(block))
+	txdata, err := l.state.TxData(l1.tip().ID())
+	require.NoError(t, err)
+	inclusionBlock := l1.extend(0)
+	l.state.TxConfirmed(txdata.ID(), inclusionBlock)
+	l.recordL1Tip(l1.tip())
+	_, err = l.state.TxData(l1.tip().ID())
+	require.ErrorIs(t, err, io.EOF, "the batch is fully submitted")
+	return txdata, inclusionBlock
+}
+
+func TestBatchSubmitterResubmitsReorgedBatch(t *testing.T) {
+	t.Run("in the same channel", func(t *testing.T) {
+		l1 := &fakeL1{}
+		for i := 0; i < 5; i++ {
+			l1.extend(0)
+		}
+		l := newReorgTestBatchSubmitter(t, l1, BatcherConfig{ConfirmationDepth: 64})
+		block := newMiniL2Block(0)
+		txdata, inclusionBlock := submitTestBlock(t, l, l1, block)
+
+		// without a reorg, nothing is resubmitted
+		l1.extend(0)
+		l.recordL1Tip(l1.tip())
+		l.checkL1Reorgs(context.Background())
+		_, err := l.state.TxData(l1.tip().ID())
+		require.ErrorIs(t, err, io.EOF)
+
+		// the L1 block with the batch is reorged out before it is final
+		l1.reorg(inclusionBlock.Number - 1)
+		l.recordL1Tip(l1.tip())
+		l.checkL1Reorgs(context.Background())
+		require.Empty(t, l.state.blocks, "the block is not requeued")
+		require.Equal(t, []*types.Block{block}, l.state.UnsubmittedBlocks(), "the reorged batch is pending again")
+
+		resubmitted, err := l.state.TxData(l1.tip().ID())
+		require.NoError(t, err, "the batch is resubmitted")
+		require.Equal(t, txdata.ID(), resubmitted.ID(), "in the same channel")
+		require.Equal(t, txdata.CallData(), resubmitted.CallData())
+		l.state.TxConfirmed(resubmitted.ID(), l1.extend(0))
+		require.Empty(t, l.state.UnsubmittedBlocks())
+		require.Equal(t, []eth.BlockID{l1.tip().ID()}, l.state.InclusionBlocks())
+	})
+
+	t.Run("in a new channel after the channel timeout", func(t *testing.T) {
+		l1 := &fakeL1{}
+		for i := 0; i < 5; i++ {
+			l1.extend(0)
+		}
+		l := newReorgTestBatchSubmitter(t, l1, BatcherConfig{ConfirmationDepth: 200})
+		block := newMiniL2Block(0)
+		txdata, inclusionBlock := submitTestBlock(t, l, l1, block)
+
+		// the L1 block with the batch is reorged out when a resubmission could not land within the channel timeout
+		for i := 0; i < 100; i++ {
+			l1.extend(0)
+		}
+		l1.reorg(inclusionBlock.Number - 1)
+		l.recordL1Tip(l1.tip())
+		l.checkL1Reorgs(context.Background())
+		require.Equal(t, []*types.Block{block}, l.state.blocks, "the block of the reorged batch is requeued")
+
+		resubmitted, err := l.state.TxData(l1.tip().ID())
+		require.NoError(t, err, "the block is resubmitted")
+		require.NotEqual(t, txdata.ID().chID, resubmitted.ID().chID, "in a new channel")
+		l.state.TxConfirmed(resubmitted.ID(), l1.extend(0))
+		require.Empty(t, l.state.UnsubmittedBlocks())
+		require.Equal(t, []eth.BlockID{l1.tip().ID()}, l.state.InclusionBlocks())
+	})
+}
+
+func TestBatchSubmitterConfirmationMode(t *testing.T) {
+	t.Run("depth", func(t *testing.T) {
+		l1 := &fakeL1{}
+		for i := 0; i < 5; i++ {
+			l1.extend(0)
+		}
+		l := newReorgTestBatchSubmitter(t, l1, BatcherConfig{ConfirmationMode: flags.DepthConfirmation, ConfirmationDepth: 3})
+		_, inclusionBlock := submitTestBlock(t, l, l1, newMiniL2Block(0))
+
+		for l1.tip().Number < inclusionBlock.Number+3 {
+			l.updateL1Final(context.Background())
+			require.Equal(t, []eth.BlockID{inclusionBlock}, l.state.InclusionBlocks(), "not final at L1 block %d", l1.tip().Number)
+			l1.extend(0)
+			l.recordL1Tip(l1.tip())
+		}
+		l.updateL1Final(context.Background())
+		require.Empty(t, l.state.InclusionBlocks(), "final at the confirmation depth")
+		require.Empty(t, l.state.channelQueue)
+
+		// a reorg of the final inclusion block is not tracked anymore
+		l1.reorg(inclusionBlock.Number - 1)
+		l.recordL1Tip(l1.tip())
+		l.checkL1Reorgs(context.Background())
+		require.Empty(t, l.state.UnsubmittedBlocks())
+	})
+
+	for _, mode := range []flags.ConfirmationMode{flags.SafeConfirmation, flags.FinalizedConfirmation} {
+		mode := mode
+		t.Run(mode.String(), func(t *testing.T) {
+			l1 := &fakeL1{}
+			for i := 0; i < 5; i++ {
+				l1.extend(0)
+			}
+			l := newReorgTestBatchSubmitter(t, l1, BatcherConfig{ConfirmationMode: mode, ConfirmationDepth: 1})
+			_, inclusionBlock := submitTestBlock(t, l, l1, newMiniL2Block(0))
+			for i := 0; i < 5; i++ {
+				l1.extend(0)
+			}
+			l.recordL1Tip(l1.tip())
+
+			l.updateL1Final(context.Background())
+			require.Equal(t, []eth.BlockID{inclusionBlock}, l.state.InclusionBlocks(), "the confirmation depth is ignored")
+
+			if mode == flags.SafeConfirmation {
+				l1.safe, l1.finalized = inclusionBlock.Number, inclusionBlock.Number-1
+			} else {
+				l1.safe, l1.finalized = l1.tip().Number, inclusionBlock.Number
+			}
+			l.updateL1Final(context.Background())
+			require.Empty(t, l.state.InclusionBlocks(), "final once the %v L1 block includes it", mode)
+			require.Empty(t, l.state.channelQueue)
+		})
+	}
+}
+
+// TestBatchSubmitterFeeCeilingSafetyBound simulates a L1 fee spike, during which batch submission is paused,
+// and tests that the submission is forced before the sequencing window of the loaded block closes.
+func TestBatchSubmitterFeeCeilingSafetyBound(t *testing.T) {
+	l1 := &fakeL1{baseFee: big.NewInt(1000)}
+	for i := 0; i < 100; i++ {
+		l1.extend(0)
+	}
+	l := NewBatchSubmitter(DriverSetup{
+		Log:          testlog.Logger(t, log.LvlCrit),
+		Metr:         metrics.NoopMetrics,
+		RollupConfig: &defaultTestRollupConfig,
+		Config: BatcherConfig{
+			NetworkTimeout: time.Second,
+			FeeCeiling: FeeCeilingConfig{
+				MaxBaseFee:    big.NewInt(100),
+				ResumePercent: 90,
+				MaxDelay:      24 * time.Hour,
+			},
+		},
+		L1Client: l1,
+		ChannelConfig: ChannelConfig{
+			SeqWindowSize:   15,
+			ChannelTimeout:  100,
+			SubSafetyMargin: 4,
+			MaxFrameSize:    120_000,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  120_000,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
+			},
+			BatchType: derive.SingularBatchType,
+		},
+	})
+	// the L1 origin of the block is 100, so its sequencing window closes at 115
+	require.NoError(t, l.state.AddL2Block(newMiniL2Block(0)))
+	deadline, err := l.state.SubmissionDeadline()
+	require.NoError(t, err)
+	require.Equal(t, uint64(111), deadline, "sequencing window minus the safety margin")
+
+	// the spike lasts, the blocks are kept, but nothing is submitted
+	for l1.tip().Number < deadline-1 {
+		l1.extend(0)
+		require.False(t, l.checkFeeCeiling(context.Background()), "paused at L1 block %d", l1.tip().Number)
+	}
+	require.Len(t, l.state.UnsubmittedBlocks(), 1)
+
+	l1.extend(0)
+	require.True(t, l.checkFeeCeiling(context.Background()), "submission is forced at the deadline")
+	txdata, err := l.state.TxData(l1.tip().ID())
+	require.NoError(t, err, "the loaded block is flushed for submission")
+	require.Len(t, txdata.Frames(), 1)
+	l.state.TxConfirmed(txdata.ID(), l1.extend(0))
+	require.Empty(t, l.state.UnsubmittedBlocks())
+	require.Less(t, l1.tip().Number, uint64(115), "included within the sequencing window")
+
+	// the spike is over
+	l1.baseFee = big.NewInt(90)
+	l1.extend(0)
+	require.True(t, l.checkFeeCeiling(context.Background()))
+}
+
+// candidateTxManager records the tx candidates that are sent, and fails them.
+type candidateTxManager struct {
+	txmgr.TxManager
+	candidates chan txmgr.TxCandidate
+}
+
+func (m *candidateTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	m.candidates <- candidate
+	return nil, errors.New("not included")
+}
+
+func TestBatchSubmitterEscalatesUrgentSubmission(t *testing.T) {
+	l1 := &fakeL1{}
+	l1.extend(0)
+	l := newReorgTestBatchSubmitter(t, l1, BatcherConfig{UrgentResubmissionTimeout: time.Second})
+	// the L1 origin of the block is 100, so its safety timeout is at 90 with the safety margin of 10
+	l.state.cfg.UrgencyMargin = 20
+	metr := &urgencyMetrics{Metricer: metrics.NoopMetrics}
+	l.state.metr = metr
+	txMgr := &candidateTxManager{candidates: make(chan txmgr.TxCandidate, 1)}
+	queue := txmgr.NewQueue[txData](context.Background(), txMgr, 1)
+	receiptsCh := make(chan txmgr.TxReceipt[txData], 1)
+	send := func() (txmgr.TxCandidate, txData) {
+		l.recordL1Tip(l1.tip())
+		txdata, err := l.state.TxData(l1.tip().ID())
+		require.NoError(t, err)
+		l.sendTransaction(txdata, queue, receiptsCh)
+		candidate := <-txMgr.candidates
+		l.handleReceipt(<-receiptsCh)
+		return candidate, txdata
+	}
+
+	require.NoError(t, l.state.AddL2Block(newMiniL2Block(0)))
+	candidate, txdata := send()
+	require.False(t, txdata.urgent)
+	require.Zero(t, candidate.ResubmissionTimeout, "the resubmission timeout of the tx manager applies")
+
+	// the tx isn't included until the urgency margin
+	for l1.tip().Number < 70 {
+		l1.extend(0)
+	}
+	candidate, txdata = send()
+	require.True(t, txdata.urgent)
+	require.Equal(t, time.Second, candidate.ResubmissionTimeout, "the fees are bumped more aggressively")
+	require.Equal(t, 1, metr.urgent)
+	require.Zero(t, metr.atRisk)
+
+	// the resubmitted tx is still pending at the safety timeout
+	_, err := l.state.TxData(l1.tip().ID())
+	require.NoError(t, err)
+	for l1.tip().Number < 90 {
+		l1.extend(0)
+	}
+	l.recordL1Tip(l1.tip())
+	require.Equal(t, 1, metr.atRisk)
+}
+
+// txResult is the outcome of a batcher tx sent to the delayedTxManager.
+type txResult struct {
+	receipt *types.Receipt
+	err     error
+}
+
+// delayedTxManager holds each sent tx until the test includes or fails it, and tracks the txs in flight.
+// A tx that is still in flight when the send is canceled fails with the context error.
+type delayedTxManager struct {
+	txmgr.TxManager
+	sent chan []byte
+
+	mu         sync.Mutex
+	results    map[common.Hash]chan txResult
+	pending    int
+	maxPending int
+}
+
+func newDelayedTxManager() *delayedTxManager {
+	return &delayedTxManager{
+		sent:    make(chan []byte, 16),
+		results: make(map[common.Hash]chan txResult),
+	}
+}
+
+func (m *delayedTxManager) result(calldata []byte) chan txResult {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := crypto.Keccak256Hash(calldata)
+	if m.results[key] == nil {
+		m.results[key] = make(chan txResult, 1)
+	}
+	return m.results[key]
+}
+
+func (m *delayedTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	m.mu.Lock()
+	m.pending++
+	m.maxPending = max(m.maxPending, m.pending)
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.pending--
+		m.mu.Unlock()
+	}()
+	result := m.result(candidate.TxData)
+	m.sent <- candidate.TxData
+	select {
+	case r := <-result:
+		return r.receipt, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// include includes the tx with the given tx data in a new L1 block.
+func (m *delayedTxManager) include(l1 *fakeL1, txdata txData) {
+	inclusion := l1.extend(0)
+	m.result(txdata.CallData()) <- txResult{receipt: &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		BlockHash:   inclusion.Hash,
+		BlockNumber: new(big.Int).SetUint64(inclusion.Number),
+	}}
+}
+
+// inFlightMetrics records the batcher txs in flight, and the confirmation latencies.
+type inFlightMetrics struct {
+	metrics.Metricer
+	mu        sync.Mutex
+	inFlight  int
+	latencies int
+}
+
+func (m *inFlightMetrics) RecordBatchTxsInFlight(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight = n
+}
+
+func (m *inFlightMetrics) RecordBatchTxConfirmationLatency(latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.latencies++
+}
+
+func (m *inFlightMetrics) get() (inFlight int, latencies int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.inFlight, m.latencies
+}
+
+func TestBatchSubmitterConcurrentSubmission(t *testing.T) {
+	l1 := &fakeL1{}
+	l1.extend(0)
+	l := newReorgTestBatchSubmitter(t, l1, BatcherConfig{MaxPendingTransactions: 3})
+	metr := &inFlightMetrics{Metricer: metrics.NoopMetrics}
+	l.Metr = metr
+	txMgr := newDelayedTxManager()
+	queue := txmgr.NewQueue[txData](context.Background(), txMgr, l.Config.MaxPendingTransactions)
+	receiptsCh := make(chan txmgr.TxReceipt[txData], 8)
+
+	var blocks []*types.Block
+	parent := common.Hash{}
+	for i := 0; i < 4; i++ {
+		block := newMiniL2BlockWithNumberParent(0, big.NewInt(int64(i)), parent)
+		require.NoError(t, l.state.AddL2Block(block))
+		blocks = append(blocks, block)
+		parent = block.Hash()
+	}
+	// sendAll sends the txs of all blocks, of a channel with a single frame per block, in order.
+	// The last tx is queued until one of the first three pending txs is done.
+	sendAll := func() []txData {
+		var txs []txData
+		for range blocks {
+			txdata, err := l.state.TxData(l1.tip().ID())
+			require.NoError(t, err)
+			txs = append(txs, txdata)
+		}
+		go func() {
+			for _, txdata := range txs {
+				l.sendTransaction(txdata, queue, receiptsCh)
+			}
+		}()
+		for i := 0; i < 3; i++ {
+			<-txMgr.sent
+		}
+		require.Eventually(t, func() bool {
+			inFlight, _ := metr.get()
+			return inFlight == 4
+		}, time.Second, time.Millisecond, "three pending txs and a queued tx")
+		return txs
+	}
+
+	// the first tx gets stuck and fails, so that the txs with its nonce successors are canceled
+	txs := sendAll()
+	txMgr.result(txs[0].CallData()) <- txResult{err: errors.New("stuck")}
+	for range txs {
+		l.handleReceipt(<-receiptsCh)
+	}
+	<-txMgr.sent
+	txMgr.mu.Lock()
+	require.Equal(t, 3, txMgr.maxPending, "up to the max pending txs are sent concurrently")
+	txMgr.mu.Unlock()
+	inFlight, latencies := metr.get()
+	require.Zero(t, inFlight)
+	require.Zero(t, latencies)
+	require.Equal(t, blocks, l.state.UnsubmittedBlocks(), "the frames of the failed and canceled txs are requeued")
+
+	// the requeued frames are sent again in order, and are included with delays
+	resent := sendAll()
+	for i, txdata := range resent {
+		require.Equal(t, txs[i].ID(), txdata.ID(), "resent in order")
+		l1.extend(0)
+		txMgr.include(l1, txdata)
+		l.handleReceipt(<-receiptsCh)
+	}
+	<-txMgr.sent
+	require.Empty(t, l.state.UnsubmittedBlocks())
+	inFlight, latencies = metr.get()
+	require.Zero(t, inFlight)
+	require.Equal(t, 4, latencies, "a confirmation latency per included tx")
+}
+
+func TestBatchSubmitterInFlightTime(t *testing.T) {
+	l1 := &fakeL1{}
+	l1.extend(0)
+	l := newReorgTestBatchSubmitter(t, l1, BatcherConfig{})
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	l.Clock = clk
+
+	first := txID{frameNumber: 0}
+	second := txID{frameNumber: 1}
+	l.txQueued(first)
+	clk.AdvanceTime(2 * time.Minute)
+	l.txQueued(second)
+	clk.AdvanceTime(3 * time.Minute)
+
+	latency, ok := l.txDone(first)
+	require.True(t, ok)
+	require.Equal(t, 5*time.Minute, latency)
+	latency, ok = l.txDone(second)
+	require.True(t, ok)
+	require.Equal(t, 3*time.Minute, latency)
+	_, ok = l.txDone(first)
+	require.False(t, ok, "the tx is no longer in flight")
+}
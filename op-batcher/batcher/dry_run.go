@@ -0,0 +1,184 @@
+package batcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// dryRunExtra is the extra data of the synthesized L1 blocks of a dry-run.
+var dryRunExtra = []byte("dry-run")
+
+// dryRunL1 is the L1 chain of a dry-run. Its head follows the L1 head of the rollup node,
+// and its blocks are synthesized, with a fixed hash per number, so that the batcher txs
+// of a dry-run are included in the synthesized head block and never reorged out.
+type dryRunL1 struct {
+	// headL1 returns the number of the L1 head
+	headL1 func(ctx context.Context) (uint64, error)
+}
+
+func newDryRunL1(headL1 func(ctx context.Context) (uint64, error)) *dryRunL1 {
+	return &dryRunL1{headL1: headL1}
+}
+
+// HeaderByNumber returns the synthesized header of the given number, or of the head if the number is nil
+// or a block tag. All blocks up to the head are considered safe and finalized.
+func (d *dryRunL1) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	head, err := d.headL1(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if number == nil || number.Sign() < 0 {
+		return dryRunHeader(head), nil
+	}
+	if n := number.Uint64(); n <= head {
+		return dryRunHeader(n), nil
+	}
+	return nil, ethereum.NotFound
+}
+
+// BlockByNumber returns the synthesized block of the given number, which has no transactions.
+func (d *dryRunL1) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	h, err := d.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewBlockWithHeader(h), nil
+}
+
+func dryRunHeader(number uint64) *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(number), Difficulty: common.Big0, Extra: dryRunExtra}
+}
+
+// dryRunTx is the metadata of a batcher tx of a dry-run, which is written to its JSON sidecar.
+type dryRunTx struct {
+	// Files are the names of the data files of the tx: the calldata, or a file per blob.
+	Files  []string `json:"files"`
+	AsBlob bool     `json:"as_blob"`
+	// DataSize is the number of data bytes posted by the tx, including the version bytes.
+	DataSize int               `json:"data_size"`
+	Frames   []dryRunFrame     `json:"frames"`
+	Channels []dryRunTxChannel `json:"channels"`
+	// EstimatedGas is the intrinsic gas of the tx, and BlobGas the blob gas of its blobs.
+	EstimatedGas   uint64      `json:"estimated_gas"`
+	BlobGas        uint64      `json:"blob_gas,omitempty"`
+	InclusionBlock eth.BlockID `json:"inclusion_block"`
+}
+
+// dryRunFrame identifies a frame carried by a batcher tx of a dry-run.
+type dryRunFrame struct {
+	ChannelID   derive.ChannelID `json:"channel_id"`
+	FrameNumber uint16           `json:"frame_number"`
+}
+
+// dryRunTxChannel is a channel of which a batcher tx of a dry-run carries frames, with the range
+// of L2 blocks it covers.
+type dryRunTxChannel struct {
+	ID         derive.ChannelID `json:"id"`
+	FirstBlock eth.BlockID      `json:"first_block"`
+	LastBlock  eth.BlockID      `json:"last_block"`
+}
+
+// dryRunWriter writes the batcher txs of a dry-run to the dry-run dir, instead of sending them to L1.
+// The txs are numbered in the order they are written, which is the order in which they would have been sent.
+type dryRunWriter struct {
+	dir string
+	// l1 is the L1 chain whose head includes the txs, normally a dryRunL1
+	l1 L1Client
+
+	mu  sync.Mutex
+	seq int
+}
+
+func newDryRunWriter(dir string, l1 L1Client) *dryRunWriter {
+	return &dryRunWriter{dir: dir, l1: l1}
+}
+
+// Write writes the data of the tx, and its JSON sidecar with the given channels and intrinsic gas.
+// It returns a receipt of the tx as if it was included in the L1 head block.
+func (w *dryRunWriter) Write(ctx context.Context, txdata txData, channels []dryRunTxChannel, intrinsicGas uint64) (*types.Receipt, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	head, err := w.l1.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting dry-run L1 head: %w", err)
+	}
+	if err := os.MkdirAll(w.dir, 0755); err != nil {
+		return nil, fmt.Errorf("create dry-run dir (%v): %w", w.dir, err)
+	}
+
+	name := fmt.Sprintf("tx-%06d", w.seq)
+	tx := dryRunTx{
+		AsBlob:         txdata.asBlob,
+		DataSize:       txdata.Len(),
+		Channels:       channels,
+		EstimatedGas:   intrinsicGas,
+		InclusionBlock: eth.BlockID{Hash: head.Hash(), Number: head.Number.Uint64()},
+	}
+	for _, f := range txdata.frames {
+		tx.Frames = append(tx.Frames, dryRunFrame{ChannelID: f.id.chID, FrameNumber: f.id.frameNumber})
+	}
+	receipt := &types.Receipt{
+		Type:        types.DynamicFeeTxType,
+		Status:      types.ReceiptStatusSuccessful,
+		GasUsed:     intrinsicGas,
+		BlockHash:   head.Hash(),
+		BlockNumber: head.Number,
+	}
+	if txdata.asBlob {
+		blobs, err := txdata.Blobs()
+		if err != nil {
+			return nil, err
+		}
+		for i, blob := range blobs {
+			file := fmt.Sprintf("%s-blob-%d.bin", name, i)
+			if err := w.writeFile(file, blob[:]); err != nil {
+				return nil, err
+			}
+			tx.Files = append(tx.Files, file)
+		}
+		tx.BlobGas = uint64(len(blobs)) * params.BlobTxBlobGasPerBlob
+		receipt.Type = types.BlobTxType
+		receipt.BlobGasUsed = tx.BlobGas
+	} else {
+		file := name + ".bin"
+		if err := w.writeFile(file, txdata.CallData()); err != nil {
+			return nil, err
+		}
+		tx.Files = append(tx.Files, file)
+	}
+
+	sidecar, err := json.MarshalIndent(&tx, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal dry-run tx sidecar: %w", err)
+	}
+	if err := w.writeFile(name+".json", sidecar); err != nil {
+		return nil, err
+	}
+	// the tx hash identifies the written data, there is no signed tx
+	receipt.TxHash = crypto.Keccak256Hash(sidecar)
+	w.seq++
+	return receipt, nil
+}
+
+func (w *dryRunWriter) writeFile(name string, data []byte) error {
+	file := filepath.Join(w.dir, name)
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("write dry-run file (%v): %w", file, err)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package batcher
+
+import (
+	"compress/zlib"
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+func TestDryRunL1(t *testing.T) {
+	var head atomic.Uint64
+	head.Store(10)
+	l1 := newDryRunL1(func(ctx context.Context) (uint64, error) { return head.Load(), nil })
+	ctx := context.Background()
+
+	latest, err := l1.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), latest.Number.Uint64())
+	h, err := l1.HeaderByNumber(ctx, big.NewInt(10))
+	require.NoError(t, err)
+	require.Equal(t, latest.Hash(), h.Hash(), "a fixed hash per number")
+	_, err = l1.HeaderByNumber(ctx, big.NewInt(11))
+	require.Error(t, err, "beyond the head")
+
+	head.Store(11)
+	h, err = l1.HeaderByNumber(ctx, big.NewInt(10))
+	require.NoError(t, err)
+	require.Equal(t, latest.Hash(), h.Hash(), "never reorged")
+	block, err := l1.BlockByNumber(ctx, big.NewInt(11))
+	require.NoError(t, err)
+	require.Empty(t, block.Transactions())
+}
+
+// TestBatchSubmitterDryRun runs the batcher in dry-run, and derives the batches of the L2 blocks
+// from the written txs.
+func TestBatchSubmitterDryRun(t *testing.T) {
+	for _, useBlobs := range []bool{false, true} {
+		useBlobs := useBlobs
+		name := "calldata"
+		if useBlobs {
+			name = "blobs"
+		}
+		t.Run(name, func(t *testing.T) {
+			testBatchSubmitterDryRun(t, useBlobs)
+		})
+	}
+}
+
+func testBatchSubmitterDryRun(t *testing.T, useBlobs bool) {
+	dir := t.TempDir()
+	var head atomic.Uint64
+	head.Store(5)
+	l1 := newDryRunL1(func(ctx context.Context) (uint64, error) { return head.Load(), nil })
+	l := NewBatchSubmitter(DriverSetup{
+		Log:          testlog.Logger(t, log.LvlCrit),
+		Metr:         metrics.NoopMetrics,
+		RollupConfig: &defaultTestRollupConfig,
+		Config:       BatcherConfig{NetworkTimeout: time.Second, DryRunDir: dir},
+		L1Client:     l1,
+		ChannelConfig: ChannelConfig{
+			MaxFrameSize:    40,
+			ChannelTimeout:  100,
+			SubSafetyMargin: 10,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  1,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
+			},
+			BatchType:      derive.SingularBatchType,
+			UseBlobs:       useBlobs,
+			MaxFramesPerTx: 2,
+		},
+	})
+	l.killCtx = context.Background()
+
+	var blocks []*types.Block
+	parent := common.Hash{}
+	for i := 0; i < 3; i++ {
+		block := newMiniL2BlockWithNumberParent(2, big.NewInt(int64(i)), parent)
+		require.NoError(t, l.state.AddL2Block(block))
+		blocks = append(blocks, block)
+		parent = block.Hash()
+	}
+	queue := txmgr.NewQueue[txData](context.Background(), nil, 0)
+	l.publishStateToL1(queue, make(chan txmgr.TxReceipt[txData]), true)
+	require.Empty(t, l.state.UnsubmittedBlocks(), "the written txs are confirmed")
+
+	// the txs stay included as the L1 head advances
+	head.Store(6)
+	l.checkL1Reorgs(context.Background())
+	require.Empty(t, l.state.UnsubmittedBlocks())
+
+	// derive the batches from the written txs, in order
+	sidecars, err := filepath.Glob(filepath.Join(dir, "tx-*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, sidecars)
+	inclusion, err := l1.HeaderByNumber(context.Background(), big.NewInt(5))
+	require.NoError(t, err)
+	l1Ref := eth.L1BlockRef{Number: 5}
+	channels := make(map[derive.ChannelID]*derive.Channel)
+	var derived []*derive.SingularBatch
+	var numFrames int
+	for _, sidecar := range sidecars {
+		data, err := os.ReadFile(sidecar)
+		require.NoError(t, err)
+		var tx dryRunTx
+		require.NoError(t, json.Unmarshal(data, &tx))
+		require.Equal(t, useBlobs, tx.AsBlob)
+		require.Equal(t, eth.BlockID{Hash: inclusion.Hash(), Number: 5}, tx.InclusionBlock)
+		require.NotZero(t, tx.EstimatedGas)
+		require.Len(t, tx.Channels, 1, "a channel per tx")
+
+		var frames []derive.Frame
+		for _, file := range tx.Files {
+			data, err := os.ReadFile(filepath.Join(dir, file))
+			require.NoError(t, err)
+			if useBlobs {
+				var blob eth.Blob
+				copy(blob[:], data)
+				data, err = blob.ToData()
+				require.NoError(t, err)
+			}
+			fs, err := derive.ParseFrames(data)
+			require.NoError(t, err)
+			frames = append(frames, fs...)
+		}
+		require.Len(t, frames, len(tx.Frames))
+		numFrames += len(frames)
+		for i, frame := range frames {
+			require.Equal(t, dryRunFrame{ChannelID: frame.ID, FrameNumber: frame.FrameNumber}, tx.Frames[i])
+			require.Equal(t, tx.Channels[0].ID, frame.ID)
+			ch, ok := channels[frame.ID]
+			if !ok {
+				ch = derive.NewChannel(frame.ID, l1Ref)
+				channels[frame.ID] = ch
+			}
+			require.NoError(t, ch.AddFrame(frame, l1Ref))
+			if !ch.IsReady() {
+				continue
+			}
+			nextBatch, err := derive.BatchReader(ch.Reader())
+			require.NoError(t, err)
+			for {
+				batchData, err := nextBatch()
+				if err != nil {
+					break
+				}
+				batch, err := derive.GetSingularBatch(batchData)
+				require.NoError(t, err)
+				require.Equal(t, blocks[len(derived)].NumberU64(), tx.Channels[0].FirstBlock.Number, "covered block range")
+				derived = append(derived, batch)
+			}
+		}
+	}
+	require.Greater(t, numFrames, len(blocks), "multiple frames per channel")
+	require.Len(t, derived, len(blocks))
+	for i, block := range blocks {
+		require.Equal(t, block.ParentHash(), derived[i].ParentHash)
+		require.Equal(t, block.Time(), derived[i].Timestamp)
+		require.Len(t, derived[i].Transactions, 2, "without the deposit")
+	}
+}
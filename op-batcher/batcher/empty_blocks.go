@@ -0,0 +1,48 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+// L1HeaderSource fetches L1 headers by number. It returns ethereum.NotFound for unknown blocks.
+type L1HeaderSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// EmptyBlockRegenerable returns whether the derivation pipeline regenerates the L2 block by itself,
+// so that it does not need to be submitted. Once the sequencing window of an epoch expires, the
+// pipeline fills the gap to the next submitted block with empty batches: the first block of an
+// epoch, and then blocks of the same epoch up to the time of the next L1 origin. A block matches
+// such an empty batch if it only contains the L1 info deposit, and either is the first block of its
+// epoch, or is older than the next L1 origin. If the next L1 origin is unknown yet, the block is
+// submitted.
+//
+// Skipping blocks requires span batches, so blocks before the Delta upgrade are never regenerable.
+func EmptyBlockRegenerable(ctx context.Context, rcfg *rollup.Config, l1 L1HeaderSource, block *types.Block) (bool, error) {
+	if !rcfg.IsDelta(block.Time()) || len(block.Transactions()) != 1 {
+		return false, nil
+	}
+	l1info, err := derive.L1InfoDepositTxData(block.Transactions()[0].Data())
+	if err != nil {
+		return false, fmt.Errorf("could not parse L1 info deposit of block %d: %w", block.NumberU64(), err)
+	}
+	if l1info.SequenceNumber == 0 {
+		return true, nil
+	}
+	next, err := l1.HeaderByNumber(ctx, new(big.Int).SetUint64(l1info.Number+1))
+	if errors.Is(err, ethereum.NotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("could not fetch L1 block %d after the origin of block %d: %w", l1info.Number+1, block.NumberU64(), err)
+	}
+	return block.Time() < next.Time, nil
+}
@@ -0,0 +1,58 @@
+package batcher
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestEmptyBlockRegenerable(t *testing.T) {
+	deltaTime := uint64(20)
+	rcfg := &rollup.Config{BlockTime: 2, DeltaTime: &deltaTime}
+	// L1 blocks 0 and 1, at times 0 and 24
+	l1 := &fakeL1{}
+	for i := 0; i < 2; i++ {
+		l1.headers = append(l1.headers, &types.Header{Number: big.NewInt(int64(i)), Time: uint64(i) * 24, BaseFee: big.NewInt(1)})
+	}
+	// block returns an L2 block at the time, of the epoch of the L1 block, with numTxs txs after the L1 info deposit
+	block := func(l1Num uint64, seqNum uint64, l2Time uint64, numTxs int) *types.Block {
+		l1Header := &types.Header{Number: new(big.Int).SetUint64(l1Num), Time: l1Num * 24, BaseFee: big.NewInt(1)}
+		infoTx, err := derive.L1InfoDeposit(seqNum, eth.HeaderBlockInfo(l1Header), eth.SystemConfig{}, true)
+		require.NoError(t, err)
+		txs := []*types.Transaction{types.NewTx(infoTx)}
+		for i := 0; i < numTxs; i++ {
+			txs = append(txs, types.NewTx(&types.DynamicFeeTx{}))
+		}
+		return types.NewBlock(&types.Header{Number: big.NewInt(1), Time: l2Time}, txs, nil, nil, trie.NewStackTrie(nil))
+	}
+
+	tests := []struct {
+		name        string
+		block       *types.Block
+		regenerable bool
+	}{
+		{"before Delta", block(0, 3, 18, 0), false},
+		{"older than the next origin", block(0, 11, 22, 0), true},
+		{"as old as the next origin", block(0, 12, 24, 0), false},
+		{"with txs", block(0, 11, 22, 1), false},
+		{"first of epoch", block(1, 0, 24, 0), true},
+		{"first of epoch with txs", block(1, 0, 24, 1), false},
+		{"next origin unknown", block(1, 1, 26, 0), false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			regenerable, err := EmptyBlockRegenerable(context.Background(), rcfg, l1, test.block)
+			require.NoError(t, err)
+			require.Equal(t, test.regenerable, regenerable)
+		})
+	}
+}
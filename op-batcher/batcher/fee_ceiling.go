@@ -0,0 +1,131 @@
+package batcher
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+)
+
+// FeeCeilingConfig configures the pausing of batch submission while the L1 fees are high.
+type FeeCeilingConfig struct {
+	// MaxBaseFee is the L1 base fee (in wei) above which batch submission pauses.
+	// If nil, the base fee is not limited.
+	MaxBaseFee *big.Int
+	// MaxBlobBaseFee is the L1 blob base fee (in wei) above which batch submission pauses.
+	// If nil, the blob base fee is not limited.
+	MaxBlobBaseFee *big.Int
+	// ResumePercent is the percentage of the fee ceilings that all fees must fall below,
+	// for a paused batch submission to resume.
+	ResumePercent uint64
+	// MaxDelay is the maximum duration that batch submission is paused for.
+	MaxDelay time.Duration
+}
+
+// Enabled returns whether any fee ceiling is set.
+func (c *FeeCeilingConfig) Enabled() bool {
+	return c.MaxBaseFee != nil || c.MaxBlobBaseFee != nil
+}
+
+// feeCeiling pauses batch submission when the L1 fees exceed the configured ceilings.
+// Blocks keep being loaded into the channel manager while paused.
+type feeCeiling struct {
+	cfg  FeeCeilingConfig
+	log  log.Logger
+	metr metrics.Metricer
+	now  func() time.Time
+
+	// pausedSince is the time at which submission got paused, zero if submission is active.
+	pausedSince time.Time
+}
+
+func newFeeCeiling(cfg FeeCeilingConfig, log log.Logger, metr metrics.Metricer) *feeCeiling {
+	return &feeCeiling{
+		cfg:  cfg,
+		log:  log,
+		metr: metr,
+		now:  time.Now,
+	}
+}
+
+// Reset ends a pause, e.g. when the batcher is restarted.
+func (f *feeCeiling) Reset() {
+	f.pausedSince = time.Time{}
+}
+
+// ShouldSubmit returns whether batch data should be submitted at the given L1 head, and if the submission
+// is forced, in which case all loaded blocks should be flushed.
+//
+// Submission pauses once a fee of the L1 head is above its ceiling, and resumes once all fees fell below
+// ResumePercent of their ceilings. A pause is forced to end once it lasted MaxDelay, or once the L1 head
+// reached the given submission deadline, so that the channel timeout and sequencing window are never
+// violated. The pause starts again at the next L1 head with fees above the ceiling.
+// A deadline of 0 means that there is no data to submit.
+func (f *feeCeiling) ShouldSubmit(head *types.Header, deadline uint64) (submit bool, forced bool) {
+	if !f.cfg.Enabled() {
+		return true, false
+	}
+	baseFee, blobBaseFee := head.BaseFee, headBlobBaseFee(head)
+	now := f.now()
+	if f.pausedSince.IsZero() {
+		if !f.above(baseFee, blobBaseFee, 100) {
+			return true, false
+		}
+		f.pausedSince = now
+		f.log.Warn("Pausing batch submission, L1 fees are above the fee ceiling",
+			"l1_head", head.Number, "base_fee", baseFee, "max_base_fee", f.cfg.MaxBaseFee,
+			"blob_base_fee", blobBaseFee, "max_blob_base_fee", f.cfg.MaxBlobBaseFee)
+	}
+
+	pausedFor := now.Sub(f.pausedSince)
+	switch {
+	case !f.above(baseFee, blobBaseFee, f.cfg.ResumePercent):
+		f.log.Info("Resuming batch submission, L1 fees fell below the resume threshold",
+			"l1_head", head.Number, "base_fee", baseFee, "blob_base_fee", blobBaseFee, "paused_for", pausedFor)
+		submit = true
+	case pausedFor >= f.cfg.MaxDelay:
+		f.log.Warn("Forcing batch submission, paused for the max delay despite high L1 fees",
+			"l1_head", head.Number, "base_fee", baseFee, "blob_base_fee", blobBaseFee, "paused_for", pausedFor)
+		submit, forced = true, true
+	case deadline != 0 && head.Number.Uint64() >= deadline:
+		f.log.Warn("Forcing batch submission, reached the channel timeout or sequencing window despite high L1 fees",
+			"l1_head", head.Number, "deadline", deadline, "base_fee", baseFee, "blob_base_fee", blobBaseFee, "paused_for", pausedFor)
+		submit, forced = true, true
+	default:
+		f.log.Debug("Batch submission paused, L1 fees are high",
+			"l1_head", head.Number, "base_fee", baseFee, "blob_base_fee", blobBaseFee, "paused_for", pausedFor)
+		f.metr.RecordSubmissionPaused(true, pausedFor)
+		return false, false
+	}
+	f.pausedSince = time.Time{}
+	f.metr.RecordSubmissionPaused(false, 0)
+	return submit, forced
+}
+
+// above returns whether any of the fees is above the given percentage of its ceiling.
+func (f *feeCeiling) above(baseFee, blobBaseFee *big.Int, percent uint64) bool {
+	return aboveCeiling(baseFee, f.cfg.MaxBaseFee, percent) || aboveCeiling(blobBaseFee, f.cfg.MaxBlobBaseFee, percent)
+}
+
+// aboveCeiling returns whether fee is above percent of ceiling. Unknown fees and unset ceilings are never above.
+func aboveCeiling(fee, ceiling *big.Int, percent uint64) bool {
+	if fee == nil || ceiling == nil {
+		return false
+	}
+	// fee > ceiling * percent / 100, without rounding
+	lhs := new(big.Int).Mul(fee, big.NewInt(100))
+	rhs := new(big.Int).Mul(ceiling, new(big.Int).SetUint64(percent))
+	return lhs.Cmp(rhs) > 0
+}
+
+// headBlobBaseFee returns the blob base fee of the given L1 block, or nil before Cancun.
+func headBlobBaseFee(head *types.Header) *big.Int {
+	if head.ExcessBlobGas == nil {
+		return nil
+	}
+	return eip4844.CalcBlobFee(*head.ExcessBlobGas)
+}
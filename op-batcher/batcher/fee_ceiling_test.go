@@ -0,0 +1,127 @@
+package batcher
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// pausedMetrics records the submission pause state.
+type pausedMetrics struct {
+	metrics.Metricer
+	paused    bool
+	pausedFor time.Duration
+}
+
+func (m *pausedMetrics) RecordSubmissionPaused(paused bool, pausedFor time.Duration) {
+	m.paused, m.pausedFor = paused, pausedFor
+}
+
+func newTestFeeCeiling(t *testing.T, cfg FeeCeilingConfig) (*feeCeiling, *pausedMetrics, *time.Time) {
+	m := &pausedMetrics{Metricer: metrics.NoopMetrics}
+	f := newFeeCeiling(cfg, testlog.Logger(t, log.LvlCrit), m)
+	now := time.Unix(1000, 0)
+	f.now = func() time.Time { return now }
+	return f, m, &now
+}
+
+func headerWithFees(number uint64, baseFee int64, excessBlobGas *uint64) *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(number), BaseFee: big.NewInt(baseFee), ExcessBlobGas: excessBlobGas}
+}
+
+func TestFeeCeilingDisabled(t *testing.T) {
+	f, _, _ := newTestFeeCeiling(t, FeeCeilingConfig{})
+	submit, forced := f.ShouldSubmit(headerWithFees(1, 1e15, nil), 0)
+	require.True(t, submit)
+	require.False(t, forced)
+}
+
+func TestFeeCeilingHysteresis(t *testing.T) {
+	f, m, now := newTestFeeCeiling(t, FeeCeilingConfig{
+		MaxBaseFee:    big.NewInt(100),
+		ResumePercent: 80,
+		MaxDelay:      time.Hour,
+	})
+
+	submit, _ := f.ShouldSubmit(headerWithFees(1, 100, nil), 0)
+	require.True(t, submit, "at the ceiling")
+
+	submit, _ = f.ShouldSubmit(headerWithFees(2, 101, nil), 0)
+	require.False(t, submit, "above the ceiling")
+	require.True(t, m.paused)
+
+	*now = now.Add(time.Minute)
+	submit, _ = f.ShouldSubmit(headerWithFees(3, 90, nil), 0)
+	require.False(t, submit, "below the ceiling, but above the resume threshold")
+	require.Equal(t, time.Minute, m.pausedFor)
+
+	submit, forced := f.ShouldSubmit(headerWithFees(4, 80, nil), 0)
+	require.True(t, submit, "at the resume threshold")
+	require.False(t, forced)
+	require.False(t, m.paused)
+	require.Zero(t, m.pausedFor)
+
+	submit, _ = f.ShouldSubmit(headerWithFees(5, 90, nil), 0)
+	require.True(t, submit, "active until above the ceiling again")
+}
+
+func TestFeeCeilingBlobBaseFee(t *testing.T) {
+	f, _, _ := newTestFeeCeiling(t, FeeCeilingConfig{
+		MaxBlobBaseFee: big.NewInt(1),
+		ResumePercent:  100,
+		MaxDelay:       time.Hour,
+	})
+	submit, _ := f.ShouldSubmit(headerWithFees(1, 1e15, nil), 0)
+	require.True(t, submit, "the base fee is not limited, and the blob base fee is unknown before Cancun")
+
+	excess := uint64(0)
+	submit, _ = f.ShouldSubmit(headerWithFees(2, 1e15, &excess), 0)
+	require.True(t, submit, "min blob base fee")
+
+	excess = 10_000_000
+	submit, _ = f.ShouldSubmit(headerWithFees(3, 1, &excess), 0)
+	require.False(t, submit, "blob base fee above the ceiling")
+}
+
+func TestFeeCeilingMaxDelay(t *testing.T) {
+	f, m, now := newTestFeeCeiling(t, FeeCeilingConfig{
+		MaxBaseFee:    big.NewInt(100),
+		ResumePercent: 90,
+		MaxDelay:      time.Hour,
+	})
+	submit, _ := f.ShouldSubmit(headerWithFees(1, 1000, nil), 0)
+	require.False(t, submit)
+
+	*now = now.Add(time.Hour - time.Second)
+	submit, _ = f.ShouldSubmit(headerWithFees(2, 1000, nil), 0)
+	require.False(t, submit)
+
+	*now = now.Add(time.Second)
+	submit, forced := f.ShouldSubmit(headerWithFees(3, 1000, nil), 0)
+	require.True(t, submit, "paused for the max delay")
+	require.True(t, forced)
+	require.False(t, m.paused)
+
+	submit, _ = f.ShouldSubmit(headerWithFees(4, 1000, nil), 0)
+	require.False(t, submit, "the pause starts again")
+}
+
+func TestFeeCeilingDeadline(t *testing.T) {
+	f, _, _ := newTestFeeCeiling(t, FeeCeilingConfig{
+		MaxBaseFee:    big.NewInt(100),
+		ResumePercent: 90,
+		MaxDelay:      time.Hour,
+	})
+	submit, _ := f.ShouldSubmit(headerWithFees(9, 1000, nil), 10)
+	require.False(t, submit, "before the deadline")
+	submit, forced := f.ShouldSubmit(headerWithFees(10, 1000, nil), 10)
+	require.True(t, submit, "at the deadline")
+	require.True(t, forced)
+}
@@ -0,0 +1,94 @@
+package batcher
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// HeadMonitorConfig configures the detection of a stalled sequencer, and of a lagging safe head.
+type HeadMonitorConfig struct {
+	// StallTimeout is the duration for which the unsafe head must not advance, for the sequencer to be
+	// considered stalled. If 0, stall detection is disabled.
+	StallTimeout time.Duration
+	// MaxSafeLag is the number of L2 blocks that the safe head may lag behind the unsafe head, before
+	// batcher submissions are considered to not land. If 0, the safe head lag is not tracked.
+	MaxSafeLag uint64
+}
+
+// headMonitor tracks the unsafe and safe heads of the rollup node, as polled by the driver loop,
+// to signal a stalled sequencer, and batcher submissions that don't land on L1.
+type headMonitor struct {
+	cfg  HeadMonitorConfig
+	log  log.Logger
+	metr metrics.Metricer
+	now  func() time.Time
+
+	// unsafe is the last seen unsafe head, and unsafeSince the time it was first seen.
+	unsafe      eth.BlockID
+	unsafeSince time.Time
+	stalled     bool
+	lagging     bool
+}
+
+func newHeadMonitor(cfg HeadMonitorConfig, log log.Logger, metr metrics.Metricer) *headMonitor {
+	return &headMonitor{
+		cfg:  cfg,
+		log:  log,
+		metr: metr,
+		now:  time.Now,
+	}
+}
+
+// Reset clears the tracked heads and signals, e.g. when the batcher is restarted.
+func (m *headMonitor) Reset() {
+	m.unsafe, m.unsafeSince = eth.BlockID{}, time.Time{}
+	if m.stalled {
+		m.stalled = false
+		m.metr.RecordSequencerStalled(false)
+	}
+	if m.lagging {
+		m.lagging = false
+		m.metr.RecordSafeHeadLagging(false)
+	}
+}
+
+// Update updates the signals with the given sync status of the rollup node.
+// The sequencer is stalled once the unsafe head didn't change for the StallTimeout, until it changes again.
+// The safe head is lagging while it is more than MaxSafeLag blocks behind the unsafe head.
+func (m *headMonitor) Update(status *eth.SyncStatus) {
+	now := m.now()
+	if unsafe := status.UnsafeL2.ID(); unsafe != m.unsafe {
+		if m.stalled {
+			m.log.Info("Sequencer unsafe head is advancing again", "unsafe", unsafe, "stalled_for", now.Sub(m.unsafeSince))
+			m.stalled = false
+			m.metr.RecordSequencerStalled(false)
+		}
+		m.unsafe, m.unsafeSince = unsafe, now
+	} else if m.cfg.StallTimeout != 0 && !m.stalled && now.Sub(m.unsafeSince) >= m.cfg.StallTimeout {
+		m.log.Warn("Sequencer unsafe head stopped advancing", "unsafe", unsafe, "stalled_for", now.Sub(m.unsafeSince))
+		m.stalled = true
+		m.metr.RecordSequencerStalled(true)
+	}
+
+	if m.cfg.MaxSafeLag == 0 {
+		return
+	}
+	var lag uint64
+	if status.UnsafeL2.Number > status.SafeL2.Number {
+		lag = status.UnsafeL2.Number - status.SafeL2.Number
+	}
+	if lagging := lag > m.cfg.MaxSafeLag; lagging != m.lagging {
+		if lagging {
+			m.log.Warn("Safe head is lagging behind the unsafe head, batcher submissions may not be landing",
+				"safe", status.SafeL2.ID(), "unsafe", status.UnsafeL2.ID(), "lag", lag, "max_lag", m.cfg.MaxSafeLag)
+		} else {
+			m.log.Info("Safe head caught up with the unsafe head", "safe", status.SafeL2.ID(), "unsafe", status.UnsafeL2.ID(), "lag", lag)
+		}
+		m.lagging = lagging
+		m.metr.RecordSafeHeadLagging(lagging)
+	}
+}
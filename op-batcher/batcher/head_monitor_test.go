@@ -0,0 +1,151 @@
+package batcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// headMetrics records the head monitor signals.
+type headMetrics struct {
+	metrics.Metricer
+	stalled, lagging bool
+}
+
+func (m *headMetrics) RecordSequencerStalled(stalled bool) {
+	m.stalled = stalled
+}
+
+func (m *headMetrics) RecordSafeHeadLagging(lagging bool) {
+	m.lagging = lagging
+}
+
+// stubRollupClient returns the sync status that is set by the test.
+type stubRollupClient struct {
+	status eth.SyncStatus
+}
+
+func (c *stubRollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	status := c.status
+	return &status, nil
+}
+
+// setHeads sets the unsafe and safe heads of the sync status.
+func (c *stubRollupClient) setHeads(unsafe, safe uint64) {
+	c.status.HeadL1 = eth.L1BlockRef{Hash: common.Hash{0x01}, Number: 100}
+	c.status.UnsafeL2 = eth.L2BlockRef{Hash: common.Hash{byte(unsafe)}, Number: unsafe}
+	c.status.SafeL2 = eth.L2BlockRef{Hash: common.Hash{byte(safe)}, Number: safe}
+}
+
+func newTestHeadMonitor(t *testing.T, cfg HeadMonitorConfig) (*headMonitor, *headMetrics, *time.Time) {
+	m := &headMetrics{Metricer: metrics.NoopMetrics}
+	h := newHeadMonitor(cfg, testlog.Logger(t, log.LvlCrit), m)
+	now := time.Unix(1000, 0)
+	h.now = func() time.Time { return now }
+	return h, m, &now
+}
+
+func TestHeadMonitorStall(t *testing.T) {
+	h, m, now := newTestHeadMonitor(t, HeadMonitorConfig{StallTimeout: time.Minute})
+	client := &stubRollupClient{}
+	update := func() {
+		status, err := client.SyncStatus(context.Background())
+		require.NoError(t, err)
+		h.Update(status)
+	}
+
+	client.setHeads(10, 5)
+	update()
+	*now = now.Add(59 * time.Second)
+	update()
+	require.False(t, m.stalled, "not stalled before the timeout")
+
+	*now = now.Add(time.Second)
+	update()
+	require.True(t, m.stalled, "stalled at the timeout")
+
+	client.setHeads(11, 5)
+	*now = now.Add(time.Hour)
+	update()
+	require.False(t, m.stalled, "recovered once the unsafe head advanced")
+
+	*now = now.Add(time.Minute)
+	update()
+	require.True(t, m.stalled, "stalled again at the new head")
+
+	h.Reset()
+	require.False(t, m.stalled, "cleared by a reset")
+	update()
+	require.False(t, m.stalled, "the timeout starts again after a reset")
+}
+
+func TestHeadMonitorStallDisabled(t *testing.T) {
+	h, m, now := newTestHeadMonitor(t, HeadMonitorConfig{})
+	client := &stubRollupClient{}
+	client.setHeads(10, 0)
+	status, _ := client.SyncStatus(context.Background())
+	h.Update(status)
+	*now = now.Add(time.Hour)
+	h.Update(status)
+	require.False(t, m.stalled)
+	require.False(t, m.lagging)
+}
+
+func TestHeadMonitorSafeLag(t *testing.T) {
+	h, m, _ := newTestHeadMonitor(t, HeadMonitorConfig{MaxSafeLag: 10})
+	client := &stubRollupClient{}
+	update := func(unsafe, safe uint64) {
+		client.setHeads(unsafe, safe)
+		status, err := client.SyncStatus(context.Background())
+		require.NoError(t, err)
+		h.Update(status)
+	}
+
+	update(20, 10)
+	require.False(t, m.lagging, "lag at the limit")
+	update(21, 10)
+	require.True(t, m.lagging, "lag above the limit")
+	update(22, 20)
+	require.False(t, m.lagging, "safe head caught up")
+	update(5, 10)
+	require.False(t, m.lagging, "safe head ahead of the unsafe head")
+}
+
+func TestBatchSubmitterSyncStatusUpdatesHeadMonitor(t *testing.T) {
+	l := newReorgTestBatchSubmitter(t, &fakeL1{}, BatcherConfig{
+		HeadMonitor: HeadMonitorConfig{StallTimeout: time.Minute, MaxSafeLag: 10},
+	})
+	m := &headMetrics{Metricer: metrics.NoopMetrics}
+	l.headMonitor.metr = m
+	now := time.Unix(1000, 0)
+	l.headMonitor.now = func() time.Time { return now }
+
+	client := &stubRollupClient{}
+	_, err := l.syncStatus(context.Background(), client)
+	require.ErrorContains(t, err, "empty sync status")
+
+	client.setHeads(30, 10)
+	status, err := l.syncStatus(context.Background(), client)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), status.UnsafeL2.Number)
+	require.True(t, m.lagging)
+
+	now = now.Add(time.Minute)
+	_, err = l.syncStatus(context.Background(), client)
+	require.NoError(t, err)
+	require.True(t, m.stalled)
+
+	client.setHeads(31, 25)
+	_, err = l.syncStatus(context.Background(), client)
+	require.NoError(t, err)
+	require.False(t, m.stalled)
+	require.False(t, m.lagging)
+}
@@ -0,0 +1,152 @@
+package batcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// persistedStateVersion is the version of the state file format.
+const persistedStateVersion = 1
+
+// persistedState is the submission state of the batcher, which is persisted to the state file,
+// so that a restarted batcher can resume after the data that already landed on L1.
+type persistedState struct {
+	Version int `json:"version"`
+	// SubmittedBlock is the last L2 block of the last channel of which all inclusion blocks are final.
+	SubmittedBlock eth.BlockID `json:"submitted_block"`
+	// SubmittedInclusion is the last L1 inclusion block of the data up to SubmittedBlock.
+	SubmittedInclusion eth.BlockID `json:"submitted_inclusion"`
+	// Channels are the channels that are pending or not final yet, in order.
+	Channels []persistedChannel `json:"channels"`
+}
+
+// persistedChannel is the metadata of a channel that is pending or not final yet.
+type persistedChannel struct {
+	ID         derive.ChannelID `json:"id"`
+	FirstBlock eth.BlockID      `json:"first_block"`
+	LastBlock  eth.BlockID      `json:"last_block"`
+	// Closed is set once the channel is full, so that all its frames are emitted.
+	Closed          bool          `json:"closed"`
+	FramesEmitted   int           `json:"frames_emitted"`
+	FramesConfirmed int           `json:"frames_confirmed"`
+	InclusionBlocks []eth.BlockID `json:"inclusion_blocks"`
+	PendingTxs      []persistedTx `json:"pending_txs"`
+}
+
+// persistedTx is a batcher tx that was sent, but not confirmed yet.
+//
+// The tx hash changes when the tx manager bumps the fees, so the tx is identified by the hashes
+// of its data instead: the hash of the calldata, or the versioned hashes of the blobs.
+type persistedTx struct {
+	FirstFrame uint16        `json:"first_frame"`
+	Frames     int           `json:"frames"`
+	SentAt     uint64        `json:"sent_at"`
+	DataHashes []common.Hash `json:"data_hashes"`
+}
+
+// sentTx is a pending tx, as tracked by the channel manager for the persisted state.
+type sentTx struct {
+	// l1Head is the number of the L1 head at which the tx was sent
+	l1Head uint64
+	// txdata is the data of the tx, which may carry the frames of multiple channels
+	txdata txData
+	// dataHashes are computed lazily when the state is persisted
+	dataHashes []common.Hash
+}
+
+// txDataHashes returns the hashes that identify the data of the given tx on L1.
+func txDataHashes(td txData) ([]common.Hash, error) {
+	if !td.asBlob {
+		return []common.Hash{crypto.Keccak256Hash(td.CallData())}, nil
+	}
+	blobs, err := td.Blobs()
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]common.Hash, 0, len(blobs))
+	for _, blob := range blobs {
+		commitment, err := blob.ComputeKZGCommitment()
+		if err != nil {
+			return nil, fmt.Errorf("computing KZG commitment: %w", err)
+		}
+		hashes = append(hashes, eth.KZGToVersionedHash(commitment))
+	}
+	return hashes, nil
+}
+
+// statePersistence writes the persisted state to the state file, and reads it back after a restart.
+type statePersistence struct {
+	file string
+	// last is the last written content, to skip writing an unchanged state
+	last []byte
+}
+
+func newStatePersistence(file string) *statePersistence {
+	return &statePersistence{file: file}
+}
+
+// Write writes the state to the file, unless it is unchanged since the last write.
+// It initially writes to a temp file, which is synced and renamed into place,
+// so that the state file isn't corrupted if IO errors occur during writing.
+func (p *statePersistence) Write(state *persistedState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal batcher state: %w", err)
+	}
+	if bytes.Equal(data, p.last) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(p.file), 0755); err != nil {
+		return fmt.Errorf("create state dir (%v): %w", p.file, err)
+	}
+	tmpFile := p.file + ".tmp"
+	file, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("open file (%v) for writing: %w", tmpFile, err)
+	}
+	defer file.Close() // Ensure file is closed even if write or sync fails
+	if _, err = file.Write(data); err != nil {
+		return fmt.Errorf("write batcher state to temp file (%v): %w", tmpFile, err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("sync batcher state temp file (%v): %w", tmpFile, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close batcher state temp file (%v): %w", tmpFile, err)
+	}
+	if err := os.Rename(tmpFile, p.file); err != nil {
+		return fmt.Errorf("rename temp state file to final destination: %w", err)
+	}
+	p.last = data
+	return nil
+}
+
+// Read reads the state from the file. It returns nil if there is no state file.
+func (p *statePersistence) Read() (*persistedState, error) {
+	data, err := os.ReadFile(p.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read state file (%v): %w", p.file, err)
+	}
+	var state persistedState
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&state); err != nil {
+		return nil, fmt.Errorf("invalid state file (%v): %w", p.file, err)
+	}
+	if state.Version != persistedStateVersion {
+		return nil, fmt.Errorf("unsupported state file (%v) version %d", p.file, state.Version)
+	}
+	return &state, nil
+}
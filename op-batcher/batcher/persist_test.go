@@ -0,0 +1,264 @@
+package batcher
+
+import (
+	"compress/zlib"
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// fakeL2 is a L2 chain of blocks by number.
+type fakeL2 map[uint64]*types.Block
+
+func (f fakeL2) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	if b, ok := f[number.Uint64()]; ok {
+		return b, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+// restartTest runs a batcher that persists its state, and restarts it.
+type restartTest struct {
+	t       *testing.T
+	l1      *fakeL1
+	l2      fakeL2
+	rcfg    *rollup.Config
+	file    string
+	from    common.Address
+	signer  types.Signer
+	batcher *BatchSubmitter
+	safe    eth.L2BlockRef
+	// sendTx signs a batcher tx with the given tx data
+	sendTx func(td txData) *types.Transaction
+}
+
+func newRestartTest(t *testing.T) *restartTest {
+	rcfg := defaultTestRollupConfig // copy
+	rcfg.L1ChainID = big.NewInt(900)
+	rcfg.BatchInboxAddress = common.Address{0xff, 0x01}
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := types.LatestSignerForChainID(rcfg.L1ChainID)
+	nonce := uint64(0)
+
+	rt := &restartTest{
+		t:      t,
+		l1:     &fakeL1{},
+		l2:     make(fakeL2),
+		rcfg:   &rcfg,
+		file:   filepath.Join(t.TempDir(), "batcher_state.json"),
+		from:   crypto.PubkeyToAddress(key.PublicKey),
+		signer: signer,
+	}
+	rt.sendTx = func(td txData) *types.Transaction {
+		tx := types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID: rcfg.L1ChainID,
+			Nonce:   nonce,
+			To:      &rcfg.BatchInboxAddress,
+			Gas:     100_000,
+			Data:    td.CallData(),
+		})
+		nonce++
+		return tx
+	}
+	for i := 0; i < 5; i++ {
+		rt.l1.extend(0)
+	}
+	var parent common.Hash
+	for i := uint64(0); i <= 3; i++ {
+		b := newMiniL2BlockWithNumberParent(0, new(big.Int).SetUint64(i), parent)
+		rt.l2[i] = b
+		parent = b.Hash()
+	}
+	rt.safe = eth.L2BlockRef{Hash: rt.l2[0].Hash(), Number: 0}
+	rt.restart()
+	return rt
+}
+
+// restart creates a new batcher with the state file, which reconciles the persisted state.
+func (rt *restartTest) restart() {
+	rt.batcher = NewBatchSubmitter(DriverSetup{
+		Log:          testlog.Logger(rt.t, log.LvlCrit),
+		Metr:         metrics.NoopMetrics,
+		RollupConfig: rt.rcfg,
+		Config:       BatcherConfig{NetworkTimeout: time.Second, StateFile: rt.file},
+		L1Client:     rt.l1,
+		ChannelConfig: ChannelConfig{
+			MaxFrameSize:   120_000,
+			ChannelTimeout: 10,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  1,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+				Level:            zlib.BestCompression,
+			},
+			BatchType: derive.SingularBatchType,
+		},
+	})
+	state, err := rt.batcher.persistence.Read()
+	require.NoError(rt.t, err)
+	if state == nil {
+		return
+	}
+	r := newStateReconciler(rt.batcher.Log, rt.l1, rt.l2, rt.signer, rt.from, rt.rcfg.BatchInboxAddress, 10, time.Second)
+	rt.batcher.resumeAt(r.Reconcile(context.Background(), state, rt.safe))
+}
+
+// submitBlock loads the given L2 block, and returns the tx data of its single frame channel.
+func (rt *restartTest) submitBlock(number uint64) txData {
+	require.NoError(rt.t, rt.batcher.state.AddL2Block(rt.l2[number]))
+	txdata, err := rt.batcher.state.TxData(rt.l1.tip().ID())
+	require.NoError(rt.t, err)
+	return txdata
+}
+
+func TestBatchSubmitterRestart(t *testing.T) {
+	t.Run("confirmed", func(t *testing.T) {
+		rt := newRestartTest(t)
+		txdata := rt.submitBlock(1)
+		inclusion := rt.l1.extendWithTxs(0, rt.sendTx(txdata))
+		rt.batcher.state.TxConfirmed(txdata.ID(), inclusion)
+		rt.batcher.persistState()
+
+		rt.restart()
+		require.Equal(t, eth.ToBlockID(rt.l2[1]), rt.batcher.lastStoredBlock, "resumes after the submitted block")
+		require.Equal(t, []eth.BlockID{inclusion}, rt.batcher.resumedInclusions)
+
+		// the state of the restarted batcher is persisted, without losing the submitted block
+		rt.batcher.persistState()
+		rt.restart()
+		require.Equal(t, eth.ToBlockID(rt.l2[1]), rt.batcher.lastStoredBlock)
+
+		rt.safe = eth.L2BlockRef{Hash: rt.l2[1].Hash(), Number: 1}
+		rt.restart()
+		require.Zero(t, rt.batcher.lastStoredBlock, "starts at the safe head, which caught up")
+	})
+
+	t.Run("unconfirmed", func(t *testing.T) {
+		rt := newRestartTest(t)
+		confirmed := rt.submitBlock(1)
+		rt.batcher.state.TxConfirmed(confirmed.ID(), rt.l1.extendWithTxs(0, rt.sendTx(confirmed)))
+		pending := rt.submitBlock(2)
+		pendingTx := rt.sendTx(pending)
+		rt.batcher.persistState()
+
+		// the pending tx did not land
+		rt.l1.extend(0)
+		rt.restart()
+		require.Equal(t, eth.ToBlockID(rt.l2[1]), rt.batcher.lastStoredBlock, "the block of the pending tx is submitted again")
+
+		// the pending tx landed before the restart
+		inclusion := rt.l1.extendWithTxs(0, pendingTx)
+		rt.restart()
+		require.Equal(t, eth.ToBlockID(rt.l2[2]), rt.batcher.lastStoredBlock, "resumes after the block of the landed tx")
+		require.Contains(t, rt.batcher.resumedInclusions, inclusion)
+	})
+
+	t.Run("unconfirmed foreign tx", func(t *testing.T) {
+		rt := newRestartTest(t)
+		pending := rt.submitBlock(1)
+		rt.batcher.persistState()
+
+		// the same data, sent by another account, does not count
+		foreign := newRestartTest(t)
+		rt.l1.extendWithTxs(0, foreign.sendTx(pending))
+		rt.restart()
+		require.Zero(t, rt.batcher.lastStoredBlock)
+	})
+
+	t.Run("reorged", func(t *testing.T) {
+		rt := newRestartTest(t)
+		txdata := rt.submitBlock(1)
+		inclusion := rt.l1.extendWithTxs(0, rt.sendTx(txdata))
+		rt.batcher.state.TxConfirmed(txdata.ID(), inclusion)
+		rt.batcher.persistState()
+
+		// the batch is reorged out of L1 while the batcher is down
+		rt.l1.reorg(inclusion.Number - 1)
+		rt.restart()
+		require.Zero(t, rt.batcher.lastStoredBlock, "starts at the safe head")
+	})
+
+	t.Run("reorged after restart", func(t *testing.T) {
+		rt := newRestartTest(t)
+		txdata := rt.submitBlock(1)
+		inclusion := rt.l1.extendWithTxs(0, rt.sendTx(txdata))
+		rt.batcher.state.TxConfirmed(txdata.ID(), inclusion)
+		rt.batcher.persistState()
+		rt.restart()
+		require.Equal(t, eth.ToBlockID(rt.l2[1]), rt.batcher.lastStoredBlock)
+		require.NoError(t, rt.batcher.state.AddL2Block(rt.l2[2]))
+
+		// the batch is reorged out of L1 after the restart
+		rt.l1.reorg(inclusion.Number - 1)
+		rt.l1.extend(0)
+		rt.batcher.recordL1Tip(rt.l1.tip())
+		rt.batcher.checkL1Reorgs(context.Background())
+		require.Zero(t, rt.batcher.lastStoredBlock, "starts again at the safe head")
+		require.Empty(t, rt.batcher.state.UnsubmittedBlocks())
+	})
+
+	t.Run("missing or corrupt state", func(t *testing.T) {
+		rt := newRestartTest(t)
+		rt.batcher.resumeFromState(context.Background())
+		require.Zero(t, rt.batcher.lastStoredBlock, "no state file")
+
+		require.NoError(t, os.WriteFile(rt.file, []byte(`{"version":1,"submitted_block":`), 0644))
+		rt.batcher.resumeFromState(context.Background())
+		require.Zero(t, rt.batcher.lastStoredBlock, "corrupt state file")
+
+		require.NoError(t, os.WriteFile(rt.file, []byte(`{"version":2}`), 0644))
+		rt.batcher.resumeFromState(context.Background())
+		require.Zero(t, rt.batcher.lastStoredBlock, "unsupported version")
+	})
+}
+
+func TestStatePersistence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "state", "batcher_state.json")
+	p := newStatePersistence(file)
+	state, err := p.Read()
+	require.NoError(t, err)
+	require.Nil(t, state)
+
+	expected := &persistedState{
+		Version:        persistedStateVersion,
+		SubmittedBlock: eth.BlockID{Hash: common.Hash{0x01}, Number: 10},
+		Channels: []persistedChannel{{
+			ID:              derive.ChannelID{0x02},
+			FirstBlock:      eth.BlockID{Hash: common.Hash{0x03}, Number: 11},
+			LastBlock:       eth.BlockID{Hash: common.Hash{0x04}, Number: 12},
+			Closed:          true,
+			FramesEmitted:   2,
+			FramesConfirmed: 1,
+			InclusionBlocks: []eth.BlockID{{Hash: common.Hash{0x05}, Number: 100}},
+			PendingTxs:      []persistedTx{{FirstFrame: 1, Frames: 1, SentAt: 101, DataHashes: []common.Hash{{0x06}}}},
+		}},
+	}
+	require.NoError(t, p.Write(expected))
+	state, err = newStatePersistence(file).Read()
+	require.NoError(t, err)
+	require.Equal(t, expected, state)
+
+	// an unchanged state is not written again
+	require.NoError(t, os.Remove(file))
+	require.NoError(t, p.Write(expected))
+	_, err = os.Stat(file)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,256 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// resumePoint is where batch submission resumes after a restart.
+type resumePoint struct {
+	// block is the last L2 block of which the data landed on L1
+	block eth.BlockID
+	// inclusion is the last L1 inclusion block of the data up to block
+	inclusion eth.BlockID
+	// inclusionBlocks are the L1 blocks with the data beyond the safe head,
+	// which are checked for L1 reorgs after resuming
+	inclusionBlocks []eth.BlockID
+}
+
+// stateReconciler reconciles the persisted state of a previous batcher run with the safe head
+// of the rollup node and with L1, to determine after which L2 block batch submission resumes.
+type stateReconciler struct {
+	log            log.Logger
+	l1             L1Client
+	l2             L2Client
+	signer         types.Signer
+	from           common.Address
+	inbox          common.Address
+	channelTimeout uint64
+	networkTimeout time.Duration
+
+	l1Head uint64
+	// scanned are the L1 blocks that were searched for batcher txs
+	scanned map[uint64]struct{}
+	// landed are the inclusion blocks of the batcher txs in the scanned L1 blocks, by data hash
+	landed map[common.Hash]eth.BlockID
+}
+
+func newStateReconciler(log log.Logger, l1 L1Client, l2 L2Client, signer types.Signer, from, inbox common.Address, channelTimeout uint64, networkTimeout time.Duration) *stateReconciler {
+	return &stateReconciler{
+		log:            log,
+		l1:             l1,
+		l2:             l2,
+		signer:         signer,
+		from:           from,
+		inbox:          inbox,
+		channelTimeout: channelTimeout,
+		networkTimeout: networkTimeout,
+		scanned:        make(map[uint64]struct{}),
+		landed:         make(map[common.Hash]eth.BlockID),
+	}
+}
+
+// Reconcile returns the resume point beyond the given safe head, up to which the data of the persisted
+// state landed on L1: the last fully submitted block, if its L1 inclusion block and the L2 block are still
+// canonical, extended by the following closed channels of which all frames landed within the channel timeout.
+// The pending txs of a channel landed if txs from the batcher to the batch inbox with the same data are
+// found on L1 after they were sent.
+// It returns false if batch submission should start at the safe head, as without a persisted state.
+func (r *stateReconciler) Reconcile(ctx context.Context, state *persistedState, safeHead eth.L2BlockRef) (resumePoint, bool) {
+	head, err := r.header(ctx, nil)
+	if err != nil {
+		r.log.Warn("Failed to get the L1 head to reconcile the batcher state", "err", err)
+		return resumePoint{}, false
+	}
+	r.l1Head = head.Number.Uint64()
+
+	point := resumePoint{block: safeHead.ID()}
+	if state.SubmittedBlock.Number > safeHead.Number {
+		ok, err := r.canonical(ctx, state.SubmittedBlock, state.SubmittedInclusion)
+		if err != nil {
+			r.log.Warn("Failed to check the last submitted block of the batcher state", "err", err)
+			return resumePoint{}, false
+		} else if !ok {
+			r.log.Warn("The last submitted block of the batcher state was reorged",
+				"block", state.SubmittedBlock, "inclusion_block", state.SubmittedInclusion)
+			return resumePoint{}, false
+		}
+		point = resumePoint{
+			block:           state.SubmittedBlock,
+			inclusion:       state.SubmittedInclusion,
+			inclusionBlocks: []eth.BlockID{state.SubmittedInclusion},
+		}
+	}
+
+	for _, ch := range state.Channels {
+		if ch.LastBlock.Number <= point.block.Number {
+			continue
+		}
+		if ch.FirstBlock.Number > point.block.Number+1 {
+			r.log.Warn("Channel of the batcher state does not follow the resume point", "id", ch.ID, "first_block", ch.FirstBlock, "resume", point.block)
+			break
+		}
+		inclusions, err := r.channelLanded(ctx, ch)
+		if err != nil {
+			r.log.Warn("Failed to check whether a channel of the batcher state landed", "id", ch.ID, "err", err)
+			break
+		} else if inclusions == nil {
+			r.log.Info("Channel of the batcher state did not fully land on L1", "id", ch.ID, "first_block", ch.FirstBlock, "last_block", ch.LastBlock)
+			break
+		}
+		if ok, err := r.canonicalL2(ctx, ch.LastBlock); err != nil || !ok {
+			r.log.Warn("Last block of a channel of the batcher state is not canonical", "id", ch.ID, "block", ch.LastBlock, "err", err)
+			break
+		}
+		point.block = ch.LastBlock
+		for _, b := range inclusions {
+			if b.Number >= point.inclusion.Number {
+				point.inclusion = b
+			}
+		}
+		point.inclusionBlocks = append(point.inclusionBlocks, inclusions...)
+	}
+
+	if point.block.Number <= safeHead.Number {
+		return resumePoint{}, false
+	}
+	return point, true
+}
+
+// channelLanded returns the inclusion blocks of the frames of the given channel, if the channel is closed,
+// and all its frames landed on L1 within the channel timeout. Otherwise, it returns nil.
+func (r *stateReconciler) channelLanded(ctx context.Context, ch persistedChannel) ([]eth.BlockID, error) {
+	if !ch.Closed {
+		return nil, nil
+	}
+	for _, b := range ch.InclusionBlocks {
+		if ok, err := r.canonicalL1(ctx, b); err != nil || !ok {
+			return nil, err
+		}
+	}
+	frames := ch.FramesConfirmed
+	inclusions := append([]eth.BlockID{}, ch.InclusionBlocks...)
+	for _, tx := range ch.PendingTxs {
+		b, ok, err := r.findTx(ctx, tx)
+		if err != nil || !ok {
+			return nil, err
+		}
+		frames += tx.Frames
+		inclusions = append(inclusions, b)
+	}
+	if frames != ch.FramesEmitted || len(inclusions) == 0 {
+		return nil, nil
+	}
+	min, max := inclusions[0].Number, inclusions[0].Number
+	for _, b := range inclusions {
+		if b.Number < min {
+			min = b.Number
+		}
+		if b.Number > max {
+			max = b.Number
+		}
+	}
+	if max-min >= r.channelTimeout {
+		return nil, nil
+	}
+	return inclusions, nil
+}
+
+// findTx searches the L1 blocks after the given tx was sent, up to the channel timeout, for a tx from
+// the batcher to the batch inbox with the same data. It returns the inclusion block of the tx, if found.
+func (r *stateReconciler) findTx(ctx context.Context, tx persistedTx) (eth.BlockID, bool, error) {
+	if len(tx.DataHashes) == 0 {
+		return eth.BlockID{}, false, fmt.Errorf("tx %d without data hashes", tx.FirstFrame)
+	}
+	end := tx.SentAt + r.channelTimeout
+	if end > r.l1Head {
+		end = r.l1Head
+	}
+	for n := tx.SentAt + 1; n <= end; n++ {
+		if err := r.scan(ctx, n); err != nil {
+			return eth.BlockID{}, false, err
+		}
+		if b, ok := r.landed[tx.DataHashes[0]]; ok {
+			return b, true, nil
+		}
+	}
+	return eth.BlockID{}, false, nil
+}
+
+// scan records the batcher txs of the given L1 block.
+func (r *stateReconciler) scan(ctx context.Context, number uint64) error {
+	if _, ok := r.scanned[number]; ok {
+		return nil
+	}
+	tctx, cancel := context.WithTimeout(ctx, r.networkTimeout)
+	defer cancel()
+	block, err := r.l1.BlockByNumber(tctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return fmt.Errorf("getting L1 block %d: %w", number, err)
+	}
+	id := eth.ToBlockID(block)
+	for _, tx := range block.Transactions() {
+		if to := tx.To(); to == nil || *to != r.inbox {
+			continue
+		}
+		if sender, err := types.Sender(r.signer, tx); err != nil || sender != r.from {
+			continue
+		}
+		if tx.Type() == types.BlobTxType {
+			for _, h := range tx.BlobHashes() {
+				r.landed[h] = id
+			}
+		} else {
+			r.landed[crypto.Keccak256Hash(tx.Data())] = id
+		}
+	}
+	r.scanned[number] = struct{}{}
+	return nil
+}
+
+// canonical returns whether the given L2 block and its L1 inclusion block are canonical.
+func (r *stateReconciler) canonical(ctx context.Context, l2Block eth.BlockID, inclusionBlock eth.BlockID) (bool, error) {
+	if ok, err := r.canonicalL1(ctx, inclusionBlock); err != nil || !ok {
+		return false, err
+	}
+	return r.canonicalL2(ctx, l2Block)
+}
+
+func (r *stateReconciler) canonicalL1(ctx context.Context, id eth.BlockID) (bool, error) {
+	header, err := r.header(ctx, new(big.Int).SetUint64(id.Number))
+	if errors.Is(err, ethereum.NotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return header.Hash() == id.Hash, nil
+}
+
+func (r *stateReconciler) canonicalL2(ctx context.Context, id eth.BlockID) (bool, error) {
+	tctx, cancel := context.WithTimeout(ctx, r.networkTimeout)
+	defer cancel()
+	block, err := r.l2.BlockByNumber(tctx, new(big.Int).SetUint64(id.Number))
+	if errors.Is(err, ethereum.NotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("getting L2 block %d: %w", id.Number, err)
+	}
+	return block.Hash() == id.Hash, nil
+}
+
+func (r *stateReconciler) header(ctx context.Context, number *big.Int) (*types.Header, error) {
+	tctx, cancel := context.WithTimeout(ctx, r.networkTimeout)
+	defer cancel()
+	return r.l1.HeaderByNumber(tctx, number)
+}
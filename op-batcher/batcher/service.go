@@ -5,20 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net"
 	_ "net/http/pprof"
-	"strconv"
 	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -34,6 +34,17 @@ type BatcherConfig struct {
 	NetworkTimeout         time.Duration
 	PollInterval           time.Duration
 	MaxPendingTransactions uint64
+	DrainTimeout           time.Duration
+	FeeCeiling             FeeCeilingConfig
+	StateFile              string
+	ConfirmationMode       flags.ConfirmationMode
+	ConfirmationDepth      uint64
+	HeadMonitor            HeadMonitorConfig
+	// UrgentResubmissionTimeout is the resubmission timeout of txs that carry frames of urgent channels,
+	// to bump their fees more aggressively. If 0, the resubmission timeout of the tx manager applies.
+	UrgentResubmissionTimeout time.Duration
+	// DryRunDir is the dir to write the batcher txs to, instead of sending them to L1. If empty, txs are sent.
+	DryRunDir string
 }
 
 // BatcherService represents a full batch-submitter instance and its resources,
@@ -56,9 +67,9 @@ type BatcherService struct {
 
 	Version string
 
-	pprofSrv   *httputil.HTTPServer
-	metricsSrv *httputil.HTTPServer
-	rpcServer  *oprpc.Server
+	pprofService *oppprof.Service
+	metricsSrv   *httputil.HTTPServer
+	rpcServer    *oprpc.Server
 
 	balanceMetricer io.Closer
 
@@ -87,6 +98,17 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 
 	bs.PollInterval = cfg.PollInterval
 	bs.MaxPendingTransactions = cfg.MaxPendingTransactions
+	bs.DrainTimeout = cfg.DrainTimeout
+	bs.FeeCeiling = cfg.FeeCeilingConfig()
+	bs.StateFile = cfg.StateFile
+	bs.ConfirmationMode = cfg.ConfirmationMode
+	bs.ConfirmationDepth = cfg.ConfirmationDepth
+	bs.UrgentResubmissionTimeout = cfg.UrgentResubmissionTimeout
+	bs.DryRunDir = cfg.DryRunDir
+	bs.HeadMonitor = HeadMonitorConfig{
+		StallTimeout: cfg.UnsafeHeadStallTimeout,
+		MaxSafeLag:   cfg.MaxSafeHeadLag,
+	}
 	bs.NetworkTimeout = cfg.TxMgrConfig.NetworkTimeout
 
 	if err := bs.initRPCClients(ctx, cfg); err != nil {
@@ -101,7 +123,9 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	if err := bs.initTxManager(cfg); err != nil {
 		return fmt.Errorf("failed to init Tx manager: %w", err)
 	}
-	bs.initBalanceMonitor(cfg)
+	if err := bs.initBalanceMonitor(cfg); err != nil {
+		return fmt.Errorf("failed to start balance monitor: %w", err)
+	}
 	if err := bs.initMetricsServer(cfg); err != nil {
 		return fmt.Errorf("failed to start metrics server: %w", err)
 	}
@@ -119,13 +143,18 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 }
 
 func (bs *BatcherService) initRPCClients(ctx context.Context, cfg *CLIConfig) error {
-	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, bs.Log, cfg.L1EthRpc)
-	if err != nil {
-		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	// a dry-run doesn't need a L1
+	if cfg.DryRunDir == "" {
+		l1 := cfg.Endpoints.L1()
+		l1Client, err := dial.DialEthClientWithTimeout(ctx, l1.DialTimeout, bs.Log, l1.URL)
+		if err != nil {
+			return fmt.Errorf("failed to dial L1 RPC: %w", err)
+		}
+		bs.L1Client = l1Client
 	}
-	bs.L1Client = l1Client
 
-	endpointProvider, err := dial.NewStaticL2EndpointProvider(ctx, bs.Log, cfg.L2EthRpc, cfg.RollupRpc)
+	l2, rollup := cfg.Endpoints.L2(), cfg.Endpoints.Rollup()
+	endpointProvider, err := dial.NewStaticL2EndpointProvider(ctx, l2.DialTimeout, bs.Log, l2.URL, rollup.URL)
 	if err != nil {
 		return fmt.Errorf("failed to create L2 endpoint provider: %w", err)
 	}
@@ -143,11 +172,20 @@ func (bs *BatcherService) initMetrics(cfg *CLIConfig) {
 	}
 }
 
-// initBalanceMonitor depends on Metrics, L1Client and TxManager to start background-monitoring of the batcher balance.
-func (bs *BatcherService) initBalanceMonitor(cfg *CLIConfig) {
-	if cfg.MetricsConfig.Enabled {
-		bs.balanceMetricer = bs.Metrics.StartBalanceMetrics(bs.Log, bs.L1Client, bs.TxManager.From())
+// initBalanceMonitor depends on Metrics, L1Client and TxManager to start background-monitoring of the batcher balance,
+// and of the balances of any additional configured accounts.
+func (bs *BatcherService) initBalanceMonitor(cfg *CLIConfig) error {
+	if !cfg.MetricsConfig.Enabled || cfg.DryRunDir != "" {
+		return nil
 	}
+	accounts, err := cfg.BalanceConfig.ParseAccounts()
+	if err != nil {
+		return err
+	}
+	accounts = append([]opmetrics.BalanceAccount{{Name: "batcher", Address: bs.TxManager.From()}}, accounts...)
+	bs.balanceMetricer = bs.Metrics.StartBalanceMonitor(bs.Log, bs.L1Client, accounts,
+		cfg.BalanceConfig.LowThresholdWei(), cfg.BalanceConfig.Interval)
+	return nil
 }
 
 func (bs *BatcherService) initRollupConfig(ctx context.Context) error {
@@ -168,21 +206,49 @@ func (bs *BatcherService) initRollupConfig(ctx context.Context) error {
 
 func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 	bs.ChannelConfig = ChannelConfig{
-		SeqWindowSize:      bs.RollupConfig.SeqWindowSize,
-		ChannelTimeout:     bs.RollupConfig.ChannelTimeout,
-		MaxChannelDuration: cfg.MaxChannelDuration,
-		SubSafetyMargin:    cfg.SubSafetyMargin,
-		MaxFrameSize:       cfg.MaxL1TxSize - 1, // subtract 1 byte for version
-		CompressorConfig:   cfg.CompressorConfig.Config(),
-		BatchType:          cfg.BatchType,
-	}
+		SeqWindowSize:        bs.RollupConfig.SeqWindowSize,
+		ChannelTimeout:       bs.RollupConfig.ChannelTimeout,
+		MaxChannelDuration:   cfg.MaxChannelDuration,
+		SubSafetyMargin:      cfg.SubSafetyMargin,
+		UrgencyMargin:        cfg.UrgencyMargin,
+		SplitUrgentFrames:    cfg.SplitUrgentFrames,
+		MaxFrameSize:         cfg.MaxL1TxSize - 1, // subtract 1 byte for version
+		MaxBlocksPerChannel:  cfg.MaxBlocksPerChannel,
+		MaxChannelInputBytes: cfg.MaxChannelInputBytes,
+		CompressorConfig:     cfg.CompressorConfig.Config(),
+		BatchType:            cfg.BatchType,
+		MultiFrameTxs:        cfg.MultiFrameTxs,
+		SkipEmptyBlocks:      cfg.SkipEmptyBlocks,
+		MaxTxSize:            cfg.MaxL1TxSize,
+	}
+	switch cfg.DataAvailabilityType {
+	case flags.BlobsType:
+		// size frames to the blob capacity, and channels to fill the blobs of a tx
+		bs.ChannelConfig.UseBlobs = true
+		bs.ChannelConfig.MaxFramesPerTx = cfg.MaxBlobsPerTx
+		bs.ChannelConfig.MaxFrameSize = eth.MaxBlobDataSize - 1 // subtract 1 byte for version
+		bs.ChannelConfig.CompressorConfig.TargetFrameSize = bs.ChannelConfig.MaxFrameSize
+		bs.ChannelConfig.CompressorConfig.TargetNumFrames = cfg.MaxBlobsPerTx
+	case flags.CalldataType:
+	default:
+		return fmt.Errorf("unknown data availability type: %q", cfg.DataAvailabilityType)
+	}
+	bs.Log.Info("Initialized channel config", "da_type", cfg.DataAvailabilityType, "max_frame_size", bs.ChannelConfig.MaxFrameSize, "max_frames_per_tx", bs.ChannelConfig.MaxFramesPerTx, "multi_frame_txs", bs.ChannelConfig.MultiFrameTxs, "skip_empty_blocks", bs.ChannelConfig.SkipEmptyBlocks,
+		"compressor", bs.ChannelConfig.CompressorConfig.Kind, "compression_level", bs.ChannelConfig.CompressorConfig.Level)
 	if err := bs.ChannelConfig.Check(); err != nil {
 		return fmt.Errorf("invalid channel configuration: %w", err)
 	}
+	if err := bs.ChannelConfig.CheckRollupConfig(bs.RollupConfig); err != nil {
+		return fmt.Errorf("invalid channel configuration for the rollup: %w", err)
+	}
 	return nil
 }
 
 func (bs *BatcherService) initTxManager(cfg *CLIConfig) error {
+	if cfg.DryRunDir != "" {
+		bs.Log.Warn("Dry-run, writing batcher txs to the dry-run dir instead of sending them to L1", "dir", cfg.DryRunDir)
+		return nil
+	}
 	txManager, err := txmgr.NewSimpleTxManager("batcher", bs.Log, bs.Metrics, cfg.TxMgrConfig)
 	if err != nil {
 		return err
@@ -192,17 +258,12 @@ func (bs *BatcherService) initTxManager(cfg *CLIConfig) error {
 }
 
 func (bs *BatcherService) initPProf(cfg *CLIConfig) error {
+	// the pprof service is always created, so that it can be started with the admin RPC
+	bs.pprofService = oppprof.NewService(bs.Log, cfg.PprofConfig.ProfileDir)
 	if !cfg.PprofConfig.Enabled {
 		return nil
 	}
-	log.Debug("starting pprof server", "addr", net.JoinHostPort(cfg.PprofConfig.ListenAddr, strconv.Itoa(cfg.PprofConfig.ListenPort)))
-	srv, err := oppprof.StartServer(cfg.PprofConfig.ListenAddr, cfg.PprofConfig.ListenPort)
-	if err != nil {
-		return err
-	}
-	bs.pprofSrv = srv
-	log.Info("started pprof server", "addr", srv.Addr())
-	return nil
+	return bs.pprofService.Start(cfg.PprofConfig.ListenAddr, cfg.PprofConfig.ListenPort)
 }
 
 func (bs *BatcherService) initMetricsServer(cfg *CLIConfig) error {
@@ -214,6 +275,9 @@ func (bs *BatcherService) initMetricsServer(cfg *CLIConfig) error {
 	if !ok {
 		return fmt.Errorf("metrics were enabled, but metricer %T does not expose registry for metrics-server", bs.Metrics)
 	}
+	if h, ok := bs.Log.GetHandler().(opmetrics.LogLevelHandler); ok {
+		opmetrics.NewLogLevelGauge(metrics.Namespace, opmetrics.With(m.Registry()), h)
+	}
 	bs.Log.Debug("starting metrics server", "addr", cfg.MetricsConfig.ListenAddr, "port", cfg.MetricsConfig.ListenPort)
 	metricsSrv, err := opmetrics.StartServer(m.Registry(), cfg.MetricsConfig.ListenAddr, cfg.MetricsConfig.ListenPort)
 	if err != nil {
@@ -225,28 +289,49 @@ func (bs *BatcherService) initMetricsServer(cfg *CLIConfig) error {
 }
 
 func (bs *BatcherService) initDriver() {
+	var l1Client L1Client = bs.L1Client
+	if bs.DryRunDir != "" {
+		l1Client = newDryRunL1(bs.rollupL1Head)
+	}
 	bs.driver = NewBatchSubmitter(DriverSetup{
 		Log:              bs.Log,
 		Metr:             bs.Metrics,
 		RollupConfig:     bs.RollupConfig,
 		Config:           bs.BatcherConfig,
 		Txmgr:            bs.TxManager,
-		L1Client:         bs.L1Client,
+		L1Client:         l1Client,
 		EndpointProvider: bs.EndpointProvider,
 		ChannelConfig:    bs.ChannelConfig,
 	})
 }
 
+// rollupL1Head returns the number of the L1 head of the rollup node, which is the L1 head of a dry-run.
+func (bs *BatcherService) rollupL1Head(ctx context.Context) (uint64, error) {
+	rollupClient, err := bs.EndpointProvider.RollupClient(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("getting rollup client: %w", err)
+	}
+	status, err := rollupClient.SyncStatus(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("getting sync status: %w", err)
+	}
+	return status.HeadL1.Number, nil
+}
+
 func (bs *BatcherService) initRPCServer(cfg *CLIConfig) error {
 	server := oprpc.NewServer(
 		cfg.RPC.ListenAddr,
 		cfg.RPC.ListenPort,
 		bs.Version,
-		oprpc.WithLogger(bs.Log),
+		append(cfg.RPC.ServerOptions(),
+			oprpc.WithLogger(bs.Log),
+			oprpc.WithMethodMetrics(bs.Metrics),
+		)...,
 	)
 	if cfg.RPC.EnableAdmin {
 		adminAPI := rpc.NewAdminAPI(bs.driver, bs.Metrics, bs.Log)
 		server.AddAPI(rpc.GetAdminAPI(adminAPI))
+		server.AddAPI(oppprof.GetAdminAPI(oppprof.NewAPI(bs.pprofService)))
 		bs.Log.Info("Admin RPC enabled")
 	}
 	bs.Log.Info("Starting JSON-RPC server")
@@ -302,8 +387,8 @@ func (bs *BatcherService) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to stop RPC server: %w", err))
 		}
 	}
-	if bs.pprofSrv != nil {
-		if err := bs.pprofSrv.Stop(ctx); err != nil {
+	if bs.pprofService != nil {
+		if err := bs.pprofService.Stop(ctx); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to stop PProf server: %w", err))
 		}
 	}
@@ -4,47 +4,80 @@ import (
 	"fmt"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 // txData represents the data for a single transaction.
 //
-// Note: The batcher currently sends exactly one frame per transaction. This
-// might change in the future to allow for multiple frames from possibly
-// different channels.
+// Calldata transactions carry exactly one frame. Blob transactions carry one
+// frame per blob, possibly several frames of the same channel.
 type txData struct {
-	frame frameData
+	frames []frameData
+	// asBlob indicates that the frames are sent in blobs instead of calldata.
+	asBlob bool
+	// urgent indicates that the frames belong to a channel that approaches its
+	// safety timeout, so that the submission of the tx is escalated.
+	urgent bool
+}
+
+func singleFrameTxData(frame frameData) txData {
+	return txData{frames: []frameData{frame}}
 }
 
 // ID returns the id for this transaction data. It can be used as a map key.
 func (td *txData) ID() txID {
-	return td.frame.id
+	return td.frames[0].id
 }
 
-// Bytes returns the transaction data. It's a version byte (0) followed by the
+// CallData returns the transaction calldata. It's a version byte (0) followed by the
 // concatenated frames for this transaction.
-func (td *txData) Bytes() []byte {
-	return append([]byte{derive.DerivationVersion0}, td.frame.data...)
+func (td *txData) CallData() []byte {
+	data := make([]byte, 1, td.Len())
+	data[0] = derive.DerivationVersion0
+	for _, f := range td.frames {
+		data = append(data, f.data...)
+	}
+	return data
 }
 
-func (td *txData) Len() int {
-	return 1 + len(td.frame.data)
+// Blobs returns the blobs of this transaction. Each blob holds a version byte (0)
+// followed by one frame.
+func (td *txData) Blobs() ([]*eth.Blob, error) {
+	blobs := make([]*eth.Blob, 0, len(td.frames))
+	for _, f := range td.frames {
+		var blob eth.Blob
+		if err := blob.FromData(append([]byte{derive.DerivationVersion0}, f.data...)); err != nil {
+			return nil, fmt.Errorf("encoding frame %v into blob: %w", f.id, err)
+		}
+		blobs = append(blobs, &blob)
+	}
+	return blobs, nil
 }
 
-// Frame returns the single frame of this tx data.
-//
-// Note: when the batcher is changed to possibly send multiple frames per tx,
-// this should be changed to a func Frames() []frameData.
-func (td *txData) Frame() frameData {
-	return td.frame
+// Len returns the number of data bytes posted by this transaction, including the version bytes.
+func (td *txData) Len() (l int) {
+	if td.asBlob {
+		l = len(td.frames) // a version byte per blob
+	} else {
+		l = 1
+	}
+	for _, f := range td.frames {
+		l += len(f.data)
+	}
+	return l
+}
+
+// Frames returns the frames of this tx data.
+func (td *txData) Frames() []frameData {
+	return td.frames
 }
 
 // txID is an opaque identifier for a transaction.
 // It's internal fields should not be inspected after creation & are subject to change.
 // This ID must be trivially comparable & work as a map key.
 //
-// Note: transactions currently only hold a single frame, so it can be
-// identified by the frame. This needs to be changed once the batcher is changed
-// to send multiple frames per tx.
+// Note: a transaction is identified by its first frame. A frame is never part of
+// more than one pending transaction, so this is unique.
 type txID = frameID
 
 func (id txID) String() string {
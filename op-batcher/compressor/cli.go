@@ -1,6 +1,7 @@
 package compressor
 
 import (
+	"compress/zlib"
 	"strings"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
@@ -12,6 +13,7 @@ const (
 	TargetNumFramesFlagName     = "target-num-frames"
 	ApproxComprRatioFlagName    = "approx-compr-ratio"
 	KindFlagName                = "compressor"
+	LevelFlagName               = "compression-level"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -40,6 +42,12 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "COMPRESSOR"),
 			Value:   ShadowKind,
 		},
+		&cli.IntFlag{
+			Name:    LevelFlagName,
+			Usage:   "The zlib compression level: -2 (Huffman only), -1 (default), or from 1 (best speed) to 9 (best compression)",
+			Value:   zlib.BestCompression,
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "COMPRESSION_LEVEL"),
+		},
 	}
 }
 
@@ -57,6 +65,8 @@ type CLIConfig struct {
 	ApproxComprRatio float64
 	// Type of compressor to use. Must be one of KindKeys.
 	Kind string
+	// Level is the zlib compression level.
+	Level int
 }
 
 func (c *CLIConfig) Config() Config {
@@ -65,6 +75,7 @@ func (c *CLIConfig) Config() Config {
 		TargetNumFrames:  c.TargetNumFrames,
 		ApproxComprRatio: c.ApproxComprRatio,
 		Kind:             c.Kind,
+		Level:            c.Level,
 	}
 }
 
@@ -74,5 +85,6 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		TargetL1TxSizeBytes: ctx.Uint64(TargetL1TxSizeBytesFlagName),
 		TargetNumFrames:     ctx.Int(TargetNumFramesFlagName),
 		ApproxComprRatio:    ctx.Float64(ApproxComprRatioFlagName),
+		Level:               ctx.Int(LevelFlagName),
 	}
 }
@@ -1,6 +1,9 @@
 package compressor
 
 import (
+	"compress/zlib"
+	"fmt"
+
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 )
 
@@ -19,6 +22,29 @@ type Config struct {
 	// Kind of compressor to use. Must be one of KindKeys. If unset, NewCompressor
 	// will default to RatioKind.
 	Kind string
+	// Level is the zlib compression level: zlib.HuffmanOnly, zlib.DefaultCompression, or from
+	// zlib.BestSpeed to zlib.BestCompression. zlib.NoCompression is not supported.
+	Level int
+}
+
+// Check validates the compressor kind and the compression level.
+func (c Config) Check() error {
+	if _, ok := Kinds[c.Kind]; c.Kind != "" && !ok {
+		return fmt.Errorf("unknown compressor kind: %q", c.Kind)
+	}
+	return c.checkLevel()
+}
+
+// checkLevel validates the compression level. The compressors check it, as zlib
+// would otherwise silently skip compression at zlib.NoCompression.
+func (c Config) checkLevel() error {
+	if c.Level == zlib.NoCompression {
+		return fmt.Errorf("compression level %d (no compression) is not supported", c.Level)
+	}
+	if c.Level < zlib.HuffmanOnly || c.Level > zlib.BestCompression {
+		return fmt.Errorf("invalid compression level %d, must be between %d and %d", c.Level, zlib.HuffmanOnly, zlib.BestCompression)
+	}
+	return nil
 }
 
 func (c Config) NewCompressor() (derive.Compressor, error) {
@@ -0,0 +1,30 @@
+package compressor_test
+
+import (
+	"compress/zlib"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+)
+
+func TestConfigCheck(t *testing.T) {
+	require.NoError(t, compressor.Config{Level: zlib.BestCompression}.Check(), "default kind")
+	require.NoError(t, compressor.Config{Kind: compressor.ShadowKind, Level: zlib.BestSpeed}.Check())
+	require.NoError(t, compressor.Config{Kind: compressor.RatioKind, Level: zlib.HuffmanOnly}.Check())
+	require.NoError(t, compressor.Config{Level: zlib.DefaultCompression}.Check())
+	require.ErrorContains(t, compressor.Config{Kind: "brotli", Level: zlib.BestCompression}.Check(), "unknown compressor kind")
+	require.ErrorContains(t, compressor.Config{}.Check(), "not supported", "no compression")
+	require.ErrorContains(t, compressor.Config{Level: zlib.BestCompression + 1}.Check(), "invalid compression level")
+	require.ErrorContains(t, compressor.Config{Level: zlib.HuffmanOnly - 1}.Check(), "invalid compression level")
+}
+
+func TestCompressorsCheckConfig(t *testing.T) {
+	for _, kind := range compressor.KindKeys {
+		_, err := compressor.Kinds[kind](compressor.Config{TargetFrameSize: 1000, TargetNumFrames: 1, ApproxComprRatio: 0.4})
+		require.ErrorContains(t, err, "not supported", "%s compressor rejects no compression", kind)
+		_, err = compressor.Kinds[kind](compressor.Config{TargetFrameSize: 1000, TargetNumFrames: 1, ApproxComprRatio: 0.4, Level: zlib.BestSpeed})
+		require.NoError(t, err, "%s compressor", kind)
+	}
+}
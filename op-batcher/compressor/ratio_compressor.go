@@ -21,11 +21,14 @@ type RatioCompressor struct {
 //
 //	full = uncompressedLength * approxCompRatio >= targetFrameSize * targetNumFrames
 func NewRatioCompressor(config Config) (derive.Compressor, error) {
+	if err := config.checkLevel(); err != nil {
+		return nil, err
+	}
 	c := &RatioCompressor{
 		config: config,
 	}
 
-	compress, err := zlib.NewWriterLevel(&c.buf, zlib.BestCompression)
+	compress, err := zlib.NewWriterLevel(&c.buf, config.Level)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package compressor_test
 
 import (
+	"compress/zlib"
 	"math"
 	"testing"
 
@@ -122,6 +123,7 @@ func TestInputThreshold(t *testing.T) {
 			TargetFrameSize:  tt.input.TargetFrameSize,
 			TargetNumFrames:  tt.input.TargetNumFrames,
 			ApproxComprRatio: tt.input.ApproxComprRatio,
+			Level:            zlib.BestCompression,
 		})
 		require.NoError(t, err)
 		got := comp.(*compressor.RatioCompressor).InputThreshold()
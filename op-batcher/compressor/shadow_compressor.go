@@ -40,16 +40,20 @@ type ShadowCompressor struct {
 // target, which allows individual blocks larger than the target to be included (and will
 // be split across multiple channel frames).
 func NewShadowCompressor(config Config) (derive.Compressor, error) {
+	if err := config.checkLevel(); err != nil {
+		return nil, err
+	}
 	c := &ShadowCompressor{
 		config: config,
 	}
 
 	var err error
-	c.compress, err = zlib.NewWriterLevel(&c.buf, zlib.BestCompression)
+	// the shadow stream uses the same level, so that its size closely estimates the final output
+	c.compress, err = zlib.NewWriterLevel(&c.buf, config.Level)
 	if err != nil {
 		return nil, err
 	}
-	c.shadowCompress, err = zlib.NewWriterLevel(&c.shadowBuf, zlib.BestCompression)
+	c.shadowCompress, err = zlib.NewWriterLevel(&c.shadowBuf, config.Level)
 	if err != nil {
 		return nil, err
 	}
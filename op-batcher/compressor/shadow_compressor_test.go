@@ -3,6 +3,7 @@ package compressor
 import (
 	"bytes"
 	"compress/zlib"
+	"fmt"
 	"io"
 	"math/rand"
 	"testing"
@@ -72,6 +73,7 @@ func TestShadowCompressor(t *testing.T) {
 			sc, err := NewShadowCompressor(Config{
 				TargetFrameSize: test.targetFrameSize,
 				TargetNumFrames: test.targetNumFrames,
+				Level:           zlib.BestCompression,
 			})
 			require.NoError(t, err)
 
@@ -125,6 +127,7 @@ func TestBoundInaccurateForLargeRandomData(t *testing.T) {
 	sc, err := NewShadowCompressor(Config{
 		TargetFrameSize: uint64(sizeLimit + 100),
 		TargetNumFrames: 1,
+		Level:           zlib.BestCompression,
 	})
 	require.NoError(t, err)
 
@@ -141,3 +144,40 @@ func TestBoundInaccurateForLargeRandomData(t *testing.T) {
 	require.NoError(t, err)
 	require.LessOrEqual(t, uint64(sc.Len()), sc.(*ShadowCompressor).bound)
 }
+
+// TestShadowCompressorLevels ensures that the compressed output of the shadow compressor stays within
+// the target size at all compression levels, and decompresses to the written data.
+func TestShadowCompressorLevels(t *testing.T) {
+	const target = 10_000
+	for _, level := range []int{zlib.HuffmanOnly, zlib.BestSpeed, zlib.DefaultCompression, 6, zlib.BestCompression} {
+		level := level
+		t.Run(fmt.Sprintf("level %d", level), func(t *testing.T) {
+			sc, err := NewShadowCompressor(Config{
+				TargetFrameSize: target,
+				TargetNumFrames: 1,
+				Level:           level,
+			})
+			require.NoError(t, err)
+
+			// partially compressible data, like batches of similar txs with random fields
+			var written []byte
+			for {
+				d := append(bytes.Repeat([]byte{0x01, 0x02}, 100), randomBytes(t, 100)...)
+				if _, err := sc.Write(d); err != nil {
+					require.ErrorIs(t, err, derive.CompressorFullErr)
+					break
+				}
+				written = append(written, d...)
+			}
+			require.Greater(t, len(written), target, "the data is compressed")
+			require.NoError(t, sc.Close())
+			require.LessOrEqual(t, sc.Len(), target, "the target is not exceeded")
+
+			r, err := zlib.NewReader(sc)
+			require.NoError(t, err)
+			uncompressed, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, written, uncompressed)
+		})
+	}
+}
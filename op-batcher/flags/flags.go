@@ -8,6 +8,8 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/endpoint"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -23,21 +25,9 @@ func prefixEnvVars(name string) []string {
 
 var (
 	// Required flags
-	L1EthRpcFlag = &cli.StringFlag{
-		Name:    "l1-eth-rpc",
-		Usage:   "HTTP provider URL for L1",
-		EnvVars: prefixEnvVars("L1_ETH_RPC"),
-	}
-	L2EthRpcFlag = &cli.StringFlag{
-		Name:    "l2-eth-rpc",
-		Usage:   "HTTP provider URL for L2 execution engine",
-		EnvVars: prefixEnvVars("L2_ETH_RPC"),
-	}
-	RollupRpcFlag = &cli.StringFlag{
-		Name:    "rollup-rpc",
-		Usage:   "HTTP provider URL for Rollup node",
-		EnvVars: prefixEnvVars("ROLLUP_RPC"),
-	}
+	L1EthRpcFlag  = endpoint.L1RPCFlag(EnvVarPrefix)
+	L2EthRpcFlag  = endpoint.L2RPCFlag(EnvVarPrefix)
+	RollupRpcFlag = endpoint.RollupRPCFlag(EnvVarPrefix)
 	// Optional flags
 	SubSafetyMarginFlag = &cli.Uint64Flag{
 		Name: "sub-safety-margin",
@@ -55,7 +45,7 @@ var (
 	}
 	MaxPendingTransactionsFlag = &cli.Uint64Flag{
 		Name:    "max-pending-tx",
-		Usage:   "The maximum number of pending transactions. 0 for no limit.",
+		Usage:   "The maximum number of pending transactions, which are sent concurrently with consecutive nonces. 0 for no limit.",
 		Value:   1,
 		EnvVars: prefixEnvVars("MAX_PENDING_TX"),
 	}
@@ -65,6 +55,38 @@ var (
 		Value:   0,
 		EnvVars: prefixEnvVars("MAX_CHANNEL_DURATION"),
 	}
+	UrgencyMarginFlag = &cli.Uint64Flag{
+		Name: "urgency-margin",
+		Usage: "The number of L1 blocks before a channel's safety timeout, from which on the submission of its " +
+			"remaining frames is escalated, so that they land before the channel times out. 0 to disable.",
+		Value:   0,
+		EnvVars: prefixEnvVars("URGENCY_MARGIN"),
+	}
+	SplitUrgentFramesFlag = &cli.BoolFlag{
+		Name: "split-urgent-frames",
+		Usage: "Send the remaining frames of urgent channels in one transaction per frame, so that they are " +
+			"submitted in parallel, up to the max pending transactions.",
+		EnvVars: prefixEnvVars("SPLIT_URGENT_FRAMES"),
+	}
+	UrgentResubmissionTimeoutFlag = &cli.DurationFlag{
+		Name: "urgent-resubmission-timeout",
+		Usage: "Resubmission timeout of transactions carrying frames of urgent channels, to bump their fees more " +
+			"aggressively. 0 to use the resubmission timeout of the transaction manager.",
+		Value:   0,
+		EnvVars: prefixEnvVars("URGENT_RESUBMISSION_TIMEOUT"),
+	}
+	MaxBlocksPerChannelFlag = &cli.Uint64Flag{
+		Name:    "max-blocks-per-channel",
+		Usage:   "The maximum number of L2 blocks per channel. 0 to disable.",
+		Value:   0,
+		EnvVars: prefixEnvVars("MAX_BLOCKS_PER_CHANNEL"),
+	}
+	MaxChannelInputBytesFlag = &cli.Uint64Flag{
+		Name:    "max-channel-input-bytes",
+		Usage:   "The maximum amount of uncompressed input data per channel. 0 to only limit by the protocol max.",
+		Value:   0,
+		EnvVars: prefixEnvVars("MAX_CHANNEL_INPUT_BYTES"),
+	}
 	MaxL1TxSizeBytesFlag = &cli.Uint64Flag{
 		Name:    "max-l1-tx-size-bytes",
 		Usage:   "The maximum size of a batch tx submitted to L1.",
@@ -82,6 +104,113 @@ var (
 		Value:   0,
 		EnvVars: prefixEnvVars("BATCH_TYPE"),
 	}
+	DataAvailabilityTypeFlag = &cli.GenericFlag{
+		Name: "data-availability-type",
+		Usage: "The data availability type to use for submitting batches to the L1. Valid options: " +
+			openum.EnumString(DataAvailabilityTypes),
+		Value: func() *DataAvailabilityType {
+			out := CalldataType
+			return &out
+		}(),
+		EnvVars: prefixEnvVars("DATA_AVAILABILITY_TYPE"),
+	}
+	MaxBlobsPerTxFlag = &cli.IntFlag{
+		Name: "max-blobs-per-tx",
+		Usage: "The maximum number of blobs to send in a single blob transaction, up to the protocol max of 6. " +
+			"Only used with the blobs data availability type, channels are then sized to fill this many blobs.",
+		Value:   1,
+		EnvVars: prefixEnvVars("MAX_BLOBS_PER_TX"),
+	}
+	MultiFrameTxsFlag = &cli.BoolFlag{
+		Name: "multi-frame-txs",
+		Usage: "Pack the frames of multiple channels into a single batcher tx, e.g. the last frames of a channel " +
+			"and the first frames of the next one. Calldata txs then carry frames up to the max L1 tx size.",
+		EnvVars: prefixEnvVars("MULTI_FRAME_TXS"),
+	}
+	SkipEmptyBlocksFlag = &cli.BoolFlag{
+		Name: "skip-empty-blocks",
+		Usage: "Do not submit the empty L2 blocks that the derivation pipeline regenerates by itself. Saves data, " +
+			"but the safe head stalls at a skipped block until the sequencing window of its epoch expires. Requires span batches.",
+		EnvVars: prefixEnvVars("SKIP_EMPTY_BLOCKS"),
+	}
+	DrainTimeoutFlag = &cli.DurationFlag{
+		Name: "drain-timeout",
+		Usage: "How long to keep submitting the remaining batch data when shutting down, " +
+			"until all batcher txs are confirmed. 0 to wait until the shutdown is forced.",
+		Value:   5 * time.Minute,
+		EnvVars: prefixEnvVars("DRAIN_TIMEOUT"),
+	}
+	MaxL1BaseFeeFlag = &cli.Float64Flag{
+		Name: "max-l1-base-fee",
+		Usage: "The L1 base fee in GWei above which batch submission pauses, while blocks keep being loaded. " +
+			"0 to disable.",
+		Value:   0,
+		EnvVars: prefixEnvVars("MAX_L1_BASE_FEE"),
+	}
+	MaxL1BlobBaseFeeFlag = &cli.Float64Flag{
+		Name: "max-l1-blob-base-fee",
+		Usage: "The L1 blob base fee in GWei above which batch submission pauses, while blocks keep being loaded. " +
+			"Only used with the blobs data availability type. 0 to disable.",
+		Value:   0,
+		EnvVars: prefixEnvVars("MAX_L1_BLOB_BASE_FEE"),
+	}
+	FeeCeilingResumePercentFlag = &cli.Uint64Flag{
+		Name:    "fee-ceiling-resume-percent",
+		Usage:   "The percentage of the L1 fee ceilings that the fees must fall below for a paused batch submission to resume.",
+		Value:   90,
+		EnvVars: prefixEnvVars("FEE_CEILING_RESUME_PERCENT"),
+	}
+	FeeCeilingMaxDelayFlag = &cli.DurationFlag{
+		Name: "fee-ceiling-max-delay",
+		Usage: "The maximum duration that batch submission is paused for by the L1 fee ceilings. " +
+			"Submission is also forced before the channel timeout or sequencing window of the loaded blocks.",
+		Value:   time.Hour,
+		EnvVars: prefixEnvVars("FEE_CEILING_MAX_DELAY"),
+	}
+	UnsafeHeadStallTimeoutFlag = &cli.DurationFlag{
+		Name: "unsafe-head-stall-timeout",
+		Usage: "Report the sequencer as stalled, if the unsafe head of the rollup node didn't advance for this duration. " +
+			"0 to disable.",
+		Value:   2 * time.Minute,
+		EnvVars: prefixEnvVars("UNSAFE_HEAD_STALL_TIMEOUT"),
+	}
+	MaxSafeHeadLagFlag = &cli.Uint64Flag{
+		Name: "max-safe-head-lag",
+		Usage: "Report that batcher submissions are not landing, if the safe head lags behind the unsafe head " +
+			"by more than this number of L2 blocks. 0 to disable.",
+		Value:   0,
+		EnvVars: prefixEnvVars("MAX_SAFE_HEAD_LAG"),
+	}
+	StateFileFlag = &cli.StringFlag{
+		Name: "state-file",
+		Usage: "File to persist the submission state to, so that a restarted batcher resumes after the data " +
+			"that already landed on L1, instead of at the safe head. Disabled if empty.",
+		EnvVars: prefixEnvVars("STATE_FILE"),
+	}
+	ConfirmationModeFlag = &cli.GenericFlag{
+		Name: "confirmation-mode",
+		Usage: "How the L1 inclusion blocks of batcher txs are considered final, after which their batch data is no longer " +
+			"resubmitted on L1 reorgs: at the confirmation depth behind the L1 head, or once the safe or finalized L1 block " +
+			"includes them. Valid options: " + openum.EnumString(ConfirmationModes),
+		Value: func() *ConfirmationMode {
+			out := DepthConfirmation
+			return &out
+		}(),
+		EnvVars: prefixEnvVars("CONFIRMATION_MODE"),
+	}
+	ConfirmationDepthFlag = &cli.Uint64Flag{
+		Name:    "confirmation-depth",
+		Usage:   "Number of L1 blocks behind the L1 head at which batcher txs are considered final, with the depth confirmation mode.",
+		Value:   64,
+		EnvVars: prefixEnvVars("CONFIRMATION_DEPTH"),
+	}
+	DryRunDirFlag = &cli.StringFlag{
+		Name: "dry-run-dir",
+		Usage: "Dry-run: write the batcher txs to this dir, as binary data files with a JSON sidecar per tx, instead of " +
+			"sending them to L1. The L1 RPC is not used, and the L1 head follows the rollup node. Disabled if empty.",
+		EnvVars: prefixEnvVars("DRY_RUN_DIR"),
+	}
+	DialTimeoutFlag = endpoint.DialTimeoutFlag(EnvVarPrefix)
 	// Legacy Flags
 	SequencerHDPathFlag = txmgr.SequencerHDPathFlag
 )
@@ -97,16 +226,38 @@ var optionalFlags = []cli.Flag{
 	PollIntervalFlag,
 	MaxPendingTransactionsFlag,
 	MaxChannelDurationFlag,
+	UrgencyMarginFlag,
+	SplitUrgentFramesFlag,
+	UrgentResubmissionTimeoutFlag,
+	MaxBlocksPerChannelFlag,
+	MaxChannelInputBytesFlag,
 	MaxL1TxSizeBytesFlag,
 	StoppedFlag,
 	SequencerHDPathFlag,
 	BatchTypeFlag,
+	DataAvailabilityTypeFlag,
+	DrainTimeoutFlag,
+	MaxBlobsPerTxFlag,
+	MultiFrameTxsFlag,
+	SkipEmptyBlocksFlag,
+	MaxL1BaseFeeFlag,
+	MaxL1BlobBaseFeeFlag,
+	FeeCeilingResumePercentFlag,
+	FeeCeilingMaxDelayFlag,
+	UnsafeHeadStallTimeoutFlag,
+	MaxSafeHeadLagFlag,
+	StateFileFlag,
+	ConfirmationModeFlag,
+	ConfirmationDepthFlag,
+	DryRunDirFlag,
+	DialTimeoutFlag,
 }
 
 func init() {
 	optionalFlags = append(optionalFlags, oprpc.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, opmetrics.BalanceCLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, compressor.CLIFlags(EnvVarPrefix)...)
@@ -119,6 +270,10 @@ var Flags []cli.Flag
 
 func CheckRequired(ctx *cli.Context) error {
 	for _, f := range requiredFlags {
+		// a dry-run doesn't need a L1
+		if f == L1EthRpcFlag && ctx.IsSet(DryRunDirFlag.Name) {
+			continue
+		}
 		if !ctx.IsSet(f.Names()[0]) {
 			return fmt.Errorf("flag %s is required", f.Names()[0])
 		}
@@ -0,0 +1,89 @@
+package flags
+
+import "fmt"
+
+// DataAvailabilityType is the way batcher transactions make the batch data available on L1.
+type DataAvailabilityType string
+
+const (
+	// CalldataType sends the batch data as calldata of the batcher transactions.
+	CalldataType DataAvailabilityType = "calldata"
+	// BlobsType sends the batch data in blobs of EIP-4844 blob transactions.
+	BlobsType DataAvailabilityType = "blobs"
+)
+
+var DataAvailabilityTypes = []DataAvailabilityType{
+	CalldataType,
+	BlobsType,
+}
+
+func (kind DataAvailabilityType) String() string {
+	return string(kind)
+}
+
+func (kind *DataAvailabilityType) Set(value string) error {
+	if !ValidDataAvailabilityType(DataAvailabilityType(value)) {
+		return fmt.Errorf("unknown data-availability type: %q", value)
+	}
+	*kind = DataAvailabilityType(value)
+	return nil
+}
+
+func (kind *DataAvailabilityType) Clone() any {
+	cpy := *kind
+	return &cpy
+}
+
+func ValidDataAvailabilityType(value DataAvailabilityType) bool {
+	for _, k := range DataAvailabilityTypes {
+		if k == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfirmationMode is the way the batcher determines that the L1 inclusion blocks of its transactions are final,
+// so that the submitted batch data is no longer tracked for L1 reorgs.
+type ConfirmationMode string
+
+const (
+	// DepthConfirmation considers L1 blocks final once they are the confirmation depth behind the L1 head.
+	DepthConfirmation ConfirmationMode = "depth"
+	// SafeConfirmation considers L1 blocks final once they are included in the safe L1 block.
+	SafeConfirmation ConfirmationMode = "safe"
+	// FinalizedConfirmation considers L1 blocks final once they are included in the finalized L1 block.
+	FinalizedConfirmation ConfirmationMode = "finalized"
+)
+
+var ConfirmationModes = []ConfirmationMode{
+	DepthConfirmation,
+	SafeConfirmation,
+	FinalizedConfirmation,
+}
+
+func (mode ConfirmationMode) String() string {
+	return string(mode)
+}
+
+func (mode *ConfirmationMode) Set(value string) error {
+	if !ValidConfirmationMode(ConfirmationMode(value)) {
+		return fmt.Errorf("unknown confirmation mode: %q", value)
+	}
+	*mode = ConfirmationMode(value)
+	return nil
+}
+
+func (mode *ConfirmationMode) Clone() any {
+	cpy := *mode
+	return &cpy
+}
+
+func ValidConfirmationMode(value ConfirmationMode) bool {
+	for _, m := range ConfirmationModes {
+		if m == value {
+			return true
+		}
+	}
+	return false
+}
@@ -2,14 +2,16 @@ package metrics
 
 import (
 	"io"
+	"math/big"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -30,7 +32,7 @@ type Metricer interface {
 
 	opmetrics.RPCMetricer
 
-	StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer
+	StartBalanceMonitor(l log.Logger, client opmetrics.BalanceClient, accounts []opmetrics.BalanceAccount, lowBalanceThreshold *big.Int, interval time.Duration) io.Closer
 
 	RecordLatestL1Block(l1ref eth.L1BlockRef)
 	RecordL2BlocksLoaded(l2ref eth.L2BlockRef)
@@ -38,13 +40,29 @@ type Metricer interface {
 	RecordL2BlocksAdded(l2ref eth.L2BlockRef, numBlocksAdded, numPendingBlocks, inputBytes, outputComprBytes int)
 	RecordL2BlockInPendingQueue(block *types.Block)
 	RecordL2BlockInChannel(block *types.Block)
-	RecordChannelClosed(id derive.ChannelID, numPendingBlocks int, numFrames int, inputBytes int, outputComprBytes int, reason error)
+	RecordPendingBlocks(numPendingBlocks int)
+	RecordChannelClosed(id derive.ChannelID, numPendingBlocks int, numFrames int, inputBytes int, outputComprBytes int, reason string)
 	RecordChannelFullySubmitted(id derive.ChannelID)
 	RecordChannelTimedOut(id derive.ChannelID)
+	RecordChannelReorged(id derive.ChannelID)
+	RecordChannelAtRisk(id derive.ChannelID)
+	RecordUrgentChannels(n int)
 
 	RecordBatchTxSubmitted()
 	RecordBatchTxSuccess()
 	RecordBatchTxFailed()
+	RecordBatchTxReorged()
+	RecordBatchTxDataGas(da flags.DataAvailabilityType, gas uint64)
+	RecordBatchTxsInFlight(n int)
+	RecordBatchTxConfirmationLatency(latency time.Duration)
+
+	RecordBatchDataPosted(da flags.DataAvailabilityType, numBytes int)
+	RecordBlobFeePaid(blobGasUsed uint64, blobGasPrice *big.Int)
+
+	RecordSubmissionPaused(paused bool, pausedFor time.Duration)
+
+	RecordSequencerStalled(stalled bool)
+	RecordSafeHeadLagging(lagging bool)
 
 	Document() []opmetrics.DocumentedMetric
 }
@@ -61,24 +79,41 @@ type Metrics struct {
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
 
-	// label by opened, closed, fully_submitted, timed_out
+	// label by opened, closed, fully_submitted, timed_out, reorged, at_risk
 	channelEvs opmetrics.EventVec
 
 	pendingBlocksCount        prometheus.GaugeVec
 	pendingBlocksBytesTotal   prometheus.Counter
 	pendingBlocksBytesCurrent prometheus.Gauge
+	pendingBlocksQueueDepth   prometheus.Gauge
 	blocksAddedCount          prometheus.Gauge
 
 	channelInputBytes       prometheus.GaugeVec
 	channelReadyBytes       prometheus.Gauge
 	channelOutputBytes      prometheus.Gauge
-	channelClosedReason     prometheus.Gauge
+	channelClosedReasons    prometheus.CounterVec
 	channelNumFrames        prometheus.Gauge
+	channelFrames           prometheus.Histogram
 	channelComprRatio       prometheus.Histogram
 	channelInputBytesTotal  prometheus.Counter
 	channelOutputBytesTotal prometheus.Counter
 
-	batcherTxEvs opmetrics.EventVec
+	batcherTxEvs            opmetrics.EventVec
+	batcherTxDataGas        prometheus.HistogramVec
+	batcherTxsInFlight      prometheus.Gauge
+	batcherTxConfirmLatency prometheus.Histogram
+
+	batchDataPostedBytes prometheus.CounterVec
+	blobFee              prometheus.Gauge
+	blobFeesTotal        prometheus.Counter
+
+	submissionPaused        prometheus.Gauge
+	submissionPausedSeconds prometheus.Gauge
+
+	sequencerStalled prometheus.Gauge
+	safeHeadLagging  prometheus.Gauge
+
+	urgentChannels prometheus.Gauge
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -134,6 +169,11 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "pending_blocks_bytes_current",
 			Help:      "Current size of transactions in the pending (fetched from L2 but not in a channel) stage.",
 		}),
+		pendingBlocksQueueDepth: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "pending_blocks_queue_depth",
+			Help:      "Number of blocks in the pending queue, waiting to be added to a channel.",
+		}),
 		blocksAddedCount: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "blocks_added_count",
@@ -155,16 +195,24 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "output_bytes",
 			Help:      "Number of compressed output bytes from a channel.",
 		}),
-		channelClosedReason: factory.NewGauge(prometheus.GaugeOpts{
+		channelClosedReasons: *factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: ns,
-			Name:      "channel_closed_reason",
-			Help:      "Pseudo-metric to record the reason a channel got closed.",
+			Name:      "channel_closed_reasons_total",
+			Help:      "Number of closed channels, by the reason the channel got closed.",
+		}, []string{
+			"reason",
 		}),
 		channelNumFrames: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "channel_num_frames",
 			Help:      "Total number of frames of closed channel.",
 		}),
+		channelFrames: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "channel_frames",
+			Help:      "Number of frames of closed channels.",
+			Buckets:   []float64{1, 2, 3, 4, 6, 8, 12, 16, 32, 64},
+		}),
 		channelComprRatio: factory.NewHistogram(prometheus.HistogramOpts{
 			Namespace: ns,
 			Name:      "channel_compr_ratio",
@@ -183,6 +231,70 @@ func NewMetrics(procName string) *Metrics {
 		}),
 
 		batcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
+		batcherTxDataGas: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "batcher_tx_data_gas",
+			Help:      "Estimated gas of the batch data of submitted batcher txs: the intrinsic gas for calldata, the blob gas for blobs.",
+			Buckets:   prometheus.ExponentialBuckets(21_000, 2, 10),
+		}, []string{
+			"da",
+		}),
+		batcherTxsInFlight: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "batcher_txs_in_flight",
+			Help:      "Number of batcher txs that are queued for sending or pending, but not confirmed or failed yet.",
+		}),
+		batcherTxConfirmLatency: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "batcher_tx_confirmation_seconds",
+			Help:      "Duration from queueing a batcher tx for sending until its confirmation on L1.",
+			Buckets:   prometheus.ExponentialBuckets(3, 2, 10),
+		}),
+
+		batchDataPostedBytes: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "batch_data_posted_bytes_total",
+			Help:      "Total number of batch data bytes posted to L1 in confirmed batcher txs, by data availability type.",
+		}, []string{
+			"da",
+		}),
+		blobFee: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "blob_fee_gwei",
+			Help:      "Blob fee paid by the last confirmed blob batcher tx, in GWei.",
+		}),
+		blobFeesTotal: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "blob_fee_gwei_total",
+			Help:      "Total blob fees paid by confirmed blob batcher txs, in GWei.",
+		}),
+
+		submissionPaused: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "submission_paused",
+			Help:      "1 if batch submission is paused because the L1 fees are above the fee ceiling, 0 if active.",
+		}),
+		submissionPausedSeconds: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "submission_paused_seconds",
+			Help:      "Duration of the current pause of batch submission due to the L1 fee ceiling, 0 if active.",
+		}),
+
+		sequencerStalled: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sequencer_stalled",
+			Help:      "1 if the unsafe head of the sequencer stopped advancing for the stall timeout, 0 otherwise.",
+		}),
+		safeHeadLagging: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "safe_head_lagging",
+			Help:      "1 if the safe head lags behind the unsafe head by more than the max safe head lag, 0 otherwise.",
+		}),
+		urgentChannels: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "urgent_channels",
+			Help:      "Number of channels of which the submission is escalated, because they approach their safety timeout.",
+		}),
 	}
 }
 
@@ -194,8 +306,8 @@ func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
 
-func (m *Metrics) StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer {
-	return opmetrics.LaunchBalanceMetrics(l, m.registry, m.ns, client, account)
+func (m *Metrics) StartBalanceMonitor(l log.Logger, client opmetrics.BalanceClient, accounts []opmetrics.BalanceAccount, lowBalanceThreshold *big.Int, interval time.Duration) io.Closer {
+	return opmetrics.NewBalanceMonitor(l, m.factory, m.ns, client, accounts, lowBalanceThreshold).Start(interval)
 }
 
 // RecordInfo sets a pseudo-metric that contains versioning and
@@ -217,10 +329,20 @@ const (
 	StageClosed         = "closed"
 	StageFullySubmitted = "fully_submitted"
 	StageTimedOut       = "timed_out"
+	StageReorged        = "reorged"
+	StageAtRisk         = "at_risk"
 
 	TxStageSubmitted = "submitted"
 	TxStageSuccess   = "success"
 	TxStageFailed    = "failed"
+	TxStageReorged   = "reorged"
+
+	ClosedReasonFull        = "full"
+	ClosedReasonTimeout     = "timeout"
+	ClosedReasonMaxDuration = "max_duration"
+	ClosedReasonMaxBlocks   = "max_blocks"
+	ClosedReasonMaxInput    = "max_input_bytes"
+	ClosedReasonShutdown    = "shutdown"
 )
 
 func (m *Metrics) RecordLatestL1Block(l1ref eth.L1BlockRef) {
@@ -249,10 +371,18 @@ func (m *Metrics) RecordL2BlocksAdded(l2ref eth.L2BlockRef, numBlocksAdded, numP
 	m.channelReadyBytes.Set(float64(outputComprBytes))
 }
 
-func (m *Metrics) RecordChannelClosed(id derive.ChannelID, numPendingBlocks int, numFrames int, inputBytes int, outputComprBytes int, reason error) {
+// RecordPendingBlocks should be called whenever the number of blocks in the pending queue changes.
+func (m *Metrics) RecordPendingBlocks(numPendingBlocks int) {
+	m.pendingBlocksQueueDepth.Set(float64(numPendingBlocks))
+}
+
+// RecordChannelClosed should be called when a channel got closed, with one of
+// the ClosedReason values as reason.
+func (m *Metrics) RecordChannelClosed(id derive.ChannelID, numPendingBlocks int, numFrames int, inputBytes int, outputComprBytes int, reason string) {
 	m.channelEvs.Record(StageClosed)
 	m.pendingBlocksCount.WithLabelValues(StageClosed).Set(float64(numPendingBlocks))
 	m.channelNumFrames.Set(float64(numFrames))
+	m.channelFrames.Observe(float64(numFrames))
 	m.channelInputBytes.WithLabelValues(StageClosed).Set(float64(inputBytes))
 	m.channelOutputBytes.Set(float64(outputComprBytes))
 	m.channelInputBytesTotal.Add(float64(inputBytes))
@@ -264,7 +394,7 @@ func (m *Metrics) RecordChannelClosed(id derive.ChannelID, numPendingBlocks int,
 	}
 	m.channelComprRatio.Observe(comprRatio)
 
-	m.channelClosedReason.Set(float64(ClosedReasonToNum(reason)))
+	m.channelClosedReasons.WithLabelValues(reason).Inc()
 }
 
 func (m *Metrics) RecordL2BlockInPendingQueue(block *types.Block) {
@@ -279,11 +409,6 @@ func (m *Metrics) RecordL2BlockInChannel(block *types.Block) {
 	// Refer to RecordL2BlocksAdded to see the current + count of bytes added to a channel
 }
 
-func ClosedReasonToNum(reason error) int {
-	// CLI-3640
-	return 0
-}
-
 func (m *Metrics) RecordChannelFullySubmitted(id derive.ChannelID) {
 	m.channelEvs.Record(StageFullySubmitted)
 }
@@ -292,6 +417,20 @@ func (m *Metrics) RecordChannelTimedOut(id derive.ChannelID) {
 	m.channelEvs.Record(StageTimedOut)
 }
 
+func (m *Metrics) RecordChannelReorged(id derive.ChannelID) {
+	m.channelEvs.Record(StageReorged)
+}
+
+// RecordChannelAtRisk records a channel of which frames risk landing after the channel timeout.
+func (m *Metrics) RecordChannelAtRisk(id derive.ChannelID) {
+	m.channelEvs.Record(StageAtRisk)
+}
+
+// RecordUrgentChannels records the number of channels of which the submission is escalated.
+func (m *Metrics) RecordUrgentChannels(n int) {
+	m.urgentChannels.Set(float64(n))
+}
+
 func (m *Metrics) RecordBatchTxSubmitted() {
 	m.batcherTxEvs.Record(TxStageSubmitted)
 }
@@ -304,6 +443,65 @@ func (m *Metrics) RecordBatchTxFailed() {
 	m.batcherTxEvs.Record(TxStageFailed)
 }
 
+func (m *Metrics) RecordBatchTxReorged() {
+	m.batcherTxEvs.Record(TxStageReorged)
+}
+
+func (m *Metrics) RecordBatchTxDataGas(da flags.DataAvailabilityType, gas uint64) {
+	m.batcherTxDataGas.WithLabelValues(da.String()).Observe(float64(gas))
+}
+
+// RecordBatchTxsInFlight records the number of batcher txs that are queued or pending.
+func (m *Metrics) RecordBatchTxsInFlight(n int) {
+	m.batcherTxsInFlight.Set(float64(n))
+}
+
+// RecordBatchTxConfirmationLatency records the duration from queueing a batcher tx until its confirmation.
+func (m *Metrics) RecordBatchTxConfirmationLatency(latency time.Duration) {
+	m.batcherTxConfirmLatency.Observe(latency.Seconds())
+}
+
+func (m *Metrics) RecordBatchDataPosted(da flags.DataAvailabilityType, numBytes int) {
+	m.batchDataPostedBytes.WithLabelValues(da.String()).Add(float64(numBytes))
+}
+
+func (m *Metrics) RecordBlobFeePaid(blobGasUsed uint64, blobGasPrice *big.Int) {
+	fee, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(new(big.Int).Mul(blobGasPrice, new(big.Int).SetUint64(blobGasUsed))),
+		big.NewFloat(params.GWei),
+	).Float64()
+	m.blobFee.Set(fee)
+	m.blobFeesTotal.Add(fee)
+}
+
+// RecordSubmissionPaused records whether batch submission is paused by the L1 fee ceiling,
+// and for how long the current pause lasts.
+func (m *Metrics) RecordSubmissionPaused(paused bool, pausedFor time.Duration) {
+	if paused {
+		m.submissionPaused.Set(1)
+	} else {
+		m.submissionPaused.Set(0)
+	}
+	m.submissionPausedSeconds.Set(pausedFor.Seconds())
+}
+
+// RecordSequencerStalled records whether the unsafe head of the sequencer stopped advancing.
+func (m *Metrics) RecordSequencerStalled(stalled bool) {
+	m.sequencerStalled.Set(boolToFloat(stalled))
+}
+
+// RecordSafeHeadLagging records whether the safe head lags too far behind the unsafe head.
+func (m *Metrics) RecordSafeHeadLagging(lagging bool) {
+	m.safeHeadLagging.Set(boolToFloat(lagging))
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 // estimateBatchSize estimates the size of the batch
 func estimateBatchSize(block *types.Block) uint64 {
 	size := uint64(70) // estimated overhead of batch metadata
@@ -2,12 +2,13 @@ package metrics
 
 import (
 	"io"
+	"math/big"
+	"time"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -34,14 +35,32 @@ func (*noopMetrics) RecordL2BlocksAdded(eth.L2BlockRef, int, int, int, int) {}
 func (*noopMetrics) RecordL2BlockInPendingQueue(*types.Block)               {}
 func (*noopMetrics) RecordL2BlockInChannel(*types.Block)                    {}
 
-func (*noopMetrics) RecordChannelClosed(derive.ChannelID, int, int, int, int, error) {}
+func (*noopMetrics) RecordPendingBlocks(int)                                          {}
+func (*noopMetrics) RecordChannelClosed(derive.ChannelID, int, int, int, int, string) {}
 
 func (*noopMetrics) RecordChannelFullySubmitted(derive.ChannelID) {}
 func (*noopMetrics) RecordChannelTimedOut(derive.ChannelID)       {}
+func (*noopMetrics) RecordChannelReorged(derive.ChannelID)        {}
+func (*noopMetrics) RecordChannelAtRisk(derive.ChannelID)         {}
+func (*noopMetrics) RecordUrgentChannels(int)                     {}
 
 func (*noopMetrics) RecordBatchTxSubmitted() {}
 func (*noopMetrics) RecordBatchTxSuccess()   {}
 func (*noopMetrics) RecordBatchTxFailed()    {}
-func (*noopMetrics) StartBalanceMetrics(log.Logger, *ethclient.Client, common.Address) io.Closer {
+func (*noopMetrics) RecordBatchTxReorged()   {}
+
+func (*noopMetrics) RecordBatchTxDataGas(flags.DataAvailabilityType, uint64) {}
+func (*noopMetrics) RecordBatchTxsInFlight(int)                              {}
+func (*noopMetrics) RecordBatchTxConfirmationLatency(time.Duration)          {}
+
+func (*noopMetrics) RecordBatchDataPosted(flags.DataAvailabilityType, int) {}
+func (*noopMetrics) RecordBlobFeePaid(uint64, *big.Int)                    {}
+
+func (*noopMetrics) RecordSubmissionPaused(bool, time.Duration) {}
+
+func (*noopMetrics) RecordSequencerStalled(bool) {}
+func (*noopMetrics) RecordSafeHeadLagging(bool)  {}
+
+func (*noopMetrics) StartBalanceMonitor(log.Logger, opmetrics.BalanceClient, []opmetrics.BalanceAccount, *big.Int, time.Duration) io.Closer {
 	return nil
 }
@@ -3,9 +3,12 @@ package rpc
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
 )
@@ -13,6 +16,38 @@ import (
 type BatcherDriver interface {
 	StartBatchSubmitting() error
 	StopBatchSubmitting(ctx context.Context) error
+	PauseBatchSubmitting()
+	ResumeBatchSubmitting()
+	FlushBatchSubmitting(ctx context.Context) error
+	BatcherStatus() *BatcherStatus
+}
+
+// BatcherStatus is the runtime status of the batcher.
+type BatcherStatus struct {
+	// Running is true if the batch submission loop runs.
+	Running bool `json:"running"`
+	// Paused is true if the submission of new batcher txs is paused by the admin.
+	Paused bool `json:"paused"`
+	// FirstPendingBlock and LastPendingBlock are the range of loaded L2 blocks that are not fully
+	// submitted yet. They are zero if NumPendingBlocks is 0.
+	FirstPendingBlock eth.BlockID `json:"first_pending_block"`
+	LastPendingBlock  eth.BlockID `json:"last_pending_block"`
+	NumPendingBlocks  int         `json:"num_pending_blocks"`
+	// OpenChannel is the channel that new blocks are added to, nil if there is none.
+	OpenChannel *ChannelStatus `json:"open_channel"`
+	// LastSubmittedTx is the hash of the last confirmed batcher tx, zero if there is none yet.
+	LastSubmittedTx common.Hash `json:"last_submitted_tx"`
+}
+
+// ChannelStatus are the stats of a channel of the batcher.
+type ChannelStatus struct {
+	ID            derive.ChannelID `json:"id"`
+	Blocks        int              `json:"blocks"`
+	InputBytes    int              `json:"input_bytes"`
+	OutputBytes   int              `json:"output_bytes"`
+	PendingFrames int              `json:"pending_frames"`
+	TotalFrames   int              `json:"total_frames"`
+	Full          bool             `json:"full"`
 }
 
 type adminAPI struct {
@@ -41,3 +76,24 @@ func (a *adminAPI) StartBatcher(_ context.Context) error {
 func (a *adminAPI) StopBatcher(ctx context.Context) error {
 	return a.b.StopBatchSubmitting(ctx)
 }
+
+// PauseBatcher pauses the submission of new batcher txs, while blocks keep being loaded.
+// The pause lasts until ResumeBatcher is called, also across restarts of the batcher.
+func (a *adminAPI) PauseBatcher(_ context.Context) error {
+	a.b.PauseBatchSubmitting()
+	return nil
+}
+
+func (a *adminAPI) ResumeBatcher(_ context.Context) error {
+	a.b.ResumeBatchSubmitting()
+	return nil
+}
+
+// Flush closes the open channel and submits all loaded blocks immediately.
+func (a *adminAPI) Flush(ctx context.Context) error {
+	return a.b.FlushBatchSubmitting(ctx)
+}
+
+func (a *adminAPI) Status(_ context.Context) (*BatcherStatus, error) {
+	return a.b.BatcherStatus(), nil
+}
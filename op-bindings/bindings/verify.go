@@ -0,0 +1,198 @@
+package bindings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// CodeReader reads the code of contracts. It is implemented by ethclient.Client.
+type CodeReader interface {
+	CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// ImmutableValue is the value of an immutable in the code of a contract.
+type ImmutableValue struct {
+	Offset int         `json:"offset"`
+	Value  common.Hash `json:"value"`
+}
+
+// VerifyResult is the comparison of the code of a contract to the embedded deployed bytecode.
+type VerifyResult struct {
+	// Name is the name of the contract in the deployment, Contract the name of the embedded deployed bytecode.
+	// The names differ for proxies.
+	Name     string         `json:"name"`
+	Contract string         `json:"contract"`
+	Address  common.Address `json:"address"`
+	Match    bool           `json:"match"`
+	// MismatchOffset is the first offset at which the code differs from the deployed bytecode, outside of
+	// the immutables. It is -1 if the code matches.
+	MismatchOffset int `json:"mismatchOffset"`
+	CodeSize       int `json:"codeSize"`
+	ExpectedSize   int `json:"expectedSize"`
+	// Immutables are the values of the immutables in the code, if the size of the code matches.
+	Immutables []ImmutableValue `json:"immutables,omitempty"`
+}
+
+func (r *VerifyResult) String() string {
+	if r.Match {
+		return fmt.Sprintf("%s (%s): code matches %s", r.Name, r.Address, r.Contract)
+	}
+	return fmt.Sprintf("%s (%s): code differs from %s at offset %d, size %d, expected %d",
+		r.Name, r.Address, r.Contract, r.MismatchOffset, r.CodeSize, r.ExpectedSize)
+}
+
+// ImmutableOffsets returns the offsets of the immutables in the deployed bytecode of a contract by name.
+// The immutables are zero in the deployed bytecode, and are read with a PUSH32. Contracts of which the
+// immutable references are empty have none.
+func ImmutableOffsets(name string) ([]int, error) {
+	deployed, err := GetDeployedBytecode(name)
+	if err != nil {
+		return nil, err
+	}
+	if has, err := HasImmutableReferences(name); err != nil || !has {
+		return nil, nil
+	}
+	var offsets []int
+	for pc := 0; pc < len(deployed); pc++ {
+		op := vm.OpCode(deployed[pc])
+		if !op.IsPush() {
+			continue
+		}
+		size := int(op - vm.PUSH0)
+		if op == vm.PUSH32 && pc+1+size <= len(deployed) && isZero(deployed[pc+1:pc+1+size]) {
+			offsets = append(offsets, pc+1)
+		}
+		pc += size
+	}
+	return offsets, nil
+}
+
+// VerifyCode compares the code to the deployed bytecode of a contract by name, ignoring the immutables.
+func VerifyCode(name string, code []byte) (*VerifyResult, error) {
+	deployed, err := GetDeployedBytecode(name)
+	if err != nil {
+		return nil, err
+	}
+	offsets, err := ImmutableOffsets(name)
+	if err != nil {
+		return nil, err
+	}
+	result := &VerifyResult{
+		Name:           name,
+		Contract:       name,
+		MismatchOffset: -1,
+		CodeSize:       len(code),
+		ExpectedSize:   len(deployed),
+	}
+
+	normalized := make([]byte, len(code))
+	copy(normalized, code)
+	if len(code) == len(deployed) {
+		for _, offset := range offsets {
+			value := normalized[offset : offset+common.HashLength]
+			result.Immutables = append(result.Immutables, ImmutableValue{Offset: offset, Value: common.BytesToHash(value)})
+			clear(value)
+		}
+	}
+	for i := 0; i < len(deployed) && i < len(normalized); i++ {
+		if deployed[i] != normalized[i] {
+			result.MismatchOffset = i
+			return result, nil
+		}
+	}
+	if len(deployed) != len(normalized) {
+		result.MismatchOffset = min(len(deployed), len(normalized))
+		return result, nil
+	}
+	result.Match = true
+	return result, nil
+}
+
+// VerifyContract compares the code at the address, at the latest block, to the deployed bytecode of a contract by name.
+// An error is only returned if the code cannot be read, or if there is no deployed bytecode of the contract.
+func VerifyContract(ctx context.Context, client CodeReader, name string, address common.Address) (*VerifyResult, error) {
+	code, err := client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read code of %s: %w", name, err)
+	}
+	result, err := VerifyCode(name, code)
+	if err != nil {
+		return nil, err
+	}
+	result.Address = address
+	return result, nil
+}
+
+// deploymentProxies are the proxies of the L1 deployment that are not a Proxy.
+var deploymentProxies = map[string]string{
+	"L1CrossDomainMessengerProxy": "ResolvedDelegateProxy",
+	"L1StandardBridgeProxy":       "L1ChugSplashProxy",
+}
+
+// DeploymentContract returns the name of the contract of which the deployed bytecode is expected
+// at a contract of a deployment. Proxies, of which the names end with Proxy, are expected to be a Proxy,
+// apart from the legacy proxies of the L1CrossDomainMessenger and the L1StandardBridge.
+func DeploymentContract(name string) string {
+	if contract, ok := deploymentProxies[name]; ok {
+		return contract
+	}
+	if _, ok := deployedBytecodes[name]; !ok && strings.HasSuffix(name, "Proxy") {
+		return "Proxy"
+	}
+	return name
+}
+
+// ReadDeploymentAddresses reads the addresses of a deployment, a JSON object of contract names to addresses.
+func ReadDeploymentAddresses(path string) (map[string]common.Address, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read deployment addresses: %w", err)
+	}
+	var addresses map[string]common.Address
+	if err := json.Unmarshal(data, &addresses); err != nil {
+		return nil, fmt.Errorf("cannot decode deployment addresses %s: %w", path, err)
+	}
+	return addresses, nil
+}
+
+// VerifyDeployment verifies the code of the contracts of a deployment, in order of name. Contracts of which there
+// is no deployed bytecode, like the L1ChugSplashProxy and the ResolvedDelegateProxy, and unset addresses are skipped.
+func VerifyDeployment(ctx context.Context, client CodeReader, addresses map[string]common.Address) ([]*VerifyResult, error) {
+	names := make([]string, 0, len(addresses))
+	for name := range addresses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var results []*VerifyResult
+	for _, name := range names {
+		contract := DeploymentContract(name)
+		if _, ok := deployedBytecodes[contract]; !ok || addresses[name] == (common.Address{}) {
+			continue
+		}
+		result, err := VerifyContract(ctx, client, contract, addresses[name])
+		if err != nil {
+			return nil, err
+		}
+		result.Name = name
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -24,6 +24,14 @@ func main() {
 				Required: true,
 				Usage:    "File system path to the deploy config",
 			},
+			&cli.StringFlag{
+				Name:  "overlay",
+				Usage: "File system path to a deploy config of a network, which overrides the fields of the deploy config",
+			},
+			&cli.BoolFlag{
+				Name:  "print-config",
+				Usage: "Print the fields of the layered deploy config, with the layer that each field was loaded from",
+			},
 		},
 		Action: entrypoint,
 	}
@@ -39,10 +47,16 @@ func entrypoint(ctx *cli.Context) error {
 	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
 	log.Info("Checking deploy config", "name", name, "path", path)
 
-	config, err := genesis.NewDeployConfig(path)
+	// The fields can be overridden by DEPLOY_CONFIG_<FIELD> environment variables
+	config, provenance, err := genesis.LoadDeployConfig(path, ctx.String("overlay"), os.Environ())
 	if err != nil {
 		return err
 	}
+	if ctx.Bool("print-config") {
+		if err := provenance.Write(os.Stdout); err != nil {
+			return err
+		}
+	}
 
 	// Check the config, no need to call `CheckAddresses()`
 	if err := config.Check(); err != nil {
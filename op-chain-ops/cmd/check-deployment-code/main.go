@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+func main() {
+	log.Root().SetHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(isatty.IsTerminal(os.Stderr.Fd()))))
+
+	app := &cli.App{
+		Name:  "check-deployment-code",
+		Usage: "Check the code of the contracts of a deployment against the deployed bytecode of the bindings",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "deployment",
+				Usage:    "File system path to the deployment addresses, a JSON object of contract names to addresses",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "rpc-url",
+				Usage:    "RPC URL of the chain of the deployment",
+				EnvVars:  []string{"RPC_URL"},
+				Required: true,
+			},
+		},
+		Action: entrypoint,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("error checking deployment code", "err", err)
+	}
+}
+
+func entrypoint(ctx *cli.Context) error {
+	addresses, err := bindings.ReadDeploymentAddresses(ctx.String("deployment"))
+	if err != nil {
+		return err
+	}
+	client, err := ethclient.DialContext(ctx.Context, ctx.String("rpc-url"))
+	if err != nil {
+		return fmt.Errorf("cannot dial RPC: %w", err)
+	}
+	defer client.Close()
+
+	results, err := bindings.VerifyDeployment(ctx.Context, client, addresses)
+	if err != nil {
+		return err
+	}
+	mismatches := 0
+	for _, result := range results {
+		if !result.Match {
+			mismatches++
+			log.Warn("Code mismatch", "name", result.Name, "address", result.Address, "contract", result.Contract,
+				"offset", result.MismatchOffset, "size", result.CodeSize, "expectedSize", result.ExpectedSize)
+			continue
+		}
+		log.Info("Code matches", "name", result.Name, "address", result.Address, "contract", result.Contract, "immutables", len(result.Immutables))
+		for _, immutable := range result.Immutables {
+			log.Debug("Immutable", "name", result.Name, "offset", immutable.Offset, "value", immutable.Value)
+		}
+	}
+	if mismatches > 0 {
+		return fmt.Errorf("%d of %d contracts do not match the bindings", mismatches, len(results))
+	}
+	log.Info("All contracts match the bindings", "contracts", len(results))
+	return nil
+}
@@ -296,26 +296,24 @@ func getRandomSignedTransaction(ctx context.Context, ethClient *ethclient.Client
 
 // confirmTransaction polls receipts to confirm transaction is included in the block.
 func confirmTransaction(ctx context.Context, ethClient *ethclient.Client, l2BlockTime uint64, txHash common.Hash) (eth.BlockID, error) {
-	var retryCount uint64
-	for {
-		receipt, _ := ethClient.TransactionReceipt(ctx, txHash)
-		if retryCount > 30 {
-			return eth.BlockID{}, fmt.Errorf("transaction confirmation failure: txHash: %s", txHash.String())
-		}
-		if receipt == nil {
+	// wait at least l2 block time between attempts
+	strategy := retry.Fixed(time.Duration(l2BlockTime) * time.Second)
+	receipt, err := retry.Do(ctx, 32, strategy, func() (*types.Receipt, error) {
+		receipt, err := ethClient.TransactionReceipt(ctx, txHash)
+		if err != nil {
 			log.Info("Waiting for transaction receipt", "txHash", txHash.String())
-			retryCount++
-			// wait at least l2 block time
-			time.Sleep(time.Duration(l2BlockTime) * time.Second)
-			continue
-		}
-		block := eth.BlockID{
-			Hash:   receipt.BlockHash,
-			Number: receipt.BlockNumber.Uint64(),
 		}
-		log.Info("Transaction receipt found", "block", block, "status", receipt.Status)
-		return block, nil
+		return receipt, err
+	})
+	if err != nil {
+		return eth.BlockID{}, fmt.Errorf("transaction confirmation failure: txHash: %s: %w", txHash.String(), err)
+	}
+	block := eth.BlockID{
+		Hash:   receipt.BlockHash,
+		Number: receipt.BlockNumber.Uint64(),
 	}
+	log.Info("Transaction receipt found", "block", block, "status", receipt.Status)
+	return block, nil
 }
 
 // checkConsolidation sends transactions and ensures them to be included in unsafe block.
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+func main() {
+	log.Root().SetHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(isatty.IsTerminal(os.Stderr.Fd()))))
+
+	app := &cli.App{
+		Name:  "check-genesis",
+		Usage: "Fingerprint the alloc of a genesis, and diff it against the alloc of another genesis",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "genesis",
+				Required: true,
+				Usage:    "File system path to the genesis, or to a genesis alloc",
+			},
+			&cli.StringFlag{
+				Name:  "other",
+				Usage: "File system path to another genesis or genesis alloc, to diff the allocs",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "text",
+				Usage: "Output format of the diff, text or json",
+			},
+			&cli.BoolFlag{
+				Name:  "names",
+				Usage: "Name the predeploys and their known storage slots in the diff",
+			},
+		},
+		Action: entrypoint,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("error checking genesis", "err", err)
+	}
+}
+
+func entrypoint(ctx *cli.Context) error {
+	format := ctx.String("format")
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	alloc, err := readAlloc(ctx.String("genesis"))
+	if err != nil {
+		return err
+	}
+	fingerprint := genesis.AllocFingerprint(alloc)
+	log.Info("Genesis alloc", "path", ctx.String("genesis"), "accounts", len(alloc), "fingerprint", fingerprint.Hex())
+
+	if !ctx.IsSet("other") {
+		return nil
+	}
+	other, err := readAlloc(ctx.String("other"))
+	if err != nil {
+		return err
+	}
+	otherFingerprint := genesis.AllocFingerprint(other)
+	log.Info("Genesis alloc", "path", ctx.String("other"), "accounts", len(other), "fingerprint", otherFingerprint.Hex())
+	if fingerprint == otherFingerprint {
+		log.Info("Genesis allocs are equal")
+		return nil
+	}
+
+	var names *genesis.DiffNames
+	if ctx.Bool("names") {
+		if names, err = genesis.PredeployDiffNames(); err != nil {
+			return fmt.Errorf("cannot name the predeploys: %w", err)
+		}
+	}
+	diff := genesis.DiffAllocs(alloc, other, names)
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(diff)
+	} else {
+		err = diff.WriteText(os.Stdout)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot write diff: %w", err)
+	}
+	return fmt.Errorf("%d accounts differ between the genesis allocs", len(diff.Accounts))
+}
+
+// readAlloc reads the alloc of a genesis, or a genesis alloc on its own.
+func readAlloc(path string) (core.GenesisAlloc, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read genesis: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("cannot decode genesis %s: %w", path, err)
+	}
+	if _, ok := fields["alloc"]; ok {
+		var gen core.Genesis
+		if err := json.Unmarshal(data, &gen); err != nil {
+			return nil, fmt.Errorf("cannot decode genesis %s: %w", path, err)
+		}
+		return gen.Alloc, nil
+	}
+	var alloc core.GenesisAlloc
+	if err := json.Unmarshal(data, &alloc); err != nil {
+		return nil, fmt.Errorf("cannot decode genesis alloc %s: %w", path, err)
+	}
+	return alloc, nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+func main() {
+	log.Root().SetHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(isatty.IsTerminal(os.Stderr.Fd()))))
+
+	app := &cli.App{
+		Name:  "check-predeploys",
+		Usage: "Check the code and the proxy configuration of the L2 predeploys, and report the fee vaults, of a genesis or a live L2",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "genesis",
+				Usage: "File system path to the L2 genesis to check",
+			},
+			&cli.StringFlag{
+				Name:    "l2-rpc-url",
+				Usage:   "L2 RPC URL, to check the predeploys at the latest block instead of a genesis",
+				EnvVars: []string{"L2_RPC_URL"},
+			},
+			&cli.StringFlag{
+				Name:  "deploy-config",
+				Usage: "File system path to the deploy config, to check the immutables and the optional predeploys",
+			},
+		},
+		Action: entrypoint,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("error checking predeploys", "err", err)
+	}
+}
+
+func entrypoint(ctx *cli.Context) error {
+	if ctx.IsSet("genesis") == ctx.IsSet("l2-rpc-url") {
+		return errors.New("exactly one of --genesis and --l2-rpc-url must be set")
+	}
+
+	var config *genesis.PredeployCheckConfig
+	if ctx.IsSet("deploy-config") {
+		deployConfig, err := genesis.NewDeployConfig(ctx.String("deploy-config"))
+		if err != nil {
+			return err
+		}
+		if config, err = genesis.NewPredeployCheckConfig(deployConfig); err != nil {
+			return fmt.Errorf("cannot create the expected predeploys: %w", err)
+		}
+	}
+
+	var discrepancies []genesis.PredeployDiscrepancy
+	var caller bind.ContractCaller
+	if ctx.IsSet("genesis") {
+		gen, err := readGenesis(ctx.String("genesis"))
+		if err != nil {
+			return err
+		}
+		if discrepancies, err = genesis.CheckPredeploysInAlloc(gen.Alloc, config); err != nil {
+			return err
+		}
+		// the fee vaults are read by calls to the predeploys of the genesis
+		backend := backends.NewSimulatedBackend(gen.Alloc, gen.GasLimit)
+		defer backend.Close()
+		caller = backend
+	} else {
+		client, err := ethclient.DialContext(ctx.Context, ctx.String("l2-rpc-url"))
+		if err != nil {
+			return fmt.Errorf("cannot dial L2: %w", err)
+		}
+		defer client.Close()
+		if discrepancies, err = genesis.CheckPredeploys(ctx.Context, client, config); err != nil {
+			return err
+		}
+		caller = client
+	}
+
+	feeVaults, err := genesis.ReadFeeVaultConfigs(ctx.Context, caller)
+	if err != nil {
+		log.Warn("Cannot read the fee vaults", "err", err)
+	}
+	for _, vault := range feeVaults {
+		log.Info("Fee vault", "name", vault.Name, "address", vault.Address, "recipient", vault.Recipient,
+			"minWithdrawalAmount", vault.MinWithdrawalAmount.ToInt(), "withdrawalNetwork", vault.WithdrawalNetwork)
+	}
+
+	for _, d := range discrepancies {
+		log.Warn("Predeploy discrepancy", "name", d.Name, "address", d.Address, "kind", d.Kind, "expected", d.Expected, "actual", d.Actual)
+	}
+	if len(discrepancies) > 0 {
+		return fmt.Errorf("%d predeploy discrepancies", len(discrepancies))
+	}
+	log.Info("All predeploys are configured as expected")
+	return nil
+}
+
+func readGenesis(path string) (*core.Genesis, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open genesis: %w", err)
+	}
+	defer f.Close()
+	var gen core.Genesis
+	if err := json.NewDecoder(f).Decode(&gen); err != nil {
+		return nil, fmt.Errorf("cannot decode genesis %s: %w", path, err)
+	}
+	return &gen, nil
+}
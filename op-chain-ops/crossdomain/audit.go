@@ -0,0 +1,263 @@
+package crossdomain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+)
+
+// LegacyMessageKind is the kind of a legacy cross domain message.
+type LegacyMessageKind string
+
+const (
+	// LegacyMessagePlain messages are not withdrawals through the legacy L2StandardBridge.
+	LegacyMessagePlain LegacyMessageKind = "message"
+	// LegacyETHWithdrawal messages finalize an ETH withdrawal through the legacy L2StandardBridge.
+	LegacyETHWithdrawal LegacyMessageKind = "ethWithdrawal"
+	// LegacyERC20Withdrawal messages finalize an ERC20 withdrawal through the legacy L2StandardBridge.
+	LegacyERC20Withdrawal LegacyMessageKind = "erc20Withdrawal"
+)
+
+// MessageAuditClass is the classification of a legacy cross domain message by the audit.
+// A message is classified by the first class that applies, in the order of MessageAuditClasses.
+type MessageAuditClass string
+
+const (
+	// MessageAuditRelayed messages were relayed on L1 before the migration.
+	MessageAuditRelayed MessageAuditClass = "relayed"
+	// MessageAuditBlockedTarget messages target a blocked address on L1.
+	MessageAuditBlockedTarget MessageAuditClass = "blockedTarget"
+	// MessageAuditUnencodable messages cannot be encoded as a Bedrock withdrawal.
+	MessageAuditUnencodable MessageAuditClass = "unencodable"
+	// MessageAuditMigratable messages are migrated to Bedrock withdrawals.
+	MessageAuditMigratable MessageAuditClass = "migratable"
+)
+
+// MessageAuditClasses are all the classes of the audit, in order of precedence.
+var MessageAuditClasses = []MessageAuditClass{
+	MessageAuditRelayed,
+	MessageAuditBlockedTarget,
+	MessageAuditUnencodable,
+	MessageAuditMigratable,
+}
+
+// LegacyMessageKinds are all the kinds of legacy cross domain messages.
+var LegacyMessageKinds = []LegacyMessageKind{
+	LegacyMessagePlain,
+	LegacyETHWithdrawal,
+	LegacyERC20Withdrawal,
+}
+
+// RelayedMessages tells whether legacy cross domain messages were relayed on L1, by the hash of the message.
+type RelayedMessages interface {
+	IsRelayed(msgHash common.Hash) (bool, error)
+}
+
+// RelayedMessageSet is the set of the hashes of the relayed messages.
+type RelayedMessageSet map[common.Hash]bool
+
+func (s RelayedMessageSet) IsRelayed(msgHash common.Hash) (bool, error) {
+	return s[msgHash], nil
+}
+
+// StorageReader reads the storage of the L1 state. It is implemented by vm.StateDB.
+type StorageReader interface {
+	GetState(addr common.Address, key common.Hash) common.Hash
+}
+
+// storageRelayedMessages reads the successfulMessages mapping of the L1CrossDomainMessenger.
+type storageRelayedMessages struct {
+	db        StorageReader
+	messenger common.Address
+	slot      common.Hash
+}
+
+// NewStorageRelayedMessages reads the relayed messages from the successfulMessages mapping in the storage of the
+// L1CrossDomainMessenger, which has the same slot in the legacy messenger.
+func NewStorageRelayedMessages(db StorageReader, l1CrossDomainMessenger common.Address) (RelayedMessages, error) {
+	layout, err := bindings.GetStorageLayout("L1CrossDomainMessenger")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range layout.Storage {
+		if entry.Label == "successfulMessages" {
+			return &storageRelayedMessages{
+				db:        db,
+				messenger: l1CrossDomainMessenger,
+				slot:      common.BigToHash(new(big.Int).SetUint64(uint64(entry.Slot))),
+			}, nil
+		}
+	}
+	return nil, errors.New("successfulMessages is not in the storage layout of the L1CrossDomainMessenger")
+}
+
+func (s *storageRelayedMessages) IsRelayed(msgHash common.Hash) (bool, error) {
+	key := crypto.Keccak256Hash(msgHash.Bytes(), s.slot.Bytes())
+	return s.db.GetState(s.messenger, key) == abiTrue, nil
+}
+
+// MessageAuditConfig is the configuration of the audit of legacy cross domain messages.
+type MessageAuditConfig struct {
+	// L1CrossDomainMessenger is the target of the migrated withdrawals.
+	L1CrossDomainMessenger common.Address
+	// ChainID is the L2 chain ID, which determines the gas limit of the migrated withdrawals.
+	ChainID *big.Int
+	// Relayed tells which messages were relayed on L1. Without it, no message is relayed.
+	Relayed RelayedMessages
+	// BlockedTargets are the L1 addresses that messages must not be relayed to.
+	BlockedTargets map[common.Address]bool
+}
+
+// MessageAudit is the classification of a legacy cross domain message.
+type MessageAudit struct {
+	LegacyHash  common.Hash       `json:"legacyHash"`
+	MessageHash common.Hash       `json:"messageHash"`
+	Nonce       *big.Int          `json:"nonce"`
+	Target      common.Address    `json:"target"`
+	Kind        LegacyMessageKind `json:"kind"`
+	Class       MessageAuditClass `json:"class"`
+	Relayed     bool              `json:"relayed"`
+	Blocked     bool              `json:"blocked"`
+	// EncodingError is why the message cannot be encoded as a Bedrock withdrawal.
+	EncodingError string `json:"encodingError,omitempty"`
+	// WithdrawalHash is the hash of the Bedrock withdrawal of the message, if it can be encoded.
+	WithdrawalHash *common.Hash `json:"withdrawalHash,omitempty"`
+	// Withdrawal is the Bedrock withdrawal of the message. It is only set for migratable messages.
+	Withdrawal *Withdrawal `json:"withdrawal,omitempty"`
+}
+
+// MessageAuditReport is the audit of legacy cross domain messages, ordered by nonce and legacy hash.
+type MessageAuditReport struct {
+	Counts   map[MessageAuditClass]int `json:"counts"`
+	Kinds    map[LegacyMessageKind]int `json:"kinds"`
+	Messages []MessageAudit            `json:"messages"`
+}
+
+// Withdrawals returns the Bedrock withdrawals of the migratable messages, in the order of the report.
+func (r *MessageAuditReport) Withdrawals() []*Withdrawal {
+	var withdrawals []*Withdrawal
+	for _, audit := range r.Messages {
+		if audit.Class == MessageAuditMigratable {
+			withdrawals = append(withdrawals, audit.Withdrawal)
+		}
+	}
+	return withdrawals
+}
+
+// AuditLegacyMessages classifies the legacy cross domain messages, and encodes the migratable ones as
+// Bedrock withdrawals. An error is only returned when a message cannot be hashed, or the relayed messages
+// cannot be read.
+func AuditLegacyMessages(messages []*LegacyWithdrawal, cfg MessageAuditConfig) (*MessageAuditReport, error) {
+	if cfg.ChainID == nil {
+		return nil, errors.New("no chain ID")
+	}
+	report := &MessageAuditReport{
+		Counts:   make(map[MessageAuditClass]int, len(MessageAuditClasses)),
+		Kinds:    make(map[LegacyMessageKind]int, len(LegacyMessageKinds)),
+		Messages: make([]MessageAudit, 0, len(messages)),
+	}
+	for _, class := range MessageAuditClasses {
+		report.Counts[class] = 0
+	}
+	for _, kind := range LegacyMessageKinds {
+		report.Kinds[kind] = 0
+	}
+	for i, msg := range messages {
+		audit, err := auditLegacyMessage(msg, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("cannot audit message %d: %w", i, err)
+		}
+		report.Counts[audit.Class]++
+		report.Kinds[audit.Kind]++
+		report.Messages = append(report.Messages, audit)
+	}
+	sort.Slice(report.Messages, func(i, j int) bool {
+		a, b := report.Messages[i], report.Messages[j]
+		if c := a.Nonce.Cmp(b.Nonce); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(a.LegacyHash[:], b.LegacyHash[:]) < 0
+	})
+	return report, nil
+}
+
+func auditLegacyMessage(msg *LegacyWithdrawal, cfg MessageAuditConfig) (MessageAudit, error) {
+	if msg.XDomainNonce == nil {
+		return MessageAudit{}, errors.New("no nonce")
+	}
+	legacyHash, err := msg.Hash()
+	if err != nil {
+		return MessageAudit{}, err
+	}
+	msgHash, err := msg.CrossDomainMessage().Hash()
+	if err != nil {
+		return MessageAudit{}, err
+	}
+	audit := MessageAudit{
+		LegacyHash:  legacyHash,
+		MessageHash: msgHash,
+		Nonce:       msg.XDomainNonce,
+		Target:      msg.XDomainTarget,
+		Kind:        legacyMessageKind(msg),
+		Blocked:     cfg.BlockedTargets[msg.XDomainTarget],
+	}
+	if cfg.Relayed != nil {
+		if audit.Relayed, err = cfg.Relayed.IsRelayed(msgHash); err != nil {
+			return MessageAudit{}, fmt.Errorf("cannot read whether message %s was relayed: %w", msgHash, err)
+		}
+	}
+
+	withdrawal, err := MigrateWithdrawal(msg, &cfg.L1CrossDomainMessenger, cfg.ChainID)
+	if err != nil {
+		audit.EncodingError = err.Error()
+	} else {
+		hash, err := withdrawal.Hash()
+		if err != nil {
+			return MessageAudit{}, err
+		}
+		audit.WithdrawalHash = &hash
+	}
+
+	switch {
+	case audit.Relayed:
+		audit.Class = MessageAuditRelayed
+	case audit.Blocked:
+		audit.Class = MessageAuditBlockedTarget
+	case audit.EncodingError != "":
+		audit.Class = MessageAuditUnencodable
+	default:
+		audit.Class = MessageAuditMigratable
+		audit.Withdrawal = withdrawal
+	}
+	return audit, nil
+}
+
+// legacyMessageKind returns whether the message is a withdrawal through the legacy L2StandardBridge.
+func legacyMessageKind(msg *LegacyWithdrawal) LegacyMessageKind {
+	if msg.XDomainSender != predeploys.L2StandardBridgeAddr || len(msg.XDomainData) < 4 {
+		return LegacyMessagePlain
+	}
+	abi, err := bindings.L1StandardBridgeMetaData.GetAbi()
+	if err != nil {
+		return LegacyMessagePlain
+	}
+	method, err := abi.MethodById(msg.XDomainData[:4])
+	if err != nil {
+		return LegacyMessagePlain
+	}
+	switch method.Name {
+	case "finalizeETHWithdrawal":
+		return LegacyETHWithdrawal
+	case "finalizeERC20Withdrawal":
+		return LegacyERC20Withdrawal
+	}
+	return LegacyMessagePlain
+}
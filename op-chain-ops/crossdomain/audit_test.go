@@ -0,0 +1,143 @@
+package crossdomain_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/crossdomain"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/state"
+)
+
+func TestAuditLegacyMessages(t *testing.T) {
+	bridgeABI, err := bindings.L1StandardBridgeMetaData.GetAbi()
+	require.NoError(t, err)
+	ethData, err := bridgeABI.Pack("finalizeETHWithdrawal", common.Address{0xaa}, common.Address{0xaa}, big.NewInt(1000), []byte{})
+	require.NoError(t, err)
+	erc20Data, err := bridgeABI.Pack("finalizeERC20Withdrawal", common.Address{0x11}, common.Address{0x22}, common.Address{0xaa}, common.Address{0xaa}, big.NewInt(5), []byte{})
+	require.NoError(t, err)
+	selector := bridgeABI.Methods["finalizeETHWithdrawal"].ID
+
+	l1Bridge := common.HexToAddress("0x99C9fc46f92E8a1c0deC1b1747d010903E884bE1")
+	l1Messenger := common.HexToAddress("0x25ace71c97B33Cc4729CF772ae268934F7ab5fA1")
+	l2Messenger := predeploys.L2CrossDomainMessengerAddr
+	blocked := common.Address{0x0b}
+	message := crossdomain.NewLegacyWithdrawal(l2Messenger, common.Address{0x01}, common.Address{0x02}, []byte{0xde, 0xad}, big.NewInt(0))
+	ethWithdrawal := crossdomain.NewLegacyWithdrawal(l2Messenger, l1Bridge, predeploys.L2StandardBridgeAddr, ethData, big.NewInt(1))
+	erc20Withdrawal := crossdomain.NewLegacyWithdrawal(l2Messenger, l1Bridge, predeploys.L2StandardBridgeAddr, erc20Data, big.NewInt(2))
+	relayed := crossdomain.NewLegacyWithdrawal(l2Messenger, l1Bridge, predeploys.L2StandardBridgeAddr, ethData, big.NewInt(3))
+	blockedTarget := crossdomain.NewLegacyWithdrawal(l2Messenger, blocked, common.Address{0x02}, nil, big.NewInt(4))
+	unencodable := crossdomain.NewLegacyWithdrawal(l2Messenger, l1Bridge, predeploys.L2StandardBridgeAddr, append(selector, 0x01), big.NewInt(5))
+	messages := []*crossdomain.LegacyWithdrawal{unencodable, blockedTarget, relayed, erc20Withdrawal, ethWithdrawal, message}
+
+	// the relayed message is in the successfulMessages mapping of the L1CrossDomainMessenger
+	relayedHash, err := relayed.CrossDomainMessage().Hash()
+	require.NoError(t, err)
+	db := state.NewMemoryStateDB(nil)
+	db.CreateAccount(l1Messenger)
+	db.SetState(l1Messenger, crypto.Keccak256Hash(relayedHash.Bytes(), common.BigToHash(big.NewInt(203)).Bytes()), common.Hash{31: 0x01})
+	relayedMessages, err := crossdomain.NewStorageRelayedMessages(db, l1Messenger)
+	require.NoError(t, err)
+
+	cfg := crossdomain.MessageAuditConfig{
+		L1CrossDomainMessenger: l1Messenger,
+		ChainID:                big.NewInt(10),
+		Relayed:                relayedMessages,
+		BlockedTargets:         map[common.Address]bool{blocked: true},
+	}
+	report, err := crossdomain.AuditLegacyMessages(messages, cfg)
+	require.NoError(t, err)
+
+	require.Equal(t, map[crossdomain.MessageAuditClass]int{
+		crossdomain.MessageAuditRelayed:       1,
+		crossdomain.MessageAuditBlockedTarget: 1,
+		crossdomain.MessageAuditUnencodable:   1,
+		crossdomain.MessageAuditMigratable:    3,
+	}, report.Counts)
+	require.Equal(t, map[crossdomain.LegacyMessageKind]int{
+		crossdomain.LegacyMessagePlain:    2,
+		crossdomain.LegacyETHWithdrawal:   3,
+		crossdomain.LegacyERC20Withdrawal: 1,
+	}, report.Kinds)
+
+	// the messages are ordered by nonce
+	expected := []struct {
+		class crossdomain.MessageAuditClass
+		kind  crossdomain.LegacyMessageKind
+	}{
+		{crossdomain.MessageAuditMigratable, crossdomain.LegacyMessagePlain},
+		{crossdomain.MessageAuditMigratable, crossdomain.LegacyETHWithdrawal},
+		{crossdomain.MessageAuditMigratable, crossdomain.LegacyERC20Withdrawal},
+		{crossdomain.MessageAuditRelayed, crossdomain.LegacyETHWithdrawal},
+		{crossdomain.MessageAuditBlockedTarget, crossdomain.LegacyMessagePlain},
+		{crossdomain.MessageAuditUnencodable, crossdomain.LegacyETHWithdrawal},
+	}
+	require.Len(t, report.Messages, len(expected))
+	for i, audit := range report.Messages {
+		require.EqualValues(t, i, audit.Nonce.Int64())
+		require.Equal(t, expected[i].class, audit.Class, "message %d", i)
+		require.Equal(t, expected[i].kind, audit.Kind, "message %d", i)
+	}
+	require.True(t, report.Messages[3].Relayed)
+	require.Equal(t, relayedHash, report.Messages[3].MessageHash)
+	require.True(t, report.Messages[4].Blocked)
+	require.NotEmpty(t, report.Messages[5].EncodingError)
+	require.Nil(t, report.Messages[5].WithdrawalHash)
+
+	// the withdrawals of the migratable messages are the migrated withdrawals
+	withdrawals := report.Withdrawals()
+	require.Len(t, withdrawals, 3)
+	for i, msg := range []*crossdomain.LegacyWithdrawal{message, ethWithdrawal, erc20Withdrawal} {
+		migrated, err := crossdomain.MigrateWithdrawal(msg, &l1Messenger, cfg.ChainID)
+		require.NoError(t, err)
+		require.Equal(t, migrated, withdrawals[i])
+		hash, err := migrated.Hash()
+		require.NoError(t, err)
+		require.Equal(t, hash, *report.Messages[i].WithdrawalHash)
+	}
+	require.Equal(t, big.NewInt(1000), withdrawals[1].Value)
+
+	t.Run("deterministic", func(t *testing.T) {
+		reversed := make([]*crossdomain.LegacyWithdrawal, len(messages))
+		for i, msg := range messages {
+			reversed[len(messages)-1-i] = msg
+		}
+		again, err := crossdomain.AuditLegacyMessages(reversed, cfg)
+		require.NoError(t, err)
+		data, err := json.Marshal(report)
+		require.NoError(t, err)
+		againData, err := json.Marshal(again)
+		require.NoError(t, err)
+		require.Equal(t, string(data), string(againData))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(report)
+		require.NoError(t, err)
+		var decoded crossdomain.MessageAuditReport
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.Equal(t, report.Counts, decoded.Counts)
+		require.Equal(t, report.Kinds, decoded.Kinds)
+		require.Len(t, decoded.Withdrawals(), 3)
+		for i, withdrawal := range decoded.Withdrawals() {
+			hash, err := withdrawal.Hash()
+			require.NoError(t, err)
+			require.Equal(t, *report.Messages[i].WithdrawalHash, hash)
+		}
+	})
+
+	t.Run("without relayed messages", func(t *testing.T) {
+		cfg := cfg
+		cfg.Relayed = crossdomain.RelayedMessageSet{}
+		report, err := crossdomain.AuditLegacyMessages(messages, cfg)
+		require.NoError(t, err)
+		require.Equal(t, 4, report.Counts[crossdomain.MessageAuditMigratable])
+		require.Equal(t, 0, report.Counts[crossdomain.MessageAuditRelayed])
+	})
+}
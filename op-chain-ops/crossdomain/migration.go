@@ -0,0 +1,163 @@
+package crossdomain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+)
+
+// abiTrue is the storage value of a message that is set in a message passer.
+var abiTrue = common.Hash{31: 0x01}
+
+// MigrationClass is the classification of a legacy withdrawal by the migration.
+type MigrationClass string
+
+const (
+	// MigrationMigratable withdrawals are migrated to the L2ToL1MessagePasser.
+	MigrationMigratable MigrationClass = "migratable"
+	// MigrationAlreadyRelayed withdrawals were relayed on L1 before the migration.
+	MigrationAlreadyRelayed MigrationClass = "alreadyRelayed"
+	// MigrationInvalidTarget withdrawals were not sent through the L2CrossDomainMessenger,
+	// so they cannot be relayed by the L1CrossDomainMessenger.
+	MigrationInvalidTarget MigrationClass = "invalidTarget"
+	// MigrationUnparseable withdrawals have calldata of which the value cannot be parsed.
+	MigrationUnparseable MigrationClass = "unparseable"
+	// MigrationMissing withdrawals are not set in the legacy message passer of the state.
+	MigrationMissing MigrationClass = "missing"
+)
+
+// MigrationClasses are all the classes of the migration, in the order of the report.
+var MigrationClasses = []MigrationClass{
+	MigrationMigratable,
+	MigrationAlreadyRelayed,
+	MigrationInvalidTarget,
+	MigrationUnparseable,
+	MigrationMissing,
+}
+
+// MigrationConfig is the configuration of the migration of the legacy withdrawals.
+type MigrationConfig struct {
+	// L1CrossDomainMessenger is the target of the migrated withdrawals.
+	L1CrossDomainMessenger common.Address
+	// ChainID is the L2 chain ID, which determines the gas limit of the migrated withdrawals.
+	ChainID *big.Int
+	// RelayedMessages are the hashes of the legacy cross domain messages that were relayed on L1,
+	// as the successfulMessages of the L1CrossDomainMessenger.
+	RelayedMessages map[common.Hash]bool
+}
+
+// MigrationEntry is the classification of a legacy withdrawal.
+type MigrationEntry struct {
+	LegacyHash common.Hash    `json:"legacyHash"`
+	Class      MigrationClass `json:"class"`
+	Reason     string         `json:"reason,omitempty"`
+	// MigratedHash and MigratedSlot are the hash and the storage slot in the L2ToL1MessagePasser
+	// of the migrated withdrawal. They are only set for migratable withdrawals.
+	MigratedHash *common.Hash `json:"migratedHash,omitempty"`
+	MigratedSlot *common.Hash `json:"migratedSlot,omitempty"`
+}
+
+// MigrationReport is the dry run of the migration of legacy withdrawals: the classification of
+// every withdrawal, and the counts by classification.
+type MigrationReport struct {
+	Counts      map[MigrationClass]int `json:"counts"`
+	Withdrawals []MigrationEntry       `json:"withdrawals"`
+}
+
+// Migratable returns the entries of the withdrawals that are migrated.
+func (r *MigrationReport) Migratable() []MigrationEntry {
+	var entries []MigrationEntry
+	for _, entry := range r.Withdrawals {
+		if entry.Class == MigrationMigratable {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// PlanWithdrawalMigration classifies the legacy withdrawals against the pre-migration state in the db,
+// without writing to it. The report is applied to the state with ApplyWithdrawalMigration.
+func PlanWithdrawalMigration(db vm.StateDB, withdrawals []*LegacyWithdrawal, cfg MigrationConfig) (*MigrationReport, error) {
+	if cfg.ChainID == nil {
+		return nil, errors.New("no chain ID")
+	}
+	report := &MigrationReport{
+		Counts:      make(map[MigrationClass]int, len(MigrationClasses)),
+		Withdrawals: make([]MigrationEntry, 0, len(withdrawals)),
+	}
+	for _, class := range MigrationClasses {
+		report.Counts[class] = 0
+	}
+	for i, legacy := range withdrawals {
+		entry, err := classifyWithdrawal(db, legacy, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("cannot classify withdrawal %d: %w", i, err)
+		}
+		report.Counts[entry.Class]++
+		report.Withdrawals = append(report.Withdrawals, entry)
+	}
+	return report, nil
+}
+
+func classifyWithdrawal(db vm.StateDB, legacy *LegacyWithdrawal, cfg MigrationConfig) (MigrationEntry, error) {
+	legacyHash, err := legacy.Hash()
+	if err != nil {
+		return MigrationEntry{}, err
+	}
+	entry := MigrationEntry{LegacyHash: legacyHash}
+
+	legacySlot, err := legacy.StorageSlot()
+	if err != nil {
+		return MigrationEntry{}, err
+	}
+	if db.GetState(predeploys.LegacyMessagePasserAddr, legacySlot) != abiTrue {
+		entry.Class = MigrationMissing
+		entry.Reason = fmt.Sprintf("legacy storage slot %s is not set", legacySlot)
+		return entry, nil
+	}
+	if legacy.MessageSender != predeploys.L2CrossDomainMessengerAddr {
+		entry.Class = MigrationInvalidTarget
+		entry.Reason = fmt.Sprintf("sent by %s instead of the L2CrossDomainMessenger", legacy.MessageSender)
+		return entry, nil
+	}
+	msgHash, err := legacy.CrossDomainMessage().Hash()
+	if err != nil {
+		return MigrationEntry{}, err
+	}
+	if cfg.RelayedMessages[msgHash] {
+		entry.Class = MigrationAlreadyRelayed
+		entry.Reason = fmt.Sprintf("message %s was relayed", msgHash)
+		return entry, nil
+	}
+	withdrawal, err := MigrateWithdrawal(legacy, &cfg.L1CrossDomainMessenger, cfg.ChainID)
+	if err != nil {
+		entry.Class = MigrationUnparseable
+		entry.Reason = err.Error()
+		return entry, nil
+	}
+	hash, err := withdrawal.Hash()
+	if err != nil {
+		return MigrationEntry{}, err
+	}
+	slot := StorageSlotOfWithdrawalHash(hash)
+	entry.Class = MigrationMigratable
+	entry.MigratedHash = &hash
+	entry.MigratedSlot = &slot
+	return entry, nil
+}
+
+// ApplyWithdrawalMigration sets the migratable withdrawals of the report in the L2ToL1MessagePasser of the state.
+func ApplyWithdrawalMigration(db vm.StateDB, report *MigrationReport) error {
+	for _, entry := range report.Migratable() {
+		if entry.MigratedSlot == nil {
+			return fmt.Errorf("migratable withdrawal %s has no migrated slot", entry.LegacyHash)
+		}
+		db.SetState(predeploys.L2ToL1MessagePasserAddr, *entry.MigratedSlot, abiTrue)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package crossdomain_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/crossdomain"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/state"
+)
+
+func TestWithdrawalMigration(t *testing.T) {
+	bridgeABI, err := bindings.L1StandardBridgeMetaData.GetAbi()
+	require.NoError(t, err)
+	ethWithdrawal, err := bridgeABI.Pack("finalizeETHWithdrawal", common.Address{0xaa}, common.Address{0xaa}, big.NewInt(1000), []byte{})
+	require.NoError(t, err)
+	selector := bridgeABI.Methods["finalizeETHWithdrawal"].ID
+
+	l1Bridge := common.HexToAddress("0x99C9fc46f92E8a1c0deC1b1747d010903E884bE1")
+	l2Messenger := predeploys.L2CrossDomainMessengerAddr
+	messageWithdrawal := crossdomain.NewLegacyWithdrawal(l2Messenger, common.Address{0x01}, common.Address{0x02}, []byte{0xde, 0xad}, big.NewInt(0))
+	ethWithdrawalMsg := crossdomain.NewLegacyWithdrawal(l2Messenger, l1Bridge, predeploys.L2StandardBridgeAddr, ethWithdrawal, big.NewInt(1))
+	relayed := crossdomain.NewLegacyWithdrawal(l2Messenger, common.Address{0x01}, common.Address{0x02}, []byte{0xbe, 0xef}, big.NewInt(2))
+	invalidTarget := crossdomain.NewLegacyWithdrawal(common.Address{0x03}, common.Address{0x01}, common.Address{0x02}, nil, big.NewInt(3))
+	unparseable := crossdomain.NewLegacyWithdrawal(l2Messenger, l1Bridge, predeploys.L2StandardBridgeAddr, append(selector, 0x01), big.NewInt(4))
+	missing := crossdomain.NewLegacyWithdrawal(l2Messenger, common.Address{0x01}, common.Address{0x02}, nil, big.NewInt(5))
+	withdrawals := []*crossdomain.LegacyWithdrawal{messageWithdrawal, ethWithdrawalMsg, relayed, invalidTarget, unparseable, missing}
+
+	// the pre-migration state has every withdrawal but the missing one in the legacy message passer
+	db := state.NewMemoryStateDB(nil)
+	db.CreateAccount(predeploys.LegacyMessagePasserAddr)
+	db.CreateAccount(predeploys.L2ToL1MessagePasserAddr)
+	for _, w := range withdrawals[:len(withdrawals)-1] {
+		slot, err := w.StorageSlot()
+		require.NoError(t, err)
+		db.SetState(predeploys.LegacyMessagePasserAddr, slot, common.Hash{31: 0x01})
+	}
+
+	relayedHash, err := relayed.CrossDomainMessage().Hash()
+	require.NoError(t, err)
+	cfg := crossdomain.MigrationConfig{
+		L1CrossDomainMessenger: common.HexToAddress("0x25ace71c97B33Cc4729CF772ae268934F7ab5fA1"),
+		ChainID:                big.NewInt(10),
+		RelayedMessages:        map[common.Hash]bool{relayedHash: true},
+	}
+
+	report, err := crossdomain.PlanWithdrawalMigration(db, withdrawals, cfg)
+	require.NoError(t, err)
+	require.Equal(t, map[crossdomain.MigrationClass]int{
+		crossdomain.MigrationMigratable:     2,
+		crossdomain.MigrationAlreadyRelayed: 1,
+		crossdomain.MigrationInvalidTarget:  1,
+		crossdomain.MigrationUnparseable:    1,
+		crossdomain.MigrationMissing:        1,
+	}, report.Counts)
+	classes := make([]crossdomain.MigrationClass, len(report.Withdrawals))
+	for i, entry := range report.Withdrawals {
+		classes[i] = entry.Class
+		legacyHash, err := withdrawals[i].Hash()
+		require.NoError(t, err)
+		require.Equal(t, legacyHash, entry.LegacyHash)
+	}
+	require.Equal(t, []crossdomain.MigrationClass{
+		crossdomain.MigrationMigratable,
+		crossdomain.MigrationMigratable,
+		crossdomain.MigrationAlreadyRelayed,
+		crossdomain.MigrationInvalidTarget,
+		crossdomain.MigrationUnparseable,
+		crossdomain.MigrationMissing,
+	}, classes)
+
+	// the dry run does not write to the state
+	require.Empty(t, db.GetAccount(predeploys.L2ToL1MessagePasserAddr).Storage)
+
+	// the report is applied after a round trip through JSON
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	var decoded crossdomain.MigrationReport
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, report, &decoded)
+	require.NoError(t, crossdomain.ApplyWithdrawalMigration(db, &decoded))
+
+	expected := make(map[common.Hash]common.Hash)
+	for _, legacy := range []*crossdomain.LegacyWithdrawal{messageWithdrawal, ethWithdrawalMsg} {
+		migrated, err := crossdomain.MigrateWithdrawal(legacy, &cfg.L1CrossDomainMessenger, cfg.ChainID)
+		require.NoError(t, err)
+		slot, err := migrated.StorageSlot()
+		require.NoError(t, err)
+		expected[slot] = common.Hash{31: 0x01}
+	}
+	require.Equal(t, expected, db.GetAccount(predeploys.L2ToL1MessagePasserAddr).Storage)
+
+	migrated, err := crossdomain.MigrateWithdrawal(ethWithdrawalMsg, &cfg.L1CrossDomainMessenger, cfg.ChainID)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1000), migrated.Value, "ETH withdrawals keep their value")
+}
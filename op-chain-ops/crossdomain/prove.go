@@ -0,0 +1,104 @@
+package crossdomain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ProveWithdrawalArgs are the arguments of the proveWithdrawalTransaction function of the OptimismPortal,
+// in the order of the function parameters.
+type ProveWithdrawalArgs struct {
+	Tx              bindings.TypesWithdrawalTransaction
+	L2OutputIndex   *big.Int
+	OutputRootProof bindings.TypesOutputRootProof
+	// WithdrawalProof are the storage trie nodes that prove the withdrawal in the L2ToL1MessagePasser,
+	// from the root to the leaf.
+	WithdrawalProof [][]byte
+}
+
+// HashWithdrawal computes the hash of the withdrawal, as the L2ToL1MessagePasser stores it.
+func HashWithdrawal(w *Withdrawal) (common.Hash, error) {
+	return w.Hash()
+}
+
+// StorageSlotOfWithdrawalHash computes the storage slot of the L2ToL1MessagePasser that is set to true
+// for the withdrawal with the hash.
+func StorageSlotOfWithdrawalHash(hash common.Hash) common.Hash {
+	// The sentMessages mapping is the 0th storage slot of the L2ToL1MessagePasser, so the slot of
+	// the withdrawal is keccak256(hash ++ bytes32(0)).
+	preimage := make([]byte, 64)
+	copy(preimage, hash.Bytes())
+	return crypto.Keccak256Hash(preimage)
+}
+
+// BuildProveWithdrawalArgs assembles the arguments to prove the withdrawal on L1, against the L2 output
+// at l2OutputIndex in the L2OutputOracle, of which the outputRootProof is the preimage. The storageProof
+// must prove that the storage slot of the withdrawal is set, in the message passer storage root of the
+// output root proof.
+func BuildProveWithdrawalArgs(w *Withdrawal, l2OutputIndex *big.Int, outputRootProof bindings.TypesOutputRootProof, storageProof eth.StorageProofEntry) (ProveWithdrawalArgs, error) {
+	hash, err := HashWithdrawal(w)
+	if err != nil {
+		return ProveWithdrawalArgs{}, err
+	}
+	slot := StorageSlotOfWithdrawalHash(hash)
+	if storageProof.Key != slot {
+		return ProveWithdrawalArgs{}, fmt.Errorf("storage proof of slot %s is not of withdrawal slot %s", storageProof.Key, slot)
+	}
+	if storageProof.Value.ToInt().Sign() == 0 {
+		return ProveWithdrawalArgs{}, fmt.Errorf("withdrawal %s is not set in the storage proof", hash)
+	}
+	if err := verifyStorageProof(outputRootProof.MessagePasserStorageRoot, storageProof); err != nil {
+		return ProveWithdrawalArgs{}, fmt.Errorf("invalid storage proof of withdrawal %s: %w", hash, err)
+	}
+
+	trieNodes := make([][]byte, len(storageProof.Proof))
+	for i, node := range storageProof.Proof {
+		trieNodes[i] = node
+	}
+	return ProveWithdrawalArgs{
+		Tx:              w.WithdrawalTransaction(),
+		L2OutputIndex:   l2OutputIndex,
+		OutputRootProof: outputRootProof,
+		WithdrawalProof: trieNodes,
+	}, nil
+}
+
+// verifyStorageProof verifies the value of the storage proof in the storage trie with the root.
+func verifyStorageProof(root common.Hash, entry eth.StorageProofEntry) error {
+	if len(entry.Proof) == 0 {
+		return errors.New("no trie nodes")
+	}
+	db := memorydb.New()
+	for i, node := range entry.Proof {
+		key := []byte(node)
+		if len(node) >= 32 { // small MPT nodes are not hashed
+			key = crypto.Keccak256(node)
+		}
+		if err := db.Put(key, node); err != nil {
+			return fmt.Errorf("failed to load trie node %d: %w", i, err)
+		}
+	}
+	val, err := trie.VerifyProof(root, crypto.Keccak256(entry.Key[:]), db)
+	if err != nil {
+		return fmt.Errorf("failed to verify against storage root %s: %w", root, err)
+	}
+	expected, err := rlp.EncodeToBytes(entry.Value.ToInt().Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to encode value: %w", err)
+	}
+	if !bytes.Equal(val, expected) {
+		return fmt.Errorf("proven value %x does not match value %x", val, expected)
+	}
+	return nil
+}
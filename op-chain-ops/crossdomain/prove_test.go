@@ -0,0 +1,121 @@
+package crossdomain_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/crossdomain"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// bridgeWithdrawal is the withdrawal of the MessagePassed event of a withdrawal through the L2StandardBridge,
+// of which the receipt is in op-node/withdrawals/testdata/bridge-withdrawal.json.
+func bridgeWithdrawal() *crossdomain.Withdrawal {
+	return crossdomain.NewWithdrawal(
+		new(big.Int),
+		ptr(common.HexToAddress("0x4200000000000000000000000000000000000007")),
+		ptr(common.HexToAddress("0x6900000000000000000000000000000000000002")),
+		new(big.Int),
+		big.NewInt(203648),
+		hexutil.MustDecode("0xd764ad0b0001000000000000000000000000000000000000000000000000000000000000000000000000000000000000420000000000000000000000000000000000001000000000000000000000000069000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000c000000000000000000000000000000000000000000000000000000000000000e40166a07a00000000000000000000000089d51be807d98fc974a0f41b2e67a8228d7846ef0000000000000000000000007c6b91d9be155a6db01f749217d76ff02a7227f2000000000000000000000000c20c5ec92fda6e611a08485123cdc0d5b84bd3a2000000000000000000000000c20c5ec92fda6e611a08485123cdc0d5b84bd3a200000000000000000000000000000000000000000000000000000000000001f400000000000000000000000000000000000000000000000000000000000000c0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"),
+	)
+}
+
+var (
+	bridgeWithdrawalHash = common.HexToHash("0x0d827f8148288e3a2466018f71b968ece4ea9f9e2a81c30da9bd46cce2868285")
+	bridgeWithdrawalSlot = common.HexToHash("0xd80754f2e75212d5cd551c0fef3abaa1b99f3ac57475cecdbfabb79b88be6bf0")
+)
+
+func TestHashWithdrawal(t *testing.T) {
+	hash, err := crossdomain.HashWithdrawal(bridgeWithdrawal())
+	require.NoError(t, err)
+	require.Equal(t, bridgeWithdrawalHash, hash)
+}
+
+func TestStorageSlotOfWithdrawalHash(t *testing.T) {
+	require.Equal(t, bridgeWithdrawalSlot, crossdomain.StorageSlotOfWithdrawalHash(bridgeWithdrawalHash))
+
+	slot, err := bridgeWithdrawal().StorageSlot()
+	require.NoError(t, err)
+	require.Equal(t, bridgeWithdrawalSlot, slot)
+}
+
+// messagePasserStorage builds the storage trie of a L2ToL1MessagePasser, in which the slots are set to true,
+// and returns its root and the storage proof of the first slot.
+func messagePasserStorage(t *testing.T, slots ...common.Hash) (common.Hash, eth.StorageProofEntry) {
+	tr := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	value, err := rlp.EncodeToBytes([]byte{1})
+	require.NoError(t, err)
+	for _, slot := range slots {
+		require.NoError(t, tr.Update(crypto.Keccak256(slot[:]), value))
+	}
+	var proof trienode.ProofList
+	require.NoError(t, tr.Prove(crypto.Keccak256(slots[0][:]), &proof))
+	nodes := make([]hexutil.Bytes, len(proof))
+	for i, node := range proof {
+		nodes[i] = hexutil.Bytes(node)
+	}
+	return tr.Hash(), eth.StorageProofEntry{
+		Key:   slots[0],
+		Value: hexutil.Big(*big.NewInt(1)),
+		Proof: nodes,
+	}
+}
+
+func TestBuildProveWithdrawalArgs(t *testing.T) {
+	w := bridgeWithdrawal()
+	root, storageProof := messagePasserStorage(t, bridgeWithdrawalSlot, common.Hash{1}, common.Hash{2})
+	outputRootProof := bindings.TypesOutputRootProof{
+		StateRoot:                common.Hash{0xaa},
+		MessagePasserStorageRoot: root,
+		LatestBlockhash:          common.Hash{0xbb},
+	}
+
+	args, err := crossdomain.BuildProveWithdrawalArgs(w, big.NewInt(7), outputRootProof, storageProof)
+	require.NoError(t, err)
+	require.Equal(t, w.WithdrawalTransaction(), args.Tx)
+	require.Equal(t, big.NewInt(7), args.L2OutputIndex)
+	require.Equal(t, outputRootProof, args.OutputRootProof)
+	require.Len(t, args.WithdrawalProof, len(storageProof.Proof))
+	for i, node := range storageProof.Proof {
+		require.Equal(t, []byte(node), args.WithdrawalProof[i])
+	}
+
+	t.Run("proof of another slot", func(t *testing.T) {
+		_, otherProof := messagePasserStorage(t, common.Hash{1}, bridgeWithdrawalSlot)
+		_, err := crossdomain.BuildProveWithdrawalArgs(w, big.NewInt(7), outputRootProof, otherProof)
+		require.ErrorContains(t, err, "is not of withdrawal slot")
+	})
+
+	t.Run("unset withdrawal", func(t *testing.T) {
+		unset := storageProof
+		unset.Value = hexutil.Big{}
+		_, err := crossdomain.BuildProveWithdrawalArgs(w, big.NewInt(7), outputRootProof, unset)
+		require.ErrorContains(t, err, "is not set in the storage proof")
+	})
+
+	t.Run("proof against another storage root", func(t *testing.T) {
+		otherRoot, _ := messagePasserStorage(t, bridgeWithdrawalSlot)
+		wrongRoot := outputRootProof
+		wrongRoot.MessagePasserStorageRoot = otherRoot
+		_, err := crossdomain.BuildProveWithdrawalArgs(w, big.NewInt(7), wrongRoot, storageProof)
+		require.ErrorContains(t, err, "invalid storage proof")
+	})
+
+	t.Run("different withdrawal", func(t *testing.T) {
+		other := bridgeWithdrawal()
+		other.Nonce = big.NewInt(1)
+		_, err := crossdomain.BuildProveWithdrawalArgs(other, big.NewInt(7), outputRootProof, storageProof)
+		require.ErrorContains(t, err, "is not of withdrawal slot")
+	})
+}
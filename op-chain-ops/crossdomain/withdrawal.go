@@ -136,11 +136,7 @@ func (w *Withdrawal) StorageSlot() (common.Hash, error) {
 	if err != nil {
 		return common.Hash{}, err
 	}
-	preimage := make([]byte, 64)
-	copy(preimage, hash.Bytes())
-
-	slot := crypto.Keccak256(preimage)
-	return common.BytesToHash(slot), nil
+	return StorageSlotOfWithdrawalHash(hash), nil
 }
 
 // WithdrawalTransaction will convert the Withdrawal to a type
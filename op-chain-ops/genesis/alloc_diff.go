@@ -0,0 +1,220 @@
+package genesis
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+)
+
+// AccountChangeKind is how an account changed between two allocs.
+type AccountChangeKind string
+
+const (
+	AccountAdded   AccountChangeKind = "added"
+	AccountRemoved AccountChangeKind = "removed"
+	AccountChanged AccountChangeKind = "changed"
+)
+
+// NonceChange is the nonce of an account before and after.
+type NonceChange struct {
+	Before uint64 `json:"before"`
+	After  uint64 `json:"after"`
+}
+
+// BalanceChange is the balance of an account before and after.
+type BalanceChange struct {
+	Before *hexutil.Big `json:"before"`
+	After  *hexutil.Big `json:"after"`
+}
+
+// CodeChange is the code hash of an account before and after.
+type CodeChange struct {
+	Before common.Hash `json:"before"`
+	After  common.Hash `json:"after"`
+}
+
+// StorageChange is the value of a storage slot before and after. Missing slots are zero.
+type StorageChange struct {
+	Key    common.Hash `json:"key"`
+	Name   string      `json:"name,omitempty"`
+	Before common.Hash `json:"before"`
+	After  common.Hash `json:"after"`
+}
+
+// AccountChange is the change of an account between two allocs. Only the parts that changed are set.
+// Added and removed accounts are compared to an empty account.
+type AccountChange struct {
+	Address common.Address    `json:"address"`
+	Name    string            `json:"name,omitempty"`
+	Kind    AccountChangeKind `json:"kind"`
+	Nonce   *NonceChange      `json:"nonce,omitempty"`
+	Balance *BalanceChange    `json:"balance,omitempty"`
+	Code    *CodeChange       `json:"code,omitempty"`
+	Storage []StorageChange   `json:"storage,omitempty"`
+}
+
+// AllocDiff is the structured diff of two allocs, sorted by address.
+type AllocDiff struct {
+	Accounts []AccountChange `json:"accounts"`
+}
+
+// DiffNames resolves addresses and storage slots to names in an AllocDiff.
+type DiffNames struct {
+	// Accounts are the names of the accounts.
+	Accounts map[common.Address]string
+	// Slots are the names of the storage slots of each account.
+	Slots map[common.Address]map[common.Hash]string
+	// CommonSlots are the names of the storage slots of any account, like the EIP-1967 slots.
+	CommonSlots map[common.Hash]string
+}
+
+// PredeployDiffNames names the predeploys and their implementations in the code namespace, the EIP-1967 slots,
+// and the storage slots of the predeploys of which the bindings have a storage layout, like the L1Block.
+// Variables that are packed in a slot are named together.
+func PredeployDiffNames() (*DiffNames, error) {
+	names := &DiffNames{
+		Accounts: make(map[common.Address]string),
+		Slots:    make(map[common.Address]map[common.Hash]string),
+		CommonSlots: map[common.Hash]string{
+			ImplementationSlot: "eip1967.implementation",
+			AdminSlot:          "eip1967.admin",
+		},
+	}
+	for name, predeploy := range predeploys.Predeploys {
+		names.Accounts[predeploy.Address] = name
+		if !predeploy.ProxyDisabled {
+			impl, err := AddressToCodeNamespace(predeploy.Address)
+			if err != nil {
+				return nil, err
+			}
+			names.Accounts[impl] = name + " implementation"
+		}
+
+		layout, err := bindings.GetStorageLayout(name)
+		if err != nil {
+			continue
+		}
+		slots := make(map[common.Hash]string)
+		for _, entry := range layout.Storage {
+			slot := common.BigToHash(new(big.Int).SetUint64(uint64(entry.Slot)))
+			if slots[slot] != "" {
+				slots[slot] += "/" + entry.Label
+			} else {
+				slots[slot] = entry.Label
+			}
+		}
+		names.Slots[predeploy.Address] = slots
+	}
+	return names, nil
+}
+
+func (n *DiffNames) account(addr common.Address) string {
+	if n == nil {
+		return ""
+	}
+	return n.Accounts[addr]
+}
+
+func (n *DiffNames) slot(addr common.Address, key common.Hash) string {
+	if n == nil {
+		return ""
+	}
+	if name, ok := n.Slots[addr][key]; ok {
+		return name
+	}
+	return n.CommonSlots[key]
+}
+
+// DiffAllocs diffs the accounts of the allocs, from a to b. The names are optional.
+func DiffAllocs(a, b core.GenesisAlloc, names *DiffNames) *AllocDiff {
+	diff := &AllocDiff{Accounts: []AccountChange{}}
+	for _, accountDiff := range Diff(a, b) {
+		addr := accountDiff.Address
+		before, inA := a[addr]
+		after, inB := b[addr]
+		change := AccountChange{
+			Address: addr,
+			Name:    names.account(addr),
+			Kind:    AccountChanged,
+		}
+		storageKeys := accountDiff.StorageKeys
+		if !inA || !inB {
+			change.Kind = AccountAdded
+			if !inB {
+				change.Kind = AccountRemoved
+			}
+			// compare all the parts of the account to an empty one
+			storageKeys = storageDiff(before.Storage, after.Storage)
+		}
+
+		if before.Nonce != after.Nonce {
+			change.Nonce = &NonceChange{Before: before.Nonce, After: after.Nonce}
+		}
+		if balanceOf(before).Cmp(balanceOf(after)) != 0 {
+			change.Balance = &BalanceChange{Before: (*hexutil.Big)(balanceOf(before)), After: (*hexutil.Big)(balanceOf(after))}
+		}
+		if beforeHash, afterHash := crypto.Keccak256Hash(before.Code), crypto.Keccak256Hash(after.Code); beforeHash != afterHash {
+			change.Code = &CodeChange{Before: beforeHash, After: afterHash}
+		}
+		for _, key := range storageKeys {
+			change.Storage = append(change.Storage, StorageChange{
+				Key:    key,
+				Name:   names.slot(addr, key),
+				Before: before.Storage[key],
+				After:  after.Storage[key],
+			})
+		}
+		diff.Accounts = append(diff.Accounts, change)
+	}
+	return diff
+}
+
+// Counts returns the number of accounts by kind of change.
+func (d *AllocDiff) Counts() map[AccountChangeKind]int {
+	counts := map[AccountChangeKind]int{AccountAdded: 0, AccountRemoved: 0, AccountChanged: 0}
+	for _, account := range d.Accounts {
+		counts[account.Kind]++
+	}
+	return counts
+}
+
+// WriteText writes a readable summary of the diff, with a line per changed part of each account.
+func (d *AllocDiff) WriteText(w io.Writer) error {
+	var sb strings.Builder
+	for _, account := range d.Accounts {
+		marker := map[AccountChangeKind]string{AccountAdded: "+", AccountRemoved: "-", AccountChanged: "~"}[account.Kind]
+		fmt.Fprintf(&sb, "%s %s%s\n", marker, account.Address, named(account.Name))
+		if account.Nonce != nil {
+			fmt.Fprintf(&sb, "    nonce: %d -> %d\n", account.Nonce.Before, account.Nonce.After)
+		}
+		if account.Balance != nil {
+			fmt.Fprintf(&sb, "    balance: %s -> %s\n", account.Balance.Before.ToInt(), account.Balance.After.ToInt())
+		}
+		if account.Code != nil {
+			fmt.Fprintf(&sb, "    code hash: %s -> %s\n", account.Code.Before, account.Code.After)
+		}
+		for _, slot := range account.Storage {
+			fmt.Fprintf(&sb, "    storage %s%s: %s -> %s\n", slot.Key, named(slot.Name), slot.Before, slot.After)
+		}
+	}
+	counts := d.Counts()
+	fmt.Fprintf(&sb, "%d accounts added, %d removed, %d changed\n", counts[AccountAdded], counts[AccountRemoved], counts[AccountChanged])
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func named(name string) string {
+	if name == "" {
+		return ""
+	}
+	return " (" + name + ")"
+}
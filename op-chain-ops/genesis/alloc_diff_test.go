@@ -0,0 +1,135 @@
+package genesis_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+)
+
+func TestDiffAllocs(t *testing.T) {
+	removed := common.Address{0x01}
+	added := common.Address{0x02}
+	funded := common.Address{0x03}
+	code := []byte{0x60, 0x00}
+	l1BlockImpl, err := genesis.AddressToCodeNamespace(predeploys.L1BlockAddr)
+	require.NoError(t, err)
+
+	a := core.GenesisAlloc{
+		removed: {Balance: big.NewInt(5)},
+		funded:  {Balance: big.NewInt(1), Nonce: 1},
+		predeploys.L1BlockAddr: {Storage: map[common.Hash]common.Hash{
+			{}:                 {31: 0x01},
+			genesis.AdminSlot:  {31: 0x18},
+			common.Hash{31: 5}: {31: 0x07},
+		}},
+		l1BlockImpl: {Code: []byte{0x00}},
+	}
+	b := core.GenesisAlloc{
+		added:  {Code: code, Storage: map[common.Hash]common.Hash{{31: 0x01}: {31: 0x02}}},
+		funded: {Balance: big.NewInt(2), Nonce: 2},
+		predeploys.L1BlockAddr: {Storage: map[common.Hash]common.Hash{
+			{}:                 {31: 0x02},
+			genesis.AdminSlot:  {31: 0x19},
+			common.Hash{31: 5}: {31: 0x07},
+		}},
+		l1BlockImpl: {Code: code},
+	}
+
+	names, err := genesis.PredeployDiffNames()
+	require.NoError(t, err)
+	diff := genesis.DiffAllocs(a, b, names)
+	require.Len(t, diff.Accounts, 5)
+	require.Equal(t, map[genesis.AccountChangeKind]int{
+		genesis.AccountAdded:   1,
+		genesis.AccountRemoved: 1,
+		genesis.AccountChanged: 3,
+	}, diff.Counts())
+
+	byAddr := make(map[common.Address]genesis.AccountChange)
+	for _, account := range diff.Accounts {
+		byAddr[account.Address] = account
+	}
+
+	t.Run("removed", func(t *testing.T) {
+		change := byAddr[removed]
+		require.Equal(t, genesis.AccountRemoved, change.Kind)
+		require.Equal(t, big.NewInt(5), change.Balance.Before.ToInt())
+		require.Equal(t, new(big.Int), change.Balance.After.ToInt())
+		require.Nil(t, change.Code)
+	})
+
+	t.Run("added", func(t *testing.T) {
+		change := byAddr[added]
+		require.Equal(t, genesis.AccountAdded, change.Kind)
+		require.Equal(t, &genesis.CodeChange{Before: crypto.Keccak256Hash(nil), After: crypto.Keccak256Hash(code)}, change.Code)
+		require.Equal(t, []genesis.StorageChange{{Key: common.Hash{31: 0x01}, After: common.Hash{31: 0x02}}}, change.Storage)
+		require.Nil(t, change.Balance)
+	})
+
+	t.Run("balance and nonce", func(t *testing.T) {
+		change := byAddr[funded]
+		require.Equal(t, genesis.AccountChanged, change.Kind)
+		require.Equal(t, &genesis.BalanceChange{Before: (*hexutil.Big)(big.NewInt(1)), After: (*hexutil.Big)(big.NewInt(2))}, change.Balance)
+		require.Equal(t, &genesis.NonceChange{Before: 1, After: 2}, change.Nonce)
+		require.Nil(t, change.Code)
+		require.Empty(t, change.Name)
+	})
+
+	t.Run("code", func(t *testing.T) {
+		change := byAddr[l1BlockImpl]
+		require.Equal(t, "L1Block implementation", change.Name)
+		require.Equal(t, crypto.Keccak256Hash(code), change.Code.After)
+		require.Empty(t, change.Storage)
+	})
+
+	t.Run("named storage", func(t *testing.T) {
+		change := byAddr[predeploys.L1BlockAddr]
+		require.Equal(t, "L1Block", change.Name)
+		require.Equal(t, []genesis.StorageChange{
+			{Key: common.Hash{}, Name: "number/timestamp", Before: common.Hash{31: 0x01}, After: common.Hash{31: 0x02}},
+			{Key: genesis.AdminSlot, Name: "eip1967.admin", Before: common.Hash{31: 0x18}, After: common.Hash{31: 0x19}},
+		}, change.Storage)
+	})
+
+	t.Run("without names", func(t *testing.T) {
+		for _, account := range genesis.DiffAllocs(a, b, nil).Accounts {
+			require.Empty(t, account.Name)
+			for _, slot := range account.Storage {
+				require.Empty(t, slot.Name)
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(diff)
+		require.NoError(t, err)
+		var decoded genesis.AllocDiff
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.Len(t, decoded.Accounts, len(diff.Accounts))
+		again, err := json.Marshal(&decoded)
+		require.NoError(t, err)
+		require.JSONEq(t, string(data), string(again))
+		require.Contains(t, string(data), `"name":"number/timestamp"`)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, diff.WriteText(&out))
+		text := out.String()
+		require.Contains(t, text, "- "+removed.Hex()+"\n    balance: 5 -> 0\n")
+		require.Contains(t, text, "+ "+added.Hex()+"\n")
+		require.Contains(t, text, "~ "+predeploys.L1BlockAddr.Hex()+" (L1Block)\n")
+		require.Contains(t, text, "storage "+common.Hash{}.Hex()+" (number/timestamp): ")
+		require.Contains(t, text, "1 accounts added, 1 removed, 3 changed\n")
+	})
+}
@@ -0,0 +1,275 @@
+package genesis
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+)
+
+// L1DeploymentReader reads the state of the L1 contracts. It is implemented by ethclient.Client.
+type L1DeploymentReader interface {
+	bind.ContractCaller
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// L1DeploymentDiscrepancyKind is the part of the L1 deployment that is not as expected.
+type L1DeploymentDiscrepancyKind string
+
+const (
+	// L1DeploymentMissingCode is a contract, or the implementation of a proxy, without code.
+	L1DeploymentMissingCode L1DeploymentDiscrepancyKind = "missingCode"
+	// L1DeploymentAdmin is a proxy of which the EIP-1967 admin, or an AddressManager of which the owner,
+	// is not the ProxyAdmin.
+	L1DeploymentAdmin L1DeploymentDiscrepancyKind = "admin"
+	// L1DeploymentOwner is a ProxyAdmin of which the owner is not the expected owner.
+	L1DeploymentOwner L1DeploymentDiscrepancyKind = "owner"
+	// L1DeploymentImplementation is a proxy of which the implementation is not the deployed implementation.
+	L1DeploymentImplementation L1DeploymentDiscrepancyKind = "implementation"
+	// L1DeploymentReference is a contract that references another contract than the deployed one.
+	L1DeploymentReference L1DeploymentDiscrepancyKind = "reference"
+	// L1DeploymentCode is a contract of which the code is not the deployed bytecode of the bindings.
+	L1DeploymentCode L1DeploymentDiscrepancyKind = "code"
+)
+
+// L1DeploymentDiscrepancy is an L1 contract that is not wired as expected.
+type L1DeploymentDiscrepancy struct {
+	Name     string                      `json:"name"`
+	Address  common.Address              `json:"address"`
+	Kind     L1DeploymentDiscrepancyKind `json:"kind"`
+	Expected string                      `json:"expected,omitempty"`
+	Actual   string                      `json:"actual,omitempty"`
+}
+
+func (d L1DeploymentDiscrepancy) String() string {
+	return fmt.Sprintf("%s (%s): %s: expected %s, got %s", d.Name, d.Address, d.Kind, d.Expected, d.Actual)
+}
+
+// l1Proxy is a proxy of the L1 deployment, and its implementation.
+type l1Proxy struct {
+	name  string
+	proxy common.Address
+	impl  common.Address
+	// resolved is whether the proxy is a ResolvedDelegateProxy, which is administered through the AddressManager.
+	resolved bool
+}
+
+// CheckL1Deployment checks that the proxies of the L1 deployment are administered by the ProxyAdmin, that the
+// ProxyAdmin is owned by the owner, that the proxies point at the deployed implementations and that the contracts
+// reference each other. It returns the discrepancies. An error is only returned when the state cannot be read.
+// Proxies that are not set in the addresses, like the DisputeGameFactoryProxy of older deployments, are not checked.
+func CheckL1Deployment(ctx context.Context, client L1DeploymentReader, addresses *L1Deployments, owner common.Address) ([]L1DeploymentDiscrepancy, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	var discrepancies []L1DeploymentDiscrepancy
+	report := func(name string, addr common.Address, kind L1DeploymentDiscrepancyKind, expected, actual string) {
+		discrepancies = append(discrepancies, L1DeploymentDiscrepancy{
+			Name:     name,
+			Address:  addr,
+			Kind:     kind,
+			Expected: expected,
+			Actual:   actual,
+		})
+	}
+	hasCode := func(name string, addr common.Address) (bool, error) {
+		code, err := client.CodeAt(ctx, addr, nil)
+		if err != nil {
+			return false, fmt.Errorf("cannot read code of %s: %w", name, err)
+		}
+		if len(code) == 0 {
+			report(name, addr, L1DeploymentMissingCode, "code at "+addr.Hex(), "no code")
+			return false, nil
+		}
+		return true, nil
+	}
+
+	if ok, err := hasCode("ProxyAdmin", addresses.ProxyAdmin); err != nil || !ok {
+		return discrepancies, err
+	}
+	if ok, err := hasCode("AddressManager", addresses.AddressManager); err != nil || !ok {
+		return discrepancies, err
+	}
+	proxyAdmin, err := bindings.NewProxyAdminCaller(addresses.ProxyAdmin, client)
+	if err != nil {
+		return nil, err
+	}
+	addressManager, err := bindings.NewAddressManagerCaller(addresses.AddressManager, client)
+	if err != nil {
+		return nil, err
+	}
+
+	proxyAdminOwner, err := proxyAdmin.Owner(opts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read owner of ProxyAdmin: %w", err)
+	}
+	if proxyAdminOwner != owner {
+		report("ProxyAdmin", addresses.ProxyAdmin, L1DeploymentOwner, owner.Hex(), proxyAdminOwner.Hex())
+	}
+	managerOfProxyAdmin, err := proxyAdmin.AddressManager(opts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read AddressManager of ProxyAdmin: %w", err)
+	}
+	if managerOfProxyAdmin != addresses.AddressManager {
+		report("ProxyAdmin", addresses.ProxyAdmin, L1DeploymentReference, "AddressManager "+addresses.AddressManager.Hex(), managerOfProxyAdmin.Hex())
+	}
+	managerOwner, err := addressManager.Owner(opts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read owner of AddressManager: %w", err)
+	}
+	if managerOwner != addresses.ProxyAdmin {
+		report("AddressManager", addresses.AddressManager, L1DeploymentAdmin, addresses.ProxyAdmin.Hex(), managerOwner.Hex())
+	}
+
+	proxies := []l1Proxy{
+		{name: "L1CrossDomainMessengerProxy", proxy: addresses.L1CrossDomainMessengerProxy, impl: addresses.L1CrossDomainMessenger, resolved: true},
+		{name: "L1ERC721BridgeProxy", proxy: addresses.L1ERC721BridgeProxy, impl: addresses.L1ERC721Bridge},
+		{name: "L1StandardBridgeProxy", proxy: addresses.L1StandardBridgeProxy, impl: addresses.L1StandardBridge},
+		{name: "L2OutputOracleProxy", proxy: addresses.L2OutputOracleProxy, impl: addresses.L2OutputOracle},
+		{name: "OptimismMintableERC20FactoryProxy", proxy: addresses.OptimismMintableERC20FactoryProxy, impl: addresses.OptimismMintableERC20Factory},
+		{name: "OptimismPortalProxy", proxy: addresses.OptimismPortalProxy, impl: addresses.OptimismPortal},
+		{name: "SystemConfigProxy", proxy: addresses.SystemConfigProxy, impl: addresses.SystemConfig},
+		{name: "ProtocolVersionsProxy", proxy: addresses.ProtocolVersionsProxy, impl: addresses.ProtocolVersions},
+		{name: "DisputeGameFactoryProxy", proxy: addresses.DisputeGameFactoryProxy, impl: addresses.DisputeGameFactory},
+	}
+	deployed := make(map[common.Address]bool)
+	for _, p := range proxies {
+		if p.proxy == (common.Address{}) {
+			continue
+		}
+		ok, err := hasCode(p.name, p.proxy)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		var impl common.Address
+		if p.resolved {
+			implName, err := proxyAdmin.ImplementationName(opts, p.proxy)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read implementation name of %s: %w", p.name, err)
+			}
+			if impl, err = addressManager.GetAddress(opts, implName); err != nil {
+				return nil, fmt.Errorf("cannot read implementation of %s: %w", p.name, err)
+			}
+		} else {
+			admin, err := client.StorageAt(ctx, p.proxy, AdminSlot, nil)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read admin of %s: %w", p.name, err)
+			}
+			if adminAddr := common.BytesToAddress(admin); adminAddr != addresses.ProxyAdmin {
+				report(p.name, p.proxy, L1DeploymentAdmin, addresses.ProxyAdmin.Hex(), adminAddr.Hex())
+			}
+			implSlot, err := client.StorageAt(ctx, p.proxy, ImplementationSlot, nil)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read implementation of %s: %w", p.name, err)
+			}
+			impl = common.BytesToAddress(implSlot)
+		}
+
+		if p.impl != (common.Address{}) && impl != p.impl {
+			report(p.name, p.proxy, L1DeploymentImplementation, p.impl.Hex(), impl.Hex())
+		}
+		implCode, err := client.CodeAt(ctx, impl, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read code of the implementation of %s: %w", p.name, err)
+		}
+		if len(implCode) == 0 {
+			report(p.name, p.proxy, L1DeploymentMissingCode, "implementation with code", impl.Hex())
+			continue
+		}
+		deployed[p.proxy] = true
+	}
+
+	references, err := l1DeploymentReferences(addresses, client)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range references {
+		if !deployed[ref.from] {
+			continue
+		}
+		actual, err := ref.get(opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read %s of %s: %w", ref.field, ref.name, err)
+		}
+		if actual != ref.expected {
+			report(ref.name, ref.from, L1DeploymentReference, ref.field+" "+ref.expected.Hex(), actual.Hex())
+		}
+	}
+	return discrepancies, nil
+}
+
+// CheckL1DeploymentCode compares the code of the contracts of the L1 deployment to the deployed bytecode of the
+// bindings, ignoring the immutables, and returns the discrepancies. An error is only returned when the code cannot be
+// read. Contracts without deployed bytecode in the bindings, like the SystemOwnerSafe, are not checked.
+func CheckL1DeploymentCode(ctx context.Context, client bindings.CodeReader, addresses *L1Deployments) ([]L1DeploymentDiscrepancy, error) {
+	contracts := make(map[string]common.Address)
+	addresses.ForEach(func(name string, addr common.Address) {
+		contracts[name] = addr
+	})
+	results, err := bindings.VerifyDeployment(ctx, client, contracts)
+	if err != nil {
+		return nil, err
+	}
+	var discrepancies []L1DeploymentDiscrepancy
+	for _, result := range results {
+		if result.Match {
+			continue
+		}
+		discrepancies = append(discrepancies, L1DeploymentDiscrepancy{
+			Name:     result.Name,
+			Address:  result.Address,
+			Kind:     L1DeploymentCode,
+			Expected: fmt.Sprintf("%s code of %d bytes", result.Contract, result.ExpectedSize),
+			Actual:   fmt.Sprintf("code of %d bytes differing at offset %d", result.CodeSize, result.MismatchOffset),
+		})
+	}
+	return discrepancies, nil
+}
+
+// l1Reference is the address of a contract that another contract of the L1 deployment references.
+type l1Reference struct {
+	name     string
+	from     common.Address
+	field    string
+	expected common.Address
+	get      func(opts *bind.CallOpts) (common.Address, error)
+}
+
+func l1DeploymentReferences(addresses *L1Deployments, client bind.ContractCaller) ([]l1Reference, error) {
+	portal, err := bindings.NewOptimismPortalCaller(addresses.OptimismPortalProxy, client)
+	if err != nil {
+		return nil, err
+	}
+	messenger, err := bindings.NewL1CrossDomainMessengerCaller(addresses.L1CrossDomainMessengerProxy, client)
+	if err != nil {
+		return nil, err
+	}
+	standardBridge, err := bindings.NewL1StandardBridgeCaller(addresses.L1StandardBridgeProxy, client)
+	if err != nil {
+		return nil, err
+	}
+	erc721Bridge, err := bindings.NewL1ERC721BridgeCaller(addresses.L1ERC721BridgeProxy, client)
+	if err != nil {
+		return nil, err
+	}
+	factory, err := bindings.NewOptimismMintableERC20FactoryCaller(addresses.OptimismMintableERC20FactoryProxy, client)
+	if err != nil {
+		return nil, err
+	}
+
+	// the getters of the immutables are read, as they are in the older deployments as well
+	return []l1Reference{
+		{"OptimismPortalProxy", addresses.OptimismPortalProxy, "systemConfig", addresses.SystemConfigProxy, portal.SYSTEMCONFIG},
+		{"OptimismPortalProxy", addresses.OptimismPortalProxy, "l2Oracle", addresses.L2OutputOracleProxy, portal.L2ORACLE},
+		{"L1CrossDomainMessengerProxy", addresses.L1CrossDomainMessengerProxy, "portal", addresses.OptimismPortalProxy, messenger.PORTAL},
+		{"L1StandardBridgeProxy", addresses.L1StandardBridgeProxy, "messenger", addresses.L1CrossDomainMessengerProxy, standardBridge.MESSENGER},
+		{"L1ERC721BridgeProxy", addresses.L1ERC721BridgeProxy, "messenger", addresses.L1CrossDomainMessengerProxy, erc721Bridge.MESSENGER},
+		{"OptimismMintableERC20FactoryProxy", addresses.OptimismMintableERC20FactoryProxy, "bridge", addresses.L1StandardBridgeProxy, factory.BRIDGE},
+	}, nil
+}
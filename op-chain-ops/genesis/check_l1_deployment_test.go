@@ -0,0 +1,171 @@
+package genesis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+)
+
+func TestCheckL1Deployment(t *testing.T) {
+	config, err := genesis.NewDeployConfig("./testdata/test-deploy-config-full.json")
+	require.NoError(t, err)
+	dump, err := genesis.NewStateDump("./testdata/allocs-l1.json")
+	require.NoError(t, err)
+	deployments, err := genesis.NewL1Deployments("./testdata/deploy.json")
+	require.NoError(t, err)
+	gen, err := genesis.BuildL1DeveloperGenesis(config, dump, nil, false)
+	require.NoError(t, err)
+	// the ProxyAdmin of the test deployment is owned by the final system owner, not by a Safe
+	owner := config.FinalSystemOwner
+
+	check := func(alloc core.GenesisAlloc, addresses *genesis.L1Deployments, owner common.Address) []genesis.L1DeploymentDiscrepancy {
+		backend := backends.NewSimulatedBackend(alloc, 15000000)
+		defer backend.Close()
+		discrepancies, err := genesis.CheckL1Deployment(context.Background(), backend, addresses, owner)
+		require.NoError(t, err)
+		return discrepancies
+	}
+	require.Empty(t, check(gen.Alloc, deployments, owner))
+
+	tests := []struct {
+		name     string
+		mutate   func(alloc core.GenesisAlloc, addresses *genesis.L1Deployments)
+		owner    common.Address
+		expected []genesis.L1DeploymentDiscrepancy
+	}{
+		{
+			name:  "owner",
+			owner: common.Address{0x01},
+			expected: []genesis.L1DeploymentDiscrepancy{
+				{Name: "ProxyAdmin", Address: deployments.ProxyAdmin, Kind: genesis.L1DeploymentOwner, Expected: common.Address{0x01}.Hex(), Actual: owner.Hex()},
+			},
+		},
+		{
+			name: "admin",
+			mutate: func(alloc core.GenesisAlloc, addresses *genesis.L1Deployments) {
+				account := copyAccount(alloc, addresses.OptimismPortalProxy)
+				account.Storage[genesis.AdminSlot] = common.BytesToHash(common.Address{0x02}.Bytes())
+				alloc[addresses.OptimismPortalProxy] = account
+			},
+			expected: []genesis.L1DeploymentDiscrepancy{
+				{Name: "OptimismPortalProxy", Address: deployments.OptimismPortalProxy, Kind: genesis.L1DeploymentAdmin, Expected: deployments.ProxyAdmin.Hex(), Actual: common.Address{0x02}.Hex()},
+			},
+		},
+		{
+			name: "implementation",
+			mutate: func(alloc core.GenesisAlloc, addresses *genesis.L1Deployments) {
+				account := copyAccount(alloc, addresses.L2OutputOracleProxy)
+				account.Storage[genesis.ImplementationSlot] = common.BytesToHash(common.Address{0x03}.Bytes())
+				alloc[addresses.L2OutputOracleProxy] = account
+			},
+			expected: []genesis.L1DeploymentDiscrepancy{
+				{Name: "L2OutputOracleProxy", Address: deployments.L2OutputOracleProxy, Kind: genesis.L1DeploymentImplementation, Expected: deployments.L2OutputOracle.Hex(), Actual: common.Address{0x03}.Hex()},
+				{Name: "L2OutputOracleProxy", Address: deployments.L2OutputOracleProxy, Kind: genesis.L1DeploymentMissingCode, Expected: "implementation with code", Actual: common.Address{0x03}.Hex()},
+			},
+		},
+		{
+			name: "reference",
+			mutate: func(alloc core.GenesisAlloc, addresses *genesis.L1Deployments) {
+				addresses.SystemConfigProxy = common.Address{0x04}
+			},
+			expected: []genesis.L1DeploymentDiscrepancy{
+				{Name: "SystemConfigProxy", Address: common.Address{0x04}, Kind: genesis.L1DeploymentMissingCode, Expected: "code at " + common.Address{0x04}.Hex(), Actual: "no code"},
+				{Name: "OptimismPortalProxy", Address: deployments.OptimismPortalProxy, Kind: genesis.L1DeploymentReference, Expected: "systemConfig " + common.Address{0x04}.Hex(), Actual: deployments.SystemConfigProxy.Hex()},
+			},
+		},
+		{
+			name: "address manager owner",
+			mutate: func(alloc core.GenesisAlloc, addresses *genesis.L1Deployments) {
+				// the owner is the first variable of the AddressManager
+				account := copyAccount(alloc, addresses.AddressManager)
+				account.Storage[common.Hash{}] = common.BytesToHash(common.Address{0x05}.Bytes())
+				alloc[addresses.AddressManager] = account
+			},
+			expected: []genesis.L1DeploymentDiscrepancy{
+				{Name: "AddressManager", Address: deployments.AddressManager, Kind: genesis.L1DeploymentAdmin, Expected: deployments.ProxyAdmin.Hex(), Actual: common.Address{0x05}.Hex()},
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			alloc := make(core.GenesisAlloc, len(gen.Alloc))
+			for addr, account := range gen.Alloc {
+				alloc[addr] = account
+			}
+			addresses := deployments.Copy()
+			if test.mutate != nil {
+				test.mutate(alloc, addresses)
+			}
+			testOwner := owner
+			if test.owner != (common.Address{}) {
+				testOwner = test.owner
+			}
+			require.Equal(t, test.expected, check(alloc, addresses, testOwner))
+		})
+	}
+}
+
+func TestCheckL1DeploymentCode(t *testing.T) {
+	deployments, err := genesis.NewL1Deployments("./testdata/deploy.json")
+	require.NoError(t, err)
+
+	// the deployed bytecode of the bindings, of which the immutables are set, at the addresses of the deployment
+	alloc := make(core.GenesisAlloc)
+	deployments.ForEach(func(name string, addr common.Address) {
+		code, err := bindings.GetDeployedBytecode(bindings.DeploymentContract(name))
+		if err != nil {
+			return
+		}
+		code = common.CopyBytes(code)
+		offsets, err := bindings.ImmutableOffsets(bindings.DeploymentContract(name))
+		require.NoError(t, err)
+		for _, offset := range offsets {
+			code[offset+common.HashLength-1] = 0x01
+		}
+		alloc[addr] = core.GenesisAccount{Code: code, Balance: common.Big0}
+	})
+	backend := backends.NewSimulatedBackend(alloc, 15000000)
+	defer backend.Close()
+
+	discrepancies, err := genesis.CheckL1DeploymentCode(context.Background(), backend, deployments)
+	require.NoError(t, err)
+	require.Empty(t, discrepancies)
+
+	result, err := bindings.VerifyContract(context.Background(), backend, "L2OutputOracle", deployments.L2OutputOracle)
+	require.NoError(t, err)
+	require.True(t, result.Match)
+	require.Equal(t, -1, result.MismatchOffset)
+	require.NotEmpty(t, result.Immutables)
+	for _, immutable := range result.Immutables {
+		require.Equal(t, common.Hash{31: 0x01}, immutable.Value)
+	}
+
+	// the code of the L2OutputOracle differs from the deployed bytecode from its first byte
+	code := common.CopyBytes(alloc[deployments.L2OutputOracle].Code)
+	code[0]++
+	alloc[deployments.L2OutputOracle] = core.GenesisAccount{Code: code, Balance: common.Big0}
+	backend = backends.NewSimulatedBackend(alloc, 15000000)
+	defer backend.Close()
+
+	discrepancies, err = genesis.CheckL1DeploymentCode(context.Background(), backend, deployments)
+	require.NoError(t, err)
+	require.Equal(t, []genesis.L1DeploymentDiscrepancy{
+		{
+			Name:     "L2OutputOracle",
+			Address:  deployments.L2OutputOracle,
+			Kind:     genesis.L1DeploymentCode,
+			Expected: fmt.Sprintf("L2OutputOracle code of %d bytes", len(code)),
+			Actual:   fmt.Sprintf("code of %d bytes differing at offset 0", len(code)),
+		},
+	}, discrepancies)
+}
@@ -0,0 +1,249 @@
+package genesis
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/immutables"
+)
+
+// PredeployStateReader reads the code and the storage of the predeploys. It is implemented by ethclient.Client.
+type PredeployStateReader interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// PredeployDiscrepancyKind is the part of a predeploy that is not as expected.
+type PredeployDiscrepancyKind string
+
+const (
+	// PredeployMissingCode is a predeploy, or the implementation of a proxied predeploy, without code.
+	PredeployMissingCode PredeployDiscrepancyKind = "missingCode"
+	// PredeployProxyCode is a proxied predeploy of which the code is not the Proxy.
+	PredeployProxyCode PredeployDiscrepancyKind = "proxyCode"
+	// PredeployAdmin is a predeploy with an unexpected EIP-1967 admin.
+	PredeployAdmin PredeployDiscrepancyKind = "admin"
+	// PredeployImplementation is a proxied predeploy of which the EIP-1967 implementation
+	// is not in the code namespace.
+	PredeployImplementation PredeployDiscrepancyKind = "implementation"
+	// PredeployCode is a predeploy of which the code is not the deployed bytecode of the bindings.
+	PredeployCode PredeployDiscrepancyKind = "code"
+	// PredeployImmutables is a predeploy of which the immutables in the code are not the expected values.
+	PredeployImmutables PredeployDiscrepancyKind = "immutables"
+)
+
+// PredeployDiscrepancy is a predeploy that is not configured as expected.
+type PredeployDiscrepancy struct {
+	Name     string                   `json:"name"`
+	Address  common.Address           `json:"address"`
+	Kind     PredeployDiscrepancyKind `json:"kind"`
+	Expected string                   `json:"expected,omitempty"`
+	Actual   string                   `json:"actual,omitempty"`
+}
+
+func (d PredeployDiscrepancy) String() string {
+	return fmt.Sprintf("%s (%s): %s: expected %s, got %s", d.Name, d.Address, d.Kind, d.Expected, d.Actual)
+}
+
+// PredeployCheckConfig is the expected configuration of the predeploys.
+type PredeployCheckConfig struct {
+	// DeployConfig determines which optional predeploys are enabled.
+	// The optional predeploys are not checked without it.
+	DeployConfig predeploys.DeployConfig
+	// Immutables is the expected code of the predeploys with immutables. Without it, the code of
+	// these predeploys is compared to the deployed bytecode of the bindings, ignoring the immutables.
+	Immutables immutables.DeploymentResults
+}
+
+// NewPredeployCheckConfig creates the expected configuration of the predeploys of the L2 genesis of the DeployConfig.
+func NewPredeployCheckConfig(config *DeployConfig) (*PredeployCheckConfig, error) {
+	immutableConfig, err := NewL2ImmutableConfig(config, nil)
+	if err != nil {
+		return nil, err
+	}
+	deployResults, err := immutables.Deploy(immutableConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &PredeployCheckConfig{
+		DeployConfig: config,
+		Immutables:   deployResults,
+	}, nil
+}
+
+// CheckPredeploys checks the code and the proxy configuration of the predeploys at the latest block of the client,
+// and returns the discrepancies. An error is only returned when the state cannot be read.
+func CheckPredeploys(ctx context.Context, client PredeployStateReader, config *PredeployCheckConfig) ([]PredeployDiscrepancy, error) {
+	if config == nil {
+		config = new(PredeployCheckConfig)
+	}
+	proxyCode, err := bindings.GetDeployedBytecode("Proxy")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(predeploys.Predeploys))
+	for name := range predeploys.Predeploys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var discrepancies []PredeployDiscrepancy
+	for _, name := range names {
+		predeploy := predeploys.Predeploys[name]
+		if predeploy.Enabled != nil && (config.DeployConfig == nil || !predeploy.Enabled(config.DeployConfig)) {
+			continue
+		}
+		found, err := checkPredeploy(ctx, client, config, proxyCode, name, predeploy)
+		if err != nil {
+			return nil, fmt.Errorf("cannot check predeploy %s: %w", name, err)
+		}
+		discrepancies = append(discrepancies, found...)
+	}
+	return discrepancies, nil
+}
+
+// CheckPredeploysInAlloc checks the predeploys of a genesis alloc, as CheckPredeploys.
+func CheckPredeploysInAlloc(alloc core.GenesisAlloc, config *PredeployCheckConfig) ([]PredeployDiscrepancy, error) {
+	return CheckPredeploys(context.Background(), allocStateReader(alloc), config)
+}
+
+func checkPredeploy(ctx context.Context, client PredeployStateReader, config *PredeployCheckConfig, proxyCode []byte, name string, predeploy *predeploys.Predeploy) ([]PredeployDiscrepancy, error) {
+	var discrepancies []PredeployDiscrepancy
+	report := func(kind PredeployDiscrepancyKind, expected, actual string) {
+		discrepancies = append(discrepancies, PredeployDiscrepancy{
+			Name:     name,
+			Address:  predeploy.Address,
+			Kind:     kind,
+			Expected: expected,
+			Actual:   actual,
+		})
+	}
+
+	code, err := client.CodeAt(ctx, predeploy.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := client.StorageAt(ctx, predeploy.Address, AdminSlot, nil)
+	if err != nil {
+		return nil, err
+	}
+	adminAddr := common.BytesToAddress(admin)
+
+	codeAddr := predeploy.Address
+	if predeploy.ProxyDisabled {
+		if adminAddr != (common.Address{}) {
+			report(PredeployAdmin, common.Address{}.Hex(), adminAddr.Hex())
+		}
+	} else {
+		if crypto.Keccak256Hash(code) != crypto.Keccak256Hash(proxyCode) {
+			report(PredeployProxyCode, crypto.Keccak256Hash(proxyCode).Hex(), crypto.Keccak256Hash(code).Hex())
+		}
+		if adminAddr != predeploys.ProxyAdminAddr {
+			report(PredeployAdmin, predeploys.ProxyAdminAddr.Hex(), adminAddr.Hex())
+		}
+		impl, err := client.StorageAt(ctx, predeploy.Address, ImplementationSlot, nil)
+		if err != nil {
+			return nil, err
+		}
+		standardImpl, err := AddressToCodeNamespace(predeploy.Address)
+		if err != nil {
+			return nil, err
+		}
+		codeAddr = common.BytesToAddress(impl)
+		if codeAddr != standardImpl {
+			report(PredeployImplementation, standardImpl.Hex(), codeAddr.Hex())
+		}
+		if codeAddr == (common.Address{}) {
+			return discrepancies, nil
+		}
+		if code, err = client.CodeAt(ctx, codeAddr, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(code) == 0 {
+		report(PredeployMissingCode, "code at "+codeAddr.Hex(), "no code")
+		return discrepancies, nil
+	}
+	expected, err := bindings.GetDeployedBytecode(name)
+	if err != nil {
+		return nil, err
+	}
+	verified, err := bindings.VerifyCode(name, code)
+	if err != nil {
+		return nil, err
+	}
+	codeHash := crypto.Keccak256Hash(code)
+	if !verified.Match {
+		report(PredeployCode, crypto.Keccak256Hash(expected).Hex(), codeHash.Hex())
+	} else if withImmutables, ok := config.Immutables[name]; ok && crypto.Keccak256Hash(withImmutables) != codeHash {
+		report(PredeployImmutables, crypto.Keccak256Hash(withImmutables).Hex(), codeHash.Hex())
+	}
+	return discrepancies, nil
+}
+
+// FeeVaultConfig is the effective configuration of a fee vault predeploy.
+type FeeVaultConfig struct {
+	Name                string            `json:"name"`
+	Address             common.Address    `json:"address"`
+	Recipient           common.Address    `json:"recipient"`
+	MinWithdrawalAmount *hexutil.Big      `json:"minWithdrawalAmount"`
+	WithdrawalNetwork   WithdrawalNetwork `json:"withdrawalNetwork"`
+}
+
+// ReadFeeVaultConfigs reads the effective configuration of the fee vault predeploys, in order of name.
+func ReadFeeVaultConfigs(ctx context.Context, caller bind.ContractCaller) ([]FeeVaultConfig, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	var configs []FeeVaultConfig
+	for _, name := range []string{"BaseFeeVault", "L1FeeVault", "SequencerFeeVault"} {
+		addr := predeploys.Predeploys[name].Address
+		// the fee vaults share the getters of the FeeVault
+		vault, err := bindings.NewBaseFeeVaultCaller(addr, caller)
+		if err != nil {
+			return nil, err
+		}
+		recipient, err := vault.RECIPIENT(opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read recipient of %s: %w", name, err)
+		}
+		minWithdrawalAmount, err := vault.MINWITHDRAWALAMOUNT(opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read minimum withdrawal amount of %s: %w", name, err)
+		}
+		withdrawalNetwork, err := vault.WITHDRAWALNETWORK(opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read withdrawal network of %s: %w", name, err)
+		}
+		configs = append(configs, FeeVaultConfig{
+			Name:                name,
+			Address:             addr,
+			Recipient:           recipient,
+			MinWithdrawalAmount: (*hexutil.Big)(minWithdrawalAmount),
+			WithdrawalNetwork:   FromUint8(withdrawalNetwork),
+		})
+	}
+	return configs, nil
+}
+
+// allocStateReader reads the state of a genesis alloc, at any block.
+type allocStateReader core.GenesisAlloc
+
+func (a allocStateReader) CodeAt(_ context.Context, account common.Address, _ *big.Int) ([]byte, error) {
+	return a[account].Code, nil
+}
+
+func (a allocStateReader) StorageAt(_ context.Context, account common.Address, key common.Hash, _ *big.Int) ([]byte, error) {
+	value := a[account].Storage[key]
+	return value[:], nil
+}
@@ -0,0 +1,216 @@
+package genesis_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+)
+
+// copyAccount replaces the account in the alloc with a copy, which can be mutated.
+func copyAccount(alloc core.GenesisAlloc, addr common.Address) core.GenesisAccount {
+	account := alloc[addr]
+	account.Code = bytes.Clone(account.Code)
+	storage := make(map[common.Hash]common.Hash, len(account.Storage))
+	for key, value := range account.Storage {
+		storage[key] = value
+	}
+	account.Storage = storage
+	return account
+}
+
+func TestCheckPredeploys(t *testing.T) {
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{}, 15000000)
+	l1Block, err := backend.BlockByNumber(context.Background(), common.Big0)
+	require.NoError(t, err)
+	config, err := genesis.NewDeployConfig("./testdata/test-deploy-config-devnet-l1.json")
+	require.NoError(t, err)
+	config.EnableGovernance = true
+	config.FundDevAccounts = false
+	gen, err := genesis.BuildL2Genesis(config, l1Block.Header())
+	require.NoError(t, err)
+
+	checkConfig, err := genesis.NewPredeployCheckConfig(config)
+	require.NoError(t, err)
+	discrepancies, err := genesis.CheckPredeploysInAlloc(gen.Alloc, checkConfig)
+	require.NoError(t, err)
+	require.Empty(t, discrepancies)
+
+	// without the expected immutables, the code is compared to the bindings
+	discrepancies, err = genesis.CheckPredeploysInAlloc(gen.Alloc, nil)
+	require.NoError(t, err)
+	require.Empty(t, discrepancies)
+
+	messengerImpl, err := genesis.AddressToCodeNamespace(predeploys.L2CrossDomainMessengerAddr)
+	require.NoError(t, err)
+	messengerCode, err := bindings.GetDeployedBytecode("L2CrossDomainMessenger")
+	require.NoError(t, err)
+	immutableIndex := -1
+	for i := range messengerCode {
+		if messengerCode[i] == 0 && gen.Alloc[messengerImpl].Code[i] != 0 {
+			immutableIndex = i
+			break
+		}
+	}
+	require.NotEqual(t, -1, immutableIndex, "the L2CrossDomainMessenger has an immutable")
+
+	tests := []struct {
+		name      string
+		mutate    func(alloc core.GenesisAlloc)
+		predeploy string
+		kind      genesis.PredeployDiscrepancyKind
+	}{
+		{
+			name: "admin",
+			mutate: func(alloc core.GenesisAlloc) {
+				account := copyAccount(alloc, predeploys.L2StandardBridgeAddr)
+				account.Storage[genesis.AdminSlot] = common.Hash{31: 0x01}
+				alloc[predeploys.L2StandardBridgeAddr] = account
+			},
+			predeploy: "L2StandardBridge",
+			kind:      genesis.PredeployAdmin,
+		},
+		{
+			name: "implementation",
+			mutate: func(alloc core.GenesisAlloc) {
+				// the implementation is moved out of the code namespace
+				impl, err := genesis.AddressToCodeNamespace(predeploys.L1BlockAddr)
+				require.NoError(t, err)
+				alloc[common.Address{0x01}] = alloc[impl]
+				account := copyAccount(alloc, predeploys.L1BlockAddr)
+				account.Storage[genesis.ImplementationSlot] = common.BytesToHash(common.Address{0x01}.Bytes())
+				alloc[predeploys.L1BlockAddr] = account
+			},
+			predeploy: "L1Block",
+			kind:      genesis.PredeployImplementation,
+		},
+		{
+			name: "proxy code",
+			mutate: func(alloc core.GenesisAlloc) {
+				account := copyAccount(alloc, predeploys.GasPriceOracleAddr)
+				account.Code = []byte{0x00}
+				alloc[predeploys.GasPriceOracleAddr] = account
+			},
+			predeploy: "GasPriceOracle",
+			kind:      genesis.PredeployProxyCode,
+		},
+		{
+			name: "missing code",
+			mutate: func(alloc core.GenesisAlloc) {
+				account := copyAccount(alloc, predeploys.WETH9Addr)
+				account.Code = nil
+				alloc[predeploys.WETH9Addr] = account
+			},
+			predeploy: "WETH9",
+			kind:      genesis.PredeployMissingCode,
+		},
+		{
+			name: "code",
+			mutate: func(alloc core.GenesisAlloc) {
+				account := copyAccount(alloc, messengerImpl)
+				account.Code = append(account.Code, 0x00)
+				alloc[messengerImpl] = account
+			},
+			predeploy: "L2CrossDomainMessenger",
+			kind:      genesis.PredeployCode,
+		},
+		{
+			name: "immutables",
+			mutate: func(alloc core.GenesisAlloc) {
+				account := copyAccount(alloc, messengerImpl)
+				account.Code[immutableIndex]++
+				alloc[messengerImpl] = account
+			},
+			predeploy: "L2CrossDomainMessenger",
+			kind:      genesis.PredeployImmutables,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			alloc := make(core.GenesisAlloc, len(gen.Alloc))
+			for addr, account := range gen.Alloc {
+				alloc[addr] = account
+			}
+			test.mutate(alloc)
+
+			discrepancies, err := genesis.CheckPredeploysInAlloc(alloc, checkConfig)
+			require.NoError(t, err)
+			require.Len(t, discrepancies, 1, discrepancies)
+			require.Equal(t, test.predeploy, discrepancies[0].Name)
+			require.Equal(t, test.kind, discrepancies[0].Kind)
+		})
+	}
+}
+
+func TestReadFeeVaultConfigs(t *testing.T) {
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{}, 15000000)
+	l1Block, err := backend.BlockByNumber(context.Background(), common.Big0)
+	require.NoError(t, err)
+	config, err := genesis.NewDeployConfig("./testdata/test-deploy-config-devnet-l1.json")
+	require.NoError(t, err)
+	config.FundDevAccounts = false
+	config.BaseFeeVaultRecipient = common.Address{0x01}
+	config.BaseFeeVaultMinimumWithdrawalAmount = (*hexutil.Big)(big.NewInt(1))
+	config.BaseFeeVaultWithdrawalNetwork = "remote"
+	config.L1FeeVaultRecipient = common.Address{0x02}
+	config.L1FeeVaultMinimumWithdrawalAmount = (*hexutil.Big)(big.NewInt(2))
+	config.L1FeeVaultWithdrawalNetwork = "local"
+	config.SequencerFeeVaultRecipient = common.Address{0x03}
+	config.SequencerFeeVaultMinimumWithdrawalAmount = (*hexutil.Big)(big.NewInt(3))
+	config.SequencerFeeVaultWithdrawalNetwork = "local"
+	gen, err := genesis.BuildL2Genesis(config, l1Block.Header())
+	require.NoError(t, err)
+
+	devnet := backends.NewSimulatedBackend(gen.Alloc, 15000000)
+	vaults, err := genesis.ReadFeeVaultConfigs(context.Background(), devnet)
+	require.NoError(t, err)
+	require.Equal(t, []genesis.FeeVaultConfig{
+		{
+			Name:                "BaseFeeVault",
+			Address:             predeploys.BaseFeeVaultAddr,
+			Recipient:           common.Address{0x01},
+			MinWithdrawalAmount: (*hexutil.Big)(big.NewInt(1)),
+			WithdrawalNetwork:   "remote",
+		},
+		{
+			Name:                "L1FeeVault",
+			Address:             predeploys.L1FeeVaultAddr,
+			Recipient:           common.Address{0x02},
+			MinWithdrawalAmount: (*hexutil.Big)(big.NewInt(2)),
+			WithdrawalNetwork:   "local",
+		},
+		{
+			Name:                "SequencerFeeVault",
+			Address:             predeploys.SequencerFeeVaultAddr,
+			Recipient:           common.Address{0x03},
+			MinWithdrawalAmount: (*hexutil.Big)(big.NewInt(3)),
+			WithdrawalNetwork:   "local",
+		},
+	}, vaults)
+
+	t.Run("zero recipient", func(t *testing.T) {
+		config := *config
+		config.L1FeeVaultRecipient = common.Address{}
+		_, err := genesis.BuildL2Genesis(&config, l1Block.Header())
+		require.ErrorContains(t, err, "l1FeeVaultRecipient")
+	})
+
+	t.Run("no minimum withdrawal amount", func(t *testing.T) {
+		config := *config
+		config.SequencerFeeVaultMinimumWithdrawalAmount = nil
+		_, err := genesis.BuildL2Genesis(&config, l1Block.Header())
+		require.ErrorIs(t, err, genesis.ErrInvalidImmutablesConfig)
+	})
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
 	gstate "github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -105,6 +106,9 @@ type DeployConfig struct {
 	L2GenesisBlockGasUsed       hexutil.Uint64 `json:"l2GenesisBlockGasUsed"`
 	L2GenesisBlockParentHash    common.Hash    `json:"l2GenesisBlockParentHash"`
 	L2GenesisBlockBaseFeePerGas *hexutil.Big   `json:"l2GenesisBlockBaseFeePerGas"`
+	// L2GenesisBlockTimestampOffset is the number of seconds after the L1 starting block that the
+	// L2 genesis block is timestamped. The fork time offsets are relative to the L2 genesis block.
+	L2GenesisBlockTimestampOffset hexutil.Uint64 `json:"l2GenesisBlockTimestampOffset,omitempty"`
 
 	// L2GenesisRegolithTimeOffset is the number of seconds after genesis block that Regolith hard fork activates.
 	// Set it to 0 to activate at genesis. Nil to disable Regolith.
@@ -230,6 +234,14 @@ type DeployConfig struct {
 
 	// When Cancun activates. Relative to L1 genesis.
 	L1CancunTimeOffset *uint64 `json:"l1CancunTimeOffset,omitempty"`
+
+	// L1GenesisAlloc are additional accounts of the L1 developer genesis, with their balance, code and storage.
+	// They must not collide with the deployed L1 contracts. The balance of a dev account is added to.
+	L1GenesisAlloc core.GenesisAlloc `json:"l1GenesisAlloc,omitempty"`
+
+	// L2GenesisPredeployOverrides replace or add predeploys of the L2 genesis, by address.
+	// They are applied after the standard predeploys are set.
+	L2GenesisPredeployOverrides map[common.Address]*PredeployOverride `json:"l2GenesisPredeployOverrides,omitempty"`
 }
 
 // Copy will deeply copy the DeployConfig. This does a JSON roundtrip to copy
@@ -247,131 +259,142 @@ func (d *DeployConfig) Copy() *DeployConfig {
 	return &cpy
 }
 
-// Check will ensure that the config is sane and return an error when it is not
+// Check will ensure that the config is sane and return an error when it is not.
+// All the problems are reported, as one error that joins an error per problem. Each of them wraps
+// ErrInvalidDeployConfig, and names the fields by their JSON tags.
 func (d *DeployConfig) Check() error {
-	if d.L1StartingBlockTag == nil {
-		return fmt.Errorf("%w: L1StartingBlockTag cannot be nil", ErrInvalidDeployConfig)
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrInvalidDeployConfig, fmt.Sprintf(format, args...)))
 	}
-	if d.L1ChainID == 0 {
-		return fmt.Errorf("%w: L1ChainID cannot be 0", ErrInvalidDeployConfig)
+	nonZero := func(field string, value uint64) {
+		if value == 0 {
+			invalid("%s cannot be 0", field)
+		}
 	}
-	if d.L2ChainID == 0 {
-		return fmt.Errorf("%w: L2ChainID cannot be 0", ErrInvalidDeployConfig)
+	nonZeroAddress := func(field string, addr common.Address) {
+		if addr == (common.Address{}) {
+			invalid("%s cannot be address(0)", field)
+		}
 	}
-	if d.L2BlockTime == 0 {
-		return fmt.Errorf("%w: L2BlockTime cannot be 0", ErrInvalidDeployConfig)
+
+	if d.L1StartingBlockTag == nil {
+		invalid("l1StartingBlockTag cannot be nil")
 	}
-	if d.FinalizationPeriodSeconds == 0 {
-		return fmt.Errorf("%w: FinalizationPeriodSeconds cannot be 0", ErrInvalidDeployConfig)
+	nonZero("l1ChainID", d.L1ChainID)
+	nonZero("l2ChainID", d.L2ChainID)
+	nonZero("l2BlockTime", d.L2BlockTime)
+	nonZero("l1BlockTime", d.L1BlockTime)
+	// L2 block time must always be smaller than L1 block time
+	if d.L1BlockTime != 0 && d.L1BlockTime < d.L2BlockTime {
+		invalid("l2BlockTime (%d) is larger than l1BlockTime (%d)", d.L2BlockTime, d.L1BlockTime)
 	}
+	nonZero("finalizationPeriodSeconds", d.FinalizationPeriodSeconds)
 	if d.L2OutputOracleStartingBlockNumber == 0 {
 		log.Warn("L2OutputOracleStartingBlockNumber is 0, should only be 0 for fresh chains")
 	}
-	if d.SuperchainConfigGuardian == (common.Address{}) {
-		return fmt.Errorf("%w: SuperchainConfigGuardian cannot be address(0)", ErrInvalidDeployConfig)
-	}
-	if d.MaxSequencerDrift == 0 {
-		return fmt.Errorf("%w: MaxSequencerDrift cannot be 0", ErrInvalidDeployConfig)
-	}
-	if d.SequencerWindowSize == 0 {
-		return fmt.Errorf("%w: SequencerWindowSize cannot be 0", ErrInvalidDeployConfig)
-	}
-	if d.ChannelTimeout == 0 {
-		return fmt.Errorf("%w: ChannelTimeout cannot be 0", ErrInvalidDeployConfig)
-	}
-	if d.P2PSequencerAddress == (common.Address{}) {
-		return fmt.Errorf("%w: P2PSequencerAddress cannot be address(0)", ErrInvalidDeployConfig)
-	}
-	if d.BatchInboxAddress == (common.Address{}) {
-		return fmt.Errorf("%w: BatchInboxAddress cannot be address(0)", ErrInvalidDeployConfig)
-	}
-	if d.BatchSenderAddress == (common.Address{}) {
-		return fmt.Errorf("%w: BatchSenderAddress cannot be address(0)", ErrInvalidDeployConfig)
-	}
-	if d.L2OutputOracleSubmissionInterval == 0 {
-		return fmt.Errorf("%w: L2OutputOracleSubmissionInterval cannot be 0", ErrInvalidDeployConfig)
-	}
+	nonZeroAddress("superchainConfigGuardian", d.SuperchainConfigGuardian)
+	nonZero("maxSequencerDrift", d.MaxSequencerDrift)
+	nonZero("sequencerWindowSize", d.SequencerWindowSize)
+	nonZero("channelTimeout", d.ChannelTimeout)
+	nonZeroAddress("p2pSequencerAddress", d.P2PSequencerAddress)
+	nonZeroAddress("batchInboxAddress", d.BatchInboxAddress)
+	nonZeroAddress("batchSenderAddress", d.BatchSenderAddress)
+	nonZero("l2OutputOracleSubmissionInterval", d.L2OutputOracleSubmissionInterval)
 	if d.L2OutputOracleStartingTimestamp == 0 {
 		log.Warn("L2OutputOracleStartingTimestamp is 0")
 	}
-	if d.L2OutputOracleProposer == (common.Address{}) {
-		return fmt.Errorf("%w: L2OutputOracleProposer cannot be address(0)", ErrInvalidDeployConfig)
-	}
-	if d.L2OutputOracleChallenger == (common.Address{}) {
-		return fmt.Errorf("%w: L2OutputOracleChallenger cannot be address(0)", ErrInvalidDeployConfig)
-	}
-	if d.FinalSystemOwner == (common.Address{}) {
-		return fmt.Errorf("%w: FinalSystemOwner cannot be address(0)", ErrInvalidDeployConfig)
-	}
-	if d.ProxyAdminOwner == (common.Address{}) {
-		return fmt.Errorf("%w: ProxyAdminOwner cannot be address(0)", ErrInvalidDeployConfig)
-	}
-	if d.BaseFeeVaultRecipient == (common.Address{}) {
-		return fmt.Errorf("%w: BaseFeeVaultRecipient cannot be address(0)", ErrInvalidDeployConfig)
-	}
-	if d.L1FeeVaultRecipient == (common.Address{}) {
-		return fmt.Errorf("%w: L1FeeVaultRecipient cannot be address(0)", ErrInvalidDeployConfig)
-	}
-	if d.SequencerFeeVaultRecipient == (common.Address{}) {
-		return fmt.Errorf("%w: SequencerFeeVaultRecipient cannot be address(0)", ErrInvalidDeployConfig)
-	}
+	nonZeroAddress("l2OutputOracleProposer", d.L2OutputOracleProposer)
+	nonZeroAddress("l2OutputOracleChallenger", d.L2OutputOracleChallenger)
+	nonZeroAddress("finalSystemOwner", d.FinalSystemOwner)
+	nonZeroAddress("proxyAdminOwner", d.ProxyAdminOwner)
+	nonZeroAddress("baseFeeVaultRecipient", d.BaseFeeVaultRecipient)
+	nonZeroAddress("l1FeeVaultRecipient", d.L1FeeVaultRecipient)
+	nonZeroAddress("sequencerFeeVaultRecipient", d.SequencerFeeVaultRecipient)
 	if !d.BaseFeeVaultWithdrawalNetwork.Valid() {
-		return fmt.Errorf("%w: BaseFeeVaultWithdrawalNetwork can only be 0 (L1) or 1 (L2)", ErrInvalidDeployConfig)
+		invalid("baseFeeVaultWithdrawalNetwork can only be 0 (L1) or 1 (L2)")
 	}
 	if !d.L1FeeVaultWithdrawalNetwork.Valid() {
-		return fmt.Errorf("%w: L1FeeVaultWithdrawalNetwork can only be 0 (L1) or 1 (L2)", ErrInvalidDeployConfig)
+		invalid("l1FeeVaultWithdrawalNetwork can only be 0 (L1) or 1 (L2)")
 	}
 	if !d.SequencerFeeVaultWithdrawalNetwork.Valid() {
-		return fmt.Errorf("%w: SequencerFeeVaultWithdrawalNetwork can only be 0 (L1) or 1 (L2)", ErrInvalidDeployConfig)
+		invalid("sequencerFeeVaultWithdrawalNetwork can only be 0 (L1) or 1 (L2)")
 	}
 	if d.GasPriceOracleOverhead == 0 {
 		log.Warn("GasPriceOracleOverhead is 0")
 	}
-	if d.GasPriceOracleScalar == 0 {
-		return fmt.Errorf("%w: GasPriceOracleScalar cannot be 0", ErrInvalidDeployConfig)
-	}
-	if d.EIP1559Denominator == 0 {
-		return fmt.Errorf("%w: EIP1559Denominator cannot be 0", ErrInvalidDeployConfig)
-	}
+	nonZero("gasPriceOracleScalar", d.GasPriceOracleScalar)
+	// the EIP-1559 parameters are only meaningful together
+	nonZero("eip1559Elasticity", d.EIP1559Elasticity)
+	nonZero("eip1559Denominator", d.EIP1559Denominator)
 	if d.L2GenesisCanyonTimeOffset != nil && d.EIP1559DenominatorCanyon == 0 {
-		return fmt.Errorf("%w: EIP1559DenominatorCanyon cannot be 0 if Canyon is activated", ErrInvalidDeployConfig)
-	}
-	if d.EIP1559Elasticity == 0 {
-		return fmt.Errorf("%w: EIP1559Elasticity cannot be 0", ErrInvalidDeployConfig)
+		invalid("eip1559DenominatorCanyon cannot be 0 if Canyon is activated")
 	}
 	if d.L2GenesisBlockGasLimit == 0 {
-		return fmt.Errorf("%w: L2 genesis block gas limit cannot be 0", ErrInvalidDeployConfig)
-	}
-	// When the initial resource config is made to be configurable by the DeployConfig, ensure
-	// that this check is updated to use the values from the DeployConfig instead of the defaults.
-	if uint64(d.L2GenesisBlockGasLimit) < uint64(DefaultResourceConfig.MaxResourceLimit+DefaultResourceConfig.SystemTxMaxGas) {
-		return fmt.Errorf("%w: L2 genesis block gas limit is too small", ErrInvalidDeployConfig)
+		invalid("l2GenesisBlockGasLimit cannot be 0")
+	} else if uint64(d.L2GenesisBlockGasLimit) < uint64(DefaultResourceConfig.MaxResourceLimit+DefaultResourceConfig.SystemTxMaxGas) {
+		// When the initial resource config is made to be configurable by the DeployConfig, ensure
+		// that this check is updated to use the values from the DeployConfig instead of the defaults.
+		invalid("l2GenesisBlockGasLimit is too small")
 	}
 	if d.L2GenesisBlockBaseFeePerGas == nil {
-		return fmt.Errorf("%w: L2 genesis block base fee per gas cannot be nil", ErrInvalidDeployConfig)
+		invalid("l2GenesisBlockBaseFeePerGas cannot be nil")
 	}
 	if d.EnableGovernance {
 		if d.GovernanceTokenName == "" {
-			return fmt.Errorf("%w: GovernanceToken.name cannot be empty", ErrInvalidDeployConfig)
+			invalid("governanceTokenName cannot be empty if governance is enabled")
 		}
 		if d.GovernanceTokenSymbol == "" {
-			return fmt.Errorf("%w: GovernanceToken.symbol cannot be empty", ErrInvalidDeployConfig)
-		}
-		if d.GovernanceTokenOwner == (common.Address{}) {
-			return fmt.Errorf("%w: GovernanceToken owner cannot be address(0)", ErrInvalidDeployConfig)
+			invalid("governanceTokenSymbol cannot be empty if governance is enabled")
 		}
+		nonZeroAddress("governanceTokenOwner", d.GovernanceTokenOwner)
 	}
-	// L2 block time must always be smaller than L1 block time
-	if d.L1BlockTime < d.L2BlockTime {
-		return fmt.Errorf("L2 block time (%d) is larger than L1 block time (%d)", d.L2BlockTime, d.L1BlockTime)
+	if d.L1UseClique {
+		nonZeroAddress("cliqueSignerAddress", d.CliqueSignerAddress)
+	}
+	if d.FaultGameMaxDepth != 0 && d.OutputBisectionGameSplitDepth >= d.FaultGameMaxDepth {
+		invalid("outputBisectionGameSplitDepth (%d) must be smaller than faultGameMaxDepth (%d)",
+			d.OutputBisectionGameSplitDepth, d.FaultGameMaxDepth)
 	}
+	errs = append(errs, d.checkForkOrder()...)
 	if d.RequiredProtocolVersion == (params.ProtocolVersion{}) {
 		log.Warn("RequiredProtocolVersion is empty")
 	}
 	if d.RecommendedProtocolVersion == (params.ProtocolVersion{}) {
 		log.Warn("RecommendedProtocolVersion is empty")
 	}
-	return nil
+	return errors.Join(errs...)
+}
+
+// checkForkOrder verifies that every configured L2 fork activates at or after the fork before it,
+// and that no fork is configured without the forks before it.
+func (d *DeployConfig) checkForkOrder() []error {
+	forks := []struct {
+		name   string
+		offset *hexutil.Uint64
+	}{
+		{"l2GenesisRegolithTimeOffset", d.L2GenesisRegolithTimeOffset},
+		{"l2GenesisCanyonTimeOffset", d.L2GenesisCanyonTimeOffset},
+		{"l2GenesisDeltaTimeOffset", d.L2GenesisDeltaTimeOffset},
+		{"l2GenesisEclipseTimeOffset", d.L2GenesisEclipseTimeOffset},
+		{"l2GenesisFjordTimeOffset", d.L2GenesisFjordTimeOffset},
+		{"l2GenesisInteropTimeOffset", d.L2GenesisInteropTimeOffset},
+	}
+	var errs []error
+	for i := 1; i < len(forks); i++ {
+		prev, next := forks[i-1], forks[i]
+		if next.offset == nil {
+			continue
+		}
+		if prev.offset == nil {
+			errs = append(errs, fmt.Errorf("%w: %s is set to %d, but prior fork %s is not set",
+				ErrInvalidDeployConfig, next.name, *next.offset, prev.name))
+		} else if *prev.offset > *next.offset {
+			errs = append(errs, fmt.Errorf("%w: %s is set to %d, but prior fork %s is set to later offset %d",
+				ErrInvalidDeployConfig, next.name, *next.offset, prev.name, *prev.offset))
+		}
+	}
+	return errs
 }
 
 // CheckAddresses will return an error if the addresses are not set.
@@ -452,6 +475,45 @@ func (d *DeployConfig) GetDeployedAddresses(hh *hardhat.Hardhat) error {
 	return nil
 }
 
+// CheckL1StartHeader checks that the L2 genesis can be anchored to the L1 starting block header.
+// The problems are reported as Check does.
+func (d *DeployConfig) CheckL1StartHeader(header *types.Header) error {
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrInvalidDeployConfig, fmt.Sprintf(format, args...)))
+	}
+
+	if header.Number == nil {
+		invalid("L1 starting block has no number")
+	}
+	if header.BaseFee == nil {
+		invalid("L1 starting block %s has no base fee", header.Hash())
+	}
+	// The L2 genesis block has the L1 starting block as L1 origin,
+	// so it cannot be timestamped beyond the sequencer drift of it.
+	if offset := uint64(d.L2GenesisBlockTimestampOffset); offset > d.MaxSequencerDrift {
+		invalid("l2GenesisBlockTimestampOffset (%d) is larger than maxSequencerDrift (%d)", offset, d.MaxSequencerDrift)
+	}
+	// The L2OutputOracle starts at an L2 block, of which the timestamp is a multiple of
+	// the L2 block time after the L2 genesis.
+	if d.L2OutputOracleStartingTimestamp > 0 && d.L2BlockTime != 0 {
+		genesisTime := d.L2GenesisTime(header.Time)
+		startingTime := uint64(d.L2OutputOracleStartingTimestamp)
+		if startingTime < genesisTime {
+			invalid("l2OutputOracleStartingTimestamp (%d) is before the L2 genesis at %d", startingTime, genesisTime)
+		} else if (startingTime-genesisTime)%d.L2BlockTime != 0 {
+			invalid("l2OutputOracleStartingTimestamp (%d) is not an L2 block after the L2 genesis at %d, with l2BlockTime %d",
+				startingTime, genesisTime, d.L2BlockTime)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// L2GenesisTime returns the timestamp of the L2 genesis block, given the timestamp of the L1 starting block.
+func (d *DeployConfig) L2GenesisTime(l1StartTime uint64) uint64 {
+	return l1StartTime + uint64(d.L2GenesisBlockTimestampOffset)
+}
+
 func (d *DeployConfig) GovernanceEnabled() bool {
 	return d.EnableGovernance
 }
@@ -523,7 +585,7 @@ func (d *DeployConfig) InteropTime(genesisTime uint64) *uint64 {
 }
 
 // RollupConfig converts a DeployConfig to a rollup.Config
-func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHash common.Hash, l2GenesisBlockNumber uint64) (*rollup.Config, error) {
+func (d *DeployConfig) RollupConfig(l1StartHeader *types.Header, l2GenesisBlockHash common.Hash, l2GenesisBlockNumber uint64) (*rollup.Config, error) {
 	if d.OptimismPortalProxy == (common.Address{}) {
 		return nil, errors.New("OptimismPortalProxy cannot be address(0)")
 	}
@@ -531,17 +593,18 @@ func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHas
 		return nil, errors.New("SystemConfigProxy cannot be address(0)")
 	}
 
+	genesisTime := d.L2GenesisTime(l1StartHeader.Time)
 	return &rollup.Config{
 		Genesis: rollup.Genesis{
 			L1: eth.BlockID{
-				Hash:   l1StartBlock.Hash(),
-				Number: l1StartBlock.NumberU64(),
+				Hash:   l1StartHeader.Hash(),
+				Number: l1StartHeader.Number.Uint64(),
 			},
 			L2: eth.BlockID{
 				Hash:   l2GenesisBlockHash,
 				Number: l2GenesisBlockNumber,
 			},
-			L2Time: l1StartBlock.Time(),
+			L2Time: genesisTime,
 			SystemConfig: eth.SystemConfig{
 				BatcherAddr: d.BatchSenderAddress,
 				Overhead:    eth.Bytes32(common.BigToHash(new(big.Int).SetUint64(d.GasPriceOracleOverhead))),
@@ -558,12 +621,12 @@ func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHas
 		BatchInboxAddress:      d.BatchInboxAddress,
 		DepositContractAddress: d.OptimismPortalProxy,
 		L1SystemConfigAddress:  d.SystemConfigProxy,
-		RegolithTime:           d.RegolithTime(l1StartBlock.Time()),
-		CanyonTime:             d.CanyonTime(l1StartBlock.Time()),
-		DeltaTime:              d.DeltaTime(l1StartBlock.Time()),
-		EclipseTime:            d.EclipseTime(l1StartBlock.Time()),
-		FjordTime:              d.FjordTime(l1StartBlock.Time()),
-		InteropTime:            d.InteropTime(l1StartBlock.Time()),
+		RegolithTime:           d.RegolithTime(genesisTime),
+		CanyonTime:             d.CanyonTime(genesisTime),
+		DeltaTime:              d.DeltaTime(genesisTime),
+		EclipseTime:            d.EclipseTime(genesisTime),
+		FjordTime:              d.FjordTime(genesisTime),
+		InteropTime:            d.InteropTime(genesisTime),
 	}, nil
 }
 
@@ -616,6 +679,7 @@ type L1Deployments struct {
 	SystemConfigProxy                 common.Address `json:"SystemConfigProxy"`
 	ProtocolVersions                  common.Address `json:"ProtocolVersions"`
 	ProtocolVersionsProxy             common.Address `json:"ProtocolVersionsProxy"`
+	SystemOwnerSafe                   common.Address `json:"SystemOwnerSafe"`
 }
 
 // GetName will return the name of the contract given an address.
@@ -644,6 +708,10 @@ func (d *L1Deployments) Check() error {
 		if name == "DisputeGameFactory" || name == "DisputeGameFactoryProxy" || name == "BlockOracle" {
 			continue
 		}
+		// Older deployments are not owned by a Safe
+		if name == "SystemOwnerSafe" {
+			continue
+		}
 		if val.Field(i).Interface().(common.Address) == (common.Address{}) {
 			return fmt.Errorf("%s is not set", name)
 		}
@@ -707,8 +775,8 @@ func NewStateDump(path string) (*gstate.Dump, error) {
 }
 
 // NewL2ImmutableConfig will create an ImmutableConfig given an instance of a
-// DeployConfig and a block.
-func NewL2ImmutableConfig(config *DeployConfig, block *types.Block) (*immutables.PredeploysImmutableConfig, error) {
+// DeployConfig and the L1 starting block header.
+func NewL2ImmutableConfig(config *DeployConfig, l1StartHeader *types.Header) (*immutables.PredeploysImmutableConfig, error) {
 	if config.L1StandardBridgeProxy == (common.Address{}) {
 		return nil, fmt.Errorf("L1StandardBridgeProxy cannot be address(0): %w", ErrInvalidImmutablesConfig)
 	}
@@ -727,6 +795,15 @@ func NewL2ImmutableConfig(config *DeployConfig, block *types.Block) (*immutables
 	if config.L1FeeVaultRecipient == (common.Address{}) {
 		return nil, fmt.Errorf("L1FeeVaultRecipient cannot be address(0): %w", ErrInvalidImmutablesConfig)
 	}
+	if config.SequencerFeeVaultMinimumWithdrawalAmount == nil {
+		return nil, fmt.Errorf("SequencerFeeVaultMinimumWithdrawalAmount cannot be nil: %w", ErrInvalidImmutablesConfig)
+	}
+	if config.BaseFeeVaultMinimumWithdrawalAmount == nil {
+		return nil, fmt.Errorf("BaseFeeVaultMinimumWithdrawalAmount cannot be nil: %w", ErrInvalidImmutablesConfig)
+	}
+	if config.L1FeeVaultMinimumWithdrawalAmount == nil {
+		return nil, fmt.Errorf("L1FeeVaultMinimumWithdrawalAmount cannot be nil: %w", ErrInvalidImmutablesConfig)
+	}
 
 	cfg := immutables.PredeploysImmutableConfig{
 		L2ToL1MessagePasser: struct{}{},
@@ -811,13 +888,13 @@ func NewL2ImmutableConfig(config *DeployConfig, block *types.Block) (*immutables
 
 // NewL2StorageConfig will create a StorageConfig given an instance of a
 // Hardhat and a DeployConfig.
-func NewL2StorageConfig(config *DeployConfig, block *types.Block) (state.StorageConfig, error) {
+func NewL2StorageConfig(config *DeployConfig, l1StartHeader *types.Header) (state.StorageConfig, error) {
 	storage := make(state.StorageConfig)
 
-	if block.Number() == nil {
+	if l1StartHeader.Number == nil {
 		return storage, errors.New("block number not set")
 	}
-	if block.BaseFee() == nil {
+	if l1StartHeader.BaseFee == nil {
 		return storage, errors.New("block base fee not set")
 	}
 
@@ -839,10 +916,10 @@ func NewL2StorageConfig(config *DeployConfig, block *types.Block) (state.Storage
 		"_initializing": false,
 	}
 	storage["L1Block"] = state.StorageValues{
-		"number":         block.Number(),
-		"timestamp":      block.Time(),
-		"basefee":        block.BaseFee(),
-		"hash":           block.Hash(),
+		"number":         l1StartHeader.Number,
+		"timestamp":      l1StartHeader.Time,
+		"basefee":        l1StartHeader.BaseFee,
+		"hash":           l1StartHeader.Hash(),
 		"sequenceNumber": 0,
 		"batcherHash":    eth.AddressAsLeftPaddedHash(config.BatchSenderAddress),
 		"l1FeeOverhead":  config.GasPriceOracleOverhead,
@@ -0,0 +1,188 @@
+package genesis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// DeployConfigEnvPrefix is the prefix of the environment variables that override the fields of a deploy config.
+// The rest of the name is the upper-cased JSON tag of the field, e.g. DEPLOY_CONFIG_L2BLOCKTIME for l2BlockTime.
+const DeployConfigEnvPrefix = "DEPLOY_CONFIG_"
+
+// DeployConfigDefaultsSource is the source of the fields that are set by the built-in defaults.
+const DeployConfigDefaultsSource = "defaults"
+
+// deployConfigDefaults are the built-in defaults of the layered deploy config, by JSON tag.
+// They are the values that the genesis builders fall back to when the fields are not set.
+var deployConfigDefaults = map[string]any{
+	"eip1559Denominator":          50,
+	"eip1559DenominatorCanyon":    250,
+	"eip1559Elasticity":           10,
+	"l2GenesisBlockGasLimit":      "0x1c9c380",
+	"l2GenesisBlockBaseFeePerGas": "0x3b9aca00",
+}
+
+// DeployConfigField is the value of a field of a layered deploy config, and the layer it was loaded from.
+type DeployConfigField struct {
+	Value  json.RawMessage `json:"value"`
+	Source string          `json:"source"`
+}
+
+// DeployConfigProvenance is the provenance of the fields of a layered deploy config, by JSON tag.
+// Fields that are not set by any layer are not in it.
+type DeployConfigProvenance map[string]DeployConfigField
+
+// Write writes the fields in order of name, with their value and source.
+func (p DeployConfigProvenance) Write(w io.Writer) error {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, name := range names {
+		field := p[name]
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", name, field.Value, field.Source); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// LoadDeployConfig loads a deploy config in layers, each of which overrides the fields of the previous ones:
+// the built-in defaults, the base file, the optional overlay file of a network, and the environment variables
+// with the DeployConfigEnvPrefix. The environment is given as os.Environ returns it.
+// Fields are replaced as a whole. Unknown fields in the files or in the environment are an error.
+func LoadDeployConfig(basePath, overlayPath string, environ []string) (*DeployConfig, DeployConfigProvenance, error) {
+	tags := deployConfigTags()
+	provenance := make(DeployConfigProvenance)
+
+	for name, value := range deployConfigDefaults {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid default of %s: %w", name, err)
+		}
+		provenance[name] = DeployConfigField{Value: raw, Source: DeployConfigDefaultsSource}
+	}
+
+	for _, path := range []string{basePath, overlayPath} {
+		if path == "" {
+			continue
+		}
+		fields, err := readDeployConfigFields(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		for name, value := range fields {
+			if tags[strings.ToUpper(name)] != name {
+				return nil, nil, fmt.Errorf("unknown field %s in deploy config %s", name, path)
+			}
+			if err := checkDeployConfigField(name, value); err != nil {
+				return nil, nil, fmt.Errorf("invalid field %s in deploy config %s: %w", name, path, err)
+			}
+			provenance[name] = DeployConfigField{Value: value, Source: path}
+		}
+	}
+
+	for _, kv := range environ {
+		key, value, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(key, DeployConfigEnvPrefix) {
+			continue
+		}
+		name, ok := tags[strings.TrimPrefix(key, DeployConfigEnvPrefix)]
+		if !ok {
+			return nil, nil, fmt.Errorf("environment variable %s does not match a deploy config field", key)
+		}
+		raw, err := envDeployConfigValue(name, value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid environment variable %s: %w", key, err)
+		}
+		provenance[name] = DeployConfigField{Value: raw, Source: "env " + key}
+	}
+
+	merged := make(map[string]json.RawMessage, len(provenance))
+	for name, field := range provenance {
+		merged[name] = field.Value
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	var config DeployConfig
+	if err := decodeDeployConfig(data, &config); err != nil {
+		return nil, nil, fmt.Errorf("cannot unmarshal layered deploy config: %w", err)
+	}
+	return &config, provenance, nil
+}
+
+// deployConfigTags returns the JSON tags of the fields of the DeployConfig, by their upper-cased tag.
+func deployConfigTags() map[string]string {
+	typ := reflect.TypeOf(DeployConfig{})
+	tags := make(map[string]string, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		tags[strings.ToUpper(name)] = name
+	}
+	return tags
+}
+
+func readDeployConfigFields(path string) (map[string]json.RawMessage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("deploy config at %s not found: %w", path, err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal deploy config %s: %w", path, err)
+	}
+	return fields, nil
+}
+
+// envDeployConfigValue converts the value of an environment variable to the JSON value of the field.
+// The value is used as JSON if the field can be decoded from it, and as a JSON string otherwise,
+// so that addresses and hex numbers do not need to be quoted.
+func envDeployConfigValue(name, value string) (json.RawMessage, error) {
+	var errJSON error
+	if json.Valid([]byte(value)) {
+		if errJSON = checkDeployConfigField(name, json.RawMessage(value)); errJSON == nil {
+			return json.RawMessage(value), nil
+		}
+	}
+	quoted, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDeployConfigField(name, quoted); err != nil {
+		if errJSON != nil {
+			return nil, errJSON
+		}
+		return nil, err
+	}
+	return quoted, nil
+}
+
+// checkDeployConfigField checks that the field of the deploy config can be decoded from the value.
+func checkDeployConfigField(name string, value json.RawMessage) error {
+	data, err := json.Marshal(map[string]json.RawMessage{name: value})
+	if err != nil {
+		return err
+	}
+	return decodeDeployConfig(data, new(DeployConfig))
+}
+
+func decodeDeployConfig(data []byte, config *DeployConfig) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(config)
+}
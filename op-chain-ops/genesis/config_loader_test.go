@@ -0,0 +1,108 @@
+package genesis_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+)
+
+func writeConfigLayer(t *testing.T, name, data string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(data), 0644))
+	return path
+}
+
+func TestLoadDeployConfig(t *testing.T) {
+	base := writeConfigLayer(t, "base.json", `{
+		"l1ChainID": 900,
+		"l2ChainID": 901,
+		"l2BlockTime": 2,
+		"eip1559Denominator": 100,
+		"batchSenderAddress": "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"
+	}`)
+	overlay := writeConfigLayer(t, "devnet.json", `{
+		"l2ChainID": 902,
+		"l2BlockTime": 1
+	}`)
+	batcher := common.HexToAddress("0x90F79bf6EB2c4f870365E785982E1f101E93b906")
+	environ := []string{
+		"PATH=/usr/bin",
+		"DEPLOY_CONFIG_L2BLOCKTIME=4",
+		"DEPLOY_CONFIG_BATCHSENDERADDRESS=" + batcher.Hex(),
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		config, provenance, err := genesis.LoadDeployConfig("", "", nil)
+		require.NoError(t, err)
+		require.Equal(t, uint64(50), config.EIP1559Denominator)
+		require.Equal(t, uint64(30_000_000), uint64(config.L2GenesisBlockGasLimit))
+		require.Equal(t, genesis.DeployConfigDefaultsSource, provenance["eip1559Denominator"].Source)
+		require.NotContains(t, provenance, "l1ChainID")
+	})
+
+	t.Run("base", func(t *testing.T) {
+		config, provenance, err := genesis.LoadDeployConfig(base, "", nil)
+		require.NoError(t, err)
+		require.Equal(t, uint64(100), config.EIP1559Denominator)
+		require.Equal(t, uint64(250), config.EIP1559DenominatorCanyon)
+		require.Equal(t, uint64(901), config.L2ChainID)
+		require.Equal(t, base, provenance["eip1559Denominator"].Source)
+		require.Equal(t, genesis.DeployConfigDefaultsSource, provenance["eip1559DenominatorCanyon"].Source)
+	})
+
+	t.Run("overlay", func(t *testing.T) {
+		config, provenance, err := genesis.LoadDeployConfig(base, overlay, nil)
+		require.NoError(t, err)
+		require.Equal(t, uint64(902), config.L2ChainID)
+		require.Equal(t, uint64(1), config.L2BlockTime)
+		require.Equal(t, uint64(900), config.L1ChainID)
+		require.Equal(t, overlay, provenance["l2ChainID"].Source)
+		require.Equal(t, base, provenance["l1ChainID"].Source)
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		config, provenance, err := genesis.LoadDeployConfig(base, overlay, environ)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), config.L2BlockTime)
+		require.Equal(t, batcher, config.BatchSenderAddress)
+		require.Equal(t, uint64(902), config.L2ChainID)
+		require.Equal(t, "env DEPLOY_CONFIG_L2BLOCKTIME", provenance["l2BlockTime"].Source)
+		require.Equal(t, "env DEPLOY_CONFIG_BATCHSENDERADDRESS", provenance["batchSenderAddress"].Source)
+
+		var out bytes.Buffer
+		require.NoError(t, provenance.Write(&out))
+		require.Contains(t, out.String(), "l2BlockTime")
+		require.Regexp(t, `l2ChainID\s+902\s+`+regexp.QuoteMeta(overlay), out.String())
+	})
+
+	t.Run("unknown environment variable", func(t *testing.T) {
+		_, _, err := genesis.LoadDeployConfig(base, "", []string{"DEPLOY_CONFIG_L2BLOCKTIMES=4"})
+		require.ErrorContains(t, err, "DEPLOY_CONFIG_L2BLOCKTIMES does not match a deploy config field")
+	})
+
+	t.Run("invalid environment variable", func(t *testing.T) {
+		_, _, err := genesis.LoadDeployConfig(base, "", []string{"DEPLOY_CONFIG_L2BLOCKTIME=two"})
+		require.ErrorContains(t, err, "invalid environment variable DEPLOY_CONFIG_L2BLOCKTIME")
+		_, _, err = genesis.LoadDeployConfig(base, "", []string{"DEPLOY_CONFIG_L2BLOCKTIME=-1"})
+		require.ErrorContains(t, err, "invalid environment variable DEPLOY_CONFIG_L2BLOCKTIME")
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		invalid := writeConfigLayer(t, "invalid.json", `{"l2BlockTimes": 1}`)
+		_, _, err := genesis.LoadDeployConfig(base, invalid, nil)
+		require.ErrorContains(t, err, "unknown field l2BlockTimes")
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		invalid := writeConfigLayer(t, "invalid.json", `{"l2BlockTime": "two"}`)
+		_, _, err := genesis.LoadDeployConfig(base, invalid, nil)
+		require.ErrorContains(t, err, "invalid field l2BlockTime in deploy config "+invalid)
+	})
+}
@@ -3,6 +3,7 @@ package genesis
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -77,6 +78,132 @@ func TestCopy(t *testing.T) {
 	require.NotEqual(t, decoded, cpy)
 }
 
+func TestDeployConfigCheck(t *testing.T) {
+	b, err := os.ReadFile("testdata/test-deploy-config-full.json")
+	require.NoError(t, err)
+	valid := new(DeployConfig)
+	require.NoError(t, json.Unmarshal(b, valid))
+	require.NoError(t, valid.Check())
+
+	offset := func(v uint64) *hexutil.Uint64 {
+		o := hexutil.Uint64(v)
+		return &o
+	}
+	tests := []struct {
+		name      string
+		override  func(c *DeployConfig)
+		errString string
+	}{
+		{"no l1StartingBlockTag", func(c *DeployConfig) { c.L1StartingBlockTag = nil }, "l1StartingBlockTag cannot be nil"},
+		{"no l1ChainID", func(c *DeployConfig) { c.L1ChainID = 0 }, "l1ChainID cannot be 0"},
+		{"no l2ChainID", func(c *DeployConfig) { c.L2ChainID = 0 }, "l2ChainID cannot be 0"},
+		{"no l2BlockTime", func(c *DeployConfig) { c.L2BlockTime = 0 }, "l2BlockTime cannot be 0"},
+		{"no l1BlockTime", func(c *DeployConfig) { c.L1BlockTime = 0 }, "l1BlockTime cannot be 0"},
+		{"l2BlockTime larger than l1BlockTime", func(c *DeployConfig) { c.L2BlockTime = c.L1BlockTime + 1 }, "is larger than l1BlockTime"},
+		{"no finalizationPeriodSeconds", func(c *DeployConfig) { c.FinalizationPeriodSeconds = 0 }, "finalizationPeriodSeconds cannot be 0"},
+		{"no superchainConfigGuardian", func(c *DeployConfig) { c.SuperchainConfigGuardian = common.Address{} }, "superchainConfigGuardian cannot be address(0)"},
+		{"no maxSequencerDrift", func(c *DeployConfig) { c.MaxSequencerDrift = 0 }, "maxSequencerDrift cannot be 0"},
+		{"no sequencerWindowSize", func(c *DeployConfig) { c.SequencerWindowSize = 0 }, "sequencerWindowSize cannot be 0"},
+		{"no channelTimeout", func(c *DeployConfig) { c.ChannelTimeout = 0 }, "channelTimeout cannot be 0"},
+		{"no p2pSequencerAddress", func(c *DeployConfig) { c.P2PSequencerAddress = common.Address{} }, "p2pSequencerAddress cannot be address(0)"},
+		{"no batchInboxAddress", func(c *DeployConfig) { c.BatchInboxAddress = common.Address{} }, "batchInboxAddress cannot be address(0)"},
+		{"no batchSenderAddress", func(c *DeployConfig) { c.BatchSenderAddress = common.Address{} }, "batchSenderAddress cannot be address(0)"},
+		{"no l2OutputOracleSubmissionInterval", func(c *DeployConfig) { c.L2OutputOracleSubmissionInterval = 0 }, "l2OutputOracleSubmissionInterval cannot be 0"},
+		{"no l2OutputOracleProposer", func(c *DeployConfig) { c.L2OutputOracleProposer = common.Address{} }, "l2OutputOracleProposer cannot be address(0)"},
+		{"no l2OutputOracleChallenger", func(c *DeployConfig) { c.L2OutputOracleChallenger = common.Address{} }, "l2OutputOracleChallenger cannot be address(0)"},
+		{"no finalSystemOwner", func(c *DeployConfig) { c.FinalSystemOwner = common.Address{} }, "finalSystemOwner cannot be address(0)"},
+		{"no proxyAdminOwner", func(c *DeployConfig) { c.ProxyAdminOwner = common.Address{} }, "proxyAdminOwner cannot be address(0)"},
+		{"no baseFeeVaultRecipient", func(c *DeployConfig) { c.BaseFeeVaultRecipient = common.Address{} }, "baseFeeVaultRecipient cannot be address(0)"},
+		{"no l1FeeVaultRecipient", func(c *DeployConfig) { c.L1FeeVaultRecipient = common.Address{} }, "l1FeeVaultRecipient cannot be address(0)"},
+		{"no sequencerFeeVaultRecipient", func(c *DeployConfig) { c.SequencerFeeVaultRecipient = common.Address{} }, "sequencerFeeVaultRecipient cannot be address(0)"},
+		{"invalid baseFeeVaultWithdrawalNetwork", func(c *DeployConfig) { c.BaseFeeVaultWithdrawalNetwork = "mainnet" }, "baseFeeVaultWithdrawalNetwork can only be"},
+		{"invalid l1FeeVaultWithdrawalNetwork", func(c *DeployConfig) { c.L1FeeVaultWithdrawalNetwork = "mainnet" }, "l1FeeVaultWithdrawalNetwork can only be"},
+		{"invalid sequencerFeeVaultWithdrawalNetwork", func(c *DeployConfig) { c.SequencerFeeVaultWithdrawalNetwork = "mainnet" }, "sequencerFeeVaultWithdrawalNetwork can only be"},
+		{"no gasPriceOracleScalar", func(c *DeployConfig) { c.GasPriceOracleScalar = 0 }, "gasPriceOracleScalar cannot be 0"},
+		{"no eip1559Elasticity", func(c *DeployConfig) { c.EIP1559Elasticity = 0 }, "eip1559Elasticity cannot be 0"},
+		{"no eip1559Denominator", func(c *DeployConfig) { c.EIP1559Denominator = 0 }, "eip1559Denominator cannot be 0"},
+		{
+			name: "no eip1559DenominatorCanyon with Canyon",
+			override: func(c *DeployConfig) {
+				c.L2GenesisRegolithTimeOffset = offset(0)
+				c.L2GenesisCanyonTimeOffset = offset(0)
+				c.EIP1559DenominatorCanyon = 0
+			},
+			errString: "eip1559DenominatorCanyon cannot be 0 if Canyon is activated",
+		},
+		{"no l2GenesisBlockGasLimit", func(c *DeployConfig) { c.L2GenesisBlockGasLimit = 0 }, "l2GenesisBlockGasLimit cannot be 0"},
+		{"small l2GenesisBlockGasLimit", func(c *DeployConfig) { c.L2GenesisBlockGasLimit = 1_000_000 }, "l2GenesisBlockGasLimit is too small"},
+		{"no l2GenesisBlockBaseFeePerGas", func(c *DeployConfig) { c.L2GenesisBlockBaseFeePerGas = nil }, "l2GenesisBlockBaseFeePerGas cannot be nil"},
+		{"no governanceTokenName", func(c *DeployConfig) { c.GovernanceTokenName = "" }, "governanceTokenName cannot be empty if governance is enabled"},
+		{"no governanceTokenSymbol", func(c *DeployConfig) { c.GovernanceTokenSymbol = "" }, "governanceTokenSymbol cannot be empty if governance is enabled"},
+		{"no governanceTokenOwner", func(c *DeployConfig) { c.GovernanceTokenOwner = common.Address{} }, "governanceTokenOwner cannot be address(0)"},
+		{
+			name: "no cliqueSignerAddress with clique",
+			override: func(c *DeployConfig) {
+				c.L1UseClique = true
+				c.CliqueSignerAddress = common.Address{}
+			},
+			errString: "cliqueSignerAddress cannot be address(0)",
+		},
+		{
+			name:      "outputBisectionGameSplitDepth not below faultGameMaxDepth",
+			override:  func(c *DeployConfig) { c.OutputBisectionGameSplitDepth = c.FaultGameMaxDepth },
+			errString: "outputBisectionGameSplitDepth (63) must be smaller than faultGameMaxDepth (63)",
+		},
+		{
+			name:      "fork without prior fork",
+			override:  func(c *DeployConfig) { c.L2GenesisDeltaTimeOffset = offset(0) },
+			errString: "l2GenesisDeltaTimeOffset is set to 0, but prior fork l2GenesisCanyonTimeOffset is not set",
+		},
+		{
+			name: "fork before prior fork",
+			override: func(c *DeployConfig) {
+				c.L2GenesisRegolithTimeOffset = offset(10)
+				c.L2GenesisCanyonTimeOffset = offset(5)
+			},
+			errString: "l2GenesisCanyonTimeOffset is set to 5, but prior fork l2GenesisRegolithTimeOffset is set to later offset 10",
+		},
+	}
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := valid.Copy()
+			tc.override(cfg)
+			err := cfg.Check()
+			require.ErrorIs(t, err, ErrInvalidDeployConfig)
+			require.ErrorContains(t, err, tc.errString)
+		})
+	}
+
+	t.Run("every problem is reported", func(t *testing.T) {
+		cfg := valid.Copy()
+		cfg.L1ChainID = 0
+		cfg.BatchInboxAddress = common.Address{}
+		cfg.EnableGovernance = true
+		cfg.GovernanceTokenName = ""
+		err := cfg.Check()
+		var joined interface{ Unwrap() []error }
+		require.True(t, errors.As(err, &joined))
+		require.Len(t, joined.Unwrap(), 3)
+		for _, e := range joined.Unwrap() {
+			require.ErrorIs(t, e, ErrInvalidDeployConfig)
+		}
+		require.ErrorContains(t, err, "l1ChainID cannot be 0")
+		require.ErrorContains(t, err, "batchInboxAddress cannot be address(0)")
+		require.ErrorContains(t, err, "governanceTokenName cannot be empty")
+	})
+
+	t.Run("fork order", func(t *testing.T) {
+		cfg := valid.Copy()
+		cfg.L2GenesisRegolithTimeOffset = offset(0)
+		cfg.L2GenesisCanyonTimeOffset = offset(0)
+		cfg.L2GenesisDeltaTimeOffset = offset(10)
+		cfg.L2GenesisEclipseTimeOffset = offset(10)
+		cfg.L2GenesisFjordTimeOffset = offset(20)
+		require.NoError(t, cfg.Check())
+	})
+}
+
 // TestL1Deployments ensures that NewL1Deployments can read a JSON file
 // from disk and deserialize all of the key/value pairs correctly.
 func TestL1Deployments(t *testing.T) {
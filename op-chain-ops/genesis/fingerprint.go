@@ -0,0 +1,153 @@
+package genesis
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// AccountComponent is a part of a genesis account that can differ between two allocs.
+type AccountComponent string
+
+const (
+	// ComponentExistence means that the account is only in one of the allocs.
+	ComponentExistence AccountComponent = "existence"
+	ComponentNonce     AccountComponent = "nonce"
+	ComponentBalance   AccountComponent = "balance"
+	ComponentCode      AccountComponent = "code"
+	ComponentStorage   AccountComponent = "storage"
+)
+
+// AccountDiff is an account that differs between two allocs.
+type AccountDiff struct {
+	Address common.Address
+	// Components are the parts of the account that differ, in the order nonce, balance, code, storage.
+	Components []AccountComponent
+	// StorageKeys are the sorted keys of the storage slots that differ.
+	StorageKeys []common.Hash
+}
+
+// AllocFingerprint computes a canonical hash of the alloc, that is independent of the order of the
+// accounts and storage slots: the hash over the sorted addresses, each followed by the AccountFingerprint
+// of its account.
+func AllocFingerprint(alloc core.GenesisAlloc) common.Hash {
+	hasher := crypto.NewKeccakState()
+	for _, addr := range sortedAddresses(alloc) {
+		hasher.Write(addr.Bytes())
+		hasher.Write(AccountFingerprint(alloc[addr]).Bytes())
+	}
+	var h common.Hash
+	_, _ = hasher.Read(h[:])
+	return h
+}
+
+// AccountFingerprint computes a canonical hash of the account: the hash over its nonce, balance, code hash,
+// and sorted storage. Storage slots with a zero value are left out, as they are not stored in the state.
+func AccountFingerprint(account core.GenesisAccount) common.Hash {
+	var nonce [8]byte
+	binary.BigEndian.PutUint64(nonce[:], account.Nonce)
+	codeHash := crypto.Keccak256Hash(account.Code)
+	storage := crypto.NewKeccakState()
+	for _, key := range sortedStorageKeys(account.Storage) {
+		storage.Write(key.Bytes())
+		storage.Write(account.Storage[key].Bytes())
+	}
+	var storageHash common.Hash
+	_, _ = storage.Read(storageHash[:])
+	return crypto.Keccak256Hash(nonce[:], common.BigToHash(balanceOf(account)).Bytes(), codeHash.Bytes(), storageHash.Bytes())
+}
+
+// Diff returns the accounts that differ between the allocs, sorted by address, with the components that differ.
+func Diff(a, b core.GenesisAlloc) []AccountDiff {
+	union := make(core.GenesisAlloc, len(a))
+	for addr, account := range a {
+		union[addr] = account
+	}
+	for addr, account := range b {
+		union[addr] = account
+	}
+
+	var diffs []AccountDiff
+	for _, addr := range sortedAddresses(union) {
+		accountA, okA := a[addr]
+		accountB, okB := b[addr]
+		diff := AccountDiff{Address: addr}
+		if okA != okB {
+			diff.Components = []AccountComponent{ComponentExistence}
+			diffs = append(diffs, diff)
+			continue
+		}
+		if accountA.Nonce != accountB.Nonce {
+			diff.Components = append(diff.Components, ComponentNonce)
+		}
+		if balanceOf(accountA).Cmp(balanceOf(accountB)) != 0 {
+			diff.Components = append(diff.Components, ComponentBalance)
+		}
+		if !bytes.Equal(accountA.Code, accountB.Code) {
+			diff.Components = append(diff.Components, ComponentCode)
+		}
+		diff.StorageKeys = storageDiff(accountA.Storage, accountB.Storage)
+		if len(diff.StorageKeys) != 0 {
+			diff.Components = append(diff.Components, ComponentStorage)
+		}
+		if len(diff.Components) != 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
+
+// storageDiff returns the sorted keys of the slots with different values, where missing slots are zero.
+func storageDiff(a, b map[common.Hash]common.Hash) []common.Hash {
+	var keys []common.Hash
+	for key, value := range a {
+		if b[key] != value {
+			keys = append(keys, key)
+		}
+	}
+	for key, value := range b {
+		if _, ok := a[key]; !ok && value != (common.Hash{}) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i][:], keys[j][:]) < 0
+	})
+	return keys
+}
+
+func balanceOf(account core.GenesisAccount) *big.Int {
+	if account.Balance == nil {
+		return new(big.Int)
+	}
+	return account.Balance
+}
+
+func sortedAddresses(alloc core.GenesisAlloc) []common.Address {
+	addrs := make([]common.Address, 0, len(alloc))
+	for addr := range alloc {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+	return addrs
+}
+
+func sortedStorageKeys(storage map[common.Hash]common.Hash) []common.Hash {
+	keys := make([]common.Hash, 0, len(storage))
+	for key, value := range storage {
+		if value != (common.Hash{}) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i][:], keys[j][:]) < 0
+	})
+	return keys
+}
@@ -0,0 +1,77 @@
+package genesis
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/stretchr/testify/require"
+)
+
+func testAlloc() core.GenesisAlloc {
+	return core.GenesisAlloc{
+		common.Address{1}: {
+			Balance: big.NewInt(100),
+			Nonce:   1,
+		},
+		common.Address{2}: {
+			Code: []byte{0x60, 0x00},
+			Storage: map[common.Hash]common.Hash{
+				{1}: {0xaa},
+				{2}: {0xbb},
+			},
+		},
+	}
+}
+
+func TestAllocFingerprint(t *testing.T) {
+	require.Equal(t, AllocFingerprint(testAlloc()), AllocFingerprint(testAlloc()))
+	require.NotEqual(t, AllocFingerprint(testAlloc()), AllocFingerprint(core.GenesisAlloc{}))
+
+	// zero-valued storage slots and nil balances are not stored in the state
+	alloc := testAlloc()
+	account := alloc[common.Address{2}]
+	account.Storage[common.Hash{3}] = common.Hash{}
+	account.Balance = big.NewInt(0)
+	alloc[common.Address{2}] = account
+	require.Equal(t, AllocFingerprint(testAlloc()), AllocFingerprint(alloc))
+	require.Empty(t, Diff(testAlloc(), alloc))
+}
+
+func TestDiffStorageOnly(t *testing.T) {
+	alloc := testAlloc()
+	alloc[common.Address{2}].Storage[common.Hash{2}] = common.Hash{0xcc}
+	alloc[common.Address{2}].Storage[common.Hash{4}] = common.Hash{0xdd}
+	require.NotEqual(t, AllocFingerprint(testAlloc()), AllocFingerprint(alloc))
+
+	require.Equal(t, []AccountDiff{{
+		Address:     common.Address{2},
+		Components:  []AccountComponent{ComponentStorage},
+		StorageKeys: []common.Hash{{2}, {4}},
+	}}, Diff(testAlloc(), alloc))
+}
+
+func TestDiffCodeOnly(t *testing.T) {
+	alloc := testAlloc()
+	account := alloc[common.Address{2}]
+	account.Code = []byte{0x60, 0x01}
+	alloc[common.Address{2}] = account
+	require.NotEqual(t, AllocFingerprint(testAlloc()), AllocFingerprint(alloc))
+
+	require.Equal(t, []AccountDiff{{
+		Address:    common.Address{2},
+		Components: []AccountComponent{ComponentCode},
+	}}, Diff(testAlloc(), alloc))
+}
+
+func TestDiffAccounts(t *testing.T) {
+	alloc := testAlloc()
+	alloc[common.Address{1}] = core.GenesisAccount{Balance: big.NewInt(200), Nonce: 2}
+	alloc[common.Address{3}] = core.GenesisAccount{Balance: big.NewInt(1)}
+
+	require.Equal(t, []AccountDiff{
+		{Address: common.Address{1}, Components: []AccountComponent{ComponentNonce, ComponentBalance}},
+		{Address: common.Address{3}, Components: []AccountComponent{ComponentExistence}},
+	}, Diff(testAlloc(), alloc))
+}
@@ -21,11 +21,12 @@ const defaultGasLimit = 30_000_000
 // BedrockTransitionBlockExtraData represents the default extra data for the bedrock transition block.
 var BedrockTransitionBlockExtraData = []byte("BEDROCK")
 
-// NewL2Genesis will create a new L2 genesis
-func NewL2Genesis(config *DeployConfig, block *types.Block) (*core.Genesis, error) {
+// NewL2Genesis will create a new L2 genesis, anchored to the L1 starting block header.
+func NewL2Genesis(config *DeployConfig, l1StartHeader *types.Header) (*core.Genesis, error) {
 	if config.L2ChainID == 0 {
 		return nil, errors.New("must define L2 ChainID")
 	}
+	genesisTime := config.L2GenesisTime(l1StartHeader.Time)
 
 	eip1559Denom := config.EIP1559Denominator
 	if eip1559Denom == 0 {
@@ -61,11 +62,11 @@ func NewL2Genesis(config *DeployConfig, block *types.Block) (*core.Genesis, erro
 		TerminalTotalDifficulty:       big.NewInt(0),
 		TerminalTotalDifficultyPassed: true,
 		BedrockBlock:                  new(big.Int).SetUint64(uint64(config.L2GenesisBlockNumber)),
-		RegolithTime:                  config.RegolithTime(block.Time()),
-		CanyonTime:                    config.CanyonTime(block.Time()),
-		ShanghaiTime:                  config.CanyonTime(block.Time()),
-		CancunTime:                    nil, // no Dencun on L2 yet.
-		InteropTime:                   config.InteropTime(block.Time()),
+		RegolithTime:                  config.RegolithTime(genesisTime),
+		CanyonTime:                    config.CanyonTime(genesisTime),
+		ShanghaiTime:                  config.CanyonTime(genesisTime),
+		CancunTime:                    config.EclipseTime(genesisTime),
+		InteropTime:                   config.InteropTime(genesisTime),
 		Optimism: &params.OptimismConfig{
 			EIP1559Denominator:       eip1559Denom,
 			EIP1559Elasticity:        eip1559Elasticity,
@@ -99,7 +100,7 @@ func NewL2Genesis(config *DeployConfig, block *types.Block) (*core.Genesis, erro
 	return &core.Genesis{
 		Config:     &optimismChainConfig,
 		Nonce:      uint64(config.L2GenesisBlockNonce),
-		Timestamp:  block.Time(),
+		Timestamp:  genesisTime,
 		ExtraData:  extraData,
 		GasLimit:   uint64(gasLimit),
 		Difficulty: difficulty.ToInt(),
@@ -97,6 +97,33 @@ func GetBlockFromTag(chain ethereum.ChainReader, tag *rpc.BlockNumberOrHash) (*t
 	}
 }
 
+// GetHeaderFromTag fetches the header of the L1 block of the tag, to anchor an L2 genesis to it.
+func GetHeaderFromTag(ctx context.Context, chain ethereum.ChainReader, tag rpc.BlockNumberOrHash) (*types.Header, error) {
+	if hash, ok := tag.Hash(); ok {
+		header, err := chain.HeaderByHash(ctx, hash)
+		if err != nil {
+			return nil, fmt.Errorf("cannot fetch header %s: %w", hash, err)
+		}
+		return header, nil
+	} else if num, ok := tag.Number(); ok {
+		var number *big.Int
+		// the latest block is fetched with a nil number, the other labels are negative numbers
+		if num != rpc.LatestBlockNumber {
+			if num < 0 {
+				return nil, fmt.Errorf("unsupported block tag: %v", tag)
+			}
+			number = big.NewInt(num.Int64())
+		}
+		header, err := chain.HeaderByNumber(ctx, number)
+		if err != nil {
+			return nil, fmt.Errorf("cannot fetch header %d: %w", num, err)
+		}
+		return header, nil
+	} else {
+		return nil, fmt.Errorf("invalid block tag: %v", tag)
+	}
+}
+
 // uint642Big creates a new *big.Int from a uint64.
 func uint642Big(in uint64) *big.Int {
 	return new(big.Int).SetUint64(in)
@@ -1,9 +1,11 @@
 package genesis
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
@@ -46,6 +48,9 @@ func init() {
 // the L1 chain.
 func BuildL1DeveloperGenesis(config *DeployConfig, dump *gstate.Dump, l1Deployments *L1Deployments, postProcess bool) (*core.Genesis, error) {
 	log.Info("Building developer L1 genesis block")
+	if err := config.Check(); err != nil {
+		return nil, err
+	}
 	genesis, err := NewL1Genesis(config)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create L1 developer genesis: %w", err)
@@ -87,9 +92,53 @@ func BuildL1DeveloperGenesis(config *DeployConfig, dump *gstate.Dump, l1Deployme
 		}
 	}
 
+	if err := SetL1GenesisAlloc(memDB, config.L1GenesisAlloc, dump, l1Deployments); err != nil {
+		return nil, fmt.Errorf("failed to set L1 genesis alloc: %w", err)
+	}
+
 	return memDB.Genesis(), nil
 }
 
+// SetL1GenesisAlloc adds the accounts of the alloc to the L1 genesis state. The accounts must not collide with
+// the accounts of the dump, nor with the L1 deployments, if any. The accounts are set in order of address,
+// so that the genesis is the same for the same inputs.
+func SetL1GenesisAlloc(stateDB *state.MemoryStateDB, alloc core.GenesisAlloc, dump *gstate.Dump, deployments *L1Deployments) error {
+	addrs := make([]common.Address, 0, len(alloc))
+	for addr := range alloc {
+		if dump != nil {
+			if _, ok := dump.Accounts[addr]; ok {
+				return fmt.Errorf("account %s collides with an account of the L1 allocs", addr)
+			}
+		}
+		if deployments != nil {
+			if name := deployments.GetName(addr); name != "" && addr != (common.Address{}) {
+				return fmt.Errorf("account %s collides with the %s deployment", addr, name)
+			}
+		}
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+
+	for _, addr := range addrs {
+		account := alloc[addr]
+		log.Info("Setting genesis alloc account", "address", addr.Hex())
+		stateDB.CreateAccount(addr)
+		stateDB.SetNonce(addr, account.Nonce)
+		if account.Balance != nil {
+			stateDB.AddBalance(addr, account.Balance)
+		}
+		if len(account.Code) > 0 {
+			stateDB.SetCode(addr, account.Code)
+		}
+		for key, value := range account.Storage {
+			stateDB.SetState(addr, key, value)
+		}
+	}
+	return nil
+}
+
 // PostProcessL1DeveloperGenesis will apply post processing to the L1 genesis
 // state. This is required to handle edge cases in the genesis generation.
 // `block.number` is used during deployment and without specifically setting
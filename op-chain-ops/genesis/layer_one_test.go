@@ -2,6 +2,7 @@ package genesis
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"math/big"
 	"os"
@@ -15,8 +16,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/deployer"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
@@ -138,3 +141,83 @@ func TestBuildL1DeveloperGenesis(t *testing.T) {
 	_, err = bridge.DepositETH(tOpts, 200000, nil)
 	require.NoError(t, err)
 }
+
+func TestBuildL1DeveloperGenesisAlloc(t *testing.T) {
+	b, err := os.ReadFile("testdata/test-deploy-config-full.json")
+	require.NoError(t, err)
+	config := new(DeployConfig)
+	require.NoError(t, json.NewDecoder(bytes.NewReader(b)).Decode(config))
+	config.L1GenesisBlockTimestamp = hexutil.Uint64(time.Now().Unix() - 100)
+
+	c, err := os.ReadFile("testdata/allocs-l1.json")
+	require.NoError(t, err)
+	dump := new(state.Dump)
+	require.NoError(t, json.NewDecoder(bytes.NewReader(c)).Decode(dump))
+
+	deployments, err := NewL1Deployments("testdata/deploy.json")
+	require.NoError(t, err)
+
+	baseline, err := BuildL1DeveloperGenesis(config, dump, nil, false)
+	require.NoError(t, err)
+
+	funded := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	contract := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	rich := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	code := []byte{0x60, 0x2a, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3} // returns 42
+	slot, value := common.Hash{0x01}, common.Hash{0x02}
+	config.L1GenesisAlloc = core.GenesisAlloc{
+		funded:   {Balance: big.NewInt(params.Ether)},
+		contract: {Balance: big.NewInt(1), Code: code, Storage: map[common.Hash]common.Hash{slot: value}},
+		rich:     {Balance: new(big.Int).Mul(big.NewInt(1_000_000), big.NewInt(params.Ether)), Nonce: 5},
+	}
+	genesis, err := BuildL1DeveloperGenesis(config, dump, deployments, false)
+	require.NoError(t, err)
+
+	// the accounts of the baseline genesis, including the deployed contracts, are unchanged
+	require.Len(t, genesis.Alloc, len(baseline.Alloc)+3)
+	for addr, account := range baseline.Alloc {
+		require.Equal(t, account, genesis.Alloc[addr], "account %s", addr)
+	}
+
+	sim := backends.NewSimulatedBackend(genesis.Alloc, 15000000)
+	ctx := context.Background()
+	for addr, account := range config.L1GenesisAlloc {
+		balance, err := sim.BalanceAt(ctx, addr, nil)
+		require.NoError(t, err)
+		require.Equal(t, account.Balance, balance)
+	}
+	nonce, err := sim.NonceAt(ctx, rich, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, nonce)
+	stored, err := sim.StorageAt(ctx, contract, slot, nil)
+	require.NoError(t, err)
+	require.Equal(t, value[:], stored)
+	res, err := sim.CallContract(ctx, ethereum.CallMsg{To: &contract}, nil)
+	require.NoError(t, err)
+	require.Equal(t, common.BigToHash(big.NewInt(42)).Bytes(), res)
+
+	oracle, err := bindings.NewL2OutputOracle(deployments.L2OutputOracleProxy, sim)
+	require.NoError(t, err)
+	proposer, err := oracle.PROPOSER(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.Equal(t, config.L2OutputOracleProposer, proposer)
+
+	// the genesis is the same for the same inputs
+	again, err := BuildL1DeveloperGenesis(config, dump, deployments, false)
+	require.NoError(t, err)
+	require.Equal(t, genesis.ToBlock().Hash(), again.ToBlock().Hash())
+
+	t.Run("collision with the L1 allocs", func(t *testing.T) {
+		cfg := config.Copy()
+		cfg.L1GenesisAlloc = core.GenesisAlloc{deployments.OptimismPortalProxy: {Balance: big.NewInt(1)}}
+		_, err := BuildL1DeveloperGenesis(cfg, dump, deployments, false)
+		require.ErrorContains(t, err, "collides with an account of the L1 allocs")
+	})
+
+	t.Run("collision with the L1 deployments", func(t *testing.T) {
+		cfg := config.Copy()
+		cfg.L1GenesisAlloc = core.GenesisAlloc{deployments.SystemConfigProxy: {Balance: big.NewInt(1)}}
+		_, err := BuildL1DeveloperGenesis(cfg, nil, deployments, false)
+		require.ErrorContains(t, err, "collides with the SystemConfigProxy deployment")
+	})
+}
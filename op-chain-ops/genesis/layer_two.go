@@ -1,21 +1,38 @@
 package genesis
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strconv"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-bindings/foundry"
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-bindings/solc"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/immutables"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/state"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
-// BuildL2Genesis will build the L2 genesis block.
-func BuildL2Genesis(config *DeployConfig, l1StartBlock *types.Block) (*core.Genesis, error) {
-	genspec, err := NewL2Genesis(config, l1StartBlock)
+// BuildL2Genesis will build the L2 genesis block, anchored to the header of any L1 block.
+// The L1Block predeploy starts with the values of the header.
+func BuildL2Genesis(config *DeployConfig, l1StartHeader *types.Header) (*core.Genesis, error) {
+	if err := config.Check(); err != nil {
+		return nil, err
+	}
+	if err := config.CheckL1StartHeader(l1StartHeader); err != nil {
+		return nil, err
+	}
+	genspec, err := NewL2Genesis(config, l1StartHeader)
 	if err != nil {
 		return nil, err
 	}
@@ -28,12 +45,12 @@ func BuildL2Genesis(config *DeployConfig, l1StartBlock *types.Block) (*core.Gene
 
 	SetPrecompileBalances(db)
 
-	storage, err := NewL2StorageConfig(config, l1StartBlock)
+	storage, err := NewL2StorageConfig(config, l1StartHeader)
 	if err != nil {
 		return nil, err
 	}
 
-	immutableConfig, err := NewL2ImmutableConfig(config, l1StartBlock)
+	immutableConfig, err := NewL2ImmutableConfig(config, l1StartHeader)
 	if err != nil {
 		return nil, err
 	}
@@ -75,5 +92,196 @@ func BuildL2Genesis(config *DeployConfig, l1StartBlock *types.Block) (*core.Gene
 		}
 	}
 
+	if err := setPredeployOverrides(db, config.L2GenesisPredeployOverrides); err != nil {
+		return nil, err
+	}
+
 	return db.Genesis(), nil
 }
+
+// PredeployOverride replaces the code of a predeploy, or adds a predeploy, in the L2 genesis.
+type PredeployOverride struct {
+	// Code is the deployed bytecode of the predeploy.
+	Code hexutil.Bytes `json:"code,omitempty"`
+	// Artifact is the path of a forge artifact, which deployed bytecode is used if the Code is empty.
+	Artifact string `json:"artifact,omitempty"`
+	// Immutables are the values of the immutables of the artifact, by name, or by the AST id of their declaration
+	// when the AST of the artifact does not declare them. They are substituted into the deployed bytecode, and
+	// are left-padded to the length of the immutable. Every immutable of the artifact must be set.
+	Immutables map[string]hexutil.Bytes `json:"immutables,omitempty"`
+	// Storage is set in the storage of the predeploy, which is the storage of the proxy of proxied predeploys.
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+	// ReplaceCore must be set to replace a predeploy of the predeploys package.
+	ReplaceCore bool `json:"replaceCore,omitempty"`
+}
+
+// DeployedBytecode returns the code of the override, or the deployed bytecode of its artifact with the immutables.
+func (o *PredeployOverride) DeployedBytecode() ([]byte, error) {
+	if len(o.Code) > 0 || o.Artifact == "" {
+		if len(o.Immutables) > 0 {
+			return nil, errors.New("immutables require an artifact")
+		}
+		return o.Code, nil
+	}
+	data, err := os.ReadFile(o.Artifact)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact: %w", err)
+	}
+	var artifact immutablesArtifact
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, fmt.Errorf("failed to decode artifact %s: %w", o.Artifact, err)
+	}
+	code, err := artifact.substituteImmutables(o.Immutables)
+	if err != nil {
+		return nil, fmt.Errorf("artifact %s: %w", o.Artifact, err)
+	}
+	return code, nil
+}
+
+// immutablesArtifact is a forge artifact with its AST, which names the immutables of the immutable references.
+type immutablesArtifact struct {
+	foundry.Artifact
+	Ast json.RawMessage `json:"ast"`
+}
+
+// substituteImmutables returns the deployed bytecode with the values of the immutables at their references.
+func (a *immutablesArtifact) substituteImmutables(values map[string]hexutil.Bytes) ([]byte, error) {
+	code := bytes.Clone(a.DeployedBytecode.Object)
+	var refs map[string][]solc.LinkReferenceOffset
+	if len(a.DeployedBytecode.ImmutableReferences) > 0 {
+		if err := json.Unmarshal(a.DeployedBytecode.ImmutableReferences, &refs); err != nil {
+			return nil, fmt.Errorf("failed to decode immutable references: %w", err)
+		}
+	}
+	if len(refs) == 0 {
+		if len(values) > 0 {
+			return nil, errors.New("no immutable references to substitute the immutables at")
+		}
+		return code, nil
+	}
+	names, err := astImmutableNames(a.Ast)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(refs))
+	for id := range refs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	used := make(map[string]bool)
+	for _, id := range ids {
+		key := names[id]
+		value, ok := values[key]
+		if !ok {
+			key = id
+			value, ok = values[id]
+		}
+		if !ok {
+			if names[id] == "" {
+				return nil, fmt.Errorf("immutable with AST id %s is not set, and is not declared in the AST", id)
+			}
+			return nil, fmt.Errorf("immutable %s is not set", names[id])
+		}
+		used[key] = true
+		for _, ref := range refs[id] {
+			if uint(len(value)) > ref.Length {
+				return nil, fmt.Errorf("immutable %s is %d bytes, which is longer than %d", key, len(value), ref.Length)
+			}
+			if ref.Start+ref.Length > uint(len(code)) {
+				return nil, fmt.Errorf("immutable %s is referenced outside of the deployed bytecode", key)
+			}
+			word := code[ref.Start : ref.Start+ref.Length]
+			clear(word)
+			copy(word[len(word)-len(value):], value)
+		}
+	}
+	for key := range values {
+		if !used[key] {
+			return nil, fmt.Errorf("unknown immutable %s", key)
+		}
+	}
+	return code, nil
+}
+
+// astImmutableNames returns the names of the immutables that are declared in the AST, by the id of the declaration.
+func astImmutableNames(ast json.RawMessage) (map[string]string, error) {
+	names := make(map[string]string)
+	if len(ast) == 0 {
+		return names, nil
+	}
+	var root any
+	if err := json.Unmarshal(ast, &root); err != nil {
+		return nil, fmt.Errorf("failed to decode AST: %w", err)
+	}
+	var walk func(node any)
+	walk = func(node any) {
+		switch node := node.(type) {
+		case map[string]any:
+			if node["nodeType"] == "VariableDeclaration" && node["mutability"] == "immutable" {
+				if id, ok := node["id"].(float64); ok {
+					name, _ := node["name"].(string)
+					names[strconv.FormatUint(uint64(id), 10)] = name
+				}
+			}
+			for _, child := range node {
+				walk(child)
+			}
+		case []any:
+			for _, child := range node {
+				walk(child)
+			}
+		}
+	}
+	walk(root)
+	return names, nil
+}
+
+// setPredeployOverrides applies the predeploy overrides, in order of address, so that the genesis is the same
+// for the same overrides. Proxied predeploys keep their proxy, and get the code of the override as implementation.
+func setPredeployOverrides(db *state.MemoryStateDB, overrides map[common.Address]*PredeployOverride) error {
+	addrs := make([]common.Address, 0, len(overrides))
+	for addr := range overrides {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+
+	for _, addr := range addrs {
+		override := overrides[addr]
+		if override == nil {
+			return fmt.Errorf("predeploy override %s is empty", addr)
+		}
+		code, err := override.DeployedBytecode()
+		if err != nil {
+			return fmt.Errorf("predeploy override %s: %w", addr, err)
+		}
+		if len(code) == 0 {
+			return fmt.Errorf("predeploy override %s has no code", addr)
+		}
+		for name, predeploy := range predeploys.Predeploys {
+			if predeploy.Address == addr && !override.ReplaceCore {
+				return fmt.Errorf("predeploy override %s replaces the core predeploy %s, which requires replaceCore", addr, name)
+			}
+		}
+
+		codeAddr := addr
+		if IsL2DevPredeploy(addr) && db.GetState(addr, AdminSlot) != (common.Hash{}) {
+			codeAddr, err = AddressToCodeNamespace(addr)
+			if err != nil {
+				return fmt.Errorf("error converting to code namespace: %w", err)
+			}
+			db.CreateAccount(codeAddr)
+			db.SetState(addr, ImplementationSlot, eth.AddressAsLeftPaddedHash(codeAddr))
+		} else {
+			db.CreateAccount(addr)
+		}
+		log.Info("Overriding predeploy", "address", addr, "code", codeAddr, "storage", len(override.Storage))
+		db.SetCode(codeAddr, code)
+		for key, value := range override.Storage {
+			db.SetState(addr, key, value)
+		}
+	}
+	return nil
+}
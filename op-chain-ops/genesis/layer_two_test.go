@@ -6,14 +6,20 @@ import (
 	"flag"
 	"math/big"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
@@ -40,7 +46,7 @@ func testBuildL2Genesis(t *testing.T, config *genesis.DeployConfig) *core.Genesi
 	block, err := backend.BlockByNumber(context.Background(), common.Big0)
 	require.NoError(t, err)
 
-	gen, err := genesis.BuildL2Genesis(config, block)
+	gen, err := genesis.BuildL2Genesis(config, block.Header())
 	require.Nil(t, err)
 	require.NotNil(t, gen)
 
@@ -101,3 +107,260 @@ func TestBuildL2MainnetNoGovernanceGenesis(t *testing.T) {
 	gen := testBuildL2Genesis(t, config)
 	require.Equal(t, 2323, len(gen.Alloc))
 }
+
+func TestBuildL2GenesisPredeployOverrides(t *testing.T) {
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{}, 15000000)
+	l1Block, err := backend.BlockByNumber(context.Background(), common.Big0)
+	require.NoError(t, err)
+	config, err := genesis.NewDeployConfig("./testdata/test-deploy-config-devnet-l1.json")
+	require.NoError(t, err)
+	config.FundDevAccounts = false
+
+	baseline, err := genesis.BuildL2Genesis(config, l1Block.Header())
+	require.NoError(t, err)
+
+	// returns 42
+	constantCode := common.FromHex("0x602a60005260206000f3")
+	// returns the value of storage slot 0
+	storageCode := common.FromHex("0x60005460005260206000f3")
+	artifact := filepath.Join(t.TempDir(), "Experimental.json")
+	require.NoError(t, os.WriteFile(artifact, []byte(`{"deployedBytecode":{"object":"0x60005460005260206000f3"}}`), 0644))
+
+	experimental := common.HexToAddress("0x4200000000000000000000000000000000000100")
+	external := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	config.L2GenesisPredeployOverrides = map[common.Address]*genesis.PredeployOverride{
+		predeploys.GasPriceOracleAddr: {Code: constantCode, ReplaceCore: true},
+		experimental: {
+			Artifact: artifact,
+			Storage:  map[common.Hash]common.Hash{{}: common.BigToHash(big.NewInt(7))},
+		},
+		external: {
+			Code:    storageCode,
+			Storage: map[common.Hash]common.Hash{{}: common.BigToHash(big.NewInt(9))},
+		},
+	}
+	gen, err := genesis.BuildL2Genesis(config, l1Block.Header())
+	require.NoError(t, err)
+
+	// the proxies of the overridden predeploys are preserved
+	proxyBytecode, err := bindings.GetDeployedBytecode("Proxy")
+	require.NoError(t, err)
+	for _, addr := range []common.Address{predeploys.GasPriceOracleAddr, experimental} {
+		require.Equal(t, proxyBytecode, gen.Alloc[addr].Code)
+		impl, err := genesis.AddressToCodeNamespace(addr)
+		require.NoError(t, err)
+		require.Equal(t, eth.AddressAsLeftPaddedHash(impl), gen.Alloc[addr].Storage[genesis.ImplementationSlot])
+	}
+	require.Equal(t, storageCode, []byte(gen.Alloc[external].Code))
+	// the other accounts are unchanged
+	gasPriceOracleImpl, err := genesis.AddressToCodeNamespace(predeploys.GasPriceOracleAddr)
+	require.NoError(t, err)
+	require.Equal(t, constantCode, []byte(gen.Alloc[gasPriceOracleImpl].Code))
+	for addr, account := range baseline.Alloc {
+		if addr == predeploys.GasPriceOracleAddr || addr == experimental || addr == gasPriceOracleImpl {
+			continue
+		}
+		require.Equal(t, account, gen.Alloc[addr], "account %s", addr)
+	}
+
+	// the overrides are part of the genesis, which is reproducible
+	again, err := genesis.BuildL2Genesis(config, l1Block.Header())
+	require.NoError(t, err)
+	require.Equal(t, gen.ToBlock().Hash(), again.ToBlock().Hash())
+	require.NotEqual(t, baseline.ToBlock().Hash(), gen.ToBlock().Hash())
+
+	devnet := backends.NewSimulatedBackend(gen.Alloc, 15000000)
+	for addr, expected := range map[common.Address]int64{
+		predeploys.GasPriceOracleAddr: 42,
+		experimental:                  7,
+		external:                      9,
+	} {
+		addr := addr
+		res, err := devnet.CallContract(context.Background(), ethereum.CallMsg{To: &addr}, nil)
+		require.NoError(t, err)
+		require.Equal(t, common.BigToHash(big.NewInt(expected)).Bytes(), res, "predeploy %s", addr)
+	}
+
+	t.Run("core predeploys require replaceCore", func(t *testing.T) {
+		config.L2GenesisPredeployOverrides = map[common.Address]*genesis.PredeployOverride{
+			predeploys.WETH9Addr: {Code: constantCode},
+		}
+		_, err := genesis.BuildL2Genesis(config, l1Block.Header())
+		require.ErrorContains(t, err, "replaces the core predeploy WETH9, which requires replaceCore")
+	})
+
+	t.Run("overrides require code", func(t *testing.T) {
+		config.L2GenesisPredeployOverrides = map[common.Address]*genesis.PredeployOverride{
+			experimental: {Storage: map[common.Hash]common.Hash{{}: {0x01}}},
+		}
+		_, err := genesis.BuildL2Genesis(config, l1Block.Header())
+		require.ErrorContains(t, err, "has no code")
+	})
+}
+
+func TestBuildL2GenesisAtL1Header(t *testing.T) {
+	config, err := genesis.NewDeployConfig("./testdata/test-deploy-config-devnet-l1.json")
+	require.NoError(t, err)
+	config.FundDevAccounts = false
+	config.L2GenesisBlockTimestampOffset = 6
+	canyonOffset := hexutil.Uint64(10)
+	config.L2GenesisCanyonTimeOffset = &canyonOffset
+
+	// an L1 block far after the L1 genesis
+	header := &types.Header{
+		ParentHash: common.Hash{0xaa},
+		Number:     big.NewInt(4_500_000),
+		Time:       1_700_000_000,
+		BaseFee:    big.NewInt(7_000_000_000),
+		GasLimit:   30_000_000,
+		Difficulty: common.Big0,
+	}
+	gen, err := genesis.BuildL2Genesis(config, header)
+	require.NoError(t, err)
+	require.Equal(t, header.Time+6, gen.Timestamp)
+	require.Equal(t, header.Time+6+10, *gen.Config.CanyonTime, "the fork offsets are relative to the L2 genesis")
+
+	devnet := backends.NewSimulatedBackend(gen.Alloc, 15000000)
+	l1Block, err := bindings.NewL1BlockCaller(predeploys.L1BlockAddr, devnet)
+	require.NoError(t, err)
+	number, err := l1Block.Number(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.Equal(t, header.Number.Uint64(), number)
+	timestamp, err := l1Block.Timestamp(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.Equal(t, header.Time, timestamp)
+	basefee, err := l1Block.Basefee(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.Equal(t, header.BaseFee, basefee)
+	hash, err := l1Block.Hash(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), common.Hash(hash))
+
+	config.OptimismPortalProxy = common.Address{0x01}
+	config.SystemConfigProxy = common.Address{0x02}
+	rollupConfig, err := config.RollupConfig(header, gen.ToBlock().Hash(), 0)
+	require.NoError(t, err)
+	require.Equal(t, gen.Timestamp, rollupConfig.Genesis.L2Time)
+	require.Equal(t, header.Hash(), rollupConfig.Genesis.L1.Hash)
+	require.Equal(t, header.Number.Uint64(), rollupConfig.Genesis.L1.Number)
+
+	t.Run("timestamp offset beyond the sequencer drift", func(t *testing.T) {
+		config := config.Copy()
+		config.L2GenesisBlockTimestampOffset = hexutil.Uint64(config.MaxSequencerDrift + 1)
+		_, err := genesis.BuildL2Genesis(config, header)
+		require.ErrorIs(t, err, genesis.ErrInvalidDeployConfig)
+		require.ErrorContains(t, err, "larger than maxSequencerDrift")
+	})
+
+	t.Run("output oracle starting between L2 blocks", func(t *testing.T) {
+		config := config.Copy()
+		config.L2OutputOracleStartingTimestamp = int(gen.Timestamp + 1)
+		_, err := genesis.BuildL2Genesis(config, header)
+		require.ErrorContains(t, err, "is not an L2 block after the L2 genesis")
+
+		config.L2OutputOracleStartingTimestamp = int(gen.Timestamp + 2*config.L2BlockTime)
+		_, err = genesis.BuildL2Genesis(config, header)
+		require.NoError(t, err)
+	})
+
+	t.Run("pre-London header", func(t *testing.T) {
+		preLondon := types.CopyHeader(header)
+		preLondon.BaseFee = nil
+		_, err := genesis.BuildL2Genesis(config, preLondon)
+		require.ErrorContains(t, err, "has no base fee")
+	})
+}
+
+func TestGetHeaderFromTag(t *testing.T) {
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{}, 15000000)
+	backend.Commit()
+	backend.Commit()
+	first, err := backend.HeaderByNumber(context.Background(), common.Big1)
+	require.NoError(t, err)
+
+	header, err := genesis.GetHeaderFromTag(context.Background(), backend, rpc.BlockNumberOrHashWithNumber(1))
+	require.NoError(t, err)
+	require.Equal(t, first.Hash(), header.Hash())
+	header, err = genesis.GetHeaderFromTag(context.Background(), backend, rpc.BlockNumberOrHashWithHash(first.Hash(), true))
+	require.NoError(t, err)
+	require.Equal(t, first.Hash(), header.Hash())
+	header, err = genesis.GetHeaderFromTag(context.Background(), backend, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), header.Number.Uint64())
+}
+
+func TestBuildL2GenesisPredeployImmutables(t *testing.T) {
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{}, 15000000)
+	l1Block, err := backend.BlockByNumber(context.Background(), common.Big0)
+	require.NoError(t, err)
+	config, err := genesis.NewDeployConfig("./testdata/test-deploy-config-devnet-l1.json")
+	require.NoError(t, err)
+	config.FundDevAccounts = false
+
+	// the artifact returns its owner and value immutables
+	artifact := "./testdata/artifacts/TwoImmutables.json"
+	owner := common.HexToAddress("0x1000000000000000000000000000000000000002")
+	experimental := common.HexToAddress("0x4200000000000000000000000000000000000100")
+	config.L2GenesisPredeployOverrides = map[common.Address]*genesis.PredeployOverride{
+		experimental: {
+			Artifact: artifact,
+			Immutables: map[string]hexutil.Bytes{
+				"owner": owner.Bytes(),
+				// by the AST id of the value immutable
+				"5": {0x2a},
+			},
+		},
+	}
+	gen, err := genesis.BuildL2Genesis(config, l1Block.Header())
+	require.NoError(t, err)
+
+	devnet := backends.NewSimulatedBackend(gen.Alloc, 15000000)
+	res, err := devnet.CallContract(context.Background(), ethereum.CallMsg{To: &experimental}, nil)
+	require.NoError(t, err)
+	require.Equal(t, append(common.BytesToHash(owner.Bytes()).Bytes(), common.BigToHash(big.NewInt(42)).Bytes()...), res)
+
+	noReferences := filepath.Join(t.TempDir(), "NoReferences.json")
+	require.NoError(t, os.WriteFile(noReferences, []byte(`{"deployedBytecode":{"object":"0x602a60005260206000f3"}}`), 0644))
+	for _, test := range []struct {
+		name     string
+		override genesis.PredeployOverride
+		err      string
+	}{
+		{
+			name:     "unset immutable",
+			override: genesis.PredeployOverride{Artifact: artifact, Immutables: map[string]hexutil.Bytes{"owner": owner.Bytes()}},
+			err:      "immutable value is not set",
+		},
+		{
+			name:     "no immutables",
+			override: genesis.PredeployOverride{Artifact: artifact},
+			err:      "immutable owner is not set",
+		},
+		{
+			name:     "unknown immutable",
+			override: genesis.PredeployOverride{Artifact: artifact, Immutables: map[string]hexutil.Bytes{"owner": owner.Bytes(), "value": {0x01}, "other": {0x01}}},
+			err:      "unknown immutable other",
+		},
+		{
+			name:     "too long",
+			override: genesis.PredeployOverride{Artifact: artifact, Immutables: map[string]hexutil.Bytes{"owner": owner.Bytes(), "value": make([]byte, 33)}},
+			err:      "immutable value is 33 bytes, which is longer than 32",
+		},
+		{
+			name:     "no references",
+			override: genesis.PredeployOverride{Artifact: noReferences, Immutables: map[string]hexutil.Bytes{"owner": owner.Bytes()}},
+			err:      "no immutable references",
+		},
+		{
+			name:     "no artifact",
+			override: genesis.PredeployOverride{Code: []byte{0x00}, Immutables: map[string]hexutil.Bytes{"owner": owner.Bytes()}},
+			err:      "immutables require an artifact",
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.override.DeployedBytecode()
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package genesis
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+)
+
+// allocPlaceholder is the encoding of an empty alloc in a genesis, which the accounts are streamed into.
+var allocPlaceholder = []byte(`"alloc":{}`)
+
+// GenesisEncoder writes a genesis in the JSON encoding of core.Genesis, account by account, so that the alloc
+// does not have to be in memory. The accounts must be written in ascending order of address,
+// which is the order of the standard encoding, so that the output is the same as json.Marshal of the genesis.
+type GenesisEncoder struct {
+	w       io.Writer
+	suffix  []byte
+	last    *common.Address
+	written int
+	closed  bool
+}
+
+// NewGenesisEncoder writes the fields of the genesis up to its alloc, which is not written. The accounts are
+// written with WriteAccount, and the encoder must be closed to write the fields after the alloc.
+func NewGenesisEncoder(w io.Writer, header *core.Genesis) (*GenesisEncoder, error) {
+	withoutAlloc := *header
+	withoutAlloc.Alloc = core.GenesisAlloc{}
+	data, err := json.Marshal(withoutAlloc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode genesis: %w", err)
+	}
+	// the alloc is followed by fields that cannot contain an alloc, unlike the chain config before it
+	i := bytes.LastIndex(data, allocPlaceholder)
+	if i < 0 {
+		return nil, errors.New("cannot find the alloc in the encoded genesis")
+	}
+	prefixEnd := i + len(allocPlaceholder) - 1
+	if _, err := w.Write(data[:prefixEnd]); err != nil {
+		return nil, err
+	}
+	return &GenesisEncoder{w: w, suffix: data[prefixEnd:]}, nil
+}
+
+// WriteAccount writes an account of the alloc. The address must be greater than the one of the previous account.
+func (e *GenesisEncoder) WriteAccount(addr common.Address, account core.GenesisAccount) error {
+	if e.closed {
+		return errors.New("genesis encoder is closed")
+	}
+	if e.last != nil && bytes.Compare(addr[:], e.last[:]) <= 0 {
+		return fmt.Errorf("account %s is not after account %s", addr, e.last)
+	}
+	key, err := json.Marshal(common.UnprefixedAddress(addr))
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(account)
+	if err != nil {
+		return fmt.Errorf("cannot encode account %s: %w", addr, err)
+	}
+	buf := make([]byte, 0, len(key)+len(value)+2)
+	if e.written > 0 {
+		buf = append(buf, ',')
+	}
+	buf = append(buf, key...)
+	buf = append(buf, ':')
+	buf = append(buf, value...)
+	if _, err := e.w.Write(buf); err != nil {
+		return err
+	}
+	e.last = &addr
+	e.written++
+	return nil
+}
+
+// Close writes the end of the alloc, and the fields after it. It does not close the writer.
+func (e *GenesisEncoder) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	_, err := e.w.Write(e.suffix)
+	return err
+}
+
+// WriteGenesis writes the genesis with a GenesisEncoder. Only the addresses of the alloc are copied to sort them.
+func WriteGenesis(w io.Writer, gen *core.Genesis) error {
+	addrs := make([]common.Address, 0, len(gen.Alloc))
+	for addr := range gen.Alloc {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+
+	enc, err := NewGenesisEncoder(w, gen)
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if err := enc.WriteAccount(addr, gen.Alloc[addr]); err != nil {
+			return err
+		}
+	}
+	return enc.Close()
+}
+
+// DecodeGenesis reads a genesis in the JSON encoding of core.Genesis, and calls fn with every account of
+// the alloc, in the order of the input, instead of keeping the alloc in memory. The returned genesis has
+// an empty alloc.
+func DecodeGenesis(r io.Reader, fn func(addr common.Address, account core.GenesisAccount) error) (*core.Genesis, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	hasAlloc := false
+	for dec.More() {
+		key, err := decodeKey(dec)
+		if err != nil {
+			return nil, err
+		}
+		if key != "alloc" {
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, fmt.Errorf("cannot decode genesis field %s: %w", key, err)
+			}
+			fields[key] = value
+			continue
+		}
+
+		hasAlloc = true
+		if err := expectDelim(dec, '{'); err != nil {
+			return nil, fmt.Errorf("cannot decode alloc: %w", err)
+		}
+		for dec.More() {
+			key, err := decodeKey(dec)
+			if err != nil {
+				return nil, fmt.Errorf("cannot decode alloc: %w", err)
+			}
+			var addr common.UnprefixedAddress
+			if err := addr.UnmarshalText([]byte(key)); err != nil {
+				return nil, fmt.Errorf("invalid account address %q: %w", key, err)
+			}
+			var account core.GenesisAccount
+			if err := dec.Decode(&account); err != nil {
+				return nil, fmt.Errorf("cannot decode account %s: %w", key, err)
+			}
+			if err := fn(common.Address(addr), account); err != nil {
+				return nil, err
+			}
+		}
+		if err := expectDelim(dec, '}'); err != nil {
+			return nil, fmt.Errorf("cannot decode alloc: %w", err)
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	if !hasAlloc {
+		return nil, errors.New("missing required field 'alloc' for Genesis")
+	}
+
+	// the fields but the alloc are decoded as a genesis with an empty alloc
+	fields["alloc"] = json.RawMessage(`{}`)
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	var gen core.Genesis
+	if err := json.Unmarshal(data, &gen); err != nil {
+		return nil, fmt.Errorf("cannot decode genesis: %w", err)
+	}
+	return &gen, nil
+}
+
+// ReadGenesis reads a genesis with DecodeGenesis, and keeps the alloc in memory.
+func ReadGenesis(r io.Reader) (*core.Genesis, error) {
+	alloc := make(core.GenesisAlloc)
+	gen, err := DecodeGenesis(r, func(addr common.Address, account core.GenesisAccount) error {
+		alloc[addr] = account
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	gen.Alloc = alloc
+	return gen, nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("expected %s, got %v", delim, tok)
+	}
+	return nil
+}
+
+func decodeKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("expected a key, got %v", tok)
+	}
+	return key, nil
+}
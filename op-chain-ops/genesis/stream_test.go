@@ -0,0 +1,138 @@
+package genesis_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+)
+
+func TestGenesisStream(t *testing.T) {
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{}, 15000000)
+	l1Block, err := backend.BlockByNumber(context.Background(), common.Big0)
+	require.NoError(t, err)
+	config, err := genesis.NewDeployConfig("./testdata/test-deploy-config-devnet-l1.json")
+	require.NoError(t, err)
+	gen, err := genesis.BuildL2Genesis(config, l1Block.Header())
+	require.NoError(t, err)
+
+	expected, err := json.Marshal(gen)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, genesis.WriteGenesis(&buf, gen))
+	require.Equal(t, string(expected), buf.String(), "the streamed genesis is the standard encoding")
+
+	read, err := genesis.ReadGenesis(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Len(t, read.Alloc, len(gen.Alloc))
+	require.Equal(t, gen.ToBlock().Hash(), read.ToBlock().Hash())
+	again, err := json.Marshal(read)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(again))
+
+	t.Run("decode accounts", func(t *testing.T) {
+		var addrs []common.Address
+		header, err := genesis.DecodeGenesis(bytes.NewReader(buf.Bytes()), func(addr common.Address, account core.GenesisAccount) error {
+			addrs = append(addrs, addr)
+			require.Equal(t, gen.Alloc[addr].Nonce, account.Nonce)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, addrs, len(gen.Alloc))
+		require.Empty(t, header.Alloc)
+		require.Equal(t, gen.Config, header.Config)
+		require.Equal(t, gen.Timestamp, header.Timestamp)
+		require.Equal(t, gen.BaseFee, header.BaseFee)
+
+		stop := errors.New("stop")
+		_, err = genesis.DecodeGenesis(bytes.NewReader(buf.Bytes()), func(common.Address, core.GenesisAccount) error {
+			return stop
+		})
+		require.ErrorIs(t, err, stop)
+	})
+
+	t.Run("prefixed addresses", func(t *testing.T) {
+		read, err := genesis.ReadGenesis(strings.NewReader(`{"gasLimit":"0x1","difficulty":"0x0","alloc":{"0x0000000000000000000000000000000000000001":{"balance":"0x2"}}}`))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(2), read.Alloc[common.Address{19: 0x01}].Balance)
+	})
+
+	t.Run("missing alloc", func(t *testing.T) {
+		_, err := genesis.ReadGenesis(strings.NewReader(`{"gasLimit":"0x1","difficulty":"0x0"}`))
+		require.ErrorContains(t, err, "missing required field 'alloc'")
+	})
+
+	t.Run("account order", func(t *testing.T) {
+		enc, err := genesis.NewGenesisEncoder(io.Discard, gen)
+		require.NoError(t, err)
+		require.NoError(t, enc.WriteAccount(common.Address{0x02}, core.GenesisAccount{Balance: common.Big1}))
+		require.ErrorContains(t, enc.WriteAccount(common.Address{0x01}, core.GenesisAccount{Balance: common.Big1}), "is not after")
+		require.ErrorContains(t, enc.WriteAccount(common.Address{0x02}, core.GenesisAccount{Balance: common.Big1}), "is not after")
+	})
+}
+
+// syntheticAccount is the account with the index of the synthetic alloc, which addresses are in ascending order.
+func syntheticAccount(i uint64) (common.Address, core.GenesisAccount) {
+	var addr common.Address
+	binary.BigEndian.PutUint64(addr[12:], i+1)
+	return addr, core.GenesisAccount{
+		Balance: new(big.Int).SetUint64(i),
+		Nonce:   1,
+		Storage: map[common.Hash]common.Hash{{}: common.BytesToHash(addr[:])},
+	}
+}
+
+// BenchmarkGenesisStream streams a genesis with an alloc of a million accounts through the encoder and the decoder,
+// and reports the peak heap, which does not grow with the size of the alloc.
+func BenchmarkGenesisStream(b *testing.B) {
+	const accounts = 1_000_000
+	header := &core.Genesis{GasLimit: 30_000_000, Difficulty: common.Big0}
+
+	for n := 0; n < b.N; n++ {
+		r, w := io.Pipe()
+		go func() {
+			enc, err := genesis.NewGenesisEncoder(w, header)
+			if err != nil {
+				w.CloseWithError(err)
+				return
+			}
+			for i := uint64(0); i < accounts; i++ {
+				if err := enc.WriteAccount(syntheticAccount(i)); err != nil {
+					w.CloseWithError(err)
+					return
+				}
+			}
+			w.CloseWithError(enc.Close())
+		}()
+
+		var stats runtime.MemStats
+		var peak uint64
+		count := 0
+		_, err := genesis.DecodeGenesis(r, func(addr common.Address, account core.GenesisAccount) error {
+			count++
+			if count%100_000 == 0 {
+				runtime.ReadMemStats(&stats)
+				if stats.HeapInuse > peak {
+					peak = stats.HeapInuse
+				}
+			}
+			return nil
+		})
+		require.NoError(b, err)
+		require.Equal(b, accounts, count)
+		b.ReportMetric(float64(peak)/(1<<20), "peak-heap-MB")
+	}
+}
@@ -0,0 +1,491 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/solc"
+)
+
+// staticArrayLength matches the length of a static array in the label of its type, like uint256[3].
+var staticArrayLength = regexp.MustCompile(`\[(\d+)\]$`)
+
+// StorageLocation is the location of a variable, or of an element of a variable, in contract storage.
+type StorageLocation struct {
+	// Slot is the first storage slot of the value.
+	Slot common.Hash
+	// Offset is the offset in bytes of the value in the slot, from its least significant byte.
+	// Values smaller than 32 bytes share their slot with their neighbors.
+	Offset uint
+	Type   solc.StorageLayoutType
+}
+
+// StorageWrite is the write of a value to the bytes of a storage slot that belong to the value.
+type StorageWrite struct {
+	Key common.Hash
+	// Offset is the offset in bytes of the value in the slot, from its least significant byte.
+	Offset uint
+	// Size is the number of bytes of the value.
+	Size uint
+	// Value is the value, right aligned, not shifted by the offset.
+	Value common.Hash
+}
+
+// Apply returns the slot value prev, with the bytes of the write set to its value. The other bytes of the slot,
+// that belong to its neighbors, are kept.
+func (w *StorageWrite) Apply(prev common.Hash) common.Hash {
+	next := prev
+	for i := uint(0); i < w.Size; i++ {
+		next[31-w.Offset-i] = w.Value[31-i]
+	}
+	return next
+}
+
+// LocateVariable returns the storage location of the variable with the label, or of its element at the path.
+// The path holds the mapping keys and array indices of the element, from the outermost.
+func LocateVariable(layout *solc.StorageLayout, label string, path ...any) (StorageLocation, error) {
+	entry, err := layout.GetStorageLayoutEntry(label)
+	if err != nil {
+		return StorageLocation{}, err
+	}
+	typ, ok := layout.Types[entry.Type]
+	if !ok {
+		return StorageLocation{}, fmt.Errorf("storage type %s of %s not found", entry.Type, label)
+	}
+	loc := StorageLocation{
+		Slot:   common.BigToHash(new(big.Int).SetUint64(uint64(entry.Slot))),
+		Offset: entry.Offset,
+		Type:   typ,
+	}
+	for i, key := range path {
+		loc, err = locateElement(layout, loc, key)
+		if err != nil {
+			return StorageLocation{}, fmt.Errorf("cannot locate element %d of the path of %s: %w", i, label, err)
+		}
+	}
+	return loc, nil
+}
+
+// ComputeVariableSlots computes the storage writes that set the variable with the label, or its element at the path,
+// to the value. Dynamic arrays are set with their length, and mappings are set per key.
+func ComputeVariableSlots(layout *solc.StorageLayout, label string, path []any, value any) ([]StorageWrite, error) {
+	loc, err := LocateVariable(layout, label, path...)
+	if err != nil {
+		return nil, err
+	}
+	writes, err := computeWrites(layout, loc, value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode %s: %w", label, err)
+	}
+	return writes, nil
+}
+
+// SetVariable sets the variable with the label, or its element at the path, to the value in the storage
+// of the contract at the address in the db.
+func SetVariable(db vm.StateDB, address common.Address, layout *solc.StorageLayout, label string, path []any, value any) error {
+	writes, err := ComputeVariableSlots(layout, label, path, value)
+	if err != nil {
+		return err
+	}
+	for _, w := range writes {
+		db.SetState(address, w.Key, w.Apply(db.GetState(address, w.Key)))
+	}
+	return nil
+}
+
+// SetVariableInAlloc sets the variable with the label, or its element at the path, to the value in the storage
+// of the account at the address in the genesis alloc. The account is added if it does not exist.
+func SetVariableInAlloc(alloc core.GenesisAlloc, address common.Address, layout *solc.StorageLayout, label string, path []any, value any) error {
+	writes, err := ComputeVariableSlots(layout, label, path, value)
+	if err != nil {
+		return err
+	}
+	account := alloc[address]
+	if account.Storage == nil {
+		account.Storage = make(map[common.Hash]common.Hash)
+	}
+	if account.Balance == nil {
+		account.Balance = new(big.Int)
+	}
+	for _, w := range writes {
+		account.Storage[w.Key] = w.Apply(account.Storage[w.Key])
+	}
+	alloc[address] = account
+	return nil
+}
+
+// locateElement returns the location of the element of the mapping or array at loc, at the key.
+func locateElement(layout *solc.StorageLayout, loc StorageLocation, key any) (StorageLocation, error) {
+	switch {
+	case loc.Type.Encoding == "mapping":
+		keyType, ok := layout.Types[loc.Type.Key]
+		if !ok {
+			return StorageLocation{}, fmt.Errorf("key type %s not found", loc.Type.Key)
+		}
+		valueType, ok := layout.Types[loc.Type.Value]
+		if !ok {
+			return StorageLocation{}, fmt.Errorf("value type %s not found", loc.Type.Value)
+		}
+		encodedKey, err := encodeMappingKey(keyType, key)
+		if err != nil {
+			return StorageLocation{}, fmt.Errorf("invalid %s key: %w", keyType.Label, err)
+		}
+		return StorageLocation{
+			Slot: crypto.Keccak256Hash(encodedKey, loc.Slot.Bytes()),
+			Type: valueType,
+		}, nil
+	case loc.Type.Encoding == "dynamic_array":
+		index, err := toIndex(key)
+		if err != nil {
+			return StorageLocation{}, err
+		}
+		return elementLocation(layout, loc.Type, crypto.Keccak256Hash(loc.Slot.Bytes()), index)
+	case loc.Type.Encoding == "inplace" && loc.Type.Base != "":
+		index, err := toIndex(key)
+		if err != nil {
+			return StorageLocation{}, err
+		}
+		length, err := staticLength(loc.Type)
+		if err != nil {
+			return StorageLocation{}, err
+		}
+		if index >= length {
+			return StorageLocation{}, fmt.Errorf("index %d out of bounds of %s", index, loc.Type.Label)
+		}
+		return elementLocation(layout, loc.Type, loc.Slot, index)
+	default:
+		return StorageLocation{}, fmt.Errorf("cannot index %s", loc.Type.Label)
+	}
+}
+
+// elementLocation returns the location of the element at the index of the array with the type, of which
+// the elements start at the slot. Elements smaller than 32 bytes are packed, as many as fit in a slot.
+func elementLocation(layout *solc.StorageLayout, array solc.StorageLayoutType, start common.Hash, index uint64) (StorageLocation, error) {
+	base, ok := layout.Types[array.Base]
+	if !ok {
+		return StorageLocation{}, fmt.Errorf("base type %s not found", array.Base)
+	}
+	if base.NumberOfBytes == 0 {
+		return StorageLocation{}, fmt.Errorf("base type %s has no size", array.Base)
+	}
+	var slot, offset uint64
+	if base.NumberOfBytes < 32 {
+		perSlot := uint64(32 / base.NumberOfBytes)
+		slot = index / perSlot
+		offset = (index % perSlot) * uint64(base.NumberOfBytes)
+	} else {
+		slot = index * uint64((base.NumberOfBytes+31)/32)
+	}
+	return StorageLocation{
+		Slot:   addToSlot(start, slot),
+		Offset: uint(offset),
+		Type:   base,
+	}, nil
+}
+
+// computeWrites computes the storage writes that set the value at the location.
+func computeWrites(layout *solc.StorageLayout, loc StorageLocation, value any) ([]StorageWrite, error) {
+	typ := loc.Type
+	switch {
+	case typ.Encoding == "dynamic_array":
+		elems, err := toSlice(value)
+		if err != nil {
+			return nil, err
+		}
+		writes := []StorageWrite{{
+			Key:   loc.Slot,
+			Size:  32,
+			Value: common.BigToHash(new(big.Int).SetUint64(uint64(len(elems)))),
+		}}
+		start := crypto.Keccak256Hash(loc.Slot.Bytes())
+		return appendElementWrites(layout, writes, typ, start, elems)
+	case typ.Encoding == "inplace" && typ.Base != "":
+		elems, err := toSlice(value)
+		if err != nil {
+			return nil, err
+		}
+		length, err := staticLength(typ)
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(elems)) > length {
+			return nil, fmt.Errorf("%d elements do not fit in %s", len(elems), typ.Label)
+		}
+		return appendElementWrites(layout, nil, typ, loc.Slot, elems)
+	case typ.Encoding == "mapping":
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Map {
+			return nil, fmt.Errorf("%w: %s must be a map", errInvalidType, typ.Label)
+		}
+		var writes []StorageWrite
+		for _, key := range v.MapKeys() {
+			elem, err := locateElement(layout, loc, key.Interface())
+			if err != nil {
+				return nil, err
+			}
+			elemWrites, err := computeWrites(layout, elem, v.MapIndex(key).Interface())
+			if err != nil {
+				return nil, err
+			}
+			writes = append(writes, elemWrites...)
+		}
+		// map iteration is random, so the writes are sorted for a deterministic result
+		sort.SliceStable(writes, func(i, j int) bool {
+			return bytes.Compare(writes[i].Key[:], writes[j].Key[:]) < 0
+		})
+		return writes, nil
+	case typ.Encoding == "bytes":
+		return encodeBytesWrites(loc.Slot, value)
+	case typ.Encoding == "inplace":
+		val, err := encodeValueType(typ, value)
+		if err != nil {
+			return nil, err
+		}
+		return []StorageWrite{{Key: loc.Slot, Offset: loc.Offset, Size: typ.NumberOfBytes, Value: val}}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnimplemented, typ.Label)
+	}
+}
+
+func appendElementWrites(layout *solc.StorageLayout, writes []StorageWrite, array solc.StorageLayoutType, start common.Hash, elems []any) ([]StorageWrite, error) {
+	for i, elem := range elems {
+		loc, err := elementLocation(layout, array, start, uint64(i))
+		if err != nil {
+			return nil, err
+		}
+		elemWrites, err := computeWrites(layout, loc, elem)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		writes = append(writes, elemWrites...)
+	}
+	return writes, nil
+}
+
+// encodeBytesWrites encodes a string or bytes value. Values shorter than 32 bytes are stored in the slot,
+// with twice their length in the last byte. Longer values store twice their length plus one in the slot,
+// and their data from the slot keccak256(slot) on.
+func encodeBytesWrites(slot common.Hash, value any) ([]StorageWrite, error) {
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	case hexutil.Bytes:
+		data = v
+	default:
+		return nil, fmt.Errorf("%w: bytes must be a string or []byte", errInvalidType)
+	}
+	if len(data) < 32 {
+		padded := common.RightPadBytes(data, 32)
+		padded[31] = byte(len(data) * 2)
+		return []StorageWrite{{Key: slot, Size: 32, Value: common.BytesToHash(padded)}}, nil
+	}
+	writes := []StorageWrite{{
+		Key:   slot,
+		Size:  32,
+		Value: common.BigToHash(new(big.Int).SetUint64(uint64(len(data)*2 + 1))),
+	}}
+	start := crypto.Keccak256Hash(slot.Bytes())
+	for i := 0; i < len(data); i += 32 {
+		chunk := data[i:min(i+32, len(data))]
+		writes = append(writes, StorageWrite{
+			Key:   addToSlot(start, uint64(i/32)),
+			Size:  32,
+			Value: common.BytesToHash(common.RightPadBytes(chunk, 32)),
+		})
+	}
+	return writes, nil
+}
+
+// encodeValueType encodes a value of a value type, right aligned, and checks that it fits in the size of the type.
+func encodeValueType(typ solc.StorageLayoutType, value any) (common.Hash, error) {
+	var val common.Hash
+	var err error
+	switch label := typ.Label; {
+	case label == "bool":
+		val, err = encodeBoolValue(value)
+	case strings.HasPrefix(label, "address"), strings.HasPrefix(label, "contract "):
+		val, err = encodeAddressValue(value)
+	case strings.HasPrefix(label, "uint"), strings.HasPrefix(label, "enum "):
+		val, err = encodeUintValue(value)
+	case strings.HasPrefix(label, "int"):
+		val, err = encodeIntValue(value, typ.NumberOfBytes)
+	case strings.HasPrefix(label, "bytes"):
+		var b []byte
+		b, err = fixedBytes(value, typ.NumberOfBytes)
+		val = common.BytesToHash(b)
+	default:
+		return common.Hash{}, fmt.Errorf("%w: %s", errUnimplemented, label)
+	}
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid %s: %w", typ.Label, err)
+	}
+	if typ.NumberOfBytes < 32 && new(big.Int).Rsh(val.Big(), typ.NumberOfBytes*8).Sign() != 0 {
+		return common.Hash{}, fmt.Errorf("value %s overflows %s", val, typ.Label)
+	}
+	return val, nil
+}
+
+// encodeMappingKey encodes a mapping key as Solidity hashes it with the slot of the mapping:
+// value types are padded to 32 bytes, and strings and bytes are not padded.
+func encodeMappingKey(typ solc.StorageLayoutType, key any) ([]byte, error) {
+	if typ.Encoding == "bytes" {
+		switch k := key.(type) {
+		case string:
+			return []byte(k), nil
+		case []byte:
+			return k, nil
+		case hexutil.Bytes:
+			return k, nil
+		default:
+			return nil, fmt.Errorf("%w: key must be a string or []byte", errInvalidType)
+		}
+	}
+	if strings.HasPrefix(typ.Label, "bytes") {
+		// fixed size bytes are left aligned in the ABI encoding
+		b, err := fixedBytes(key, typ.NumberOfBytes)
+		if err != nil {
+			return nil, err
+		}
+		return common.RightPadBytes(b, 32), nil
+	}
+	val, err := encodeValueType(typ, key)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(typ.Label, "int") {
+		// signed integers are sign extended to 32 bytes in the ABI encoding
+		return common.BigToHash(sizedToInt(val, typ.NumberOfBytes)).Bytes(), nil
+	}
+	return val.Bytes(), nil
+}
+
+// encodeIntValue encodes a signed integer in two's complement, in the size in bytes.
+func encodeIntValue(value any, size uint) (common.Hash, error) {
+	var n *big.Int
+	switch v := value.(type) {
+	case int:
+		n = big.NewInt(int64(v))
+	case int64:
+		n = big.NewInt(v)
+	case int32:
+		n = big.NewInt(int64(v))
+	case int16:
+		n = big.NewInt(int64(v))
+	case int8:
+		n = big.NewInt(int64(v))
+	case *big.Int:
+		n = v
+	default:
+		return common.Hash{}, errInvalidType
+	}
+	bits := size * 8
+	limit := new(big.Int).Lsh(common.Big1, bits-1)
+	if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return common.Hash{}, fmt.Errorf("value %d overflows int%d", n, bits)
+	}
+	if n.Sign() < 0 {
+		n = new(big.Int).Add(n, new(big.Int).Lsh(common.Big1, bits))
+	}
+	return common.BigToHash(n), nil
+}
+
+// sizedToInt sign extends the two's complement value of the size in bytes to a 256 bits two's complement.
+func sizedToInt(val common.Hash, size uint) *big.Int {
+	n := val.Big()
+	if size == 32 || n.Bit(int(size*8-1)) == 0 {
+		return n
+	}
+	n.Sub(n, new(big.Int).Lsh(common.Big1, size*8))
+	return n.Add(n, new(big.Int).Lsh(common.Big1, 256))
+}
+
+// fixedBytes returns the value of a bytesN type, right padded to the size.
+func fixedBytes(value any, size uint) ([]byte, error) {
+	var b []byte
+	switch v := value.(type) {
+	case common.Hash:
+		b = v.Bytes()
+	case []byte:
+		b = v
+	case hexutil.Bytes:
+		b = v
+	case string:
+		decoded, err := hexutil.Decode(v)
+		if err != nil {
+			return nil, err
+		}
+		b = decoded
+	default:
+		return nil, errInvalidType
+	}
+	if uint(len(b)) > size {
+		return nil, fmt.Errorf("%d bytes do not fit in bytes%d", len(b), size)
+	}
+	return common.RightPadBytes(b, int(size)), nil
+}
+
+func toIndex(key any) (uint64, error) {
+	switch k := key.(type) {
+	case int:
+		if k < 0 {
+			return 0, fmt.Errorf("negative index %d", k)
+		}
+		return uint64(k), nil
+	case uint:
+		return uint64(k), nil
+	case uint64:
+		return k, nil
+	case *big.Int:
+		if !k.IsUint64() {
+			return 0, fmt.Errorf("index %d out of range", k)
+		}
+		return k.Uint64(), nil
+	default:
+		return 0, fmt.Errorf("%w: index must be an integer", errInvalidType)
+	}
+}
+
+func toSlice(value any) ([]any, error) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, errors.New("arrays must be set to a slice or array")
+	}
+	elems := make([]any, v.Len())
+	for i := range elems {
+		elems[i] = v.Index(i).Interface()
+	}
+	return elems, nil
+}
+
+func staticLength(typ solc.StorageLayoutType) (uint64, error) {
+	match := staticArrayLength.FindStringSubmatch(typ.Label)
+	if match == nil {
+		return 0, fmt.Errorf("no length in static array type %s", typ.Label)
+	}
+	return strconv.ParseUint(match[1], 10, 64)
+}
+
+// addToSlot returns the slot at the distance from the slot, wrapping around like the EVM.
+func addToSlot(slot common.Hash, distance uint64) common.Hash {
+	if distance == 0 {
+		return slot
+	}
+	sum := new(big.Int).Add(slot.Big(), new(big.Int).SetUint64(distance))
+	return common.BigToHash(new(big.Int).And(sum, new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 256), common.Big1)))
+}
@@ -0,0 +1,204 @@
+package state_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/solc"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/state"
+)
+
+// variablesLayout is the storage layout of the contract:
+//
+//	contract Variables {
+//	    uint128 total;
+//	    address owner;
+//	    bool paused;
+//	    uint8 version;
+//	    mapping(address => mapping(uint256 => bool)) approvals;
+//	    uint64[] checkpoints;
+//	    address[] members;
+//	    string name;
+//	    mapping(string => uint256) ids;
+//	    bytes4 selector;
+//	    int16 delta;
+//	    uint256[3] limits;
+//	}
+func variablesLayout(t *testing.T) *solc.StorageLayout {
+	data, err := os.ReadFile("./testdata/variables-layout.json")
+	require.NoError(t, err)
+	var layout solc.StorageLayout
+	require.NoError(t, json.Unmarshal(data, &layout))
+	return &layout
+}
+
+var contractAddr = common.Address{0xc0}
+
+func slotHash(n uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(n))
+}
+
+func slotAdd(slot common.Hash, n int64) common.Hash {
+	return common.BigToHash(new(big.Int).Add(slot.Big(), big.NewInt(n)))
+}
+
+func setVariable(t *testing.T, layout *solc.StorageLayout, alloc core.GenesisAlloc, label string, path []any, value any) {
+	require.NoError(t, state.SetVariableInAlloc(alloc, contractAddr, layout, label, path, value))
+}
+
+func TestSetVariablePacking(t *testing.T) {
+	layout := variablesLayout(t)
+	alloc := make(core.GenesisAlloc)
+	owner := common.HexToAddress("0x4200000000000000000000000000000000000042")
+
+	setVariable(t, layout, alloc, "owner", nil, owner)
+	setVariable(t, layout, alloc, "paused", nil, true)
+	setVariable(t, layout, alloc, "version", nil, uint8(3))
+	require.Equal(t, common.HexToHash("0x0000000000000000000003014200000000000000000000000000000000000042"), alloc[contractAddr].Storage[slotHash(1)])
+
+	// setting a variable keeps the neighbors in its slot
+	setVariable(t, layout, alloc, "paused", nil, false)
+	require.Equal(t, common.HexToHash("0x0000000000000000000003004200000000000000000000000000000000000042"), alloc[contractAddr].Storage[slotHash(1)])
+	setVariable(t, layout, alloc, "owner", nil, common.Address{})
+	require.Equal(t, common.HexToHash("0x0000000000000000000003000000000000000000000000000000000000000000"), alloc[contractAddr].Storage[slotHash(1)])
+
+	setVariable(t, layout, alloc, "total", nil, new(big.Int).Lsh(common.Big1, 127))
+	require.Equal(t, common.HexToHash("0x0000000000000000000000000000000080000000000000000000000000000000"), alloc[contractAddr].Storage[slotHash(0)])
+
+	setVariable(t, layout, alloc, "selector", nil, "0x11223344")
+	setVariable(t, layout, alloc, "delta", nil, -2)
+	require.Equal(t, common.HexToHash("0x0000000000000000000000000000000000000000000000000000fffe11223344"), alloc[contractAddr].Storage[slotHash(7)])
+
+	for label, value := range map[string]any{
+		"version":  uint64(256),
+		"total":    new(big.Int).Lsh(common.Big1, 128),
+		"delta":    40000,
+		"selector": "0x1122334455",
+	} {
+		_, err := state.ComputeVariableSlots(layout, label, nil, value)
+		require.Error(t, err, label)
+	}
+}
+
+func TestSetVariableNestedMapping(t *testing.T) {
+	layout := variablesLayout(t)
+	alloc := make(core.GenesisAlloc)
+	spender := common.HexToAddress("0x1234567890123456789012345678901234567890")
+
+	setVariable(t, layout, alloc, "approvals", []any{spender, 5}, true)
+	outer := crypto.Keccak256Hash(common.LeftPadBytes(spender.Bytes(), 32), slotHash(2).Bytes())
+	inner := crypto.Keccak256Hash(slotHash(5).Bytes(), outer.Bytes())
+	require.Equal(t, common.Hash{31: 1}, alloc[contractAddr].Storage[inner])
+
+	loc, err := state.LocateVariable(layout, "approvals", spender)
+	require.NoError(t, err)
+	require.Equal(t, outer, loc.Slot)
+	require.Equal(t, "mapping(uint256 => bool)", loc.Type.Label)
+
+	// a whole mapping is set per key
+	writes, err := state.ComputeVariableSlots(layout, "approvals", nil, map[common.Address]map[uint64]bool{
+		spender: {5: true, 6: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, writes, 2)
+	keys := []common.Hash{writes[0].Key, writes[1].Key}
+	require.Contains(t, keys, inner)
+	require.Contains(t, keys, crypto.Keccak256Hash(slotHash(6).Bytes(), outer.Bytes()))
+
+	setVariable(t, layout, alloc, "ids", []any{"optimism"}, 10)
+	require.Equal(t, slotHash(10), alloc[contractAddr].Storage[crypto.Keccak256Hash([]byte("optimism"), slotHash(6).Bytes())])
+
+	_, err = state.LocateVariable(layout, "approvals", spender, 5, 1)
+	require.ErrorContains(t, err, "cannot index bool")
+}
+
+func TestSetVariableDynamicArray(t *testing.T) {
+	layout := variablesLayout(t)
+	alloc := make(core.GenesisAlloc)
+
+	// four uint64 elements are packed in a slot
+	setVariable(t, layout, alloc, "checkpoints", nil, []uint64{1, 2, 3, 4, 5})
+	data := crypto.Keccak256Hash(slotHash(3).Bytes())
+	storage := alloc[contractAddr].Storage
+	require.Equal(t, slotHash(5), storage[slotHash(3)], "length")
+	require.Equal(t, common.HexToHash("0x0000000000000004000000000000000300000000000000020000000000000001"), storage[data])
+	require.Equal(t, common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000005"), storage[slotAdd(data, 1)])
+
+	setVariable(t, layout, alloc, "checkpoints", []any{5}, uint64(6))
+	require.Equal(t, common.HexToHash("0x0000000000000000000000000000000000000000000000060000000000000005"), storage[slotAdd(data, 1)])
+
+	// addresses are not packed
+	members := []common.Address{{1}, {2}}
+	setVariable(t, layout, alloc, "members", nil, members)
+	data = crypto.Keccak256Hash(slotHash(4).Bytes())
+	require.Equal(t, slotHash(2), storage[slotHash(4)], "length")
+	require.Equal(t, common.BytesToHash(members[0].Bytes()), storage[data])
+	require.Equal(t, common.BytesToHash(members[1].Bytes()), storage[slotAdd(data, 1)])
+
+	loc, err := state.LocateVariable(layout, "members", 1)
+	require.NoError(t, err)
+	require.Equal(t, slotAdd(data, 1), loc.Slot)
+	require.Equal(t, uint(0), loc.Offset)
+}
+
+func TestSetVariableStaticArray(t *testing.T) {
+	layout := variablesLayout(t)
+	alloc := make(core.GenesisAlloc)
+
+	setVariable(t, layout, alloc, "limits", []any{2}, 7)
+	require.Equal(t, slotHash(7), alloc[contractAddr].Storage[slotHash(10)])
+
+	_, err := state.LocateVariable(layout, "limits", 3)
+	require.ErrorContains(t, err, "out of bounds")
+	_, err = state.ComputeVariableSlots(layout, "limits", nil, []uint64{1, 2, 3, 4})
+	require.ErrorContains(t, err, "do not fit")
+}
+
+func TestSetVariableString(t *testing.T) {
+	layout := variablesLayout(t)
+	alloc := make(core.GenesisAlloc)
+
+	setVariable(t, layout, alloc, "name", nil, "short")
+	require.Equal(t, common.HexToHash("0x73686f727400000000000000000000000000000000000000000000000000000a"), alloc[contractAddr].Storage[slotHash(5)])
+
+	long := strings.Repeat("a", 40)
+	setVariable(t, layout, alloc, "name", nil, long)
+	storage := alloc[contractAddr].Storage
+	data := crypto.Keccak256Hash(slotHash(5).Bytes())
+	require.Equal(t, slotHash(81), storage[slotHash(5)])
+	require.Equal(t, common.BytesToHash([]byte(long[:32])), storage[data])
+	require.Equal(t, common.BytesToHash(common.RightPadBytes([]byte(long[32:]), 32)), storage[slotAdd(data, 1)])
+}
+
+func TestSetVariableInStateDB(t *testing.T) {
+	layout := variablesLayout(t)
+	alloc := make(core.GenesisAlloc)
+	db := state.NewMemoryStateDB(nil)
+	db.CreateAccount(contractAddr)
+
+	for _, set := range []struct {
+		label string
+		path  []any
+		value any
+	}{
+		{"owner", nil, common.Address{0xaa}},
+		{"version", nil, uint8(2)},
+		{"approvals", []any{common.Address{0xbb}, 1}, true},
+		{"checkpoints", nil, []uint64{7, 8}},
+		{"checkpoints", []any{1}, uint64(9)},
+	} {
+		setVariable(t, layout, alloc, set.label, set.path, set.value)
+		require.NoError(t, state.SetVariable(db, contractAddr, layout, set.label, set.path, set.value))
+	}
+	for key, value := range alloc[contractAddr].Storage {
+		require.Equal(t, value, db.GetState(contractAddr, key))
+	}
+}
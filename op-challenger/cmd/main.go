@@ -51,6 +51,8 @@ func run(ctx context.Context, args []string, action ConfiguredLifecycle) error {
 			return nil, err
 		}
 		logger.Info("Starting op-challenger", "version", VersionWithMeta)
+		// the challenger has no admin RPC to change the log level
+		oplog.CycleLogLevelOnSignal(ctx.Context, logger)
 
 		cfg, err := flags.NewConfigFromCLI(ctx)
 		if err != nil {
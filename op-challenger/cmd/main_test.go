@@ -189,6 +189,47 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestAgreementPolicy(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.AgreementPolicyFull, cfg.AgreementPolicy)
+	})
+
+	for _, policy := range config.AgreementPolicies {
+		policy := policy
+		t.Run("Valid-"+policy.String(), func(t *testing.T) {
+			cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--agreement-policy", policy.String()))
+			require.Equal(t, policy, cfg.AgreementPolicy)
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"unknown agreement policy: \"nope\"",
+			addRequiredArgs(config.TraceTypeAlphabet, "--agreement-policy", "nope"))
+	})
+}
+
+func TestResponseConcurrency(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultResponseConcurrency, cfg.ResponseConcurrency)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--response-concurrency", "12"))
+		require.Equal(t, uint(12), cfg.ResponseConcurrency)
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"response-concurrency must not be 0",
+			addRequiredArgs(config.TraceTypeAlphabet, "--response-concurrency", "0"))
+	})
+}
+
 func TestPollInterval(t *testing.T) {
 	t.Run("UsesDefault", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon))
@@ -19,6 +19,7 @@ var (
 	ErrMissingTraceType              = errors.New("no supported trace types specified")
 	ErrMissingDatadir                = errors.New("missing datadir")
 	ErrMaxConcurrencyZero            = errors.New("max concurrency must not be 0")
+	ErrResponseConcurrencyZero       = errors.New("response concurrency must not be 0")
 	ErrMissingCannonL2               = errors.New("missing cannon L2")
 	ErrMissingCannonBin              = errors.New("missing cannon bin")
 	ErrMissingCannonServer           = errors.New("missing cannon server")
@@ -34,6 +35,7 @@ var (
 	ErrCannonNetworkAndL2Genesis     = errors.New("only specify one of network or l2 genesis path")
 	ErrCannonNetworkUnknown          = errors.New("unknown cannon network")
 	ErrMissingRollupRpc              = errors.New("missing rollup rpc url")
+	ErrUnknownAgreementPolicy        = errors.New("unknown agreement policy")
 )
 
 type TraceType string
@@ -86,10 +88,68 @@ func ValidTraceType(value TraceType) bool {
 	return false
 }
 
+// AgreementPolicy controls which games the challenger participates in, by whether it agrees with their root claim.
+// Agreement with the root claim is determined by the trace of the game, for output root games from the outputs of
+// the configured rollup node.
+type AgreementPolicy string
+
+const (
+	// AgreementPolicyDefend only participates in games that the challenger agrees with, to defend their root claim.
+	AgreementPolicyDefend AgreementPolicy = "defend"
+	// AgreementPolicyChallenge only participates in games that the challenger disagrees with, to challenge their root claim.
+	AgreementPolicyChallenge AgreementPolicy = "challenge"
+	// AgreementPolicyFull participates in all games.
+	AgreementPolicyFull AgreementPolicy = "full"
+)
+
+var AgreementPolicies = []AgreementPolicy{AgreementPolicyDefend, AgreementPolicyChallenge, AgreementPolicyFull}
+
+func (p AgreementPolicy) String() string {
+	return string(p)
+}
+
+// Set implements the Set method required by the [cli.Generic] interface.
+func (p *AgreementPolicy) Set(value string) error {
+	if !ValidAgreementPolicy(AgreementPolicy(value)) {
+		return fmt.Errorf("unknown agreement policy: %q", value)
+	}
+	*p = AgreementPolicy(value)
+	return nil
+}
+
+func (p *AgreementPolicy) Clone() any {
+	cpy := *p
+	return &cpy
+}
+
+// Participates returns whether the policy allows moves in a game, by whether the challenger agrees with its root claim.
+func (p AgreementPolicy) Participates(agreeWithRootClaim bool) bool {
+	switch p {
+	case AgreementPolicyDefend:
+		return agreeWithRootClaim
+	case AgreementPolicyChallenge:
+		return !agreeWithRootClaim
+	case AgreementPolicyFull:
+		return true
+	default:
+		return false
+	}
+}
+
+func ValidAgreementPolicy(value AgreementPolicy) bool {
+	for _, p := range AgreementPolicies {
+		if p == value {
+			return true
+		}
+	}
+	return false
+}
+
 const (
-	DefaultPollInterval       = time.Second * 12
-	DefaultCannonSnapshotFreq = uint(1_000_000_000)
-	DefaultCannonInfoFreq     = uint(10_000_000)
+	DefaultPollInterval        = time.Second * 12
+	DefaultResponseConcurrency = uint(4)
+	DefaultCannonSnapshotFreq  = uint(1_000_000_000)
+	DefaultCannonInfoFreq      = uint(10_000_000)
 	// DefaultGameWindow is the default maximum time duration in the past
 	// that the challenger will look for games to progress.
 	// The default value is 11 days, which is a 4 day resolution buffer
@@ -101,16 +161,19 @@ const (
 // This also contains config options for auxiliary services.
 // It is used to initialize the challenger.
 type Config struct {
-	L1EthRpc           string           // L1 RPC Url
-	GameFactoryAddress common.Address   // Address of the dispute game factory
-	GameAllowlist      []common.Address // Allowlist of fault game addresses
-	GameWindow         time.Duration    // Maximum time duration to look for games to progress
-	Datadir            string           // Data Directory
-	MaxConcurrency     uint             // Maximum number of threads to use when progressing games
-	PollInterval       time.Duration    // Polling interval for latest-block subscription when using an HTTP RPC provider
+	L1EthRpc            string           // L1 RPC Url
+	GameFactoryAddress  common.Address   // Address of the dispute game factory
+	GameAllowlist       []common.Address // Allowlist of fault game addresses
+	GameWindow          time.Duration    // Maximum time duration to look for games to progress
+	Datadir             string           // Data Directory
+	MaxConcurrency      uint             // Maximum number of threads to use when progressing games
+	ResponseConcurrency uint             // Maximum number of responses to perform at once, across all games
+	PollInterval        time.Duration    // Polling interval for latest-block subscription when using an HTTP RPC provider
 
 	TraceTypes []TraceType // Type of traces supported
 
+	AgreementPolicy AgreementPolicy // Which games to participate in, by agreement with their root claim
+
 	// Specific to the alphabet trace provider
 	AlphabetTrace string // String for the AlphabetTraceProvider
 
@@ -140,13 +203,16 @@ func NewConfig(
 	supportedTraceTypes ...TraceType,
 ) Config {
 	return Config{
-		L1EthRpc:           l1EthRpc,
-		GameFactoryAddress: gameFactoryAddress,
-		MaxConcurrency:     uint(runtime.NumCPU()),
-		PollInterval:       DefaultPollInterval,
+		L1EthRpc:            l1EthRpc,
+		GameFactoryAddress:  gameFactoryAddress,
+		MaxConcurrency:      uint(runtime.NumCPU()),
+		ResponseConcurrency: DefaultResponseConcurrency,
+		PollInterval:        DefaultPollInterval,
 
 		TraceTypes: supportedTraceTypes,
 
+		AgreementPolicy: AgreementPolicyFull,
+
 		TxMgrConfig:   txmgr.NewCLIConfig(l1EthRpc, txmgr.DefaultChallengerFlagValues),
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
 		PprofConfig:   oppprof.DefaultCLIConfig(),
@@ -179,6 +245,12 @@ func (c Config) Check() error {
 	if c.MaxConcurrency == 0 {
 		return ErrMaxConcurrencyZero
 	}
+	if c.ResponseConcurrency == 0 {
+		return ErrResponseConcurrencyZero
+	}
+	if !ValidAgreementPolicy(c.AgreementPolicy) {
+		return ErrUnknownAgreementPolicy
+	}
 	if c.TraceTypeEnabled(TraceTypeOutputCannon) || c.TraceTypeEnabled(TraceTypeOutputAlphabet) {
 		if c.RollupRpc == "" {
 			return ErrMissingRollupRpc
@@ -127,6 +127,41 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestResponseConcurrency(t *testing.T) {
+	t.Run("Required", func(t *testing.T) {
+		config := validConfig(TraceTypeAlphabet)
+		config.ResponseConcurrency = 0
+		require.ErrorIs(t, config.Check(), ErrResponseConcurrencyZero)
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		config := validConfig(TraceTypeAlphabet)
+		require.Equal(t, DefaultResponseConcurrency, config.ResponseConcurrency)
+	})
+}
+
+func TestAgreementPolicy(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		config := validConfig(TraceTypeAlphabet)
+		require.Equal(t, AgreementPolicyFull, config.AgreementPolicy)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		config := validConfig(TraceTypeAlphabet)
+		config.AgreementPolicy = "nope"
+		require.ErrorIs(t, config.Check(), ErrUnknownAgreementPolicy)
+	})
+
+	t.Run("Participates", func(t *testing.T) {
+		require.True(t, AgreementPolicyDefend.Participates(true))
+		require.False(t, AgreementPolicyDefend.Participates(false))
+		require.False(t, AgreementPolicyChallenge.Participates(true))
+		require.True(t, AgreementPolicyChallenge.Participates(false))
+		require.True(t, AgreementPolicyFull.Participates(true))
+		require.True(t, AgreementPolicyFull.Participates(false))
+	})
+}
+
 func TestHttpPollInterval(t *testing.T) {
 	t.Run("Default", func(t *testing.T) {
 		config := validConfig(TraceTypeAlphabet)
@@ -50,6 +50,16 @@ var (
 		Usage:   "The trace types to support. Valid options: " + openum.EnumString(config.TraceTypes),
 		EnvVars: prefixEnvVars("TRACE_TYPE"),
 	}
+	AgreementPolicyFlag = &cli.GenericFlag{
+		Name: "agreement-policy",
+		Usage: "Which games to participate in, by whether the challenger agrees with their root claim. Valid options: " +
+			openum.EnumString(config.AgreementPolicies),
+		EnvVars: prefixEnvVars("AGREEMENT_POLICY"),
+		Value: func() *config.AgreementPolicy {
+			out := config.AgreementPolicyFull
+			return &out
+		}(),
+	}
 	DatadirFlag = &cli.StringFlag{
 		Name:    "datadir",
 		Usage:   "Directory to store data generated as part of responding to games",
@@ -62,6 +72,12 @@ var (
 		EnvVars: prefixEnvVars("MAX_CONCURRENCY"),
 		Value:   uint(runtime.NumCPU()),
 	}
+	ResponseConcurrencyFlag = &cli.UintFlag{
+		Name:    "response-concurrency",
+		Usage:   "Maximum number of responses to perform at once, across all games. The responses closest to their clock deadline are performed first",
+		EnvVars: prefixEnvVars("RESPONSE_CONCURRENCY"),
+		Value:   config.DefaultResponseConcurrency,
+	}
 	HTTPPollInterval = &cli.DurationFlag{
 		Name:    "http-poll-interval",
 		Usage:   "Polling interval for latest-block subscription when using an HTTP RPC provider.",
@@ -146,7 +162,9 @@ var requiredFlags = []cli.Flag{
 
 // optionalFlags is a list of unchecked cli flags
 var optionalFlags = []cli.Flag{
+	AgreementPolicyFlag,
 	MaxConcurrencyFlag,
+	ResponseConcurrencyFlag,
 	HTTPPollInterval,
 	RollupRpcFlag,
 	AlphabetFlag,
@@ -281,6 +299,10 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 	if maxConcurrency == 0 {
 		return nil, fmt.Errorf("%v must not be 0", MaxConcurrencyFlag.Name)
 	}
+	responseConcurrency := ctx.Uint(ResponseConcurrencyFlag.Name)
+	if responseConcurrency == 0 {
+		return nil, fmt.Errorf("%v must not be 0", ResponseConcurrencyFlag.Name)
+	}
 	return &config.Config{
 		// Required Flags
 		L1EthRpc:               ctx.String(L1EthRpcFlag.Name),
@@ -288,7 +310,9 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		GameFactoryAddress:     gameFactoryAddress,
 		GameAllowlist:          allowedGames,
 		GameWindow:             ctx.Duration(GameWindowFlag.Name),
+		AgreementPolicy:        config.AgreementPolicy(ctx.String(AgreementPolicyFlag.Name)),
 		MaxConcurrency:         maxConcurrency,
+		ResponseConcurrency:    responseConcurrency,
 		PollInterval:           ctx.Duration(HTTPPollInterval.Name),
 		RollupRpc:              ctx.String(RollupRpcFlag.Name),
 		AlphabetTrace:          ctx.String(AlphabetFlag.Name),
@@ -0,0 +1,194 @@
+package fault
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrActionObsolete is returned for a scheduled action that is no longer required by the on-chain state of its game.
+var ErrActionObsolete = errors.New("action is obsolete")
+
+var errSchedulerClosed = errors.New("action scheduler closed")
+
+// ScheduledAction is an action of a game, to be performed before its deadline.
+type ScheduledAction struct {
+	Game     common.Address
+	Action   types.Action
+	Deadline time.Time
+	// Obsolete checks the latest on-chain state of the game right before the action is performed,
+	// and returns true if the action is no longer required.
+	Obsolete func(ctx context.Context) (bool, error)
+	Perform  func(ctx context.Context) error
+}
+
+type pendingAction struct {
+	ScheduledAction
+	seq    uint64
+	result chan error
+}
+
+// actionQueue is a heap of the pending actions, ordered by deadline and then by the order they were scheduled in.
+type actionQueue []*pendingAction
+
+func (q actionQueue) Len() int { return len(q) }
+
+func (q actionQueue) Less(i, j int) bool {
+	if !q[i].Deadline.Equal(q[j].Deadline) {
+		return q[i].Deadline.Before(q[j].Deadline)
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q actionQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *actionQueue) Push(x any) { *q = append(*q, x.(*pendingAction)) }
+
+func (q *actionQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return item
+}
+
+// ActionScheduler performs the actions of all games with a bounded number of workers.
+// The pending action with the earliest deadline is always performed first, so that a burst of actions
+// in some games does not delay the responses of other games beyond their clock deadlines.
+// The transactions of the actions are sent through the txmgr shared by all games, which assigns the nonces
+// of concurrent sends.
+type ActionScheduler struct {
+	logger  log.Logger
+	clock   clock.Clock
+	workers uint
+
+	lock    sync.Mutex
+	pending actionQueue
+	seq     uint64
+	// wake signals the workers that actions are pending
+	wake chan struct{}
+
+	closed chan struct{}
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+func NewActionScheduler(logger log.Logger, cl clock.Clock, workers uint) *ActionScheduler {
+	return &ActionScheduler{
+		logger:  logger,
+		clock:   cl,
+		workers: workers,
+		wake:    make(chan struct{}, 1),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (s *ActionScheduler) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	for i := uint(0); i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.worker(ctx)
+	}
+}
+
+func (s *ActionScheduler) Close() error {
+	close(s.closed)
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+	return nil
+}
+
+// Perform schedules the actions, and waits for all of them to be performed.
+// It returns the result of every action, in the order of the actions.
+func (s *ActionScheduler) Perform(ctx context.Context, actions []ScheduledAction) []error {
+	pending := make([]*pendingAction, len(actions))
+	s.lock.Lock()
+	for i, action := range actions {
+		s.seq++
+		pending[i] = &pendingAction{ScheduledAction: action, seq: s.seq, result: make(chan error, 1)}
+		heap.Push(&s.pending, pending[i])
+	}
+	queued := s.pending.Len()
+	s.lock.Unlock()
+	if len(actions) > 0 {
+		s.logger.Debug("Scheduled actions", "count", len(actions), "pending", queued)
+		s.signal()
+	}
+
+	results := make([]error, len(actions))
+	for i, action := range pending {
+		select {
+		case results[i] = <-action.result:
+		case <-ctx.Done():
+			results[i] = ctx.Err()
+		case <-s.closed:
+			results[i] = errSchedulerClosed
+		}
+	}
+	return results
+}
+
+// signal wakes a worker without blocking, if none is already about to wake.
+func (s *ActionScheduler) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next removes the most urgent pending action. It wakes another worker if more actions are pending.
+func (s *ActionScheduler) next() (*pendingAction, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.pending.Len() == 0 {
+		return nil, false
+	}
+	action := heap.Pop(&s.pending).(*pendingAction)
+	if s.pending.Len() > 0 {
+		s.signal()
+	}
+	return action, true
+}
+
+func (s *ActionScheduler) worker(ctx context.Context) {
+	defer s.wg.Done()
+	for {
+		action, ok := s.next()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.wake:
+			}
+			continue
+		}
+		action.result <- s.execute(ctx, action.ScheduledAction)
+	}
+}
+
+func (s *ActionScheduler) execute(ctx context.Context, action ScheduledAction) error {
+	logger := s.logger.New("game", action.Game, "parent", action.Action.ParentIdx, "deadline", action.Deadline)
+	if action.Obsolete != nil {
+		obsolete, err := action.Obsolete(ctx)
+		if err != nil {
+			logger.Warn("Failed to check if action is obsolete", "err", err)
+		} else if obsolete {
+			return ErrActionObsolete
+		}
+	}
+	if remaining := action.Deadline.Sub(s.clock.Now()); remaining < 0 {
+		logger.Warn("Performing action after its deadline", "late", -remaining)
+	}
+	return action.Perform(ctx)
+}
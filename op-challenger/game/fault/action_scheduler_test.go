@@ -0,0 +1,234 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestActionSchedulerPerformsMostUrgentFirst(t *testing.T) {
+	s := NewActionScheduler(testlog.Logger(t, log.LvlInfo), clock.SystemClock, 1)
+	now := time.Unix(10_000, 0)
+	var lock sync.Mutex
+	var performed []int
+	action := func(id int, deadline time.Time) ScheduledAction {
+		return ScheduledAction{
+			Game:     common.Address{byte(id)},
+			Deadline: deadline,
+			Perform: func(ctx context.Context) error {
+				lock.Lock()
+				defer lock.Unlock()
+				performed = append(performed, id)
+				return nil
+			},
+		}
+	}
+
+	// Schedule the actions of several games before any is performed
+	var wg sync.WaitGroup
+	scheduled := 0
+	schedule := func(actions ...ScheduledAction) {
+		scheduled += len(actions)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, err := range s.Perform(context.Background(), actions) {
+				require.NoError(t, err)
+			}
+		}()
+		waitForPending(t, s, scheduled)
+	}
+	schedule(action(1, now.Add(time.Hour)), action(2, now.Add(time.Minute)))
+	schedule(action(3, now.Add(time.Second)))
+	schedule(action(4, now.Add(time.Minute)), action(5, now.Add(30*time.Minute)))
+
+	s.Start(context.Background())
+	wg.Wait()
+	require.NoError(t, s.Close())
+	require.Equal(t, []int{3, 2, 4, 5, 1}, performed, "should perform by deadline, then in the order scheduled")
+}
+
+func TestActionSchedulerSkipsObsoleteActions(t *testing.T) {
+	s := NewActionScheduler(testlog.Logger(t, log.LvlInfo), clock.SystemClock, 2)
+	s.Start(context.Background())
+	defer s.Close()
+
+	performed := make(map[string]bool)
+	var lock sync.Mutex
+	action := func(name string, obsolete bool, obsoleteErr error) ScheduledAction {
+		return ScheduledAction{
+			Obsolete: func(ctx context.Context) (bool, error) {
+				return obsolete, obsoleteErr
+			},
+			Perform: func(ctx context.Context) error {
+				lock.Lock()
+				defer lock.Unlock()
+				performed[name] = true
+				return nil
+			},
+		}
+	}
+	results := s.Perform(context.Background(), []ScheduledAction{
+		action("required", false, nil),
+		action("obsolete", true, nil),
+		action("unknown", true, errors.New("boom")),
+	})
+	require.NoError(t, results[0])
+	require.ErrorIs(t, results[1], ErrActionObsolete)
+	require.NoError(t, results[2])
+	require.Equal(t, map[string]bool{"required": true, "unknown": true}, performed,
+		"should skip obsolete actions, and perform actions that cannot be checked")
+}
+
+func TestActionSchedulerReturnsPerformErrors(t *testing.T) {
+	s := NewActionScheduler(testlog.Logger(t, log.LvlInfo), clock.SystemClock, 1)
+	s.Start(context.Background())
+	defer s.Close()
+
+	err := errors.New("boom")
+	results := s.Perform(context.Background(), []ScheduledAction{
+		{Perform: func(ctx context.Context) error { return err }},
+		{Perform: func(ctx context.Context) error { return nil }},
+	})
+	require.Equal(t, []error{err, nil}, results)
+}
+
+func TestActionSchedulerClose(t *testing.T) {
+	s := NewActionScheduler(testlog.Logger(t, log.LvlInfo), clock.SystemClock, 1)
+	done := make(chan []error, 1)
+	go func() {
+		done <- s.Perform(context.Background(), []ScheduledAction{{Perform: func(ctx context.Context) error { return nil }}})
+	}()
+	waitForPending(t, s, 1)
+	require.NoError(t, s.Close())
+	require.ErrorIs(t, (<-done)[0], errSchedulerClosed, "should not wait for actions that are never performed")
+}
+
+// TestActionSchedulerMeetsDeadlinesOfManyGames responds to a burst of games with short clocks. Every response takes
+// a round of responseTime, in which up to the configured number of responses are performed at once. There is exactly
+// enough time to respond to all the games, if the games with the earliest deadlines are responded to first.
+func TestActionSchedulerMeetsDeadlinesOfManyGames(t *testing.T) {
+	const (
+		workers      = 4
+		games        = 24
+		depth        = 4
+		gameDuration = uint64(7200)
+		responseTime = 10 * time.Second
+	)
+	logger := testlog.Logger(t, log.LvlInfo)
+	cl := clock.NewDeterministicClock(time.Unix(1_000_000, 0))
+	rounds := &responseRounds{clock: cl, size: workers, total: games, duration: responseTime}
+	rounds.cond = sync.NewCond(&rounds.lock)
+	s := NewActionScheduler(logger, cl, workers)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider("abcd", depth))
+
+	agents := make([]*Agent, games)
+	responders := make([]*roundResponder, games)
+	for i := 0; i < games; i++ {
+		// The games with the highest index are created first, but have the most time left to respond
+		deadline := cl.Now().Add(time.Duration(i/workers+1) * responseTime)
+		root := claimBuilder.CreateRootClaim(false)
+		root.Clock = uint64(deadline.Unix()) - gameDuration/2
+		responders[i] = &roundResponder{rounds: rounds, deadline: deadline}
+		responders[i].callResolveErr = errors.New("game is not resolvable")
+		responders[i].callResolveClaimErr = errors.New("claim is not resolvable")
+		loader := &stubClaimLoader{claims: []types.Claim{root}}
+		provider := alphabet.NewTraceProvider("abcd", depth)
+		responses, err := LoadResponseStore(t.TempDir())
+		require.NoError(t, err)
+		agents[i] = NewAgent(metrics.NoopMetrics, common.Address{byte(i)}, loader, depth, gameDuration, trace.NewSimpleTraceAccessor(provider), responders[i], s, responses, config.AgreementPolicyFull, logger)
+	}
+
+	var wg sync.WaitGroup
+	for i := games - 1; i >= 0; i-- {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, agents[i].Act(context.Background()))
+		}()
+		waitForPending(t, s, games-i)
+	}
+	s.Start(context.Background())
+	wg.Wait()
+	require.NoError(t, s.Close())
+
+	for i, responder := range responders {
+		require.Equal(t, 1, responder.performed, "should respond to game %v", i)
+		require.False(t, responder.late, "should respond to game %v before its deadline", i)
+	}
+}
+
+// responseRounds simulates responses that take a round of the duration each, in which up to size
+// responses are performed at once.
+type responseRounds struct {
+	lock     sync.Mutex
+	cond     *sync.Cond
+	clock    *clock.DeterministicClock
+	size     int
+	total    int
+	duration time.Duration
+	started  int
+	round    int
+}
+
+// perform waits for the round of the response to end, and returns the time the response is complete.
+func (r *responseRounds) perform() time.Time {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	round := r.round
+	r.started++
+	if r.started%r.size == 0 || r.started == r.total {
+		r.clock.AdvanceTime(r.duration)
+		r.round++
+		r.cond.Broadcast()
+	}
+	for r.round == round {
+		r.cond.Wait()
+	}
+	return r.clock.Now()
+}
+
+type roundResponder struct {
+	stubResponder
+	rounds    *responseRounds
+	deadline  time.Time
+	performed int
+	late      bool
+}
+
+func (r *roundResponder) PerformAction(ctx context.Context, action types.Action) (*ethtypes.Receipt, error) {
+	if r.rounds.perform().After(r.deadline) {
+		r.late = true
+	}
+	r.performed++
+	return &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful, BlockNumber: big.NewInt(1)}, nil
+}
+
+var _ Responder = (*roundResponder)(nil)
+
+// waitForPending waits until the number of actions pending in the scheduler is the count.
+func waitForPending(t *testing.T, s *ActionScheduler, count int) {
+	require.Eventually(t, func() bool {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		return s.pending.Len() == count
+	}, 10*time.Second, time.Millisecond)
+}
@@ -5,12 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -21,7 +25,7 @@ type Responder interface {
 	Resolve(ctx context.Context) error
 	CallResolveClaim(ctx context.Context, claimIdx uint64) error
 	ResolveClaim(ctx context.Context, claimIdx uint64) error
-	PerformAction(ctx context.Context, action types.Action) error
+	PerformAction(ctx context.Context, action types.Action) (*ethtypes.Receipt, error)
 }
 
 type ClaimLoader interface {
@@ -29,22 +33,49 @@ type ClaimLoader interface {
 }
 
 type Agent struct {
-	metrics   metrics.Metricer
-	solver    *solver.GameSolver
-	loader    ClaimLoader
-	responder Responder
-	maxDepth  int
-	log       log.Logger
+	metrics      metrics.Metricer
+	addr         common.Address
+	solver       *solver.GameSolver
+	loader       ClaimLoader
+	responder    Responder
+	scheduler    *ActionScheduler
+	responses    *ResponseStore
+	policy       config.AgreementPolicy
+	maxDepth     int
+	gameDuration uint64
+	log          log.Logger
+	clock        clock.Clock
+
+	// trackedClaims is the number of claims of the game that are included in the tracked claims metric.
+	trackedClaims int
 }
 
-func NewAgent(m metrics.Metricer, loader ClaimLoader, maxDepth int, trace types.TraceAccessor, responder Responder, log log.Logger) *Agent {
+func NewAgent(
+	m metrics.Metricer,
+	addr common.Address,
+	loader ClaimLoader,
+	maxDepth int,
+	gameDuration uint64,
+	trace types.TraceAccessor,
+	responder Responder,
+	scheduler *ActionScheduler,
+	responses *ResponseStore,
+	policy config.AgreementPolicy,
+	log log.Logger,
+) *Agent {
 	return &Agent{
-		metrics:   m,
-		solver:    solver.NewGameSolver(maxDepth, trace),
-		loader:    loader,
-		responder: responder,
-		maxDepth:  maxDepth,
-		log:       log,
+		metrics:      m,
+		addr:         addr,
+		solver:       solver.NewGameSolver(maxDepth, trace),
+		loader:       loader,
+		responder:    responder,
+		scheduler:    scheduler,
+		responses:    responses,
+		policy:       policy,
+		maxDepth:     maxDepth,
+		gameDuration: gameDuration,
+		log:          log,
+		clock:        clock.SystemClock,
 	}
 }
 
@@ -58,36 +89,149 @@ func (a *Agent) Act(ctx context.Context) error {
 		return fmt.Errorf("create game from contracts: %w", err)
 	}
 
+	// Responses that are not on-chain yet, like those sent before a restart, must not be repeated
+	if err := a.responses.Reconcile(game, a.clock.Now()); err != nil {
+		return fmt.Errorf("reconcile responses: %w", err)
+	}
+
+	claims := game.Claims()
+	a.setTrackedClaims(len(claims))
+	agree, err := a.solver.AgreeWithRootClaim(ctx, game)
+	if err != nil {
+		return fmt.Errorf("check agreement with root claim: %w", err)
+	}
+	if !a.policy.Participates(agree) {
+		a.log.Debug("Not participating in game", "policy", a.policy, "claimIdx", 0, "value", claims[0].Value, "agree", agree)
+		return nil
+	}
+	a.log.Debug("Checked root claim", "claimIdx", 0, "value", claims[0].Value, "agree", agree)
+
 	// Calculate the actions to take
 	actions, err := a.solver.CalculateNextActions(ctx, game)
 	if err != nil {
 		log.Error("Failed to calculate all required moves", "err", err)
 	}
+	actions = a.withoutRecordedResponses(actions)
 
-	// Perform the actions
-	for _, action := range actions {
-		log := a.log.New("action", action.Type, "is_attack", action.IsAttack, "parent", action.ParentIdx)
+	// Perform the actions, along with the actions of other games
+	scheduled := make([]ScheduledAction, len(actions))
+	for i, action := range actions {
+		action := action
+		scheduled[i] = ScheduledAction{
+			Game:     a.addr,
+			Action:   action,
+			Deadline: a.responseDeadline(claims, claims[action.ParentIdx]),
+			Obsolete: func(ctx context.Context) (bool, error) {
+				return a.isObsolete(ctx, action)
+			},
+			Perform: func(ctx context.Context) error {
+				return a.performAction(ctx, action)
+			},
+		}
+		a.log.Info("Scheduling action", "action", action.Type, "is_attack", action.IsAttack, "parent", action.ParentIdx, "deadline", scheduled[i].Deadline)
+	}
+	results := a.scheduler.Perform(ctx, scheduled)
+	for i, action := range actions {
+		parent := claims[action.ParentIdx]
+		log := a.log.New("action", action.Type, "is_attack", action.IsAttack, "parent", action.ParentIdx, "parent_value", parent.Value)
 		if action.Type == types.ActionTypeStep {
 			log = log.New("prestate", common.Bytes2Hex(action.PreState), "proof", common.Bytes2Hex(action.ProofData))
 		} else {
 			log = log.New("value", action.Value)
 		}
 
+		err := results[i]
+		if errors.Is(err, ErrActionObsolete) {
+			log.Info("Skipped obsolete action")
+			continue
+		}
 		switch action.Type {
 		case types.ActionTypeMove:
-			a.metrics.RecordGameMove()
+			a.metrics.RecordGameMove(action.IsAttack, err == nil)
 		case types.ActionTypeStep:
-			a.metrics.RecordGameStep()
+			a.metrics.RecordGameStep(err == nil)
 		}
-		log.Info("Performing action")
-		err := a.responder.PerformAction(ctx, action)
 		if err != nil {
 			log.Error("Action failed", "err", err)
+			continue
 		}
+		if parent.Clock != 0 {
+			responseTime := a.clock.Now().Sub(time.Unix(int64(parent.Clock), 0))
+			a.metrics.RecordResponseTime(responseTime.Seconds())
+			log = log.New("response_time", responseTime)
+		}
+		log.Info("Performed action")
 	}
 	return nil
 }
 
+// responseDeadline returns the time a response to the claim must be made by, before our chess clock runs out.
+// Our clock is the duration of the grandparent of the response, plus the time since the claim was made.
+func (a *Agent) responseDeadline(claims []types.Claim, claim types.Claim) time.Time {
+	var used uint64
+	if !claim.IsRoot() {
+		used = claims[claim.ParentContractIndex].ClockDuration
+	}
+	var remaining uint64
+	if maxDuration := a.gameDuration / 2; used < maxDuration {
+		remaining = maxDuration - used
+	}
+	return time.Unix(int64(claim.Clock+remaining), 0)
+}
+
+// isObsolete checks whether the action is no longer required by the latest claims of the game,
+// because an identical move was made or the claim has been countered by a step already.
+func (a *Agent) isObsolete(ctx context.Context, action types.Action) (bool, error) {
+	game, err := a.newGameFromContracts(ctx)
+	if err != nil {
+		return false, err
+	}
+	response := Response{ParentIdx: action.ParentIdx, Type: action.Type, IsAttack: action.IsAttack, Value: action.Value}
+	return isOnChain(game, game.Claims()[action.ParentIdx], response), nil
+}
+
+// withoutRecordedResponses removes the actions that were performed already, but are not on-chain yet.
+func (a *Agent) withoutRecordedResponses(actions []types.Action) []types.Action {
+	var remaining []types.Action
+	for _, action := range actions {
+		if response, ok := a.responses.Find(action); ok {
+			a.log.Info("Not repeating earlier response", "action", action.Type, "is_attack", action.IsAttack,
+				"parent", action.ParentIdx, "status", response.Status, "tx_hash", response.TxHash)
+			continue
+		}
+		remaining = append(remaining, action)
+	}
+	return remaining
+}
+
+// performAction performs the action, and persists it as a response until it is on-chain.
+func (a *Agent) performAction(ctx context.Context, action types.Action) error {
+	if err := a.responses.RecordPending(action, a.clock.Now()); err != nil {
+		return fmt.Errorf("record pending response: %w", err)
+	}
+	receipt, err := a.responder.PerformAction(ctx, action)
+	if err == nil && receipt.Status == ethtypes.ReceiptStatusFailed {
+		err = fmt.Errorf("response tx %v reverted", receipt.TxHash)
+	}
+	if err != nil {
+		// The action was not performed, so it may be performed again
+		if err := a.responses.Remove(action); err != nil {
+			a.log.Error("Failed to remove failed response", "parent", action.ParentIdx, "err", err)
+		}
+		return err
+	}
+	if err := a.responses.RecordIncluded(action, receipt.TxHash, receipt.BlockNumber.Uint64(), a.clock.Now()); err != nil {
+		return fmt.Errorf("record included response: %w", err)
+	}
+	return nil
+}
+
+// setTrackedClaims updates the tracked claims metric with the number of claims of the game.
+func (a *Agent) setTrackedClaims(count int) {
+	a.metrics.AddTrackedClaims(count - a.trackedClaims)
+	a.trackedClaims = count
+}
+
 // tryResolve resolves the game if it is in a winning state
 // Returns true if the game is resolvable (regardless of whether it was actually resolved)
 func (a *Agent) tryResolve(ctx context.Context) bool {
@@ -99,9 +243,11 @@ func (a *Agent) tryResolve(ctx context.Context) bool {
 	if err != nil || status == gameTypes.GameStatusInProgress {
 		return false
 	}
-	a.log.Info("Resolving game")
+	// The claims of a resolvable game are no longer played
+	a.setTrackedClaims(0)
+	a.log.Info("Resolving game", "status", status)
 	if err := a.responder.Resolve(ctx); err != nil {
-		a.log.Error("Failed to resolve the game", "err", err)
+		a.log.Error("Failed to resolve the game", "status", status, "err", err)
 	}
 	return true
 }
@@ -138,7 +284,7 @@ func (a *Agent) tryResolveClaims(ctx context.Context) error {
 			defer wg.Done()
 			err := a.responder.ResolveClaim(ctx, uint64(claimIdx))
 			if err != nil {
-				a.log.Error("Failed to resolve claim", "err", err)
+				a.log.Error("Failed to resolve claim", "claimIdx", claimIdx, "err", err)
 			}
 		}()
 	}
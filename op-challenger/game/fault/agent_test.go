@@ -3,18 +3,24 @@ package fault
 import (
 	"context"
 	"errors"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
@@ -65,19 +71,210 @@ func TestLoadClaimsWhenGameNotResolvable(t *testing.T) {
 
 	require.NoError(t, agent.Act(context.Background()))
 
-	require.EqualValues(t, 2, claimLoader.callCount, "should load claims for unresolvable game")
+	require.EqualValues(t, 3, claimLoader.callCount, "should load claims for unresolvable game, and again to check the action is not obsolete")
 	require.EqualValues(t, responder.callResolveClaimCount, 1, "should check if claim is resolvable")
 	require.Zero(t, responder.resolveClaimCount, "should not send resolveClaim")
 }
 
+func TestAgreementPolicy(t *testing.T) {
+	depth := 4
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider("abcd", uint64(depth)))
+	// The root claim is valid, and is attacked with an invalid claim
+	agreeRoot := claimBuilder.CreateRootClaim(true)
+	counter := claimBuilder.AttackClaim(agreeRoot, false)
+	counter.ContractIndex = 1
+	agreeingGame := []types.Claim{agreeRoot, counter}
+	// The root claim is invalid
+	disagreeingGame := []types.Claim{claimBuilder.CreateRootClaim(false)}
+
+	tests := []struct {
+		policy       config.AgreementPolicy
+		agreeActs    bool
+		disagreeActs bool
+	}{
+		{policy: config.AgreementPolicyDefend, agreeActs: true, disagreeActs: false},
+		{policy: config.AgreementPolicyChallenge, agreeActs: false, disagreeActs: true},
+		{policy: config.AgreementPolicyFull, agreeActs: true, disagreeActs: true},
+	}
+	for _, test := range tests {
+		test := test
+		run := func(t *testing.T, claims []types.Claim, expectActs bool) {
+			agent, claimLoader, responder := setupTestAgent(t)
+			agent.policy = test.policy
+			responder.callResolveErr = errors.New("game is not resolvable")
+			responder.callResolveClaimErr = errors.New("claim is not resolvable")
+			claimLoader.claims = claims
+			require.NoError(t, agent.Act(context.Background()))
+			if expectActs {
+				require.Equal(t, 1, responder.performActionCount, "should respond")
+			} else {
+				require.Zero(t, responder.performActionCount, "should not respond")
+			}
+		}
+		t.Run(test.policy.String()+"-Agree", func(t *testing.T) {
+			run(t, agreeingGame, test.agreeActs)
+		})
+		t.Run(test.policy.String()+"-Disagree", func(t *testing.T) {
+			run(t, disagreeingGame, test.disagreeActs)
+		})
+	}
+}
+
+func TestAgentMetrics(t *testing.T) {
+	ctx := context.Background()
+	agent, claimLoader, responder := setupTestAgent(t)
+	m := &stubAgentMetrics{moves: make(map[bool]map[bool]int)}
+	agent.metrics = m
+	now := time.Unix(10_000, 0)
+	agent.clock = clock.NewDeterministicClock(now)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := 4
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider("abcd", uint64(depth)))
+
+	// The root claim is invalid, so the agent attacks it
+	root := claimBuilder.CreateRootClaim(false)
+	root.Clock = uint64(now.Add(-30 * time.Second).Unix())
+	claimLoader.claims = []types.Claim{root}
+	require.NoError(t, agent.Act(ctx))
+	require.Equal(t, 1, m.moves[true][true], "should record successful attack")
+	require.Equal(t, 1, m.trackedClaims)
+	require.Equal(t, []float64{30}, m.responseTimes)
+
+	// The attack of the agent is countered with an invalid claim, and the response fails
+	ours := claimBuilder.AttackClaim(root, true)
+	ours.ContractIndex = 1
+	ours.Clock = uint64(now.Add(-20 * time.Second).Unix())
+	counter := claimBuilder.AttackClaim(ours, false)
+	counter.ContractIndex = 2
+	counter.Clock = uint64(now.Add(-10 * time.Second).Unix())
+	claimLoader.claims = []types.Claim{root, ours, counter}
+	responder.performActionErr = errors.New("boom")
+	require.NoError(t, agent.Act(ctx))
+	require.Equal(t, 1, m.moves[true][false]+m.moves[false][false], "should record failed move")
+	require.Equal(t, 3, m.trackedClaims)
+	require.Equal(t, []float64{30}, m.responseTimes, "should not record response time of failed move")
+
+	// Once the game is resolvable, its claims are no longer tracked
+	responder.callResolveErr = nil
+	responder.callResolveStatus = gameTypes.GameStatusChallengerWon
+	require.NoError(t, agent.Act(ctx))
+	require.Zero(t, m.trackedClaims)
+	require.Equal(t, 2, m.moveCount(), "should not move in resolvable game")
+	require.Zero(t, m.steps)
+}
+
+func TestResponseDeadline(t *testing.T) {
+	agent, _, _ := setupTestAgent(t)
+	claimBuilder := test.NewClaimBuilder(t, 4, alphabet.NewTraceProvider("abcd", 4))
+	root := claimBuilder.CreateRootClaim(false)
+	root.Clock = 1000
+	attack := claimBuilder.AttackClaim(root, true)
+	attack.ContractIndex = 1
+	attack.Clock = 1500
+	attack.ClockDuration = 500
+	counter := claimBuilder.AttackClaim(attack, false)
+	counter.ContractIndex = 2
+	counter.Clock = 2000
+	counter.ClockDuration = 500
+	claims := []types.Claim{root, attack, counter}
+
+	// The game duration is 3600, so every team has a clock of 1800 seconds
+	require.Equal(t, time.Unix(2800, 0), agent.responseDeadline(claims, root), "should have full clock for root claim")
+	require.Equal(t, time.Unix(3300, 0), agent.responseDeadline(claims, attack))
+	require.Equal(t, time.Unix(3300, 0), agent.responseDeadline(claims, counter), "should subtract the duration of our previous claim")
+
+	attack.ClockDuration = 2000
+	claims[1] = attack
+	require.Equal(t, time.Unix(2000, 0), agent.responseDeadline(claims, counter), "should not have time left after the clock ran out")
+}
+
+func TestAgentDoesNotRepeatResponsesAfterRestart(t *testing.T) {
+	depth := 4
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider("abcd", uint64(depth)))
+	root := claimBuilder.CreateRootClaim(false)
+	attack := claimBuilder.AttackClaim(root, true)
+	attack.ContractIndex = 1
+	dir := t.TempDir()
+	claimLoader := &stubClaimLoader{claims: []types.Claim{root}}
+	// start creates an agent for the game, like a challenger (re)started with the same data dir
+	start := func() (*Agent, *stubResponder) {
+		agent, responder := setupTestAgentWithDir(t, claimLoader, dir)
+		responder.callResolveErr = errors.New("game is not resolvable")
+		responder.callResolveClaimErr = errors.New("claim is not resolvable")
+		return agent, responder
+	}
+
+	agent, responder := start()
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 1, responder.performActionCount, "should attack the root claim")
+	require.Len(t, agent.responses.Responses(), 1)
+	require.Equal(t, responseIncluded, agent.responses.Responses()[0].Status)
+	require.Equal(t, uint64(1), agent.responses.L1Block())
+
+	// Restart while the L1 node does not have the attack yet
+	agent, responder = start()
+	require.NoError(t, agent.Act(context.Background()))
+	require.Zero(t, responder.performActionCount, "should not send the attack again")
+
+	// The response times out if it never makes it on-chain
+	agent, responder = start()
+	agent.clock = clock.NewDeterministicClock(time.Now().Add(responseTimeout + time.Minute))
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 1, responder.performActionCount, "should attack again after the response timed out")
+
+	// Restart after the attack is on-chain
+	claimLoader.claims = []types.Claim{root, attack}
+	agent, responder = start()
+	require.NoError(t, agent.Act(context.Background()))
+	require.Zero(t, responder.performActionCount)
+	require.Empty(t, agent.responses.Responses(), "should remove responses that are on-chain")
+}
+
+func TestAgentRetriesFailedResponses(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := 4
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider("abcd", uint64(depth)))
+	claimLoader.claims = []types.Claim{claimBuilder.CreateRootClaim(false)}
+
+	responder.performActionErr = errors.New("boom")
+	require.NoError(t, agent.Act(context.Background()))
+	require.Empty(t, agent.responses.Responses(), "should not record responses that failed to send")
+
+	responder.performActionErr = nil
+	responder.receipt = &ethtypes.Receipt{Status: ethtypes.ReceiptStatusFailed, BlockNumber: big.NewInt(1)}
+	require.NoError(t, agent.Act(context.Background()))
+	require.Empty(t, agent.responses.Responses(), "should not record reverted responses")
+
+	responder.receipt = nil
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 3, responder.performActionCount)
+	require.Len(t, agent.responses.Responses(), 1)
+}
+
 func setupTestAgent(t *testing.T) (*Agent, *stubClaimLoader, *stubResponder) {
-	logger := testlog.Logger(t, log.LvlInfo)
 	claimLoader := &stubClaimLoader{}
+	agent, responder := setupTestAgentWithDir(t, claimLoader, t.TempDir())
+	return agent, claimLoader, responder
+}
+
+// setupTestAgentWithDir creates an agent that persists its responses in the dir, like a challenger with that data dir.
+func setupTestAgentWithDir(t *testing.T, claimLoader *stubClaimLoader, dir string) (*Agent, *stubResponder) {
+	logger := testlog.Logger(t, log.LvlInfo)
 	depth := 4
 	provider := alphabet.NewTraceProvider("abcd", uint64(depth))
 	responder := &stubResponder{}
-	agent := NewAgent(metrics.NoopMetrics, claimLoader, depth, trace.NewSimpleTraceAccessor(provider), responder, logger)
-	return agent, claimLoader, responder
+	actions := NewActionScheduler(logger, clock.SystemClock, 1)
+	actions.Start(context.Background())
+	t.Cleanup(func() {
+		require.NoError(t, actions.Close())
+	})
+	responses, err := LoadResponseStore(dir)
+	require.NoError(t, err)
+	agent := NewAgent(metrics.NoopMetrics, common.Address{0xaa}, claimLoader, depth, 3600, trace.NewSimpleTraceAccessor(provider), responder, actions, responses, config.AgreementPolicyFull, logger)
+	return agent, responder
 }
 
 type stubClaimLoader struct {
@@ -101,6 +298,10 @@ type stubResponder struct {
 	callResolveClaimCount int
 	callResolveClaimErr   error
 	resolveClaimCount     int
+
+	performActionCount int
+	performActionErr   error
+	receipt            *ethtypes.Receipt
 }
 
 func (s *stubResponder) CallResolve(ctx context.Context) (gameTypes.GameStatus, error) {
@@ -123,6 +324,51 @@ func (s *stubResponder) ResolveClaim(ctx context.Context, clainIdx uint64) error
 	return nil
 }
 
-func (s *stubResponder) PerformAction(ctx context.Context, response types.Action) error {
-	return nil
+func (s *stubResponder) PerformAction(ctx context.Context, response types.Action) (*ethtypes.Receipt, error) {
+	s.performActionCount++
+	if s.performActionErr != nil {
+		return nil, s.performActionErr
+	}
+	if s.receipt != nil {
+		return s.receipt, nil
+	}
+	return &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful, TxHash: common.Hash{0xbb}, BlockNumber: big.NewInt(1)}, nil
+}
+
+type stubAgentMetrics struct {
+	metrics.NoopMetricsImpl
+	// moves counts the moves by whether they are attacks and whether they succeeded
+	moves         map[bool]map[bool]int
+	steps         int
+	responseTimes []float64
+	trackedClaims int
+}
+
+func (s *stubAgentMetrics) RecordGameMove(isAttack bool, success bool) {
+	if s.moves[isAttack] == nil {
+		s.moves[isAttack] = make(map[bool]int)
+	}
+	s.moves[isAttack][success]++
+}
+
+func (s *stubAgentMetrics) RecordGameStep(success bool) {
+	s.steps++
+}
+
+func (s *stubAgentMetrics) RecordResponseTime(t float64) {
+	s.responseTimes = append(s.responseTimes, t)
+}
+
+func (s *stubAgentMetrics) AddTrackedClaims(count int) {
+	s.trackedClaims += count
+}
+
+func (s *stubAgentMetrics) moveCount() int {
+	count := 0
+	for _, results := range s.moves {
+		for _, n := range results {
+			count += n
+		}
+	}
+	return count
 }
@@ -207,6 +207,7 @@ func (f *disputeGameContract) decodeClaim(result *batching.CallResult, contractI
 		},
 		Countered:           countered,
 		Clock:               clock.Uint64(),
+		ClockDuration:       new(big.Int).Rsh(clock, 64).Uint64(),
 		ContractIndex:       contractIndex,
 		ParentContractIndex: int(parentIndex),
 	}
@@ -129,7 +129,8 @@ func runGetClaimTest(t *testing.T, setup disputeGameSetupFunc) {
 	countered := true
 	value := common.Hash{0xab}
 	position := big.NewInt(2)
-	clock := big.NewInt(1234)
+	// the duration of 5678 seconds is packed in the upper 64 bits of the clock, with the timestamp
+	clock := new(big.Int).Or(new(big.Int).Lsh(big.NewInt(5678), 64), big.NewInt(1234))
 	stubRpc.SetResponse(fdgAddr, methodClaim, batching.BlockLatest, []interface{}{idx}, []interface{}{parentIndex, countered, value, position, clock})
 	status, err := game.GetClaim(context.Background(), idx.Uint64())
 	require.NoError(t, err)
@@ -140,6 +141,7 @@ func runGetClaimTest(t *testing.T, setup disputeGameSetupFunc) {
 		},
 		Countered:           true,
 		Clock:               1234,
+		ClockDuration:       5678,
 		ContractIndex:       int(idx.Uint64()),
 		ParentContractIndex: 1,
 	}, status)
@@ -164,6 +166,7 @@ func runGetAllClaimsTest(t *testing.T, setup disputeGameSetupFunc) {
 		},
 		Countered:           true,
 		Clock:               4455,
+		ClockDuration:       20,
 		ContractIndex:       1,
 		ParentContractIndex: 0,
 	}
@@ -174,6 +177,7 @@ func runGetAllClaimsTest(t *testing.T, setup disputeGameSetupFunc) {
 		},
 		Countered:           false,
 		Clock:               7777,
+		ClockDuration:       30,
 		ContractIndex:       2,
 		ParentContractIndex: 1,
 	}
@@ -249,6 +253,6 @@ func expectGetClaim(stubRpc *batchingTest.AbiBasedRpc, claim faultTypes.Claim) {
 			claim.Countered,
 			claim.Value,
 			claim.Position.ToGIndex(),
-			big.NewInt(int64(claim.Clock)),
+			new(big.Int).Or(new(big.Int).Lsh(new(big.Int).SetUint64(claim.ClockDuration), 64), new(big.Int).SetUint64(claim.Clock)),
 		})
 }
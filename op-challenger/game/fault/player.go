@@ -2,8 +2,10 @@ package fault
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
@@ -24,6 +26,7 @@ type GamePlayer struct {
 	act                actor
 	loader             GameInfo
 	logger             log.Logger
+	metrics            metrics.Metricer
 	prestateValidators []Validator
 	status             gameTypes.GameStatus
 }
@@ -34,6 +37,7 @@ type GameContract interface {
 	ClaimLoader
 	GetStatus(ctx context.Context) (gameTypes.GameStatus, error)
 	GetMaxGameDepth(ctx context.Context) (uint64, error)
+	GetGameDuration(ctx context.Context) (uint64, error)
 }
 
 type resourceCreator func(ctx context.Context, logger log.Logger, gameDepth uint64, dir string) (types.TraceAccessor, error)
@@ -45,6 +49,8 @@ func NewGamePlayer(
 	dir string,
 	addr common.Address,
 	txMgr txmgr.TxManager,
+	actions *ActionScheduler,
+	policy config.AgreementPolicy,
 	loader GameContract,
 	validators []Validator,
 	creator resourceCreator,
@@ -60,6 +66,7 @@ func NewGamePlayer(
 		// Game is already complete so skip creating the trace provider, loading game inputs etc.
 		return &GamePlayer{
 			logger:             logger,
+			metrics:            m,
 			loader:             loader,
 			prestateValidators: validators,
 			status:             status,
@@ -74,6 +81,27 @@ func NewGamePlayer(
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch the game depth: %w", err)
 	}
+	gameDuration, err := loader.GetGameDuration(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the game duration: %w", err)
+	}
+
+	responses, err := LoadResponseStore(dir)
+	if errors.Is(err, ErrCorruptResponses) {
+		// The responses made to the game are unknown, so any action may repeat an earlier one
+		logger.Error("Quarantining game with corrupt persisted state", "dir", dir, "err", err)
+		return &GamePlayer{
+			logger:  logger,
+			metrics: m,
+			loader:  loader,
+			status:  status,
+			act: func(ctx context.Context) error {
+				return nil
+			},
+		}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load responses: %w", err)
+	}
 
 	accessor, err := creator(ctx, logger, gameDepth, dir)
 	if err != nil {
@@ -85,12 +113,13 @@ func NewGamePlayer(
 		return nil, fmt.Errorf("failed to create the responder: %w", err)
 	}
 
-	agent := NewAgent(m, loader, int(gameDepth), accessor, responder, logger)
+	agent := NewAgent(m, addr, loader, int(gameDepth), gameDuration, accessor, responder, actions, responses, policy, logger)
 	return &GamePlayer{
-		act:    agent.Act,
-		loader: loader,
-		logger: logger,
-		status: status,
+		act:     agent.Act,
+		loader:  loader,
+		logger:  logger,
+		metrics: m,
+		status:  status,
 	}, nil
 }
 
@@ -123,6 +152,9 @@ func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
 		return gameTypes.GameStatusInProgress
 	}
 	g.logGameStatus(ctx, status)
+	if status != gameTypes.GameStatusInProgress {
+		g.metrics.RecordGameResolved(status == gameTypes.GameStatusChallengerWon)
+	}
 	g.status = status
 	return status
 }
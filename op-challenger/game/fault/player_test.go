@@ -4,9 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -71,6 +76,22 @@ func TestProgressGame_LogGameStatus(t *testing.T) {
 	}
 }
 
+func TestProgressGame_RecordGameResolved(t *testing.T) {
+	_, game, gameState := setupProgressGameTest(t)
+	m := &stubPlayerMetrics{}
+	game.metrics = m
+
+	game.ProgressGame(context.Background())
+	require.Empty(t, m.resolved, "should not record in progress game")
+
+	gameState.status = types.GameStatusChallengerWon
+	game.ProgressGame(context.Background())
+	require.Equal(t, []bool{true}, m.resolved, "should record winner of resolved game")
+
+	game.ProgressGame(context.Background())
+	require.Equal(t, []bool{true}, m.resolved, "should only record resolution once")
+}
+
 func TestDoNotActOnCompleteGame(t *testing.T) {
 	for _, status := range []types.GameStatus{types.GameStatusChallengerWon, types.GameStatusDefenderWon} {
 		t.Run(status.String(), func(t *testing.T) {
@@ -89,6 +110,27 @@ func TestDoNotActOnCompleteGame(t *testing.T) {
 	}
 }
 
+func TestNewGamePlayer_QuarantineCorruptGame(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	handler := &testlog.CapturingHandler{
+		Delegate: logger.GetHandler(),
+	}
+	logger.SetHandler(handler)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, responsesFile), []byte("not json"), 0o644))
+	contract := &stubGameContract{status: types.GameStatusInProgress}
+	creator := func(ctx context.Context, logger log.Logger, gameDepth uint64, dir string) (faultTypes.TraceAccessor, error) {
+		t.Fatal("should not create trace accessor for quarantined game")
+		return nil, nil
+	}
+
+	game, err := NewGamePlayer(context.Background(), logger, metrics.NoopMetrics, dir, common.Address{0xaa}, nil, nil,
+		config.AgreementPolicyFull, contract, nil, creator)
+	require.NoError(t, err)
+	require.NotNil(t, handler.FindLog(log.LvlError, "Quarantining game with corrupt persisted state"))
+	require.Equal(t, types.GameStatusInProgress, game.ProgressGame(context.Background()), "should keep tracking the game")
+}
+
 func TestValidatePrestate(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -153,9 +195,10 @@ func setupProgressGameTest(t *testing.T) (*testlog.CapturingHandler, *GamePlayer
 	logger.SetHandler(handler)
 	gameState := &stubGameState{claimCount: 1}
 	game := &GamePlayer{
-		act:    gameState.Act,
-		loader: gameState,
-		logger: logger,
+		act:     gameState.Act,
+		loader:  gameState,
+		logger:  logger,
+		metrics: metrics.NoopMetrics,
 	}
 	return handler, game, gameState
 }
@@ -184,3 +227,34 @@ func (s *stubGameState) GetClaimCount(ctx context.Context) (uint64, error) {
 func (s *stubGameState) GetAbsolutePrestateHash(ctx context.Context) (common.Hash, error) {
 	return common.Hash{}, s.Err
 }
+
+type stubPlayerMetrics struct {
+	metrics.NoopMetricsImpl
+	resolved []bool
+}
+
+func (s *stubPlayerMetrics) RecordGameResolved(challengerWon bool) {
+	s.resolved = append(s.resolved, challengerWon)
+}
+
+// stubGameContract implements the calls made by NewGamePlayer before it creates the trace accessor.
+type stubGameContract struct {
+	GameContract
+	status types.GameStatus
+}
+
+func (s *stubGameContract) GetStatus(ctx context.Context) (types.GameStatus, error) {
+	return s.status, nil
+}
+
+func (s *stubGameContract) GetClaimCount(ctx context.Context) (uint64, error) {
+	return 1, nil
+}
+
+func (s *stubGameContract) GetMaxGameDepth(ctx context.Context) (uint64, error) {
+	return 4, nil
+}
+
+func (s *stubGameContract) GetGameDuration(ctx context.Context) (uint64, error) {
+	return 3600, nil
+}
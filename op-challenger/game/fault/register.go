@@ -41,6 +41,7 @@ func RegisterGameTypes(
 	cfg *config.Config,
 	rollupClient outputs.OutputRollupClient,
 	txMgr txmgr.TxManager,
+	actions *ActionScheduler,
 	caller *batching.MultiCaller,
 ) (CloseFunc, error) {
 	var closer CloseFunc
@@ -54,16 +55,16 @@ func RegisterGameTypes(
 		closer = l2Client.Close
 	}
 	if cfg.TraceTypeEnabled(config.TraceTypeOutputCannon) {
-		registerOutputCannon(registry, ctx, logger, m, cfg, rollupClient, txMgr, caller, l2Client)
+		registerOutputCannon(registry, ctx, logger, m, cfg, rollupClient, txMgr, actions, cfg.AgreementPolicy, caller, l2Client)
 	}
 	if cfg.TraceTypeEnabled(config.TraceTypeOutputAlphabet) {
-		registerOutputAlphabet(registry, ctx, logger, m, rollupClient, txMgr, caller)
+		registerOutputAlphabet(registry, ctx, logger, m, rollupClient, txMgr, actions, cfg.AgreementPolicy, caller)
 	}
 	if cfg.TraceTypeEnabled(config.TraceTypeCannon) {
-		registerCannon(registry, ctx, logger, m, cfg, txMgr, caller, l2Client)
+		registerCannon(registry, ctx, logger, m, cfg, txMgr, actions, cfg.AgreementPolicy, caller, l2Client)
 	}
 	if cfg.TraceTypeEnabled(config.TraceTypeAlphabet) {
-		registerAlphabet(registry, ctx, logger, m, cfg.AlphabetTrace, txMgr, caller)
+		registerAlphabet(registry, ctx, logger, m, cfg.AlphabetTrace, txMgr, actions, cfg.AgreementPolicy, caller)
 	}
 	return closer, nil
 }
@@ -75,6 +76,8 @@ func registerOutputAlphabet(
 	m metrics.Metricer,
 	rollupClient outputs.OutputRollupClient,
 	txMgr txmgr.TxManager,
+	actions *ActionScheduler,
+	policy config.AgreementPolicy,
 	caller *batching.MultiCaller) {
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
 		contract, err := contracts.NewOutputBisectionGameContract(game.Proxy, caller)
@@ -99,7 +102,7 @@ func registerOutputAlphabet(
 		}
 		prestateValidator := NewPrestateValidator(contract.GetAbsolutePrestateHash, prestateProvider)
 		genesisValidator := NewPrestateValidator(contract.GetGenesisOutputRoot, prestateProvider)
-		return NewGamePlayer(ctx, logger, m, dir, game.Proxy, txMgr, contract, []Validator{prestateValidator, genesisValidator}, creator)
+		return NewGamePlayer(ctx, logger, m, dir, game.Proxy, txMgr, actions, policy, contract, []Validator{prestateValidator, genesisValidator}, creator)
 	}
 	registry.RegisterGameType(outputAlphabetGameType, playerCreator)
 }
@@ -112,6 +115,8 @@ func registerOutputCannon(
 	cfg *config.Config,
 	rollupClient outputs.OutputRollupClient,
 	txMgr txmgr.TxManager,
+	actions *ActionScheduler,
+	policy config.AgreementPolicy,
 	caller *batching.MultiCaller,
 	l2Client cannon.L2HeaderSource) {
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
@@ -137,7 +142,7 @@ func registerOutputCannon(
 		}
 		prestateValidator := NewPrestateValidator(contract.GetAbsolutePrestateHash, prestateProvider)
 		genesisValidator := NewPrestateValidator(contract.GetGenesisOutputRoot, prestateProvider)
-		return NewGamePlayer(ctx, logger, m, dir, game.Proxy, txMgr, contract, []Validator{prestateValidator, genesisValidator}, creator)
+		return NewGamePlayer(ctx, logger, m, dir, game.Proxy, txMgr, actions, policy, contract, []Validator{prestateValidator, genesisValidator}, creator)
 	}
 	registry.RegisterGameType(outputCannonGameType, playerCreator)
 }
@@ -149,6 +154,8 @@ func registerCannon(
 	m metrics.Metricer,
 	cfg *config.Config,
 	txMgr txmgr.TxManager,
+	actions *ActionScheduler,
+	policy config.AgreementPolicy,
 	caller *batching.MultiCaller,
 	l2Client cannon.L2HeaderSource) {
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
@@ -166,7 +173,7 @@ func registerCannon(
 			return trace.NewSimpleTraceAccessor(provider), nil
 		}
 		validator := NewPrestateValidator(contract.GetAbsolutePrestateHash, prestateProvider)
-		return NewGamePlayer(ctx, logger, m, dir, game.Proxy, txMgr, contract, []Validator{validator}, creator)
+		return NewGamePlayer(ctx, logger, m, dir, game.Proxy, txMgr, actions, policy, contract, []Validator{validator}, creator)
 	}
 	registry.RegisterGameType(cannonGameType, playerCreator)
 }
@@ -178,6 +185,8 @@ func registerAlphabet(
 	m metrics.Metricer,
 	alphabetTrace string,
 	txMgr txmgr.TxManager,
+	actions *ActionScheduler,
+	policy config.AgreementPolicy,
 	caller *batching.MultiCaller) {
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
 		contract, err := contracts.NewFaultDisputeGameContract(game.Proxy, caller)
@@ -190,7 +199,7 @@ func registerAlphabet(
 			return trace.NewSimpleTraceAccessor(traceProvider), nil
 		}
 		validator := NewPrestateValidator(contract.GetAbsolutePrestateHash, prestateProvider)
-		return NewGamePlayer(ctx, logger, m, dir, game.Proxy, txMgr, contract, []Validator{validator}, creator)
+		return NewGamePlayer(ctx, logger, m, dir, game.Proxy, txMgr, actions, policy, contract, []Validator{validator}, creator)
 	}
 	registry.RegisterGameType(alphabetGameType, playerCreator)
 }
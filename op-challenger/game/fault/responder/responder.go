@@ -54,7 +54,8 @@ func (r *FaultResponder) Resolve(ctx context.Context) error {
 		return err
 	}
 
-	return r.sendTxAndWait(ctx, candidate)
+	_, err = r.sendTxAndWait(ctx, candidate)
+	return err
 }
 
 // CallResolveClaim determines if the resolveClaim function on the fault dispute game contract
@@ -69,18 +70,20 @@ func (r *FaultResponder) ResolveClaim(ctx context.Context, claimIdx uint64) erro
 	if err != nil {
 		return err
 	}
-	return r.sendTxAndWait(ctx, candidate)
+	_, err = r.sendTxAndWait(ctx, candidate)
+	return err
 }
 
-func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action) error {
+// PerformAction sends the transaction of the action, and returns its receipt.
+func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action) (*ethtypes.Receipt, error) {
 	if action.OracleData != nil {
 		r.log.Info("Updating oracle data", "key", action.OracleData.OracleKey)
 		candidate, err := r.contract.UpdateOracleTx(ctx, uint64(action.ParentIdx), action.OracleData)
 		if err != nil {
-			return fmt.Errorf("failed to create pre-image oracle tx: %w", err)
+			return nil, fmt.Errorf("failed to create pre-image oracle tx: %w", err)
 		}
-		if err := r.sendTxAndWait(ctx, candidate); err != nil {
-			return fmt.Errorf("failed to populate pre-image oracle: %w", err)
+		if _, err := r.sendTxAndWait(ctx, candidate); err != nil {
+			return nil, fmt.Errorf("failed to populate pre-image oracle: %w", err)
 		}
 	}
 	var candidate txmgr.TxCandidate
@@ -96,22 +99,22 @@ func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action)
 		candidate, err = r.contract.StepTx(uint64(action.ParentIdx), action.IsAttack, action.PreState, action.ProofData)
 	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 	return r.sendTxAndWait(ctx, candidate)
 }
 
 // sendTxAndWait sends a transaction through the [txmgr] and waits for a receipt.
 // This sets the tx GasLimit to 0, performing gas estimation online through the [txmgr].
-func (r *FaultResponder) sendTxAndWait(ctx context.Context, candidate txmgr.TxCandidate) error {
+func (r *FaultResponder) sendTxAndWait(ctx context.Context, candidate txmgr.TxCandidate) (*ethtypes.Receipt, error) {
 	receipt, err := r.txMgr.Send(ctx, candidate)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if receipt.Status == ethtypes.ReceiptStatusFailed {
 		r.log.Error("Responder tx successfully published but reverted", "tx_hash", receipt.TxHash)
 	} else {
 		r.log.Debug("Responder tx successfully published", "tx_hash", receipt.TxHash)
 	}
-	return nil
+	return receipt, nil
 }
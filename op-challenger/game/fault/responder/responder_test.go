@@ -99,7 +99,7 @@ func TestPerformAction(t *testing.T) {
 	t.Run("send fails", func(t *testing.T) {
 		responder, mockTxMgr, _ := newTestFaultResponder(t)
 		mockTxMgr.sendFails = true
-		err := responder.PerformAction(context.Background(), types.Action{
+		_, err := responder.PerformAction(context.Background(), types.Action{
 			Type:      types.ActionTypeMove,
 			ParentIdx: 123,
 			IsAttack:  true,
@@ -111,7 +111,7 @@ func TestPerformAction(t *testing.T) {
 
 	t.Run("sends response", func(t *testing.T) {
 		responder, mockTxMgr, _ := newTestFaultResponder(t)
-		err := responder.PerformAction(context.Background(), types.Action{
+		receipt, err := responder.PerformAction(context.Background(), types.Action{
 			Type:      types.ActionTypeMove,
 			ParentIdx: 123,
 			IsAttack:  true,
@@ -119,6 +119,7 @@ func TestPerformAction(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.Equal(t, 1, mockTxMgr.sends)
+		require.NotNil(t, receipt, "should return receipt of the response")
 	})
 
 	t.Run("attack", func(t *testing.T) {
@@ -129,7 +130,7 @@ func TestPerformAction(t *testing.T) {
 			IsAttack:  true,
 			Value:     common.Hash{0xaa},
 		}
-		err := responder.PerformAction(context.Background(), action)
+		_, err := responder.PerformAction(context.Background(), action)
 		require.NoError(t, err)
 
 		require.Len(t, mockTxMgr.sent, 1)
@@ -145,7 +146,7 @@ func TestPerformAction(t *testing.T) {
 			IsAttack:  false,
 			Value:     common.Hash{0xaa},
 		}
-		err := responder.PerformAction(context.Background(), action)
+		_, err := responder.PerformAction(context.Background(), action)
 		require.NoError(t, err)
 
 		require.Len(t, mockTxMgr.sent, 1)
@@ -162,7 +163,7 @@ func TestPerformAction(t *testing.T) {
 			PreState:  []byte{1, 2, 3},
 			ProofData: []byte{4, 5, 6},
 		}
-		err := responder.PerformAction(context.Background(), action)
+		_, err := responder.PerformAction(context.Background(), action)
 		require.NoError(t, err)
 
 		require.Len(t, mockTxMgr.sent, 1)
@@ -182,7 +183,7 @@ func TestPerformAction(t *testing.T) {
 				IsLocal: true,
 			},
 		}
-		err := responder.PerformAction(context.Background(), action)
+		_, err := responder.PerformAction(context.Background(), action)
 		require.NoError(t, err)
 
 		require.Len(t, mockTxMgr.sent, 2)
@@ -0,0 +1,221 @@
+package fault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const responsesFile = "responses.json"
+
+// responseTimeout is how long a recorded response that is not on-chain yet stops the action being performed again.
+// It covers a restart while the transaction of the response is pending, or while the L1 node lags behind it.
+const responseTimeout = 10 * time.Minute
+
+// ErrCorruptResponses is returned when the persisted responses of a game cannot be read.
+var ErrCorruptResponses = errors.New("corrupt persisted responses")
+
+type responseStatus string
+
+const (
+	// responsePending is a response of which the transaction is being sent.
+	responsePending responseStatus = "pending"
+	// responseIncluded is a response of which the transaction was included in an L1 block.
+	responseIncluded responseStatus = "included"
+)
+
+// Response is an action performed by the challenger in a game.
+type Response struct {
+	ParentIdx int              `json:"parentIdx"`
+	Type      types.ActionType `json:"type"`
+	IsAttack  bool             `json:"isAttack"`
+	Value     common.Hash      `json:"value"`
+	Status    responseStatus   `json:"status"`
+	TxHash    common.Hash      `json:"txHash,omitempty"`
+	// Time is the unix timestamp the response was last recorded at.
+	Time uint64 `json:"time"`
+}
+
+func (r Response) matches(action types.Action) bool {
+	return r.ParentIdx == action.ParentIdx && r.Type == action.Type && r.IsAttack == action.IsAttack && r.Value == action.Value
+}
+
+// gameResponses is the state of a game that is persisted in its data directory.
+type gameResponses struct {
+	// Responses are the responses that are not known to be on-chain yet.
+	Responses []Response `json:"responses"`
+	// L1Block is the latest L1 block that a response to the game was included in.
+	L1Block uint64 `json:"l1Block"`
+}
+
+// ResponseStore persists the responses of the challenger to a game in the data directory of the game,
+// so that a restarted challenger does not perform the actions of responses that are not visible on-chain yet again.
+// The trace data of the game, like the snapshots and proofs of cannon, is kept in the same directory.
+type ResponseStore struct {
+	path  string
+	lock  sync.Mutex
+	state gameResponses
+}
+
+// LoadResponseStore loads the responses persisted in the directory. ErrCorruptResponses is returned if they cannot
+// be decoded.
+func LoadResponseStore(dir string) (*ResponseStore, error) {
+	s := &ResponseStore{path: filepath.Join(dir, responsesFile)}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read responses: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.state); err != nil {
+		return nil, fmt.Errorf("%w %v: %v", ErrCorruptResponses, s.path, err)
+	}
+	return s, nil
+}
+
+// Responses returns the responses that are not known to be on-chain yet.
+func (s *ResponseStore) Responses() []Response {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]Response(nil), s.state.Responses...)
+}
+
+// L1Block returns the latest L1 block that a response to the game was included in.
+func (s *ResponseStore) L1Block() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.state.L1Block
+}
+
+// Find returns the recorded response that performs the action, if any.
+func (s *ResponseStore) Find(action types.Action) (Response, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, response := range s.state.Responses {
+		if response.matches(action) {
+			return response, true
+		}
+	}
+	return Response{}, false
+}
+
+// RecordPending records that the transaction of the action is about to be sent.
+func (s *ResponseStore) RecordPending(action types.Action, now time.Time) error {
+	return s.update(func(state *gameResponses) {
+		state.Responses = append(removeResponse(state.Responses, action), Response{
+			ParentIdx: action.ParentIdx,
+			Type:      action.Type,
+			IsAttack:  action.IsAttack,
+			Value:     action.Value,
+			Status:    responsePending,
+			Time:      uint64(now.Unix()),
+		})
+	})
+}
+
+// RecordIncluded records that the transaction of the action was included in the L1 block.
+func (s *ResponseStore) RecordIncluded(action types.Action, txHash common.Hash, l1Block uint64, now time.Time) error {
+	return s.update(func(state *gameResponses) {
+		state.Responses = append(removeResponse(state.Responses, action), Response{
+			ParentIdx: action.ParentIdx,
+			Type:      action.Type,
+			IsAttack:  action.IsAttack,
+			Value:     action.Value,
+			Status:    responseIncluded,
+			TxHash:    txHash,
+			Time:      uint64(now.Unix()),
+		})
+		if l1Block > state.L1Block {
+			state.L1Block = l1Block
+		}
+	})
+}
+
+// Remove removes the response of the action, so that the action can be performed again.
+func (s *ResponseStore) Remove(action types.Action) error {
+	return s.update(func(state *gameResponses) {
+		state.Responses = removeResponse(state.Responses, action)
+	})
+}
+
+// Reconcile removes the responses that are on-chain in the game, and the responses that are not on-chain
+// after the response timeout, of which the transactions were presumably lost.
+func (s *ResponseStore) Reconcile(game types.Game, now time.Time) error {
+	claims := game.Claims()
+	return s.update(func(state *gameResponses) {
+		var remaining []Response
+		for _, response := range state.Responses {
+			// The parent claim is missing if the L1 node lags behind the state the response was made to
+			if response.ParentIdx < len(claims) && isOnChain(game, claims[response.ParentIdx], response) {
+				continue
+			}
+			if now.Sub(time.Unix(int64(response.Time), 0)) > responseTimeout {
+				continue
+			}
+			remaining = append(remaining, response)
+		}
+		state.Responses = remaining
+	})
+}
+
+func (s *ResponseStore) update(fn func(state *gameResponses)) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	fn(&s.state)
+	data, err := json.Marshal(s.state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create game dir: %w", err)
+	}
+	out, err := ioutil.NewAtomicWriterCompressed(s.path, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to persist responses: %w", err)
+	}
+	if _, err := out.Write(data); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("failed to persist responses: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to persist responses: %w", err)
+	}
+	return nil
+}
+
+func removeResponse(responses []Response, action types.Action) []Response {
+	var remaining []Response
+	for _, response := range responses {
+		if !response.matches(action) {
+			remaining = append(remaining, response)
+		}
+	}
+	return remaining
+}
+
+// isOnChain returns whether the response to the parent claim is in the game.
+// A move is on-chain if the claim it makes exists, and a step if the parent claim is countered.
+func isOnChain(game types.Game, parent types.Claim, response Response) bool {
+	switch response.Type {
+	case types.ActionTypeMove:
+		position := parent.Position.Defend()
+		if response.IsAttack {
+			position = parent.Position.Attack()
+		}
+		return game.IsDuplicate(types.Claim{
+			ClaimData:           types.ClaimData{Value: response.Value, Position: position},
+			ParentContractIndex: response.ParentIdx,
+		})
+	case types.ActionTypeStep:
+		return parent.Countered
+	}
+	return false
+}
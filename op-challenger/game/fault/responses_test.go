@@ -0,0 +1,91 @@
+package fault
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+func TestResponseStore(t *testing.T) {
+	now := time.Unix(10_000, 0)
+	attack := types.Action{Type: types.ActionTypeMove, ParentIdx: 0, IsAttack: true, Value: common.Hash{0xaa}}
+	step := types.Action{Type: types.ActionTypeStep, ParentIdx: 3, IsAttack: true}
+
+	t.Run("Empty", func(t *testing.T) {
+		store, err := LoadResponseStore(filepath.Join(t.TempDir(), "game"))
+		require.NoError(t, err)
+		require.Empty(t, store.Responses())
+		require.Zero(t, store.L1Block())
+		_, ok := store.Find(attack)
+		require.False(t, ok)
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "game")
+		store, err := LoadResponseStore(dir)
+		require.NoError(t, err)
+		require.NoError(t, store.RecordPending(attack, now))
+		require.NoError(t, store.RecordPending(step, now))
+		require.NoError(t, store.RecordIncluded(attack, common.Hash{0xbb}, 15, now))
+		require.NoError(t, store.RecordIncluded(step, common.Hash{0xcc}, 12, now))
+
+		reloaded, err := LoadResponseStore(dir)
+		require.NoError(t, err)
+		require.Equal(t, store.Responses(), reloaded.Responses())
+		require.Equal(t, uint64(15), reloaded.L1Block(), "should keep the latest L1 block")
+		response, ok := reloaded.Find(attack)
+		require.True(t, ok)
+		require.Equal(t, responseIncluded, response.Status)
+		require.Equal(t, common.Hash{0xbb}, response.TxHash)
+
+		require.NoError(t, reloaded.Remove(attack))
+		reloaded, err = LoadResponseStore(dir)
+		require.NoError(t, err)
+		_, ok = reloaded.Find(attack)
+		require.False(t, ok)
+		_, ok = reloaded.Find(step)
+		require.True(t, ok)
+	})
+
+	t.Run("Corrupt", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, responsesFile), []byte("{\"responses\":["), 0o644))
+		_, err := LoadResponseStore(dir)
+		require.ErrorIs(t, err, ErrCorruptResponses)
+	})
+}
+
+func TestResponseStoreReconcile(t *testing.T) {
+	depth := 4
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider("abcd", uint64(depth)))
+	root := claimBuilder.CreateRootClaim(false)
+	attack := claimBuilder.AttackClaim(root, true)
+	attack.ContractIndex = 1
+	now := time.Unix(10_000, 0)
+
+	store, err := LoadResponseStore(t.TempDir())
+	require.NoError(t, err)
+	onChain := types.Action{Type: types.ActionTypeMove, ParentIdx: 0, IsAttack: true, Value: attack.Value}
+	pending := types.Action{Type: types.ActionTypeMove, ParentIdx: 0, IsAttack: false, Value: common.Hash{0xaa}}
+	expired := types.Action{Type: types.ActionTypeMove, ParentIdx: 1, IsAttack: true, Value: common.Hash{0xbb}}
+	unknownParent := types.Action{Type: types.ActionTypeMove, ParentIdx: 5, IsAttack: true, Value: common.Hash{0xcc}}
+	require.NoError(t, store.RecordPending(onChain, now))
+	require.NoError(t, store.RecordPending(pending, now))
+	require.NoError(t, store.RecordPending(expired, now.Add(-responseTimeout-time.Second)))
+	require.NoError(t, store.RecordPending(unknownParent, now))
+
+	game := types.NewGameState([]types.Claim{root, attack}, uint64(depth))
+	require.NoError(t, store.Reconcile(game, now))
+	responses := store.Responses()
+	require.Len(t, responses, 2, "should only keep responses that may still be made on-chain")
+	require.True(t, responses[0].matches(pending))
+	require.True(t, responses[1].matches(unknownParent), "should keep responses to claims the L1 node does not have yet")
+}
@@ -0,0 +1,249 @@
+package cannon
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+const (
+	// stubVMEnv makes the test binary run as a stub of the cannon binary, instead of running the tests.
+	stubVMEnv = "OP_CHALLENGER_STUB_CANNON"
+	// stubVMExitEnv is the step of the first exited state of the trace of the stub.
+	stubVMExitEnv = "OP_CHALLENGER_STUB_CANNON_EXIT"
+	// stubVMLogEnv is the file that the stub appends the step it starts each run from to.
+	stubVMLogEnv = "OP_CHALLENGER_STUB_CANNON_LOG"
+)
+
+func TestMain(m *testing.M) {
+	if os.Getenv(stubVMEnv) != "" {
+		if err := runStubVM(os.Args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// stubVMState is the state at the given step of the deterministic trace of the stub, which exits at exitStep.
+func stubVMState(step uint64, exitStep uint64) *mipsevm.State {
+	return &mipsevm.State{
+		Memory: mipsevm.NewMemory(),
+		PC:     uint32(step * 4),
+		NextPC: uint32(step*4 + 4),
+		Step:   step,
+		Exited: step >= exitStep,
+	}
+}
+
+func stubVMStateHash(step uint64, exitStep uint64) common.Hash {
+	hash, err := mipsevm.StateWitness(stubVMState(step, exitStep).EncodeWitness()).StateHash()
+	if err != nil {
+		panic(err)
+	}
+	return hash
+}
+
+func stubVMProofData(step uint64) []byte {
+	return crypto.Keccak256(binary.BigEndian.AppendUint64(nil, step))
+}
+
+// runStubVM behaves like cannon run, with a trace of which every state only depends on its step.
+func runStubVM(args []string) error {
+	if len(args) == 0 || args[0] != "run" {
+		return fmt.Errorf("unsupported command: %v", args)
+	}
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	input := flags.String("input", "", "")
+	output := flags.String("output", "", "")
+	flags.String("meta", "", "")
+	flags.String("info-at", "", "")
+	proofAt := flags.String("proof-at", "", "")
+	proofFmt := flags.String("proof-fmt", "", "")
+	snapshotAt := flags.String("snapshot-at", "", "")
+	snapshotFmt := flags.String("snapshot-fmt", "", "")
+	stopAt := flags.String("stop-at", "", "")
+	// the arguments after -- are the pre-image server command, which the stub does not need
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	exitStep, err := strconv.ParseUint(os.Getenv(stubVMExitEnv), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid exit step: %w", err)
+	}
+	start, err := parseState(*input)
+	if err != nil {
+		return err
+	}
+	proofStep, err := strconv.ParseUint(strings.TrimPrefix(*proofAt, "="), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid proof-at: %w", err)
+	}
+	stopStep := uint64(math.MaxUint64)
+	if *stopAt != "" {
+		if stopStep, err = strconv.ParseUint(strings.TrimPrefix(*stopAt, "="), 10, 64); err != nil {
+			return fmt.Errorf("invalid stop-at: %w", err)
+		}
+	}
+	snapshotFreq, err := strconv.ParseUint(strings.TrimPrefix(*snapshotAt, "%"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid snapshot-at: %w", err)
+	}
+
+	logFile, err := os.OpenFile(os.Getenv(stubVMLogEnv), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+	if _, err := fmt.Fprintln(logFile, start.Step); err != nil {
+		return err
+	}
+
+	writeState := func(path string, state *mipsevm.State) error {
+		data, err := json.Marshal(state)
+		if err != nil {
+			return err
+		}
+		return writeGzip(path, data)
+	}
+	step := start.Step
+	for step < exitStep && step != stopStep {
+		if step%snapshotFreq == 0 && step != start.Step {
+			if err := writeState(fmt.Sprintf(*snapshotFmt, step), stubVMState(step, exitStep)); err != nil {
+				return err
+			}
+		}
+		if step == proofStep {
+			proof := proofData{
+				ClaimValue: stubVMStateHash(step+1, exitStep),
+				StateData:  []byte(stubVMState(step, exitStep).EncodeWitness()),
+				ProofData:  stubVMProofData(step),
+			}
+			data, err := json.Marshal(proof)
+			if err != nil {
+				return err
+			}
+			if err := writeGzip(fmt.Sprintf(*proofFmt, step), data); err != nil {
+				return err
+			}
+		}
+		step++
+	}
+	return writeState(*output, stubVMState(step, exitStep))
+}
+
+func TestTraceProviderWithStubVM(t *testing.T) {
+	const exitStep = 5000
+	dir := t.TempDir()
+	prestate := filepath.Join(dir, "prestate.json")
+	data, err := json.Marshal(stubVMState(0, exitStep))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(prestate, data, 0o644))
+	runLog := filepath.Join(dir, "runs.log")
+	t.Setenv(stubVMEnv, "1")
+	t.Setenv(stubVMExitEnv, strconv.Itoa(exitStep))
+	t.Setenv(stubVMLogEnv, runLog)
+
+	cfg := config.NewConfig(common.Address{0xaa}, "http://localhost:8545", dir, config.TraceTypeCannon)
+	cfg.CannonBin = os.Args[0]
+	cfg.CannonServer = "op-program"
+	cfg.CannonAbsolutePreState = prestate
+	cfg.CannonSnapshotFreq = 1000
+	cfg.CannonInfoFreq = 1000
+	inputs := LocalGameInputs{L2BlockNumber: big.NewInt(1)}
+	logger := testlog.Logger(t, log.LvlInfo)
+	provider := NewTraceProvider(logger, metrics.NoopMetrics, &cfg, types.NoLocalContext, inputs, filepath.Join(dir, "game"), 63)
+
+	// runs returns the steps that the stub started its runs from
+	runs := func() []uint64 {
+		file, err := os.Open(runLog)
+		require.NoError(t, err)
+		defer file.Close()
+		var starts []uint64
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			start, err := strconv.ParseUint(scanner.Text(), 10, 64)
+			require.NoError(t, err)
+			starts = append(starts, start)
+		}
+		require.NoError(t, scanner.Err())
+		return starts
+	}
+	get := func(traceIndex uint64) common.Hash {
+		value, err := provider.Get(context.Background(), PositionFromTraceIndex(provider, new(big.Int).SetUint64(traceIndex)))
+		require.NoError(t, err)
+		return value
+	}
+
+	t.Run("AbsolutePreState", func(t *testing.T) {
+		commitment, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, stubVMStateHash(0, exitStep), commitment)
+	})
+
+	t.Run("FirstIndex", func(t *testing.T) {
+		require.Equal(t, stubVMStateHash(1, exitStep), get(0), "should be the state after the first step")
+		require.Equal(t, []uint64{0}, runs())
+	})
+
+	t.Run("ResumeFromSnapshot", func(t *testing.T) {
+		require.Equal(t, stubVMStateHash(1500, exitStep), get(1499))
+		require.Equal(t, stubVMStateHash(2501, exitStep), get(2500))
+		require.Equal(t, []uint64{0, 0, 1000}, runs(), "should resume from the latest snapshot before the index")
+	})
+
+	t.Run("CachedProofs", func(t *testing.T) {
+		require.Equal(t, stubVMStateHash(1, exitStep), get(0))
+		require.Equal(t, stubVMStateHash(2501, exitStep), get(2500))
+		require.Len(t, runs(), 3, "should not execute again for generated proofs")
+	})
+
+	t.Run("StepData", func(t *testing.T) {
+		state, proof, oracleData, err := provider.GetStepData(context.Background(), PositionFromTraceIndex(provider, big.NewInt(2500)))
+		require.NoError(t, err)
+		require.Equal(t, []byte(stubVMState(2500, exitStep).EncodeWitness()), state, "should be the state before the step")
+		require.Equal(t, stubVMProofData(2500), proof)
+		require.Nil(t, oracleData)
+	})
+
+	t.Run("SnapshotBoundaries", func(t *testing.T) {
+		require.Equal(t, stubVMStateHash(2000, exitStep), get(1999))
+		require.Equal(t, stubVMStateHash(2001, exitStep), get(2000))
+		require.Equal(t, []uint64{0, 0, 1000, 1000, 1000}, runs(), "should only resume from snapshots before the index")
+	})
+
+	t.Run("LastStep", func(t *testing.T) {
+		final := stubVMStateHash(exitStep, exitStep)
+		require.Equal(t, final, get(exitStep-1), "last step should lead to the exited state")
+		require.Equal(t, final, get(exitStep), "should extend the trace with the exited state")
+		require.Equal(t, final, get(1<<63-1), "should extend the trace up to the max index")
+		state, proof, _, err := provider.GetStepData(context.Background(), PositionFromTraceIndex(provider, big.NewInt(exitStep+10)))
+		require.NoError(t, err)
+		require.Equal(t, []byte(stubVMState(exitStep, exitStep).EncodeWitness()), state)
+		require.Empty(t, proof)
+		starts := runs()
+		require.Equal(t, []uint64{2000, 4000}, starts[5:], "should not execute beyond the end of the trace once it is known")
+	})
+}
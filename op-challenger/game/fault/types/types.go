@@ -110,7 +110,11 @@ type Claim struct {
 	//       When caching is implemented for the Challenger, this will need
 	//       to be changed/removed to avoid invalid/stale contract state.
 	Countered bool
-	Clock     uint64
+	// Clock is the timestamp the claim was made at, the lower 64 bits of its clock in the contract.
+	Clock uint64
+	// ClockDuration is the time in seconds used by the team of the claim when it was made,
+	// the upper 64 bits of its clock in the contract.
+	ClockDuration uint64
 	// Location of the claim & it's parent inside the contract. Does not exist
 	// for claims that have not made it to the contract.
 	ContractIndex       int
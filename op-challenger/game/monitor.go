@@ -30,17 +30,31 @@ type gameScheduler interface {
 	Schedule([]types.GameMetadata) error
 }
 
+// gameTypeRegistry tells which game types the challenger can play
+type gameTypeRegistry interface {
+	SupportsGameType(gameType uint8) bool
+}
+
+type MonitorMetricer interface {
+	RecordUnsupportedGames(count int)
+}
+
 type gameMonitor struct {
 	logger           log.Logger
+	metrics          MonitorMetricer
 	clock            clock.Clock
 	source           gameSource
 	scheduler        gameScheduler
+	gameTypes        gameTypeRegistry
 	gameWindow       time.Duration
 	fetchBlockNumber blockNumberFetcher
 	allowedGames     []common.Address
 	l1HeadsSub       ethereum.Subscription
 	l1Source         *headSource
 	runState         sync.Mutex
+
+	// unsupportedTypes are the unsupported game types that were already reported, to only warn once about each
+	unsupportedTypes map[uint8]bool
 }
 
 type MinimalSubscriber interface {
@@ -57,9 +71,11 @@ func (s *headSource) SubscribeNewHead(ctx context.Context, ch chan<- *ethTypes.H
 
 func newGameMonitor(
 	logger log.Logger,
+	m MonitorMetricer,
 	cl clock.Clock,
 	source gameSource,
 	scheduler gameScheduler,
+	gameTypes gameTypeRegistry,
 	gameWindow time.Duration,
 	fetchBlockNumber blockNumberFetcher,
 	allowedGames []common.Address,
@@ -67,13 +83,16 @@ func newGameMonitor(
 ) *gameMonitor {
 	return &gameMonitor{
 		logger:           logger,
+		metrics:          m,
 		clock:            cl,
 		scheduler:        scheduler,
+		gameTypes:        gameTypes,
 		source:           source,
 		gameWindow:       gameWindow,
 		fetchBlockNumber: fetchBlockNumber,
 		allowedGames:     allowedGames,
 		l1Source:         &headSource{inner: l1Source},
+		unsupportedTypes: make(map[uint8]bool),
 	}
 }
 
@@ -107,13 +126,25 @@ func (m *gameMonitor) progressGames(ctx context.Context, blockHash common.Hash)
 		return fmt.Errorf("failed to load games: %w", err)
 	}
 	var gamesToPlay []types.GameMetadata
+	unsupported := 0
 	for _, game := range games {
 		if !m.allowedGame(game.Proxy) {
 			m.logger.Debug("Skipping game not on allow list", "game", game.Proxy)
 			continue
 		}
+		if !m.gameTypes.SupportsGameType(game.GameType) {
+			unsupported++
+			if !m.unsupportedTypes[game.GameType] {
+				m.unsupportedTypes[game.GameType] = true
+				m.logger.Warn("Skipping games of unsupported game type", "gameType", game.GameType, "game", game.Proxy)
+			} else {
+				m.logger.Debug("Skipping game of unsupported game type", "gameType", game.GameType, "game", game.Proxy)
+			}
+			continue
+		}
 		gamesToPlay = append(gamesToPlay, game)
 	}
+	m.metrics.RecordUnsupportedGames(unsupported)
 	if err := m.scheduler.Schedule(gamesToPlay); errors.Is(err, scheduler.ErrBusy) {
 		m.logger.Info("Scheduler still busy with previous update")
 	} else if err != nil {
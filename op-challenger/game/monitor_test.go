@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/registry"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -166,6 +170,84 @@ func TestMonitorOnlyScheduleSpecifiedGame(t *testing.T) {
 	require.Equal(t, []common.Address{addr2}, sched.Scheduled()[0])
 }
 
+func TestMonitorSkipsUnsupportedGameTypes(t *testing.T) {
+	addr1 := common.Address{0xaa}
+	addr2 := common.Address{0xbb}
+	addr3 := common.Address{0xcc}
+	monitor, source, sched, _ := setupMonitorTest(t, []common.Address{})
+	monitor.gameTypes = &stubGameTypes{unsupported: map[uint8]bool{7: true}}
+	m := &stubMonitorMetrics{}
+	monitor.metrics = m
+	source.games = []types.GameMetadata{
+		{GameType: 0, Proxy: addr1, Timestamp: 9999},
+		{GameType: 7, Proxy: addr2, Timestamp: 9999},
+		{GameType: 255, Proxy: addr3, Timestamp: 9999},
+	}
+
+	require.NoError(t, monitor.progressGames(context.Background(), common.Hash{0x01}))
+	require.Len(t, sched.Scheduled(), 1)
+	require.Equal(t, []common.Address{addr1, addr3}, sched.Scheduled()[0])
+	require.Equal(t, 1, m.unsupported)
+
+	source.games = source.games[:1]
+	require.NoError(t, monitor.progressGames(context.Background(), common.Hash{0x02}))
+	require.Equal(t, 0, m.unsupported, "should reset the metric once the game is no longer loaded")
+}
+
+// TestMonitorPlaysMultipleGameTypes checks that games of different types are played concurrently by the players
+// of their types, while games of an unknown type are skipped.
+func TestMonitorPlaysMultipleGameTypes(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	gameTypes := registry.NewGameTypeRegistry()
+	players := make(map[common.Address]*countingPlayer)
+	var playersLock sync.Mutex
+	register := func(gameType uint8) {
+		gameTypes.RegisterGameType(gameType, func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
+			if game.GameType != gameType {
+				return nil, fmt.Errorf("game type %v created with the player of game type %v", game.GameType, gameType)
+			}
+			playersLock.Lock()
+			defer playersLock.Unlock()
+			player := &countingPlayer{}
+			players[game.Proxy] = player
+			return player, nil
+		})
+	}
+	register(0)
+	register(255)
+
+	sched := scheduler.NewScheduler(logger, metrics.NoopMetrics, newDiskManager(t.TempDir()), 2, gameTypes.CreatePlayer)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sched.Start(ctx)
+	defer func() {
+		require.NoError(t, sched.Close())
+	}()
+
+	cannonGame := common.Address{0xaa}
+	alphabetGame := common.Address{0xbb}
+	unknownGame := common.Address{0xcc}
+	source := &stubGameSource{games: []types.GameMetadata{
+		{GameType: 0, Proxy: cannonGame, Timestamp: 9999},
+		{GameType: 255, Proxy: alphabetGame, Timestamp: 9999},
+		{GameType: 42, Proxy: unknownGame, Timestamp: 9999},
+	}}
+	m := &stubMonitorMetrics{}
+	monitor := newGameMonitor(logger, m, clock.SystemClock, source, sched, gameTypes, time.Duration(0), nil, nil, &mockNewHeadSource{})
+
+	require.NoError(t, monitor.progressGames(ctx, common.Hash{0x01}))
+	require.Equal(t, 1, m.unsupported)
+	waitErr := wait.For(ctx, 10*time.Second, func() (bool, error) {
+		playersLock.Lock()
+		defer playersLock.Unlock()
+		return len(players) == 2 && players[cannonGame].Progressed() && players[alphabetGame].Progressed(), nil
+	})
+	require.NoError(t, waitErr, "should play the games of both types")
+	playersLock.Lock()
+	defer playersLock.Unlock()
+	require.NotContains(t, players, unknownGame)
+}
+
 func newFDG(proxy common.Address, timestamp uint64) types.GameMetadata {
 	return types.GameMetadata{
 		Proxy:     proxy,
@@ -188,9 +270,11 @@ func setupMonitorTest(
 	mockHeadSource := &mockNewHeadSource{}
 	monitor := newGameMonitor(
 		logger,
+		&stubMonitorMetrics{},
 		clock.SystemClock,
 		source,
 		sched,
+		&stubGameTypes{},
 		time.Duration(0),
 		fetchBlockNum,
 		allowedGames,
@@ -281,3 +365,40 @@ func (s *stubScheduler) Schedule(games []types.GameMetadata) error {
 	s.scheduled = append(s.scheduled, addrs)
 	return nil
 }
+
+type stubMonitorMetrics struct {
+	unsupported int
+}
+
+func (m *stubMonitorMetrics) RecordUnsupportedGames(count int) {
+	m.unsupported = count
+}
+
+type stubGameTypes struct {
+	unsupported map[uint8]bool
+}
+
+func (s *stubGameTypes) SupportsGameType(gameType uint8) bool {
+	return !s.unsupported[gameType]
+}
+
+type countingPlayer struct {
+	progressCount atomic.Int32
+}
+
+func (p *countingPlayer) ValidatePrestate(_ context.Context) error {
+	return nil
+}
+
+func (p *countingPlayer) ProgressGame(_ context.Context) types.GameStatus {
+	p.progressCount.Add(1)
+	return types.GameStatusInProgress
+}
+
+func (p *countingPlayer) Status() types.GameStatus {
+	return types.GameStatusInProgress
+}
+
+func (p *countingPlayer) Progressed() bool {
+	return p.progressCount.Load() > 0
+}
@@ -39,3 +39,9 @@ func (r *GameTypeRegistry) CreatePlayer(game types.GameMetadata, dir string) (sc
 	}
 	return creator(game, dir)
 }
+
+// SupportsGameType returns whether a scheduler.PlayerCreator is registered for the game type.
+func (r *GameTypeRegistry) SupportsGameType(gameType uint8) bool {
+	_, ok := r.types[gameType]
+	return ok
+}
@@ -14,6 +14,7 @@ func TestUnknownGameType(t *testing.T) {
 	player, err := registry.CreatePlayer(types.GameMetadata{GameType: 0}, "")
 	require.ErrorIs(t, err, ErrUnsupportedGameType)
 	require.Nil(t, player)
+	require.False(t, registry.SupportsGameType(0))
 }
 
 func TestKnownGameType(t *testing.T) {
@@ -26,6 +27,8 @@ func TestKnownGameType(t *testing.T) {
 	player, err := registry.CreatePlayer(types.GameMetadata{GameType: 0}, "")
 	require.NoError(t, err)
 	require.Same(t, expectedPlayer, player)
+	require.True(t, registry.SupportsGameType(0))
+	require.False(t, registry.SupportsGameType(1))
 }
 
 func TestPanicsOnDuplicateGameType(t *testing.T) {
@@ -34,6 +34,9 @@ type Service struct {
 	metrics metrics.Metricer
 	monitor *gameMonitor
 	sched   *scheduler.Scheduler
+	actions *fault.ActionScheduler
+
+	gameTypes *registry.GameTypeRegistry
 
 	faultGamesCloser fault.CloseFunc
 
@@ -187,11 +190,13 @@ func (s *Service) initRollupClient(ctx context.Context, cfg *config.Config) erro
 func (s *Service) initScheduler(ctx context.Context, cfg *config.Config) error {
 	gameTypeRegistry := registry.NewGameTypeRegistry()
 	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
-	closer, err := fault.RegisterGameTypes(gameTypeRegistry, ctx, s.logger, s.metrics, cfg, s.rollupClient, s.txMgr, caller)
+	s.actions = fault.NewActionScheduler(s.logger, clock.SystemClock, cfg.ResponseConcurrency)
+	closer, err := fault.RegisterGameTypes(gameTypeRegistry, ctx, s.logger, s.metrics, cfg, s.rollupClient, s.txMgr, s.actions, caller)
 	if err != nil {
 		return err
 	}
 	s.faultGamesCloser = closer
+	s.gameTypes = gameTypeRegistry
 
 	disk := newDiskManager(cfg.Datadir)
 	s.sched = scheduler.NewScheduler(s.logger, s.metrics, disk, cfg.MaxConcurrency, gameTypeRegistry.CreatePlayer)
@@ -200,11 +205,12 @@ func (s *Service) initScheduler(ctx context.Context, cfg *config.Config) error {
 
 func (s *Service) initMonitor(cfg *config.Config) {
 	cl := clock.SystemClock
-	s.monitor = newGameMonitor(s.logger, cl, s.loader, s.sched, cfg.GameWindow, s.l1Client.BlockNumber, cfg.GameAllowlist, s.pollClient)
+	s.monitor = newGameMonitor(s.logger, s.metrics, cl, s.loader, s.sched, s.gameTypes, cfg.GameWindow, s.l1Client.BlockNumber, cfg.GameAllowlist, s.pollClient)
 }
 
 func (s *Service) Start(ctx context.Context) error {
 	s.logger.Info("starting scheduler")
+	s.actions.Start(ctx)
 	s.sched.Start(ctx)
 	s.logger.Info("starting monitoring")
 	s.monitor.StartMonitoring()
@@ -225,6 +231,11 @@ func (s *Service) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to close scheduler: %w", err))
 		}
 	}
+	if s.actions != nil {
+		if err := s.actions.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close action scheduler: %w", err))
+		}
+	}
 	if s.monitor != nil {
 		s.monitor.StopMonitoring()
 	}
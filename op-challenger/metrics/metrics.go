@@ -28,11 +28,16 @@ type Metricer interface {
 	// Record cache metrics
 	caching.Metrics
 
-	RecordGameStep()
-	RecordGameMove()
+	RecordGameStep(success bool)
+	RecordGameMove(isAttack bool, success bool)
+	RecordResponseTime(t float64)
 	RecordCannonExecutionTime(t float64)
 
+	RecordGameResolved(challengerWon bool)
+	AddTrackedClaims(count int)
+
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
+	RecordUnsupportedGames(count int)
 
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
@@ -57,13 +62,18 @@ type Metrics struct {
 
 	executors prometheus.GaugeVec
 
-	moves prometheus.Counter
-	steps prometheus.Counter
+	moves        prometheus.CounterVec
+	steps        prometheus.CounterVec
+	responseTime prometheus.Histogram
 
 	cannonExecutionTime prometheus.Histogram
 
-	trackedGames  prometheus.GaugeVec
-	inflightGames prometheus.Gauge
+	resolvedGames prometheus.CounterVec
+	trackedClaims prometheus.Gauge
+
+	trackedGames     prometheus.GaugeVec
+	inflightGames    prometheus.Gauge
+	unsupportedGames prometheus.Gauge
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -100,15 +110,26 @@ func NewMetrics() *Metrics {
 		}, []string{
 			"status",
 		}),
-		moves: factory.NewCounter(prometheus.CounterOpts{
+		moves: *factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "moves",
 			Help:      "Number of game moves made by the challenge agent",
+		}, []string{
+			"type",
+			"result",
 		}),
-		steps: factory.NewCounter(prometheus.CounterOpts{
+		steps: *factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "steps",
 			Help:      "Number of game steps made by the challenge agent",
+		}, []string{
+			"result",
+		}),
+		responseTime: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "response_time",
+			Help:      "Time (in seconds) from a claim being made to the challenge agent responding to it",
+			Buckets:   prometheus.ExponentialBuckets(1.0, 2.0, 20),
 		}),
 		cannonExecutionTime: factory.NewHistogram(prometheus.HistogramOpts{
 			Namespace: Namespace,
@@ -118,6 +139,18 @@ func NewMetrics() *Metrics {
 				[]float64{1.0, 10.0},
 				prometheus.ExponentialBuckets(30.0, 2.0, 14)...),
 		}),
+		resolvedGames: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "resolved_games",
+			Help:      "Number of games seen to be resolved by the challenger",
+		}, []string{
+			"winner",
+		}),
+		trackedClaims: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "tracked_claims",
+			Help:      "Number of claims in the in progress games played by the challenger",
+		}),
 		trackedGames: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "tracked_games",
@@ -130,6 +163,11 @@ func NewMetrics() *Metrics {
 			Name:      "inflight_games",
 			Help:      "Number of games being tracked by the challenger",
 		}),
+		unsupportedGames: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "unsupported_games",
+			Help:      "Number of games skipped by the challenger because their game type is not supported",
+		}),
 	}
 }
 
@@ -161,12 +199,20 @@ func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
 
-func (m *Metrics) RecordGameMove() {
-	m.moves.Add(1)
+func (m *Metrics) RecordGameMove(isAttack bool, success bool) {
+	moveType := "defend"
+	if isAttack {
+		moveType = "attack"
+	}
+	m.moves.WithLabelValues(moveType, resultLabel(success)).Add(1)
+}
+
+func (m *Metrics) RecordGameStep(success bool) {
+	m.steps.WithLabelValues(resultLabel(success)).Add(1)
 }
 
-func (m *Metrics) RecordGameStep() {
-	m.steps.Add(1)
+func (m *Metrics) RecordResponseTime(t float64) {
+	m.responseTime.Observe(t)
 }
 
 func (m *Metrics) RecordCannonExecutionTime(t float64) {
@@ -195,6 +241,22 @@ func (m *Metrics) RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 	m.trackedGames.WithLabelValues("challenger_won").Set(float64(challengerWon))
 }
 
+func (m *Metrics) RecordGameResolved(challengerWon bool) {
+	winner := "defender"
+	if challengerWon {
+		winner = "challenger"
+	}
+	m.resolvedGames.WithLabelValues(winner).Add(1)
+}
+
+func (m *Metrics) AddTrackedClaims(count int) {
+	m.trackedClaims.Add(float64(count))
+}
+
+func (m *Metrics) RecordUnsupportedGames(count int) {
+	m.unsupportedGames.Set(float64(count))
+}
+
 func (m *Metrics) RecordGameUpdateScheduled() {
 	m.inflightGames.Add(1)
 }
@@ -202,3 +264,10 @@ func (m *Metrics) RecordGameUpdateScheduled() {
 func (m *Metrics) RecordGameUpdateCompleted() {
 	m.inflightGames.Sub(1)
 }
+
+func resultLabel(success bool) string {
+	if success {
+		return "success"
+	}
+	return "failure"
+}
@@ -23,12 +23,17 @@ var NoopMetrics Metricer = new(NoopMetricsImpl)
 func (*NoopMetricsImpl) RecordInfo(version string) {}
 func (*NoopMetricsImpl) RecordUp()                 {}
 
-func (*NoopMetricsImpl) RecordGameMove() {}
-func (*NoopMetricsImpl) RecordGameStep() {}
+func (*NoopMetricsImpl) RecordGameMove(isAttack bool, success bool) {}
+func (*NoopMetricsImpl) RecordGameStep(success bool)                {}
+func (*NoopMetricsImpl) RecordResponseTime(t float64)               {}
 
 func (*NoopMetricsImpl) RecordCannonExecutionTime(t float64) {}
 
+func (*NoopMetricsImpl) RecordGameResolved(challengerWon bool) {}
+func (*NoopMetricsImpl) AddTrackedClaims(count int)            {}
+
 func (*NoopMetricsImpl) RecordGamesStatus(inProgress, defenderWon, challengerWon int) {}
+func (*NoopMetricsImpl) RecordUnsupportedGames(count int)                             {}
 
 func (*NoopMetricsImpl) RecordGameUpdateScheduled() {}
 func (*NoopMetricsImpl) RecordGameUpdateCompleted() {}
@@ -0,0 +1,58 @@
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// TestDerivationStageMetrics checks that deriving a batch from L1 times every derivation stage,
+// and that the timings are observed by the op-node metrics.
+func TestDerivationStageMetrics(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+	miner, seqEngine, sequencer := setupSequencerTest(t, sd, log)
+	_, verifier := setupVerifier(t, sd, log, miner.L1Client(t, sd.RollupCfg), &sync.Config{})
+
+	m := metrics.NewMetrics("")
+	stages := make(map[string]int)
+	verifier.metrics.FnRecordStageTime = func(stage string, duration time.Duration) {
+		stages[stage] += 1
+		m.RecordDerivationStageTime(stage, duration)
+	}
+
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize: 0,
+		MaxL1TxSize: 128_000,
+		BatcherKey:  dp.Secrets.Batcher,
+	}, sequencer.RollupClient(), miner.EthClient(), seqEngine.EthClient(), seqEngine.EngineClient(t, sd.RollupCfg))
+
+	sequencer.ActL2PipelineFull(t)
+	sequencer.ActL2StartBlock(t)
+	sequencer.ActL2EndBlock(t)
+
+	batcher.ActSubmitAll(t)
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+	miner.ActL1EndBlock(t)
+
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, uint64(1), verifier.SyncStatus().SafeL2.Number, "derived the batch")
+
+	for _, stage := range []string{derive.StageL1Receipts, derive.StageChannelBankFrame, derive.StageBatchQueueBatch, derive.StageAttributesBuild} {
+		require.Positive(t, stages[stage], "stage %s is timed", stage)
+	}
+	require.Equal(t, 4, testutil.CollectAndCount(m.DerivationStageDurationSeconds), "a histogram per stage is registered")
+}
@@ -0,0 +1,35 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-program/host"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/prefetcher"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+// RunFaultProofProgram runs the fault proof program in process, with the host fetching the pre-images from the
+// L1 chain of the miner and the L2 chain of the engine, instead of from the L1 and L2 URLs of the config.
+// The program logs to the given logger, so a test logger reports them when the test fails.
+// It returns the result of the program, which wraps driver.ErrClaimNotValid when the claim is invalid.
+func RunFaultProofProgram(t Testing, logger log.Logger, miner *L1Miner, engine *L2Engine, cfg *config.Config) error {
+	inProcessPrefetcher := host.WithPrefetcher(func(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *config.Config) (*prefetcher.Prefetcher, error) {
+		l1Cl, err := sources.NewL1Client(miner.RPCClient(), logger, nil, sources.L1ClientDefaultConfig(cfg.Rollup, cfg.L1TrustRPC, cfg.L1RPCKind))
+		if err != nil {
+			return nil, err
+		}
+		l2RPC := engine.RPCClient()
+		l2ClCfg := sources.L2ClientDefaultConfig(cfg.Rollup, true)
+		l2Cl, err := host.NewL2Client(l2RPC, logger, nil, &host.L2ClientConfig{L2ClientConfig: l2ClCfg, L2Head: cfg.L2Head})
+		if err != nil {
+			return nil, err
+		}
+		l2DebugCl := &host.L2Source{L2Client: l2Cl, DebugClient: sources.NewDebugClient(l2RPC.CallContext)}
+		return prefetcher.NewPrefetcher(logger, l1Cl, nil, l2DebugCl, kv), nil
+	})
+	return host.FaultProofProgram(t.Ctx(), logger, cfg, inProcessPrefetcher)
+}
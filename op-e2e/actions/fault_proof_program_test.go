@@ -0,0 +1,88 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-program/client/driver"
+	"github.com/ethereum-optimism/optimism/op-program/host"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// setupFaultProofProgramTest builds a small chain, of which the batcher posted a few channels, and returns the
+// config of the fault proof program for the correct output root of a safe block.
+func setupFaultProofProgramTest(t Testing) (*L1Miner, *L2Engine, *config.Config, log.Logger) {
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+	miner, seqEngine, sequencer := setupSequencerTest(t, sd, log)
+	rollupSeqCl := sequencer.RollupClient()
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize: 0,
+		MaxL1TxSize: 128_000,
+		BatcherKey:  dp.Secrets.Batcher,
+	}, rollupSeqCl, miner.EthClient(), seqEngine.EthClient(), seqEngine.EngineClient(t, sd.RollupCfg))
+
+	// every L1 block confirms a channel with the L2 blocks up to the previous L1 block
+	buildChannel := func() {
+		sequencer.ActL1HeadSignal(t)
+		sequencer.ActBuildToL1Head(t)
+		batcher.ActSubmitAll(t)
+		miner.ActL1StartBlock(12)(t)
+		miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+		miner.ActL1EndBlock(t)
+		sequencer.ActL1HeadSignal(t)
+		sequencer.ActL2PipelineFull(t)
+	}
+	sequencer.ActL2PipelineFull(t)
+	buildChannel()
+
+	agreed := sequencer.L2Safe()
+	require.NotZero(t, agreed.Number, "agreed block should be derived from a channel")
+	agreedOutput, err := rollupSeqCl.OutputAtBlock(t.Ctx(), agreed.Number)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		buildChannel()
+	}
+	claimed := sequencer.L2Safe()
+	require.Greater(t, claimed.Number, agreed.Number, "claimed block should be derived from later channels")
+	claimedOutput, err := rollupSeqCl.OutputAtBlock(t.Ctx(), claimed.Number)
+	require.NoError(t, err)
+	l1Head := miner.l1Chain.CurrentBlock().Hash()
+
+	cfg := config.NewConfig(sd.RollupCfg, sd.L2Cfg.Config, l1Head, agreed.Hash, common.Hash(agreedOutput.OutputRoot), common.Hash(claimedOutput.OutputRoot), claimed.Number)
+	return miner, seqEngine, cfg, log
+}
+
+// TestFaultProofProgram checks that the fault proof program accepts the output root of a safe block,
+// and rejects a corrupted one.
+func TestFaultProofProgram(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	miner, seqEngine, cfg, log := setupFaultProofProgramTest(t)
+	require.NoError(t, RunFaultProofProgram(t, log, miner, seqEngine, cfg), "should accept the correct claim")
+
+	corrupted := cfg.L2Claim
+	corrupted[0] ^= 0xff
+	cfg.L2Claim = corrupted
+	require.ErrorIs(t, RunFaultProofProgram(t, log, miner, seqEngine, cfg), driver.ErrClaimNotValid, "should reject a corrupted claim")
+}
+
+// TestFaultProofProgramOffline prefetches the pre-images of the fault proof program into a data directory,
+// and checks that the program then accepts the claim without fetching anything.
+func TestFaultProofProgramOffline(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	miner, seqEngine, cfg, log := setupFaultProofProgramTest(t)
+	cfg.DataDir = gt.TempDir()
+	cfg.PrefetchOnly = true
+	require.NoError(t, RunFaultProofProgram(t, log, miner, seqEngine, cfg), "should prefetch the pre-images")
+
+	cfg.PrefetchOnly = false
+	cfg.Offline = true
+	require.NoError(t, host.FaultProofProgram(t.Ctx(), log, cfg), "should accept the claim with the prefetched pre-images only")
+}
@@ -0,0 +1,113 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// gossipReceiver buffers the blocks received over gossip, for the verifier to process them in the test routine.
+type gossipReceiver chan *eth.ExecutionPayloadEnvelope
+
+func (r gossipReceiver) OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg *eth.ExecutionPayloadEnvelope) error {
+	r <- msg
+	return nil
+}
+
+// TestTinyMeshFloodPublish checks that the blocks of the sequencer reach all verifiers of a 3-node network with a
+// mesh of a single peer, as used on low-peer-count devnets: the peer outside of the mesh of the sequencer
+// receives the blocks because they are flood-published.
+func TestTinyMeshFloodPublish(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	// Gossiped blocks must be recent: start the chain now, so the blocks built by the test are accepted.
+	dp.DeployConfig.L1GenesisBlockTimestamp = hexutil.Uint64(time.Now().Unix())
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	logger := testlog.Logger(t, log.LvlInfo)
+
+	miner, seqEng, sequencer := setupSequencerTest(t, sd, logger)
+	verifiers := make([]*L2Verifier, 2)
+	for i := range verifiers {
+		_, verifiers[i] = setupVerifier(t, sd, logger, miner.L1Client(t, sd.RollupCfg), &sync.Config{})
+	}
+
+	conf := &p2p.Config{
+		MeshD:           1,
+		MeshDLo:         1,
+		MeshDHi:         1,
+		MeshDLazy:       1,
+		GossipHeartbeat: 100 * time.Millisecond,
+		FloodPublish:    true,
+	}
+	runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: crypto.PubkeyToAddress(dp.Secrets.SequencerP2P.PublicKey)}
+
+	mnet, err := mocknet.FullMeshConnected(1 + len(verifiers))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = mnet.Close()
+	})
+	hosts := mnet.Hosts()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	join := func(i int, gossipIn p2p.GossipIn) p2p.GossipOut {
+		ps, err := p2p.NewGossipSub(ctx, hosts[i], sd.RollupCfg, conf, nil, metrics.NoopMetrics, logger)
+		require.NoError(t, err)
+		out, err := p2p.JoinGossip(hosts[i].ID(), ps, logger.New("host", i), sd.RollupCfg, runCfg, gossipIn,
+			p2p.NoopViolationReporter{}, p2p.PublishRetryConfig{}, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = out.Close()
+		})
+		return out
+	}
+	seqOut := join(0, gossipReceiver(make(chan *eth.ExecutionPayloadEnvelope, 1)))
+	receivers := make([]gossipReceiver, len(verifiers))
+	for i := range verifiers {
+		receivers[i] = make(gossipReceiver, 10)
+		join(1+i, receivers[i])
+	}
+	require.Eventually(t, func() bool {
+		return len(seqOut.AllBlockTopicsPeers()) == len(verifiers)
+	}, 10*time.Second, 10*time.Millisecond, "sequencer must learn all verifier subscriptions")
+
+	sequencer.ActL2PipelineFull(t)
+	for _, verifier := range verifiers {
+		verifier.ActL2PipelineFull(t)
+	}
+
+	// Build a block, and publish it like the sequencer does
+	sequencer.ActL2StartBlock(t)
+	sequencer.ActL2EndBlock(t)
+	envelope, err := seqEng.EngineClient(t, sd.RollupCfg).PayloadByLabel(t.Ctx(), eth.Unsafe)
+	require.NoError(t, err)
+	signer := &p2p.PreparedSigner{Signer: p2p.NewLocalSigner(dp.Secrets.SequencerP2P)}
+	require.NoError(t, seqOut.PublishL2Payload(t.Ctx(), envelope, signer))
+
+	for i, verifier := range verifiers {
+		select {
+		case received := <-receivers[i]:
+			verifier.ActL2UnsafeGossipReceive(received)(t)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("verifier %d did not receive the block", i)
+		}
+		verifier.ActL2PipelineFull(t)
+		require.Equal(t, sequencer.L2Unsafe(), verifier.L2Unsafe(), "verifier %d must sync the gossiped block", i)
+	}
+}
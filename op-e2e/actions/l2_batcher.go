@@ -2,9 +2,11 @@ package actions
 
 import (
 	"bytes"
+	"compress/zlib"
 	"context"
 	"crypto/ecdsa"
 	"crypto/rand"
+	"errors"
 	"io"
 	"math/big"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/batcher"
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
@@ -47,8 +50,16 @@ type BatcherCfg struct {
 
 	ForceSubmitSingularBatch bool
 	ForceSubmitSpanBatch     bool
+
+	// SkipEmptyBlocks leaves out the blocks that the derivation pipeline regenerates by itself, like the
+	// op-batcher with the skip-empty-blocks flag. The channels are split at the skipped blocks.
+	SkipEmptyBlocks bool
 }
 
+// errSubmitBeforeSkip is returned by Buffer if the next block is skipped while a channel is open.
+// A span batch cannot span the skipped block, so the open channel has to be submitted first.
+var errSubmitBeforeSkip = errors.New("open channel must be submitted before skipping a block")
+
 type L2BlockRefs interface {
 	L2BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L2BlockRef, error)
 }
@@ -145,6 +156,20 @@ func (s *L2Batcher) Buffer(t Testing) error {
 		s.l2BufferedBlock = syncStatus.SafeL2
 		s.l2ChannelOut = nil
 	}
+	if s.l2BatcherCfg.SkipEmptyBlocks {
+		skip, err := batcher.EmptyBlockRegenerable(t.Ctx(), s.rollupCfg, s.l1, block)
+		require.NoError(t, err, "failed to check whether block is regenerable")
+		if skip {
+			if s.l2ChannelOut != nil {
+				return errSubmitBeforeSkip
+			}
+			s.log.Info("skipping empty block", "block", eth.ToBlockID(block))
+			ref, err := s.engCl.L2BlockRefByHash(t.Ctx(), block.Hash())
+			require.NoError(t, err, "failed to get L2BlockRef")
+			s.l2BufferedBlock = ref
+			return nil
+		}
+	}
 	// Create channel if we don't have one yet
 	if s.l2ChannelOut == nil {
 		var ch ChannelOutIface
@@ -155,6 +180,7 @@ func (s *L2Batcher) Buffer(t Testing) error {
 				TargetFrameSize:  s.l2BatcherCfg.MaxL1TxSize,
 				TargetNumFrames:  1,
 				ApproxComprRatio: 1,
+				Level:            zlib.BestCompression,
 			})
 			require.NoError(t, e, "failed to create compressor")
 
@@ -329,7 +355,11 @@ func (s *L2Batcher) ActBufferAll(t Testing) {
 	stat, err := s.syncStatusAPI.SyncStatus(t.Ctx())
 	require.NoError(t, err)
 	for s.l2BufferedBlock.Number < stat.UnsafeL2.Number {
-		s.ActL2BatchBuffer(t)
+		if err := s.Buffer(t); errors.Is(err, errSubmitBeforeSkip) {
+			break
+		} else {
+			require.NoError(t, err, "failed to add block to channel")
+		}
 	}
 }
 
@@ -118,7 +118,12 @@ func NormalBatcher(gt *testing.T, deltaTimeOffset *hexutil.Uint64) {
 	// sync verifier from L1 batch in otherwise empty sequence window
 	verifier.ActL1HeadSignal(t)
 	verifier.ActL2PipelineFull(t)
-	require.Equal(t, uint64(1), verifier.SyncStatus().SafeL2.L1Origin.Number)
+	status := verifier.SyncStatus()
+	require.Equal(t, uint64(1), status.SafeL2.L1Origin.Number)
+	require.Equal(t, status.HeadL1, status.CurrentL1, "derivation caught up with the L1 head")
+	require.Equal(t, status.SafeL2, status.PendingSafeL2, "pending safe head is fully consolidated")
+	require.Zero(t, status.FinalizedL2.Number, "nothing is finalized yet")
+	require.Zero(t, status.UnsafeL2SyncTarget, "no queued unsafe payloads without gossip")
 
 	// check that the tx from alice made it into the L2 chain
 	verifCl := verifEngine.EthClient()
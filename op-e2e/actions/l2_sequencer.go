@@ -7,12 +7,13 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 
-	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
 )
 
 // MockL1OriginSelector is a shim to override the origin as sequencer, so we can force it to stay on an older origin.
@@ -38,20 +39,25 @@ type L2Sequencer struct {
 	failL2GossipUnsafeBlock error // mock error
 
 	mockL1OriginSelector *MockL1OriginSelector
+
+	// sequencerMetrics can be hooked into by tests, to observe the sequencer metrics
+	sequencerMetrics *testutils.TestSequencerMetrics
 }
 
 func NewL2Sequencer(t Testing, log log.Logger, l1 derive.L1Fetcher, eng L2API, cfg *rollup.Config, seqConfDepth uint64) *L2Sequencer {
-	ver := NewL2Verifier(t, log, l1, eng, cfg, &sync.Config{})
+	ver := NewL2Verifier(t, log, l1, eng, cfg, &sync.Config{}, safedb.Disabled)
 	attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, eng)
 	seqConfDepthL1 := driver.NewConfDepth(seqConfDepth, ver.l1State.L1Head, l1)
 	l1OriginSelector := &MockL1OriginSelector{
 		actual: driver.NewL1OriginSelector(log, cfg, seqConfDepthL1),
 	}
+	seqMetrics := &testutils.TestSequencerMetrics{}
 	return &L2Sequencer{
 		L2Verifier:              *ver,
-		sequencer:               driver.NewSequencer(log, cfg, ver.derivation, attrBuilder, l1OriginSelector, metrics.NoopMetrics),
+		sequencer:               driver.NewSequencer(log, cfg, ver.derivation, attrBuilder, l1OriginSelector, driver.AlwaysAdmit, 0, seqMetrics),
 		mockL1OriginSelector:    l1OriginSelector,
 		failL2GossipUnsafeBlock: nil,
+		sequencerMetrics:        seqMetrics,
 	}
 }
 
@@ -159,7 +165,7 @@ func (s *L2Sequencer) ActBuildToL1HeadExclUnsafe(t Testing) {
 
 func (s *L2Sequencer) ActBuildL2ToRegolith(t Testing) {
 	require.NotNil(t, s.rollupCfg.RegolithTime, "cannot activate Regolith when it is not scheduled")
-	for s.L2Unsafe().Time < *s.rollupCfg.RegolithTime {
+	for !s.rollupCfg.IsRegolith(s.L2Unsafe().Time) {
 		s.ActL2StartBlock(t)
 		s.ActL2EndBlock(t)
 	}
@@ -3,7 +3,9 @@ package actions
 import (
 	"math/big"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
 
@@ -102,6 +104,104 @@ func TestL2Sequencer_SequencerDrift(gt *testing.T) {
 	require.True(t, engine.engineApi.ForcedEmpty(), "engine should not be allowed to include anything after sequencer drift is surpassed")
 }
 
+// TestL2Sequencer_SequencerDriftL1Outage tests that the sequencer produces blocks up to the max sequencer drift
+// while L1 is frozen, then produces deposit-only blocks once L1 resumes, until the L1 origin catches up again.
+func TestL2Sequencer_SequencerDriftL1Outage(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	p := &e2eutils.TestParams{
+		MaxSequencerDrift:   20, // larger than L1 block time we simulate in this test (12)
+		SequencerWindowSize: 24,
+		ChannelTimeout:      20,
+		L1BlockTime:         12,
+	}
+	dp := e2eutils.MakeDeployParams(t, p)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+	miner, engine, sequencer := setupSequencerTest(t, sd, log)
+	miner.ActL1SetFeeRecipient(common.Address{'A'})
+
+	var drift time.Duration
+	exceededBlocks := 0
+	sequencer.sequencerMetrics.FnRecordSequencerDrift = func(d time.Duration) {
+		drift = d
+	}
+	sequencer.sequencerMetrics.FnRecordSequencerDriftExceededBlock = func() {
+		exceededBlocks += 1
+	}
+
+	sequencer.ActL2PipelineFull(t)
+
+	signer := types.LatestSigner(sd.L2Cfg.Config)
+	cl := engine.EthClient()
+	makeL2BlockWithAliceTx := func() {
+		n, err := cl.PendingNonceAt(t.Ctx(), dp.Addresses.Alice)
+		require.NoError(t, err)
+		tx := types.MustSignNewTx(dp.Secrets.Alice, signer, &types.DynamicFeeTx{
+			ChainID:   sd.L2Cfg.Config.ChainID,
+			Nonce:     n,
+			GasTipCap: big.NewInt(2 * params.GWei),
+			GasFeeCap: new(big.Int).Add(miner.l1Chain.CurrentBlock().BaseFee, big.NewInt(2*params.GWei)),
+			Gas:       params.TxGas,
+			To:        &dp.Addresses.Bob,
+			Value:     e2eutils.Ether(2),
+		})
+		require.NoError(gt, cl.SendTransaction(t.Ctx(), tx))
+		sequencer.ActL2StartBlock(t)
+		require.False(t, engine.engineApi.ForcedEmpty(), "txs are allowed within the sequencer drift")
+		engine.ActL2IncludeTx(dp.Addresses.Alice)(t)
+		sequencer.ActL2EndBlock(t)
+	}
+
+	// L1 makes a block, and L2 adopts it as origin
+	miner.ActEmptyBlock(t)
+	sequencer.ActL1HeadSignal(t)
+	sequencer.ActBuildToL1HeadUnsafe(t)
+	origin := miner.l1Chain.CurrentBlock()
+	require.Equal(t, origin.Hash(), sequencer.SyncStatus().UnsafeL2.L1Origin.Hash)
+
+	// L1 is frozen: L2 keeps producing blocks with txs, up to the sequencer drift
+	maxDrift := time.Duration(sd.RollupCfg.MaxSequencerDrift) * time.Second
+	for sequencer.SyncStatus().UnsafeL2.Time+sd.RollupCfg.BlockTime <= origin.Time+sd.RollupCfg.MaxSequencerDrift {
+		makeL2BlockWithAliceTx()
+		require.Equal(t, origin.Hash(), sequencer.SyncStatus().UnsafeL2.L1Origin.Hash, "expected to keep the only L1 origin")
+		require.LessOrEqual(t, drift, maxDrift)
+	}
+	require.Equal(t, maxDrift, drift, "sequencer hit the drift bound")
+	require.Zero(t, exceededBlocks)
+
+	// The next block is past the drift, and cannot be produced without knowing the next L1 origin
+	head := sequencer.SyncStatus().UnsafeL2
+	sequencer.ActL2StartBlockCheckErr(t, ethereum.NotFound)
+	require.Equal(t, head, sequencer.SyncStatus().UnsafeL2, "no block past the sequencer drift while L1 is frozen")
+
+	// L1 resumes, with a block timestamp well after the L2 head.
+	const outage = 48
+	miner.ActL1StartBlock(outage)(t)
+	miner.ActL1EndBlock(t)
+	sequencer.ActL1HeadSignal(t)
+	nextOrigin := miner.l1Chain.CurrentBlock()
+
+	// The sequencer keeps the old origin until the L2 time catches up with the next origin,
+	// and only produces deposit-only blocks meanwhile.
+	expectedExceeded := 0
+	for sequencer.SyncStatus().UnsafeL2.Time+sd.RollupCfg.BlockTime < nextOrigin.Time {
+		sequencer.ActL2StartBlock(t)
+		require.True(t, engine.engineApi.ForcedEmpty(), "engine should not be allowed to include anything after sequencer drift is surpassed")
+		sequencer.ActL2EndBlock(t)
+		expectedExceeded += 1
+		require.Equal(t, origin.Hash(), sequencer.SyncStatus().UnsafeL2.L1Origin.Hash, "next origin is ahead of the L2 time")
+		require.Greater(t, drift, maxDrift)
+	}
+	require.Equal(t, expectedExceeded, exceededBlocks)
+	require.NotZero(t, exceededBlocks)
+
+	// The sequencer adopts the next origin as soon as it can, and recovers from the exceeded drift
+	makeL2BlockWithAliceTx()
+	require.Equal(t, nextOrigin.Hash(), sequencer.SyncStatus().UnsafeL2.L1Origin.Hash, "adopted the next L1 origin")
+	require.Zero(t, drift)
+	require.Equal(t, expectedExceeded, exceededBlocks, "recovered from the exceeded drift")
+}
+
 // TestL2Sequencer_SequencerOnlyReorg regression-tests a Goerli halt where the sequencer
 // would build an unsafe L2 block with a L1 origin that then gets reorged out,
 // while the verifier-codepath only ever sees the valid post-reorg L1 chain.
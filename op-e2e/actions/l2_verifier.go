@@ -38,6 +38,9 @@ type L2Verifier struct {
 	l1      derive.L1Fetcher
 	l1State *driver.L1State
 
+	// metrics of the derivation pipeline, tests may hook into them
+	metrics *testutils.TestDerivationMetrics
+
 	l2PipelineIdle bool
 	l2Building     bool
 
@@ -55,11 +58,17 @@ type L2API interface {
 	// GetProof returns a proof of the account, it may return a nil result without error if the address was not found.
 	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
 	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
+	OutputV0WithProofAtBlock(ctx context.Context, blockHash common.Hash, storageKeys []common.Hash) (*eth.OutputV0, *eth.AccountResult, error)
+}
+
+type safeDB interface {
+	derive.SafeHeadListener
+	node.SafeDBReader
 }
 
-func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, eng L2API, cfg *rollup.Config, syncCfg *sync.Config) *L2Verifier {
+func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, eng L2API, cfg *rollup.Config, syncCfg *sync.Config, safeHeadListener safeDB) *L2Verifier {
 	metrics := &testutils.TestDerivationMetrics{}
-	pipeline := derive.NewDerivationPipeline(log, cfg, l1, eng, metrics, syncCfg)
+	pipeline := derive.NewDerivationPipeline(log, cfg, l1, nil, eng, metrics, syncCfg, safeHeadListener)
 	pipeline.Reset()
 
 	rollupNode := &L2Verifier{
@@ -68,6 +77,7 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, eng L2API, cf
 		derivation:     pipeline,
 		l1:             l1,
 		l1State:        driver.NewL1State(log, metrics),
+		metrics:        metrics,
 		l2PipelineIdle: true,
 		l2Building:     false,
 		rollupCfg:      cfg,
@@ -81,7 +91,7 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, eng L2API, cf
 	apis := []rpc.API{
 		{
 			Namespace:     "optimism",
-			Service:       node.NewNodeAPI(cfg, eng, backend, log, m),
+			Service:       node.NewNodeAPI(cfg, eng, backend, safeHeadListener, &testutils.MockRuntimeConfig{}, log, m),
 			Public:        true,
 			Authenticated: false,
 		},
@@ -99,6 +109,9 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, eng L2API, cf
 
 type l2VerifierBackend struct {
 	verifier *L2Verifier
+
+	// syncStatusFeed is never notified: action tests step the verifier and poll the sync status instead.
+	syncStatusFeed driver.SyncStatusFeed
 }
 
 func (s *l2VerifierBackend) BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2BlockRef, *eth.SyncStatus, error) {
@@ -127,6 +140,18 @@ func (s *l2VerifierBackend) SequencerActive(ctx context.Context) (bool, error) {
 	return false, nil
 }
 
+func (s *l2VerifierBackend) SequencerStatus(ctx context.Context) (*driver.SequencerStatus, error) {
+	return &driver.SequencerStatus{}, nil
+}
+
+func (s *l2VerifierBackend) SubscribeSyncStatus() *driver.SyncStatusSubscription {
+	return s.syncStatusFeed.Subscribe()
+}
+
+func (s *l2VerifierBackend) StateSnapshot(ctx context.Context) (*driver.StateSnapshot, error) {
+	return nil, errors.New("state snapshots of the L2Verifier are not supported")
+}
+
 func (s *L2Verifier) L2Finalized() eth.L2BlockRef {
 	return s.derivation.Finalized()
 }
@@ -246,7 +271,7 @@ func (s *L2Verifier) ActL2PipelineFull(t Testing) {
 }
 
 // ActL2UnsafeGossipReceive creates an action that can receive an unsafe execution payload, like gossipsub
-func (s *L2Verifier) ActL2UnsafeGossipReceive(payload *eth.ExecutionPayload) Action {
+func (s *L2Verifier) ActL2UnsafeGossipReceive(payload *eth.ExecutionPayloadEnvelope) Action {
 	return func(t Testing) {
 		s.derivation.AddUnsafePayload(payload)
 	}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts"
@@ -709,3 +710,96 @@ func TestBatchEquivalence(gt *testing.T) {
 	// Delta deactivated spanVerifier must be synced
 	require.Equal(t, spanVerifier.L2Safe(), singularVerifier.L2Safe())
 }
+
+// TestSpanBatchSkipEmptyBlocks tests that the verifier derives the same chain as the sequencer if the batcher
+// skips the empty blocks that the derivation pipeline regenerates, once their sequencing window expired.
+func TestSpanBatchSkipEmptyBlocks(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	p := &e2eutils.TestParams{
+		MaxSequencerDrift:   20,
+		SequencerWindowSize: 24,
+		ChannelTimeout:      20,
+		L1BlockTime:         12,
+	}
+	dp := e2eutils.MakeDeployParams(t, p)
+	minTs := hexutil.Uint64(0)
+	// Activate Delta hardfork
+	dp.DeployConfig.L2GenesisDeltaTimeOffset = &minTs
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlError)
+	miner, seqEngine, sequencer := setupSequencerTest(t, sd, log)
+	verifEngine, verifier := setupVerifier(t, sd, log, miner.L1Client(t, sd.RollupCfg), &sync.Config{})
+
+	rollupSeqCl := sequencer.RollupClient()
+	batcher := NewL2Batcher(log, sd.RollupCfg, &BatcherCfg{
+		MinL1TxSize:     0,
+		MaxL1TxSize:     128_000,
+		BatcherKey:      dp.Secrets.Batcher,
+		SkipEmptyBlocks: true,
+	}, rollupSeqCl, miner.EthClient(), seqEngine.EthClient(), seqEngine.EngineClient(t, sd.RollupCfg))
+
+	sequencer.ActL2PipelineFull(t)
+	verifier.ActL2PipelineFull(t)
+
+	// the blocks of an epoch are only skipped once the next L1 origin is known
+	miner.ActEmptyBlock(t)
+	sequencer.ActL1HeadSignal(t)
+
+	cl := seqEngine.EthClient()
+	signer := types.LatestSigner(sd.L2Cfg.Config)
+	// Blocks 1 to 5 are of epoch 0 and older than L1 block 1, block 6 is the first block of epoch 1.
+	// Only the blocks with a tx of Alice are submitted, the batcher skips blocks 2, 3, 5 and 6.
+	txBlocks := map[uint64]bool{1: true, 4: true, 7: true}
+	seqBlocks := make(map[uint64]eth.L2BlockRef)
+	for i := uint64(1); i <= 7; i++ {
+		sequencer.ActL2StartBlock(t)
+		if txBlocks[i] {
+			n, err := cl.PendingNonceAt(t.Ctx(), dp.Addresses.Alice)
+			require.NoError(t, err)
+			tx := types.MustSignNewTx(dp.Secrets.Alice, signer, &types.DynamicFeeTx{
+				ChainID:   sd.L2Cfg.Config.ChainID,
+				Nonce:     n,
+				GasTipCap: big.NewInt(2 * params.GWei),
+				GasFeeCap: new(big.Int).Add(miner.l1Chain.CurrentBlock().BaseFee, big.NewInt(2*params.GWei)),
+				Gas:       params.TxGas,
+				To:        &dp.Addresses.Bob,
+				Value:     e2eutils.Ether(1),
+			})
+			require.NoError(t, cl.SendTransaction(t.Ctx(), tx))
+			seqEngine.ActL2IncludeTx(dp.Addresses.Alice)(t)
+		}
+		sequencer.ActL2EndBlock(t)
+		seqBlocks[i] = sequencer.L2Unsafe()
+	}
+	require.Equal(t, uint64(1), seqBlocks[6].L1Origin.Number)
+	require.Zero(t, seqBlocks[6].SequenceNumber)
+
+	// the batcher splits the blocks into a channel per range of submitted blocks: [1], [4] and [7]
+	for i := 0; i < 3; i++ {
+		batcher.ActSubmitAll(t)
+		miner.ActL1StartBlock(12)(t)
+		miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+		miner.ActL1EndBlock(t)
+	}
+	require.Equal(t, seqBlocks[7], batcher.l2BufferedBlock)
+
+	// the safe head stalls at the first skipped block while its sequencing window did not expire
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, seqBlocks[1], verifier.L2Safe())
+
+	for i := uint64(0); i < sd.RollupCfg.SeqWindowSize; i++ {
+		miner.ActEmptyBlock(t)
+	}
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+
+	// the verifier regenerates the skipped blocks exactly, and derives the submitted blocks on top of them
+	require.Greater(t, verifier.L2Safe().Number, uint64(7))
+	verifCl := verifEngine.EthClient()
+	for i := uint64(1); i <= 7; i++ {
+		block, err := verifCl.BlockByNumber(t.Ctx(), new(big.Int).SetUint64(i))
+		require.NoError(t, err)
+		require.Equal(t, seqBlocks[i].Hash, block.Hash(), "block %d", i)
+	}
+}
@@ -1,13 +1,19 @@
 package actions
 
 import (
+	"compress/zlib"
+	"context"
 	"errors"
 	"math/big"
 	"math/rand"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -15,6 +21,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -163,6 +173,149 @@ func TestUnsafeSync(gt *testing.T) {
 	}
 }
 
+// TestUnsafeSyncGap tests that a verifier heals a gap in the unsafe chain,
+// by fetching the missing blocks from an alt-sync source: the L2 RPC of the sequencer.
+func TestUnsafeSyncGap(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlInfo)
+
+	sd, _, _, sequencer, seqEng, verifier, _, _ := setupReorgTestActors(t, dp, sd, log)
+	seqEngCl, err := sources.NewEngineClient(seqEng.RPCClient(), log, nil, sources.EngineClientDefaultConfig(sd.RollupCfg))
+	require.NoError(t, err)
+
+	// The sync client delivers payloads from its own routine: buffer them for the verifier to process.
+	synced := make(chan *eth.ExecutionPayloadEnvelope, 10)
+	receiver := func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayloadEnvelope) error {
+		synced <- payload
+		return nil
+	}
+	syncCl, err := sources.NewSyncClient(receiver, seqEng.RPCClient(), log, nil, metrics.NoopMetrics, sources.SyncClientDefaultConfig(sd.RollupCfg, false))
+	require.NoError(t, err)
+	require.NoError(t, syncCl.Start())
+	t.Cleanup(func() {
+		_ = syncCl.Close()
+	})
+
+	sequencer.ActL2PipelineFull(t)
+	verifier.ActL2PipelineFull(t)
+	verifierStart := verifier.L2Unsafe()
+
+	// Build L2 blocks that are not gossiped to the verifier, creating a gap.
+	for i := 0; i < 3; i++ {
+		sequencer.ActL2StartBlock(t)
+		sequencer.ActL2EndBlock(t)
+	}
+	// Gossip the next blocks: the verifier queues them, but cannot process them.
+	for i := 0; i < 3; i++ {
+		sequencer.ActL2StartBlock(t)
+		sequencer.ActL2EndBlock(t)
+		seqHead, err := seqEngCl.PayloadByLabel(t.Ctx(), eth.Unsafe)
+		require.NoError(t, err)
+		verifier.ActL2UnsafeGossipReceive(seqHead)(t)
+		verifier.ActL2PipelineFull(t)
+	}
+	require.Equal(t, verifierStart, verifier.L2Unsafe(), "verifier is stuck on the gap")
+	target := verifier.SyncStatus().UnsafeL2SyncTarget
+	require.Equal(t, verifierStart.Number+4, target.Number, "first queued block is after the gap")
+
+	// Request the gap, like the driver does when it detects one
+	require.NoError(t, syncCl.RequestL2Range(t.Ctx(), verifier.L2Unsafe(), target))
+	for i := 0; i < 3; i++ {
+		select {
+		case payload := <-synced:
+			verifier.ActL2UnsafeGossipReceive(payload)(t)
+		case <-t.Ctx().Done():
+			t.Fatalf("missing synced block: %v", t.Ctx().Err())
+		}
+	}
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, sequencer.L2Unsafe(), verifier.L2Unsafe(), "verifier healed the gap")
+}
+
+// TestUnsafeSyncGapP2P tests that a verifier heals a 10-block gap in the unsafe chain,
+// by fetching the missing blocks from the sequencer with the p2p payloads_by_range protocol.
+func TestUnsafeSyncGapP2P(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	// The server does not serve blocks ahead of the wall-clock: start the chain in the past,
+	// so the blocks built by the test are not in the future.
+	dp.DeployConfig.L1GenesisBlockTimestamp = hexutil.Uint64(time.Now().Add(-time.Hour).Unix())
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	logger := testlog.Logger(t, log.LvlInfo)
+
+	sd, _, _, sequencer, seqEng, verifier, _, _ := setupReorgTestActors(t, dp, sd, logger)
+	seqEngCl, err := sources.NewEngineClient(seqEng.RPCClient(), logger, nil, sources.EngineClientDefaultConfig(sd.RollupCfg))
+	require.NoError(t, err)
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = mnet.Close()
+	})
+	hosts := mnet.Hosts()
+	seqHost, verifierHost := hosts[0], hosts[1]
+
+	// The sequencer serves its unsafe chain, counting the range requests
+	srv := p2p.NewReqRespServer(sd.RollupCfg, seqEngCl, metrics.NoopMetrics)
+	var rangeRequests atomic.Int32
+	payloadsByRange := p2p.MakeStreamHandler(t.Ctx(), logger.New("serve", "payloads_by_range"), func(ctx context.Context, log log.Logger, stream network.Stream) {
+		rangeRequests.Add(1)
+		srv.HandleSyncRangeRequest(ctx, log, stream)
+	})
+	seqHost.SetStreamHandler(p2p.PayloadsByRangeProtocolID(sd.RollupCfg.L2ChainID), payloadsByRange)
+	seqHost.SetStreamHandler(p2p.PayloadByNumberProtocolID(sd.RollupCfg.L2ChainID),
+		p2p.MakeStreamHandler(t.Ctx(), logger.New("serve", "payloads_by_number"), srv.HandleSyncRequest))
+
+	// The sync client delivers payloads from its own routine: buffer them for the verifier to process.
+	synced := make(chan *eth.ExecutionPayloadEnvelope, 10)
+	receiver := func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayloadEnvelope) error {
+		synced <- payload
+		return nil
+	}
+	syncCl := p2p.NewSyncClient(logger, sd.RollupCfg, verifierHost.NewStream, receiver, metrics.NoopMetrics, &p2p.NoopApplicationScorer{}, p2p.NoopViolationReporter{})
+	syncCl.AddPeer(seqHost.ID())
+	syncCl.Start()
+	t.Cleanup(func() {
+		_ = syncCl.Close()
+	})
+
+	sequencer.ActL2PipelineFull(t)
+	verifier.ActL2PipelineFull(t)
+	verifierStart := verifier.L2Unsafe()
+
+	// Build 10 L2 blocks that are not gossiped to the verifier, creating a gap.
+	for i := 0; i < 10; i++ {
+		sequencer.ActL2StartBlock(t)
+		sequencer.ActL2EndBlock(t)
+	}
+	// Gossip the next block: the verifier queues it, but cannot process it.
+	sequencer.ActL2StartBlock(t)
+	sequencer.ActL2EndBlock(t)
+	seqHead, err := seqEngCl.PayloadByLabel(t.Ctx(), eth.Unsafe)
+	require.NoError(t, err)
+	verifier.ActL2UnsafeGossipReceive(seqHead)(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, verifierStart, verifier.L2Unsafe(), "verifier is stuck on the gap")
+	target := verifier.SyncStatus().UnsafeL2SyncTarget
+	require.Equal(t, verifierStart.Number+11, target.Number, "queued block is after the gap")
+
+	// Request the gap, like the driver does when it detects one, and queue the synced blocks in the engine queue
+	require.NoError(t, syncCl.RequestL2Range(t.Ctx(), verifier.L2Unsafe(), target))
+	for i := 0; i < 10; i++ {
+		select {
+		case payload := <-synced:
+			verifier.ActL2UnsafeGossipReceive(payload)(t)
+		case <-t.Ctx().Done():
+			t.Fatalf("missing synced block: %v", t.Ctx().Err())
+		}
+	}
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, sequencer.L2Unsafe(), verifier.L2Unsafe(), "verifier healed the gap")
+	require.NotZero(t, rangeRequests.Load(), "gap is synced with range requests")
+}
+
 func TestEngineP2PSync(gt *testing.T) {
 	t := NewDefaultTesting(gt)
 	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
@@ -224,6 +377,7 @@ func TestInvalidPayloadInSpanBatch(gt *testing.T) {
 		TargetFrameSize:  128_000,
 		TargetNumFrames:  1,
 		ApproxComprRatio: 1,
+		Level:            zlib.BestCompression,
 	})
 	require.NoError(t, e)
 	spanBatchBuilder := derive.NewSpanBatchBuilder(sd.RollupCfg.Genesis.L2Time, sd.RollupCfg.L2ChainID)
@@ -273,6 +427,7 @@ func TestInvalidPayloadInSpanBatch(gt *testing.T) {
 		TargetFrameSize:  128_000,
 		TargetNumFrames:  1,
 		ApproxComprRatio: 1,
+		Level:            zlib.BestCompression,
 	})
 	require.NoError(t, e)
 	spanBatchBuilder = derive.NewSpanBatchBuilder(sd.RollupCfg.Genesis.L2Time, sd.RollupCfg.L2ChainID)
@@ -431,3 +586,87 @@ func TestSpanBatchAtomicity_ForceAdvance(gt *testing.T) {
 		require.Equal(t, verifier.L2Unsafe(), verifier.L2PendingSafe())
 	}
 }
+
+// TestUnsafeReorgByConsolidation tests that the verifier reports the reorg of its unsafe chain,
+// when an unsafe block is never batched, and a conflicting block is batched instead.
+func TestUnsafeReorgByConsolidation(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlInfo)
+	_, _, miner, sequencer, seqEng, verifier, _, batcher := setupReorgTestActors(t, dp, sd, log)
+	l2Cl := seqEng.EthClient()
+	seqEngCl, err := sources.NewEngineClient(seqEng.RPCClient(), log, nil, sources.EngineClientDefaultConfig(sd.RollupCfg))
+	require.NoError(t, err)
+	signer := types.LatestSigner(sd.L2Cfg.Config)
+
+	sequencer.ActL2PipelineFull(t)
+	verifier.ActL2PipelineFull(t)
+
+	// Create the unsafe blocks A1 ~ A6, and gossip them to the verifier
+	miner.ActEmptyBlock(t)
+	sequencer.ActL1HeadSignal(t)
+	sequencer.ActBuildToL1HeadUnsafe(t)
+	unsafeHead := sequencer.L2Unsafe()
+	for i := uint64(1); i <= unsafeHead.Number; i++ {
+		payload, err := seqEngCl.PayloadByNumber(t.Ctx(), i)
+		require.NoError(t, err)
+		verifier.ActL2UnsafeGossipReceive(payload)(t)
+	}
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, unsafeHead, verifier.L2Unsafe())
+
+	c, err := compressor.NewRatioCompressor(compressor.Config{
+		TargetFrameSize:  128_000,
+		TargetNumFrames:  1,
+		ApproxComprRatio: 1,
+		Level:            zlib.BestCompression,
+	})
+	require.NoError(t, err)
+	channelOut, err := derive.NewChannelOut(derive.SingularBatchType, c, nil)
+	require.NoError(t, err)
+
+	// Batch A1 ~ A3, and B4 instead of A4: B4 has the same parent, but includes an extra transaction.
+	// A4 ~ A6 are never batched.
+	conflictNum := uint64(4)
+	for i := uint64(1); i <= conflictNum; i++ {
+		block, err := l2Cl.BlockByNumber(t.Ctx(), new(big.Int).SetUint64(i))
+		require.NoError(t, err)
+		if i == conflictNum {
+			tx := types.MustSignNewTx(dp.Secrets.Alice, signer, &types.DynamicFeeTx{
+				ChainID:   sd.L2Cfg.Config.ChainID,
+				Nonce:     0,
+				GasTipCap: big.NewInt(2 * params.GWei),
+				GasFeeCap: new(big.Int).Add(block.BaseFee(), big.NewInt(2*params.GWei)),
+				Gas:       params.TxGas,
+				To:        &dp.Addresses.Bob,
+				Value:     e2eutils.Ether(1),
+			})
+			block = block.WithBody([]*types.Transaction{block.Transactions()[0], tx}, nil)
+		}
+		_, err = channelOut.AddBlock(block)
+		require.NoError(t, err)
+	}
+	batcher.l2ChannelOut = channelOut
+	batcher.ActL2ChannelClose(t)
+	batcher.ActL2BatchSubmit(t)
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+	miner.ActL1EndBlock(t)
+
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+
+	replaced, err := l2Cl.BlockByNumber(t.Ctx(), new(big.Int).SetUint64(conflictNum))
+	require.NoError(t, err)
+	safe := verifier.L2Safe()
+	require.Equal(t, conflictNum, safe.Number)
+	require.NotEqual(t, replaced.Hash(), safe.Hash, "conflicting block must be safe")
+	require.Equal(t, safe, verifier.L2Unsafe(), "unsafe chain must be reorged")
+
+	reorgs := verifier.derivation.Progress().UnsafeReorgs
+	require.Len(t, reorgs, 1)
+	require.Equal(t, eth.BlockID{Hash: replaced.Hash(), Number: conflictNum}, reorgs[0].Replaced)
+	require.Equal(t, safe, reorgs[0].Safe)
+	require.Equal(t, unsafeHead.Number-(conflictNum-1), reorgs[0].Depth, "A4 ~ A6 must be reorged")
+}
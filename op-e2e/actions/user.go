@@ -425,20 +425,17 @@ func (s *CrossLayerUser) ProveWithdrawal(t Testing, l2TxHash common.Hash) common
 	params, err := withdrawals.ProveWithdrawalParameters(t.Ctx(), s.L2.env.Bindings.ProofClient, s.L2.env.EthCl, s.lastL2WithdrawalTxHash, header, &s.L1.env.Bindings.L2OutputOracle.L2OutputOracleCaller)
 	require.NoError(t, err)
 
+	params.L2OutputIndex = l2OutputIndex
+	args, err := params.ProveArgs()
+	require.NoError(t, err)
+
 	// Create the prove tx
 	tx, err := s.L1.env.Bindings.OptimismPortal.ProveWithdrawalTransaction(
 		&s.L1.txOpts,
-		bindings.TypesWithdrawalTransaction{
-			Nonce:    params.Nonce,
-			Sender:   params.Sender,
-			Target:   params.Target,
-			Value:    params.Value,
-			GasLimit: params.GasLimit,
-			Data:     params.Data,
-		},
-		l2OutputIndex,
-		params.OutputRootProof,
-		params.WithdrawalProof,
+		args.Tx,
+		args.L2OutputIndex,
+		args.OutputRootProof,
+		args.WithdrawalProof,
 	)
 	require.NoError(t, err)
 
@@ -499,14 +496,7 @@ func (s *CrossLayerUser) CompleteWithdrawal(t Testing, l2TxHash common.Hash) com
 	// Create the withdrawal tx
 	tx, err := s.L1.env.Bindings.OptimismPortal.FinalizeWithdrawalTransaction(
 		&s.L1.txOpts,
-		bindings.TypesWithdrawalTransaction{
-			Nonce:    params.Nonce,
-			Sender:   params.Sender,
-			Target:   params.Target,
-			Value:    params.Value,
-			GasLimit: params.GasLimit,
-			Data:     params.Data,
-		},
+		params.Withdrawal().WithdrawalTransaction(),
 	)
 	require.NoError(t, err)
 
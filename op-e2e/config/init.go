@@ -43,7 +43,7 @@ var (
 )
 
 func init() {
-	var l1AllocsPath, l1DeploymentsPath, deployConfigPath, externalL2 string
+	var l1AllocsPath, l1DeploymentsPath, deployConfigPath, deployConfigOverlayPath, externalL2 string
 
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -61,6 +61,7 @@ func init() {
 	flag.StringVar(&l1AllocsPath, "l1-allocs", defaultL1AllocsPath, "")
 	flag.StringVar(&l1DeploymentsPath, "l1-deployments", defaultL1DeploymentsPath, "")
 	flag.StringVar(&deployConfigPath, "deploy-config", defaultDeployConfigPath, "")
+	flag.StringVar(&deployConfigOverlayPath, "deploy-config-overlay", "", "Deploy config that overrides the fields of the deploy config")
 	flag.StringVar(&externalL2, "externalL2", "", "Enable tests with external L2")
 	flag.IntVar(&EthNodeVerbosity, "ethLogVerbosity", int(log.LvlInfo), "The level of verbosity to use for the eth node logs")
 	testing.Init() // Register test flags before parsing
@@ -95,7 +96,9 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
-	DeployConfig, err = genesis.NewDeployConfig(deployConfigPath)
+	// The deploy config is layered, so the fields can be overridden with
+	// an overlay file and DEPLOY_CONFIG_<FIELD> environment variables.
+	DeployConfig, _, err = genesis.LoadDeployConfig(deployConfigPath, deployConfigOverlayPath, os.Environ())
 	if err != nil {
 		panic(err)
 	}
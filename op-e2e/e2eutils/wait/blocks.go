@@ -61,10 +61,34 @@ func ForNextBlock(ctx context.Context, client BlockCaller) error {
 }
 
 func ForProcessingFullBatch(ctx context.Context, rollupCl *sources.RollupClient) error {
-	_, err := AndGet(ctx, time.Second, func() (*eth.SyncStatus, error) {
-		return rollupCl.SyncStatus(ctx)
-	}, func(syncStatus *eth.SyncStatus) bool {
+	_, err := ForSyncStatus(ctx, rollupCl, func(syncStatus *eth.SyncStatus) bool {
 		return syncStatus.PendingSafeL2 == syncStatus.SafeL2
 	})
 	return err
 }
+
+// ForSyncStatus waits until the sync status of the rollup node satisfies the condition, and returns that status.
+// It subscribes to the sync status if the rollup client supports subscriptions, and falls back to polling otherwise.
+func ForSyncStatus(ctx context.Context, rollupCl *sources.RollupClient, cond func(*eth.SyncStatus) bool) (*eth.SyncStatus, error) {
+	ch := make(chan *eth.SyncStatus, 1)
+	sub, err := rollupCl.SubscribeSyncStatus(ctx, ch)
+	if err != nil {
+		// e.g. subscriptions are not supported over HTTP
+		return AndGet(ctx, time.Second, func() (*eth.SyncStatus, error) {
+			return rollupCl.SyncStatus(ctx)
+		}, cond)
+	}
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case syncStatus := <-ch:
+			if cond(syncStatus) {
+				return syncStatus, nil
+			}
+		case err := <-sub.Err():
+			return nil, fmt.Errorf("sync status subscription failed: %w", err)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
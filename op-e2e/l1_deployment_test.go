@@ -0,0 +1,81 @@
+package op_e2e
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+)
+
+// TestCheckL1Deployment checks the proxy admins, the ownership, the references and the code of the developer deployment.
+func TestCheckL1Deployment(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	sys, err := cfg.Start(t)
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	// the developer deployment transfers the ownership of the ProxyAdmin to the SystemOwnerSafe
+	owner := cfg.L1Deployments.SystemOwnerSafe
+	require.NotEqual(t, common.Address{}, owner)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	discrepancies, err := genesis.CheckL1Deployment(ctx, sys.Clients["l1"], cfg.L1Deployments, owner)
+	require.NoError(t, err)
+	require.Empty(t, discrepancies)
+
+	discrepancies, err = genesis.CheckL1DeploymentCode(ctx, sys.Clients["l1"], cfg.L1Deployments)
+	require.NoError(t, err)
+	require.Empty(t, discrepancies)
+}
+
+// TestVerifyL1Deployment verifies the code of the developer deployment, read from a deployment-addresses JSON,
+// against the deployed bytecode of the bindings.
+func TestVerifyL1Deployment(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	sys, err := cfg.Start(t)
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	data, err := json.Marshal(cfg.L1Deployments)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "addresses.json")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	addresses, err := bindings.ReadDeploymentAddresses(path)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	results, err := bindings.VerifyDeployment(ctx, sys.Clients["l1"], addresses)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	verified := make(map[string]*bindings.VerifyResult)
+	for _, result := range results {
+		require.True(t, result.Match, result.String())
+		verified[result.Name] = result
+	}
+	require.Equal(t, "Proxy", verified["OptimismPortalProxy"].Contract)
+
+	// the immutables of the L2OutputOracle are read from its code
+	oracle := verified["L2OutputOracle"]
+	require.NotNil(t, oracle)
+	var values []common.Hash
+	for _, immutable := range oracle.Immutables {
+		values = append(values, immutable.Value)
+	}
+	require.Contains(t, values, common.BigToHash(new(big.Int).SetUint64(cfg.DeployConfig.L2BlockTime)))
+	require.Contains(t, values, common.BigToHash(new(big.Int).SetUint64(cfg.DeployConfig.L2OutputOracleSubmissionInterval)))
+}
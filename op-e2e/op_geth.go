@@ -18,6 +18,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -57,7 +58,7 @@ func NewOpGeth(t *testing.T, ctx context.Context, cfg *SystemConfig) (*OpGeth, e
 	require.Nil(t, err)
 	l1Block := l1Genesis.ToBlock()
 
-	l2Genesis, err := genesis.BuildL2Genesis(cfg.DeployConfig, l1Block)
+	l2Genesis, err := genesis.BuildL2Genesis(cfg.DeployConfig, l1Block.Header())
 	require.Nil(t, err)
 	l2GenesisBlock := l2Genesis.ToBlock()
 
@@ -106,7 +107,7 @@ func NewOpGeth(t *testing.T, ctx context.Context, cfg *SystemConfig) (*OpGeth, e
 	l2Client, err := ethclient.Dial(selectEndpoint(node))
 	require.Nil(t, err)
 
-	genesisPayload, err := eth.BlockAsPayload(l2GenesisBlock, cfg.DeployConfig.CanyonTime(l2GenesisBlock.Time()))
+	genesisPayload, err := eth.BlockAsPayloadEnv(l2GenesisBlock, cfg.DeployConfig.CanyonTime(l2GenesisBlock.Time()))
 
 	require.Nil(t, err)
 	return &OpGeth{
@@ -117,7 +118,7 @@ func NewOpGeth(t *testing.T, ctx context.Context, cfg *SystemConfig) (*OpGeth, e
 		L1ChainConfig: l1Genesis.Config,
 		L2ChainConfig: l2Genesis.Config,
 		L1Head:        eth.BlockToInfo(l1Block),
-		L2Head:        genesisPayload,
+		L2Head:        genesisPayload.ExecutionPayload,
 		lgr:           logger,
 	}, nil
 }
@@ -142,15 +143,16 @@ func (d *OpGeth) AddL2Block(ctx context.Context, txs ...*types.Transaction) (*et
 		return nil, err
 	}
 
-	payload, err := d.l2Engine.GetPayload(ctx, *res.PayloadID)
+	envelope, err := d.l2Engine.GetPayload(ctx, eth.PayloadInfo{ID: *res.PayloadID, ParentBeaconBlockRoot: attrs.ParentBeaconBlockRoot})
 	if err != nil {
 		return nil, err
 	}
+	payload := envelope.ExecutionPayload
 	if !reflect.DeepEqual(payload.Transactions, attrs.Transactions) {
 		return nil, errors.New("required transactions were not included")
 	}
 
-	status, err := d.l2Engine.NewPayload(ctx, payload)
+	status, err := d.l2Engine.NewPayload(ctx, payload, envelope.ParentBeaconBlockRoot)
 	if err != nil {
 		return nil, err
 	}
@@ -204,27 +206,32 @@ func (d *OpGeth) CreatePayloadAttributes(txs ...*types.Transaction) (*eth.Payloa
 		return nil, err
 	}
 
-	var txBytes []hexutil.Bytes
-	txBytes = append(txBytes, l1Info)
-	for _, tx := range txs {
-		bin, err := tx.MarshalBinary()
-		if err != nil {
-			return nil, fmt.Errorf("tx marshalling failed: %w", err)
-		}
-		txBytes = append(txBytes, bin)
+	opaqueTxs, err := eth.EncodeTransactions(txs)
+	if err != nil {
+		return nil, fmt.Errorf("tx marshalling failed: %w", err)
 	}
+	txBytes := append([]hexutil.Bytes{l1Info}, opaqueTxs...)
 
 	var withdrawals *types.Withdrawals
 	if d.L2ChainConfig.IsCanyon(uint64(timestamp)) {
 		withdrawals = &types.Withdrawals{}
 	}
 
+	var parentBeaconRoot *common.Hash
+	if d.L2ChainConfig.IsCancun(new(big.Int).SetUint64(uint64(d.L2Head.BlockNumber)+1), uint64(timestamp)) {
+		parentBeaconRoot = d.L1Head.ParentBeaconRoot()
+		if parentBeaconRoot == nil { // pre-Dencun L1 origins use the zero hash
+			parentBeaconRoot = new(common.Hash)
+		}
+	}
+
 	attrs := eth.PayloadAttributes{
-		Timestamp:    timestamp,
-		Transactions: txBytes,
-		NoTxPool:     true,
-		GasLimit:     (*eth.Uint64Quantity)(&d.SystemConfig.GasLimit),
-		Withdrawals:  withdrawals,
+		Timestamp:             timestamp,
+		Transactions:          txBytes,
+		NoTxPool:              true,
+		GasLimit:              (*eth.Uint64Quantity)(&d.SystemConfig.GasLimit),
+		Withdrawals:           withdrawals,
+		ParentBeaconBlockRoot: parentBeaconRoot,
 	}
 	return &attrs, nil
 }
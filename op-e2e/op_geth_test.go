@@ -195,14 +195,15 @@ func TestGethOnlyPendingBlockIsLatest(t *testing.T) {
 	time.Sleep(time.Second * 4) // conservatively wait 4 seconds, CI might lag during block building.
 
 	// retrieve the block
-	payload, err := opGeth.l2Engine.GetPayload(ctx, *res.PayloadID)
+	envelope, err := opGeth.l2Engine.GetPayload(ctx, eth.PayloadInfo{ID: *res.PayloadID, ParentBeaconBlockRoot: attrs.ParentBeaconBlockRoot})
 	require.NoError(t, err)
+	payload := envelope.ExecutionPayload
 	checkPending("retrieved", 0)
 	require.Len(t, payload.Transactions, 2, "must include L1 info tx and tx from alice")
 	checkPendingBalance()
 
 	// process the block
-	status, err := opGeth.l2Engine.NewPayload(ctx, payload)
+	status, err := opGeth.l2Engine.NewPayload(ctx, payload, envelope.ParentBeaconBlockRoot)
 	require.NoError(t, err)
 	require.Equal(t, eth.ExecutionValid, status.Status)
 	checkPending("processed", 0)
@@ -861,3 +862,60 @@ func TestCanyon(t *testing.T) {
 		})
 	}
 }
+
+func TestEclipse(t *testing.T) {
+	InitParallel(t)
+
+	tests := []struct {
+		name        string
+		eclipseTime hexutil.Uint64
+		activeFork  func(ctx context.Context, opGeth *OpGeth)
+	}{
+		{name: "ActivateAtGenesis", eclipseTime: 0, activeFork: func(ctx context.Context, opGeth *OpGeth) {}},
+		{name: "ActivateAfterGenesis", eclipseTime: 2, activeFork: func(ctx context.Context, opGeth *OpGeth) {
+			// Adding this block advances us to the fork time.
+			_, err := opGeth.AddL2Block(ctx)
+			require.NoError(t, err)
+		}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("BuildsBlockWithBeaconRoot_%s", test.name), func(t *testing.T) {
+			InitParallel(t)
+			cfg := DefaultSystemConfig(t)
+			s := hexutil.Uint64(0)
+			cfg.DeployConfig.L2GenesisRegolithTimeOffset = &s
+			cfg.DeployConfig.L2GenesisCanyonTimeOffset = &s
+			cfg.DeployConfig.L2GenesisDeltaTimeOffset = &s
+			cfg.DeployConfig.L2GenesisEclipseTimeOffset = &test.eclipseTime
+
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			opGeth, err := NewOpGeth(t, ctx, &cfg)
+			require.NoError(t, err)
+			defer opGeth.Close()
+
+			test.activeFork(ctx, opGeth)
+
+			// the block is built with engine_forkchoiceUpdatedV3 and engine_getPayloadV3,
+			// and inserted with engine_newPayloadV3
+			b, err := opGeth.AddL2Block(ctx)
+			require.NoError(t, err)
+			require.NotNil(t, b.BlobGasUsed)
+			require.NotNil(t, b.ExcessBlobGas)
+			require.Zero(t, *b.BlobGasUsed)
+			require.Zero(t, *b.ExcessBlobGas)
+
+			expectedRoot := opGeth.L1Head.ParentBeaconRoot()
+			if expectedRoot == nil {
+				expectedRoot = new(common.Hash)
+			}
+			header, err := opGeth.L2Client.HeaderByHash(ctx, b.BlockHash)
+			require.NoError(t, err)
+			require.Equal(t, expectedRoot, header.ParentBeaconRoot)
+			require.Equal(t, uint64(*b.BlobGasUsed), *header.BlobGasUsed)
+			require.Equal(t, uint64(*b.ExcessBlobGas), *header.ExcessBlobGas)
+		})
+	}
+}
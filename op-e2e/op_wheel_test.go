@@ -0,0 +1,214 @@
+package op_e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/geth"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-wheel/cheat"
+	"github.com/ethereum-optimism/optimism/op-wheel/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWheelSetForkchoice moves the forkchoice of op-geth with op-wheel, forward and backwards,
+// and checks the forkchoice op-geth reports afterwards.
+func TestWheelSetForkchoice(t *testing.T) {
+	InitParallel(t)
+	cfg := DefaultSystemConfig(t)
+	cfg.DeployConfig.FundDevAccounts = false
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	opGeth, err := NewOpGeth(t, ctx, &cfg)
+	require.NoError(t, err)
+	defer opGeth.Close()
+
+	genesis := opGeth.L2Head
+	blocks := make([]*eth.ExecutionPayload, 6)
+	blocks[0] = genesis
+	for i := 1; i < len(blocks); i++ {
+		blocks[i], err = opGeth.AddL2Block(ctx)
+		require.NoError(t, err)
+	}
+
+	// AddL2Block leaves finalization unset, finalize the genesis block like the rollup node does
+	res, err := opGeth.l2Engine.ForkchoiceUpdate(ctx, &eth.ForkchoiceState{
+		HeadBlockHash:      blocks[5].BlockHash,
+		SafeBlockHash:      blocks[5].BlockHash,
+		FinalizedBlockHash: genesis.BlockHash,
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, eth.ExecutionValid, res.PayloadStatus.Status)
+
+	engineClient, err := engine.DialClient(ctx, opGeth.node.WSAuthEndpoint(), cfg.JWTSecret)
+	require.NoError(t, err)
+	rpcClient, err := rpc.DialContext(ctx, selectEndpoint(opGeth.node))
+	require.NoError(t, err)
+	openClient := client.NewBaseRPCClient(rpcClient)
+
+	byNumber := func(n uint64) *engine.BlockArg {
+		return &engine.BlockArg{Number: n}
+	}
+	byHash := func(h common.Hash) *engine.BlockArg {
+		return &engine.BlockArg{Hash: h, ByHash: true}
+	}
+
+	// Blocks must be ordered finalized <= safe <= unsafe
+	_, err = engine.PrepareForkchoice(ctx, engineClient, byNumber(4), byNumber(3), byNumber(5))
+	require.ErrorContains(t, err, "cannot set safe (3) < finalized (4)")
+	_, err = engine.PrepareForkchoice(ctx, engineClient, byNumber(1), byNumber(4), byNumber(3))
+	require.ErrorContains(t, err, "cannot set unsafe (3) < safe (4)")
+	_, err = engine.PrepareForkchoice(ctx, engineClient, byNumber(1), byNumber(1), byNumber(100))
+	require.ErrorContains(t, err, "not found")
+
+	// Advance finalization, without force
+	update, err := engine.PrepareForkchoice(ctx, engineClient, byNumber(2), byHash(blocks[5].BlockHash), byNumber(5))
+	require.NoError(t, err)
+	require.Empty(t, update.Rewinds())
+	status, err := engine.SetForkchoice(ctx, engineClient, nil, update, false)
+	require.NoError(t, err)
+	require.Equal(t, blocks[5].BlockHash, status.Head.Hash)
+	require.Equal(t, blocks[5].BlockHash, status.Safe.Hash)
+	require.Equal(t, blocks[2].BlockHash, status.Finalized.Hash)
+
+	// Rewind all heads
+	update, err = engine.PrepareForkchoice(ctx, engineClient, byNumber(1), byNumber(2), byHash(blocks[3].BlockHash))
+	require.NoError(t, err)
+	require.Equal(t, []string{"unsafe", "safe", "finalized"}, update.Rewinds())
+
+	var out bytes.Buffer
+	require.NoError(t, update.DryRun(&out))
+	var dryRun struct {
+		Rewinds  []string `json:"rewinds"`
+		Requests []struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		} `json:"requests"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &dryRun))
+	require.Equal(t, update.Rewinds(), dryRun.Rewinds)
+	require.Len(t, dryRun.Requests, 2)
+	require.Equal(t, "debug_setHead", dryRun.Requests[0].Method)
+	require.JSONEq(t, `"0x3"`, string(dryRun.Requests[0].Params[0]))
+	require.Equal(t, "engine_forkchoiceUpdatedV2", dryRun.Requests[1].Method)
+	expectedState, err := json.Marshal(update.State())
+	require.NoError(t, err)
+	require.JSONEq(t, string(expectedState), string(dryRun.Requests[1].Params[0]))
+	require.JSONEq(t, `null`, string(dryRun.Requests[1].Params[1]))
+
+	_, err = engine.SetForkchoice(ctx, engineClient, openClient, update, false)
+	require.ErrorContains(t, err, "force is required")
+	_, err = engine.SetForkchoice(ctx, engineClient, nil, update, true)
+	require.ErrorContains(t, err, "without the open engine RPC")
+
+	// Nothing was sent to the engine when refusing the update
+	status, err = engine.Status(ctx, engineClient)
+	require.NoError(t, err)
+	require.Equal(t, blocks[5].BlockHash, status.Head.Hash)
+
+	status, err = engine.SetForkchoice(ctx, engineClient, openClient, update, true)
+	require.NoError(t, err)
+	require.Equal(t, blocks[3].BlockHash, status.Head.Hash)
+	require.Equal(t, blocks[2].BlockHash, status.Safe.Hash)
+	require.Equal(t, blocks[1].BlockHash, status.Finalized.Hash)
+
+	latest, err := opGeth.L2Client.BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), latest)
+}
+
+// TestWheelCheat applies op-wheel cheats to the datadir of the sequencer op-geth,
+// and checks the cheated state after restarting op-geth on the datadir.
+func TestWheelCheat(t *testing.T) {
+	InitParallel(t)
+	cfg := DefaultSystemConfig(t)
+	dataDir := t.TempDir()
+	cfg.GethOptions["sequencer"] = append(cfg.GethOptions["sequencer"], func(ethCfg *ethconfig.Config, nodeCfg *node.Config) error {
+		nodeCfg.DataDir = dataDir
+		return nil
+	})
+	sys, err := cfg.Start(t)
+	require.NoError(t, err, "Error starting up system")
+	defer sys.Close()
+
+	_, err = geth.WaitForBlock(big.NewInt(3), sys.Clients["sequencer"], 30*time.Second)
+	require.NoError(t, err)
+
+	lgr := testlog.Logger(t, log.LvlInfo)
+	chainData := sys.EthInstances["sequencer"].(*GethInstance).Node.ResolvePath("chaindata")
+
+	// The datadir of a live node cannot be cheated on
+	_, err = cheat.OpenGethDB(lgr, chainData, false)
+	require.ErrorContains(t, err, "must be stopped")
+
+	sys.Close()
+
+	account := common.Address{0x13, 0x37}
+	storageKey, storageValue := common.Hash{31: 1}, common.Hash{0: 0xaa, 31: 0xbb}
+	codeFile := filepath.Join(t.TempDir(), "code.hex")
+	require.NoError(t, os.WriteFile(codeFile, []byte("0x600160005500\n"), 0644))
+	code, err := cheat.ReadCode(codeFile)
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Bytes{0x60, 0x01, 0x60, 0x00, 0x55, 0x00}, code)
+
+	ch, err := cheat.OpenGethDB(lgr, chainData, true)
+	require.NoError(t, err)
+	preHead := ch.Blockchain.CurrentBlock()
+	require.NoError(t, ch.Close())
+
+	for _, fn := range []cheat.HeadFn{
+		cheat.SetBalance(lgr, account, big.NewInt(1234)),
+		cheat.SetNonce(lgr, account, 42),
+		cheat.SetCode(lgr, account, code),
+		cheat.StorageSet(lgr, account, storageKey, storageValue),
+	} {
+		ch, err := cheat.OpenGethDB(lgr, chainData, false)
+		require.NoError(t, err)
+		require.NoError(t, ch.RunAndClose(fn))
+	}
+
+	// Restart op-geth on the cheated datadir
+	l2Node, _, err := geth.InitL2("sequencer", cfg.L2ChainIDBig(), sys.L2GenesisCfg, cfg.JWTFilePath, cfg.GethOptions["sequencer"]...)
+	require.NoError(t, err)
+	require.NoError(t, l2Node.Start())
+	defer l2Node.Close()
+	l2Client, err := ethclient.Dial(l2Node.HTTPEndpoint())
+	require.NoError(t, err)
+	defer l2Client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	head, err := l2Client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, preHead.Number, head.Number, "cheats replace the head block")
+	require.NotEqual(t, preHead.Hash(), head.Hash())
+	require.NotEqual(t, preHead.Root, head.Root)
+
+	balance, err := l2Client.BalanceAt(ctx, account, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1234), balance)
+	nonce, err := l2Client.NonceAt(ctx, account, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), nonce)
+	accountCode, err := l2Client.CodeAt(ctx, account, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte(code), accountCode)
+	value, err := l2Client.StorageAt(ctx, account, storageKey, nil)
+	require.NoError(t, err)
+	require.Equal(t, storageValue[:], value)
+}
@@ -1,6 +1,7 @@
 package op_e2e
 
 import (
+	"compress/zlib"
 	"context"
 	"crypto/ecdsa"
 	"crypto/rand"
@@ -40,6 +41,7 @@ import (
 
 	bss "github.com/ethereum-optimism/optimism/op-batcher/batcher"
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	batcherFlags "github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
 	"github.com/ethereum-optimism/optimism/op-e2e/config"
@@ -58,6 +60,7 @@ import (
 	l2os "github.com/ethereum-optimism/optimism/op-proposer/proposer"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/endpoint"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
@@ -213,8 +216,14 @@ type SystemConfig struct {
 	// Max L1 tx size for the batcher transactions
 	BatcherMaxL1TxSizeBytes uint64
 
+	// Max duration of the batcher channels, in L1 blocks (default 1)
+	BatcherMaxChannelDuration uint64
+
 	// SupportL1TimeTravel determines if the L1 node supports quickly skipping forward in time
 	SupportL1TimeTravel bool
+
+	// DataAvailabilityType is where the batcher posts the batches: calldata (default if empty) or blobs
+	DataAvailabilityType batcherFlags.DataAvailabilityType
 }
 
 type GethInstance struct {
@@ -302,7 +311,8 @@ func (sys *System) RollupClient(name string) *sources.RollupClient {
 		return client
 	}
 	logger := testlog.Logger(sys.t, log.LvlInfo).New("rollupClient", name)
-	endpoint := sys.RollupEndpoint(name)
+	// dial the WebSocket endpoint, for the wait helpers to subscribe to the sync status
+	endpoint := sys.RollupNodes[name].WSEndpoint()
 	client, err := dial.DialRollupClientWithTimeout(context.Background(), 30*time.Second, logger, endpoint)
 	require.NoErrorf(sys.t, err, "Failed to dial rollup client %v", name)
 	sys.rollupClients[name] = client
@@ -443,7 +453,7 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 	}
 
 	l1Block := l1Genesis.ToBlock()
-	l2Genesis, err := genesis.BuildL2Genesis(cfg.DeployConfig, l1Block)
+	l2Genesis, err := genesis.BuildL2Genesis(cfg.DeployConfig, l1Block.Header())
 	if err != nil {
 		return nil, err
 	}
@@ -511,6 +521,7 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 	require.NoError(t, bcn.Start("127.0.0.1:0"))
 	beaconApiAddr := bcn.BeaconAddr()
 	require.NotEmpty(t, beaconApiAddr, "beacon API listener must be up")
+	sys.L1BeaconAPIAddr = beaconApiAddr
 
 	// Initialize nodes
 	l1Node, l1Backend, err := geth.InitL1(cfg.DeployConfig.L1ChainID, cfg.DeployConfig.L1BlockTime, l1Genesis, c,
@@ -561,7 +572,7 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 	// TODO: refactor testing to allow use of in-process rpc connections instead
 	// of only websockets (which are required for external eth client tests).
 	for name, rollupCfg := range cfg.Nodes {
-		configureL1(rollupCfg, sys.EthInstances["l1"])
+		configureL1(rollupCfg, sys.EthInstances["l1"], sys.L1BeaconAPIAddr)
 		configureL2(rollupCfg, sys.EthInstances[name], cfg.JWTSecret)
 	}
 
@@ -727,8 +738,10 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 
 	// L2Output Submitter
 	proposerCLIConfig := &l2os.CLIConfig{
-		L1EthRpc:          sys.EthInstances["l1"].WSEndpoint(),
-		RollupRpc:         sys.RollupNodes["sequencer"].HTTPEndpoint(),
+		Endpoints: endpoint.CLIConfig{
+			L1RPC:     sys.EthInstances["l1"].WSEndpoint(),
+			RollupRPC: sys.RollupNodes["sequencer"].HTTPEndpoint(),
+		},
 		L2OOAddress:       config.L1Deployments.L2OutputOracleProxy.Hex(),
 		PollInterval:      50 * time.Millisecond,
 		TxMgrConfig:       newTxMgrConfig(sys.EthInstances["l1"].WSEndpoint(), cfg.Secrets.Proposer),
@@ -755,17 +768,28 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 	if batcherMaxL1TxSizeBytes == 0 {
 		batcherMaxL1TxSizeBytes = 240_000
 	}
+	batcherMaxChannelDuration := cfg.BatcherMaxChannelDuration
+	if batcherMaxChannelDuration == 0 {
+		batcherMaxChannelDuration = 1
+	}
+	dataAvailabilityType := cfg.DataAvailabilityType
+	if dataAvailabilityType == "" {
+		dataAvailabilityType = batcherFlags.CalldataType
+	}
 	batcherCLIConfig := &bss.CLIConfig{
-		L1EthRpc:               sys.EthInstances["l1"].WSEndpoint(),
-		L2EthRpc:               sys.EthInstances["sequencer"].WSEndpoint(),
-		RollupRpc:              sys.RollupNodes["sequencer"].HTTPEndpoint(),
+		Endpoints: endpoint.CLIConfig{
+			L1RPC:     sys.EthInstances["l1"].WSEndpoint(),
+			L2RPC:     sys.EthInstances["sequencer"].WSEndpoint(),
+			RollupRPC: sys.RollupNodes["sequencer"].HTTPEndpoint(),
+		},
 		MaxPendingTransactions: 0,
-		MaxChannelDuration:     1,
+		MaxChannelDuration:     batcherMaxChannelDuration,
 		MaxL1TxSize:            batcherMaxL1TxSizeBytes,
 		CompressorConfig: compressor.CLIConfig{
 			TargetL1TxSizeBytes: cfg.BatcherTargetL1TxSizeBytes,
 			TargetNumFrames:     1,
 			ApproxComprRatio:    0.4,
+			Level:               zlib.BestCompression,
 		},
 		SubSafetyMargin: 4,
 		PollInterval:    50 * time.Millisecond,
@@ -774,8 +798,12 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 			Level:  log.LvlInfo,
 			Format: oplog.FormatText,
 		},
-		Stopped:   sys.Cfg.DisableBatcher, // Batch submitter may be enabled later
-		BatchType: batchType,
+		Stopped:              sys.Cfg.DisableBatcher, // Batch submitter may be enabled later
+		BatchType:            batchType,
+		DataAvailabilityType: dataAvailabilityType,
+		MaxBlobsPerTx:        1,
+		ConfirmationMode:     batcherFlags.DepthConfirmation,
+		ConfirmationDepth:    64,
 	}
 	// Batch Submitter
 	batcher, err := bss.BatcherServiceFromCLIConfig(context.Background(), "0.0.1", batcherCLIConfig, sys.Cfg.Loggers["batcher"])
@@ -847,7 +875,7 @@ func selectEndpoint(node EthInstance) string {
 	return node.WSEndpoint()
 }
 
-func configureL1(rollupNodeCfg *rollupNode.Config, l1Node EthInstance) {
+func configureL1(rollupNodeCfg *rollupNode.Config, l1Node EthInstance, beaconEndpoint string) {
 	l1EndpointConfig := selectEndpoint(l1Node)
 	rollupNodeCfg.L1 = &rollupNode.L1EndpointConfig{
 		L1NodeAddr:       l1EndpointConfig,
@@ -858,6 +886,9 @@ func configureL1(rollupNodeCfg *rollupNode.Config, l1Node EthInstance) {
 		HttpPollInterval: time.Millisecond * 100,
 		MaxConcurrency:   10,
 	}
+	rollupNodeCfg.Beacon = &rollupNode.L1BeaconEndpointConfig{
+		BeaconAddr: beaconEndpoint,
+	}
 }
 
 type WSOrHTTPEndpoint interface {
@@ -2,14 +2,19 @@ package op_e2e
 
 import (
 	"context"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/node"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 )
@@ -51,6 +56,15 @@ func TestStopStartSequencer(t *testing.T) {
 	active, err = rollupClient.SequencerActive(ctx)
 	require.NoError(t, err)
 	require.False(t, active, "sequencer should be inactive")
+	status, err := rollupClient.SequencerStatus(ctx)
+	require.NoError(t, err)
+	require.True(t, status.Enabled)
+	require.True(t, status.Stopped, "sequencer should be paused by the stop request")
+	require.Equal(t, blockHash, status.LastSequenced.Hash, "the head on stop was sequenced by this node")
+
+	verifierStatus, err := sys.RollupClient("verifier").SequencerStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &driver.SequencerStatus{}, verifierStatus, "verifier never sequences")
 
 	blockBefore := latestBlock(t, l2Seq)
 	time.Sleep(time.Duration(cfg.DeployConfig.L2BlockTime+1) * time.Second)
@@ -73,6 +87,83 @@ func TestStopStartSequencer(t *testing.T) {
 		wait.ForNextBlock(ctx, l2Seq),
 		"Chain did not advance after starting sequencer",
 	)
+	status, err = rollupClient.SequencerStatus(ctx)
+	require.NoError(t, err)
+	require.True(t, status.Active)
+	require.False(t, status.Stopped)
+	require.Greater(t, status.LastSequenced.Number, blockBefore, "sequencer should report the new block")
+}
+
+// TestSequencerHandoff hands off sequencing between two op-nodes that share one engine:
+// the new sequencer must continue on the last block of the old sequencer, without conflicting blocks.
+func TestSequencerHandoff(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	delete(cfg.Nodes, "verifier")
+	sys, err := cfg.Start(t)
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	l2Seq := sys.Clients["sequencer"]
+	clientA := sys.RollupClient("sequencer")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	require.NoError(t, wait.ForNextBlock(ctx, l2Seq), "Chain did not advance")
+
+	headA, err := clientA.StopSequencer(ctx)
+	require.NoError(t, err, "Error stopping sequencer")
+	require.NotEqual(t, common.Hash{}, headA)
+	// the in-flight block is completed before stopping: the returned head is the engine head
+	latest, err := l2Seq.BlockByNumber(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, headA, latest.Hash(), "stopped sequencer must return the latest block")
+
+	// stopping again is safe, and reports the same head
+	again, err := clientA.StopSequencer(ctx)
+	require.NoError(t, err, "Stopping a stopped sequencer must be a no-op")
+	require.Equal(t, headA, again)
+
+	// the old sequencer is shut down, and a new op-node takes over the engine
+	require.NoError(t, sys.RollupNodes["sequencer"].Stop(ctx))
+	nodeCfg := *cfg.Nodes["sequencer"] // copy
+	nodeCfg.Rollup = *sys.RollupConfig
+	nodeCfg.Driver.SequencerStopped = true
+	nodeCfg.P2P = nil
+	nodeCfg.P2PSigner = nil
+	snapLog := log.New()
+	snapLog.SetHandler(log.DiscardHandler())
+	nodeB, err := node.New(context.Background(), &nodeCfg, testlog.Logger(t, log.LvlInfo).New("role", "sequencer-b"), snapLog, "", metrics.NewMetrics(""))
+	require.NoError(t, err)
+	require.NoError(t, nodeB.Start(context.Background()))
+	sys.RollupNodes["sequencer-b"] = nodeB
+	clientB := sys.RollupClient("sequencer-b")
+
+	active, err := clientB.SequencerActive(ctx)
+	require.NoError(t, err)
+	require.False(t, active, "new sequencer must start stopped")
+	statusB, err := clientB.SequencerStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &driver.SequencerStatus{Enabled: true, Stopped: true}, statusB, "new sequencer has not sequenced yet")
+
+	// the new sequencer refuses to start on a head it does not have
+	err = clientB.StartSequencer(ctx, common.Hash{0xaa})
+	require.ErrorContains(t, err, "block hash does not match")
+	require.Eventually(t, func() bool {
+		return clientB.StartSequencer(ctx, headA) == nil
+	}, 10*time.Second, 100*time.Millisecond, "new sequencer must start on the head of the old sequencer")
+	// starting again on the same head is safe
+	require.NoError(t, clientB.StartSequencer(ctx, headA))
+
+	require.NoError(t, wait.ForNextBlock(ctx, l2Seq), "Chain did not advance after handoff")
+	next, err := l2Seq.BlockByNumber(ctx, new(big.Int).Add(latest.Number(), common.Big1))
+	require.NoError(t, err)
+	require.Equal(t, headA, next.ParentHash(), "new sequencer must build on the head of the old sequencer")
+	statusB, err = clientB.SequencerStatus(ctx)
+	require.NoError(t, err)
+	require.True(t, statusB.Active)
+	require.GreaterOrEqual(t, statusB.LastSequenced.Number, next.NumberU64(), "new sequencer must report its blocks")
 }
 
 func TestPersistSequencerStateWhenChanged(t *testing.T) {
@@ -132,9 +223,12 @@ func TestLoadSequencerStateOnStarted_Stopped(t *testing.T) {
 	// Still persisted as stopped after startup
 	assertPersistedSequencerState(t, stateFile, node.StateStopped)
 
-	// Sequencer is really stopped
+	// Sequencer is really stopped: stopping again does not change the state
+	active, err := rollupClient.SequencerActive(ctx)
+	require.NoError(t, err)
+	require.False(t, active)
 	_, err = rollupClient.StopSequencer(ctx)
-	require.ErrorContains(t, err, "sequencer not running")
+	require.NoError(t, err)
 	assertPersistedSequencerState(t, stateFile, node.StateStopped)
 }
 
@@ -166,9 +260,12 @@ func TestLoadSequencerStateOnStarted_Started(t *testing.T) {
 	// Still persisted as stopped after startup
 	assertPersistedSequencerState(t, stateFile, node.StateStarted)
 
-	// Sequencer is really stopped
+	// Sequencer is really started: starting again does not change the state
+	active, err := rollupClient.SequencerActive(ctx)
+	require.NoError(t, err)
+	require.True(t, active)
 	err = rollupClient.StartSequencer(ctx, common.Hash{})
-	require.ErrorContains(t, err, "sequencer already running")
+	require.NoError(t, err)
 	assertPersistedSequencerState(t, stateFile, node.StateStarted)
 }
 
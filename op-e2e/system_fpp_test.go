@@ -275,6 +275,7 @@ func testFaultProofProgramScenario(t *testing.T, ctx context.Context, sys *Syste
 	fppConfig := oppconf.NewConfig(sys.RollupConfig, sys.L2GenesisCfg.Config, s.L1Head, s.L2Head, s.L2OutputRoot, common.Hash(s.L2Claim), s.L2ClaimBlockNumber)
 	fppConfig.L1URL = sys.NodeEndpoint("l1")
 	fppConfig.L2URL = sys.NodeEndpoint("sequencer")
+	fppConfig.L1BeaconURL = sys.L1BeaconAPIAddr
 	fppConfig.DataDir = preimageDir
 	if s.Detached {
 		// When running in detached mode we need to compile the client executable since it will be called directly.
@@ -287,6 +288,14 @@ func testFaultProofProgramScenario(t *testing.T, ctx context.Context, sys *Syste
 	err := opp.FaultProofProgram(ctx, log, fppConfig)
 	require.NoError(t, err)
 
+	// Check that a claim for a block after the L1 head cannot be verified
+	t.Log("Running fault proof with a claim beyond the L1 head")
+	incompleteConfig := *fppConfig
+	incompleteConfig.DataDir = t.TempDir()
+	incompleteConfig.L2ClaimBlockNumber = s.L2ClaimBlockNumber + 1000
+	err = opp.FaultProofProgram(ctx, log, &incompleteConfig)
+	require.ErrorIs(t, err, driver.ErrDerivationIncomplete)
+
 	t.Log("Shutting down network")
 	// Shutdown the nodes from the actual chain. Should now be able to run using only the pre-fetched data.
 	require.NoError(t, sys.BatchSubmitter.Kill())
@@ -308,11 +317,7 @@ func testFaultProofProgramScenario(t *testing.T, ctx context.Context, sys *Syste
 	t.Log("Running fault proof with invalid claim")
 	fppConfig.L2Claim = common.Hash{0xaa}
 	err = opp.FaultProofProgram(ctx, log, fppConfig)
-	if s.Detached {
-		require.Error(t, err, "exit status 1")
-	} else {
-		require.ErrorIs(t, err, driver.ErrClaimNotValid)
-	}
+	require.ErrorIs(t, err, driver.ErrClaimNotValid)
 }
 
 func waitForSafeHead(ctx context.Context, safeBlockNum uint64, rollupClient *sources.RollupClient) error {
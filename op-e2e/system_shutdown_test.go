@@ -0,0 +1,65 @@
+package op_e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// TestVerifierRestartMidDerivation interrupts the verifier while it derives the safe chain,
+// and checks that a new op-node on the same engine resumes from a consistent state.
+func TestVerifierRestartMidDerivation(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	sys, err := cfg.Start(t)
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	rollupClient := sys.RollupClient("verifier")
+	status, err := wait.ForSyncStatus(ctx, rollupClient, func(status *eth.SyncStatus) bool {
+		return status.SafeL2.Number > 0
+	})
+	require.NoError(t, err, "verifier must derive safe blocks")
+
+	// the verifier is still deriving: the sequencer keeps producing blocks and batches
+	stopCtx, stopCancel := context.WithTimeout(ctx, 10*time.Second)
+	defer stopCancel()
+	require.NoError(t, sys.RollupNodes["verifier"].Stop(stopCtx), "graceful shutdown must not be forced")
+	require.True(t, sys.RollupNodes["verifier"].Stopped())
+
+	nodeCfg := *cfg.Nodes["verifier"] // copy
+	nodeCfg.Rollup = *sys.RollupConfig
+	nodeCfg.P2P = nil
+	snapLog := log.New()
+	snapLog.SetHandler(log.DiscardHandler())
+	nodeB, err := node.New(context.Background(), &nodeCfg, testlog.Logger(t, log.LvlInfo).New("role", "verifier-b"), snapLog, "", metrics.NewMetrics(""))
+	require.NoError(t, err)
+	require.NoError(t, nodeB.Start(context.Background()))
+	sys.RollupNodes["verifier-b"] = nodeB
+
+	// the restarted verifier continues from the safe head it had, and derives past it
+	_, err = wait.ForSyncStatus(ctx, sys.RollupClient("verifier-b"), func(next *eth.SyncStatus) bool {
+		return next.SafeL2.Number > status.SafeL2.Number && next.UnsafeL2.Number >= next.SafeL2.Number
+	})
+	require.NoError(t, err, "restarted verifier must continue deriving")
+
+	l2Verif := sys.Clients["verifier"]
+	verifHead, err := l2Verif.BlockByNumber(ctx, nil)
+	require.NoError(t, err)
+	seqBlock, err := sys.Clients["sequencer"].BlockByNumber(ctx, verifHead.Number())
+	require.NoError(t, err)
+	require.Equal(t, seqBlock.Hash(), verifHead.Hash(), "restarted verifier must be on the sequencer chain")
+}
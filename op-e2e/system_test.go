@@ -28,6 +28,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/slices"
 
+	bss "github.com/ethereum-optimism/optimism/op-batcher/batcher"
+	batcherFlags "github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum-optimism/optimism/op-e2e/config"
@@ -39,6 +41,8 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	proposerrpc "github.com/ethereum-optimism/optimism/op-proposer/proposer/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -157,6 +161,60 @@ func TestL2OutputSubmitter(t *testing.T) {
 	}
 }
 
+// TestL2OutputSubmitterPauseResume tests that the output count of the L2OutputOracle doesn't increase
+// while the proposer is paused, and that the proposer proposes outputs again after resuming.
+func TestL2OutputSubmitterPauseResume(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	cfg.NonFinalizedProposals = true // speed up the time till we see output proposals
+
+	sys, err := cfg.Start(t)
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	l2OutputOracle, err := bindings.NewL2OutputOracleCaller(cfg.L1Deployments.L2OutputOracleProxy, sys.Clients["l1"])
+	require.Nil(t, err)
+	driver := sys.L2OutputSubmitter.Driver()
+	outputs := func() uint64 {
+		next, err := l2OutputOracle.NextOutputIndex(&bind.CallOpts{Context: ctx})
+		require.NoError(t, err)
+		return next.Uint64()
+	}
+	proposalInclusionDuration := time.Duration(3*cfg.DeployConfig.L1BlockTime) * time.Second
+
+	require.NoError(t, wait.For(ctx, time.Second, func() (bool, error) {
+		return outputs() > 0, nil
+	}), "proposer must propose outputs")
+
+	driver.PauseL2OutputSubmitting()
+	status, err := driver.ProposalStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, proposerrpc.ProposerPaused, status.State)
+	require.NotEqual(t, common.Hash{}, status.LastProposalTx)
+
+	// a proposal tx that was in flight when pausing may still be included
+	time.Sleep(proposalInclusionDuration)
+	paused := outputs()
+	time.Sleep(3 * proposalInclusionDuration)
+	require.Equal(t, paused, outputs(), "output count must not increase while the proposer is paused")
+	status, err = driver.ProposalStatus(ctx)
+	require.NoError(t, err)
+	latest, err := l2OutputOracle.LatestBlockNumber(&bind.CallOpts{Context: ctx})
+	require.NoError(t, err)
+	require.Equal(t, latest.Uint64()+cfg.DeployConfig.L2OutputOracleSubmissionInterval, status.NextBlockNumber)
+
+	driver.ResumeL2OutputSubmitting()
+	require.NoError(t, wait.For(ctx, time.Second, func() (bool, error) {
+		return outputs() > paused, nil
+	}), "proposer must propose outputs after resuming")
+	status, err = driver.ProposalStatus(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, proposerrpc.ProposerPaused, status.State)
+}
+
 func TestSystemE2EDencunAtGenesis(t *testing.T) {
 	InitParallel(t)
 
@@ -209,6 +267,52 @@ func TestSystemE2EDencunAtGenesisWithBlobs(t *testing.T) {
 	require.Nil(t, err, "Waiting for safety of L2 block")
 }
 
+// TestSystemE2EEclipseBlobBatches tests that the verifier derives the safe chain from batches posted in blobs.
+func TestSystemE2EEclipseBlobBatches(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	genesisActivation := hexutil.Uint64(0)
+	cfg.DeployConfig.L1CancunTimeOffset = new(uint64)
+	// Eclipse can only be scheduled together with all the forks before it
+	cfg.DeployConfig.L2GenesisRegolithTimeOffset = &genesisActivation
+	cfg.DeployConfig.L2GenesisCanyonTimeOffset = &genesisActivation
+	cfg.DeployConfig.L2GenesisDeltaTimeOffset = &genesisActivation
+	cfg.DeployConfig.L2GenesisEclipseTimeOffset = &genesisActivation
+	cfg.DataAvailabilityType = batcherFlags.BlobsType
+
+	sys, err := cfg.Start(t)
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	status, err := wait.ForSyncStatus(ctx, sys.RollupClient("verifier"), func(status *eth.SyncStatus) bool {
+		return status.SafeL2.Number > 0
+	})
+	require.NoError(t, err, "verifier must derive safe blocks from blobs")
+
+	// the batches that made the verifier safe head are posted in blob txs
+	l1Client := sys.Clients["l1"]
+	var blobBatches int
+	for n := uint64(0); n <= status.CurrentL1.Number; n++ {
+		block, err := l1Client.BlockByNumber(ctx, new(big.Int).SetUint64(n))
+		require.NoError(t, err)
+		for _, tx := range block.Transactions() {
+			if to := tx.To(); to == nil || *to != cfg.DeployConfig.BatchInboxAddress {
+				continue
+			}
+			require.Equal(t, uint8(types.BlobTxType), tx.Type(), "batcher tx must be a blob tx")
+			blobBatches++
+		}
+	}
+	require.NotZero(t, blobBatches, "expected batches in blobs")
+
+	seqBlock, err := sys.Clients["sequencer"].BlockByNumber(ctx, new(big.Int).SetUint64(status.SafeL2.Number))
+	require.NoError(t, err)
+	require.Equal(t, seqBlock.Hash(), status.SafeL2.Hash, "verifier must derive the sequencer chain")
+}
+
 // TestSystemE2E sets up a L1 Geth node, a rollup node, and a L2 geth node and then confirms that L1 deposits are reflected on L2.
 // All nodes are run in process (but are the full nodes, not mocked or stubbed).
 func TestSystemE2E(t *testing.T) {
@@ -545,11 +649,11 @@ func TestSystemMockP2P(t *testing.T) {
 
 	var published, received []common.Hash
 	seqTracer, verifTracer := new(FnTracer), new(FnTracer)
-	seqTracer.OnPublishL2PayloadFn = func(ctx context.Context, payload *eth.ExecutionPayload) {
-		published = append(published, payload.BlockHash)
+	seqTracer.OnPublishL2PayloadFn = func(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) {
+		published = append(published, envelope.ExecutionPayload.BlockHash)
 	}
-	verifTracer.OnUnsafeL2PayloadFn = func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) {
-		received = append(received, payload.BlockHash)
+	verifTracer.OnUnsafeL2PayloadFn = func(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) {
+		received = append(received, envelope.ExecutionPayload.BlockHash)
 	}
 	cfg.Nodes["sequencer"].Tracer = seqTracer
 	cfg.Nodes["verifier"].Tracer = verifTracer
@@ -646,8 +750,8 @@ func TestSystemP2PAltSync(t *testing.T) {
 	var published []string
 	seqTracer := new(FnTracer)
 	// The sequencer still publishes the blocks to the tracer, even if they do not reach the network due to disabled P2P
-	seqTracer.OnPublishL2PayloadFn = func(ctx context.Context, payload *eth.ExecutionPayload) {
-		published = append(published, payload.ID().String())
+	seqTracer.OnPublishL2PayloadFn = func(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) {
+		published = append(published, envelope.ExecutionPayload.ID().String())
 	}
 	// Blocks are now received via the RPC based alt-sync method
 	cfg.Nodes["sequencer"].Tracer = seqTracer
@@ -700,12 +804,12 @@ func TestSystemP2PAltSync(t *testing.T) {
 		Pprof:               oppprof.CLIConfig{},
 		L1EpochPollInterval: time.Second * 10,
 		Tracer: &FnTracer{
-			OnUnsafeL2PayloadFn: func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) {
-				syncedPayloads = append(syncedPayloads, payload.ID().String())
+			OnUnsafeL2PayloadFn: func(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) {
+				syncedPayloads = append(syncedPayloads, envelope.ExecutionPayload.ID().String())
 			},
 		},
 	}
-	configureL1(syncNodeCfg, sys.EthInstances["l1"])
+	configureL1(syncNodeCfg, sys.EthInstances["l1"], sys.L1BeaconAPIAddr)
 	syncerL2Engine, _, err := geth.InitL2("syncer", big.NewInt(int64(cfg.DeployConfig.L2ChainID)), sys.L2GenesisCfg, cfg.JWTFilePath)
 	require.NoError(t, err)
 	require.NoError(t, syncerL2Engine.Start())
@@ -740,6 +844,89 @@ func TestSystemP2PAltSync(t *testing.T) {
 	require.ElementsMatch(t, syncedPayloads, published[:len(syncedPayloads)])
 }
 
+// TestSystemELSyncLateJoiner starts a node in execution-layer sync mode after the chain progressed.
+// The op-node forwards the gossiped unsafe blocks to its engine, which syncs the missed blocks from the sequencer engine over devp2p,
+// and the op-node resumes derivation once the engine validated the gossiped head.
+func TestSystemELSyncLateJoiner(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	// The sequencer engine serves the chain to the engine of the late-joining node.
+	cfg.GethOptions["sequencer"] = append(cfg.GethOptions["sequencer"], geth.WithP2P())
+	// connect the nodes, so the sequencer has a p2p host to gossip the blocks with
+	cfg.P2PTopology = map[string][]string{
+		"verifier": {"sequencer"},
+	}
+
+	sys, err := cfg.Start(t)
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	l2Seq := sys.Clients["sequencer"]
+
+	// Submit a TX to L2 sequencer node
+	receiptSeq := SendL2Tx(t, cfg, l2Seq, cfg.Secrets.Alice, func(opts *TxOpts) {
+		opts.ToAddr = &common.Address{0xff, 0xff}
+		opts.Value = big.NewInt(1_000_000_000)
+	})
+	// Let the chain progress, so the blocks up to and including the tx are never gossiped to the late-joining node.
+	_, err = geth.WaitForBlock(new(big.Int).Add(receiptSeq.BlockNumber, big.NewInt(10)), l2Seq, 30*time.Duration(cfg.DeployConfig.L2BlockTime)*time.Second)
+	require.NoError(t, err)
+
+	cfg.Loggers["syncer"] = testlog.Logger(t, log.LvlInfo).New("role", "syncer")
+	snapLog := log.New()
+	snapLog.SetHandler(log.DiscardHandler())
+
+	// Create a peer, and hook up the sequencer
+	h, err := sys.newMockNetPeer()
+	require.NoError(t, err)
+	_, err = sys.Mocknet.LinkPeers(sys.RollupNodes["sequencer"].P2P().Host().ID(), h.ID())
+	require.NoError(t, err)
+
+	// Configure the late-joining rollup node, without req-resp sync: the missed blocks can only come from the engine sync
+	syncNodeCfg := &rollupNode.Config{
+		Driver:    driver.Config{VerifierConfDepth: 0},
+		Rollup:    *sys.RollupConfig,
+		P2PSigner: nil,
+		RPC: rollupNode.RPCConfig{
+			ListenAddr:  "127.0.0.1",
+			ListenPort:  0,
+			EnableAdmin: true,
+		},
+		P2P:                 &p2p.Prepared{HostP2P: h},
+		Metrics:             rollupNode.MetricsConfig{Enabled: false}, // no metrics server
+		Pprof:               oppprof.CLIConfig{},
+		L1EpochPollInterval: time.Second * 4,
+		Sync:                sync.Config{SyncMode: sync.ELSync},
+	}
+	configureL1(syncNodeCfg, sys.EthInstances["l1"], sys.L1BeaconAPIAddr)
+	syncerL2Engine, _, err := geth.InitL2("syncer", big.NewInt(int64(cfg.DeployConfig.L2ChainID)), sys.L2GenesisCfg, cfg.JWTFilePath, geth.WithP2P())
+	require.NoError(t, err)
+	require.NoError(t, syncerL2Engine.Start())
+
+	configureL2(syncNodeCfg, syncerL2Engine, cfg.JWTSecret)
+
+	syncerNode, err := rollupNode.New(context.Background(), syncNodeCfg, cfg.Loggers["syncer"], snapLog, "", metrics.NewMetrics(""))
+	require.NoError(t, err)
+	err = syncerNode.Start(context.Background())
+	require.NoError(t, err)
+
+	// connect the sequencer to our new syncer node, and the engines to each other
+	_, err = sys.Mocknet.ConnectPeers(sys.RollupNodes["sequencer"].P2P().Host().ID(), syncerNode.P2P().Host().ID())
+	require.NoError(t, err)
+	l2Verif := ethclient.NewClient(syncerL2Engine.Attach())
+	geth.ConnectP2P(t, l2Verif, l2Seq)
+
+	// The engine syncs the blocks the op-node did not receive, towards the first gossiped block
+	receiptVerif, err := geth.WaitForTransaction(receiptSeq.TxHash, l2Verif, 100*time.Duration(sys.RollupConfig.BlockTime)*time.Second)
+	require.Nil(t, err, "Waiting for L2 tx on verifier")
+	require.Equal(t, receiptSeq, receiptVerif)
+
+	// Once the engine validated the sync target, the op-node resumes the derivation from L1, and consolidates the synced blocks
+	_, err = geth.WaitForBlockToBeSafe(receiptSeq.BlockNumber, l2Verif, 100*time.Duration(sys.RollupConfig.BlockTime)*time.Second)
+	require.NoError(t, err, "Waiting for the synced block to be derived as safe")
+}
+
 // TestSystemDenseTopology sets up a dense p2p topology with 3 verifier nodes and 1 sequencer node.
 func TestSystemDenseTopology(t *testing.T) {
 	t.Skip("Skipping dense topology test to avoid flakiness. @refcell address in p2p scoring pr.")
@@ -790,17 +977,17 @@ func TestSystemDenseTopology(t *testing.T) {
 
 	var published, received1, received2, received3 []common.Hash
 	seqTracer, verifTracer, verifTracer2, verifTracer3 := new(FnTracer), new(FnTracer), new(FnTracer), new(FnTracer)
-	seqTracer.OnPublishL2PayloadFn = func(ctx context.Context, payload *eth.ExecutionPayload) {
-		published = append(published, payload.BlockHash)
+	seqTracer.OnPublishL2PayloadFn = func(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) {
+		published = append(published, envelope.ExecutionPayload.BlockHash)
 	}
-	verifTracer.OnUnsafeL2PayloadFn = func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) {
-		received1 = append(received1, payload.BlockHash)
+	verifTracer.OnUnsafeL2PayloadFn = func(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) {
+		received1 = append(received1, envelope.ExecutionPayload.BlockHash)
 	}
-	verifTracer2.OnUnsafeL2PayloadFn = func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) {
-		received2 = append(received2, payload.BlockHash)
+	verifTracer2.OnUnsafeL2PayloadFn = func(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) {
+		received2 = append(received2, envelope.ExecutionPayload.BlockHash)
 	}
-	verifTracer3.OnUnsafeL2PayloadFn = func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) {
-		received3 = append(received3, payload.BlockHash)
+	verifTracer3.OnUnsafeL2PayloadFn = func(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) {
+		received3 = append(received3, envelope.ExecutionPayload.BlockHash)
 	}
 	cfg.Nodes["sequencer"].Tracer = seqTracer
 	cfg.Nodes["verifier"].Tracer = verifTracer
@@ -1056,6 +1243,33 @@ type stateGetterAdapter struct {
 	blockNum *big.Int
 }
 
+// TestWithdrawalProofFromOutput proves and finalizes a withdrawal with only the output of the rollup node,
+// including the proof of the withdrawal, without a separate eth_getProof call.
+func TestWithdrawalProofFromOutput(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	cfg.DeployConfig.FinalizationPeriodSeconds = 2 // 2s finalization period
+
+	sys, err := cfg.Start(t)
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	l1Client := sys.Clients["l1"]
+	l2Seq := sys.Clients["sequencer"]
+	l2Verif := sys.Clients["verifier"]
+
+	// the withdrawing account is funded on L2 at genesis
+	ethPrivKey := cfg.Secrets.Alice
+	_, receipt := SendWithdrawal(t, cfg, l2Seq, ethPrivKey, func(opts *WithdrawalTxOpts) {
+		opts.Value = big.NewInt(500_000_000_000)
+		opts.VerifyOnClients(l2Verif)
+	})
+
+	params, proveReceipt := ProveWithdrawalFromOutput(t, cfg, l1Client, sys.RollupClient("verifier"), ethPrivKey, receipt)
+	FinalizeWithdrawal(t, cfg, l1Client, ethPrivKey, proveReceipt, params)
+}
+
 func (sga *stateGetterAdapter) GetState(addr common.Address, key common.Hash) common.Hash {
 	sga.t.Helper()
 	val, err := sga.client.StorageAt(sga.ctx, addr, key, sga.blockNum)
@@ -1305,6 +1519,101 @@ func StopStartBatcher(t *testing.T, deltaTimeOffset *hexutil.Uint64) {
 	require.Greater(t, newSeqStatus.SafeL2.Number, seqStatus.SafeL2.Number, "Safe chain did not advance after batcher was restarted")
 }
 
+// TestBatcherDrainOnStop tests that stopping the batcher submits the blocks of its open channel,
+// and that the safe chain continues without a gap after the batcher is started again.
+func TestBatcherDrainOnStop(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	// channels are kept open, so that the batcher has an open channel when it is stopped
+	cfg.BatcherMaxChannelDuration = 1000
+	sys, err := cfg.Start(t)
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	l2Seq := sys.Clients["sequencer"]
+	l2Verif := sys.Clients["verifier"]
+	safeTimeout := time.Duration(10*cfg.DeployConfig.L1BlockTime) * time.Second
+
+	_, err = geth.WaitForBlock(big.NewInt(5), l2Seq, time.Duration(cfg.DeployConfig.L2BlockTime*15)*time.Second)
+	require.Nil(t, err, "Waiting for L2 blocks")
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	seqHead, err := l2Seq.BlockNumber(ctx)
+	require.NoError(t, err)
+	// give the batcher a few poll intervals to load the sequencer head
+	time.Sleep(500 * time.Millisecond)
+
+	require.NoError(t, sys.BatchSubmitter.Driver().StopBatchSubmitting(ctx))
+
+	// the blocks of the drained channel become safe, without the batcher running
+	_, err = geth.WaitForBlockToBeSafe(new(big.Int).SetUint64(seqHead), l2Verif, safeTimeout)
+	require.NoError(t, err, "the blocks loaded before stopping must be submitted")
+
+	require.NoError(t, sys.BatchSubmitter.Driver().StartBatchSubmitting())
+	restartHead, err := l2Seq.BlockNumber(ctx)
+	require.NoError(t, err)
+	_, err = geth.WaitForBlockToBeSafe(new(big.Int).SetUint64(restartHead+1), l2Verif, safeTimeout)
+	require.NoError(t, err, "the safe chain must continue after restarting the batcher")
+
+	// the safe chain has no gap: the verifier derived the same blocks as the sequencer across the restart
+	for n := seqHead; n <= restartHead+1; n++ {
+		seqBlock, err := l2Seq.BlockByNumber(ctx, new(big.Int).SetUint64(n))
+		require.NoError(t, err)
+		verifBlock, err := l2Verif.BlockByNumber(ctx, new(big.Int).SetUint64(n))
+		require.NoError(t, err)
+		require.Equal(t, seqBlock.Hash(), verifBlock.Hash(), "block %d must match", n)
+	}
+}
+
+// TestBatcherPauseResumeFlush tests that the safe head stalls while the batcher is paused,
+// and that all loaded blocks become safe after resuming and flushing the batcher.
+func TestBatcherPauseResumeFlush(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	sys, err := cfg.Start(t)
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	rollupClient := sys.RollupClient("verifier")
+	driver := sys.BatchSubmitter.Driver()
+	safeBlockInclusionDuration := time.Duration(6*cfg.DeployConfig.L1BlockTime) * time.Second
+
+	_, err = wait.ForSyncStatus(ctx, rollupClient, func(status *eth.SyncStatus) bool {
+		return status.SafeL2.Number > 0
+	})
+	require.NoError(t, err, "verifier must derive safe blocks")
+
+	driver.PauseBatchSubmitting()
+	require.True(t, driver.BatcherStatus().Paused)
+	require.ErrorIs(t, driver.FlushBatchSubmitting(ctx), bss.ErrBatcherPaused, "cannot flush while paused")
+
+	// batcher txs that were in flight when pausing may still be included
+	time.Sleep(safeBlockInclusionDuration)
+	stalled, err := rollupClient.SyncStatus(ctx)
+	require.NoError(t, err)
+	time.Sleep(safeBlockInclusionDuration)
+	status, err := rollupClient.SyncStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, stalled.SafeL2, status.SafeL2, "safe head must stall while the batcher is paused")
+	batcherStatus := driver.BatcherStatus()
+	require.True(t, batcherStatus.Running)
+	require.Greater(t, batcherStatus.NumPendingBlocks, 0, "blocks keep being loaded while paused")
+	require.Greater(t, batcherStatus.LastPendingBlock.Number, status.SafeL2.Number)
+
+	driver.ResumeBatchSubmitting()
+	require.False(t, driver.BatcherStatus().Paused)
+	require.NoError(t, driver.FlushBatchSubmitting(ctx))
+	_, err = wait.ForSyncStatus(ctx, rollupClient, func(status *eth.SyncStatus) bool {
+		return status.SafeL2.Number >= batcherStatus.LastPendingBlock.Number
+	})
+	require.NoError(t, err, "the blocks loaded while paused must become safe")
+	require.NotEqual(t, common.Hash{}, driver.BatcherStatus().LastSubmittedTx)
+}
+
 func TestBatcherMultiTx(t *testing.T) {
 	InitParallel(t)
 
@@ -1441,6 +1750,84 @@ func TestRuntimeConfigReload(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestRuntimeConfigSignerRotation rotates the unsafe block signer in the SystemConfig,
+// and checks that the verifier accepts the blocks signed by the new key, without a restart.
+func TestRuntimeConfigSignerRotation(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	// Disable the batcher, so the verifier only receives L2 blocks via gossip
+	cfg.DisableBatcher = true
+	cfg.DeployConfig.SequencerWindowSize = 100_000
+	cfg.DeployConfig.MaxSequencerDrift = 100_000
+	cfg.Nodes["verifier"].RuntimeConfigReloadInterval = time.Second * 5
+	cfg.Nodes["verifier"].Driver.VerifierConfDepth = 1
+	cfg.P2PTopology = map[string][]string{
+		"verifier": {"sequencer"},
+	}
+
+	// the sequencer signs with the new key from the start, the verifier only accepts it after the rotation
+	newSignerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	newSigner := crypto.PubkeyToAddress(newSignerKey.PublicKey)
+	cfg.Nodes["sequencer"].P2PSigner = &p2p.PreparedSigner{Signer: p2p.NewLocalSigner(newSignerKey)}
+
+	received := make(chan common.Hash, 1000)
+	verifTracer := new(FnTracer)
+	verifTracer.OnUnsafeL2PayloadFn = func(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) {
+		select {
+		case received <- envelope.ExecutionPayload.BlockHash:
+		default:
+		}
+	}
+	cfg.Nodes["verifier"].Tracer = verifTracer
+
+	sys, err := cfg.Start(t)
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+	verifierRollup := sys.RollupClient("verifier")
+
+	keys, err := verifierRollup.SequencerKeys(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, cfg.Secrets.Addresses().SequencerP2P, keys.Primary)
+
+	// blocks signed by the key that is not in the SystemConfig yet are rejected
+	time.Sleep(time.Duration(cfg.DeployConfig.L2BlockTime*3) * time.Second)
+	require.Empty(t, received, "blocks signed by an unknown key must be rejected")
+
+	l1 := sys.Clients["l1"]
+	sysCfgContract, err := bindings.NewSystemConfig(cfg.L1Deployments.SystemConfigProxy, l1)
+	require.NoError(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(cfg.Secrets.SysCfgOwner, cfg.L1ChainIDBig())
+	require.NoError(t, err)
+	tx, err := sysCfgContract.SetUnsafeBlockSigner(opts, newSigner)
+	require.NoError(t, err)
+	_, err = wait.ForReceiptOK(context.Background(), l1, tx.Hash())
+	require.NoError(t, err)
+
+	// wait for the verifier to reload the signer
+	_, err = retry.Do(context.Background(), 10, retry.Fixed(time.Second*10), func() (struct{}, error) {
+		keys, err := verifierRollup.SequencerKeys(context.Background())
+		if err != nil {
+			return struct{}{}, err
+		}
+		if keys.Primary == newSigner {
+			return struct{}{}, nil
+		}
+		return struct{}{}, fmt.Errorf("no change yet, seeing %s but looking for %s", keys.Primary, newSigner)
+	})
+	require.NoError(t, err)
+
+	// the blocks signed by the new key pass the gossip validation now
+	select {
+	case blockHash := <-received:
+		_, err := sys.Clients["sequencer"].BlockByHash(context.Background(), blockHash)
+		require.NoError(t, err, "accepted block is a block of the sequencer")
+	case <-time.After(time.Duration(cfg.DeployConfig.L2BlockTime*10) * time.Second):
+		t.Fatal("no blocks of the new signer accepted")
+	}
+}
+
 func TestRecommendedProtocolVersionChange(t *testing.T) {
 	InitParallel(t)
 
@@ -555,17 +555,12 @@ func TestMixedWithdrawalValidity(t *testing.T) {
 			require.Nil(t, err)
 
 			// Obtain our withdrawal parameters
-			withdrawalTransaction := &bindings.TypesWithdrawalTransaction{
-				Nonce:    params.Nonce,
-				Sender:   params.Sender,
-				Target:   params.Target,
-				Value:    params.Value,
-				GasLimit: params.GasLimit,
-				Data:     params.Data,
-			}
-			l2OutputIndexParam := params.L2OutputIndex
-			outputRootProofParam := params.OutputRootProof
-			withdrawalProofParam := params.WithdrawalProof
+			args, err := params.ProveArgs()
+			require.Nil(t, err)
+			withdrawalTransaction := &args.Tx
+			l2OutputIndexParam := args.L2OutputIndex
+			outputRootProofParam := args.OutputRootProof
+			withdrawalProofParam := args.WithdrawalProof
 
 			// Determine if this will be a bad withdrawal.
 			badWithdrawal := i < 8
@@ -9,10 +9,12 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/crossdomain"
 	"github.com/ethereum-optimism/optimism/op-e2e/config"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/geth"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
 	"github.com/ethereum-optimism/optimism/op-node/withdrawals"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -120,20 +122,9 @@ func ProveWithdrawal(t *testing.T, cfg SystemConfig, clients ClientProvider, l2N
 	require.Nil(t, err)
 
 	// Prove withdrawal
-	tx, err := portal.ProveWithdrawalTransaction(
-		opts,
-		bindings.TypesWithdrawalTransaction{
-			Nonce:    params.Nonce,
-			Sender:   params.Sender,
-			Target:   params.Target,
-			Value:    params.Value,
-			GasLimit: params.GasLimit,
-			Data:     params.Data,
-		},
-		params.L2OutputIndex,
-		params.OutputRootProof,
-		params.WithdrawalProof,
-	)
+	args, err := params.ProveArgs()
+	require.Nil(t, err)
+	tx, err := portal.ProveWithdrawalTransaction(opts, args.Tx, args.L2OutputIndex, args.OutputRootProof, args.WithdrawalProof)
 	require.Nil(t, err)
 
 	// Ensure that our withdrawal was proved successfully
@@ -143,6 +134,49 @@ func ProveWithdrawal(t *testing.T, cfg SystemConfig, clients ClientProvider, l2N
 	return params, proveReceipt
 }
 
+// ProveWithdrawalFromOutput proves a withdrawal with just the output of the rollup node,
+// that includes the proof of the withdrawal, rather than retrieving the proof from the L2 execution engine.
+func ProveWithdrawalFromOutput(t *testing.T, cfg SystemConfig, l1Client *ethclient.Client, rollupClient *sources.RollupClient, ethPrivKey *ecdsa.PrivateKey, l2WithdrawalReceipt *types.Receipt) (withdrawals.ProvenWithdrawalParameters, *types.Receipt) {
+	ctx, cancel := context.WithTimeout(context.Background(), 40*time.Duration(cfg.DeployConfig.L1BlockTime)*time.Second)
+	defer cancel()
+	blockNumber, err := wait.ForOutputRootPublished(ctx, l1Client, config.L1Deployments.L2OutputOracleProxy, l2WithdrawalReceipt.BlockNumber)
+	require.NoError(t, err)
+
+	ev, err := withdrawals.ParseMessagePassed(l2WithdrawalReceipt)
+	require.NoError(t, err)
+	slot := crossdomain.StorageSlotOfWithdrawalHash(ev.WithdrawalHash)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	output, err := rollupClient.OutputWithProofAtBlock(ctx, blockNumber, []common.Hash{slot})
+	require.NoError(t, err)
+
+	oracle, err := bindings.NewL2OutputOracleCaller(config.L1Deployments.L2OutputOracleProxy, l1Client)
+	require.NoError(t, err)
+	l2OutputIndex, err := oracle.GetL2OutputIndexAfter(&bind.CallOpts{}, new(big.Int).SetUint64(blockNumber))
+	require.NoError(t, err)
+	proposal, err := oracle.GetL2Output(&bind.CallOpts{}, l2OutputIndex)
+	require.NoError(t, err)
+	require.Equal(t, proposal.OutputRoot, [32]byte(output.OutputRoot), "output must match the proposal")
+
+	params, err := withdrawals.ProveWithdrawalParametersForOutput(ev, output, l2OutputIndex)
+	require.NoError(t, err)
+	args, err := params.ProveArgs()
+	require.NoError(t, err)
+
+	portal, err := bindings.NewOptimismPortal(config.L1Deployments.OptimismPortalProxy, l1Client)
+	require.NoError(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(ethPrivKey, cfg.L1ChainIDBig())
+	require.NoError(t, err)
+	tx, err := portal.ProveWithdrawalTransaction(opts, args.Tx, args.L2OutputIndex, args.OutputRootProof, args.WithdrawalProof)
+	require.NoError(t, err)
+
+	proveReceipt, err := geth.WaitForTransaction(tx.Hash(), l1Client, 3*time.Duration(cfg.DeployConfig.L1BlockTime)*time.Second)
+	require.NoError(t, err, "prove withdrawal")
+	require.Equal(t, types.ReceiptStatusSuccessful, proveReceipt.Status)
+	return params, proveReceipt
+}
+
 func FinalizeWithdrawal(t *testing.T, cfg SystemConfig, l1Client *ethclient.Client, privKey *ecdsa.PrivateKey, withdrawalProofReceipt *types.Receipt, params withdrawals.ProvenWithdrawalParameters) *types.Receipt {
 	// Wait for finalization and then create the Finalized Withdrawal Transaction
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Duration(cfg.DeployConfig.L1BlockTime)*time.Second)
@@ -158,14 +192,7 @@ func FinalizeWithdrawal(t *testing.T, cfg SystemConfig, l1Client *ethclient.Clie
 	// Finalize withdrawal
 	tx, err := portal.FinalizeWithdrawalTransaction(
 		opts,
-		bindings.TypesWithdrawalTransaction{
-			Nonce:    params.Nonce,
-			Sender:   params.Sender,
-			Target:   params.Target,
-			Value:    params.Value,
-			GasLimit: params.GasLimit,
-			Data:     params.Data,
-		},
+		params.Withdrawal().WithdrawalTransaction(),
 	)
 	require.Nil(t, err)
 
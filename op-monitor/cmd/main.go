@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	monitor "github.com/ethereum-optimism/optimism/op-monitor"
+	"github.com/ethereum-optimism/optimism/op-monitor/flags"
+	"github.com/ethereum-optimism/optimism/op-monitor/version"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/opio"
+)
+
+var (
+	GitCommit = ""
+	GitDate   = ""
+)
+
+// VersionWithMeta holds the textual version string including the metadata.
+var VersionWithMeta = opservice.FormatVersion(version.Version, GitCommit, GitDate, version.Meta)
+
+func main() {
+	oplog.SetupDefaults()
+
+	app := cli.NewApp()
+	app.Version = VersionWithMeta
+	app.Flags = cliapp.ProtectFlags(flags.Flags)
+	app.Name = "op-monitor"
+	app.Usage = "Monitor proposed outputs"
+	app.Description = "Compares the outputs proposed to the L2OutputOracle to the outputs of a rollup node, and reports any divergence."
+	app.Action = cliapp.LifecycleCmd(func(ctx *cli.Context, close context.CancelCauseFunc) (cliapp.Lifecycle, error) {
+		logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+		oplog.SetGlobalLogHandler(logger.GetHandler())
+		logger.Info("Starting op-monitor", "version", VersionWithMeta)
+
+		cfg, err := flags.NewConfigFromCLI(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return monitor.Main(ctx.Context, logger, cfg)
+	})
+
+	ctx := opio.WithInterruptBlocker(context.Background())
+	if err := app.RunContext(ctx, os.Args); err != nil {
+		log.Crit("Application failed", "err", err)
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
+)
+
+var (
+	ErrMissingL1EthRPC       = errors.New("missing l1 eth rpc url")
+	ErrMissingRollupRpc      = errors.New("missing rollup rpc url")
+	ErrMissingL2OOAddress    = errors.New("missing l2 output oracle address")
+	ErrPollIntervalZero      = errors.New("poll interval must not be 0")
+	ErrCheckedOutputsZero    = errors.New("number of checked outputs must not be 0")
+	ErrTooManyCheckedOutputs = errors.New("number of checked outputs must not exceed 1000")
+)
+
+const (
+	DefaultPollInterval   = 30 * time.Second
+	DefaultCheckedOutputs = uint64(10)
+	MaxCheckedOutputs     = uint64(1000)
+)
+
+// Config is a well typed config that is parsed from the CLI params.
+// This also contains config options for auxiliary services.
+// It is used to initialize the monitor.
+type Config struct {
+	L1EthRpc       string         // L1 RPC Url
+	RollupRpc      string         // Rollup node RPC Url, of which the outputs are compared to the proposed outputs
+	L2OOAddress    common.Address // Address of the L2OutputOracle
+	PollInterval   time.Duration  // Interval at which the proposed outputs are checked
+	CheckedOutputs uint64         // Number of the latest proposed outputs to check every interval
+
+	MetricsConfig opmetrics.CLIConfig
+	PprofConfig   oppprof.CLIConfig
+}
+
+func NewConfig(l1EthRpc string, rollupRpc string, l2ooAddress common.Address) Config {
+	return Config{
+		L1EthRpc:       l1EthRpc,
+		RollupRpc:      rollupRpc,
+		L2OOAddress:    l2ooAddress,
+		PollInterval:   DefaultPollInterval,
+		CheckedOutputs: DefaultCheckedOutputs,
+
+		MetricsConfig: opmetrics.DefaultCLIConfig(),
+		PprofConfig:   oppprof.DefaultCLIConfig(),
+	}
+}
+
+func (c Config) Check() error {
+	if c.L1EthRpc == "" {
+		return ErrMissingL1EthRPC
+	}
+	if c.RollupRpc == "" {
+		return ErrMissingRollupRpc
+	}
+	if c.L2OOAddress == (common.Address{}) {
+		return ErrMissingL2OOAddress
+	}
+	if c.PollInterval == 0 {
+		return ErrPollIntervalZero
+	}
+	if c.CheckedOutputs == 0 {
+		return ErrCheckedOutputsZero
+	}
+	if c.CheckedOutputs > MaxCheckedOutputs {
+		return ErrTooManyCheckedOutputs
+	}
+	if err := c.MetricsConfig.Check(); err != nil {
+		return err
+	}
+	if err := c.PprofConfig.Check(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package drift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// OutputOracle reads the outputs proposed to the L2OutputOracle. It is implemented by bindings.L2OutputOracleCaller.
+type OutputOracle interface {
+	NextOutputIndex(opts *bind.CallOpts) (*big.Int, error)
+	GetL2Output(opts *bind.CallOpts, index *big.Int) (bindings.TypesOutputProposal, error)
+}
+
+// RollupClient computes the outputs of the L2 chain. It is implemented by sources.RollupClient.
+type RollupClient interface {
+	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+}
+
+type Metricer interface {
+	RecordOutputsMatch(match bool)
+	RecordFirstMismatch(index int64)
+	RecordProposalLag(blocks uint64)
+}
+
+// Result is the comparison of the latest proposed outputs to the outputs computed by the rollup node.
+type Result struct {
+	// Checked is the number of proposed outputs that were compared. Outputs beyond the safe head of the rollup node
+	// are not compared.
+	Checked int
+	// FirstMismatch is the index of the first proposed output that differs from the output of the rollup node,
+	// or -1 if all compared outputs match.
+	FirstMismatch int64
+	// ProposalLag is the number of L2 blocks that the safe head of the rollup node is ahead of the latest output.
+	ProposalLag uint64
+}
+
+// Monitor periodically compares the latest outputs proposed to the L2OutputOracle to the outputs computed by
+// a rollup node, to detect proposals that drift from the chain the rollup node derives.
+type Monitor struct {
+	logger   log.Logger
+	metrics  Metricer
+	clock    clock.Clock
+	oracle   OutputOracle
+	rollup   RollupClient
+	interval time.Duration
+	// outputs is the number of the latest proposed outputs that are compared on every check
+	outputs uint64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewMonitor(logger log.Logger, metrics Metricer, cl clock.Clock, oracle OutputOracle, rollup RollupClient, interval time.Duration, outputs uint64) *Monitor {
+	return &Monitor{
+		logger:   logger,
+		metrics:  metrics,
+		clock:    cl,
+		oracle:   oracle,
+		rollup:   rollup,
+		interval: interval,
+		outputs:  outputs,
+	}
+}
+
+// Start checks the outputs every interval, until Stop is called.
+func (m *Monitor) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.wg.Add(1)
+	go m.loop(ctx)
+}
+
+func (m *Monitor) Stop() {
+	if m.cancel == nil {
+		return
+	}
+	m.cancel()
+	m.wg.Wait()
+	m.cancel = nil
+}
+
+func (m *Monitor) loop(ctx context.Context) {
+	defer m.wg.Done()
+	ticker := m.clock.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := m.CheckOutputs(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			m.logger.Warn("Failed to check outputs", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Ch():
+		}
+	}
+}
+
+// CheckOutputs compares the latest proposed outputs to the outputs of the rollup node and records the result.
+// Every mismatch is logged at error level, with both output roots.
+func (m *Monitor) CheckOutputs(ctx context.Context) (*Result, error) {
+	status, err := m.rollup.SyncStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sync status: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	next, err := m.oracle.NextOutputIndex(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch next output index: %w", err)
+	}
+
+	result := &Result{FirstMismatch: -1}
+	end := next.Uint64()
+	start := end - min(end, m.outputs)
+	for index := start; index < end; index++ {
+		proposal, err := m.oracle.GetL2Output(opts, new(big.Int).SetUint64(index))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch output %d: %w", index, err)
+		}
+		blockNum := proposal.L2BlockNumber.Uint64()
+		if index == end-1 && status.SafeL2.Number > blockNum {
+			result.ProposalLag = status.SafeL2.Number - blockNum
+		}
+		if blockNum > status.SafeL2.Number {
+			m.logger.Debug("Output is beyond the safe head", "index", index, "l2BlockNumber", blockNum, "safeHead", status.SafeL2.Number)
+			continue
+		}
+		output, err := m.rollup.OutputAtBlock(ctx, blockNum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch output at block %d: %w", blockNum, err)
+		}
+		result.Checked++
+		proposed := common.Hash(proposal.OutputRoot)
+		if proposed == common.Hash(output.OutputRoot) {
+			continue
+		}
+		m.logger.Error("Proposed output root differs from the rollup node", "index", index, "l2BlockNumber", blockNum,
+			"proposedRoot", proposed, "expectedRoot", common.Hash(output.OutputRoot))
+		if result.FirstMismatch < 0 {
+			result.FirstMismatch = int64(index)
+		}
+	}
+
+	m.metrics.RecordOutputsMatch(result.FirstMismatch < 0)
+	m.metrics.RecordFirstMismatch(result.FirstMismatch)
+	m.metrics.RecordProposalLag(result.ProposalLag)
+	m.logger.Info("Checked outputs", "checked", result.Checked, "nextIndex", end, "firstMismatch", result.FirstMismatch, "lag", result.ProposalLag)
+	return result, nil
+}
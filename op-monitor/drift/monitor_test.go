@@ -0,0 +1,233 @@
+package drift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var errNotFound = errors.New("not found")
+
+// outputRoot is the output root that the rollup node computes for an L2 block
+func outputRoot(blockNum uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(1000 + blockNum))
+}
+
+func TestCheckOutputs(t *testing.T) {
+	t.Run("NoOutputs", func(t *testing.T) {
+		monitor, _, _, m, _ := setupMonitorTest(t, 10)
+		result, err := monitor.CheckOutputs(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, &Result{FirstMismatch: -1}, result)
+		require.True(t, m.match)
+		require.Equal(t, int64(-1), m.firstMismatch)
+	})
+
+	t.Run("Agreement", func(t *testing.T) {
+		monitor, oracle, rollup, m, logs := setupMonitorTest(t, 10)
+		for i := uint64(1); i <= 5; i++ {
+			oracle.propose(i*10, outputRoot(i*10))
+		}
+		rollup.safeHead = 55
+
+		result, err := monitor.CheckOutputs(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, &Result{Checked: 5, FirstMismatch: -1, ProposalLag: 5}, result)
+		require.True(t, m.match)
+		require.Equal(t, int64(-1), m.firstMismatch)
+		require.Equal(t, uint64(5), m.proposalLag)
+		require.Nil(t, logs.FindLog(log.LvlError, "Proposed output root differs from the rollup node"))
+	})
+
+	t.Run("Divergence", func(t *testing.T) {
+		monitor, oracle, rollup, m, logs := setupMonitorTest(t, 10)
+		oracle.propose(10, outputRoot(10))
+		oracle.propose(20, common.Hash{0xaa})
+		oracle.propose(30, outputRoot(30))
+		oracle.propose(40, common.Hash{0xbb})
+		rollup.safeHead = 40
+
+		result, err := monitor.CheckOutputs(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, &Result{Checked: 4, FirstMismatch: 1}, result)
+		require.False(t, m.match)
+		require.Equal(t, int64(1), m.firstMismatch)
+		require.Zero(t, m.proposalLag)
+
+		l := logs.FindLog(log.LvlError, "Proposed output root differs from the rollup node")
+		require.NotNil(t, l)
+		require.Equal(t, uint64(1), l.GetContextValue("index"))
+		require.Equal(t, uint64(20), l.GetContextValue("l2BlockNumber"))
+		require.Equal(t, common.Hash{0xaa}, l.GetContextValue("proposedRoot"))
+		require.Equal(t, outputRoot(20), l.GetContextValue("expectedRoot"))
+	})
+
+	t.Run("OnlyLatestOutputs", func(t *testing.T) {
+		monitor, oracle, rollup, m, _ := setupMonitorTest(t, 2)
+		// the diverging output is older than the checked outputs
+		oracle.propose(10, common.Hash{0xaa})
+		oracle.propose(20, outputRoot(20))
+		oracle.propose(30, outputRoot(30))
+		rollup.safeHead = 30
+
+		result, err := monitor.CheckOutputs(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, &Result{Checked: 2, FirstMismatch: -1}, result)
+		require.True(t, m.match)
+		require.Equal(t, []uint64{20, 30}, rollup.requested)
+	})
+
+	t.Run("SkipBeyondSafeHead", func(t *testing.T) {
+		monitor, oracle, rollup, m, _ := setupMonitorTest(t, 10)
+		oracle.propose(10, outputRoot(10))
+		// the rollup node did not derive the block of the output yet, so cannot disagree with it
+		oracle.propose(20, common.Hash{0xaa})
+		rollup.safeHead = 15
+
+		result, err := monitor.CheckOutputs(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, &Result{Checked: 1, FirstMismatch: -1}, result)
+		require.True(t, m.match)
+		require.Equal(t, []uint64{10}, rollup.requested)
+	})
+
+	t.Run("RollupError", func(t *testing.T) {
+		monitor, oracle, rollup, m, _ := setupMonitorTest(t, 10)
+		oracle.propose(10, outputRoot(10))
+		rollup.safeHead = 10
+		rollup.outputErr = errNotFound
+
+		_, err := monitor.CheckOutputs(context.Background())
+		require.ErrorIs(t, err, errNotFound)
+		require.Zero(t, m.checks, "metrics are not updated when the check fails")
+	})
+
+	t.Run("OracleError", func(t *testing.T) {
+		monitor, oracle, _, m, _ := setupMonitorTest(t, 10)
+		oracle.err = errNotFound
+
+		_, err := monitor.CheckOutputs(context.Background())
+		require.ErrorIs(t, err, errNotFound)
+		require.Zero(t, m.checks)
+	})
+}
+
+func TestMonitorLoop(t *testing.T) {
+	monitor, oracle, rollup, m, _ := setupMonitorTest(t, 10)
+	oracle.propose(10, common.Hash{0xaa})
+	rollup.safeHead = 10
+
+	monitor.Start()
+	defer monitor.Stop()
+	// the outputs are checked when the monitor starts, before the first tick
+	require.Eventually(t, func() bool {
+		return m.checkCount() > 0
+	}, 10*time.Second, 10*time.Millisecond)
+	monitor.Stop()
+	require.False(t, m.match)
+}
+
+func setupMonitorTest(t *testing.T, outputs uint64) (*Monitor, *stubOracle, *stubRollupClient, *stubMetrics, *testlog.CapturingHandler) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	logs := testlog.Capture(logger)
+	oracle := &stubOracle{}
+	rollup := &stubRollupClient{}
+	m := &stubMetrics{}
+	monitor := NewMonitor(logger, m, clock.SystemClock, oracle, rollup, time.Hour, outputs)
+	return monitor, oracle, rollup, m, logs
+}
+
+type stubOracle struct {
+	outputs []bindings.TypesOutputProposal
+	err     error
+}
+
+func (s *stubOracle) propose(blockNum uint64, root common.Hash) {
+	s.outputs = append(s.outputs, bindings.TypesOutputProposal{
+		OutputRoot:    root,
+		Timestamp:     big.NewInt(int64(len(s.outputs))),
+		L2BlockNumber: new(big.Int).SetUint64(blockNum),
+	})
+}
+
+func (s *stubOracle) NextOutputIndex(opts *bind.CallOpts) (*big.Int, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return big.NewInt(int64(len(s.outputs))), nil
+}
+
+func (s *stubOracle) GetL2Output(opts *bind.CallOpts, index *big.Int) (bindings.TypesOutputProposal, error) {
+	if !index.IsUint64() || index.Uint64() >= uint64(len(s.outputs)) {
+		return bindings.TypesOutputProposal{}, fmt.Errorf("output %v: %w", index, errNotFound)
+	}
+	return s.outputs[index.Uint64()], nil
+}
+
+type stubRollupClient struct {
+	safeHead  uint64
+	outputErr error
+	requested []uint64
+}
+
+func (s *stubRollupClient) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	if s.outputErr != nil {
+		return nil, s.outputErr
+	}
+	s.requested = append(s.requested, blockNum)
+	return &eth.OutputResponse{
+		OutputRoot: eth.Bytes32(outputRoot(blockNum)),
+		BlockRef:   eth.L2BlockRef{Number: blockNum},
+	}, nil
+}
+
+func (s *stubRollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	return &eth.SyncStatus{SafeL2: eth.L2BlockRef{Number: s.safeHead}}, nil
+}
+
+type stubMetrics struct {
+	mu            sync.Mutex
+	checks        int
+	match         bool
+	firstMismatch int64
+	proposalLag   uint64
+}
+
+func (s *stubMetrics) checkCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checks
+}
+
+func (s *stubMetrics) RecordOutputsMatch(match bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checks++
+	s.match = match
+}
+
+func (s *stubMetrics) RecordFirstMismatch(index int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.firstMismatch = index
+}
+
+func (s *stubMetrics) RecordProposalLag(blocks uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.proposalLag = blocks
+}
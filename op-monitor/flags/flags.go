@@ -0,0 +1,106 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-monitor/config"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
+)
+
+const (
+	envVarPrefix = "OP_MONITOR"
+)
+
+func prefixEnvVars(name string) []string {
+	return opservice.PrefixEnvVar(envVarPrefix, name)
+}
+
+var (
+	// Required Flags
+	L1EthRpcFlag = &cli.StringFlag{
+		Name:    "l1-eth-rpc",
+		Usage:   "HTTP provider URL for L1.",
+		EnvVars: prefixEnvVars("L1_ETH_RPC"),
+	}
+	RollupRpcFlag = &cli.StringFlag{
+		Name:    "rollup-rpc",
+		Usage:   "HTTP provider URL for the rollup node, of which the outputs are compared to the proposed outputs",
+		EnvVars: prefixEnvVars("ROLLUP_RPC"),
+	}
+	L2OOAddressFlag = &cli.StringFlag{
+		Name:    "l2oo-address",
+		Usage:   "Address of the L2OutputOracle contract",
+		EnvVars: prefixEnvVars("L2OO_ADDRESS"),
+	}
+	// Optional Flags
+	PollIntervalFlag = &cli.DurationFlag{
+		Name:    "poll-interval",
+		Usage:   "Interval at which the proposed outputs are checked",
+		EnvVars: prefixEnvVars("POLL_INTERVAL"),
+		Value:   config.DefaultPollInterval,
+	}
+	CheckedOutputsFlag = &cli.Uint64Flag{
+		Name:    "checked-outputs",
+		Usage:   "Number of the latest proposed outputs to check every interval",
+		EnvVars: prefixEnvVars("CHECKED_OUTPUTS"),
+		Value:   config.DefaultCheckedOutputs,
+	}
+)
+
+// requiredFlags are checked by [CheckRequired]
+var requiredFlags = []cli.Flag{
+	L1EthRpcFlag,
+	RollupRpcFlag,
+	L2OOAddressFlag,
+}
+
+// optionalFlags is a list of unchecked cli flags
+var optionalFlags = []cli.Flag{
+	PollIntervalFlag,
+	CheckedOutputsFlag,
+}
+
+func init() {
+	optionalFlags = append(optionalFlags, oplog.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, oppprof.CLIFlags(envVarPrefix)...)
+
+	Flags = append(requiredFlags, optionalFlags...)
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func CheckRequired(ctx *cli.Context) error {
+	for _, f := range requiredFlags {
+		if !ctx.IsSet(f.Names()[0]) {
+			return fmt.Errorf("flag %s is required", f.Names()[0])
+		}
+	}
+	return nil
+}
+
+// NewConfigFromCLI parses the Config from the provided flags or environment variables.
+func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
+	if err := CheckRequired(ctx); err != nil {
+		return nil, err
+	}
+	l2ooAddress, err := opservice.ParseAddress(ctx.String(L2OOAddressFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	return &config.Config{
+		L1EthRpc:       ctx.String(L1EthRpcFlag.Name),
+		RollupRpc:      ctx.String(RollupRpcFlag.Name),
+		L2OOAddress:    l2ooAddress,
+		PollInterval:   ctx.Duration(PollIntervalFlag.Name),
+		CheckedOutputs: ctx.Uint64(CheckedOutputsFlag.Name),
+		MetricsConfig:  opmetrics.ReadCLIConfig(ctx),
+		PprofConfig:    oppprof.ReadCLIConfig(ctx),
+	}, nil
+}
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const Namespace = "op_monitor"
+
+type Metricer interface {
+	RecordInfo(version string)
+	RecordUp()
+
+	RecordOutputsMatch(match bool)
+	RecordFirstMismatch(index int64)
+	RecordProposalLag(blocks uint64)
+}
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	info prometheus.GaugeVec
+	up   prometheus.Gauge
+
+	outputsMatch  prometheus.Gauge
+	firstMismatch prometheus.Gauge
+	proposalLag   prometheus.Gauge
+}
+
+var _ Metricer = (*Metrics)(nil)
+
+func NewMetrics() *Metrics {
+	registry := opmetrics.NewRegistry()
+	factory := opmetrics.With(registry)
+
+	return &Metrics{
+		registry: registry,
+
+		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "info",
+			Help:      "Pseudo-metric tracking version and config info",
+		}, []string{
+			"version",
+		}),
+		up: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "up",
+			Help:      "1 if the op-monitor has finished starting up",
+		}),
+		outputsMatch: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "outputs_match",
+			Help:      "1 if the latest proposed outputs match the outputs of the rollup node, 0 if any differs",
+		}),
+		firstMismatch: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "first_mismatch_index",
+			Help:      "Index of the first proposed output that differs from the output of the rollup node, -1 if none",
+		}),
+		proposalLag: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "proposal_lag_blocks",
+			Help:      "Number of L2 blocks the safe head of the rollup node is ahead of the latest proposed output",
+		}),
+	}
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+func (m *Metrics) RecordInfo(version string) {
+	m.info.WithLabelValues(version).Set(1)
+}
+
+// RecordUp sets the up metric to 1.
+func (m *Metrics) RecordUp() {
+	m.up.Set(1)
+}
+
+func (m *Metrics) RecordOutputsMatch(match bool) {
+	if match {
+		m.outputsMatch.Set(1)
+	} else {
+		m.outputsMatch.Set(0)
+	}
+}
+
+func (m *Metrics) RecordFirstMismatch(index int64) {
+	m.firstMismatch.Set(float64(index))
+}
+
+func (m *Metrics) RecordProposalLag(blocks uint64) {
+	m.proposalLag.Set(float64(blocks))
+}
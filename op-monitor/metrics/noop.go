@@ -0,0 +1,12 @@
+package metrics
+
+type NoopMetricsImpl struct{}
+
+var NoopMetrics Metricer = new(NoopMetricsImpl)
+
+func (*NoopMetricsImpl) RecordInfo(version string) {}
+func (*NoopMetricsImpl) RecordUp()                 {}
+
+func (*NoopMetricsImpl) RecordOutputsMatch(match bool)   {}
+func (*NoopMetricsImpl) RecordFirstMismatch(index int64) {}
+func (*NoopMetricsImpl) RecordProposalLag(blocks uint64) {}
@@ -0,0 +1,162 @@
+package op_monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-monitor/config"
+	"github.com/ethereum-optimism/optimism/op-monitor/drift"
+	"github.com/ethereum-optimism/optimism/op-monitor/metrics"
+	"github.com/ethereum-optimism/optimism/op-monitor/version"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+// Main is the programmatic entry-point for running op-monitor with a given configuration.
+func Main(ctx context.Context, logger log.Logger, cfg *config.Config) (cliapp.Lifecycle, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	return NewService(ctx, logger, cfg)
+}
+
+type Service struct {
+	logger  log.Logger
+	metrics metrics.Metricer
+	monitor *drift.Monitor
+
+	l1Client     *ethclient.Client
+	rollupClient *sources.RollupClient
+
+	pprofSrv   *httputil.HTTPServer
+	metricsSrv *httputil.HTTPServer
+
+	stopped atomic.Bool
+}
+
+var _ cliapp.Lifecycle = (*Service)(nil)
+
+// NewService creates a new Service.
+func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Service, error) {
+	s := &Service{
+		logger:  logger,
+		metrics: metrics.NewMetrics(),
+	}
+
+	if err := s.initFromConfig(ctx, cfg); err != nil {
+		// upon initialization error we can try to close any of the service components that may have started already.
+		return nil, errors.Join(fmt.Errorf("failed to init monitor service: %w", err), s.Stop(ctx))
+	}
+
+	return s, nil
+}
+
+func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error {
+	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, cfg.L1EthRpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial L1: %w", err)
+	}
+	s.l1Client = l1Client
+	rollupClient, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, cfg.RollupRpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial rollup node: %w", err)
+	}
+	s.rollupClient = rollupClient
+	if err := s.initPProfServer(&cfg.PprofConfig); err != nil {
+		return err
+	}
+	if err := s.initMetricsServer(&cfg.MetricsConfig); err != nil {
+		return err
+	}
+
+	oracle, err := bindings.NewL2OutputOracleCaller(cfg.L2OOAddress, s.l1Client)
+	if err != nil {
+		return fmt.Errorf("failed to bind the L2OutputOracle: %w", err)
+	}
+	s.monitor = drift.NewMonitor(s.logger, s.metrics, clock.SystemClock, oracle, s.rollupClient, cfg.PollInterval, cfg.CheckedOutputs)
+
+	s.metrics.RecordInfo(version.SimpleWithMeta)
+	s.metrics.RecordUp()
+	return nil
+}
+
+func (s *Service) initPProfServer(cfg *oppprof.CLIConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	s.logger.Debug("starting pprof", "addr", cfg.ListenAddr, "port", cfg.ListenPort)
+	pprofSrv, err := oppprof.StartServer(cfg.ListenAddr, cfg.ListenPort)
+	if err != nil {
+		return fmt.Errorf("failed to start pprof server: %w", err)
+	}
+	s.pprofSrv = pprofSrv
+	s.logger.Info("started pprof server", "addr", pprofSrv.Addr())
+	return nil
+}
+
+func (s *Service) initMetricsServer(cfg *opmetrics.CLIConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	s.logger.Debug("starting metrics server", "addr", cfg.ListenAddr, "port", cfg.ListenPort)
+	m, ok := s.metrics.(opmetrics.RegistryMetricer)
+	if !ok {
+		return fmt.Errorf("metrics were enabled, but metricer %T does not expose registry for metrics-server", s.metrics)
+	}
+	metricsSrv, err := opmetrics.StartServer(m.Registry(), cfg.ListenAddr, cfg.ListenPort)
+	if err != nil {
+		return fmt.Errorf("failed to start metrics server: %w", err)
+	}
+	s.logger.Info("started metrics server", "addr", metricsSrv.Addr())
+	s.metricsSrv = metricsSrv
+	return nil
+}
+
+func (s *Service) Start(ctx context.Context) error {
+	s.logger.Info("starting output drift monitor")
+	s.monitor.Start()
+	return nil
+}
+
+func (s *Service) Stopped() bool {
+	return s.stopped.Load()
+}
+
+func (s *Service) Stop(ctx context.Context) error {
+	s.logger.Info("stopping monitor service")
+
+	var result error
+	if s.monitor != nil {
+		s.monitor.Stop()
+	}
+	if s.rollupClient != nil {
+		s.rollupClient.Close()
+	}
+	if s.l1Client != nil {
+		s.l1Client.Close()
+	}
+	if s.pprofSrv != nil {
+		if err := s.pprofSrv.Stop(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close pprof server: %w", err))
+		}
+	}
+	if s.metricsSrv != nil {
+		if err := s.metricsSrv.Stop(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close metrics server: %w", err))
+		}
+	}
+	s.stopped.Store(true)
+	s.logger.Info("stopped monitor service", "err", result)
+	return result
+}
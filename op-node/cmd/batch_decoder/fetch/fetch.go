@@ -61,10 +61,15 @@ func Batches(client *ethclient.Client, config Config) (totalValid, totalInvalid
 		}
 		number := i
 		g.Go(func() error {
-			valid, invalid, err := fetchBatchesPerBlock(ctx, client, number, signer, config)
+			txms, valid, invalid, err := fetchBatchesPerBlock(ctx, client, number, signer, config)
 			if err != nil {
 				return fmt.Errorf("error occurred while fetching block %d: %w", number, err)
 			}
+			for _, txm := range txms {
+				if err := writeTransaction(txm, config.OutDirectory); err != nil {
+					return fmt.Errorf("error occurred while writing transactions of block %d: %w", number, err)
+				}
+			}
 			atomic.AddUint64(&totalValid, valid)
 			atomic.AddUint64(&totalInvalid, invalid)
 			return nil
@@ -76,26 +81,57 @@ func Batches(client *ethclient.Client, config Config) (totalValid, totalInvalid
 	return
 }
 
+// Transactions fetches all transactions sent to the batch inbox address in the given block range
+// (inclusive to exclusive) without storing them, ordered as they are processed in derivation.
+func Transactions(client *ethclient.Client, config Config) ([]TransactionWithMetadata, error) {
+	signer := types.LatestSignerForChainID(config.ChainID)
+	g, ctx := errgroup.WithContext(context.Background())
+	g.SetLimit(int(config.ConcurrentRequests))
+
+	txmsPerBlock := make([][]TransactionWithMetadata, config.End-config.Start)
+	for i := config.Start; i < config.End; i++ {
+		number := i
+		g.Go(func() error {
+			txms, _, _, err := fetchBatchesPerBlock(ctx, client, number, signer, config)
+			if err != nil {
+				return fmt.Errorf("error occurred while fetching block %d: %w", number, err)
+			}
+			txmsPerBlock[number-config.Start] = txms
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var out []TransactionWithMetadata
+	for _, txms := range txmsPerBlock {
+		out = append(out, txms...)
+	}
+	return out, nil
+}
+
 // fetchBatchesPerBlock gets a block & the parses all of the transactions in the block.
-func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, number uint64, signer types.Signer, config Config) (uint64, uint64, error) {
+func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, number uint64, signer types.Signer, config Config) ([]TransactionWithMetadata, uint64, uint64, error) {
 	validBatchCount := uint64(0)
 	invalidBatchCount := uint64(0)
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	block, err := client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
 	if err != nil {
-		return 0, 0, err
+		return nil, 0, 0, err
 	}
-	fmt.Println("Fetched block: ", number)
+	fmt.Fprintln(os.Stderr, "Fetched block: ", number)
+	var txms []TransactionWithMetadata
 	for i, tx := range block.Transactions() {
 		if tx.To() != nil && *tx.To() == config.BatchInbox {
 			sender, err := signer.Sender(tx)
 			if err != nil {
-				return 0, 0, err
+				return nil, 0, 0, err
 			}
 			validSender := true
 			if _, ok := config.BatchSenders[sender]; !ok {
-				fmt.Printf("Found a transaction (%s) from an invalid sender (%s)\n", tx.Hash().String(), sender.String())
+				fmt.Fprintf(os.Stderr, "Found a transaction (%s) from an invalid sender (%s)\n", tx.Hash().String(), sender.String())
 				invalidBatchCount += 1
 				validSender = false
 			}
@@ -104,7 +140,7 @@ func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, number
 			frameError := ""
 			frames, err := derive.ParseFrames(tx.Data())
 			if err != nil {
-				fmt.Printf("Found a transaction (%s) with invalid data: %v\n", tx.Hash().String(), err)
+				fmt.Fprintf(os.Stderr, "Found a transaction (%s) with invalid data: %v\n", tx.Hash().String(), err)
 				validFrames = false
 				frameError = err.Error()
 			}
@@ -115,7 +151,7 @@ func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, number
 				invalidBatchCount += 1
 			}
 
-			txm := &TransactionWithMetadata{
+			txms = append(txms, TransactionWithMetadata{
 				Tx:          tx,
 				Sender:      sender,
 				ValidSender: validSender,
@@ -128,18 +164,20 @@ func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, number
 				Frames:      frames,
 				FrameErr:    frameError,
 				ValidFrames: validFrames,
-			}
-			filename := path.Join(config.OutDirectory, fmt.Sprintf("%s.json", tx.Hash().String()))
-			file, err := os.Create(filename)
-			if err != nil {
-				return 0, 0, err
-			}
-			defer file.Close()
-			enc := json.NewEncoder(file)
-			if err := enc.Encode(txm); err != nil {
-				return 0, 0, err
-			}
+			})
 		}
 	}
-	return validBatchCount, invalidBatchCount, nil
+	return txms, validBatchCount, invalidBatchCount, nil
+}
+
+// writeTransaction stores the transaction & its metadata as a JSON file named after the transaction hash
+func writeTransaction(txm TransactionWithMetadata, directory string) error {
+	filename := path.Join(directory, fmt.Sprintf("%s.json", txm.Tx.Hash().String()))
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	enc := json.NewEncoder(file)
+	return enc.Encode(txm)
 }
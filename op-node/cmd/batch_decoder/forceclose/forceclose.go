@@ -0,0 +1,80 @@
+package forceclose
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// L1Client is the part of ethclient.Client that is needed to build and submit a transaction.
+type L1Client interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// TxParams are the chain and fee parameters of a transaction to the batch inbox.
+type TxParams struct {
+	ChainID   *big.Int
+	Nonce     uint64
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+}
+
+// FetchTxParams fetches the parameters of the next transaction of the account. The fee cap allows
+// the base fee to double before the transaction is included.
+func FetchTxParams(ctx context.Context, client L1Client, from common.Address) (TxParams, error) {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return TxParams{}, fmt.Errorf("failed to fetch chain ID: %w", err)
+	}
+	nonce, err := client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return TxParams{}, fmt.Errorf("failed to fetch nonce of %s: %w", from, err)
+	}
+	tip, err := client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return TxParams{}, fmt.Errorf("failed to fetch gas tip cap: %w", err)
+	}
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return TxParams{}, fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	if head.BaseFee == nil {
+		return TxParams{}, fmt.Errorf("L1 head %s has no base fee", head.Hash())
+	}
+	feeCap := new(big.Int).Add(tip, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+	return TxParams{ChainID: chainID, Nonce: nonce, GasTipCap: tip, GasFeeCap: feeCap}, nil
+}
+
+// SignedTx signs a transaction with the data to the batch inbox. The data is only read by the derivation
+// pipeline if the key is the key of the batcher. The gas limit is the intrinsic gas of the data.
+func SignedTx(key *ecdsa.PrivateKey, inbox common.Address, data []byte, params TxParams) (*types.Transaction, error) {
+	gas, err := core.IntrinsicGas(data, nil, false, true, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute intrinsic gas: %w", err)
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   params.ChainID,
+		Nonce:     params.Nonce,
+		GasTipCap: params.GasTipCap,
+		GasFeeCap: params.GasFeeCap,
+		Gas:       gas,
+		To:        &inbox,
+		Data:      data,
+	})
+	return types.SignTx(tx, types.LatestSignerForChainID(params.ChainID), key)
+}
+
+// Sender returns the address of the key, that the transaction is sent from.
+func Sender(key *ecdsa.PrivateKey) common.Address {
+	return crypto.PubkeyToAddress(key.PublicKey)
+}
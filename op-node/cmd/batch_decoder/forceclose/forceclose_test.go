@@ -0,0 +1,84 @@
+package forceclose
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+var inbox = common.HexToAddress("0xff00000000000000000000000000000000000010")
+
+type stubL1Client struct {
+	nonce   uint64
+	baseFee *big.Int
+	sent    []*types.Transaction
+}
+
+func (s *stubL1Client) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(900), nil
+}
+
+func (s *stubL1Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return s.nonce, nil
+}
+
+func (s *stubL1Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(params.GWei), nil
+}
+
+func (s *stubL1Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(100), BaseFee: s.baseFee}, nil
+}
+
+func (s *stubL1Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	s.sent = append(s.sent, tx)
+	return nil
+}
+
+func TestSignedTx(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	id := derive.ChannelID{0xde, 0xad}
+	data, err := derive.CloseChannelTxData(id, []derive.Frame{{ID: id, FrameNumber: 0}, {ID: id, FrameNumber: 1}})
+	require.NoError(t, err)
+
+	client := &stubL1Client{nonce: 7, baseFee: big.NewInt(10 * params.GWei)}
+	txParams, err := FetchTxParams(context.Background(), client, Sender(key))
+	require.NoError(t, err)
+	require.Equal(t, TxParams{
+		ChainID:   big.NewInt(900),
+		Nonce:     7,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(21 * params.GWei),
+	}, txParams)
+
+	tx, err := SignedTx(key, inbox, data, txParams)
+	require.NoError(t, err)
+	require.Equal(t, &inbox, tx.To())
+	require.Equal(t, data, tx.Data())
+	require.Equal(t, uint64(7), tx.Nonce())
+	require.Equal(t, txParams.GasFeeCap, tx.GasFeeCap())
+	// the data is 1 zero byte for the version and 23 bytes per frame, of which 4 are non-zero
+	require.Equal(t, params.TxGas+uint64(1+23-4)*params.TxDataZeroGas+4*params.TxDataNonZeroGasEIP2028, tx.Gas())
+	sender, err := types.Sender(types.LatestSignerForChainID(txParams.ChainID), tx)
+	require.NoError(t, err)
+	require.Equal(t, Sender(key), sender)
+
+	// the derivation pipeline reads the empty closing frame from the transaction
+	frames, err := derive.ParseFrames(tx.Data())
+	require.NoError(t, err)
+	require.Equal(t, []derive.Frame{{ID: id, FrameNumber: 2, Data: []byte{}, IsLast: true}}, frames)
+}
+
+func TestFetchTxParamsWithoutBaseFee(t *testing.T) {
+	_, err := FetchTxParams(context.Background(), &stubL1Client{}, common.Address{})
+	require.ErrorContains(t, err, "no base fee")
+}
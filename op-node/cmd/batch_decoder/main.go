@@ -2,17 +2,22 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"math/big"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/fetch"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/forceclose"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/reassemble"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/urfave/cli/v2"
 )
@@ -165,9 +170,105 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:  "decode",
+			Usage: "Fetches batches in the specified range, reassembles the channels & prints the decoded batches as JSON",
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:     "start",
+					Required: true,
+					Usage:    "First block (inclusive) to fetch",
+				},
+				&cli.IntFlag{
+					Name:     "end",
+					Required: true,
+					Usage:    "Last block (exclusive) to fetch",
+				},
+				&cli.StringFlag{
+					Name:     "inbox",
+					Required: true,
+					Usage:    "Batch Inbox Address",
+				},
+				&cli.StringFlag{
+					Name:     "sender",
+					Required: true,
+					Usage:    "Batch Sender Address",
+				},
+				&cli.StringFlag{
+					Name:     "l1",
+					Required: true,
+					Usage:    "L1 RPC URL",
+					EnvVars:  []string{"L1_RPC"},
+				},
+				&cli.IntFlag{
+					Name:  "concurrent-requests",
+					Value: 10,
+					Usage: "Concurrency level when fetching L1",
+				},
+				&cli.Uint64Flag{
+					Name:  "l2-chain-id",
+					Value: 10,
+					Usage: "L2 chain id for span batch derivation. Default value from op-mainnet.",
+				},
+				&cli.Uint64Flag{
+					Name:  "l2-genesis-timestamp",
+					Value: 1686068903,
+					Usage: "L2 genesis time for span batch derivation. Default value from op-mainnet.",
+				},
+				&cli.Uint64Flag{
+					Name:  "l2-block-time",
+					Value: 2,
+					Usage: "L2 block time for span batch derivation. Default value from op-mainnet.",
+				},
+			},
+			Action: func(cliCtx *cli.Context) error {
+				client, err := ethclient.Dial(cliCtx.String("l1"))
+				if err != nil {
+					log.Fatal(err)
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+				defer cancel()
+				chainID, err := client.ChainID(ctx)
+				if err != nil {
+					log.Fatal(err)
+				}
+				fetchConfig := fetch.Config{
+					Start:   uint64(cliCtx.Int("start")),
+					End:     uint64(cliCtx.Int("end")),
+					ChainID: chainID,
+					BatchSenders: map[common.Address]struct{}{
+						common.HexToAddress(cliCtx.String("sender")): {},
+					},
+					BatchInbox:         common.HexToAddress(cliCtx.String("inbox")),
+					ConcurrentRequests: uint64(cliCtx.Int("concurrent-requests")),
+				}
+				txns, err := fetch.Transactions(client, fetchConfig)
+				if err != nil {
+					log.Fatal(err)
+				}
+				config := reassemble.Config{
+					BatchInbox:    fetchConfig.BatchInbox,
+					L2ChainID:     new(big.Int).SetUint64(cliCtx.Uint64("l2-chain-id")),
+					L2GenesisTime: cliCtx.Uint64("l2-genesis-timestamp"),
+					L2BlockTime:   cliCtx.Uint64("l2-block-time"),
+				}
+				// The channels are printed as JSON lines, while the progress is reported on stderr
+				enc := json.NewEncoder(os.Stdout)
+				for _, ch := range reassemble.ReassembleTransactions(config, txns) {
+					if !ch.IsReady {
+						fmt.Fprintf(os.Stderr, "Channel %v is incomplete in range [%v,%v). Missing frames: %v. Closed: %v\n",
+							ch.ID, fetchConfig.Start, fetchConfig.End, ch.Stats.MissingFrames, ch.Stats.IsClosed)
+					}
+					if err := enc.Encode(ch.Summary()); err != nil {
+						log.Fatal(err)
+					}
+				}
+				return nil
+			},
+		},
 		{
 			Name:  "force-close",
-			Usage: "Create the tx data which will force close a channel",
+			Usage: "Create the tx data, or a signed tx, which will force close a channel",
 			Flags: []cli.Flag{
 				&cli.StringFlag{
 					Name:     "id",
@@ -184,24 +285,94 @@ func main() {
 					Value: "/tmp/batch_decoder/transactions_cache",
 					Usage: "Cache directory for the found transactions",
 				},
+				&cli.UintSliceFlag{
+					Name:  "frames",
+					Usage: "(Optional) Numbers of the frames of the channel on L1, none of which is the last frame, instead of the frames of the cache",
+				},
+				&cli.StringFlag{
+					Name:  "private-key",
+					Usage: "(Optional) Hex private key of the batcher, to sign a transaction with the tx data to the batch inbox",
+				},
+				&cli.StringFlag{
+					Name:    "l1",
+					Usage:   "L1 RPC URL, to fetch the nonce & fees of the signed transaction",
+					EnvVars: []string{"L1_RPC"},
+				},
+				&cli.BoolFlag{
+					Name:  "send",
+					Usage: "Submit the signed transaction to L1",
+				},
 			},
 			Action: func(cliCtx *cli.Context) error {
 				var id derive.ChannelID
 				if err := (&id).UnmarshalText([]byte(cliCtx.String("id"))); err != nil {
 					log.Fatal(err)
 				}
-				frames := reassemble.LoadFrames(cliCtx.String("in"), common.HexToAddress(cliCtx.String("inbox")))
-				var filteredFrames []derive.Frame
-				for _, frame := range frames {
-					if frame.Frame.ID == id {
-						filteredFrames = append(filteredFrames, frame.Frame)
+				inbox := common.HexToAddress(cliCtx.String("inbox"))
+				var channelFrames []derive.Frame
+				if cliCtx.IsSet("frames") {
+					for _, number := range cliCtx.UintSlice("frames") {
+						if number > math.MaxUint16 {
+							log.Fatalf("invalid frame number %d", number)
+						}
+						channelFrames = append(channelFrames, derive.Frame{ID: id, FrameNumber: uint16(number)})
 					}
+				} else {
+					for _, frame := range reassemble.LoadFrames(cliCtx.String("in"), inbox) {
+						if frame.Frame.ID == id {
+							channelFrames = append(channelFrames, frame.Frame)
+						}
+					}
+				}
+				data, err := derive.CloseChannelTxData(id, channelFrames)
+				if err != nil {
+					log.Fatal(err)
+				}
+				if !cliCtx.IsSet("private-key") {
+					if cliCtx.Bool("send") {
+						log.Fatal("must specify --private-key to send the transaction")
+					}
+					fmt.Printf("%x\n", data)
+					return nil
+				}
+
+				if inbox == (common.Address{}) {
+					log.Fatal("must specify --inbox to sign the transaction")
+				}
+				if !cliCtx.IsSet("l1") {
+					log.Fatal("must specify --l1 to sign the transaction")
 				}
-				data, err := derive.ForceCloseTxData(filteredFrames)
+				key, err := crypto.HexToECDSA(strings.TrimPrefix(cliCtx.String("private-key"), "0x"))
 				if err != nil {
+					log.Fatal(fmt.Errorf("invalid private key: %w", err))
+				}
+				ctx, cancel := context.WithTimeout(cliCtx.Context, 30*time.Second)
+				defer cancel()
+				client, err := ethclient.DialContext(ctx, cliCtx.String("l1"))
+				if err != nil {
+					log.Fatal(err)
+				}
+				defer client.Close()
+				txParams, err := forceclose.FetchTxParams(ctx, client, forceclose.Sender(key))
+				if err != nil {
+					log.Fatal(err)
+				}
+				tx, err := forceclose.SignedTx(key, inbox, data, txParams)
+				if err != nil {
+					log.Fatal(err)
+				}
+				if !cliCtx.Bool("send") {
+					raw, err := tx.MarshalBinary()
+					if err != nil {
+						log.Fatal(err)
+					}
+					fmt.Printf("%x\n", raw)
+					return nil
+				}
+				if err := client.SendTransaction(ctx, tx); err != nil {
 					log.Fatal(err)
 				}
-				fmt.Printf("%x\n", data)
+				fmt.Printf("Sent transaction %s closing channel %v from %s\n", tx.Hash(), id, forceclose.Sender(key))
 				return nil
 			},
 		},
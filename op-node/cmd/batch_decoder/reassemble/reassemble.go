@@ -1,6 +1,8 @@
 package reassemble
 
 import (
+	"bytes"
+	"compress/zlib"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -24,6 +26,55 @@ type ChannelWithMetadata struct {
 	Frames         []FrameWithMetadata `json:"frames"`
 	Batches        []derive.Batch      `json:"batches"`
 	BatchTypes     []int               `json:"batch_types"`
+	BatchSummaries []BatchSummary      `json:"batch_summaries"`
+	Stats          ChannelStats        `json:"stats"`
+}
+
+// ChannelStats describes the frames of a channel & the data they carry.
+type ChannelStats struct {
+	Frames int `json:"frames"`
+	// IsClosed is set once the last frame of the channel is found
+	IsClosed bool `json:"is_closed"`
+	// MissingFrames are the frame numbers not found below the highest found frame number.
+	// Channels at the edges of the fetched range are missing their first or last frames.
+	MissingFrames []uint16 `json:"missing_frames"`
+	// CompressedSize is the size of the data of all frames, UncompressedSize is zero until the channel is ready
+	CompressedSize   int      `json:"compressed_size"`
+	UncompressedSize int      `json:"uncompressed_size"`
+	InclusionBlocks  []uint64 `json:"inclusion_blocks"`
+}
+
+// BatchSummary describes an L2 block of a batch. The blocks of a span batch are listed individually,
+// without the parent & epoch hashes which span batches only carry a prefix of.
+type BatchSummary struct {
+	BatchType  int         `json:"batch_type"`
+	EpochNum   uint64      `json:"epoch_num"`
+	EpochHash  common.Hash `json:"epoch_hash"`
+	ParentHash common.Hash `json:"parent_hash"`
+	Timestamp  uint64      `json:"timestamp"`
+	TxCount    int         `json:"tx_count"`
+}
+
+// ChannelSummary is the compact description of a channel, without the frames data & the batch transactions.
+type ChannelSummary struct {
+	ID             derive.ChannelID `json:"id"`
+	IsReady        bool             `json:"is_ready"`
+	InvalidFrames  bool             `json:"invalid_frames"`
+	InvalidBatches bool             `json:"invalid_batches"`
+	Stats          ChannelStats     `json:"stats"`
+	Batches        []BatchSummary   `json:"batches"`
+}
+
+// Summary describes the channel with its statistics & batch summaries
+func (ch ChannelWithMetadata) Summary() ChannelSummary {
+	return ChannelSummary{
+		ID:             ch.ID,
+		IsReady:        ch.IsReady,
+		InvalidFrames:  ch.InvalidFrames,
+		InvalidBatches: ch.InvalidBatches,
+		Stats:          ch.Stats,
+		Batches:        ch.BatchSummaries,
+	}
 }
 
 type FrameWithMetadata struct {
@@ -45,17 +96,7 @@ type Config struct {
 
 func LoadFrames(directory string, inbox common.Address) []FrameWithMetadata {
 	txns := loadTransactions(directory, inbox)
-	// Sort first by block number then by transaction index inside the block number range.
-	// This is to match the order they are processed in derivation.
-	sort.Slice(txns, func(i, j int) bool {
-		if txns[i].BlockNumber == txns[j].BlockNumber {
-			return txns[i].TxIndex < txns[j].TxIndex
-		} else {
-			return txns[i].BlockNumber < txns[j].BlockNumber
-		}
-
-	})
-	return transactionsToFrames(txns)
+	return TransactionsToFrames(txns)
 }
 
 // Channels loads all transactions from the given input directory that are submitted to the
@@ -66,17 +107,45 @@ func Channels(config Config) {
 		log.Fatal(err)
 	}
 	frames := LoadFrames(config.InDirectory, config.BatchInbox)
+	for _, ch := range ReassembleFrames(config, frames) {
+		filename := path.Join(config.OutDirectory, fmt.Sprintf("%s.json", ch.ID.String()))
+		if err := writeChannel(ch, filename); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// ReassembleTransactions re-assembles the channels from the frames of the transactions submitted by a valid
+// sender to the batch inbox, as fetched by fetch.Transactions.
+func ReassembleTransactions(config Config, txns []fetch.TransactionWithMetadata) []ChannelWithMetadata {
+	var valid []fetch.TransactionWithMetadata
+	for _, txm := range txns {
+		if txm.InboxAddr == config.BatchInbox && txm.ValidSender {
+			valid = append(valid, txm)
+		}
+	}
+	return ReassembleFrames(config, TransactionsToFrames(valid))
+}
+
+// ReassembleFrames re-assembles the frames into channels & decodes the batches of the ready channels.
+// The frames must be ordered as they are processed in derivation. Channels missing frames, i.e at the
+// edges of the range the frames were fetched from, are returned as not ready without batches.
+// The channels are ordered by their first frame.
+func ReassembleFrames(config Config, frames []FrameWithMetadata) []ChannelWithMetadata {
+	var ids []derive.ChannelID
 	framesByChannel := make(map[derive.ChannelID][]FrameWithMetadata)
 	for _, frame := range frames {
+		if _, ok := framesByChannel[frame.Frame.ID]; !ok {
+			ids = append(ids, frame.Frame.ID)
+		}
 		framesByChannel[frame.Frame.ID] = append(framesByChannel[frame.Frame.ID], frame)
 	}
-	for id, frames := range framesByChannel {
-		ch := processFrames(config, id, frames)
-		filename := path.Join(config.OutDirectory, fmt.Sprintf("%s.json", id.String()))
-		if err := writeChannel(ch, filename); err != nil {
-			log.Fatal(err)
-		}
+
+	channels := make([]ChannelWithMetadata, len(ids))
+	for i, id := range ids {
+		channels[i] = processFrames(config, id, framesByChannel[id])
 	}
+	return channels
 }
 
 func writeChannel(ch ChannelWithMetadata, filename string) error {
@@ -95,25 +164,32 @@ func processFrames(cfg Config, id derive.ChannelID, frames []FrameWithMetadata)
 
 	for _, frame := range frames {
 		if ch.IsReady() {
-			fmt.Printf("Channel %v is ready despite having more frames\n", id.String())
+			fmt.Fprintf(os.Stderr, "Channel %v is ready despite having more frames\n", id.String())
 			invalidFrame = true
 			break
 		}
 		if err := ch.AddFrame(frame.Frame, eth.L1BlockRef{Number: frame.InclusionBlock}); err != nil {
-			fmt.Printf("Error adding to channel %v. Err: %v\n", id.String(), err)
+			fmt.Fprintf(os.Stderr, "Error adding to channel %v. Err: %v\n", id.String(), err)
 			invalidFrame = true
 		}
 	}
 
+	stats := channelStats(frames)
 	var batches []derive.Batch
 	var batchTypes []int
+	var summaries []BatchSummary
 	invalidBatches := false
 	if ch.IsReady() {
-		br, err := derive.BatchReader(ch.Reader())
+		channelData, err := io.ReadAll(ch.Reader())
+		if err != nil {
+			log.Fatal(err)
+		}
+		stats.UncompressedSize = uncompressedSize(channelData)
+		br, err := derive.BatchReader(bytes.NewReader(channelData))
 		if err == nil {
 			for batchData, err := br(); err != io.EOF; batchData, err = br() {
 				if err != nil {
-					fmt.Printf("Error reading batchData for channel %v. Err: %v\n", id.String(), err)
+					fmt.Fprintf(os.Stderr, "Error reading batchData for channel %v. Err: %v\n", id.String(), err)
 					invalidBatches = true
 				} else {
 					batchType := batchData.GetBatchType()
@@ -123,28 +199,48 @@ func processFrames(cfg Config, id derive.ChannelID, frames []FrameWithMetadata)
 						singularBatch, err := derive.GetSingularBatch(batchData)
 						if err != nil {
 							invalidBatches = true
-							fmt.Printf("Error converting singularBatch from batchData for channel %v. Err: %v\n", id.String(), err)
+							fmt.Fprintf(os.Stderr, "Error converting singularBatch from batchData for channel %v. Err: %v\n", id.String(), err)
 						}
 						// singularBatch will be nil when errored
 						batches = append(batches, singularBatch)
+						if singularBatch != nil {
+							summaries = append(summaries, BatchSummary{
+								BatchType:  derive.SingularBatchType,
+								EpochNum:   uint64(singularBatch.EpochNum),
+								EpochHash:  singularBatch.EpochHash,
+								ParentHash: singularBatch.ParentHash,
+								Timestamp:  singularBatch.Timestamp,
+								TxCount:    len(singularBatch.Transactions),
+							})
+						}
 					case derive.SpanBatchType:
 						spanBatch, err := derive.DeriveSpanBatch(batchData, cfg.L2BlockTime, cfg.L2GenesisTime, cfg.L2ChainID)
 						if err != nil {
 							invalidBatches = true
-							fmt.Printf("Error deriving spanBatch from batchData for channel %v. Err: %v\n", id.String(), err)
+							fmt.Fprintf(os.Stderr, "Error deriving spanBatch from batchData for channel %v. Err: %v\n", id.String(), err)
 						}
 						// spanBatch will be nil when errored
 						batches = append(batches, spanBatch)
+						if spanBatch != nil {
+							for i := 0; i < spanBatch.GetBlockCount(); i++ {
+								summaries = append(summaries, BatchSummary{
+									BatchType: derive.SpanBatchType,
+									EpochNum:  spanBatch.GetBlockEpochNum(i),
+									Timestamp: spanBatch.GetBlockTimestamp(i),
+									TxCount:   len(spanBatch.GetBlockTransactions(i)),
+								})
+							}
+						}
 					default:
-						fmt.Printf("unrecognized batch type: %d for channel %v.\n", batchData.GetBatchType(), id.String())
+						fmt.Fprintf(os.Stderr, "unrecognized batch type: %d for channel %v.\n", batchData.GetBatchType(), id.String())
 					}
 				}
 			}
 		} else {
-			fmt.Printf("Error creating batch reader for channel %v. Err: %v\n", id.String(), err)
+			fmt.Fprintf(os.Stderr, "Error creating batch reader for channel %v. Err: %v\n", id.String(), err)
 		}
 	} else {
-		fmt.Printf("Channel %v is not ready\n", id.String())
+		fmt.Fprintf(os.Stderr, "Channel %v is not ready\n", id.String())
 	}
 
 	return ChannelWithMetadata{
@@ -155,10 +251,57 @@ func processFrames(cfg Config, id derive.ChannelID, frames []FrameWithMetadata)
 		InvalidBatches: invalidBatches,
 		Batches:        batches,
 		BatchTypes:     batchTypes,
+		BatchSummaries: summaries,
+		Stats:          stats,
+	}
+}
+
+func channelStats(frames []FrameWithMetadata) ChannelStats {
+	stats := ChannelStats{Frames: len(frames)}
+	found := make(map[uint16]struct{})
+	highest := uint16(0)
+	for _, frame := range frames {
+		found[frame.Frame.FrameNumber] = struct{}{}
+		if frame.Frame.FrameNumber > highest {
+			highest = frame.Frame.FrameNumber
+		}
+		stats.IsClosed = stats.IsClosed || frame.Frame.IsLast
+		stats.CompressedSize += len(frame.Frame.Data)
+		if n := len(stats.InclusionBlocks); n == 0 || stats.InclusionBlocks[n-1] != frame.InclusionBlock {
+			stats.InclusionBlocks = append(stats.InclusionBlocks, frame.InclusionBlock)
+		}
+	}
+	for fn := uint16(0); fn < highest; fn++ {
+		if _, ok := found[fn]; !ok {
+			stats.MissingFrames = append(stats.MissingFrames, fn)
+		}
 	}
+	return stats
 }
 
-func transactionsToFrames(txns []fetch.TransactionWithMetadata) []FrameWithMetadata {
+// uncompressedSize is the size of the decompressed channel data, counted up to the first decompression error
+func uncompressedSize(channelData []byte) int {
+	zr, err := zlib.NewReader(bytes.NewReader(channelData))
+	if err != nil {
+		return 0
+	}
+	n, _ := io.Copy(io.Discard, zr)
+	return int(n)
+}
+
+// TransactionsToFrames lists the frames of the transactions, in the order they are processed in derivation
+func TransactionsToFrames(txns []fetch.TransactionWithMetadata) []FrameWithMetadata {
+	// Sort first by block number then by transaction index inside the block number range.
+	// This is to match the order they are processed in derivation.
+	sort.Slice(txns, func(i, j int) bool {
+		if txns[i].BlockNumber == txns[j].BlockNumber {
+			return txns[i].TxIndex < txns[j].TxIndex
+		} else {
+			return txns[i].BlockNumber < txns[j].BlockNumber
+		}
+
+	})
+
 	var out []FrameWithMetadata
 	for _, tx := range txns {
 		for _, frame := range tx.Frames {
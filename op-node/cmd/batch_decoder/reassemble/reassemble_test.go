@@ -0,0 +1,208 @@
+package reassemble
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/fetch"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	dtest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = Config{
+	BatchInbox:    common.HexToAddress("0xff00000000000000000000000000000000000010"),
+	L2ChainID:     big.NewInt(901),
+	L2GenesisTime: 1000,
+	L2BlockTime:   2,
+}
+
+// randomBatches creates consecutive singular batches, as span batches require
+func randomBatches(rng *rand.Rand, count int) []*derive.SingularBatch {
+	batches := make([]*derive.SingularBatch, count)
+	for i := range batches {
+		block := dtest.RandomL2BlockWithChainId(rng, 1+rng.Intn(3), testConfig.L2ChainID)
+		batch, _, err := derive.BlockToSingularBatch(block)
+		if err != nil {
+			panic(err)
+		}
+		batch.EpochNum = rollup.Epoch(100 + i/2)
+		batch.Timestamp = testConfig.L2GenesisTime + uint64(10+i)*testConfig.L2BlockTime
+		batches[i] = batch
+	}
+	return batches
+}
+
+// channelTransactions outputs the frames of the channel, each in a transaction submitted in its own block
+func channelTransactions(t *testing.T, co derive.ChannelOut, batches []*derive.SingularBatch, startBlock uint64) []fetch.TransactionWithMetadata {
+	for i, batch := range batches {
+		_, err := co.AddSingularBatch(batch, uint64(i))
+		require.NoError(t, err)
+	}
+	require.NoError(t, co.Close())
+
+	var txns []fetch.TransactionWithMetadata
+	for {
+		var buf bytes.Buffer
+		_, err := co.OutputFrame(&buf, 200)
+		if err != nil && !errors.Is(err, io.EOF) {
+			require.NoError(t, err)
+		}
+
+		data := append([]byte{derive.DerivationVersion0}, buf.Bytes()...)
+		frames, parseErr := derive.ParseFrames(data)
+		require.NoError(t, parseErr)
+		txns = append(txns, fetch.TransactionWithMetadata{
+			InboxAddr:   testConfig.BatchInbox,
+			BlockNumber: startBlock + uint64(len(txns)),
+			ValidSender: true,
+			ValidFrames: true,
+			Frames:      frames,
+			Tx:          types.NewTx(&types.DynamicFeeTx{To: &testConfig.BatchInbox, Data: data}),
+		})
+
+		if errors.Is(err, io.EOF) {
+			return txns
+		}
+	}
+}
+
+func newCompressor(t *testing.T) derive.Compressor {
+	c, err := compressor.NewRatioCompressor(compressor.Config{TargetFrameSize: 1000, TargetNumFrames: 100, ApproxComprRatio: 0.4, Level: zlib.BestCompression})
+	require.NoError(t, err)
+	return c
+}
+
+func TestReassembleSingularChannel(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	batches := randomBatches(rng, 4)
+
+	co, err := derive.NewChannelOut(derive.SingularBatchType, newCompressor(t), nil)
+	require.NoError(t, err)
+	txns := channelTransactions(t, co, batches, 10)
+	require.Greater(t, len(txns), 1, "channel should span multiple frames")
+
+	channels := ReassembleTransactions(testConfig, txns)
+	require.Len(t, channels, 1)
+	ch := channels[0]
+	require.Equal(t, co.ID(), ch.ID)
+	require.True(t, ch.IsReady)
+	require.False(t, ch.InvalidFrames)
+	require.False(t, ch.InvalidBatches)
+
+	require.Len(t, ch.BatchSummaries, len(batches))
+	for i, batch := range batches {
+		summary := ch.BatchSummaries[i]
+		require.Equal(t, derive.SingularBatchType, summary.BatchType)
+		require.Equal(t, uint64(batch.EpochNum), summary.EpochNum)
+		require.Equal(t, batch.EpochHash, summary.EpochHash)
+		require.Equal(t, batch.ParentHash, summary.ParentHash)
+		require.Equal(t, batch.Timestamp, summary.Timestamp)
+		require.Equal(t, len(batch.Transactions), summary.TxCount)
+	}
+
+	compressedSize := 0
+	inclusionBlocks := make([]uint64, len(txns))
+	for i, txm := range txns {
+		compressedSize += len(txm.Frames[0].Data)
+		inclusionBlocks[i] = txm.BlockNumber
+	}
+	require.Equal(t, len(txns), ch.Stats.Frames)
+	require.True(t, ch.Stats.IsClosed)
+	require.Empty(t, ch.Stats.MissingFrames)
+	require.Equal(t, compressedSize, ch.Stats.CompressedSize)
+	require.Equal(t, co.InputBytes(), ch.Stats.UncompressedSize)
+	require.Equal(t, inclusionBlocks, ch.Stats.InclusionBlocks)
+}
+
+func TestReassembleSpanChannel(t *testing.T) {
+	rng := rand.New(rand.NewSource(5678))
+	batches := randomBatches(rng, 5)
+
+	spanBatchBuilder := derive.NewSpanBatchBuilder(testConfig.L2GenesisTime, testConfig.L2ChainID)
+	co, err := derive.NewChannelOut(derive.SpanBatchType, newCompressor(t), spanBatchBuilder)
+	require.NoError(t, err)
+	txns := channelTransactions(t, co, batches, 10)
+
+	channels := ReassembleTransactions(testConfig, txns)
+	require.Len(t, channels, 1)
+	ch := channels[0]
+	require.True(t, ch.IsReady)
+	require.False(t, ch.InvalidBatches)
+	require.Equal(t, []int{derive.SpanBatchType}, ch.BatchTypes)
+
+	// The blocks of the span batch are summarized individually
+	require.Len(t, ch.BatchSummaries, len(batches))
+	for i, batch := range batches {
+		summary := ch.BatchSummaries[i]
+		require.Equal(t, derive.SpanBatchType, summary.BatchType)
+		require.Equal(t, uint64(batch.EpochNum), summary.EpochNum)
+		require.Equal(t, batch.Timestamp, summary.Timestamp)
+		require.Equal(t, len(batch.Transactions), summary.TxCount)
+	}
+	require.Greater(t, ch.Stats.UncompressedSize, 0)
+}
+
+func TestReassembleIncompleteChannels(t *testing.T) {
+	rng := rand.New(rand.NewSource(9012))
+	newChannel := func(startBlock uint64) (derive.ChannelOut, []fetch.TransactionWithMetadata) {
+		co, err := derive.NewChannelOut(derive.SingularBatchType, newCompressor(t), nil)
+		require.NoError(t, err)
+		txns := channelTransactions(t, co, randomBatches(rng, 4), startBlock)
+		require.Greater(t, len(txns), 2)
+		return co, txns
+	}
+
+	// The first channel started before the range & the last channel is closed after the range
+	startCo, startTxns := newChannel(10)
+	completeCo, completeTxns := newChannel(20)
+	endCo, endTxns := newChannel(30)
+
+	var txns []fetch.TransactionWithMetadata
+	txns = append(txns, endTxns[:len(endTxns)-1]...)
+	txns = append(txns, completeTxns...)
+	txns = append(txns, startTxns[1:]...)
+
+	// Transactions from unknown senders are ignored
+	invalid := completeTxns[0]
+	invalid.ValidSender = false
+	invalid.BlockNumber = 25
+	txns = append(txns, invalid)
+
+	channels := ReassembleTransactions(testConfig, txns)
+	require.Len(t, channels, 3)
+
+	start, complete, end := channels[0], channels[1], channels[2]
+	require.Equal(t, startCo.ID(), start.ID)
+	require.False(t, start.IsReady)
+	require.True(t, start.Stats.IsClosed)
+	require.Equal(t, []uint16{0}, start.Stats.MissingFrames)
+	require.Empty(t, start.BatchSummaries)
+	require.Zero(t, start.Stats.UncompressedSize)
+
+	require.Equal(t, completeCo.ID(), complete.ID)
+	require.True(t, complete.IsReady)
+	require.False(t, complete.InvalidFrames)
+	require.Equal(t, len(completeTxns), complete.Stats.Frames)
+	require.Len(t, complete.BatchSummaries, 4)
+
+	require.Equal(t, endCo.ID(), end.ID)
+	require.False(t, end.IsReady)
+	require.False(t, end.Stats.IsClosed)
+	require.Empty(t, end.Stats.MissingFrames)
+	require.Equal(t, len(endTxns)-1, end.Stats.Frames)
+	require.Empty(t, end.BatchSummaries)
+
+	summary := end.Summary()
+	require.Equal(t, end.ID, summary.ID)
+	require.Equal(t, end.Stats, summary.Stats)
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math/big"
 	"os"
 	"path/filepath"
 
@@ -188,11 +187,13 @@ var Subcommands = cli.Commands{
 				config.SetDeployments(deployments)
 			}
 
-			var l1StartBlock *types.Block
+			var l1StartHeader *types.Header
 			if l1StartBlockPath != "" {
-				if l1StartBlock, err = readBlockJSON(l1StartBlockPath); err != nil {
+				l1StartBlock, err := readBlockJSON(l1StartBlockPath)
+				if err != nil {
 					return fmt.Errorf("cannot read L1 starting block at %s: %w", l1StartBlockPath, err)
 				}
+				l1StartHeader = l1StartBlock.Header()
 			}
 
 			if l1RPC != "" {
@@ -201,28 +202,22 @@ var Subcommands = cli.Commands{
 					return fmt.Errorf("cannot dial %s: %w", l1RPC, err)
 				}
 
+				tag := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+				if config.L1StartingBlockTag != nil {
+					tag = rpc.BlockNumberOrHash(*config.L1StartingBlockTag)
+				}
+				l1StartHeader, err = genesis.GetHeaderFromTag(context.Background(), client, tag)
+				if err != nil {
+					return err
+				}
 				if config.L1StartingBlockTag == nil {
-					l1StartBlock, err = client.BlockByNumber(context.Background(), nil)
-					if err != nil {
-						return fmt.Errorf("cannot fetch latest block: %w", err)
-					}
-					tag := rpc.BlockNumberOrHashWithHash(l1StartBlock.Hash(), true)
+					tag := rpc.BlockNumberOrHashWithHash(l1StartHeader.Hash(), true)
 					config.L1StartingBlockTag = (*genesis.MarshalableRPCBlockNumberOrHash)(&tag)
-				} else if config.L1StartingBlockTag.BlockHash != nil {
-					l1StartBlock, err = client.BlockByHash(context.Background(), *config.L1StartingBlockTag.BlockHash)
-					if err != nil {
-						return fmt.Errorf("cannot fetch block by hash: %w", err)
-					}
-				} else if config.L1StartingBlockTag.BlockNumber != nil {
-					l1StartBlock, err = client.BlockByNumber(context.Background(), big.NewInt(config.L1StartingBlockTag.BlockNumber.Int64()))
-					if err != nil {
-						return fmt.Errorf("cannot fetch block by number: %w", err)
-					}
 				}
 			}
 
 			// Ensure that there is a starting L1 block
-			if l1StartBlock == nil {
+			if l1StartHeader == nil {
 				return errors.New("no starting L1 block")
 			}
 
@@ -232,16 +227,16 @@ var Subcommands = cli.Commands{
 				return err
 			}
 
-			log.Info("Using L1 Start Block", "number", l1StartBlock.Number(), "hash", l1StartBlock.Hash().Hex())
+			log.Info("Using L1 Start Block", "number", l1StartHeader.Number, "hash", l1StartHeader.Hash().Hex())
 
 			// Build the L2 genesis block
-			l2Genesis, err := genesis.BuildL2Genesis(config, l1StartBlock)
+			l2Genesis, err := genesis.BuildL2Genesis(config, l1StartHeader)
 			if err != nil {
 				return fmt.Errorf("error creating l2 genesis: %w", err)
 			}
 
 			l2GenesisBlock := l2Genesis.ToBlock()
-			rollupConfig, err := config.RollupConfig(l1StartBlock, l2GenesisBlock.Hash(), l2GenesisBlock.Number().Uint64())
+			rollupConfig, err := config.RollupConfig(l1StartHeader, l2GenesisBlock.Hash(), l2GenesisBlock.Number().Uint64())
 			if err != nil {
 				return err
 			}
@@ -7,8 +7,11 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/endpoint"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 
 	"github.com/urfave/cli/v2"
@@ -24,25 +27,10 @@ func prefixEnvVars(name string) []string {
 
 var (
 	/* Required Flags */
-	L1NodeAddr = &cli.StringFlag{
-		Name:    "l1",
-		Usage:   "Address of L1 User JSON-RPC endpoint to use (eth namespace required)",
-		Value:   "http://127.0.0.1:8545",
-		EnvVars: prefixEnvVars("L1_ETH_RPC"),
-	}
-	L2EngineAddr = &cli.StringFlag{
-		Name:    "l2",
-		Usage:   "Address of L2 Engine JSON-RPC endpoints to use (engine and eth namespace required)",
-		EnvVars: prefixEnvVars("L2_ENGINE_RPC"),
-	}
-	L2EngineJWTSecret = &cli.StringFlag{
-		Name:        "l2.jwt-secret",
-		Usage:       "Path to JWT secret key. Keys are 32 bytes, hex encoded in a file. A new key will be generated if the file is empty.",
-		EnvVars:     prefixEnvVars("L2_ENGINE_AUTH"),
-		Value:       "",
-		Destination: new(string),
-	}
-	RollupConfig = &cli.StringFlag{
+	L1NodeAddr        = endpoint.L1RPCFlag(EnvVarPrefix, "l1")
+	L2EngineAddr      = endpoint.L2EngineRPCFlag(EnvVarPrefix, "l2")
+	L2EngineJWTSecret = endpoint.L2EngineJWTSecretFlag(EnvVarPrefix)
+	RollupConfig      = &cli.StringFlag{
 		Name:    "rollup.config",
 		Usage:   "Rollup chain parameters",
 		EnvVars: prefixEnvVars("ROLLUP_CONFIG"),
@@ -52,6 +40,11 @@ var (
 		Usage:   fmt.Sprintf("Predefined network selection. Available networks: %s", strings.Join(chaincfg.AvailableNetworks(), ", ")),
 		EnvVars: prefixEnvVars("NETWORK"),
 	}
+	L2ChainID = &cli.Uint64Flag{
+		Name:    "l2.chainid",
+		Usage:   "L2 chain ID of a chain known in the superchain-registry, to load the rollup config of. Alternative to the network and rollup.config flags.",
+		EnvVars: prefixEnvVars("L2_CHAIN_ID"),
+	}
 	/* Optional Flags */
 	SyncModeFlag = &cli.GenericFlag{
 		Name:    "syncmode",
@@ -63,6 +56,14 @@ var (
 		}(),
 		Hidden: true,
 	}
+	ELSyncDistanceFlag = &cli.Uint64Flag{
+		Name: "syncmode.el-distance",
+		Usage: "IN DEVELOPMENT: Number of blocks an unsafe block must be ahead of the safe head, " +
+			"for the node to defer to the execution-layer sync with --syncmode=execution-layer. 0 always defers.",
+		EnvVars: prefixEnvVars("SYNCMODE_EL_DISTANCE"),
+		Value:   0,
+		Hidden:  true,
+	}
 	RPCListenAddr = &cli.StringFlag{
 		Name:    "rpc.addr",
 		Usage:   "RPC listening address",
@@ -77,14 +78,24 @@ var (
 	}
 	RPCEnableAdmin = &cli.BoolFlag{
 		Name:    "rpc.enable-admin",
-		Usage:   "Enable the admin API (experimental)",
+		Usage:   "Enable the admin API (experimental), including the opp2p methods to block, protect, connect and disconnect peers",
 		EnvVars: prefixEnvVars("RPC_ENABLE_ADMIN"),
 	}
 	RPCAdminPersistence = &cli.StringFlag{
 		Name:    "rpc.admin-state",
-		Usage:   "File path used to persist state changes made via the admin API so they persist across restarts. Disabled if not set.",
+		Usage:   "File path used to persist state changes made via the admin API, and an ongoing p2p sequencer key rotation, so they persist across restarts. Disabled if not set.",
 		EnvVars: prefixEnvVars("RPC_ADMIN_STATE"),
 	}
+	RPCRateLimits = &cli.StringSliceFlag{
+		Name:    "rpc.rate-limits",
+		Usage:   "Rate limits of RPC method namespaces in requests per second, as <namespace>=<rps>, e.g. admin=5",
+		EnvVars: prefixEnvVars("RPC_RATE_LIMITS"),
+	}
+	BeaconAddr = &cli.StringFlag{
+		Name:    "l1.beacon",
+		Usage:   "Address of L1 Beacon-node HTTP endpoint to use, to fetch the blobs of batcher transactions. Required from the Eclipse upgrade onwards.",
+		EnvVars: prefixEnvVars("L1_BEACON"),
+	}
 	L1TrustRPC = &cli.BoolFlag{
 		Name:    "l1.trustrpc",
 		Usage:   "Trust the L1 RPC, sync faster at risk of malicious/buggy RPC providing bad or inconsistent L1 data",
@@ -152,6 +163,35 @@ var (
 		EnvVars: prefixEnvVars("SEQUENCER_MAX_SAFE_LAG"),
 		Value:   0,
 	}
+	SequencerAdmissionEndpointFlag = &cli.StringFlag{
+		Name:    "sequencer.admission-endpoint",
+		Usage:   "HTTP endpoint that admits the sequencer to build each block, e.g. for leader election amongst sequencer replicas. The sequencer does not build a block if the endpoint denies it, or fails. Every block is admitted if empty.",
+		EnvVars: prefixEnvVars("SEQUENCER_ADMISSION_ENDPOINT"),
+	}
+	SequencerAdmissionTimeoutFlag = &cli.DurationFlag{
+		Name:    "sequencer.admission-timeout",
+		Usage:   "Timeout of the sequencer admission check, after which the block is not admitted.",
+		EnvVars: prefixEnvVars("SEQUENCER_ADMISSION_TIMEOUT"),
+		Value:   500 * time.Millisecond,
+	}
+	SequencerBuildBudgetFlag = &cli.DurationFlag{
+		Name:    "sequencer.build-budget",
+		Usage:   "Maximum time to wait for the engine to return a block, after which the sequencer takes the best block the engine built so far, or falls back to a deposit-only block, to not skip the slot. Defaults to half the block time if 0.",
+		EnvVars: prefixEnvVars("SEQUENCER_BUILD_BUDGET"),
+		Value:   0,
+	}
+	DerivationMaxBackoffFlag = &cli.DurationFlag{
+		Name:    "derivation.max-backoff",
+		Usage:   "Maximum delay between re-attempts of a derivation step that failed, e.g. due to a temporary L1 RPC error.",
+		EnvVars: prefixEnvVars("DERIVATION_MAX_BACKOFF"),
+		Value:   10 * time.Second,
+	}
+	DerivationMaxStepAttemptsFlag = &cli.Uint64Flag{
+		Name:    "derivation.max-step-attempts",
+		Usage:   "Number of consecutive failed derivation steps after which the derivation pipeline is reset, discarding the buffered data, rather than retried in place. Disabled if 0.",
+		EnvVars: prefixEnvVars("DERIVATION_MAX_STEP_ATTEMPTS"),
+		Value:   0,
+	}
 	SequencerL1Confs = &cli.Uint64Flag{
 		Name:    "sequencer.l1-confs",
 		Usage:   "Number of L1 blocks to keep distance from the L1 head as a sequencer for picking an L1 origin.",
@@ -187,23 +227,6 @@ var (
 		Value:   7300,
 		EnvVars: prefixEnvVars("METRICS_PORT"),
 	}
-	PprofEnabledFlag = &cli.BoolFlag{
-		Name:    "pprof.enabled",
-		Usage:   "Enable the pprof server",
-		EnvVars: prefixEnvVars("PPROF_ENABLED"),
-	}
-	PprofAddrFlag = &cli.StringFlag{
-		Name:    "pprof.addr",
-		Usage:   "pprof listening address",
-		Value:   "0.0.0.0", // TODO(CLI-4159): Switch to 127.0.0.1
-		EnvVars: prefixEnvVars("PPROF_ADDR"),
-	}
-	PprofPortFlag = &cli.IntFlag{
-		Name:    "pprof.port",
-		Usage:   "pprof listening port",
-		Value:   6060,
-		EnvVars: prefixEnvVars("PPROF_PORT"),
-	}
 	SnapshotLog = &cli.StringFlag{
 		Name:    "snapshotlog.file",
 		Usage:   "Path to the snapshot log file",
@@ -225,16 +248,73 @@ var (
 		EnvVars: prefixEnvVars("HEARTBEAT_URL"),
 		Value:   "https://heartbeat.optimism.io",
 	}
+	HeartbeatRedactFlag = &cli.StringSliceFlag{
+		Name:    "heartbeat.redact",
+		Usage:   "Omits the given fields from the heartbeat payload. Any of: version, moniker, peer-id, chain-id",
+		EnvVars: prefixEnvVars("HEARTBEAT_REDACT"),
+	}
 	RollupHalt = &cli.StringFlag{
 		Name:    "rollup.halt",
 		Usage:   "Opt-in option to halt on incompatible protocol version requirements of the given level (major/minor/patch/none), as signaled onchain in L1",
 		EnvVars: prefixEnvVars("ROLLUP_HALT"),
 	}
+	RollupHaltAction = &cli.StringFlag{
+		Name:    "rollup.halt-action",
+		Usage:   "How to halt on incompatible protocol version requirements, see rollup.halt: shutdown the node, stop-sequencer to stop sequencing but keep following the chain, or warn to only log a warning",
+		EnvVars: prefixEnvVars("ROLLUP_HALT_ACTION"),
+		Value:   "shutdown",
+	}
 	RollupLoadProtocolVersions = &cli.BoolFlag{
 		Name:    "rollup.load-protocol-versions",
 		Usage:   "Load protocol versions from the superchain L1 ProtocolVersions contract (if available), and report in logs and metrics",
 		EnvVars: prefixEnvVars("ROLLUP_LOAD_PROTOCOL_VERSIONS"),
 	}
+	RollupSkipGenesisCheck = &cli.BoolFlag{
+		Name:    "rollup.skip-genesis-check",
+		Usage:   "Skip the startup check of the rollup genesis blocks against the L1 and L2 RPCs. Chain IDs are still checked.",
+		EnvVars: prefixEnvVars("ROLLUP_SKIP_GENESIS_CHECK"),
+	}
+	RollupL1ContractsCheck = &cli.StringFlag{
+		Name:    "rollup.l1-contracts-check",
+		Usage:   "Startup check of the rollup L1 addresses (deposit contract, batch inbox, system config) against L1: warn, strict (refuse to start on mismatch) or skip (e.g. pre-deployment devnets)",
+		EnvVars: prefixEnvVars("ROLLUP_L1_CONTRACTS_CHECK"),
+		Value:   "warn",
+	}
+	SafeDBPath = &cli.StringFlag{
+		Name:    "safedb.path",
+		Usage:   "File path used to persist the safe head progression per L1 block, to serve optimism_safeHeadAtL1Block. Disabled if not set.",
+		EnvVars: prefixEnvVars("SAFEDB_PATH"),
+	}
+	SafeDBRetention = &cli.Uint64Flag{
+		Name:    "safedb.retention",
+		Usage:   "Number of L1 blocks to keep the safe head progression of, behind the latest L1 block that advanced the safe head. 0 keeps all.",
+		EnvVars: prefixEnvVars("SAFEDB_RETENTION"),
+		Value:   0,
+	}
+	HealthL1HeadMaxAge = &cli.DurationFlag{
+		Name:    "health.l1-head-max-age",
+		Usage:   "Maximum age of the L1 head, by block timestamp, for the node to report healthy. Disabled if 0.",
+		EnvVars: prefixEnvVars("HEALTH_L1_HEAD_MAX_AGE"),
+		Value:   time.Minute * 5,
+	}
+	HealthUnsafeHeadMaxStall = &cli.DurationFlag{
+		Name:    "health.unsafe-head-max-stall",
+		Usage:   "Maximum duration without progress of the unsafe L2 head, for the node to report healthy. Disabled if 0.",
+		EnvVars: prefixEnvVars("HEALTH_UNSAFE_HEAD_MAX_STALL"),
+		Value:   time.Minute * 5,
+	}
+	HealthSafeHeadMaxStall = &cli.DurationFlag{
+		Name:    "health.safe-head-max-stall",
+		Usage:   "Maximum duration without progress of the safe L2 head, for the node to report healthy. Disabled if 0.",
+		EnvVars: prefixEnvVars("HEALTH_SAFE_HEAD_MAX_STALL"),
+		Value:   time.Hour,
+	}
+	ShutdownTimeout = &cli.DurationFlag{
+		Name:    "shutdown-timeout",
+		Usage:   "Maximum duration of a graceful shutdown, after which in-flight engine calls and derivation work are aborted. Not bounded if 0.",
+		EnvVars: prefixEnvVars("SHUTDOWN_TIMEOUT"),
+		Value:   time.Second * 30,
+	}
 	CanyonOverrideFlag = &cli.Uint64Flag{
 		Name:    "override.canyon",
 		Usage:   "Manually specify the Canyon fork timestamp, overriding the bundled setting",
@@ -271,16 +351,14 @@ var (
 	}
 	BackupL2UnsafeSyncRPC = &cli.StringFlag{
 		Name:    "l2.backup-unsafe-sync-rpc",
-		Usage:   "Set the backup L2 unsafe sync RPC endpoint.",
+		Usage:   "Set the backup L2 unsafe sync RPC endpoint, to fetch missing unsafe L2 blocks from if there is a gap.",
 		EnvVars: prefixEnvVars("L2_BACKUP_UNSAFE_SYNC_RPC"),
-		Hidden:  true,
 	}
-	BackupL2UnsafeSyncRPCTrustRPC = &cli.StringFlag{
+	BackupL2UnsafeSyncRPCTrustRPC = &cli.BoolFlag{
 		Name: "l2.backup-unsafe-sync-rpc.trustrpc",
 		Usage: "Like l1.trustrpc, configure if response data from the RPC needs to be verified, e.g. blockhash computation." +
 			"This does not include checks if the blockhash is part of the canonical chain.",
 		EnvVars: prefixEnvVars("L2_BACKUP_UNSAFE_SYNC_RPC_TRUST_RPC"),
-		Hidden:  true,
 	}
 )
 
@@ -292,10 +370,13 @@ var requiredFlags = []cli.Flag{
 
 var optionalFlags = []cli.Flag{
 	SyncModeFlag,
+	ELSyncDistanceFlag,
 	RPCListenAddr,
 	RPCListenPort,
 	RollupConfig,
 	Network,
+	L2ChainID,
+	BeaconAddr,
 	L1TrustRPC,
 	L1RPCProviderKind,
 	L1RPCRateLimit,
@@ -306,34 +387,47 @@ var optionalFlags = []cli.Flag{
 	SequencerEnabledFlag,
 	SequencerStoppedFlag,
 	SequencerMaxSafeLagFlag,
+	SequencerAdmissionEndpointFlag,
+	SequencerAdmissionTimeoutFlag,
+	SequencerBuildBudgetFlag,
 	SequencerL1Confs,
+	DerivationMaxBackoffFlag,
+	DerivationMaxStepAttemptsFlag,
 	L1EpochPollIntervalFlag,
 	RuntimeConfigReloadIntervalFlag,
 	RPCEnableAdmin,
 	RPCAdminPersistence,
+	RPCRateLimits,
 	MetricsEnabledFlag,
 	MetricsAddrFlag,
 	MetricsPortFlag,
-	PprofEnabledFlag,
-	PprofAddrFlag,
-	PprofPortFlag,
 	SnapshotLog,
 	HeartbeatEnabledFlag,
 	HeartbeatMonikerFlag,
 	HeartbeatURLFlag,
+	HeartbeatRedactFlag,
 	RollupHalt,
+	RollupHaltAction,
 	RollupLoadProtocolVersions,
+	RollupSkipGenesisCheck,
+	RollupL1ContractsCheck,
 	L1RethDBPath,
+	SafeDBPath,
+	SafeDBRetention,
+	HealthL1HeadMaxAge,
+	HealthUnsafeHeadMaxStall,
+	HealthSafeHeadMaxStall,
+	ShutdownTimeout,
 	CanyonOverrideFlag,
 	DeltaOverrideFlag,
+	BackupL2UnsafeSyncRPC,
+	BackupL2UnsafeSyncRPCTrustRPC,
 }
 
 var DeprecatedFlags = []cli.Flag{
 	L2EngineSyncEnabled,
 	SkipSyncStartCheck,
 	BetaExtraNetworks,
-	BackupL2UnsafeSyncRPC,
-	BackupL2UnsafeSyncRPCTrustRPC,
 	// Deprecated P2P Flags are added at the init step
 }
 
@@ -344,6 +438,8 @@ func init() {
 	DeprecatedFlags = append(DeprecatedFlags, deprecatedP2PFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, P2PFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, opsigner.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, DeprecatedFlags...)
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -354,6 +450,7 @@ var requiredXorFlags = [][]string{
 	{
 		RollupConfig.Name,
 		Network.Name,
+		L2ChainID.Name,
 	},
 }
 
@@ -14,45 +14,56 @@ func p2pEnv(envprefix, v string) []string {
 }
 
 var (
-	DisableP2PName         = "p2p.disable"
-	NoDiscoveryName        = "p2p.no-discovery"
-	ScoringName            = "p2p.scoring"
-	PeerScoringName        = "p2p.scoring.peers"
-	PeerScoreBandsName     = "p2p.score.bands"
-	BanningName            = "p2p.ban.peers"
-	BanningThresholdName   = "p2p.ban.threshold"
-	BanningDurationName    = "p2p.ban.duration"
-	TopicScoringName       = "p2p.scoring.topics"
-	P2PPrivPathName        = "p2p.priv.path"
-	P2PPrivRawName         = "p2p.priv.raw"
-	ListenIPName           = "p2p.listen.ip"
-	ListenTCPPortName      = "p2p.listen.tcp"
-	ListenUDPPortName      = "p2p.listen.udp"
-	AdvertiseIPName        = "p2p.advertise.ip"
-	AdvertiseTCPPortName   = "p2p.advertise.tcp"
-	AdvertiseUDPPortName   = "p2p.advertise.udp"
-	BootnodesName          = "p2p.bootnodes"
-	StaticPeersName        = "p2p.static"
-	NetRestrictName        = "p2p.netrestrict"
-	HostMuxName            = "p2p.mux"
-	HostSecurityName       = "p2p.security"
-	PeersLoName            = "p2p.peers.lo"
-	PeersHiName            = "p2p.peers.hi"
-	PeersGraceName         = "p2p.peers.grace"
-	NATName                = "p2p.nat"
-	UserAgentName          = "p2p.useragent"
-	TimeoutNegotiationName = "p2p.timeout.negotiation"
-	TimeoutAcceptName      = "p2p.timeout.accept"
-	TimeoutDialName        = "p2p.timeout.dial"
-	PeerstorePathName      = "p2p.peerstore.path"
-	DiscoveryPathName      = "p2p.discovery.path"
-	SequencerP2PKeyName    = "p2p.sequencer.key"
-	GossipMeshDName        = "p2p.gossip.mesh.d"
-	GossipMeshDloName      = "p2p.gossip.mesh.lo"
-	GossipMeshDhiName      = "p2p.gossip.mesh.dhi"
-	GossipMeshDlazyName    = "p2p.gossip.mesh.dlazy"
-	GossipFloodPublishName = "p2p.gossip.mesh.floodpublish"
-	SyncReqRespName        = "p2p.sync.req-resp"
+	DisableP2PName                = "p2p.disable"
+	NoDiscoveryName               = "p2p.no-discovery"
+	DialUnmarkedName              = "p2p.discovery.dial-unmarked"
+	ScoringName                   = "p2p.scoring"
+	PeerScoringName               = "p2p.scoring.peers"
+	ScoringDecayName              = "p2p.scoring.decay-interval"
+	ScoringTopicWeightName        = "p2p.scoring.topic-weight"
+	ScoringGraylistName           = "p2p.scoring.graylist-threshold"
+	PeerScoreBandsName            = "p2p.score.bands"
+	BanningName                   = "p2p.ban.peers"
+	BanningThresholdName          = "p2p.ban.threshold"
+	BanningDurationName           = "p2p.ban.duration"
+	TopicScoringName              = "p2p.scoring.topics"
+	P2PPrivPathName               = "p2p.priv.path"
+	P2PPrivRawName                = "p2p.priv.raw"
+	ListenIPName                  = "p2p.listen.ip"
+	ListenTCPPortName             = "p2p.listen.tcp"
+	ListenUDPPortName             = "p2p.listen.udp"
+	AdvertiseIPName               = "p2p.advertise.ip"
+	AdvertiseTCPPortName          = "p2p.advertise.tcp"
+	AdvertiseUDPPortName          = "p2p.advertise.udp"
+	BootnodesName                 = "p2p.bootnodes"
+	DNSDiscoveryName              = "p2p.dns"
+	StaticPeersName               = "p2p.static"
+	NetRestrictName               = "p2p.netrestrict"
+	HostMuxName                   = "p2p.mux"
+	HostSecurityName              = "p2p.security"
+	PeersLoName                   = "p2p.peers.lo"
+	PeersHiName                   = "p2p.peers.hi"
+	PeersGraceName                = "p2p.peers.grace"
+	NATName                       = "p2p.nat"
+	UserAgentName                 = "p2p.useragent"
+	TimeoutNegotiationName        = "p2p.timeout.negotiation"
+	TimeoutAcceptName             = "p2p.timeout.accept"
+	TimeoutDialName               = "p2p.timeout.dial"
+	PeerstorePathName             = "p2p.peerstore.path"
+	DiscoveryPathName             = "p2p.discovery.path"
+	SequencerP2PKeyName           = "p2p.sequencer.key"
+	SequencerKeysPrimaryName      = "p2p.sequencer.keys.primary"
+	SequencerKeysSecondaryName    = "p2p.sequencer.keys.secondary"
+	SequencerKeysRotationTimeName = "p2p.sequencer.keys.rotation-time"
+	GossipMeshDName               = "p2p.gossip.mesh.d"
+	GossipMeshDloName             = "p2p.gossip.mesh.lo"
+	GossipMeshDhiName             = "p2p.gossip.mesh.dhi"
+	GossipMeshDlazyName           = "p2p.gossip.mesh.dlazy"
+	GossipFloodPublishName        = "p2p.gossip.mesh.floodpublish"
+	GossipHeartbeatName           = "p2p.gossip.heartbeat"
+	GossipRetryDepthName          = "p2p.gossip.publish-retry.depth"
+	GossipRetryTTLName            = "p2p.gossip.publish-retry.ttl"
+	SyncReqRespName               = "p2p.sync.req-resp"
 )
 
 func deprecatedP2PFlags(envPrefix string) []cli.Flag {
@@ -95,13 +106,38 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			Required: false,
 			EnvVars:  p2pEnv(envPrefix, "NO_DISCOVERY"),
 		},
+		&cli.BoolFlag{
+			Name: DialUnmarkedName,
+			Usage: "Also dial discovered peers that do not advertise the opstack chain ID in their node record. " +
+				"These are dialed after the peers that are known to be on the same chain, and are pruned first.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "DISCOVERY_DIAL_UNMARKED"),
+		},
 		&cli.StringFlag{
 			Name:     ScoringName,
-			Usage:    "Sets the peer scoring strategy for the P2P stack. Can be one of: none or light.",
+			Usage:    "Sets the peer scoring preset for the P2P stack. Can be one of: light, full, or off (alias: none).",
 			Required: false,
 			Value:    "light",
 			EnvVars:  p2pEnv(envPrefix, "PEER_SCORING"),
 		},
+		&cli.DurationFlag{
+			Name:     ScoringDecayName,
+			Usage:    "Overrides the peer score decay interval of the scoring preset. The preset value is used if 0.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "PEER_SCORING_DECAY_INTERVAL"),
+		},
+		&cli.Float64Flag{
+			Name:     ScoringTopicWeightName,
+			Usage:    "Overrides the blocks topic weight of the scoring preset. The preset value is used if 0.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "PEER_SCORING_TOPIC_WEIGHT"),
+		},
+		&cli.Float64Flag{
+			Name:     ScoringGraylistName,
+			Usage:    "Overrides the score below which gossip from peers is ignored (graylisted). The preset value is used if 0. Must be negative.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "PEER_SCORING_GRAYLIST_THRESHOLD"),
+		},
 		&cli.BoolFlag{
 			// Banning Flag - whether or not we want to act on the scoring
 			Name:     BanningName,
@@ -193,10 +229,18 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			Value:    "",
 			EnvVars:  p2pEnv(envPrefix, "BOOTNODES"),
 		},
+		&cli.StringFlag{
+			Name:     DNSDiscoveryName,
+			Usage:    "Comma-separated list of enrtree:// URLs. EIP-1459 DNS node lists to discover other node records from, next to the discv5 bootnodes.",
+			Required: false,
+			Value:    "",
+			EnvVars:  p2pEnv(envPrefix, "DNS"),
+		},
 		&cli.StringFlag{
 			Name: StaticPeersName,
 			Usage: "Comma-separated multiaddr-format peer list. Static connections to make and maintain, these peers will be regarded as trusted. " +
-				"Addresses of the local peer are ignored. Duplicate/Alternative addresses for the same peer all apply, but only a single connection per peer is maintained.",
+				"Addresses of the local peer are ignored. Duplicate/Alternative addresses for the same peer all apply, but only a single connection per peer is maintained. " +
+				"Disconnected static peers are redialed with backoff, and are never pruned or banned.",
 			Required: false,
 			Value:    "",
 			EnvVars:  p2pEnv(envPrefix, "STATIC"),
@@ -246,7 +290,7 @@ func P2PFlags(envPrefix string) []cli.Flag {
 		},
 		&cli.BoolFlag{
 			Name:     NATName,
-			Usage:    "Enable NAT traversal with PMP/UPNP devices to learn external IP.",
+			Usage:    "Enable NAT traversal with PMP/UPNP devices, to map the listen port and learn the external IP. The external address is re-checked periodically and advertised in the discovery record.",
 			Required: false,
 			EnvVars:  p2pEnv(envPrefix, "NAT"),
 		},
@@ -307,6 +351,24 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			Value:    "",
 			EnvVars:  p2pEnv(envPrefix, "SEQUENCER_KEY"),
 		},
+		&cli.StringFlag{
+			Name:     SequencerKeysPrimaryName,
+			Usage:    "Address of the p2p sequencer key that gossiped blocks must be signed with, overriding the unsafe block signer of the SystemConfig. Disabled if not set.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SEQUENCER_KEYS_PRIMARY"),
+		},
+		&cli.StringFlag{
+			Name:     SequencerKeysSecondaryName,
+			Usage:    fmt.Sprintf("Address of the previous p2p sequencer key, accepted until the %s, to rotate the key set with %s manually.", SequencerKeysRotationTimeName, SequencerKeysPrimaryName),
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SEQUENCER_KEYS_SECONDARY"),
+		},
+		&cli.Uint64Flag{
+			Name:     SequencerKeysRotationTimeName,
+			Usage:    fmt.Sprintf("Unix timestamp (seconds) from which the %s is no longer accepted.", SequencerKeysSecondaryName),
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SEQUENCER_KEYS_ROTATION_TIME"),
+		},
 		&cli.UintFlag{
 			Name:     GossipMeshDName,
 			Usage:    "Configure GossipSub topic stable mesh target count, a.k.a. desired outbound degree, number of peers to gossip to",
@@ -346,6 +408,30 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			Hidden:   true,
 			EnvVars:  p2pEnv(envPrefix, "GOSSIP_FLOOD_PUBLISH"),
 		},
+		&cli.DurationFlag{
+			Name:     GossipHeartbeatName,
+			Usage:    "Configure the GossipSub heartbeat interval, at which the mesh is maintained and gossip is emitted.",
+			Required: false,
+			Hidden:   true,
+			Value:    p2p.DefaultGossipHeartbeat,
+			EnvVars:  p2pEnv(envPrefix, "GOSSIP_HEARTBEAT"),
+		},
+		&cli.Uint64Flag{
+			Name:     GossipRetryDepthName,
+			Usage:    "Number of newer blocks after which a block that failed to publish is no longer retried.",
+			Required: false,
+			Hidden:   true,
+			Value:    p2p.DefaultPublishRetryDepth,
+			EnvVars:  p2pEnv(envPrefix, "GOSSIP_PUBLISH_RETRY_DEPTH"),
+		},
+		&cli.DurationFlag{
+			Name:     GossipRetryTTLName,
+			Usage:    "Duration after which a block that failed to publish is no longer retried. Retrying is disabled if 0.",
+			Required: false,
+			Hidden:   true,
+			Value:    p2p.DefaultPublishRetryTTL,
+			EnvVars:  p2pEnv(envPrefix, "GOSSIP_PUBLISH_RETRY_TTL"),
+		},
 		&cli.BoolFlag{
 			Name:     SyncReqRespName,
 			Usage:    "Enables P2P req-resp alternative sync method, on both server and client side.",
@@ -10,27 +10,77 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/retry"
 )
 
 // SendInterval determines the delay between requests. This must be larger than the MinHeartbeatInterval in the server.
 const SendInterval = 10 * time.Minute
 
+// sendAttempts is the number of attempts to deliver a heartbeat, before giving up until the next interval.
+const sendAttempts = 5
+
+// Names of the payload fields that can be redacted.
+const (
+	FieldVersion = "version" // redacts both the version and the version meta
+	FieldMoniker = "moniker"
+	FieldPeerID  = "peer-id"
+	FieldChainID = "chain-id"
+)
+
+// RedactableFields are the names of the payload fields that can be redacted.
+var RedactableFields = []string{FieldVersion, FieldMoniker, FieldPeerID, FieldChainID}
+
 type Payload struct {
-	Version string `json:"version"`
-	Meta    string `json:"meta"`
-	Moniker string `json:"moniker"`
-	PeerID  string `json:"peerID"`
-	ChainID uint64 `json:"chainID"`
+	Version string `json:"version,omitempty"`
+	Meta    string `json:"meta,omitempty"`
+	Moniker string `json:"moniker,omitempty"`
+	PeerID  string `json:"peerID,omitempty"`
+	ChainID uint64 `json:"chainID,omitempty"`
 }
 
+// Redact omits the given fields from the payload. See RedactableFields for the supported field names.
+func (p *Payload) Redact(fields []string) error {
+	for _, f := range fields {
+		switch f {
+		case FieldVersion:
+			p.Version = ""
+			p.Meta = ""
+		case FieldMoniker:
+			p.Moniker = ""
+		case FieldPeerID:
+			p.PeerID = ""
+		case FieldChainID:
+			p.ChainID = 0
+		default:
+			return fmt.Errorf("unknown heartbeat field %q, expected one of %v", f, RedactableFields)
+		}
+	}
+	return nil
+}
+
+type Metrics interface {
+	RecordHeartbeat(success bool)
+}
+
+type NoopMetrics struct{}
+
+func (NoopMetrics) RecordHeartbeat(success bool) {}
+
 // Beat sends a heartbeat to the server at the given URL. It will send a heartbeat immediately, and then every SendInterval.
-// Beat spawns a goroutine that will send heartbeats until the context is canceled.
+// Failed heartbeats are retried with backoff, before giving up until the next interval.
+// Beat blocks and sends heartbeats until the context is canceled.
 func Beat(
 	ctx context.Context,
 	log log.Logger,
+	m Metrics,
 	url string,
 	payload *Payload,
 ) error {
+	return beat(ctx, log, m, url, payload, SendInterval, retry.Exponential())
+}
+
+func beat(ctx context.Context, log log.Logger, m Metrics, url string, payload *Payload, interval time.Duration, strategy retry.Strategy) error {
 	payloadJSON, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("telemetry crashed: %w", err)
@@ -40,31 +90,46 @@ func Beat(
 		Timeout: 10 * time.Second,
 	}
 
-	send := func() {
+	userAgent := "op-node"
+	if payload.Version != "" {
+		userAgent += "/" + payload.Version
+	}
+	sendOnce := func() (struct{}, error) {
 		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payloadJSON))
-		req.Header.Set("User-Agent", fmt.Sprintf("op-node/%s", payload.Version))
-		req.Header.Set("Content-Type", "application/json")
 		if err != nil {
-			log.Error("error creating heartbeat HTTP request", "err", err)
-			return
+			return struct{}{}, fmt.Errorf("error creating heartbeat HTTP request: %w", err)
 		}
+		req.Header.Set("User-Agent", userAgent)
+		req.Header.Set("Content-Type", "application/json")
 		res, err := client.Do(req)
 		if err != nil {
-			log.Warn("error sending heartbeat", "err", err)
-			return
+			log.Debug("error sending heartbeat", "err", err)
+			return struct{}{}, fmt.Errorf("error sending heartbeat: %w", err)
 		}
 		res.Body.Close()
 
 		if res.StatusCode < 200 || res.StatusCode > 204 {
-			log.Warn("heartbeat server returned non-200 status code", "status", res.StatusCode)
-			return
+			log.Debug("heartbeat server returned non-200 status code", "status", res.StatusCode)
+			return struct{}{}, fmt.Errorf("heartbeat server returned status code %d", res.StatusCode)
 		}
+		return struct{}{}, nil
+	}
 
+	send := func() {
+		if _, err := retry.Do(ctx, sendAttempts, strategy, sendOnce); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			m.RecordHeartbeat(false)
+			log.Warn("failed to send heartbeat, retrying next interval", "err", err)
+			return
+		}
+		m.RecordHeartbeat(true)
 		log.Info("sent heartbeat")
 	}
 
 	send()
-	tick := time.NewTicker(SendInterval)
+	tick := time.NewTicker(interval)
 	defer tick.Stop()
 	for {
 		select {
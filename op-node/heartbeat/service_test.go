@@ -2,15 +2,22 @@ package heartbeat
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
 const expHeartbeat = `{
@@ -37,7 +44,7 @@ func TestBeat(t *testing.T) {
 
 	doneCh := make(chan struct{})
 	go func() {
-		_ = Beat(ctx, log.Root(), s.URL, &Payload{
+		_ = Beat(ctx, log.Root(), NoopMetrics{}, s.URL, &Payload{
 			Version: "v1.2.3",
 			Meta:    "meta",
 			Moniker: "yeet",
@@ -56,3 +63,143 @@ func TestBeat(t *testing.T) {
 		t.Fatalf("error: %v", ctx.Err())
 	}
 }
+
+type testMetrics struct {
+	mu        sync.Mutex
+	successes int
+	failures  int
+}
+
+func (m *testMetrics) RecordHeartbeat(success bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if success {
+		m.successes++
+	} else {
+		m.failures++
+	}
+}
+
+func (m *testMetrics) counts() (successes, failures int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.successes, m.failures
+}
+
+func testPayload() *Payload {
+	return &Payload{
+		Version: "v1.2.3",
+		Meta:    "meta",
+		Moniker: "yeet",
+		PeerID:  "1UiUfoobar",
+		ChainID: 1234,
+	}
+}
+
+// startBeat runs the heartbeat against a stub server with the given handler, until the test ends.
+func startBeat(t *testing.T, handler http.HandlerFunc, m Metrics, payload *Payload) {
+	s := httptest.NewServer(handler)
+	t.Cleanup(s.Close)
+	ctx, cancel := context.WithCancel(context.Background())
+	doneCh := make(chan struct{})
+	go func() {
+		_ = beat(ctx, testlog.Logger(t, log.LvlError), m, s.URL, payload, time.Hour, retry.Fixed(time.Millisecond))
+		close(doneCh)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-doneCh
+	})
+}
+
+func TestBeatRedaction(t *testing.T) {
+	fieldKeys := map[string][]string{
+		FieldVersion: {"version", "meta"},
+		FieldMoniker: {"moniker"},
+		FieldPeerID:  {"peerID"},
+		FieldChainID: {"chainID"},
+	}
+	// every combination of redacted fields
+	for mask := 0; mask < 1<<len(RedactableFields); mask++ {
+		var redact []string
+		for i, f := range RedactableFields {
+			if mask&(1<<i) != 0 {
+				redact = append(redact, f)
+			}
+		}
+		t.Run(fmt.Sprintf("%v", redact), func(t *testing.T) {
+			payload := testPayload()
+			require.NoError(t, payload.Redact(redact))
+
+			reqCh := make(chan []byte, 1)
+			startBeat(t, func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				w.WriteHeader(204)
+				select {
+				case reqCh <- body:
+				default:
+				}
+			}, NoopMetrics{}, payload)
+
+			var body []byte
+			select {
+			case body = <-reqCh:
+			case <-time.After(10 * time.Second):
+				t.Fatal("no heartbeat received")
+			}
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(body, &got))
+			var expected map[string]any
+			require.NoError(t, json.Unmarshal([]byte(expHeartbeat), &expected))
+			for _, f := range redact {
+				for _, key := range fieldKeys[f] {
+					delete(expected, key)
+				}
+			}
+			require.Equal(t, expected, got)
+		})
+	}
+}
+
+func TestRedactUnknownField(t *testing.T) {
+	require.ErrorContains(t, testPayload().Redact([]string{"public-ip"}), "unknown heartbeat field")
+}
+
+func TestBeatRetry(t *testing.T) {
+	var requests atomic.Int32
+	m := &testMetrics{}
+	startBeat(t, func(w http.ResponseWriter, r *http.Request) {
+		// the heartbeat fails twice, before it is delivered
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(204)
+	}, m, testPayload())
+
+	require.Eventually(t, func() bool {
+		successes, _ := m.counts()
+		return successes == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	_, failures := m.counts()
+	require.Zero(t, failures, "retried deliveries are not failures")
+	require.EqualValues(t, 3, requests.Load())
+}
+
+func TestBeatFailure(t *testing.T) {
+	var requests atomic.Int32
+	m := &testMetrics{}
+	startBeat(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, m, testPayload())
+
+	require.Eventually(t, func() bool {
+		_, failures := m.counts()
+		return failures == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	successes, _ := m.counts()
+	require.Zero(t, successes)
+	require.EqualValues(t, sendAttempts, requests.Load())
+}
@@ -30,10 +30,18 @@ const (
 	BatchMethod = "<batch>"
 )
 
+// Results of alt-sync payloads, see RecordAltSyncPayloads.
+const (
+	AltSyncRequested = "requested"
+	AltSyncReceived  = "received"
+	AltSyncInvalid   = "invalid"
+)
+
 type Metricer interface {
 	RecordInfo(version string)
 	RecordUp()
 	RecordRPCServerRequest(method string) func()
+	RecordRPCServerNamespaceRequest(namespace string) func(failed bool)
 	RecordRPCClientRequest(method string) func(err error)
 	RecordRPCClientResponse(method string, err error)
 	SetDerivationIdle(status bool)
@@ -49,8 +57,15 @@ type Metricer interface {
 	RecordDerivedBatches(batchType string)
 	CountSequencedTxs(count int)
 	RecordL1ReorgDepth(d uint64)
+	RecordUnsafeReorg(depth uint64)
+	RecordDerivationStep(outcome string)
+	RecordDerivationStageTime(stage string, duration time.Duration)
+	RecordL2EngineRequestTime(method string, duration time.Duration)
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
+	RecordSequencerDrift(drift time.Duration)
+	RecordSequencerDriftExceededBlock()
+	RecordSequencerBuildPath(path string)
 	RecordGossipEvent(evType int32)
 	IncPeerCount()
 	DecPeerCount()
@@ -68,12 +83,25 @@ type Metricer interface {
 	SetPeerScores(allScores []store.PeerScores)
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ServerPayloadsByRangeEvent(start uint64, served uint64, resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
+	RecordAltSyncPayloads(source string, result string, n int)
 	RecordPeerUnban()
 	RecordIPUnban()
 	RecordDial(allow bool)
 	RecordAccept(allow bool)
+	RecordStaticPeerDial(success bool)
+	SetStaticPeersConnected(n int)
+	RecordDiscoveredNode(result string)
+	RecordDNSDiscoveredNode(result string)
+	RecordGossipTopicBytes(topic string, direction string, size int)
+	RecordGossipPublishAttempt(retry bool)
+	RecordGossipPublishDrop(reason string)
+	SetProtocolBandwidth(protocol string, in, out int64)
+	SetPeerBandwidth(peers map[string]libp2pmetrics.Stats)
 	ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion)
+	RecordP2PSequencerAddress(addr common.Address)
+	RecordHeartbeat(success bool)
 }
 
 // Metrics tracks all the metrics for the op-node.
@@ -99,14 +127,25 @@ type Metrics struct {
 	P2PReqDurationSeconds *prometheus.HistogramVec
 	P2PReqTotal           *prometheus.CounterVec
 	P2PPayloadByNumber    *prometheus.GaugeVec
+	P2PPayloadsByRange    prometheus.Gauge
+	P2PPayloadsServed     prometheus.Counter
 
 	PayloadsQuarantineTotal prometheus.Gauge
 
+	AltSyncPayloads *prometheus.CounterVec
+
 	SequencerInconsistentL1Origin *metrics.Event
 	SequencerResets               *metrics.Event
 
+	SequencerDriftSeconds             prometheus.Gauge
+	SequencerDriftExceededBlocksTotal prometheus.Counter
+	SequencerBuildPaths               *prometheus.CounterVec
+
 	L1RequestDurationSeconds *prometheus.HistogramVec
 
+	DerivationStageDurationSeconds *prometheus.HistogramVec
+	L2EngineRequestDurationSeconds *prometheus.HistogramVec
+
 	SequencerBuildingDiffDurationSeconds prometheus.Histogram
 	SequencerBuildingDiffTotal           prometheus.Counter
 
@@ -120,6 +159,10 @@ type Metrics struct {
 
 	L1ReorgDepth prometheus.Histogram
 
+	UnsafeReorgs *prometheus.CounterVec
+
+	DerivationSteps *prometheus.CounterVec
+
 	TransactionsSequencedTotal prometheus.Counter
 
 	// Channel Bank Metrics
@@ -136,6 +179,16 @@ type Metrics struct {
 	IPUnbans          prometheus.Counter
 	Dials             *prometheus.CounterVec
 	Accepts           *prometheus.CounterVec
+	StaticPeerDials   *prometheus.CounterVec
+	StaticPeers       prometheus.Gauge
+	DiscoveredNodes   *prometheus.CounterVec
+	DNSNodes          *prometheus.CounterVec
+	GossipTopicBytes  *prometheus.CounterVec
+	GossipPublishes   prometheus.Counter
+	GossipRetries     prometheus.Counter
+	GossipDrops       *prometheus.CounterVec
+	ProtocolBandwidth *prometheus.GaugeVec
+	PeerBandwidth     *prometheus.GaugeVec
 	PeerScores        *prometheus.HistogramVec
 
 	ChannelInputBytes prometheus.Counter
@@ -146,6 +199,11 @@ type Metrics struct {
 	// ProtocolVersions is pseudo-metric to report the exact protocol version info
 	ProtocolVersions *prometheus.GaugeVec
 
+	Heartbeats *prometheus.CounterVec
+
+	// P2PSequencerAddress is a pseudo-metric to report the unsafe block signer address that is currently active
+	P2PSequencerAddress *prometheus.GaugeVec
+
 	registry *prometheus.Registry
 	factory  metrics.Factory
 }
@@ -220,6 +278,22 @@ func NewMetrics(procName string) *Metrics {
 			Help:      "Histogram of L1 Reorg Depths",
 		}),
 
+		UnsafeReorgs: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "unsafe_reorgs",
+			Help:      "Count of unsafe L2 blocks reorged by conflicting safe blocks derived from L1, by reorg depth",
+		}, []string{
+			"depth",
+		}),
+
+		DerivationSteps: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "derivation_steps",
+			Help:      "Count of derivation pipeline steps, by outcome class",
+		}, []string{
+			"outcome",
+		}),
+
 		TransactionsSequencedTotal: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "transactions_sequenced_total",
@@ -284,6 +358,66 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "accepts",
 			Help:      "Count of incoming dial attempts to accept, with label to filter to allowed attempts",
 		}, []string{"allow"}),
+		StaticPeerDials: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "static_peer_dials",
+			Help:      "Count of dial attempts to disconnected static peers, with label to filter to successful attempts",
+		}, []string{"success"}),
+		StaticPeers: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "static_peers_connected",
+			Help:      "Count of static peers that are currently connected",
+		}),
+		DiscoveredNodes: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "discovered_nodes",
+			Help:      "Count of discovered node records, by filter result: matching, unmarked or filtered",
+		}, []string{"result"}),
+		DNSNodes: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "dns_discovered_nodes",
+			Help:      "Count of node records resolved from DNS node lists, by filter result: matching (accepted), unmarked or filtered",
+		}, []string{"result"}),
+		GossipTopicBytes: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "gossip_topic_bytes_total",
+			Help:      "Bytes of gossip messages, by topic and direction",
+		}, []string{"topic", "direction"}),
+		GossipPublishes: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "gossip_publish_attempts_total",
+			Help:      "Count of attempts to publish a block, including retries",
+		}),
+		GossipRetries: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "gossip_publish_retries_total",
+			Help:      "Count of retried attempts to publish a block, after an earlier attempt failed",
+		}),
+		GossipDrops: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "gossip_publish_drops_total",
+			Help:      "Count of blocks that failed to publish and are no longer retried, by reason",
+		}, []string{"reason"}),
+		ProtocolBandwidth: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "protocol_bandwidth_bytes_total",
+			Help:      "P2P bandwidth by protocol (gossip, reqresp, discovery or other) and direction",
+		}, []string{"protocol", "direction"}),
+		PeerBandwidth: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "peer_bandwidth_bytes_total",
+			Help:      "P2P bandwidth of the connected peers with the most traffic, by peer ID and direction",
+		}, []string{"peer", "direction"}),
 
 		headChannelOpenedEvent: metrics.NewEvent(factory, ns, "", "head_channel", "New channel at the front of the channel bank"),
 		channelTimedOutEvent:   metrics.NewEvent(factory, ns, "", "channel_timeout", "Channel has timed out"),
@@ -326,6 +460,18 @@ func NewMetrics(procName string) *Metrics {
 		}, []string{
 			"p2p_role", // "client" or "server"
 		}),
+		P2PPayloadsByRange: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "payloads_by_range",
+			Help:      "Start of the last served payloads by range request",
+		}),
+		P2PPayloadsServed: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "payloads_by_range_served_total",
+			Help:      "Number of payloads served in responses to payloads by range requests",
+		}),
 		PayloadsQuarantineTotal: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Subsystem: "p2p",
@@ -333,6 +479,15 @@ func NewMetrics(procName string) *Metrics {
 			Help:      "number of unverified execution payloads buffered in quarantine",
 		}),
 
+		AltSyncPayloads: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "alt_sync_payloads_total",
+			Help:      "Count of unsafe L2 payloads requested, received and rejected as invalid by alt-sync, per sync source",
+		}, []string{
+			"source", // "p2p" or "rpc"
+			"result", // "requested", "received" or "invalid"
+		}),
+
 		L1RequestDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: ns,
 			Name:      "l1_request_seconds",
@@ -341,6 +496,22 @@ func NewMetrics(procName string) *Metrics {
 			Help: "Histogram of L1 request time",
 		}, []string{"request"}),
 
+		DerivationStageDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "derivation_stage_seconds",
+			Buckets: []float64{
+				.0001, .0005, .001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help: "Histogram of the time spent in each derivation pipeline stage, per unit of work of the stage",
+		}, []string{"stage"}),
+
+		L2EngineRequestDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "l2_engine_request_seconds",
+			Buckets: []float64{
+				.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help: "Histogram of engine API request time, by method",
+		}, []string{"method"}),
+
 		SequencerBuildingDiffDurationSeconds: factory.NewHistogram(prometheus.HistogramOpts{
 			Namespace: ns,
 			Name:      "sequencer_building_diff_seconds",
@@ -365,6 +536,23 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "sequencer_sealing_total",
 			Help:      "Number of sequencer block sealing jobs",
 		}),
+		SequencerDriftSeconds: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sequencer_drift_seconds",
+			Help:      "Difference between the timestamp of the latest block the sequencer started building and the timestamp of its L1 origin",
+		}),
+		SequencerDriftExceededBlocksTotal: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "sequencer_drift_exceeded_blocks_total",
+			Help:      "Number of deposit-only blocks sealed by the sequencer because the max sequencer drift was exceeded",
+		}),
+		SequencerBuildPaths: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "sequencer_build_paths",
+			Help:      "Number of blocks sealed by the sequencer, by path: within the build time budget, the best block built so far after exceeding it, or the deposit-only fallback",
+		}, []string{
+			"path",
+		}),
 
 		ProtocolVersionDelta: factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: ns,
@@ -383,6 +571,19 @@ func NewMetrics(procName string) *Metrics {
 			"recommended",
 			"required",
 		}),
+		Heartbeats: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "heartbeats",
+			Help:      "Count of heartbeat deliveries, with label to filter to successful deliveries",
+		}, []string{"success"}),
+		P2PSequencerAddress: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "sequencer_address",
+			Help:      "Pseudo-metric tracking the unsafe block signer address that gossiped blocks are verified against",
+		}, []string{
+			"address",
+		}),
 
 		registry: registry,
 		factory:  factory,
@@ -466,6 +667,32 @@ func (m *Metrics) RecordL1ReorgDepth(d uint64) {
 	m.L1ReorgDepth.Observe(float64(d))
 }
 
+// RecordUnsafeReorg records a reorg of the unsafe L2 chain, with the number of unsafe blocks that were reorged.
+func (m *Metrics) RecordUnsafeReorg(depth uint64) {
+	m.UnsafeReorgs.WithLabelValues(unsafeReorgDepthBucket(depth)).Inc()
+}
+
+// unsafeReorgDepthBucket buckets the reorg depth, to bound the label cardinality.
+func unsafeReorgDepthBucket(depth uint64) string {
+	switch {
+	case depth <= 1:
+		return "1"
+	case depth <= 4:
+		return "2-4"
+	case depth <= 16:
+		return "5-16"
+	case depth <= 64:
+		return "17-64"
+	default:
+		return "65+"
+	}
+}
+
+// RecordDerivationStep records the outcome class of a derivation pipeline step.
+func (m *Metrics) RecordDerivationStep(outcome string) {
+	m.DerivationSteps.WithLabelValues(outcome).Inc()
+}
+
 func (m *Metrics) RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID) {
 	m.SequencerInconsistentL1Origin.Record()
 	m.RecordRef("l1_origin", "inconsistent_from", from.Number, 0, from.Hash)
@@ -476,6 +703,19 @@ func (m *Metrics) RecordSequencerReset() {
 	m.SequencerResets.Record()
 }
 
+func (m *Metrics) RecordSequencerDrift(drift time.Duration) {
+	m.SequencerDriftSeconds.Set(drift.Seconds())
+}
+
+func (m *Metrics) RecordSequencerDriftExceededBlock() {
+	m.SequencerDriftExceededBlocksTotal.Inc()
+}
+
+// RecordSequencerBuildPath records the path that a block sealed by the sequencer was built with.
+func (m *Metrics) RecordSequencerBuildPath(path string) {
+	m.SequencerBuildPaths.WithLabelValues(path).Inc()
+}
+
 func (m *Metrics) RecordGossipEvent(evType int32) {
 	m.GossipEventsTotal.WithLabelValues(pb.TraceEvent_Type_name[evType]).Inc()
 }
@@ -517,6 +757,16 @@ func (m *Metrics) RecordL1RequestTime(method string, duration time.Duration) {
 	m.L1RequestDurationSeconds.WithLabelValues(method).Observe(float64(duration) / float64(time.Second))
 }
 
+// RecordDerivationStageTime tracks the amount of time spent in a derivation pipeline stage, see the derive.Stage* names.
+func (m *Metrics) RecordDerivationStageTime(stage string, duration time.Duration) {
+	m.DerivationStageDurationSeconds.WithLabelValues(stage).Observe(duration.Seconds())
+}
+
+// RecordL2EngineRequestTime tracks the amount of time spent waiting for engine API requests.
+func (m *Metrics) RecordL2EngineRequestTime(method string, duration time.Duration) {
+	m.L2EngineRequestDurationSeconds.WithLabelValues(method).Observe(duration.Seconds())
+}
+
 // RecordSequencerBuildingDiffTime tracks the amount of time the sequencer was allowed between
 // start to finish, incl. sealing, minus the block time.
 // Ideally this is 0, realistically the sequencer scheduler may be busy with other jobs like syncing sometimes.
@@ -533,6 +783,11 @@ func (m *Metrics) RecordSequencerSealingTime(duration time.Duration) {
 }
 
 // StartServer starts the metrics server on the given hostname and port.
+// RegisterLogLevel exports the log level of the log handler, which may change at runtime.
+func (m *Metrics) RegisterLogLevel(h metrics.LogLevelHandler) {
+	metrics.NewLogLevelGauge(Namespace, m.factory, h)
+}
+
 func (m *Metrics) StartServer(hostname string, port int) (*ophttp.HTTPServer, error) {
 	addr := net.JoinHostPort(hostname, strconv.Itoa(port))
 	h := promhttp.InstrumentMetricHandler(
@@ -562,10 +817,22 @@ func (m *Metrics) ServerPayloadByNumberEvent(num uint64, resultCode byte, durati
 	m.P2PPayloadByNumber.WithLabelValues("server").Set(float64(num))
 }
 
+func (m *Metrics) ServerPayloadsByRangeEvent(start uint64, served uint64, resultCode byte, duration time.Duration) {
+	code := strconv.FormatUint(uint64(resultCode), 10)
+	m.P2PReqTotal.WithLabelValues("server", "payloads_by_range", code).Inc()
+	m.P2PReqDurationSeconds.WithLabelValues("server", "payloads_by_range", code).Observe(float64(duration) / float64(time.Second))
+	m.P2PPayloadsByRange.Set(float64(start))
+	m.P2PPayloadsServed.Add(float64(served))
+}
+
 func (m *Metrics) PayloadsQuarantineSize(n int) {
 	m.PayloadsQuarantineTotal.Set(float64(n))
 }
 
+func (m *Metrics) RecordAltSyncPayloads(source string, result string, n int) {
+	m.AltSyncPayloads.WithLabelValues(source, result).Add(float64(n))
+}
+
 func (m *Metrics) RecordChannelInputBytes(inputCompressedBytes int) {
 	m.ChannelInputBytes.Add(float64(inputCompressedBytes))
 }
@@ -605,6 +872,56 @@ func (m *Metrics) RecordAccept(allow bool) {
 		m.Accepts.WithLabelValues("false").Inc()
 	}
 }
+
+func (m *Metrics) RecordStaticPeerDial(success bool) {
+	if success {
+		m.StaticPeerDials.WithLabelValues("true").Inc()
+	} else {
+		m.StaticPeerDials.WithLabelValues("false").Inc()
+	}
+}
+
+func (m *Metrics) SetStaticPeersConnected(n int) {
+	m.StaticPeers.Set(float64(n))
+}
+
+func (m *Metrics) RecordDiscoveredNode(result string) {
+	m.DiscoveredNodes.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) RecordDNSDiscoveredNode(result string) {
+	m.DNSNodes.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) RecordGossipTopicBytes(topic string, direction string, size int) {
+	m.GossipTopicBytes.WithLabelValues(topic, direction).Add(float64(size))
+}
+
+func (m *Metrics) RecordGossipPublishAttempt(retry bool) {
+	m.GossipPublishes.Inc()
+	if retry {
+		m.GossipRetries.Inc()
+	}
+}
+
+func (m *Metrics) RecordGossipPublishDrop(reason string) {
+	m.GossipDrops.WithLabelValues(reason).Inc()
+}
+
+func (m *Metrics) SetProtocolBandwidth(protocol string, in, out int64) {
+	m.ProtocolBandwidth.WithLabelValues(protocol, "in").Set(float64(in))
+	m.ProtocolBandwidth.WithLabelValues(protocol, "out").Set(float64(out))
+}
+
+// SetPeerBandwidth replaces the per-peer bandwidth metrics, to bound the cardinality to the given peers.
+func (m *Metrics) SetPeerBandwidth(peers map[string]libp2pmetrics.Stats) {
+	m.PeerBandwidth.Reset()
+	for id, stats := range peers {
+		m.PeerBandwidth.WithLabelValues(id, "in").Set(float64(stats.TotalIn))
+		m.PeerBandwidth.WithLabelValues(id, "out").Set(float64(stats.TotalOut))
+	}
+}
+
 func (m *Metrics) ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion) {
 	m.ProtocolVersionDelta.WithLabelValues("local_recommended").Set(float64(local.Compare(recommended)))
 	m.ProtocolVersionDelta.WithLabelValues("local_required").Set(float64(local.Compare(required)))
@@ -613,6 +930,20 @@ func (m *Metrics) ReportProtocolVersions(local, engine, recommended, required pa
 	m.ProtocolVersions.WithLabelValues(local.String(), engine.String(), recommended.String(), required.String()).Set(1)
 }
 
+func (m *Metrics) RecordHeartbeat(success bool) {
+	if success {
+		m.Heartbeats.WithLabelValues("true").Inc()
+	} else {
+		m.Heartbeats.WithLabelValues("false").Inc()
+	}
+}
+
+// RecordP2PSequencerAddress reports the active unsafe block signer, replacing the previously reported address.
+func (m *Metrics) RecordP2PSequencerAddress(addr common.Address) {
+	m.P2PSequencerAddress.Reset()
+	m.P2PSequencerAddress.WithLabelValues(addr.String()).Set(1)
+}
+
 type noopMetricer struct {
 	metrics.NoopRPCMetrics
 }
@@ -664,12 +995,33 @@ func (n *noopMetricer) CountSequencedTxs(count int) {
 func (n *noopMetricer) RecordL1ReorgDepth(d uint64) {
 }
 
+func (n *noopMetricer) RecordUnsafeReorg(depth uint64) {
+}
+
+func (n *noopMetricer) RecordDerivationStep(outcome string) {
+}
+
+func (n *noopMetricer) RecordDerivationStageTime(stage string, duration time.Duration) {
+}
+
+func (n *noopMetricer) RecordL2EngineRequestTime(method string, duration time.Duration) {
+}
+
 func (n *noopMetricer) RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID) {
 }
 
 func (n *noopMetricer) RecordSequencerReset() {
 }
 
+func (n *noopMetricer) RecordSequencerDrift(drift time.Duration) {
+}
+
+func (n *noopMetricer) RecordSequencerDriftExceededBlock() {
+}
+
+func (n *noopMetricer) RecordSequencerBuildPath(path string) {
+}
+
 func (n *noopMetricer) RecordGossipEvent(evType int32) {
 }
 
@@ -707,9 +1059,15 @@ func (n *noopMetricer) ClientPayloadByNumberEvent(num uint64, resultCode byte, d
 func (n *noopMetricer) ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration) {
 }
 
+func (n *noopMetricer) ServerPayloadsByRangeEvent(start uint64, served uint64, resultCode byte, duration time.Duration) {
+}
+
 func (n *noopMetricer) PayloadsQuarantineSize(int) {
 }
 
+func (n *noopMetricer) RecordAltSyncPayloads(string, string, int) {
+}
+
 func (n *noopMetricer) RecordChannelInputBytes(int) {
 }
 
@@ -733,5 +1091,38 @@ func (n *noopMetricer) RecordDial(allow bool) {
 
 func (n *noopMetricer) RecordAccept(allow bool) {
 }
+
+func (n *noopMetricer) RecordStaticPeerDial(success bool) {
+}
+
+func (n *noopMetricer) SetStaticPeersConnected(int) {
+}
+
+func (n *noopMetricer) RecordDiscoveredNode(result string) {
+}
+
+func (n *noopMetricer) RecordDNSDiscoveredNode(result string) {
+}
+
+func (n *noopMetricer) RecordGossipTopicBytes(topic string, direction string, size int) {
+}
+
+func (n *noopMetricer) RecordGossipPublishAttempt(retry bool) {
+}
+
+func (n *noopMetricer) RecordGossipPublishDrop(reason string) {
+}
+
+func (n *noopMetricer) SetProtocolBandwidth(protocol string, in, out int64) {
+}
+
+func (n *noopMetricer) SetPeerBandwidth(peers map[string]libp2pmetrics.Stats) {
+}
 func (n *noopMetricer) ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion) {
 }
+
+func (n *noopMetricer) RecordP2PSequencerAddress(addr common.Address) {
+}
+
+func (n *noopMetricer) RecordHeartbeat(success bool) {
+}
@@ -3,12 +3,16 @@ package node
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -21,6 +25,8 @@ type l2EthClient interface {
 	// Optionally keys of the account storage trie can be specified to include with corresponding values in the proof.
 	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
 	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
+	// OutputV0WithProofAtBlock returns the output, and the verified L2ToL1MessagePasser account proof it was computed with.
+	OutputV0WithProofAtBlock(ctx context.Context, blockHash common.Hash, storageKeys []common.Hash) (*eth.OutputV0, *eth.AccountResult, error)
 }
 
 type driverClient interface {
@@ -30,8 +36,20 @@ type driverClient interface {
 	StartSequencer(ctx context.Context, blockHash common.Hash) error
 	StopSequencer(context.Context) (common.Hash, error)
 	SequencerActive(context.Context) (bool, error)
+	SequencerStatus(context.Context) (*driver.SequencerStatus, error)
+	StateSnapshot(context.Context) (*driver.StateSnapshot, error)
+	SubscribeSyncStatus() *driver.SyncStatusSubscription
 }
 
+// SafeDBReader reads the persisted safe head progression.
+type SafeDBReader interface {
+	SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1 eth.BlockID, safeHead eth.BlockID, err error)
+}
+
+// stateSnapshotTimeout bounds how long a state snapshot waits for the driver event loop,
+// so the snapshot of a stalled node fails fast, rather than hanging with the stalled event loop.
+const stateSnapshotTimeout = 5 * time.Second
+
 type adminAPI struct {
 	*rpc.CommonAdminAPI
 	dr driverClient
@@ -68,25 +86,48 @@ func (n *adminAPI) SequencerActive(ctx context.Context) (bool, error) {
 	return n.dr.SequencerActive(ctx)
 }
 
+// SequencerStatus returns whether the sequencer is enabled and active, and the latest block it sequenced.
+func (n *adminAPI) SequencerStatus(ctx context.Context) (*driver.SequencerStatus, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_sequencerStatus")
+	defer recordDur()
+	return n.dr.SequencerStatus(ctx)
+}
+
+// StateSnapshot returns a snapshot of the in-memory driver state, to debug stalls.
+func (n *adminAPI) StateSnapshot(ctx context.Context) (*driver.StateSnapshot, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_stateSnapshot")
+	defer recordDur()
+	ctx, cancel := context.WithTimeout(ctx, stateSnapshotTimeout)
+	defer cancel()
+	return n.dr.StateSnapshot(ctx)
+}
+
 type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
 	dr     driverClient
+	safeDB SafeDBReader
+	runCfg p2p.GossipRuntimeConfig
 	log    log.Logger
 	m      metrics.RPCMetricer
 }
 
-func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, log log.Logger, m metrics.RPCMetricer) *nodeAPI {
+func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, safeDB SafeDBReader, runCfg p2p.GossipRuntimeConfig, log log.Logger, m metrics.RPCMetricer) *nodeAPI {
 	return &nodeAPI{
 		config: config,
 		client: l2Client,
 		dr:     dr,
+		safeDB: safeDB,
+		runCfg: runCfg,
 		log:    log,
 		m:      m,
 	}
 }
 
-func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64) (*eth.OutputResponse, error) {
+// OutputAtBlock returns the output at the given L2 block, with the sync status at the time the block was looked up.
+// The proof of the L2ToL1MessagePasser account that the output was computed with is optionally included,
+// so withdrawals can be proven from a single consistent response.
+func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64, opts *eth.OutputAtBlockOptions) (*eth.OutputResponse, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_outputAtBlock")
 	defer recordDur()
 
@@ -95,17 +136,39 @@ func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64) (*et
 		return nil, fmt.Errorf("failed to get L2 block ref with sync status: %w", err)
 	}
 
-	output, err := n.client.OutputV0AtBlock(ctx, ref.Hash)
+	var output *eth.OutputV0
+	var proof *eth.AccountResult
+	if opts != nil && opts.IncludeProof {
+		output, proof, err = n.client.OutputV0WithProofAtBlock(ctx, ref.Hash, opts.StorageKeys)
+	} else {
+		output, err = n.client.OutputV0AtBlock(ctx, ref.Hash)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get L2 output at block %s: %w", ref, err)
 	}
 	return &eth.OutputResponse{
 		Version:               output.Version(),
-		OutputRoot:            eth.OutputRoot(output),
+		OutputRoot:            output.OutputRoot(),
 		BlockRef:              ref,
 		WithdrawalStorageRoot: common.Hash(output.MessagePasserStorageRoot),
 		StateRoot:             common.Hash(output.StateRoot),
 		Status:                status,
+		MessagePasserProof:    proof,
+	}, nil
+}
+
+// SafeHeadAtL1Block returns the safe head that was derived from the L1 chain up to and including the given L1 block,
+// and the latest L1 block that advanced the safe head. This requires the safe head database to be enabled.
+func (n *nodeAPI) SafeHeadAtL1Block(ctx context.Context, number hexutil.Uint64) (*eth.SafeHeadResponse, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_safeHeadAtL1Block")
+	defer recordDur()
+	l1Block, safeHead, err := n.safeDB.SafeHeadAtL1(ctx, uint64(number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get safe head at L1 block %d: %w", number, err)
+	}
+	return &eth.SafeHeadResponse{
+		L1Block:  l1Block,
+		SafeHead: safeHead,
 	}, nil
 }
 
@@ -121,8 +184,61 @@ func (n *nodeAPI) RollupConfig(_ context.Context) (*rollup.Config, error) {
 	return n.config, nil
 }
 
+// SequencerKeys returns the unsafe block signer keys that gossiped blocks are currently accepted from,
+// as configured manually, or as last loaded from the SystemConfig contract.
+func (n *nodeAPI) SequencerKeys(_ context.Context) (*eth.SequencerKeys, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_sequencerKeys")
+	defer recordDur()
+	keys := n.runCfg.P2PSequencerKeys()
+	return &keys, nil
+}
+
 func (n *nodeAPI) Version(ctx context.Context) (string, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_version")
 	defer recordDur()
 	return version.Version + "-" + version.Meta, nil
 }
+
+// subscriptionAPI serves the eth_subscribe subscriptions of the rollup node, over WebSocket connections.
+type subscriptionAPI struct {
+	dr  driverClient
+	log log.Logger
+	m   metrics.RPCMetricer
+}
+
+func NewSubscriptionAPI(dr driverClient, log log.Logger, m metrics.RPCMetricer) *subscriptionAPI {
+	return &subscriptionAPI{
+		dr:  dr,
+		log: log,
+		m:   m,
+	}
+}
+
+// Heads subscribes to the sync status, starting with the current status,
+// and notified whenever the unsafe, safe or finalized L2 head changes.
+// A slow subscriber misses intermediate updates, rather than blocking the driver.
+func (s *subscriptionAPI) Heads(ctx context.Context) (*gethrpc.Subscription, error) {
+	recordDur := s.m.RecordRPCServerRequest("eth_subscribe")
+	defer recordDur()
+	notifier, supported := gethrpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+	sub := s.dr.SubscribeSyncStatus()
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case status := <-sub.Updates():
+				if err := notifier.Notify(rpcSub.ID, status); err != nil {
+					s.log.Debug("Failed to notify heads subscription", "id", rpcSub.ID, "err", err)
+					return
+				}
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
@@ -8,18 +8,37 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/flags"
+	"github.com/ethereum-optimism/optimism/op-node/heartbeat"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	"github.com/ethereum/go-ethereum/log"
 )
 
+const (
+	// L1ContractsCheckWarn logs a warning if the rollup L1 addresses do not match the L1 state.
+	L1ContractsCheckWarn = "warn"
+	// L1ContractsCheckStrict refuses to start if the rollup L1 addresses do not match the L1 state.
+	L1ContractsCheckStrict = "strict"
+	// L1ContractsCheckSkip disables the check, e.g. for devnets that deploy the L1 contracts later.
+	L1ContractsCheckSkip = "skip"
+)
+
 type Config struct {
 	L1 L1EndpointSetup
 	L2 L2EndpointSetup
 
+	// Beacon is the L1 beacon-node API endpoint, to fetch the blobs of batcher transactions from.
+	// It is required from the Eclipse upgrade onwards, and may be nil before.
+	Beacon L1BeaconEndpointSetup
+
+	// L2Sync is an optional RPC endpoint of another L2 node, to fetch missing unsafe blocks from.
+	// This may be nil, or set up without an endpoint, if RPC alt-sync is disabled.
+	L2Sync L2SyncEndpointSetup
+
 	Driver driver.Config
 
 	Rollup rollup.Config
@@ -28,6 +47,10 @@ type Config struct {
 	// if the node is sequencing and if the p2p stack is enabled
 	P2PSigner p2p.SignerSetup
 
+	// P2PSequencerKeys are the keys that gossiped blocks may be signed with, overriding the unsafe block signer
+	// of the SystemConfig, e.g. to rotate the key manually. Optional.
+	P2PSequencerKeys *eth.SequencerKeys
+
 	RPC RPCConfig
 
 	P2P p2p.SetupP2P
@@ -57,17 +80,44 @@ type Config struct {
 	// change of the given severity (major/minor/patch). Disabled if empty.
 	RollupHalt string
 
+	// RollupHaltAction is how to halt on an incompatible protocol version requirement, see RollupHalt:
+	// shutdown (default if empty), stop-sequencer, or warn.
+	RollupHaltAction string
+
+	// SkipGenesisCheck disables the startup check of the rollup genesis against the L1 and L2 RPCs.
+	// The chain IDs are still verified.
+	SkipGenesisCheck bool
+
+	// L1ContractsCheck configures the startup check of the rollup L1 addresses against the L1 state:
+	// one of L1ContractsCheckWarn (default if empty), L1ContractsCheckStrict or L1ContractsCheckSkip.
+	L1ContractsCheck string
+
 	// Cancel to request a premature shutdown of the node itself, e.g. when halting. This may be nil.
 	Cancel context.CancelCauseFunc
 
 	// [OPTIONAL] The reth DB path to read receipts from
 	RethDBPath string
+
+	// SafeDBPath is the path of the database of the safe head progression per L1 block. Disabled if empty.
+	SafeDBPath string
+	// SafeDBRetention is the number of L1 blocks to keep the safe head progression of. All is kept if 0.
+	SafeDBRetention uint64
+
+	// Health configures the thresholds of the sync health check
+	Health HealthConfig
+
+	// ShutdownTimeout bounds the graceful shutdown of the RPC server, p2p stack, driver and clients,
+	// after which in-flight work is aborted. The metrics and pprof servers are closed last, after any halt idling.
+	// Not bounded if 0.
+	ShutdownTimeout time.Duration
 }
 
 type RPCConfig struct {
 	ListenAddr  string
 	ListenPort  int
 	EnableAdmin bool
+	// RateLimits are the requests per second that each RPC method namespace is limited to, e.g. admin.
+	RateLimits map[string]float64
 }
 
 func (cfg *RPCConfig) HttpEndpoint() string {
@@ -96,6 +146,18 @@ type HeartbeatConfig struct {
 	Enabled bool
 	Moniker string
 	URL     string
+	// Redact lists the heartbeat payload fields to omit, see heartbeat.RedactableFields.
+	Redact []string
+}
+
+func (cfg *HeartbeatConfig) Check() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.URL == "" {
+		return errors.New("heartbeat URL is required if heartbeats are enabled")
+	}
+	return (&heartbeat.Payload{}).Redact(cfg.Redact)
 }
 
 func (cfg *Config) LoadPersisted(log log.Logger) error {
@@ -124,6 +186,19 @@ func (cfg *Config) Check() error {
 	if err := cfg.L2.Check(); err != nil {
 		return fmt.Errorf("l2 endpoint config error: %w", err)
 	}
+	if cfg.Rollup.EclipseTime != nil {
+		if cfg.Beacon == nil {
+			return fmt.Errorf("the Eclipse upgrade is scheduled, but no L1 beacon API endpoint is configured")
+		}
+		if err := cfg.Beacon.Check(); err != nil {
+			return fmt.Errorf("l1 beacon endpoint config error: %w", err)
+		}
+	}
+	if cfg.L2Sync != nil {
+		if err := cfg.L2Sync.Check(); err != nil {
+			return fmt.Errorf("sync config error: %w", err)
+		}
+	}
 	if err := cfg.Rollup.Check(); err != nil {
 		return fmt.Errorf("rollup config error: %w", err)
 	}
@@ -138,8 +213,32 @@ func (cfg *Config) Check() error {
 			return fmt.Errorf("p2p config error: %w", err)
 		}
 	}
+	if cfg.P2PSequencerKeys != nil {
+		if err := cfg.P2PSequencerKeys.Check(); err != nil {
+			return fmt.Errorf("p2p sequencer keys config error: %w", err)
+		}
+	}
+	if err := cfg.Heartbeat.Check(); err != nil {
+		return fmt.Errorf("heartbeat config error: %w", err)
+	}
+	if cfg.ShutdownTimeout < 0 {
+		return fmt.Errorf("negative shutdown timeout: %s", cfg.ShutdownTimeout)
+	}
+	if err := cfg.Health.Check(); err != nil {
+		return fmt.Errorf("health check config error: %w", err)
+	}
+	switch cfg.L1ContractsCheck {
+	case "", L1ContractsCheckWarn, L1ContractsCheckStrict, L1ContractsCheckSkip:
+	default:
+		return fmt.Errorf("invalid L1 contracts check option: %q", cfg.L1ContractsCheck)
+	}
 	if !(cfg.RollupHalt == "" || cfg.RollupHalt == "major" || cfg.RollupHalt == "minor" || cfg.RollupHalt == "patch") {
 		return fmt.Errorf("invalid rollup halting option: %q", cfg.RollupHalt)
 	}
+	switch cfg.RollupHaltAction {
+	case "", HaltActionShutdown, HaltActionStopSequencer, HaltActionWarn:
+	default:
+		return fmt.Errorf("invalid rollup halting action: %q", cfg.RollupHaltAction)
+	}
 	return nil
 }
@@ -8,6 +8,8 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 type RunningState int
@@ -19,13 +21,22 @@ const (
 )
 
 type persistedState struct {
-	SequencerStarted *bool `json:"sequencerStarted,omitempty"`
+	SequencerStarted *bool              `json:"sequencerStarted,omitempty"`
+	P2PSequencerKeys *eth.SequencerKeys `json:"p2pSequencerKeys,omitempty"`
 }
 
 type ConfigPersistence interface {
 	SequencerStarted() error
 	SequencerStopped() error
 	SequencerState() (RunningState, error)
+	SequencerKeysPersistence
+}
+
+// SequencerKeysPersistence persists the p2p sequencer keys, so that a key rotation continues after a restart.
+type SequencerKeysPersistence interface {
+	// P2PSequencerKeys returns the persisted keys, or nil if no keys are persisted.
+	P2PSequencerKeys() (*eth.SequencerKeys, error)
+	SetP2PSequencerKeys(keys eth.SequencerKeys) error
 }
 
 var _ ConfigPersistence = (*ActiveConfigPersistence)(nil)
@@ -41,21 +52,38 @@ func NewConfigPersistence(file string) *ActiveConfigPersistence {
 }
 
 func (p *ActiveConfigPersistence) SequencerStarted() error {
-	return p.persist(true)
+	return p.persistSequencerStarted(true)
 }
 
 func (p *ActiveConfigPersistence) SequencerStopped() error {
-	return p.persist(false)
+	return p.persistSequencerStarted(false)
 }
 
-// persist writes the new config state to the file as safely as possible.
+func (p *ActiveConfigPersistence) persistSequencerStarted(sequencerStarted bool) error {
+	return p.persist(func(state *persistedState) {
+		state.SequencerStarted = &sequencerStarted
+	})
+}
+
+func (p *ActiveConfigPersistence) SetP2PSequencerKeys(keys eth.SequencerKeys) error {
+	return p.persist(func(state *persistedState) {
+		state.P2PSequencerKeys = &keys
+	})
+}
+
+// persist applies the update to the config state, and writes the new state to the file as safely as possible.
 // It uses sync to ensure the data is actually persisted to disk and initially writes to a temp file
 // before renaming it into place. On UNIX systems this rename is typically atomic, ensuring the
 // actual file isn't corrupted if IO errors occur during writing.
-func (p *ActiveConfigPersistence) persist(sequencerStarted bool) error {
+func (p *ActiveConfigPersistence) persist(update func(state *persistedState)) error {
 	p.lock.Lock()
 	defer p.lock.Unlock()
-	data, err := json.Marshal(persistedState{SequencerStarted: &sequencerStarted})
+	state, err := p.readLocked()
+	if err != nil {
+		return err
+	}
+	update(&state)
+	data, err := json.Marshal(state)
 	if err != nil {
 		return fmt.Errorf("marshall new config: %w", err)
 	}
@@ -102,9 +130,21 @@ func (p *ActiveConfigPersistence) SequencerState() (RunningState, error) {
 	}
 }
 
+func (p *ActiveConfigPersistence) P2PSequencerKeys() (*eth.SequencerKeys, error) {
+	config, err := p.read()
+	if err != nil {
+		return nil, err
+	}
+	return config.P2PSequencerKeys, nil
+}
+
 func (p *ActiveConfigPersistence) read() (persistedState, error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
+	return p.readLocked()
+}
+
+func (p *ActiveConfigPersistence) readLocked() (persistedState, error) {
 	data, err := os.ReadFile(p.file)
 	if errors.Is(err, os.ErrNotExist) {
 		// persistedState.SequencerStarted == nil: SequencerState() will return StateUnset if no state is found
@@ -118,7 +158,7 @@ func (p *ActiveConfigPersistence) read() (persistedState, error) {
 	if err = dec.Decode(&config); err != nil {
 		return persistedState{}, fmt.Errorf("invalid config file (%v): %w", p.file, err)
 	}
-	if config.SequencerStarted == nil {
+	if config.SequencerStarted == nil && config.P2PSequencerKeys == nil {
 		return persistedState{}, fmt.Errorf("missing sequencerStarted value in config file (%v)", p.file)
 	}
 	return config, nil
@@ -140,3 +180,11 @@ func (d DisabledConfigPersistence) SequencerStarted() error {
 func (d DisabledConfigPersistence) SequencerStopped() error {
 	return nil
 }
+
+func (d DisabledConfigPersistence) P2PSequencerKeys() (*eth.SequencerKeys, error) {
+	return nil, nil
+}
+
+func (d DisabledConfigPersistence) SetP2PSequencerKeys(keys eth.SequencerKeys) error {
+	return nil
+}
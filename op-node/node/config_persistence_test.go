@@ -3,7 +3,10 @@ package node
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 func TestActive(t *testing.T) {
@@ -59,6 +62,29 @@ func TestActive(t *testing.T) {
 		require.Equal(t, StateStopped, state)
 	})
 
+	t.Run("PersistP2PSequencerKeys", func(t *testing.T) {
+		config1 := create()
+		keys, err := config1.P2PSequencerKeys()
+		require.NoError(t, err)
+		require.Nil(t, keys)
+
+		expected := eth.SequencerKeys{Primary: common.Address{0xbb}, Secondary: common.Address{0xaa}, RotationTime: 1000}
+		require.NoError(t, config1.SetP2PSequencerKeys(expected))
+		state, err := config1.SequencerState()
+		require.NoError(t, err)
+		require.Equal(t, StateUnset, state)
+
+		// the sequencer state and the keys are persisted independently
+		require.NoError(t, config1.SequencerStopped())
+		config2 := NewConfigPersistence(config1.file)
+		keys, err = config2.P2PSequencerKeys()
+		require.NoError(t, err)
+		require.Equal(t, &expected, keys)
+		state, err = config2.SequencerState()
+		require.NoError(t, err)
+		require.Equal(t, StateStopped, state)
+	})
+
 	t.Run("CreateParentDirs", func(t *testing.T) {
 		dir := t.TempDir()
 		config := NewConfigPersistence(dir + "/some/dir/state")
@@ -93,4 +119,9 @@ func TestDisabledConfigPersistence_AlwaysUnset(t *testing.T) {
 	state, err = config.SequencerState()
 	require.NoError(t, err)
 	require.Equal(t, StateUnset, state)
+
+	require.NoError(t, config.SetP2PSequencerKeys(eth.SequencerKeys{Primary: common.Address{0xbb}}))
+	keys, err := config.P2PSequencerKeys()
+	require.NoError(t, err)
+	require.Nil(t, keys)
 }
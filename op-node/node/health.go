@@ -0,0 +1,147 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+// healthCheckTimeout bounds how long a health check waits for the driver and the engine,
+// so the probe of a stalled node fails, rather than hanging.
+const healthCheckTimeout = 5 * time.Second
+
+// HealthConfig configures the thresholds of the sync health check. A threshold of 0 disables the check.
+type HealthConfig struct {
+	// L1HeadMaxAge is the maximum age of the latest L1 head seen by the node, by L1 block timestamp.
+	L1HeadMaxAge time.Duration
+	// UnsafeHeadMaxStall is the maximum duration without progress of the unsafe L2 head.
+	UnsafeHeadMaxStall time.Duration
+	// SafeHeadMaxStall is the maximum duration without progress of the safe L2 head.
+	SafeHeadMaxStall time.Duration
+}
+
+func (c *HealthConfig) Check() error {
+	if c.L1HeadMaxAge < 0 || c.UnsafeHeadMaxStall < 0 || c.SafeHeadMaxStall < 0 {
+		return fmt.Errorf("negative health check threshold: %+v", *c)
+	}
+	return nil
+}
+
+// HealthStatus is the result of a sync health check.
+type HealthStatus struct {
+	Healthy bool `json:"healthy"`
+	// Reasons explains why the node is unhealthy. Empty if healthy.
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+type healthDriverClient interface {
+	StateSnapshot(context.Context) (*driver.StateSnapshot, error)
+}
+
+type healthEngineClient interface {
+	InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error)
+}
+
+// healthChecker checks that the node is making sync progress, rather than only running.
+type healthChecker struct {
+	cfg    HealthConfig
+	dr     healthDriverClient
+	engine healthEngineClient
+	clock  clock.Clock
+	log    log.Logger
+
+	// startTime is used instead of the progress time of the L2 heads, if there was no progress since the start.
+	startTime time.Time
+}
+
+func newHealthChecker(cfg HealthConfig, dr healthDriverClient, engine healthEngineClient, clk clock.Clock, log log.Logger) *healthChecker {
+	return &healthChecker{
+		cfg:       cfg,
+		dr:        dr,
+		engine:    engine,
+		clock:     clk,
+		log:       log,
+		startTime: clk.Now(),
+	}
+}
+
+// Check checks the sync health: the L1 head must be recent, the unsafe and safe L2 heads must be advancing,
+// and the engine must be reachable.
+func (h *healthChecker) Check(ctx context.Context) *HealthStatus {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	var reasons []string
+	snap, err := h.dr.StateSnapshot(ctx)
+	if err != nil {
+		// without the driver state there is nothing else to check
+		return &HealthStatus{Reasons: []string{fmt.Sprintf("driver is unresponsive: %v", err)}}
+	}
+	now := h.clock.Now()
+
+	if h.cfg.L1HeadMaxAge > 0 {
+		if head := snap.L1.Head; head == (eth.L1BlockRef{}) {
+			reasons = append(reasons, "no L1 head seen yet")
+		} else if age := now.Sub(time.Unix(int64(head.Time), 0)); age > h.cfg.L1HeadMaxAge {
+			reasons = append(reasons, fmt.Sprintf("L1 head %s is %s old, exceeding %s", head, age.Truncate(time.Second), h.cfg.L1HeadMaxAge))
+		}
+	}
+	if stall := h.sinceProgress(now, snap.LastProgress.UnsafeL2); h.cfg.UnsafeHeadMaxStall > 0 && stall > h.cfg.UnsafeHeadMaxStall {
+		reasons = append(reasons, fmt.Sprintf("unsafe L2 head %s did not advance for %s, exceeding %s", snap.Engine.Unsafe, stall.Truncate(time.Second), h.cfg.UnsafeHeadMaxStall))
+	}
+	if stall := h.sinceProgress(now, snap.LastProgress.SafeL2); h.cfg.SafeHeadMaxStall > 0 && stall > h.cfg.SafeHeadMaxStall {
+		reasons = append(reasons, fmt.Sprintf("safe L2 head %s did not advance for %s, exceeding %s", snap.Engine.Safe, stall.Truncate(time.Second), h.cfg.SafeHeadMaxStall))
+	}
+
+	if unsafe := snap.Engine.Unsafe; unsafe.Hash == (common.Hash{}) {
+		reasons = append(reasons, "engine forkchoice state is not known yet")
+	} else if _, err := h.engine.InfoByHash(ctx, unsafe.Hash); err != nil {
+		reasons = append(reasons, fmt.Sprintf("engine is unreachable: %v", err))
+	}
+
+	if len(reasons) > 0 {
+		h.log.Debug("Node is unhealthy", "reasons", reasons)
+	}
+	return &HealthStatus{Healthy: len(reasons) == 0, Reasons: reasons}
+}
+
+// sinceProgress returns the duration since the given progress unix timestamp in milliseconds,
+// or since the start of the health checker if there was no progress yet.
+func (h *healthChecker) sinceProgress(now time.Time, progressMilli int64) time.Duration {
+	if progressMilli == 0 {
+		return now.Sub(h.startTime)
+	}
+	return now.Sub(time.UnixMilli(progressMilli))
+}
+
+// ServeHTTP serves the health status as JSON, with status code 503 if unhealthy.
+func (h *healthChecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status := h.Check(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	if !status.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+type healthAPI struct {
+	checker *healthChecker
+	m       metrics.RPCMetricer
+}
+
+// Health returns the sync health of the node, with the reasons if unhealthy.
+func (h *healthAPI) Health(ctx context.Context) (*HealthStatus, error) {
+	recordDur := h.m.RecordRPCServerRequest("optimism_health")
+	defer recordDur()
+	return h.checker.Check(ctx), nil
+}
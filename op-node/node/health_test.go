@@ -0,0 +1,199 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	rpcclient "github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type fakeHealthDriver struct {
+	snap *driver.StateSnapshot
+	err  error
+}
+
+func (f *fakeHealthDriver) StateSnapshot(ctx context.Context) (*driver.StateSnapshot, error) {
+	return f.snap, f.err
+}
+
+type fakeHealthEngine struct {
+	err error
+}
+
+func (f *fakeHealthEngine) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &testutils.MockBlockInfo{InfoHash: hash}, nil
+}
+
+var testHealthConfig = HealthConfig{
+	L1HeadMaxAge:       time.Minute,
+	UnsafeHeadMaxStall: time.Minute,
+	SafeHeadMaxStall:   time.Hour,
+}
+
+// healthySnapshot is the state of a node that is in sync at the given time.
+func healthySnapshot(now time.Time) *driver.StateSnapshot {
+	return &driver.StateSnapshot{
+		Engine: driver.EngineSnapshot{
+			Unsafe: eth.L2BlockRef{Hash: common.Hash{0xaa}, Number: 100},
+			Safe:   eth.L2BlockRef{Hash: common.Hash{0xbb}, Number: 90},
+		},
+		L1: driver.L1Snapshot{
+			Head: eth.L1BlockRef{Hash: common.Hash{0xcc}, Number: 10, Time: uint64(now.Add(-12 * time.Second).Unix())},
+		},
+		LastProgress: driver.ProgressSnapshot{
+			UnsafeL2: now.Add(-2 * time.Second).UnixMilli(),
+			SafeL2:   now.Add(-5 * time.Minute).UnixMilli(),
+		},
+	}
+}
+
+func requireUnhealthy(t *testing.T, status *HealthStatus, reason string) {
+	require.False(t, status.Healthy)
+	require.Len(t, status.Reasons, 1)
+	require.Contains(t, status.Reasons[0], reason)
+}
+
+func TestHealthCheck(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlError)
+	clk := clock.NewDeterministicClock(time.Unix(1_700_000_000, 0))
+	ctx := context.Background()
+	setup := func(cfg HealthConfig) (*healthChecker, *fakeHealthDriver, *fakeHealthEngine) {
+		dr := &fakeHealthDriver{snap: healthySnapshot(clk.Now())}
+		engine := &fakeHealthEngine{}
+		return newHealthChecker(cfg, dr, engine, clk, logger), dr, engine
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		h, _, _ := setup(testHealthConfig)
+		require.Equal(t, &HealthStatus{Healthy: true}, h.Check(ctx))
+	})
+	t.Run("driver unresponsive", func(t *testing.T) {
+		h, dr, _ := setup(testHealthConfig)
+		dr.snap, dr.err = nil, context.DeadlineExceeded
+		requireUnhealthy(t, h.Check(ctx), "driver is unresponsive")
+	})
+	t.Run("L1 head stale", func(t *testing.T) {
+		h, dr, _ := setup(testHealthConfig)
+		dr.snap.L1.Head.Time = uint64(clk.Now().Add(-2 * time.Minute).Unix())
+		requireUnhealthy(t, h.Check(ctx), "L1 head")
+	})
+	t.Run("no L1 head", func(t *testing.T) {
+		h, dr, _ := setup(testHealthConfig)
+		dr.snap.L1.Head = eth.L1BlockRef{}
+		requireUnhealthy(t, h.Check(ctx), "no L1 head seen yet")
+	})
+	t.Run("unsafe head stalled", func(t *testing.T) {
+		h, dr, _ := setup(testHealthConfig)
+		dr.snap.LastProgress.UnsafeL2 = clk.Now().Add(-2 * time.Minute).UnixMilli()
+		requireUnhealthy(t, h.Check(ctx), "unsafe L2 head")
+	})
+	t.Run("safe head stalled", func(t *testing.T) {
+		h, dr, _ := setup(testHealthConfig)
+		dr.snap.LastProgress.SafeL2 = clk.Now().Add(-2 * time.Hour).UnixMilli()
+		requireUnhealthy(t, h.Check(ctx), "safe L2 head")
+	})
+	t.Run("no progress since start", func(t *testing.T) {
+		h, dr, _ := setup(testHealthConfig)
+		dr.snap.LastProgress.UnsafeL2 = 0
+		require.True(t, h.Check(ctx).Healthy, "the node just started")
+		clk.AdvanceTime(2 * time.Minute)
+		dr.snap.L1.Head.Time = uint64(clk.Now().Unix())
+		dr.snap.LastProgress.SafeL2 = clk.Now().UnixMilli()
+		requireUnhealthy(t, h.Check(ctx), "unsafe L2 head")
+	})
+	t.Run("engine unreachable", func(t *testing.T) {
+		h, _, engine := setup(testHealthConfig)
+		engine.err = errors.New("connection refused")
+		requireUnhealthy(t, h.Check(ctx), "engine is unreachable")
+	})
+	t.Run("engine state unknown", func(t *testing.T) {
+		h, dr, _ := setup(testHealthConfig)
+		dr.snap.Engine.Unsafe = eth.L2BlockRef{}
+		requireUnhealthy(t, h.Check(ctx), "engine forkchoice state is not known yet")
+	})
+	t.Run("multiple reasons", func(t *testing.T) {
+		h, dr, engine := setup(testHealthConfig)
+		dr.snap.L1.Head.Time = 0
+		engine.err = errors.New("connection refused")
+		status := h.Check(ctx)
+		require.False(t, status.Healthy)
+		require.Len(t, status.Reasons, 2)
+	})
+	t.Run("disabled thresholds", func(t *testing.T) {
+		h, dr, _ := setup(HealthConfig{})
+		dr.snap.L1.Head = eth.L1BlockRef{}
+		dr.snap.LastProgress = driver.ProgressSnapshot{}
+		clk.AdvanceTime(24 * time.Hour)
+		require.True(t, h.Check(ctx).Healthy)
+	})
+}
+
+func TestHealthCheckConfig(t *testing.T) {
+	require.NoError(t, testHealthConfig.Check())
+	require.NoError(t, (&HealthConfig{}).Check())
+	require.ErrorContains(t, (&HealthConfig{SafeHeadMaxStall: -time.Second}).Check(), "negative")
+}
+
+func TestHealthCheckServer(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlError)
+	clk := clock.NewDeterministicClock(time.Now())
+	dr := &fakeHealthDriver{snap: healthySnapshot(clk.Now())}
+	engine := &fakeHealthEngine{}
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, &mockDriverClient{}, safedb.Disabled, &testutils.MockRuntimeConfig{}, logger, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableHealthCheck(newHealthChecker(testHealthConfig, dr, engine, clk, logger), metrics.NoopMetrics)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+	endpoint := "http://" + server.Addr().String()
+
+	client, err := rpcclient.NewRPC(context.Background(), logger, endpoint, rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	defer client.Close()
+
+	checkHTTP := func(expectedCode int) *HealthStatus {
+		resp, err := http.Get(endpoint + "/healthz/sync")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, expectedCode, resp.StatusCode)
+		var status HealthStatus
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+		return &status
+	}
+
+	var out *HealthStatus
+	require.NoError(t, client.CallContext(context.Background(), &out, "optimism_health"))
+	require.True(t, out.Healthy)
+	require.True(t, checkHTTP(http.StatusOK).Healthy)
+
+	engine.err = errors.New("connection refused")
+	require.NoError(t, client.CallContext(context.Background(), &out, "optimism_health"))
+	requireUnhealthy(t, out, "engine is unreachable")
+	requireUnhealthy(t, checkHTTP(http.StatusServiceUnavailable), "engine is unreachable")
+}
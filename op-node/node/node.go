@@ -4,8 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net"
-	"strconv"
+	"io"
 	"sync/atomic"
 	"time"
 
@@ -20,12 +19,17 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/heartbeat"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
@@ -44,19 +48,26 @@ type OpNode struct {
 	l1SafeSub      ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 	l1FinalizedSub ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 
-	l1Source  *sources.L1Client     // L1 Client to fetch data from
-	l2Driver  *driver.Driver        // L2 Engine to Sync
-	l2Source  *sources.EngineClient // L2 Execution Engine RPC bindings
-	server    *rpcServer            // RPC server hosting the rollup-node API
-	p2pNode   *p2p.NodeP2P          // P2P node functionality
-	p2pSigner p2p.Signer            // p2p gogssip application messages will be signed with this signer
-	tracer    Tracer                // tracer to get events for testing/debugging
-	runCfg    *RuntimeConfig        // runtime configurables
+	l1Source  *sources.L1Client       // L1 Client to fetch data from
+	beacon    *sources.L1BeaconClient // L1 beacon-node client to fetch blobs from, nil if not configured
+	l2Driver  *driver.Driver          // L2 Engine to Sync
+	l2Source  *sources.EngineClient   // L2 Execution Engine RPC bindings
+	rpcSync   *sources.SyncClient     // Alt-sync RPC client, optional (may be nil)
+	server    *rpcServer              // RPC server hosting the rollup-node API
+	p2pNode   *p2p.NodeP2P            // P2P node functionality
+	p2pSigner p2p.Signer              // p2p gogssip application messages will be signed with this signer
+	tracer    Tracer                  // tracer to get events for testing/debugging
+	runCfg    *RuntimeConfig          // runtime configurables
 
-	rollupHalt string // when to halt the rollup, disabled if empty
+	safeDB closableSafeDB // persisted safe head progression, may be safedb.Disabled
 
-	pprofSrv   *httputil.HTTPServer
-	metricsSrv *httputil.HTTPServer
+	rollupHalt       string // when to halt the rollup, disabled if empty
+	rollupHaltAction string // how to halt the rollup, shutdown if empty
+
+	shutdownTimeout time.Duration // bounds the graceful shutdown, not bounded if 0
+
+	pprofService *oppprof.Service
+	metricsSrv   *httputil.HTTPServer
 
 	// some resources cannot be stopped directly, like the p2p gossipsub router (not our design),
 	// and depend on this ctx to be closed.
@@ -73,6 +84,12 @@ type OpNode struct {
 	halted atomic.Bool
 }
 
+type closableSafeDB interface {
+	derive.SafeHeadListener
+	SafeDBReader
+	io.Closer
+}
+
 // The OpNode handles incoming gossip
 var _ p2p.GossipIn = (*OpNode)(nil)
 
@@ -85,11 +102,13 @@ func New(ctx context.Context, cfg *Config, log log.Logger, snapshotLog log.Logge
 	}
 
 	n := &OpNode{
-		log:        log,
-		appVersion: appVersion,
-		metrics:    m,
-		rollupHalt: cfg.RollupHalt,
-		cancel:     cfg.Cancel,
+		log:              log,
+		appVersion:       appVersion,
+		metrics:          m,
+		rollupHalt:       cfg.RollupHalt,
+		rollupHaltAction: cfg.RollupHaltAction,
+		shutdownTimeout:  cfg.ShutdownTimeout,
+		cancel:           cfg.Cancel,
 	}
 	// not a context leak, gossipsub is closed with a context.
 	n.resourcesCtx, n.resourcesClose = context.WithCancel(context.Background())
@@ -114,9 +133,15 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	if err := n.initL1(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init L1: %w", err)
 	}
+	if err := n.initL1BeaconAPI(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init L1 beacon API: %w", err)
+	}
 	if err := n.initL2(ctx, cfg, snapshotLog); err != nil {
 		return fmt.Errorf("failed to init L2: %w", err)
 	}
+	if err := n.initRPCSync(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init RPC sync: %w", err)
+	}
 	if err := n.initRuntimeConfig(ctx, cfg); err != nil { // depends on L2, to signal initial runtime values to
 		return fmt.Errorf("failed to init the runtime config: %w", err)
 	}
@@ -126,6 +151,9 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	if err := n.initP2P(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the P2P stack: %w", err)
 	}
+	if err := n.initPProf(cfg); err != nil {
+		return fmt.Errorf("failed to init pprof server: %w", err)
+	}
 	// Only expose the server at the end, ensuring all RPC backend components are initialized.
 	if err := n.initRPCServer(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the RPC server: %w", err)
@@ -136,9 +164,6 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	n.metrics.RecordInfo(n.appVersion)
 	n.metrics.RecordUp()
 	n.initHeartbeat(cfg)
-	if err := n.initPProf(cfg); err != nil {
-		return fmt.Errorf("failed to init pprof server: %w", err)
-	}
 	return nil
 }
 
@@ -151,6 +176,23 @@ func (n *OpNode) initTracer(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
+func (n *OpNode) initL1BeaconAPI(ctx context.Context, cfg *Config) error {
+	if cfg.Beacon == nil {
+		n.log.Warn("No beacon endpoint configured, blobs of batcher transactions cannot be fetched")
+		return nil
+	}
+	httpClient, err := cfg.Beacon.Setup(ctx, n.log)
+	if err != nil {
+		return fmt.Errorf("failed to setup L1 beacon client: %w", err)
+	}
+	n.beacon = sources.NewL1BeaconClient(httpClient)
+	// fail early if the beacon node cannot be reached
+	if _, err := n.beacon.GetTimeToSlotFn(ctx); err != nil {
+		return fmt.Errorf("failed to fetch the beacon genesis and config: %w", err)
+	}
+	return nil
+}
+
 func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 	l1Node, rpcCfg, err := cfg.L1.Setup(ctx, n.log, &cfg.Rollup)
 	if err != nil {
@@ -166,10 +208,19 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 		return fmt.Errorf("failed to create L1 source: %w", err)
 	}
 
-	if err := cfg.Rollup.ValidateL1Config(ctx, n.l1Source); err != nil {
+	if cfg.SkipGenesisCheck {
+		n.log.Warn("Skipping L1 genesis check, only verifying the L1 chain ID")
+		if err := cfg.Rollup.CheckL1ChainID(ctx, n.l1Source); err != nil {
+			return fmt.Errorf("failed to validate the L1 config: %w", err)
+		}
+	} else if err := cfg.Rollup.ValidateL1Config(ctx, n.l1Source); err != nil {
 		return fmt.Errorf("failed to validate the L1 config: %w", err)
 	}
 
+	if err := n.checkL1Contracts(ctx, cfg); err != nil {
+		return err
+	}
+
 	// Keep subscribed to the L1 heads, which keeps the L1 maintainer pointing to the best headers to sync
 	n.l1HeadsSub = event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
 		if err != nil {
@@ -196,7 +247,7 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 
 func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 	// attempt to load runtime config, repeat N times
-	n.runCfg = NewRuntimeConfig(n.log, n.l1Source, &cfg.Rollup)
+	n.runCfg = NewRuntimeConfig(n.log, n.l1Source, &cfg.Rollup, cfg.P2PSequencerKeys, cfg.ConfigPersistence)
 
 	confDepth := cfg.Driver.VerifierConfDepth
 	reload := func(ctx context.Context) (eth.L1BlockRef, error) {
@@ -228,6 +279,7 @@ func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 			n.log.Error("failed to fetch runtime config data", "err", err)
 			return l1Head, err
 		}
+		n.metrics.RecordP2PSequencerAddress(n.runCfg.P2PSequencerAddress())
 
 		err = n.handleProtocolVersionsUpdate(ctx)
 		return l1Head, err
@@ -236,7 +288,7 @@ func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 	// initialize the runtime config before unblocking
 	if _, err := retry.Do(ctx, 5, retry.Fixed(time.Second*10), func() (eth.L1BlockRef, error) {
 		ref, err := reload(ctx)
-		if errors.Is(err, errNodeHalt) { // don't retry on halt error
+		if errors.Is(err, errNodeHalt) || errors.Is(err, errSequencerHalt) { // don't retry on halt error
 			err = nil
 		}
 		return ref, err
@@ -267,6 +319,8 @@ func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 						} else {
 							n.log.Debug("opted to halt, but cannot halt node", "l1_head", l1Head)
 						}
+					} else if errors.Is(err, errSequencerHalt) {
+						n.stopSequencerOnHalt(ctx)
 					} else {
 						n.log.Warn("failed to reload runtime config", "err", err)
 					}
@@ -288,6 +342,24 @@ func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
+// checkL1Contracts sanity-checks the L1 addresses of the rollup config against the L1 state,
+// and only returns an error if the check is configured to be strict.
+func (n *OpNode) checkL1Contracts(ctx context.Context, cfg *Config) error {
+	if cfg.L1ContractsCheck == L1ContractsCheckSkip {
+		n.log.Info("Skipping L1 contracts check")
+		return nil
+	}
+	err := cfg.Rollup.CheckL1Contracts(ctx, n.l1Source)
+	if err == nil {
+		return nil
+	}
+	if cfg.L1ContractsCheck == L1ContractsCheckStrict {
+		return fmt.Errorf("failed to validate the L1 contracts: %w", err)
+	}
+	n.log.Warn("L1 contracts check failed, the rollup config may not match the L1 chain", "err", err)
+	return nil
+}
+
 func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger) error {
 	rpcClient, rpcCfg, err := cfg.L2.Setup(ctx, n.log, &cfg.Rollup)
 	if err != nil {
@@ -301,25 +373,69 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 		return fmt.Errorf("failed to create Engine client: %w", err)
 	}
 
-	if err := cfg.Rollup.ValidateL2Config(ctx, n.l2Source); err != nil {
+	if cfg.SkipGenesisCheck {
+		n.log.Warn("Skipping L2 genesis check, only verifying the L2 chain ID")
+		if err := cfg.Rollup.CheckL2ChainID(ctx, n.l2Source); err != nil {
+			return err
+		}
+	} else if err := cfg.Rollup.ValidateL2Config(ctx, n.l2Source); errors.Is(err, rollup.ErrL2GenesisNotAvailable) {
+		// An empty or syncing engine may not have the genesis block yet, this is not a genesis mismatch.
+		n.log.Warn("L2 genesis block is not available yet, cannot verify the L2 genesis", "err", err)
+	} else if err != nil {
 		return err
 	}
 
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, &cfg.Sync)
+	if cfg.SafeDBPath != "" {
+		n.log.Info("Safe head database enabled", "path", cfg.SafeDBPath, "retention", cfg.SafeDBRetention)
+		safeDB, err := safedb.NewSafeDB(n.log, cfg.SafeDBPath, cfg.SafeDBRetention)
+		if err != nil {
+			return fmt.Errorf("failed to create safe head database at %v: %w", cfg.SafeDBPath, err)
+		}
+		n.safeDB = safeDB
+	} else {
+		n.safeDB = safedb.Disabled
+	}
+
+	var l1Blobs derive.L1BlobsFetcher // left nil without beacon, instead of a typed nil
+	if n.beacon != nil {
+		l1Blobs = n.beacon
+	}
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, l1Blobs, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync)
 
 	return nil
 }
 
+func (n *OpNode) initRPCSync(ctx context.Context, cfg *Config) error {
+	if cfg.L2Sync == nil {
+		return nil
+	}
+	rpcSyncClient, rpcCfg, err := cfg.L2Sync.Setup(ctx, n.log, &cfg.Rollup)
+	if err != nil {
+		return fmt.Errorf("failed to setup L2 execution-engine RPC client for backup sync: %w", err)
+	}
+	if rpcSyncClient == nil { // if no RPC client is configured to sync from, then don't add the RPC sync client
+		return nil
+	}
+	syncClient, err := sources.NewSyncClient(n.OnUnsafeL2Payload, rpcSyncClient, n.log, n.metrics.L2SourceCache, n.metrics, rpcCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create sync client: %w", err)
+	}
+	n.rpcSync = syncClient
+	return nil
+}
+
 func (n *OpNode) initRPCServer(ctx context.Context, cfg *Config) error {
-	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.log, n.appVersion, n.metrics)
+	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.safeDB, n.runCfg, n.log, n.appVersion, n.metrics)
 	if err != nil {
 		return err
 	}
 	if n.p2pNode != nil {
-		server.EnableP2P(p2p.NewP2PAPIBackend(n.p2pNode, n.log, n.metrics))
+		server.EnableP2P(p2p.NewP2PAPIBackend(n.p2pNode, n.log, n.metrics, cfg.RPC.EnableAdmin))
 	}
+	server.EnableHealthCheck(newHealthChecker(cfg.Health, n.l2Driver, n.l2Source, clock.SystemClock, n.log.New("rpc", "health")), n.metrics)
 	if cfg.RPC.EnableAdmin {
 		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics, n.log))
+		server.EnablePprofAPI(oppprof.NewAPI(n.pprofService))
 		n.log.Info("Admin RPC enabled")
 	}
 	n.log.Info("Starting JSON-RPC server")
@@ -335,6 +451,9 @@ func (n *OpNode) initMetricsServer(cfg *Config) error {
 		n.log.Info("metrics disabled")
 		return nil
 	}
+	if h, ok := n.log.GetHandler().(opmetrics.LogLevelHandler); ok {
+		n.metrics.RegisterLogLevel(h)
+	}
 	n.log.Debug("starting metrics server", "addr", cfg.Metrics.ListenAddr, "port", cfg.Metrics.ListenPort)
 	metricsSrv, err := n.metrics.StartServer(cfg.Metrics.ListenAddr, cfg.Metrics.ListenPort)
 	if err != nil {
@@ -363,26 +482,25 @@ func (n *OpNode) initHeartbeat(cfg *Config) {
 		PeerID:  peerID,
 		ChainID: cfg.Rollup.L2ChainID.Uint64(),
 	}
+	if err := payload.Redact(cfg.Heartbeat.Redact); err != nil { // already checked with the config
+		n.log.Error("invalid heartbeat redaction, not sending heartbeats", "err", err)
+		return
+	}
 
 	go func(url string) {
-		if err := heartbeat.Beat(n.resourcesCtx, n.log, url, payload); err != nil {
+		if err := heartbeat.Beat(n.resourcesCtx, n.log, n.metrics, url, payload); err != nil {
 			log.Error("heartbeat goroutine crashed", "err", err)
 		}
 	}(cfg.Heartbeat.URL)
 }
 
 func (n *OpNode) initPProf(cfg *Config) error {
+	// the pprof service is always created, so that it can be started with the admin RPC
+	n.pprofService = oppprof.NewService(n.log, cfg.Pprof.ProfileDir)
 	if !cfg.Pprof.Enabled {
 		return nil
 	}
-	log.Debug("starting pprof server", "addr", net.JoinHostPort(cfg.Pprof.ListenAddr, strconv.Itoa(cfg.Pprof.ListenPort)))
-	srv, err := oppprof.StartServer(cfg.Pprof.ListenAddr, cfg.Pprof.ListenPort)
-	if err != nil {
-		return err
-	}
-	n.pprofSrv = srv
-	log.Info("started pprof server", "addr", srv.Addr())
-	return nil
+	return n.pprofService.Start(cfg.Pprof.ListenAddr, cfg.Pprof.ListenPort)
 }
 
 func (n *OpNode) initP2P(ctx context.Context, cfg *Config) error {
@@ -417,6 +535,14 @@ func (n *OpNode) Start(ctx context.Context) error {
 		n.log.Error("Could not start a rollup node", "err", err)
 		return err
 	}
+	// If the backup unsafe sync client is enabled, start its event loop
+	if n.rpcSync != nil {
+		if err := n.rpcSync.Start(); err != nil {
+			n.log.Error("Could not start the backup sync client", "err", err)
+			return err
+		}
+		n.log.Info("Started L2-RPC sync service")
+	}
 	log.Info("Rollup node started")
 	return nil
 }
@@ -459,43 +585,47 @@ func (n *OpNode) OnNewL1Finalized(ctx context.Context, sig eth.L1BlockRef) {
 	}
 }
 
-func (n *OpNode) PublishL2Payload(ctx context.Context, payload *eth.ExecutionPayload) error {
-	n.tracer.OnPublishL2Payload(ctx, payload)
+func (n *OpNode) PublishL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
+	n.tracer.OnPublishL2Payload(ctx, envelope)
 
 	// publish to p2p, if we are running p2p at all
 	if n.p2pNode != nil {
 		if n.p2pSigner == nil {
-			return fmt.Errorf("node has no p2p signer, payload %s cannot be published", payload.ID())
+			return fmt.Errorf("node has no p2p signer, payload %s cannot be published", envelope.ExecutionPayload.ID())
 		}
-		n.log.Info("Publishing signed execution payload on p2p", "id", payload.ID())
-		return n.p2pNode.GossipOut().PublishL2Payload(ctx, payload, n.p2pSigner)
+		n.log.Info("Publishing signed execution payload on p2p", "id", envelope.ExecutionPayload.ID())
+		return n.p2pNode.GossipOut().PublishL2Payload(ctx, envelope, n.p2pSigner)
 	}
 	// if p2p is not enabled then we just don't publish the payload
 	return nil
 }
 
-func (n *OpNode) OnUnsafeL2Payload(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) error {
+func (n *OpNode) OnUnsafeL2Payload(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) error {
 	// ignore if it's from ourselves
 	if n.p2pNode != nil && from == n.p2pNode.Host().ID() {
 		return nil
 	}
 
-	n.tracer.OnUnsafeL2Payload(ctx, from, payload)
+	n.tracer.OnUnsafeL2Payload(ctx, from, envelope)
 
-	n.log.Info("Received signed execution payload from p2p", "id", payload.ID(), "peer", from)
+	n.log.Info("Received signed execution payload from p2p", "id", envelope.ExecutionPayload.ID(), "peer", from)
 
 	// Pass on the event to the L2 Engine
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 
-	if err := n.l2Driver.OnUnsafeL2Payload(ctx, payload); err != nil {
-		n.log.Warn("failed to notify engine driver of new L2 payload", "err", err, "id", payload.ID())
+	if err := n.l2Driver.OnUnsafeL2Payload(ctx, envelope); err != nil {
+		n.log.Warn("failed to notify engine driver of new L2 payload", "err", err, "id", envelope.ExecutionPayload.ID())
 	}
 
 	return nil
 }
 
 func (n *OpNode) RequestL2Range(ctx context.Context, start, end eth.L2BlockRef) error {
+	// The trusted RPC is preferred over p2p, as it can serve an open-ended range, and needs no sync target to verify against.
+	if n.rpcSync != nil {
+		return n.rpcSync.RequestL2Range(ctx, start, end)
+	}
 	if n.p2pNode != nil && n.p2pNode.AltSyncEnabled() {
 		if unixTimeStale(start.Time, 12*time.Hour) {
 			n.log.Debug("ignoring request to sync L2 range, timestamp is too old for p2p", "start", start, "end", end, "start_time", start.Time)
@@ -529,8 +659,18 @@ func (n *OpNode) Stop(ctx context.Context) error {
 
 	var result *multierror.Error
 
+	// Shut down in order: first stop accepting new input, then let the driver finish its current step,
+	// then close the databases and clients it used. The shutdown timeout bounds all of this,
+	// after which in-flight work is aborted.
+	drainCtx := ctx
+	if n.shutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		drainCtx, cancel = context.WithTimeout(ctx, n.shutdownTimeout)
+		defer cancel()
+	}
+
 	if n.server != nil {
-		if err := n.server.Stop(ctx); err != nil {
+		if err := n.server.Stop(drainCtx); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close RPC server: %w", err))
 		}
 	}
@@ -564,16 +704,30 @@ func (n *OpNode) Stop(ctx context.Context) error {
 
 	// close L2 driver
 	if n.l2Driver != nil {
-		if err := n.l2Driver.Close(); err != nil {
+		if err := n.l2Driver.Close(drainCtx); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close L2 engine driver cleanly: %w", err))
 		}
 	}
 
+	// close the safe head database, after the driver stopped updating it
+	if n.safeDB != nil {
+		if err := n.safeDB.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close safe head database: %w", err))
+		}
+	}
+
 	// Wait for the runtime config loader to be done using the data sources before closing them
 	if n.runtimeConfigReloaderDone != nil {
 		<-n.runtimeConfigReloaderDone
 	}
 
+	// close the backup sync client, after the driver stopped requesting blocks
+	if n.rpcSync != nil {
+		if err := n.rpcSync.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close L2 engine backup sync client cleanly: %w", err))
+		}
+	}
+
 	// close L2 engine RPC client
 	if n.l2Source != nil {
 		n.l2Source.Close()
@@ -588,6 +742,10 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		n.closed.Store(true)
 	}
 
+	if drainCtx.Err() != nil {
+		n.log.Warn("Shutdown timeout exceeded, in-flight work was aborted", "timeout", n.shutdownTimeout)
+	}
+
 	if n.halted.Load() {
 		// if we had a halt upon initialization, idle for a while, with open metrics, to prevent a rapid restart-loop
 		tim := time.NewTimer(time.Minute * 5)
@@ -600,8 +758,8 @@ func (n *OpNode) Stop(ctx context.Context) error {
 	}
 
 	// Close metrics and pprof only after we are done idling
-	if n.pprofSrv != nil {
-		if err := n.pprofSrv.Stop(ctx); err != nil {
+	if n.pprofService != nil {
+		if err := n.pprofService.Stop(ctx); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close pprof server: %w", err))
 		}
 	}
@@ -624,3 +782,11 @@ func (n *OpNode) HTTPEndpoint() string {
 	}
 	return fmt.Sprintf("http://%s", n.server.Addr().String())
 }
+
+// WSEndpoint returns the WebSocket endpoint of the RPC server, served on the same address as the HTTP endpoint.
+func (n *OpNode) WSEndpoint() string {
+	if n.server == nil {
+		return ""
+	}
+	return fmt.Sprintf("ws://%s", n.server.Addr().String())
+}
@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -28,8 +31,15 @@ var (
 	RecommendedProtocolVersionStorageSlot = common.HexToHash("0xe314dfc40f0025322aacc0ba8ef420b62fb3b702cf01e0cdf3d829117ac2ff1a")
 )
 
+// P2PSignerRotationWindow is how long the previous p2p sequencer key remains accepted,
+// after the unsafe block signer in the SystemConfig changes, measured from the time of the L1 block
+// that emitted the change. This gives the sequencer time to switch keys,
+// without verifiers dropping the blocks it signs in the meantime.
+const P2PSignerRotationWindow = 30 * time.Minute
+
 type RuntimeCfgL1Source interface {
 	ReadStorageAt(ctx context.Context, address common.Address, storageSlot common.Hash, blockHash common.Hash) (common.Hash, error)
+	FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error)
 }
 
 type ReadonlyRuntimeConfig interface {
@@ -50,6 +60,11 @@ type RuntimeConfig struct {
 	l1Client  RuntimeCfgL1Source
 	rollupCfg *rollup.Config
 
+	// manualKeys override the p2p sequencer keys of the SystemConfig if set.
+	manualKeys *eth.SequencerKeys
+	// persistence keeps the p2p sequencer keys across restarts, to not lose an ongoing key rotation.
+	persistence SequencerKeysPersistence
+
 	// l1Ref is the current source of the data,
 	// if this is invalidated with a reorg the data will have to be reloaded.
 	l1Ref eth.L1BlockRef
@@ -61,6 +76,10 @@ type RuntimeConfig struct {
 type runtimeConfigData struct {
 	p2pBlockSignerAddr common.Address
 
+	// previous p2p block signer, accepted until the rotation time, if the signer changed.
+	p2pPrevBlockSignerAddr common.Address
+	p2pSignerRotationTime  uint64
+
 	// superchain protocol version signals
 	recommended params.ProtocolVersion
 	required    params.ProtocolVersion
@@ -68,11 +87,14 @@ type runtimeConfigData struct {
 
 var _ p2p.GossipRuntimeConfig = (*RuntimeConfig)(nil)
 
-func NewRuntimeConfig(log log.Logger, l1Client RuntimeCfgL1Source, rollupCfg *rollup.Config) *RuntimeConfig {
+func NewRuntimeConfig(log log.Logger, l1Client RuntimeCfgL1Source, rollupCfg *rollup.Config,
+	manualKeys *eth.SequencerKeys, persistence SequencerKeysPersistence) *RuntimeConfig {
 	return &RuntimeConfig{
-		log:       log,
-		l1Client:  l1Client,
-		rollupCfg: rollupCfg,
+		log:         log,
+		l1Client:    l1Client,
+		rollupCfg:   rollupCfg,
+		manualKeys:  manualKeys,
+		persistence: persistence,
 	}
 }
 
@@ -82,6 +104,22 @@ func (r *RuntimeConfig) P2PSequencerAddress() common.Address {
 	return r.p2pBlockSignerAddr
 }
 
+// P2PSequencerKeys returns the keys that gossiped blocks may be signed with:
+// the latest unsafe block signer, and the previous signer during a key rotation.
+// Manually configured keys take precedence over the keys of the SystemConfig.
+func (r *RuntimeConfig) P2PSequencerKeys() eth.SequencerKeys {
+	if r.manualKeys != nil {
+		return *r.manualKeys
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return eth.SequencerKeys{
+		Primary:      r.p2pBlockSignerAddr,
+		Secondary:    r.p2pPrevBlockSignerAddr,
+		RotationTime: r.p2pSignerRotationTime,
+	}
+}
+
 func (r *RuntimeConfig) RequiredProtocolVersion() params.ProtocolVersion {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -116,12 +154,88 @@ func (r *RuntimeConfig) Load(ctx context.Context, l1Ref eth.L1BlockRef) error {
 		}
 		recommendedProtoVersion = params.ProtocolVersion(recommendedVal)
 	}
+	signer := common.BytesToAddress(p2pSignerVal[:])
+	rotation := r.signerRotation(ctx, l1Ref, signer)
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.l1Ref = l1Ref
-	r.p2pBlockSignerAddr = common.BytesToAddress(p2pSignerVal[:])
+	if rotation != nil {
+		r.p2pPrevBlockSignerAddr = rotation.Secondary
+		r.p2pSignerRotationTime = rotation.RotationTime
+		r.p2pBlockSignerAddr = signer
+		if err := r.persistence.SetP2PSequencerKeys(*rotation); err != nil {
+			r.log.Warn("failed to persist the p2p sequencer keys", "err", err)
+		}
+	}
 	r.required = requiredProtVersion
 	r.recommended = recommendedProtoVersion
 	r.log.Info("loaded new runtime config values!", "p2p_seq_address", r.p2pBlockSignerAddr)
 	return nil
 }
+
+// signerRotation returns the new p2p sequencer keys if the unsafe block signer changed, or nil if it did not.
+// The previous signer is the last loaded signer, or the persisted signer if no signer was loaded since the start.
+// The previous signer remains accepted for the P2PSignerRotationWindow after the L1 block that emitted the change.
+func (r *RuntimeConfig) signerRotation(ctx context.Context, l1Ref eth.L1BlockRef, signer common.Address) *eth.SequencerKeys {
+	r.mu.RLock()
+	prev, prevRef := r.p2pBlockSignerAddr, r.l1Ref
+	r.mu.RUnlock()
+	if prev == (common.Address{}) {
+		persisted, err := r.persistence.P2PSequencerKeys()
+		if err != nil {
+			r.log.Warn("failed to read the persisted p2p sequencer keys", "err", err)
+		} else if persisted != nil && persisted.Primary == signer {
+			r.log.Info("restored the persisted p2p sequencer keys", "primary", persisted.Primary,
+				"secondary", persisted.Secondary, "rotation_time", persisted.RotationTime)
+			return persisted
+		} else if persisted != nil {
+			// the signer changed while the node was offline
+			prev = persisted.Primary
+		}
+	}
+	if signer == prev {
+		return nil
+	}
+	keys := &eth.SequencerKeys{Primary: signer}
+	if prev == (common.Address{}) {
+		return keys
+	}
+	updateTime, err := r.signerUpdateTime(ctx, l1Ref, prevRef.Number)
+	if err != nil {
+		r.log.Warn("failed to find the L1 block of the p2p sequencer key change, starting the rotation window now", "err", err)
+		updateTime = l1Ref.Time
+	}
+	keys.Secondary = prev
+	keys.RotationTime = updateTime + uint64(P2PSignerRotationWindow/time.Second)
+	r.log.Info("p2p sequencer key changed, accepting the previous key during the rotation window",
+		"prev", prev, "next", signer, "rotation_time", keys.RotationTime)
+	return keys
+}
+
+// signerUpdateTime returns the time of the latest L1 block, up to the given L1 block, that emitted an update of the
+// unsafe block signer. The L1 chain is searched back until the block after the given since-block, or until the
+// blocks are older than the rotation window. If no update is found, the time of the oldest searched block is returned,
+// as the update was emitted before it.
+func (r *RuntimeConfig) signerUpdateTime(ctx context.Context, l1Ref eth.L1BlockRef, since uint64) (uint64, error) {
+	window := uint64(P2PSignerRotationWindow / time.Second)
+	hash := l1Ref.Hash
+	for {
+		info, receipts, err := r.l1Client.FetchReceipts(ctx, hash)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch receipts of L1 block %s: %w", hash, err)
+		}
+		for _, rec := range receipts {
+			for _, l := range rec.Logs {
+				if l.Address == r.rollupCfg.L1SystemConfigAddress && len(l.Topics) == 3 &&
+					l.Topics[0] == derive.ConfigUpdateEventABIHash && l.Topics[2] == derive.SystemConfigUpdateUnsafeBlockSigner {
+					return info.Time(), nil
+				}
+			}
+		}
+		if info.NumberU64() <= since+1 || info.NumberU64() == 0 || info.Time()+window <= l1Ref.Time {
+			return info.Time(), nil
+		}
+		hash = info.ParentHash()
+	}
+}
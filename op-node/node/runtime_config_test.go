@@ -0,0 +1,172 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// testRuntimeCfgL1Source is an L1 chain of 12 second blocks, with the SystemConfig emitting
+// unsafe block signer updates in the blocks of the updates set.
+type testRuntimeCfgL1Source struct {
+	sysCfgAddr common.Address
+	signer     common.Address
+	updates    map[uint64]bool
+	err        error
+}
+
+func testL1Hash(num uint64) common.Hash {
+	return common.Hash{0: 0xb1, 31: byte(num)}
+}
+
+func testL1Ref(num uint64) eth.L1BlockRef {
+	return eth.L1BlockRef{Hash: testL1Hash(num), Number: num, Time: 988 + 12*num}
+}
+
+func (s *testRuntimeCfgL1Source) ReadStorageAt(ctx context.Context, address common.Address, storageSlot common.Hash, blockHash common.Hash) (common.Hash, error) {
+	if s.err != nil {
+		return common.Hash{}, s.err
+	}
+	return common.BytesToHash(s.signer[:]), nil
+}
+
+func (s *testRuntimeCfgL1Source) FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	num := uint64(blockHash[31])
+	ref := testL1Ref(num)
+	info := &testutils.MockBlockInfo{InfoHash: ref.Hash, InfoNum: num, InfoTime: ref.Time}
+	if num > 0 {
+		info.InfoParentHash = testL1Hash(num - 1)
+	}
+	var receipts types.Receipts
+	if s.updates[num] {
+		receipts = append(receipts, &types.Receipt{Logs: []*types.Log{{
+			Address: s.sysCfgAddr,
+			Topics:  []common.Hash{derive.ConfigUpdateEventABIHash, derive.ConfigUpdateEventVersion0, derive.SystemConfigUpdateUnsafeBlockSigner},
+		}}})
+	}
+	return info, receipts, nil
+}
+
+func setupRuntimeConfigTest(t *testing.T, signer common.Address, persistence SequencerKeysPersistence) (*testRuntimeCfgL1Source, *RuntimeConfig) {
+	cfg := &rollup.Config{L1SystemConfigAddress: common.Address{0x42}}
+	l1 := &testRuntimeCfgL1Source{sysCfgAddr: cfg.L1SystemConfigAddress, signer: signer, updates: make(map[uint64]bool)}
+	return l1, NewRuntimeConfig(testlog.Logger(t, log.LvlError), l1, cfg, nil, persistence)
+}
+
+func TestRuntimeConfigSignerRotation(t *testing.T) {
+	oldSigner, newSigner := common.Address{0xaa}, common.Address{0xbb}
+	l1, runCfg := setupRuntimeConfigTest(t, oldSigner, DisabledConfigPersistence{})
+	ctx := context.Background()
+
+	require.NoError(t, runCfg.Load(ctx, testL1Ref(1)))
+	require.Equal(t, eth.SequencerKeys{Primary: oldSigner}, runCfg.P2PSequencerKeys(), "no rotation on initial load")
+
+	// reloading the same signer does not start a rotation
+	require.NoError(t, runCfg.Load(ctx, testL1Ref(2)))
+	require.Equal(t, eth.SequencerKeys{Primary: oldSigner}, runCfg.P2PSequencerKeys())
+
+	l1.signer = newSigner
+	l1.updates[3] = true
+	require.NoError(t, runCfg.Load(ctx, testL1Ref(3)))
+	keys := runCfg.P2PSequencerKeys()
+	require.Equal(t, eth.SequencerKeys{
+		Primary:      newSigner,
+		Secondary:    oldSigner,
+		RotationTime: 1024 + uint64(P2PSignerRotationWindow/time.Second),
+	}, keys)
+	require.Equal(t, newSigner, runCfg.P2PSequencerAddress())
+
+	// the previous signer is accepted during the window, the latest signer before, during and after
+	require.True(t, keys.Accepts(oldSigner, 1024))
+	require.True(t, keys.Accepts(newSigner, 1024))
+	require.False(t, keys.Accepts(oldSigner, keys.RotationTime))
+	require.True(t, keys.Accepts(newSigner, keys.RotationTime))
+	require.False(t, keys.Accepts(common.Address{}, 1024))
+
+	// the rotation window is unaffected by reloads of the same signer
+	require.NoError(t, runCfg.Load(ctx, testL1Ref(4)))
+	require.Equal(t, keys, runCfg.P2PSequencerKeys())
+}
+
+// TestRuntimeConfigSignerRotationAnchor asserts that the rotation window starts at the L1 block that emitted
+// the signer update, rather than at the later L1 block at which the update is loaded.
+func TestRuntimeConfigSignerRotationAnchor(t *testing.T) {
+	oldSigner, newSigner := common.Address{0xaa}, common.Address{0xbb}
+	l1, runCfg := setupRuntimeConfigTest(t, oldSigner, DisabledConfigPersistence{})
+	ctx := context.Background()
+	require.NoError(t, runCfg.Load(ctx, testL1Ref(1)))
+
+	l1.signer = newSigner
+	l1.updates[5] = true
+	require.NoError(t, runCfg.Load(ctx, testL1Ref(10)))
+	require.Equal(t, eth.SequencerKeys{
+		Primary:      newSigner,
+		Secondary:    oldSigner,
+		RotationTime: testL1Ref(5).Time + uint64(P2PSignerRotationWindow/time.Second),
+	}, runCfg.P2PSequencerKeys())
+}
+
+func TestRuntimeConfigSignerRotationRestart(t *testing.T) {
+	oldSigner, newSigner, nextSigner := common.Address{0xaa}, common.Address{0xbb}, common.Address{0xcc}
+	persistence := NewConfigPersistence(filepath.Join(t.TempDir(), "state"))
+	l1, runCfg := setupRuntimeConfigTest(t, oldSigner, persistence)
+	ctx := context.Background()
+	require.NoError(t, runCfg.Load(ctx, testL1Ref(1)))
+
+	l1.signer = newSigner
+	l1.updates[2] = true
+	require.NoError(t, runCfg.Load(ctx, testL1Ref(3)))
+	keys := runCfg.P2PSequencerKeys()
+	require.Equal(t, oldSigner, keys.Secondary)
+
+	// a restart during the rotation window keeps accepting the previous signer
+	l1, restarted := setupRuntimeConfigTest(t, newSigner, persistence)
+	l1.updates[2] = true
+	require.NoError(t, restarted.Load(ctx, testL1Ref(4)))
+	require.Equal(t, keys, restarted.P2PSequencerKeys())
+
+	// a signer update while the node is offline rotates from the persisted signer
+	l1, restarted = setupRuntimeConfigTest(t, nextSigner, persistence)
+	l1.updates[6] = true
+	require.NoError(t, restarted.Load(ctx, testL1Ref(8)))
+	require.Equal(t, eth.SequencerKeys{
+		Primary:      nextSigner,
+		Secondary:    newSigner,
+		RotationTime: testL1Ref(6).Time + uint64(P2PSignerRotationWindow/time.Second),
+	}, restarted.P2PSequencerKeys())
+}
+
+func TestRuntimeConfigManualSequencerKeys(t *testing.T) {
+	manual := &eth.SequencerKeys{Primary: common.Address{0xbb}, Secondary: common.Address{0xaa}, RotationTime: 2000}
+	cfg := &rollup.Config{L1SystemConfigAddress: common.Address{0x42}}
+	l1 := &testRuntimeCfgL1Source{sysCfgAddr: cfg.L1SystemConfigAddress, signer: common.Address{0xcc}}
+	runCfg := NewRuntimeConfig(testlog.Logger(t, log.LvlError), l1, cfg, manual, DisabledConfigPersistence{})
+	require.NoError(t, runCfg.Load(context.Background(), testL1Ref(1)))
+	require.Equal(t, *manual, runCfg.P2PSequencerKeys(), "manual keys override the SystemConfig signer")
+}
+
+func TestRuntimeConfigLoadFailure(t *testing.T) {
+	signer := common.Address{0xaa}
+	l1, runCfg := setupRuntimeConfigTest(t, signer, DisabledConfigPersistence{})
+	ctx := context.Background()
+	require.NoError(t, runCfg.Load(ctx, testL1Ref(1)))
+
+	// a failed reload keeps the signer, rather than rejecting all gossiped blocks until the next reload
+	l1.err = errors.New("L1 RPC unavailable")
+	require.ErrorIs(t, runCfg.Load(ctx, testL1Ref(2)), l1.err)
+	require.Equal(t, eth.SequencerKeys{Primary: signer}, runCfg.P2PSequencerKeys())
+}
@@ -0,0 +1,304 @@
+package safedb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrNotEnabled = errors.New("safe head database not enabled")
+	ErrClosed     = errors.New("safe head database closed")
+)
+
+const (
+	// keyPrefixSafeByL1BlockNum is the prefix of the entries, keyed by L1 block number.
+	keyPrefixSafeByL1BlockNum byte = 0
+	keyLen                         = 1 + 8
+	// entryLen is the length of an entry value: L1 block hash, L2 block hash, L2 block number.
+	// A gap entry has no value: the safe head is unknown from the L1 block of the gap entry,
+	// until the L1 block of the next entry.
+	entryLen = 32 + 32 + 8
+)
+
+func safeByL1BlockNumKey(l1BlockNum uint64) []byte {
+	var key [keyLen]byte
+	key[0] = keyPrefixSafeByL1BlockNum
+	binary.BigEndian.PutUint64(key[1:], l1BlockNum)
+	return key[:]
+}
+
+type entry struct {
+	l1Block  eth.BlockID
+	safeHead eth.BlockID
+}
+
+func (e *entry) encode() []byte {
+	val := make([]byte, entryLen)
+	copy(val[:32], e.l1Block.Hash[:])
+	copy(val[32:64], e.safeHead.Hash[:])
+	binary.BigEndian.PutUint64(val[64:], e.safeHead.Number)
+	return val
+}
+
+// decodeEntry decodes an entry. It returns nil if the entry is a gap entry.
+func decodeEntry(key []byte, val []byte) (*entry, error) {
+	if len(key) != keyLen || key[0] != keyPrefixSafeByL1BlockNum {
+		return nil, fmt.Errorf("invalid key %x", key)
+	}
+	if len(val) == 0 {
+		return nil, nil
+	}
+	if len(val) != entryLen {
+		return nil, fmt.Errorf("invalid entry length %d, expected %d", len(val), entryLen)
+	}
+	return &entry{
+		l1Block:  eth.BlockID{Hash: common.Hash(val[:32]), Number: binary.BigEndian.Uint64(key[1:])},
+		safeHead: eth.BlockID{Hash: common.Hash(val[32:64]), Number: binary.BigEndian.Uint64(val[64:])},
+	}, nil
+}
+
+// SafeDB persists the safe head progression: for every L1 block that advanced the safe head,
+// the latest safe head that was derived from the L1 chain up to and including that L1 block.
+//
+// Updates are queued and written in batches in the background, to not slow down the derivation.
+// Updates that are lost on an unclean shutdown are detected on the reset of the derivation pipeline at startup:
+// the safe head is then unknown for the L1 blocks after the latest persisted entry,
+// until the derivation records the next safe head progression.
+type SafeDB struct {
+	log log.Logger
+	db  *pebble.DB
+	// retention is the number of L1 blocks to keep entries for, counted back from the latest entry.
+	// Entries are never pruned if 0.
+	retention uint64
+
+	// mu guards pending and closed
+	mu      sync.Mutex
+	pending []entry
+	closed  bool
+
+	// writeMu serializes the writes to the database
+	writeMu sync.Mutex
+
+	flushSig chan struct{}
+	closing  chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewSafeDB(logger log.Logger, path string, retention uint64) (*SafeDB, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open safe head database at %q: %w", path, err)
+	}
+	s := &SafeDB{
+		log:       logger,
+		db:        db,
+		retention: retention,
+		flushSig:  make(chan struct{}, 1),
+		closing:   make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.loop()
+	return s, nil
+}
+
+func (s *SafeDB) loop() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-s.flushSig:
+			if err := s.flush(); err != nil {
+				s.log.Error("Failed to write safe head updates", "err", err)
+			}
+		}
+	}
+}
+
+// SafeHeadUpdated queues the new safe head, that was derived from the L1 chain up to and including the given L1 block.
+// A later update for the same L1 block replaces the previous one.
+func (s *SafeDB) SafeHeadUpdated(safeHead eth.L2BlockRef, l1Block eth.BlockID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	e := entry{l1Block: l1Block, safeHead: safeHead.ID()}
+	if n := len(s.pending); n > 0 && s.pending[n-1].l1Block.Number == l1Block.Number {
+		s.pending[n-1] = e
+	} else {
+		s.pending = append(s.pending, e)
+	}
+	select {
+	case s.flushSig <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// flush writes the pending updates, and prunes the entries past the retention window.
+// The pending updates are kept for a retry if the write fails.
+func (s *SafeDB) flush() error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	for i := range pending {
+		if err := batch.Set(safeByL1BlockNumKey(pending[i].l1Block.Number), pending[i].encode(), nil); err != nil {
+			s.requeue(pending)
+			return fmt.Errorf("failed to add safe head update to batch: %w", err)
+		}
+	}
+	if latest := pending[len(pending)-1].l1Block.Number; s.retention > 0 && latest > s.retention {
+		if err := batch.DeleteRange(safeByL1BlockNumKey(0), safeByL1BlockNumKey(latest-s.retention), nil); err != nil {
+			s.requeue(pending)
+			return fmt.Errorf("failed to add pruning to batch: %w", err)
+		}
+	}
+	// No need to sync every batch: updates lost on a crash result in a gap that is detected on the next reset.
+	if err := batch.Commit(pebble.NoSync); err != nil {
+		s.requeue(pending)
+		return fmt.Errorf("failed to commit safe head updates: %w", err)
+	}
+	return nil
+}
+
+// requeue puts back failed updates before any updates that were queued while writing.
+func (s *SafeDB) requeue(failed []entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = append(failed, s.pending...)
+}
+
+// SafeHeadReset is called when the derivation pipeline reset to the given safe head,
+// e.g. at startup, or after an L1 reorg. Entries with a safe head past the reset safe head are removed,
+// as the L1 blocks that made them safe may no longer be canonical, and will be re-derived.
+// If the latest remaining entry is behind the reset safe head, the updates in between were lost,
+// and a gap entry is written, so these L1 blocks are not reported with an outdated safe head.
+func (s *SafeDB) SafeHeadReset(safeHead eth.L2BlockRef) error {
+	if err := s.flush(); err != nil {
+		return err
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	iter, err := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: safeByL1BlockNumKey(0),
+		UpperBound: []byte{keyPrefixSafeByL1BlockNum + 1},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	var latest *entry
+	var removed int
+	for valid := iter.Last(); valid; valid = iter.Prev() {
+		e, err := decodeEntry(iter.Key(), iter.Value())
+		if err != nil {
+			return err
+		}
+		if e != nil && (e.safeHead.Number < safeHead.Number || e.safeHead == safeHead.ID()) {
+			latest = e
+			break
+		}
+		if err := batch.Delete(slices.Clone(iter.Key()), nil); err != nil {
+			return fmt.Errorf("failed to add deletion to batch: %w", err)
+		}
+		if e != nil {
+			removed++
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("failed to iterate safe head entries: %w", err)
+	}
+	if removed > 0 {
+		s.log.Warn("Removed safe head entries past the reset safe head", "removed", removed, "safe", safeHead)
+	}
+	if latest != nil && latest.safeHead.Number < safeHead.Number {
+		s.log.Warn("Safe head database has a gap, safe head is unknown until the next safe head update",
+			"latest_l1", latest.l1Block, "latest_safe", latest.safeHead, "safe", safeHead)
+		if err := batch.Set(safeByL1BlockNumKey(latest.l1Block.Number+1), nil, nil); err != nil {
+			return fmt.Errorf("failed to add gap entry to batch: %w", err)
+		}
+	}
+	if batch.Empty() {
+		return nil
+	}
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return fmt.Errorf("failed to commit safe head reset: %w", err)
+	}
+	return nil
+}
+
+// SafeHeadAtL1 returns the safe head that was derived from the L1 chain up to and including the given L1 block number,
+// together with the latest L1 block that advanced the safe head.
+// ErrNotFound is returned if the safe head at the given L1 block was not recorded, pruned, or lost.
+func (s *SafeDB) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error) {
+	// Read the pending updates too
+	if err := s.flush(); err != nil {
+		return eth.BlockID{}, eth.BlockID{}, err
+	}
+	iter, err := s.db.NewIterWithContext(ctx, &pebble.IterOptions{
+		LowerBound: safeByL1BlockNumKey(0),
+		UpperBound: safeByL1BlockNumKey(l1BlockNum + 1),
+	})
+	if err != nil {
+		return eth.BlockID{}, eth.BlockID{}, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	if !iter.Last() {
+		if err := iter.Error(); err != nil {
+			return eth.BlockID{}, eth.BlockID{}, fmt.Errorf("failed to read safe head entry: %w", err)
+		}
+		return eth.BlockID{}, eth.BlockID{}, ErrNotFound
+	}
+	e, err := decodeEntry(iter.Key(), iter.Value())
+	if err != nil {
+		return eth.BlockID{}, eth.BlockID{}, err
+	}
+	if e == nil {
+		return eth.BlockID{}, eth.BlockID{}, fmt.Errorf("%w: safe head updates were lost", ErrNotFound)
+	}
+	return e.l1Block, e.safeHead, nil
+}
+
+// Close writes the pending updates, and closes the database.
+func (s *SafeDB) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrClosed
+	}
+	s.closed = true
+	s.mu.Unlock()
+	close(s.closing)
+	s.wg.Wait()
+	// ignore the pending updates if they fail to write: the gap is detected on the next reset
+	if err := s.flush(); err != nil {
+		s.log.Error("Failed to write safe head updates on close", "err", err)
+	}
+	return s.db.Close()
+}
@@ -0,0 +1,210 @@
+package safedb
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// testChain is a chain of L1 blocks, and L2 safe heads derived from them.
+type testChain struct {
+	rng *rand.Rand
+}
+
+func (c *testChain) l1(num uint64) eth.BlockID {
+	return eth.BlockID{Hash: testutils.RandomHash(c.rng), Number: num}
+}
+
+func (c *testChain) l2(num uint64) eth.L2BlockRef {
+	return eth.L2BlockRef{Hash: testutils.RandomHash(c.rng), Number: num}
+}
+
+func newTestDB(t *testing.T, dir string, retention uint64) *SafeDB {
+	db, err := NewSafeDB(testlog.Logger(t, log.LvlInfo), dir, retention)
+	require.NoError(t, err)
+	return db
+}
+
+func requireSafeHeadAt(t *testing.T, db *SafeDB, l1BlockNum uint64, expectedL1 eth.BlockID, expectedSafe eth.L2BlockRef) {
+	l1, safe, err := db.SafeHeadAtL1(context.Background(), l1BlockNum)
+	require.NoError(t, err)
+	require.Equal(t, expectedL1, l1)
+	require.Equal(t, expectedSafe.ID(), safe)
+}
+
+func requireNotFoundAt(t *testing.T, db *SafeDB, l1BlockNum uint64) {
+	_, _, err := db.SafeHeadAtL1(context.Background(), l1BlockNum)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSafeHeadAtL1(t *testing.T) {
+	c := &testChain{rng: rand.New(rand.NewSource(1234))}
+	db := newTestDB(t, t.TempDir(), 0)
+	defer db.Close()
+
+	l1A, l1B := c.l1(10), c.l1(15)
+	safeA1, safeA2, safeB := c.l2(100), c.l2(101), c.l2(120)
+	require.NoError(t, db.SafeHeadUpdated(safeA1, l1A))
+	require.NoError(t, db.SafeHeadUpdated(safeA2, l1A), "later updates for the same L1 block replace earlier ones")
+	require.NoError(t, db.SafeHeadUpdated(safeB, l1B))
+
+	// reads include the updates that may not be written yet
+	requireNotFoundAt(t, db, 9)
+	requireSafeHeadAt(t, db, 10, l1A, safeA2)
+	requireSafeHeadAt(t, db, 14, l1A, safeA2)
+	requireSafeHeadAt(t, db, 15, l1B, safeB)
+	requireSafeHeadAt(t, db, 1000, l1B, safeB)
+}
+
+func TestSafeHeadPersisted(t *testing.T) {
+	c := &testChain{rng: rand.New(rand.NewSource(1234))}
+	dir := t.TempDir()
+	db := newTestDB(t, dir, 0)
+	l1A := c.l1(10)
+	safeA := c.l2(100)
+	require.NoError(t, db.SafeHeadUpdated(safeA, l1A))
+	require.NoError(t, db.Close())
+	require.ErrorIs(t, db.SafeHeadUpdated(c.l2(101), c.l1(11)), ErrClosed)
+
+	db = newTestDB(t, dir, 0)
+	defer db.Close()
+	// a clean restart resets to the latest safe head, without any gap
+	require.NoError(t, db.SafeHeadReset(safeA))
+	requireSafeHeadAt(t, db, 11, l1A, safeA)
+}
+
+func TestSafeHeadReorg(t *testing.T) {
+	c := &testChain{rng: rand.New(rand.NewSource(1234))}
+	db := newTestDB(t, t.TempDir(), 0)
+	defer db.Close()
+
+	l1A, l1B, l1C := c.l1(10), c.l1(11), c.l1(12)
+	safeA, safeB, safeC := c.l2(100), c.l2(110), c.l2(120)
+	require.NoError(t, db.SafeHeadUpdated(safeA, l1A))
+	require.NoError(t, db.SafeHeadUpdated(safeB, l1B))
+	require.NoError(t, db.SafeHeadUpdated(safeC, l1C))
+
+	// L1 blocks B and C become non-canonical: the pipeline resets to the safe head derived up to L1 block A.
+	require.NoError(t, db.SafeHeadReset(safeA))
+	requireSafeHeadAt(t, db, 11, l1A, safeA)
+	requireSafeHeadAt(t, db, 12, l1A, safeA)
+
+	// the safe head is re-derived from the new canonical L1 chain
+	l1B2, l1C2 := c.l1(11), c.l1(12)
+	safeB2 := c.l2(105)
+	require.NoError(t, db.SafeHeadUpdated(safeB2, l1C2))
+	requireSafeHeadAt(t, db, l1B2.Number, l1A, safeA)
+	requireSafeHeadAt(t, db, l1C2.Number, l1C2, safeB2)
+}
+
+func TestSafeHeadReorgSameHeight(t *testing.T) {
+	c := &testChain{rng: rand.New(rand.NewSource(1234))}
+	db := newTestDB(t, t.TempDir(), 0)
+	defer db.Close()
+
+	l1A, l1B := c.l1(10), c.l1(11)
+	safeA, safeB := c.l2(100), c.l2(110)
+	require.NoError(t, db.SafeHeadUpdated(safeA, l1A))
+	require.NoError(t, db.SafeHeadUpdated(safeB, l1B))
+
+	// the pipeline resets to a different L2 block at the height of the latest entry
+	safeB2 := c.l2(110)
+	require.NoError(t, db.SafeHeadReset(safeB2))
+	requireSafeHeadAt(t, db, 10, l1A, safeA)
+	// the reset safe head is ahead of the remaining entry, and the L1 block that made it safe is unknown
+	requireNotFoundAt(t, db, 11)
+	requireNotFoundAt(t, db, 12)
+}
+
+func TestSafeHeadGapAfterUncleanShutdown(t *testing.T) {
+	c := &testChain{rng: rand.New(rand.NewSource(1234))}
+	db := newTestDB(t, t.TempDir(), 0)
+	defer db.Close()
+
+	l1A := c.l1(10)
+	safeA := c.l2(100)
+	require.NoError(t, db.SafeHeadUpdated(safeA, l1A))
+
+	// The updates of L1 blocks 11 to 14 were lost, e.g. in a crash, while the engine persisted the safe head.
+	safeLost := c.l2(140)
+	require.NoError(t, db.SafeHeadReset(safeLost))
+	requireSafeHeadAt(t, db, 10, l1A, safeA)
+	requireNotFoundAt(t, db, 11)
+	requireNotFoundAt(t, db, 20)
+
+	// once the derivation advances the safe head again, it is known again
+	l1B := c.l1(16)
+	safeB := c.l2(150)
+	require.NoError(t, db.SafeHeadUpdated(safeB, l1B))
+	requireNotFoundAt(t, db, 15)
+	requireSafeHeadAt(t, db, 16, l1B, safeB)
+
+	// a later reset to the latest safe head does not change anything
+	require.NoError(t, db.SafeHeadReset(safeB))
+	requireNotFoundAt(t, db, 15)
+	requireSafeHeadAt(t, db, 17, l1B, safeB)
+}
+
+func TestSafeHeadResetEmpty(t *testing.T) {
+	c := &testChain{rng: rand.New(rand.NewSource(1234))}
+	db := newTestDB(t, t.TempDir(), 0)
+	defer db.Close()
+
+	// the database may be enabled on a node that is already synced
+	require.NoError(t, db.SafeHeadReset(c.l2(100)))
+	requireNotFoundAt(t, db, 10)
+	l1A := c.l1(10)
+	safeA := c.l2(101)
+	require.NoError(t, db.SafeHeadUpdated(safeA, l1A))
+	requireNotFoundAt(t, db, 9)
+	requireSafeHeadAt(t, db, 10, l1A, safeA)
+}
+
+func TestSafeHeadRetention(t *testing.T) {
+	c := &testChain{rng: rand.New(rand.NewSource(1234))}
+	db := newTestDB(t, t.TempDir(), 5)
+	defer db.Close()
+
+	var l1s []eth.BlockID
+	var safes []eth.L2BlockRef
+	for i := uint64(0); i < 10; i++ {
+		l1s = append(l1s, c.l1(10+i))
+		safes = append(safes, c.l2(100+i))
+		require.NoError(t, db.SafeHeadUpdated(safes[i], l1s[i]))
+		// write every update separately, for the pruning to apply with every update
+		require.NoError(t, db.flush())
+	}
+	// entries before L1 block 19-5 are pruned
+	requireNotFoundAt(t, db, 13)
+	for i := 4; i < 10; i++ {
+		requireSafeHeadAt(t, db, l1s[i].Number, l1s[i], safes[i])
+	}
+}
+
+func TestDecodeEntry(t *testing.T) {
+	e := entry{
+		l1Block:  eth.BlockID{Hash: common.Hash{0xaa}, Number: 1234},
+		safeHead: eth.BlockID{Hash: common.Hash{0xbb}, Number: 5678},
+	}
+	decoded, err := decodeEntry(safeByL1BlockNumKey(e.l1Block.Number), e.encode())
+	require.NoError(t, err)
+	require.Equal(t, e, *decoded)
+
+	gap, err := decodeEntry(safeByL1BlockNumKey(1), nil)
+	require.NoError(t, err)
+	require.Nil(t, gap)
+
+	_, err = decodeEntry(safeByL1BlockNumKey(1), []byte{1, 2, 3})
+	require.ErrorContains(t, err, "invalid entry length")
+	_, err = decodeEntry([]byte{1, 2, 3}, e.encode())
+	require.ErrorContains(t, err, "invalid key")
+}
@@ -6,8 +6,11 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 
 	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
+	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -21,15 +24,18 @@ import (
 type rpcServer struct {
 	endpoint   string
 	apis       []rpc.API
+	health     *healthChecker
 	httpServer *ophttp.HTTPServer
 	appVersion string
+	rateLimits map[string]float64
 	log        log.Logger
+	m          metrics.Metricer
 	sources.L2Client
 }
 
-func newRPCServer(ctx context.Context, rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthClient, dr driverClient, log log.Logger, appVersion string, m metrics.Metricer) (*rpcServer, error) {
-	api := NewNodeAPI(rollupCfg, l2Client, dr, log.New("rpc", "node"), m)
-	// TODO: extend RPC config with options for WS, IPC and HTTP RPC connections
+func newRPCServer(ctx context.Context, rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthClient, dr driverClient, safeDB SafeDBReader, runCfg p2p.GossipRuntimeConfig, log log.Logger, appVersion string, m metrics.Metricer) (*rpcServer, error) {
+	api := NewNodeAPI(rollupCfg, l2Client, dr, safeDB, runCfg, log.New("rpc", "node"), m)
+	// TODO: extend RPC config with options for IPC connections
 	endpoint := net.JoinHostPort(rpcCfg.ListenAddr, strconv.Itoa(rpcCfg.ListenPort))
 	r := &rpcServer{
 		endpoint: endpoint,
@@ -37,9 +43,15 @@ func newRPCServer(ctx context.Context, rpcCfg *RPCConfig, rollupCfg *rollup.Conf
 			Namespace:     "optimism",
 			Service:       api,
 			Authenticated: false,
+		}, {
+			Namespace:     "eth",
+			Service:       NewSubscriptionAPI(dr, log.New("rpc", "subscriptions"), m),
+			Authenticated: false,
 		}},
 		appVersion: appVersion,
+		rateLimits: rpcCfg.RateLimits,
 		log:        log,
+		m:          m,
 	}
 	return r, nil
 }
@@ -53,6 +65,11 @@ func (s *rpcServer) EnableAdminAPI(api *adminAPI) {
 	})
 }
 
+// EnablePprofAPI serves the admin methods to control the pprof service at runtime.
+func (s *rpcServer) EnablePprofAPI(api *oppprof.API) {
+	s.apis = append(s.apis, oppprof.GetAdminAPI(api))
+}
+
 func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 	s.apis = append(s.apis, rpc.API{
 		Namespace:     p2p.NamespaceRPC,
@@ -62,21 +79,48 @@ func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 	})
 }
 
+// EnableHealthCheck serves the sync health check, as optimism_health RPC method, and at the /healthz/sync HTTP path.
+func (s *rpcServer) EnableHealthCheck(checker *healthChecker, m metrics.Metricer) {
+	s.health = checker
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "optimism",
+		Service:       &healthAPI{checker: checker, m: m},
+		Authenticated: false,
+	})
+}
+
 func (s *rpcServer) Start() error {
 	srv := rpc.NewServer()
 	if err := node.RegisterApis(s.apis, nil, srv); err != nil {
 		return err
 	}
 
+	var rpcHandler http.Handler = srv
+	if len(s.rateLimits) > 0 {
+		rpcHandler = oprpc.NewRateLimitMiddleware(s.rateLimits)(rpcHandler)
+	}
+	rpcHandler = oprpc.NewMethodMetricsMiddleware(s.m)(rpcHandler)
+
 	// The CORS and VHosts arguments below must be set in order for
 	// other services to connect to the opnode. VHosts in particular
 	// defaults to localhost, which will prevent containers from
 	// calling into the opnode without an "invalid host" error.
-	nodeHandler := node.NewHTTPHandlerStack(srv, []string{"*"}, []string{"*"}, nil)
+	nodeHandler := node.NewHTTPHandlerStack(rpcHandler, []string{"*"}, []string{"*"}, nil)
+	// WebSocket connections are served on the same endpoint, for subscriptions.
+	wsHandler := node.NewWSHandlerStack(srv.WebsocketHandler([]string{"*"}), nil)
 
 	mux := http.NewServeMux()
-	mux.Handle("/", nodeHandler)
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebsocket(r) {
+			wsHandler.ServeHTTP(w, r)
+			return
+		}
+		nodeHandler.ServeHTTP(w, r)
+	}))
 	mux.HandleFunc("/healthz", healthzHandler(s.appVersion))
+	if s.health != nil {
+		mux.Handle("/healthz/sync", s.health)
+	}
 
 	hs, err := ophttp.StartHTTPServer(s.endpoint, mux)
 	if err != nil {
@@ -99,3 +143,9 @@ func healthzHandler(appVersion string) http.HandlerFunc {
 		_, _ = w.Write([]byte(appVersion))
 	}
 }
+
+// isWebsocket checks if the request is a WebSocket upgrade request.
+func isWebsocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -15,10 +17,14 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	rpcclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 )
@@ -101,7 +107,7 @@ func TestOutputAtBlock(t *testing.T) {
 	status := randomSyncStatus(rand.New(rand.NewSource(123)))
 	drClient.ExpectBlockRefWithStatus(0xdcdc89, ref, status, nil)
 
-	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safedb.Disabled, &testutils.MockRuntimeConfig{}, log, "0.0", metrics.NoopMetrics)
 	require.NoError(t, err)
 	require.NoError(t, server.Start())
 	defer func() {
@@ -120,6 +126,22 @@ func TestOutputAtBlock(t *testing.T) {
 	require.Equal(t, "0xb46d4bcb0e471e1b8506031a1f34ebc6f200253cbaba56246dd2320e8e2c8f13", out.StateRoot.String())
 	require.Equal(t, "0xc1917a80cb25ccc50d0d1921525a44fb619b4601194ca726ae32312f08a799f8", out.WithdrawalStorageRoot.String())
 	require.Equal(t, *status, *out.Status)
+	require.Nil(t, out.MessagePasserProof, "proof is only included on request")
+
+	// the proof that the output was computed with is included on request
+	storageKeys := []common.Hash{{0x01}}
+	l2Client.ExpectOutputV0WithProofAtBlock(ref.Hash, storageKeys, output, &result, nil)
+	drClient.ExpectBlockRefWithStatus(0xdcdc89, ref, status, nil)
+	var outWithProof *eth.OutputResponse
+	err = client.CallContext(context.Background(), &outWithProof, "optimism_outputAtBlock", "0xdcdc89",
+		&eth.OutputAtBlockOptions{IncludeProof: true, StorageKeys: storageKeys})
+	require.NoError(t, err)
+	require.Equal(t, out.OutputRoot, outWithProof.OutputRoot)
+	require.Equal(t, ref, outWithProof.BlockRef)
+	require.Equal(t, *status, *outWithProof.Status)
+	require.Equal(t, result, *outWithProof.MessagePasserProof)
+	require.NoError(t, outWithProof.MessagePasserProof.Verify(common.Hash(outWithProof.StateRoot)))
+
 	l2Client.Mock.AssertExpectations(t)
 	drClient.Mock.AssertExpectations(t)
 }
@@ -135,7 +157,7 @@ func TestVersion(t *testing.T) {
 	rollupCfg := &rollup.Config{
 		// ignore other rollup config info in this test
 	}
-	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safedb.Disabled, &testutils.MockRuntimeConfig{}, log, "0.0", metrics.NoopMetrics)
 	assert.NoError(t, err)
 	assert.NoError(t, server.Start())
 	defer func() {
@@ -167,6 +189,40 @@ func randomSyncStatus(rng *rand.Rand) *eth.SyncStatus {
 	}
 }
 
+func TestSequencerKeys(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: common.Address{0xaa}}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safedb.Disabled, runCfg, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *eth.SequencerKeys
+	require.NoError(t, client.CallContext(context.Background(), &out, "optimism_sequencerKeys"))
+	require.Equal(t, eth.SequencerKeys{Primary: common.Address{0xaa}}, *out)
+
+	// the keys are read on every call, to serve the reloaded signer
+	runCfg.P2PSeqAddress = common.Address{0xbb}
+	runCfg.P2PSeqSecondary = common.Address{0xaa}
+	runCfg.P2PSeqRotationTime = 1234
+	require.NoError(t, client.CallContext(context.Background(), &out, "optimism_sequencerKeys"))
+	require.Equal(t, eth.SequencerKeys{Primary: common.Address{0xbb}, Secondary: common.Address{0xaa}, RotationTime: 1234}, *out)
+}
+
 func TestSyncStatus(t *testing.T) {
 	log := testlog.Logger(t, log.LvlError)
 	l2Client := &testutils.MockL2Client{}
@@ -182,7 +238,7 @@ func TestSyncStatus(t *testing.T) {
 	rollupCfg := &rollup.Config{
 		// ignore other rollup config info in this test
 	}
-	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safedb.Disabled, &testutils.MockRuntimeConfig{}, log, "0.0", metrics.NoopMetrics)
 	assert.NoError(t, err)
 	assert.NoError(t, server.Start())
 	defer func() {
@@ -198,8 +254,196 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, status, out)
 }
 
+func TestSafeHeadAtL1Block(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rng := rand.New(rand.NewSource(1234))
+	safeDB, err := safedb.NewSafeDB(log, t.TempDir(), 0)
+	require.NoError(t, err)
+	defer safeDB.Close()
+	l1Block := eth.BlockID{Hash: testutils.RandomHash(rng), Number: 10}
+	safeHead := testutils.RandomL2BlockRef(rng)
+	require.NoError(t, safeDB.SafeHeadUpdated(safeHead, l1Block))
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safeDB, &testutils.MockRuntimeConfig{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *eth.SafeHeadResponse
+	require.NoError(t, client.CallContext(context.Background(), &out, "optimism_safeHeadAtL1Block", hexutil.Uint64(12)))
+	require.Equal(t, &eth.SafeHeadResponse{L1Block: l1Block, SafeHead: safeHead.ID()}, out)
+
+	err = client.CallContext(context.Background(), &out, "optimism_safeHeadAtL1Block", hexutil.Uint64(9))
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestStateSnapshot(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rng := rand.New(rand.NewSource(1234))
+	snap := &driver.StateSnapshot{
+		Time: 1234,
+		Loop: driver.LoopSnapshot{
+			StepAttempts: 3,
+			LastReset:    &driver.ResetSnapshot{Time: 1000, Reason: "reset: test"},
+		},
+		Sequencer: driver.SequencerSnapshot{Enabled: true, BuildingOnto: testutils.RandomL2BlockRef(rng)},
+		Derivation: derive.PipelineProgress{
+			Stages:         []derive.StageProgress{{Stage: "EngineQueue", Origin: testutils.RandomBlockRef(rng)}},
+			UnsafePayloads: derive.UnsafePayloadsSummary{Len: 2, MemSize: 100, Next: testutils.RandomBlockID(rng)},
+		},
+		Engine: driver.EngineSnapshot{
+			Ready:     true,
+			Unsafe:    testutils.RandomL2BlockRef(rng),
+			Safe:      testutils.RandomL2BlockRef(rng),
+			Finalized: testutils.RandomL2BlockRef(rng),
+		},
+		L1:           driver.L1Snapshot{Head: testutils.RandomBlockRef(rng)},
+		LastProgress: driver.ProgressSnapshot{L1Head: 1200, Derivation: 1100},
+	}
+	drClient.On("StateSnapshot").Return(snap)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safedb.Disabled, &testutils.MockRuntimeConfig{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, metrics.NoopMetrics, log))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *driver.StateSnapshot
+	err = client.CallContext(context.Background(), &out, "admin_stateSnapshot")
+	require.NoError(t, err)
+	require.Equal(t, snap, out)
+}
+
+func TestSequencerStatus(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rng := rand.New(rand.NewSource(1234))
+	status := &driver.SequencerStatus{
+		Enabled:           true,
+		Stopped:           true,
+		LastSequenced:     testutils.RandomBlockID(rng),
+		LastSequencedTime: 1234,
+	}
+	drClient.On("SequencerStatus").Return(status)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safedb.Disabled, &testutils.MockRuntimeConfig{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, metrics.NoopMetrics, log))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *driver.SequencerStatus
+	err = client.CallContext(context.Background(), &out, "admin_sequencerStatus")
+	require.NoError(t, err)
+	require.Equal(t, status, out)
+}
+
+func TestSubscribeSyncStatus(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rng := rand.New(rand.NewSource(1234))
+	randomStatus := func() *eth.SyncStatus {
+		return &eth.SyncStatus{
+			UnsafeL2:    testutils.RandomL2BlockRef(rng),
+			SafeL2:      testutils.RandomL2BlockRef(rng),
+			FinalizedL2: testutils.RandomL2BlockRef(rng),
+		}
+	}
+	initial := randomStatus()
+	drClient.syncStatusFeed.Send(initial)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safedb.Disabled, &testutils.MockRuntimeConfig{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	// subscriptions are not supported over HTTP
+	httpClient, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	defer httpClient.Close()
+	_, err = sources.NewRollupClient(httpClient).SubscribeSyncStatus(context.Background(), make(chan *eth.SyncStatus))
+	require.Error(t, err)
+
+	wsClient, err := rpcclient.NewRPC(context.Background(), log, "ws://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	defer wsClient.Close()
+	ch := make(chan *eth.SyncStatus, 10)
+	sub, err := sources.NewRollupClient(wsClient).SubscribeSyncStatus(context.Background(), ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	receive := func() *eth.SyncStatus {
+		select {
+		case status := <-ch:
+			return status
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for sync status")
+		}
+		return nil
+	}
+	require.Equal(t, initial, receive(), "starts with the current status")
+	next := randomStatus()
+	drClient.syncStatusFeed.Send(next)
+	require.Equal(t, next, receive())
+}
+
 type mockDriverClient struct {
 	mock.Mock
+
+	syncStatusFeed driver.SyncStatusFeed
 }
 
 func (c *mockDriverClient) ExpectBlockRefWithStatus(num uint64, ref eth.L2BlockRef, status *eth.SyncStatus, err error) {
@@ -230,3 +474,15 @@ func (c *mockDriverClient) StopSequencer(ctx context.Context) (common.Hash, erro
 func (c *mockDriverClient) SequencerActive(ctx context.Context) (bool, error) {
 	return c.Mock.MethodCalled("SequencerActive").Get(0).(bool), nil
 }
+
+func (c *mockDriverClient) SequencerStatus(ctx context.Context) (*driver.SequencerStatus, error) {
+	return c.Mock.MethodCalled("SequencerStatus").Get(0).(*driver.SequencerStatus), nil
+}
+
+func (c *mockDriverClient) SubscribeSyncStatus() *driver.SyncStatusSubscription {
+	return c.syncStatusFeed.Subscribe()
+}
+
+func (c *mockDriverClient) StateSnapshot(ctx context.Context) (*driver.StateSnapshot, error) {
+	return c.Mock.MethodCalled("StateSnapshot").Get(0).(*driver.StateSnapshot), nil
+}
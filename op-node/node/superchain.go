@@ -3,13 +3,27 @@ package node
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum/go-ethereum/eth/catalyst"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-var errNodeHalt = errors.New("opted to halt, unprepared for protocol change")
+var (
+	errNodeHalt      = errors.New("opted to halt, unprepared for protocol change")
+	errSequencerHalt = errors.New("opted to stop sequencing, unprepared for protocol change")
+)
+
+// The actions to take when halting on an incompatible protocol version requirement.
+const (
+	// HaltActionShutdown shuts down the node.
+	HaltActionShutdown = "shutdown"
+	// HaltActionStopSequencer stops the sequencer, the node keeps running as verifier.
+	HaltActionStopSequencer = "stop-sequencer"
+	// HaltActionWarn only logs a warning.
+	HaltActionWarn = "warn"
+)
 
 func (n *OpNode) handleProtocolVersionsUpdate(ctx context.Context) error {
 	recommended := n.runCfg.RecommendedProtocolVersion()
@@ -38,15 +52,47 @@ func (n *OpNode) handleProtocolVersionsUpdate(ctx context.Context) error {
 
 // haltMaybe returns errNodeHalt if the runtime config indicates an incompatible required protocol change
 // and the node is configured to opt-in to halting at this protocol-change level.
+// If the node is configured to halt with the stop-sequencer action, errSequencerHalt is returned instead.
+// With the warn action, only a warning is logged.
 func (n *OpNode) haltMaybe() error {
 	local := rollup.OPStackSupport
 	required := n.runCfg.RequiredProtocolVersion()
-	if haltMaybe(n.rollupHalt, local.Compare(required)) { // halt if we opted in to do so at this granularity
+	if !haltMaybe(n.rollupHalt, local.Compare(required)) { // halt if we opted in to do so at this granularity
+		return nil
+	}
+	switch n.rollupHaltAction {
+	case HaltActionWarn:
+		n.log.Warn("Unprepared for protocol change, opted to not halt", "required", required, "local", local)
+		return nil
+	case HaltActionStopSequencer:
+		n.log.Error("Opted to stop sequencing, unprepared for protocol change", "required", required, "local", local)
+		// Avoid deadlocking the runtime config reloader by stopping the sequencer elsewhere
+		return errSequencerHalt
+	default:
 		n.log.Error("Opted to halt, unprepared for protocol change", "required", required, "local", local)
 		// Avoid deadlocking the runtime config reloader by closing the OpNode elsewhere
 		return errNodeHalt
 	}
-	return nil
+}
+
+// stopSequencerOnHalt stops the sequencer, if it is active, after an incompatible protocol change.
+func (n *OpNode) stopSequencerOnHalt(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	active, err := n.l2Driver.SequencerActive(ctx)
+	if err != nil {
+		n.log.Error("Failed to check if sequencer is active, to stop it for protocol change", "err", err)
+		return
+	}
+	if !active {
+		return
+	}
+	head, err := n.l2Driver.StopSequencer(ctx)
+	if err != nil {
+		n.log.Error("Failed to stop sequencer for protocol change", "err", err)
+		return
+	}
+	n.log.Warn("Stopped sequencer, unprepared for protocol change", "head", head)
 }
 
 // haltMaybe returns true when we should halt, given the halt-option and required-version comparison
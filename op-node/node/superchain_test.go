@@ -1,12 +1,21 @@
 package node
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/slices"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
 func TestHaltMaybe(t *testing.T) {
@@ -37,3 +46,56 @@ func TestHaltMaybe(t *testing.T) {
 	haltTest("minor", params.OutdatedMajor, params.OutdatedMinor)
 	haltTest("patch", params.OutdatedMajor, params.OutdatedMinor, params.OutdatedPatch)
 }
+
+// testProtocolVersionsL1Source stubs the storage reads of the SystemConfig and ProtocolVersions contracts.
+type testProtocolVersionsL1Source struct {
+	required    params.ProtocolVersion
+	recommended params.ProtocolVersion
+}
+
+func (s *testProtocolVersionsL1Source) ReadStorageAt(ctx context.Context, address common.Address, storageSlot common.Hash, blockHash common.Hash) (common.Hash, error) {
+	switch storageSlot {
+	case RequiredProtocolVersionStorageSlot:
+		return common.Hash(s.required), nil
+	case RecommendedProtocolVersionStorageSlot:
+		return common.Hash(s.recommended), nil
+	default:
+		return common.Hash{}, nil
+	}
+}
+
+func (s *testProtocolVersionsL1Source) FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
+func TestHaltAction(t *testing.T) {
+	_, build, major, minor, patch, preRelease := rollup.OPStackSupport.Parse()
+	nextMajor := params.ProtocolVersionV0{Build: build, Major: major + 1, Minor: minor, Patch: patch, PreRelease: preRelease}.Encode()
+
+	cfg := &rollup.Config{L1SystemConfigAddress: common.Address{0x42}, ProtocolVersionsAddress: common.Address{0x43}}
+	setup := func(t *testing.T, halt string, action string, required params.ProtocolVersion) *OpNode {
+		logger := testlog.Logger(t, log.LvlError)
+		l1 := &testProtocolVersionsL1Source{required: required, recommended: required}
+		runCfg := NewRuntimeConfig(logger, l1, cfg, nil, DisabledConfigPersistence{})
+		require.NoError(t, runCfg.Load(context.Background(), eth.L1BlockRef{Number: 1}))
+		require.Equal(t, required, runCfg.RequiredProtocolVersion())
+		return &OpNode{log: logger, runCfg: runCfg, rollupHalt: halt, rollupHaltAction: action}
+	}
+
+	for _, action := range []string{"", HaltActionShutdown, HaltActionStopSequencer, HaltActionWarn} {
+		action := action
+		t.Run("action "+action, func(t *testing.T) {
+			expected := errNodeHalt
+			switch action {
+			case HaltActionStopSequencer:
+				expected = errSequencerHalt
+			case HaltActionWarn:
+				expected = nil
+			}
+			require.Equal(t, expected, setup(t, "major", action, nextMajor).haltMaybe())
+			require.Equal(t, expected, setup(t, "patch", action, nextMajor).haltMaybe())
+			require.NoError(t, setup(t, "major", action, rollup.OPStackSupport).haltMaybe(), "required version is supported")
+			require.NoError(t, setup(t, "", action, nextMajor).haltMaybe(), "not opted in to halt")
+		})
+	}
+}
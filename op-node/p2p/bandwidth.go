@@ -0,0 +1,169 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/metrics"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/ethereum/go-ethereum/p2p/discover"
+)
+
+const (
+	// bandwidthRefreshInterval is the interval at which the protocol and per-peer bandwidth metrics are refreshed.
+	bandwidthRefreshInterval = 10 * time.Second
+	// bandwidthTopPeers is the number of connected peers that per-peer bandwidth metrics are exported for.
+	bandwidthTopPeers = 10
+	// discv5BandwidthProtocol is the protocol that discv5 UDP traffic is accounted to in the bandwidth counter.
+	// Discv5 does not run on libp2p, this protocol ID is never negotiated.
+	discv5BandwidthProtocol = protocol.ID("/discv5")
+)
+
+// Protocol classes, to label bandwidth metrics with.
+const (
+	BandwidthGossip    = "gossip"
+	BandwidthReqResp   = "reqresp"
+	BandwidthDiscovery = "discovery"
+	BandwidthOther     = "other"
+)
+
+type BandwidthMetrics interface {
+	SetProtocolBandwidth(protocol string, in, out int64)
+	SetPeerBandwidth(peers map[string]metrics.Stats)
+}
+
+// bandwidthProtocolClass classifies a protocol ID, to bound the cardinality of the protocol bandwidth metrics.
+func bandwidthProtocolClass(id protocol.ID) string {
+	switch {
+	case id == discv5BandwidthProtocol:
+		return BandwidthDiscovery
+	case strings.HasPrefix(string(id), "/meshsub/") || strings.HasPrefix(string(id), "/floodsub/"):
+		return BandwidthGossip
+	case strings.HasPrefix(string(id), "/opstack/req/"):
+		return BandwidthReqResp
+	default:
+		return BandwidthOther
+	}
+}
+
+// recordBandwidth exports the bandwidth per protocol class,
+// and the bandwidth of the connected peers with the most traffic.
+func recordBandwidth(bwc *metrics.BandwidthCounter, connected []peer.ID, m BandwidthMetrics) {
+	classes := map[string]metrics.Stats{
+		BandwidthGossip:    {},
+		BandwidthReqResp:   {},
+		BandwidthDiscovery: {},
+		BandwidthOther:     {},
+	}
+	for id, stats := range bwc.GetBandwidthByProtocol() {
+		class := bandwidthProtocolClass(id)
+		total := classes[class]
+		total.TotalIn += stats.TotalIn
+		total.TotalOut += stats.TotalOut
+		classes[class] = total
+	}
+	for class, total := range classes {
+		m.SetProtocolBandwidth(class, total.TotalIn, total.TotalOut)
+	}
+
+	type peerStats struct {
+		id    peer.ID
+		stats metrics.Stats
+	}
+	peers := make([]peerStats, 0, len(connected))
+	for _, id := range connected {
+		peers = append(peers, peerStats{id: id, stats: bwc.GetBandwidthForPeer(id)})
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].stats.TotalIn+peers[i].stats.TotalOut > peers[j].stats.TotalIn+peers[j].stats.TotalOut
+	})
+	if len(peers) > bandwidthTopPeers {
+		peers = peers[:bandwidthTopPeers]
+	}
+	top := make(map[string]metrics.Stats, len(peers))
+	for _, p := range peers {
+		top[p.id.String()] = p.stats
+	}
+	m.SetPeerBandwidth(top)
+}
+
+// monitorBandwidth periodically refreshes the bandwidth metrics, until the context is canceled.
+func monitorBandwidth(ctx context.Context, bwc *metrics.BandwidthCounter, nw network.Network, m BandwidthMetrics) {
+	ticker := time.NewTicker(bandwidthRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			recordBandwidth(bwc, nw.Peers(), m)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// meteredUDPConn reports the discv5 traffic to the bandwidth reporter.
+type meteredUDPConn struct {
+	discover.UDPConn
+	reporter metrics.Reporter
+}
+
+var _ discover.UDPConn = (*meteredUDPConn)(nil)
+
+func (c *meteredUDPConn) ReadFromUDP(b []byte) (n int, addr *net.UDPAddr, err error) {
+	n, addr, err = c.UDPConn.ReadFromUDP(b)
+	if n > 0 {
+		c.reporter.LogRecvMessage(int64(n))
+		c.reporter.LogRecvMessageStream(int64(n), discv5BandwidthProtocol, "")
+	}
+	return n, addr, err
+}
+
+func (c *meteredUDPConn) WriteToUDP(b []byte, addr *net.UDPAddr) (n int, err error) {
+	n, err = c.UDPConn.WriteToUDP(b, addr)
+	if n > 0 {
+		c.reporter.LogSentMessage(int64(n))
+		c.reporter.LogSentMessageStream(int64(n), discv5BandwidthProtocol, "")
+	}
+	return n, err
+}
+
+// gossipBandwidthTracer records the bytes of gossip messages per topic.
+// Only the published messages in RPCs are counted, not the control messages.
+type gossipBandwidthTracer struct {
+	m GossipMetricer
+}
+
+var _ pubsub.RawTracer = (*gossipBandwidthTracer)(nil)
+
+func (g *gossipBandwidthTracer) RecvRPC(rpc *pubsub.RPC) {
+	for _, msg := range rpc.GetPublish() {
+		g.m.RecordGossipTopicBytes(msg.GetTopic(), "in", msg.Size())
+	}
+}
+
+func (g *gossipBandwidthTracer) SendRPC(rpc *pubsub.RPC, p peer.ID) {
+	for _, msg := range rpc.GetPublish() {
+		g.m.RecordGossipTopicBytes(msg.GetTopic(), "out", msg.Size())
+	}
+}
+
+func (g *gossipBandwidthTracer) AddPeer(p peer.ID, proto protocol.ID)        {}
+func (g *gossipBandwidthTracer) RemovePeer(p peer.ID)                        {}
+func (g *gossipBandwidthTracer) Join(topic string)                           {}
+func (g *gossipBandwidthTracer) Leave(topic string)                          {}
+func (g *gossipBandwidthTracer) Graft(p peer.ID, topic string)               {}
+func (g *gossipBandwidthTracer) Prune(p peer.ID, topic string)               {}
+func (g *gossipBandwidthTracer) ValidateMessage(msg *pubsub.Message)         {}
+func (g *gossipBandwidthTracer) DeliverMessage(msg *pubsub.Message)          {}
+func (g *gossipBandwidthTracer) RejectMessage(msg *pubsub.Message, _ string) {}
+func (g *gossipBandwidthTracer) DuplicateMessage(msg *pubsub.Message)        {}
+func (g *gossipBandwidthTracer) ThrottlePeer(p peer.ID)                      {}
+func (g *gossipBandwidthTracer) DropRPC(rpc *pubsub.RPC, p peer.ID)          {}
+func (g *gossipBandwidthTracer) UndeliverableMessage(msg *pubsub.Message)    {}
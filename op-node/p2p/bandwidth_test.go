@@ -0,0 +1,136 @@
+package p2p
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type bandwidthMetrics struct {
+	mu         sync.Mutex
+	topicBytes map[string]int
+	protocols  map[string]metrics.Stats
+	peers      map[string]metrics.Stats
+}
+
+func newBandwidthMetrics() *bandwidthMetrics {
+	return &bandwidthMetrics{topicBytes: make(map[string]int)}
+}
+
+func (b *bandwidthMetrics) RecordGossipEvent(evType int32) {}
+
+func (b *bandwidthMetrics) RecordGossipPublishAttempt(retry bool) {}
+
+func (b *bandwidthMetrics) RecordGossipPublishDrop(reason string) {}
+
+func (b *bandwidthMetrics) RecordGossipTopicBytes(topic string, direction string, size int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.topicBytes[topic+"/"+direction] += size
+}
+
+func (b *bandwidthMetrics) topic(topic string, direction string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.topicBytes[topic+"/"+direction]
+}
+
+func (b *bandwidthMetrics) SetProtocolBandwidth(protocol string, in, out int64) {
+	if b.protocols == nil {
+		b.protocols = make(map[string]metrics.Stats)
+	}
+	b.protocols[protocol] = metrics.Stats{TotalIn: in, TotalOut: out}
+}
+
+func (b *bandwidthMetrics) SetPeerBandwidth(peers map[string]metrics.Stats) {
+	b.peers = peers
+}
+
+func TestGossipTopicBandwidth(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlError)
+	cfg := &rollup.Config{L2ChainID: big.NewInt(777)}
+	conf := &Config{MeshD: 1, MeshDLo: 1, MeshDHi: 1, MeshDLazy: 1, GossipHeartbeat: 100 * time.Millisecond}
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	topic := blocksTopicV1(cfg)
+	ms := []*bandwidthMetrics{newBandwidthMetrics(), newBandwidthMetrics()}
+	ps, err := NewGossipSub(ctx, hosts[0], cfg, conf, nil, ms[0], logger)
+	require.NoError(t, err)
+	topicA, err := ps.Join(topic)
+	require.NoError(t, err)
+	ps, err = NewGossipSub(ctx, hosts[1], cfg, conf, nil, ms[1], logger)
+	require.NoError(t, err)
+	topicB, err := ps.Join(topic)
+	require.NoError(t, err)
+	sub, err := topicB.Subscribe()
+	require.NoError(t, err)
+	defer sub.Cancel()
+	require.Eventually(t, func() bool {
+		return len(topicA.ListPeers()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	require.Zero(t, ms[0].topic(topic, "out"))
+	require.NoError(t, topicA.Publish(ctx, make([]byte, 1000)))
+	readCtx, readCancel := context.WithTimeout(ctx, 10*time.Second)
+	defer readCancel()
+	_, err = sub.Next(readCtx)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return ms[0].topic(topic, "out") > 1000
+	}, 10*time.Second, 10*time.Millisecond, "publisher counts the sent message bytes")
+	require.Greater(t, ms[1].topic(topic, "in"), 1000, "subscriber counts the received message bytes")
+	require.Zero(t, ms[1].topic(topic, "out"), "subscriber does not relay back to the publisher")
+}
+
+func TestRecordBandwidth(t *testing.T) {
+	bwc := metrics.NewBandwidthCounter()
+	var connected []peer.ID
+	for i := 0; i < bandwidthTopPeers+2; i++ {
+		id := peer.ID(rune('a' + i))
+		connected = append(connected, id)
+		// later peers have more traffic
+		bwc.LogRecvMessageStream(int64(100*(i+1)), "/meshsub/1.1.0", id)
+	}
+	bwc.LogSentMessageStream(50, "/opstack/req/payload_by_number/10/0", connected[0])
+	bwc.LogRecvMessageStream(30, discv5BandwidthProtocol, "")
+	bwc.LogSentMessageStream(20, "/ipfs/id/1.0.0", connected[0])
+	disconnected := peer.ID("disconnected")
+	bwc.LogRecvMessageStream(1_000_000, "/meshsub/1.1.0", disconnected)
+
+	m := newBandwidthMetrics()
+	// the bandwidth counter totals are updated in the background
+	require.Eventually(t, func() bool {
+		recordBandwidth(bwc, connected, m)
+		return m.protocols[BandwidthOther].TotalOut == 20
+	}, 10*time.Second, 50*time.Millisecond)
+
+	require.Equal(t, int64(1_000_000+100*(bandwidthTopPeers+2)*(bandwidthTopPeers+3)/2), m.protocols[BandwidthGossip].TotalIn)
+	require.Equal(t, int64(50), m.protocols[BandwidthReqResp].TotalOut)
+	require.Equal(t, int64(30), m.protocols[BandwidthDiscovery].TotalIn)
+
+	require.Len(t, m.peers, bandwidthTopPeers)
+	require.NotContains(t, m.peers, disconnected.String(), "only connected peers are exported")
+	require.NotContains(t, m.peers, connected[0].String(), "least active peers are not exported")
+	require.NotContains(t, m.peers, connected[1].String(), "least active peers are not exported")
+	top := connected[len(connected)-1]
+	require.Equal(t, int64(100*len(connected)), m.peers[top.String()].TotalIn)
+}
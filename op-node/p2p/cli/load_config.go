@@ -65,6 +65,7 @@ func NewConfig(ctx *cli.Context, rollupCfg *rollup.Config) (*p2p.Config, error)
 	}
 
 	conf.EnableReqRespSync = ctx.Bool(flags.SyncReqRespName)
+	conf.DiscoveryDialUnmarked = ctx.Bool(flags.DialUnmarkedName)
 
 	return conf, nil
 }
@@ -97,6 +98,16 @@ func loadScoringParams(conf *p2p.Config, ctx *cli.Context, rollupCfg *rollup.Con
 		if err != nil {
 			return err
 		}
+		if params != nil {
+			overrides := p2p.ScoringOverrides{
+				DecayInterval:     ctx.Duration(flags.ScoringDecayName),
+				TopicWeight:       ctx.Float64(flags.ScoringTopicWeightName),
+				GraylistThreshold: ctx.Float64(flags.ScoringGraylistName),
+			}
+			if err := overrides.Apply(params); err != nil {
+				return err
+			}
+		}
 		conf.ScoringParams = params
 	}
 
@@ -194,6 +205,14 @@ func loadDiscoveryOpts(conf *p2p.Config, ctx *cli.Context) error {
 		conf.Bootnodes = p2p.DefaultBootnodes
 	}
 
+	for _, url := range strings.Split(ctx.String(flags.DNSDiscoveryName), ",") {
+		url = strings.TrimSpace(url)
+		if url == "" {
+			continue
+		}
+		conf.DNSDiscoveryURLs = append(conf.DNSDiscoveryURLs, url)
+	}
+
 	if ctx.IsSet(flags.NetRestrictName) {
 		netRestrict, err := netutil.ParseNetlist(ctx.String(flags.NetRestrictName))
 		if err != nil {
@@ -338,5 +357,9 @@ func loadGossipOptions(conf *p2p.Config, ctx *cli.Context) error {
 	conf.MeshDHi = ctx.Int(flags.GossipMeshDhiName)
 	conf.MeshDLazy = ctx.Int(flags.GossipMeshDlazyName)
 	conf.FloodPublish = ctx.Bool(flags.GossipFloodPublishName)
+	conf.GossipHeartbeat = ctx.Duration(flags.GossipHeartbeatName)
+	conf.PublishRetry = p2p.DefaultPublishRetryConfig()
+	conf.PublishRetry.SupersedeDepth = ctx.Uint64(flags.GossipRetryDepthName)
+	conf.PublishRetry.TTL = ctx.Duration(flags.GossipRetryTTLName)
 	return nil
 }
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-node/flags"
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func runGossipOptions(t *testing.T, args ...string) *p2p.Config {
+	conf := &p2p.Config{}
+	app := cli.NewApp()
+	app.Flags = flags.P2PFlags("OP_NODE")
+	app.Action = func(ctx *cli.Context) error {
+		return loadGossipOptions(conf, ctx)
+	}
+	require.NoError(t, app.Run(append([]string{"op-node"}, args...)))
+	return conf
+}
+
+func TestLoadGossipOptions(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		conf := runGossipOptions(t)
+		require.Equal(t, p2p.DefaultMeshD, conf.MeshD)
+		require.Equal(t, p2p.DefaultMeshDlo, conf.MeshDLo)
+		require.Equal(t, p2p.DefaultMeshDhi, conf.MeshDHi)
+		require.Equal(t, p2p.DefaultMeshDlazy, conf.MeshDLazy)
+		require.Equal(t, p2p.DefaultGossipHeartbeat, conf.GossipHeartbeat)
+		require.False(t, conf.FloodPublish)
+	})
+	t.Run("overrides", func(t *testing.T) {
+		conf := runGossipOptions(t,
+			"--"+flags.GossipMeshDName+"=2",
+			"--"+flags.GossipMeshDloName+"=1",
+			"--"+flags.GossipMeshDhiName+"=3",
+			"--"+flags.GossipMeshDlazyName+"=1",
+			"--"+flags.GossipHeartbeatName+"=100ms",
+			"--"+flags.GossipFloodPublishName)
+		require.Equal(t, 2, conf.MeshD)
+		require.Equal(t, 1, conf.MeshDLo)
+		require.Equal(t, 3, conf.MeshDHi)
+		require.Equal(t, 1, conf.MeshDLazy)
+		require.Equal(t, 100*time.Millisecond, conf.GossipHeartbeat)
+		require.True(t, conf.FloodPublish)
+
+		params := conf.GossipParams(nil)
+		require.Equal(t, 2, params.D)
+		require.Equal(t, 1, params.Dlo)
+		require.Equal(t, 3, params.Dhi)
+		require.Equal(t, 1, params.Dlazy)
+		require.Equal(t, 100*time.Millisecond, params.HeartbeatInterval)
+		require.Equal(t, 0, params.Dout, "outbound quota must fit in the tiny mesh")
+		require.LessOrEqual(t, params.Dscore, params.D)
+	})
+}
+
+func TestLoadSequencerKeys(t *testing.T) {
+	run := func(args ...string) (*eth.SequencerKeys, error) {
+		var keys *eth.SequencerKeys
+		app := cli.NewApp()
+		app.Flags = flags.P2PFlags("OP_NODE")
+		app.Action = func(ctx *cli.Context) (err error) {
+			keys, err = LoadSequencerKeys(ctx)
+			return err
+		}
+		err := app.Run(append([]string{"op-node"}, args...))
+		return keys, err
+	}
+	primary, secondary := common.Address{0xbb}, common.Address{0xaa}
+
+	keys, err := run()
+	require.NoError(t, err)
+	require.Nil(t, keys, "the SystemConfig keys are used by default")
+
+	keys, err = run("--" + flags.SequencerKeysPrimaryName + "=" + primary.Hex())
+	require.NoError(t, err)
+	require.Equal(t, &eth.SequencerKeys{Primary: primary}, keys)
+
+	keys, err = run("--"+flags.SequencerKeysPrimaryName+"="+primary.Hex(),
+		"--"+flags.SequencerKeysSecondaryName+"="+secondary.Hex(),
+		"--"+flags.SequencerKeysRotationTimeName+"=1000")
+	require.NoError(t, err)
+	require.Equal(t, &eth.SequencerKeys{Primary: primary, Secondary: secondary, RotationTime: 1000}, keys)
+
+	_, err = run("--" + flags.SequencerKeysSecondaryName + "=" + secondary.Hex())
+	require.ErrorContains(t, err, flags.SequencerKeysPrimaryName)
+	_, err = run("--"+flags.SequencerKeysPrimaryName+"="+primary.Hex(), "--"+flags.SequencerKeysSecondaryName+"="+secondary.Hex())
+	require.ErrorContains(t, err, "rotation time")
+	_, err = run("--" + flags.SequencerKeysPrimaryName + "=0xinvalid")
+	require.ErrorContains(t, err, "invalid primary")
+}
@@ -1,22 +1,28 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 )
 
-// TODO: implement remote signer setup (config to authenticated endpoint)
-// and remote signer itself (e.g. a open http client to make signing requests)
-
 // LoadSignerSetup loads a configuration for a Signer to be set up later
-func LoadSignerSetup(ctx *cli.Context) (p2p.SignerSetup, error) {
+func LoadSignerSetup(ctx *cli.Context, lgr log.Logger) (p2p.SignerSetup, error) {
 	key := ctx.String(flags.SequencerP2PKeyName)
+	signerCfg := opsigner.ReadCLIConfig(ctx)
+	if key != "" && signerCfg.Enabled() {
+		return nil, errors.New("cannot specify both a p2p sequencer key and a remote signer")
+	}
 	if key != "" {
 		// Mnemonics are bad because they leak *all* keys when they leak.
 		// Unencrypted keys from file are bad because they are easy to leak (and we are not checking file permissions).
@@ -28,7 +34,41 @@ func LoadSignerSetup(ctx *cli.Context) (p2p.SignerSetup, error) {
 		return &p2p.PreparedSigner{Signer: p2p.NewLocalSigner(priv)}, nil
 	}
 
-	// TODO: create remote signer
+	if signerCfg.Enabled() {
+		if err := signerCfg.Check(); err != nil {
+			return nil, fmt.Errorf("invalid remote signer config: %w", err)
+		}
+		return &p2p.RemoteSignerSetup{Config: signerCfg, Log: lgr}, nil
+	}
 
 	return nil, nil
 }
+
+// LoadSequencerKeys loads the manually configured p2p sequencer keys, or nil if no keys are configured.
+func LoadSequencerKeys(ctx *cli.Context) (*eth.SequencerKeys, error) {
+	primary := ctx.String(flags.SequencerKeysPrimaryName)
+	secondary := ctx.String(flags.SequencerKeysSecondaryName)
+	if primary == "" {
+		if secondary != "" || ctx.IsSet(flags.SequencerKeysRotationTimeName) {
+			return nil, fmt.Errorf("the secondary p2p sequencer key requires the %s flag", flags.SequencerKeysPrimaryName)
+		}
+		return nil, nil
+	}
+	if !common.IsHexAddress(primary) {
+		return nil, fmt.Errorf("invalid primary p2p sequencer key address: %q", primary)
+	}
+	keys := &eth.SequencerKeys{
+		Primary:      common.HexToAddress(primary),
+		RotationTime: ctx.Uint64(flags.SequencerKeysRotationTimeName),
+	}
+	if secondary != "" {
+		if !common.IsHexAddress(secondary) {
+			return nil, fmt.Errorf("invalid secondary p2p sequencer key address: %q", secondary)
+		}
+		keys.Secondary = common.HexToAddress(secondary)
+	}
+	if err := keys.Check(); err != nil {
+		return nil, fmt.Errorf("invalid p2p sequencer keys: %w", err)
+	}
+	return keys, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/ethereum/go-ethereum/p2p/dnsdisc"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/netutil"
 	ds "github.com/ipfs/go-datastore"
@@ -34,6 +35,7 @@ var DefaultBootnodes = []*enode.Node{
 type HostMetrics interface {
 	gating.UnbanMetrics
 	gating.ConnectionGaterMetrics
+	StaticPeerMetrics
 }
 
 // SetupP2P provides a host and discovery service for usage in the rollup node.
@@ -43,19 +45,28 @@ type SetupP2P interface {
 	// Host creates a libp2p host service. Returns nil, nil if p2p is disabled.
 	Host(log log.Logger, reporter metrics.Reporter, metrics HostMetrics) (host.Host, error)
 	// Discovery creates a disc-v5 service. Returns nil, nil, nil if discovery is disabled.
-	Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort uint16) (*enode.LocalNode, *discover.UDPv5, error)
+	// The discovery traffic is reported to the bandwidth reporter, if not nil.
+	Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort uint16, reporter metrics.Reporter) (*enode.LocalNode, *discover.UDPv5, error)
+	// DNSDiscovery creates a DNS node list discovery service. Returns nil, nil if DNS discovery is disabled.
+	DNSDiscovery(log log.Logger) (*DNSDiscovery, error)
 	TargetPeers() uint
 	BanPeers() bool
 	BanThreshold() float64
 	BanDuration() time.Duration
 	GossipSetupConfigurables
 	ReqRespSyncEnabled() bool
+	// DialUnmarkedPeers returns whether discovered peers without opstack node record entry are dialed.
+	DialUnmarkedPeers() bool
+	// PublishRetryParams returns how blocks that failed to publish are retried.
+	PublishRetryParams() PublishRetryConfig
 }
 
 // ScoringParams defines the various types of peer scoring parameters.
 type ScoringParams struct {
 	PeerScoring        pubsub.PeerScoreParams
 	ApplicationScoring ApplicationScoreParams
+	// Thresholds of the gossip score bands. The NewPeerScoreThresholds defaults are used if nil.
+	Thresholds *pubsub.PeerScoreThresholds
 }
 
 // Config sets up a p2p host and discv5 service from configuration.
@@ -86,6 +97,10 @@ type Config struct {
 	Bootnodes        []*enode.Node
 	DiscoveryDB      *enode.DB
 	NetRestrict      *netutil.Netlist
+	// DiscoveryDialUnmarked enables dialing discovered peers without opstack node record entry.
+	DiscoveryDialUnmarked bool
+	// DNSDiscoveryURLs are the enrtree:// URLs of EIP-1459 DNS node lists to discover peers from, next to discv5.
+	DNSDiscoveryURLs []string
 
 	StaticPeers []core.Multiaddr
 
@@ -102,9 +117,17 @@ type Config struct {
 	MeshDHi   int // topic stable mesh high watermark
 	MeshDLazy int // gossip target
 
+	// GossipHeartbeat is the interval of the gossipsub heartbeat, which maintains the mesh and emits gossip.
+	// If 0, the default heartbeat interval is used.
+	GossipHeartbeat time.Duration
+
 	// FloodPublish publishes messages from ourselves to peers outside of the gossip topic mesh but supporting the same topic.
+	// This includes the blocks published by the sequencer. Peers with a score below the publish threshold are excluded.
 	FloodPublish bool
 
+	// PublishRetry configures the retrying of blocks that failed to publish, e.g. because there are no peers yet.
+	PublishRetry PublishRetryConfig
+
 	// If true a NAT manager will host a NAT port mapping that is updated with PMP and UPNP by libp2p/go-nat
 	NAT bool
 
@@ -160,7 +183,18 @@ func (conf *Config) ReqRespSyncEnabled() bool {
 	return conf.EnableReqRespSync
 }
 
-const maxMeshParam = 1000
+func (conf *Config) DialUnmarkedPeers() bool {
+	return conf.DiscoveryDialUnmarked
+}
+
+func (conf *Config) PublishRetryParams() PublishRetryConfig {
+	return conf.PublishRetry
+}
+
+const (
+	maxMeshParam       = 1000
+	maxGossipHeartbeat = 10 * time.Second
+)
 
 func (conf *Config) Check() error {
 	if conf.DisableP2P {
@@ -173,6 +207,13 @@ func (conf *Config) Check() error {
 		if conf.DiscoveryDB == nil {
 			return errors.New("discovery requires a persistent or in-memory discv5 db, but found none")
 		}
+	} else if len(conf.DNSDiscoveryURLs) > 0 {
+		return errors.New("DNS discovery requires discovery to be enabled")
+	}
+	for _, url := range conf.DNSDiscoveryURLs {
+		if _, _, err := dnsdisc.ParseURL(url); err != nil {
+			return fmt.Errorf("invalid DNS discovery URL %q: %w", url, err)
+		}
 	}
 	if conf.PeersLo == 0 || conf.PeersHi == 0 || conf.PeersLo > conf.PeersHi {
 		return fmt.Errorf("peers lo/hi tides are invalid: %d, %d", conf.PeersLo, conf.PeersHi)
@@ -189,5 +230,14 @@ func (conf *Config) Check() error {
 	if conf.MeshDLazy <= 0 || conf.MeshDLazy > maxMeshParam {
 		return fmt.Errorf("mesh Dlazy param must not be 0 or exceed %d, but got %d", maxMeshParam, conf.MeshDLazy)
 	}
+	if conf.MeshDLo > conf.MeshD || conf.MeshD > conf.MeshDHi {
+		return fmt.Errorf("mesh params must satisfy Dlo <= D <= Dhi, but got Dlo %d, D %d, Dhi %d", conf.MeshDLo, conf.MeshD, conf.MeshDHi)
+	}
+	if conf.GossipHeartbeat < 0 || conf.GossipHeartbeat > maxGossipHeartbeat {
+		return fmt.Errorf("gossip heartbeat must not be negative or exceed %s, but got %s", maxGossipHeartbeat, conf.GossipHeartbeat)
+	}
+	if err := conf.PublishRetry.Check(); err != nil {
+		return err
+	}
 	return nil
 }
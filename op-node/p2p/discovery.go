@@ -7,15 +7,18 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 	"math/rand"
 	"net"
 	"time"
 
 	decredSecp "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/metrics"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
+	"golang.org/x/exp/slices"
 
 	gcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
@@ -29,6 +32,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 )
 
 // force to use the new chainhash module, and not the legacy chainhash package btcd module
@@ -51,7 +55,7 @@ const (
 	collectiveDialTimeout  = time.Second * 30
 )
 
-func (conf *Config) Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort uint16) (*enode.LocalNode, *discover.UDPv5, error) {
+func (conf *Config) Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort uint16, reporter metrics.Reporter) (*enode.LocalNode, *discover.UDPv5, error) {
 	if conf.NoDiscovery {
 		return nil, nil, nil
 	}
@@ -81,6 +85,7 @@ func (conf *Config) Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort
 		version: 0,
 	}
 	localNode.Set(&dat)
+	localNode.Set(&OpStackForkENRData{digest: ForkDigest(rollupCfg)})
 
 	udpAddr := &net.UDPAddr{
 		IP:   conf.ListenIP,
@@ -104,19 +109,29 @@ func (conf *Config) Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort
 		Log:          log,
 		ValidSchemes: enode.ValidSchemes,
 	}
-	udpV5, err := discover.ListenV5(conn, localNode, cfg)
+	var udpConn discover.UDPConn = conn
+	if reporter != nil {
+		udpConn = &meteredUDPConn{UDPConn: conn, reporter: reporter}
+	}
+	udpV5, err := discover.ListenV5(udpConn, localNode, cfg)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	log.Info("started discovery service", "enr", localNode.Node(), "id", localNode.ID())
 
-	// TODO: periodically we can pull the external IP and TCP port from libp2p NAT service,
-	// and add it as a statement to keep the localNode accurate (if we trust the NAT device more than the discv5 statements)
+	// The external IP and TCP port, as mapped by the NAT device or overridden, are kept up to date by the NodeP2P.
 
 	return localNode, udpV5, nil
 }
 
+func (conf *Config) DNSDiscovery(log log.Logger) (*DNSDiscovery, error) {
+	if conf.NoDiscovery || len(conf.DNSDiscoveryURLs) == 0 {
+		return nil, nil
+	}
+	return NewDNSDiscovery(log, nil, conf.DNSDiscoveryURLs, conf.Store, clock.SystemClock)
+}
+
 // Secp256k1 is like the geth Secp256k1 enr entry type, but using the libp2p pubkey representation instead
 type Secp256k1 crypto.Secp256k1PublicKey
 
@@ -211,27 +226,106 @@ func (o *OpStackENRData) DecodeRLP(s *rlp.Stream) error {
 
 var _ enr.Entry = (*OpStackENRData)(nil)
 
-func FilterEnodes(log log.Logger, cfg *rollup.Config) func(node *enode.Node) bool {
-	return func(node *enode.Node) bool {
-		var dat OpStackENRData
-		err := node.Load(&dat)
-		// if the entry does not exist, or if it is invalid, then ignore the node
-		if err != nil {
-			log.Trace("discovered node record has no opstack info", "node", node.ID(), "err", err)
-			return false
-		}
-		// check chain ID matches
-		if cfg.L2ChainID.Uint64() != dat.chainID {
-			log.Trace("discovered node record has no matching chain ID", "node", node.ID(), "got", dat.chainID, "expected", cfg.L2ChainID.Uint64())
-			return false
+// OpStackForkENRData is the "opfork" ENR entry: the fork digest of the rollup config the node runs with.
+// It is optional, to stay compatible with nodes that do not advertise it yet.
+type OpStackForkENRData struct {
+	digest [4]byte
+}
+
+func (o *OpStackForkENRData) ENRKey() string {
+	return "opfork"
+}
+
+func (o *OpStackForkENRData) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, o.digest[:])
+}
+
+func (o *OpStackForkENRData) DecodeRLP(s *rlp.Stream) error {
+	b, err := s.Bytes()
+	if err != nil {
+		return fmt.Errorf("failed to decode outer ENR entry: %w", err)
+	}
+	if len(b) != len(o.digest) {
+		return fmt.Errorf("invalid fork digest length: %d", len(b))
+	}
+	copy(o.digest[:], b)
+	return nil
+}
+
+var _ enr.Entry = (*OpStackForkENRData)(nil)
+
+// ForkDigest commits to the chain ID, the L2 genesis block, and the activation times of the network upgrades
+// of the rollup config. Nodes with a different digest follow a different chain, or will after the next upgrade.
+func ForkDigest(cfg *rollup.Config) [4]byte {
+	data := binary.BigEndian.AppendUint64(nil, cfg.L2ChainID.Uint64())
+	data = append(data, cfg.Genesis.L2.Hash[:]...)
+	for _, t := range []*uint64{cfg.RegolithTime, cfg.CanyonTime, cfg.DeltaTime, cfg.EclipseTime, cfg.FjordTime, cfg.InteropTime} {
+		activation := uint64(math.MaxUint64) // not scheduled
+		if t != nil {
+			activation = *t
 		}
-		// check version matches
-		if dat.version != 0 {
-			log.Trace("discovered node record has no matching version", "node", node.ID(), "got", dat.version, "expected", 0)
-			return false
+		data = binary.BigEndian.AppendUint64(data, activation)
+	}
+	var out [4]byte
+	copy(out[:], gcrypto.Keccak256(data))
+	return out
+}
+
+// Results of classifying a discovered node record with ClassifyEnode. These are used as metric labels.
+const (
+	// DiscoveredMatching nodes are on the same network as us.
+	DiscoveredMatching = "matching"
+	// DiscoveredUnmarked nodes do not have an "opstack" entry, these are only dialed if configured to.
+	DiscoveredUnmarked = "unmarked"
+	// DiscoveredFiltered nodes are on a different chain, or run an incompatible version or rollup config.
+	DiscoveredFiltered = "filtered"
+)
+
+// ClassifyEnode checks if a discovered node record is on the same network as the given rollup config.
+func ClassifyEnode(log log.Logger, cfg *rollup.Config, node *enode.Node) string {
+	var dat OpStackENRData
+	// if the entry does not exist, or if it is invalid, then the node is unmarked
+	if err := node.Load(&dat); err != nil {
+		log.Trace("discovered node record has no opstack info", "node", node.ID(), "err", err)
+		return DiscoveredUnmarked
+	}
+	// check chain ID matches
+	if cfg.L2ChainID.Uint64() != dat.chainID {
+		log.Trace("discovered node record has no matching chain ID", "node", node.ID(), "got", dat.chainID, "expected", cfg.L2ChainID.Uint64())
+		return DiscoveredFiltered
+	}
+	// check version matches
+	if dat.version != 0 {
+		log.Trace("discovered node record has no matching version", "node", node.ID(), "got", dat.version, "expected", 0)
+		return DiscoveredFiltered
+	}
+	// check fork digest matches, if the node advertises one
+	var fork OpStackForkENRData
+	if err := node.Load(&fork); err == nil {
+		if expected := ForkDigest(cfg); fork.digest != expected {
+			log.Trace("discovered node record has no matching fork digest", "node", node.ID(), "got", fork.digest, "expected", expected)
+			return DiscoveredFiltered
 		}
-		return true
+	} else if !enr.IsNotFound(err) {
+		log.Trace("discovered node record has invalid fork digest", "node", node.ID(), "err", err)
+		return DiscoveredFiltered
 	}
+	return DiscoveredMatching
+}
+
+// FilterEnodes returns a filter that only accepts node records of the same network as the given rollup config.
+func FilterEnodes(log log.Logger, cfg *rollup.Config) func(node *enode.Node) bool {
+	return func(node *enode.Node) bool {
+		return ClassifyEnode(log, cfg, node) == DiscoveredMatching
+	}
+}
+
+// dialRank orders peers to dial: peers we know to be on our chain go first, before unmarked peers.
+func (n *NodeP2P) dialRank(id peer.ID, chainID uint64) int {
+	if md, err := n.store.GetPeerMetadata(id); err == nil && md.OPStackID == chainID {
+		return 0
+	}
+	return 1
 }
 
 // DiscoveryProcess runs a discovery process that randomly walks the DHT to fill the peerstore,
@@ -243,12 +337,36 @@ func (n *NodeP2P) DiscoveryProcess(ctx context.Context, log log.Logger, cfg *rol
 		log.Warn("peer discovery is disabled")
 		return
 	}
-	filter := FilterEnodes(log, cfg)
+	filter := func(node *enode.Node) bool {
+		class := ClassifyEnode(log, cfg, node)
+		if n.metrics != nil {
+			n.metrics.RecordDiscoveredNode(class)
+		}
+		return class == DiscoveredMatching || (class == DiscoveredUnmarked && n.dialUnmarked)
+	}
 	// We pull nodes from discv5 DHT in random order to find new peers.
 	// Eventually we'll find a peer record that matches our filter.
-	randomNodeIter := n.dv5Udp.RandomNodes()
-
-	randomNodeIter = enode.Filter(randomNodeIter, filter)
+	var randomNodeIter enode.Iterator = enode.Filter(n.dv5Udp.RandomNodes(), filter)
+
+	// The DNS node lists are mixed in with the DHT nodes, if any are configured.
+	// The records are filtered the same, but metered separately, and cached to use after a restart.
+	var dnsFilter func(node *enode.Node) bool
+	if n.dnsDisc != nil {
+		dnsFilter = func(node *enode.Node) bool {
+			class := ClassifyEnode(log, cfg, node)
+			if n.metrics != nil {
+				n.metrics.RecordDNSDiscoveredNode(class)
+			}
+			if class == DiscoveredMatching {
+				n.dnsDisc.Accept(ctx, node)
+			}
+			return class == DiscoveredMatching || (class == DiscoveredUnmarked && n.dialUnmarked)
+		}
+		mix := enode.NewFairMix(0)
+		mix.AddSource(randomNodeIter)
+		mix.AddSource(enode.Filter(n.dnsDisc.Nodes(), dnsFilter))
+		randomNodeIter = mix
+	}
 	defer randomNodeIter.Close()
 
 	// We pull from the DHT in a slow/fast interval, depending on the need to find more peers
@@ -339,6 +457,25 @@ func (n *NodeP2P) DiscoveryProcess(ctx context.Context, log log.Logger, cfg *rol
 				}
 			}
 		}
+		// The DNS node records of the previous run are used too, in case the DNS lists cannot be resolved.
+		if n.dnsDisc == nil {
+			return
+		}
+		cached, err := n.dnsDisc.CachedNodes(ctx)
+		if err != nil {
+			log.Warn("failed to load cached DNS discovered nodes", "err", err)
+			return
+		}
+		for _, rec := range cached {
+			if ClassifyEnode(log, cfg, rec) == DiscoveredMatching {
+				select {
+				case randomNodesCh <- rec:
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
 	}()
 
 	pstore := n.Host().Peerstore()
@@ -349,16 +486,15 @@ func (n *NodeP2P) DiscoveryProcess(ctx context.Context, log log.Logger, cfg *rol
 			return // no ctx error, expected close
 		case found := <-randomNodesCh:
 			var dat OpStackENRData
-			if err := found.Load(&dat); err != nil { // we already filtered on chain ID and version
-				continue
-			}
+			// we already filtered on chain ID and version, unmarked nodes only pass if we dial them
+			marked := found.Load(&dat) == nil
 			info, pub, err := enrToAddrInfo(found)
 			if err != nil {
 				continue
 			}
 
 			// record metadata to the peerstore if it is an extended peerstore
-			if eps, ok := pstore.(store.ExtendedPeerstore); ok {
+			if eps, ok := pstore.(store.ExtendedPeerstore); ok && marked {
 				_, err := eps.SetPeerMetadata(info.ID, store.PeerMetadata{
 					ENR:       found.String(),
 					OPStackID: dat.chainID,
@@ -375,7 +511,12 @@ func (n *NodeP2P) DiscoveryProcess(ctx context.Context, log log.Logger, cfg *rol
 			// Tag the peer, we'd rather have the connection manager prune away old peers,
 			// or peers on different chains, or anyone we have not seen via discovery.
 			// There is no tag score decay yet, so just set it to 42.
-			n.ConnectionManager().TagPeer(info.ID, fmt.Sprintf("opstack-%d-%d", dat.chainID, dat.version), 42)
+			// Unmarked peers may not be on our chain at all, these get a low score to be pruned first.
+			if marked {
+				n.ConnectionManager().TagPeer(info.ID, fmt.Sprintf("opstack-%d-%d", dat.chainID, dat.version), 42)
+			} else {
+				n.ConnectionManager().TagPeer(info.ID, "opstack-unmarked", 1)
+			}
 			log.Debug("discovered peer", "peer", info.ID, "nodeID", found.ID(), "addr", info.Addrs[0])
 		case <-connectTicker.C:
 			connected := n.Host().Network().Peers()
@@ -391,6 +532,11 @@ func (n *NodeP2P) DiscoveryProcess(ctx context.Context, log log.Logger, cfg *rol
 				if err := shufflePeers(peersWithAddrs); err != nil {
 					continue
 				}
+				// Dial the peers we know to be on our chain first
+				chainID := cfg.L2ChainID.Uint64()
+				slices.SortStableFunc(peersWithAddrs, func(a, b peer.ID) int {
+					return n.dialRank(a, chainID) - n.dialRank(b, chainID)
+				})
 
 				existing := make(map[peer.ID]struct{})
 				for _, p := range connected {
@@ -0,0 +1,96 @@
+package p2p
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	gcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func testNodeRecord(t *testing.T, entries ...enr.Entry) *enode.Node {
+	priv, err := gcrypto.GenerateKey()
+	require.NoError(t, err)
+	db, err := enode.OpenDB("")
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+	localNode := enode.NewLocalNode(db, priv)
+	for _, e := range entries {
+		localNode.Set(e)
+	}
+	return localNode.Node()
+}
+
+func TestForkDigest(t *testing.T) {
+	canyon := uint64(1000)
+	cfg := &rollup.Config{L2ChainID: big.NewInt(10), CanyonTime: &canyon}
+	cfg.Genesis.L2.Hash = common.Hash{0xaa}
+	digest := ForkDigest(cfg)
+
+	same := *cfg
+	require.Equal(t, digest, ForkDigest(&same))
+
+	otherChain := *cfg
+	otherChain.L2ChainID = big.NewInt(420)
+	require.NotEqual(t, digest, ForkDigest(&otherChain))
+
+	otherGenesis := *cfg
+	otherGenesis.Genesis.L2.Hash = common.Hash{0xbb}
+	require.NotEqual(t, digest, ForkDigest(&otherGenesis))
+
+	otherCanyon := canyon + 1
+	otherFork := *cfg
+	otherFork.CanyonTime = &otherCanyon
+	require.NotEqual(t, digest, ForkDigest(&otherFork))
+
+	unscheduled := *cfg
+	unscheduled.CanyonTime = nil
+	require.NotEqual(t, digest, ForkDigest(&unscheduled))
+}
+
+func TestClassifyEnode(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlError)
+	canyon := uint64(1000)
+	cfg := &rollup.Config{L2ChainID: big.NewInt(10), CanyonTime: &canyon}
+	otherCanyon := canyon + 1
+	otherFork := *cfg
+	otherFork.CanyonTime = &otherCanyon
+
+	ours := &OpStackENRData{chainID: 10, version: 0}
+	// a mixed discovery table, with nodes of our own network, other networks, and nodes that are not marked at all
+	table := []struct {
+		name     string
+		node     *enode.Node
+		expected string
+	}{
+		{"matching", testNodeRecord(t, ours, &OpStackForkENRData{digest: ForkDigest(cfg)}), DiscoveredMatching},
+		{"matching without fork digest", testNodeRecord(t, ours), DiscoveredMatching},
+		{"other chain", testNodeRecord(t, &OpStackENRData{chainID: 420, version: 0}), DiscoveredFiltered},
+		{"other version", testNodeRecord(t, &OpStackENRData{chainID: 10, version: 1}), DiscoveredFiltered},
+		{"other fork", testNodeRecord(t, ours, &OpStackForkENRData{digest: ForkDigest(&otherFork)}), DiscoveredFiltered},
+		{"unmarked", testNodeRecord(t), DiscoveredUnmarked},
+	}
+	filter := FilterEnodes(logger, cfg)
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, ClassifyEnode(logger, cfg, tc.node))
+			require.Equal(t, tc.expected == DiscoveredMatching, filter(tc.node))
+		})
+	}
+}
+
+func TestOpStackForkENRDataRoundTrip(t *testing.T) {
+	in := &OpStackForkENRData{digest: [4]byte{1, 2, 3, 4}}
+	node := testNodeRecord(t, in)
+	var out OpStackForkENRData
+	require.NoError(t, node.Load(&out))
+	require.Equal(t, in.digest, out.digest)
+}
@@ -0,0 +1,160 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/dnsdisc"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+const (
+	// dnsCacheExpiry is the duration after which a cached DNS node record is no longer used, unless resolved again.
+	dnsCacheExpiry = 7 * 24 * time.Hour
+	// dnsCacheRefresh is the minimum duration between updates of the last-seen time of an unchanged cached record.
+	dnsCacheRefresh = time.Hour
+)
+
+var dnsCacheBase = ds.NewKey("/dnsdisc/nodes")
+
+// DNSDiscovery resolves EIP-1459 DNS node lists, and caches the resolved node records,
+// so known nodes can still be dialed after a restart, even if the DNS lists cannot be resolved at that time.
+type DNSDiscovery struct {
+	log   log.Logger
+	iter  enode.Iterator
+	cache *dnsNodeCache
+}
+
+// NewDNSDiscovery creates a DNS discovery service for the given enrtree:// URLs.
+// The system DNS resolver is used if the resolver is nil.
+func NewDNSDiscovery(log log.Logger, resolver dnsdisc.Resolver, urls []string, store ds.Batching, clock clock.Clock) (*DNSDiscovery, error) {
+	client := dnsdisc.NewClient(dnsdisc.Config{
+		Resolver: resolver,
+		Logger:   log,
+	})
+	// The iterator keeps syncing the trees in the background, and retries failed DNS lookups.
+	iter, err := client.NewIterator(urls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DNS discovery iterator: %w", err)
+	}
+	return &DNSDiscovery{
+		log:   log,
+		iter:  iter,
+		cache: &dnsNodeCache{log: log, store: store, clock: clock, seen: make(map[enode.ID]dnsCacheEntry)},
+	}, nil
+}
+
+// Nodes returns an iterator of the resolved node records. The iterator is closed when the DNS discovery is closed.
+func (d *DNSDiscovery) Nodes() enode.Iterator {
+	return d.iter
+}
+
+// CachedNodes returns the node records that were resolved before and did not expire.
+func (d *DNSDiscovery) CachedNodes(ctx context.Context) ([]*enode.Node, error) {
+	return d.cache.load(ctx)
+}
+
+// Accept caches the node record, to use in a later run.
+func (d *DNSDiscovery) Accept(ctx context.Context, node *enode.Node) {
+	if err := d.cache.put(ctx, node); err != nil {
+		d.log.Warn("failed to cache DNS discovered node", "node", node.ID(), "err", err)
+	}
+}
+
+func (d *DNSDiscovery) Close() error {
+	d.iter.Close()
+	return nil
+}
+
+type dnsCacheRecord struct {
+	ENR      string `json:"enr"`
+	LastSeen int64  `json:"lastSeen"`
+}
+
+type dnsCacheEntry struct {
+	seq      uint64
+	lastSeen time.Time
+}
+
+// dnsNodeCache persists node records in the datastore.
+type dnsNodeCache struct {
+	log   log.Logger
+	store ds.Batching
+	clock clock.Clock
+
+	// mu guards seen, the cached records, to not rewrite unchanged records every time the tree is iterated
+	mu   sync.Mutex
+	seen map[enode.ID]dnsCacheEntry
+}
+
+func dnsCacheKey(id enode.ID) ds.Key {
+	return dnsCacheBase.ChildString(id.String())
+}
+
+func (c *dnsNodeCache) put(ctx context.Context, node *enode.Node) error {
+	now := c.clock.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if prev, ok := c.seen[node.ID()]; ok && prev.seq == node.Seq() && now.Sub(prev.lastSeen) < dnsCacheRefresh {
+		return nil
+	}
+	data, err := json.Marshal(&dnsCacheRecord{ENR: node.String(), LastSeen: now.Unix()})
+	if err != nil {
+		return err
+	}
+	if err := c.store.Put(ctx, dnsCacheKey(node.ID()), data); err != nil {
+		return err
+	}
+	c.seen[node.ID()] = dnsCacheEntry{seq: node.Seq(), lastSeen: now}
+	return nil
+}
+
+// load returns the cached node records, and removes the expired records from the cache.
+func (c *dnsNodeCache) load(ctx context.Context) ([]*enode.Node, error) {
+	results, err := c.store.Query(ctx, query.Query{Prefix: dnsCacheBase.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query DNS node cache: %w", err)
+	}
+	defer results.Close()
+
+	now := c.clock.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var nodes []*enode.Node
+	var expired []ds.Key
+	for res := range results.Next() {
+		if res.Error != nil {
+			return nil, fmt.Errorf("failed to read DNS node cache: %w", res.Error)
+		}
+		var rec dnsCacheRecord
+		var node *enode.Node
+		if err := json.Unmarshal(res.Value, &rec); err == nil {
+			node, err = enode.Parse(enode.ValidSchemes, rec.ENR)
+			if err != nil {
+				node = nil
+			}
+		}
+		lastSeen := time.Unix(rec.LastSeen, 0)
+		if node == nil || now.Sub(lastSeen) > dnsCacheExpiry {
+			expired = append(expired, ds.NewKey(res.Key))
+			continue
+		}
+		nodes = append(nodes, node)
+		c.seen[node.ID()] = dnsCacheEntry{seq: node.Seq(), lastSeen: lastSeen}
+	}
+	for _, key := range expired {
+		if err := c.store.Delete(ctx, key); err != nil {
+			c.log.Warn("failed to delete expired DNS node cache entry", "key", key, "err", err)
+		}
+	}
+	return nodes, nil
+}
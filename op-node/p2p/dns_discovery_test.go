@@ -0,0 +1,150 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/dnsdisc"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// mapResolver is an in-memory DNS resolver, serving the TXT records of a DNS node list.
+type mapResolver map[string]string
+
+func (m mapResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if txt, ok := m[name]; ok {
+		return []string{txt}, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+// outageResolver fails every DNS lookup.
+type outageResolver struct{}
+
+func (outageResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	return nil, errors.New("DNS outage")
+}
+
+func testDNSNode(t *testing.T, chainID uint64) *enode.Node {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	var r enr.Record
+	r.Set(enr.IPv4(net.IP{127, 0, 0, 1}))
+	r.Set(enr.TCP(9222))
+	r.Set(&OpStackENRData{chainID: chainID, version: 0})
+	require.NoError(t, enode.SignV4(&r, key))
+	node, err := enode.New(enode.ValidSchemes, &r)
+	require.NoError(t, err)
+	return node
+}
+
+func TestDNSDiscovery(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlError)
+	cfg := &rollup.Config{L2ChainID: big.NewInt(901)}
+	nodes := []*enode.Node{testDNSNode(t, 901), testDNSNode(t, 901), testDNSNode(t, 902)}
+
+	tree, err := dnsdisc.MakeTree(1, nodes, nil)
+	require.NoError(t, err)
+	treeKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	const domain = "nodes.example.org"
+	url, err := tree.Sign(treeKey, domain)
+	require.NoError(t, err)
+
+	store := sync.MutexWrap(ds.NewMapDatastore())
+	clk := clock.NewDeterministicClock(time.Now())
+	ctx := context.Background()
+
+	disc, err := NewDNSDiscovery(logger, mapResolver(tree.ToTXT(domain)), []string{url}, store, clk)
+	require.NoError(t, err)
+	cached, err := disc.CachedNodes(ctx)
+	require.NoError(t, err)
+	require.Empty(t, cached, "nothing cached yet")
+
+	// The iterator visits the nodes of the tree in random order, repeating nodes: iterate until we saw all.
+	seen := make(map[enode.ID]string)
+	iter := disc.Nodes()
+	for len(seen) < len(nodes) {
+		require.True(t, iter.Next())
+		node := iter.Node()
+		class := ClassifyEnode(logger, cfg, node)
+		seen[node.ID()] = class
+		if class == DiscoveredMatching {
+			disc.Accept(ctx, node)
+		}
+	}
+	require.Equal(t, DiscoveredMatching, seen[nodes[0].ID()])
+	require.Equal(t, DiscoveredMatching, seen[nodes[1].ID()])
+	require.Equal(t, DiscoveredFiltered, seen[nodes[2].ID()])
+	require.NoError(t, disc.Close())
+
+	// After a restart, the accepted nodes are available from the cache, even if DNS cannot be resolved.
+	disc, err = NewDNSDiscovery(logger, outageResolver{}, []string{url}, store, clk)
+	require.NoError(t, err)
+	defer disc.Close()
+	cached, err = disc.CachedNodes(ctx)
+	require.NoError(t, err)
+	require.Len(t, cached, 2)
+	require.ElementsMatch(t, []enode.ID{nodes[0].ID(), nodes[1].ID()}, []enode.ID{cached[0].ID(), cached[1].ID()})
+
+	// Cached nodes expire if they are not resolved again.
+	clk.AdvanceTime(dnsCacheExpiry + time.Second)
+	cached, err = disc.CachedNodes(ctx)
+	require.NoError(t, err)
+	require.Empty(t, cached)
+	keys, err := store.Query(ctx, query.Query{Prefix: dnsCacheBase.String()})
+	require.NoError(t, err)
+	entries, err := keys.Rest()
+	require.NoError(t, err)
+	require.Empty(t, entries, "expired entries are removed")
+}
+
+func TestConfigCheckDNSDiscovery(t *testing.T) {
+	tree, err := dnsdisc.MakeTree(1, nil, nil)
+	require.NoError(t, err)
+	treeKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	url, err := tree.Sign(treeKey, "nodes.example.org")
+	require.NoError(t, err)
+
+	newConf := func() *Config {
+		return &Config{
+			Store:       sync.MutexWrap(ds.NewMapDatastore()),
+			DiscoveryDB: &enode.DB{},
+			PeersLo:     1,
+			PeersHi:     10,
+			MeshD:       DefaultMeshD,
+			MeshDLo:     DefaultMeshDlo,
+			MeshDHi:     DefaultMeshDhi,
+			MeshDLazy:   DefaultMeshDlazy,
+		}
+	}
+	conf := newConf()
+	conf.DNSDiscoveryURLs = []string{url}
+	require.NoError(t, conf.Check())
+
+	conf = newConf()
+	conf.DNSDiscoveryURLs = []string{"enrtree://invalid@nodes.example.org"}
+	require.ErrorContains(t, conf.Check(), "invalid DNS discovery URL")
+
+	conf = newConf()
+	conf.NoDiscovery = true
+	conf.DNSDiscoveryURLs = []string{url}
+	require.ErrorContains(t, conf.Check(), "requires discovery")
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
@@ -33,14 +34,14 @@ const (
 	maxOutboundQueue       = 256
 	maxValidateQueue       = 256
 	globalValidateThrottle = 512
-	gossipHeartbeat        = 500 * time.Millisecond
-	// seenMessagesTTL limits the duration that message IDs are remembered for gossip deduplication purposes
-	// 130 * gossipHeartbeat
-	seenMessagesTTL  = 130 * gossipHeartbeat
-	DefaultMeshD     = 8  // topic stable mesh target count
-	DefaultMeshDlo   = 6  // topic stable mesh low watermark
-	DefaultMeshDhi   = 12 // topic stable mesh high watermark
-	DefaultMeshDlazy = 6  // gossip target
+	DefaultGossipHeartbeat = 500 * time.Millisecond
+	// seenMessagesHeartbeats limits the number of heartbeats that message IDs are remembered for gossip
+	// deduplication purposes
+	seenMessagesHeartbeats = 130
+	DefaultMeshD           = 8  // topic stable mesh target count
+	DefaultMeshDlo         = 6  // topic stable mesh low watermark
+	DefaultMeshDhi         = 12 // topic stable mesh high watermark
+	DefaultMeshDlazy       = 6  // gossip target
 	// peerScoreInspectFrequency is the frequency at which peer scores are inspected
 	peerScoreInspectFrequency = 15 * time.Second
 )
@@ -58,12 +59,15 @@ type GossipSetupConfigurables interface {
 }
 
 type GossipRuntimeConfig interface {
-	P2PSequencerAddress() common.Address
+	P2PSequencerKeys() eth.SequencerKeys
 }
 
 //go:generate mockery --name GossipMetricer
 type GossipMetricer interface {
 	RecordGossipEvent(evType int32)
+	RecordGossipTopicBytes(topic string, direction string, size int)
+	RecordGossipPublishAttempt(retry bool)
+	RecordGossipPublishDrop(reason string)
 }
 
 func blocksTopicV1(cfg *rollup.Config) string {
@@ -74,10 +78,14 @@ func blocksTopicV2(cfg *rollup.Config) string {
 	return fmt.Sprintf("/optimism/%s/1/blocks", cfg.L2ChainID.String())
 }
 
+func blocksTopicV3(cfg *rollup.Config) string {
+	return fmt.Sprintf("/optimism/%s/2/blocks", cfg.L2ChainID.String())
+}
+
 // BuildSubscriptionFilter builds a simple subscription filter,
 // to help protect against peers spamming useless subscriptions.
 func BuildSubscriptionFilter(cfg *rollup.Config) pubsub.SubscriptionFilter {
-	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg), blocksTopicV2(cfg)) // add more topics here in the future, if any.
+	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg), blocksTopicV2(cfg), blocksTopicV3(cfg)) // add more topics here in the future, if any.
 }
 
 var msgBufPool = sync.Pool{New: func() any {
@@ -126,6 +134,20 @@ func BuildMsgIdFn(cfg *rollup.Config) pubsub.MsgIdFunction {
 }
 
 func (p *Config) ConfigureGossip(rollupCfg *rollup.Config) []pubsub.Option {
+	// in the future we may add more advanced options like scoring and PX / direct-mesh / episub
+	return append(gossipParamsOptions(p.GossipParams(rollupCfg)),
+		// Flood-publishing applies to messages published by this node itself,
+		// e.g. the blocks of the sequencer, not to messages that are relayed.
+		pubsub.WithFloodPublish(p.FloodPublish),
+	)
+}
+
+// GossipParams builds the gossipsub parameters, with the mesh and heartbeat settings of the config applied.
+//
+// Note that peer scoring interacts with the mesh: peers with a negative score are pruned from the mesh,
+// and peers below the gossip/publish thresholds receive no gossip or flood-published messages,
+// so the effective mesh may be smaller than D. A small Dlo is thus more sensitive to scoring.
+func (p *Config) GossipParams(rollupCfg *rollup.Config) pubsub.GossipSubParams {
 	params := BuildGlobalGossipParams(rollupCfg)
 
 	// override with CLI changes
@@ -133,28 +155,43 @@ func (p *Config) ConfigureGossip(rollupCfg *rollup.Config) []pubsub.Option {
 	params.Dlo = p.MeshDLo
 	params.Dhi = p.MeshDHi
 	params.Dlazy = p.MeshDLazy
+	if p.GossipHeartbeat != 0 {
+		params.HeartbeatInterval = p.GossipHeartbeat
+	}
 
-	// in the future we may add more advanced options like scoring and PX / direct-mesh / episub
-	return []pubsub.Option{
-		pubsub.WithGossipSubParams(params),
-		pubsub.WithFloodPublish(p.FloodPublish),
+	// The outbound and score-retained mesh quota must fit in a small mesh,
+	// gossipsub rejects Dout >= Dlo and Dout > D/2.
+	params.Dout = min(params.Dout, params.Dlo-1, params.D/2)
+	if params.Dout < 0 {
+		params.Dout = 0
 	}
+	params.Dscore = min(params.Dscore, params.D)
+	return params
 }
 
 func BuildGlobalGossipParams(cfg *rollup.Config) pubsub.GossipSubParams {
 	params := pubsub.DefaultGossipSubParams()
-	params.D = DefaultMeshD                    // topic stable mesh target count
-	params.Dlo = DefaultMeshDlo                // topic stable mesh low watermark
-	params.Dhi = DefaultMeshDhi                // topic stable mesh high watermark
-	params.Dlazy = DefaultMeshDlazy            // gossip target
-	params.HeartbeatInterval = gossipHeartbeat // interval of heartbeat
-	params.FanoutTTL = 24 * time.Second        // ttl for fanout maps for topics we are not subscribed to but have published to
-	params.HistoryLength = 12                  // number of windows to retain full messages in cache for IWANT responses
-	params.HistoryGossip = 3                   // number of windows to gossip about
+	params.D = DefaultMeshD                           // topic stable mesh target count
+	params.Dlo = DefaultMeshDlo                       // topic stable mesh low watermark
+	params.Dhi = DefaultMeshDhi                       // topic stable mesh high watermark
+	params.Dlazy = DefaultMeshDlazy                   // gossip target
+	params.HeartbeatInterval = DefaultGossipHeartbeat // interval of heartbeat
+	params.FanoutTTL = 24 * time.Second               // ttl for fanout maps for topics we are not subscribed to but have published to
+	params.HistoryLength = 12                         // number of windows to retain full messages in cache for IWANT responses
+	params.HistoryGossip = 3                          // number of windows to gossip about
 
 	return params
 }
 
+// gossipParamsOptions applies the gossipsub parameters, and remembers the seen message IDs
+// for seenMessagesHeartbeats heartbeats of the configured heartbeat interval.
+func gossipParamsOptions(params pubsub.GossipSubParams) []pubsub.Option {
+	return []pubsub.Option{
+		pubsub.WithGossipSubParams(params),
+		pubsub.WithSeenMessagesTTL(seenMessagesHeartbeats * params.HeartbeatInterval),
+	}
+}
+
 // NewGossipSub configures a new pubsub instance with the specified parameters.
 // PubSub uses a GossipSubRouter as it's router under the hood.
 func NewGossipSub(p2pCtx context.Context, h host.Host, cfg *rollup.Config, gossipConf GossipSetupConfigurables, scorer Scorer, m GossipMetricer, log log.Logger) (*pubsub.PubSub, error) {
@@ -171,11 +208,13 @@ func NewGossipSub(p2pCtx context.Context, h host.Host, cfg *rollup.Config, gossi
 		pubsub.WithValidateQueueSize(maxValidateQueue),
 		pubsub.WithPeerOutboundQueueSize(maxOutboundQueue),
 		pubsub.WithValidateThrottle(globalValidateThrottle),
-		pubsub.WithSeenMessagesTTL(seenMessagesTTL),
 		pubsub.WithPeerExchange(false),
 		pubsub.WithBlacklist(denyList),
 		pubsub.WithEventTracer(&gossipTracer{m: m}),
 	}
+	if m != nil {
+		gossipOpts = append(gossipOpts, pubsub.WithRawTracer(&gossipBandwidthTracer{m: m}))
+	}
 	gossipOpts = append(gossipOpts, ConfigurePeerScoring(gossipConf, scorer, log)...)
 	gossipOpts = append(gossipOpts, gossipConf.ConfigureGossip(cfg)...)
 	return pubsub.NewGossipSub(p2pCtx, h, gossipOpts...)
@@ -243,7 +282,7 @@ func (sb *seenBlocks) markSeen(h common.Hash) {
 	sb.blockHashes = append(sb.blockHashes, h)
 }
 
-func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, blockVersion eth.BlockVersion) pubsub.ValidatorEx {
+func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, blockVersion eth.BlockVersion, violations ViolationReporter) pubsub.ValidatorEx {
 
 	// Seen block hashes per block height
 	// uint64 -> *seenBlocks
@@ -281,17 +320,27 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 		signatureBytes, payloadBytes := data[:65], data[65:]
 
 		// [REJECT] if the signature by the sequencer is not valid
-		result := verifyBlockSignature(log, cfg, runCfg, id, signatureBytes, payloadBytes)
+		result := verifyBlockSignature(log, cfg, runCfg, id, signatureBytes, payloadBytes, violations)
 		if result != pubsub.ValidationAccept {
 			return result
 		}
 
 		// [REJECT] if the block encoding is not valid
-		var payload eth.ExecutionPayload
-		if err := payload.UnmarshalSSZ(blockVersion, uint32(len(payloadBytes)), bytes.NewReader(payloadBytes)); err != nil {
-			log.Warn("invalid payload", "err", err, "peer", id)
-			return pubsub.ValidationReject
+		var envelope eth.ExecutionPayloadEnvelope
+		if blockVersion == eth.BlockV3 {
+			if err := envelope.UnmarshalSSZ(uint32(len(payloadBytes)), bytes.NewReader(payloadBytes)); err != nil {
+				log.Warn("invalid envelope payload", "err", err, "peer", id)
+				return pubsub.ValidationReject
+			}
+		} else {
+			var payload eth.ExecutionPayload
+			if err := payload.UnmarshalSSZ(blockVersion, uint32(len(payloadBytes)), bytes.NewReader(payloadBytes)); err != nil {
+				log.Warn("invalid payload", "err", err, "peer", id)
+				return pubsub.ValidationReject
+			}
+			envelope.ExecutionPayload = &payload
 		}
+		payload := envelope.ExecutionPayload
 
 		// rounding down to seconds is fine here.
 		now := uint64(time.Now().Unix())
@@ -309,7 +358,7 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 		}
 
 		// [REJECT] if the `block_hash` in the `payload` is not valid
-		if actual, ok := payload.CheckBlockHash(); !ok {
+		if actual, ok := envelope.CheckBlockHash(); !ok {
 			log.Warn("payload has bad block hash", "bad_hash", payload.BlockHash.String(), "actual", actual.String())
 			return pubsub.ValidationReject
 		}
@@ -320,15 +369,27 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 			return pubsub.ValidationReject
 		}
 
-		// [REJECT] if a V2 Block does not have withdrawals
-		if blockVersion == eth.BlockV2 && payload.Withdrawals == nil {
-			log.Warn("payload is on v2 topic, but does not have withdrawals", "bad_hash", payload.BlockHash.String())
+		// [REJECT] if a >= V2 Block does not have withdrawals
+		if blockVersion >= eth.BlockV2 && payload.Withdrawals == nil {
+			log.Warn("payload is on v2+ topic, but does not have withdrawals", "bad_hash", payload.BlockHash.String())
+			return pubsub.ValidationReject
+		}
+
+		// [REJECT] if a >= V2 Block has non-empty withdrawals
+		if blockVersion >= eth.BlockV2 && len(*payload.Withdrawals) != 0 {
+			log.Warn("payload is on v2+ topic, but has non-empty withdrawals", "bad_hash", payload.BlockHash.String(), "withdrawal_count", len(*payload.Withdrawals))
 			return pubsub.ValidationReject
 		}
 
-		// [REJECT] if a V2 Block has non-empty withdrawals
-		if blockVersion == eth.BlockV2 && len(*payload.Withdrawals) != 0 {
-			log.Warn("payload is on v2 topic, but has non-empty withdrawals", "bad_hash", payload.BlockHash.String(), "withdrawal_count", len(*payload.Withdrawals))
+		// [REJECT] if a V3 Block has no parent beacon block root, or no blob gas fields
+		if blockVersion == eth.BlockV3 && (envelope.ParentBeaconBlockRoot == nil || payload.BlobGasUsed == nil || payload.ExcessBlobGas == nil) {
+			log.Warn("payload is on v3 topic, but is missing the parent beacon block root or blob gas fields", "bad_hash", payload.BlockHash.String())
+			return pubsub.ValidationReject
+		}
+
+		// [REJECT] if a V3 Block has blob gas, there are no blob transactions on L2
+		if blockVersion == eth.BlockV3 && *payload.BlobGasUsed != 0 {
+			log.Warn("payload is on v3 topic, but has non-zero blob gas used", "bad_hash", payload.BlockHash.String(), "blob_gas_used", *payload.BlobGasUsed)
 			return pubsub.ValidationReject
 		}
 
@@ -352,13 +413,17 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 		// but validator concurrency is limited anyway)
 		seen.markSeen(payload.BlockHash)
 
-		// remember the decoded payload for later usage in topic subscriber.
-		message.ValidatorData = &payload
+		// remember the decoded envelope for later usage in topic subscriber.
+		message.ValidatorData = &envelope
 		return pubsub.ValidationAccept
 	}
 }
 
-func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, id peer.ID, signatureBytes []byte, payloadBytes []byte) pubsub.ValidationResult {
+// verifyBlockSignature checks the block was signed by the sequencer.
+// A malformed signature is reported as a violation: peers must not relay messages that they could not validate.
+// A valid signature by an unexpected signer is only rejected, since honest peers may relay blocks
+// of the previous signer around a key rotation.
+func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, id peer.ID, signatureBytes []byte, payloadBytes []byte, violations ViolationReporter) pubsub.ValidationResult {
 	signingHash, err := BlockSigningHash(cfg, payloadBytes)
 	if err != nil {
 		log.Warn("failed to compute block signing hash", "err", err, "peer", id)
@@ -368,38 +433,41 @@ func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 	pub, err := crypto.SigToPub(signingHash[:], signatureBytes)
 	if err != nil {
 		log.Warn("invalid block signature", "err", err, "peer", id)
+		violations.ReportViolation(id, "invalid block signature")
 		return pubsub.ValidationReject
 	}
 	addr := crypto.PubkeyToAddress(*pub)
 
 	// In the future we may load & validate block metadata before checking the signature.
 	// And then check the signer based on the metadata, to support e.g. multiple p2p signers at the same time.
-	// For now we only have one signer at a time and thus check the address directly.
-	// This means we may drop old payloads upon key rotation,
-	// but this can be recovered from like any other missed unsafe payload.
-	if expected := runCfg.P2PSequencerAddress(); expected == (common.Address{}) {
+	// For now we check the address directly: a key rotation has an overlap window in which the previous key
+	// is accepted too, so in-flight blocks of the previous key are not dropped. After the window,
+	// blocks of the previous key can still be recovered from like any other missed unsafe payload.
+	keys := runCfg.P2PSequencerKeys()
+	if keys.Primary == (common.Address{}) {
 		log.Warn("no configured p2p sequencer address, ignoring gossiped block", "peer", id, "addr", addr)
 		return pubsub.ValidationIgnore
-	} else if addr != expected {
-		log.Warn("unexpected block author", "err", err, "peer", id, "addr", addr, "expected", expected)
+	} else if !keys.Accepts(addr, uint64(time.Now().Unix())) {
+		log.Warn("unexpected block author", "peer", id, "addr", addr, "expected", keys.Primary, "secondary", keys.Secondary, "rotation_time", keys.RotationTime)
 		return pubsub.ValidationReject
 	}
 	return pubsub.ValidationAccept
 }
 
 type GossipIn interface {
-	OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg *eth.ExecutionPayload) error
+	OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg *eth.ExecutionPayloadEnvelope) error
 }
 
 type GossipTopicInfo interface {
 	AllBlockTopicsPeers() []peer.ID
 	BlocksTopicV1Peers() []peer.ID
 	BlocksTopicV2Peers() []peer.ID
+	BlocksTopicV3Peers() []peer.ID
 }
 
 type GossipOut interface {
 	GossipTopicInfo
-	PublishL2Payload(ctx context.Context, msg *eth.ExecutionPayload, signer Signer) error
+	PublishL2Payload(ctx context.Context, msg *eth.ExecutionPayloadEnvelope, signer Signer) error
 	Close() error
 }
 
@@ -429,6 +497,10 @@ type publisher struct {
 
 	blocksV1 *blockTopic
 	blocksV2 *blockTopic
+	blocksV3 *blockTopic
+
+	// queue publishes the blocks in order, and retries the blocks that failed to publish
+	queue *publishQueue
 
 	runCfg GossipRuntimeConfig
 }
@@ -451,7 +523,7 @@ func combinePeers(allPeers ...[]peer.ID) []peer.ID {
 }
 
 func (p *publisher) AllBlockTopicsPeers() []peer.ID {
-	return combinePeers(p.BlocksTopicV1Peers(), p.BlocksTopicV2Peers())
+	return combinePeers(p.BlocksTopicV1Peers(), p.BlocksTopicV2Peers(), p.BlocksTopicV3Peers())
 }
 
 func (p *publisher) BlocksTopicV1Peers() []peer.ID {
@@ -462,7 +534,11 @@ func (p *publisher) BlocksTopicV2Peers() []peer.ID {
 	return p.blocksV2.topic.ListPeers()
 }
 
-func (p *publisher) PublishL2Payload(ctx context.Context, payload *eth.ExecutionPayload, signer Signer) error {
+func (p *publisher) BlocksTopicV3Peers() []peer.ID {
+	return p.blocksV3.topic.ListPeers()
+}
+
+func (p *publisher) PublishL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope, signer Signer) error {
 	res := msgBufPool.Get().(*[]byte)
 	buf := bytes.NewBuffer((*res)[:0])
 	defer func() {
@@ -470,8 +546,15 @@ func (p *publisher) PublishL2Payload(ctx context.Context, payload *eth.Execution
 		defer msgBufPool.Put(res)
 	}()
 
+	payload := envelope.ExecutionPayload
+	eclipse := p.cfg.IsEclipse(uint64(payload.Timestamp))
+
 	buf.Write(make([]byte, 65))
-	if _, err := payload.MarshalSSZ(buf); err != nil {
+	if eclipse {
+		if _, err := envelope.MarshalSSZ(buf); err != nil {
+			return fmt.Errorf("failed to encoded execution payload envelope to publish: %w", err)
+		}
+	} else if _, err := payload.MarshalSSZ(buf); err != nil {
 		return fmt.Errorf("failed to encoded execution payload to publish: %w", err)
 	}
 	data := buf.Bytes()
@@ -486,25 +569,33 @@ func (p *publisher) PublishL2Payload(ctx context.Context, payload *eth.Execution
 	// This also copies the data, freeing up the original buffer to go back into the pool
 	out := snappy.Encode(nil, data)
 
-	if p.cfg.IsCanyon(uint64(payload.Timestamp)) {
-		return p.blocksV2.topic.Publish(ctx, out)
-	} else {
-		return p.blocksV1.topic.Publish(ctx, out)
+	topic := p.blocksV1.topic
+	if eclipse {
+		topic = p.blocksV3.topic
+	} else if p.cfg.IsCanyon(uint64(payload.Timestamp)) {
+		topic = p.blocksV2.topic
 	}
+	return p.queue.publish(ctx, uint64(payload.BlockNumber), topic, out)
 }
 
 func (p *publisher) Close() error {
+	p.queue.Close()
 	p.p2pCancel()
 	e1 := p.blocksV1.Close()
 	e2 := p.blocksV2.Close()
-	return errors.Join(e1, e2)
+	e3 := p.blocksV3.Close()
+	return errors.Join(e1, e2, e3)
 }
 
-func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn) (GossipOut, error) {
+func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn, violations ViolationReporter, retryCfg PublishRetryConfig, m GossipMetricer) (GossipOut, error) {
+	if m == nil {
+		m = metrics.NoopMetrics
+	}
+
 	p2pCtx, p2pCancel := context.WithCancel(context.Background())
 
 	v1Logger := log.New("topic", "blocksV1")
-	blocksV1Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv1", v1Logger, BuildBlocksValidator(v1Logger, cfg, runCfg, eth.BlockV1)))
+	blocksV1Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv1", v1Logger, BuildBlocksValidator(v1Logger, cfg, runCfg, eth.BlockV1, violations)))
 	blocksV1, err := newBlockTopic(p2pCtx, blocksTopicV1(cfg), ps, v1Logger, gossipIn, blocksV1Validator)
 	if err != nil {
 		p2pCancel()
@@ -512,19 +603,29 @@ func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Con
 	}
 
 	v2Logger := log.New("topic", "blocksV2")
-	blocksV2Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv2", v2Logger, BuildBlocksValidator(v2Logger, cfg, runCfg, eth.BlockV2)))
+	blocksV2Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv2", v2Logger, BuildBlocksValidator(v2Logger, cfg, runCfg, eth.BlockV2, violations)))
 	blocksV2, err := newBlockTopic(p2pCtx, blocksTopicV2(cfg), ps, v2Logger, gossipIn, blocksV2Validator)
 	if err != nil {
 		p2pCancel()
 		return nil, fmt.Errorf("failed to setup blocks v2 p2p: %w", err)
 	}
 
+	v3Logger := log.New("topic", "blocksV3")
+	blocksV3Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv3", v3Logger, BuildBlocksValidator(v3Logger, cfg, runCfg, eth.BlockV3, violations)))
+	blocksV3, err := newBlockTopic(p2pCtx, blocksTopicV3(cfg), ps, v3Logger, gossipIn, blocksV3Validator)
+	if err != nil {
+		p2pCancel()
+		return nil, fmt.Errorf("failed to setup blocks v3 p2p: %w", err)
+	}
+
 	return &publisher{
 		log:       log,
 		cfg:       cfg,
 		p2pCancel: p2pCancel,
 		blocksV1:  blocksV1,
 		blocksV2:  blocksV2,
+		blocksV3:  blocksV3,
+		queue:     newPublishQueue(log.New("p2p", "publisher"), retryCfg, m),
 		runCfg:    runCfg,
 	}, nil
 }
@@ -570,13 +671,13 @@ func newBlockTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log l
 type TopicSubscriber func(ctx context.Context, sub *pubsub.Subscription)
 type MessageHandler func(ctx context.Context, from peer.ID, msg any) error
 
-func BlocksHandler(onBlock func(ctx context.Context, from peer.ID, msg *eth.ExecutionPayload) error) MessageHandler {
+func BlocksHandler(onBlock func(ctx context.Context, from peer.ID, msg *eth.ExecutionPayloadEnvelope) error) MessageHandler {
 	return func(ctx context.Context, from peer.ID, msg any) error {
-		payload, ok := msg.(*eth.ExecutionPayload)
+		envelope, ok := msg.(*eth.ExecutionPayloadEnvelope)
 		if !ok {
-			return fmt.Errorf("expected topic validator to parse and validate data into execution payload, but got %T", msg)
+			return fmt.Errorf("expected topic validator to parse and validate data into execution payload envelope, but got %T", msg)
 		}
-		return onBlock(ctx, from, payload)
+		return onBlock(ctx, from, envelope)
 	}
 }
 
@@ -3,8 +3,11 @@ package p2p
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
 	"fmt"
+	"io"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -55,6 +58,23 @@ func TestCombinePeers(t *testing.T) {
 	require.Equal(t, []peer.ID{"foo", "bar", "baz"}, res)
 }
 
+type violationRecorder struct {
+	mu         sync.Mutex
+	violations []peer.ID
+}
+
+func (v *violationRecorder) ReportViolation(id peer.ID, reason string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.violations = append(v.violations, id)
+}
+
+func (v *violationRecorder) reported() []peer.ID {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]peer.ID(nil), v.violations...)
+}
+
 func TestVerifyBlockSignature(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlCrit)
 	cfg := &rollup.Config{
@@ -70,7 +90,7 @@ func TestVerifyBlockSignature(t *testing.T) {
 		signer := &PreparedSigner{Signer: NewLocalSigner(secrets.SequencerP2P)}
 		sig, err := signer.Sign(context.Background(), SigningDomainBlocksV1, cfg.L2ChainID, msg)
 		require.NoError(t, err)
-		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig[:65], msg)
+		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig[:65], msg, NoopViolationReporter{})
 		require.Equal(t, pubsub.ValidationAccept, result)
 	})
 
@@ -79,15 +99,19 @@ func TestVerifyBlockSignature(t *testing.T) {
 		signer := &PreparedSigner{Signer: NewLocalSigner(secrets.SequencerP2P)}
 		sig, err := signer.Sign(context.Background(), SigningDomainBlocksV1, cfg.L2ChainID, msg)
 		require.NoError(t, err)
-		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig[:65], msg)
+		violations := &violationRecorder{}
+		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig[:65], msg, violations)
 		require.Equal(t, pubsub.ValidationReject, result)
+		require.Empty(t, violations.reported(), "an unexpected signer may be relayed around a key rotation")
 	})
 
 	t.Run("InvalidSignature", func(t *testing.T) {
 		runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: crypto.PubkeyToAddress(secrets.SequencerP2P.PublicKey)}
 		sig := make([]byte, 65)
-		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg)
+		violations := &violationRecorder{}
+		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig, msg, violations)
 		require.Equal(t, pubsub.ValidationReject, result)
+		require.Equal(t, []peer.ID{peerId}, violations.reported(), "invalid signatures are a violation")
 	})
 
 	t.Run("NoSequencer", func(t *testing.T) {
@@ -95,12 +119,52 @@ func TestVerifyBlockSignature(t *testing.T) {
 		signer := &PreparedSigner{Signer: NewLocalSigner(secrets.SequencerP2P)}
 		sig, err := signer.Sign(context.Background(), SigningDomainBlocksV1, cfg.L2ChainID, msg)
 		require.NoError(t, err)
-		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig[:65], msg)
+		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig[:65], msg, NoopViolationReporter{})
 		require.Equal(t, pubsub.ValidationIgnore, result)
 	})
 }
 
-func createSignedP2Payload(payload *eth.ExecutionPayload, signer Signer, l2ChainID *big.Int) ([]byte, error) {
+func TestVerifyBlockSignatureKeyRotation(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlCrit)
+	cfg := &rollup.Config{
+		L2ChainID: big.NewInt(100),
+	}
+	peerId := peer.ID("foo")
+	msg := []byte("any msg")
+	oldKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	newKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	oldAddr, newAddr := crypto.PubkeyToAddress(oldKey.PublicKey), crypto.PubkeyToAddress(newKey.PublicKey)
+	sign := func(key *ecdsa.PrivateKey) []byte {
+		signer := &PreparedSigner{Signer: NewLocalSigner(key)}
+		sig, err := signer.Sign(context.Background(), SigningDomainBlocksV1, cfg.L2ChainID, msg)
+		require.NoError(t, err)
+		return sig[:65]
+	}
+	now := uint64(time.Now().Unix())
+
+	table := []struct {
+		name      string
+		runCfg    *testutils.MockRuntimeConfig
+		oldResult pubsub.ValidationResult
+		newResult pubsub.ValidationResult
+	}{
+		{"before", &testutils.MockRuntimeConfig{P2PSeqAddress: oldAddr}, pubsub.ValidationAccept, pubsub.ValidationReject},
+		{"during", &testutils.MockRuntimeConfig{P2PSeqAddress: newAddr, P2PSeqSecondary: oldAddr, P2PSeqRotationTime: now + 60}, pubsub.ValidationAccept, pubsub.ValidationAccept},
+		{"after", &testutils.MockRuntimeConfig{P2PSeqAddress: newAddr, P2PSeqSecondary: oldAddr, P2PSeqRotationTime: now - 1}, pubsub.ValidationReject, pubsub.ValidationAccept},
+	}
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.oldResult, verifyBlockSignature(logger, cfg, tc.runCfg, peerId, sign(oldKey), msg, NoopViolationReporter{}), "old key")
+			require.Equal(t, tc.newResult, verifyBlockSignature(logger, cfg, tc.runCfg, peerId, sign(newKey), msg, NoopViolationReporter{}), "new key")
+		})
+	}
+}
+
+func createSignedP2Payload(payload interface {
+	MarshalSSZ(w io.Writer) (int, error)
+}, signer Signer, l2ChainID *big.Int) ([]byte, error) {
 	var buf bytes.Buffer
 	buf.Write(make([]byte, 65))
 	if _, err := payload.MarshalSSZ(&buf); err != nil {
@@ -131,7 +195,7 @@ func TestBlockValidator(t *testing.T) {
 	signer := &PreparedSigner{Signer: NewLocalSigner(secrets.SequencerP2P)}
 
 	// valFnV1 := BuildBlocksValidator(testlog.Logger(t, log.LvlCrit), rollupCfg, runCfg, eth.BlockV1)
-	valFnV2 := BuildBlocksValidator(testlog.Logger(t, log.LvlCrit), cfg, runCfg, eth.BlockV2)
+	valFnV2 := BuildBlocksValidator(testlog.Logger(t, log.LvlCrit), cfg, runCfg, eth.BlockV2, NoopViolationReporter{})
 
 	// Params Set 2: Call the validation function
 	peerID := peer.ID("foo")
@@ -160,4 +224,62 @@ func TestBlockValidator(t *testing.T) {
 	res = valFnV2(context.TODO(), peerID, message)
 	require.Equal(t, res, pubsub.ValidationReject)
 
+	valFnV3 := BuildBlocksValidator(testlog.Logger(t, log.LvlCrit), cfg, runCfg, eth.BlockV3, NoopViolationReporter{})
+
+	// Valid V3 case: the envelope includes the parent beacon block root, which is part of the block hash
+	zero := eth.Uint64Quantity(0)
+	envelope := eth.ExecutionPayloadEnvelope{
+		ParentBeaconBlockRoot: &common.Hash{0xbe, 0xac},
+		ExecutionPayload: &eth.ExecutionPayload{
+			Timestamp:     hexutil.Uint64(time.Now().Unix()),
+			Withdrawals:   &types.Withdrawals{},
+			BlobGasUsed:   &zero,
+			ExcessBlobGas: &zero,
+		},
+	}
+	envelope.ExecutionPayload.BlockHash, _ = envelope.CheckBlockHash()
+	data, err = createSignedP2Payload(&envelope, signer, cfg.L2ChainID)
+	require.NoError(t, err)
+	message = &pubsub.Message{Message: &pubsub_pb.Message{Data: data}}
+	res = valFnV3(context.TODO(), peerID, message)
+	require.Equal(t, res, pubsub.ValidationAccept)
+	validated := message.ValidatorData.(*eth.ExecutionPayloadEnvelope)
+	require.Equal(t, envelope.ParentBeaconBlockRoot, validated.ParentBeaconBlockRoot)
+	require.Equal(t, envelope.ExecutionPayload.BlockHash, validated.ExecutionPayload.BlockHash)
+
+	// Invalid because the block hash does not commit to the parent beacon block root of the envelope
+	envelope.ParentBeaconBlockRoot = &common.Hash{0xff}
+	data, err = createSignedP2Payload(&envelope, signer, cfg.L2ChainID)
+	require.NoError(t, err)
+	message = &pubsub.Message{Message: &pubsub_pb.Message{Data: data}}
+	res = valFnV3(context.TODO(), peerID, message)
+	require.Equal(t, res, pubsub.ValidationReject)
+
+	// Invalid because a V2 payload is not an envelope
+	data, err = createSignedP2Payload(&payload, signer, cfg.L2ChainID)
+	require.NoError(t, err)
+	message = &pubsub.Message{Message: &pubsub_pb.Message{Data: data}}
+	res = valFnV3(context.TODO(), peerID, message)
+	require.Equal(t, res, pubsub.ValidationReject)
+}
+
+func TestConfigCheckMesh(t *testing.T) {
+	valid := func() *Config {
+		conf := TestingConfig(t)
+		conf.MeshD, conf.MeshDLo, conf.MeshDHi, conf.MeshDLazy = 2, 1, 3, 1
+		return conf
+	}
+	require.NoError(t, valid().Check())
+
+	conf := valid()
+	conf.MeshDLo = 3
+	require.ErrorContains(t, conf.Check(), "Dlo <= D <= Dhi")
+
+	conf = valid()
+	conf.MeshDHi = 1
+	require.ErrorContains(t, conf.Check(), "Dlo <= D <= Dhi")
+
+	conf = valid()
+	conf.GossipHeartbeat = -time.Second
+	require.ErrorContains(t, conf.Check(), "heartbeat")
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/metrics"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
 	"github.com/libp2p/go-libp2p/core/sec/insecure"
 	basichost "github.com/libp2p/go-libp2p/p2p/host/basic"
 	"github.com/libp2p/go-libp2p/p2p/muxer/yamux"
@@ -32,12 +33,29 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p/gating"
 	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
 )
 
 const (
 	staticPeerTag = "static"
+	// interval to check the connectedness of static peers at
+	staticPeerCheckInterval = time.Second * 5
+	// timeout of a single round of static peer dials
+	staticPeerDialTimeout = time.Second * 30
 )
 
+// StaticPeerMetrics records the reconnect attempts and connectivity of static peers.
+type StaticPeerMetrics interface {
+	RecordStaticPeerDial(success bool)
+	SetStaticPeersConnected(n int)
+}
+
+// staticPeerBackoff is the backoff between dial attempts of a disconnected static peer:
+// starting at a second, doubling per failed attempt, up to 5 minutes.
+func staticPeerBackoff() retry.Strategy {
+	return &retry.ExponentialStrategy{Min: 0, Max: time.Minute * 5, MaxJitter: time.Second}
+}
+
 type ExtraHostFeatures interface {
 	host.Host
 	ConnectionGater() gating.BlockingConnectionGater
@@ -50,7 +68,10 @@ type extraHost struct {
 	connMgr connmgr.ConnManager
 	log     log.Logger
 
-	staticPeers []*peer.AddrInfo
+	staticPeers             []*peer.AddrInfo
+	staticPeerBackoff       retry.Strategy
+	staticPeerCheckInterval time.Duration
+	metrics                 StaticPeerMetrics
 
 	quitC chan struct{}
 }
@@ -69,19 +90,23 @@ func (e *extraHost) Close() error {
 }
 
 func (e *extraHost) initStaticPeers() {
+	eps, _ := e.Peerstore().(store.ExtendedPeerstore)
 	for _, addr := range e.staticPeers {
-		e.Peerstore().AddAddrs(addr.ID, addr.Addrs, time.Hour*24*7)
+		// Static peers are configured by the operator, their addresses are not subject to expiry.
+		e.Peerstore().AddAddrs(addr.ID, addr.Addrs, peerstore.PermanentAddrTTL)
 		// We protect the peer, so the connection manager doesn't decide to prune it.
 		// We tag it with "static" so other protects/unprotects with different tags don't affect this protection.
 		e.connMgr.Protect(addr.ID, staticPeerTag)
-		// Try to dial the node in the background
-		go func(addr *peer.AddrInfo) {
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
-			defer cancel()
-			if err := e.dialStaticPeer(ctx, addr); err != nil {
-				e.log.Warn("error dialing static peer", "peer", addr.ID, "err", err)
+		// Static peers are never banned: the peer monitor skips them,
+		// but a ban may have been persisted before the peer was configured as static.
+		if eps != nil {
+			if expiry, err := eps.GetPeerBanExpiration(addr.ID); err == nil {
+				e.log.Warn("clearing ban of static peer", "peer", addr.ID, "expiry", expiry)
+				if err := eps.SetPeerBanExpiration(addr.ID, time.Time{}); err != nil {
+					e.log.Error("failed to clear ban of static peer", "peer", addr.ID, "err", err)
+				}
 			}
-		}(addr)
+		}
 	}
 }
 
@@ -93,37 +118,75 @@ func (e *extraHost) dialStaticPeer(ctx context.Context, addr *peer.AddrInfo) err
 	return nil
 }
 
+// staticPeerDialState tracks the reconnect backoff of a single static peer.
+type staticPeerDialState struct {
+	// failed dial attempts since the peer was last connected
+	attempts int
+	// no new dial attempt is made before this time
+	next time.Time
+}
+
+// monitorStaticPeers dials the static peers, and keeps redialing them with backoff whenever they are disconnected.
 func (e *extraHost) monitorStaticPeers() {
-	tick := time.NewTicker(time.Minute)
+	tick := time.NewTicker(e.staticPeerCheckInterval)
 	defer tick.Stop()
 
-	for {
-		select {
-		case <-tick.C:
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
-			var wg sync.WaitGroup
+	states := make(map[peer.ID]*staticPeerDialState, len(e.staticPeers))
+	for _, addr := range e.staticPeers {
+		states[addr.ID] = new(staticPeerDialState)
+	}
 
-			e.log.Debug("polling static peers", "peers", len(e.staticPeers))
-			for _, addr := range e.staticPeers {
-				connectedness := e.Network().Connectedness(addr.ID)
-				e.log.Trace("static peer connectedness", "peer", addr.ID, "connectedness", connectedness)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), staticPeerDialTimeout)
+		var wg sync.WaitGroup
+
+		e.log.Debug("polling static peers", "peers", len(e.staticPeers))
+		now := time.Now()
+		for _, addr := range e.staticPeers {
+			connectedness := e.Network().Connectedness(addr.ID)
+			e.log.Trace("static peer connectedness", "peer", addr.ID, "connectedness", connectedness)
+
+			state := states[addr.ID]
+			if connectedness == network.Connected {
+				state.attempts = 0
+				continue
+			}
+			if now.Before(state.next) {
+				continue
+			}
 
-				if connectedness == network.Connected {
-					continue
+			wg.Add(1)
+			// Each routine only accesses the state of its own peer.
+			go func(addr *peer.AddrInfo, state *staticPeerDialState) {
+				defer wg.Done()
+				if state.attempts > 0 {
+					e.log.Warn("static peer disconnected, reconnecting", "peer", addr.ID, "attempts", state.attempts)
+				}
+				if err := e.dialStaticPeer(ctx, addr); err != nil {
+					e.log.Warn("error dialing static peer", "peer", addr.ID, "err", err)
+					e.metrics.RecordStaticPeerDial(false)
+					state.next = time.Now().Add(e.staticPeerBackoff.Duration(state.attempts))
+					state.attempts += 1
+				} else {
+					e.metrics.RecordStaticPeerDial(true)
+					state.attempts = 0
 				}
+			}(addr, state)
+		}
 
-				wg.Add(1)
-				go func(addr *peer.AddrInfo) {
-					e.log.Warn("static peer disconnected, reconnecting", "peer", addr.ID)
-					if err := e.dialStaticPeer(ctx, addr); err != nil {
-						e.log.Warn("error reconnecting to static peer", "peer", addr.ID, "err", err)
-					}
-					wg.Done()
-				}(addr)
+		wg.Wait()
+		cancel()
+
+		connected := 0
+		for _, addr := range e.staticPeers {
+			if e.Network().Connectedness(addr.ID) == network.Connected {
+				connected += 1
 			}
+		}
+		e.metrics.SetStaticPeersConnected(connected)
 
-			wg.Wait()
-			cancel()
+		select {
+		case <-tick.C:
 		case <-e.quitC:
 			return
 		}
@@ -217,6 +280,8 @@ func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics Host
 		// Help peers with their NAT reachability status, but throttle to avoid too much work.
 		libp2p.EnableNATService(),
 		libp2p.AutoNATServiceRateLimit(10, 5, time.Second*60),
+		// Advertise the external address to peers, if overridden.
+		libp2p.AddrsFactory(conf.advertisedAddrs),
 	}
 	opts = append(opts, conf.HostMux...)
 	if conf.NoTransportSecurity {
@@ -243,18 +308,20 @@ func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics Host
 	}
 
 	out := &extraHost{
-		Host:        h,
-		connMgr:     connMngr,
-		log:         log,
-		staticPeers: staticPeers,
-		quitC:       make(chan struct{}),
+		Host:                    h,
+		gater:                   connGtr,
+		connMgr:                 connMngr,
+		log:                     log,
+		staticPeers:             staticPeers,
+		staticPeerBackoff:       staticPeerBackoff(),
+		staticPeerCheckInterval: staticPeerCheckInterval,
+		metrics:                 metrics,
+		quitC:                   make(chan struct{}),
 	}
 	out.initStaticPeers()
-	if len(conf.StaticPeers) > 0 {
+	if len(staticPeers) > 0 {
 		go out.monitorStaticPeers()
 	}
-
-	out.gater = connGtr
 	return out, nil
 }
 
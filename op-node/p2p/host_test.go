@@ -5,13 +5,16 @@ import (
 	"crypto/rand"
 	"math/big"
 	"net"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/sync"
 	"github.com/libp2p/go-libp2p"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
@@ -25,9 +28,11 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/p2p/gating"
 	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 )
@@ -73,10 +78,10 @@ func TestP2PSimple(t *testing.T) {
 }
 
 type mockGossipIn struct {
-	OnUnsafeL2PayloadFn func(ctx context.Context, from peer.ID, msg *eth.ExecutionPayload) error
+	OnUnsafeL2PayloadFn func(ctx context.Context, from peer.ID, msg *eth.ExecutionPayloadEnvelope) error
 }
 
-func (m *mockGossipIn) OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg *eth.ExecutionPayload) error {
+func (m *mockGossipIn) OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg *eth.ExecutionPayloadEnvelope) error {
 	if m.OnUnsafeL2PayloadFn != nil {
 		return m.OnUnsafeL2PayloadFn(ctx, from, msg)
 	}
@@ -128,10 +133,13 @@ func TestP2PFull(t *testing.T) {
 	hostA := nodeA.Host()
 	hostA.Network().Notify(&network.NotifyBundle{
 		ConnectedF: func(n network.Network, conn network.Conn) {
-			conns <- conn
+			select {
+			case conns <- conn:
+			default: // only the first connection is checked, don't block on reconnects
+			}
 		}})
 
-	backend := NewP2PAPIBackend(nodeA, logA, nil)
+	backend := NewP2PAPIBackend(nodeA, logA, nil, true)
 	srv := rpc.NewServer()
 	require.NoError(t, srv.RegisterName("opp2p", backend))
 	client := rpc.DialInProc(srv)
@@ -180,6 +188,10 @@ func TestP2PFull(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []peer.ID{hostB.ID()}, blockedPeers)
 	require.NoError(t, p2pClientA.UnblockPeer(ctx, hostB.ID()))
+	// blocking disconnected the peer, connect to it again
+	addrsB, err := peer.AddrInfoToP2pAddrs(&peer.AddrInfo{ID: hostB.ID(), Addrs: hostB.Addrs()})
+	require.NoError(t, err)
+	require.NoError(t, p2pClientA.ConnectPeer(ctx, addrsB[0].String()))
 
 	require.NoError(t, p2pClientA.BlockAddr(ctx, net.IP{123, 123, 123, 123}))
 	blockedIPs, err := p2pClientA.ListBlockedAddrs(ctx)
@@ -201,11 +213,15 @@ func TestP2PFull(t *testing.T) {
 	require.Nil(t, err)
 	require.Contains(t, peerDump.Peers, hostB.ID().String())
 	data := peerDump.Peers[hostB.ID().String()]
-	require.Equal(t, data.Direction, network.DirInbound)
+	require.Equal(t, data.Direction, network.DirOutbound, "host A reconnected to B after unblocking it")
 
 	stats, err := p2pClientA.PeerStats(ctx)
 	require.Nil(t, err)
 	require.Equal(t, uint(1), stats.Connected)
+	require.Eventually(t, func() bool {
+		stats, err := p2pClientA.PeerStats(ctx)
+		return err == nil && stats.BandwidthIn > 0 && stats.BandwidthOut > 0
+	}, 10*time.Second, 50*time.Millisecond, "peer stats include the bandwidth totals")
 
 	// disconnect
 	require.NoError(t, p2pClientA.DisconnectPeer(ctx, hostB.ID()))
@@ -215,14 +231,122 @@ func TestP2PFull(t *testing.T) {
 	require.Equal(t, data.Connectedness, network.NotConnected)
 
 	// reconnect
-	addrsB, err := peer.AddrInfoToP2pAddrs(&peer.AddrInfo{ID: hostB.ID(), Addrs: hostB.Addrs()})
-	require.NoError(t, err)
 	require.NoError(t, p2pClientA.ConnectPeer(ctx, addrsB[0].String()))
 
 	require.NoError(t, p2pClientA.ProtectPeer(ctx, hostB.ID()))
 	require.NoError(t, p2pClientA.UnprotectPeer(ctx, hostB.ID()))
 }
 
+func TestPeerInfoRPC(t *testing.T) {
+	newConf := func() *Config {
+		priv, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+		require.NoError(t, err, "failed to generate new p2p priv key")
+		return &Config{
+			Priv:               (priv).(*crypto.Secp256k1PrivateKey),
+			NoDiscovery:        true,
+			ListenIP:           net.IP{127, 0, 0, 1},
+			HostMux:            []libp2p.Option{YamuxC()},
+			HostSecurity:       []libp2p.Option{NoiseC()},
+			PeersLo:            1,
+			PeersHi:            10,
+			PeersGrace:         time.Second * 10,
+			UserAgent:          "optimism-testing",
+			TimeoutNegotiation: time.Second * 2,
+			TimeoutAccept:      time.Second * 2,
+			TimeoutDial:        time.Second * 2,
+			Store:              sync.MutexWrap(ds.NewMapDatastore()),
+		}
+	}
+	newNode := func(name string, conf *Config) (*NodeP2P, *Client) {
+		logger := testlog.Logger(t, log.LvlError).New("host", name)
+		runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: common.Address{0x42}}
+		node, err := NewNodeP2P(context.Background(), &rollup.Config{}, logger, conf, &mockGossipIn{}, nil, runCfg, metrics.NoopMetrics, false)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = node.Close()
+		})
+		srv := rpc.NewServer()
+		require.NoError(t, srv.RegisterName("opp2p", NewP2PAPIBackend(node, logger, nil, false)))
+		t.Cleanup(srv.Stop)
+		return node, NewClient(rpc.DialInProc(srv))
+	}
+
+	confA := newConf()
+	nodeA, clientA := newNode("A", confA)
+	hostA := nodeA.Host()
+	// B connects to A, as static peer
+	confB := newConf()
+	var err error
+	confB.StaticPeers, err = peer.AddrInfoToP2pAddrs(&peer.AddrInfo{ID: hostA.ID(), Addrs: hostA.Addrs()})
+	require.NoError(t, err)
+	nodeB, clientB := newNode("B", confB)
+	hostB := nodeB.Host()
+
+	ctx := context.Background()
+	// wait for the connection, and for the gossip subscriptions to be exchanged
+	infoOf := func(cl *Client, id peer.ID) *PeerInfo {
+		dump, err := cl.Peers(ctx, true)
+		require.NoError(t, err)
+		return dump.Peers[id.String()]
+	}
+	require.Eventually(t, func() bool {
+		a, b := infoOf(clientA, hostB.ID()), infoOf(clientB, hostA.ID())
+		return a != nil && b != nil && len(a.Topics) > 0 && len(b.Topics) > 0
+	}, 10*time.Second, 50*time.Millisecond, "nodes must connect and see each other's topics")
+
+	selfA, err := clientA.Self(ctx)
+	require.NoError(t, err)
+	selfB, err := clientB.Self(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, selfA.Topics)
+
+	for _, tc := range []struct {
+		name      string
+		cl        *Client
+		self      *PeerInfo
+		remote    *PeerInfo
+		direction network.Direction
+		protected bool
+	}{
+		{"A", clientA, selfA, selfB, network.DirInbound, false},
+		// A is a static peer of B, and protected from pruning by B
+		{"B", clientB, selfB, selfA, network.DirOutbound, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			info := infoOf(tc.cl, tc.remote.PeerID)
+			require.Equal(t, tc.remote.PeerID, info.PeerID)
+			require.Equal(t, tc.remote.NodeID, info.NodeID)
+			require.Equal(t, "optimism-testing", info.UserAgent)
+			require.Equal(t, network.Connected, info.Connectedness)
+			require.Equal(t, tc.direction, info.Direction)
+			require.Positive(t, info.ConnectedDuration)
+			require.NotEmpty(t, info.Addresses)
+			require.Contains(t, info.Protocols, string(pubsub.GossipSubID_v11))
+			// both nodes join the same topics, and are subscribed to all of them
+			require.Equal(t, tc.self.Topics, info.Topics)
+			require.True(t, info.GossipBlocks)
+			require.False(t, info.Banned)
+			require.Equal(t, tc.protected, info.Protected)
+
+			stats, err := tc.cl.PeerStats(ctx)
+			require.NoError(t, err)
+			require.Equal(t, uint(1), stats.Connected)
+			if tc.direction == network.DirInbound {
+				require.Equal(t, uint(1), stats.Inbound)
+				require.Zero(t, stats.Outbound)
+			} else {
+				require.Zero(t, stats.Inbound)
+				require.Equal(t, uint(1), stats.Outbound)
+			}
+			var scored uint
+			for _, b := range stats.Scores {
+				scored += b.Count
+			}
+			require.Equal(t, stats.Connected, scored, "all connected peers are in the score histogram")
+		})
+	}
+}
+
 func TestDiscovery(t *testing.T) {
 	pA, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
 	require.NoError(t, err, "failed to generate new p2p priv key")
@@ -364,3 +488,176 @@ func TestP2PMocknet(t *testing.T) {
 	require.Equal(t, hostA.Network().Connectedness(hostC.ID()), network.Connected)
 	require.Equal(t, hostB.Network().Connectedness(hostC.ID()), network.Connected)
 }
+
+type staticPeerMetrics struct {
+	failedDials     atomic.Int32
+	successfulDials atomic.Int32
+	connected       atomic.Int32
+}
+
+func (m *staticPeerMetrics) RecordStaticPeerDial(success bool) {
+	if success {
+		m.successfulDials.Add(1)
+	} else {
+		m.failedDials.Add(1)
+	}
+}
+
+func (m *staticPeerMetrics) SetStaticPeersConnected(n int) {
+	m.connected.Store(int32(n))
+}
+
+// TestStaticPeerReconnect checks that a static peer that is down at startup is connected to, once it comes up.
+func TestStaticPeerReconnect(t *testing.T) {
+	mnet := mocknet.New()
+	defer mnet.Close()
+	hostA, err := mnet.GenPeer()
+	require.NoError(t, err)
+	hostB, err := mnet.GenPeer()
+	require.NoError(t, err)
+
+	connMgr, err := DefaultConnManager(TestingConfig(t))
+	require.NoError(t, err)
+	m := new(staticPeerMetrics)
+	// Host A is a static peer of host B, but there is no link between them yet: A is down from the perspective of B.
+	ext := &extraHost{
+		Host:                    hostB,
+		connMgr:                 connMgr,
+		log:                     testlog.Logger(t, log.LvlError),
+		staticPeers:             []*peer.AddrInfo{{ID: hostA.ID(), Addrs: hostA.Addrs()}},
+		staticPeerBackoff:       retry.Fixed(time.Millisecond * 20),
+		staticPeerCheckInterval: time.Millisecond * 10,
+		metrics:                 m,
+		quitC:                   make(chan struct{}),
+	}
+	ext.initStaticPeers()
+	go ext.monitorStaticPeers()
+	defer ext.Close()
+
+	require.True(t, connMgr.IsProtected(hostA.ID(), staticPeerTag), "static peer must be protected from pruning")
+	require.Eventually(t, func() bool {
+		return m.failedDials.Load() >= 2
+	}, time.Second*5, time.Millisecond*10, "expected repeated dials to the static peer while it is down")
+	require.Zero(t, m.connected.Load())
+
+	// Bring up the static peer
+	_, err = mnet.LinkPeers(hostA.ID(), hostB.ID())
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return hostB.Network().Connectedness(hostA.ID()) == network.Connected && m.connected.Load() == 1
+	}, time.Second*5, time.Millisecond*10, "expected to connect to the static peer once it is up")
+	require.Equal(t, int32(1), m.successfulDials.Load())
+
+	// And reconnect when the connection drops
+	require.NoError(t, hostB.Network().ClosePeer(hostA.ID()))
+	require.Eventually(t, func() bool {
+		return hostB.Network().Connectedness(hostA.ID()) == network.Connected && m.successfulDials.Load() == 2
+	}, time.Second*5, time.Millisecond*10, "expected to reconnect to the static peer")
+}
+
+// TestP2PAdminBlockAndProtect checks that a blocked peer is disconnected and cannot reconnect,
+// while a protected peer survives connection pruning.
+func TestP2PAdminBlockAndProtect(t *testing.T) {
+	logA := testlog.Logger(t, log.LvlError).New("host", "A")
+	confA := TestingConfig(t)
+	confA.PeersLo = 1
+	confA.PeersHi = 2
+	confA.PeersGrace = 0
+	nodeA, err := NewNodeP2P(context.Background(), &rollup.Config{}, logA, confA, &mockGossipIn{}, nil,
+		&testutils.MockRuntimeConfig{P2PSeqAddress: common.Address{0x42}}, metrics.NoopMetrics, false)
+	require.NoError(t, err)
+	defer nodeA.Close()
+	hostA := nodeA.Host()
+	infoA := peer.AddrInfo{ID: hostA.ID(), Addrs: hostA.Addrs()}
+	backend := NewP2PAPIBackend(nodeA, logA, nil, true)
+
+	var peers []host.Host
+	for i := 0; i < 3; i++ {
+		h, err := TestingConfig(t).Host(testlog.Logger(t, log.LvlError).New("host", i), nil, metrics.NoopMetrics)
+		require.NoError(t, err)
+		defer h.Close()
+		peers = append(peers, h)
+	}
+	hostB := peers[0]
+	ctx := context.Background()
+
+	// Without the admin API enabled, the peering cannot be changed
+	require.ErrorIs(t, NewP2PAPIBackend(nodeA, logA, nil, false).BlockPeer(ctx, hostB.ID()), ErrAdminDisabled)
+
+	// A blocked peer is disconnected, and cannot reconnect
+	require.NoError(t, hostB.Connect(ctx, infoA))
+	require.NoError(t, backend.BlockPeer(ctx, hostB.ID()))
+	require.NotEqual(t, network.Connected, hostA.Network().Connectedness(hostB.ID()))
+	_ = hostB.Connect(ctx, infoA)
+	require.NotEqual(t, network.Connected, hostA.Network().Connectedness(hostB.ID()), "blocked peer must not be able to reconnect")
+	// The block is persisted in the datastore, and applies after a restart
+	gater, err := gating.NewBlockingConnectionGater(confA.Store)
+	require.NoError(t, err)
+	require.Equal(t, []peer.ID{hostB.ID()}, gater.ListBlockedPeers())
+	require.NoError(t, backend.UnblockPeer(ctx, hostB.ID()))
+
+	// A protected peer survives pruning, while other peers get pruned down to the low-water mark
+	require.NoError(t, backend.ProtectPeer(ctx, hostB.ID()))
+	for _, h := range peers {
+		require.NoError(t, hostA.Connect(ctx, peer.AddrInfo{ID: h.ID(), Addrs: h.Addrs()}))
+	}
+	nodeA.ConnectionManager().TrimOpenConns(ctx)
+	require.Eventually(t, func() bool {
+		return len(hostA.Network().Peers()) == 2
+	}, time.Second*5, time.Millisecond*10, "expected an unprotected peer to be pruned")
+	require.Equal(t, network.Connected, hostA.Network().Connectedness(hostB.ID()), "protected peer must not be pruned")
+
+	// Blocking an address closes all connections from it, even those of protected peers
+	require.NoError(t, backend.BlockAddr(ctx, net.IP{127, 0, 0, 1}))
+	require.Empty(t, hostA.Network().Peers())
+	_ = peers[2].Connect(ctx, infoA)
+	require.Empty(t, hostA.Network().Peers(), "peer with blocked address must not be able to reconnect")
+}
+
+// TestBanOnViolation checks that a peer is banned upon a protocol violation,
+// that the ban persists across a restart and rejects reconnections, and that the ban expires.
+func TestBanOnViolation(t *testing.T) {
+	logA := testlog.Logger(t, log.LvlError).New("host", "A")
+	confA := TestingConfig(t)
+	confA.BanningEnabled = true
+	confA.BanningDuration = 3 * time.Second
+	newNodeA := func() *NodeP2P {
+		nodeA, err := NewNodeP2P(context.Background(), &rollup.Config{}, logA, confA, &mockGossipIn{}, nil,
+			&testutils.MockRuntimeConfig{P2PSeqAddress: common.Address{0x42}}, metrics.NoopMetrics, false)
+		require.NoError(t, err)
+		return nodeA
+	}
+	nodeA := newNodeA()
+	hostB, err := TestingConfig(t).Host(testlog.Logger(t, log.LvlError).New("host", "B"), nil, metrics.NoopMetrics)
+	require.NoError(t, err)
+	defer hostB.Close()
+	infoB := peer.AddrInfo{ID: hostB.ID(), Addrs: hostB.Addrs()}
+	ctx := context.Background()
+
+	require.NoError(t, nodeA.Host().Connect(ctx, infoB))
+	nodeA.ReportViolation(hostB.ID(), "test violation")
+	require.NotEqual(t, network.Connected, nodeA.Host().Network().Connectedness(hostB.ID()), "violating peer is disconnected")
+	bans, err := NewP2PAPIBackend(nodeA, logA, nil, true).ListBans(ctx)
+	require.NoError(t, err)
+	require.Contains(t, bans.Peers, hostB.ID())
+
+	// The ban is persisted in the datastore, and applies after a restart
+	require.NoError(t, nodeA.Close())
+	nodeA = newNodeA()
+	defer nodeA.Close()
+	bans, err = NewP2PAPIBackend(nodeA, logA, nil, true).ListBans(ctx)
+	require.NoError(t, err)
+	require.Contains(t, bans.Peers, hostB.ID())
+	infoA := peer.AddrInfo{ID: nodeA.Host().ID(), Addrs: nodeA.Host().Addrs()}
+	_ = hostB.Connect(ctx, infoA)
+	require.NotEqual(t, network.Connected, nodeA.Host().Network().Connectedness(hostB.ID()), "banned peer must not be able to reconnect")
+	require.Error(t, nodeA.Host().Connect(ctx, infoB), "banned peer must not be dialed")
+
+	// The ban expires
+	require.Eventually(t, func() bool {
+		return nodeA.Host().Connect(ctx, infoB) == nil
+	}, 10*time.Second, 100*time.Millisecond, "expected ban to expire")
+	bans, err = NewP2PAPIBackend(nodeA, logA, nil, true).ListBans(ctx)
+	require.NoError(t, err)
+	require.Empty(t, bans.Peers)
+}
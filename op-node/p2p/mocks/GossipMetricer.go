@@ -14,6 +14,21 @@ func (_m *GossipMetricer) RecordGossipEvent(evType int32) {
 	_m.Called(evType)
 }
 
+// RecordGossipTopicBytes provides a mock function with given fields: topic, direction, size
+func (_m *GossipMetricer) RecordGossipTopicBytes(topic string, direction string, size int) {
+	_m.Called(topic, direction, size)
+}
+
+// RecordGossipPublishAttempt provides a mock function with given fields: retry
+func (_m *GossipMetricer) RecordGossipPublishAttempt(retry bool) {
+	_m.Called(retry)
+}
+
+// RecordGossipPublishDrop provides a mock function with given fields: reason
+func (_m *GossipMetricer) RecordGossipPublishDrop(reason string) {
+	_m.Called(reason)
+}
+
 type mockConstructorTestingTNewGossipMetricer interface {
 	mock.TestingT
 	Cleanup(func())
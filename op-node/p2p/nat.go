@@ -0,0 +1,128 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// externalAddrCheckInterval is the interval at which the external address of the host is re-checked.
+// The NAT manager of the host maintains the port mappings, and reflects them in the host addresses.
+const externalAddrCheckInterval = time.Minute
+
+// addrSource provides the addresses that the host advertises, including any NAT port mappings.
+type addrSource interface {
+	Addrs() []ma.Multiaddr
+}
+
+// advertisedAddrs applies the advertised IP and TCP port overrides to the given host addresses,
+// for deployments behind a load balancer or static port forwarding.
+// The addresses are used as-is if no override is configured.
+func (conf *Config) advertisedAddrs(addrs []ma.Multiaddr) []ma.Multiaddr {
+	if conf.AdvertiseIP == nil && conf.AdvertiseTCPPort == 0 {
+		return addrs
+	}
+	out := make([]ma.Multiaddr, 0, len(addrs))
+	for _, addr := range addrs {
+		ip, port, ok := tcpAddrParts(addr)
+		if !ok {
+			out = append(out, addr)
+			continue
+		}
+		if conf.AdvertiseIP != nil {
+			ip = conf.AdvertiseIP
+		}
+		if conf.AdvertiseTCPPort != 0 {
+			port = conf.AdvertiseTCPPort
+		}
+		override, err := addrFromIPAndPort(ip, port)
+		if err != nil {
+			continue
+		}
+		if !containsAddr(out, override) {
+			out = append(out, override)
+		}
+	}
+	return out
+}
+
+// selectExternalAddr selects the first public TCP address, the address other peers can reach us at.
+func selectExternalAddr(addrs []ma.Multiaddr) (addr ma.Multiaddr, ip net.IP, port uint16, ok bool) {
+	for _, addr := range addrs {
+		if !manet.IsPublicAddr(addr) {
+			continue
+		}
+		if ip, port, ok := tcpAddrParts(addr); ok {
+			return addr, ip, port, true
+		}
+	}
+	return nil, nil, 0, false
+}
+
+// tcpAddrParts returns the IP and TCP port of an /ip4/.../tcp/... or /ip6/.../tcp/... address.
+func tcpAddrParts(addr ma.Multiaddr) (net.IP, uint16, bool) {
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return nil, 0, false
+	}
+	portStr, err := addr.ValueForProtocol(ma.P_TCP)
+	if err != nil {
+		return nil, 0, false
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil, 0, false
+	}
+	return ip, uint16(port), true
+}
+
+func containsAddr(addrs []ma.Multiaddr, addr ma.Multiaddr) bool {
+	for _, a := range addrs {
+		if a.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// monitorExternalAddr periodically checks the external address of the host,
+// and updates the discovery record when it changes, e.g. when a NAT port mapping is established.
+func (n *NodeP2P) monitorExternalAddr(ctx context.Context, src addrSource, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var current ma.Multiaddr
+	for {
+		current = n.updateExternalAddr(src, current)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// updateExternalAddr logs and applies a change of the external address, and returns the current external address.
+func (n *NodeP2P) updateExternalAddr(src addrSource, prev ma.Multiaddr) ma.Multiaddr {
+	addr, ip, port, ok := selectExternalAddr(src.Addrs())
+	if !ok {
+		if prev != nil {
+			n.log.Warn("lost external p2p address", "prev", prev)
+		}
+		return nil
+	}
+	if prev != nil && prev.Equal(addr) {
+		return prev
+	}
+	n.log.Info("detected external p2p address", "addr", addr)
+	// Only public addresses are used, discv5 may otherwise still learn the external IP from its peers.
+	if n.dv5Local != nil {
+		n.dv5Local.SetStaticIP(ip)
+		n.dv5Local.Set(enr.TCP(port))
+	}
+	return addr
+}
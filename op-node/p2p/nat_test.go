@@ -0,0 +1,98 @@
+package p2p
+
+import (
+	"net"
+	"testing"
+
+	gcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeAddrSource struct {
+	addrs []ma.Multiaddr
+}
+
+func (f *fakeAddrSource) Addrs() []ma.Multiaddr {
+	return f.addrs
+}
+
+func mustAddrs(t *testing.T, addrs ...string) []ma.Multiaddr {
+	out := make([]ma.Multiaddr, 0, len(addrs))
+	for _, a := range addrs {
+		addr, err := ma.NewMultiaddr(a)
+		require.NoError(t, err)
+		out = append(out, addr)
+	}
+	return out
+}
+
+func TestAdvertisedAddrs(t *testing.T) {
+	listen := mustAddrs(t, "/ip4/127.0.0.1/tcp/9222", "/ip4/10.0.0.2/tcp/9222")
+	table := []struct {
+		name     string
+		conf     Config
+		expected []ma.Multiaddr
+	}{
+		{"no override", Config{}, listen},
+		{"ip override", Config{AdvertiseIP: net.IPv4(1, 2, 3, 4)}, mustAddrs(t, "/ip4/1.2.3.4/tcp/9222")},
+		{"port override", Config{AdvertiseTCPPort: 30303}, mustAddrs(t, "/ip4/127.0.0.1/tcp/30303", "/ip4/10.0.0.2/tcp/30303")},
+		{"ip and port override", Config{AdvertiseIP: net.IPv4(1, 2, 3, 4), AdvertiseTCPPort: 30303}, mustAddrs(t, "/ip4/1.2.3.4/tcp/30303")},
+		{"ip6 override", Config{AdvertiseIP: net.ParseIP("2001:db8::1")}, mustAddrs(t, "/ip6/2001:db8::1/tcp/9222")},
+	}
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.conf.advertisedAddrs(listen))
+		})
+	}
+}
+
+func TestSelectExternalAddr(t *testing.T) {
+	_, _, _, ok := selectExternalAddr(mustAddrs(t, "/ip4/127.0.0.1/tcp/9222", "/ip4/192.168.1.2/tcp/9222"))
+	require.False(t, ok, "private addresses are not external")
+
+	addr, ip, port, ok := selectExternalAddr(mustAddrs(t, "/ip4/192.168.1.2/tcp/9222", "/ip4/8.8.8.8/tcp/4001", "/ip4/8.8.4.4/tcp/4002"))
+	require.True(t, ok)
+	require.Equal(t, "/ip4/8.8.8.8/tcp/4001", addr.String())
+	require.True(t, ip.Equal(net.IPv4(8, 8, 8, 8)))
+	require.Equal(t, uint16(4001), port)
+
+	_, _, _, ok = selectExternalAddr(mustAddrs(t, "/ip4/8.8.8.8/udp/4001"))
+	require.False(t, ok, "only TCP addresses are dialable by libp2p peers")
+}
+
+func TestUpdateExternalAddr(t *testing.T) {
+	priv, err := gcrypto.GenerateKey()
+	require.NoError(t, err)
+	db, err := enode.OpenDB("")
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+	localNode := enode.NewLocalNode(db, priv)
+	n := &NodeP2P{log: testlog.Logger(t, log.LvlError), dv5Local: localNode}
+	src := &fakeAddrSource{addrs: mustAddrs(t, "/ip4/192.168.1.2/tcp/9222")}
+
+	current := n.updateExternalAddr(src, nil)
+	require.Nil(t, current, "no NAT mapping yet")
+
+	// the NAT device maps our port
+	src.addrs = append(src.addrs, mustAddrs(t, "/ip4/8.8.8.8/tcp/4001")...)
+	current = n.updateExternalAddr(src, current)
+	require.Equal(t, "/ip4/8.8.8.8/tcp/4001", current.String())
+	require.True(t, localNode.Node().IP().Equal(net.IPv4(8, 8, 8, 8)))
+	require.Equal(t, 4001, localNode.Node().TCP())
+
+	// the mapping changes
+	src.addrs = mustAddrs(t, "/ip4/8.8.4.4/tcp/4002")
+	current = n.updateExternalAddr(src, current)
+	require.Equal(t, "/ip4/8.8.4.4/tcp/4002", current.String())
+	require.True(t, localNode.Node().IP().Equal(net.IPv4(8, 8, 4, 4)))
+	require.Equal(t, 4002, localNode.Node().TCP())
+
+	// the mapping is lost
+	src.addrs = mustAddrs(t, "/ip4/192.168.1.2/tcp/9222")
+	require.Nil(t, n.updateExternalAddr(src, current))
+}
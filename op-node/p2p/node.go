@@ -44,12 +44,32 @@ type NodeP2P struct {
 	// the below components are all optional, and may be nil. They require the host to not be nil.
 	dv5Local *enode.LocalNode // p2p discovery identity
 	dv5Udp   *discover.UDPv5  // p2p discovery service
+	dnsDisc  *DNSDiscovery    // DNS node list discovery, next to discv5
 	gs       *pubsub.PubSub   // p2p gossip router
 	gsOut    GossipOut        // p2p gossip application interface for publishing
 	syncCl   *SyncClient
 	syncSrv  *ReqRespServer
+	bwc      *p2pmetrics.BandwidthCounter // bandwidth of the host and discovery
+
+	metrics      metrics.Metricer // may be nil
+	dialUnmarked bool             // dial discovered peers without opstack node record entry
+
+	banViolations bool          // ban peers upon hard protocol violations
+	banDuration   time.Duration // duration of bans upon hard protocol violations
 }
 
+// ViolationReporter is informed of hard protocol violations by peers, which warrant a ban,
+// like a gossiped block with an invalid signature, or a malformed sync response.
+type ViolationReporter interface {
+	ReportViolation(id peer.ID, reason string)
+}
+
+type NoopViolationReporter struct{}
+
+func (NoopViolationReporter) ReportViolation(id peer.ID, reason string) {}
+
+var _ ViolationReporter = NoopViolationReporter{}
+
 // NewNodeP2P creates a new p2p node, and returns a reference to it. If the p2p is disabled, it returns nil.
 // If metrics are configured, a bandwidth monitor will be spawned in a goroutine.
 func NewNodeP2P(resourcesCtx context.Context, rollupCfg *rollup.Config, log log.Logger, setup SetupP2P, gossipIn GossipIn, l2Chain L2Chain, runCfg GossipRuntimeConfig, metrics metrics.Metricer, elSyncEnabled bool) (*NodeP2P, error) {
@@ -72,8 +92,13 @@ func NewNodeP2P(resourcesCtx context.Context, rollupCfg *rollup.Config, log log.
 
 func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, log log.Logger, setup SetupP2P, gossipIn GossipIn, l2Chain L2Chain, runCfg GossipRuntimeConfig, metrics metrics.Metricer, elSyncEnabled bool) error {
 	bwc := p2pmetrics.NewBandwidthCounter()
+	n.bwc = bwc
 
 	n.log = log
+	n.metrics = metrics
+	n.dialUnmarked = setup.DialUnmarkedPeers()
+	n.banViolations = setup.BanPeers()
+	n.banDuration = setup.BanDuration()
 
 	var err error
 	// nil if disabled.
@@ -106,7 +131,7 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 		}
 		// Activate the P2P req-resp sync if enabled by feature-flag.
 		if setup.ReqRespSyncEnabled() && !elSyncEnabled {
-			n.syncCl = NewSyncClient(log, rollupCfg, n.host.NewStream, gossipIn.OnUnsafeL2Payload, metrics, n.appScorer)
+			n.syncCl = NewSyncClient(log, rollupCfg, n.host.NewStream, gossipIn.OnUnsafeL2Payload, metrics, n.appScorer, n)
 			n.host.Network().Notify(&network.NotifyBundle{
 				ConnectedF: func(nw network.Network, conn network.Conn) {
 					n.syncCl.AddPeer(conn.RemotePeer())
@@ -128,6 +153,8 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 				// register the sync protocol with libp2p host
 				payloadByNumber := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_number"), n.syncSrv.HandleSyncRequest)
 				n.host.SetStreamHandler(PayloadByNumberProtocolID(rollupCfg.L2ChainID), payloadByNumber)
+				payloadsByRange := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_range"), n.syncSrv.HandleSyncRangeRequest)
+				n.host.SetStreamHandler(PayloadsByRangeProtocolID(rollupCfg.L2ChainID), payloadsByRange)
 			}
 		}
 		n.scorer = NewScorer(rollupCfg, eps, metrics, n.appScorer, log)
@@ -138,7 +165,7 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 		if err != nil {
 			return fmt.Errorf("failed to start gossipsub router: %w", err)
 		}
-		n.gsOut, err = JoinGossip(n.host.ID(), n.gs, log, rollupCfg, runCfg, gossipIn)
+		n.gsOut, err = JoinGossip(n.host.ID(), n.gs, log, rollupCfg, runCfg, gossipIn, n, setup.PublishRetryParams(), metrics)
 		if err != nil {
 			return fmt.Errorf("failed to join blocks gossip topic: %w", err)
 		}
@@ -150,13 +177,20 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 		}
 
 		// All nil if disabled.
-		n.dv5Local, n.dv5Udp, err = setup.Discovery(log.New("p2p", "discv5"), rollupCfg, tcpPort)
+		n.dv5Local, n.dv5Udp, err = setup.Discovery(log.New("p2p", "discv5"), rollupCfg, tcpPort, bwc)
 		if err != nil {
 			return fmt.Errorf("failed to start discv5: %w", err)
 		}
+		// nil if disabled.
+		n.dnsDisc, err = setup.DNSDiscovery(log.New("p2p", "dnsdisc"))
+		if err != nil {
+			return fmt.Errorf("failed to start DNS discovery: %w", err)
+		}
+		go n.monitorExternalAddr(resourcesCtx, n.host, externalAddrCheckInterval)
 
 		if metrics != nil {
 			go metrics.RecordBandwidth(resourcesCtx, bwc)
+			go monitorBandwidth(resourcesCtx, bwc, n.host.Network(), metrics)
 		}
 
 		if setup.BanPeers() {
@@ -187,6 +221,10 @@ func (n *NodeP2P) Dv5Local() *enode.LocalNode {
 	return n.dv5Local
 }
 
+func (n *NodeP2P) BandwidthCounter() *p2pmetrics.BandwidthCounter {
+	return n.bwc
+}
+
 func (n *NodeP2P) Dv5Udp() *discover.UDPv5 {
 	return n.dv5Udp
 }
@@ -229,6 +267,19 @@ func (n *NodeP2P) BanPeer(id peer.ID, expiration time.Time) error {
 	return nil
 }
 
+// ReportViolation bans the peer for a hard protocol violation, if banning is enabled.
+// Static peers are not banned, like with score-based banning.
+func (n *NodeP2P) ReportViolation(id peer.ID, reason string) {
+	if !n.banViolations || n.IsStatic(id) {
+		n.log.Warn("peer violated protocol", "peer", id, "reason", reason)
+		return
+	}
+	n.log.Warn("banning peer for protocol violation", "peer", id, "reason", reason, "duration", n.banDuration)
+	if err := n.BanPeer(id, time.Now().Add(n.banDuration)); err != nil {
+		n.log.Error("failed to ban peer", "peer", id, "err", err)
+	}
+}
+
 func (n *NodeP2P) BanIP(ip net.IP, expiration time.Time) error {
 	if err := n.store.SetIPBanExpiration(ip, expiration); err != nil {
 		return fmt.Errorf("failed to set IP ban expiry: %w", err)
@@ -257,6 +308,11 @@ func (n *NodeP2P) Close() error {
 	if n.dv5Udp != nil {
 		n.dv5Udp.Close()
 	}
+	if n.dnsDisc != nil {
+		if err := n.dnsDisc.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close DNS discovery cleanly: %w", err))
+		}
+	}
 	if n.gsOut != nil {
 		if err := n.gsOut.Close(); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close gossip cleanly: %w", err))
@@ -87,6 +87,28 @@ func inMeshCap(slot time.Duration) float64 {
 	return float64((3600 * time.Second) / slot)
 }
 
+// FullPeerScoreParams is an instantiation of [pubsub.PeerScoreParams] with the full weight
+// applied to the blocks topic, penalizing misbehaving peers faster than [LightPeerScoreParams].
+func FullPeerScoreParams(cfg *rollup.Config) pubsub.PeerScoreParams {
+	params := LightPeerScoreParams(cfg)
+	for _, topic := range params.Topics {
+		topic.TopicWeight = 1
+	}
+	return params
+}
+
+// FullPeerScoreThresholds returns stricter [pubsub.PeerScoreThresholds] than [NewPeerScoreThresholds],
+// to go with [FullPeerScoreParams].
+func FullPeerScoreThresholds() pubsub.PeerScoreThresholds {
+	thresholds := NewPeerScoreThresholds()
+	thresholds.GossipThreshold = -5
+	thresholds.PublishThreshold = -20
+	thresholds.GraylistThreshold = -20
+	return thresholds
+}
+
+// GetScoringParams returns the scoring parameters of the named preset:
+// "light" (the default), "full", or "off"/"none" to disable peer scoring, in which case nil is returned.
 func GetScoringParams(name string, cfg *rollup.Config) (*ScoringParams, error) {
 	switch name {
 	case "light":
@@ -94,13 +116,66 @@ func GetScoringParams(name string, cfg *rollup.Config) (*ScoringParams, error) {
 			PeerScoring:        LightPeerScoreParams(cfg),
 			ApplicationScoring: LightApplicationScoreParams(cfg),
 		}, nil
-	case "none":
+	case "full":
+		thresholds := FullPeerScoreThresholds()
+		return &ScoringParams{
+			PeerScoring:        FullPeerScoreParams(cfg),
+			ApplicationScoring: LightApplicationScoreParams(cfg),
+			Thresholds:         &thresholds,
+		}, nil
+	case "none", "off":
 		return nil, nil
 	default:
 		return nil, fmt.Errorf("unknown p2p scoring level: %v", name)
 	}
 }
 
+// ScoringOverrides are optional operator adjustments to a scoring preset.
+// Zero values keep the value of the preset.
+type ScoringOverrides struct {
+	DecayInterval     time.Duration
+	TopicWeight       float64
+	GraylistThreshold float64
+}
+
+// Apply modifies the scoring params with the non-zero overrides.
+func (o *ScoringOverrides) Apply(params *ScoringParams) error {
+	if o.DecayInterval < 0 {
+		return fmt.Errorf("peer score decay interval must not be negative: %s", o.DecayInterval)
+	}
+	if o.TopicWeight < 0 {
+		return fmt.Errorf("peer score topic weight must not be negative: %v", o.TopicWeight)
+	}
+	if o.GraylistThreshold > 0 {
+		return fmt.Errorf("peer score graylist threshold must not be positive: %v", o.GraylistThreshold)
+	}
+	if o.DecayInterval != 0 {
+		params.PeerScoring.DecayInterval = o.DecayInterval
+		params.ApplicationScoring.DecayInterval = o.DecayInterval
+	}
+	if o.TopicWeight != 0 {
+		for _, topic := range params.PeerScoring.Topics {
+			topic.TopicWeight = o.TopicWeight
+		}
+	}
+	if o.GraylistThreshold != 0 {
+		thresholds := NewPeerScoreThresholds()
+		if params.Thresholds != nil {
+			thresholds = *params.Thresholds
+		}
+		thresholds.GraylistThreshold = o.GraylistThreshold
+		// the publish threshold must be at least the graylist threshold
+		if thresholds.PublishThreshold < thresholds.GraylistThreshold {
+			thresholds.PublishThreshold = thresholds.GraylistThreshold
+		}
+		if thresholds.GossipThreshold < thresholds.PublishThreshold {
+			thresholds.GossipThreshold = thresholds.PublishThreshold
+		}
+		params.Thresholds = &thresholds
+	}
+	return nil
+}
+
 // NewPeerScoreThresholds returns a default [pubsub.PeerScoreThresholds].
 // See [PeerScoreThresholds] for detailed documentation.
 //
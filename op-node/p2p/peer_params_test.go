@@ -106,3 +106,56 @@ func (testSuite *PeerParamsTestSuite) TestParamsZeroBlockTime() {
 	testSuite.Equal(params.PeerScoring.DecayInterval, slot)
 	testSuite.Equal(params.ApplicationScoring.DecayInterval, slot)
 }
+
+// TestGetPeerScoreParams_Presets validates the full and off presets.
+func (testSuite *PeerParamsTestSuite) TestGetPeerScoreParams_Presets() {
+	cfg := chaincfg.Goerli
+	params, err := GetScoringParams("off", cfg)
+	testSuite.NoError(err)
+	testSuite.Nil(params)
+
+	light, err := GetScoringParams("light", cfg)
+	testSuite.NoError(err)
+	testSuite.Nil(light.Thresholds, "light preset uses the default thresholds")
+
+	full, err := GetScoringParams("full", cfg)
+	testSuite.NoError(err)
+	testSuite.Equal(float64(1), full.PeerScoring.Topics[blocksTopicV1(cfg)].TopicWeight)
+	testSuite.Equal(0.8, light.PeerScoring.Topics[blocksTopicV1(cfg)].TopicWeight, "presets must not share topic params")
+	testSuite.Equal(FullPeerScoreThresholds(), *full.Thresholds)
+	testSuite.Greater(full.Thresholds.GraylistThreshold, NewPeerScoreThresholds().GraylistThreshold)
+
+	_, err = GetScoringParams("unknown", cfg)
+	testSuite.ErrorContains(err, "unknown p2p scoring level")
+}
+
+// TestScoringOverrides validates the operator overrides of the scoring presets.
+func (testSuite *PeerParamsTestSuite) TestScoringOverrides() {
+	cfg := chaincfg.Goerli
+	params, err := GetScoringParams("light", cfg)
+	testSuite.NoError(err)
+
+	noop := ScoringOverrides{}
+	before := params.PeerScoring.DecayInterval
+	testSuite.NoError(noop.Apply(params))
+	testSuite.Equal(before, params.PeerScoring.DecayInterval)
+	testSuite.Nil(params.Thresholds)
+
+	overrides := ScoringOverrides{DecayInterval: 5 * time.Second, TopicWeight: 0.5, GraylistThreshold: -100}
+	testSuite.NoError(overrides.Apply(params))
+	testSuite.Equal(5*time.Second, params.PeerScoring.DecayInterval)
+	testSuite.Equal(5*time.Second, params.ApplicationScoring.DecayInterval)
+	testSuite.Equal(0.5, params.PeerScoring.Topics[blocksTopicV1(cfg)].TopicWeight)
+	testSuite.Equal(float64(-100), params.Thresholds.GraylistThreshold)
+	testSuite.Equal(NewPeerScoreThresholds().PublishThreshold, params.Thresholds.PublishThreshold)
+
+	// a graylist threshold above the publish threshold raises the publish and gossip thresholds
+	overrides = ScoringOverrides{GraylistThreshold: -1}
+	testSuite.NoError(overrides.Apply(params))
+	testSuite.Equal(float64(-1), params.Thresholds.PublishThreshold)
+	testSuite.Equal(float64(-1), params.Thresholds.GossipThreshold)
+
+	testSuite.Error((&ScoringOverrides{GraylistThreshold: 1}).Apply(params))
+	testSuite.Error((&ScoringOverrides{TopicWeight: -1}).Apply(params))
+	testSuite.Error((&ScoringOverrides{DecayInterval: -time.Second}).Apply(params))
+}
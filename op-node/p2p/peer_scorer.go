@@ -73,10 +73,13 @@ func (s *scorer) SnapshotHook() pubsub.ExtendedPeerScoreInspectFn {
 				BehavioralPenalty:  snap.BehaviourPenalty,
 			}
 			if topSnap, ok := snap.Topics[blocksTopicName]; ok {
-				diff.Blocks.TimeInMesh = float64(topSnap.TimeInMesh) / float64(time.Second)
-				diff.Blocks.MeshMessageDeliveries = topSnap.MeshMessageDeliveries
-				diff.Blocks.FirstMessageDeliveries = topSnap.FirstMessageDeliveries
-				diff.Blocks.InvalidMessageDeliveries = topSnap.InvalidMessageDeliveries
+				diff.Blocks = topicScores(topSnap)
+			}
+			if len(snap.Topics) > 0 {
+				diff.Topics = make(map[string]store.TopicScores, len(snap.Topics))
+				for topic, topSnap := range snap.Topics {
+					diff.Topics[topic] = topicScores(topSnap)
+				}
 			}
 			if peerScores, err := s.peerStore.SetScore(id, &diff); err != nil {
 				s.log.Warn("Unable to update peer gossip score", "err", err)
@@ -88,6 +91,15 @@ func (s *scorer) SnapshotHook() pubsub.ExtendedPeerScoreInspectFn {
 	}
 }
 
+func topicScores(snap *pubsub.TopicScoreSnapshot) store.TopicScores {
+	return store.TopicScores{
+		TimeInMesh:               float64(snap.TimeInMesh) / float64(time.Second),
+		FirstMessageDeliveries:   snap.FirstMessageDeliveries,
+		MeshMessageDeliveries:    snap.MeshMessageDeliveries,
+		InvalidMessageDeliveries: snap.InvalidMessageDeliveries,
+	}
+}
+
 func (s *scorer) ApplicationScore(id peer.ID) float64 {
 	return s.appScorer.ApplicationScore(id)
 }
@@ -49,7 +49,7 @@ func (p *Prepared) Host(log log.Logger, reporter metrics.Reporter, metrics HostM
 }
 
 // Discovery creates a disc-v5 service. Returns nil, nil, nil if discovery is disabled.
-func (p *Prepared) Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort uint16) (*enode.LocalNode, *discover.UDPv5, error) {
+func (p *Prepared) Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort uint16, reporter metrics.Reporter) (*enode.LocalNode, *discover.UDPv5, error) {
 	if p.LocalNode != nil {
 		dat := OpStackENRData{
 			chainID: rollupCfg.L2ChainID.Uint64(),
@@ -63,10 +63,12 @@ func (p *Prepared) Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort u
 	return p.LocalNode, p.UDPv5, nil
 }
 
+func (p *Prepared) DNSDiscovery(log log.Logger) (*DNSDiscovery, error) {
+	return nil, nil
+}
+
 func (p *Prepared) ConfigureGossip(rollupCfg *rollup.Config) []pubsub.Option {
-	return []pubsub.Option{
-		pubsub.WithGossipSubParams(BuildGlobalGossipParams(rollupCfg)),
-	}
+	return gossipParamsOptions(BuildGlobalGossipParams(rollupCfg))
 }
 
 func (p *Prepared) PeerScoringParams() *ScoringParams {
@@ -92,3 +94,11 @@ func (p *Prepared) Disabled() bool {
 func (p *Prepared) ReqRespSyncEnabled() bool {
 	return p.EnableReqRespSync
 }
+
+func (p *Prepared) DialUnmarkedPeers() bool {
+	return false
+}
+
+func (p *Prepared) PublishRetryParams() PublishRetryConfig {
+	return DefaultPublishRetryConfig()
+}
@@ -0,0 +1,238 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	PublishDropSuperseded = "superseded"
+	PublishDropExpired    = "expired"
+	PublishDropOverflow   = "overflow"
+)
+
+const (
+	DefaultPublishRetryDepth = 4
+	DefaultPublishRetryTTL   = 20 * time.Second
+
+	defaultPublishRetryMaxQueued = 16
+	defaultPublishRetryBackoff   = 250 * time.Millisecond
+	// publishRetryMaxBackoff caps the exponential backoff between attempts,
+	// so a block is published soon after the first peer joins the topic.
+	publishRetryMaxBackoff = 2 * time.Second
+)
+
+// PublishRetryConfig configures the retrying of blocks that failed to publish,
+// e.g. because there are no peers to publish to yet, or because of a transient pubsub error.
+type PublishRetryConfig struct {
+	// MaxQueued bounds the number of blocks that are queued for a retry. The oldest block is dropped if full.
+	MaxQueued int
+	// SupersedeDepth is the number of newer blocks after which a queued block is no longer retried.
+	SupersedeDepth uint64
+	// TTL is the duration after which a queued block is no longer retried.
+	// Retrying is disabled if 0: blocks are then published right away, and only once.
+	TTL time.Duration
+	// Backoff is the duration of the first attempt to wait for peers, doubled for every next attempt.
+	Backoff time.Duration
+}
+
+func DefaultPublishRetryConfig() PublishRetryConfig {
+	return PublishRetryConfig{
+		MaxQueued:      defaultPublishRetryMaxQueued,
+		SupersedeDepth: DefaultPublishRetryDepth,
+		TTL:            DefaultPublishRetryTTL,
+		Backoff:        defaultPublishRetryBackoff,
+	}
+}
+
+func (c *PublishRetryConfig) Check() error {
+	if c.TTL < 0 {
+		return fmt.Errorf("publish retry TTL must not be negative, but got %s", c.TTL)
+	}
+	if c.TTL > 0 {
+		if c.MaxQueued <= 0 {
+			return fmt.Errorf("publish retry queue must fit at least 1 block, but got %d", c.MaxQueued)
+		}
+		if c.Backoff <= 0 {
+			return fmt.Errorf("publish retry backoff must be positive, but got %s", c.Backoff)
+		}
+	}
+	return nil
+}
+
+type queuedPublish struct {
+	num      uint64
+	topic    *pubsub.Topic
+	data     []byte
+	expiry   time.Time
+	attempts int
+	next     time.Time
+}
+
+// publishQueue publishes blocks in order in the background, and retries the blocks that failed to publish.
+// The caller only queues the block, and thus never waits for the publishing or a retry of a block.
+type publishQueue struct {
+	log log.Logger
+	cfg PublishRetryConfig
+	m   GossipMetricer
+
+	// mu guards the queue
+	mu    sync.Mutex
+	queue []*queuedPublish
+
+	wake chan struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newPublishQueue(log log.Logger, cfg PublishRetryConfig, m GossipMetricer) *publishQueue {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &publishQueue{
+		log:    log,
+		cfg:    cfg,
+		m:      m,
+		wake:   make(chan struct{}, 1),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	q.wg.Add(1)
+	go q.loop()
+	return q
+}
+
+// publish queues the block to be published after the blocks before it.
+// If retrying is disabled, the block is published right away instead, and any error is returned.
+func (q *publishQueue) publish(ctx context.Context, num uint64, topic *pubsub.Topic, data []byte) error {
+	if q.cfg.TTL == 0 {
+		q.m.RecordGossipPublishAttempt(false)
+		return topic.Publish(ctx, data)
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.queue) > 0 && q.queue[0].num+q.cfg.SupersedeDepth < num {
+		q.drop(PublishDropSuperseded, "superseded_by", num)
+	}
+	now := time.Now()
+	q.queue = append(q.queue, &queuedPublish{
+		num:    num,
+		topic:  topic,
+		data:   data,
+		expiry: now.Add(q.cfg.TTL),
+		next:   now,
+	})
+	if len(q.queue) > q.cfg.MaxQueued {
+		q.drop(PublishDropOverflow, "queued", len(q.queue))
+	}
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// drop removes the first queued block. The caller must hold the lock.
+func (q *publishQueue) drop(reason string, ctx ...any) {
+	head := q.queue[0]
+	q.queue[0] = nil
+	q.queue = q.queue[1:]
+	q.m.RecordGossipPublishDrop(reason)
+	// Blocks are superseded all the time if there are no peers, e.g. on a single-node devnet.
+	logFn := q.log.Warn
+	if reason == PublishDropSuperseded {
+		logFn = q.log.Debug
+	}
+	logFn("Dropping block from publish queue", append([]any{"num", head.num, "reason", reason, "attempts", head.attempts}, ctx...)...)
+}
+
+// head returns the first queued block that has not expired, and the duration until it is due to be published.
+func (q *publishQueue) head() (*queuedPublish, time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := time.Now()
+	for len(q.queue) > 0 && !now.Before(q.queue[0].expiry) {
+		q.drop(PublishDropExpired, "ttl", q.cfg.TTL)
+	}
+	if len(q.queue) == 0 {
+		return nil, 0
+	}
+	return q.queue[0], q.queue[0].next.Sub(now)
+}
+
+func (q *publishQueue) backoff(attempts int) time.Duration {
+	backoff := q.cfg.Backoff << attempts
+	if backoff > publishRetryMaxBackoff || backoff <= 0 {
+		backoff = publishRetryMaxBackoff
+	}
+	return backoff
+}
+
+// attempt publishes the block. The publishing waits up to the backoff duration
+// for the topic mesh to have peers: publishing to an empty mesh succeeds, but does not reach anyone,
+// and marks the message as seen, so a later retry of the same message would be ignored by the pubsub router.
+func (q *publishQueue) attempt(pub *queuedPublish) {
+	start := time.Now()
+	backoff := q.backoff(pub.attempts)
+	ctx, cancel := context.WithTimeout(q.ctx, backoff)
+	defer cancel()
+	q.m.RecordGossipPublishAttempt(pub.attempts > 0)
+	err := pub.topic.Publish(ctx, pub.data, pubsub.WithReadiness(pubsub.MinTopicSize(1)))
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	pub.attempts++
+	if err != nil {
+		pub.next = start.Add(backoff)
+		q.log.Debug("Failed to publish block, retrying", "num", pub.num, "attempts", pub.attempts, "err", err)
+		return
+	}
+	if pub.attempts > 1 {
+		q.log.Info("Published block after retry", "num", pub.num, "attempts", pub.attempts)
+	}
+	// the block may have been dropped from the queue while it was being published
+	if len(q.queue) > 0 && q.queue[0] == pub {
+		q.queue[0] = nil
+		q.queue = q.queue[1:]
+	}
+}
+
+func (q *publishQueue) loop() {
+	defer q.wg.Done()
+	for {
+		pub, wait := q.head()
+		if pub == nil || wait > 0 {
+			var timer *time.Timer
+			var due <-chan time.Time
+			if pub != nil {
+				timer = time.NewTimer(wait)
+				due = timer.C
+			}
+			select {
+			case <-q.ctx.Done():
+				return
+			case <-q.wake:
+			case <-due:
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			continue
+		}
+		q.attempt(pub)
+		if q.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (q *publishQueue) Close() {
+	q.cancel()
+	q.wg.Wait()
+}
@@ -0,0 +1,232 @@
+package p2p
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type publishMetrics struct {
+	mu       sync.Mutex
+	attempts int
+	retries  int
+	drops    map[string]int
+}
+
+func (m *publishMetrics) RecordGossipEvent(evType int32) {}
+
+func (m *publishMetrics) RecordGossipTopicBytes(topic string, direction string, size int) {}
+
+func (m *publishMetrics) RecordGossipPublishAttempt(retry bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.attempts++
+	if retry {
+		m.retries++
+	}
+}
+
+func (m *publishMetrics) RecordGossipPublishDrop(reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.drops[reason]++
+}
+
+func (m *publishMetrics) counts() (attempts int, retries int, drops map[string]int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	drops = make(map[string]int)
+	for k, v := range m.drops {
+		drops[k] = v
+	}
+	return m.attempts, m.retries, drops
+}
+
+type publishTestSetup struct {
+	out      GossipOut
+	signer   Signer
+	metrics  *publishMetrics
+	received chan uint64
+	// join joins the verifier to the block topics.
+	join func(t *testing.T)
+}
+
+// setupPublishTest sets up a publisher, and a verifier that is connected to it, but did not join the block topics yet.
+// The verifier is connected upfront, so the pubsub router of the publisher is ready
+// to publish to the verifier as soon as it learns about the topic subscriptions of the verifier.
+func setupPublishTest(t *testing.T, retryCfg PublishRetryConfig) *publishTestSetup {
+	logger := testlog.Logger(t, log.LvlError)
+	cfg := &rollup.Config{L2ChainID: big.NewInt(777)}
+	conf := &Config{
+		MeshD:           DefaultMeshD,
+		MeshDLo:         DefaultMeshDlo,
+		MeshDHi:         DefaultMeshDhi,
+		MeshDLazy:       DefaultMeshDlazy,
+		GossipHeartbeat: 100 * time.Millisecond,
+	}
+	secrets, err := e2eutils.DefaultMnemonicConfig.Secrets()
+	require.NoError(t, err)
+	runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: crypto.PubkeyToAddress(secrets.SequencerP2P.PublicKey)}
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = mnet.Close()
+	})
+	hostA, hostB := mnet.Hosts()[0], mnet.Hosts()[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	m := &publishMetrics{drops: make(map[string]int)}
+	psA, err := NewGossipSub(ctx, hostA, cfg, conf, nil, m, logger)
+	require.NoError(t, err)
+	outA, err := JoinGossip(hostA.ID(), psA, logger.New("host", "A"), cfg, runCfg, &mockGossipIn{}, NoopViolationReporter{}, retryCfg, m)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = outA.Close()
+	})
+	psB, err := NewGossipSub(ctx, hostB, cfg, conf, nil, nil, logger)
+	require.NoError(t, err)
+
+	received := make(chan uint64, 20)
+	join := func(t *testing.T) {
+		gossipInB := &mockGossipIn{OnUnsafeL2PayloadFn: func(ctx context.Context, from peer.ID, msg *eth.ExecutionPayloadEnvelope) error {
+			received <- uint64(msg.ExecutionPayload.BlockNumber)
+			return nil
+		}}
+		outB, err := JoinGossip(hostB.ID(), psB, logger.New("host", "B"), cfg, runCfg, gossipInB, NoopViolationReporter{}, retryCfg, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = outB.Close()
+		})
+		require.Eventually(t, func() bool {
+			return len(outA.AllBlockTopicsPeers()) > 0
+		}, 10*time.Second, 10*time.Millisecond, "publisher must learn the verifier subscriptions")
+	}
+
+	return &publishTestSetup{
+		out:      outA,
+		signer:   &PreparedSigner{Signer: NewLocalSigner(secrets.SequencerP2P)},
+		metrics:  m,
+		received: received,
+		join:     join,
+	}
+}
+
+func (s *publishTestSetup) publish(t *testing.T, num uint64) {
+	payload := &eth.ExecutionPayload{
+		BlockNumber: hexutil.Uint64(num),
+		Timestamp:   hexutil.Uint64(time.Now().Unix()),
+	}
+	payload.BlockHash, _ = payload.CheckBlockHash()
+	require.NoError(t, s.out.PublishL2Payload(context.Background(), &eth.ExecutionPayloadEnvelope{ExecutionPayload: payload}, s.signer), "failed publications are retried, not returned")
+}
+
+func (s *publishTestSetup) expectReceived(t *testing.T, nums ...uint64) {
+	for _, num := range nums {
+		select {
+		case got := <-s.received:
+			require.Equal(t, num, got, "blocks must be received in publish order")
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for block %d", num)
+		}
+	}
+}
+
+func TestPublishRetry(t *testing.T) {
+	s := setupPublishTest(t, PublishRetryConfig{
+		MaxQueued:      16,
+		SupersedeDepth: 4,
+		TTL:            time.Minute,
+		Backoff:        50 * time.Millisecond,
+	})
+
+	// the publisher has no peers yet: the blocks are queued
+	s.publish(t, 1)
+	s.publish(t, 2)
+	require.Eventually(t, func() bool {
+		_, retries, _ := s.metrics.counts()
+		return retries >= 2
+	}, 10*time.Second, 10*time.Millisecond, "failed publication is retried")
+	require.Empty(t, s.received)
+
+	// the publisher gains a peer after two blocks
+	s.join(t)
+	s.expectReceived(t, 1, 2)
+	s.publish(t, 3)
+	s.expectReceived(t, 3)
+
+	attempts, retries, drops := s.metrics.counts()
+	require.Empty(t, drops)
+	// block 1 is retried until the verifier joins, the later blocks are published on the first attempt
+	require.Equal(t, attempts, retries+3)
+}
+
+func TestPublishRetryDrops(t *testing.T) {
+	t.Run("superseded", func(t *testing.T) {
+		s := setupPublishTest(t, PublishRetryConfig{
+			MaxQueued:      16,
+			SupersedeDepth: 2,
+			TTL:            time.Minute,
+			Backoff:        50 * time.Millisecond,
+		})
+		for num := uint64(1); num <= 6; num++ {
+			s.publish(t, num)
+		}
+		_, _, drops := s.metrics.counts()
+		require.Equal(t, map[string]int{PublishDropSuperseded: 3}, drops)
+
+		s.join(t)
+		s.expectReceived(t, 4, 5, 6)
+	})
+	t.Run("overflow", func(t *testing.T) {
+		s := setupPublishTest(t, PublishRetryConfig{
+			MaxQueued:      2,
+			SupersedeDepth: 10,
+			TTL:            time.Minute,
+			Backoff:        50 * time.Millisecond,
+		})
+		for num := uint64(1); num <= 4; num++ {
+			s.publish(t, num)
+		}
+		_, _, drops := s.metrics.counts()
+		require.Equal(t, map[string]int{PublishDropOverflow: 2}, drops)
+
+		s.join(t)
+		s.expectReceived(t, 3, 4)
+	})
+	t.Run("expired", func(t *testing.T) {
+		s := setupPublishTest(t, PublishRetryConfig{
+			MaxQueued:      16,
+			SupersedeDepth: 10,
+			TTL:            200 * time.Millisecond,
+			Backoff:        50 * time.Millisecond,
+		})
+		s.publish(t, 1)
+		s.publish(t, 2)
+		require.Eventually(t, func() bool {
+			_, _, drops := s.metrics.counts()
+			return drops[PublishDropExpired] == 2
+		}, 10*time.Second, 10*time.Millisecond)
+
+		s.join(t)
+		s.publish(t, 3)
+		s.expectReceived(t, 3)
+	})
+}
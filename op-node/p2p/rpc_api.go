@@ -27,10 +27,18 @@ type PeerInfo struct {
 	Protected     bool                  `json:"protected"`     // Protected peers do not get
 	ChainID       uint64                `json:"chainID"`       // some peers might try to connect, but we figure out they are on a different chain later. This may be 0 if the peer is not an optimism node at all.
 	Latency       time.Duration         `json:"latency"`
+	// ConnectedDuration is how long the oldest open connection to the peer has been open, 0 if not connected.
+	ConnectedDuration time.Duration `json:"connectedDuration"`
 
-	GossipBlocks bool `json:"gossipBlocks"` // if the peer is in our gossip topic
+	GossipBlocks bool     `json:"gossipBlocks"` // if the peer is in our gossip topic
+	Topics       []string `json:"topics"`       // the gossip topics the peer is subscribed to, of the topics we joined
 
 	PeerScores store.PeerScores `json:"scores"`
+
+	// Banned is true if the peer is blocked by the connection gater, or has an expiring ban.
+	Banned bool `json:"banned"`
+	// BanExpiry is the time the expiring ban of the peer ends, zero if there is none.
+	BanExpiry time.Time `json:"banExpiry"`
 }
 
 type PeerDump struct {
@@ -41,6 +49,12 @@ type PeerDump struct {
 	BannedSubnets  []*net.IPNet         `json:"bannedSubnets"`
 }
 
+// BanList lists the expiring bans, as issued by peer scoring or upon protocol violations, and their expiry time.
+type BanList struct {
+	Peers map[peer.ID]time.Time `json:"peers"`
+	IPs   map[string]time.Time  `json:"ips"`
+}
+
 type API interface {
 	Self(ctx context.Context) (*PeerInfo, error)
 	Peers(ctx context.Context, connected bool) (*PeerDump, error)
@@ -55,6 +69,7 @@ type API interface {
 	BlockSubnet(ctx context.Context, ipnet *net.IPNet) error
 	UnblockSubnet(ctx context.Context, ipnet *net.IPNet) error
 	ListBlockedSubnets(ctx context.Context) ([]*net.IPNet, error)
+	ListBans(ctx context.Context) (*BanList, error)
 	ProtectPeer(ctx context.Context, p peer.ID) error
 	UnprotectPeer(ctx context.Context, p peer.ID) error
 	ConnectPeer(ctx context.Context, addr string) error
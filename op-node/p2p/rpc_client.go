@@ -64,6 +64,12 @@ func (c *Client) ListBlockedPeers(ctx context.Context) ([]peer.ID, error) {
 	return out, err
 }
 
+func (c *Client) ListBans(ctx context.Context) (*BanList, error) {
+	var out *BanList
+	err := c.c.CallContext(ctx, &out, prefixRPC("listBans"))
+	return out, err
+}
+
 func (c *Client) BlockAddr(ctx context.Context, ip net.IP) error {
 	return c.c.CallContext(ctx, nil, prefixRPC("blockAddr"), ip)
 }
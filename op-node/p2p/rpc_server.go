@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net"
+	"sort"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/p2p/gating"
@@ -16,9 +18,11 @@ import (
 	"github.com/libp2p/go-libp2p/core/connmgr"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
+	p2pmetrics "github.com/libp2p/go-libp2p/core/metrics"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
+	manet "github.com/multiformats/go-multiaddr/net"
 
 	gcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
@@ -36,6 +40,7 @@ var (
 	ErrDisabledDiscovery   = errors.New("discovery disabled")
 	ErrNoConnectionManager = errors.New("no connection manager")
 	ErrNoConnectionGater   = errors.New("no connection gater")
+	ErrAdminDisabled       = errors.New("p2p admin methods are disabled, enable the admin RPC to use them")
 )
 
 type Node interface {
@@ -53,25 +58,50 @@ type Node interface {
 	ConnectionGater() gating.BlockingConnectionGater
 	// ConnectionManager returns the connection manager, to protect peers with, may be nil
 	ConnectionManager() connmgr.ConnManager
+	// BandwidthCounter returns the bandwidth counter of the host and discovery, may be nil
+	BandwidthCounter() *p2pmetrics.BandwidthCounter
 }
 
 type APIBackend struct {
 	node Node
 	log  log.Logger
 	m    metrics.Metricer
+	// admin enables the methods that change the peering of the node, like blocking and protecting peers.
+	admin bool
 }
 
 var _ API = (*APIBackend)(nil)
 
-func NewP2PAPIBackend(node Node, log log.Logger, m metrics.Metricer) *APIBackend {
+func NewP2PAPIBackend(node Node, log log.Logger, m metrics.Metricer, admin bool) *APIBackend {
 	if m == nil {
 		m = metrics.NoopMetrics
 	}
 
 	return &APIBackend{
-		node: node,
-		log:  log,
-		m:    m,
+		node:  node,
+		log:   log,
+		m:     m,
+		admin: admin,
+	}
+}
+
+func (s *APIBackend) checkAdmin() error {
+	if !s.admin {
+		return ErrAdminDisabled
+	}
+	return nil
+}
+
+// closeConns closes all connections to remote IP addresses that match the given filter.
+func (s *APIBackend) closeConns(match func(ip net.IP) bool) {
+	for _, conn := range s.node.Host().Network().Conns() {
+		ip, err := manet.ToIP(conn.RemoteMultiaddr())
+		if err != nil || !match(ip) {
+			continue
+		}
+		if err := conn.Close(); err != nil {
+			s.log.Warn("failed to close connection to blocked peer", "peer", conn.RemotePeer(), "ip", ip, "err", err)
+		}
 	}
 }
 
@@ -87,6 +117,10 @@ func (s *APIBackend) Self(ctx context.Context) (*PeerInfo, error) {
 	}
 	info.GossipBlocks = true
 	info.Latency = 0
+	if ps := s.node.GossipSub(); ps != nil {
+		info.Topics = ps.GetTopics()
+		sort.Strings(info.Topics)
+	}
 	if local := s.node.Dv5Local(); local != nil {
 		info.ENR = local.Node().String()
 	}
@@ -119,6 +153,10 @@ func dumpPeer(id peer.ID, nw network.Network, pstore peerstore.Peerstore, connMg
 			info.ENR = md.ENR
 			info.ChainID = md.OPStackID
 		}
+		if expiry, err := eps.GetPeerBanExpiration(id); err == nil && expiry.After(time.Now()) {
+			info.Banned = true
+			info.BanExpiry = expiry
+		}
 	}
 	if dat, err := pstore.Get(id, "ProtocolVersion"); err == nil {
 		protocolVersion, ok := dat.(string)
@@ -146,9 +184,14 @@ func dumpPeer(id peer.ID, nw network.Network, pstore peerstore.Peerstore, connMg
 		}
 	}
 	// get the first connection direction, if any (will default to unknown when there are no connections)
-	for _, c := range nw.ConnsToPeer(id) {
-		info.Direction = c.Stat().Direction
-		break
+	for i, c := range nw.ConnsToPeer(id) {
+		stat := c.Stat()
+		if i == 0 {
+			info.Direction = stat.Direction
+		}
+		if d := time.Since(stat.Opened); d > info.ConnectedDuration {
+			info.ConnectedDuration = d
+		}
 	}
 	info.Latency = pstore.LatencyEWMA(id)
 	if connMgr != nil {
@@ -191,14 +234,69 @@ func (s *APIBackend) Peers(ctx context.Context, connected bool) (*PeerDump, erro
 			p.GossipBlocks = true
 		}
 	}
+	if ps := s.node.GossipSub(); ps != nil {
+		for id, topics := range topicsByPeer(ps) {
+			if p, ok := dump.Peers[id.String()]; ok {
+				p.Topics = topics
+			}
+		}
+	}
 	if gater := s.node.ConnectionGater(); gater != nil {
 		dump.BannedPeers = gater.ListBlockedPeers()
 		dump.BannedSubnets = gater.ListBlockedSubnets()
 		dump.BannedIPS = gater.ListBlockedAddrs()
+		for _, id := range dump.BannedPeers {
+			if p, ok := dump.Peers[id.String()]; ok {
+				p.Banned = true
+			}
+		}
 	}
 	return dump, nil
 }
 
+// topicsByPeer lists the sorted gossip topics each peer is subscribed to, of the topics we joined.
+func topicsByPeer(ps *pubsub.PubSub) map[peer.ID][]string {
+	out := make(map[peer.ID][]string)
+	topics := ps.GetTopics()
+	sort.Strings(topics)
+	for _, topic := range topics {
+		for _, id := range ps.ListPeers(topic) {
+			out[id] = append(out[id], topic)
+		}
+	}
+	return out
+}
+
+// peerScoreBucketBounds are the upper bounds of the gossip score histogram in the peer stats,
+// the last bucket is unbounded.
+var peerScoreBucketBounds = []float64{-100, -10, 0, 10, 100}
+
+// ScoreBucket counts the connected peers with a total gossip score in the (Min, Max] range.
+type ScoreBucket struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Count uint    `json:"count"`
+}
+
+func newScoreBuckets() []ScoreBucket {
+	buckets := make([]ScoreBucket, 0, len(peerScoreBucketBounds)+1)
+	lower := -math.MaxFloat64
+	for _, upper := range peerScoreBucketBounds {
+		buckets = append(buckets, ScoreBucket{Min: lower, Max: upper})
+		lower = upper
+	}
+	return append(buckets, ScoreBucket{Min: lower, Max: math.MaxFloat64})
+}
+
+func addScore(buckets []ScoreBucket, score float64) {
+	for i := range buckets {
+		if score <= buckets[i].Max {
+			buckets[i].Count++
+			return
+		}
+	}
+}
+
 type PeerStats struct {
 	Connected     uint `json:"connected"`
 	Table         uint `json:"table"`
@@ -206,6 +304,14 @@ type PeerStats struct {
 	BlocksTopicV2 uint `json:"blocksTopicV2"`
 	Banned        uint `json:"banned"`
 	Known         uint `json:"known"`
+	// BandwidthIn and BandwidthOut are the total bytes received and sent, by the host and discovery
+	BandwidthIn  int64 `json:"bandwidthIn"`
+	BandwidthOut int64 `json:"bandwidthOut"`
+	// Inbound and Outbound count the connected peers by the direction of their first connection
+	Inbound  uint `json:"inbound"`
+	Outbound uint `json:"outbound"`
+	// Scores is a histogram of the total gossip scores of the connected peers
+	Scores []ScoreBucket `json:"scores"`
 }
 
 func (s *APIBackend) PeerStats(_ context.Context) (*PeerStats, error) {
@@ -229,6 +335,30 @@ func (s *APIBackend) PeerStats(_ context.Context) (*PeerStats, error) {
 	if dv5 := s.node.Dv5Udp(); dv5 != nil {
 		stats.Table = uint(len(dv5.AllNodes()))
 	}
+	if bwc := s.node.BandwidthCounter(); bwc != nil {
+		totals := bwc.GetBandwidthTotals()
+		stats.BandwidthIn = totals.TotalIn
+		stats.BandwidthOut = totals.TotalOut
+	}
+	stats.Scores = newScoreBuckets()
+	eps, _ := pstore.(store.ExtendedPeerstore)
+	for _, id := range nw.Peers() {
+		if conns := nw.ConnsToPeer(id); len(conns) > 0 {
+			switch conns[0].Stat().Direction {
+			case network.DirInbound:
+				stats.Inbound++
+			case network.DirOutbound:
+				stats.Outbound++
+			}
+		}
+		var score float64 // peers without a score record count as neutral
+		if eps != nil {
+			if scores, err := eps.GetPeerScores(id); err == nil {
+				score = scores.Gossip.Total
+			}
+		}
+		addScore(stats.Scores, score)
+	}
 	return stats, nil
 }
 
@@ -245,16 +375,24 @@ func (s *APIBackend) DiscoveryTable(_ context.Context) ([]*enode.Node, error) {
 func (s *APIBackend) BlockPeer(_ context.Context, p peer.ID) error {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_blockPeer")
 	defer recordDur()
+	if err := s.checkAdmin(); err != nil {
+		return err
+	}
 	if gater := s.node.ConnectionGater(); gater == nil {
 		return ErrNoConnectionGater
-	} else {
-		return gater.BlockPeer(p)
+	} else if err := gater.BlockPeer(p); err != nil {
+		return err
 	}
+	// the gater only denies new connections, close the existing ones too
+	return s.node.Host().Network().ClosePeer(p)
 }
 
 func (s *APIBackend) UnblockPeer(_ context.Context, p peer.ID) error {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_unblockPeer")
 	defer recordDur()
+	if err := s.checkAdmin(); err != nil {
+		return err
+	}
 	if gater := s.node.ConnectionGater(); gater == nil {
 		return ErrNoConnectionGater
 	} else {
@@ -272,21 +410,47 @@ func (s *APIBackend) ListBlockedPeers(_ context.Context) ([]peer.ID, error) {
 	}
 }
 
-// BlockAddr adds an IP address to the set of blocked addresses.
-// Note: active connections to the IP address are not automatically closed.
+// ListBans lists the expiring peer and IP bans, which are persisted in the peerstore.
+func (s *APIBackend) ListBans(_ context.Context) (*BanList, error) {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_listBans")
+	defer recordDur()
+	eps, ok := s.node.Host().Peerstore().(store.ExtendedPeerstore)
+	if !ok {
+		return nil, errors.New("peerstore does not track bans")
+	}
+	peers, err := eps.PeerBans()
+	if err != nil {
+		return nil, err
+	}
+	ips, err := eps.IPBans()
+	if err != nil {
+		return nil, err
+	}
+	return &BanList{Peers: peers, IPs: ips}, nil
+}
+
+// BlockAddr adds an IP address to the set of blocked addresses, and closes active connections to the IP address.
 func (s *APIBackend) BlockAddr(_ context.Context, ip net.IP) error {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_blockAddr")
 	defer recordDur()
+	if err := s.checkAdmin(); err != nil {
+		return err
+	}
 	if gater := s.node.ConnectionGater(); gater == nil {
 		return ErrNoConnectionGater
-	} else {
-		return gater.BlockAddr(ip)
+	} else if err := gater.BlockAddr(ip); err != nil {
+		return err
 	}
+	s.closeConns(ip.Equal)
+	return nil
 }
 
 func (s *APIBackend) UnblockAddr(_ context.Context, ip net.IP) error {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_unblockAddr")
 	defer recordDur()
+	if err := s.checkAdmin(); err != nil {
+		return err
+	}
 	if gater := s.node.ConnectionGater(); gater == nil {
 		return ErrNoConnectionGater
 	} else {
@@ -304,21 +468,28 @@ func (s *APIBackend) ListBlockedAddrs(_ context.Context) ([]net.IP, error) {
 	}
 }
 
-// BlockSubnet adds an IP subnet to the set of blocked addresses.
-// Note: active connections to the IP subnet are not automatically closed.
+// BlockSubnet adds an IP subnet to the set of blocked addresses, and closes active connections to the IP subnet.
 func (s *APIBackend) BlockSubnet(_ context.Context, ipnet *net.IPNet) error {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_blockSubnet")
 	defer recordDur()
+	if err := s.checkAdmin(); err != nil {
+		return err
+	}
 	if gater := s.node.ConnectionGater(); gater == nil {
 		return ErrNoConnectionGater
-	} else {
-		return gater.BlockSubnet(ipnet)
+	} else if err := gater.BlockSubnet(ipnet); err != nil {
+		return err
 	}
+	s.closeConns(ipnet.Contains)
+	return nil
 }
 
 func (s *APIBackend) UnblockSubnet(_ context.Context, ipnet *net.IPNet) error {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_unblockSubnet")
 	defer recordDur()
+	if err := s.checkAdmin(); err != nil {
+		return err
+	}
 	if gater := s.node.ConnectionGater(); gater == nil {
 		return ErrNoConnectionGater
 	} else {
@@ -339,6 +510,9 @@ func (s *APIBackend) ListBlockedSubnets(_ context.Context) ([]*net.IPNet, error)
 func (s *APIBackend) ProtectPeer(_ context.Context, p peer.ID) error {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_protectPeer")
 	defer recordDur()
+	if err := s.checkAdmin(); err != nil {
+		return err
+	}
 	if manager := s.node.ConnectionManager(); manager == nil {
 		return ErrNoConnectionManager
 	} else {
@@ -350,6 +524,9 @@ func (s *APIBackend) ProtectPeer(_ context.Context, p peer.ID) error {
 func (s *APIBackend) UnprotectPeer(_ context.Context, p peer.ID) error {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_unprotectPeer")
 	defer recordDur()
+	if err := s.checkAdmin(); err != nil {
+		return err
+	}
 	if manager := s.node.ConnectionManager(); manager == nil {
 		return ErrNoConnectionManager
 	} else {
@@ -362,6 +539,9 @@ func (s *APIBackend) UnprotectPeer(_ context.Context, p peer.ID) error {
 func (s *APIBackend) ConnectPeer(ctx context.Context, addr string) error {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_connectPeer")
 	defer recordDur()
+	if err := s.checkAdmin(); err != nil {
+		return err
+	}
 	h := s.node.Host()
 	addrInfo, err := peer.AddrInfoFromString(addr)
 	if err != nil {
@@ -376,5 +556,8 @@ func (s *APIBackend) ConnectPeer(ctx context.Context, addr string) error {
 func (s *APIBackend) DisconnectPeer(_ context.Context, id peer.ID) error {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_disconnectPeer")
 	defer recordDur()
+	if err := s.checkAdmin(); err != nil {
+		return err
+	}
 	return s.node.Host().Network().ClosePeer(id)
 }
@@ -4,13 +4,16 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 )
 
 var SigningDomainBlocksV1 = [32]byte{}
@@ -21,18 +24,7 @@ type Signer interface {
 }
 
 func SigningHash(domain [32]byte, chainID *big.Int, payloadBytes []byte) (common.Hash, error) {
-	var msgInput [32 + 32 + 32]byte
-	// domain: first 32 bytes
-	copy(msgInput[:32], domain[:])
-	// chain_id: second 32 bytes
-	if chainID.BitLen() > 256 {
-		return common.Hash{}, errors.New("chain_id is too large")
-	}
-	chainID.FillBytes(msgInput[32:64])
-	// payload_hash: third 32 bytes, hash of encoded payload
-	copy(msgInput[64:], crypto.Keccak256(payloadBytes))
-
-	return crypto.Keccak256Hash(msgInput[:]), nil
+	return opsigner.BlockPayloadSigningHash(domain, chainID, crypto.Keccak256Hash(payloadBytes))
 }
 
 func BlockSigningHash(cfg *rollup.Config, payloadBytes []byte) (common.Hash, error) {
@@ -69,6 +61,25 @@ func (s *LocalSigner) Close() error {
 	return nil
 }
 
+// RemoteSigner signs the block payloads with a remote signer, for the address of the sequencer.
+type RemoteSigner struct {
+	client  *opsigner.SignerClient
+	address common.Address
+}
+
+func NewRemoteSigner(client *opsigner.SignerClient, address common.Address) *RemoteSigner {
+	return &RemoteSigner{client: client, address: address}
+}
+
+func (s *RemoteSigner) Sign(ctx context.Context, domain [32]byte, chainID *big.Int, encodedMsg []byte) (sig *[65]byte, err error) {
+	return s.client.SignBlockPayload(ctx, chainID, s.address, domain, crypto.Keccak256Hash(encodedMsg))
+}
+
+func (s *RemoteSigner) Close() error {
+	s.client.Close()
+	return nil
+}
+
 type PreparedSigner struct {
 	Signer
 }
@@ -80,3 +91,17 @@ func (p *PreparedSigner) SetupSigner(ctx context.Context) (Signer, error) {
 type SignerSetup interface {
 	SetupSigner(ctx context.Context) (Signer, error)
 }
+
+// RemoteSignerSetup connects to the remote signer when the signer is set up.
+type RemoteSignerSetup struct {
+	Config opsigner.CLIConfig
+	Log    log.Logger
+}
+
+func (r *RemoteSignerSetup) SetupSigner(ctx context.Context) (Signer, error) {
+	client, err := opsigner.NewSignerClientFromConfig(r.Log, r.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the signer client: %w", err)
+	}
+	return NewRemoteSigner(client, common.HexToAddress(r.Config.Address)), nil
+}
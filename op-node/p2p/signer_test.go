@@ -1,11 +1,21 @@
 package p2p
 
 import (
+	"context"
+	"crypto/ecdsa"
 	"math/big"
+	"net/http/httptest"
 	"testing"
 
-	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
 func TestSigningHash_DifferentDomain(t *testing.T) {
@@ -65,3 +75,50 @@ func TestSigningHash_LimitChainID(t *testing.T) {
 	_, err := SigningHash(SigningDomainBlocksV1, cfg.L2ChainID, []byte("arbitraryData"))
 	require.ErrorContains(t, err, "chain_id is too large")
 }
+
+type stubOpSignerAPI struct {
+	key *ecdsa.PrivateKey
+}
+
+func (a *stubOpSignerAPI) SignBlockPayload(args opsigner.BlockPayloadArgs) (hexutil.Bytes, error) {
+	signingHash, err := args.SigningHash()
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(signingHash[:], a.key)
+}
+
+type stubHealthAPI struct{}
+
+func (stubHealthAPI) Status() string {
+	return "ok"
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("health", stubHealthAPI{}))
+	require.NoError(t, server.RegisterName("opsigner", &stubOpSignerAPI{key: key}))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	setup := &RemoteSignerSetup{
+		Config: opsigner.CLIConfig{
+			Endpoint: httpServer.URL,
+			Address:  crypto.PubkeyToAddress(key.PublicKey).Hex(),
+		},
+		Log: testlog.Logger(t, log.LvlInfo),
+	}
+	signer, err := setup.SetupSigner(context.Background())
+	require.NoError(t, err)
+	defer signer.Close()
+
+	chainID := big.NewInt(100)
+	payloadBytes := []byte("arbitraryData")
+	sig, err := signer.Sign(context.Background(), SigningDomainBlocksV1, chainID, payloadBytes)
+	require.NoError(t, err)
+	expected, err := NewLocalSigner(key).Sign(context.Background(), SigningDomainBlocksV1, chainID, payloadBytes)
+	require.NoError(t, err)
+	require.Equal(t, expected, sig, "the remote signer signs the same hash as the local signer")
+}
@@ -18,10 +18,12 @@ type TopicScores struct {
 }
 
 type GossipScores struct {
-	Total              float64     `json:"total"`
-	Blocks             TopicScores `json:"blocks"` // fully zeroed if the peer has not been in the mesh on the topic
-	IPColocationFactor float64     `json:"IPColocationFactor"`
-	BehavioralPenalty  float64     `json:"behavioralPenalty"`
+	Total  float64     `json:"total"`
+	Blocks TopicScores `json:"blocks"` // fully zeroed if the peer has not been in the mesh on the topic
+	// Topics breaks down the score per gossip topic, for all topics the peer has a score on.
+	Topics             map[string]TopicScores `json:"topics,omitempty"`
+	IPColocationFactor float64                `json:"IPColocationFactor"`
+	BehavioralPenalty  float64                `json:"behavioralPenalty"`
 }
 
 func (g GossipScores) Apply(rec *scoreRecord) {
@@ -120,6 +122,13 @@ type IPBanStore interface {
 	GetIPBanExpiration(ip net.IP) (time.Time, error)
 }
 
+type BanLister interface {
+	// PeerBans returns the peers with an active ban, and their ban expiration time.
+	PeerBans() (map[peer.ID]time.Time, error)
+	// IPBans returns the IPs with an active ban, and their ban expiration time.
+	IPBans() (map[string]time.Time, error)
+}
+
 type MetadataStore interface {
 	// SetPeerMetadata sets the metadata for the specified peer
 	SetPeerMetadata(id peer.ID, md PeerMetadata) (PeerMetadata, error)
@@ -134,5 +143,6 @@ type ExtendedPeerstore interface {
 	peerstore.CertifiedAddrBook
 	PeerBanStore
 	IPBanStore
+	BanLister
 	MetadataStore
 }
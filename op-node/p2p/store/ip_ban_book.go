@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net"
 	"time"
 
@@ -88,6 +89,23 @@ func (d *ipBanBook) SetIPBanExpiration(ip net.IP, expirationTime time.Time) erro
 	return err
 }
 
+func (d *ipBanBook) IPBans() (map[string]time.Time, error) {
+	recs, err := d.book.records()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list IP bans: %w", err)
+	}
+	now := d.book.clock.Now()
+	out := make(map[string]time.Time, len(recs))
+	for key, rec := range recs {
+		expiry := time.Unix(rec.Expiry, 0)
+		if !expiry.After(now) {
+			continue
+		}
+		out[key] = expiry
+	}
+	return out, nil
+}
+
 func (d *ipBanBook) Close() {
 	d.book.Close()
 }
@@ -3,12 +3,14 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum/go-ethereum/log"
 	ds "github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-base32"
 )
 
 const (
@@ -84,6 +86,27 @@ func (d *peerBanBook) SetPeerBanExpiration(id peer.ID, expirationTime time.Time)
 	return err
 }
 
+func (d *peerBanBook) PeerBans() (map[peer.ID]time.Time, error) {
+	recs, err := d.book.records()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list peer bans: %w", err)
+	}
+	now := d.book.clock.Now()
+	out := make(map[peer.ID]time.Time, len(recs))
+	for key, rec := range recs {
+		expiry := time.Unix(rec.Expiry, 0)
+		if !expiry.After(now) {
+			continue
+		}
+		id, err := base32.RawStdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer ban key %q: %w", key, err)
+		}
+		out[peer.ID(id)] = expiry
+	}
+	return out, nil
+}
+
 func (d *peerBanBook) Close() {
 	d.book.Close()
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 )
 
@@ -31,6 +32,20 @@ func TestRoundTripPeerBan(t *testing.T) {
 	require.Equal(t, result, expiry)
 }
 
+func TestListPeerBans(t *testing.T) {
+	book := createMemoryPeerBanBook(t)
+	defer book.Close()
+	expiry := time.Unix(2484924, 0)
+	require.NoError(t, book.SetPeerBanExpiration("a", expiry))
+	require.NoError(t, book.SetPeerBanExpiration("b", expiry.Add(time.Hour)))
+	require.NoError(t, book.SetPeerBanExpiration("expired", time.Unix(0, 0)))
+	require.NoError(t, book.SetPeerBanExpiration("deleted", expiry))
+	require.NoError(t, book.SetPeerBanExpiration("deleted", time.Time{}))
+	bans, err := book.PeerBans()
+	require.NoError(t, err)
+	require.Equal(t, map[peer.ID]time.Time{"a": expiry, "b": expiry.Add(time.Hour)}, bans)
+}
+
 func createMemoryPeerBanBook(t *testing.T) *peerBanBook {
 	store := sync.MutexWrap(ds.NewMapDatastore())
 	logger := testlog.Logger(t, log.LvlInfo)
@@ -197,6 +197,34 @@ func (d *recordsBook[K, V]) prune() error {
 	return nil
 }
 
+// records returns all records in the store that have not expired, keyed by the name of their entry key.
+func (d *recordsBook[K, V]) records() (map[string]V, error) {
+	d.RLock()
+	defer d.RUnlock()
+	results, err := d.store.Query(d.ctx, query.Query{
+		Prefix: d.dsBaseKey.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+	out := make(map[string]V)
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		v := d.newRecord()
+		if err := v.UnmarshalBinary(result.Value); err != nil {
+			return nil, fmt.Errorf("invalid value for key %v: %w", result.Key, err)
+		}
+		if d.hasExpired(v) {
+			continue
+		}
+		out[ds.NewKey(result.Key).BaseNamespace()] = v
+	}
+	return out, nil
+}
+
 func (d *recordsBook[K, V]) hasExpired(v V) bool {
 	return v.LastUpdated().Add(d.recordExpiry).Before(d.clock.Now())
 }
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"reflect"
 	"strconv"
 	"testing"
 	"time"
@@ -174,7 +175,7 @@ func TestPrune(t *testing.T) {
 	hasScoreRecorded := func(id peer.ID) bool {
 		scores, err := book.GetPeerScores(id)
 		require.NoError(t, err)
-		return scores != PeerScores{}
+		return !reflect.DeepEqual(scores, PeerScores{})
 	}
 
 	firstStore := clock.Now()
@@ -228,7 +229,7 @@ func TestPruneMultipleBatches(t *testing.T) {
 	hasScoreRecorded := func(id peer.ID) bool {
 		scores, err := book.GetPeerScores(id)
 		require.NoError(t, err)
-		return scores != PeerScores{}
+		return !reflect.DeepEqual(scores, PeerScores{})
 	}
 
 	// Set scores for more peers than the max batch size
@@ -23,6 +23,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
@@ -59,12 +60,19 @@ const (
 	// and eventually kick the peer based on degraded scoring if it's really not serving us well.
 	// TODO(CLI-4009): Use a backoff rather than this mechanism.
 	clientErrRateCost = peerServerBlocksBurst
+	// Maximum number of consecutive blocks that can be requested with a single range request.
+	// This stays below the per-peer burst, so a range can always be served without exceeding it.
+	maxPayloadsPerRangeRequest = 8
 )
 
 func PayloadByNumberProtocolID(l2ChainID *big.Int) protocol.ID {
 	return protocol.ID(fmt.Sprintf("/opstack/req/payload_by_number/%d/0", l2ChainID))
 }
 
+func PayloadsByRangeProtocolID(l2ChainID *big.Int) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/opstack/req/payloads_by_range/%d/0", l2ChainID))
+}
+
 type requestHandlerFn func(ctx context.Context, log log.Logger, stream network.Stream)
 
 func MakeStreamHandler(resourcesCtx context.Context, log log.Logger, fn requestHandlerFn) network.StreamHandler {
@@ -82,7 +90,7 @@ func MakeStreamHandler(resourcesCtx context.Context, log log.Logger, fn requestH
 
 type newStreamFn func(ctx context.Context, peerId peer.ID, protocolId ...protocol.ID) (network.Stream, error)
 
-type receivePayloadFn func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) error
+type receivePayloadFn func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayloadEnvelope) error
 
 type rangeRequest struct {
 	start uint64
@@ -90,8 +98,8 @@ type rangeRequest struct {
 }
 
 type syncResult struct {
-	payload *eth.ExecutionPayload
-	peer    peer.ID
+	envelope *eth.ExecutionPayloadEnvelope
+	peer     peer.ID
 }
 
 type peerRequest struct {
@@ -109,8 +117,12 @@ type inFlightCheck struct {
 type SyncClientMetrics interface {
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
+	RecordAltSyncPayloads(source string, result string, n int)
 }
 
+// altSyncSourceP2P is the alt-sync source label of payloads synced from peers.
+const altSyncSourceP2P = "p2p"
+
 type SyncPeerScorer interface {
 	onValidResponse(id peer.ID)
 	onResponseError(id peer.ID)
@@ -186,11 +198,13 @@ type SyncClient struct {
 
 	cfg *rollup.Config
 
-	metrics   SyncClientMetrics
-	appScorer SyncPeerScorer
+	metrics    SyncClientMetrics
+	appScorer  SyncPeerScorer
+	violations ViolationReporter
 
 	newStreamFn     newStreamFn
 	payloadByNumber protocol.ID
+	payloadsByRange protocol.ID
 
 	peersLock sync.Mutex
 	// syncing worker per peer
@@ -234,7 +248,7 @@ type SyncClient struct {
 	closingPeers bool
 }
 
-func NewSyncClient(log log.Logger, cfg *rollup.Config, newStream newStreamFn, rcv receivePayloadFn, metrics SyncClientMetrics, appScorer SyncPeerScorer) *SyncClient {
+func NewSyncClient(log log.Logger, cfg *rollup.Config, newStream newStreamFn, rcv receivePayloadFn, metrics SyncClientMetrics, appScorer SyncPeerScorer, violations ViolationReporter) *SyncClient {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	c := &SyncClient{
@@ -242,8 +256,10 @@ func NewSyncClient(log log.Logger, cfg *rollup.Config, newStream newStreamFn, rc
 		cfg:             cfg,
 		metrics:         metrics,
 		appScorer:       appScorer,
+		violations:      violations,
 		newStreamFn:     newStream,
 		payloadByNumber: PayloadByNumberProtocolID(cfg.L2ChainID),
+		payloadsByRange: PayloadsByRangeProtocolID(cfg.L2ChainID),
 		peers:           make(map[peer.ID]context.CancelFunc),
 		quarantineByNum: make(map[uint64]common.Hash),
 		inFlight:        make(map[uint64]*atomic.Bool),
@@ -390,6 +406,13 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 		}
 	}
 
+	scheduled := 0
+	defer func() {
+		if scheduled > 0 {
+			s.metrics.RecordAltSyncPayloads(altSyncSourceP2P, metrics.AltSyncRequested, scheduled)
+		}
+	}()
+
 	// Now try to fetch lower numbers than current end, to traverse back towards the updated start.
 	for i := uint64(0); ; i++ {
 		num := req.end.Number - 1 - i
@@ -417,6 +440,7 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 		select {
 		case s.peerRequests <- pr:
 			s.inFlight[num] = pr.complete
+			scheduled++
 		case <-ctx.Done():
 			log.Info("did not schedule full P2P sync range", "current", num, "err", ctx.Err())
 			return
@@ -428,14 +452,14 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 }
 
 func (s *SyncClient) onQuarantineEvict(key common.Hash, value syncResult) {
-	delete(s.quarantineByNum, uint64(value.payload.BlockNumber))
+	delete(s.quarantineByNum, uint64(value.envelope.ExecutionPayload.BlockNumber))
 	s.metrics.PayloadsQuarantineSize(s.quarantine.Len())
 	if !s.trusted.Contains(key) {
-		s.log.Debug("evicting untrusted payload from quarantine", "id", value.payload.ID(), "peer", value.peer)
+		s.log.Debug("evicting untrusted payload from quarantine", "id", value.envelope.ExecutionPayload.ID(), "peer", value.peer)
 		// Down-score peer for having provided us a bad block that never turned out to be canonical
 		s.appScorer.onRejectedPayload(value.peer)
 	} else {
-		s.log.Debug("evicting trusted payload from quarantine", "id", value.payload.ID(), "peer", value.peer)
+		s.log.Debug("evicting trusted payload from quarantine", "id", value.envelope.ExecutionPayload.ID(), "peer", value.peer)
 	}
 }
 
@@ -455,27 +479,28 @@ func (s *SyncClient) tryPromote(h common.Hash) {
 }
 
 func (s *SyncClient) promote(ctx context.Context, res syncResult) {
-	s.log.Debug("promoting p2p sync result", "payload", res.payload.ID(), "peer", res.peer)
-	if err := s.receivePayload(ctx, res.peer, res.payload); err != nil {
+	s.log.Debug("promoting p2p sync result", "payload", res.envelope.ExecutionPayload.ID(), "peer", res.peer)
+	if err := s.receivePayload(ctx, res.peer, res.envelope); err != nil {
 		s.log.Warn("failed to promote payload, receiver error", "err", err)
 		return
 	}
-	s.trusted.Add(res.payload.BlockHash, struct{}{})
-	if s.quarantine.Remove(res.payload.BlockHash) {
-		s.log.Debug("promoted previously p2p-synced block from quarantine to main", "id", res.payload.ID())
+	s.metrics.RecordAltSyncPayloads(altSyncSourceP2P, metrics.AltSyncReceived, 1)
+	s.trusted.Add(res.envelope.ExecutionPayload.BlockHash, struct{}{})
+	if s.quarantine.Remove(res.envelope.ExecutionPayload.BlockHash) {
+		s.log.Debug("promoted previously p2p-synced block from quarantine to main", "id", res.envelope.ExecutionPayload.ID())
 	} else {
-		s.log.Debug("promoted new p2p-synced block to main", "id", res.payload.ID())
+		s.log.Debug("promoted new p2p-synced block to main", "id", res.envelope.ExecutionPayload.ID())
 	}
 
 	// Mark parent block as trusted, so that we can promote it once we receive it / find it
-	s.trusted.Add(res.payload.ParentHash, struct{}{})
+	s.trusted.Add(res.envelope.ExecutionPayload.ParentHash, struct{}{})
 
 	// Try to promote the parent block too, if any: previous unverifiable data may now be canonical
-	s.tryPromote(res.payload.ParentHash)
+	s.tryPromote(res.envelope.ExecutionPayload.ParentHash)
 
 	// In case we don't have the parent, and what we have in quarantine is wrong,
 	// clear what we buffered in favor of fetching something else.
-	if h, ok := s.quarantineByNum[uint64(res.payload.BlockNumber)-1]; ok {
+	if h, ok := s.quarantineByNum[uint64(res.envelope.ExecutionPayload.BlockNumber)-1]; ok {
 		s.quarantine.Remove(h)
 	}
 }
@@ -483,15 +508,15 @@ func (s *SyncClient) promote(ctx context.Context, res syncResult) {
 // onResult is exclusively called by the main loop, and has thus direct access to the request bookkeeping state.
 // This function verifies if the result is canonical, and either promotes the result or moves the result into quarantine.
 func (s *SyncClient) onResult(ctx context.Context, res syncResult) {
-	s.log.Debug("processing p2p sync result", "payload", res.payload.ID(), "peer", res.peer)
+	s.log.Debug("processing p2p sync result", "payload", res.envelope.ExecutionPayload.ID(), "peer", res.peer)
 	// Clean up the in-flight request, we have a result now.
-	delete(s.inFlight, uint64(res.payload.BlockNumber))
+	delete(s.inFlight, uint64(res.envelope.ExecutionPayload.BlockNumber))
 	// Always put it in quarantine first. If promotion fails because the receiver is too busy, this functions as cache.
-	s.quarantine.Add(res.payload.BlockHash, res)
-	s.quarantineByNum[uint64(res.payload.BlockNumber)] = res.payload.BlockHash
+	s.quarantine.Add(res.envelope.ExecutionPayload.BlockHash, res)
+	s.quarantineByNum[uint64(res.envelope.ExecutionPayload.BlockNumber)] = res.envelope.ExecutionPayload.BlockHash
 	s.metrics.PayloadsQuarantineSize(s.quarantine.Len())
 	// If we know this block is canonical, then promote it
-	if s.trusted.Contains(res.payload.BlockHash) {
+	if s.trusted.Contains(res.envelope.ExecutionPayload.BlockHash) {
 		s.promote(ctx, res)
 	}
 }
@@ -513,6 +538,11 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 	// so we don't be too aggressive to the server.
 	rl := rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst)
 
+	// Peers that do not serve the range protocol are synced from one block at a time.
+	rangeSupported := true
+	// A request that was taken from the queue, but could not be batched with the previous requests.
+	var carry *peerRequest
+
 	for {
 		// wait for a global allocation to be available
 		if err := s.globalRL.Wait(ctx); err != nil {
@@ -524,43 +554,96 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 		}
 
 		// once the peer is available, wait for a sync request.
-		select {
-		case pr := <-s.peerRequests:
-			// We already established the peer is available w.r.t. rate-limiting,
-			// and this is the only loop over this peer, so we can request now.
-			start := time.Now()
-			err := s.doRequest(ctx, id, pr.num)
-			if err != nil {
-				// mark as complete if there's an error: we are not sending any result and can complete immediately.
-				pr.complete.Store(true)
-				log.Warn("failed p2p sync request", "num", pr.num, "err", err)
-				s.appScorer.onResponseError(id)
-				// If we hit an error, then count it as many requests.
-				// We'd like to avoid making more requests for a while, to back off.
-				if err := rl.WaitN(ctx, clientErrRateCost); err != nil {
-					return
+		var batch []peerRequest
+		if carry != nil {
+			batch, carry = []peerRequest{*carry}, nil
+		} else {
+			select {
+			case pr := <-s.peerRequests:
+				batch = []peerRequest{pr}
+			case <-ctx.Done():
+				return
+			}
+		}
+		// Requests are scheduled from high to low: batch the consecutive requests that are already queued.
+	batching:
+		for rangeSupported && len(batch) < maxPayloadsPerRangeRequest {
+			select {
+			case next := <-s.peerRequests:
+				if next.num+1 != batch[len(batch)-1].num {
+					carry = &next
+					break batching
 				}
-			} else {
-				log.Debug("completed p2p sync request", "num", pr.num)
-				s.appScorer.onValidResponse(id)
+				batch = append(batch, next)
+			default:
+				break batching
 			}
-			took := time.Since(start)
-
-			resultCode := byte(0)
-			if err != nil {
-				if re, ok := err.(requestResultErr); ok {
-					resultCode = re.ResultCode()
-				} else {
-					resultCode = 1
+		}
+
+		// We already established the peer is available w.r.t. rate-limiting,
+		// and this is the only loop over this peer, so we can request now.
+		start := time.Now()
+		var err error
+		if len(batch) > 1 {
+			// Account for the additional blocks: this delays the next request, rather than this one.
+			rl.ReserveN(start, len(batch)-1)
+			s.globalRL.ReserveN(start, len(batch)-1)
+			err = s.doRangeRequest(ctx, id, batch)
+			if errors.Is(err, errRangeUnsupported) {
+				log.Info("peer does not serve range sync requests, falling back to requests by number", "err", err)
+				rangeSupported = false
+				// hand back the remainder of the batch, so it can be picked up by any peer
+				for _, pr := range batch[1:] {
+					select {
+					case s.peerRequests <- pr:
+					default: // queue is full: complete it, the block may be requested again with the next range.
+						pr.complete.Store(true)
+					}
 				}
+				err = s.doRequest(ctx, id, batch[0].num)
+				batch = batch[:1]
+			}
+		} else {
+			err = s.doRequest(ctx, id, batch[0].num)
+		}
+		if err != nil {
+			// mark as complete if there's an error: we are not sending any result and can complete immediately.
+			for _, pr := range batch {
+				pr.complete.Store(true)
+			}
+			log.Warn("failed p2p sync request", "num", batch[0].num, "count", len(batch), "err", err)
+			s.appScorer.onResponseError(id)
+			if errors.Is(err, errMalformedResponse) {
+				s.violations.ReportViolation(id, "malformed sync response")
+				s.metrics.RecordAltSyncPayloads(altSyncSourceP2P, metrics.AltSyncInvalid, len(batch))
+			}
+			// If we hit an error, then count it as many requests.
+			// We'd like to avoid making more requests for a while, to back off.
+			if err := rl.WaitN(ctx, clientErrRateCost); err != nil {
+				return
+			}
+		} else {
+			log.Debug("completed p2p sync request", "num", batch[0].num, "count", len(batch))
+			s.appScorer.onValidResponse(id)
+		}
+		took := time.Since(start)
+
+		resultCode := byte(0)
+		if err != nil {
+			if re, ok := err.(requestResultErr); ok {
+				resultCode = re.ResultCode()
+			} else {
+				resultCode = 1
 			}
-			s.metrics.ClientPayloadByNumberEvent(pr.num, resultCode, took)
-		case <-ctx.Done():
-			return
 		}
+		s.metrics.ClientPayloadByNumberEvent(batch[0].num, resultCode, took)
 	}
 }
 
+// errMalformedResponse is returned when a peer responds with data that does not decode or verify,
+// which is a protocol violation rather than an unavailable block.
+var errMalformedResponse = errors.New("malformed response")
+
 type requestResultErr byte
 
 func (r requestResultErr) Error() string {
@@ -608,9 +691,6 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum
 		return fmt.Errorf("failed to read version part of response: %w", err)
 	}
 	version := binary.LittleEndian.Uint32(versionData[:])
-	if version != 0 {
-		return fmt.Errorf("unrecognized ExecutionPayload version: %d", version)
-	}
 	// payload is SSZ encoded with Snappy framed compression
 	r = snappy.NewReader(r)
 	r = io.LimitReader(r, maxGossipSize)
@@ -621,37 +701,75 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum
 		return fmt.Errorf("failed to read response: %w", err)
 	}
 
-	expectedBlockTime := s.cfg.TimestampForBlock(expectedBlockNum)
-
-	blockVersion := eth.BlockV1
-	if s.cfg.IsCanyon(expectedBlockTime) {
-		blockVersion = eth.BlockV2
-	}
-	var res eth.ExecutionPayload
-	if err := res.UnmarshalSSZ(blockVersion, uint32(len(data)), bytes.NewReader(data)); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	res, err := decodeSyncPayload(s.cfg, version, data, expectedBlockNum)
+	if err != nil {
+		return err
 	}
 
 	if err := str.CloseRead(); err != nil {
 		return fmt.Errorf("failed to close reading side")
 	}
-	if err := verifyBlock(&res, expectedBlockNum); err != nil {
-		return fmt.Errorf("received execution payload is invalid: %w", err)
+	if err := verifyBlock(res, expectedBlockNum); err != nil {
+		return fmt.Errorf("%w: received execution payload is invalid: %w", errMalformedResponse, err)
 	}
 	select {
-	case s.results <- syncResult{payload: &res, peer: id}:
+	case s.results <- syncResult{envelope: res, peer: id}:
 	case <-ctx.Done():
 		return fmt.Errorf("failed to process response, sync client is too busy: %w", err)
 	}
 	return nil
 }
 
-func verifyBlock(payload *eth.ExecutionPayload, expectedNum uint64) error {
+// decodeSyncPayload decodes the SSZ payload of a sync response. Version 0 is a bare execution payload,
+// version 1 an execution payload envelope, which is the only version of blocks since Eclipse.
+func decodeSyncPayload(cfg *rollup.Config, version uint32, data []byte, expectedBlockNum uint64) (*eth.ExecutionPayloadEnvelope, error) {
+	expectedBlockTime := cfg.TimestampForBlock(expectedBlockNum)
+	switch version {
+	case 0:
+		if cfg.IsEclipse(expectedBlockTime) {
+			return nil, fmt.Errorf("%w: received ExecutionPayload version 0 for Eclipse block %d", errMalformedResponse, expectedBlockNum)
+		}
+		blockVersion := eth.BlockV1
+		if cfg.IsCanyon(expectedBlockTime) {
+			blockVersion = eth.BlockV2
+		}
+		var res eth.ExecutionPayload
+		if err := res.UnmarshalSSZ(blockVersion, uint32(len(data)), bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("%w: failed to decode response: %w", errMalformedResponse, err)
+		}
+		return &eth.ExecutionPayloadEnvelope{ExecutionPayload: &res}, nil
+	case 1:
+		if !cfg.IsEclipse(expectedBlockTime) {
+			return nil, fmt.Errorf("%w: received ExecutionPayload version 1 for pre-Eclipse block %d", errMalformedResponse, expectedBlockNum)
+		}
+		var res eth.ExecutionPayloadEnvelope
+		if err := res.UnmarshalSSZ(uint32(len(data)), bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("%w: failed to decode response: %w", errMalformedResponse, err)
+		}
+		return &res, nil
+	default:
+		return nil, fmt.Errorf("%w: unrecognized ExecutionPayload version: %d", errMalformedResponse, version)
+	}
+}
+
+// encodeSyncPayload writes the SSZ payload of a sync response, and returns the version of it:
+// blocks with a parent beacon block root are served as envelope.
+func encodeSyncPayload(w io.Writer, envelope *eth.ExecutionPayloadEnvelope) (version uint32, err error) {
+	if envelope.ParentBeaconBlockRoot != nil {
+		_, err = envelope.MarshalSSZ(w)
+		return 1, err
+	}
+	_, err = envelope.ExecutionPayload.MarshalSSZ(w)
+	return 0, err
+}
+
+func verifyBlock(envelope *eth.ExecutionPayloadEnvelope, expectedNum uint64) error {
+	payload := envelope.ExecutionPayload
 	// verify L2 block
 	if expectedNum != uint64(payload.BlockNumber) {
 		return fmt.Errorf("received execution payload for block %d, but expected block %d", payload.BlockNumber, expectedNum)
 	}
-	actual, ok := payload.CheckBlockHash()
+	actual, ok := envelope.CheckBlockHash()
 	if !ok { // payload itself contains bad block hash
 		return fmt.Errorf("received execution payload for block %d with bad block hash %s, expected %s", expectedNum, payload.BlockHash, actual)
 	}
@@ -665,11 +783,12 @@ type peerStat struct {
 }
 
 type L2Chain interface {
-	PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayload, error)
+	PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayloadEnvelope, error)
 }
 
 type ReqRespServerMetrics interface {
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ServerPayloadsByRangeEvent(start uint64, served uint64, resultCode byte, duration time.Duration)
 }
 
 type ReqRespServer struct {
@@ -737,13 +856,12 @@ func (srv *ReqRespServer) HandleSyncRequest(ctx context.Context, log log.Logger,
 
 var invalidRequestErr = errors.New("invalid request")
 
-func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.Stream) (uint64, error) {
-	peerId := stream.Conn().RemotePeer()
-
+// waitRateLimits takes n tokens from the global rate-limiter and the rate-limiter of the given peer.
+func (srv *ReqRespServer) waitRateLimits(ctx context.Context, peerId peer.ID, n int) error {
 	// take a token from the global rate-limiter,
 	// to make sure there's not too much concurrent server work between different peers.
-	if err := srv.globalRequestsRL.Wait(ctx); err != nil {
-		return 0, fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
+	if err := srv.globalRequestsRL.WaitN(ctx, n); err != nil {
+		return fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
 	}
 
 	// find rate limiting data of peer, or add otherwise
@@ -754,18 +872,28 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 			Requests: rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst),
 		}
 		srv.peerRateLimits.Add(peerId, ps)
-		ps.Requests.Reserve() // count the hit, but make it delay the next request rather than immediately waiting
+		ps.Requests.ReserveN(time.Now(), n) // count the hit, but make it delay the next request rather than immediately waiting
 	} else {
 		// Only wait if it's an existing peer, otherwise the instant rate-limit Wait call always errors.
 
 		// If the requester thinks we're taking too long, then it's their problem and they can disconnect.
 		// We'll disconnect ourselves only when failing to read/write,
 		// if the work is invalid (range validation), or when individual sub tasks timeout.
-		if err := ps.Requests.Wait(ctx); err != nil {
-			return 0, fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
+		if err := ps.Requests.WaitN(ctx, n); err != nil {
+			srv.peerStatsLock.Unlock()
+			return fmt.Errorf("timed out waiting for peer sync rate limit: %w", err)
 		}
 	}
 	srv.peerStatsLock.Unlock()
+	return nil
+}
+
+func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.Stream) (uint64, error) {
+	peerId := stream.Conn().RemotePeer()
+
+	if err := srv.waitRateLimits(ctx, peerId, 1); err != nil {
+		return 0, err
+	}
 
 	// Set read deadline, if available
 	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
@@ -791,7 +919,7 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 		return req, fmt.Errorf("cannot serve request for L2 block %d after max expected block (%v): %w", req, max, invalidRequestErr)
 	}
 
-	payload, err := srv.l2.PayloadByNumber(ctx, req)
+	envelope, err := srv.l2.PayloadByNumber(ctx, req)
 	if err != nil {
 		if errors.Is(err, ethereum.NotFound) {
 			return req, fmt.Errorf("peer requested unknown block by number: %w", err)
@@ -804,13 +932,16 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 	_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
 
 	// 0 - resultCode: success = 0
-	// 1:5 - version: 0
+	// 1:5 - version: 0, or 1 for an envelope
 	var tmp [5]byte
+	if envelope.ParentBeaconBlockRoot != nil {
+		binary.LittleEndian.PutUint32(tmp[1:5], 1)
+	}
 	if _, err := stream.Write(tmp[:]); err != nil {
 		return req, fmt.Errorf("failed to write response header data: %w", err)
 	}
 	w := snappy.NewBufferedWriter(stream)
-	if _, err := payload.MarshalSSZ(w); err != nil {
+	if _, err := encodeSyncPayload(w, envelope); err != nil {
 		return req, fmt.Errorf("failed to write payload to sync response: %w", err)
 	}
 	if err := w.Close(); err != nil {
@@ -0,0 +1,260 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// The payloads_by_range protocol serves up to maxPayloadsPerRangeRequest consecutive blocks with a single stream.
+//
+// Request: start block number (uint64, little-endian), followed by the block count (uint64, little-endian).
+//
+// Response: a chunk per block, in ascending block order. Each chunk consists of:
+//   - 0 - resultCode: success = 0
+//   - 1:5 - version: 0, or 1 for an execution payload envelope, the encoding of blocks since Eclipse
+//   - 5:9 - length of the compressed payload (uint32, little-endian)
+//   - 9:9+length - SSZ encoded payload, with Snappy block compression
+//
+// If the server cannot serve the next block, it writes a single non-zero result code and closes the stream.
+// A response may thus contain fewer blocks than requested.
+//
+// Unlike gossip, and like the payload_by_number protocol, responses do not include the sequencer signature:
+// servers do not persist the signatures of the blocks they received, so they cannot serve them.
+// The client verifies that the payloads form a valid hash-chain instead, and the sync-client only
+// promotes them once they connect to a trusted block-hash, i.e. the gossip-validated sync target.
+// See the payloads_by_range section of the rollup-node p2p spec.
+
+// errRangeUnsupported is returned when no payloads_by_range stream could be opened to the peer,
+// e.g. because the peer runs an older version that only serves payloads by number.
+var errRangeUnsupported = errors.New("range sync protocol unavailable")
+
+// rangeChunkHeaderSize is the size of the result code, version, and length prefix of a response chunk.
+const rangeChunkHeaderSize = 1 + 4 + 4
+
+// doRangeRequest requests the given batch of block requests, ordered from high to low number, with a single stream.
+// Valid payloads are sent to the main loop from high to low, so they can be promoted right away.
+func (s *SyncClient) doRangeRequest(ctx context.Context, id peer.ID, batch []peerRequest) error {
+	start := batch[len(batch)-1].num
+	count := uint64(len(batch))
+
+	// open stream to peer
+	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
+	str, err := s.newStreamFn(reqCtx, id, s.payloadsByRange)
+	reqCancel()
+	if err != nil {
+		return fmt.Errorf("%w: failed to open stream: %w", errRangeUnsupported, err)
+	}
+	defer str.Close()
+	// set write timeout (if available)
+	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
+	var req [16]byte
+	binary.LittleEndian.PutUint64(req[0:8], start)
+	binary.LittleEndian.PutUint64(req[8:16], count)
+	if _, err := str.Write(req[:]); err != nil {
+		return fmt.Errorf("failed to write range request (%d, %d): %w", start, count, err)
+	}
+	if err := str.CloseWrite(); err != nil {
+		return fmt.Errorf("failed to close writer side while making request: %w", err)
+	}
+
+	payloads := make([]*eth.ExecutionPayloadEnvelope, 0, count)
+	var respErr error
+	for i := uint64(0); i < count; i++ {
+		// set read timeout (if available), per chunk
+		_ = str.SetReadDeadline(time.Now().Add(clientReadResponsetimeout))
+		envelope, err := s.readRangeChunk(str, start+i)
+		if err != nil {
+			var resErr requestResultErr
+			if !errors.As(err, &resErr) {
+				return err
+			}
+			respErr = err
+			break
+		}
+		if i > 0 {
+			prev := payloads[i-1].ExecutionPayload
+			if payload := envelope.ExecutionPayload; payload.ParentHash != prev.BlockHash {
+				return fmt.Errorf("%w: received execution payload %s does not build on previous payload %s", errMalformedResponse, payload.ID(), prev.ID())
+			}
+		}
+		payloads = append(payloads, envelope)
+	}
+	if len(payloads) == 0 {
+		return respErr
+	}
+	// A peer may not have all blocks of the range: use what it has, and count it as a valid response.
+	// The server stops at the first block it cannot serve, so what we do have is consecutive.
+	// The remaining blocks are completed in the request bookkeeping, so they can be requested again.
+	for _, pr := range batch[:count-uint64(len(payloads))] {
+		pr.complete.Store(true)
+	}
+	for i := len(payloads) - 1; i >= 0; i-- {
+		select {
+		case s.results <- syncResult{envelope: payloads[i], peer: id}:
+		case <-ctx.Done():
+			return fmt.Errorf("failed to process response, sync client is too busy: %w", ctx.Err())
+		}
+	}
+	return nil
+}
+
+// readRangeChunk reads and verifies a single payload chunk of a range response.
+func (s *SyncClient) readRangeChunk(r io.Reader, expectedBlockNum uint64) (*eth.ExecutionPayloadEnvelope, error) {
+	var result [1]byte
+	if _, err := io.ReadFull(r, result[:]); err != nil {
+		return nil, fmt.Errorf("failed to read result part of response: %w", err)
+	}
+	if res := result[0]; res != 0 {
+		return nil, requestResultErr(res)
+	}
+	var header [rangeChunkHeaderSize - 1]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read chunk header of response: %w", err)
+	}
+	version := binary.LittleEndian.Uint32(header[0:4])
+	// We do not trust the claimed length: limit what we read, as well as what we decompress.
+	size := binary.LittleEndian.Uint32(header[4:8])
+	if size > maxGossipSize {
+		return nil, fmt.Errorf("%w: compressed payload too large: %d", errMalformedResponse, size)
+	}
+	compressed := make([]byte, size)
+	if _, err := io.ReadFull(r, compressed); err != nil {
+		return nil, fmt.Errorf("failed to read payload of response: %w", err)
+	}
+	if n, err := snappy.DecodedLen(compressed); err != nil {
+		return nil, fmt.Errorf("%w: invalid snappy compression of response: %w", errMalformedResponse, err)
+	} else if n > maxGossipSize {
+		return nil, fmt.Errorf("%w: decompressed payload too large: %d", errMalformedResponse, n)
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decompress response: %w", errMalformedResponse, err)
+	}
+
+	res, err := decodeSyncPayload(s.cfg, version, data, expectedBlockNum)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyBlock(res, expectedBlockNum); err != nil {
+		return nil, fmt.Errorf("%w: received execution payload is invalid: %w", errMalformedResponse, err)
+	}
+	return res, nil
+}
+
+// HandleSyncRangeRequest is a stream handler function to register the L2 unsafe payloads-by-range alt-sync protocol.
+// See MakeStreamHandler to transform this into a LibP2P handler function.
+//
+// Note that the same peer may open parallel streams.
+//
+// The caller must Close the stream.
+func (srv *ReqRespServer) HandleSyncRangeRequest(ctx context.Context, log log.Logger, stream network.Stream) {
+	start := time.Now()
+
+	// We wait as long as necessary; we throttle the peer instead of disconnecting,
+	// unless the delay reaches a threshold that is unreasonable to wait for.
+	ctx, cancel := context.WithTimeout(ctx, maxThrottleDelay)
+	req, served, err := srv.handleSyncRangeRequest(ctx, stream)
+	cancel()
+
+	resultCode := byte(0)
+	if err != nil {
+		log.Warn("failed to serve p2p range sync request", "start", req, "served", served, "err", err)
+		if errors.Is(err, ethereum.NotFound) {
+			resultCode = 1
+		} else if errors.Is(err, invalidRequestErr) {
+			resultCode = 2
+		} else {
+			resultCode = 3
+		}
+		// try to write error code, so the other peer can understand the reason for failure.
+		_, _ = stream.Write([]byte{resultCode})
+	} else {
+		log.Debug("successfully served range sync response", "start", req, "served", served)
+	}
+	srv.metrics.ServerPayloadsByRangeEvent(req, served, resultCode, time.Since(start))
+}
+
+func (srv *ReqRespServer) handleSyncRangeRequest(ctx context.Context, stream network.Stream) (start uint64, served uint64, err error) {
+	peerId := stream.Conn().RemotePeer()
+
+	// Set read deadline, if available
+	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
+
+	// Read the request
+	var req [16]byte
+	if _, err := io.ReadFull(stream, req[:]); err != nil {
+		return 0, 0, fmt.Errorf("failed to read requested block range: %w", err)
+	}
+	if err := stream.CloseRead(); err != nil {
+		return 0, 0, fmt.Errorf("failed to close reading-side of a P2P range sync request call: %w", err)
+	}
+	start = binary.LittleEndian.Uint64(req[0:8])
+	count := binary.LittleEndian.Uint64(req[8:16])
+
+	// Check the request is within the expected range of blocks
+	if count == 0 || count > maxPayloadsPerRangeRequest {
+		return start, 0, fmt.Errorf("cannot serve range of %d blocks, expected 1 to %d: %w", count, maxPayloadsPerRangeRequest, invalidRequestErr)
+	}
+	if start < srv.cfg.Genesis.L2.Number {
+		return start, 0, fmt.Errorf("cannot serve request for L2 block %d before genesis %d: %w", start, srv.cfg.Genesis.L2.Number, invalidRequestErr)
+	}
+	max, err := srv.cfg.TargetBlockNumber(uint64(time.Now().Unix()))
+	if err != nil {
+		return start, 0, fmt.Errorf("cannot determine max target block number to verify request: %w", invalidRequestErr)
+	}
+	if end := start + count - 1; end < start || end > max {
+		return start, 0, fmt.Errorf("cannot serve request for L2 blocks %d to %d after max expected block (%v): %w", start, end, max, invalidRequestErr)
+	}
+
+	// Each served block costs a rate-limit token, the same as a request by number.
+	if err := srv.waitRateLimits(ctx, peerId, int(count)); err != nil {
+		return start, 0, err
+	}
+
+	var buf bytes.Buffer
+	for ; served < count; served++ {
+		num := start + served
+		envelope, err := srv.l2.PayloadByNumber(ctx, num)
+		if err != nil {
+			if errors.Is(err, ethereum.NotFound) {
+				return start, served, fmt.Errorf("peer requested unknown block %d by range: %w", num, err)
+			} else {
+				return start, served, fmt.Errorf("failed to retrieve payload %d to serve to peer: %w", num, err)
+			}
+		}
+		buf.Reset()
+		version, err := encodeSyncPayload(&buf, envelope)
+		if err != nil {
+			return start, served, fmt.Errorf("failed to encode payload %d for sync response: %w", num, err)
+		}
+		data := snappy.Encode(nil, buf.Bytes())
+
+		// We set write deadline per chunk, if available, to safely write without blocking on a throttling peer connection
+		_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
+
+		var header [rangeChunkHeaderSize]byte // result code is 0
+		binary.LittleEndian.PutUint32(header[1:5], version)
+		binary.LittleEndian.PutUint32(header[5:9], uint32(len(data)))
+		if _, err := stream.Write(header[:]); err != nil {
+			return start, served, fmt.Errorf("failed to write response chunk header: %w", err)
+		}
+		if _, err := stream.Write(data); err != nil {
+			return start, served, fmt.Errorf("failed to write payload %d to sync response: %w", num, err)
+		}
+	}
+	return start, served, nil
+}
@@ -1,9 +1,13 @@
 package p2p
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"io"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -15,6 +19,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
@@ -25,8 +30,12 @@ import (
 
 type mockPayloadFn func(n uint64) (*eth.ExecutionPayload, error)
 
-func (fn mockPayloadFn) PayloadByNumber(_ context.Context, number uint64) (*eth.ExecutionPayload, error) {
-	return fn(number)
+func (fn mockPayloadFn) PayloadByNumber(_ context.Context, number uint64) (*eth.ExecutionPayloadEnvelope, error) {
+	payload, err := fn(number)
+	if err != nil {
+		return nil, err
+	}
+	return &eth.ExecutionPayloadEnvelope{ExecutionPayload: payload}, nil
 }
 
 var _ L2Chain = mockPayloadFn(nil)
@@ -115,7 +124,8 @@ func TestSinglePeerSync(t *testing.T) {
 
 	// collect received payloads in a buffered channel, so we can verify we get everything
 	received := make(chan *eth.ExecutionPayload, 100)
-	receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) error {
+	receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) error {
+		payload := envelope.ExecutionPayload
 		received <- payload
 		return nil
 	})
@@ -137,7 +147,7 @@ func TestSinglePeerSync(t *testing.T) {
 	hostA.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID), payloadByNumber)
 
 	// Setup host B as the client
-	cl := NewSyncClient(log.New("role", "client"), cfg, hostB.NewStream, receivePayload, metrics.NoopMetrics, &NoopApplicationScorer{})
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB.NewStream, receivePayload, metrics.NoopMetrics, &NoopApplicationScorer{}, NoopViolationReporter{})
 
 	// Setup host B (client) to sync from its peer Host A (server)
 	cl.AddPeer(hostA.ID())
@@ -157,6 +167,80 @@ func TestSinglePeerSync(t *testing.T) {
 	}
 }
 
+func TestSinglePeerRangeSync(t *testing.T) {
+	t.Parallel() // Takes a while, but can run in parallel
+
+	logger := testlog.Logger(t, log.LvlError)
+
+	cfg, payloads := setupSyncTestData(25)
+
+	servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayload, error) {
+		p, ok := payloads.getPayload(n)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	})
+
+	received := make(chan *eth.ExecutionPayload, 100)
+	receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) error {
+		payload := envelope.ExecutionPayload
+		received <- payload
+		return nil
+	})
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Setup host A as the server, counting the range requests it serves
+	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
+	var rangeRequests atomic.Int32
+	payloadsByRange := MakeStreamHandler(ctx, logger.New("role", "server"), func(ctx context.Context, log log.Logger, stream network.Stream) {
+		rangeRequests.Add(1)
+		srv.HandleSyncRangeRequest(ctx, log, stream)
+	})
+	hostA.SetStreamHandler(PayloadsByRangeProtocolID(cfg.L2ChainID), payloadsByRange)
+	payloadByNumber := MakeStreamHandler(ctx, logger.New("role", "server"), srv.HandleSyncRequest)
+	hostA.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID), payloadByNumber)
+
+	// Setup host B as the client
+	cl := NewSyncClient(logger.New("role", "client"), cfg, hostB.NewStream, receivePayload, metrics.NoopMetrics, &NoopApplicationScorer{}, NoopViolationReporter{})
+	cl.AddPeer(hostA.ID())
+	cl.Start()
+	defer cl.Close()
+
+	// request a gap of 10 blocks: 11 to 20
+	require.NoError(t, cl.RequestL2Range(ctx, payloads.getBlockRef(10), payloads.getBlockRef(21)))
+
+	for i := uint64(20); i > 10; i-- {
+		p := <-received
+		require.Equal(t, i, uint64(p.BlockNumber), "expecting payloads in order")
+		exp, ok := payloads.getPayload(uint64(p.BlockNumber))
+		require.True(t, ok, "expecting known payload")
+		require.Equal(t, exp.BlockHash, p.BlockHash, "expecting the correct payload")
+	}
+	require.NotZero(t, rangeRequests.Load(), "expecting the gap to be synced with range requests")
+
+	// The server rejects ranges that are too large
+	str, err := hostB.NewStream(ctx, hostA.ID(), PayloadsByRangeProtocolID(cfg.L2ChainID))
+	require.NoError(t, err)
+	var req [16]byte
+	binary.LittleEndian.PutUint64(req[0:8], 1)
+	binary.LittleEndian.PutUint64(req[8:16], maxPayloadsPerRangeRequest+1)
+	_, err = str.Write(req[:])
+	require.NoError(t, err)
+	require.NoError(t, str.CloseWrite())
+	resp, err := io.ReadAll(str)
+	require.NoError(t, err)
+	require.Equal(t, []byte{2}, resp, "expecting invalid request result code")
+}
+
 func TestMultiPeerSync(t *testing.T) {
 	t.Parallel() // Takes a while, but can run in parallel
 
@@ -180,7 +264,8 @@ func TestMultiPeerSync(t *testing.T) {
 
 		// collect received payloads in a buffered channel, so we can verify we get everything
 		received := make(chan *eth.ExecutionPayload, 100)
-		receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) error {
+		receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) error {
+			payload := envelope.ExecutionPayload
 			received <- payload
 			return nil
 		})
@@ -190,7 +275,7 @@ func TestMultiPeerSync(t *testing.T) {
 		payloadByNumber := MakeStreamHandler(ctx, log.New("serve", "payloads_by_number"), srv.HandleSyncRequest)
 		h.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID), payloadByNumber)
 
-		cl := NewSyncClient(log.New("role", "client"), cfg, h.NewStream, receivePayload, metrics.NoopMetrics, &NoopApplicationScorer{})
+		cl := NewSyncClient(log.New("role", "client"), cfg, h.NewStream, receivePayload, metrics.NoopMetrics, &NoopApplicationScorer{}, NoopViolationReporter{})
 		return cl, received
 	}
 
@@ -304,9 +389,9 @@ func TestNetworkNotifyAddPeerAndRemovePeer(t *testing.T) {
 	require.NoError(t, err, "failed to launch host B")
 	defer hostB.Close()
 
-	syncCl := NewSyncClient(log, cfg, hostA.NewStream, func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) error {
+	syncCl := NewSyncClient(log, cfg, hostA.NewStream, func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayloadEnvelope) error {
 		return nil
-	}, metrics.NoopMetrics, &NoopApplicationScorer{})
+	}, metrics.NoopMetrics, &NoopApplicationScorer{}, NoopViolationReporter{})
 
 	waitChan := make(chan struct{}, 1)
 	hostA.Network().Notify(&network.NotifyBundle{
@@ -342,3 +427,98 @@ func TestNetworkNotifyAddPeerAndRemovePeer(t *testing.T) {
 	require.True(t, !peerBExist3, "peerB should not exist in syncClient")
 
 }
+
+func TestSyncMalformedResponseViolation(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlError)
+	cfg, payloads := setupSyncTestData(25)
+
+	// the server responds with the wrong block
+	servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayload, error) {
+		p, ok := payloads.getPayload(n + 1)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	})
+	receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) error {
+		payload := envelope.ExecutionPayload
+		t.Errorf("unexpected payload %s", payload.ID())
+		return nil
+	})
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
+	payloadByNumber := MakeStreamHandler(ctx, logger.New("role", "server"), srv.HandleSyncRequest)
+	hostA.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID), payloadByNumber)
+
+	violations := &violationRecorder{}
+	cl := NewSyncClient(logger.New("role", "client"), cfg, hostB.NewStream, receivePayload, metrics.NoopMetrics, &NoopApplicationScorer{}, violations)
+	cl.AddPeer(hostA.ID())
+	cl.Start()
+	defer cl.Close()
+
+	require.NoError(t, cl.RequestL2Range(ctx, payloads.getBlockRef(10), payloads.getBlockRef(12)))
+	require.Eventually(t, func() bool {
+		return len(violations.reported()) > 0
+	}, 10*time.Second, 10*time.Millisecond, "malformed response must be reported as violation")
+	require.Equal(t, hostA.ID(), violations.reported()[0])
+}
+
+func TestSyncPayloadVersions(t *testing.T) {
+	zero := uint64(0)
+	eclipseTime := uint64(10)
+	cfg := &rollup.Config{
+		Genesis:      rollup.Genesis{L2Time: 0},
+		BlockTime:    2,
+		RegolithTime: &zero,
+		CanyonTime:   &zero,
+		DeltaTime:    &zero,
+		EclipseTime:  &eclipseTime,
+	}
+	blobGas := eth.Uint64Quantity(0)
+	envelope := &eth.ExecutionPayloadEnvelope{
+		ParentBeaconBlockRoot: &common.Hash{0xbe, 0xac},
+		ExecutionPayload: &eth.ExecutionPayload{
+			BlockNumber:   6,
+			Timestamp:     12,
+			Withdrawals:   &types.Withdrawals{},
+			BlobGasUsed:   &blobGas,
+			ExcessBlobGas: &blobGas,
+		},
+	}
+
+	var buf bytes.Buffer
+	version, err := encodeSyncPayload(&buf, envelope)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), version, "Eclipse blocks are served as envelope")
+	res, err := decodeSyncPayload(cfg, version, buf.Bytes(), 6)
+	require.NoError(t, err)
+	require.Equal(t, envelope.ParentBeaconBlockRoot, res.ParentBeaconBlockRoot)
+	require.Equal(t, envelope.ExecutionPayload.BlobGasUsed, res.ExecutionPayload.BlobGasUsed)
+	_, err = decodeSyncPayload(cfg, version, buf.Bytes(), 4)
+	require.ErrorIs(t, err, errMalformedResponse, "envelope of pre-Eclipse block")
+	_, err = decodeSyncPayload(cfg, 0, buf.Bytes(), 6)
+	require.ErrorIs(t, err, errMalformedResponse, "bare payload of Eclipse block")
+
+	pre := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{
+		BlockNumber: 4,
+		Timestamp:   8,
+		Withdrawals: &types.Withdrawals{},
+	}}
+	buf.Reset()
+	version, err = encodeSyncPayload(&buf, pre)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), version, "pre-Eclipse blocks are served as bare payload")
+	res, err = decodeSyncPayload(cfg, version, buf.Bytes(), 4)
+	require.NoError(t, err)
+	require.Nil(t, res.ParentBeaconBlockRoot)
+	require.Equal(t, pre.ExecutionPayload.BlockNumber, res.ExecutionPayload.BlockNumber)
+}
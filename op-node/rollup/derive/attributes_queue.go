@@ -34,14 +34,16 @@ type AttributesQueue struct {
 	prev         *BatchQueue
 	batch        *SingularBatch
 	isLastInSpan bool
+	metrics      Metrics
 }
 
-func NewAttributesQueue(log log.Logger, cfg *rollup.Config, builder AttributesBuilder, prev *BatchQueue) *AttributesQueue {
+func NewAttributesQueue(log log.Logger, cfg *rollup.Config, builder AttributesBuilder, prev *BatchQueue, metrics Metrics) *AttributesQueue {
 	return &AttributesQueue{
 		log:     log,
 		config:  cfg,
 		builder: builder,
 		prev:    prev,
+		metrics: metrics,
 	}
 }
 
@@ -86,7 +88,9 @@ func (aq *AttributesQueue) createNextAttributes(ctx context.Context, batch *Sing
 	}
 	fetchCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
 	defer cancel()
+	start := time.Now()
 	attrs, err := aq.builder.PreparePayloadAttributes(fetchCtx, l2SafeHead, batch.Epoch())
+	aq.metrics.RecordDerivationStageTime(StageAttributesBuild, time.Since(start))
 	if err != nil {
 		return nil, err
 	}
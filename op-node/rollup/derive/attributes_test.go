@@ -242,6 +242,44 @@ func TestPreparePayloadAttributes(t *testing.T) {
 			})
 		}
 	})
+	// Test that the parent beacon block root is only set from Eclipse onwards
+	t.Run("eclipse parent beacon block root", func(t *testing.T) {
+		testCases := []struct {
+			name             string
+			eclipseTime      *uint64
+			parentBeaconRoot *common.Hash
+			expected         *common.Hash
+		}{
+			{"inactive", nil, &common.Hash{0xaa}, nil},
+			{"active", new(uint64), &common.Hash{0xaa}, &common.Hash{0xaa}},
+			{"active pre-dencun L1", new(uint64), nil, &common.Hash{}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				cfgCopy := *cfg // copy, we are making eclipse config modifications
+				cfg := &cfgCopy
+				cfg.EclipseTime = tc.eclipseTime
+				rng := rand.New(rand.NewSource(1234))
+				l1Fetcher := &testutils.MockL1Source{}
+				defer l1Fetcher.AssertExpectations(t)
+				l2Parent := testutils.RandomL2BlockRef(rng)
+				l1CfgFetcher := &testutils.MockL2Client{}
+				l1CfgFetcher.ExpectSystemConfigByL2Hash(l2Parent.Hash, testSysCfg, nil)
+				defer l1CfgFetcher.AssertExpectations(t)
+				l1Info := testutils.RandomBlockInfo(rng)
+				l1Info.InfoHash = l2Parent.L1Origin.Hash
+				l1Info.InfoNum = l2Parent.L1Origin.Number
+				l1Info.InfoParentBeaconRoot = tc.parentBeaconRoot
+
+				epoch := l1Info.ID()
+				l1Fetcher.ExpectInfoByHash(epoch.Hash, l1Info, nil)
+				attrBuilder := NewFetchingAttributesBuilder(cfg, l1Fetcher, l1CfgFetcher)
+				attrs, err := attrBuilder.PreparePayloadAttributes(context.Background(), l2Parent, epoch)
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, attrs.ParentBeaconBlockRoot)
+			})
+		}
+	})
 }
 
 func encodeDeposits(deposits []*types.DepositTx) (out []eth.Data, err error) {
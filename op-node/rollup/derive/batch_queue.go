@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 
@@ -34,7 +35,7 @@ type NextBatchProvider interface {
 
 type SafeBlockFetcher interface {
 	L2BlockRefByNumber(context.Context, uint64) (eth.L2BlockRef, error)
-	PayloadByNumber(context.Context, uint64) (*eth.ExecutionPayload, error)
+	PayloadByNumber(context.Context, uint64) (*eth.ExecutionPayloadEnvelope, error)
 }
 
 // BatchQueue contains a set of batches for every L1 block.
@@ -60,15 +61,18 @@ type BatchQueue struct {
 	nextSpan []*SingularBatch
 
 	l2 SafeBlockFetcher
+
+	metrics Metrics
 }
 
 // NewBatchQueue creates a BatchQueue, which should be Reset(origin) before use.
-func NewBatchQueue(log log.Logger, cfg *rollup.Config, prev NextBatchProvider, l2 SafeBlockFetcher) *BatchQueue {
+func NewBatchQueue(log log.Logger, cfg *rollup.Config, prev NextBatchProvider, l2 SafeBlockFetcher, metrics Metrics) *BatchQueue {
 	return &BatchQueue{
-		log:    log,
-		config: cfg,
-		prev:   prev,
-		l2:     l2,
+		log:     log,
+		config:  cfg,
+		prev:    prev,
+		l2:      l2,
+		metrics: metrics,
 	}
 }
 
@@ -157,7 +161,9 @@ func (bq *BatchQueue) NextBatch(ctx context.Context, parent eth.L2BlockRef) (*Si
 	} else if err != nil {
 		return nil, false, err
 	} else if !originBehind {
+		start := time.Now()
 		bq.AddBatch(ctx, batch, parent)
+		bq.metrics.RecordDerivationStageTime(StageBatchQueueBatch, time.Since(start))
 	}
 
 	// Skip adding data unless we are up to date with the origin, but do fully
@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -89,6 +90,7 @@ func l1InfoDepositTx(t *testing.T, l1BlockNum uint64) hexutil.Bytes {
 	infoData, err := l1Info.MarshalBinary()
 	require.NoError(t, err)
 	depositTx := &types.DepositTx{
+		To:   &L1BlockAddress,
 		Data: infoData,
 	}
 	txData, err := types.NewTx(depositTx).MarshalBinary()
@@ -191,7 +193,7 @@ func BatchQueueNewOrigin(t *testing.T, batchType int) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 	require.Equal(t, []eth.L1BlockRef{l1[0]}, bq.l1Blocks)
 
@@ -280,7 +282,7 @@ func BatchQueueEager(t *testing.T, batchType int) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 	// Advance the origin
 	input.origin = l1[1]
@@ -358,7 +360,7 @@ func BatchQueueInvalidInternalAdvance(t *testing.T, batchType int) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 
 	// Load continuous batches for epoch 0
@@ -473,7 +475,7 @@ func BatchQueueMissing(t *testing.T, batchType int) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 
 	for i := 0; i < len(expectedOutputBatches); i++ {
@@ -600,7 +602,7 @@ func BatchQueueAdvancedEpoch(t *testing.T, batchType int) {
 		origin:  l1[inputOriginNumber],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[1], eth.SystemConfig{})
 
 	for i := 0; i < len(expectedOutputBatches); i++ {
@@ -691,7 +693,7 @@ func BatchQueueShuffle(t *testing.T, batchType int) {
 		origin:  l1[inputOriginNumber],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[1], eth.SystemConfig{})
 
 	for i := 0; i < len(expectedOutputBatches); i++ {
@@ -798,15 +800,15 @@ func TestBatchQueueOverlappingSpanBatch(t *testing.T) {
 				// In CheckBatch(), "PayloadByNumber" is called when fetching the overlapped blocks.
 				// blocks at 14, 20 are included in overlapped blocks once.
 				// CheckBatch() is called twice for a batch - before adding to the queue, after getting from the queue
-				l2Client.Mock.On("PayloadByNumber", uint64(i+1)).Times(2).Return(&payload, &nilErr)
+				l2Client.Mock.On("PayloadByNumber", uint64(i+1)).Times(2).Return(&eth.ExecutionPayloadEnvelope{ExecutionPayload: &payload}, &nilErr)
 			} else if i == 2 || i == 3 {
 				// blocks at 16, 18 are included in overlapped blocks twice.
-				l2Client.Mock.On("PayloadByNumber", uint64(i+1)).Times(4).Return(&payload, &nilErr)
+				l2Client.Mock.On("PayloadByNumber", uint64(i+1)).Times(4).Return(&eth.ExecutionPayloadEnvelope{ExecutionPayload: &payload}, &nilErr)
 			}
 		}
 	}
 
-	bq := NewBatchQueue(log, cfg, input, &l2Client)
+	bq := NewBatchQueue(log, cfg, input, &l2Client, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 	// Advance the origin
 	input.origin = l1[1]
@@ -907,12 +909,12 @@ func TestBatchQueueComplex(t *testing.T) {
 			if i == 1 || i == 2 || i == 4 {
 				// In CheckBatch(), "PayloadByNumber" is called when fetching the overlapped blocks.
 				// so blocks at 14, 20 could be called, depends on the order of batches
-				l2Client.Mock.On("PayloadByNumber", uint64(i+1)).Return(&payload, &nilErr).Maybe()
+				l2Client.Mock.On("PayloadByNumber", uint64(i+1)).Return(&eth.ExecutionPayloadEnvelope{ExecutionPayload: &payload}, &nilErr).Maybe()
 			}
 		}
 	}
 
-	bq := NewBatchQueue(log, cfg, input, &l2Client)
+	bq := NewBatchQueue(log, cfg, input, &l2Client, metrics.NoopMetrics)
 	_ = bq.Reset(context.Background(), l1[1], eth.SystemConfig{})
 
 	for i := 0; i < len(expectedOutputBatches); i++ {
@@ -982,7 +984,7 @@ func TestBatchQueueResetSpan(t *testing.T) {
 		origin:  l1[2],
 	}
 	l2Client := testutils.MockL2Client{}
-	bq := NewBatchQueue(log, cfg, input, &l2Client)
+	bq := NewBatchQueue(log, cfg, input, &l2Client, metrics.NoopMetrics)
 	bq.l1Blocks = l1 // Set enough l1 blocks to derive span batch
 
 	// This NextBatch() will derive the span batch, return the first singular batch and save rest of batches in span.
@@ -338,12 +338,13 @@ func checkSpanBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1B
 	if batch.GetTimestamp() < nextTimestamp {
 		for i := uint64(0); i < l2SafeHead.Number-parentNum; i++ {
 			safeBlockNum := parentNum + i + 1
-			safeBlockPayload, err := l2Fetcher.PayloadByNumber(ctx, safeBlockNum)
+			safeBlockEnvelope, err := l2Fetcher.PayloadByNumber(ctx, safeBlockNum)
 			if err != nil {
 				log.Warn("failed to fetch L2 block payload", "number", parentNum, "err", err)
 				// unable to validate the batch for now. retry later.
 				return BatchUndecided
 			}
+			safeBlockPayload := safeBlockEnvelope.ExecutionPayload
 			safeBlockTxs := safeBlockPayload.Transactions
 			batchTxs := batch.GetBlockTransactions(int(i))
 			// execution payload has deposit TXs, but batch does not.
@@ -1515,7 +1515,7 @@ func TestValidBatch(t *testing.T) {
 	// will return an error for block #99 (parent of l2A0)
 	l2Client.Mock.On("L2BlockRefByNumber", l2A0.Number-1).Return(eth.L2BlockRef{}, &tempErr)
 	// will return an error for l2A3
-	l2Client.Mock.On("PayloadByNumber", l2A3.Number).Return(&eth.ExecutionPayload{}, &tempErr)
+	l2Client.Mock.On("PayloadByNumber", l2A3.Number).Return(&eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{}}, &tempErr)
 
 	// make payloads for L2 blocks and set as expected return value of MockL2Client
 	for _, l2Block := range []eth.L2BlockRef{l2A0, l2A1, l2A2, l2B0} {
@@ -1529,7 +1529,7 @@ func TestValidBatch(t *testing.T) {
 			Transactions: []hexutil.Bytes{txData},
 		}
 		l2Client.Mock.On("L2BlockRefByNumber", l2Block.Number).Return(l2Block, &nilErr)
-		l2Client.Mock.On("PayloadByNumber", l2Block.Number).Return(&payload, &nilErr)
+		l2Client.Mock.On("PayloadByNumber", l2Block.Number).Return(&eth.ExecutionPayloadEnvelope{ExecutionPayload: &payload}, &nilErr)
 	}
 
 	runTestCase := func(t *testing.T, testCase ValidBatchTestCase) {
@@ -1581,7 +1581,7 @@ func TestValidBatch(t *testing.T) {
 		BlockHash:    l2B1.Hash,
 		Transactions: []hexutil.Bytes{txData, randTxData},
 	}
-	l2Client.Mock.On("PayloadByNumber", l2B1.Number).Return(&payload, &nilErr).Once()
+	l2Client.Mock.On("PayloadByNumber", l2B1.Number).Return(&eth.ExecutionPayloadEnvelope{ExecutionPayload: &payload}, &nilErr).Once()
 
 	randTx = testutils.RandomTx(rng, new(big.Int).SetUint64(rng.Uint64()), signer)
 	randTxData, _ = randTx.MarshalBinary()
@@ -1626,7 +1626,7 @@ func TestValidBatch(t *testing.T) {
 		// First TX is not a deposit TX. it will make error when extracting L2BlockRef from the payload
 		Transactions: []hexutil.Bytes{randTxData},
 	}
-	l2Client.Mock.On("PayloadByNumber", l2B1.Number).Return(&payload, &nilErr).Once()
+	l2Client.Mock.On("PayloadByNumber", l2B1.Number).Return(&eth.ExecutionPayloadEnvelope{ExecutionPayload: &payload}, &nilErr).Once()
 
 	invalidTxTestCase := ValidBatchTestCase{
 		Name:       "invalid_tx_overlapping_batch",
@@ -0,0 +1,150 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// BlobDataSource fetches both call-data (backup) and blobs and transforms them into usable rollup data.
+// Like the calldata DataSource, the constructor does not fail: fetching is re-attempted on the next call to `Next`.
+type BlobDataSource struct {
+	data         []eth.Data
+	ref          eth.L1BlockRef
+	batcherAddr  common.Address
+	dsCfg        DataSourceConfig
+	fetcher      L1TransactionFetcher
+	blobsFetcher L1BlobsFetcher
+	log          log.Logger
+}
+
+// NewBlobDataSource creates a new blob data source.
+func NewBlobDataSource(ctx context.Context, log log.Logger, dsCfg DataSourceConfig, fetcher L1TransactionFetcher, blobsFetcher L1BlobsFetcher, ref eth.L1BlockRef, batcherAddr common.Address) DataIter {
+	return &BlobDataSource{
+		ref:          ref,
+		dsCfg:        dsCfg,
+		fetcher:      fetcher,
+		log:          log.New("origin", ref),
+		batcherAddr:  batcherAddr,
+		blobsFetcher: blobsFetcher,
+	}
+}
+
+// Next returns the next piece of batcher data, or an io.EOF error if no data remains. It returns ResetError if it cannot
+// find the referenced block or a referenced blob, or TemporaryError for any other failure to fetch a block or blob.
+func (ds *BlobDataSource) Next(ctx context.Context) (eth.Data, error) {
+	if ds.data == nil {
+		var err error
+		if ds.data, err = ds.open(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(ds.data) == 0 {
+		return nil, io.EOF
+	} else {
+		data := ds.data[0]
+		ds.data = ds.data[1:]
+		return data, nil
+	}
+}
+
+// open fetches and returns the blob or calldata (as appropriate) from all valid batcher
+// transactions in the referenced block. Returns an empty (non-nil) array if no batcher
+// transactions are found.
+func (ds *BlobDataSource) open(ctx context.Context) ([]eth.Data, error) {
+	_, txs, err := ds.fetcher.InfoAndTxsByHash(ctx, ds.ref.Hash)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, NewResetError(fmt.Errorf("failed to open blob data source: %w", err))
+		}
+		return nil, NewTemporaryError(fmt.Errorf("failed to open blob data source: %w", err))
+	}
+
+	data, hashes := dataAndHashesFromTxs(txs, &ds.dsCfg, ds.batcherAddr, ds.log)
+	if len(hashes) == 0 {
+		// there are no blobs to fetch so we can return immediately
+		return blobsToData(data, nil, ds.log), nil
+	}
+	if ds.blobsFetcher == nil {
+		return nil, NewCriticalError(fmt.Errorf("found %d blobs in block %s, but no blobs fetcher is configured", len(hashes), ds.ref))
+	}
+
+	// download the actual blob bodies corresponding to the indexed blob hashes
+	blobs, err := ds.blobsFetcher.GetBlobs(ctx, ds.ref, hashes)
+	if errors.Is(err, ethereum.NotFound) {
+		// If the L1 block was available, then the blobs should be available too. The only
+		// exception is if the blob retention window has expired, which we will ultimately handle
+		// by failing over to a blob archival service.
+		return nil, NewResetError(fmt.Errorf("failed to fetch blobs: %w", err))
+	} else if err != nil {
+		return nil, NewTemporaryError(fmt.Errorf("failed to fetch blobs: %w", err))
+	}
+	return blobsToData(data, blobs, ds.log), nil
+}
+
+// dataAndHashesFromTxs extracts calldata and blob hashes from the batcher transactions, in tx order.
+// Each blob hash gets a nil placeholder in the returned data, which blobsToData fills in with the blob contents.
+func dataAndHashesFromTxs(txs types.Transactions, config *DataSourceConfig, batcherAddr common.Address, log log.Logger) ([]*eth.Data, []eth.IndexedBlobHash) {
+	var data []*eth.Data
+	var hashes []eth.IndexedBlobHash
+	blobIndex := 0 // index of each blob in the block's blob sidecar
+	for j, tx := range txs {
+		// skip any non-batcher transactions
+		if !isValidBatchTx(tx, *config, batcherAddr, log.New("index", j)) {
+			blobIndex += len(tx.BlobHashes())
+			continue
+		}
+		// handle non-blob batcher transactions by extracting their calldata
+		if tx.Type() != types.BlobTxType {
+			calldata := eth.Data(tx.Data())
+			data = append(data, &calldata)
+			continue
+		}
+		// handle blob batcher transactions by extracting their blob hashes, ignoring any calldata.
+		if len(tx.Data()) > 0 {
+			log.Warn("blob tx has calldata, which will be ignored", "txhash", tx.Hash())
+		}
+		for _, h := range tx.BlobHashes() {
+			idh := eth.IndexedBlobHash{
+				Index: uint64(blobIndex),
+				Hash:  h,
+			}
+			hashes = append(hashes, idh)
+			data = append(data, nil)
+			blobIndex += 1
+		}
+	}
+	return data, hashes
+}
+
+// blobsToData fills in the blob placeholders of the data with the decoded fetched blobs, in order.
+// Blobs that fail to decode are dropped: the batcher is trusted to only post well-formed blobs,
+// and a malformed one must not stall the derivation.
+func blobsToData(data []*eth.Data, blobs []*eth.Blob, log log.Logger) []eth.Data {
+	out := make([]eth.Data, 0, len(data))
+	blobIndex := 0
+	for _, d := range data {
+		if d != nil {
+			out = append(out, *d)
+			continue
+		}
+		blob := blobs[blobIndex]
+		blobIndex++
+		calldata, err := blob.ToData()
+		if err != nil {
+			log.Warn("ignoring blob due to parse failure", "blobIndex", blobIndex-1, "err", err)
+			continue
+		}
+		out = append(out, calldata)
+	}
+	return out
+}
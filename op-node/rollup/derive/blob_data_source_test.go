@@ -0,0 +1,207 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type mockBlobsFetcher struct {
+	mock.Mock
+}
+
+func (m *mockBlobsFetcher) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	out := m.Mock.MethodCalled("GetBlobs", ref, hashes)
+	return out.Get(0).([]*eth.Blob), out.Error(1)
+}
+
+func (m *mockBlobsFetcher) ExpectGetBlobs(ref eth.L1BlockRef, hashes []eth.IndexedBlobHash, blobs []*eth.Blob, err error) {
+	m.Mock.On("GetBlobs", ref, hashes).Once().Return(blobs, err)
+}
+
+func TestDataAndHashesFromTxs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	privateKey := testutils.InsecureRandomKey(rng)
+	batcherAddr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	batchInboxAddr := testutils.RandomAddress(rng)
+	logger := testlog.Logger(t, log.LvlInfo)
+
+	chainId := new(big.Int).SetUint64(rng.Uint64())
+	signer := types.NewCancunSigner(chainId)
+	config := DataSourceConfig{
+		l1Signer:          signer,
+		batchInboxAddress: batchInboxAddr,
+	}
+
+	// create a valid non-blob batcher transaction and make sure it's picked up
+	txData := &types.LegacyTx{
+		Nonce:    rng.Uint64(),
+		GasPrice: new(big.Int).SetUint64(rng.Uint64()),
+		Gas:      2_000_000,
+		To:       &batchInboxAddr,
+		Value:    big.NewInt(10),
+		Data:     testutils.RandomData(rng, rng.Intn(1000)),
+	}
+	calldataTx, _ := types.SignNewTx(privateKey, signer, txData)
+	txs := types.Transactions{calldataTx}
+	data, blobHashes := dataAndHashesFromTxs(txs, &config, batcherAddr, logger)
+	require.Equal(t, 1, len(data))
+	require.Equal(t, 0, len(blobHashes))
+
+	// create a valid blob batcher tx and make sure it's picked up
+	blobHash := testutils.RandomHash(rng)
+	blobTxData := &types.BlobTx{
+		Nonce:      rng.Uint64(),
+		Gas:        2_000_000,
+		To:         batchInboxAddr,
+		Data:       testutils.RandomData(rng, rng.Intn(1000)),
+		BlobHashes: []common.Hash{blobHash},
+	}
+	blobTx, _ := types.SignNewTx(privateKey, signer, blobTxData)
+	txs = types.Transactions{blobTx}
+	data, blobHashes = dataAndHashesFromTxs(txs, &config, batcherAddr, logger)
+	require.Equal(t, 1, len(data))
+	require.Nil(t, data[0], "placeholder for the blob")
+	require.Equal(t, []eth.IndexedBlobHash{{Index: 0, Hash: blobHash}}, blobHashes)
+
+	// try again with both the blob & calldata transactions and make sure both are picked up
+	txs = types.Transactions{blobTx, calldataTx}
+	data, blobHashes = dataAndHashesFromTxs(txs, &config, batcherAddr, logger)
+	require.Equal(t, 2, len(data))
+	require.Equal(t, 1, len(blobHashes))
+	require.NotNil(t, data[1], "calldata after the blob")
+
+	// make sure blob tx to the batch inbox is ignored if not signed by the batcher
+	blobTx, _ = types.SignNewTx(testutils.RandomKey(), signer, blobTxData)
+	txs = types.Transactions{blobTx}
+	data, blobHashes = dataAndHashesFromTxs(txs, &config, batcherAddr, logger)
+	require.Equal(t, 0, len(data))
+	require.Equal(t, 0, len(blobHashes))
+
+	// make sure blob tx ignored if the tx isn't going to the batch inbox addr, even if the
+	// signature is valid. Its blobs still count towards the blob index of later txs.
+	blobTxData.To = testutils.RandomAddress(rng)
+	otherTx, _ := types.SignNewTx(privateKey, signer, blobTxData)
+	blobTxData.To = batchInboxAddr
+	blobTx, _ = types.SignNewTx(privateKey, signer, blobTxData)
+	txs = types.Transactions{otherTx, blobTx}
+	data, blobHashes = dataAndHashesFromTxs(txs, &config, batcherAddr, logger)
+	require.Equal(t, 1, len(data))
+	require.Equal(t, []eth.IndexedBlobHash{{Index: 1, Hash: blobHash}}, blobHashes)
+}
+
+func TestBlobDataSource(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	privateKey := testutils.InsecureRandomKey(rng)
+	batcherAddr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	eclipseTime := uint64(1000)
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(100),
+		BatchInboxAddress: testutils.RandomAddress(rng),
+		EclipseTime:       &eclipseTime,
+	}
+	signer := cfg.L1Signer()
+	logger := testlog.Logger(t, log.LvlInfo)
+
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(eth.Data("blob batch")))
+	blobHash := testutils.RandomHash(rng)
+	blobTx, err := types.SignNewTx(privateKey, signer, &types.BlobTx{
+		ChainID:    uint256.MustFromBig(cfg.L1ChainID),
+		Gas:        100_000,
+		To:         cfg.BatchInboxAddress,
+		BlobHashes: []common.Hash{blobHash},
+	})
+	require.NoError(t, err)
+	calldataTx, err := types.SignNewTx(privateKey, signer, &types.DynamicFeeTx{
+		ChainID: cfg.L1ChainID,
+		Gas:     100_000,
+		To:      &cfg.BatchInboxAddress,
+		Data:    []byte("calldata batch"),
+	})
+	require.NoError(t, err)
+	txs := types.Transactions{calldataTx, blobTx}
+
+	ref := eth.L1BlockRef{Hash: common.Hash{0xaa}, Number: 10, Time: eclipseTime}
+	hashes := []eth.IndexedBlobHash{{Index: 0, Hash: blobHash}}
+
+	readAll := func(t *testing.T, src DataIter) []eth.Data {
+		var out []eth.Data
+		for {
+			data, err := src.Next(context.Background())
+			if err == io.EOF {
+				return out
+			}
+			require.NoError(t, err)
+			out = append(out, data)
+		}
+	}
+
+	t.Run("blobs and calldata in tx order", func(t *testing.T) {
+		l1F := &testutils.MockEthClient{}
+		blobsF := &mockBlobsFetcher{}
+		defer l1F.AssertExpectations(t)
+		defer blobsF.AssertExpectations(t)
+		l1F.ExpectInfoAndTxsByHash(ref.Hash, &testutils.MockBlockInfo{}, txs, nil)
+		blobsF.ExpectGetBlobs(ref, hashes, []*eth.Blob{&blob}, nil)
+
+		src := NewDataSourceFactory(logger, cfg, l1F, blobsF).OpenData(context.Background(), ref, batcherAddr)
+		require.IsType(t, &BlobDataSource{}, src)
+		require.Equal(t, []eth.Data{eth.Data("calldata batch"), eth.Data("blob batch")}, readAll(t, src))
+	})
+
+	t.Run("before eclipse", func(t *testing.T) {
+		l1F := &testutils.MockEthClient{}
+		defer l1F.AssertExpectations(t)
+		preRef := eth.L1BlockRef{Hash: common.Hash{0xbb}, Number: 9, Time: eclipseTime - 1}
+		l1F.ExpectInfoAndTxsByHash(preRef.Hash, &testutils.MockBlockInfo{}, txs, nil)
+
+		src := NewDataSourceFactory(logger, cfg, l1F, nil).OpenData(context.Background(), preRef, batcherAddr)
+		require.IsType(t, &DataSource{}, src)
+		// the calldata of the blob tx, which is empty, is read as is before Eclipse
+		require.Equal(t, []eth.Data{eth.Data("calldata batch"), eth.Data(nil)}, readAll(t, src))
+	})
+
+	t.Run("fetch errors", func(t *testing.T) {
+		l1F := &testutils.MockEthClient{}
+		blobsF := &mockBlobsFetcher{}
+		l1F.ExpectInfoAndTxsByHash(ref.Hash, &testutils.MockBlockInfo{}, nil, ethereum.NotFound)
+		l1F.ExpectInfoAndTxsByHash(ref.Hash, &testutils.MockBlockInfo{}, txs, nil)
+		l1F.ExpectInfoAndTxsByHash(ref.Hash, &testutils.MockBlockInfo{}, txs, nil)
+		blobsF.ExpectGetBlobs(ref, hashes, []*eth.Blob(nil), errors.New("unavailable"))
+		blobsF.ExpectGetBlobs(ref, hashes, []*eth.Blob{&blob}, nil)
+
+		src := NewBlobDataSource(context.Background(), logger, DataSourceConfig{signer, cfg.BatchInboxAddress}, l1F, blobsF, ref, batcherAddr)
+		_, err := src.Next(context.Background())
+		require.ErrorIs(t, err, ErrReset)
+		_, err = src.Next(context.Background())
+		require.ErrorIs(t, err, ErrTemporary)
+		require.Equal(t, []eth.Data{eth.Data("calldata batch"), eth.Data("blob batch")}, readAll(t, src), "retried")
+	})
+
+	t.Run("no blobs fetcher", func(t *testing.T) {
+		l1F := &testutils.MockEthClient{}
+		l1F.ExpectInfoAndTxsByHash(ref.Hash, &testutils.MockBlockInfo{}, txs, nil)
+		src := NewBlobDataSource(context.Background(), logger, DataSourceConfig{signer, cfg.BatchInboxAddress}, l1F, nil, ref, batcherAddr)
+		_, err := src.Next(context.Background())
+		require.ErrorIs(t, err, ErrCritical)
+	})
+}
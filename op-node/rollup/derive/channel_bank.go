@@ -3,6 +3,7 @@ package derive
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -80,6 +81,9 @@ func (cb *ChannelBank) prune() {
 // IngestFrame adds new L1 data to the channel bank.
 // Read() should be called repeatedly first, until everything has been read, before adding new data.
 func (cb *ChannelBank) IngestFrame(f Frame) {
+	defer func(start time.Time) {
+		cb.metrics.RecordDerivationStageTime(StageChannelBankFrame, time.Since(start))
+	}(time.Now())
 	origin := cb.Origin()
 	log := cb.log.New("origin", origin, "channel", f.ID, "length", len(f.Data), "frame_number", f.FrameNumber, "is_last", f.IsLast)
 	log.Debug("channel bank got new data")
@@ -1,7 +1,9 @@
 package derive
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"io"
 	"math/rand"
 	"strconv"
@@ -306,3 +308,147 @@ func TestChannelBankDuplicates(t *testing.T) {
 	require.Nil(t, out)
 	require.Equal(t, io.EOF, err)
 }
+
+// frameData encodes the frames as the data of a batcher transaction.
+func frameData(t *testing.T, frames ...testFrame) eth.Data {
+	var buf bytes.Buffer
+	buf.WriteByte(DerivationVersion0)
+	for _, tf := range frames {
+		f := tf.ToFrame()
+		require.NoError(t, f.MarshalBinary(&buf))
+	}
+	return buf.Bytes()
+}
+
+// TestChannelBankTransientL1Failure tests that a temporary failure of the L1 source, while advancing to the next L1 block,
+// does not drop the frames that are buffered in the channel bank: retrying the step in place completes the channel.
+func TestChannelBankTransientL1Failure(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	a := testutils.RandomBlockRef(rng)
+	b := testutils.NextRandomRef(rng, a)
+	sysCfg := eth.SystemConfig{BatcherAddr: testutils.RandomAddress(rng)}
+	cfg := &rollup.Config{ChannelTimeout: 10, L1SystemConfigAddress: testutils.RandomAddress(rng)}
+	logger := testlog.Logger(t, log.LvlError)
+
+	l1 := &testutils.MockL1Source{}
+	defer l1.AssertExpectations(t)
+	dataSrc := &MockDataSource{}
+	defer dataSrc.AssertExpectations(t)
+	dataSrc.ExpectOpenData(a.ID(), &fakeDataIter{
+		data: []eth.Data{frameData(t, "a:0:first"), nil},
+		errs: []error{nil, io.EOF},
+	}, sysCfg.BatcherAddr)
+	dataSrc.ExpectOpenData(b.ID(), &fakeDataIter{
+		data: []eth.Data{frameData(t, "a:1:second!"), nil},
+		errs: []error{nil, io.EOF},
+	}, sysCfg.BatcherAddr)
+
+	traversal := NewL1Traversal(logger, cfg, l1, metrics.NoopMetrics)
+	require.Equal(t, io.EOF, traversal.Reset(context.Background(), a, sysCfg))
+	cb := NewChannelBank(logger, cfg, NewFrameQueue(logger, NewL1Retrieval(logger, dataSrc, traversal)), nil, metrics.NoopMetrics)
+
+	// readAll reads from the channel bank until it runs out of data of the current L1 block.
+	readAll := func() (out []byte) {
+		for {
+			data, err := cb.NextData(context.Background())
+			if err == io.EOF {
+				return out
+			} else if errors.Is(err, NotEnoughData) {
+				continue
+			}
+			require.NoError(t, err)
+			out = append(out, data...)
+		}
+	}
+
+	require.Empty(t, readAll(), "the channel is not complete in the first L1 block")
+
+	// the L1 source fails temporarily, while the first frame of the channel is buffered
+	l1.ExpectL1BlockRefByNumber(b.Number, eth.L1BlockRef{}, errors.New("connection reset by peer"))
+	require.ErrorIs(t, traversal.AdvanceL1Block(context.Background()), ErrTemporary)
+	require.Empty(t, readAll())
+	require.Len(t, cb.channels, 1, "buffered channel survives the failure")
+
+	// the step is retried in place, and the channel completes with the frame of the next L1 block
+	l1.ExpectL1BlockRefByNumber(b.Number, b, nil)
+	l1.ExpectFetchReceipts(b.Hash, &testutils.MockBlockInfo{InfoHash: b.Hash, InfoNum: b.Number}, nil, nil)
+	require.NoError(t, traversal.AdvanceL1Block(context.Background()))
+	require.Equal(t, "firstsecond", string(readAll()))
+}
+
+// TestChannelBankForceClose tests that the channel bank reads a channel that is stuck on missing frames
+// as soon as the data of CloseChannelTxData is included, instead of holding the channel until it times out.
+func TestChannelBankForceClose(t *testing.T) {
+	tests := []struct {
+		name      string
+		submitted []testFrame
+	}{
+		// the batcher submitted the first & the third frame of the channel, but not the last
+		{"unclosed", []testFrame{"a:0:first", "a:2:third"}},
+		// the batcher submitted the first & the last frame of the channel, but not the second
+		{"missing frame", []testFrame{"a:0:first", "a:2:third!"}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			testChannelBankForceClose(t, test.submitted)
+		})
+	}
+}
+
+func testChannelBankForceClose(t *testing.T, submittedFrames []testFrame) {
+	rng := rand.New(rand.NewSource(1234))
+	a := testutils.RandomBlockRef(rng)
+	b := testutils.NextRandomRef(rng, a)
+	sysCfg := eth.SystemConfig{BatcherAddr: testutils.RandomAddress(rng)}
+	cfg := &rollup.Config{ChannelTimeout: 10, L1SystemConfigAddress: testutils.RandomAddress(rng)}
+	logger := testlog.Logger(t, log.LvlError)
+
+	submitted := frameData(t, submittedFrames...)
+	frames, err := ParseFrames(submitted)
+	require.NoError(t, err)
+	closeData, err := CloseChannelTxData(testFrame("a:0:").ChannelID(), frames)
+	require.NoError(t, err)
+
+	l1 := &testutils.MockL1Source{}
+	defer l1.AssertExpectations(t)
+	dataSrc := &MockDataSource{}
+	defer dataSrc.AssertExpectations(t)
+	dataSrc.ExpectOpenData(a.ID(), &fakeDataIter{
+		data: []eth.Data{submitted, nil},
+		errs: []error{nil, io.EOF},
+	}, sysCfg.BatcherAddr)
+	dataSrc.ExpectOpenData(b.ID(), &fakeDataIter{
+		data: []eth.Data{closeData, nil},
+		errs: []error{nil, io.EOF},
+	}, sysCfg.BatcherAddr)
+
+	traversal := NewL1Traversal(logger, cfg, l1, metrics.NoopMetrics)
+	require.Equal(t, io.EOF, traversal.Reset(context.Background(), a, sysCfg))
+	cb := NewChannelBank(logger, cfg, NewFrameQueue(logger, NewL1Retrieval(logger, dataSrc, traversal)), nil, metrics.NoopMetrics)
+
+	// readAll reads from the channel bank until it runs out of data of the current L1 block.
+	readAll := func() (out []byte) {
+		for {
+			data, err := cb.NextData(context.Background())
+			if err == io.EOF {
+				return out
+			} else if errors.Is(err, NotEnoughData) {
+				continue
+			}
+			require.NoError(t, err)
+			out = append(out, data...)
+		}
+	}
+
+	require.Empty(t, readAll())
+	require.Len(t, cb.channels, 1, "the incomplete channel is held")
+
+	// the channel is complete with the close data, and leaves the channel bank in the next L1 block
+	l1.ExpectL1BlockRefByNumber(b.Number, b, nil)
+	l1.ExpectFetchReceipts(b.Hash, &testutils.MockBlockInfo{InfoHash: b.Hash, InfoNum: b.Number}, nil, nil)
+	require.NoError(t, traversal.AdvanceL1Block(context.Background()))
+	require.Equal(t, "firstthird", string(readAll()))
+	require.Empty(t, cb.channels)
+	require.Empty(t, cb.channelQueue)
+}
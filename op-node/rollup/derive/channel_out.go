@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -16,6 +17,7 @@ import (
 var ErrMaxFrameSizeTooSmall = errors.New("maxSize is too small to fit the fixed frame overhead")
 var ErrNotDepositTx = errors.New("first transaction in block is not a deposit tx")
 var ErrTooManyRLPBytes = errors.New("batch would cause RLP bytes to go over limit")
+var ErrChannelClosed = errors.New("channel is already closed")
 
 // FrameV0OverHeadSize is the absolute minimum size of a frame.
 // This is the fixed overhead frame size, calculated as specified
@@ -255,58 +257,66 @@ func BlockToSingularBatch(block *types.Block) (*SingularBatch, L1BlockInfo, erro
 	}, l1Info, nil
 }
 
-// ForceCloseTxData generates the transaction data for a transaction which will force close
-// a channel. It should be given every frame of that channel which has been submitted on
-// chain. The frames should be given in order that they appear on L1.
-func ForceCloseTxData(frames []Frame) ([]byte, error) {
+// CloseChannelTxData generates the transaction data which completes a channel that is stuck on missing frames,
+// so that the channel bank reads the channel without waiting for the channel timeout.
+// It should be given every frame of that channel which has been submitted on chain, in the order that they
+// appear on L1. If the last frame was never submitted, the data holds an empty last frame, numbered after the
+// highest submitted frame. In both cases the data holds an empty frame for every missing number before the
+// last frame, so the channel is complete once the data is included. It errors if the channel is already complete.
+func CloseChannelTxData(id ChannelID, frames []Frame) ([]byte, error) {
 	if len(frames) == 0 {
-		return nil, errors.New("must provide at least one frame")
+		return nil, fmt.Errorf("no frames of channel %v", id)
 	}
+	// The frame numbers the channel bank holds once the frames are read, in order.
 	frameNumbers := make(map[uint16]struct{})
-	id := frames[0].ID
-	closeNumber := uint16(0)
 	closed := false
-	for i, frame := range frames {
-		if !closed && frame.IsLast {
-			closeNumber = frame.FrameNumber
+	last := uint16(0)
+	for _, frame := range frames {
+		if frame.ID != id {
+			return nil, fmt.Errorf("frame %d is of channel %v, not of channel %v", frame.FrameNumber, frame.ID, id)
+		}
+		// duplicate frames, and last frames or frames numbered after the last frame of a closed channel, are dropped
+		if _, ok := frameNumbers[frame.FrameNumber]; ok || (closed && (frame.IsLast || frame.FrameNumber > last)) {
+			continue
+		}
+		if frame.IsLast {
+			closed = true
+			last = frame.FrameNumber
+			// frames numbered after the last frame are pruned
+			for n := range frameNumbers {
+				if n > last {
+					delete(frameNumbers, n)
+				}
+			}
+		} else if !closed {
+			last = max(last, frame.FrameNumber)
 		}
-		closed = closed || frame.IsLast
 		frameNumbers[frame.FrameNumber] = struct{}{}
-		if frame.ID != id {
-			return nil, fmt.Errorf("invalid ID in list: first ID: %v, %vth ID: %v", id, i, frame.ID)
+	}
+	if !closed {
+		if last == math.MaxUint16 {
+			return nil, fmt.Errorf("channel %v has no frame number left to close it", id)
 		}
+		last++
+	} else if len(frameNumbers) == int(last)+1 {
+		return nil, fmt.Errorf("%w: channel %v has every frame up to last frame %d", ErrChannelClosed, id, last)
 	}
 
 	var out bytes.Buffer
 	out.WriteByte(DerivationVersion0)
-
-	if !closed {
+	for i := 0; i <= int(last); i++ {
+		if _, ok := frameNumbers[uint16(i)]; ok {
+			continue
+		}
 		f := Frame{
 			ID:          id,
-			FrameNumber: 0,
-			Data:        nil,
-			IsLast:      true,
+			FrameNumber: uint16(i),
+			IsLast:      uint16(i) == last,
 		}
 		if err := f.MarshalBinary(&out); err != nil {
 			return nil, err
 		}
-	} else {
-		for i := uint16(0); i <= closeNumber; i++ {
-			if _, ok := frameNumbers[i]; ok {
-				continue
-			}
-			f := Frame{
-				ID:          id,
-				FrameNumber: i,
-				Data:        nil,
-				IsLast:      false,
-			}
-			if err := f.MarshalBinary(&out); err != nil {
-				return nil, err
-			}
-		}
 	}
-
 	return out.Bytes(), nil
 }
 
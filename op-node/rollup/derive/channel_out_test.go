@@ -2,6 +2,7 @@ package derive
 
 import (
 	"bytes"
+	"math"
 	"math/big"
 	"testing"
 
@@ -84,70 +85,83 @@ func TestRLPByteLimit(t *testing.T) {
 	require.Equal(t, out2, "")
 }
 
-func TestForceCloseTxData(t *testing.T) {
+func TestCloseChannelTxData(t *testing.T) {
 	id := [16]byte{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad, 0xbe, 0xef, 0xde, 0xad, 0xbe, 0xef, 0xde, 0xad, 0xbe, 0xef}
 	tests := []struct {
+		name   string
 		frames []Frame
-		errors bool
+		err    string
 		output string
 	}{
 		{
+			name:   "no frames",
 			frames: []Frame{},
-			errors: true,
-			output: "",
+			err:    "no frames",
 		},
 		{
-			frames: []Frame{{FrameNumber: 0, IsLast: false}, {ID: id, FrameNumber: 1, IsLast: true}},
-			errors: true,
-			output: "",
+			name:   "other channel",
+			frames: []Frame{{ID: id, FrameNumber: 0}, {FrameNumber: 1}},
+			err:    "frame 1 is of channel",
 		},
 		{
-			frames: []Frame{{ID: id, FrameNumber: 0, IsLast: false}},
-			errors: false,
-			output: "00deadbeefdeadbeefdeadbeefdeadbeef00000000000001",
+			name:   "complete",
+			frames: []Frame{{ID: id, FrameNumber: 0}, {ID: id, FrameNumber: 1, IsLast: true}},
+			err:    ErrChannelClosed.Error(),
 		},
 		{
-			frames: []Frame{{ID: id, FrameNumber: 0, IsLast: true}},
-			errors: false,
-			output: "00",
+			name:   "complete with pruned frames",
+			frames: []Frame{{ID: id, FrameNumber: 0}, {ID: id, FrameNumber: 2}, {ID: id, FrameNumber: 1, IsLast: true}, {ID: id, FrameNumber: 3}},
+			err:    ErrChannelClosed.Error(),
 		},
 		{
-			frames: []Frame{{ID: id, FrameNumber: 1, IsLast: false}},
-			errors: false,
-			output: "00deadbeefdeadbeefdeadbeefdeadbeef00000000000001",
+			name:   "no frame number left",
+			frames: []Frame{{ID: id, FrameNumber: math.MaxUint16}},
+			err:    "no frame number left",
 		},
 		{
-			frames: []Frame{{ID: id, FrameNumber: 1, IsLast: true}},
-			errors: false,
-			output: "00deadbeefdeadbeefdeadbeefdeadbeef00000000000000",
+			name:   "first frame",
+			frames: []Frame{{ID: id, FrameNumber: 0}},
+			output: "00deadbeefdeadbeefdeadbeefdeadbeef00010000000001",
 		},
 		{
-			frames: []Frame{{ID: id, FrameNumber: 2, IsLast: true}},
-			errors: false,
-			output: "00deadbeefdeadbeefdeadbeefdeadbeef00000000000000deadbeefdeadbeefdeadbeefdeadbeef00010000000000",
+			name:   "contiguous frames",
+			frames: []Frame{{ID: id, FrameNumber: 1}, {ID: id, FrameNumber: 0}, {ID: id, FrameNumber: 2}},
+			output: "00deadbeefdeadbeefdeadbeefdeadbeef00030000000001",
 		},
 		{
-			frames: []Frame{{ID: id, FrameNumber: 1, IsLast: false}, {ID: id, FrameNumber: 3, IsLast: true}},
-			errors: false,
+			name:   "missing frames",
+			frames: []Frame{{ID: id, FrameNumber: 2}},
+			output: "00deadbeefdeadbeefdeadbeefdeadbeef00000000000000deadbeefdeadbeefdeadbeefdeadbeef00010000000000deadbeefdeadbeefdeadbeefdeadbeef00030000000001",
+		},
+		{
+			name:   "closed with missing frames",
+			frames: []Frame{{ID: id, FrameNumber: 1}, {ID: id, FrameNumber: 3, IsLast: true}},
 			output: "00deadbeefdeadbeefdeadbeefdeadbeef00000000000000deadbeefdeadbeefdeadbeefdeadbeef00020000000000",
 		},
 		{
-			frames: []Frame{{ID: id, FrameNumber: 1, IsLast: false}, {ID: id, FrameNumber: 3, IsLast: true}, {ID: id, FrameNumber: 5, IsLast: true}},
-			errors: false,
+			name:   "closed with dropped frames",
+			frames: []Frame{{ID: id, FrameNumber: 4}, {ID: id, FrameNumber: 1}, {ID: id, FrameNumber: 3, IsLast: true}, {ID: id, FrameNumber: 2, IsLast: true}, {ID: id, FrameNumber: 5, IsLast: true}},
 			output: "00deadbeefdeadbeefdeadbeefdeadbeef00000000000000deadbeefdeadbeefdeadbeefdeadbeef00020000000000",
 		},
 	}
 
-	for i, test := range tests {
-		out, err := ForceCloseTxData(test.frames)
-		if test.errors {
-			require.NotNil(t, err, "Should error on tc %v", i)
-			require.Nil(t, out, "Should return no value in tc %v", i)
-		} else {
-			require.NoError(t, err, "Should not error on tc %v", i)
-			require.Equal(t, common.FromHex(test.output), out, "Should match output tc %v", i)
-		}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			out, err := CloseChannelTxData(id, test.frames)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				require.Nil(t, out)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, common.FromHex(test.output), out)
+		})
 	}
+	t.Run("closed error", func(t *testing.T) {
+		_, err := CloseChannelTxData(id, []Frame{{ID: id, FrameNumber: 0, IsLast: true}})
+		require.ErrorIs(t, err, ErrChannelClosed)
+	})
 }
 
 func TestBlockToBatchValidity(t *testing.T) {
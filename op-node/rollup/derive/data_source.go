@@ -0,0 +1,76 @@
+package derive
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type L1TransactionFetcher interface {
+	InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error)
+}
+
+type L1BlobsFetcher interface {
+	// GetBlobs fetches blobs that were confirmed in the given L1 block with the given indexed hashes.
+	GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error)
+}
+
+// DataSourceFactory readers raw transactions from a given block & then filters for
+// batch submitter transactions.
+// This is not a stage in the pipeline, but a wrapper for another stage in the pipeline
+type DataSourceFactory struct {
+	log          log.Logger
+	dsCfg        DataSourceConfig
+	fetcher      L1TransactionFetcher
+	blobsFetcher L1BlobsFetcher
+	cfg          *rollup.Config
+}
+
+func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher, blobsFetcher L1BlobsFetcher) *DataSourceFactory {
+	return &DataSourceFactory{
+		log:          log,
+		dsCfg:        DataSourceConfig{l1Signer: cfg.L1Signer(), batchInboxAddress: cfg.BatchInboxAddress},
+		fetcher:      fetcher,
+		blobsFetcher: blobsFetcher,
+		cfg:          cfg,
+	}
+}
+
+// OpenData returns the appropriate data source for the L1 block `ref`.
+// Batches may be posted in blobs from the Eclipse upgrade onwards, timed by the L1 block.
+func (ds *DataSourceFactory) OpenData(ctx context.Context, ref eth.L1BlockRef, batcherAddr common.Address) DataIter {
+	if ds.cfg.IsEclipse(ref.Time) {
+		return NewBlobDataSource(ctx, ds.log, ds.dsCfg, ds.fetcher, ds.blobsFetcher, ref, batcherAddr)
+	}
+	return NewDataSource(ctx, ds.log, ds.dsCfg, ds.fetcher, ref.ID(), batcherAddr)
+}
+
+// DataSourceConfig regroups the mandatory rollup.Config fields needed for DataFromEVMTransactions.
+type DataSourceConfig struct {
+	l1Signer          types.Signer
+	batchInboxAddress common.Address
+}
+
+// isValidBatchTx returns true if the tx is sent to the batch inbox address, by the batch submitter.
+func isValidBatchTx(tx *types.Transaction, dsCfg DataSourceConfig, batcherAddr common.Address, log log.Logger) bool {
+	to := tx.To()
+	if to == nil || *to != dsCfg.batchInboxAddress {
+		return false
+	}
+	seqDataSubmitter, err := dsCfg.l1Signer.Sender(tx) // optimization: only derive sender if To is correct
+	if err != nil {
+		log.Warn("tx in inbox with invalid signature", "txHash", tx.Hash(), "err", err)
+		return false // bad signature, ignore
+	}
+	// some random L1 user might have sent a transaction to our batch inbox, ignore them
+	if seqDataSubmitter != batcherAddr {
+		log.Warn("tx in inbox with unauthorized submitter", "txHash", tx.Hash(), "err", err)
+		return false // not an authorized batch submitter, ignore
+	}
+	return true
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -29,11 +30,11 @@ type NextAttributesProvider interface {
 }
 
 type Engine interface {
-	GetPayload(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error)
+	GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error)
 	ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
-	NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error)
-	PayloadByHash(context.Context, common.Hash) (*eth.ExecutionPayload, error)
-	PayloadByNumber(context.Context, uint64) (*eth.ExecutionPayload, error)
+	NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error)
+	PayloadByHash(context.Context, common.Hash) (*eth.ExecutionPayloadEnvelope, error)
+	PayloadByNumber(context.Context, uint64) (*eth.ExecutionPayloadEnvelope, error)
 	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
 	L2BlockRefByHash(ctx context.Context, l2Hash common.Hash) (eth.L2BlockRef, error)
 	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
@@ -57,7 +58,10 @@ type EngineControl interface {
 	// If updateSafe, the resulting block will be marked as a safe block.
 	StartPayload(ctx context.Context, parent eth.L2BlockRef, attrs *eth.PayloadAttributes, updateSafe bool) (errType BlockInsertionErrType, err error)
 	// ConfirmPayload requests the engine to complete the current block. If no block is being built, or if it fails, an error is returned.
-	ConfirmPayload(ctx context.Context) (out *eth.ExecutionPayload, errTyp BlockInsertionErrType, err error)
+	ConfirmPayload(ctx context.Context) (out *eth.ExecutionPayloadEnvelope, errTyp BlockInsertionErrType, err error)
+	// ConfirmPayloadWithin is like ConfirmPayload, but returns ErrPayloadTimeout if the engine does not return the payload
+	// within the timeout. The block is then still being built. Once returned, the payload is inserted regardless of the timeout.
+	ConfirmPayloadWithin(ctx context.Context, timeout time.Duration) (out *eth.ExecutionPayloadEnvelope, errTyp BlockInsertionErrType, err error)
 	// CancelPayload requests the engine to stop building the current block without making it canonical.
 	// This is optional, as the engine expires building jobs that are left uncompleted, but can still save resources.
 	CancelPayload(ctx context.Context, force bool) error
@@ -65,6 +69,25 @@ type EngineControl interface {
 	BuildingPayload() (onto eth.L2BlockRef, id eth.PayloadID, safe bool)
 }
 
+// SafeHeadListener is notified of the safe head progression of the engine queue, e.g. to persist it.
+type SafeHeadListener interface {
+	// SafeHeadUpdated indicates that the safe head advanced,
+	// after deriving from the L1 chain up to and including the given L1 block.
+	SafeHeadUpdated(newSafeHead eth.L2BlockRef, l1Block eth.BlockID) error
+	// SafeHeadReset indicates that the derivation pipeline reset to the given safe head.
+	// The L1 block that made the reset safe head safe is unknown.
+	SafeHeadReset(resetSafeHead eth.L2BlockRef) error
+}
+
+type noopSafeHeadListener struct{}
+
+func (noopSafeHeadListener) SafeHeadUpdated(eth.L2BlockRef, eth.BlockID) error { return nil }
+
+func (noopSafeHeadListener) SafeHeadReset(eth.L2BlockRef) error { return nil }
+
+// NoopSafeHeadListener ignores the safe head progression.
+var NoopSafeHeadListener SafeHeadListener = noopSafeHeadListener{}
+
 // Max memory used for buffering unsafe payloads
 const maxUnsafePayloadsMemory = 500 * 1024 * 1024
 
@@ -83,6 +106,9 @@ const maxUnsafePayloadsMemory = 500 * 1024 * 1024
 // And then we add 1 to make pruning easier by leaving room for a new item without pruning the 32*4.
 const finalityLookback = 4*32 + 1
 
+// maxUnsafeReorgs is the number of recent unsafe chain reorgs to keep track of, for debugging purposes.
+const maxUnsafeReorgs = 8
+
 // finalityDelay is the number of L1 blocks to traverse before trying to finalize L2 blocks again.
 // We do not want to do this too often, since it requires fetching a L1 block by number, so no cache data.
 const finalityDelay = 64
@@ -113,7 +139,7 @@ type EngineQueue struct {
 	engineSyncTarget eth.L2BlockRef
 
 	buildingOnto eth.L2BlockRef
-	buildingID   eth.PayloadID
+	buildingInfo eth.PayloadInfo
 	buildingSafe bool
 
 	// Track when the rollup node changes the forkchoice without engine action,
@@ -128,6 +154,12 @@ type EngineQueue struct {
 	// triedFinalizeAt tracks at which origin we last tried to finalize during sync.
 	triedFinalizeAt eth.L1BlockRef
 
+	safeHeadNotifs SafeHeadListener // notified when the safe head is updated
+
+	// finalityCheckPending is set when the finalized L1 block has no matching finality data,
+	// and the finality data needs to be checked against the finalizing L1 chain before finalizing L2 blocks.
+	finalityCheckPending bool
+
 	// The queued-up attributes
 	safeAttributes *AttributesWithParent
 	unsafePayloads *PayloadsQueue // queue of unsafe payloads, ordered by ascending block number, may have gaps and duplicates
@@ -135,6 +167,9 @@ type EngineQueue struct {
 	// Tracks which L2 blocks where last derived from which L1 block. At most finalityLookback large.
 	finalityData []FinalityData
 
+	// The latest reorgs of the unsafe chain by conflicting safe blocks, oldest first. At most maxUnsafeReorgs large.
+	unsafeReorgs []UnsafeReorg
+
 	engine Engine
 	prev   NextAttributesProvider
 
@@ -150,7 +185,7 @@ type EngineQueue struct {
 var _ EngineControl = (*EngineQueue)(nil)
 
 // NewEngineQueue creates a new EngineQueue, which should be Reset(origin) before use.
-func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher, syncCfg *sync.Config) *EngineQueue {
+func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher, syncCfg *sync.Config, safeHeadListener SafeHeadListener) *EngineQueue {
 	return &EngineQueue{
 		log:            log,
 		cfg:            cfg,
@@ -161,6 +196,7 @@ func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics M
 		prev:           prev,
 		l1Fetcher:      l1Fetcher,
 		syncCfg:        syncCfg,
+		safeHeadNotifs: safeHeadListener,
 	}
 }
 
@@ -183,17 +219,17 @@ func (eq *EngineQueue) SetEngineSyncTarget(head eth.L2BlockRef) {
 	eq.metrics.RecordL2Ref("l2_engineSyncTarget", head)
 }
 
-func (eq *EngineQueue) AddUnsafePayload(payload *eth.ExecutionPayload) {
-	if payload == nil {
+func (eq *EngineQueue) AddUnsafePayload(envelope *eth.ExecutionPayloadEnvelope) {
+	if envelope == nil || envelope.ExecutionPayload == nil {
 		eq.log.Warn("cannot add nil unsafe payload")
 		return
 	}
 
-	if err := eq.unsafePayloads.Push(payload); err != nil {
-		eq.log.Warn("Could not add unsafe payload", "id", payload.ID(), "timestamp", uint64(payload.Timestamp), "err", err)
+	if err := eq.unsafePayloads.Push(envelope); err != nil {
+		eq.log.Warn("Could not add unsafe payload", "id", envelope.ExecutionPayload.ID(), "timestamp", uint64(envelope.ExecutionPayload.Timestamp), "err", err)
 		return
 	}
-	p := eq.unsafePayloads.Peek()
+	p := eq.unsafePayloads.Peek().ExecutionPayload
 	eq.metrics.RecordUnsafePayloadsBuffer(uint64(eq.unsafePayloads.Len()), eq.unsafePayloads.MemSize(), p.ID())
 	eq.log.Trace("Next unsafe payload to process", "next", p.ID(), "timestamp", uint64(p.Timestamp))
 }
@@ -203,6 +239,12 @@ func (eq *EngineQueue) Finalize(l1Origin eth.L1BlockRef) {
 		eq.log.Error("ignoring old L1 finalized block signal! Is the L1 provider corrupted?", "prev_finalized_l1", eq.finalizedL1, "signaled_finalized_l1", l1Origin)
 		return
 	}
+	if eq.finalizedL1 != (eth.L1BlockRef{}) && l1Origin.Number == eq.finalizedL1.Number {
+		if l1Origin.Hash != eq.finalizedL1.Hash {
+			eq.log.Error("ignoring conflicting L1 finalized block signal! Is the L1 provider corrupted?", "prev_finalized_l1", eq.finalizedL1, "signaled_finalized_l1", l1Origin)
+		}
+		return // nothing new to finalize
+	}
 
 	// remember the L1 finalization signal
 	eq.finalizedL1 = l1Origin
@@ -219,6 +261,10 @@ func (eq *EngineQueue) Finalize(l1Origin eth.L1BlockRef) {
 		}
 	}
 
+	// The finalized L1 block may not have any finality data, e.g. if it did not contain any batch data.
+	// The L2 blocks derived from the L1 blocks before it can then still be finalized,
+	// after checking during the next step that we derived them from the finalizing L1 chain.
+	eq.finalityCheckPending = true
 	eq.log.Info("received L1 finality signal, but missing data for immediate L2 finalization", "prev_finalized_l1", eq.finalizedL1, "signaled_finalized_l1", l1Origin)
 }
 
@@ -248,11 +294,54 @@ func (eq *EngineQueue) EngineSyncTarget() eth.L2BlockRef {
 	return eq.engineSyncTarget
 }
 
+// UnsafePayloadsSummary summarizes the queue of unsafe payloads that are not yet processed.
+type UnsafePayloadsSummary struct {
+	Len     int    `json:"len"`
+	MemSize uint64 `json:"memSize"`
+	// Next is the lowest queued payload, zeroed if the queue is empty.
+	Next eth.BlockID `json:"next"`
+}
+
+func (eq *EngineQueue) UnsafePayloads() UnsafePayloadsSummary {
+	out := UnsafePayloadsSummary{Len: eq.unsafePayloads.Len(), MemSize: eq.unsafePayloads.MemSize()}
+	if p := eq.unsafePayloads.Peek(); p != nil {
+		out.Next = p.ExecutionPayload.ID()
+	}
+	return out
+}
+
+// UnsafeReorg is a reorg of the unsafe chain, by a safe block derived from L1 that conflicts with the unsafe block at the same height.
+type UnsafeReorg struct {
+	// Time is the unix timestamp in milliseconds of the reorg.
+	Time int64 `json:"time"`
+	// Replaced is the unsafe block that was replaced by the safe block.
+	Replaced eth.BlockID `json:"replaced"`
+	// Safe is the safe block that replaced the unsafe block, with the L1 origin it was derived with.
+	Safe eth.L2BlockRef `json:"safe"`
+	// Depth is the number of unsafe blocks that were reorged.
+	Depth uint64 `json:"depth"`
+}
+
+// RecentUnsafeReorgs returns the latest reorgs of the unsafe chain, oldest first.
+func (eq *EngineQueue) RecentUnsafeReorgs() []UnsafeReorg {
+	return slices.Clone(eq.unsafeReorgs)
+}
+
 // Determine if the engine is syncing to the target block
 func (eq *EngineQueue) isEngineSyncing() bool {
 	return eq.unsafeHead.Hash != eq.engineSyncTarget.Hash
 }
 
+// deferToELSync determines if the unsafe payload is inserted without requiring the parent chain,
+// to let the execution engine sync towards it. Once the engine is syncing, it keeps syncing towards the newer payloads,
+// until it validated the sync target.
+func (eq *EngineQueue) deferToELSync(payload *eth.ExecutionPayload) bool {
+	if eq.syncCfg.SyncMode != sync.ELSync {
+		return false
+	}
+	return eq.isEngineSyncing() || uint64(payload.BlockNumber) > eq.safeHead.Number+eq.syncCfg.ELSyncDistance
+}
+
 func (eq *EngineQueue) Step(ctx context.Context) error {
 	if eq.needForkchoiceUpdate {
 		return eq.tryUpdateEngine(ctx)
@@ -341,13 +430,14 @@ func (eq *EngineQueue) tryFinalizePastL2Blocks(ctx context.Context) error {
 
 	// If the L1 is finalized beyond the point we are traversing (e.g. during sync),
 	// then we should check if we can finalize this L1 block we are traversing.
-	// Otherwise, nothing to act on here, we will finalize later on a new finality signal matching the recent history.
-	if eq.finalizedL1.Number < eq.origin.Number {
+	// Otherwise, nothing to act on here, we will finalize later on a new finality signal matching the recent history,
+	// unless the last finality signal did not match the recent history, and is pending a check.
+	if eq.finalizedL1.Number < eq.origin.Number && !eq.finalityCheckPending {
 		return nil
 	}
 
 	// If we recently tried finalizing, then don't try again just yet, but traverse more of L1 first.
-	if eq.triedFinalizeAt != (eth.L1BlockRef{}) && eq.origin.Number <= eq.triedFinalizeAt.Number+finalityDelay {
+	if !eq.finalityCheckPending && eq.triedFinalizeAt != (eth.L1BlockRef{}) && eq.origin.Number <= eq.triedFinalizeAt.Number+finalityDelay {
 		return nil
 	}
 
@@ -359,6 +449,7 @@ func (eq *EngineQueue) tryFinalizePastL2Blocks(ctx context.Context) error {
 	if err != nil {
 		return NewTemporaryError(fmt.Errorf("failed to check if on finalizing L1 chain: %w", err))
 	}
+	eq.finalityCheckPending = false
 	if ref.Hash != eq.origin.Hash {
 		return NewResetError(fmt.Errorf("need to reset, we are on %s, not on the finalizing L1 chain %s (towards %s)", eq.origin, ref, eq.finalizedL1))
 	}
@@ -385,11 +476,24 @@ func (eq *EngineQueue) tryFinalizeL2() {
 	}
 	eq.finalized = finalizedL2
 	eq.metrics.RecordL2Ref("l2_finalized", finalizedL2)
+
+	// prune the finality data that was finalized, it is no longer needed
+	pruned := 0
+	for pruned < len(eq.finalityData) && eq.finalityData[pruned].L1Block.Number <= eq.finalizedL1.Number {
+		pruned++
+	}
+	if pruned > 0 {
+		eq.finalityData = append(eq.finalityData[:0], eq.finalityData[pruned:]...)
+	}
 }
 
 // postProcessSafeL2 buffers the L1 block the safe head was fully derived from,
 // to finalize it once the L1 block, or later, finalizes.
 func (eq *EngineQueue) postProcessSafeL2() {
+	// the safe head database is auxiliary, and heals gaps on the next reset: do not stall the derivation on errors
+	if err := eq.safeHeadNotifs.SafeHeadUpdated(eq.safeHead, eq.origin.ID()); err != nil {
+		eq.log.Error("Failed to notify safe head update", "safe", eq.safeHead, "l1", eq.origin, "err", err)
+	}
 	// prune finality data if necessary
 	if len(eq.finalityData) >= finalityLookback {
 		eq.finalityData = append(eq.finalityData[:0], eq.finalityData[1:finalityLookback]...)
@@ -437,6 +541,9 @@ func (eq *EngineQueue) tryUpdateEngine(ctx context.Context) error {
 		SafeBlockHash:      eq.safeHead.Hash,
 		FinalizedBlockHash: eq.finalized.Hash,
 	}
+	if err := eq.checkForkchoice(&fc, eq.engineSyncTarget); err != nil {
+		return NewResetError(err)
+	}
 	_, err := eq.engine.ForkchoiceUpdate(ctx, &fc, nil)
 	if err != nil {
 		var inputErr eth.InputError
@@ -455,6 +562,18 @@ func (eq *EngineQueue) tryUpdateEngine(ctx context.Context) error {
 	return nil
 }
 
+// checkForkchoice verifies that the forkchoice state, with the given head, is consistent
+// with the safe and finalized heads of the engine queue, before it is sent to the engine.
+func (eq *EngineQueue) checkForkchoice(fc *eth.ForkchoiceState, head eth.L2BlockRef) error {
+	if err := fc.Validate(); err != nil {
+		return fmt.Errorf("invalid forkchoice state %s: %w", fc, err)
+	}
+	if err := eth.CheckForkchoiceRefs(head, eq.safeHead, eq.finalized); err != nil {
+		return fmt.Errorf("invalid forkchoice state %s: %w", fc, err)
+	}
+	return nil
+}
+
 // checkNewPayloadStatus checks returned status of engine_newPayloadV1 request for next unsafe payload.
 // It returns true if the status is acceptable.
 func (eq *EngineQueue) checkNewPayloadStatus(status eth.ExecutePayloadStatus) bool {
@@ -476,7 +595,8 @@ func (eq *EngineQueue) checkForkchoiceUpdatedStatus(status eth.ExecutePayloadSta
 }
 
 func (eq *EngineQueue) tryNextUnsafePayload(ctx context.Context) error {
-	first := eq.unsafePayloads.Peek()
+	envelope := eq.unsafePayloads.Peek()
+	first := envelope.ExecutionPayload
 
 	if uint64(first.BlockNumber) <= eq.safeHead.Number {
 		eq.log.Info("skipping unsafe payload, since it is older than safe head", "safe", eq.safeHead.ID(), "unsafe", first.ID(), "payload", first.ID())
@@ -490,7 +610,7 @@ func (eq *EngineQueue) tryNextUnsafePayload(ctx context.Context) error {
 	}
 
 	// Ensure that the unsafe payload builds upon the current unsafe head
-	if eq.syncCfg.SyncMode != sync.ELSync && first.ParentHash != eq.unsafeHead.Hash {
+	if first.ParentHash != eq.unsafeHead.Hash && !eq.deferToELSync(first) {
 		if uint64(first.BlockNumber) == eq.unsafeHead.Number+1 {
 			eq.log.Info("skipping unsafe payload, since it does not build onto the existing unsafe chain", "safe", eq.safeHead.ID(), "unsafe", first.ID(), "payload", first.ID())
 			eq.unsafePayloads.Pop()
@@ -505,7 +625,7 @@ func (eq *EngineQueue) tryNextUnsafePayload(ctx context.Context) error {
 		return nil
 	}
 
-	status, err := eq.engine.NewPayload(ctx, first)
+	status, err := eq.engine.NewPayload(ctx, first, envelope.ParentBeaconBlockRoot)
 	if err != nil {
 		return NewTemporaryError(fmt.Errorf("failed to update insert payload: %w", err))
 	}
@@ -521,6 +641,9 @@ func (eq *EngineQueue) tryNextUnsafePayload(ctx context.Context) error {
 		SafeBlockHash:      eq.safeHead.Hash, // this should guarantee we do not reorg past the safe head
 		FinalizedBlockHash: eq.finalized.Hash,
 	}
+	if err := eq.checkForkchoice(&fc, ref); err != nil {
+		return NewResetError(err)
+	}
 	fcRes, err := eq.engine.ForkchoiceUpdate(ctx, &fc, nil)
 	if err != nil {
 		var inputErr eth.InputError
@@ -541,6 +664,7 @@ func (eq *EngineQueue) tryNextUnsafePayload(ctx context.Context) error {
 			first.ID(), first.ParentID(), eth.ForkchoiceUpdateErr(fcRes.PayloadStatus)))
 	}
 
+	wasSyncing := eq.isEngineSyncing()
 	eq.engineSyncTarget = ref
 	eq.metrics.RecordL2Ref("l2_engineSyncTarget", ref)
 	// unsafeHead should be updated only if the payload status is VALID
@@ -549,6 +673,12 @@ func (eq *EngineQueue) tryNextUnsafePayload(ctx context.Context) error {
 		eq.metrics.RecordL2Ref("l2_unsafe", ref)
 	}
 	eq.unsafePayloads.Pop()
+	if syncing := eq.isEngineSyncing(); syncing && !wasSyncing {
+		eq.log.Info("Starting execution-layer sync, deferring derivation until the engine validated the sync target",
+			"target", ref, "unsafe", eq.unsafeHead, "safe", eq.safeHead, "status", fcRes.PayloadStatus.Status)
+	} else if !syncing && wasSyncing {
+		eq.log.Info("Finished execution-layer sync, resuming derivation", "unsafe", eq.unsafeHead, "safe", eq.safeHead)
+	}
 	eq.log.Trace("Executed unsafe payload", "hash", ref.Hash, "number", ref.Number, "timestamp", ref.Time, "l1Origin", ref.L1Origin)
 	eq.logSyncProgress("unsafe payload from sequencer")
 
@@ -597,7 +727,7 @@ func (eq *EngineQueue) consolidateNextSafeAttributes(ctx context.Context) error
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 
-	payload, err := eq.engine.PayloadByNumber(ctx, eq.pendingSafeHead.Number+1)
+	envelope, err := eq.engine.PayloadByNumber(ctx, eq.pendingSafeHead.Number+1)
 	if err != nil {
 		if errors.Is(err, ethereum.NotFound) {
 			// engine may have restarted, or inconsistent safe head. We need to reset
@@ -605,10 +735,16 @@ func (eq *EngineQueue) consolidateNextSafeAttributes(ctx context.Context) error
 		}
 		return NewTemporaryError(fmt.Errorf("failed to get existing unsafe payload to compare against derived attributes from L1: %w", err))
 	}
-	if err := AttributesMatchBlock(eq.safeAttributes.attributes, eq.pendingSafeHead.Hash, payload, eq.log); err != nil {
+	payload := envelope.ExecutionPayload
+	if err := AttributesMatchBlock(eq.safeAttributes.attributes, eq.pendingSafeHead.Hash, envelope, eq.log); err != nil {
 		eq.log.Warn("L2 reorg: existing unsafe block does not match derived attributes from L1", "err", err, "unsafe", eq.unsafeHead, "pending_safe", eq.pendingSafeHead, "safe", eq.safeHead)
+		parent, unsafeHead := eq.pendingSafeHead, eq.unsafeHead
 		// geth cannot wind back a chain without reorging to a new, previously non-canonical, block
-		return eq.forceNextSafeAttributes(ctx)
+		if err := eq.forceNextSafeAttributes(ctx); err != nil {
+			return err
+		}
+		eq.checkUnsafeReorg(parent, unsafeHead, payload)
+		return nil
 	}
 	ref, err := PayloadToBlockRef(payload, &eq.cfg.Genesis)
 	if err != nil {
@@ -629,6 +765,29 @@ func (eq *EngineQueue) consolidateNextSafeAttributes(ctx context.Context) error
 	return nil
 }
 
+// checkUnsafeReorg checks if the safe block that was forced onto the given parent replaced the existing unsafe block,
+// and reports the reorg of the unsafe chain.
+func (eq *EngineQueue) checkUnsafeReorg(parent eth.L2BlockRef, prevUnsafeHead eth.L2BlockRef, replaced *eth.ExecutionPayload) {
+	safe := eq.pendingSafeHead
+	// the new safe block may have been dropped, e.g. if the payload was invalid
+	if safe.ParentHash != parent.Hash || safe.Number != parent.Number+1 || safe.Hash == replaced.BlockHash {
+		return
+	}
+	reorg := UnsafeReorg{
+		Time:     time.Now().UnixMilli(),
+		Replaced: replaced.ID(),
+		Safe:     safe,
+		Depth:    prevUnsafeHead.Number - parent.Number,
+	}
+	eq.log.Warn("Unsafe chain reorged by conflicting safe block derived from L1",
+		"replaced", reorg.Replaced, "safe", safe, "l1_origin", safe.L1Origin, "depth", reorg.Depth, "prev_unsafe", prevUnsafeHead)
+	eq.metrics.RecordUnsafeReorg(reorg.Depth)
+	if len(eq.unsafeReorgs) >= maxUnsafeReorgs {
+		eq.unsafeReorgs = eq.unsafeReorgs[1:]
+	}
+	eq.unsafeReorgs = append(eq.unsafeReorgs, reorg)
+}
+
 // forceNextSafeAttributes inserts the provided attributes, reorging away any conflicting unsafe chain.
 func (eq *EngineQueue) forceNextSafeAttributes(ctx context.Context) error {
 	if eq.safeAttributes == nil {
@@ -687,8 +846,8 @@ func (eq *EngineQueue) StartPayload(ctx context.Context, parent eth.L2BlockRef,
 	if eq.isEngineSyncing() {
 		return BlockInsertTemporaryErr, fmt.Errorf("engine is in progess of p2p sync")
 	}
-	if eq.buildingID != (eth.PayloadID{}) {
-		eq.log.Warn("did not finish previous block building, starting new building now", "prev_onto", eq.buildingOnto, "prev_payload_id", eq.buildingID, "new_onto", parent)
+	if eq.buildingInfo != (eth.PayloadInfo{}) {
+		eq.log.Warn("did not finish previous block building, starting new building now", "prev_onto", eq.buildingOnto, "prev_payload_id", eq.buildingInfo.ID, "new_onto", parent)
 		// TODO: maybe worth it to force-cancel the old payload ID here.
 	}
 	fc := eth.ForkchoiceState{
@@ -696,18 +855,25 @@ func (eq *EngineQueue) StartPayload(ctx context.Context, parent eth.L2BlockRef,
 		SafeBlockHash:      eq.safeHead.Hash,
 		FinalizedBlockHash: eq.finalized.Hash,
 	}
+	if err := eq.checkForkchoice(&fc, parent); err != nil {
+		return BlockInsertPrestateErr, err
+	}
 	id, errTyp, err := StartPayload(ctx, eq.engine, fc, attrs)
 	if err != nil {
 		return errTyp, err
 	}
-	eq.buildingID = id
+	eq.buildingInfo = eth.PayloadInfo{ID: id, ParentBeaconBlockRoot: attrs.ParentBeaconBlockRoot}
 	eq.buildingSafe = updateSafe
 	eq.buildingOnto = parent
 	return BlockInsertOK, nil
 }
 
-func (eq *EngineQueue) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPayload, errTyp BlockInsertionErrType, err error) {
-	if eq.buildingID == (eth.PayloadID{}) {
+func (eq *EngineQueue) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPayloadEnvelope, errTyp BlockInsertionErrType, err error) {
+	return eq.ConfirmPayloadWithin(ctx, 0)
+}
+
+func (eq *EngineQueue) ConfirmPayloadWithin(ctx context.Context, timeout time.Duration) (out *eth.ExecutionPayloadEnvelope, errTyp BlockInsertionErrType, err error) {
+	if eq.buildingInfo == (eth.PayloadInfo{}) {
 		return nil, BlockInsertPrestateErr, fmt.Errorf("cannot complete payload building: not currently building a payload")
 	}
 	if eq.buildingOnto.Hash != eq.unsafeHead.Hash { // E.g. when safe-attributes consolidation fails, it will drop the existing work.
@@ -720,11 +886,11 @@ func (eq *EngineQueue) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPa
 	}
 	// Update the safe head if the payload is built with the last attributes in the batch.
 	updateSafe := eq.buildingSafe && eq.safeAttributes != nil && eq.safeAttributes.isLastInSpan
-	payload, errTyp, err := ConfirmPayload(ctx, eq.log, eq.engine, fc, eq.buildingID, updateSafe)
+	envelope, errTyp, err := ConfirmPayload(ctx, eq.log, eq.engine, fc, eq.buildingInfo, updateSafe, timeout)
 	if err != nil {
-		return nil, errTyp, fmt.Errorf("failed to complete building on top of L2 chain %s, id: %s, error (%d): %w", eq.buildingOnto, eq.buildingID, errTyp, err)
+		return nil, errTyp, fmt.Errorf("failed to complete building on top of L2 chain %s, id: %s, error (%d): %w", eq.buildingOnto, eq.buildingInfo.ID, errTyp, err)
 	}
-	ref, err := PayloadToBlockRef(payload, &eq.cfg.Genesis)
+	ref, err := PayloadToBlockRef(envelope.ExecutionPayload, &eq.cfg.Genesis)
 	if err != nil {
 		return nil, BlockInsertPayloadErr, NewResetError(fmt.Errorf("failed to decode L2 block ref from payload: %w", err))
 	}
@@ -743,18 +909,18 @@ func (eq *EngineQueue) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPa
 		}
 	}
 	eq.resetBuildingState()
-	return payload, BlockInsertOK, nil
+	return envelope, BlockInsertOK, nil
 }
 
 func (eq *EngineQueue) CancelPayload(ctx context.Context, force bool) error {
-	if eq.buildingID == (eth.PayloadID{}) { // only cancel if there is something to cancel.
+	if eq.buildingInfo == (eth.PayloadInfo{}) { // only cancel if there is something to cancel.
 		return nil
 	}
 	// the building job gets wrapped up as soon as the payload is retrieved, there's no explicit cancel in the Engine API
-	eq.log.Error("cancelling old block sealing job", "payload", eq.buildingID)
-	_, err := eq.engine.GetPayload(ctx, eq.buildingID)
+	eq.log.Error("cancelling old block sealing job", "payload", eq.buildingInfo.ID)
+	_, err := eq.engine.GetPayload(ctx, eq.buildingInfo)
 	if err != nil {
-		eq.log.Error("failed to cancel block building job", "payload", eq.buildingID, "err", err)
+		eq.log.Error("failed to cancel block building job", "payload", eq.buildingInfo.ID, "err", err)
 		if !force {
 			return err
 		}
@@ -764,11 +930,11 @@ func (eq *EngineQueue) CancelPayload(ctx context.Context, force bool) error {
 }
 
 func (eq *EngineQueue) BuildingPayload() (onto eth.L2BlockRef, id eth.PayloadID, safe bool) {
-	return eq.buildingOnto, eq.buildingID, eq.buildingSafe
+	return eq.buildingOnto, eq.buildingInfo.ID, eq.buildingSafe
 }
 
 func (eq *EngineQueue) resetBuildingState() {
-	eq.buildingID = eth.PayloadID{}
+	eq.buildingInfo = eth.PayloadInfo{}
 	eq.buildingOnto = eth.L2BlockRef{}
 	eq.buildingSafe = false
 }
@@ -833,6 +999,9 @@ func (eq *EngineQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.System
 	eq.metrics.RecordL2Ref("l2_pending_safe", eq.pendingSafeHead)
 	eq.metrics.RecordL2Ref("l2_unsafe", unsafe)
 	eq.metrics.RecordL2Ref("l2_engineSyncTarget", unsafe)
+	if err := eq.safeHeadNotifs.SafeHeadReset(safe); err != nil {
+		eq.log.Error("Failed to notify safe head reset", "safe", safe, "err", err)
+	}
 	eq.logSyncProgress("reset derivation work")
 	return io.EOF
 }
@@ -840,7 +1009,7 @@ func (eq *EngineQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.System
 // UnsafeL2SyncTarget retrieves the first queued-up L2 unsafe payload, or a zeroed reference if there is none.
 func (eq *EngineQueue) UnsafeL2SyncTarget() eth.L2BlockRef {
 	if first := eq.unsafePayloads.Peek(); first != nil {
-		ref, err := PayloadToBlockRef(first, &eq.cfg.Genesis)
+		ref, err := PayloadToBlockRef(first.ExecutionPayload, &eq.cfg.Genesis)
 		if err != nil {
 			return eth.L2BlockRef{}
 		}
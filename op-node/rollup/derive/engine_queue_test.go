@@ -248,7 +248,7 @@ func TestEngineQueue_Finalize(t *testing.T) {
 
 	prev := &fakeAttributesQueue{}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, NoopSafeHeadListener)
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -275,6 +275,84 @@ func TestEngineQueue_Finalize(t *testing.T) {
 	l1F.AssertExpectations(t)
 	eng.AssertExpectations(t)
 }
+
+// TestEngineQueue_FinalityFeed simulates a feed of L1 finality signals while deriving,
+// including L1 non-finality, regressions, conflicting signals and signals without matching finality data.
+func TestEngineQueue_FinalityFeed(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	rng := rand.New(rand.NewSource(1234))
+
+	// a simulated L1 chain, and the 2 L2 blocks that were derived from each L1 block
+	l1Blocks := []eth.L1BlockRef{{Hash: testutils.RandomHash(rng), Number: 100, Time: 1000}}
+	l2Blocks := []eth.L2BlockRef{{Hash: testutils.RandomHash(rng), Number: 0, Time: 1000, L1Origin: l1Blocks[0].ID()}}
+	for i := 1; i < 2*finalityLookback; i++ {
+		l1Blocks = append(l1Blocks, testutils.NextRandomRef(rng, l1Blocks[i-1]))
+		l2Blocks = append(l2Blocks, testutils.NextRandomL2Ref(rng, 2, l2Blocks[len(l2Blocks)-1], l1Blocks[i].ID()))
+		l2Blocks = append(l2Blocks, testutils.NextRandomL2Ref(rng, 2, l2Blocks[len(l2Blocks)-1], l1Blocks[i].ID()))
+	}
+	// lastDerived returns the last L2 block derived from the given L1 block
+	lastDerived := func(l1Num uint64) eth.L2BlockRef {
+		return l2Blocks[2*(l1Num-l1Blocks[0].Number)]
+	}
+
+	l1F := &testutils.MockL1Source{}
+	eq := NewEngineQueue(logger, &rollup.Config{}, nil, &testutils.TestDerivationMetrics{}, &fakeAttributesQueue{}, l1F, &sync.Config{}, NoopSafeHeadListener)
+	eq.origin = l1Blocks[0]
+	eq.safeHead = l2Blocks[0]
+	eq.finalized = l2Blocks[0]
+	deriveTo := func(l1Num uint64) {
+		for eq.origin.Number < l1Num {
+			eq.origin = l1Blocks[eq.origin.Number-l1Blocks[0].Number+1]
+			eq.safeHead = lastDerived(eq.origin.Number)
+			eq.postProcessSafeL2()
+		}
+	}
+
+	deriveTo(l1Blocks[10].Number)
+	eq.Finalize(l1Blocks[4])
+	require.Equal(t, lastDerived(l1Blocks[4].Number), eq.Finalized(), "finalize the L2 blocks derived from the finalized L1 block")
+	require.Len(t, eq.finalityData, 6, "finalized data is pruned")
+
+	eq.Finalize(l1Blocks[3])
+	require.Equal(t, l1Blocks[4], eq.FinalizedL1(), "finality regression is ignored")
+	conflicting := l1Blocks[4]
+	conflicting.Hash = testutils.RandomHash(rng)
+	eq.Finalize(conflicting)
+	require.Equal(t, l1Blocks[4], eq.FinalizedL1(), "conflicting finality signal is ignored")
+	require.Equal(t, lastDerived(l1Blocks[4].Number), eq.Finalized())
+
+	// L1 does not finalize for a long time: the finality data is pruned to the lookback window
+	deriveTo(l1Blocks[len(l1Blocks)-1].Number)
+	require.Len(t, eq.finalityData, finalityLookback)
+	require.Equal(t, lastDerived(l1Blocks[4].Number), eq.Finalized())
+
+	// L1 finalizes a block that we have no finality data for anymore: nothing can be finalized right away,
+	// and after checking we are on the finalizing chain, the remaining data is not finalized either, since it is newer.
+	oldest := eq.finalityData[0].L1Block.Number
+	eq.Finalize(l1Blocks[oldest-l1Blocks[0].Number-1])
+	require.True(t, eq.finalityCheckPending)
+	l1F.ExpectL1BlockRefByNumber(eq.origin.Number, eq.origin, nil)
+	require.NoError(t, eq.tryFinalizePastL2Blocks(context.Background()))
+	require.False(t, eq.finalityCheckPending)
+	require.Equal(t, lastDerived(l1Blocks[4].Number), eq.Finalized(), "no finality data for the finalized L1 block")
+
+	// L1 finalizes a block within the lookback window again
+	next := l1Blocks[oldest-l1Blocks[0].Number+10]
+	eq.Finalize(next)
+	require.Equal(t, lastDerived(next.Number), eq.Finalized())
+	require.Equal(t, next.Number+1, eq.finalityData[0].L1Block.Number, "finalized data is pruned")
+
+	// L1 finalizes a block that conflicts with the L1 chain we derived from: we need to reset
+	other := testutils.NextRandomRef(rng, next)
+	eq.Finalize(other)
+	require.Equal(t, lastDerived(next.Number), eq.Finalized(), "no finalization of conflicting data")
+	l1F.ExpectL1BlockRefByNumber(eq.origin.Number, testutils.RandomBlockRef(rng), nil)
+	require.ErrorIs(t, eq.tryFinalizePastL2Blocks(context.Background()), ErrReset)
+	require.Equal(t, lastDerived(next.Number), eq.Finalized())
+
+	l1F.AssertExpectations(t)
+}
+
 func TestEngineQueue_ResetWhenUnsafeOriginNotCanonical(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
 
@@ -482,7 +560,7 @@ func TestEngineQueue_ResetWhenUnsafeOriginNotCanonical(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refE}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, NoopSafeHeadListener)
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -813,7 +891,7 @@ func TestVerifyNewL1Origin(t *testing.T) {
 			}, nil)
 
 			prev := &fakeAttributesQueue{origin: refE}
-			eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+			eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, NoopSafeHeadListener)
 			require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 			require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -911,7 +989,7 @@ func TestBlockBuildingRace(t *testing.T) {
 	}
 
 	prev := &fakeAttributesQueue{origin: refA, attrs: attrs, islastInSpan: true}
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, NoopSafeHeadListener)
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	id := eth.PayloadID{0xff}
@@ -938,7 +1016,7 @@ func TestBlockBuildingRace(t *testing.T) {
 	eng.ExpectForkchoiceUpdate(preFc, attrs, preFcRes, nil)
 	// Don't let the payload be confirmed straight away
 	mockErr := fmt.Errorf("mock error")
-	eng.ExpectGetPayload(id, nil, mockErr)
+	eng.ExpectGetPayload(id, &eth.ExecutionPayloadEnvelope{ExecutionPayload: nil}, mockErr)
 	// The job will be not be cancelled, the untyped error is a temporary error
 
 	require.ErrorIs(t, eq.Step(context.Background()), NotEnoughData, "queue up attributes")
@@ -978,8 +1056,8 @@ func TestBlockBuildingRace(t *testing.T) {
 			a1InfoTx,
 		},
 	}
-	eng.ExpectGetPayload(id, payloadA1, nil)
-	eng.ExpectNewPayload(payloadA1, &eth.PayloadStatusV1{
+	eng.ExpectGetPayload(id, &eth.ExecutionPayloadEnvelope{ExecutionPayload: payloadA1}, nil)
+	eng.ExpectNewPayload(payloadA1, nil, &eth.PayloadStatusV1{
 		Status:          eth.ExecutionValid,
 		LatestValidHash: &refA1.Hash,
 		ValidationError: nil,
@@ -1081,7 +1159,7 @@ func TestResetLoop(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refA, attrs: attrs, islastInSpan: true}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{}, NoopSafeHeadListener)
 	eq.unsafeHead = refA2
 	eq.engineSyncTarget = refA2
 	eq.safeHead = refA1
@@ -1179,12 +1257,12 @@ func TestEngineQueue_StepPopOlderUnsafe(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refA}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{}, NoopSafeHeadListener)
 	eq.unsafeHead = refA2
 	eq.safeHead = refA0
 	eq.finalized = refA0
 
-	eq.AddUnsafePayload(payloadA1)
+	eq.AddUnsafePayload(&eth.ExecutionPayloadEnvelope{ExecutionPayload: payloadA1})
 
 	err := eq.Step(context.Background())
 	require.NoError(t, err)
@@ -1195,3 +1273,102 @@ func TestEngineQueue_StepPopOlderUnsafe(t *testing.T) {
 	l1F.AssertExpectations(t)
 	eng.AssertExpectations(t)
 }
+
+func TestEngineQueue_ELSyncDistance(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	eng := &testutils.MockEngine{}
+	l1F := &testutils.MockL1Source{}
+
+	rng := rand.New(rand.NewSource(1234))
+
+	refA := testutils.RandomBlockRef(rng)
+	refA0 := eth.L2BlockRef{
+		Hash:           testutils.RandomHash(rng),
+		Number:         0,
+		ParentHash:     common.Hash{},
+		Time:           refA.Time,
+		L1Origin:       refA.ID(),
+		SequenceNumber: 0,
+	}
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1:     refA.ID(),
+			L2:     refA0.ID(),
+			L2Time: refA0.Time,
+			SystemConfig: eth.SystemConfig{
+				BatcherAddr: common.Address{42},
+				Overhead:    [32]byte{123},
+				Scalar:      [32]byte{42},
+				GasLimit:    20_000_000,
+			},
+		},
+		BlockTime:     1,
+		SeqWindowSize: 2,
+	}
+	// payload creates an unsafe payload that does not build on the local chain, unless the parent is given.
+	payload := func(num uint64, parent common.Hash) *eth.ExecutionPayload {
+		if parent == (common.Hash{}) {
+			parent = testutils.RandomHash(rng)
+		}
+		infoTx, err := L1InfoDepositBytes(num, &testutils.MockBlockInfo{
+			InfoHash:       refA.Hash,
+			InfoParentHash: refA.ParentHash,
+			InfoNum:        refA.Number,
+			InfoTime:       refA.Time,
+			InfoBaseFee:    big.NewInt(7),
+		}, cfg.Genesis.SystemConfig, false)
+		require.NoError(t, err)
+		return &eth.ExecutionPayload{
+			ParentHash:    parent,
+			BlockNumber:   eth.Uint64Quantity(num),
+			GasLimit:      eth.Uint64Quantity(20_000_000),
+			Timestamp:     eth.Uint64Quantity(refA0.Time + num*cfg.BlockTime),
+			BaseFeePerGas: *uint256.NewInt(7),
+			BlockHash:     testutils.RandomHash(rng),
+			Transactions:  []eth.Data{infoTx},
+		}
+	}
+	expectInsert := func(p *eth.ExecutionPayload, status eth.ExecutePayloadStatus) {
+		eng.ExpectNewPayload(p, nil, &eth.PayloadStatusV1{Status: status}, nil)
+		eng.ExpectForkchoiceUpdate(&eth.ForkchoiceState{
+			HeadBlockHash:      p.BlockHash,
+			SafeBlockHash:      refA0.Hash,
+			FinalizedBlockHash: refA0.Hash,
+		}, nil, &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: status}}, nil)
+	}
+
+	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, &fakeAttributesQueue{origin: refA}, l1F,
+		&sync.Config{SyncMode: sync.ELSync, ELSyncDistance: 2}, NoopSafeHeadListener)
+	eq.unsafeHead = refA0
+	eq.engineSyncTarget = refA0
+	eq.safeHead = refA0
+	eq.finalized = refA0
+
+	// A payload within the distance of the safe head requires the parent chain, as in CL sync.
+	near := payload(2, common.Hash{})
+	eq.AddUnsafePayload(&eth.ExecutionPayloadEnvelope{ExecutionPayload: near})
+	require.ErrorIs(t, eq.tryNextUnsafePayload(context.Background()), io.EOF)
+	require.Equal(t, near, eq.unsafePayloads.Peek().ExecutionPayload, "the gap may still be filled")
+	eq.unsafePayloads.Pop()
+
+	// A payload past the distance is deferred to the EL sync, which may not be able to validate it yet.
+	far := payload(3, common.Hash{})
+	expectInsert(far, eth.ExecutionSyncing)
+	eq.AddUnsafePayload(&eth.ExecutionPayloadEnvelope{ExecutionPayload: far})
+	require.NoError(t, eq.tryNextUnsafePayload(context.Background()))
+	require.Equal(t, refA0, eq.UnsafeL2Head(), "unsafe head only changes on a valid payload")
+	require.Equal(t, far.BlockHash, eq.EngineSyncTarget().Hash)
+	require.ErrorIs(t, eq.Step(context.Background()), EngineELSyncing, "derivation waits for the EL sync")
+
+	// While syncing, the next payloads are forwarded, wherever they are, until the engine validated the sync target.
+	next := payload(4, far.BlockHash)
+	expectInsert(next, eth.ExecutionValid)
+	eq.AddUnsafePayload(&eth.ExecutionPayloadEnvelope{ExecutionPayload: next})
+	require.NoError(t, eq.tryNextUnsafePayload(context.Background()))
+	require.Equal(t, next.BlockHash, eq.UnsafeL2Head().Hash)
+	require.Equal(t, next.BlockHash, eq.EngineSyncTarget().Hash)
+	require.False(t, eq.isEngineSyncing(), "EL sync completed")
+
+	l1F.AssertExpectations(t)
+	eng.AssertExpectations(t)
+}
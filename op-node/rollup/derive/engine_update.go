@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -82,6 +83,9 @@ const (
 // StartPayload starts an execution payload building process in the provided Engine, with the given attributes.
 // The severity of the error is distinguished to determine whether the same payload attributes may be re-attempted later.
 func StartPayload(ctx context.Context, eng Engine, fc eth.ForkchoiceState, attrs *eth.PayloadAttributes) (id eth.PayloadID, errType BlockInsertionErrType, err error) {
+	if err := fc.Validate(); err != nil {
+		return eth.PayloadID{}, BlockInsertPrestateErr, fmt.Errorf("invalid forkchoice state %s: %w", &fc, err)
+	}
 	fcRes, err := eng.ForkchoiceUpdate(ctx, &fc, attrs)
 	if err != nil {
 		var inputErr eth.InputError
@@ -114,20 +118,35 @@ func StartPayload(ctx context.Context, eng Engine, fc eth.ForkchoiceState, attrs
 	}
 }
 
+// ErrPayloadTimeout is returned if the engine did not return the payload that is being built within the given time.
+var ErrPayloadTimeout = errors.New("engine did not return the payload in time")
+
 // ConfirmPayload ends an execution payload building process in the provided Engine, and persists the payload as the canonical head.
 // If updateSafe is true, then the payload will also be recognized as safe-head at the same time.
+// If getTimeout is not 0, ErrPayloadTimeout is returned if the engine does not return the payload within it. The timeout does
+// not apply to the insertion of the payload: once the payload is retrieved, it is not abandoned halfway.
 // The severity of the error is distinguished to determine whether the payload was valid and can become canonical.
-func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.ForkchoiceState, id eth.PayloadID, updateSafe bool) (out *eth.ExecutionPayload, errTyp BlockInsertionErrType, err error) {
-	payload, err := eng.GetPayload(ctx, id)
+func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.ForkchoiceState, payloadInfo eth.PayloadInfo, updateSafe bool, getTimeout time.Duration) (out *eth.ExecutionPayloadEnvelope, errTyp BlockInsertionErrType, err error) {
+	getCtx := ctx
+	if getTimeout > 0 {
+		var cancel context.CancelFunc
+		getCtx, cancel = context.WithTimeout(ctx, getTimeout)
+		defer cancel()
+	}
+	envelope, err := eng.GetPayload(getCtx, payloadInfo)
 	if err != nil {
+		if getCtx.Err() != nil && ctx.Err() == nil {
+			return nil, BlockInsertTemporaryErr, fmt.Errorf("%w within %s: %v", ErrPayloadTimeout, getTimeout, err)
+		}
 		// even if it is an input-error (unknown payload ID), it is temporary, since we will re-attempt the full payload building, not just the retrieval of the payload.
 		return nil, BlockInsertTemporaryErr, fmt.Errorf("failed to get execution payload: %w", err)
 	}
+	payload := envelope.ExecutionPayload
 	if err := sanityCheckPayload(payload); err != nil {
 		return nil, BlockInsertPayloadErr, err
 	}
 
-	status, err := eng.NewPayload(ctx, payload)
+	status, err := eng.NewPayload(ctx, payload, envelope.ParentBeaconBlockRoot)
 	if err != nil {
 		return nil, BlockInsertTemporaryErr, fmt.Errorf("failed to insert execution payload: %w", err)
 	}
@@ -142,6 +161,9 @@ func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.Fork
 	if updateSafe {
 		fc.SafeBlockHash = payload.BlockHash
 	}
+	if err := fc.Validate(); err != nil {
+		return nil, BlockInsertPrestateErr, fmt.Errorf("invalid forkchoice state %s: %w", &fc, err)
+	}
 	fcRes, err := eng.ForkchoiceUpdate(ctx, &fc, nil)
 	if err != nil {
 		var inputErr eth.InputError
@@ -164,5 +186,5 @@ func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.Fork
 		"state_root", payload.StateRoot, "timestamp", uint64(payload.Timestamp), "parent", payload.ParentHash,
 		"prev_randao", payload.PrevRandao, "fee_recipient", payload.FeeRecipient,
 		"txs", len(payload.Transactions), "update_safe", updateSafe)
-	return payload, BlockInsertOK, nil
+	return envelope, BlockInsertOK, nil
 }
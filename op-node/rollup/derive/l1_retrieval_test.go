@@ -35,8 +35,8 @@ type MockDataSource struct {
 	mock.Mock
 }
 
-func (m *MockDataSource) OpenData(ctx context.Context, id eth.BlockID, batcherAddr common.Address) DataIter {
-	out := m.Mock.MethodCalled("OpenData", id, batcherAddr)
+func (m *MockDataSource) OpenData(ctx context.Context, ref eth.L1BlockRef, batcherAddr common.Address) DataIter {
+	out := m.Mock.MethodCalled("OpenData", ref.ID(), batcherAddr)
 	return out[0].(DataIter)
 }
 
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -29,15 +30,17 @@ type L1Traversal struct {
 	log      log.Logger
 	sysCfg   eth.SystemConfig
 	cfg      *rollup.Config
+	metrics  Metrics
 }
 
 var _ ResettableStage = (*L1Traversal)(nil)
 
-func NewL1Traversal(log log.Logger, cfg *rollup.Config, l1Blocks L1BlockRefByNumberFetcher) *L1Traversal {
+func NewL1Traversal(log log.Logger, cfg *rollup.Config, l1Blocks L1BlockRefByNumberFetcher, metrics Metrics) *L1Traversal {
 	return &L1Traversal{
 		log:      log,
 		l1Blocks: l1Blocks,
 		cfg:      cfg,
+		metrics:  metrics,
 	}
 }
 
@@ -71,7 +74,9 @@ func (l1t *L1Traversal) AdvanceL1Block(ctx context.Context) error {
 	}
 
 	// Parse L1 receipts of the given block and update the L1 system configuration
+	start := time.Now()
 	_, receipts, err := l1t.l1Blocks.FetchReceipts(ctx, nextL1Origin.Hash)
+	l1t.metrics.RecordDerivationStageTime(StageL1Receipts, time.Since(start))
 	if err != nil {
 		return NewTemporaryError(fmt.Errorf("failed to fetch receipts of L1 block %s (parent: %s) for L1 sysCfg update: %w", nextL1Origin, origin, err))
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -36,7 +37,7 @@ func TestL1TraversalNext(t *testing.T) {
 		Genesis:               rollup.Genesis{SystemConfig: l1Cfg},
 		L1SystemConfigAddress: sysCfgAddr,
 	}
-	tr := NewL1Traversal(testlog.Logger(t, log.LvlError), cfg, nil)
+	tr := NewL1Traversal(testlog.Logger(t, log.LvlError), cfg, nil, metrics.NoopMetrics)
 
 	_ = tr.Reset(context.Background(), a, l1Cfg)
 
@@ -132,7 +133,7 @@ func TestL1TraversalAdvance(t *testing.T) {
 				Genesis:               rollup.Genesis{SystemConfig: test.initialL1Cfg},
 				L1SystemConfigAddress: sysCfgAddr,
 			}
-			tr := NewL1Traversal(testlog.Logger(t, log.LvlError), cfg, src)
+			tr := NewL1Traversal(testlog.Logger(t, log.LvlError), cfg, src, metrics.NoopMetrics)
 			// Load up the initial state with a reset
 			_ = tr.Reset(context.Background(), test.startBlock, test.initialL1Cfg)
 
@@ -3,8 +3,6 @@ package derive
 import (
 	"fmt"
 
-	"github.com/ethereum/go-ethereum/core/types"
-
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
@@ -21,15 +19,9 @@ func PayloadToBlockRef(payload *eth.ExecutionPayload, genesis *rollup.Genesis) (
 		l1Origin = genesis.L1
 		sequenceNumber = 0
 	} else {
-		if len(payload.Transactions) == 0 {
-			return eth.L2BlockRef{}, fmt.Errorf("l2 block is missing L1 info deposit tx, block hash: %s", payload.BlockHash)
-		}
-		var tx types.Transaction
-		if err := tx.UnmarshalBinary(payload.Transactions[0]); err != nil {
-			return eth.L2BlockRef{}, fmt.Errorf("failed to decode first tx to read l1 info from: %w", err)
-		}
-		if tx.Type() != types.DepositTxType {
-			return eth.L2BlockRef{}, fmt.Errorf("first payload tx has unexpected tx type: %d", tx.Type())
+		tx, err := eth.CheckFirstIsL1Info(payload.Transactions)
+		if err != nil {
+			return eth.L2BlockRef{}, fmt.Errorf("invalid L2 block %s: %w", payload.BlockHash, err)
 		}
 		info, err := L1InfoDepositTxData(tx.Data())
 		if err != nil {
@@ -56,15 +48,9 @@ func PayloadToSystemConfig(payload *eth.ExecutionPayload, cfg *rollup.Config) (e
 		}
 		return cfg.Genesis.SystemConfig, nil
 	} else {
-		if len(payload.Transactions) == 0 {
-			return eth.SystemConfig{}, fmt.Errorf("l2 block is missing L1 info deposit tx, block hash: %s", payload.BlockHash)
-		}
-		var tx types.Transaction
-		if err := tx.UnmarshalBinary(payload.Transactions[0]); err != nil {
-			return eth.SystemConfig{}, fmt.Errorf("failed to decode first tx to read l1 info from: %w", err)
-		}
-		if tx.Type() != types.DepositTxType {
-			return eth.SystemConfig{}, fmt.Errorf("first payload tx has unexpected tx type: %d", tx.Type())
+		tx, err := eth.CheckFirstIsL1Info(payload.Transactions)
+		if err != nil {
+			return eth.SystemConfig{}, fmt.Errorf("invalid L2 block %s: %w", payload.BlockHash, err)
 		}
 		info, err := L1InfoDepositTxData(tx.Data())
 		if err != nil {
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 
@@ -22,8 +24,18 @@ type Metrics interface {
 	RecordChannelTimedOut()
 	RecordFrame()
 	RecordDerivedBatches(batchType string)
+	RecordUnsafeReorg(depth uint64)
+	RecordDerivationStageTime(stage string, duration time.Duration)
 }
 
+// Derivation stages timed with Metrics.RecordDerivationStageTime
+const (
+	StageL1Receipts       = "l1_receipts"        // fetching the receipts of an L1 block, per block
+	StageChannelBankFrame = "channel_bank_frame" // ingesting a frame into the channel bank, per frame
+	StageBatchQueueBatch  = "batch_queue_batch"  // validating a batch in the batch queue, per batch
+	StageAttributesBuild  = "attributes_build"   // preparing the payload attributes of a block, per block
+)
+
 type L1Fetcher interface {
 	L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error)
 	L1BlockRefByNumberFetcher
@@ -54,16 +66,35 @@ type EngineQueueStage interface {
 	SafeL2Head() eth.L2BlockRef
 	PendingSafeL2Head() eth.L2BlockRef
 	EngineSyncTarget() eth.L2BlockRef
+	UnsafePayloads() UnsafePayloadsSummary
+	RecentUnsafeReorgs() []UnsafeReorg
 	Origin() eth.L1BlockRef
 	SystemConfig() eth.SystemConfig
 	SetUnsafeHead(head eth.L2BlockRef)
 
 	Finalize(l1Origin eth.L1BlockRef)
-	AddUnsafePayload(payload *eth.ExecutionPayload)
+	AddUnsafePayload(envelope *eth.ExecutionPayloadEnvelope)
 	UnsafeL2SyncTarget() eth.L2BlockRef
 	Step(context.Context) error
 }
 
+// StageProgress is the L1 block that a stage of the derivation pipeline is currently processing.
+type StageProgress struct {
+	Stage  string         `json:"stage"`
+	Origin eth.L1BlockRef `json:"origin"`
+}
+
+// PipelineProgress summarizes the progress of the derivation pipeline, for debugging purposes.
+type PipelineProgress struct {
+	// Stages lists the progress of the stages, from the engine queue down to the L1 traversal.
+	Stages []StageProgress `json:"stages"`
+	// Resetting is the stage that is being reset, empty if the pipeline is not resetting.
+	Resetting      string                `json:"resetting,omitempty"`
+	UnsafePayloads UnsafePayloadsSummary `json:"unsafePayloads"`
+	// UnsafeReorgs lists the latest reorgs of the unsafe chain by conflicting safe blocks, oldest first.
+	UnsafeReorgs []UnsafeReorg `json:"unsafeReorgs,omitempty"`
+}
+
 // DerivationPipeline is updated with new L1 data, and the Step() function can be iterated on to keep the L2 Engine in sync.
 type DerivationPipeline struct {
 	log       log.Logger
@@ -83,21 +114,21 @@ type DerivationPipeline struct {
 }
 
 // NewDerivationPipeline creates a derivation pipeline, which should be reset before use.
-func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, engine Engine, metrics Metrics, syncCfg *sync.Config) *DerivationPipeline {
+func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, l1Blobs L1BlobsFetcher, engine Engine, metrics Metrics, syncCfg *sync.Config, safeHeadListener SafeHeadListener) *DerivationPipeline {
 
 	// Pull stages
-	l1Traversal := NewL1Traversal(log, cfg, l1Fetcher)
-	dataSrc := NewDataSourceFactory(log, cfg, l1Fetcher) // auxiliary stage for L1Retrieval
+	l1Traversal := NewL1Traversal(log, cfg, l1Fetcher, metrics)
+	dataSrc := NewDataSourceFactory(log, cfg, l1Fetcher, l1Blobs) // auxiliary stage for L1Retrieval
 	l1Src := NewL1Retrieval(log, dataSrc, l1Traversal)
 	frameQueue := NewFrameQueue(log, l1Src)
 	bank := NewChannelBank(log, cfg, frameQueue, l1Fetcher, metrics)
 	chInReader := NewChannelInReader(cfg, log, bank, metrics)
-	batchQueue := NewBatchQueue(log, cfg, chInReader, engine)
+	batchQueue := NewBatchQueue(log, cfg, chInReader, engine, metrics)
 	attrBuilder := NewFetchingAttributesBuilder(cfg, l1Fetcher, engine)
-	attributesQueue := NewAttributesQueue(log, cfg, attrBuilder, batchQueue)
+	attributesQueue := NewAttributesQueue(log, cfg, attrBuilder, batchQueue, metrics)
 
 	// Step stages
-	eng := NewEngineQueue(log, cfg, engine, metrics, attributesQueue, l1Fetcher, syncCfg)
+	eng := NewEngineQueue(log, cfg, engine, metrics, attributesQueue, l1Fetcher, syncCfg, safeHeadListener)
 
 	// Reset from engine queue then up from L1 Traversal. The stages do not talk to each other during
 	// the reset, but after the engine queue, this is the order in which the stages could talk to each other.
@@ -132,6 +163,21 @@ func (dp *DerivationPipeline) Origin() eth.L1BlockRef {
 	return dp.eng.Origin()
 }
 
+// Progress returns the progress of each of the pipeline stages.
+func (dp *DerivationPipeline) Progress() PipelineProgress {
+	out := PipelineProgress{UnsafePayloads: dp.eng.UnsafePayloads(), UnsafeReorgs: dp.eng.RecentUnsafeReorgs()}
+	for i, stage := range dp.stages {
+		name := strings.TrimPrefix(fmt.Sprintf("%T", stage), "*derive.")
+		if i == dp.resetting {
+			out.Resetting = name
+		}
+		if o, ok := stage.(interface{ Origin() eth.L1BlockRef }); ok {
+			out.Stages = append(out.Stages, StageProgress{Stage: name, Origin: o.Origin()})
+		}
+	}
+	return out
+}
+
 func (dp *DerivationPipeline) Finalize(l1Origin eth.L1BlockRef) {
 	dp.eng.Finalize(l1Origin)
 }
@@ -167,10 +213,14 @@ func (dp *DerivationPipeline) StartPayload(ctx context.Context, parent eth.L2Blo
 	return dp.eng.StartPayload(ctx, parent, attrs, updateSafe)
 }
 
-func (dp *DerivationPipeline) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPayload, errTyp BlockInsertionErrType, err error) {
+func (dp *DerivationPipeline) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPayloadEnvelope, errTyp BlockInsertionErrType, err error) {
 	return dp.eng.ConfirmPayload(ctx)
 }
 
+func (dp *DerivationPipeline) ConfirmPayloadWithin(ctx context.Context, timeout time.Duration) (out *eth.ExecutionPayloadEnvelope, errTyp BlockInsertionErrType, err error) {
+	return dp.eng.ConfirmPayloadWithin(ctx, timeout)
+}
+
 func (dp *DerivationPipeline) CancelPayload(ctx context.Context, force bool) error {
 	return dp.eng.CancelPayload(ctx, force)
 }
@@ -180,8 +230,8 @@ func (dp *DerivationPipeline) BuildingPayload() (onto eth.L2BlockRef, id eth.Pay
 }
 
 // AddUnsafePayload schedules an execution payload to be processed, ahead of deriving it from L1
-func (dp *DerivationPipeline) AddUnsafePayload(payload *eth.ExecutionPayload) {
-	dp.eng.AddUnsafePayload(payload)
+func (dp *DerivationPipeline) AddUnsafePayload(envelope *eth.ExecutionPayloadEnvelope) {
+	dp.eng.AddUnsafePayload(envelope)
 }
 
 // UnsafeL2SyncTarget retrieves the first queued-up L2 unsafe payload, or a zeroed reference if there is none.
@@ -0,0 +1,87 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrNotAdmitted is returned when the sequencer is not admitted to build the next block.
+var ErrNotAdmitted = errors.New("sequencer not admitted to build block")
+
+// SequencerAdmission decides if the sequencer may build the next block,
+// e.g. to elect a leader amongst the replicas of a high-availability sequencer.
+type SequencerAdmission interface {
+	// CheckLeader returns true if the sequencer may build a block on top of the given parent block.
+	// If an error is returned, the sequencer is not admitted.
+	CheckLeader(ctx context.Context, parent eth.L2BlockRef) (bool, error)
+}
+
+type alwaysAdmit struct{}
+
+func (alwaysAdmit) CheckLeader(ctx context.Context, parent eth.L2BlockRef) (bool, error) {
+	return true, nil
+}
+
+// AlwaysAdmit admits the sequencer to build every block.
+var AlwaysAdmit SequencerAdmission = alwaysAdmit{}
+
+// DefaultAdmissionTimeout is the default timeout of an HTTP sequencer admission check.
+const DefaultAdmissionTimeout = 500 * time.Millisecond
+
+// AdmissionRequest is the request body of an HTTP sequencer admission check.
+type AdmissionRequest struct {
+	Parent eth.L2BlockRef `json:"parent"`
+}
+
+// AdmissionResponse is the response body of an HTTP sequencer admission check.
+type AdmissionResponse struct {
+	Admitted bool `json:"admitted"`
+}
+
+// HTTPSequencerAdmission checks the admission of the sequencer with an external HTTP endpoint.
+// It fails closed: the sequencer is not admitted if the endpoint is unreachable, slow, or responds with an error.
+type HTTPSequencerAdmission struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewHTTPSequencerAdmission creates an admission check that POSTs an AdmissionRequest to the endpoint,
+// and expects an AdmissionResponse with status code 200 within the given timeout.
+func NewHTTPSequencerAdmission(endpoint string, timeout time.Duration) *HTTPSequencerAdmission {
+	return &HTTPSequencerAdmission{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (h *HTTPSequencerAdmission) CheckLeader(ctx context.Context, parent eth.L2BlockRef) (bool, error) {
+	body, err := json.Marshal(&AdmissionRequest{Parent: parent})
+	if err != nil {
+		return false, fmt.Errorf("failed to encode admission request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create admission request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to request admission: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("admission endpoint returned status code %d", resp.StatusCode)
+	}
+	var out AdmissionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return false, fmt.Errorf("failed to decode admission response: %w", err)
+	}
+	return out.Admitted, nil
+}
@@ -0,0 +1,77 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestHTTPSequencerAdmission(t *testing.T) {
+	parent := eth.L2BlockRef{Hash: common.Hash{0xaa}, Number: 100, Time: 1234}
+	serve := func(t *testing.T, handler http.HandlerFunc) *HTTPSequencerAdmission {
+		srv := httptest.NewServer(handler)
+		t.Cleanup(srv.Close)
+		return NewHTTPSequencerAdmission(srv.URL, 100*time.Millisecond)
+	}
+	respond := func(admitted bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var req AdmissionRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, parent, req.Parent)
+			require.NoError(t, json.NewEncoder(w).Encode(&AdmissionResponse{Admitted: admitted}))
+		}
+	}
+
+	t.Run("admitted", func(t *testing.T) {
+		admitted, err := serve(t, respond(true)).CheckLeader(context.Background(), parent)
+		require.NoError(t, err)
+		require.True(t, admitted)
+	})
+	t.Run("denied", func(t *testing.T) {
+		admitted, err := serve(t, respond(false)).CheckLeader(context.Background(), parent)
+		require.NoError(t, err)
+		require.False(t, admitted)
+	})
+	t.Run("error status", func(t *testing.T) {
+		admitted, err := serve(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}).CheckLeader(context.Background(), parent)
+		require.ErrorContains(t, err, "status code 500")
+		require.False(t, admitted)
+	})
+	t.Run("invalid response", func(t *testing.T) {
+		admitted, err := serve(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("yes"))
+		}).CheckLeader(context.Background(), parent)
+		require.ErrorContains(t, err, "failed to decode")
+		require.False(t, admitted)
+	})
+	t.Run("timeout", func(t *testing.T) {
+		done := make(chan struct{})
+		defer close(done)
+		admitted, err := serve(t, func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-done:
+			case <-r.Context().Done():
+			}
+		}).CheckLeader(context.Background(), parent)
+		require.Error(t, err)
+		require.False(t, admitted)
+	})
+	t.Run("unreachable", func(t *testing.T) {
+		srv := httptest.NewServer(respond(true))
+		srv.Close()
+		admitted, err := NewHTTPSequencerAdmission(srv.URL, 100*time.Millisecond).CheckLeader(context.Background(), parent)
+		require.Error(t, err)
+		require.False(t, admitted)
+	})
+}
@@ -1,5 +1,7 @@
 package driver
 
+import "time"
+
 type Config struct {
 	// VerifierConfDepth is the distance to keep from the L1 head when reading L1 data for L2 derivation.
 	VerifierConfDepth uint64 `json:"verifier_conf_depth"`
@@ -20,4 +22,25 @@ type Config struct {
 	// SequencerMaxSafeLag is the maximum number of L2 blocks for restricting the distance between L2 safe and unsafe.
 	// Disabled if 0.
 	SequencerMaxSafeLag uint64 `json:"sequencer_max_safe_lag"`
+
+	// SequencerAdmissionEndpoint is the HTTP endpoint that admits the sequencer to build each block,
+	// e.g. for leader election amongst sequencer replicas. Every block is admitted if empty.
+	SequencerAdmissionEndpoint string `json:"sequencer_admission_endpoint"`
+
+	// SequencerAdmissionTimeout is the timeout of the admission check, after which the block is not admitted.
+	// DefaultAdmissionTimeout is used if 0.
+	SequencerAdmissionTimeout time.Duration `json:"sequencer_admission_timeout"`
+
+	// SequencerBuildBudget is the maximum time to wait for the engine to return a block, after which the sequencer
+	// takes the best block the engine built so far, or falls back to a deposit-only block, to not skip the slot.
+	// DefaultBuildBudget is used if 0.
+	SequencerBuildBudget time.Duration `json:"sequencer_build_budget"`
+
+	// DerivationMaxBackoff is the maximum delay between re-attempts of a failed derivation step.
+	// DefaultDerivationMaxBackoff is used if 0.
+	DerivationMaxBackoff time.Duration `json:"derivation_max_backoff"`
+
+	// DerivationMaxStepAttempts is the number of consecutive failed derivation steps,
+	// after which the pipeline is reset, rather than retried in place. Disabled if 0.
+	DerivationMaxStepAttempts uint64 `json:"derivation_max_step_attempts"`
 }
@@ -34,9 +34,14 @@ type Metrics interface {
 	SetDerivationIdle(idle bool)
 
 	RecordL1ReorgDepth(d uint64)
+	RecordUnsafeReorg(depth uint64)
+	RecordDerivationStep(outcome string)
+
+	RecordDerivationStageTime(stage string, duration time.Duration)
 
 	EngineMetrics
 	L1FetcherMetrics
+	L2EngineMetrics
 	SequencerMetrics
 }
 
@@ -55,7 +60,7 @@ type L2Chain interface {
 type DerivationPipeline interface {
 	Reset()
 	Step(ctx context.Context) error
-	AddUnsafePayload(payload *eth.ExecutionPayload)
+	AddUnsafePayload(payload *eth.ExecutionPayloadEnvelope)
 	UnsafeL2SyncTarget() eth.L2BlockRef
 	Finalize(ref eth.L1BlockRef)
 	FinalizedL1() eth.L1BlockRef
@@ -66,6 +71,7 @@ type DerivationPipeline interface {
 	Origin() eth.L1BlockRef
 	EngineReady() bool
 	EngineSyncTarget() eth.L2BlockRef
+	Progress() derive.PipelineProgress
 }
 
 type L1StateIface interface {
@@ -80,16 +86,17 @@ type L1StateIface interface {
 
 type SequencerIface interface {
 	StartBuildingBlock(ctx context.Context) error
-	CompleteBuildingBlock(ctx context.Context) (*eth.ExecutionPayload, error)
+	CompleteBuildingBlock(ctx context.Context) (*eth.ExecutionPayloadEnvelope, error)
 	PlanNextSequencerAction() time.Duration
-	RunNextSequencerAction(ctx context.Context) (*eth.ExecutionPayload, error)
+	RunNextSequencerAction(ctx context.Context) (*eth.ExecutionPayloadEnvelope, error)
 	BuildingOnto() eth.L2BlockRef
 	CancelBuildingBlock(ctx context.Context)
+	FinishBuildingBlock(ctx context.Context) (*eth.ExecutionPayloadEnvelope, error)
 }
 
 type Network interface {
 	// PublishL2Payload is called by the driver whenever there is a new payload to publish, synchronously with the driver main loop.
-	PublishL2Payload(ctx context.Context, payload *eth.ExecutionPayload) error
+	PublishL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 }
 
 type AltSync interface {
@@ -117,42 +124,58 @@ type SequencerStateListener interface {
 }
 
 // NewDriver composes an events handler that tracks L1 state, triggers L2 derivation, and optionally sequences new L2 blocks.
-func NewDriver(driverCfg *Config, cfg *rollup.Config, l2 L2Chain, l1 L1Chain, altSync AltSync, network Network, log log.Logger, snapshotLog log.Logger, metrics Metrics, sequencerStateListener SequencerStateListener, syncCfg *sync.Config) *Driver {
+func NewDriver(driverCfg *Config, cfg *rollup.Config, l2 L2Chain, l1 L1Chain, l1Blobs derive.L1BlobsFetcher, altSync AltSync, network Network, log log.Logger, snapshotLog log.Logger, metrics Metrics, sequencerStateListener SequencerStateListener, safeHeadListener derive.SafeHeadListener, syncCfg *sync.Config) *Driver {
 	l1 = NewMeteredL1Fetcher(l1, metrics)
+	l2 = NewMeteredL2Chain(l2, metrics)
 	l1State := NewL1State(log, metrics)
 	sequencerConfDepth := NewConfDepth(driverCfg.SequencerConfDepth, l1State.L1Head, l1)
 	findL1Origin := NewL1OriginSelector(log, cfg, sequencerConfDepth)
 	verifConfDepth := NewConfDepth(driverCfg.VerifierConfDepth, l1State.L1Head, l1)
-	derivationPipeline := derive.NewDerivationPipeline(log, cfg, verifConfDepth, l2, metrics, syncCfg)
+	derivationPipeline := derive.NewDerivationPipeline(log, cfg, verifConfDepth, l1Blobs, l2, metrics, syncCfg, safeHeadListener)
 	attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, l2)
 	engine := derivationPipeline
 	meteredEngine := NewMeteredEngine(cfg, engine, metrics, log)
-	sequencer := NewSequencer(log, cfg, meteredEngine, attrBuilder, findL1Origin, metrics)
+	admission := AlwaysAdmit
+	if driverCfg.SequencerAdmissionEndpoint != "" {
+		timeout := driverCfg.SequencerAdmissionTimeout
+		if timeout == 0 {
+			timeout = DefaultAdmissionTimeout
+		}
+		admission = NewHTTPSequencerAdmission(driverCfg.SequencerAdmissionEndpoint, timeout)
+	}
+	buildBudget := driverCfg.SequencerBuildBudget
+	if buildBudget == 0 {
+		buildBudget = DefaultBuildBudget(cfg)
+	}
+	sequencer := NewSequencer(log, cfg, meteredEngine, attrBuilder, findL1Origin, admission, buildBudget, metrics)
 	driverCtx, driverCancel := context.WithCancel(context.Background())
 	return &Driver{
-		l1State:          l1State,
-		derivation:       derivationPipeline,
-		stateReq:         make(chan chan struct{}),
-		forceReset:       make(chan chan struct{}, 10),
-		startSequencer:   make(chan hashAndErrorChannel, 10),
-		stopSequencer:    make(chan chan hashAndError, 10),
-		sequencerActive:  make(chan chan bool, 10),
-		sequencerNotifs:  sequencerStateListener,
-		config:           cfg,
-		driverConfig:     driverCfg,
-		driverCtx:        driverCtx,
-		driverCancel:     driverCancel,
-		log:              log,
-		snapshotLog:      snapshotLog,
-		l1:               l1,
-		l2:               l2,
-		sequencer:        sequencer,
-		network:          network,
-		metrics:          metrics,
-		l1HeadSig:        make(chan eth.L1BlockRef, 10),
-		l1SafeSig:        make(chan eth.L1BlockRef, 10),
-		l1FinalizedSig:   make(chan eth.L1BlockRef, 10),
-		unsafeL2Payloads: make(chan *eth.ExecutionPayload, 10),
-		altSync:          altSync,
+		l1State:            l1State,
+		derivation:         derivationPipeline,
+		stateReq:           make(chan chan struct{}),
+		stateSnapshotReq:   make(chan chan *StateSnapshot, 10),
+		forceReset:         make(chan chan struct{}, 10),
+		startSequencer:     make(chan hashAndErrorChannel, 10),
+		stopSequencer:      make(chan chan hashAndError, 10),
+		sequencerActive:    make(chan chan bool, 10),
+		sequencerStatusReq: make(chan chan *SequencerStatus, 10),
+		sequencerNotifs:    sequencerStateListener,
+		config:             cfg,
+		driverConfig:       driverCfg,
+		driverCtx:          driverCtx,
+		driverCancel:       driverCancel,
+		closing:            make(chan struct{}),
+		log:                log,
+		snapshotLog:        snapshotLog,
+		l1:                 l1,
+		l2:                 l2,
+		sequencer:          sequencer,
+		network:            network,
+		metrics:            metrics,
+		l1HeadSig:          make(chan eth.L1BlockRef, 10),
+		l1SafeSig:          make(chan eth.L1BlockRef, 10),
+		l1FinalizedSig:     make(chan eth.L1BlockRef, 10),
+		unsafeL2Payloads:   make(chan *eth.ExecutionPayloadEnvelope, 10),
+		altSync:            altSync,
 	}
 }
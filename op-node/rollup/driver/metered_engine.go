@@ -63,14 +63,19 @@ func (m *MeteredEngine) StartPayload(ctx context.Context, parent eth.L2BlockRef,
 	return errType, err
 }
 
-func (m *MeteredEngine) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPayload, errTyp derive.BlockInsertionErrType, err error) {
+func (m *MeteredEngine) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPayloadEnvelope, errTyp derive.BlockInsertionErrType, err error) {
+	return m.ConfirmPayloadWithin(ctx, 0)
+}
+
+func (m *MeteredEngine) ConfirmPayloadWithin(ctx context.Context, timeout time.Duration) (out *eth.ExecutionPayloadEnvelope, errTyp derive.BlockInsertionErrType, err error) {
 	sealingStart := time.Now()
 	// Actually execute the block and add it to the head of the chain.
-	payload, errType, err := m.inner.ConfirmPayload(ctx)
+	envelope, errType, err := m.inner.ConfirmPayloadWithin(ctx, timeout)
 	if err != nil {
 		m.metrics.RecordSequencingError()
-		return payload, errType, err
+		return envelope, errType, err
 	}
+	payload := envelope.ExecutionPayload
 	now := time.Now()
 	sealTime := now.Sub(sealingStart)
 	buildTime := now.Sub(m.buildingStartTime)
@@ -83,7 +88,7 @@ func (m *MeteredEngine) ConfirmPayload(ctx context.Context) (out *eth.ExecutionP
 	m.log.Debug("Processed new L2 block", "l2_unsafe", ref, "l1_origin", ref.L1Origin,
 		"txs", len(payload.Transactions), "time", ref.Time, "seal_time", sealTime, "build_time", buildTime)
 
-	return payload, errType, err
+	return envelope, errType, err
 }
 
 func (m *MeteredEngine) CancelPayload(ctx context.Context, force bool) error {
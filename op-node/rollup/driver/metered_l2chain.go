@@ -0,0 +1,55 @@
+package driver
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type L2EngineMetrics interface {
+	RecordL2EngineRequestTime(method string, duration time.Duration)
+}
+
+// MeteredL2Chain times the engine API requests of the wrapped L2 chain.
+// All other requests are passed through as-is.
+type MeteredL2Chain struct {
+	L2Chain
+	metrics L2EngineMetrics
+	now     func() time.Time
+}
+
+func NewMeteredL2Chain(inner L2Chain, metrics L2EngineMetrics) *MeteredL2Chain {
+	return &MeteredL2Chain{
+		L2Chain: inner,
+		metrics: metrics,
+		now:     time.Now,
+	}
+}
+
+func (m *MeteredL2Chain) ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	defer m.recordTime("engine_forkchoiceUpdated")()
+	return m.L2Chain.ForkchoiceUpdate(ctx, state, attr)
+}
+
+func (m *MeteredL2Chain) NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error) {
+	defer m.recordTime("engine_newPayload")()
+	return m.L2Chain.NewPayload(ctx, payload, parentBeaconBlockRoot)
+}
+
+func (m *MeteredL2Chain) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error) {
+	defer m.recordTime("engine_getPayload")()
+	return m.L2Chain.GetPayload(ctx, payloadInfo)
+}
+
+var _ L2Chain = (*MeteredL2Chain)(nil)
+
+func (m *MeteredL2Chain) recordTime(method string) func() {
+	start := m.now()
+	return func() {
+		end := m.now()
+		m.metrics.RecordL2EngineRequestTime(method, end.Sub(start))
+	}
+}
@@ -0,0 +1,88 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngineDurationRecorded(t *testing.T) {
+	payload := &eth.ExecutionPayload{BlockHash: common.Hash{0xaa}}
+	envelope := &eth.ExecutionPayloadEnvelope{ExecutionPayload: payload}
+	payloadID := eth.PayloadID{0xbb}
+	expectedErr := errors.New("test error")
+
+	tests := []struct {
+		method string
+		call   func(t *testing.T, chain *MeteredL2Chain, inner *testutils.MockEngine)
+	}{
+		{
+			method: "engine_forkchoiceUpdated",
+			call: func(t *testing.T, chain *MeteredL2Chain, inner *testutils.MockEngine) {
+				state := &eth.ForkchoiceState{HeadBlockHash: payload.BlockHash}
+				res := &eth.ForkchoiceUpdatedResult{PayloadID: &payloadID}
+				inner.ExpectForkchoiceUpdate(state, nil, res, expectedErr)
+
+				result, err := chain.ForkchoiceUpdate(context.Background(), state, nil)
+				require.Equal(t, res, result)
+				require.Equal(t, expectedErr, err)
+			},
+		},
+		{
+			method: "engine_newPayload",
+			call: func(t *testing.T, chain *MeteredL2Chain, inner *testutils.MockEngine) {
+				status := &eth.PayloadStatusV1{Status: eth.ExecutionValid}
+				inner.ExpectNewPayload(payload, nil, status, expectedErr)
+
+				result, err := chain.NewPayload(context.Background(), payload, nil)
+				require.Equal(t, status, result)
+				require.Equal(t, expectedErr, err)
+			},
+		},
+		{
+			method: "engine_getPayload",
+			call: func(t *testing.T, chain *MeteredL2Chain, inner *testutils.MockEngine) {
+				inner.ExpectGetPayload(payloadID, envelope, expectedErr)
+
+				result, err := chain.GetPayload(context.Background(), eth.PayloadInfo{ID: payloadID})
+				require.Equal(t, envelope, result)
+				require.Equal(t, expectedErr, err)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.method, func(t *testing.T) {
+			duration := 200 * time.Millisecond
+			inner := &testutils.MockEngine{}
+			metrics := &mockL2EngineMetrics{}
+			defer inner.AssertExpectations(t)
+			defer metrics.AssertExpectations(t)
+
+			chain := NewMeteredL2Chain(inner, metrics)
+			currTime := time.UnixMilli(1294812934000000)
+			chain.now = func() time.Time {
+				currTime = currTime.Add(duration)
+				return currTime
+			}
+			metrics.On("RecordL2EngineRequestTime", test.method, duration).Once()
+
+			test.call(t, chain, inner)
+		})
+	}
+}
+
+type mockL2EngineMetrics struct {
+	mock.Mock
+}
+
+func (m *mockL2EngineMetrics) RecordL2EngineRequestTime(method string, duration time.Duration) {
+	m.MethodCalled("RecordL2EngineRequestTime", method, duration)
+}
@@ -27,6 +27,24 @@ type L1OriginSelectorIface interface {
 type SequencerMetrics interface {
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
+	RecordSequencerDrift(drift time.Duration)
+	RecordSequencerDriftExceededBlock()
+	RecordSequencerBuildPath(path string)
+}
+
+// Paths that blocks sealed by the sequencer are built with, as recorded in the metrics.
+const (
+	// BuildPathInBudget is a block retrieved from the engine within the build time budget.
+	BuildPathInBudget = "in_budget"
+	// BuildPathBestSoFar is the best block that the engine built so far, retrieved after exceeding the budget.
+	BuildPathBestSoFar = "best_so_far"
+	// BuildPathDepositOnly is a deposit-only block, built after the engine did not return any block within the budget.
+	BuildPathDepositOnly = "deposit_only_fallback"
+)
+
+// DefaultBuildBudget returns the default build time budget of a block: half the block time.
+func DefaultBuildBudget(cfg *rollup.Config) time.Duration {
+	return time.Duration(cfg.BlockTime) * time.Second / 2
 }
 
 // Sequencer implements the sequencing interface of the driver: it starts and completes block building jobs.
@@ -39,15 +57,32 @@ type Sequencer struct {
 	attrBuilder      derive.AttributesBuilder
 	l1OriginSelector L1OriginSelectorIface
 
+	// admission is checked before starting to build each block
+	admission SequencerAdmission
+
+	// buildBudget is the maximum time to wait for the engine to return a block, before asking it for the
+	// best block built so far, and then falling back to a deposit-only block. Disabled if 0.
+	buildBudget time.Duration
+
 	metrics SequencerMetrics
 
 	// timeNow enables sequencer testing to mock the time
 	timeNow func() time.Time
 
 	nextAction time.Time
+
+	// deniedOnto is the L2 head that the latest block was not admitted to be built on top of.
+	deniedOnto eth.L2BlockRef
+
+	// pastDrift is true if the latest started block exceeded the max sequencer drift, the block is then deposit-only.
+	pastDrift bool
+	// buildingPastDrift is true if the block that is being built exceeds the max sequencer drift.
+	buildingPastDrift bool
+	// buildingAttrs are the attributes of the block that is being built, to fall back to a deposit-only block.
+	buildingAttrs *eth.PayloadAttributes
 }
 
-func NewSequencer(log log.Logger, cfg *rollup.Config, engine derive.ResettableEngineControl, attributesBuilder derive.AttributesBuilder, l1OriginSelector L1OriginSelectorIface, metrics SequencerMetrics) *Sequencer {
+func NewSequencer(log log.Logger, cfg *rollup.Config, engine derive.ResettableEngineControl, attributesBuilder derive.AttributesBuilder, l1OriginSelector L1OriginSelectorIface, admission SequencerAdmission, buildBudget time.Duration, metrics SequencerMetrics) *Sequencer {
 	return &Sequencer{
 		log:              log,
 		config:           cfg,
@@ -55,14 +90,23 @@ func NewSequencer(log log.Logger, cfg *rollup.Config, engine derive.ResettableEn
 		timeNow:          time.Now,
 		attrBuilder:      attributesBuilder,
 		l1OriginSelector: l1OriginSelector,
+		admission:        admission,
+		buildBudget:      buildBudget,
 		metrics:          metrics,
 	}
 }
 
 // StartBuildingBlock initiates a block building job on top of the given L2 head, safe and finalized blocks, and using the provided l1Origin.
+// ErrNotAdmitted is returned, before any changes are made, if the sequencer is not admitted to build the block.
 func (d *Sequencer) StartBuildingBlock(ctx context.Context) error {
 	l2Head := d.engine.UnsafeL2Head()
 
+	if admitted, err := d.admission.CheckLeader(ctx, l2Head); err != nil {
+		return fmt.Errorf("%w: admission check failed: %v", ErrNotAdmitted, err)
+	} else if !admitted {
+		return ErrNotAdmitted
+	}
+
 	// Figure out which L1 origin block we're going to be building on top of.
 	l1Origin, err := d.l1OriginSelector.FindL1Origin(ctx, l2Head)
 	if err != nil {
@@ -91,6 +135,19 @@ func (d *Sequencer) StartBuildingBlock(ctx context.Context) error {
 	// from the transaction pool.
 	attrs.NoTxPool = uint64(attrs.Timestamp) > l1Origin.Time+d.config.MaxSequencerDrift
 
+	drift := time.Duration(uint64(attrs.Timestamp)-l1Origin.Time) * time.Second
+	d.metrics.RecordSequencerDrift(drift)
+	if attrs.NoTxPool != d.pastDrift {
+		if attrs.NoTxPool {
+			d.log.Warn("Sequencer drift exceeded, producing deposit-only blocks until the L1 origin catches up",
+				"num", l2Head.Number+1, "time", uint64(attrs.Timestamp), "origin", l1Origin, "drift", drift, "max_drift", d.config.MaxSequencerDrift)
+		} else {
+			d.log.Info("Sequencer drift recovered, including transactions again",
+				"num", l2Head.Number+1, "time", uint64(attrs.Timestamp), "origin", l1Origin, "drift", drift)
+		}
+		d.pastDrift = attrs.NoTxPool
+	}
+
 	d.log.Debug("prepared attributes for new block",
 		"num", l2Head.Number+1, "time", uint64(attrs.Timestamp),
 		"origin", l1Origin, "origin_time", l1Origin.Time, "noTxPool", attrs.NoTxPool)
@@ -100,18 +157,58 @@ func (d *Sequencer) StartBuildingBlock(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to start building on top of L2 chain %s, error (%d): %w", l2Head, errTyp, err)
 	}
+	d.buildingPastDrift = attrs.NoTxPool
+	d.buildingAttrs = attrs
 	return nil
 }
 
 // CompleteBuildingBlock takes the current block that is being built, and asks the engine to complete the building, seal the block, and persist it as canonical.
+// The build time budget limits the wait for the engine to return the block. If the budget is exceeded, the engine is asked
+// once more, again within the budget, for the best block it built so far. If it still does not return a block, the block
+// building is cancelled, and a deposit-only block is built on top of the same parent instead, to not skip the slot.
+// The budget does not apply to the insertion of a returned block, so no sibling of an inserted block is built.
 // Warning: the safe and finalized L2 blocks as viewed during the initiation of the block building are reused for completion of the block building.
 // The Execution engine should not change the safe and finalized blocks between start and completion of block building.
-func (d *Sequencer) CompleteBuildingBlock(ctx context.Context) (*eth.ExecutionPayload, error) {
-	payload, errTyp, err := d.engine.ConfirmPayload(ctx)
+func (d *Sequencer) CompleteBuildingBlock(ctx context.Context) (*eth.ExecutionPayloadEnvelope, error) {
+	path := BuildPathInBudget
+	envelope, errTyp, err := d.engine.ConfirmPayloadWithin(ctx, d.buildBudget)
+	if errors.Is(err, derive.ErrPayloadTimeout) {
+		// the engine stops building once the payload is requested, and then returns the best payload it built so far
+		d.log.Warn("sequencer exceeded the build time budget, retrieving the best block built so far", "budget", d.buildBudget, "err", err)
+		path = BuildPathBestSoFar
+		envelope, errTyp, err = d.engine.ConfirmPayloadWithin(ctx, d.buildBudget)
+	}
+	if errors.Is(err, derive.ErrPayloadTimeout) && d.buildingAttrs != nil && !d.buildingAttrs.NoTxPool {
+		d.log.Warn("engine did not return a block within the build time budget, falling back to a deposit-only block", "budget", d.buildBudget, "err", err)
+		path = BuildPathDepositOnly
+		envelope, errTyp, err = d.buildDepositOnlyBlock(ctx)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to complete building block: error (%d): %w", errTyp, err)
 	}
-	return payload, nil
+	if d.buildingPastDrift {
+		d.metrics.RecordSequencerDriftExceededBlock()
+	}
+	d.metrics.RecordSequencerBuildPath(path)
+	d.buildingAttrs = nil
+	return envelope, nil
+}
+
+// buildDepositOnlyBlock cancels the current block building job, and builds a block with the same attributes,
+// but without the transactions of the tx pool, on top of the same parent. The cancelled block was never
+// returned by the engine, so it was not inserted.
+func (d *Sequencer) buildDepositOnlyBlock(ctx context.Context) (*eth.ExecutionPayloadEnvelope, derive.BlockInsertionErrType, error) {
+	onto, _, _ := d.engine.BuildingPayload()
+	cancelCtx, cancel := context.WithTimeout(ctx, d.buildBudget)
+	d.CancelBuildingBlock(cancelCtx)
+	cancel()
+	attrs := *d.buildingAttrs
+	attrs.NoTxPool = true
+	if errTyp, err := d.engine.StartPayload(ctx, onto, &attrs, false); err != nil {
+		return nil, errTyp, fmt.Errorf("failed to start building deposit-only block on top of L2 chain %s: %w", onto, err)
+	}
+	d.buildingAttrs = &attrs
+	return d.engine.ConfirmPayload(ctx)
 }
 
 // CancelBuildingBlock cancels the current open block building job.
@@ -121,6 +218,28 @@ func (d *Sequencer) CancelBuildingBlock(ctx context.Context) {
 	_ = d.engine.CancelPayload(ctx, true)
 }
 
+// FinishBuildingBlock seals the block that is being built, if it still builds on top of the unsafe head,
+// and returns it for publishing. Block building that no longer builds on top of the unsafe head is cancelled.
+// Nil is returned if there was no block to seal. Safe block building by the derivation process is not interrupted.
+func (d *Sequencer) FinishBuildingBlock(ctx context.Context) (*eth.ExecutionPayloadEnvelope, error) {
+	onto, buildingID, safe := d.engine.BuildingPayload()
+	if buildingID == (eth.PayloadID{}) || safe {
+		return nil, nil
+	}
+	if onto.Hash != d.engine.UnsafeL2Head().Hash {
+		d.CancelBuildingBlock(ctx)
+		return nil, nil
+	}
+	envelope, err := d.CompleteBuildingBlock(ctx)
+	if err != nil {
+		d.CancelBuildingBlock(ctx)
+		return nil, err
+	}
+	payload := envelope.ExecutionPayload
+	d.log.Info("sequencer sealed in-flight block", "block", payload.ID(), "time", uint64(payload.Timestamp), "txs", len(payload.Transactions))
+	return envelope, nil
+}
+
 // PlanNextSequencerAction returns a desired delay till the RunNextSequencerAction call.
 func (d *Sequencer) PlanNextSequencerAction() time.Duration {
 	// If the engine is busy building safe blocks (and thus changing the head that we would sync on top of),
@@ -141,6 +260,10 @@ func (d *Sequencer) PlanNextSequencerAction() time.Duration {
 	if delay := d.nextAction.Sub(now); delay > 0 && buildingOnto.Hash == head.Hash {
 		return delay
 	}
+	// If the block was not admitted, we wait for the next slot, or until the head changed.
+	if delay := d.nextAction.Sub(now); delay > 0 && buildingID == (eth.PayloadID{}) && d.deniedOnto.Hash == head.Hash {
+		return delay
+	}
 
 	blockTime := time.Duration(d.config.BlockTime) * time.Second
 	payloadTime := time.Unix(int64(head.Time+d.config.BlockTime), 0)
@@ -196,7 +319,7 @@ func (d *Sequencer) BuildingOnto() eth.L2BlockRef {
 // If the derivation pipeline does force a conflicting block, then an ongoing sequencer task might still finish,
 // but the derivation can continue to reset until the chain is correct.
 // If the engine is currently building safe blocks, then that building is not interrupted, and sequencing is delayed.
-func (d *Sequencer) RunNextSequencerAction(ctx context.Context) (*eth.ExecutionPayload, error) {
+func (d *Sequencer) RunNextSequencerAction(ctx context.Context) (*eth.ExecutionPayloadEnvelope, error) {
 	if onto, buildingID, safe := d.engine.BuildingPayload(); buildingID != (eth.PayloadID{}) {
 		if safe {
 			d.log.Warn("avoiding sequencing to not interrupt safe-head changes", "onto", onto, "onto_time", onto.Time)
@@ -204,7 +327,7 @@ func (d *Sequencer) RunNextSequencerAction(ctx context.Context) (*eth.ExecutionP
 			d.nextAction = d.timeNow().Add(time.Second * time.Duration(d.config.BlockTime))
 			return nil, nil
 		}
-		payload, err := d.CompleteBuildingBlock(ctx)
+		envelope, err := d.CompleteBuildingBlock(ctx)
 		if err != nil {
 			if errors.Is(err, derive.ErrCritical) {
 				return nil, err // bubble up critical errors.
@@ -226,8 +349,9 @@ func (d *Sequencer) RunNextSequencerAction(ctx context.Context) (*eth.ExecutionP
 			}
 			return nil, nil
 		} else {
+			payload := envelope.ExecutionPayload
 			d.log.Info("sequencer successfully built a new block", "block", payload.ID(), "time", uint64(payload.Timestamp), "txs", len(payload.Transactions))
-			return payload, nil
+			return envelope, nil
 		}
 	} else {
 		err := d.StartBuildingBlock(ctx)
@@ -239,6 +363,11 @@ func (d *Sequencer) RunNextSequencerAction(ctx context.Context) (*eth.ExecutionP
 				d.metrics.RecordSequencerReset()
 				d.nextAction = d.timeNow().Add(time.Second * time.Duration(d.config.BlockTime)) // hold off from sequencing for a full block
 				d.engine.Reset()
+			} else if errors.Is(err, ErrNotAdmitted) {
+				// skip the slot: nothing changed, and building is re-attempted on top of the same head
+				d.deniedOnto = d.engine.UnsafeL2Head()
+				d.log.Warn("sequencer skipped building new block", "parent", d.deniedOnto, "err", err)
+				d.nextAction = d.timeNow().Add(time.Second * time.Duration(d.config.BlockTime))
 			} else if errors.Is(err, derive.ErrTemporary) {
 				d.log.Error("sequencer temporarily failed to start building new block", "err", err)
 				d.nextAction = d.timeNow().Add(time.Second)
@@ -44,6 +44,14 @@ type FakeEngineControl struct {
 
 	makePayload func(onto eth.L2BlockRef, attrs *eth.PayloadAttributes) *eth.ExecutionPayload
 
+	// waitPayload optionally mocks the time the engine takes to return the payload, it may return a context error.
+	waitPayload func(ctx context.Context, attrs *eth.PayloadAttributes) error
+	// waitInsert optionally mocks the time the engine takes to insert the payload, it may return a context error.
+	waitInsert func(ctx context.Context) error
+	// inserted are the parents of the inserted blocks, to detect sibling blocks
+	inserted map[common.Hash]bool
+	siblings int
+
 	errTyp derive.BlockInsertionErrType
 	err    error
 
@@ -73,10 +81,40 @@ func (m *FakeEngineControl) StartPayload(ctx context.Context, parent eth.L2Block
 	return derive.BlockInsertOK, nil
 }
 
-func (m *FakeEngineControl) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPayload, errTyp derive.BlockInsertionErrType, err error) {
+func (m *FakeEngineControl) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPayloadEnvelope, errTyp derive.BlockInsertionErrType, err error) {
+	return m.ConfirmPayloadWithin(ctx, 0)
+}
+
+func (m *FakeEngineControl) ConfirmPayloadWithin(ctx context.Context, timeout time.Duration) (out *eth.ExecutionPayloadEnvelope, errTyp derive.BlockInsertionErrType, err error) {
 	if m.err != nil {
 		return nil, m.errTyp, m.err
 	}
+	if m.waitPayload != nil {
+		getCtx := ctx
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			getCtx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		if err := m.waitPayload(getCtx, m.buildingAttrs); err != nil {
+			if getCtx.Err() != nil && ctx.Err() == nil {
+				return nil, derive.BlockInsertTemporaryErr, fmt.Errorf("%w: %v", derive.ErrPayloadTimeout, err)
+			}
+			return nil, derive.BlockInsertTemporaryErr, fmt.Errorf("failed to get execution payload: %w", err)
+		}
+	}
+	if m.waitInsert != nil {
+		if err := m.waitInsert(ctx); err != nil {
+			return nil, derive.BlockInsertTemporaryErr, fmt.Errorf("failed to insert execution payload: %w", err)
+		}
+	}
+	if m.inserted == nil {
+		m.inserted = make(map[common.Hash]bool)
+	}
+	if m.inserted[m.buildingOnto.Hash] {
+		m.siblings++
+	}
+	m.inserted[m.buildingOnto.Hash] = true
 	buildTime := m.timeNow().Sub(m.buildingStart)
 	m.totalBuildingTime += buildTime
 	m.totalBuiltBlocks += 1
@@ -92,7 +130,7 @@ func (m *FakeEngineControl) ConfirmPayload(ctx context.Context) (out *eth.Execut
 
 	m.resetBuildingState()
 	m.totalTxs += len(payload.Transactions)
-	return payload, derive.BlockInsertOK, nil
+	return &eth.ExecutionPayloadEnvelope{ExecutionPayload: payload}, derive.BlockInsertOK, nil
 }
 
 func (m *FakeEngineControl) CancelPayload(ctx context.Context, force bool) error {
@@ -302,7 +340,7 @@ func TestSequencerChaosMonkey(t *testing.T) {
 		}
 	})
 
-	seq := NewSequencer(log, cfg, engControl, attrBuilder, originSelector, metrics.NoopMetrics)
+	seq := NewSequencer(log, cfg, engControl, attrBuilder, originSelector, AlwaysAdmit, 0, metrics.NoopMetrics)
 	seq.timeNow = clockFn
 
 	// try to build 1000 blocks, with 5x as many planning attempts, to handle errors and clock problems
@@ -348,9 +386,10 @@ func TestSequencerChaosMonkey(t *testing.T) {
 		default:
 			// no error
 		}
-		payload, err := seq.RunNextSequencerAction(context.Background())
+		envelope, err := seq.RunNextSequencerAction(context.Background())
 		require.NoError(t, err)
-		if payload != nil {
+		if envelope != nil {
+			payload := envelope.ExecutionPayload
 			require.Equal(t, engControl.UnsafeL2Head().ID(), payload.ID(), "head must stay in sync with emitted payloads")
 			var tx types.Transaction
 			require.NoError(t, tx.UnmarshalBinary(payload.Transactions[0]))
@@ -376,3 +415,278 @@ func TestSequencerChaosMonkey(t *testing.T) {
 	require.Greater(t, engControl.avgBuildingTime(), time.Second, "With 2 second block time and 1 second error backoff and healthy-on-average errors, building time should at least be a second")
 	require.Greater(t, engControl.avgTxsPerBlock(), 3.0, "We expect at least 1 system tx per block, but with a mocked 0-10 txs we expect an higher avg")
 }
+
+type testAdmissionFn func(ctx context.Context, parent eth.L2BlockRef) (bool, error)
+
+func (fn testAdmissionFn) CheckLeader(ctx context.Context, parent eth.L2BlockRef) (bool, error) {
+	return fn(ctx, parent)
+}
+
+var _ SequencerAdmission = (testAdmissionFn)(nil)
+
+// TestSequencerAdmission flaps the admission of the sequencer between blocks,
+// and checks that denied slots are skipped without duplicate or gapped blocks.
+func TestSequencerAdmission(t *testing.T) {
+	mockHash := func(num uint64, layer byte) (out common.Hash) {
+		out[31] = layer
+		binary.BigEndian.PutUint64(out[:], num)
+		return
+	}
+	rng := rand.New(rand.NewSource(1234))
+	log := testlog.Logger(t, log.LvlCrit)
+
+	l1Origin := eth.L1BlockRef{Hash: mockHash(100, 1), Number: 100, ParentHash: mockHash(99, 1), Time: 1000}
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1:     l1Origin.ID(),
+			L2:     eth.BlockID{Hash: mockHash(200, 2), Number: 200},
+			L2Time: l1Origin.Time,
+		},
+		BlockTime:         2,
+		MaxSequencerDrift: 10_000,
+	}
+	genesisL2 := eth.L2BlockRef{
+		Hash:       cfg.Genesis.L2.Hash,
+		Number:     cfg.Genesis.L2.Number,
+		ParentHash: mockHash(cfg.Genesis.L2.Number-1, 2),
+		Time:       cfg.Genesis.L2Time,
+		L1Origin:   cfg.Genesis.L1,
+	}
+	clockTime := time.Unix(int64(genesisL2.Time), 0)
+	clockFn := func() time.Time {
+		return clockTime
+	}
+	engControl := &FakeEngineControl{
+		finalized: genesisL2,
+		safe:      genesisL2,
+		unsafe:    genesisL2,
+		cfg:       cfg,
+		timeNow:   clockFn,
+	}
+	engControl.makePayload = func(onto eth.L2BlockRef, attrs *eth.PayloadAttributes) *eth.ExecutionPayload {
+		return &eth.ExecutionPayload{
+			ParentHash:   onto.Hash,
+			BlockNumber:  eth.Uint64Quantity(onto.Number) + 1,
+			Timestamp:    attrs.Timestamp,
+			BlockHash:    mockHash(onto.Number+1, 2),
+			Transactions: attrs.Transactions,
+		}
+	}
+	attrBuilder := testAttrBuilderFn(func(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID) (*eth.PayloadAttributes, error) {
+		l1Info := &testutils.MockBlockInfo{
+			InfoHash:       l1Origin.Hash,
+			InfoParentHash: l1Origin.ParentHash,
+			InfoNum:        l1Origin.Number,
+			InfoTime:       l1Origin.Time,
+			InfoBaseFee:    big.NewInt(1234),
+		}
+		infoDep, err := derive.L1InfoDepositBytes(l2Parent.SequenceNumber+1, l1Info, cfg.Genesis.SystemConfig, false)
+		require.NoError(t, err)
+		return &eth.PayloadAttributes{
+			Timestamp:    eth.Uint64Quantity(l2Parent.Time + cfg.BlockTime),
+			Transactions: []eth.Data{infoDep},
+		}, nil
+	})
+	originQueries := 0
+	originSelector := testOriginSelectorFn(func(ctx context.Context, l2Head eth.L2BlockRef) (eth.L1BlockRef, error) {
+		originQueries++
+		return l1Origin, nil
+	})
+
+	var admitted bool
+	admissionErr := errors.New("mock admission endpoint unreachable")
+	var denied, failed int
+	admission := testAdmissionFn(func(ctx context.Context, parent eth.L2BlockRef) (bool, error) {
+		require.Equal(t, engControl.UnsafeL2Head(), parent, "admission must be checked for the current head")
+		switch rng.Intn(4) {
+		case 0:
+			denied++
+			admitted = false
+			return false, nil
+		case 1:
+			failed++
+			admitted = false
+			return false, admissionErr
+		default:
+			admitted = true
+			return true, nil
+		}
+	})
+
+	seq := NewSequencer(log, cfg, engControl, attrBuilder, originSelector, admission, 0, metrics.NoopMetrics)
+	seq.timeNow = clockFn
+
+	head := genesisL2.ID()
+	desiredBlocks := 100
+	for i := 0; i < 10*desiredBlocks && engControl.totalBuiltBlocks < desiredBlocks; i++ {
+		clockTime = clockTime.Add(seq.PlanNextSequencerAction())
+		_, buildingID, _ := engControl.BuildingPayload()
+		starting := buildingID == (eth.PayloadID{})
+		queries := originQueries
+
+		envelope, err := seq.RunNextSequencerAction(context.Background())
+		require.NoError(t, err)
+		if starting && !admitted {
+			_, buildingID, _ := engControl.BuildingPayload()
+			require.Equal(t, eth.PayloadID{}, buildingID, "denied block must not be started")
+			require.Equal(t, queries, originQueries, "denied block must not select an L1 origin")
+		}
+		if envelope != nil {
+			payload := envelope.ExecutionPayload
+			require.Equal(t, head.Number+1, uint64(payload.BlockNumber), "no gapped or duplicate blocks")
+			require.Equal(t, head.Hash, payload.ParentHash, "block must build on the previous block")
+			head = payload.ID()
+		}
+	}
+	require.Equal(t, desiredBlocks, engControl.totalBuiltBlocks)
+	require.Equal(t, head, engControl.UnsafeL2Head().ID())
+	require.NotZero(t, denied, "admission must have been denied")
+	require.NotZero(t, failed, "admission must have failed")
+	require.Less(t, clockTime.Sub(time.Unix(int64(engControl.UnsafeL2Head().Time), 0)), time.Duration(cfg.BlockTime)*time.Second*10,
+		"denied slots are retried, and the sequencer catches up with the wallclock")
+}
+
+// TestSequencerBuildBudget fakes an engine that is too slow to return some blocks with transactions, and slow to
+// insert every block. It checks that the sequencer takes the best block built so far, or falls back to a deposit-only
+// block if the engine does not return any, without skipping slots, and without building sibling blocks.
+func TestSequencerBuildBudget(t *testing.T) {
+	mockHash := func(num uint64, layer byte) (out common.Hash) {
+		out[31] = layer
+		binary.BigEndian.PutUint64(out[:], num)
+		return
+	}
+	rng := rand.New(rand.NewSource(1234))
+	log := testlog.Logger(t, log.LvlCrit)
+	budget := 10 * time.Millisecond
+
+	l1Origin := eth.L1BlockRef{Hash: mockHash(100, 1), Number: 100, ParentHash: mockHash(99, 1), Time: 1000}
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1:     l1Origin.ID(),
+			L2:     eth.BlockID{Hash: mockHash(200, 2), Number: 200},
+			L2Time: l1Origin.Time,
+		},
+		BlockTime:         2,
+		MaxSequencerDrift: 10_000,
+	}
+	genesisL2 := eth.L2BlockRef{
+		Hash:       cfg.Genesis.L2.Hash,
+		Number:     cfg.Genesis.L2.Number,
+		ParentHash: mockHash(cfg.Genesis.L2.Number-1, 2),
+		Time:       cfg.Genesis.L2Time,
+		L1Origin:   cfg.Genesis.L1,
+	}
+	clockTime := time.Unix(int64(genesisL2.Time), 0)
+	clockFn := func() time.Time {
+		return clockTime
+	}
+	engControl := &FakeEngineControl{
+		finalized: genesisL2,
+		safe:      genesisL2,
+		unsafe:    genesisL2,
+		cfg:       cfg,
+		timeNow:   clockFn,
+	}
+	engControl.makePayload = func(onto eth.L2BlockRef, attrs *eth.PayloadAttributes) *eth.ExecutionPayload {
+		txs := append([]eth.Data{}, attrs.Transactions...)
+		if !attrs.NoTxPool {
+			txs = append(txs, []byte("mock sequenced tx"))
+		}
+		return &eth.ExecutionPayload{
+			ParentHash:   onto.Hash,
+			BlockNumber:  eth.Uint64Quantity(onto.Number) + 1,
+			Timestamp:    attrs.Timestamp,
+			BlockHash:    mockHash(onto.Number+1, 2),
+			Transactions: txs,
+		}
+	}
+	const (
+		fast  = iota // returns the block within the budget
+		slow         // exceeds the budget, but then returns the best block built so far
+		stuck        // does not return the block at all
+	)
+	blocks := make(map[int]int)
+	kinds := make(map[*eth.PayloadAttributes]int)
+	requests := make(map[*eth.PayloadAttributes]int)
+	engControl.waitPayload = func(ctx context.Context, attrs *eth.PayloadAttributes) error {
+		if attrs.NoTxPool {
+			return nil
+		}
+		if _, ok := kinds[attrs]; !ok {
+			kinds[attrs] = rng.Intn(3)
+			blocks[kinds[attrs]]++
+		}
+		requests[attrs]++
+		if kinds[attrs] == fast || (kinds[attrs] == slow && requests[attrs] > 1) {
+			return nil
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	engControl.waitInsert = func(ctx context.Context) error {
+		// the insertion takes longer than the budget, and must not be interrupted
+		select {
+		case <-time.After(2 * budget):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	attrBuilder := testAttrBuilderFn(func(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID) (*eth.PayloadAttributes, error) {
+		l1Info := &testutils.MockBlockInfo{
+			InfoHash:       l1Origin.Hash,
+			InfoParentHash: l1Origin.ParentHash,
+			InfoNum:        l1Origin.Number,
+			InfoTime:       l1Origin.Time,
+			InfoBaseFee:    big.NewInt(1234),
+		}
+		infoDep, err := derive.L1InfoDepositBytes(l2Parent.SequenceNumber+1, l1Info, cfg.Genesis.SystemConfig, false)
+		require.NoError(t, err)
+		return &eth.PayloadAttributes{
+			Timestamp:    eth.Uint64Quantity(l2Parent.Time + cfg.BlockTime),
+			Transactions: []eth.Data{infoDep},
+		}, nil
+	})
+	originSelector := testOriginSelectorFn(func(ctx context.Context, l2Head eth.L2BlockRef) (eth.L1BlockRef, error) {
+		return l1Origin, nil
+	})
+	paths := make(map[string]int)
+	m := &testutils.TestSequencerMetrics{
+		FnRecordSequencerBuildPath: func(path string) {
+			paths[path]++
+		},
+	}
+
+	seq := NewSequencer(log, cfg, engControl, attrBuilder, originSelector, AlwaysAdmit, budget, m)
+	seq.timeNow = clockFn
+
+	head := genesisL2.ID()
+	desiredBlocks := 50
+	for i := 0; i < desiredBlocks; i++ {
+		// start building
+		clockTime = clockTime.Add(seq.PlanNextSequencerAction())
+		envelope, err := seq.RunNextSequencerAction(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, envelope)
+
+		// seal the block: a block is sealed within the slot, even if the engine is too slow
+		clockTime = clockTime.Add(seq.PlanNextSequencerAction())
+		envelope, err = seq.RunNextSequencerAction(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, envelope, "slot must not be skipped")
+		payload := envelope.ExecutionPayload
+		require.Equal(t, head.Number+1, uint64(payload.BlockNumber), "no gapped or duplicate blocks")
+		require.Equal(t, head.Hash, payload.ParentHash)
+		require.Equal(t, engControl.UnsafeL2Head().ID(), payload.ID())
+		head = payload.ID()
+	}
+	require.NotZero(t, blocks[slow], "engine must have been too slow")
+	require.NotZero(t, blocks[stuck], "engine must have been stuck")
+	require.Zero(t, engControl.siblings, "no sibling blocks")
+	require.Equal(t, blocks[fast], paths[BuildPathInBudget])
+	require.Equal(t, blocks[slow], paths[BuildPathBestSoFar], "slow blocks are taken as built so far")
+	require.Equal(t, blocks[stuck], paths[BuildPathDepositOnly], "stuck blocks fall back to deposit-only blocks")
+	require.Equal(t, blocks[fast]+blocks[slow], engControl.totalTxs-desiredBlocks, "only returned blocks include txs")
+	require.Less(t, clockTime.Sub(time.Unix(int64(engControl.UnsafeL2Head().Time), 0)).Abs(), time.Duration(cfg.BlockTime)*time.Second,
+		"the L2 time keeps up with the wallclock")
+}
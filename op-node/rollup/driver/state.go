@@ -16,7 +16,6 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
-	"github.com/ethereum-optimism/optimism/op-service/retry"
 )
 
 // Deprecated: use eth.SyncStatus instead.
@@ -35,6 +34,10 @@ type Driver struct {
 	// Requests to block the event loop for synchronous execution to avoid reading an inconsistent state
 	stateReq chan chan struct{}
 
+	// Upon receiving a channel in this channel, a snapshot of the driver state is sent to the channel.
+	// The channel must be buffered, the event loop does not wait for the caller to receive the snapshot.
+	stateSnapshotReq chan chan *StateSnapshot
+
 	// Upon receiving a channel in this channel, the derivation pipeline is forced to be reset.
 	// It tells the caller that the reset occurred by closing the passed in channel.
 	forceReset chan chan struct{}
@@ -52,6 +55,12 @@ type Driver struct {
 	// true when the sequencer is active, false when it is not.
 	sequencerActive chan chan bool
 
+	// Upon receiving a channel in this channel, the detailed sequencer status is queried.
+	sequencerStatusReq chan chan *SequencerStatus
+
+	// syncStatusFeed is notified with the sync status whenever the unsafe, safe or finalized L2 head changes
+	syncStatusFeed SyncStatusFeed
+
 	// sequencerNotifs is notified when the sequencer is started or stopped
 	sequencerNotifs SequencerStateListener
 
@@ -76,7 +85,7 @@ type Driver struct {
 
 	// L2 Signals:
 
-	unsafeL2Payloads chan *eth.ExecutionPayload
+	unsafeL2Payloads chan *eth.ExecutionPayloadEnvelope
 
 	l1        L1Chain
 	l2        L2Chain
@@ -91,6 +100,10 @@ type Driver struct {
 
 	driverCtx    context.Context
 	driverCancel context.CancelFunc
+
+	// closing is closed to request the event loop to stop after its current step
+	closing   chan struct{}
+	closeOnce gosync.Once
 }
 
 // Start starts up the state loop.
@@ -119,10 +132,27 @@ func (s *Driver) Start() error {
 	return nil
 }
 
-func (s *Driver) Close() error {
+// Close stops the driver gracefully: the event loop finishes its current step,
+// cancels any in-flight block building, and returns.
+// If the ctx is done before then, the in-flight work of the driver is aborted.
+func (s *Driver) Close(ctx context.Context) error {
+	s.closeOnce.Do(func() { close(s.closing) })
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.log.Warn("Driver did not stop in time, aborting in-flight work", "err", ctx.Err())
+		err = fmt.Errorf("forced driver shutdown: %w", ctx.Err())
+		s.driverCancel()
+		<-done
+	}
 	s.driverCancel()
-	s.wg.Wait()
-	return nil
+	return err
 }
 
 // OnL1Head signals the driver that the L1 chain changed the "unsafe" block,
@@ -156,11 +186,11 @@ func (s *Driver) OnL1Finalized(ctx context.Context, finalized eth.L1BlockRef) er
 	}
 }
 
-func (s *Driver) OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayload) error {
+func (s *Driver) OnUnsafeL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
-	case s.unsafeL2Payloads <- payload:
+	case s.unsafeL2Payloads <- envelope:
 		return nil
 	}
 }
@@ -180,9 +210,13 @@ func (s *Driver) eventLoop() {
 	var delayedStepReq <-chan time.Time
 
 	// keep track of consecutive failed attempts, to adjust the backoff time accordingly
-	bOffStrategy := retry.Exponential()
+	bOffStrategy := stepBackoff(s.driverConfig.DerivationMaxBackoff)
 	stepAttempts := 0
 
+	// keep track of the latest progress, to debug stalls with a state snapshot
+	var progress progressTracker
+	derivationIdle := false
+
 	// step requests a derivation step to be taken. Won't deadlock if the channel is full.
 	step := func() {
 		select {
@@ -231,10 +265,29 @@ func (s *Driver) eventLoop() {
 	defer altSyncTicker.Stop()
 	lastUnsafeL2 := s.derivation.UnsafeL2Head()
 
+	// shutdown cancels any in-flight block building, so the engine is not left with a dangling payload build.
+	shutdown := func() {
+		if s.sequencer.BuildingOnto() != (eth.L2BlockRef{}) {
+			ctx, cancel := context.WithTimeout(s.driverCtx, time.Second*2)
+			s.sequencer.CancelBuildingBlock(ctx)
+			cancel()
+		}
+		s.log.Info("Driver stopped", "unsafe", s.derivation.UnsafeL2Head(), "safe", s.derivation.SafeL2Head())
+	}
+
 	for {
 		if s.driverCtx.Err() != nil { // don't try to schedule/handle more work when we are closing.
 			return
 		}
+		select {
+		case <-s.closing:
+			shutdown()
+			return
+		default:
+		}
+		if progress.updateHeads(time.Now(), s.derivation.UnsafeL2Head(), s.derivation.SafeL2Head(), s.derivation.Finalized()) {
+			s.syncStatusFeed.Send(s.syncStatus())
+		}
 
 		// If we are sequencing, and the L1 state is ready, update the trigger for the next sequencer action.
 		// This may adjust at any time based on fork-choice changes or previous errors.
@@ -272,16 +325,19 @@ func (s *Driver) eventLoop() {
 
 		select {
 		case <-sequencerCh:
-			payload, err := s.sequencer.RunNextSequencerAction(s.driverCtx)
+			envelope, err := s.sequencer.RunNextSequencerAction(s.driverCtx)
 			if err != nil {
 				s.log.Error("Sequencer critical error", "err", err)
 				return
 			}
-			if s.network != nil && payload != nil {
+			if envelope != nil {
+				progress.sequenced(time.Now(), envelope.ExecutionPayload)
+			}
+			if s.network != nil && envelope != nil {
 				// Publishing of unsafe data via p2p is optional.
 				// Errors are not severe enough to change/halt sequencing but should be logged and metered.
-				if err := s.network.PublishL2Payload(s.driverCtx, payload); err != nil {
-					s.log.Warn("failed to publish newly created block", "id", payload.ID(), "err", err)
+				if err := s.network.PublishL2Payload(s.driverCtx, envelope); err != nil {
+					s.log.Warn("failed to publish newly created block", "id", envelope.ExecutionPayload.ID(), "err", err)
 					s.metrics.RecordPublishingError()
 				}
 			}
@@ -294,15 +350,28 @@ func (s *Driver) eventLoop() {
 			if err != nil {
 				s.log.Warn("failed to check for unsafe L2 blocks to sync", "err", err)
 			}
-		case payload := <-s.unsafeL2Payloads:
+		case envelope := <-s.unsafeL2Payloads:
+			payload := envelope.ExecutionPayload
 			s.snapshot("New unsafe payload")
 			s.log.Info("Optimistically queueing unsafe L2 execution payload", "id", payload.ID())
-			s.derivation.AddUnsafePayload(payload)
+			s.derivation.AddUnsafePayload(envelope)
 			s.metrics.RecordReceivedUnsafePayload(payload)
+			// A payload more than one block ahead of the unsafe head reveals a gap:
+			// request the missing blocks right away, instead of waiting for the alt-sync ticker.
+			// Repeated requests for the same range are deduplicated by the alt-sync source.
+			if s.derivation.EngineReady() && uint64(payload.BlockNumber) > s.derivation.UnsafeL2Head().Number+1 {
+				ctx, cancel := context.WithTimeout(s.driverCtx, time.Second*2)
+				if err := s.checkForGapInUnsafeQueue(ctx); err != nil {
+					s.log.Warn("failed to request missing unsafe L2 blocks", "err", err)
+				}
+				cancel()
+				altSyncTicker.Reset(syncCheckInterval)
+			}
 			reqStep()
 
 		case newL1Head := <-s.l1HeadSig:
 			s.l1State.HandleNewL1HeadBlock(newL1Head)
+			progress.last.L1Head = time.Now().UnixMilli()
 			reqStep() // a new L1 head may mean we have the data to not get an EOF again.
 		case newL1Safe := <-s.l1SafeSig:
 			s.l1State.HandleNewL1SafeBlock(newL1Safe)
@@ -319,81 +388,133 @@ func (s *Driver) eventLoop() {
 			s.log.Debug("Derivation process step", "onto_origin", s.derivation.Origin(), "attempts", stepAttempts)
 			err := s.derivation.Step(s.driverCtx)
 			stepAttempts += 1 // count as attempt by default. We reset to 0 if we are making healthy progress.
-			if err == io.EOF {
-				s.log.Debug("Derivation process went idle", "progress", s.derivation.Origin(), "err", err)
-				stepAttempts = 0
-				s.metrics.SetDerivationIdle(true)
-				continue
-			} else if err != nil && errors.Is(err, derive.EngineELSyncing) {
-				s.log.Debug("Derivation process went idle because the engine is syncing", "progress", s.derivation.Origin(), "sync_target", s.derivation.EngineSyncTarget(), "err", err)
+			derivationIdle = err == io.EOF || errors.Is(err, derive.EngineELSyncing)
+			outcome := classifyStepResult(err)
+			s.metrics.RecordDerivationStep(outcome)
+			switch outcome {
+			case StepOutcomeIdle:
+				if err == io.EOF {
+					s.log.Debug("Derivation process went idle", "progress", s.derivation.Origin(), "err", err)
+				} else {
+					s.log.Debug("Derivation process went idle because the engine is syncing", "progress", s.derivation.Origin(), "sync_target", s.derivation.EngineSyncTarget(), "err", err)
+				}
 				stepAttempts = 0
 				s.metrics.SetDerivationIdle(true)
 				continue
-			} else if err != nil && errors.Is(err, derive.ErrReset) {
+			case StepOutcomeReset:
 				// If the pipeline corrupts, e.g. due to a reorg, simply reset it
 				s.log.Warn("Derivation pipeline is reset", "err", err)
+				progress.reset(time.Now(), err.Error())
 				s.derivation.Reset()
 				s.metrics.RecordPipelineReset()
 				continue
-			} else if err != nil && errors.Is(err, derive.ErrTemporary) {
-				s.log.Warn("Derivation process temporary error", "attempts", stepAttempts, "err", err)
-				reqStep()
-				continue
-			} else if err != nil && errors.Is(err, derive.ErrCritical) {
+			case StepOutcomeCritical:
 				s.log.Error("Derivation process critical error", "err", err)
 				return
-			} else if err != nil && errors.Is(err, derive.NotEnoughData) {
+			case StepOutcomeNotEnough:
 				stepAttempts = 0 // don't do a backoff for this error
 				reqStep()
 				continue
-			} else if err != nil {
-				s.log.Error("Derivation process error", "attempts", stepAttempts, "err", err)
+			case StepOutcomeTemporary, StepOutcomeUnclassified:
+				// Retry in place, to keep the data buffered in the pipeline stages, unless the failures persist.
+				if escalateStepFailure(stepAttempts, s.driverConfig.DerivationMaxStepAttempts) {
+					s.log.Error("Derivation process keeps failing, resetting the pipeline", "attempts", stepAttempts, "err", err)
+					progress.reset(time.Now(), err.Error())
+					s.derivation.Reset()
+					s.metrics.RecordPipelineReset()
+					stepAttempts = 0
+					reqStep()
+					continue
+				}
+				if outcome == StepOutcomeTemporary {
+					s.log.Warn("Derivation process temporary error", "attempts", stepAttempts, "err", err)
+				} else {
+					s.log.Error("Derivation process error", "attempts", stepAttempts, "err", err)
+				}
 				reqStep()
 				continue
-			} else {
+			default:
 				stepAttempts = 0
+				progress.last.Derivation = time.Now().UnixMilli()
 				reqStep() // continue with the next step if we can
 			}
 		case respCh := <-s.stateReq:
 			respCh <- struct{}{}
+		case respCh := <-s.stateSnapshotReq:
+			respCh <- s.stateSnapshot(&progress, stepAttempts, delayedStepReq != nil, derivationIdle)
 		case respCh := <-s.forceReset:
 			s.log.Warn("Derivation pipeline is manually reset")
+			progress.reset(time.Now(), "manual reset")
 			s.derivation.Reset()
 			s.metrics.RecordPipelineReset()
 			close(respCh)
 		case resp := <-s.startSequencer:
 			unsafeHead := s.derivation.UnsafeL2Head().Hash
 			if !s.driverConfig.SequencerStopped {
-				resp.err <- errors.New("sequencer already running")
-			} else if !bytes.Equal(unsafeHead[:], resp.hash[:]) {
+				// Starting again is a no-op, as long as the caller agrees on the head the sequencer was started on.
+				if resp.hash == (common.Hash{}) || resp.hash == unsafeHead {
+					close(resp.err)
+				} else {
+					resp.err <- fmt.Errorf("sequencer already running: head %s, received %s", unsafeHead.String(), resp.hash.String())
+				}
+			} else if resp.hash != (common.Hash{}) && !bytes.Equal(unsafeHead[:], resp.hash[:]) {
 				resp.err <- fmt.Errorf("block hash does not match: head %s, received %s", unsafeHead.String(), resp.hash.String())
 			} else {
 				if err := s.sequencerNotifs.SequencerStarted(); err != nil {
 					resp.err <- fmt.Errorf("sequencer start notification: %w", err)
 					continue
 				}
-				s.log.Info("Sequencer has been started")
+				if resp.hash == (common.Hash{}) {
+					s.log.Warn("Sequencer has been force-started, without head hash check", "head", unsafeHead)
+				} else {
+					s.log.Info("Sequencer has been started", "head", unsafeHead)
+				}
 				s.driverConfig.SequencerStopped = false
 				close(resp.err)
 				planSequencerAction() // resume sequencing
 			}
 		case respCh := <-s.stopSequencer:
 			if s.driverConfig.SequencerStopped {
-				respCh <- hashAndError{err: errors.New("sequencer not running")}
+				// Stopping again is a no-op: report the head again, in case the previous response was lost.
+				respCh <- hashAndError{hash: s.derivation.UnsafeL2Head().Hash}
 			} else {
 				if err := s.sequencerNotifs.SequencerStopped(); err != nil {
-					respCh <- hashAndError{err: fmt.Errorf("sequencer start notification: %w", err)}
+					respCh <- hashAndError{err: fmt.Errorf("sequencer stop notification: %w", err)}
 					continue
 				}
-				s.log.Warn("Sequencer has been stopped")
 				s.driverConfig.SequencerStopped = true
-				// Cancel any inflight block building. If we don't cancel this, we can resume sequencing an old block
+				// Seal the in-flight block, so the returned head includes it, and the next sequencer can build on it.
+				// Any remaining block building is cancelled: if we don't cancel it, we can resume sequencing an old block
 				// even if we've received new unsafe heads in the interim, causing us to introduce a re-org.
-				s.sequencer.CancelBuildingBlock(s.driverCtx)
-				respCh <- hashAndError{hash: s.derivation.UnsafeL2Head().Hash}
+				envelope, err := s.sequencer.FinishBuildingBlock(s.driverCtx)
+				if err == nil && envelope != nil {
+					progress.sequenced(time.Now(), envelope.ExecutionPayload)
+				}
+				if err != nil {
+					s.log.Error("Failed to seal in-flight block while stopping sequencer", "err", err)
+				} else if s.network != nil && envelope != nil {
+					if err := s.network.PublishL2Payload(s.driverCtx, envelope); err != nil {
+						s.log.Warn("failed to publish newly created block", "id", envelope.ExecutionPayload.ID(), "err", err)
+						s.metrics.RecordPublishingError()
+					}
+				}
+				unsafeHead := s.derivation.UnsafeL2Head()
+				s.log.Warn("Sequencer has been stopped", "head", unsafeHead)
+				respCh <- hashAndError{hash: unsafeHead.Hash}
 			}
 		case respCh := <-s.sequencerActive:
 			respCh <- !s.driverConfig.SequencerStopped
+		case respCh := <-s.sequencerStatusReq:
+			respCh <- &SequencerStatus{
+				Enabled:           true,
+				Active:            !s.driverConfig.SequencerStopped,
+				Stopped:           s.driverConfig.SequencerStopped,
+				LastSequenced:     progress.lastSequenced,
+				LastSequencedTime: progress.lastSequencedTime,
+			}
+		case <-s.closing:
+			shutdown()
+			return
 		case <-s.driverCtx.Done():
 			return
 		}
@@ -418,6 +539,9 @@ func (s *Driver) ResetDerivationPipeline(ctx context.Context) error {
 	}
 }
 
+// StartSequencer starts the sequencer, if the unsafe head matches the given block hash.
+// A zero block hash force-starts the sequencer on top of whatever the unsafe head is.
+// Starting a running sequencer is a no-op, if the block hash is zero or matches the unsafe head.
 func (s *Driver) StartSequencer(ctx context.Context, blockHash common.Hash) error {
 	if !s.driverConfig.SequencerEnabled {
 		return errors.New("sequencer is not enabled")
@@ -439,6 +563,8 @@ func (s *Driver) StartSequencer(ctx context.Context, blockHash common.Hash) erro
 	}
 }
 
+// StopSequencer stops the sequencer, after sealing the in-flight block, and returns the unsafe head hash,
+// for another sequencer to be started on. Stopping a stopped sequencer is a no-op, and returns the unsafe head hash.
 func (s *Driver) StopSequencer(ctx context.Context) (common.Hash, error) {
 	if !s.driverConfig.SequencerEnabled {
 		return common.Hash{}, errors.New("sequencer is not enabled")
@@ -475,6 +601,39 @@ func (s *Driver) SequencerActive(ctx context.Context) (bool, error) {
 	}
 }
 
+// SequencerStatus is the sequencing state of the node.
+type SequencerStatus struct {
+	// Enabled is false if the node is not configured to be a sequencer.
+	Enabled bool `json:"enabled"`
+	// Active is true if the node is currently sequencing.
+	Active bool `json:"active"`
+	// Stopped is true if the sequencer is enabled, but paused by a stop request, or started in the stopped state.
+	Stopped bool `json:"stopped"`
+	// LastSequenced is the latest block sequenced by this node since startup, zero if none.
+	LastSequenced eth.BlockID `json:"lastSequenced"`
+	// LastSequencedTime is the L2 timestamp of the LastSequenced block, zero if none.
+	LastSequencedTime uint64 `json:"lastSequencedTime"`
+}
+
+// SequencerStatus returns the detailed sequencer status, including the latest block sequenced by this node.
+func (s *Driver) SequencerStatus(ctx context.Context) (*SequencerStatus, error) {
+	if !s.driverConfig.SequencerEnabled {
+		return &SequencerStatus{}, nil
+	}
+	respCh := make(chan *SequencerStatus, 1)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case s.sequencerStatusReq <- respCh:
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case status := <-respCh:
+			return status, nil
+		}
+	}
+}
+
 // syncStatus returns the current sync status, and should only be called synchronously with
 // the driver event loop to avoid retrieval of an inconsistent status.
 func (s *Driver) syncStatus() *eth.SyncStatus {
@@ -507,6 +666,12 @@ func (s *Driver) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	}
 }
 
+// SubscribeSyncStatus subscribes to the sync status, updated whenever the unsafe, safe or finalized L2 head changes.
+// Updates are never blocking the event loop: a slow subscriber only receives the latest update.
+func (s *Driver) SubscribeSyncStatus() *SyncStatusSubscription {
+	return s.syncStatusFeed.Subscribe()
+}
+
 // BlockRefWithStatus blocks the driver event loop and captures the syncing status,
 // along with an L2 block reference by number consistent with that same status.
 // If the event loop is too busy and the context expires, a context error is returned.
@@ -523,6 +688,39 @@ func (s *Driver) BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2Bloc
 	}
 }
 
+// stateSnapshot captures the driver state, and should only be called synchronously with the driver event loop.
+func (s *Driver) stateSnapshot(progress *progressTracker, stepAttempts int, stepRetryScheduled bool, derivationIdle bool) *StateSnapshot {
+	return &StateSnapshot{
+		Time: time.Now().UnixMilli(),
+		Loop: LoopSnapshot{
+			StepAttempts:       stepAttempts,
+			StepRetryScheduled: stepRetryScheduled,
+			DerivationIdle:     derivationIdle,
+			LastReset:          progress.lastReset,
+		},
+		Sequencer: SequencerSnapshot{
+			Enabled:      s.driverConfig.SequencerEnabled,
+			Stopped:      s.driverConfig.SequencerStopped,
+			BuildingOnto: s.sequencer.BuildingOnto(),
+		},
+		Derivation: s.derivation.Progress(),
+		Engine: EngineSnapshot{
+			Ready:       s.derivation.EngineReady(),
+			Unsafe:      s.derivation.UnsafeL2Head(),
+			Safe:        s.derivation.SafeL2Head(),
+			Finalized:   s.derivation.Finalized(),
+			PendingSafe: s.derivation.PendingSafeL2Head(),
+			SyncTarget:  s.derivation.EngineSyncTarget(),
+		},
+		L1: L1Snapshot{
+			Head:      s.l1State.L1Head(),
+			Safe:      s.l1State.L1Safe(),
+			Finalized: s.l1State.L1Finalized(),
+		},
+		LastProgress: progress.last,
+	}
+}
+
 // deferJSONString helps avoid a JSON-encoding performance hit if the snapshot logger does not run
 type deferJSONString struct {
 	x any
@@ -0,0 +1,143 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// StateSnapshot is a structured snapshot of the in-memory driver state, to debug stalled nodes.
+type StateSnapshot struct {
+	// Time is the unix timestamp in milliseconds of when the snapshot was taken.
+	Time int64 `json:"time"`
+
+	Loop      LoopSnapshot      `json:"loop"`
+	Sequencer SequencerSnapshot `json:"sequencer"`
+
+	Derivation derive.PipelineProgress `json:"derivation"`
+	Engine     EngineSnapshot          `json:"engine"`
+	L1         L1Snapshot              `json:"l1"`
+
+	LastProgress ProgressSnapshot `json:"lastProgress"`
+}
+
+// LoopSnapshot is the state of the driver event loop.
+type LoopSnapshot struct {
+	// StepAttempts is the number of consecutive failed derivation steps.
+	StepAttempts int `json:"stepAttempts"`
+	// StepRetryScheduled is true if a re-attempt of a failed derivation step is scheduled.
+	StepRetryScheduled bool `json:"stepRetryScheduled"`
+	// DerivationIdle is true if the derivation went idle, for lack of new L1 data, or while the engine syncs.
+	DerivationIdle bool `json:"derivationIdle"`
+	// LastReset is the latest reset of the derivation pipeline, nil if there was no reset since startup.
+	LastReset *ResetSnapshot `json:"lastReset,omitempty"`
+}
+
+type ResetSnapshot struct {
+	// Time is the unix timestamp in milliseconds of the reset.
+	Time   int64  `json:"time"`
+	Reason string `json:"reason"`
+}
+
+type SequencerSnapshot struct {
+	Enabled bool `json:"enabled"`
+	Stopped bool `json:"stopped"`
+	// BuildingOnto is the L2 block the latest block is or was being built on top of.
+	BuildingOnto eth.L2BlockRef `json:"buildingOnto"`
+}
+
+// EngineSnapshot is the forkchoice state of the engine, as tracked by the engine controller.
+type EngineSnapshot struct {
+	Ready       bool           `json:"ready"`
+	Unsafe      eth.L2BlockRef `json:"unsafe"`
+	Safe        eth.L2BlockRef `json:"safe"`
+	Finalized   eth.L2BlockRef `json:"finalized"`
+	PendingSafe eth.L2BlockRef `json:"pendingSafe"`
+	SyncTarget  eth.L2BlockRef `json:"syncTarget"`
+}
+
+type L1Snapshot struct {
+	Head      eth.L1BlockRef `json:"head"`
+	Safe      eth.L1BlockRef `json:"safe"`
+	Finalized eth.L1BlockRef `json:"finalized"`
+}
+
+// ProgressSnapshot has the unix timestamps in milliseconds of the latest progress of each subsystem,
+// zero if there was no progress since startup.
+type ProgressSnapshot struct {
+	L1Head      int64 `json:"l1Head"`
+	Derivation  int64 `json:"derivation"`
+	UnsafeL2    int64 `json:"unsafeL2"`
+	SafeL2      int64 `json:"safeL2"`
+	FinalizedL2 int64 `json:"finalizedL2"`
+	Sequencer   int64 `json:"sequencer"`
+}
+
+// progressTracker keeps track of the latest progress of the driver subsystems,
+// and is only used synchronously with the driver event loop.
+type progressTracker struct {
+	unsafeL2    eth.L2BlockRef
+	safeL2      eth.L2BlockRef
+	finalizedL2 eth.L2BlockRef
+
+	last      ProgressSnapshot
+	lastReset *ResetSnapshot
+
+	// lastSequenced is the latest block sequenced by this node, and lastSequencedTime its L2 timestamp
+	lastSequenced     eth.BlockID
+	lastSequencedTime uint64
+}
+
+// updateHeads registers progress of the L2 heads, if they changed since the last update, and returns true if any changed.
+func (p *progressTracker) updateHeads(now time.Time, unsafe, safe, finalized eth.L2BlockRef) (changed bool) {
+	if unsafe != p.unsafeL2 {
+		p.unsafeL2 = unsafe
+		p.last.UnsafeL2 = now.UnixMilli()
+		changed = true
+	}
+	if safe != p.safeL2 {
+		p.safeL2 = safe
+		p.last.SafeL2 = now.UnixMilli()
+		changed = true
+	}
+	if finalized != p.finalizedL2 {
+		p.finalizedL2 = finalized
+		p.last.FinalizedL2 = now.UnixMilli()
+		changed = true
+	}
+	return changed
+}
+
+// sequenced registers a block sequenced by this node.
+func (p *progressTracker) sequenced(now time.Time, payload *eth.ExecutionPayload) {
+	p.last.Sequencer = now.UnixMilli()
+	p.lastSequenced = payload.ID()
+	p.lastSequencedTime = uint64(payload.Timestamp)
+}
+
+func (p *progressTracker) reset(now time.Time, reason string) {
+	p.lastReset = &ResetSnapshot{Time: now.UnixMilli(), Reason: reason}
+}
+
+// StateSnapshot captures a snapshot of the driver state.
+// The snapshot is taken by the driver event loop, which does not wait for the caller to receive it:
+// if the event loop is too busy and the context expires, a context error is returned.
+func (s *Driver) StateSnapshot(ctx context.Context) (*StateSnapshot, error) {
+	respCh := make(chan *StateSnapshot, 1)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.driverCtx.Done():
+		return nil, errors.New("driver is closed")
+	case s.stateSnapshotReq <- respCh:
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case snap := <-respCh:
+			return snap, nil
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package driver
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// blockingPipeline is a derivation pipeline of which the step blocks until it is released, or the ctx is done.
+type blockingPipeline struct {
+	DerivationPipeline
+
+	stepping chan struct{}
+	release  chan struct{}
+	stepErr  chan error
+}
+
+func (p *blockingPipeline) Step(ctx context.Context) error {
+	p.stepping <- struct{}{}
+	var err error
+	select {
+	case <-p.release:
+		err = io.EOF
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	p.stepErr <- err
+	return err
+}
+
+func (p *blockingPipeline) Origin() eth.L1BlockRef             { return eth.L1BlockRef{} }
+func (p *blockingPipeline) FinalizedL1() eth.L1BlockRef        { return eth.L1BlockRef{} }
+func (p *blockingPipeline) UnsafeL2Head() eth.L2BlockRef       { return eth.L2BlockRef{} }
+func (p *blockingPipeline) SafeL2Head() eth.L2BlockRef         { return eth.L2BlockRef{} }
+func (p *blockingPipeline) Finalized() eth.L2BlockRef          { return eth.L2BlockRef{} }
+func (p *blockingPipeline) PendingSafeL2Head() eth.L2BlockRef  { return eth.L2BlockRef{} }
+func (p *blockingPipeline) UnsafeL2SyncTarget() eth.L2BlockRef { return eth.L2BlockRef{} }
+func (p *blockingPipeline) EngineSyncTarget() eth.L2BlockRef   { return eth.L2BlockRef{} }
+func (p *blockingPipeline) EngineReady() bool                  { return true }
+
+// buildingSequencer is a sequencer that is building a block, and records if the block building is cancelled.
+type buildingSequencer struct {
+	SequencerIface
+
+	cancelled bool
+}
+
+func (s *buildingSequencer) BuildingOnto() eth.L2BlockRef {
+	if s.cancelled {
+		return eth.L2BlockRef{}
+	}
+	return eth.L2BlockRef{Hash: common.Hash{0xaa}, Number: 100}
+}
+
+func (s *buildingSequencer) CancelBuildingBlock(ctx context.Context) {
+	s.cancelled = true
+}
+
+func startBlockingDriver(t *testing.T) (*Driver, *blockingPipeline, *buildingSequencer) {
+	logger := testlog.Logger(t, log.LvlError)
+	pipeline := &blockingPipeline{
+		stepping: make(chan struct{}, 1),
+		release:  make(chan struct{}),
+		stepErr:  make(chan error, 1),
+	}
+	seq := &buildingSequencer{}
+	driverCtx, driverCancel := context.WithCancel(context.Background())
+	d := &Driver{
+		l1State:      NewL1State(logger, metrics.NoopMetrics),
+		derivation:   pipeline,
+		config:       &rollup.Config{BlockTime: 2},
+		driverConfig: &Config{},
+		driverCtx:    driverCtx,
+		driverCancel: driverCancel,
+		closing:      make(chan struct{}),
+		log:          logger,
+		sequencer:    seq,
+		metrics:      metrics.NewMetrics(""),
+	}
+	d.wg.Add(1)
+	go d.eventLoop()
+	select {
+	case <-pipeline.stepping:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the driver to step")
+	}
+	return d, pipeline, seq
+}
+
+func TestDriverCloseMidStep(t *testing.T) {
+	t.Run("graceful", func(t *testing.T) {
+		d, pipeline, seq := startBlockingDriver(t)
+		closed := make(chan error, 1)
+		go func() {
+			closed <- d.Close(context.Background())
+		}()
+		select {
+		case <-closed:
+			t.Fatal("driver must finish the in-flight step before closing")
+		case <-time.After(50 * time.Millisecond):
+		}
+		close(pipeline.release)
+		require.NoError(t, <-closed)
+		require.Equal(t, io.EOF, <-pipeline.stepErr, "the in-flight step completes")
+		require.True(t, seq.cancelled, "in-flight block building is cancelled")
+		require.NoError(t, d.Close(context.Background()), "closing again is safe")
+	})
+	t.Run("forced", func(t *testing.T) {
+		d, pipeline, _ := startBlockingDriver(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, d.Close(ctx), context.DeadlineExceeded)
+		require.ErrorIs(t, <-pipeline.stepErr, context.Canceled, "the in-flight step is aborted")
+	})
+}
@@ -0,0 +1,63 @@
+package driver
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+// DefaultDerivationMaxBackoff is the default maximum delay between re-attempts of failed derivation steps.
+const DefaultDerivationMaxBackoff = 10 * time.Second
+
+// Outcome classes of a derivation step, as recorded in the metrics.
+const (
+	StepOutcomeOK           = "ok"
+	StepOutcomeIdle         = "idle"
+	StepOutcomeNotEnough    = "not_enough_data"
+	StepOutcomeTemporary    = "temporary"
+	StepOutcomeReset        = "reset"
+	StepOutcomeCritical     = "critical"
+	StepOutcomeUnclassified = "unclassified"
+)
+
+// classifyStepResult classifies the result of a derivation step by the typed errors of the pipeline stages.
+func classifyStepResult(err error) string {
+	switch {
+	case err == nil:
+		return StepOutcomeOK
+	case err == io.EOF, errors.Is(err, derive.EngineELSyncing):
+		return StepOutcomeIdle
+	case errors.Is(err, derive.NotEnoughData):
+		return StepOutcomeNotEnough
+	case errors.Is(err, derive.ErrReset):
+		return StepOutcomeReset
+	case errors.Is(err, derive.ErrTemporary):
+		return StepOutcomeTemporary
+	case errors.Is(err, derive.ErrCritical):
+		return StepOutcomeCritical
+	default:
+		return StepOutcomeUnclassified
+	}
+}
+
+// stepBackoff returns the backoff strategy of failed derivation steps, with the delay capped at maxBackoff.
+// DefaultDerivationMaxBackoff is used if maxBackoff is 0.
+func stepBackoff(maxBackoff time.Duration) retry.Strategy {
+	if maxBackoff == 0 {
+		maxBackoff = DefaultDerivationMaxBackoff
+	}
+	return &retry.ExponentialStrategy{
+		Min:       0,
+		Max:       maxBackoff,
+		MaxJitter: 250 * time.Millisecond,
+	}
+}
+
+// escalateStepFailure returns true if the consecutive failed step attempts reached the maximum,
+// and the pipeline should be reset, rather than retried in place. Escalation is disabled if the maximum is 0.
+func escalateStepFailure(attempts int, maxAttempts uint64) bool {
+	return maxAttempts > 0 && uint64(attempts) >= maxAttempts
+}
@@ -0,0 +1,56 @@
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+func TestClassifyStepResult(t *testing.T) {
+	rpcErr := errors.New("connection reset by peer")
+	tests := []struct {
+		err     error
+		outcome string
+	}{
+		{nil, StepOutcomeOK},
+		{io.EOF, StepOutcomeIdle},
+		{fmt.Errorf("engine stage failed: %w", derive.EngineELSyncing), StepOutcomeIdle},
+		{derive.NotEnoughData, StepOutcomeNotEnough},
+		{derive.NewTemporaryError(rpcErr), StepOutcomeTemporary},
+		{fmt.Errorf("engine stage failed: %w", derive.NewTemporaryError(rpcErr)), StepOutcomeTemporary},
+		{derive.NewResetError(rpcErr), StepOutcomeReset},
+		{fmt.Errorf("stage 2 failed resetting: %w", derive.NewResetError(rpcErr)), StepOutcomeReset},
+		{derive.NewCriticalError(rpcErr), StepOutcomeCritical},
+		{rpcErr, StepOutcomeUnclassified},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.outcome, classifyStepResult(test.err), "error: %v", test.err)
+	}
+}
+
+func TestStepBackoff(t *testing.T) {
+	const jitter = 250 * time.Millisecond
+	strategy := stepBackoff(3 * time.Second)
+	require.Less(t, strategy.Duration(0), time.Second+jitter)
+	for attempt := 1; attempt < 100; attempt++ {
+		require.LessOrEqual(t, strategy.Duration(attempt), 3*time.Second+jitter, "attempt %d", attempt)
+	}
+	require.GreaterOrEqual(t, strategy.Duration(10), 3*time.Second, "backoff reaches the maximum")
+
+	require.GreaterOrEqual(t, stepBackoff(0).Duration(100), DefaultDerivationMaxBackoff)
+	require.LessOrEqual(t, stepBackoff(0).Duration(100), DefaultDerivationMaxBackoff+jitter)
+}
+
+func TestEscalateStepFailure(t *testing.T) {
+	require.False(t, escalateStepFailure(1, 0))
+	require.False(t, escalateStepFailure(1000, 0), "escalation is disabled")
+	require.False(t, escalateStepFailure(2, 3))
+	require.True(t, escalateStepFailure(3, 3))
+	require.True(t, escalateStepFailure(4, 3))
+}
@@ -0,0 +1,68 @@
+package driver
+
+import (
+	gosync "sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SyncStatusFeed delivers sync status updates to subscribers, without ever blocking the sender:
+// a slow subscriber misses the intermediate updates, and receives the latest update once it catches up.
+type SyncStatusFeed struct {
+	mu     gosync.Mutex
+	latest *eth.SyncStatus
+	subs   map[*SyncStatusSubscription]struct{}
+}
+
+// SyncStatusSubscription receives the sync status updates of a SyncStatusFeed.
+type SyncStatusSubscription struct {
+	feed *SyncStatusFeed
+	ch   chan *eth.SyncStatus
+}
+
+// Updates returns the channel of sync status updates. The channel is not closed when unsubscribing.
+func (s *SyncStatusSubscription) Updates() <-chan *eth.SyncStatus {
+	return s.ch
+}
+
+// Unsubscribe stops the delivery of updates. It is safe to call multiple times.
+func (s *SyncStatusSubscription) Unsubscribe() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	delete(s.feed.subs, s)
+}
+
+// Subscribe subscribes to the sync status updates, starting with the latest update, if any.
+func (f *SyncStatusFeed) Subscribe() *SyncStatusSubscription {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sub := &SyncStatusSubscription{feed: f, ch: make(chan *eth.SyncStatus, 1)}
+	if f.latest != nil {
+		sub.ch <- f.latest
+	}
+	if f.subs == nil {
+		f.subs = make(map[*SyncStatusSubscription]struct{})
+	}
+	f.subs[sub] = struct{}{}
+	return sub
+}
+
+// Send delivers the update to all subscribers, replacing any update they did not receive yet.
+func (f *SyncStatusFeed) Send(status *eth.SyncStatus) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.latest = status
+	for sub := range f.subs {
+		select {
+		case sub.ch <- status:
+			continue
+		default:
+		}
+		// drop the stale update; the subscriber may receive it concurrently, in which case there is space again
+		select {
+		case <-sub.ch:
+		default:
+		}
+		sub.ch <- status
+	}
+}
@@ -0,0 +1,36 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestSyncStatusFeed(t *testing.T) {
+	status := func(n uint64) *eth.SyncStatus {
+		return &eth.SyncStatus{UnsafeL2: eth.L2BlockRef{Number: n}}
+	}
+	var feed SyncStatusFeed
+	feed.Send(status(1))
+
+	sub := feed.Subscribe()
+	require.Equal(t, status(1), <-sub.Updates(), "starts with the latest status")
+	require.Empty(t, sub.Updates())
+
+	// a slow subscriber does not block the sender, and only receives the latest status
+	feed.Send(status(2))
+	feed.Send(status(3))
+	feed.Send(status(4))
+	require.Equal(t, status(4), <-sub.Updates())
+	require.Empty(t, sub.Updates())
+
+	other := feed.Subscribe()
+	require.Equal(t, status(4), <-other.Updates())
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+	feed.Send(status(5))
+	require.Empty(t, sub.Updates(), "no updates after unsubscribing")
+	require.Equal(t, status(5), <-other.Updates())
+}
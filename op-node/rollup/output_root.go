@@ -23,7 +23,7 @@ func ComputeL2OutputRoot(proofElements *bindings.TypesOutputRootProof) (eth.Byte
 		MessagePasserStorageRoot: proofElements.MessagePasserStorageRoot,
 		BlockHash:                proofElements.LatestBlockhash,
 	}
-	return eth.OutputRoot(&l2Output), nil
+	return l2Output.OutputRoot(), nil
 }
 
 func ComputeL2OutputRootV0(block eth.BlockInfo, storageRoot [32]byte) (eth.Bytes32, error) {
@@ -33,5 +33,5 @@ func ComputeL2OutputRootV0(block eth.BlockInfo, storageRoot [32]byte) (eth.Bytes
 		MessagePasserStorageRoot: storageRoot,
 		BlockHash:                block.Hash(),
 	}
-	return eth.OutputRoot(&l2Output), nil
+	return l2Output.OutputRoot(), nil
 }
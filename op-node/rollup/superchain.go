@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/params"
+	"golang.org/x/exp/slices"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -28,12 +29,22 @@ const (
 	chaosnet    = 888
 )
 
+// KnownOPStackChainIDs returns the sorted L2 chain IDs of all chains embedded from the superchain-registry.
+func KnownOPStackChainIDs() []uint64 {
+	ids := make([]uint64, 0, len(superchain.OPChains))
+	for id := range superchain.OPChains {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
 // LoadOPStackRollupConfig loads the rollup configuration of the requested chain ID from the superchain-registry.
 // Some chains may require a SystemConfigProvider to retrieve any values not part of the registry.
 func LoadOPStackRollupConfig(chainID uint64) (*Config, error) {
 	chConfig, ok := superchain.OPChains[chainID]
 	if !ok {
-		return nil, fmt.Errorf("unknown chain ID: %d", chainID)
+		return nil, fmt.Errorf("unknown chain ID: %d, known chain IDs: %v", chainID, KnownOPStackChainIDs())
 	}
 
 	superChain, ok := superchain.Superchains[chConfig.Superchain]
@@ -0,0 +1,32 @@
+package rollup
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOPStackRollupConfig(t *testing.T) {
+	for _, chainID := range []uint64{opMainnet, opSepolia, baseMainnet, zoraMainnet} {
+		t.Run(fmt.Sprintf("chain %d", chainID), func(t *testing.T) {
+			cfg, err := LoadOPStackRollupConfig(chainID)
+			require.NoError(t, err)
+			require.Equal(t, chainID, cfg.L2ChainID.Uint64())
+			require.NoError(t, cfg.Check())
+		})
+	}
+}
+
+func TestLoadOPStackRollupConfigUnknown(t *testing.T) {
+	_, err := LoadOPStackRollupConfig(0xdeadbeef)
+	require.ErrorContains(t, err, "unknown chain ID: 3735928559")
+	require.ErrorContains(t, err, fmt.Sprintf("%v", KnownOPStackChainIDs()))
+}
+
+func TestKnownOPStackChainIDs(t *testing.T) {
+	ids := KnownOPStackChainIDs()
+	require.Contains(t, ids, uint64(opMainnet))
+	require.Contains(t, ids, uint64(baseMainnet))
+	require.IsIncreasing(t, ids)
+}
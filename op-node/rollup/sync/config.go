@@ -70,4 +70,9 @@ type Config struct {
 	// Note: We probably need to detect the condition that snap sync has not complete when we do a restart prior to running sync-start if we are doing
 	// snap sync with a genesis finalization data.
 	SkipSyncStartCheck bool `json:"skip_sync_start_check"`
+	// ELSyncDistance is the number of blocks an unsafe payload must be ahead of the safe head derived from L1,
+	// for the op-node to defer to the execution-layer sync in ELSync mode: the payload is then inserted
+	// without requiring the parent chain, and derivation waits until the engine validated the payload.
+	// Closer payloads are processed as in CLSync mode. If 0, all unsafe payloads are deferred to the execution-layer sync.
+	ELSyncDistance uint64 `json:"el_sync_distance"`
 }
@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -34,6 +35,12 @@ var (
 	ErrChainIDsSame                  = errors.New("L1 and L2 chain IDs must be different")
 	ErrL1ChainIDNotPositive          = errors.New("L1 chain ID must be non-zero and positive")
 	ErrL2ChainIDNotPositive          = errors.New("L2 chain ID must be non-zero and positive")
+	ErrInvalidGenesisSystemConfig    = errors.New("invalid genesis system config")
+	ErrBlockTimeNotL1Divisor         = errors.New("L2 block time must divide into the L1 block time")
+	ErrForkOrder                     = errors.New("network upgrades must activate in order")
+	ErrGenesisMismatch               = errors.New("rollup genesis does not match the chain")
+	ErrL2GenesisNotAvailable         = errors.New("L2 genesis block not available yet")
+	ErrL1ContractsMismatch           = errors.New("rollup config L1 addresses do not match the L1 chain")
 )
 
 type Genesis struct {
@@ -181,7 +188,49 @@ func (cfg *Config) CheckL1GenesisBlockHash(ctx context.Context, client L1Client)
 		return fmt.Errorf("failed to get L1 genesis blockhash: %w", err)
 	}
 	if l1GenesisBlockRef.Hash != cfg.Genesis.L1.Hash {
-		return fmt.Errorf("incorrect L1 genesis block hash %s, expected %s", l1GenesisBlockRef.Hash, cfg.Genesis.L1.Hash)
+		return fmt.Errorf("%w: L1 block %d has hash %s, expected %s",
+			ErrGenesisMismatch, cfg.Genesis.L1.Number, l1GenesisBlockRef.Hash, cfg.Genesis.L1.Hash)
+	}
+	return nil
+}
+
+type L1ContractsClient interface {
+	CodeAt(ctx context.Context, address common.Address, blockTag string) ([]byte, error)
+}
+
+// CheckL1Contracts sanity-checks the configured L1 addresses against the latest L1 state:
+// the deposit contract and the SystemConfig contract (if configured) must have code,
+// and the batch inbox must not have code.
+// Only the immutable contract addresses are checked: the system config values, like the batcher,
+// may legitimately have been changed after genesis.
+// All failed checks are reported in the returned error, which wraps ErrL1ContractsMismatch.
+func (cfg *Config) CheckL1Contracts(ctx context.Context, client L1ContractsClient) error {
+	var problems []error
+	code, err := client.CodeAt(ctx, cfg.DepositContractAddress, "latest")
+	if err != nil {
+		return fmt.Errorf("failed to get code of deposit contract %s: %w", cfg.DepositContractAddress, err)
+	}
+	if len(code) == 0 {
+		problems = append(problems, fmt.Errorf("deposit contract %s has no code", cfg.DepositContractAddress))
+	}
+	code, err = client.CodeAt(ctx, cfg.BatchInboxAddress, "latest")
+	if err != nil {
+		return fmt.Errorf("failed to get code of batch inbox %s: %w", cfg.BatchInboxAddress, err)
+	}
+	if len(code) != 0 {
+		problems = append(problems, fmt.Errorf("batch inbox %s has code, expected an EOA-style inbox", cfg.BatchInboxAddress))
+	}
+	if cfg.L1SystemConfigAddress != (common.Address{}) {
+		code, err = client.CodeAt(ctx, cfg.L1SystemConfigAddress, "latest")
+		if err != nil {
+			return fmt.Errorf("failed to get code of system config %s: %w", cfg.L1SystemConfigAddress, err)
+		}
+		if len(code) == 0 {
+			problems = append(problems, fmt.Errorf("system config %s has no code", cfg.L1SystemConfigAddress))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %w", ErrL1ContractsMismatch, errors.Join(problems...))
 	}
 	return nil
 }
@@ -203,19 +252,37 @@ func (cfg *Config) CheckL2ChainID(ctx context.Context, client L2Client) error {
 	return nil
 }
 
-// CheckL2GenesisBlockHash checks that the configured L2 genesis block hash is valid for the given client.
+// CheckL2GenesisBlockHash checks that the configured L2 genesis block hash and time are valid for the given client.
+// If the client does not have the genesis block yet, e.g. an empty or syncing engine,
+// the returned error wraps ErrL2GenesisNotAvailable rather than ErrGenesisMismatch.
 func (cfg *Config) CheckL2GenesisBlockHash(ctx context.Context, client L2Client) error {
 	l2GenesisBlockRef, err := client.L2BlockRefByNumber(ctx, cfg.Genesis.L2.Number)
-	if err != nil {
+	if errors.Is(err, ethereum.NotFound) {
+		return fmt.Errorf("%w: L2 block %d: %w", ErrL2GenesisNotAvailable, cfg.Genesis.L2.Number, err)
+	} else if err != nil {
 		return fmt.Errorf("failed to get L2 genesis blockhash: %w", err)
 	}
 	if l2GenesisBlockRef.Hash != cfg.Genesis.L2.Hash {
-		return fmt.Errorf("incorrect L2 genesis block hash %s, expected %s", l2GenesisBlockRef.Hash, cfg.Genesis.L2.Hash)
+		return fmt.Errorf("%w: L2 block %d has hash %s, expected %s",
+			ErrGenesisMismatch, cfg.Genesis.L2.Number, l2GenesisBlockRef.Hash, cfg.Genesis.L2.Hash)
+	}
+	if l2GenesisBlockRef.Time != cfg.Genesis.L2Time {
+		return fmt.Errorf("%w: L2 block %d has time %d, expected %d",
+			ErrGenesisMismatch, cfg.Genesis.L2.Number, l2GenesisBlockRef.Time, cfg.Genesis.L2Time)
 	}
 	return nil
 }
 
-// Check verifies that the given configuration makes sense
+// l1BlockTimes lists the block time of well-known L1 chains, to sanity-check the L2 block time against.
+var l1BlockTimes = map[uint64]uint64{
+	1:        12, // mainnet
+	5:        12, // goerli
+	17000:    12, // holesky
+	11155111: 12, // sepolia
+}
+
+// Check verifies that the given configuration makes sense.
+// The returned error wraps one of the Err* values of this package, and names the offending field and value.
 func (cfg *Config) Check() error {
 	if cfg.BlockTime == 0 {
 		return ErrBlockTimeZero
@@ -224,16 +291,16 @@ func (cfg *Config) Check() error {
 		return ErrMissingChannelTimeout
 	}
 	if cfg.SeqWindowSize < 2 {
-		return ErrInvalidSeqWindowSize
+		return fmt.Errorf("%w: seq_window_size is %d", ErrInvalidSeqWindowSize, cfg.SeqWindowSize)
 	}
 	if cfg.Genesis.L1.Hash == (common.Hash{}) {
-		return ErrMissingGenesisL1Hash
+		return fmt.Errorf("%w: genesis.l1.hash is zero (genesis.l1.number is %d)", ErrMissingGenesisL1Hash, cfg.Genesis.L1.Number)
 	}
 	if cfg.Genesis.L2.Hash == (common.Hash{}) {
-		return ErrMissingGenesisL2Hash
+		return fmt.Errorf("%w: genesis.l2.hash is zero (genesis.l2.number is %d)", ErrMissingGenesisL2Hash, cfg.Genesis.L2.Number)
 	}
 	if cfg.Genesis.L2.Hash == cfg.Genesis.L1.Hash {
-		return ErrGenesisHashesSame
+		return fmt.Errorf("%w: genesis.l1.hash and genesis.l2.hash are both %s", ErrGenesisHashesSame, cfg.Genesis.L1.Hash)
 	}
 	if cfg.Genesis.L2Time == 0 {
 		return ErrMissingGenesisL2Time
@@ -250,6 +317,9 @@ func (cfg *Config) Check() error {
 	if cfg.Genesis.SystemConfig.GasLimit == 0 {
 		return ErrMissingGasLimit
 	}
+	if err := cfg.Genesis.SystemConfig.Validate(); err != nil {
+		return fmt.Errorf("%w: genesis.system_config: %w", ErrInvalidGenesisSystemConfig, err)
+	}
 	if cfg.BatchInboxAddress == (common.Address{}) {
 		return ErrMissingBatchInboxAddress
 	}
@@ -263,19 +333,59 @@ func (cfg *Config) Check() error {
 		return ErrMissingL2ChainID
 	}
 	if cfg.L1ChainID.Cmp(cfg.L2ChainID) == 0 {
-		return ErrChainIDsSame
+		return fmt.Errorf("%w: l1_chain_id and l2_chain_id are both %d", ErrChainIDsSame, cfg.L1ChainID)
 	}
 	if cfg.L1ChainID.Sign() < 1 {
-		return ErrL1ChainIDNotPositive
+		return fmt.Errorf("%w: l1_chain_id is %d", ErrL1ChainIDNotPositive, cfg.L1ChainID)
 	}
 	if cfg.L2ChainID.Sign() < 1 {
-		return ErrL2ChainIDNotPositive
+		return fmt.Errorf("%w: l2_chain_id is %d", ErrL2ChainIDNotPositive, cfg.L2ChainID)
+	}
+	if cfg.L1ChainID.IsUint64() {
+		if l1BlockTime, ok := l1BlockTimes[cfg.L1ChainID.Uint64()]; ok && l1BlockTime%cfg.BlockTime != 0 {
+			return fmt.Errorf("%w: block_time %d does not divide into the %d second block time of L1 chain %d",
+				ErrBlockTimeNotL1Divisor, cfg.BlockTime, l1BlockTime, cfg.L1ChainID)
+		}
+	}
+	if err := cfg.checkForkOrder(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkForkOrder verifies that every configured fork activates at or after the fork before it,
+// and that no fork is configured without the forks before it.
+func (cfg *Config) checkForkOrder() error {
+	forks := []struct {
+		name string
+		time *uint64
+	}{
+		{"regolith_time", cfg.RegolithTime},
+		{"canyon_time", cfg.CanyonTime},
+		{"delta_time", cfg.DeltaTime},
+		{"eclipse_time", cfg.EclipseTime},
+		{"fjord_time", cfg.FjordTime},
+		{"interop_time", cfg.InteropTime},
+	}
+	for i := 1; i < len(forks); i++ {
+		prev, next := forks[i-1], forks[i]
+		if next.time == nil {
+			continue
+		}
+		if prev.time == nil {
+			return fmt.Errorf("%w: %s is set to %d, but prior fork %s is not set",
+				ErrForkOrder, next.name, *next.time, prev.name)
+		}
+		if *prev.time > *next.time {
+			return fmt.Errorf("%w: %s is set to %d, but prior fork %s is set to later time %d",
+				ErrForkOrder, next.name, *next.time, prev.name, *prev.time)
+		}
 	}
 	return nil
 }
 
 func (c *Config) L1Signer() types.Signer {
-	return types.NewLondonSigner(c.L1ChainID)
+	return types.NewCancunSigner(c.L1ChainID)
 }
 
 // IsRegolith returns true if the Regolith hardfork is active at or past the given timestamp.
@@ -308,6 +418,54 @@ func (c *Config) IsInterop(timestamp uint64) bool {
 	return c.InteropTime != nil && timestamp >= *c.InteropTime
 }
 
+// IsRegolithActivationBlock returns whether the block at the given timestamp is the first block
+// subject to the Regolith upgrade, i.e. the previous block was not yet subject to it.
+func (c *Config) IsRegolithActivationBlock(l2BlockTime uint64) bool {
+	return c.IsRegolith(l2BlockTime) &&
+		l2BlockTime >= c.BlockTime &&
+		!c.IsRegolith(l2BlockTime-c.BlockTime)
+}
+
+// IsCanyonActivationBlock returns whether the block at the given timestamp is the first block
+// subject to the Canyon upgrade, i.e. the previous block was not yet subject to it.
+func (c *Config) IsCanyonActivationBlock(l2BlockTime uint64) bool {
+	return c.IsCanyon(l2BlockTime) &&
+		l2BlockTime >= c.BlockTime &&
+		!c.IsCanyon(l2BlockTime-c.BlockTime)
+}
+
+// IsDeltaActivationBlock returns whether the block at the given timestamp is the first block
+// subject to the Delta upgrade, i.e. the previous block was not yet subject to it.
+func (c *Config) IsDeltaActivationBlock(l2BlockTime uint64) bool {
+	return c.IsDelta(l2BlockTime) &&
+		l2BlockTime >= c.BlockTime &&
+		!c.IsDelta(l2BlockTime-c.BlockTime)
+}
+
+// IsEclipseActivationBlock returns whether the block at the given timestamp is the first block
+// subject to the Eclipse upgrade, i.e. the previous block was not yet subject to it.
+func (c *Config) IsEclipseActivationBlock(l2BlockTime uint64) bool {
+	return c.IsEclipse(l2BlockTime) &&
+		l2BlockTime >= c.BlockTime &&
+		!c.IsEclipse(l2BlockTime-c.BlockTime)
+}
+
+// IsFjordActivationBlock returns whether the block at the given timestamp is the first block
+// subject to the Fjord upgrade, i.e. the previous block was not yet subject to it.
+func (c *Config) IsFjordActivationBlock(l2BlockTime uint64) bool {
+	return c.IsFjord(l2BlockTime) &&
+		l2BlockTime >= c.BlockTime &&
+		!c.IsFjord(l2BlockTime-c.BlockTime)
+}
+
+// IsInteropActivationBlock returns whether the block at the given timestamp is the first block
+// subject to the Interop upgrade, i.e. the previous block was not yet subject to it.
+func (c *Config) IsInteropActivationBlock(l2BlockTime uint64) bool {
+	return c.IsInterop(l2BlockTime) &&
+		l2BlockTime >= c.BlockTime &&
+		!c.IsInterop(l2BlockTime-c.BlockTime)
+}
+
 // Description outputs a banner describing the important parts of rollup configuration in a human-readable form.
 // Optionally provide a mapping of L2 chain IDs to network names to label the L2 chain with if not unknown.
 // The config should be config.Check()-ed before creating a description.
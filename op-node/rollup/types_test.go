@@ -3,6 +3,7 @@ package rollup
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -200,6 +202,8 @@ func TestRegolithActivation(t *testing.T) {
 type mockL2Client struct {
 	chainID *big.Int
 	Hash    common.Hash
+	Time    uint64
+	err     error
 }
 
 func (m *mockL2Client) ChainID(context.Context) (*big.Int, error) {
@@ -207,9 +211,13 @@ func (m *mockL2Client) ChainID(context.Context) (*big.Int, error) {
 }
 
 func (m *mockL2Client) L2BlockRefByNumber(ctx context.Context, number uint64) (eth.L2BlockRef, error) {
+	if m.err != nil {
+		return eth.L2BlockRef{}, m.err
+	}
 	return eth.L2BlockRef{
 		Hash:   m.Hash,
 		Number: 100,
+		Time:   m.Time,
 	}, nil
 }
 
@@ -218,7 +226,7 @@ func TestValidateL2Config(t *testing.T) {
 	config.L2ChainID = big.NewInt(100)
 	config.Genesis.L2.Number = 100
 	config.Genesis.L2.Hash = [32]byte{0x01}
-	mockClient := mockL2Client{chainID: big.NewInt(100), Hash: common.Hash{0x01}}
+	mockClient := mockL2Client{chainID: big.NewInt(100), Hash: common.Hash{0x01}, Time: config.Genesis.L2Time}
 	err := config.ValidateL2Config(context.TODO(), &mockClient)
 	assert.NoError(t, err)
 }
@@ -264,7 +272,7 @@ func TestCheckL2BlockRefByNumber(t *testing.T) {
 	config := randConfig()
 	config.Genesis.L2.Number = 100
 	config.Genesis.L2.Hash = [32]byte{0x01}
-	mockClient := mockL2Client{chainID: big.NewInt(100), Hash: common.Hash{0x01}}
+	mockClient := mockL2Client{chainID: big.NewInt(100), Hash: common.Hash{0x01}, Time: config.Genesis.L2Time}
 	err := config.CheckL2GenesisBlockHash(context.TODO(), &mockClient)
 	assert.NoError(t, err)
 	mockClient.Hash = common.Hash{0x02}
@@ -275,6 +283,32 @@ func TestCheckL2BlockRefByNumber(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestCheckL2GenesisMismatch(t *testing.T) {
+	config := randConfig()
+	config.Genesis.L2.Number = 100
+	config.Genesis.L2.Hash = [32]byte{0x01}
+
+	mockClient := mockL2Client{chainID: big.NewInt(100), Hash: common.Hash{0x02}, Time: config.Genesis.L2Time}
+	err := config.CheckL2GenesisBlockHash(context.TODO(), &mockClient)
+	assert.ErrorIs(t, err, ErrGenesisMismatch)
+	assert.ErrorContains(t, err, "L2 block 100 has hash "+common.Hash{0x02}.String()+", expected "+common.Hash{0x01}.String())
+
+	mockClient = mockL2Client{chainID: big.NewInt(100), Hash: common.Hash{0x01}, Time: config.Genesis.L2Time + 1}
+	err = config.CheckL2GenesisBlockHash(context.TODO(), &mockClient)
+	assert.ErrorIs(t, err, ErrGenesisMismatch)
+	assert.ErrorContains(t, err, "has time")
+
+	mockClient = mockL2Client{chainID: big.NewInt(100), err: ethereum.NotFound}
+	err = config.CheckL2GenesisBlockHash(context.TODO(), &mockClient)
+	assert.ErrorIs(t, err, ErrL2GenesisNotAvailable)
+	assert.NotErrorIs(t, err, ErrGenesisMismatch)
+
+	mockClient = mockL2Client{chainID: big.NewInt(100), err: errors.New("connection refused")}
+	err = config.CheckL2GenesisBlockHash(context.TODO(), &mockClient)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrL2GenesisNotAvailable)
+}
+
 func TestConfig_Check(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -386,17 +420,73 @@ func TestConfig_Check(t *testing.T) {
 			modifier:    func(cfg *Config) { cfg.L2ChainID = big.NewInt(0) },
 			expectedErr: ErrL2ChainIDNotPositive,
 		},
+		{
+			name:        "GasLimitTooLow",
+			modifier:    func(cfg *Config) { cfg.Genesis.SystemConfig.GasLimit = 1000 },
+			expectedErr: ErrInvalidGenesisSystemConfig,
+		},
+		{
+			name:        "BlockTimeNotL1Divisor",
+			modifier:    func(cfg *Config) { cfg.L1ChainID = big.NewInt(1); cfg.BlockTime = 5 },
+			expectedErr: ErrBlockTimeNotL1Divisor,
+		},
+		{
+			name:        "BlockTimeLargerThanL1",
+			modifier:    func(cfg *Config) { cfg.L1ChainID = big.NewInt(11155111); cfg.BlockTime = 24 },
+			expectedErr: ErrBlockTimeNotL1Divisor,
+		},
+		{
+			name: "ForkWithoutPriorFork",
+			modifier: func(cfg *Config) {
+				cfg.RegolithTime = nil
+				cfg.CanyonTime = new(uint64)
+			},
+			expectedErr: ErrForkOrder,
+		},
+		{
+			name: "ForkBeforePriorFork",
+			modifier: func(cfg *Config) {
+				canyon, delta := uint64(20), uint64(10)
+				cfg.RegolithTime = new(uint64)
+				cfg.CanyonTime = &canyon
+				cfg.DeltaTime = &delta
+			},
+			expectedErr: ErrForkOrder,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			cfg := randConfig()
 			test.modifier(cfg)
 			err := cfg.Check()
-			assert.Same(t, err, test.expectedErr)
+			assert.ErrorIs(t, err, test.expectedErr)
 		})
 	}
 }
 
+func TestConfig_CheckErrorMessages(t *testing.T) {
+	cfg := randConfig()
+	require.NoError(t, cfg.Check())
+
+	cfg.SeqWindowSize = 1
+	require.ErrorContains(t, cfg.Check(), "seq_window_size is 1")
+
+	cfg = randConfig()
+	cfg.L2ChainID = big.NewInt(0)
+	require.ErrorContains(t, cfg.Check(), "l2_chain_id is 0")
+
+	cfg = randConfig()
+	canyon, delta := uint64(20), uint64(10)
+	cfg.RegolithTime = new(uint64)
+	cfg.CanyonTime = &canyon
+	cfg.DeltaTime = &delta
+	require.ErrorContains(t, cfg.Check(), "delta_time is set to 10, but prior fork canyon_time is set to later time 20")
+
+	cfg = randConfig()
+	cfg.Genesis.SystemConfig.GasLimit = 1000
+	require.ErrorContains(t, cfg.Check(), "got 1000")
+}
+
 func TestTimestampForBlock(t *testing.T) {
 	config := randConfig()
 
@@ -447,3 +537,96 @@ func TestTimestampForBlock(t *testing.T) {
 	}
 
 }
+
+// TestForkActivations tests the activation conditions and activation-block helpers of every upgrade.
+func TestForkActivations(t *testing.T) {
+	type forkHelpers struct {
+		name            string
+		set             func(c *Config, ts *uint64)
+		isActive        func(c *Config, ts uint64) bool
+		isActivationBlk func(c *Config, ts uint64) bool
+	}
+	forks := []forkHelpers{
+		{"regolith", func(c *Config, ts *uint64) { c.RegolithTime = ts }, (*Config).IsRegolith, (*Config).IsRegolithActivationBlock},
+		{"canyon", func(c *Config, ts *uint64) { c.CanyonTime = ts }, (*Config).IsCanyon, (*Config).IsCanyonActivationBlock},
+		{"delta", func(c *Config, ts *uint64) { c.DeltaTime = ts }, (*Config).IsDelta, (*Config).IsDeltaActivationBlock},
+		{"eclipse", func(c *Config, ts *uint64) { c.EclipseTime = ts }, (*Config).IsEclipse, (*Config).IsEclipseActivationBlock},
+		{"fjord", func(c *Config, ts *uint64) { c.FjordTime = ts }, (*Config).IsFjord, (*Config).IsFjordActivationBlock},
+		{"interop", func(c *Config, ts *uint64) { c.InteropTime = ts }, (*Config).IsInterop, (*Config).IsInteropActivationBlock},
+	}
+	for _, fork := range forks {
+		fork := fork
+		t.Run(fork.name, func(t *testing.T) {
+			cfg := randConfig()
+			bt := cfg.BlockTime
+
+			fork.set(cfg, nil)
+			require.False(t, fork.isActive(cfg, 0), "never active if nil")
+			require.False(t, fork.isActive(cfg, 1_000_000))
+			require.False(t, fork.isActivationBlk(cfg, 1_000_000))
+
+			fork.set(cfg, new(uint64))
+			require.True(t, fork.isActive(cfg, 0), "active from genesis if 0")
+			require.True(t, fork.isActive(cfg, 1_000_000))
+			require.False(t, fork.isActivationBlk(cfg, 0), "genesis is not an activation block")
+			require.False(t, fork.isActivationBlk(cfg, bt))
+
+			ts := uint64(1000)
+			fork.set(cfg, &ts)
+			require.False(t, fork.isActive(cfg, ts-1))
+			require.True(t, fork.isActive(cfg, ts))
+			require.True(t, fork.isActive(cfg, ts+bt))
+			require.False(t, fork.isActivationBlk(cfg, ts-bt))
+			require.True(t, fork.isActivationBlk(cfg, ts), "first block at activation time")
+			require.False(t, fork.isActivationBlk(cfg, ts+bt))
+			// if the activation time is not aligned with the L2 block times,
+			// then the first block after the activation time is the activation block.
+			require.True(t, fork.isActivationBlk(cfg, ts+1))
+			require.False(t, fork.isActivationBlk(cfg, ts+1+bt))
+		})
+	}
+}
+
+type mockL1ContractsClient struct {
+	code map[common.Address][]byte
+}
+
+func (m *mockL1ContractsClient) CodeAt(ctx context.Context, address common.Address, blockTag string) ([]byte, error) {
+	return m.code[address], nil
+}
+
+func TestCheckL1Contracts(t *testing.T) {
+	config := randConfig()
+	config.L1SystemConfigAddress = common.Address{0x55}
+	valid := func() *mockL1ContractsClient {
+		return &mockL1ContractsClient{
+			code: map[common.Address][]byte{
+				config.DepositContractAddress: {0x60, 0x80},
+				config.L1SystemConfigAddress:  {0x60, 0x80},
+			},
+		}
+	}
+	require.NoError(t, config.CheckL1Contracts(context.Background(), valid()))
+
+	client := valid()
+	delete(client.code, config.DepositContractAddress)
+	err := config.CheckL1Contracts(context.Background(), client)
+	require.ErrorIs(t, err, ErrL1ContractsMismatch)
+	require.ErrorContains(t, err, "deposit contract")
+
+	client = valid()
+	client.code[config.BatchInboxAddress] = []byte{0x60}
+	err = config.CheckL1Contracts(context.Background(), client)
+	require.ErrorIs(t, err, ErrL1ContractsMismatch)
+	require.ErrorContains(t, err, "batch inbox")
+
+	client = valid()
+	delete(client.code, config.L1SystemConfigAddress)
+	err = config.CheckL1Contracts(context.Background(), client)
+	require.ErrorIs(t, err, ErrL1ContractsMismatch)
+	require.ErrorContains(t, err, "system config")
+
+	// without a system config address, only the deposit contract and batch inbox are checked
+	config.L1SystemConfigAddress = common.Address{}
+	require.NoError(t, config.CheckL1Contracts(context.Background(), client))
+}
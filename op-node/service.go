@@ -1,21 +1,20 @@
 package opnode
 
 import (
-	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-service/endpoint"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/flags"
@@ -46,19 +45,29 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 	driverConfig := NewDriverConfig(ctx)
 
-	p2pSignerSetup, err := p2pcli.LoadSignerSetup(ctx)
+	p2pSignerSetup, err := p2pcli.LoadSignerSetup(ctx, log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load p2p signer: %w", err)
 	}
 
+	p2pSequencerKeys, err := p2pcli.LoadSequencerKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load p2p sequencer keys: %w", err)
+	}
+
 	p2pConfig, err := p2pcli.NewConfig(ctx, rollupConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load p2p config: %w", err)
 	}
 
-	l1Endpoint := NewL1EndpointConfig(ctx)
+	endpoints := endpoint.ReadCLIConfig(ctx)
+	if err := endpoints.Check(); err != nil {
+		return nil, err
+	}
+
+	l1Endpoint := NewL1EndpointConfig(ctx, endpoints.L1())
 
-	l2Endpoint, err := NewL2EndpointConfig(ctx, log)
+	l2Endpoint, err := NewL2EndpointConfig(endpoints, log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load l2 endpoints info: %w", err)
 	}
@@ -68,6 +77,11 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		return nil, fmt.Errorf("failed to create the sync config: %w", err)
 	}
 
+	rpcRateLimits, err := oprpc.ParseRateLimits(ctx.StringSlice(flags.RPCRateLimits.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RPC rate limits: %w", err)
+	}
+
 	haltOption := ctx.String(flags.RollupHalt.Name)
 	if haltOption == "none" {
 		haltOption = ""
@@ -76,36 +90,48 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 	cfg := &node.Config{
 		L1:     l1Endpoint,
 		L2:     l2Endpoint,
+		L2Sync: NewL2SyncEndpointConfig(ctx),
+		Beacon: NewBeaconEndpointConfig(ctx),
 		Rollup: *rollupConfig,
 		Driver: *driverConfig,
 		RPC: node.RPCConfig{
 			ListenAddr:  ctx.String(flags.RPCListenAddr.Name),
 			ListenPort:  ctx.Int(flags.RPCListenPort.Name),
 			EnableAdmin: ctx.Bool(flags.RPCEnableAdmin.Name),
+			RateLimits:  rpcRateLimits,
 		},
 		Metrics: node.MetricsConfig{
 			Enabled:    ctx.Bool(flags.MetricsEnabledFlag.Name),
 			ListenAddr: ctx.String(flags.MetricsAddrFlag.Name),
 			ListenPort: ctx.Int(flags.MetricsPortFlag.Name),
 		},
-		Pprof: oppprof.CLIConfig{
-			Enabled:    ctx.Bool(flags.PprofEnabledFlag.Name),
-			ListenAddr: ctx.String(flags.PprofAddrFlag.Name),
-			ListenPort: ctx.Int(flags.PprofPortFlag.Name),
-		},
+		Pprof:                       oppprof.ReadCLIConfig(ctx),
 		P2P:                         p2pConfig,
 		P2PSigner:                   p2pSignerSetup,
+		P2PSequencerKeys:            p2pSequencerKeys,
 		L1EpochPollInterval:         ctx.Duration(flags.L1EpochPollIntervalFlag.Name),
 		RuntimeConfigReloadInterval: ctx.Duration(flags.RuntimeConfigReloadIntervalFlag.Name),
 		Heartbeat: node.HeartbeatConfig{
 			Enabled: ctx.Bool(flags.HeartbeatEnabledFlag.Name),
 			Moniker: ctx.String(flags.HeartbeatMonikerFlag.Name),
 			URL:     ctx.String(flags.HeartbeatURLFlag.Name),
+			Redact:  ctx.StringSlice(flags.HeartbeatRedactFlag.Name),
 		},
 		ConfigPersistence: configPersistence,
 		Sync:              *syncConfig,
 		RollupHalt:        haltOption,
+		RollupHaltAction:  ctx.String(flags.RollupHaltAction.Name),
+		SkipGenesisCheck:  ctx.Bool(flags.RollupSkipGenesisCheck.Name),
+		L1ContractsCheck:  ctx.String(flags.RollupL1ContractsCheck.Name),
 		RethDBPath:        ctx.String(flags.L1RethDBPath.Name),
+		SafeDBPath:        ctx.String(flags.SafeDBPath.Name),
+		SafeDBRetention:   ctx.Uint64(flags.SafeDBRetention.Name),
+		Health: node.HealthConfig{
+			L1HeadMaxAge:       ctx.Duration(flags.HealthL1HeadMaxAge.Name),
+			UnsafeHeadMaxStall: ctx.Duration(flags.HealthUnsafeHeadMaxStall.Name),
+			SafeHeadMaxStall:   ctx.Duration(flags.HealthSafeHeadMaxStall.Name),
+		},
+		ShutdownTimeout: ctx.Duration(flags.ShutdownTimeout.Name),
 	}
 
 	if err := cfg.LoadPersisted(log); err != nil {
@@ -118,9 +144,9 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 	return cfg, nil
 }
 
-func NewL1EndpointConfig(ctx *cli.Context) *node.L1EndpointConfig {
+func NewL1EndpointConfig(ctx *cli.Context, l1 endpoint.RPC) *node.L1EndpointConfig {
 	return &node.L1EndpointConfig{
-		L1NodeAddr:       ctx.String(flags.L1NodeAddr.Name),
+		L1NodeAddr:       l1.URL,
 		L1TrustRPC:       ctx.Bool(flags.L1TrustRPC.Name),
 		L1RPCKind:        sources.RPCProviderKind(strings.ToLower(ctx.String(flags.L1RPCProviderKind.Name))),
 		RateLimit:        ctx.Float64(flags.L1RPCRateLimit.Name),
@@ -130,36 +156,34 @@ func NewL1EndpointConfig(ctx *cli.Context) *node.L1EndpointConfig {
 	}
 }
 
-func NewL2EndpointConfig(ctx *cli.Context, log log.Logger) (*node.L2EndpointConfig, error) {
-	l2Addr := ctx.String(flags.L2EngineAddr.Name)
-	fileName := ctx.String(flags.L2EngineJWTSecret.Name)
-	var secret [32]byte
-	fileName = strings.TrimSpace(fileName)
-	if fileName == "" {
-		return nil, fmt.Errorf("file-name of jwt secret is empty")
-	}
-	if data, err := os.ReadFile(fileName); err == nil {
-		jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
-		if len(jwtSecret) != 32 {
-			return nil, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", fileName)
-		}
-		copy(secret[:], jwtSecret)
-	} else {
-		log.Warn("Failed to read JWT secret from file, generating a new one now. Configure L2 geth with --authrpc.jwt-secret=" + fmt.Sprintf("%q", fileName))
-		if _, err := io.ReadFull(rand.Reader, secret[:]); err != nil {
-			return nil, fmt.Errorf("failed to generate jwt secret: %w", err)
-		}
-		if err := os.WriteFile(fileName, []byte(hexutil.Encode(secret[:])), 0600); err != nil {
-			return nil, err
-		}
+// NewBeaconEndpointConfig returns the L1 beacon endpoint config, or nil if no beacon address is configured.
+func NewBeaconEndpointConfig(ctx *cli.Context) node.L1BeaconEndpointSetup {
+	addr := ctx.String(flags.BeaconAddr.Name)
+	if addr == "" {
+		return nil
 	}
+	return &node.L1BeaconEndpointConfig{BeaconAddr: addr}
+}
 
+func NewL2EndpointConfig(endpoints endpoint.CLIConfig, log log.Logger) (*node.L2EndpointConfig, error) {
+	l2, err := endpoints.L2Engine(log)
+	if err != nil {
+		return nil, err
+	}
 	return &node.L2EndpointConfig{
-		L2EngineAddr:      l2Addr,
-		L2EngineJWTSecret: secret,
+		L2EngineAddr:      l2.URL,
+		L2EngineJWTSecret: l2.JWTSecret,
 	}, nil
 }
 
+// NewL2SyncEndpointConfig returns a pointer to a L2SyncEndpointConfig struct
+func NewL2SyncEndpointConfig(ctx *cli.Context) *node.L2SyncEndpointConfig {
+	return &node.L2SyncEndpointConfig{
+		L2NodeAddr: ctx.String(flags.BackupL2UnsafeSyncRPC.Name),
+		TrustRPC:   ctx.Bool(flags.BackupL2UnsafeSyncRPCTrustRPC.Name),
+	}
+}
+
 func NewConfigPersistence(ctx *cli.Context) node.ConfigPersistence {
 	stateFile := ctx.String(flags.RPCAdminPersistence.Name)
 	if stateFile == "" {
@@ -175,6 +199,13 @@ func NewDriverConfig(ctx *cli.Context) *driver.Config {
 		SequencerEnabled:    ctx.Bool(flags.SequencerEnabledFlag.Name),
 		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
 		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+
+		SequencerAdmissionEndpoint: ctx.String(flags.SequencerAdmissionEndpointFlag.Name),
+		SequencerAdmissionTimeout:  ctx.Duration(flags.SequencerAdmissionTimeoutFlag.Name),
+		SequencerBuildBudget:       ctx.Duration(flags.SequencerBuildBudgetFlag.Name),
+
+		DerivationMaxBackoff:      ctx.Duration(flags.DerivationMaxBackoffFlag.Name),
+		DerivationMaxStepAttempts: ctx.Uint64(flags.DerivationMaxStepAttemptsFlag.Name),
 	}
 }
 
@@ -198,6 +229,14 @@ Conflicting configuration is deprecated, and will stop the op-node from starting
 		applyOverrides(ctx, rollupConfig)
 		return rollupConfig, nil
 	}
+	if ctx.IsSet(flags.L2ChainID.Name) && rollupConfigPath == "" {
+		rollupConfig, err := rollup.LoadOPStackRollupConfig(ctx.Uint64(flags.L2ChainID.Name))
+		if err != nil {
+			return nil, err
+		}
+		applyOverrides(ctx, rollupConfig)
+		return rollupConfig, nil
+	}
 
 	file, err := os.Open(rollupConfigPath)
 	if err != nil {
@@ -253,6 +292,7 @@ func NewSyncConfig(ctx *cli.Context, log log.Logger) (*sync.Config, error) {
 	cfg := &sync.Config{
 		SyncMode:           mode,
 		SkipSyncStartCheck: ctx.Bool(flags.SkipSyncStartCheck.Name),
+		ELSyncDistance:     ctx.Uint64(flags.ELSyncDistanceFlag.Name),
 	}
 	if ctx.Bool(flags.L2EngineSyncEnabled.Name) {
 		cfg.SyncMode = sync.ELSync
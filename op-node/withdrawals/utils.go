@@ -7,15 +7,17 @@ import (
 	"fmt"
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient/gethclient"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/crossdomain"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 var MessagePassedTopic = crypto.Keccak256Hash([]byte("MessagePassed(uint256,address,address,uint256,uint256,bytes,bytes32)"))
@@ -108,34 +110,86 @@ func ProveWithdrawalParameters(ctx context.Context, proofCl ProofClient, l2Recei
 	}, nil
 }
 
-// Standard ABI types copied from golang ABI tests
-var (
-	Uint256Type, _ = abi.NewType("uint256", "", nil)
-	BytesType, _   = abi.NewType("bytes", "", nil)
-	AddressType, _ = abi.NewType("address", "", nil)
-)
+// ProveWithdrawalParametersForOutput creates the withdrawal parameters and proof to prove a withdrawal on L1,
+// from a rollup node output that includes the proof of the storage slot of the withdrawal.
+// The output must match the output proposal at l2OutputIndex in the L2 Output Oracle.
+func ProveWithdrawalParametersForOutput(ev *bindings.L2ToL1MessagePasserMessagePassed, output *eth.OutputResponse, l2OutputIndex *big.Int) (ProvenWithdrawalParameters, error) {
+	withdrawalHash, err := WithdrawalHash(ev)
+	if err != nil {
+		return ProvenWithdrawalParameters{}, err
+	}
+	if withdrawalHash != ev.WithdrawalHash {
+		return ProvenWithdrawalParameters{}, errors.New("computed withdrawal hash incorrectly")
+	}
+	p := output.MessagePasserProof
+	if p == nil {
+		return ProvenWithdrawalParameters{}, errors.New("output does not include the message passer proof")
+	}
+	if p.StorageHash != output.WithdrawalStorageRoot {
+		return ProvenWithdrawalParameters{}, fmt.Errorf("proof storage hash %s does not match output withdrawal storage root %s", p.StorageHash, output.WithdrawalStorageRoot)
+	}
+	if err := p.Verify(output.StateRoot); err != nil {
+		return ProvenWithdrawalParameters{}, fmt.Errorf("invalid message passer proof: %w", err)
+	}
+	if len(p.StorageProof) != 1 {
+		return ProvenWithdrawalParameters{}, fmt.Errorf("output does not include the storage proof of withdrawal slot %s", StorageSlotOfWithdrawalHash(withdrawalHash))
+	}
+	outputRootProof := bindings.TypesOutputRootProof{
+		Version:                  output.Version,
+		StateRoot:                output.StateRoot,
+		MessagePasserStorageRoot: output.WithdrawalStorageRoot,
+		LatestBlockhash:          output.BlockRef.Hash,
+	}
+	args, err := crossdomain.BuildProveWithdrawalArgs(withdrawalFromEvent(ev), l2OutputIndex, outputRootProof, p.StorageProof[0])
+	if err != nil {
+		return ProvenWithdrawalParameters{}, err
+	}
+	return ProvenWithdrawalParameters{
+		Nonce:           ev.Nonce,
+		Sender:          ev.Sender,
+		Target:          ev.Target,
+		Value:           ev.Value,
+		GasLimit:        ev.GasLimit,
+		L2OutputIndex:   args.L2OutputIndex,
+		Data:            ev.Data,
+		OutputRootProof: args.OutputRootProof,
+		WithdrawalProof: args.WithdrawalProof,
+	}, nil
+}
+
+// Withdrawal returns the withdrawal transaction of the parameters.
+func (p *ProvenWithdrawalParameters) Withdrawal() *crossdomain.Withdrawal {
+	return crossdomain.NewWithdrawal(p.Nonce, &p.Sender, &p.Target, p.Value, p.GasLimit, p.Data)
+}
+
+// ProveArgs assembles the arguments of the proveWithdrawalTransaction function of the OptimismPortal,
+// verifying the withdrawal proof against the message passer storage root of the output root proof.
+func (p *ProvenWithdrawalParameters) ProveArgs() (crossdomain.ProveWithdrawalArgs, error) {
+	w := p.Withdrawal()
+	hash, err := crossdomain.HashWithdrawal(w)
+	if err != nil {
+		return crossdomain.ProveWithdrawalArgs{}, err
+	}
+	nodes := make([]hexutil.Bytes, len(p.WithdrawalProof))
+	for i, node := range p.WithdrawalProof {
+		nodes[i] = node
+	}
+	storageProof := eth.StorageProofEntry{
+		Key:   crossdomain.StorageSlotOfWithdrawalHash(hash),
+		Value: hexutil.Big(*common.Big1),
+		Proof: nodes,
+	}
+	return crossdomain.BuildProveWithdrawalArgs(w, p.L2OutputIndex, p.OutputRootProof, storageProof)
+}
+
+func withdrawalFromEvent(ev *bindings.L2ToL1MessagePasserMessagePassed) *crossdomain.Withdrawal {
+	return crossdomain.NewWithdrawal(ev.Nonce, &ev.Sender, &ev.Target, ev.Value, ev.GasLimit, ev.Data)
+}
 
 // WithdrawalHash computes the hash of the withdrawal that was stored in the L2toL1MessagePasser
 // contract state.
-// TODO:
-//   - I don't like having to use the ABI Generated struct
-//   - There should be a better way to run the ABI encoding
-//   - These needs to be fuzzed against the solidity
 func WithdrawalHash(ev *bindings.L2ToL1MessagePasserMessagePassed) (common.Hash, error) {
-	//  abi.encode(nonce, msg.sender, _target, msg.value, _gasLimit, _data)
-	args := abi.Arguments{
-		{Name: "nonce", Type: Uint256Type},
-		{Name: "sender", Type: AddressType},
-		{Name: "target", Type: AddressType},
-		{Name: "value", Type: Uint256Type},
-		{Name: "gasLimit", Type: Uint256Type},
-		{Name: "data", Type: BytesType},
-	}
-	enc, err := args.Pack(ev.Nonce, ev.Sender, ev.Target, ev.Value, ev.GasLimit, ev.Data)
-	if err != nil {
-		return common.Hash{}, fmt.Errorf("failed to pack for withdrawal hash: %w", err)
-	}
-	return crypto.Keccak256Hash(enc), nil
+	return crossdomain.HashWithdrawal(withdrawalFromEvent(ev))
 }
 
 // ParseMessagePassed parses MessagePassed events from
@@ -164,10 +218,5 @@ func ParseMessagePassed(receipt *types.Receipt) (*bindings.L2ToL1MessagePasserMe
 // StorageSlotOfWithdrawalHash determines the storage slot of the L2ToL1MessagePasser contract to look at
 // given a WithdrawalHash
 func StorageSlotOfWithdrawalHash(hash common.Hash) common.Hash {
-	// The withdrawals mapping is the 0th storage slot in the L2ToL1MessagePasser contract.
-	// To determine the storage slot, use keccak256(withdrawalHash ++ p)
-	// Where p is the 32 byte value of the storage slot and ++ is concatenation
-	buf := make([]byte, 64)
-	copy(buf, hash[:])
-	return crypto.Keccak256Hash(buf)
+	return crossdomain.StorageSlotOfWithdrawalHash(hash)
 }
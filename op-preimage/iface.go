@@ -34,6 +34,10 @@ const (
 	LocalKeyType KeyType = 1
 	// Keccak256KeyType is for keccak256 pre-images, for any global shared pre-images.
 	Keccak256KeyType KeyType = 2
+	// BlobKeyType is for the field elements of L1 blobs, see BlobFieldElementKey.
+	BlobKeyType KeyType = 3
+	// PrecompileKeyType is for the results of precompile calls computed by the host, see PrecompileKey.
+	PrecompileKeyType KeyType = 4
 )
 
 // LocalIndexKey is a key local to the program, indexing a special program input.
@@ -62,6 +66,50 @@ func (k Keccak256Key) TerminalString() string {
 	return "0x" + hex.EncodeToString(k[:])
 }
 
+// BlobKey wraps the hash that identifies a field element of a blob to use it as a typed pre-image key.
+type BlobKey [32]byte
+
+// BlobFieldElementKey returns the key of the field element with the given index of the blob with the given
+// versioned hash: the keccak256 hash of the versioned hash and the index as a 32-byte big-endian number.
+func BlobFieldElementKey(versionedHash [32]byte, index uint64) BlobKey {
+	var data [64]byte
+	copy(data[:32], versionedHash[:])
+	binary.BigEndian.PutUint64(data[56:], index)
+	return Keccak256(data[:])
+}
+
+func (k BlobKey) PreimageKey() (out [32]byte) {
+	out = k                    // copy the hash
+	out[0] = byte(BlobKeyType) // apply prefix
+	return
+}
+
+func (k BlobKey) String() string {
+	return "0x" + hex.EncodeToString(k[:])
+}
+
+func (k BlobKey) TerminalString() string {
+	return "0x" + hex.EncodeToString(k[:])
+}
+
+// PrecompileKey wraps the keccak256 hash of the address of a precompile and the input of a call to it,
+// to use it as a typed pre-image key. The pre-image is the status byte of the call, followed by its output.
+type PrecompileKey [32]byte
+
+func (k PrecompileKey) PreimageKey() (out [32]byte) {
+	out = k                          // copy the hash
+	out[0] = byte(PrecompileKeyType) // apply prefix
+	return
+}
+
+func (k PrecompileKey) String() string {
+	return "0x" + hex.EncodeToString(k[:])
+}
+
+func (k PrecompileKey) TerminalString() string {
+	return "0x" + hex.EncodeToString(k[:])
+}
+
 // Hint is an interface to enable any program type to function as a hint,
 // when passed to the Hinter interface, returning a string representation
 // of what data the host should prepare pre-images for.
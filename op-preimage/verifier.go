@@ -28,6 +28,19 @@ func WithVerification(source PreimageGetter) PreimageGetter {
 				return nil, fmt.Errorf("%w for key %v, hash: %v data: %x", ErrIncorrectData, key, hash, data)
 			}
 			return data, nil
+		case BlobKeyType:
+			// A field element cannot be verified without the rest of its blob,
+			// the blob is verified against its versioned hash when it is fetched.
+			if len(data) != 32 {
+				return nil, fmt.Errorf("%w for key %v: field element of %d bytes", ErrIncorrectData, key, len(data))
+			}
+			return data, nil
+		case PrecompileKeyType:
+			// The key only commits to the call, the result is computed by the host when the call is hinted.
+			if len(data) == 0 {
+				return nil, fmt.Errorf("%w for key %v: precompile result without status", ErrIncorrectData, key)
+			}
+			return data, nil
 		default:
 			return nil, fmt.Errorf("%w: %v", ErrUnsupportedKeyType, key[0])
 		}
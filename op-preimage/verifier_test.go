@@ -10,6 +10,7 @@ import (
 func TestWithVerification(t *testing.T) {
 	validData := []byte{1, 2, 3, 4, 5, 6}
 	keccak256Key := Keccak256Key(Keccak256(validData))
+	validFieldElement := [32]byte{0xaa}
 	anError := errors.New("boom")
 
 	tests := []struct {
@@ -51,6 +52,30 @@ func TestWithVerification(t *testing.T) {
 			data:        []byte{},
 			expectedErr: ErrIncorrectData,
 		},
+		{
+			name:         "Blob Valid",
+			key:          BlobFieldElementKey([32]byte{0x01}, 7),
+			data:         validFieldElement[:],
+			expectedData: validFieldElement[:],
+		},
+		{
+			name:        "Blob InvalidLength",
+			key:         BlobFieldElementKey([32]byte{0x01}, 7),
+			data:        []byte{1, 2, 3},
+			expectedErr: ErrIncorrectData,
+		},
+		{
+			name:         "Precompile Valid",
+			key:          PrecompileKey(Keccak256([]byte{0x01})),
+			data:         []byte{1, 0xaa},
+			expectedData: []byte{1, 0xaa},
+		},
+		{
+			name:        "Precompile NoStatus",
+			key:         PrecompileKey(Keccak256([]byte{0x01})),
+			data:        []byte{},
+			expectedErr: ErrIncorrectData,
+		},
 		{
 			name:        "UnknownKey",
 			key:         invalidKey([32]byte{0xaa}),
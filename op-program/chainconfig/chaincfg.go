@@ -20,13 +20,15 @@ func init() {
 	OPGoerliChainConfig = mustLoadConfig(420)
 	OPSepoliaChainConfig = mustLoadConfig(11155420)
 	OPMainnetChainConfig = mustLoadConfig(10)
+	// the map is populated after the configs are loaded, as it would otherwise be initialized with nil configs
+	L2ChainConfigsByChainID = map[uint64]*params.ChainConfig{
+		420:      OPGoerliChainConfig,
+		11155420: OPSepoliaChainConfig,
+		10:       OPMainnetChainConfig,
+	}
 }
 
-var L2ChainConfigsByChainID = map[uint64]*params.ChainConfig{
-	420:      OPGoerliChainConfig,
-	11155420: OPSepoliaChainConfig,
-	10:       OPMainnetChainConfig,
-}
+var L2ChainConfigsByChainID map[uint64]*params.ChainConfig
 
 func RollupConfigByChainID(chainID uint64) (*rollup.Config, error) {
 	config, err := rollup.LoadOPStackRollupConfig(chainID)
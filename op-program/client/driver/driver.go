@@ -16,6 +16,8 @@ import (
 
 var (
 	ErrClaimNotValid = errors.New("invalid claim")
+	// ErrDerivationIncomplete is returned when the claimed block could not be derived with the provided data.
+	ErrDerivationIncomplete = errors.New("derivation incomplete")
 )
 
 type Derivation interface {
@@ -35,8 +37,8 @@ type Driver struct {
 	targetBlockNum uint64
 }
 
-func NewDriver(logger log.Logger, cfg *rollup.Config, l1Source derive.L1Fetcher, l2Source L2Source, targetBlockNum uint64) *Driver {
-	pipeline := derive.NewDerivationPipeline(logger, cfg, l1Source, l2Source, metrics.NoopMetrics, &sync.Config{})
+func NewDriver(logger log.Logger, cfg *rollup.Config, l1Source derive.L1Fetcher, l1BlobsSource derive.L1BlobsFetcher, l2Source L2Source, targetBlockNum uint64) *Driver {
+	pipeline := derive.NewDerivationPipeline(logger, cfg, l1Source, l1BlobsSource, l2Source, metrics.NoopMetrics, &sync.Config{}, derive.NoopSafeHeadListener)
 	pipeline.Reset()
 	return &Driver{
 		logger:         logger,
@@ -77,14 +79,20 @@ func (d *Driver) SafeHead() eth.L2BlockRef {
 	return d.pipeline.SafeL2Head()
 }
 
+// ValidateClaim checks the claimed output root against the output root of the claimed block.
+// Returns ErrDerivationIncomplete if the claimed block was not derived, and ErrClaimNotValid if the claim does not match.
 func (d *Driver) ValidateClaim(l2ClaimBlockNum uint64, claimedOutputRoot eth.Bytes32) error {
+	head := d.SafeHead()
+	if head.Number < l2ClaimBlockNum {
+		return fmt.Errorf("%w: derived to block %v, claim is for block %d", ErrDerivationIncomplete, head, l2ClaimBlockNum)
+	}
 	outputRoot, err := d.l2OutputRoot(l2ClaimBlockNum)
 	if err != nil {
 		return fmt.Errorf("calculate L2 output root: %w", err)
 	}
-	d.logger.Info("Validating claim", "head", d.SafeHead(), "output", outputRoot, "claim", claimedOutputRoot)
+	d.logger.Info("Validating claim", "head", head, "output", outputRoot, "claim", claimedOutputRoot)
 	if claimedOutputRoot != outputRoot {
-		return fmt.Errorf("%w: claim: %v actual: %v", ErrClaimNotValid, claimedOutputRoot, outputRoot)
+		return fmt.Errorf("%w: claim: %v actual: %v block: %d derived to: %v", ErrClaimNotValid, claimedOutputRoot, outputRoot, l2ClaimBlockNum, head)
 	}
 	return nil
 }
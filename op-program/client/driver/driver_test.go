@@ -87,6 +87,18 @@ func TestValidateClaim(t *testing.T) {
 		}
 		err := driver.ValidateClaim(uint64(0), eth.Bytes32{0x11})
 		require.ErrorIs(t, err, ErrClaimNotValid)
+		require.ErrorContains(t, err, eth.Bytes32{0x11}.String(), "should report the claim")
+		require.ErrorContains(t, err, eth.Bytes32{0x22}.String(), "should report the actual output root")
+	})
+
+	t.Run("Incomplete", func(t *testing.T) {
+		driver := createDriverWithNextBlock(t, io.EOF, 10)
+		driver.l2OutputRoot = func(_ uint64) (eth.Bytes32, error) {
+			return eth.Bytes32{}, errors.New("should not be called")
+		}
+		err := driver.ValidateClaim(uint64(11), eth.Bytes32{0x11})
+		require.ErrorIs(t, err, ErrDerivationIncomplete)
+		require.NotErrorIs(t, err, ErrClaimNotValid)
 	})
 
 	t.Run("Error", func(t *testing.T) {
@@ -0,0 +1,35 @@
+package l1
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// BlobFetcher implements derive.L1BlobsFetcher, retrieving the blobs from the pre-image oracle.
+type BlobFetcher struct {
+	logger log.Logger
+	oracle Oracle
+}
+
+var _ derive.L1BlobsFetcher = (*BlobFetcher)(nil)
+
+func NewBlobFetcher(logger log.Logger, oracle Oracle) *BlobFetcher {
+	return &BlobFetcher{
+		logger: logger,
+		oracle: oracle,
+	}
+}
+
+// GetBlobs fetches blobs that were confirmed in the given L1 block with the given indexed hashes.
+func (b *BlobFetcher) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	b.logger.Debug("Fetching blobs", "l1", ref, "count", len(hashes))
+	blobs := make([]*eth.Blob, len(hashes))
+	for i, hash := range hashes {
+		blobs[i] = b.oracle.GetBlob(ref, hash)
+	}
+	return blobs, nil
+}
@@ -12,23 +12,29 @@ import (
 // Cache size is quite high as retrieving data from the pre-image oracle can be quite expensive
 const cacheSize = 2000
 
+// blobCacheSize is lower, as blobs are large, and each blob is only read by the derivation once
+const blobCacheSize = 100
+
 // CachingOracle is an implementation of Oracle that delegates to another implementation, adding caching of all results
 type CachingOracle struct {
 	oracle Oracle
 	blocks *simplelru.LRU[common.Hash, eth.BlockInfo]
 	txs    *simplelru.LRU[common.Hash, types.Transactions]
 	rcpts  *simplelru.LRU[common.Hash, types.Receipts]
+	blobs  *simplelru.LRU[common.Hash, *eth.Blob]
 }
 
 func NewCachingOracle(oracle Oracle) *CachingOracle {
 	blockLRU, _ := simplelru.NewLRU[common.Hash, eth.BlockInfo](cacheSize, nil)
 	txsLRU, _ := simplelru.NewLRU[common.Hash, types.Transactions](cacheSize, nil)
 	rcptsLRU, _ := simplelru.NewLRU[common.Hash, types.Receipts](cacheSize, nil)
+	blobsLRU, _ := simplelru.NewLRU[common.Hash, *eth.Blob](blobCacheSize, nil)
 	return &CachingOracle{
 		oracle: oracle,
 		blocks: blockLRU,
 		txs:    txsLRU,
 		rcpts:  rcptsLRU,
+		blobs:  blobsLRU,
 	}
 }
 
@@ -63,3 +69,13 @@ func (o *CachingOracle) ReceiptsByBlockHash(blockHash common.Hash) (eth.BlockInf
 	o.rcpts.Add(blockHash, rcpts)
 	return block, rcpts
 }
+
+func (o *CachingOracle) GetBlob(ref eth.L1BlockRef, blobHash eth.IndexedBlobHash) *eth.Blob {
+	blob, ok := o.blobs.Get(blobHash.Hash)
+	if ok {
+		return blob
+	}
+	blob = o.oracle.GetBlob(ref, blobHash)
+	o.blobs.Add(blobHash.Hash, blob)
+	return blob
+}
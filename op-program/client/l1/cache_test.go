@@ -71,3 +71,20 @@ func TestCachingOracle_ReceiptsByBlockHash(t *testing.T) {
 	require.Equal(t, eth.BlockToInfo(block), actualBlock)
 	require.EqualValues(t, rcpts, actualRcpts)
 }
+
+func TestCachingOracle_GetBlob(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	stub := test.NewStubOracle(t)
+	oracle := NewCachingOracle(stub)
+	ref := testutils.RandomBlockRef(rng)
+	hash := eth.IndexedBlobHash{Index: 1, Hash: testutils.RandomHash(rng)}
+	blob := &eth.Blob{0x01, 0x02}
+
+	// Initial call retrieves from the stub
+	stub.Blobs[hash.Hash] = blob
+	require.Equal(t, blob, oracle.GetBlob(ref, hash))
+
+	// Later calls should retrieve from cache
+	delete(stub.Blobs, hash.Hash)
+	require.Equal(t, blob, oracle.GetBlob(ref, hash))
+}
@@ -1,7 +1,11 @@
 package l1
 
 import (
+	"encoding/binary"
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 )
@@ -10,6 +14,8 @@ const (
 	HintL1BlockHeader  = "l1-block-header"
 	HintL1Transactions = "l1-transactions"
 	HintL1Receipts     = "l1-receipts"
+	HintL1Blob         = "l1-blob"
+	HintL1Precompile   = "l1-precompile"
 )
 
 type BlockHeaderHint common.Hash
@@ -35,3 +41,66 @@ var _ preimage.Hint = ReceiptsHint{}
 func (l ReceiptsHint) Hint() string {
 	return HintL1Receipts + " " + (common.Hash)(l).String()
 }
+
+// BlobHint requests a field element of a blob, confirmed in the L1 block with the given timestamp.
+// The index of the blob in the block is included, as the beacon API retrieves blobs by index.
+type BlobHint struct {
+	Hash         common.Hash
+	Index        uint64
+	Time         uint64
+	FieldElement uint64
+}
+
+var _ preimage.Hint = BlobHint{}
+
+// blobHintLen is the length of the encoded data of a BlobHint.
+const blobHintLen = 32 + 8 + 8 + 8
+
+func (l BlobHint) Hint() string {
+	data := make([]byte, 0, blobHintLen)
+	data = append(data, l.Hash[:]...)
+	data = binary.BigEndian.AppendUint64(data, l.Index)
+	data = binary.BigEndian.AppendUint64(data, l.Time)
+	data = binary.BigEndian.AppendUint64(data, l.FieldElement)
+	return HintL1Blob + " " + hexutil.Encode(data)
+}
+
+// ParseBlobHint decodes the data of a BlobHint, as encoded after the hint type.
+func ParseBlobHint(data string) (BlobHint, error) {
+	b, err := hexutil.Decode(data)
+	if err != nil {
+		return BlobHint{}, fmt.Errorf("invalid blob hint data %q: %w", data, err)
+	}
+	if len(b) != blobHintLen {
+		return BlobHint{}, fmt.Errorf("invalid blob hint data length: %d, expected %d", len(b), blobHintLen)
+	}
+	return BlobHint{
+		Hash:         common.BytesToHash(b[:32]),
+		Index:        binary.BigEndian.Uint64(b[32:40]),
+		Time:         binary.BigEndian.Uint64(b[40:48]),
+		FieldElement: binary.BigEndian.Uint64(b[48:56]),
+	}, nil
+}
+
+// PrecompileHint requests the result of a precompile call, computed by the host.
+// The data is the address of the precompile, followed by the input of the call.
+type PrecompileHint []byte
+
+var _ preimage.Hint = PrecompileHint{}
+
+func (l PrecompileHint) Hint() string {
+	return HintL1Precompile + " " + hexutil.Encode(l)
+}
+
+// ParsePrecompileHint decodes the data of a PrecompileHint, as encoded after the hint type,
+// into the address of the precompile and the input of the call.
+func ParsePrecompileHint(data string) (common.Address, []byte, error) {
+	b, err := hexutil.Decode(data)
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("invalid precompile hint data %q: %w", data, err)
+	}
+	if len(b) < common.AddressLength {
+		return common.Address{}, nil, fmt.Errorf("invalid precompile hint data length: %d, expected at least %d", len(b), common.AddressLength)
+	}
+	return common.BytesToAddress(b[:common.AddressLength]), b[common.AddressLength:], nil
+}
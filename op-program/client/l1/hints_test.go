@@ -0,0 +1,44 @@
+package l1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobHint(t *testing.T) {
+	hint := BlobHint{Hash: common.Hash{0x01, 0x02}, Index: 3, Time: 1700000000, FieldElement: 4095}
+	hintType, hintData, found := strings.Cut(hint.Hint(), " ")
+	require.True(t, found)
+	require.Equal(t, HintL1Blob, hintType)
+
+	parsed, err := ParseBlobHint(hintData)
+	require.NoError(t, err)
+	require.Equal(t, hint, parsed)
+
+	_, err = ParseBlobHint("0x1234")
+	require.ErrorContains(t, err, "invalid blob hint data length")
+	_, err = ParseBlobHint("not hex")
+	require.ErrorContains(t, err, "invalid blob hint data")
+}
+
+func TestPrecompileHint(t *testing.T) {
+	address := common.BytesToAddress([]byte{0x01})
+	input := []byte{0xaa, 0xbb}
+	hint := PrecompileHint(append(address.Bytes(), input...))
+	hintType, hintData, found := strings.Cut(hint.Hint(), " ")
+	require.True(t, found)
+	require.Equal(t, HintL1Precompile, hintType)
+
+	parsedAddress, parsedInput, err := ParsePrecompileHint(hintData)
+	require.NoError(t, err)
+	require.Equal(t, address, parsedAddress)
+	require.Equal(t, input, parsedInput)
+
+	_, _, err = ParsePrecompileHint("0x1234")
+	require.ErrorContains(t, err, "invalid precompile hint data length")
+	_, _, err = ParsePrecompileHint("not hex")
+	require.ErrorContains(t, err, "invalid precompile hint data")
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
@@ -21,6 +23,9 @@ type Oracle interface {
 
 	// ReceiptsByBlockHash retrieves the receipts from the block with the given hash.
 	ReceiptsByBlockHash(blockHash common.Hash) (eth.BlockInfo, types.Receipts)
+
+	// GetBlob retrieves the blob with the given hash, confirmed in the given L1 block.
+	GetBlob(ref eth.L1BlockRef, blobHash eth.IndexedBlobHash) *eth.Blob
 }
 
 // PreimageOracle implements Oracle using by interfacing with the pure preimage.Oracle
@@ -86,3 +91,37 @@ func (p *PreimageOracle) ReceiptsByBlockHash(blockHash common.Hash) (eth.BlockIn
 
 	return info, receipts
 }
+
+// GetBlob retrieves the blob with the given hash, confirmed in the given L1 block, field element by field element.
+func (p *PreimageOracle) GetBlob(ref eth.L1BlockRef, blobHash eth.IndexedBlobHash) *eth.Blob {
+	var blob eth.Blob
+	for i := 0; i < params.BlobTxFieldElementsPerBlob; i++ {
+		p.hint.Hint(BlobHint{Hash: blobHash.Hash, Index: blobHash.Index, Time: ref.Time, FieldElement: uint64(i)})
+		fieldElement := p.oracle.Get(preimage.BlobFieldElementKey(blobHash.Hash, uint64(i)))
+		if len(fieldElement) != 32 {
+			panic(fmt.Errorf("invalid field element %d of blob %s: %d bytes", i, blobHash.Hash, len(fieldElement)))
+		}
+		copy(blob[i*32:(i+1)*32], fieldElement)
+	}
+	// the field elements are not verified by the oracle, only the commitment of the whole blob is
+	commitment, err := blob.ComputeKZGCommitment()
+	if err != nil {
+		panic(fmt.Errorf("cannot compute KZG commitment of blob %s: %w", blobHash.Hash, err))
+	}
+	if actual := eth.KZGToVersionedHash(commitment); actual != blobHash.Hash {
+		panic(fmt.Errorf("blob %s does not match its versioned hash, got %s", blobHash.Hash, actual))
+	}
+	return &blob
+}
+
+// Precompile retrieves the result of calling the precompile at the given address with the given input,
+// computed by the host. It returns the output of the call, and whether the call succeeded.
+func (p *PreimageOracle) Precompile(address common.Address, input []byte) ([]byte, bool) {
+	hintData := append(address.Bytes(), input...)
+	p.hint.Hint(PrecompileHint(hintData))
+	result := p.oracle.Get(preimage.PrecompileKey(crypto.Keccak256Hash(hintData)))
+	if len(result) == 0 {
+		panic(fmt.Errorf("missing status of precompile %s call", address))
+	}
+	return result[1:], result[0] == 1
+}
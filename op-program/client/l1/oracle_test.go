@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -103,3 +104,74 @@ func TestPreimageOracleBlockByHash(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlob(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var blob eth.Blob
+	for i := 0; i < params.BlobTxFieldElementsPerBlob; i++ {
+		// keep the field elements below the BLS modulus
+		rng.Read(blob[i*32+1 : (i+1)*32])
+	}
+	commitment, err := blob.ComputeKZGCommitment()
+	require.NoError(t, err)
+	blobHash := eth.IndexedBlobHash{Index: 2, Hash: eth.KZGToVersionedHash(commitment)}
+
+	newOracle := func(blob *eth.Blob) *PreimageOracle {
+		return NewPreimageOracle(preimage.OracleFn(func(key preimage.Key) []byte {
+			for i := 0; i < params.BlobTxFieldElementsPerBlob; i++ {
+				if key.PreimageKey() == preimage.BlobFieldElementKey(blobHash.Hash, uint64(i)).PreimageKey() {
+					return blob[i*32 : (i+1)*32]
+				}
+			}
+			t.Fatalf("unexpected key %v", key)
+			return nil
+		}), preimage.HinterFn(func(v preimage.Hint) {}))
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		require.Equal(t, &blob, newOracle(&blob).GetBlob(eth.L1BlockRef{}, blobHash))
+	})
+
+	t.Run("WrongFieldElement", func(t *testing.T) {
+		modified := blob
+		modified[100] ^= 0x01
+		require.PanicsWithError(t, fmt.Sprintf("blob %s does not match its versioned hash, got %s",
+			blobHash.Hash, versionedHash(t, &modified)), func() {
+			newOracle(&modified).GetBlob(eth.L1BlockRef{}, blobHash)
+		})
+	})
+
+	t.Run("NonCanonicalFieldElement", func(t *testing.T) {
+		modified := blob
+		modified[0] = 0xff
+		require.Panics(t, func() {
+			newOracle(&modified).GetBlob(eth.L1BlockRef{}, blobHash)
+		})
+	})
+}
+
+func TestPrecompile(t *testing.T) {
+	address := common.BytesToAddress([]byte{0x0a})
+	input := []byte{1, 2, 3}
+	hintData := append(address.Bytes(), input...)
+	key := preimage.PrecompileKey(crypto.Keccak256Hash(hintData)).PreimageKey()
+
+	var hints []string
+	oracle := NewPreimageOracle(preimage.OracleFn(func(k preimage.Key) []byte {
+		require.Equal(t, key, k.PreimageKey())
+		return []byte{1, 0xaa, 0xbb}
+	}), preimage.HinterFn(func(v preimage.Hint) {
+		hints = append(hints, v.Hint())
+	}))
+
+	output, ok := oracle.Precompile(address, input)
+	require.True(t, ok)
+	require.Equal(t, []byte{0xaa, 0xbb}, output)
+	require.Equal(t, []string{PrecompileHint(hintData).Hint()}, hints)
+}
+
+func versionedHash(t *testing.T, blob *eth.Blob) common.Hash {
+	commitment, err := blob.ComputeKZGCommitment()
+	require.NoError(t, err)
+	return eth.KZGToVersionedHash(commitment)
+}
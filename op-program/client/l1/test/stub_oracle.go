@@ -19,6 +19,9 @@ type StubOracle struct {
 
 	// Rcpts maps Block hash to receipts
 	Rcpts map[common.Hash]types.Receipts
+
+	// Blobs maps blob hash to blob
+	Blobs map[common.Hash]*eth.Blob
 }
 
 func NewStubOracle(t *testing.T) *StubOracle {
@@ -27,6 +30,7 @@ func NewStubOracle(t *testing.T) *StubOracle {
 		Blocks: make(map[common.Hash]eth.BlockInfo),
 		Txs:    make(map[common.Hash]types.Transactions),
 		Rcpts:  make(map[common.Hash]types.Receipts),
+		Blobs:  make(map[common.Hash]*eth.Blob),
 	}
 }
 func (o StubOracle) HeaderByBlockHash(blockHash common.Hash) eth.BlockInfo {
@@ -52,3 +56,11 @@ func (o StubOracle) ReceiptsByBlockHash(blockHash common.Hash) (eth.BlockInfo, t
 	}
 	return o.HeaderByBlockHash(blockHash), rcpts
 }
+
+func (o StubOracle) GetBlob(ref eth.L1BlockRef, blobHash eth.IndexedBlobHash) *eth.Blob {
+	blob, ok := o.Blobs[blobHash.Hash]
+	if !ok {
+		o.t.Fatalf("unknown blob %s", blobHash.Hash)
+	}
+	return blob
+}
@@ -50,31 +50,40 @@ func (o *OracleEngine) L2OutputRoot(l2ClaimBlockNum uint64) (eth.Bytes32, error)
 	return rollup.ComputeL2OutputRootV0(eth.HeaderBlockInfo(outBlock), withdrawalsTrie.Hash())
 }
 
-func (o *OracleEngine) GetPayload(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error) {
-	res, err := o.api.GetPayloadV2(ctx, payloadId)
-	if err != nil {
-		return nil, err
+func (o *OracleEngine) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error) {
+	if payloadInfo.ParentBeaconBlockRoot != nil {
+		return o.api.GetPayloadV3(ctx, payloadInfo.ID)
 	}
-	return res.ExecutionPayload, nil
+	return o.api.GetPayloadV2(ctx, payloadInfo.ID)
 }
 
 func (o *OracleEngine) ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	if attr != nil && attr.ParentBeaconBlockRoot != nil {
+		return o.api.ForkchoiceUpdatedV3(ctx, state, attr)
+	}
 	return o.api.ForkchoiceUpdatedV2(ctx, state, attr)
 }
 
-func (o *OracleEngine) NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error) {
+func (o *OracleEngine) NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error) {
+	if parentBeaconBlockRoot != nil {
+		hashes, err := payload.BlobVersionedHashes()
+		if err != nil {
+			return nil, err
+		}
+		return o.api.NewPayloadV3(ctx, payload, hashes, parentBeaconBlockRoot)
+	}
 	return o.api.NewPayloadV2(ctx, payload)
 }
 
-func (o *OracleEngine) PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayload, error) {
+func (o *OracleEngine) PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayloadEnvelope, error) {
 	block := o.backend.GetBlockByHash(hash)
 	if block == nil {
 		return nil, ErrNotFound
 	}
-	return eth.BlockAsPayload(block, o.rollupCfg.CanyonTime)
+	return eth.BlockAsPayloadEnv(block, o.rollupCfg.CanyonTime)
 }
 
-func (o *OracleEngine) PayloadByNumber(ctx context.Context, n uint64) (*eth.ExecutionPayload, error) {
+func (o *OracleEngine) PayloadByNumber(ctx context.Context, n uint64) (*eth.ExecutionPayloadEnvelope, error) {
 	hash := o.backend.GetCanonicalHash(n)
 	if hash == (common.Hash{}) {
 		return nil, ErrNotFound
@@ -121,9 +130,9 @@ func (o *OracleEngine) L2BlockRefByNumber(ctx context.Context, n uint64) (eth.L2
 }
 
 func (o *OracleEngine) SystemConfigByL2Hash(ctx context.Context, hash common.Hash) (eth.SystemConfig, error) {
-	payload, err := o.PayloadByHash(ctx, hash)
+	envelope, err := o.PayloadByHash(ctx, hash)
 	if err != nil {
 		return eth.SystemConfig{}, err
 	}
-	return derive.PayloadToSystemConfig(payload, o.rollupCfg)
+	return derive.PayloadToSystemConfig(envelope.ExecutionPayload, o.rollupCfg)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -49,6 +50,8 @@ func NewBlockProcessorFromPayloadAttributes(provider BlockDataProvider, parent c
 		Extra:      nil,
 		MixDigest:  common.Hash(params.PrevRandao),
 		Nonce:      types.EncodeNonce(0),
+
+		ParentBeaconRoot: params.ParentBeaconBlockRoot,
 	}
 	return NewBlockProcessorFromHeader(provider, header)
 }
@@ -71,6 +74,25 @@ func NewBlockProcessorFromHeader(provider BlockDataProvider, h *types.Header) (*
 	header.BaseFee = eip1559.CalcBaseFee(provider.Config(), parentHeader, header.Time)
 	header.GasUsed = 0
 	gasPool := new(core.GasPool).AddGas(header.GasLimit)
+	if provider.Config().IsCancun(header.Number, header.Time) {
+		if header.ParentBeaconRoot == nil {
+			return nil, errors.New("missing parent beacon block root post-cancun")
+		}
+		var excessBlobGas uint64
+		if provider.Config().IsCancun(parentHeader.Number, parentHeader.Time) {
+			excessBlobGas = eip4844.CalcExcessBlobGas(*parentHeader.ExcessBlobGas, *parentHeader.BlobGasUsed)
+		} else {
+			// For the first post-fork block, both parent.data_gas_used and parent.excess_data_gas are evaluated as 0
+			excessBlobGas = eip4844.CalcExcessBlobGas(0, 0)
+		}
+		header.BlobGasUsed = new(uint64)
+		header.ExcessBlobGas = &excessBlobGas
+
+		vmenv := vm.NewEVM(core.NewEVMBlockContext(header, provider, nil, provider.Config(), statedb), vm.TxContext{}, statedb, provider.Config(), vm.Config{})
+		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, vmenv, statedb)
+	} else if header.ParentBeaconRoot != nil {
+		return nil, errors.New("unexpected parent beacon block root pre-cancun")
+	}
 	return &BlockProcessor{
 		header:       header,
 		state:        statedb,
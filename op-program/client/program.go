@@ -20,22 +20,43 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
+const (
+	// ExitCodeClaimValid is the exit code of the client program when the claim matches the derived output root.
+	ExitCodeClaimValid = 0
+	// ExitCodeClaimInvalid is the exit code of the client program when the claimed block was derived,
+	// but the claim does not match its output root.
+	ExitCodeClaimInvalid = 1
+	// ExitCodeIncomplete is the exit code of the client program when the derivation could not be completed
+	// with the provided data, so the claim could not be verified.
+	ExitCodeIncomplete = 2
+)
+
+// ExitCode returns the exit code of the client program for the result of RunProgram.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeClaimValid
+	}
+	if errors.Is(err, cldr.ErrClaimNotValid) {
+		return ExitCodeClaimInvalid
+	}
+	return ExitCodeIncomplete
+}
+
 // Main executes the client program in a detached context and exits the current process.
 // The client runtime environment must be preset before calling this function.
 func Main(logger log.Logger) {
 	log.Info("Starting fault proof program client")
 	preimageOracle := CreatePreimageChannel()
 	preimageHinter := CreateHinterChannel()
-	if err := RunProgram(logger, preimageOracle, preimageHinter); errors.Is(err, cldr.ErrClaimNotValid) {
+	err := RunProgram(logger, preimageOracle, preimageHinter)
+	if errors.Is(err, cldr.ErrClaimNotValid) {
 		log.Error("Claim is invalid", "err", err)
-		os.Exit(1)
 	} else if err != nil {
 		log.Error("Program failed", "err", err)
-		os.Exit(2)
 	} else {
 		log.Info("Claim successfully verified")
-		os.Exit(0)
 	}
+	os.Exit(ExitCode(err))
 }
 
 // RunProgram executes the Program, while attached to an IO based pre-image oracle, to be served by a host.
@@ -64,6 +85,7 @@ func RunProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter
 // runDerivation executes the L2 state transition, given a minimal interface to retrieve data.
 func runDerivation(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainConfig, l1Head common.Hash, l2OutputRoot common.Hash, l2Claim common.Hash, l2ClaimBlockNum uint64, l1Oracle l1.Oracle, l2Oracle l2.Oracle) error {
 	l1Source := l1.NewOracleL1Client(logger, l1Oracle, l1Head)
+	l1BlobsSource := l1.NewBlobFetcher(logger, l1Oracle)
 	engineBackend, err := l2.NewOracleBackedL2Chain(logger, l2Oracle, l2Cfg, l2OutputRoot)
 	if err != nil {
 		return fmt.Errorf("failed to create oracle-backed L2 chain: %w", err)
@@ -71,7 +93,7 @@ func runDerivation(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainCon
 	l2Source := l2.NewOracleEngine(cfg, logger, engineBackend)
 
 	logger.Info("Starting derivation")
-	d := cldr.NewDriver(logger, cfg, l1Source, l2Source, l2ClaimBlockNum)
+	d := cldr.NewDriver(logger, cfg, l1Source, l1BlobsSource, l2Source, l2ClaimBlockNum)
 	for {
 		if err = d.Step(context.Background()); errors.Is(err, io.EOF) {
 			break
@@ -0,0 +1,18 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cldr "github.com/ethereum-optimism/optimism/op-program/client/driver"
+)
+
+func TestExitCode(t *testing.T) {
+	require.Equal(t, ExitCodeClaimValid, ExitCode(nil))
+	require.Equal(t, ExitCodeClaimInvalid, ExitCode(fmt.Errorf("%w: claim mismatch", cldr.ErrClaimNotValid)))
+	require.Equal(t, ExitCodeIncomplete, ExitCode(fmt.Errorf("%w: not derived", cldr.ErrDerivationIncomplete)))
+	require.Equal(t, ExitCodeIncomplete, ExitCode(errors.New("pipeline err")))
+}
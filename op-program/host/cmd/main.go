@@ -1,8 +1,11 @@
 package main
 
 import (
+	"errors"
 	"os"
 
+	"github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-program/client/driver"
 	"github.com/ethereum-optimism/optimism/op-program/host"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
@@ -23,8 +26,12 @@ var VersionWithMeta = opservice.FormatVersion(version.Version, GitCommit, GitDat
 
 func main() {
 	args := os.Args
-	if err := run(args, host.Main); err != nil {
-		log.Crit("Application failed", "err", err)
+	if err := run(args, host.Main); errors.Is(err, driver.ErrClaimNotValid) {
+		// The invalid claim is already logged by the host
+		os.Exit(client.ExitCodeClaimInvalid)
+	} else if err != nil {
+		log.Error("Application failed", "err", err)
+		os.Exit(client.ExitCodeIncomplete)
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
@@ -79,11 +80,30 @@ func TestNetwork(t *testing.T) {
 	})
 
 	t.Run("Required", func(t *testing.T) {
-		verifyArgsInvalid(t, "flag rollup.config or network is required", addRequiredArgsExcept("--network"))
+		verifyArgsInvalid(t, "flag rollup.config, network or l2.chainid is required", addRequiredArgsExcept("--network"))
 	})
 
-	t.Run("DisallowNetworkAndRollupConfig", func(t *testing.T) {
-		verifyArgsInvalid(t, "cannot specify both rollup.config and network", addRequiredArgs("--rollup.config=foo"))
+	t.Run("DisallowNetworkAndL2ChainID", func(t *testing.T) {
+		verifyArgsInvalid(t, "cannot specify both network and l2.chainid", addRequiredArgs("--l2.chainid=420"))
+	})
+
+	t.Run("RollupConfigOverridesNetwork", func(t *testing.T) {
+		rollupCfg := *chaincfg.Goerli
+		rollupCfg.BlockTime = 4
+		configFile := writeRollupConfig(t, &rollupCfg)
+		cfg := configForArgs(t, addRequiredArgs("--rollup.config", configFile))
+		require.Equal(t, rollupCfg, *cfg.Rollup)
+		require.Equal(t, chainconfig.OPGoerliChainConfig, cfg.L2ChainConfig)
+	})
+
+	t.Run("RollupConfigMismatch", func(t *testing.T) {
+		configFile := writeValidRollupConfig(t)
+		verifyArgsInvalid(t, config.ErrNetworkMismatch.Error(), append(replaceRequiredArg("--network", "op-sepolia"), "--rollup.config", configFile))
+	})
+
+	t.Run("L2GenesisMismatch", func(t *testing.T) {
+		genesisFile := writeValidGenesis(t)
+		verifyArgsInvalid(t, config.ErrNetworkMismatch.Error(), addRequiredArgs("--l2.genesis", genesisFile))
 	})
 
 	t.Run("RollupConfig", func(t *testing.T) {
@@ -106,12 +126,50 @@ func TestNetwork(t *testing.T) {
 	}
 }
 
+func TestL2ChainID(t *testing.T) {
+	t.Run("Unknown", func(t *testing.T) {
+		verifyArgsInvalid(t, "failed to get rollup config for chain ID 1234", addRequiredArgsExcept("--network", "--l2.chainid", "1234"))
+	})
+
+	for _, chainID := range []uint64{10, 420, 11155420} {
+		chainID := chainID
+		t.Run(strconv.FormatUint(chainID, 10), func(t *testing.T) {
+			cfg := configForArgs(t, addRequiredArgsExcept("--network", "--l2.chainid", strconv.FormatUint(chainID, 10)))
+			expected, err := chainconfig.RollupConfigByChainID(chainID)
+			require.NoError(t, err)
+			require.Equal(t, *expected, *cfg.Rollup)
+			require.NoError(t, cfg.Rollup.Check())
+			require.Equal(t, chainconfig.L2ChainConfigsByChainID[chainID], cfg.L2ChainConfig)
+			require.False(t, cfg.IsCustomChainConfig)
+		})
+	}
+}
+
 func TestDataDir(t *testing.T) {
 	expected := "/tmp/mainTestDataDir"
 	cfg := configForArgs(t, addRequiredArgs("--datadir", expected))
 	require.Equal(t, expected, cfg.DataDir)
 }
 
+func TestDataDirAlias(t *testing.T) {
+	expected := "/tmp/mainTestDataDir"
+	cfg := configForArgs(t, addRequiredArgs("--data.dir", expected))
+	require.Equal(t, expected, cfg.DataDir)
+}
+
+func TestFetchCache(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, "", cfg.FetchCacheDir)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		expected := "/tmp/mainTestFetchCache"
+		cfg := configForArgs(t, addRequiredArgs("--fetch.cache", expected))
+		require.Equal(t, expected, cfg.FetchCacheDir)
+	})
+}
+
 func TestL2(t *testing.T) {
 	expected := "https://example.com:8545"
 	cfg := configForArgs(t, addRequiredArgs("--l2", expected))
@@ -188,6 +246,12 @@ func TestL1(t *testing.T) {
 	require.Equal(t, expected, cfg.L1URL)
 }
 
+func TestL1Beacon(t *testing.T) {
+	expected := "https://example.com:5052"
+	cfg := configForArgs(t, addRequiredArgs("--l1.beacon", expected))
+	require.Equal(t, expected, cfg.L1BeaconURL)
+}
+
 func TestL1TrustRPC(t *testing.T) {
 	t.Run("DefaultFalse", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs())
@@ -290,6 +354,42 @@ func TestServerMode(t *testing.T) {
 	})
 }
 
+func TestServerSockets(t *testing.T) {
+	t.Run("DefaultInherited", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--server"))
+		require.Empty(t, cfg.ServerPreimageSocket)
+		require.Empty(t, cfg.ServerHintSocket)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--server", "--server.preimage-socket", "/tmp/preimage.sock", "--server.hint-socket", "/tmp/hint.sock"))
+		require.Equal(t, "/tmp/preimage.sock", cfg.ServerPreimageSocket)
+		require.Equal(t, "/tmp/hint.sock", cfg.ServerHintSocket)
+	})
+}
+
+func TestPrefetchOnly(t *testing.T) {
+	t.Run("DefaultFalse", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.False(t, cfg.PrefetchOnly)
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--prefetch-only"))
+		require.True(t, cfg.PrefetchOnly)
+	})
+}
+
+func TestOffline(t *testing.T) {
+	t.Run("DefaultFalse", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.False(t, cfg.Offline)
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--offline", "--l1", "http://localhost:8545", "--l2", "http://localhost:9545"))
+		require.True(t, cfg.Offline)
+		require.False(t, cfg.FetchingEnabled())
+	})
+}
+
 func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
 	_, _, err := runWithArgs(cliArgs)
 	require.ErrorContains(t, err, messageContains)
@@ -354,8 +454,12 @@ func writeValidGenesis(t *testing.T) string {
 }
 
 func writeValidRollupConfig(t *testing.T) string {
+	return writeRollupConfig(t, chaincfg.Goerli)
+}
+
+func writeRollupConfig(t *testing.T, rollupCfg *rollup.Config) string {
 	dir := t.TempDir()
-	j, err := json.Marshal(chaincfg.Goerli)
+	j, err := json.Marshal(rollupCfg)
 	require.NoError(t, err)
 	cfgFile := dir + "/rollup.json"
 	require.NoError(t, os.WriteFile(cfgFile, j, 0666))
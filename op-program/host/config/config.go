@@ -8,8 +8,8 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 
-	opnode "github.com/ethereum-optimism/optimism/op-node"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
@@ -30,6 +30,12 @@ var (
 	ErrInvalidL2ClaimBlock = errors.New("invalid l2 claim block number")
 	ErrDataDirRequired     = errors.New("datadir must be specified when in non-fetching mode")
 	ErrNoExecInServerMode  = errors.New("exec command must not be set when in server mode")
+	ErrSocketsInconsistent = errors.New("pre-image and hint sockets must be specified together or both omitted")
+	ErrSocketsNotInServer  = errors.New("sockets must only be set in server mode")
+	ErrNetworkMismatch     = errors.New("config file does not match the selected network")
+	ErrPrefetchNotFetching = errors.New("fetching must be enabled in prefetch-only mode")
+	ErrPrefetchNoDataDir   = errors.New("datadir must be specified in prefetch-only mode")
+	ErrPrefetchInServer    = errors.New("prefetch-only mode must not be set in server mode")
 )
 
 type Config struct {
@@ -37,12 +43,18 @@ type Config struct {
 	// DataDir is the directory to read/write pre-image data from/to.
 	//If not set, an in-memory key-value store is used and fetching data must be enabled
 	DataDir string
+	// FetchCacheDir is the directory to cache fetched pre-images in, across runs.
+	// If not set, fetched pre-images are not cached.
+	FetchCacheDir string
 
 	// L1Head is the block has of the L1 chain head block
-	L1Head     common.Hash
-	L1URL      string
-	L1TrustRPC bool
-	L1RPCKind  sources.RPCProviderKind
+	L1Head common.Hash
+	L1URL  string
+	// L1BeaconURL is the address of the L1 beacon node to fetch blobs from.
+	// If not set, blobs cannot be fetched.
+	L1BeaconURL string
+	L1TrustRPC  bool
+	L1RPCKind   sources.RPCProviderKind
 
 	// L2Head is the l2 block hash contained in the L2 Output referenced by the L2OutputRoot
 	// TODO(inphi): This can be made optional with hardcoded rollup configs and output oracle addresses by searching the oracle for the l2 output root
@@ -64,6 +76,17 @@ type Config struct {
 	// ServerMode indicates that the program should run in pre-image server mode and wait for requests.
 	// No client program is run.
 	ServerMode bool
+	// ServerPreimageSocket and ServerHintSocket are the paths of the unix sockets to serve on in server mode.
+	// If not set, the server uses the inherited file descriptors.
+	ServerPreimageSocket string
+	ServerHintSocket     string
+
+	// PrefetchOnly indicates that the program runs only to fetch the pre-images it requests into the DataDir,
+	// so that it can later run offline against the DataDir. The claim is not validated.
+	PrefetchOnly bool
+	// Offline indicates that no pre-image is fetched, even if the L1 and L2 URLs are set.
+	// A pre-image that is not in the DataDir is an error.
+	Offline bool
 
 	// IsCustomChainConfig indicates that the program uses a custom chain configuration
 	IsCustomChainConfig bool
@@ -103,11 +126,28 @@ func (c *Config) Check() error {
 	if c.ServerMode && c.ExecCmd != "" {
 		return ErrNoExecInServerMode
 	}
+	if (c.ServerPreimageSocket != "") != (c.ServerHintSocket != "") {
+		return ErrSocketsInconsistent
+	}
+	if !c.ServerMode && c.ServerPreimageSocket != "" {
+		return ErrSocketsNotInServer
+	}
+	if c.PrefetchOnly {
+		if !c.FetchingEnabled() {
+			return ErrPrefetchNotFetching
+		}
+		if c.DataDir == "" {
+			return ErrPrefetchNoDataDir
+		}
+		if c.ServerMode {
+			return ErrPrefetchInServer
+		}
+	}
 	return nil
 }
 
 func (c *Config) FetchingEnabled() bool {
-	return c.L1URL != "" && c.L2URL != ""
+	return !c.Offline && c.L1URL != "" && c.L2URL != ""
 }
 
 // NewConfig creates a Config with all optional values set to the CLI default value
@@ -139,7 +179,11 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	if err := flags.CheckRequired(ctx); err != nil {
 		return nil, err
 	}
-	rollupCfg, err := opnode.NewRollupConfig(log, ctx)
+	chainID, err := selectedChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rollupCfg, err := loadRollupConfig(ctx.String(flags.RollupConfig.Name), chainID)
 	if err != nil {
 		return nil, err
 	}
@@ -164,42 +208,80 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	var l2ChainConfig *params.ChainConfig
 	var isCustomConfig bool
 	if l2GenesisPath == "" {
-		networkName := ctx.String(flags.Network.Name)
-		ch := chaincfg.ChainByName(networkName)
-		if ch == nil {
-			return nil, fmt.Errorf("flag %s is required for network %s", flags.L2GenesisPath.Name, networkName)
-		}
-		cfg, err := params.LoadOPStackChainConfig(ch.ChainID)
+		l2ChainConfig, err = chainconfig.ChainConfigByChainID(chainID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load chain config for chain %d: %w", ch.ChainID, err)
+			return nil, fmt.Errorf("failed to load chain config for chain %d: %w", chainID, err)
 		}
-		l2ChainConfig = cfg
 	} else {
 		l2ChainConfig, err = loadChainConfigFromGenesis(l2GenesisPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid genesis: %w", err)
+		}
+		if chainID != 0 && (l2ChainConfig.ChainID == nil || l2ChainConfig.ChainID.Uint64() != chainID) {
+			return nil, fmt.Errorf("%w: l2 genesis is for chain %v, not %d", ErrNetworkMismatch, l2ChainConfig.ChainID, chainID)
+		}
 		isCustomConfig = true
 	}
-	if err != nil {
-		return nil, fmt.Errorf("invalid genesis: %w", err)
-	}
 	return &Config{
-		Rollup:              rollupCfg,
-		DataDir:             ctx.String(flags.DataDir.Name),
-		L2URL:               ctx.String(flags.L2NodeAddr.Name),
-		L2ChainConfig:       l2ChainConfig,
-		L2Head:              l2Head,
-		L2OutputRoot:        l2OutputRoot,
-		L2Claim:             l2Claim,
-		L2ClaimBlockNumber:  l2ClaimBlockNum,
-		L1Head:              l1Head,
-		L1URL:               ctx.String(flags.L1NodeAddr.Name),
-		L1TrustRPC:          ctx.Bool(flags.L1TrustRPC.Name),
-		L1RPCKind:           sources.RPCProviderKind(ctx.String(flags.L1RPCProviderKind.Name)),
-		ExecCmd:             ctx.String(flags.Exec.Name),
-		ServerMode:          ctx.Bool(flags.Server.Name),
-		IsCustomChainConfig: isCustomConfig,
+		Rollup:               rollupCfg,
+		DataDir:              ctx.String(flags.DataDir.Name),
+		FetchCacheDir:        ctx.String(flags.FetchCache.Name),
+		L2URL:                ctx.String(flags.L2NodeAddr.Name),
+		L2ChainConfig:        l2ChainConfig,
+		L2Head:               l2Head,
+		L2OutputRoot:         l2OutputRoot,
+		L2Claim:              l2Claim,
+		L2ClaimBlockNumber:   l2ClaimBlockNum,
+		L1Head:               l1Head,
+		L1URL:                ctx.String(flags.L1NodeAddr.Name),
+		L1BeaconURL:          ctx.String(flags.L1BeaconAddr.Name),
+		L1TrustRPC:           ctx.Bool(flags.L1TrustRPC.Name),
+		L1RPCKind:            sources.RPCProviderKind(ctx.String(flags.L1RPCProviderKind.Name)),
+		ExecCmd:              ctx.String(flags.Exec.Name),
+		ServerMode:           ctx.Bool(flags.Server.Name),
+		ServerPreimageSocket: ctx.String(flags.ServerPreimageSocket.Name),
+		ServerHintSocket:     ctx.String(flags.ServerHintSocket.Name),
+		PrefetchOnly:         ctx.Bool(flags.PrefetchOnly.Name),
+		Offline:              ctx.Bool(flags.Offline.Name),
+		IsCustomChainConfig:  isCustomConfig,
 	}, nil
 }
 
+// selectedChainID returns the L2 chain ID of the predefined network selected by the network or l2.chainid flags,
+// or 0 if no network is selected.
+func selectedChainID(ctx *cli.Context) (uint64, error) {
+	name := ctx.String(flags.Network.Name)
+	if name == "" {
+		return ctx.Uint64(flags.L2ChainID.Name), nil
+	}
+	ch := chaincfg.ChainByName(name)
+	if ch == nil {
+		return 0, fmt.Errorf("invalid network: %q", name)
+	}
+	return ch.ChainID, nil
+}
+
+// loadRollupConfig reads the rollup config from the file at path, or uses the embedded rollup config of the
+// selected chain if no path is set. A file for another chain than the selected one is an error.
+func loadRollupConfig(path string, chainID uint64) (*rollup.Config, error) {
+	if path == "" {
+		return chainconfig.RollupConfigByChainID(chainID)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rollup config: %w", err)
+	}
+	defer file.Close()
+	var rollupCfg rollup.Config
+	if err := json.NewDecoder(file).Decode(&rollupCfg); err != nil {
+		return nil, fmt.Errorf("failed to decode rollup config: %w", err)
+	}
+	if chainID != 0 && (rollupCfg.L2ChainID == nil || rollupCfg.L2ChainID.Uint64() != chainID) {
+		return nil, fmt.Errorf("%w: rollup config is for chain %v, not %d", ErrNetworkMismatch, rollupCfg.L2ChainID, chainID)
+	}
+	return &rollupCfg, nil
+}
+
 func loadChainConfigFromGenesis(path string) (*params.ChainConfig, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
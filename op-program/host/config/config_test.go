@@ -141,6 +141,14 @@ func TestFetchingEnabled(t *testing.T) {
 		cfg.L2URL = "https://example.com:5678"
 		require.True(t, cfg.FetchingEnabled(), "Should enable fetching when node URL supplied")
 	})
+
+	t.Run("FetchingNotEnabledWhenOffline", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L1URL = "https://example.com:1234"
+		cfg.L2URL = "https://example.com:5678"
+		cfg.Offline = true
+		require.False(t, cfg.FetchingEnabled(), "Should not enable fetching when offline")
+	})
 }
 
 func TestRequireDataDirInNonFetchingMode(t *testing.T) {
@@ -160,6 +168,72 @@ func TestRejectExecAndServerMode(t *testing.T) {
 	require.ErrorIs(t, err, ErrNoExecInServerMode)
 }
 
+func TestServerSocketsConsistency(t *testing.T) {
+	t.Run("BothSet", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.ServerMode = true
+		cfg.ServerPreimageSocket = "/tmp/preimage.sock"
+		cfg.ServerHintSocket = "/tmp/hint.sock"
+		require.NoError(t, cfg.Check())
+	})
+	t.Run("OnlyPreimage", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.ServerMode = true
+		cfg.ServerPreimageSocket = "/tmp/preimage.sock"
+		require.ErrorIs(t, cfg.Check(), ErrSocketsInconsistent)
+	})
+	t.Run("OnlyHint", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.ServerMode = true
+		cfg.ServerHintSocket = "/tmp/hint.sock"
+		require.ErrorIs(t, cfg.Check(), ErrSocketsInconsistent)
+	})
+	t.Run("NotInServerMode", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.ServerPreimageSocket = "/tmp/preimage.sock"
+		cfg.ServerHintSocket = "/tmp/hint.sock"
+		require.ErrorIs(t, cfg.Check(), ErrSocketsNotInServer)
+	})
+}
+
+func TestPrefetchOnly(t *testing.T) {
+	prefetchConfig := func() *Config {
+		cfg := validConfig()
+		cfg.L1URL = "https://example.com:1234"
+		cfg.L2URL = "https://example.com:5678"
+		cfg.DataDir = "/tmp/witness"
+		cfg.PrefetchOnly = true
+		return cfg
+	}
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, prefetchConfig().Check())
+	})
+	t.Run("RequireFetching", func(t *testing.T) {
+		cfg := prefetchConfig()
+		cfg.Offline = true
+		require.ErrorIs(t, cfg.Check(), ErrPrefetchNotFetching)
+	})
+	t.Run("RequireDataDir", func(t *testing.T) {
+		cfg := prefetchConfig()
+		cfg.DataDir = ""
+		require.ErrorIs(t, cfg.Check(), ErrPrefetchNoDataDir)
+	})
+	t.Run("RejectServerMode", func(t *testing.T) {
+		cfg := prefetchConfig()
+		cfg.ServerMode = true
+		require.ErrorIs(t, cfg.Check(), ErrPrefetchInServer)
+	})
+}
+
+func TestOfflineRequiresDataDir(t *testing.T) {
+	cfg := validConfig()
+	cfg.L1URL = "https://example.com:1234"
+	cfg.L2URL = "https://example.com:5678"
+	cfg.DataDir = ""
+	cfg.Offline = true
+	require.ErrorIs(t, cfg.Check(), ErrDataDirRequired)
+}
+
 func TestIsCustomChainConfig(t *testing.T) {
 	t.Run("nonCustom", func(t *testing.T) {
 		cfg := validConfig()
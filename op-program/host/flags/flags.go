@@ -30,11 +30,22 @@ var (
 		Usage:   fmt.Sprintf("Predefined network selection. Available networks: %s", strings.Join(chaincfg.AvailableNetworks(), ", ")),
 		EnvVars: prefixEnvVars("NETWORK"),
 	}
+	L2ChainID = &cli.Uint64Flag{
+		Name:    "l2.chainid",
+		Usage:   "L2 chain ID of a predefined network, to use the embedded rollup and L2 chain configs of. Alternative to the network flag",
+		EnvVars: prefixEnvVars("L2_CHAIN_ID"),
+	}
 	DataDir = &cli.StringFlag{
 		Name:    "datadir",
+		Aliases: []string{"data.dir"},
 		Usage:   "Directory to use for preimage data storage. Default uses in-memory storage",
 		EnvVars: prefixEnvVars("DATADIR"),
 	}
+	FetchCache = &cli.StringFlag{
+		Name:    "fetch.cache",
+		Usage:   "Directory to cache fetched pre-images in, so they are not fetched again by later runs. Default is no cache",
+		EnvVars: prefixEnvVars("FETCH_CACHE"),
+	}
 	L2NodeAddr = &cli.StringFlag{
 		Name:    "l2",
 		Usage:   "Address of L2 JSON-RPC endpoint to use (eth and debug namespace required)",
@@ -75,6 +86,11 @@ var (
 		Usage:   "Address of L1 JSON-RPC endpoint to use (eth namespace required)",
 		EnvVars: prefixEnvVars("L1_RPC"),
 	}
+	L1BeaconAddr = &cli.StringFlag{
+		Name:    "l1.beacon",
+		Usage:   "Address of L1 Beacon-node HTTP endpoint to use, to fetch the blobs of batcher transactions",
+		EnvVars: prefixEnvVars("L1_BEACON"),
+	}
 	L1TrustRPC = &cli.BoolFlag{
 		Name:    "l1.trustrpc",
 		Usage:   "Trust the L1 RPC, sync faster at risk of malicious/buggy RPC providing bad or inconsistent L1 data",
@@ -100,6 +116,26 @@ var (
 		Usage:   "Run in pre-image server mode without executing any client program.",
 		EnvVars: prefixEnvVars("SERVER"),
 	}
+	ServerPreimageSocket = &cli.StringFlag{
+		Name:    "server.preimage-socket",
+		Usage:   "Path of the unix socket to serve the pre-image oracle on in server mode. Default uses the inherited file descriptors",
+		EnvVars: prefixEnvVars("SERVER_PREIMAGE_SOCKET"),
+	}
+	ServerHintSocket = &cli.StringFlag{
+		Name:    "server.hint-socket",
+		Usage:   "Path of the unix socket to serve the hints on in server mode. Default uses the inherited file descriptors",
+		EnvVars: prefixEnvVars("SERVER_HINT_SOCKET"),
+	}
+	PrefetchOnly = &cli.BoolFlag{
+		Name:    "prefetch-only",
+		Usage:   "Run the program online only to fetch all the pre-images it requests into the datadir, without validating the claim.",
+		EnvVars: prefixEnvVars("PREFETCH_ONLY"),
+	}
+	Offline = &cli.BoolFlag{
+		Name:    "offline",
+		Usage:   "Never fetch pre-images, even if the L1 and L2 URLs are set. A pre-image missing in the datadir is an error.",
+		EnvVars: prefixEnvVars("OFFLINE"),
+	}
 )
 
 // Flags contains the list of configuration options available to the binary.
@@ -115,14 +151,21 @@ var requiredFlags = []cli.Flag{
 var programFlags = []cli.Flag{
 	RollupConfig,
 	Network,
+	L2ChainID,
 	DataDir,
+	FetchCache,
 	L2NodeAddr,
 	L2GenesisPath,
 	L1NodeAddr,
+	L1BeaconAddr,
 	L1TrustRPC,
 	L1RPCProviderKind,
 	Exec,
 	Server,
+	ServerPreimageSocket,
+	ServerHintSocket,
+	PrefetchOnly,
+	Offline,
 }
 
 func init() {
@@ -134,13 +177,15 @@ func init() {
 func CheckRequired(ctx *cli.Context) error {
 	rollupConfig := ctx.String(RollupConfig.Name)
 	network := ctx.String(Network.Name)
-	if rollupConfig == "" && network == "" {
-		return fmt.Errorf("flag %s or %s is required", RollupConfig.Name, Network.Name)
+	chainID := ctx.Uint64(L2ChainID.Name)
+	if network != "" && chainID != 0 {
+		return fmt.Errorf("cannot specify both %s and %s", Network.Name, L2ChainID.Name)
 	}
-	if rollupConfig != "" && network != "" {
-		return fmt.Errorf("cannot specify both %s and %s", RollupConfig.Name, Network.Name)
+	predefined := network != "" || chainID != 0
+	if rollupConfig == "" && !predefined {
+		return fmt.Errorf("flag %s, %s or %s is required", RollupConfig.Name, Network.Name, L2ChainID.Name)
 	}
-	if network == "" && ctx.String(L2GenesisPath.Name) == "" {
+	if !predefined && ctx.String(L2GenesisPath.Name) == "" {
 		return fmt.Errorf("flag %s is required for custom networks", L2GenesisPath.Name)
 	}
 	for _, flag := range requiredFlags {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"net"
 	"os"
 	"os/exec"
 
@@ -30,6 +31,24 @@ type L2Source struct {
 	*sources.DebugClient
 }
 
+// PrefetcherCreator creates the prefetcher of the pre-image server, which fetches the pre-images into the KV store.
+type PrefetcherCreator func(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *config.Config) (*prefetcher.Prefetcher, error)
+
+type programOpts struct {
+	prefetcher PrefetcherCreator
+}
+
+// ProgramOpt is an option of FaultProofProgram and PreimageServer.
+type ProgramOpt func(opts *programOpts)
+
+// WithPrefetcher creates the prefetcher with the given creator, instead of connecting to the L1 and L2 URLs
+// of the config. Pre-images are fetched even when the URLs are not set.
+func WithPrefetcher(creator PrefetcherCreator) ProgramOpt {
+	return func(opts *programOpts) {
+		opts.prefetcher = creator
+	}
+}
+
 func Main(logger log.Logger, cfg *config.Config) error {
 	if err := cfg.Check(); err != nil {
 		return fmt.Errorf("invalid config: %w", err)
@@ -39,23 +58,25 @@ func Main(logger log.Logger, cfg *config.Config) error {
 
 	ctx := context.Background()
 	if cfg.ServerMode {
-		preimageChan := cl.CreatePreimageChannel()
-		hinterChan := cl.CreateHinterChannel()
-		return PreimageServer(ctx, logger, cfg, preimageChan, hinterChan)
+		return RunPreimageServer(ctx, logger, cfg)
 	}
 
-	if err := FaultProofProgram(ctx, logger, cfg); errors.Is(err, driver.ErrClaimNotValid) {
-		log.Crit("Claim is invalid", "err", err)
-	} else if err != nil {
-		return err
-	} else {
-		log.Info("Claim successfully verified")
+	err := FaultProofProgram(ctx, logger, cfg)
+	if cfg.PrefetchOnly && (errors.Is(err, driver.ErrClaimNotValid) || errors.Is(err, driver.ErrDerivationIncomplete)) {
+		// the pre-images are fetched whatever the outcome of the claim is
+		logger.Info("Prefetched pre-images of the program", "result", err)
+		return nil
 	}
-	return nil
+	if errors.Is(err, driver.ErrClaimNotValid) {
+		logger.Error("Claim is invalid", "err", err)
+	} else if err == nil {
+		logger.Info("Claim successfully verified")
+	}
+	return err
 }
 
 // FaultProofProgram is the programmatic entry-point for the fault proof program
-func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Config) error {
+func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Config, opts ...ProgramOpt) error {
 	var (
 		serverErr chan error
 		pClientRW oppio.FileChannel
@@ -92,7 +113,7 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 	serverErr = make(chan error)
 	go func() {
 		defer close(serverErr)
-		serverErr <- PreimageServer(ctx, logger, cfg, pHostRW, hHostRW)
+		serverErr <- PreimageServer(ctx, logger, cfg, pHostRW, hHostRW, opts...)
 	}()
 
 	var cmd *exec.Cmd
@@ -111,6 +132,15 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 			return fmt.Errorf("program cmd failed to start: %w", err)
 		}
 		if err := cmd.Wait(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				switch exitErr.ExitCode() {
+				case cl.ExitCodeClaimInvalid:
+					return fmt.Errorf("%w: client program exited with code %d", driver.ErrClaimNotValid, exitErr.ExitCode())
+				case cl.ExitCodeIncomplete:
+					return fmt.Errorf("%w: client program exited with code %d", driver.ErrDerivationIncomplete, exitErr.ExitCode())
+				}
+			}
 			return fmt.Errorf("failed to wait for child program: %w", err)
 		}
 		logger.Debug("Client program completed successfully")
@@ -120,13 +150,63 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 	}
 }
 
+// RunPreimageServer runs the pre-image server of the server mode, on the unix sockets of the config,
+// or on the inherited file descriptors when no sockets are set. It returns once the client closes the channels.
+func RunPreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, opts ...ProgramOpt) error {
+	if cfg.ServerPreimageSocket == "" {
+		return PreimageServer(ctx, logger, cfg, cl.CreatePreimageChannel(), cl.CreateHinterChannel(), opts...)
+	}
+	logger.Info("Waiting for client to connect", "preimage", cfg.ServerPreimageSocket, "hint", cfg.ServerHintSocket)
+	acceptCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type accepted struct {
+		ch  io.ReadWriteCloser
+		err error
+	}
+	hintAccepted := make(chan accepted, 1)
+	go func() {
+		ch, err := oppio.AcceptUnixChannel(acceptCtx, cfg.ServerHintSocket)
+		hintAccepted <- accepted{ch, err}
+	}()
+	preimageChan, err := oppio.AcceptUnixChannel(acceptCtx, cfg.ServerPreimageSocket)
+	if err != nil {
+		cancel()
+		if hint := <-hintAccepted; hint.ch != nil {
+			_ = hint.ch.Close()
+		}
+		return fmt.Errorf("failed to accept pre-image client: %w", err)
+	}
+	hint := <-hintAccepted
+	if hint.err != nil {
+		_ = preimageChan.Close()
+		return fmt.Errorf("failed to accept hint client: %w", hint.err)
+	}
+	logger.Info("Client connected")
+	return PreimageServer(ctx, logger, cfg, preimageChan, hint.ch, opts...)
+}
+
 // PreimageServer reads hints and preimage requests from the provided channels and processes those requests.
 // This method will block until both the hinter and preimage handlers complete.
 // If either returns an error both handlers are stopped.
 // The supplied preimageChannel and hintChannel will be closed before this function returns.
-func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel oppio.FileChannel, hintChannel oppio.FileChannel) error {
+func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel io.ReadWriteCloser, hintChannel io.ReadWriteCloser, opts ...ProgramOpt) error {
+	var o programOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
+	createPrefetcher := o.prefetcher
+	if cfg.Offline {
+		createPrefetcher = nil
+	} else if createPrefetcher == nil && cfg.FetchingEnabled() {
+		createPrefetcher = makePrefetcher
+	}
+	if cfg.PrefetchOnly && createPrefetcher == nil {
+		return config.ErrPrefetchNotFetching
+	}
 	var serverDone chan error
 	var hinterDone chan error
+	var fetchCache *prefetcher.FetchCache
+	var recorder *kvstore.PreimageRecorder
 	defer func() {
 		preimageChannel.Close()
 		hintChannel.Close()
@@ -138,6 +218,12 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 			// Wait for hinter to complete
 			<-hinterDone
 		}
+		if fetchCache != nil {
+			logger.Info("Fetch cache usage", "hits", fetchCache.Hits(), "misses", fetchCache.Misses())
+		}
+		if recorder != nil {
+			logger.Info("Prefetched pre-images", "count", recorder.Count(), "bytes", recorder.Size(), "datadir", cfg.DataDir)
+		}
 	}()
 	logger.Info("Starting preimage server")
 	var kv kvstore.KV
@@ -146,18 +232,28 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 		kv = kvstore.NewMemKV()
 	} else {
 		logger.Info("Creating disk storage", "datadir", cfg.DataDir)
-		if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
-			return fmt.Errorf("creating datadir: %w", err)
+		diskKV, err := kvstore.OpenDiskKV(cfg.DataDir)
+		if err != nil {
+			return fmt.Errorf("opening datadir: %w", err)
 		}
-		kv = kvstore.NewDiskKV(cfg.DataDir)
+		kv = diskKV
 	}
 
 	var (
 		getPreimage kvstore.PreimageSource
 		hinter      preimage.HintHandler
 	)
-	if cfg.FetchingEnabled() {
-		prefetch, err := makePrefetcher(ctx, logger, kv, cfg)
+	if createPrefetcher != nil {
+		if cfg.FetchCacheDir != "" {
+			logger.Info("Using fetch cache", "dir", cfg.FetchCacheDir)
+			cacheKV, err := kvstore.OpenDiskKV(cfg.FetchCacheDir)
+			if err != nil {
+				return fmt.Errorf("opening fetch cache: %w", err)
+			}
+			fetchCache = prefetcher.NewFetchCache(kv, cacheKV)
+			kv = fetchCache
+		}
+		prefetch, err := createPrefetcher(ctx, logger, kv, cfg)
 		if err != nil {
 			return fmt.Errorf("failed to create prefetcher: %w", err)
 		}
@@ -165,13 +261,24 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 		hinter = prefetch.Hint
 	} else {
 		logger.Info("Using offline mode. All required pre-images must be pre-populated.")
-		getPreimage = kv.Get
+		getPreimage = func(key common.Hash) ([]byte, error) {
+			value, err := kv.Get(key)
+			if errors.Is(err, kvstore.ErrNotFound) {
+				return nil, fmt.Errorf("pre-image %s is missing in offline mode: %w", key, err)
+			}
+			return value, err
+		}
 		hinter = func(hint string) error {
 			logger.Debug("ignoring prefetch hint", "hint", hint)
 			return nil
 		}
 	}
 
+	if cfg.PrefetchOnly {
+		recorder = kvstore.NewPreimageRecorder(getPreimage)
+		getPreimage = recorder.Get
+	}
+
 	localPreimageSource := kvstore.NewLocalPreimageSource(cfg)
 	splitter := kvstore.NewPreimageSourceSplitter(localPreimageSource.Get, getPreimage)
 	preimageGetter := preimage.WithVerification(splitter.Get)
@@ -209,8 +316,13 @@ func makePrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L2 client: %w", err)
 	}
+	var l1BlobFetcher prefetcher.L1BlobSource
+	if cfg.L1BeaconURL != "" {
+		logger.Info("Using L1 beacon node", "l1.beacon", cfg.L1BeaconURL)
+		l1BlobFetcher = sources.NewL1BeaconClient(client.NewBasicHTTPClient(cfg.L1BeaconURL, logger))
+	}
 	l2DebugCl := &L2Source{L2Client: l2Cl, DebugClient: sources.NewDebugClient(l2RPC.CallContext)}
-	return prefetcher.NewPrefetcher(logger, l1Cl, l2DebugCl, kv), nil
+	return prefetcher.NewPrefetcher(logger, l1Cl, l1BlobFetcher, l2DebugCl, kv), nil
 }
 
 func routeHints(logger log.Logger, hHostRW io.ReadWriter, hinter preimage.HintHandler) chan error {
@@ -220,7 +332,7 @@ func routeHints(logger log.Logger, hHostRW io.ReadWriter, hinter preimage.HintHa
 		defer close(chErr)
 		for {
 			if err := hintReader.NextHint(hinter); err != nil {
-				if err == io.EOF || errors.Is(err, fs.ErrClosed) {
+				if err == io.EOF || errors.Is(err, fs.ErrClosed) || errors.Is(err, net.ErrClosed) {
 					logger.Debug("closing pre-image hint handler")
 					return
 				}
@@ -240,7 +352,7 @@ func launchOracleServer(logger log.Logger, pHostRW io.ReadWriteCloser, getter pr
 		defer close(chErr)
 		for {
 			if err := server.NextPreimageRequest(getter); err != nil {
-				if err == io.EOF || errors.Is(err, fs.ErrClosed) {
+				if err == io.EOF || errors.Is(err, fs.ErrClosed) || errors.Is(err, net.ErrClosed) {
 					logger.Debug("closing pre-image server")
 					return
 				}
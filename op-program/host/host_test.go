@@ -3,6 +3,10 @@ package host
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -10,12 +14,15 @@ import (
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-program/client/driver"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/prefetcher"
 	"github.com/ethereum-optimism/optimism/op-program/io"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
@@ -55,6 +62,61 @@ func TestServerMode(t *testing.T) {
 	require.ErrorIs(t, waitFor(result), kvstore.ErrNotFound)
 }
 
+func TestOfflineMode(t *testing.T) {
+	dir := t.TempDir()
+	kv, err := kvstore.OpenDiskKV(dir)
+	require.NoError(t, err)
+	data := []byte("pre-populated")
+	key := preimage.Keccak256Key(crypto.Keccak256Hash(data)).PreimageKey()
+	require.NoError(t, kv.Put(key, data))
+
+	cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	cfg.DataDir = dir
+	cfg.L1URL = "http://localhost:8545"
+	cfg.L2URL = "http://localhost:9545"
+	cfg.Offline = true
+
+	preimageServer, preimageClient, err := io.CreateBidirectionalChannel()
+	require.NoError(t, err)
+	defer preimageClient.Close()
+	hintServer, hintClient, err := io.CreateBidirectionalChannel()
+	require.NoError(t, err)
+	defer hintClient.Close()
+	logger := testlog.Logger(t, log.LvlTrace)
+	noFetching := WithPrefetcher(func(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *config.Config) (*prefetcher.Prefetcher, error) {
+		return nil, errors.New("should not create a prefetcher when offline")
+	})
+	result := make(chan error)
+	go func() {
+		result <- PreimageServer(context.Background(), logger, cfg, preimageServer, hintServer, noFetching)
+	}()
+
+	pClient := preimage.NewOracleClient(preimageClient)
+	require.Equal(t, data, pClient.Get(preimage.Keccak256Key(crypto.Keccak256Hash(data))))
+
+	// Should fail rather than fetch a missing pre-image
+	missing := preimage.Keccak256Key(common.Hash{0xaa}).PreimageKey()
+	_, err = preimageClient.Write(missing[:])
+	require.NoError(t, err)
+	err = waitFor(result)
+	require.ErrorIs(t, err, kvstore.ErrNotFound)
+	require.ErrorContains(t, err, "missing in offline mode")
+}
+
+func TestPrefetchOnlyRequiresFetching(t *testing.T) {
+	cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	cfg.DataDir = t.TempDir()
+	cfg.PrefetchOnly = true
+	preimageServer, preimageClient, err := io.CreateBidirectionalChannel()
+	require.NoError(t, err)
+	defer preimageClient.Close()
+	hintServer, hintClient, err := io.CreateBidirectionalChannel()
+	require.NoError(t, err)
+	defer hintClient.Close()
+	err = PreimageServer(context.Background(), testlog.Logger(t, log.LvlTrace), cfg, preimageServer, hintServer)
+	require.ErrorIs(t, err, config.ErrPrefetchNotFetching)
+}
+
 func waitFor(ch chan error) error {
 	timeout := time.After(30 * time.Second)
 	select {
@@ -64,3 +126,95 @@ func waitFor(ch chan error) error {
 		return errors.New("timed out")
 	}
 }
+
+func TestDetachedClientExitCodes(t *testing.T) {
+	tests := []struct {
+		exitCode int
+		expected error
+	}{
+		{exitCode: client.ExitCodeClaimValid},
+		{exitCode: client.ExitCodeClaimInvalid, expected: driver.ErrClaimNotValid},
+		{exitCode: client.ExitCodeIncomplete, expected: driver.ErrDerivationIncomplete},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("ExitCode%d", test.exitCode), func(t *testing.T) {
+			dir := t.TempDir()
+			execCmd := filepath.Join(dir, "client.sh")
+			require.NoError(t, os.WriteFile(execCmd, []byte(fmt.Sprintf("#!/bin/sh\nexit %d\n", test.exitCode)), 0755))
+
+			cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+			cfg.DataDir = dir
+			cfg.ExecCmd = execCmd
+			err := FaultProofProgram(context.Background(), testlog.Logger(t, log.LvlTrace), cfg)
+			if test.expected == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestServerModeUnixSockets(t *testing.T) {
+	dir := t.TempDir()
+	kv, err := kvstore.OpenDiskKV(filepath.Join(dir, "data"))
+	require.NoError(t, err)
+	var preimages [][]byte
+	for i := 0; i < 20; i++ {
+		data := []byte(fmt.Sprintf("pre-image %d", i))
+		require.NoError(t, kv.Put(preimage.Keccak256Key(crypto.Keccak256Hash(data)).PreimageKey(), data))
+		preimages = append(preimages, data)
+	}
+
+	l1Head := common.Hash{0x11}
+	cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, l1Head, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	cfg.DataDir = filepath.Join(dir, "data")
+	cfg.ServerMode = true
+	cfg.ServerPreimageSocket = filepath.Join(dir, "preimage.sock")
+	cfg.ServerHintSocket = filepath.Join(dir, "hint.sock")
+	require.NoError(t, cfg.Check())
+
+	logger := testlog.Logger(t, log.LvlTrace)
+	result := make(chan error)
+	go func() {
+		result <- RunPreimageServer(context.Background(), logger, cfg)
+	}()
+
+	preimageConn := dialUnix(t, cfg.ServerPreimageSocket)
+	hintConn := dialUnix(t, cfg.ServerHintSocket)
+	pClient := preimage.NewOracleClient(preimageConn)
+	hClient := preimage.NewHintWriter(hintConn)
+
+	// hints are sent while pre-images are read
+	hintsDone := make(chan struct{})
+	go func() {
+		defer close(hintsDone)
+		for _, data := range preimages {
+			hClient.Hint(l1.BlockHeaderHint(crypto.Keccak256Hash(data)))
+		}
+	}()
+	require.Equal(t, l1Head.Bytes(), pClient.Get(client.L1HeadLocalIndex))
+	for _, data := range preimages {
+		require.Equal(t, data, pClient.Get(preimage.Keccak256Key(crypto.Keccak256Hash(data))))
+	}
+	<-hintsDone
+
+	require.NoFileExists(t, cfg.ServerPreimageSocket, "socket should be removed once connected")
+	require.NoFileExists(t, cfg.ServerHintSocket, "socket should be removed once connected")
+
+	// Should exit without error when the client closes the channels
+	require.NoError(t, preimageConn.Close())
+	require.NoError(t, hintConn.Close())
+	require.NoError(t, waitFor(result))
+}
+
+func dialUnix(t *testing.T, path string) net.Conn {
+	var conn net.Conn
+	require.Eventually(t, func() bool {
+		var err error
+		conn, err = net.Dial("unix", path)
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond, "server should listen on %s", path)
+	return conn
+}
@@ -7,6 +7,9 @@ import (
 	"io"
 	"os"
 	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -15,7 +18,18 @@ import (
 // read/write mode for user/group/other, not executable.
 const diskPermission = 0666
 
+// DiskKVFormatVersion is the version of the layout of the files of a DiskKV directory.
+// Version 1 is a hex-encoded .txt file per key-value pair.
+const DiskKVFormatVersion = 1
+
+// diskKVVersionFile is the name of the file with the format version of a DiskKV directory.
+const diskKVVersionFile = "kvstore.version"
+
+// ErrIncompatibleFormat is returned when a DiskKV directory has a different format version.
+var ErrIncompatibleFormat = errors.New("incompatible kv store format")
+
 // DiskKV is a disk-backed key-value store, every key-value pair is a hex-encoded .txt file, with the value as content.
+// Values are synced to disk before they are moved into place, so a pre-image file is either complete or absent.
 // DiskKV is safe for concurrent use with a single DiskKV instance.
 // DiskKV is safe for concurrent use between different DiskKV instances of the same disk directory as long as the
 // file system supports atomic renames.
@@ -26,33 +40,108 @@ type DiskKV struct {
 
 // NewDiskKV creates a DiskKV that puts/gets pre-images as files in the given directory path.
 // The path must exist, or subsequent Put/Get calls will error when it does not.
+// Unlike OpenDiskKV, the format version of the directory is not checked.
 func NewDiskKV(path string) *DiskKV {
 	return &DiskKV{path: path}
 }
 
-func (d *DiskKV) pathKey(k common.Hash) string {
-	return path.Join(d.path, k.String()+".txt")
+// OpenDiskKV creates the given directory if it does not exist, and opens it as a DiskKV.
+// The format version of the directory is checked, and written if the directory does not have one yet.
+// Temp files of pre-images that were not completely written, e.g. because of a crash, are removed,
+// so no other DiskKV instance may be writing to the directory while it is opened.
+func OpenDiskKV(path string) (*DiskKV, error) {
+	if err := os.MkdirAll(path, 0777); err != nil {
+		return nil, fmt.Errorf("failed to create directory %v: %w", path, err)
+	}
+	if err := checkFormatVersion(path); err != nil {
+		return nil, err
+	}
+	if err := removeTempFiles(path); err != nil {
+		return nil, err
+	}
+	return NewDiskKV(path), nil
 }
 
-func (d *DiskKV) Put(k common.Hash, v []byte) error {
-	d.Lock()
-	defer d.Unlock()
-	f, err := openTempFile(d.path, k.String()+".txt.*")
+func checkFormatVersion(dir string) error {
+	versionFile := filepath.Join(dir, diskKVVersionFile)
+	dat, err := os.ReadFile(versionFile)
+	if errors.Is(err, os.ErrNotExist) {
+		// Directories of older versions have no version file, but the same layout as version 1.
+		return writeFileSync(dir, diskKVVersionFile, []byte(strconv.Itoa(DiskKVFormatVersion)+"\n"))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read kv store format version: %w", err)
+	}
+	version, err := strconv.Atoi(strings.TrimSpace(string(dat)))
 	if err != nil {
-		return fmt.Errorf("failed to open temp file for pre-image %s: %w", k, err)
+		return fmt.Errorf("%w: invalid version %q in %v", ErrIncompatibleFormat, strings.TrimSpace(string(dat)), versionFile)
+	}
+	if version != DiskKVFormatVersion {
+		return fmt.Errorf("%w: directory %v has version %d, expected %d", ErrIncompatibleFormat, dir, version, DiskKVFormatVersion)
+	}
+	return nil
+}
+
+func removeTempFiles(dir string) error {
+	tempFiles, err := filepath.Glob(filepath.Join(dir, "*.txt.*"))
+	if err != nil {
+		return err
+	}
+	for _, name := range tempFiles {
+		if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove incomplete pre-image file %v: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// writeFileSync writes the file through a temp file that is synced to disk before it is moved into place.
+func writeFileSync(dir string, name string, data []byte) error {
+	f, err := openTempFile(dir, name+".*")
+	if err != nil {
+		return fmt.Errorf("failed to open temp file for %v: %w", name, err)
 	}
 	defer os.Remove(f.Name()) // Clean up the temp file if it doesn't actually get moved into place
-	if _, err := f.Write([]byte(hex.EncodeToString(v))); err != nil {
+	if _, err := f.Write(data); err != nil {
 		_ = f.Close()
-		return fmt.Errorf("failed to write pre-image %s to disk: %w", k, err)
+		return fmt.Errorf("failed to write %v to disk: %w", name, err)
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to sync %v to disk: %w", name, err)
 	}
 	if err := f.Close(); err != nil {
-		return fmt.Errorf("failed to close temp pre-image %s file: %w", k, err)
+		return fmt.Errorf("failed to close temp %v file: %w", name, err)
 	}
-
-	targetFile := d.pathKey(k)
+	targetFile := path.Join(dir, name)
 	if err := os.Rename(f.Name(), targetFile); err != nil {
-		return fmt.Errorf("failed to move temp dir %v to final destination %v: %w", f.Name(), targetFile, err)
+		return fmt.Errorf("failed to move temp file %v to final destination %v: %w", f.Name(), targetFile, err)
+	}
+	return syncDir(dir)
+}
+
+// syncDir syncs the directory, so that the files that were moved into it persist.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open directory %v: %w", dir, err)
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("failed to sync directory %v: %w", dir, err)
+	}
+	return nil
+}
+
+func (d *DiskKV) pathKey(k common.Hash) string {
+	return path.Join(d.path, k.String()+".txt")
+}
+
+func (d *DiskKV) Put(k common.Hash, v []byte) error {
+	d.Lock()
+	defer d.Unlock()
+	if err := writeFileSync(d.path, k.String()+".txt", []byte(hex.EncodeToString(v))); err != nil {
+		return fmt.Errorf("failed to put pre-image %s: %w", k, err)
 	}
 	return nil
 }
@@ -87,7 +176,11 @@ func (d *DiskKV) Get(k common.Hash) ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read pre-image from file %s: %w", k, err)
 	}
-	return hex.DecodeString(string(dat))
+	v, err := hex.DecodeString(string(dat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode pre-image from file %s: %w", k, err)
+	}
+	return v, nil
 }
 
 var _ KV = (*DiskKV)(nil)
@@ -1,6 +1,9 @@
 package kvstore
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -22,3 +25,95 @@ func TestCreateMissingDirectory(t *testing.T) {
 	key := crypto.Keccak256Hash(val)
 	require.NoError(t, kv.Put(key, val))
 }
+
+func TestOpenDiskKV(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	kv, err := OpenDiskKV(dir)
+	require.NoError(t, err)
+	kvTest(t, kv)
+
+	dat, err := os.ReadFile(filepath.Join(dir, diskKVVersionFile))
+	require.NoError(t, err)
+	require.Equal(t, "1\n", string(dat))
+
+	// reopening the directory keeps the pre-images
+	val := []byte{1, 2, 3, 4}
+	key := crypto.Keccak256Hash(val)
+	require.NoError(t, kv.Put(key, val))
+	kv, err = OpenDiskKV(dir)
+	require.NoError(t, err)
+	got, err := kv.Get(key)
+	require.NoError(t, err)
+	require.Equal(t, val, got)
+}
+
+func TestOpenDiskKVWithoutVersion(t *testing.T) {
+	dir := t.TempDir()
+	val := []byte{1, 2, 3, 4}
+	key := crypto.Keccak256Hash(val)
+	require.NoError(t, NewDiskKV(dir).Put(key, val))
+
+	kv, err := OpenDiskKV(dir)
+	require.NoError(t, err)
+	got, err := kv.Get(key)
+	require.NoError(t, err)
+	require.Equal(t, val, got)
+	require.FileExists(t, filepath.Join(dir, diskKVVersionFile))
+}
+
+func TestOpenDiskKVIncompatibleFormat(t *testing.T) {
+	for _, version := range []string{"2\n", "not a version"} {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, diskKVVersionFile), []byte(version), diskPermission))
+		_, err := OpenDiskKV(dir)
+		require.ErrorIs(t, err, ErrIncompatibleFormat)
+	}
+}
+
+func TestDiskKVPartialWrite(t *testing.T) {
+	dir := t.TempDir()
+	kv, err := OpenDiskKV(dir)
+	require.NoError(t, err)
+	val := []byte{1, 2, 3, 4}
+	key := crypto.Keccak256Hash(val)
+
+	// a crash during Put leaves the temp file behind, with part of the value
+	tempFile := filepath.Join(dir, key.String()+".txt.123456")
+	require.NoError(t, os.WriteFile(tempFile, []byte(hex.EncodeToString(val)[:3]), diskPermission))
+	_, err = kv.Get(key)
+	require.ErrorIs(t, err, ErrNotFound, "incomplete pre-image is not found")
+
+	kv, err = OpenDiskKV(dir)
+	require.NoError(t, err)
+	require.NoFileExists(t, tempFile, "incomplete pre-image is removed on open")
+	_, err = kv.Get(key)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, kv.Put(key, val))
+	got, err := kv.Get(key)
+	require.NoError(t, err)
+	require.Equal(t, val, got)
+}
+
+func TestDiskKVCorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	kv := NewDiskKV(dir)
+	key := crypto.Keccak256Hash([]byte{1})
+	require.NoError(t, os.WriteFile(kv.pathKey(key), []byte("abc"), diskPermission))
+	_, err := kv.Get(key)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrNotFound)
+}
+
+func TestDiskKVLargeValue(t *testing.T) {
+	kv, err := OpenDiskKV(t.TempDir())
+	require.NoError(t, err)
+	val := make([]byte, 16*1024*1024)
+	_, err = rand.Read(val)
+	require.NoError(t, err)
+	key := crypto.Keccak256Hash(val)
+	require.NoError(t, kv.Put(key, val))
+	got, err := kv.Get(key)
+	require.NoError(t, err)
+	require.Equal(t, val, got)
+}
@@ -0,0 +1,52 @@
+package kvstore
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PreimageRecorder records the keys of the pre-images that are read from a source, and their total size.
+// A key that is read several times is only recorded once.
+type PreimageRecorder struct {
+	source PreimageSource
+
+	mu    sync.Mutex
+	sizes map[common.Hash]int
+	total uint64
+}
+
+func NewPreimageRecorder(source PreimageSource) *PreimageRecorder {
+	return &PreimageRecorder{
+		source: source,
+		sizes:  make(map[common.Hash]int),
+	}
+}
+
+func (r *PreimageRecorder) Get(key common.Hash) ([]byte, error) {
+	value, err := r.source(key)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.sizes[key]; !ok {
+		r.sizes[key] = len(value)
+		r.total += uint64(len(value))
+	}
+	return value, nil
+}
+
+// Count returns the number of distinct pre-images that were read.
+func (r *PreimageRecorder) Count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.sizes)
+}
+
+// Size returns the total size in bytes of the distinct pre-images that were read.
+func (r *PreimageRecorder) Size() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.total
+}
@@ -0,0 +1,35 @@
+package kvstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreimageRecorder(t *testing.T) {
+	kv := NewMemKV()
+	require.NoError(t, kv.Put(common.Hash{0x01}, []byte{1, 2, 3}))
+	require.NoError(t, kv.Put(common.Hash{0x02}, []byte{4, 5}))
+	recorder := NewPreimageRecorder(kv.Get)
+	require.Zero(t, recorder.Count())
+	require.Zero(t, recorder.Size())
+
+	for i := 0; i < 2; i++ {
+		value, err := recorder.Get(common.Hash{0x01})
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 2, 3}, value)
+	}
+	require.Equal(t, 1, recorder.Count(), "should record a key read twice once")
+	require.Equal(t, uint64(3), recorder.Size())
+
+	_, err := recorder.Get(common.Hash{0x03})
+	require.True(t, errors.Is(err, ErrNotFound))
+	require.Equal(t, 1, recorder.Count(), "should not record missing pre-images")
+
+	_, err = recorder.Get(common.Hash{0x02})
+	require.NoError(t, err)
+	require.Equal(t, 2, recorder.Count())
+	require.Equal(t, uint64(5), recorder.Size())
+}
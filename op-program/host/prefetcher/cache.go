@@ -0,0 +1,85 @@
+package prefetcher
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+)
+
+// FetchCache is a KV store that is backed by a persistent cache of fetched pre-images, which is shared across runs.
+// Pre-images that are not in the KV store are read from the cache before they are fetched,
+// and fetched pre-images are written to the cache after they are verified against their key.
+type FetchCache struct {
+	kv     kvstore.KV
+	cache  kvstore.KV
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func NewFetchCache(kv kvstore.KV, cache kvstore.KV) *FetchCache {
+	return &FetchCache{
+		kv:    kv,
+		cache: cache,
+	}
+}
+
+func (c *FetchCache) Put(k common.Hash, v []byte) error {
+	if err := c.kv.Put(k, v); err != nil {
+		return err
+	}
+	if err := verifyPreimage(k, v); err != nil {
+		return fmt.Errorf("not caching fetched pre-image: %w", err)
+	}
+	if err := c.cache.Put(k, v); err != nil {
+		return fmt.Errorf("failed to cache pre-image %s: %w", k, err)
+	}
+	return nil
+}
+
+func (c *FetchCache) Get(k common.Hash) ([]byte, error) {
+	v, err := c.kv.Get(k)
+	if !errors.Is(err, kvstore.ErrNotFound) {
+		return v, err
+	}
+	v, err = c.cache.Get(k)
+	if errors.Is(err, kvstore.ErrNotFound) {
+		c.misses.Add(1)
+		return nil, kvstore.ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read cached pre-image %s: %w", k, err)
+	}
+	// The cache directory may have been modified by anyone, so only correct pre-images are used.
+	if err := verifyPreimage(k, v); err != nil {
+		c.misses.Add(1)
+		return nil, kvstore.ErrNotFound
+	}
+	c.hits.Add(1)
+	if err := c.kv.Put(k, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Hits returns the number of pre-images that were read from the cache.
+func (c *FetchCache) Hits() uint64 {
+	return c.hits.Load()
+}
+
+// Misses returns the number of pre-images that were not in the cache, and had to be fetched.
+func (c *FetchCache) Misses() uint64 {
+	return c.misses.Load()
+}
+
+func verifyPreimage(k common.Hash, v []byte) error {
+	_, err := preimage.WithVerification(func(key [32]byte) ([]byte, error) {
+		return v, nil
+	})(k)
+	return err
+}
+
+var _ kvstore.KV = (*FetchCache)(nil)
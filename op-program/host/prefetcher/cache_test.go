@@ -0,0 +1,141 @@
+package prefetcher
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFetchCacheSharedAcrossRuns(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	block, receipts := testutils.RandomBlock(rng, 2)
+	node := []byte{0xc1, 0x01}
+	stub := &countingSource{block: block, receipts: receipts, node: node}
+	cacheDir := t.TempDir()
+
+	run := func() *FetchCache {
+		logger := testlog.Logger(t, log.LvlDebug)
+		cache, err := kvstore.OpenDiskKV(cacheDir)
+		require.NoError(t, err)
+		fetchCache := NewFetchCache(kvstore.NewMemKV(), cache)
+		prefetcher := NewPrefetcher(logger, stub, nil, stub, fetchCache)
+
+		l1Oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		header, txs := l1Oracle.TransactionsByBlockHash(block.Hash())
+		require.Equal(t, block.Hash(), header.Hash())
+		assertTransactionsEqual(t, block.Transactions(), txs)
+		_, rcpts := l1Oracle.ReceiptsByBlockHash(block.Hash())
+		assertReceiptsEqual(t, receipts, rcpts)
+
+		l2Oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		require.EqualValues(t, node, l2Oracle.NodeByHash(crypto.Keccak256Hash(node)))
+		return fetchCache
+	}
+
+	first := run()
+	require.NotZero(t, stub.calls)
+	require.Zero(t, first.Hits())
+	require.NotZero(t, first.Misses())
+
+	stub.calls = 0
+	second := run()
+	require.Zero(t, stub.calls, "second run is served from the cache")
+	require.Zero(t, second.Misses())
+	require.NotZero(t, second.Hits())
+}
+
+func TestFetchCacheVerifiesPreimages(t *testing.T) {
+	cache := kvstore.NewMemKV()
+	fetchCache := NewFetchCache(kvstore.NewMemKV(), cache)
+	data := []byte{1, 2, 3}
+	key := preimage.Keccak256Key(crypto.Keccak256Hash(data)).PreimageKey()
+
+	t.Run("NotCachedWhenIncorrect", func(t *testing.T) {
+		wrongKey := preimage.Keccak256Key(common.Hash{0xaa}).PreimageKey()
+		require.ErrorIs(t, fetchCache.Put(wrongKey, data), preimage.ErrIncorrectData)
+		_, err := cache.Get(wrongKey)
+		require.ErrorIs(t, err, kvstore.ErrNotFound)
+	})
+
+	t.Run("IncorrectCachedPreimageIsMiss", func(t *testing.T) {
+		require.NoError(t, cache.Put(key, []byte{4, 5, 6}))
+		_, err := fetchCache.Get(key)
+		require.ErrorIs(t, err, kvstore.ErrNotFound)
+		require.Equal(t, uint64(1), fetchCache.Misses())
+		require.Zero(t, fetchCache.Hits())
+	})
+
+	t.Run("Cached", func(t *testing.T) {
+		require.NoError(t, fetchCache.Put(key, data))
+		cached, err := cache.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, data, cached)
+	})
+}
+
+// countingSource is an L1 and L2 source of a single block and state node, that counts the calls to it.
+type countingSource struct {
+	block    *types.Block
+	receipts types.Receipts
+	node     []byte
+	calls    int
+}
+
+var errUnknown = errors.New("unknown")
+
+func (s *countingSource) InfoByHash(_ context.Context, blockHash common.Hash) (eth.BlockInfo, error) {
+	s.calls++
+	if blockHash != s.block.Hash() {
+		return nil, errUnknown
+	}
+	return eth.HeaderBlockInfo(s.block.Header()), nil
+}
+
+func (s *countingSource) InfoAndTxsByHash(_ context.Context, blockHash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	s.calls++
+	if blockHash != s.block.Hash() {
+		return nil, nil, errUnknown
+	}
+	return eth.HeaderBlockInfo(s.block.Header()), s.block.Transactions(), nil
+}
+
+func (s *countingSource) FetchReceipts(_ context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	s.calls++
+	if blockHash != s.block.Hash() {
+		return nil, nil, errUnknown
+	}
+	return eth.HeaderBlockInfo(s.block.Header()), s.receipts, nil
+}
+
+func (s *countingSource) NodeByHash(_ context.Context, hash common.Hash) ([]byte, error) {
+	s.calls++
+	if hash != crypto.Keccak256Hash(s.node) {
+		return nil, errUnknown
+	}
+	return s.node, nil
+}
+
+func (s *countingSource) CodeByHash(_ context.Context, _ common.Hash) ([]byte, error) {
+	s.calls++
+	return nil, errUnknown
+}
+
+func (s *countingSource) OutputByRoot(_ context.Context, _ common.Hash) (eth.Output, error) {
+	s.calls++
+	return nil, errUnknown
+}
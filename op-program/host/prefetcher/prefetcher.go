@@ -15,8 +15,10 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 type L1Source interface {
@@ -32,21 +34,52 @@ type L2Source interface {
 	OutputByRoot(ctx context.Context, root common.Hash) (eth.Output, error)
 }
 
+type L1BlobSource interface {
+	GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error)
+}
+
+// HintHandler prefetches the pre-images of a hint, given the data of the hint after the hint type.
+type HintHandler func(ctx context.Context, hintData string) error
+
 type Prefetcher struct {
-	logger    log.Logger
-	l1Fetcher L1Source
-	l2Fetcher L2Source
-	lastHint  string
-	kvStore   kvstore.KV
+	logger        log.Logger
+	l1Fetcher     L1Source
+	l1BlobFetcher L1BlobSource
+	l2Fetcher     L2Source
+	lastHint      string
+	kvStore       kvstore.KV
+	handlers      map[string]HintHandler
 }
 
-func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
-	return &Prefetcher{
+// NewPrefetcher creates a Prefetcher with handlers for the L1 and L2 hint types.
+// The L1 blob fetcher may be nil, in which case blob hints cannot be handled.
+func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
+	p := &Prefetcher{
 		logger:    logger,
 		l1Fetcher: NewRetryingL1Source(logger, l1Fetcher),
 		l2Fetcher: NewRetryingL2Source(logger, l2Fetcher),
 		kvStore:   kvStore,
+		handlers:  make(map[string]HintHandler),
 	}
+	if l1BlobFetcher != nil {
+		p.l1BlobFetcher = NewRetryingL1BlobSource(logger, l1BlobFetcher)
+	}
+	p.RegisterHintHandler(l1.HintL1BlockHeader, hashHint(p.prefetchL1BlockHeader))
+	p.RegisterHintHandler(l1.HintL1Transactions, hashHint(p.prefetchL1Transactions))
+	p.RegisterHintHandler(l1.HintL1Receipts, hashHint(p.prefetchL1Receipts))
+	p.RegisterHintHandler(l1.HintL1Blob, p.prefetchL1Blob)
+	p.RegisterHintHandler(l1.HintL1Precompile, p.prefetchL1Precompile)
+	p.RegisterHintHandler(l2.HintL2BlockHeader, hashHint(p.prefetchL2Block))
+	p.RegisterHintHandler(l2.HintL2Transactions, hashHint(p.prefetchL2Block))
+	p.RegisterHintHandler(l2.HintL2StateNode, hashHint(p.prefetchL2StateNode))
+	p.RegisterHintHandler(l2.HintL2Code, hashHint(p.prefetchL2Code))
+	p.RegisterHintHandler(l2.HintL2Output, hashHint(p.prefetchL2Output))
+	return p
+}
+
+// RegisterHintHandler registers the handler of a hint type, replacing any handler of the hint type.
+func (p *Prefetcher) RegisterHintHandler(hintType string, handler HintHandler) {
+	p.handlers[hintType] = handler
 }
 
 func (p *Prefetcher) Hint(hint string) error {
@@ -75,68 +108,142 @@ func (p *Prefetcher) GetPreimage(ctx context.Context, key common.Hash) ([]byte,
 }
 
 func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
-	hintType, hash, err := parseHint(hint)
+	hintType, hintData, found := strings.Cut(hint, " ")
+	if !found {
+		return fmt.Errorf("unsupported hint: %s", hint)
+	}
+	handler, ok := p.handlers[hintType]
+	if !ok {
+		p.logger.Error("Received hint of unknown type", "hint", hint)
+		return fmt.Errorf("unknown hint type: %v", hintType)
+	}
+	p.logger.Debug("Prefetching", "type", hintType, "data", hintData)
+	return handler(ctx, hintData)
+}
+
+func (p *Prefetcher) prefetchL1BlockHeader(ctx context.Context, hash common.Hash) error {
+	header, err := p.l1Fetcher.InfoByHash(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 block %s header: %w", hash, err)
+	}
+	data, err := header.HeaderRLP()
+	if err != nil {
+		return fmt.Errorf("marshall header: %w", err)
+	}
+	return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), data)
+}
+
+func (p *Prefetcher) prefetchL1Transactions(ctx context.Context, hash common.Hash) error {
+	_, txs, err := p.l1Fetcher.InfoAndTxsByHash(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 block %s txs: %w", hash, err)
+	}
+	return p.storeTransactions(txs)
+}
+
+func (p *Prefetcher) prefetchL1Receipts(ctx context.Context, hash common.Hash) error {
+	_, receipts, err := p.l1Fetcher.FetchReceipts(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 block %s receipts: %w", hash, err)
+	}
+	return p.storeReceipts(receipts)
+}
+
+// prefetchL1Blob fetches the blob of the hint, and stores all its field elements.
+func (p *Prefetcher) prefetchL1Blob(ctx context.Context, hintData string) error {
+	hint, err := l1.ParseBlobHint(hintData)
 	if err != nil {
 		return err
 	}
-	p.logger.Debug("Prefetching", "type", hintType, "hash", hash)
-	switch hintType {
-	case l1.HintL1BlockHeader:
-		header, err := p.l1Fetcher.InfoByHash(ctx, hash)
-		if err != nil {
-			return fmt.Errorf("failed to fetch L1 block %s header: %w", hash, err)
-		}
-		data, err := header.HeaderRLP()
-		if err != nil {
-			return fmt.Errorf("marshall header: %w", err)
-		}
-		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), data)
-	case l1.HintL1Transactions:
-		_, txs, err := p.l1Fetcher.InfoAndTxsByHash(ctx, hash)
-		if err != nil {
-			return fmt.Errorf("failed to fetch L1 block %s txs: %w", hash, err)
-		}
-		return p.storeTransactions(txs)
-	case l1.HintL1Receipts:
-		_, receipts, err := p.l1Fetcher.FetchReceipts(ctx, hash)
-		if err != nil {
-			return fmt.Errorf("failed to fetch L1 block %s receipts: %w", hash, err)
-		}
-		return p.storeReceipts(receipts)
-	case l2.HintL2BlockHeader, l2.HintL2Transactions:
-		header, txs, err := p.l2Fetcher.InfoAndTxsByHash(ctx, hash)
-		if err != nil {
-			return fmt.Errorf("failed to fetch L2 block %s: %w", hash, err)
-		}
-		data, err := header.HeaderRLP()
-		if err != nil {
-			return fmt.Errorf("failed to encode header to RLP: %w", err)
-		}
-		err = p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), data)
-		if err != nil {
-			return err
-		}
-		return p.storeTransactions(txs)
-	case l2.HintL2StateNode:
-		node, err := p.l2Fetcher.NodeByHash(ctx, hash)
-		if err != nil {
-			return fmt.Errorf("failed to fetch L2 state node %s: %w", hash, err)
-		}
-		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), node)
-	case l2.HintL2Code:
-		code, err := p.l2Fetcher.CodeByHash(ctx, hash)
-		if err != nil {
-			return fmt.Errorf("failed to fetch L2 contract code %s: %w", hash, err)
-		}
-		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), code)
-	case l2.HintL2Output:
-		output, err := p.l2Fetcher.OutputByRoot(ctx, hash)
-		if err != nil {
-			return fmt.Errorf("failed to fetch L2 output root %s: %w", hash, err)
+	if p.l1BlobFetcher == nil {
+		return fmt.Errorf("cannot fetch L1 blob %s: no L1 beacon node configured", hint.Hash)
+	}
+	if hint.FieldElement >= params.BlobTxFieldElementsPerBlob {
+		return fmt.Errorf("invalid field element %d of L1 blob %s", hint.FieldElement, hint.Hash)
+	}
+	// the blob is verified against its versioned hash by the fetcher
+	blobs, err := p.l1BlobFetcher.GetBlobs(ctx, eth.L1BlockRef{Time: hint.Time}, []eth.IndexedBlobHash{{Index: hint.Index, Hash: hint.Hash}})
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 blob %s: %w", hint.Hash, err)
+	}
+	if len(blobs) != 1 {
+		return fmt.Errorf("expected 1 L1 blob %s, got %d", hint.Hash, len(blobs))
+	}
+	blob := blobs[0]
+	for i := 0; i < params.BlobTxFieldElementsPerBlob; i++ {
+		key := preimage.BlobFieldElementKey(hint.Hash, uint64(i)).PreimageKey()
+		if err := p.kvStore.Put(key, blob[i*32:(i+1)*32]); err != nil {
+			return fmt.Errorf("failed to store field element %d of L1 blob %s: %w", i, hint.Hash, err)
 		}
-		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), output.Marshal())
 	}
-	return fmt.Errorf("unknown hint type: %v", hintType)
+	return nil
+}
+
+// acceleratedPrecompiles are the precompiles of which the host computes the result for the program,
+// as they are expensive to run in the program.
+var acceleratedPrecompiles = map[common.Address]vm.PrecompiledContract{
+	common.BytesToAddress([]byte{0x01}): vm.PrecompiledContractsCancun[common.BytesToAddress([]byte{0x01})], // ecrecover
+	common.BytesToAddress([]byte{0x0a}): vm.PrecompiledContractsCancun[common.BytesToAddress([]byte{0x0a})], // KZG point evaluation
+}
+
+// prefetchL1Precompile runs the precompile call of the hint, and stores its status and output.
+func (p *Prefetcher) prefetchL1Precompile(ctx context.Context, hintData string) error {
+	address, input, err := l1.ParsePrecompileHint(hintData)
+	if err != nil {
+		return err
+	}
+	contract, ok := acceleratedPrecompiles[address]
+	if !ok {
+		return fmt.Errorf("unsupported precompile %s", address)
+	}
+	result := []byte{1}
+	if output, err := contract.Run(input); err != nil {
+		result = []byte{0}
+	} else {
+		result = append(result, output...)
+	}
+	key := preimage.PrecompileKey(crypto.Keccak256Hash(append(address.Bytes(), input...))).PreimageKey()
+	return p.kvStore.Put(key, result)
+}
+
+func (p *Prefetcher) prefetchL2Block(ctx context.Context, hash common.Hash) error {
+	header, txs, err := p.l2Fetcher.InfoAndTxsByHash(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L2 block %s: %w", hash, err)
+	}
+	data, err := header.HeaderRLP()
+	if err != nil {
+		return fmt.Errorf("failed to encode header to RLP: %w", err)
+	}
+	err = p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), data)
+	if err != nil {
+		return err
+	}
+	return p.storeTransactions(txs)
+}
+
+func (p *Prefetcher) prefetchL2StateNode(ctx context.Context, hash common.Hash) error {
+	node, err := p.l2Fetcher.NodeByHash(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L2 state node %s: %w", hash, err)
+	}
+	return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), node)
+}
+
+func (p *Prefetcher) prefetchL2Code(ctx context.Context, hash common.Hash) error {
+	code, err := p.l2Fetcher.CodeByHash(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L2 contract code %s: %w", hash, err)
+	}
+	return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), code)
+}
+
+func (p *Prefetcher) prefetchL2Output(ctx context.Context, hash common.Hash) error {
+	output, err := p.l2Fetcher.OutputByRoot(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L2 output root %s: %w", hash, err)
+	}
+	return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), output.Marshal())
 }
 
 func (p *Prefetcher) storeReceipts(receipts types.Receipts) error {
@@ -166,15 +273,13 @@ func (p *Prefetcher) storeTrieNodes(values []hexutil.Bytes) error {
 	return nil
 }
 
-// parseHint parses a hint string in wire protocol. Returns the hint type, requested hash and error (if any).
-func parseHint(hint string) (string, common.Hash, error) {
-	hintType, hashStr, found := strings.Cut(hint, " ")
-	if !found {
-		return "", common.Hash{}, fmt.Errorf("unsupported hint: %s", hint)
-	}
-	hash := common.HexToHash(hashStr)
-	if hash == (common.Hash{}) {
-		return "", common.Hash{}, fmt.Errorf("invalid hash: %s", hashStr)
+// hashHint returns a HintHandler for the hint types of which the data is a hash.
+func hashHint(fn func(ctx context.Context, hash common.Hash) error) HintHandler {
+	return func(ctx context.Context, hintData string) error {
+		hash := common.HexToHash(hintData)
+		if hash == (common.Hash{}) {
+			return fmt.Errorf("invalid hash: %s", hintData)
+		}
+		return fn(ctx, hash)
 	}
-	return hintType, hash, nil
 }
@@ -2,13 +2,16 @@ package prefetcher
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/require"
 
@@ -257,6 +260,111 @@ func TestFetchL2Code(t *testing.T) {
 	})
 }
 
+func TestFetchL1Blob(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	var blob eth.Blob
+	for i := 0; i < params.BlobTxFieldElementsPerBlob; i++ {
+		// keep the field elements below the BLS modulus
+		rng.Read(blob[i*32+1 : (i+1)*32])
+	}
+	commitment, err := kzg4844.BlobToCommitment(*blob.KZGBlob())
+	require.NoError(t, err)
+	blobHash := eth.IndexedBlobHash{Index: 3, Hash: eth.KZGToVersionedHash(commitment)}
+	ref := eth.L1BlockRef{Time: 1234}
+
+	t.Run("AlreadyKnown", func(t *testing.T) {
+		prefetcher, _, _, kv := createPrefetcher(t)
+		for i := 0; i < params.BlobTxFieldElementsPerBlob; i++ {
+			key := preimage.BlobFieldElementKey(blobHash.Hash, uint64(i)).PreimageKey()
+			require.NoError(t, kv.Put(key, blob[i*32:(i+1)*32]))
+		}
+
+		oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		require.Equal(t, &blob, oracle.GetBlob(ref, blobHash))
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		blobSource := &stubBlobSource{blobs: map[eth.IndexedBlobHash]*eth.Blob{blobHash: &blob}}
+		prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlDebug), nil, blobSource, nil, kvstore.NewMemKV())
+
+		oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		require.Equal(t, &blob, oracle.GetBlob(ref, blobHash))
+		require.Equal(t, []uint64{ref.Time}, blobSource.times, "should fetch the whole blob once")
+	})
+
+	t.Run("NoBlobSource", func(t *testing.T) {
+		prefetcher, _, _, _ := createPrefetcher(t)
+		require.NoError(t, prefetcher.Hint(l1.BlobHint{Hash: blobHash.Hash, Index: blobHash.Index, Time: ref.Time}.Hint()))
+		_, err := prefetcher.GetPreimage(context.Background(), preimage.BlobFieldElementKey(blobHash.Hash, 0).PreimageKey())
+		require.ErrorContains(t, err, "no L1 beacon node")
+	})
+
+	t.Run("InvalidFieldElement", func(t *testing.T) {
+		blobSource := &stubBlobSource{blobs: map[eth.IndexedBlobHash]*eth.Blob{blobHash: &blob}}
+		prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlDebug), nil, blobSource, nil, kvstore.NewMemKV())
+		hint := l1.BlobHint{Hash: blobHash.Hash, Index: blobHash.Index, Time: ref.Time, FieldElement: params.BlobTxFieldElementsPerBlob}
+		require.NoError(t, prefetcher.Hint(hint.Hint()))
+		_, err := prefetcher.GetPreimage(context.Background(), preimage.BlobFieldElementKey(blobHash.Hash, 0).PreimageKey())
+		require.ErrorContains(t, err, "invalid field element")
+	})
+}
+
+func TestFetchL1Precompile(t *testing.T) {
+	ecrecover := common.BytesToAddress([]byte{0x01})
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	msgHash := crypto.Keccak256Hash([]byte("message"))
+	sig, err := crypto.Sign(msgHash[:], key)
+	require.NoError(t, err)
+	// ecrecover input: hash, v (27 or 28) as a word, r, s
+	input := make([]byte, 128)
+	copy(input[0:32], msgHash[:])
+	input[63] = sig[64] + 27
+	copy(input[64:128], sig[:64])
+
+	t.Run("Success", func(t *testing.T) {
+		prefetcher, _, _, _ := createPrefetcher(t)
+		oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		output, ok := oracle.Precompile(ecrecover, input)
+		require.True(t, ok)
+		require.Equal(t, common.LeftPadBytes(crypto.PubkeyToAddress(key.PublicKey).Bytes(), 32), output)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		prefetcher, _, _, _ := createPrefetcher(t)
+		oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		// the point evaluation precompile fails on an invalid input length
+		output, ok := oracle.Precompile(common.BytesToAddress([]byte{0x0a}), []byte{1, 2, 3})
+		require.False(t, ok)
+		require.Empty(t, output)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		prefetcher, _, _, _ := createPrefetcher(t)
+		hintData := append(common.BytesToAddress([]byte{0x02}).Bytes(), input...)
+		require.NoError(t, prefetcher.Hint(l1.PrecompileHint(hintData).Hint()))
+		_, err := prefetcher.GetPreimage(context.Background(), preimage.PrecompileKey(crypto.Keccak256Hash(hintData)).PreimageKey())
+		require.ErrorContains(t, err, "unsupported precompile")
+	})
+}
+
+func TestRegisterHintHandler(t *testing.T) {
+	prefetcher, _, _, kv := createPrefetcher(t)
+	data := []byte{1, 2, 3}
+	key := preimage.Keccak256Key(crypto.Keccak256Hash(data)).PreimageKey()
+	var received []string
+	prefetcher.RegisterHintHandler("custom", func(ctx context.Context, hintData string) error {
+		received = append(received, hintData)
+		return kv.Put(key, data)
+	})
+
+	require.NoError(t, prefetcher.Hint("custom some data"))
+	pre, err := prefetcher.GetPreimage(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, data, pre)
+	require.Equal(t, []string{"some data"}, received)
+}
+
 func TestBadHints(t *testing.T) {
 	prefetcher, _, _, kv := createPrefetcher(t)
 	hash := common.Hash{0xad}
@@ -314,7 +422,7 @@ func TestRetryWhenNotAvailableAfterPrefetching(t *testing.T) {
 	_, l1Source, l2Cl, kv := createPrefetcher(t)
 	putsToIgnore := 2
 	kv = &unreliableKvStore{KV: kv, putsToIgnore: putsToIgnore}
-	prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlInfo), l1Source, l2Cl, kv)
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlInfo), l1Source, nil, l2Cl, kv)
 
 	// Expect one call for each ignored put, plus one more request for when the put succeeds
 	for i := 0; i < putsToIgnore+1; i++ {
@@ -327,6 +435,24 @@ func TestRetryWhenNotAvailableAfterPrefetching(t *testing.T) {
 	require.EqualValues(t, node, result)
 }
 
+type stubBlobSource struct {
+	blobs map[eth.IndexedBlobHash]*eth.Blob
+	times []uint64
+}
+
+func (s *stubBlobSource) GetBlobs(_ context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	s.times = append(s.times, ref.Time)
+	blobs := make([]*eth.Blob, len(hashes))
+	for i, h := range hashes {
+		blob, ok := s.blobs[h]
+		if !ok {
+			return nil, fmt.Errorf("unknown blob %v", h.Hash)
+		}
+		blobs[i] = blob
+	}
+	return blobs, nil
+}
+
 type unreliableKvStore struct {
 	kvstore.KV
 	putsToIgnore int
@@ -365,7 +491,7 @@ func createPrefetcher(t *testing.T) (*Prefetcher, *testutils.MockL1Source, *l2Cl
 		MockDebugClient: new(testutils.MockDebugClient),
 	}
 
-	prefetcher := NewPrefetcher(logger, l1Source, l2Source, kv)
+	prefetcher := NewPrefetcher(logger, l1Source, nil, l2Source, kv)
 	return prefetcher, l1Source, l2Source, kv
 }
 
@@ -1,8 +1,11 @@
 package io
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"io"
+	"net"
 	"os"
 )
 
@@ -57,3 +60,25 @@ func CreateBidirectionalChannel() (FileChannel, FileChannel, error) {
 	}
 	return NewReadWritePair(ar, aw), NewReadWritePair(br, bw), nil
 }
+
+// AcceptUnixChannel listens on a unix socket at the given path, and returns the first connection to it.
+// The socket is removed once the connection is accepted, or the context is done.
+func AcceptUnixChannel(ctx context.Context, path string) (io.ReadWriteCloser, error) {
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on unix socket %s: %w", path, err)
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = l.Close()
+	})
+	defer stop()
+	defer l.Close()
+	conn, err := l.Accept()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("failed to accept connection on unix socket %s: %w", path, err)
+	}
+	return conn, nil
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/urfave/cli/v2"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/endpoint"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -22,19 +24,11 @@ func prefixEnvVars(name string) []string {
 
 var (
 	// Required Flags
-	L1EthRpcFlag = &cli.StringFlag{
-		Name:    "l1-eth-rpc",
-		Usage:   "HTTP provider URL for L1",
-		EnvVars: prefixEnvVars("L1_ETH_RPC"),
-	}
-	RollupRpcFlag = &cli.StringFlag{
-		Name:    "rollup-rpc",
-		Usage:   "HTTP provider URL for the rollup node",
-		EnvVars: prefixEnvVars("ROLLUP_RPC"),
-	}
+	L1EthRpcFlag    = endpoint.L1RPCFlag(EnvVarPrefix)
+	RollupRpcFlag   = endpoint.RollupRPCFlag(EnvVarPrefix)
 	L2OOAddressFlag = &cli.StringFlag{
 		Name:    "l2oo-address",
-		Usage:   "Address of the L2OutputOracle contract",
+		Usage:   "Address of the L2OutputOracle, or DisputeGameFactory contract, which is detected at startup",
 		EnvVars: prefixEnvVars("L2OO_ADDRESS"),
 	}
 
@@ -50,6 +44,77 @@ var (
 		Usage:   "Allow the proposer to submit proposals for L2 blocks derived from non-finalized L1 blocks.",
 		EnvVars: prefixEnvVars("ALLOW_NON_FINALIZED"),
 	}
+	ProposalSourceFlag = &cli.GenericFlag{
+		Name: "proposal-source",
+		Usage: "The L2 head of the rollup node up to which outputs are proposed. Non-finalized sources require " +
+			"--allow-non-finalized. If not set, the safe head is used with --allow-non-finalized, else the finalized head. " +
+			"Valid options: " + openum.EnumString(ProposalSources),
+		Value: func() *ProposalSource {
+			var out ProposalSource
+			return &out
+		}(),
+		EnvVars: prefixEnvVars("PROPOSAL_SOURCE"),
+	}
+	ProposalMaxRetriesFlag = &cli.Uint64Flag{
+		Name:    "proposal-max-retries",
+		Usage:   "Number of times a proposal tx that failed with a transient error is retried, while the output is still needed.",
+		Value:   3,
+		EnvVars: prefixEnvVars("PROPOSAL_MAX_RETRIES"),
+	}
+	ProposalRetryMaxBackoffFlag = &cli.DurationFlag{
+		Name:    "proposal-retry-max-backoff",
+		Usage:   "Maximum backoff between the retries of a failed proposal tx, which increases exponentially from 1s.",
+		Value:   30 * time.Second,
+		EnvVars: prefixEnvVars("PROPOSAL_RETRY_MAX_BACKOFF"),
+	}
+	DisputeGameTypeFlag = &cli.UintFlag{
+		Name:    "game-type",
+		Usage:   "Type of the dispute games to create per output, when proposing to a DisputeGameFactory.",
+		Value:   0,
+		EnvVars: prefixEnvVars("GAME_TYPE"),
+	}
+	ProposalIntervalFlag = &cli.Uint64Flag{
+		Name: "proposal-interval",
+		Usage: "Interval of the L2 blocks of the outputs to propose, when proposing to a DisputeGameFactory. " +
+			"A L2OutputOracle only accepts outputs at its submission interval: the interval must be 0 or equal to it.",
+		EnvVars: prefixEnvVars("PROPOSAL_INTERVAL"),
+	}
+	ProposalTimeIntervalFlag = &cli.DurationFlag{
+		Name:    "proposal-time-interval",
+		Usage:   "Minimum duration between two proposals, on top of the proposal interval. 0 to disable.",
+		Value:   0,
+		EnvVars: prefixEnvVars("PROPOSAL_TIME_INTERVAL"),
+	}
+	MaxL1BaseFeeFlag = &cli.Float64Flag{
+		Name:    "max-l1-base-fee",
+		Usage:   "The L1 base fee in GWei above which proposals are deferred. 0 to disable.",
+		Value:   0,
+		EnvVars: prefixEnvVars("MAX_L1_BASE_FEE"),
+	}
+	MaxProposalCostFlag = &cli.Float64Flag{
+		Name:    "max-proposal-cost",
+		Usage:   "The estimated cost in GWei of a proposal tx at the L1 base fee, above which proposals are deferred. 0 to disable.",
+		Value:   0,
+		EnvVars: prefixEnvVars("MAX_PROPOSAL_COST"),
+	}
+	FeeCeilingMaxDelayFlag = &cli.DurationFlag{
+		Name:    "fee-ceiling-max-delay",
+		Usage:   "The maximum duration that proposals are deferred for by the L1 fee ceilings, after which the next output is proposed regardless.",
+		Value:   30 * time.Minute,
+		EnvVars: prefixEnvVars("FEE_CEILING_MAX_DELAY"),
+	}
+	L2VerifyRpcFlag = &cli.StringFlag{
+		Name: "l2-verify-rpc",
+		Usage: "HTTP provider URL of an L2 execution node, independent of the rollup node, to verify the output roots " +
+			"with before proposing them. Outputs with a mismatching output root are not proposed.",
+		EnvVars: prefixEnvVars("L2_VERIFY_RPC"),
+	}
+	L2VerifyFailOpenFlag = &cli.BoolFlag{
+		Name:    "l2-verify-fail-open",
+		Usage:   "Propose outputs that cannot be verified, because the L2 verification RPC is unavailable.",
+		EnvVars: prefixEnvVars("L2_VERIFY_FAIL_OPEN"),
+	}
+	DialTimeoutFlag = endpoint.DialTimeoutFlag(EnvVarPrefix)
 	// Legacy Flags
 	L2OutputHDPathFlag = txmgr.L2OutputHDPathFlag
 )
@@ -63,6 +128,18 @@ var requiredFlags = []cli.Flag{
 var optionalFlags = []cli.Flag{
 	PollIntervalFlag,
 	AllowNonFinalizedFlag,
+	ProposalSourceFlag,
+	ProposalMaxRetriesFlag,
+	ProposalRetryMaxBackoffFlag,
+	DisputeGameTypeFlag,
+	ProposalIntervalFlag,
+	ProposalTimeIntervalFlag,
+	MaxL1BaseFeeFlag,
+	MaxProposalCostFlag,
+	FeeCeilingMaxDelayFlag,
+	L2VerifyRpcFlag,
+	L2VerifyFailOpenFlag,
+	DialTimeoutFlag,
 	L2OutputHDPathFlag,
 }
 
@@ -70,6 +147,7 @@ func init() {
 	optionalFlags = append(optionalFlags, oprpc.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, opmetrics.BalanceCLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
 
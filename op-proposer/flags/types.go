@@ -0,0 +1,47 @@
+package flags
+
+import "fmt"
+
+// ProposalSource is the L2 head of the rollup node up to which the proposer proposes outputs.
+type ProposalSource string
+
+const (
+	// FinalizedSource proposes outputs of the finalized L2 head.
+	FinalizedSource ProposalSource = "finalized"
+	// SafeSource proposes outputs of the safe L2 head, which is derived from non-finalized L1 data.
+	SafeSource ProposalSource = "safe"
+	// UnsafeSource proposes outputs of the unsafe L2 head, which is not derived from L1 data yet.
+	UnsafeSource ProposalSource = "unsafe"
+)
+
+var ProposalSources = []ProposalSource{
+	FinalizedSource,
+	SafeSource,
+	UnsafeSource,
+}
+
+func (source ProposalSource) String() string {
+	return string(source)
+}
+
+func (source *ProposalSource) Set(value string) error {
+	if !ValidProposalSource(ProposalSource(value)) {
+		return fmt.Errorf("unknown proposal source: %q", value)
+	}
+	*source = ProposalSource(value)
+	return nil
+}
+
+func (source *ProposalSource) Clone() any {
+	cpy := *source
+	return &cpy
+}
+
+func ValidProposalSource(value ProposalSource) bool {
+	for _, s := range ProposalSources {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
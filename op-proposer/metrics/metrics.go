@@ -2,11 +2,11 @@ package metrics
 
 import (
 	"io"
+	"math/big"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -31,9 +31,17 @@ type Metricer interface {
 
 	opmetrics.RPCMetricer
 
-	StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer
+	StartBalanceMonitor(l log.Logger, client opmetrics.BalanceClient, accounts []opmetrics.BalanceAccount, lowBalanceThreshold *big.Int, interval time.Duration) io.Closer
 
 	RecordL2BlocksProposed(l2ref eth.L2BlockRef)
+	RecordProposalOutcome(outcome string)
+	RecordProposalDeferred(deferred bool, deferredFor time.Duration)
+	RecordOutputRootMismatch()
+	RecordProposalLatency(latency time.Duration)
+	RecordLastProposedBlock(number uint64)
+	RecordProposalLag(blocks int64)
+	RecordOutputVerificationMismatch()
+	RecordOutputVerificationError()
 }
 
 type Metrics struct {
@@ -45,8 +53,21 @@ type Metrics struct {
 	txmetrics.TxMetrics
 	opmetrics.RPCMetrics
 
-	info prometheus.GaugeVec
-	up   prometheus.Gauge
+	info      prometheus.GaugeVec
+	up        prometheus.Gauge
+	proposals *prometheus.CounterVec
+
+	proposalDeferred        prometheus.Gauge
+	proposalDeferredSeconds prometheus.Gauge
+
+	outputRootMismatches prometheus.Counter
+
+	proposalLatency   prometheus.Histogram
+	lastProposedBlock prometheus.Gauge
+	proposalLag       prometheus.Gauge
+
+	outputVerificationMismatches prometheus.Counter
+	outputVerificationErrors     prometheus.Counter
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -81,6 +102,54 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the op-proposer has finished starting up",
 		}),
+		proposals: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "proposals_total",
+			Help:      "Number of proposals of outputs, by outcome",
+		}, []string{
+			"outcome",
+		}),
+		proposalDeferred: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "proposal_deferred",
+			Help:      "1 if proposals are deferred by the L1 fee ceiling, 0 otherwise",
+		}),
+		proposalDeferredSeconds: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "proposal_deferred_seconds",
+			Help:      "Duration of the current deferral of proposals by the L1 fee ceiling, 0 if not deferred",
+		}),
+		outputRootMismatches: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "output_root_mismatches_total",
+			Help:      "Number of outputs proposed on L1 by someone else, with a different output root than the rollup node's. Critical.",
+		}),
+		proposalLatency: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "proposal_latency_seconds",
+			Help:      "Time between the timestamp of a proposed L2 block and the timestamp of the L1 block that includes its proposal.",
+			Buckets:   []float64{60, 120, 300, 600, 900, 1200, 1800, 2700, 3600, 5400, 7200, 10800, 21600},
+		}),
+		lastProposedBlock: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "last_proposed_block",
+			Help:      "Number of the L2 block of the last output proposed by the proposer.",
+		}),
+		proposalLag: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "proposal_lag_blocks",
+			Help:      "Number of L2 blocks between the last proposed block and the finalized head of the rollup node, negative if the last proposed block is not finalized.",
+		}),
+		outputVerificationMismatches: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "output_verification_mismatches_total",
+			Help:      "Number of outputs of the rollup node with a different output root than the independent L2 source, which are not proposed. Critical.",
+		}),
+		outputVerificationErrors: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "output_verification_errors_total",
+			Help:      "Number of outputs that could not be verified, because the independent L2 source was unavailable.",
+		}),
 	}
 }
 
@@ -88,8 +157,8 @@ func (m *Metrics) Registry() *prometheus.Registry {
 	return m.registry
 }
 
-func (m *Metrics) StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer {
-	return opmetrics.LaunchBalanceMetrics(l, m.registry, m.ns, client, account)
+func (m *Metrics) StartBalanceMonitor(l log.Logger, client opmetrics.BalanceClient, accounts []opmetrics.BalanceAccount, lowBalanceThreshold *big.Int, interval time.Duration) io.Closer {
+	return opmetrics.NewBalanceMonitor(l, m.factory, m.ns, client, accounts, lowBalanceThreshold).Start(interval)
 }
 
 // RecordInfo sets a pseudo-metric that contains versioning and
@@ -108,6 +177,75 @@ const (
 	BlockProposed = "proposed"
 )
 
+// Outcomes of the proposal of an output
+const (
+	// ProposalProposed is a proposal tx that landed
+	ProposalProposed = "proposed"
+	// ProposalSuperseded is a proposal that is no longer needed, because the output got proposed by someone else
+	ProposalSuperseded = "superseded"
+	// ProposalReorged is a proposal that is no longer needed, because the L2 block of the output got reorged
+	ProposalReorged = "reorged"
+	// ProposalReverted is a proposal tx that reverted, or would revert
+	ProposalReverted = "reverted"
+	// ProposalFailed is a proposal tx that failed permanently, or after all retries
+	ProposalFailed = "failed"
+	// ProposalPaused is a proposal whose retries were aborted, because the proposer got paused
+	ProposalPaused = "paused"
+	// ProposalRaced is a proposal that lost the race against another proposer, which proposed the same output
+	ProposalRaced = "raced"
+	// ProposalMismatch is a proposal of an output that got proposed by someone else, with a different output root
+	ProposalMismatch = "mismatch"
+)
+
+// RecordProposalOutcome records the outcome of the proposal of an output
+func (m *Metrics) RecordProposalOutcome(outcome string) {
+	m.proposals.WithLabelValues(outcome).Inc()
+}
+
+// RecordProposalDeferred records whether proposals are deferred by the L1 fee ceiling,
+// and for how long the current deferral lasts.
+func (m *Metrics) RecordProposalDeferred(deferred bool, deferredFor time.Duration) {
+	if deferred {
+		m.proposalDeferred.Set(1)
+	} else {
+		m.proposalDeferred.Set(0)
+	}
+	m.proposalDeferredSeconds.Set(deferredFor.Seconds())
+}
+
+// RecordOutputRootMismatch records an output that got proposed by someone else,
+// with a different output root than the rollup node's.
+func (m *Metrics) RecordOutputRootMismatch() {
+	m.outputRootMismatches.Inc()
+}
+
+// RecordOutputVerificationMismatch records an output of the rollup node with a different output root
+// than the output root computed with the independent L2 source.
+func (m *Metrics) RecordOutputVerificationMismatch() {
+	m.outputVerificationMismatches.Inc()
+}
+
+// RecordOutputVerificationError records an output that could not be verified with the independent L2 source.
+func (m *Metrics) RecordOutputVerificationError() {
+	m.outputVerificationErrors.Inc()
+}
+
+// RecordProposalLatency records the time between the timestamp of a proposed L2 block
+// and the timestamp of the L1 block that includes its proposal.
+func (m *Metrics) RecordProposalLatency(latency time.Duration) {
+	m.proposalLatency.Observe(latency.Seconds())
+}
+
+// RecordLastProposedBlock records the number of the L2 block of the last proposed output.
+func (m *Metrics) RecordLastProposedBlock(number uint64) {
+	m.lastProposedBlock.Set(float64(number))
+}
+
+// RecordProposalLag records the number of L2 blocks that the finalized head is ahead of the last proposed block.
+func (m *Metrics) RecordProposalLag(blocks int64) {
+	m.proposalLag.Set(float64(blocks))
+}
+
 // RecordL2BlocksProposed should be called when new L2 block is proposed
 func (m *Metrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {
 	m.RecordL2Ref(BlockProposed, l2ref)
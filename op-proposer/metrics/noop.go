@@ -2,9 +2,9 @@ package metrics
 
 import (
 	"io"
+	"math/big"
+	"time"
 
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -24,7 +24,15 @@ func (*noopMetrics) RecordInfo(version string) {}
 func (*noopMetrics) RecordUp()                 {}
 
 func (*noopMetrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {}
-
-func (*noopMetrics) StartBalanceMetrics(log.Logger, *ethclient.Client, common.Address) io.Closer {
+func (*noopMetrics) RecordProposalOutcome(outcome string)        {}
+func (*noopMetrics) RecordProposalDeferred(bool, time.Duration)  {}
+func (*noopMetrics) RecordOutputRootMismatch()                   {}
+func (*noopMetrics) RecordProposalLatency(time.Duration)         {}
+func (*noopMetrics) RecordLastProposedBlock(uint64)              {}
+func (*noopMetrics) RecordProposalLag(int64)                     {}
+func (*noopMetrics) RecordOutputVerificationMismatch()           {}
+func (*noopMetrics) RecordOutputVerificationError()              {}
+
+func (*noopMetrics) StartBalanceMonitor(log.Logger, opmetrics.BalanceClient, []opmetrics.BalanceAccount, *big.Int, time.Duration) io.Closer {
 	return nil
 }
@@ -1,11 +1,17 @@
 package proposer
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-proposer/flags"
+	"github.com/ethereum-optimism/optimism/op-service/endpoint"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -19,13 +25,10 @@ import (
 type CLIConfig struct {
 	/* Required Params */
 
-	// L1EthRpc is the HTTP provider URL for L1.
-	L1EthRpc string
+	// Endpoints are the RPC URLs of L1 and of the rollup node.
+	Endpoints endpoint.CLIConfig
 
-	// RollupRpc is the HTTP provider URL for the rollup node.
-	RollupRpc string
-
-	// L2OOAddress is the L2OutputOracle contract address.
+	// L2OOAddress is the address of the L2OutputOracle, or DisputeGameFactory contract.
 	L2OOAddress string
 
 	// PollInterval is the delay between querying L2 for more transaction
@@ -36,6 +39,45 @@ type CLIConfig struct {
 	// for L2 blocks derived from non-finalized L1 data.
 	AllowNonFinalized bool
 
+	// ProposalSource is the L2 head up to which outputs are proposed. Non-finalized sources
+	// require AllowNonFinalized. If empty, it is the safe head if AllowNonFinalized, else the finalized head.
+	ProposalSource flags.ProposalSource
+
+	// ProposalMaxRetries is the number of times a proposal tx that failed with a transient error is retried.
+	ProposalMaxRetries uint64
+
+	// ProposalRetryMaxBackoff is the maximum backoff between the retries of a failed proposal tx.
+	ProposalRetryMaxBackoff time.Duration
+
+	// DisputeGameType is the type of the dispute games to create, when proposing to a DisputeGameFactory.
+	DisputeGameType uint
+
+	// ProposalInterval is the interval of the L2 blocks of the outputs to propose, when proposing to a
+	// DisputeGameFactory. A L2OutputOracle only accepts outputs at its submission interval,
+	// so it must be 0 or equal to the submission interval when proposing to a L2OutputOracle.
+	ProposalInterval uint64
+
+	// ProposalTimeInterval is the minimum duration between two proposals of the proposer. 0 disables it.
+	ProposalTimeInterval time.Duration
+
+	// MaxL1BaseFee is the L1 base fee (in GWei) above which proposals are deferred.
+	// 0 disables the base fee ceiling.
+	MaxL1BaseFee float64
+
+	// MaxProposalCost is the estimated cost (in GWei) of a proposal tx at the L1 base fee, above which
+	// proposals are deferred. 0 disables the cost ceiling.
+	MaxProposalCost float64
+
+	// FeeCeilingMaxDelay is the maximum duration that proposals are deferred for by the fee ceilings.
+	FeeCeilingMaxDelay time.Duration
+
+	// L2VerifyRpc is the HTTP provider URL of an L2 execution node, independent of the rollup node,
+	// to verify output roots with. Empty disables the verification.
+	L2VerifyRpc string
+
+	// L2VerifyFailOpen proposes outputs that cannot be verified, because the L2VerifyRpc is unavailable.
+	L2VerifyFailOpen bool
+
 	TxMgrConfig txmgr.CLIConfig
 
 	RPCConfig oprpc.CLIConfig
@@ -44,16 +86,54 @@ type CLIConfig struct {
 
 	MetricsConfig opmetrics.CLIConfig
 
+	BalanceConfig opmetrics.BalanceCLIConfig
+
 	PprofConfig oppprof.CLIConfig
 }
 
 func (c *CLIConfig) Check() error {
+	if err := c.Endpoints.Check(); err != nil {
+		return err
+	}
+	if c.L2VerifyRpc != "" {
+		if err := endpoint.ValidateURL(c.L2VerifyRpc); err != nil {
+			return fmt.Errorf("invalid L2 verification RPC: %w", err)
+		}
+	}
+	if c.ProposalSource != "" {
+		if !flags.ValidProposalSource(c.ProposalSource) {
+			return fmt.Errorf("unknown proposal source: %q", c.ProposalSource)
+		}
+		if c.ProposalSource != flags.FinalizedSource && !c.AllowNonFinalized {
+			return fmt.Errorf("proposal source %q requires allow-non-finalized", c.ProposalSource)
+		}
+	}
+	if c.ProposalRetryMaxBackoff < 0 {
+		return errors.New("proposal retry max backoff cannot be negative")
+	}
+	if c.ProposalTimeInterval < 0 {
+		return errors.New("proposal time interval cannot be negative")
+	}
+	if c.DisputeGameType > math.MaxUint8 {
+		return fmt.Errorf("dispute game type %d out of range", c.DisputeGameType)
+	}
+	if c.MaxL1BaseFee < 0 || c.MaxProposalCost < 0 {
+		return errors.New("max L1 fees cannot be negative")
+	}
+	if (c.MaxL1BaseFee > 0 || c.MaxProposalCost > 0) && c.FeeCeilingMaxDelay <= 0 {
+		return errors.New("fee ceiling max delay must be positive")
+	}
 	if err := c.RPCConfig.Check(); err != nil {
 		return err
 	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return err
 	}
+	if c.MetricsConfig.Enabled {
+		if err := c.BalanceConfig.Check(); err != nil {
+			return err
+		}
+	}
 	if err := c.PprofConfig.Check(); err != nil {
 		return err
 	}
@@ -67,16 +147,56 @@ func (c *CLIConfig) Check() error {
 func NewConfig(ctx *cli.Context) *CLIConfig {
 	return &CLIConfig{
 		// Required Flags
-		L1EthRpc:     ctx.String(flags.L1EthRpcFlag.Name),
-		RollupRpc:    ctx.String(flags.RollupRpcFlag.Name),
+		Endpoints:    endpoint.ReadCLIConfig(ctx),
 		L2OOAddress:  ctx.String(flags.L2OOAddressFlag.Name),
 		PollInterval: ctx.Duration(flags.PollIntervalFlag.Name),
 		TxMgrConfig:  txmgr.ReadCLIConfig(ctx),
 		// Optional Flags
-		AllowNonFinalized: ctx.Bool(flags.AllowNonFinalizedFlag.Name),
-		RPCConfig:         oprpc.ReadCLIConfig(ctx),
-		LogConfig:         oplog.ReadCLIConfig(ctx),
-		MetricsConfig:     opmetrics.ReadCLIConfig(ctx),
-		PprofConfig:       oppprof.ReadCLIConfig(ctx),
+		AllowNonFinalized:       ctx.Bool(flags.AllowNonFinalizedFlag.Name),
+		ProposalSource:          flags.ProposalSource(ctx.String(flags.ProposalSourceFlag.Name)),
+		ProposalMaxRetries:      ctx.Uint64(flags.ProposalMaxRetriesFlag.Name),
+		ProposalRetryMaxBackoff: ctx.Duration(flags.ProposalRetryMaxBackoffFlag.Name),
+		DisputeGameType:         ctx.Uint(flags.DisputeGameTypeFlag.Name),
+		ProposalInterval:        ctx.Uint64(flags.ProposalIntervalFlag.Name),
+		ProposalTimeInterval:    ctx.Duration(flags.ProposalTimeIntervalFlag.Name),
+		MaxL1BaseFee:            ctx.Float64(flags.MaxL1BaseFeeFlag.Name),
+		MaxProposalCost:         ctx.Float64(flags.MaxProposalCostFlag.Name),
+		FeeCeilingMaxDelay:      ctx.Duration(flags.FeeCeilingMaxDelayFlag.Name),
+		L2VerifyRpc:             ctx.String(flags.L2VerifyRpcFlag.Name),
+		L2VerifyFailOpen:        ctx.Bool(flags.L2VerifyFailOpenFlag.Name),
+		RPCConfig:               oprpc.ReadCLIConfig(ctx),
+		LogConfig:               oplog.ReadCLIConfig(ctx),
+		MetricsConfig:           opmetrics.ReadCLIConfig(ctx),
+		BalanceConfig:           opmetrics.ReadBalanceCLIConfig(ctx),
+		PprofConfig:             oppprof.ReadCLIConfig(ctx),
+	}
+}
+
+// FeeCeilingConfig returns the L1 fee ceiling configuration, with the fees converted to wei.
+func (c *CLIConfig) FeeCeilingConfig() FeeCeilingConfig {
+	cfg := FeeCeilingConfig{
+		MaxDelay: c.FeeCeilingMaxDelay,
+	}
+	if c.MaxL1BaseFee > 0 {
+		cfg.MaxBaseFee = gweiToWei(c.MaxL1BaseFee)
+	}
+	if c.MaxProposalCost > 0 {
+		cfg.MaxProposalCost = gweiToWei(c.MaxProposalCost)
+	}
+	return cfg
+}
+
+// OutputVerificationConfig returns the configuration of the verification of output roots with the L2VerifyRpc.
+// The output roots are computed up to 5 times, 2s apart, as the L2VerifyRpc may be behind the rollup node.
+func (c *CLIConfig) OutputVerificationConfig() OutputVerificationConfig {
+	return OutputVerificationConfig{
+		FailOpen:      c.L2VerifyFailOpen,
+		Attempts:      5,
+		RetryInterval: 2 * time.Second,
 	}
 }
+
+func gweiToWei(gwei float64) *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(params.GWei)).Int(nil)
+	return wei
+}
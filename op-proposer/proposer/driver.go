@@ -6,26 +6,34 @@ import (
 	"fmt"
 	"math/big"
 	_ "net/http/pprof"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
-	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-proposer/flags"
 	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-proposer/proposer/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
 var supportedL2OutputVersion = eth.Bytes32{}
 var ErrProposerNotRunning = errors.New("proposer is not running")
 
+// errProposalReverted is returned for a proposal tx that was published, but reverted.
+var errProposalReverted = errors.New("proposal tx reverted")
+
 type L1Client interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 	// CodeAt returns the code of the given account. This is needed to differentiate
@@ -35,6 +43,9 @@ type L1Client interface {
 	// CallContract executes an Ethereum contract call with the specified data as the
 	// input.
 	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+
+	// EstimateGas estimates the gas of a proposal tx, for the fee ceiling.
+	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
 }
 
 type RollupClient interface {
@@ -51,6 +62,9 @@ type DriverSetup struct {
 
 	// RollupProvider's RollupClient() is used to retrieve output roots from
 	RollupProvider dial.RollupProvider
+
+	// VerifyL2Client is used to verify the output roots of the rollup node before proposing them, if set
+	VerifyL2Client L2Client
 }
 
 // L2OutputSubmitter is responsible for proposing outputs
@@ -66,33 +80,49 @@ type L2OutputSubmitter struct {
 	mutex   sync.Mutex
 	running bool
 
-	l2ooContract *bindings.L2OutputOracleCaller
-	l2ooABI      *abi.ABI
+	// paused is set by the admin to pause the proposal of outputs
+	paused atomic.Bool
+	// submitting is true while a proposal tx of an output is submitted
+	submitting atomic.Bool
+
+	lastProposalMu sync.Mutex
+	// lastProposal is the last output proposed by the proposer, lastProposalTx its proposal tx,
+	// and lastProposalTime the time of its proposal
+	lastProposal     *eth.OutputResponse
+	lastProposalTx   common.Hash
+	lastProposalTime time.Time
+
+	// now returns the current time, for the proposal time interval
+	now func() time.Time
+
+	// submitter is the contract that outputs are proposed to, as detected at startup
+	submitter OutputSubmitter
+
+	// rollupClient returns the rollup client of the RollupProvider
+	rollupClient func(ctx context.Context) (RollupClient, error)
+
+	// feeCeiling defers proposals while the L1 fees are high
+	feeCeiling *feeCeiling
+
+	// verifier verifies output roots with the VerifyL2Client, nil if there is none
+	verifier *outputVerifier
 }
 
 // NewL2OutputSubmitter creates a new L2 Output Submitter
 func NewL2OutputSubmitter(setup DriverSetup) (*L2OutputSubmitter, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	l2ooContract, err := bindings.NewL2OutputOracleCaller(setup.Cfg.L2OutputOracleAddr, setup.L1Client)
-	if err != nil {
-		cancel()
-		return nil, fmt.Errorf("failed to create L2OO at address %s: %w", setup.Cfg.L2OutputOracleAddr, err)
-	}
-
 	cCtx, cCancel := context.WithTimeout(ctx, setup.Cfg.NetworkTimeout)
 	defer cCancel()
-	version, err := l2ooContract.Version(&bind.CallOpts{Context: cCtx})
+	submitter, err := DetectOutputSubmitter(cCtx, setup.Log, setup.Cfg, setup.L1Client, setup.Txmgr.From())
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	log.Info("Connected to L2OutputOracle", "address", setup.Cfg.L2OutputOracleAddr, "version", version)
 
-	parsed, err := bindings.L2OutputOracleMetaData.GetAbi()
-	if err != nil {
-		cancel()
-		return nil, err
+	var verifier *outputVerifier
+	if setup.VerifyL2Client != nil {
+		verifier = newOutputVerifier(setup.Cfg.OutputVerification, setup.VerifyL2Client, setup.Log, setup.Metr)
 	}
 
 	return &L2OutputSubmitter{
@@ -101,8 +131,13 @@ func NewL2OutputSubmitter(setup DriverSetup) (*L2OutputSubmitter, error) {
 		ctx:         ctx,
 		cancel:      cancel,
 
-		l2ooContract: l2ooContract,
-		l2ooABI:      parsed,
+		submitter: submitter,
+		rollupClient: func(ctx context.Context) (RollupClient, error) {
+			return setup.RollupProvider.RollupClient(ctx)
+		},
+		feeCeiling: newFeeCeiling(setup.Cfg.FeeCeiling, setup.Log, setup.Metr),
+		now:        time.Now,
+		verifier:   verifier,
 	}, nil
 }
 
@@ -151,24 +186,69 @@ func (l *L2OutputSubmitter) StopL2OutputSubmitting() error {
 	return nil
 }
 
-// FetchNextOutputInfo gets the block number of the next proposal.
-// It returns: the next block number, if the proposal should be made, error
-func (l *L2OutputSubmitter) FetchNextOutputInfo(ctx context.Context) (*eth.OutputResponse, bool, error) {
+// PauseL2OutputSubmitting pauses the proposal of outputs before the next proposal attempt, until
+// ResumeL2OutputSubmitting is called, also across restarts of the proposer loop.
+func (l *L2OutputSubmitter) PauseL2OutputSubmitting() {
+	if !l.paused.Swap(true) {
+		l.Log.Warn("Paused output proposals")
+	}
+}
+
+// ResumeL2OutputSubmitting resumes the proposal of outputs after PauseL2OutputSubmitting.
+func (l *L2OutputSubmitter) ResumeL2OutputSubmitting() {
+	if l.paused.Swap(false) {
+		l.Log.Info("Resumed output proposals")
+	}
+}
+
+// ProposalStatus returns the runtime status of the proposer, with the next block number of the contract.
+func (l *L2OutputSubmitter) ProposalStatus(ctx context.Context) (*rpc.ProposalStatus, error) {
+	l.mutex.Lock()
+	running := l.running
+	l.mutex.Unlock()
+
+	status := &rpc.ProposalStatus{
+		Running: running,
+		State:   rpc.ProposerWaiting,
+	}
+	if l.paused.Load() {
+		status.State = rpc.ProposerPaused
+	} else if l.submitting.Load() {
+		status.State = rpc.ProposerSubmitting
+	}
+	l.lastProposalMu.Lock()
+	if l.lastProposal != nil {
+		status.LastProposedBlock = l.lastProposal.BlockRef.ID()
+		status.LastOutputRoot = l.lastProposal.OutputRoot
+		status.LastProposalTx = l.lastProposalTx
+	}
+	l.lastProposalMu.Unlock()
+
 	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
 	defer cancel()
-	callOpts := &bind.CallOpts{
-		From:    l.Txmgr.From(),
-		Context: cCtx,
+	rollupClient, err := l.rollupClient(cCtx)
+	if err != nil {
+		return nil, fmt.Errorf("getting rollup client: %w", err)
 	}
-	nextCheckpointBlock, err := l.l2ooContract.NextBlockNumber(callOpts)
+	syncStatus, err := rollupClient.SyncStatus(cCtx)
 	if err != nil {
-		l.Log.Error("proposer unable to get next block number", "err", err)
-		return nil, false, err
+		return nil, fmt.Errorf("getting sync status: %w", err)
+	}
+	next, err := l.submitter.NextBlockNumber(cCtx, l.proposalHead(syncStatus).Number)
+	if err != nil {
+		return nil, fmt.Errorf("querying %s for next block number: %w", l.submitter.Kind(), err)
 	}
+	status.NextBlockNumber = next
+	return status, nil
+}
+
+// FetchNextOutputInfo gets the block number of the next proposal.
+// It returns: the next block number, if the proposal should be made, error
+func (l *L2OutputSubmitter) FetchNextOutputInfo(ctx context.Context) (*eth.OutputResponse, bool, error) {
 	// Fetch the current L2 heads
-	cCtx, cancel = context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
 	defer cancel()
-	rollupClient, err := l.RollupProvider.RollupClient(cCtx)
+	rollupClient, err := l.rollupClient(cCtx)
 	if err != nil {
 		l.Log.Error("proposer unable to get rollup client", "err", err)
 		return nil, false, err
@@ -179,27 +259,50 @@ func (l *L2OutputSubmitter) FetchNextOutputInfo(ctx context.Context) (*eth.Outpu
 		return nil, false, err
 	}
 
-	// Use either the finalized or safe head depending on the config. Finalized head is default & safer.
-	var currentBlockNumber *big.Int
-	if l.Cfg.AllowNonFinalized {
-		currentBlockNumber = new(big.Int).SetUint64(status.SafeL2.Number)
-	} else {
-		currentBlockNumber = new(big.Int).SetUint64(status.FinalizedL2.Number)
+	l.recordProposalLag(status)
+
+	if wait := l.proposalTimeIntervalWait(); wait > 0 {
+		l.Log.Debug("proposer time interval has not elapsed", "wait", wait)
+		return nil, false, nil
+	}
+
+	// Use the finalized, safe or unsafe head depending on the config. Finalized head is default & safer.
+	currentBlockNumber := l.proposalHead(status).Number
+	nextCheckpointBlock, err := l.submitter.NextBlockNumber(cCtx, currentBlockNumber)
+	if err != nil {
+		l.Log.Error("proposer unable to get next block number", "err", err, "contract", l.submitter.Kind())
+		return nil, false, err
 	}
 	// Ensure that we do not submit a block in the future
-	if currentBlockNumber.Cmp(nextCheckpointBlock) < 0 {
-		l.Log.Debug("proposer submission interval has not elapsed", "currentBlockNumber", currentBlockNumber, "nextBlockNumber", nextCheckpointBlock)
+	if currentBlockNumber < nextCheckpointBlock {
+		l.Log.Debug("proposer submission interval has not elapsed", "currentBlockNumber", currentBlockNumber, "nextBlockNumber", nextCheckpointBlock,
+			"source", l.Cfg.proposalSource())
 		return nil, false, nil
 	}
 
-	return l.fetchOutput(ctx, nextCheckpointBlock)
+	output, shouldPropose, err := l.fetchOutput(ctx, new(big.Int).SetUint64(nextCheckpointBlock))
+	if err != nil || !shouldPropose {
+		return nil, false, err
+	}
+	cCtx, cancel = context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	proposed, err := l.submitter.IsProposed(cCtx, output)
+	if err != nil {
+		l.Log.Error("proposer unable to check whether the output is proposed", "err", err, "contract", l.submitter.Kind())
+		return nil, false, err
+	}
+	if proposed {
+		l.Log.Debug("output is proposed already", "l2_proposal", output.BlockRef, "contract", l.submitter.Kind())
+		return nil, false, nil
+	}
+	return output, true, nil
 }
 
 func (l *L2OutputSubmitter) fetchOutput(ctx context.Context, block *big.Int) (*eth.OutputResponse, bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
 	defer cancel()
 
-	rollupClient, err := l.RollupProvider.RollupClient(ctx)
+	rollupClient, err := l.rollupClient(ctx)
 	if err != nil {
 		l.Log.Error("proposer unable to get rollup client", "err", err)
 		return nil, false, err
@@ -218,21 +321,35 @@ func (l *L2OutputSubmitter) fetchOutput(ctx context.Context, block *big.Int) (*e
 		return nil, false, errors.New("invalid blockNumber")
 	}
 
-	// Always propose if it's part of the Finalized L2 chain. Or if allowed, if it's part of the safe L2 chain.
-	if !(output.BlockRef.Number <= output.Status.FinalizedL2.Number || (l.Cfg.AllowNonFinalized && output.BlockRef.Number <= output.Status.SafeL2.Number)) {
+	// Always propose if it's part of the Finalized L2 chain. Or if allowed, if it's part of the safe or unsafe L2 chain.
+	if output.BlockRef.Number > l.proposalHead(output.Status).Number {
 		l.Log.Debug("not proposing yet, L2 block is not ready for proposal",
 			"l2_proposal", output.BlockRef,
+			"l2_unsafe", output.Status.UnsafeL2,
 			"l2_safe", output.Status.SafeL2,
 			"l2_finalized", output.Status.FinalizedL2,
-			"allow_non_finalized", l.Cfg.AllowNonFinalized)
+			"allow_non_finalized", l.Cfg.AllowNonFinalized,
+			"source", l.Cfg.proposalSource())
 		return nil, false, nil
 	}
 	return output, true, nil
 }
 
-// ProposeL2OutputTxData creates the transaction data for the ProposeL2Output function
+// proposalHead returns the L2 head of the sync status up to which outputs are proposed, per the proposal source.
+func (l *L2OutputSubmitter) proposalHead(status *eth.SyncStatus) eth.L2BlockRef {
+	switch l.Cfg.proposalSource() {
+	case flags.UnsafeSource:
+		return status.UnsafeL2
+	case flags.SafeSource:
+		return status.SafeL2
+	default:
+		return status.FinalizedL2
+	}
+}
+
+// ProposeL2OutputTxData creates the transaction data to propose the output to the detected contract
 func (l *L2OutputSubmitter) ProposeL2OutputTxData(output *eth.OutputResponse) ([]byte, error) {
-	return proposeL2OutputTxData(l.l2ooABI, output)
+	return l.submitter.ProposalTxData(output)
 }
 
 // proposeL2OutputTxData creates the transaction data for the ProposeL2Output function
@@ -294,15 +411,254 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 	}
 	if receipt.Status == types.ReceiptStatusFailed {
 		l.Log.Error("proposer tx successfully published but reverted", "tx_hash", receipt.TxHash)
-	} else {
-		l.Log.Info("proposer tx successfully published",
-			"tx_hash", receipt.TxHash,
-			"l1blocknum", output.Status.CurrentL1.Number,
-			"l1blockhash", output.Status.CurrentL1.Hash)
+		return errProposalReverted
 	}
+	l.Log.Info("proposer tx successfully published",
+		"tx_hash", receipt.TxHash,
+		"l1blocknum", output.Status.CurrentL1.Number,
+		"l1blockhash", output.Status.CurrentL1.Hash)
+	l.lastProposalMu.Lock()
+	l.lastProposal, l.lastProposalTx, l.lastProposalTime = output, receipt.TxHash, l.now()
+	l.lastProposalMu.Unlock()
+	l.recordProposal(ctx, output, receipt)
 	return nil
 }
 
+// proposalTimeIntervalWait returns the remaining time until the proposal time interval since the last proposal
+// elapsed, or 0 if there is no time interval, or no proposal yet.
+func (l *L2OutputSubmitter) proposalTimeIntervalWait() time.Duration {
+	if l.Cfg.ProposalTimeInterval <= 0 {
+		return 0
+	}
+	l.lastProposalMu.Lock()
+	last := l.lastProposalTime
+	l.lastProposalMu.Unlock()
+	if last.IsZero() {
+		return 0
+	}
+	if wait := l.Cfg.ProposalTimeInterval - l.now().Sub(last); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// recordProposal records the last proposed block, and the latency of the proposal of the output:
+// the time between the timestamp of its L2 block, and the timestamp of the L1 block that includes the proposal tx.
+func (l *L2OutputSubmitter) recordProposal(ctx context.Context, output *eth.OutputResponse, receipt *types.Receipt) {
+	l.Metr.RecordLastProposedBlock(output.BlockRef.Number)
+	l.recordProposalLag(output.Status)
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	header, err := l.L1Client.HeaderByNumber(cCtx, receipt.BlockNumber)
+	if err != nil {
+		l.Log.Warn("Failed to get the L1 block of the proposal tx, to record the proposal latency", "err", err,
+			"tx_hash", receipt.TxHash, "l1_block", receipt.BlockNumber)
+		return
+	}
+	l.Metr.RecordProposalLatency(proposalLatency(header.Time, output.BlockRef.Time))
+}
+
+// recordProposalLag records how many L2 blocks the finalized head of the sync status is ahead of
+// the last proposed block, if there is one.
+func (l *L2OutputSubmitter) recordProposalLag(status *eth.SyncStatus) {
+	l.lastProposalMu.Lock()
+	last := l.lastProposal
+	l.lastProposalMu.Unlock()
+	if last == nil || status == nil {
+		return
+	}
+	l.Metr.RecordProposalLag(int64(status.FinalizedL2.Number) - int64(last.BlockRef.Number))
+}
+
+// proposalLatency returns the time between the timestamps of an L2 block and of the L1 block that includes
+// its proposal, or 0 if the L1 timestamp isn't later.
+func proposalLatency(l1Time, l2Time uint64) time.Duration {
+	if l1Time <= l2Time {
+		return 0
+	}
+	return time.Duration(l1Time-l2Time) * time.Second
+}
+
+// proposeOutput sends the proposal tx of the output, and retries it per the retry policy, and returns the outcome.
+// Stuck proposal txs are fee-bumped by the txmgr. If a proposal tx fails, it is aborted if the output is no longer
+// needed, because it got proposed by someone else or its L2 block got reorged. Else, transient failures, like RPC
+// errors, nonce races or underpriced txs, are retried with an exponential backoff. Reverted txs aren't retried,
+// as the same tx would revert again, and the output is proposed again in a later poll instead.
+// Before every attempt, it is checked whether the output is still needed, as redundant proposers race
+// to propose the same output. Retries are aborted if the proposer got paused.
+func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, output *eth.OutputResponse) string {
+	l.submitting.Store(true)
+	defer l.submitting.Store(false)
+	backoff := &retry.ExponentialStrategy{Max: l.Cfg.ProposalRetryMaxBackoff}
+	for attempt := uint64(0); ; attempt++ {
+		if attempt > 0 && l.paused.Load() {
+			l.Log.Info("Proposer paused, aborting proposal", "l2_proposal", output.BlockRef)
+			return metrics.ProposalPaused
+		}
+		if outcome, needed := l.outputNeeded(ctx, output); !needed {
+			l.Log.Info("Proposal no longer needed, skipping", "l2_proposal", output.BlockRef, "outcome", outcome)
+			return outcome
+		}
+		cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+		err := l.sendTransaction(cCtx, output)
+		cancel()
+		if err == nil {
+			return metrics.ProposalProposed
+		}
+		if ctx.Err() != nil {
+			l.Log.Warn("Proposal aborted", "err", err, "l2_proposal", output.BlockRef)
+			return metrics.ProposalFailed
+		}
+		if outcome, needed := l.outputNeeded(ctx, output); !needed {
+			l.Log.Info("Proposal no longer needed, aborting", "err", err, "revert_reason", revertReason(err),
+				"l2_proposal", output.BlockRef, "outcome", outcome)
+			return outcome
+		}
+		if !isTransientProposalErr(err) {
+			l.Log.Error("Failed to send proposal transaction",
+				"err", err,
+				"revert_reason", revertReason(err),
+				"l1blocknum", output.Status.CurrentL1.Number,
+				"l1blockhash", output.Status.CurrentL1.Hash,
+				"l1head", output.Status.HeadL1.Number)
+			if errors.Is(err, errProposalReverted) || errStringMatch(err, vm.ErrExecutionReverted) {
+				return metrics.ProposalReverted
+			}
+			return metrics.ProposalFailed
+		}
+		if attempt >= l.Cfg.ProposalMaxRetries {
+			l.Log.Error("Failed to send proposal transaction, giving up after retries",
+				"err", err, "retries", attempt, "l2_proposal", output.BlockRef)
+			return metrics.ProposalFailed
+		}
+		wait := backoff.Duration(int(attempt))
+		l.Log.Warn("Failed to send proposal transaction, retrying", "err", err, "l2_proposal", output.BlockRef,
+			"attempt", attempt+1, "backoff", wait)
+		select {
+		case <-time.After(wait):
+		case <-l.done:
+			return metrics.ProposalFailed
+		}
+	}
+}
+
+// outputNeeded returns whether the output still needs to be proposed, or else the outcome of the proposal:
+//   - raced if someone else, e.g. a redundant proposer, proposed the same output root at its L2 block,
+//   - superseded if someone else proposed an output beyond its L2 block,
+//   - mismatch if someone else proposed a different output root at its L2 block than the rollup node's,
+//     which is recorded as a critical error,
+//   - or reorged if the output at its L2 block changed.
+//
+// If the checks fail, the output is assumed to be needed.
+func (l *L2OutputSubmitter) outputNeeded(ctx context.Context, output *eth.OutputResponse) (string, bool) {
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	proposedRoot, proposedAt, err := l.submitter.ProposedOutputRoot(cCtx, output)
+	if err != nil {
+		l.Log.Warn("Failed to check whether the output got proposed", "err", err)
+	}
+	if proposedAt && proposedRoot == output.OutputRoot {
+		return metrics.ProposalRaced, false
+	}
+	if !proposedAt {
+		proposed, err := l.submitter.IsProposed(cCtx, output)
+		if err != nil {
+			l.Log.Warn("Failed to check whether a later output got proposed", "err", err)
+		} else if proposed {
+			return metrics.ProposalSuperseded, false
+		}
+	}
+	rollupClient, err := l.rollupClient(cCtx)
+	if err != nil {
+		l.Log.Warn("Failed to get the rollup client to check whether the output got reorged", "err", err)
+		return "", true
+	}
+	current, err := rollupClient.OutputAtBlock(cCtx, output.BlockRef.Number)
+	if err != nil {
+		l.Log.Warn("Failed to check whether the output got reorged", "err", err)
+		return "", true
+	}
+	if proposedAt && proposedRoot != current.OutputRoot {
+		l.Log.Error("Output proposed by someone else mismatches the output root of the rollup node",
+			"l2_proposal", output.BlockRef, "proposed_root", proposedRoot, "output_root", current.OutputRoot)
+		l.Metr.RecordOutputRootMismatch()
+		return metrics.ProposalMismatch, false
+	}
+	if current.OutputRoot != output.OutputRoot {
+		return metrics.ProposalReorged, false
+	}
+	return "", true
+}
+
+// revertReason returns the decoded revert reason of the error of a proposal tx that would revert, if any.
+func revertReason(err error) string {
+	var dataErr gethrpc.DataError
+	if !errors.As(err, &dataErr) {
+		return ""
+	}
+	data, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return ""
+	}
+	reason, err := abi.UnpackRevert(common.FromHex(data))
+	if err != nil {
+		return ""
+	}
+	return reason
+}
+
+// isTransientProposalErr returns whether sending a proposal tx failed with an error that may not occur again, e.g. an
+// RPC error, a nonce race or an underpriced tx, as opposed to a reverted tx, or a tx that would revert.
+func isTransientProposalErr(err error) bool {
+	return !errors.Is(err, errProposalReverted) && !errStringMatch(err, vm.ErrExecutionReverted)
+}
+
+// errStringMatch returns whether the error message contains the message of the target error,
+// for errors that are returned by RPCs, which can't be matched by type.
+func errStringMatch(err, target error) bool {
+	return err != nil && strings.Contains(err.Error(), target.Error())
+}
+
+// checkFeeCeiling returns whether the output should be proposed under the L1 fee ceiling.
+// If the L1 fees can't be determined, the output is proposed regardless.
+func (l *L2OutputSubmitter) checkFeeCeiling(ctx context.Context, output *eth.OutputResponse) bool {
+	if !l.Cfg.FeeCeiling.Enabled() {
+		return true
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	head, err := l.L1Client.HeaderByNumber(cCtx, nil)
+	if err != nil {
+		l.Log.Warn("Failed to query L1 head for the fee ceiling, proposing regardless", "err", err)
+		return true
+	}
+	var cost *big.Int
+	if l.Cfg.FeeCeiling.MaxProposalCost != nil && head.BaseFee != nil {
+		cost, err = l.estimateProposalCost(cCtx, output, head.BaseFee)
+		if err != nil {
+			l.Log.Warn("Failed to estimate the proposal cost for the fee ceiling", "err", err)
+		}
+	}
+	return l.feeCeiling.ShouldPropose(head.BaseFee, cost)
+}
+
+// estimateProposalCost estimates the cost of the proposal tx of the output at the given L1 base fee.
+func (l *L2OutputSubmitter) estimateProposalCost(ctx context.Context, output *eth.OutputResponse, baseFee *big.Int) (*big.Int, error) {
+	data, err := l.ProposeL2OutputTxData(output)
+	if err != nil {
+		return nil, err
+	}
+	gas, err := l.L1Client.EstimateGas(ctx, ethereum.CallMsg{
+		From: l.Txmgr.From(),
+		To:   &l.Cfg.L2OutputOracleAddr,
+		Data: data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(gas), baseFee), nil
+}
+
 // loop is responsible for creating & submitting the next outputs
 func (l *L2OutputSubmitter) loop() {
 	defer l.wg.Done()
@@ -314,6 +670,9 @@ func (l *L2OutputSubmitter) loop() {
 	for {
 		select {
 		case <-ticker.C:
+			if l.paused.Load() {
+				break
+			}
 			output, shouldPropose, err := l.FetchNextOutputInfo(ctx)
 			if err != nil {
 				break
@@ -321,18 +680,17 @@ func (l *L2OutputSubmitter) loop() {
 			if !shouldPropose {
 				break
 			}
-			cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
-			if err := l.sendTransaction(cCtx, output); err != nil {
-				l.Log.Error("Failed to send proposal transaction",
-					"err", err,
-					"l1blocknum", output.Status.CurrentL1.Number,
-					"l1blockhash", output.Status.CurrentL1.Hash,
-					"l1head", output.Status.HeadL1.Number)
-				cancel()
+			if !l.checkFeeCeiling(ctx, output) {
+				break
+			}
+			if l.verifier != nil && !l.verifier.ShouldPropose(ctx, output, l.Cfg.NetworkTimeout) {
 				break
 			}
-			l.Metr.RecordL2BlocksProposed(output.BlockRef)
-			cancel()
+			outcome := l.proposeOutput(ctx, output)
+			l.Metr.RecordProposalOutcome(outcome)
+			if outcome == metrics.ProposalProposed || outcome == metrics.ProposalRaced {
+				l.Metr.RecordL2BlocksProposed(output.BlockRef)
+			}
 
 		case <-l.done:
 			return
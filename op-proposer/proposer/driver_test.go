@@ -0,0 +1,754 @@
+package proposer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-proposer/flags"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-proposer/proposer/rpc"
+	opcrypto "github.com/ethereum-optimism/optimism/op-service/crypto"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// stubL1Client answers the calls to the L2OutputOracle or DisputeGameFactory with the results that are set
+// by the test, by method name. The calls of other methods revert.
+type stubL1Client struct {
+	L1Client
+	abis    []*abi.ABI
+	code    []byte
+	results map[string][]any
+	// baseFee is the base fee of the L1 head, and gas the gas estimate of all txs
+	baseFee *big.Int
+	gas     uint64
+	// headTime is the timestamp of all L1 blocks
+	headTime uint64
+}
+
+func newStubL1Client(t *testing.T) *stubL1Client {
+	l2ooABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	dgfABI, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	return &stubL1Client{
+		abis:    []*abi.ABI{l2ooABI, dgfABI},
+		code:    []byte{0x01},
+		results: map[string][]any{"version": {"1.0.0"}, "submissionInterval": {big.NewInt(10)}},
+	}
+}
+
+// setNext sets the next block number of the L2OutputOracle.
+func (c *stubL1Client) setNext(next uint64) {
+	c.results["nextBlockNumber"] = []any{new(big.Int).SetUint64(next)}
+}
+
+// setOutputAfter sets the output of the L2OutputOracle that is returned for all blocks by getL2OutputAfter.
+func (c *stubL1Client) setOutputAfter(block uint64, root eth.Bytes32) {
+	c.results["getL2OutputAfter"] = []any{bindings.TypesOutputProposal{
+		OutputRoot:    root,
+		Timestamp:     big.NewInt(1),
+		L2BlockNumber: new(big.Int).SetUint64(block),
+	}}
+}
+
+func (c *stubL1Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(100), BaseFee: c.baseFee, Time: c.headTime}, nil
+}
+
+func (c *stubL1Client) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return c.gas, nil
+}
+
+func (c *stubL1Client) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return c.code, nil
+}
+
+func (c *stubL1Client) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	for _, a := range c.abis {
+		method, err := a.MethodById(call.Data[:4])
+		if err != nil {
+			continue
+		}
+		if result, ok := c.results[method.Name]; ok {
+			return method.Outputs.Pack(result...)
+		}
+	}
+	return nil, errors.New("execution reverted")
+}
+
+// stubTxManager sends txs with the send func that is set by the test, with a L1 head beyond all proposals.
+type stubTxManager struct {
+	txmgr.TxManager
+	send  func(candidate txmgr.TxCandidate) (*types.Receipt, error)
+	sends int
+}
+
+func (m *stubTxManager) From() common.Address {
+	return common.Address{0x01}
+}
+
+func (m *stubTxManager) BlockNumber(ctx context.Context) (uint64, error) {
+	return 100, nil
+}
+
+func (m *stubTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	m.sends++
+	return m.send(candidate)
+}
+
+// stubRollupClient returns the sync status that is set by the test, and outputs at any block.
+type stubRollupClient struct {
+	status *eth.SyncStatus
+	// root is the output root of all blocks
+	root eth.Bytes32
+}
+
+func (c *stubRollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	return c.status, nil
+}
+
+func (c *stubRollupClient) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	return &eth.OutputResponse{
+		Version:    supportedL2OutputVersion,
+		OutputRoot: c.root,
+		BlockRef:   eth.L2BlockRef{Number: blockNum, Time: blockNum * 2},
+		Status:     c.status,
+	}, nil
+}
+
+func newTestL2OutputSubmitter(t *testing.T, cfg ProposerConfig, l1 *stubL1Client, rollup *stubRollupClient) *L2OutputSubmitter {
+	logger := testlog.Logger(t, log.LvlCrit)
+	txMgr := &stubTxManager{}
+	submitter, err := DetectOutputSubmitter(context.Background(), logger, cfg, l1, txMgr.From())
+	require.NoError(t, err)
+	return &L2OutputSubmitter{
+		DriverSetup: DriverSetup{
+			Log:      logger,
+			Metr:     metrics.NoopMetrics,
+			Cfg:      cfg,
+			Txmgr:    txMgr,
+			L1Client: l1,
+		},
+		done:       make(chan struct{}),
+		submitter:  submitter,
+		feeCeiling: newFeeCeiling(cfg.FeeCeiling, logger, metrics.NoopMetrics),
+		now:        time.Now,
+		rollupClient: func(ctx context.Context) (RollupClient, error) {
+			return rollup, nil
+		},
+	}
+}
+
+func TestFetchNextOutputInfoProposalSource(t *testing.T) {
+	status := &eth.SyncStatus{
+		FinalizedL2: eth.L2BlockRef{Number: 10},
+		SafeL2:      eth.L2BlockRef{Number: 20},
+		UnsafeL2:    eth.L2BlockRef{Number: 30},
+	}
+	tests := []struct {
+		name              string
+		allowNonFinalized bool
+		source            flags.ProposalSource
+		next              uint64
+		propose           bool
+	}{
+		{name: "finalized", next: 10, propose: true},
+		{name: "finalized not reached", next: 11},
+		{name: "finalized by config", source: flags.FinalizedSource, allowNonFinalized: true, next: 11},
+		{name: "safe by default", allowNonFinalized: true, next: 20, propose: true},
+		{name: "safe", source: flags.SafeSource, allowNonFinalized: true, next: 20, propose: true},
+		{name: "safe not reached", source: flags.SafeSource, allowNonFinalized: true, next: 21},
+		{name: "unsafe", source: flags.UnsafeSource, allowNonFinalized: true, next: 30, propose: true},
+		{name: "unsafe not reached", source: flags.UnsafeSource, allowNonFinalized: true, next: 31},
+		{name: "unsafe without allow non-finalized", source: flags.UnsafeSource, next: 11},
+	}
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			l1 := newStubL1Client(t)
+			l1.setNext(tc.next)
+			l := newTestL2OutputSubmitter(t, ProposerConfig{
+				NetworkTimeout:    time.Second,
+				AllowNonFinalized: tc.allowNonFinalized,
+				ProposalSource:    tc.source,
+			}, l1, &stubRollupClient{status: status})
+			output, propose, err := l.FetchNextOutputInfo(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tc.propose, propose)
+			if tc.propose {
+				require.Equal(t, tc.next, output.BlockRef.Number)
+			} else {
+				require.Nil(t, output)
+			}
+		})
+	}
+}
+
+func TestProposalSourceConfig(t *testing.T) {
+	cfg := CLIConfig{ProposalSource: flags.UnsafeSource}
+	require.ErrorContains(t, cfg.Check(), `proposal source "unsafe" requires allow-non-finalized`)
+	cfg.ProposalSource = "latest"
+	require.ErrorContains(t, cfg.Check(), "unknown proposal source")
+}
+
+func TestProposeOutputRetries(t *testing.T) {
+	var (
+		errNonceTooLow  = errors.New("nonce too low")
+		errUnderpriced  = errors.New("replacement transaction underpriced")
+		errWouldRevert  = errors.New("failed to create the tx: failed to estimate gas: execution reverted")
+		receipt         = &types.Receipt{Status: types.ReceiptStatusSuccessful}
+		revertedReceipt = &types.Receipt{Status: types.ReceiptStatusFailed}
+	)
+	tests := []struct {
+		name string
+		// results are the results of the sent txs, the last result repeats
+		results []error
+		receipt *types.Receipt
+		// onFailure is called for a failed tx
+		onFailure func(l1 *stubL1Client, rollup *stubRollupClient)
+		outcome   string
+		sends     int
+	}{
+		{name: "proposed", results: []error{nil}, receipt: receipt, outcome: metrics.ProposalProposed, sends: 1},
+		{name: "transient failures", results: []error{errNonceTooLow, errUnderpriced, nil}, receipt: receipt,
+			outcome: metrics.ProposalProposed, sends: 3},
+		{name: "transient failures after retries", results: []error{errUnderpriced}, outcome: metrics.ProposalFailed, sends: 3},
+		{name: "reverted", results: []error{nil}, receipt: revertedReceipt, outcome: metrics.ProposalReverted, sends: 1},
+		{name: "would revert", results: []error{errWouldRevert}, outcome: metrics.ProposalReverted, sends: 1},
+		{name: "superseded", results: []error{errNonceTooLow},
+			onFailure: func(l1 *stubL1Client, rollup *stubRollupClient) { l1.setNext(20) },
+			outcome:   metrics.ProposalSuperseded, sends: 1},
+		{name: "superseded reverted", results: []error{nil}, receipt: revertedReceipt,
+			onFailure: func(l1 *stubL1Client, rollup *stubRollupClient) { l1.setNext(20) },
+			outcome:   metrics.ProposalSuperseded, sends: 1},
+		{name: "reorged", results: []error{errUnderpriced},
+			onFailure: func(l1 *stubL1Client, rollup *stubRollupClient) { rollup.root = eth.Bytes32{0x02} },
+			outcome:   metrics.ProposalReorged, sends: 1},
+	}
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			l1 := newStubL1Client(t)
+			l1.setNext(10)
+			rollup := &stubRollupClient{status: &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 10}}, root: eth.Bytes32{0x01}}
+			l := newTestL2OutputSubmitter(t, ProposerConfig{
+				PollInterval:            time.Millisecond,
+				NetworkTimeout:          time.Second,
+				ProposalMaxRetries:      2,
+				ProposalRetryMaxBackoff: time.Millisecond,
+			}, l1, rollup)
+			txMgr := l.Txmgr.(*stubTxManager)
+			txMgr.send = func(candidate txmgr.TxCandidate) (*types.Receipt, error) {
+				err := tc.results[min(txMgr.sends, len(tc.results))-1]
+				failed := err != nil || tc.receipt.Status == types.ReceiptStatusFailed
+				if failed && tc.onFailure != nil {
+					tc.onFailure(l1, rollup)
+				}
+				if err != nil {
+					return nil, err
+				}
+				return tc.receipt, nil
+			}
+
+			output, propose, err := l.FetchNextOutputInfo(context.Background())
+			require.NoError(t, err)
+			require.True(t, propose)
+			require.Equal(t, tc.outcome, l.proposeOutput(context.Background(), output))
+			require.Equal(t, tc.sends, txMgr.sends)
+		})
+	}
+}
+
+// setDisputeGameFactory makes the stub answer the calls to a DisputeGameFactory, with the implementation of any game type.
+func (c *stubL1Client) setDisputeGameFactory(impl common.Address) {
+	c.results["gameCount"] = []any{big.NewInt(0)}
+	c.results["gameImpls"] = []any{impl}
+	c.results["games"] = []any{common.Address{}, uint64(0)}
+}
+
+func TestDetectOutputSubmitter(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(l1 *stubL1Client)
+		interval uint64
+		kind     string
+		err      string
+	}{
+		{name: "L2OutputOracle", setup: func(l1 *stubL1Client) { l1.setNext(10) }, kind: "L2OutputOracle"},
+		{name: "L2OutputOracle with submission interval", setup: func(l1 *stubL1Client) { l1.setNext(10) },
+			interval: 10, kind: "L2OutputOracle"},
+		{name: "L2OutputOracle with proposal interval above submission interval", setup: func(l1 *stubL1Client) { l1.setNext(10) },
+			interval: 30, err: "proposal interval 30 differs from the submission interval 10"},
+		{name: "L2OutputOracle with proposal interval below submission interval", setup: func(l1 *stubL1Client) { l1.setNext(10) },
+			interval: 5, err: "proposal interval 5 differs from the submission interval 10"},
+		{name: "DisputeGameFactory", setup: func(l1 *stubL1Client) { l1.setDisputeGameFactory(common.Address{0xaa}) },
+			interval: 10, kind: "DisputeGameFactory"},
+		{name: "DisputeGameFactory without proposal interval",
+			setup: func(l1 *stubL1Client) { l1.setDisputeGameFactory(common.Address{0xaa}) },
+			err:   "proposal interval must be set"},
+		{name: "DisputeGameFactory without game implementation",
+			setup:    func(l1 *stubL1Client) { l1.setDisputeGameFactory(common.Address{}) },
+			interval: 10, err: "has no implementation of game type 1"},
+		{name: "no contract", setup: func(l1 *stubL1Client) { l1.code = nil }, err: "no contract at output submission address"},
+		{name: "neither", setup: func(l1 *stubL1Client) {}, err: "is neither a L2OutputOracle nor a DisputeGameFactory"},
+	}
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			l1 := newStubL1Client(t)
+			tc.setup(l1)
+			cfg := ProposerConfig{DisputeGameType: 1, ProposalInterval: tc.interval}
+			submitter, err := DetectOutputSubmitter(context.Background(), testlog.Logger(t, log.LvlCrit), cfg, l1, common.Address{0x01})
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.kind, submitter.Kind())
+		})
+	}
+}
+
+func TestDisputeGameFactorySubmitter(t *testing.T) {
+	l1 := newStubL1Client(t)
+	l1.setDisputeGameFactory(common.Address{0xaa})
+	rollup := &stubRollupClient{status: &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 25}}, root: eth.Bytes32{0x01}}
+	l := newTestL2OutputSubmitter(t, ProposerConfig{
+		NetworkTimeout:   time.Second,
+		DisputeGameType:  1,
+		ProposalInterval: 10,
+	}, l1, rollup)
+
+	for head, next := range map[uint64]uint64{0: 10, 5: 10, 10: 10, 19: 10, 20: 20, 25: 20} {
+		n, err := l.submitter.NextBlockNumber(context.Background(), head)
+		require.NoError(t, err)
+		require.Equal(t, next, n, "next block number at head %d", head)
+	}
+
+	output, propose, err := l.FetchNextOutputInfo(context.Background())
+	require.NoError(t, err)
+	require.True(t, propose)
+	require.Equal(t, uint64(20), output.BlockRef.Number)
+
+	data, err := l.ProposeL2OutputTxData(output)
+	require.NoError(t, err)
+	dgfABI, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	method, err := dgfABI.MethodById(data[:4])
+	require.NoError(t, err)
+	require.Equal(t, "create", method.Name)
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	require.Equal(t, uint8(1), args[0])
+	require.Equal(t, [32]byte(output.OutputRoot), args[1])
+	extraData, err := disputeGameExtraData(output)
+	require.NoError(t, err)
+	require.Equal(t, extraData, args[2])
+	require.Equal(t, big.NewInt(20), new(big.Int).SetBytes(extraData))
+
+	// the game of the output exists already
+	l1.results["games"] = []any{common.Address{0xbb}, uint64(1)}
+	output, propose, err = l.FetchNextOutputInfo(context.Background())
+	require.NoError(t, err)
+	require.False(t, propose)
+	require.Nil(t, output)
+}
+
+type stubSignerHealthAPI struct{}
+
+func (stubSignerHealthAPI) Status() string {
+	return "ok"
+}
+
+// stubSignerEthAPI is a remote signer that signs the txs with its key.
+type stubSignerEthAPI struct {
+	chainID *big.Int
+	key     *ecdsa.PrivateKey
+}
+
+func (a *stubSignerEthAPI) SignTransaction(args opsigner.TransactionArgs) (hexutil.Bytes, error) {
+	signed, err := types.SignTx(args.ToTransaction(), types.LatestSignerForChainID(a.chainID), a.key)
+	if err != nil {
+		return nil, err
+	}
+	return signed.MarshalBinary()
+}
+
+func TestProposeOutputRemoteSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(900)
+	server := gethrpc.NewServer()
+	require.NoError(t, server.RegisterName("health", stubSignerHealthAPI{}))
+	require.NoError(t, server.RegisterName("eth", &stubSignerEthAPI{chainID: chainID, key: key}))
+	signerServer := httptest.NewServer(server)
+	defer signerServer.Close()
+
+	signerFactory, signerAddr, err := opcrypto.SignerFactoryFromConfig(testlog.Logger(t, log.LvlCrit), "", "", "",
+		opsigner.CLIConfig{Endpoint: signerServer.URL, Address: from.Hex()})
+	require.NoError(t, err)
+	require.Equal(t, from, signerAddr)
+	signer := signerFactory(chainID)
+
+	l1 := newStubL1Client(t)
+	l1.setNext(10)
+	rollup := &stubRollupClient{status: &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 10}}, root: eth.Bytes32{0x01}}
+	l := newTestL2OutputSubmitter(t, ProposerConfig{
+		PollInterval:       time.Millisecond,
+		NetworkTimeout:     time.Second,
+		L2OutputOracleAddr: common.Address{0xaa},
+		ProposalMaxRetries: 1,
+	}, l1, rollup)
+	var proposal *types.Transaction
+	l.Txmgr.(*stubTxManager).send = func(candidate txmgr.TxCandidate) (*types.Receipt, error) {
+		proposal, err = signer(context.Background(), signerAddr, types.NewTx(&types.DynamicFeeTx{
+			ChainID: chainID,
+			Gas:     candidate.GasLimit,
+			To:      candidate.To,
+			Data:    candidate.TxData,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: proposal.Hash()}, nil
+	}
+
+	output, propose, err := l.FetchNextOutputInfo(context.Background())
+	require.NoError(t, err)
+	require.True(t, propose)
+	require.Equal(t, metrics.ProposalProposed, l.proposeOutput(context.Background(), output))
+
+	require.NotNil(t, proposal)
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), proposal)
+	require.NoError(t, err)
+	require.Equal(t, from, sender)
+	require.Equal(t, l.Cfg.L2OutputOracleAddr, *proposal.To())
+	data, err := l.ProposeL2OutputTxData(output)
+	require.NoError(t, err)
+	require.Equal(t, data, proposal.Data())
+}
+
+func TestProposalStatusPauseResume(t *testing.T) {
+	l1 := newStubL1Client(t)
+	l1.setNext(10)
+	rollup := &stubRollupClient{status: &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 10}}, root: eth.Bytes32{0x01}}
+	l := newTestL2OutputSubmitter(t, ProposerConfig{
+		PollInterval:            time.Millisecond,
+		NetworkTimeout:          time.Second,
+		ProposalMaxRetries:      2,
+		ProposalRetryMaxBackoff: time.Millisecond,
+	}, l1, rollup)
+	txMgr := l.Txmgr.(*stubTxManager)
+	txHash := common.Hash{0xcc}
+	txMgr.send = func(candidate txmgr.TxCandidate) (*types.Receipt, error) {
+		return &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: txHash}, nil
+	}
+
+	status, err := l.ProposalStatus(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, &rpc.ProposalStatus{State: rpc.ProposerWaiting, NextBlockNumber: 10}, status)
+
+	output, propose, err := l.FetchNextOutputInfo(context.Background())
+	require.NoError(t, err)
+	require.True(t, propose)
+	require.Equal(t, metrics.ProposalProposed, l.proposeOutput(context.Background(), output))
+	l1.setNext(20)
+	status, err = l.ProposalStatus(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, &rpc.ProposalStatus{
+		State:             rpc.ProposerWaiting,
+		LastProposedBlock: eth.BlockID{Number: 10},
+		LastOutputRoot:    rollup.root,
+		LastProposalTx:    txHash,
+		NextBlockNumber:   20,
+	}, status)
+
+	// pausing while submitting aborts the retries
+	l1.setNext(10)
+	txMgr.send = func(candidate txmgr.TxCandidate) (*types.Receipt, error) {
+		status, err := l.ProposalStatus(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, rpc.ProposerSubmitting, status.State)
+		l.PauseL2OutputSubmitting()
+		return nil, errors.New("nonce too low")
+	}
+	txMgr.sends = 0
+	require.Equal(t, metrics.ProposalPaused, l.proposeOutput(context.Background(), output))
+	require.Equal(t, 1, txMgr.sends)
+	status, err = l.ProposalStatus(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, rpc.ProposerPaused, status.State)
+
+	// the paused loop doesn't propose
+	txMgr.sends = 0
+	l.ctx, l.cancel = context.WithCancel(context.Background())
+	require.NoError(t, l.StartL2OutputSubmitting())
+	time.Sleep(20 * l.Cfg.PollInterval)
+	require.NoError(t, l.StopL2OutputSubmitting())
+	require.Zero(t, txMgr.sends)
+
+	l.ResumeL2OutputSubmitting()
+	status, err = l.ProposalStatus(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, rpc.ProposerWaiting, status.State)
+}
+
+// mismatchMetrics counts the recorded output root mismatches.
+type mismatchMetrics struct {
+	metrics.Metricer
+	mismatches int
+}
+
+func (m *mismatchMetrics) RecordOutputRootMismatch() {
+	m.mismatches++
+}
+
+// TestProposeOutputRace tests the outcomes of a proposal when a redundant proposer proposes at the same time.
+func TestProposeOutputRace(t *testing.T) {
+	root := eth.Bytes32{0x01}
+	tests := []struct {
+		name string
+		// before is the output that is proposed by the other proposer before the proposal tx is sent,
+		// and raced the output that it proposes while the proposal tx is sent, which makes it revert.
+		before, raced *bindings.TypesOutputProposal
+		outcome       string
+		sends         int
+		mismatches    int
+	}{
+		{name: "raced", raced: &bindings.TypesOutputProposal{OutputRoot: root, L2BlockNumber: big.NewInt(10)},
+			outcome: metrics.ProposalRaced, sends: 1},
+		{name: "mismatch", raced: &bindings.TypesOutputProposal{OutputRoot: eth.Bytes32{0x02}, L2BlockNumber: big.NewInt(10)},
+			outcome: metrics.ProposalMismatch, sends: 1, mismatches: 1},
+		{name: "superseded", raced: &bindings.TypesOutputProposal{OutputRoot: eth.Bytes32{0x02}, L2BlockNumber: big.NewInt(20)},
+			outcome: metrics.ProposalSuperseded, sends: 1},
+		{name: "proposed before sending", before: &bindings.TypesOutputProposal{OutputRoot: root, L2BlockNumber: big.NewInt(10)},
+			outcome: metrics.ProposalRaced},
+		{name: "mismatch before sending", before: &bindings.TypesOutputProposal{OutputRoot: eth.Bytes32{0x02}, L2BlockNumber: big.NewInt(10)},
+			outcome: metrics.ProposalMismatch, mismatches: 1},
+	}
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			l1 := newStubL1Client(t)
+			l1.setNext(10)
+			rollup := &stubRollupClient{status: &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 10}}, root: root}
+			l := newTestL2OutputSubmitter(t, ProposerConfig{
+				PollInterval:            time.Millisecond,
+				NetworkTimeout:          time.Second,
+				ProposalMaxRetries:      2,
+				ProposalRetryMaxBackoff: time.Millisecond,
+			}, l1, rollup)
+			metr := &mismatchMetrics{Metricer: metrics.NoopMetrics}
+			l.Metr = metr
+			propose := func(proposal *bindings.TypesOutputProposal) {
+				l1.setNext(proposal.L2BlockNumber.Uint64() + 10)
+				l1.setOutputAfter(proposal.L2BlockNumber.Uint64(), proposal.OutputRoot)
+			}
+			txMgr := l.Txmgr.(*stubTxManager)
+			txMgr.send = func(candidate txmgr.TxCandidate) (*types.Receipt, error) {
+				propose(tc.raced)
+				return &types.Receipt{Status: types.ReceiptStatusFailed}, nil
+			}
+
+			output, shouldPropose, err := l.FetchNextOutputInfo(context.Background())
+			require.NoError(t, err)
+			require.True(t, shouldPropose)
+			if tc.before != nil {
+				propose(tc.before)
+			}
+			require.Equal(t, tc.outcome, l.proposeOutput(context.Background(), output))
+			require.Equal(t, tc.sends, txMgr.sends)
+			require.Equal(t, tc.mismatches, metr.mismatches)
+		})
+	}
+}
+
+// revertError is a JSON-RPC error of a call that reverted, with the revert data.
+type revertError struct {
+	data string
+}
+
+func (e *revertError) Error() string          { return "execution reverted" }
+func (e *revertError) ErrorData() interface{} { return e.data }
+
+func TestRevertReason(t *testing.T) {
+	reason := "L2OutputOracle: block number must be equal to next expected block number"
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	packed, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	require.NoError(t, err)
+	data := hexutil.Encode(append(common.FromHex("0x08c379a0"), packed...))
+
+	err = fmt.Errorf("failed to estimate gas: %w", &revertError{data: data})
+	require.Equal(t, reason, revertReason(err))
+	require.Empty(t, revertReason(errors.New("execution reverted")), "without revert data")
+}
+
+// proposalMetrics records the proposal latency, last proposed block and proposal lag metrics.
+type proposalMetrics struct {
+	metrics.Metricer
+	latencies         []time.Duration
+	lastProposedBlock uint64
+	lag               int64
+}
+
+func (m *proposalMetrics) RecordProposalLatency(latency time.Duration) {
+	m.latencies = append(m.latencies, latency)
+}
+
+func (m *proposalMetrics) RecordLastProposedBlock(number uint64) {
+	m.lastProposedBlock = number
+}
+
+func (m *proposalMetrics) RecordProposalLag(blocks int64) {
+	m.lag = blocks
+}
+
+func TestProposalLatencyMetrics(t *testing.T) {
+	l1 := newStubL1Client(t)
+	l1.setNext(10)
+	// the L2 block 10 has the timestamp 20
+	l1.headTime = 20 + 600
+	rollup := &stubRollupClient{status: &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 10}}, root: eth.Bytes32{0x01}}
+	l := newTestL2OutputSubmitter(t, ProposerConfig{
+		PollInterval:       time.Millisecond,
+		NetworkTimeout:     time.Second,
+		ProposalMaxRetries: 2,
+	}, l1, rollup)
+	metr := &proposalMetrics{Metricer: metrics.NoopMetrics}
+	l.Metr = metr
+	txMgr := l.Txmgr.(*stubTxManager)
+	txMgr.send = func(candidate txmgr.TxCandidate) (*types.Receipt, error) {
+		return &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(100)}, nil
+	}
+
+	output, propose, err := l.FetchNextOutputInfo(context.Background())
+	require.NoError(t, err)
+	require.True(t, propose)
+	require.Equal(t, metrics.ProposalProposed, l.proposeOutput(context.Background(), output))
+	require.Equal(t, []time.Duration{10 * time.Minute}, metr.latencies)
+	require.Equal(t, uint64(10), metr.lastProposedBlock)
+	require.Zero(t, metr.lag)
+
+	l1.setNext(20)
+	rollup.status = &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 25}}
+	_, _, err = l.FetchNextOutputInfo(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(15), metr.lag)
+
+	rollup.status = &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 5}}
+	_, _, err = l.FetchNextOutputInfo(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(-5), metr.lag, "last proposed block not finalized")
+}
+
+func TestProposalLatency(t *testing.T) {
+	require.Equal(t, 90*time.Second, proposalLatency(100, 10))
+	require.Zero(t, proposalLatency(10, 10))
+	require.Zero(t, proposalLatency(10, 100), "L1 block before the L2 block")
+}
+
+// TestProposalInterval tests the cadence of the proposals to a L2OutputOracle with a submission interval of 10,
+// over a simulated chain of which the finalized head advances by a block per cycle.
+func TestProposalInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval uint64
+		// proposals are the finalized heads at which the outputs of the L2 blocks are proposed
+		proposals map[uint64]uint64
+	}{
+		{name: "submission interval", proposals: map[uint64]uint64{10: 10, 20: 20, 30: 30, 40: 40, 50: 50, 60: 60}},
+		{name: "equal to submission interval", interval: 10,
+			proposals: map[uint64]uint64{10: 10, 20: 20, 30: 30, 40: 40, 50: 50, 60: 60}},
+	}
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			l1 := newStubL1Client(t)
+			l1.setNext(10)
+			rollup := &stubRollupClient{root: eth.Bytes32{0x01}}
+			l := newTestL2OutputSubmitter(t, ProposerConfig{
+				PollInterval:       time.Millisecond,
+				NetworkTimeout:     time.Second,
+				ProposalMaxRetries: 2,
+				ProposalInterval:   tc.interval,
+			}, l1, rollup)
+			txMgr := l.Txmgr.(*stubTxManager)
+			var block uint64
+			txMgr.send = func(candidate txmgr.TxCandidate) (*types.Receipt, error) {
+				l1.setNext(block + 10)
+				return &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(100)}, nil
+			}
+
+			proposals := make(map[uint64]uint64)
+			for head := uint64(0); head <= 60; head++ {
+				rollup.status = &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: head}}
+				output, propose, err := l.FetchNextOutputInfo(context.Background())
+				require.NoError(t, err)
+				if !propose {
+					continue
+				}
+				block = output.BlockRef.Number
+				require.Equal(t, metrics.ProposalProposed, l.proposeOutput(context.Background(), output))
+				proposals[block] = head
+			}
+			require.Equal(t, tc.proposals, proposals)
+		})
+	}
+}
+
+func TestProposalTimeInterval(t *testing.T) {
+	l1 := newStubL1Client(t)
+	l1.setNext(10)
+	rollup := &stubRollupClient{status: &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 50}}, root: eth.Bytes32{0x01}}
+	l := newTestL2OutputSubmitter(t, ProposerConfig{
+		PollInterval:         time.Millisecond,
+		NetworkTimeout:       time.Second,
+		ProposalMaxRetries:   2,
+		ProposalTimeInterval: time.Minute,
+	}, l1, rollup)
+	now := time.Unix(1000, 0)
+	l.now = func() time.Time { return now }
+	txMgr := l.Txmgr.(*stubTxManager)
+	txMgr.send = func(candidate txmgr.TxCandidate) (*types.Receipt, error) {
+		return &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(100)}, nil
+	}
+
+	output, propose, err := l.FetchNextOutputInfo(context.Background())
+	require.NoError(t, err)
+	require.True(t, propose, "no proposal yet")
+	require.Equal(t, metrics.ProposalProposed, l.proposeOutput(context.Background(), output))
+	l1.setNext(20)
+
+	now = now.Add(59 * time.Second)
+	_, propose, err = l.FetchNextOutputInfo(context.Background())
+	require.NoError(t, err)
+	require.False(t, propose, "time interval not elapsed")
+
+	now = now.Add(time.Second)
+	output, propose, err = l.FetchNextOutputInfo(context.Background())
+	require.NoError(t, err)
+	require.True(t, propose, "time interval elapsed")
+	require.Equal(t, uint64(20), output.BlockRef.Number)
+}
@@ -0,0 +1,88 @@
+package proposer
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+)
+
+// FeeCeilingConfig configures the deferral of proposals while the L1 fees are high.
+type FeeCeilingConfig struct {
+	// MaxBaseFee is the L1 base fee (in wei) above which proposals are deferred.
+	// If nil, the base fee is not limited.
+	MaxBaseFee *big.Int
+	// MaxProposalCost is the estimated cost (in wei) of a proposal tx at the L1 base fee, above which
+	// proposals are deferred. If nil, the cost is not limited.
+	MaxProposalCost *big.Int
+	// MaxDelay is the maximum duration that proposals are deferred for, after which the next output
+	// is proposed regardless of the L1 fees.
+	MaxDelay time.Duration
+}
+
+// Enabled returns whether any fee ceiling is set.
+func (c *FeeCeilingConfig) Enabled() bool {
+	return c.MaxBaseFee != nil || c.MaxProposalCost != nil
+}
+
+// feeCeiling defers proposals when the L1 fees exceed the configured ceilings.
+type feeCeiling struct {
+	cfg  FeeCeilingConfig
+	log  log.Logger
+	metr metrics.Metricer
+	now  func() time.Time
+
+	// deferredSince is the time at which proposals got deferred, zero if proposals are not deferred.
+	deferredSince time.Time
+}
+
+func newFeeCeiling(cfg FeeCeilingConfig, log log.Logger, metr metrics.Metricer) *feeCeiling {
+	return &feeCeiling{
+		cfg:  cfg,
+		log:  log,
+		metr: metr,
+		now:  time.Now,
+	}
+}
+
+// ShouldPropose returns whether an output should be proposed at the given L1 base fee and estimated proposal cost.
+// Proposals are deferred while the base fee or cost is above its ceiling, until it fell below, or until the
+// deferral lasted MaxDelay, so that the output cadence is kept. Unknown fees are never above the ceiling.
+func (f *feeCeiling) ShouldPropose(baseFee, cost *big.Int) bool {
+	if !f.cfg.Enabled() {
+		return true
+	}
+	above := aboveCeiling(baseFee, f.cfg.MaxBaseFee) || aboveCeiling(cost, f.cfg.MaxProposalCost)
+	if f.deferredSince.IsZero() {
+		if !above {
+			return true
+		}
+		f.deferredSince = f.now()
+		f.log.Warn("Deferring proposals, L1 fees are above the fee ceiling",
+			"base_fee", baseFee, "max_base_fee", f.cfg.MaxBaseFee, "cost", cost, "max_cost", f.cfg.MaxProposalCost)
+	}
+
+	deferredFor := f.now().Sub(f.deferredSince)
+	switch {
+	case !above:
+		f.log.Info("Resuming proposals, L1 fees fell below the fee ceiling",
+			"base_fee", baseFee, "cost", cost, "deferred_for", deferredFor)
+	case deferredFor >= f.cfg.MaxDelay:
+		f.log.Warn("Forcing proposal, deferred for the max delay despite high L1 fees",
+			"base_fee", baseFee, "cost", cost, "deferred_for", deferredFor)
+	default:
+		f.log.Debug("Proposals deferred, L1 fees are high", "base_fee", baseFee, "cost", cost, "deferred_for", deferredFor)
+		f.metr.RecordProposalDeferred(true, deferredFor)
+		return false
+	}
+	f.deferredSince = time.Time{}
+	f.metr.RecordProposalDeferred(false, 0)
+	return true
+}
+
+// aboveCeiling returns whether fee is above ceiling. Unknown fees and unset ceilings are never above.
+func aboveCeiling(fee, ceiling *big.Int) bool {
+	return fee != nil && ceiling != nil && fee.Cmp(ceiling) > 0
+}
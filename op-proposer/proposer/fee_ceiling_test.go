@@ -0,0 +1,110 @@
+package proposer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// deferredMetrics records the proposal deferral state.
+type deferredMetrics struct {
+	metrics.Metricer
+	deferred    bool
+	deferredFor time.Duration
+}
+
+func (m *deferredMetrics) RecordProposalDeferred(deferred bool, deferredFor time.Duration) {
+	m.deferred, m.deferredFor = deferred, deferredFor
+}
+
+func newTestFeeCeiling(t *testing.T, cfg FeeCeilingConfig) (*feeCeiling, *deferredMetrics, *time.Time) {
+	m := &deferredMetrics{Metricer: metrics.NoopMetrics}
+	f := newFeeCeiling(cfg, testlog.Logger(t, log.LvlCrit), m)
+	now := time.Unix(1000, 0)
+	f.now = func() time.Time { return now }
+	return f, m, &now
+}
+
+func TestFeeCeilingDisabled(t *testing.T) {
+	f, _, _ := newTestFeeCeiling(t, FeeCeilingConfig{})
+	require.True(t, f.ShouldPropose(big.NewInt(1e15), big.NewInt(1e18)))
+}
+
+func TestFeeCeilingDeferral(t *testing.T) {
+	f, m, now := newTestFeeCeiling(t, FeeCeilingConfig{
+		MaxBaseFee:      big.NewInt(100),
+		MaxProposalCost: big.NewInt(1000),
+		MaxDelay:        time.Hour,
+	})
+
+	require.True(t, f.ShouldPropose(big.NewInt(100), big.NewInt(1000)), "at the ceilings")
+
+	require.False(t, f.ShouldPropose(big.NewInt(101), big.NewInt(1000)), "base fee above the ceiling")
+	require.True(t, m.deferred)
+	*now = now.Add(time.Minute)
+	require.False(t, f.ShouldPropose(big.NewInt(100), big.NewInt(1001)), "cost above the ceiling")
+	require.True(t, m.deferred)
+	require.Equal(t, time.Minute, m.deferredFor)
+
+	require.True(t, f.ShouldPropose(big.NewInt(90), nil), "below the ceilings, with an unknown cost")
+	require.False(t, m.deferred)
+	require.Zero(t, m.deferredFor)
+}
+
+func TestFeeCeilingMaxDelay(t *testing.T) {
+	f, m, now := newTestFeeCeiling(t, FeeCeilingConfig{
+		MaxBaseFee: big.NewInt(100),
+		MaxDelay:   time.Hour,
+	})
+
+	require.False(t, f.ShouldPropose(big.NewInt(200), nil))
+	*now = now.Add(time.Hour - time.Second)
+	require.False(t, f.ShouldPropose(big.NewInt(200), nil))
+	require.Equal(t, time.Hour-time.Second, m.deferredFor)
+
+	*now = now.Add(time.Second)
+	require.True(t, f.ShouldPropose(big.NewInt(200), nil), "forced at the max delay")
+	require.False(t, m.deferred)
+
+	require.False(t, f.ShouldPropose(big.NewInt(200), nil), "the next proposal is deferred again")
+	require.True(t, m.deferred)
+	require.Zero(t, m.deferredFor)
+}
+
+func TestCheckFeeCeiling(t *testing.T) {
+	l1 := newStubL1Client(t)
+	l1.setNext(10)
+	l1.baseFee = big.NewInt(10)
+	l1.gas = 100
+	rollup := &stubRollupClient{status: &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 10}}, root: eth.Bytes32{0x01}}
+	l := newTestL2OutputSubmitter(t, ProposerConfig{
+		NetworkTimeout: time.Second,
+		FeeCeiling: FeeCeilingConfig{
+			MaxBaseFee:      big.NewInt(10),
+			MaxProposalCost: big.NewInt(1000),
+			MaxDelay:        time.Hour,
+		},
+	}, l1, rollup)
+	now := time.Unix(1000, 0)
+	l.feeCeiling.now = func() time.Time { return now }
+	output, propose, err := l.FetchNextOutputInfo(context.Background())
+	require.NoError(t, err)
+	require.True(t, propose)
+
+	require.True(t, l.checkFeeCeiling(context.Background(), output), "at the ceilings")
+	l1.gas = 101
+	require.False(t, l.checkFeeCeiling(context.Background(), output), "cost above the ceiling")
+	l1.gas = 100
+	l1.baseFee = big.NewInt(11)
+	require.False(t, l.checkFeeCeiling(context.Background(), output), "base fee above the ceiling")
+	now = now.Add(time.Hour)
+	require.True(t, l.checkFeeCeiling(context.Background(), output), "forced at the max delay")
+}
@@ -0,0 +1,242 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// OutputSubmitter is the contract that outputs are proposed to: the legacy L2OutputOracle,
+// or a DisputeGameFactory, which creates a dispute game per proposed output.
+type OutputSubmitter interface {
+	// Kind is the kind of the contract, for logging.
+	Kind() string
+	// NextBlockNumber returns the number of the L2 block of the next output to propose,
+	// given the L2 head up to which outputs are proposed.
+	NextBlockNumber(ctx context.Context, head uint64) (uint64, error)
+	// IsProposed returns whether the output is proposed already, e.g. by someone else.
+	IsProposed(ctx context.Context, output *eth.OutputResponse) (bool, error)
+	// ProposedOutputRoot returns the output root that is proposed at the L2 block of the output,
+	// or false if there is no proposal at that block.
+	ProposedOutputRoot(ctx context.Context, output *eth.OutputResponse) (eth.Bytes32, bool, error)
+	// ProposalTxData returns the data of the tx that proposes the output.
+	ProposalTxData(output *eth.OutputResponse) ([]byte, error)
+}
+
+// DetectOutputSubmitter probes the contract at the configured address, and returns the OutputSubmitter
+// of the matching contract. It fails if the contract is neither a L2OutputOracle nor a DisputeGameFactory.
+func DetectOutputSubmitter(ctx context.Context, log log.Logger, cfg ProposerConfig, l1 L1Client, from common.Address) (OutputSubmitter, error) {
+	addr := cfg.L2OutputOracleAddr
+	code, err := l1.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code at output submission contract %v: %w", addr, err)
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("no contract at output submission address %v", addr)
+	}
+	callOpts := &bind.CallOpts{From: from, Context: ctx}
+
+	l2oo, err := newL2OOSubmitter(addr, l1, from)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := l2oo.contract.NextBlockNumber(callOpts); err == nil {
+		version, err := l2oo.contract.Version(callOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get version of L2OutputOracle %v: %w", addr, err)
+		}
+		submissionInterval, err := l2oo.contract.SubmissionInterval(callOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get submission interval of L2OutputOracle %v: %w", addr, err)
+		}
+		// The oracle only accepts the output at its next block number, every submission interval:
+		// a different proposal interval cannot change the cadence of the outputs.
+		if cfg.ProposalInterval != 0 && cfg.ProposalInterval != submissionInterval.Uint64() {
+			return nil, fmt.Errorf("proposal interval %d differs from the submission interval %d of L2OutputOracle %v",
+				cfg.ProposalInterval, submissionInterval, addr)
+		}
+		log.Info("Connected to L2OutputOracle", "address", addr, "version", version,
+			"submission_interval", submissionInterval)
+		return l2oo, nil
+	}
+
+	dgf, err := newDisputeGameFactorySubmitter(addr, l1, from, cfg.DisputeGameType, cfg.ProposalInterval)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dgf.contract.GameCount(callOpts); err == nil {
+		version, err := dgf.contract.Version(callOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get version of DisputeGameFactory %v: %w", addr, err)
+		}
+		if cfg.ProposalInterval == 0 {
+			return nil, fmt.Errorf("proposal interval must be set to propose outputs to DisputeGameFactory %v", addr)
+		}
+		impl, err := dgf.contract.GameImpls(callOpts, cfg.DisputeGameType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get implementation of game type %d: %w", cfg.DisputeGameType, err)
+		}
+		if impl == (common.Address{}) {
+			return nil, fmt.Errorf("DisputeGameFactory %v has no implementation of game type %d", addr, cfg.DisputeGameType)
+		}
+		log.Info("Connected to DisputeGameFactory", "address", addr, "version", version,
+			"game_type", cfg.DisputeGameType, "game_impl", impl, "proposal_interval", cfg.ProposalInterval)
+		return dgf, nil
+	}
+	return nil, fmt.Errorf("contract at %v is neither a L2OutputOracle nor a DisputeGameFactory", addr)
+}
+
+// l2ooSubmitter proposes outputs to the L2OutputOracle, at the L2 blocks of its submission interval.
+type l2ooSubmitter struct {
+	contract *bindings.L2OutputOracleCaller
+	abi      *abi.ABI
+	from     common.Address
+}
+
+func newL2OOSubmitter(addr common.Address, l1 L1Client, from common.Address) (*l2ooSubmitter, error) {
+	contract, err := bindings.NewL2OutputOracleCaller(addr, l1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create L2OO at address %s: %w", addr, err)
+	}
+	parsed, err := bindings.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &l2ooSubmitter{contract: contract, abi: parsed, from: from}, nil
+}
+
+func (s *l2ooSubmitter) Kind() string {
+	return "L2OutputOracle"
+}
+
+// NextBlockNumber returns the next block number of the L2OutputOracle, regardless of the head.
+func (s *l2ooSubmitter) NextBlockNumber(ctx context.Context, head uint64) (uint64, error) {
+	return s.nextBlockNumber(ctx)
+}
+
+func (s *l2ooSubmitter) nextBlockNumber(ctx context.Context) (uint64, error) {
+	next, err := s.contract.NextBlockNumber(&bind.CallOpts{From: s.from, Context: ctx})
+	if err != nil {
+		return 0, err
+	}
+	return next.Uint64(), nil
+}
+
+// IsProposed returns whether the L2OutputOracle moved beyond the L2 block of the output.
+func (s *l2ooSubmitter) IsProposed(ctx context.Context, output *eth.OutputResponse) (bool, error) {
+	next, err := s.nextBlockNumber(ctx)
+	if err != nil {
+		return false, err
+	}
+	return next > output.BlockRef.Number, nil
+}
+
+// ProposedOutputRoot returns the root of the first output at or after the L2 block of the output,
+// if that output is at the L2 block.
+func (s *l2ooSubmitter) ProposedOutputRoot(ctx context.Context, output *eth.OutputResponse) (eth.Bytes32, bool, error) {
+	proposed, err := s.IsProposed(ctx, output)
+	if err != nil || !proposed {
+		return eth.Bytes32{}, false, err
+	}
+	block := new(big.Int).SetUint64(output.BlockRef.Number)
+	proposal, err := s.contract.GetL2OutputAfter(&bind.CallOpts{From: s.from, Context: ctx}, block)
+	if err != nil {
+		return eth.Bytes32{}, false, err
+	}
+	if proposal.L2BlockNumber.Cmp(block) != 0 {
+		return eth.Bytes32{}, false, nil
+	}
+	return proposal.OutputRoot, true, nil
+}
+
+func (s *l2ooSubmitter) ProposalTxData(output *eth.OutputResponse) ([]byte, error) {
+	return proposeL2OutputTxData(s.abi, output)
+}
+
+// disputeGameFactorySubmitter proposes outputs to a DisputeGameFactory, by creating a dispute game of
+// the configured game type per output, at the L2 blocks that are multiples of the proposal interval.
+type disputeGameFactorySubmitter struct {
+	contract *bindings.DisputeGameFactoryCaller
+	abi      *abi.ABI
+	from     common.Address
+	gameType uint8
+	interval uint64
+}
+
+func newDisputeGameFactorySubmitter(addr common.Address, l1 L1Client, from common.Address, gameType uint8, interval uint64) (*disputeGameFactorySubmitter, error) {
+	contract, err := bindings.NewDisputeGameFactoryCaller(addr, l1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DisputeGameFactory at address %s: %w", addr, err)
+	}
+	parsed, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &disputeGameFactorySubmitter{contract: contract, abi: parsed, from: from, gameType: gameType, interval: interval}, nil
+}
+
+func (s *disputeGameFactorySubmitter) Kind() string {
+	return "DisputeGameFactory"
+}
+
+// NextBlockNumber returns the last multiple of the proposal interval up to the head.
+// Before the first interval, it returns the first interval, which isn't reached yet.
+func (s *disputeGameFactorySubmitter) NextBlockNumber(ctx context.Context, head uint64) (uint64, error) {
+	if s.interval == 0 {
+		return 0, errors.New("no proposal interval")
+	}
+	next := head - head%s.interval
+	if next == 0 {
+		return s.interval, nil
+	}
+	return next, nil
+}
+
+// IsProposed returns whether the game of the output exists already.
+func (s *disputeGameFactorySubmitter) IsProposed(ctx context.Context, output *eth.OutputResponse) (bool, error) {
+	extraData, err := disputeGameExtraData(output)
+	if err != nil {
+		return false, err
+	}
+	game, err := s.contract.Games(&bind.CallOpts{From: s.from, Context: ctx}, s.gameType, output.OutputRoot, extraData)
+	if err != nil {
+		return false, err
+	}
+	return game.Proxy != (common.Address{}), nil
+}
+
+// ProposedOutputRoot returns the output root if the game of the output exists. Games of other output roots
+// at the same L2 block are disputes of the output, rather than conflicting proposals.
+func (s *disputeGameFactorySubmitter) ProposedOutputRoot(ctx context.Context, output *eth.OutputResponse) (eth.Bytes32, bool, error) {
+	proposed, err := s.IsProposed(ctx, output)
+	if err != nil || !proposed {
+		return eth.Bytes32{}, false, err
+	}
+	return output.OutputRoot, true, nil
+}
+
+func (s *disputeGameFactorySubmitter) ProposalTxData(output *eth.OutputResponse) ([]byte, error) {
+	extraData, err := disputeGameExtraData(output)
+	if err != nil {
+		return nil, err
+	}
+	return s.abi.Pack("create", s.gameType, output.OutputRoot, extraData)
+}
+
+// disputeGameExtraData returns the extra data of the dispute game of the output, which is its L2 block number.
+func disputeGameExtraData(output *eth.OutputResponse) ([]byte, error) {
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return abi.Arguments{{Type: uint256Type}}.Pack(new(big.Int).SetUint64(output.BlockRef.Number))
+}
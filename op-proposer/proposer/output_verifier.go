@@ -0,0 +1,131 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+// errOutputMismatch is returned when the output root of the rollup node differs from the output root
+// that is computed with the independent L2 source.
+var errOutputMismatch = errors.New("output root mismatch")
+
+// L2Client is an L2 execution RPC, independent of the rollup node, that output roots are verified against.
+type L2Client interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	// GetProof returns the proof of the account at the given block, with the proofs of the storage keys.
+	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
+}
+
+// ethL2Client is the L2Client of an L2 execution RPC.
+type ethL2Client struct {
+	*ethclient.Client
+}
+
+func (c *ethL2Client) GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error) {
+	var result *eth.AccountResult
+	if err := c.Client.Client().CallContext(ctx, &result, "eth_getProof", address, storage, blockTag); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, ethereum.NotFound
+	}
+	return result, nil
+}
+
+// OutputVerificationConfig configures the verification of output roots against an independent L2 source.
+type OutputVerificationConfig struct {
+	// FailOpen proposes outputs that cannot be verified, because the L2 source is unavailable.
+	// Outputs with a mismatching output root are never proposed.
+	FailOpen bool
+	// Attempts is the number of times the output root is computed before giving up, as the L2 source
+	// may be behind the rollup node, and RetryInterval the delay between the attempts.
+	Attempts      int
+	RetryInterval time.Duration
+}
+
+// outputVerifier verifies the output roots of the rollup node, by recomputing them with an independent L2 source.
+type outputVerifier struct {
+	cfg    OutputVerificationConfig
+	client L2Client
+	log    log.Logger
+	metr   metrics.Metricer
+}
+
+func newOutputVerifier(cfg OutputVerificationConfig, client L2Client, log log.Logger, metr metrics.Metricer) *outputVerifier {
+	if cfg.Attempts < 1 {
+		cfg.Attempts = 1
+	}
+	return &outputVerifier{
+		cfg:    cfg,
+		client: client,
+		log:    log,
+		metr:   metr,
+	}
+}
+
+// ShouldPropose returns whether the output root of the output matches the output root that is computed with
+// the L2 source. If the L2 source is unavailable, it returns whether verification fails open.
+func (v *outputVerifier) ShouldPropose(ctx context.Context, output *eth.OutputResponse, timeout time.Duration) bool {
+	root, err := retry.Do(ctx, v.cfg.Attempts, retry.Fixed(v.cfg.RetryInterval), func() (eth.Bytes32, error) {
+		cCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		root, err := v.outputRoot(cCtx, output.BlockRef.Number)
+		if err != nil {
+			return eth.Bytes32{}, err
+		}
+		if root != output.OutputRoot {
+			return root, errOutputMismatch
+		}
+		return root, nil
+	})
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, errOutputMismatch):
+		v.metr.RecordOutputVerificationMismatch()
+		v.log.Error("Output root of the rollup node does not match the output root of the L2 source, not proposing",
+			"l2_block", output.BlockRef, "output_root", output.OutputRoot, "expected_output_root", root)
+		return false
+	default:
+		v.metr.RecordOutputVerificationError()
+		v.log.Warn("Failed to verify the output root with the L2 source", "err", err,
+			"l2_block", output.BlockRef, "fail_open", v.cfg.FailOpen)
+		return v.cfg.FailOpen
+	}
+}
+
+// outputRoot computes the output root of the L2 block with the header of the block, and the proof of the
+// L2ToL1MessagePasser account, which is verified against the state root of the block.
+func (v *outputVerifier) outputRoot(ctx context.Context, number uint64) (eth.Bytes32, error) {
+	header, err := v.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return eth.Bytes32{}, fmt.Errorf("failed to get L2 block %d: %w", number, err)
+	}
+	hash := header.Hash()
+	proof, err := v.client.GetProof(ctx, predeploys.L2ToL1MessagePasserAddr, []common.Hash{}, hash.String())
+	if err != nil {
+		return eth.Bytes32{}, fmt.Errorf("failed to get message passer proof at block %s: %w", hash, err)
+	}
+	if err := proof.Verify(header.Root); err != nil {
+		return eth.Bytes32{}, fmt.Errorf("invalid message passer proof, state root was %s: %w", header.Root, err)
+	}
+	return eth.OutputRoot(&eth.OutputV0{
+		StateRoot:                eth.Bytes32(header.Root),
+		MessagePasserStorageRoot: eth.Bytes32(proof.StorageHash),
+		BlockHash:                hash,
+	}), nil
+}
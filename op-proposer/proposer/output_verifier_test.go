@@ -0,0 +1,160 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// proofList collects the nodes of a trie proof.
+type proofList []hexutil.Bytes
+
+func (l *proofList) Put(key []byte, value []byte) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func (l *proofList) Delete(key []byte) error {
+	return errors.New("not supported")
+}
+
+// stubL2Client answers with an L2 block of which the state only has the L2ToL1MessagePasser account.
+type stubL2Client struct {
+	header *types.Header
+	proof  *eth.AccountResult
+	// unavailable is the number of calls that fail before the block is available
+	unavailable int
+}
+
+func newStubL2Client(t *testing.T, number uint64, storageRoot common.Hash) *stubL2Client {
+	addr := predeploys.L2ToL1MessagePasserAddr
+	proof := &eth.AccountResult{
+		Address:     addr,
+		Balance:     (*hexutil.Big)(big.NewInt(0)),
+		CodeHash:    crypto.Keccak256Hash([]byte{0x01}),
+		Nonce:       1,
+		StorageHash: storageRoot,
+	}
+	account, err := rlp.EncodeToBytes([]any{uint64(proof.Nonce), proof.Balance.ToInt().Bytes(), proof.StorageHash, proof.CodeHash})
+	require.NoError(t, err)
+	tr := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	key := crypto.Keccak256(addr[:])
+	tr.MustUpdate(key, account)
+	var nodes proofList
+	require.NoError(t, tr.Prove(key, &nodes))
+	proof.AccountProof = nodes
+	return &stubL2Client{
+		header: &types.Header{Number: new(big.Int).SetUint64(number), Root: tr.Hash()},
+		proof:  proof,
+	}
+}
+
+// outputRoot returns the output root of the L2 block.
+func (c *stubL2Client) outputRoot() eth.Bytes32 {
+	return eth.OutputRoot(&eth.OutputV0{
+		StateRoot:                eth.Bytes32(c.header.Root),
+		MessagePasserStorageRoot: eth.Bytes32(c.proof.StorageHash),
+		BlockHash:                c.header.Hash(),
+	})
+}
+
+func (c *stubL2Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if c.unavailable > 0 {
+		c.unavailable--
+		return nil, ethereum.NotFound
+	}
+	if number.Cmp(c.header.Number) != 0 {
+		return nil, ethereum.NotFound
+	}
+	return c.header, nil
+}
+
+func (c *stubL2Client) GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error) {
+	if address != c.proof.Address || blockTag != c.header.Hash().String() {
+		return nil, ethereum.NotFound
+	}
+	return c.proof, nil
+}
+
+// verificationMetrics counts the recorded output verification mismatches and errors.
+type verificationMetrics struct {
+	metrics.Metricer
+	mismatches, errors int
+}
+
+func (m *verificationMetrics) RecordOutputVerificationMismatch() {
+	m.mismatches++
+}
+
+func (m *verificationMetrics) RecordOutputVerificationError() {
+	m.errors++
+}
+
+func TestOutputVerifier(t *testing.T) {
+	tests := []struct {
+		name        string
+		failOpen    bool
+		unavailable int
+		// root is the output root of the rollup node, the output root of the L2 source if empty
+		root       eth.Bytes32
+		propose    bool
+		mismatches int
+		errors     int
+	}{
+		{name: "match", propose: true},
+		{name: "mismatch", root: eth.Bytes32{0x02}, mismatches: 1},
+		{name: "mismatch fail-open", failOpen: true, root: eth.Bytes32{0x02}, mismatches: 1},
+		{name: "L2 source behind", unavailable: 2, propose: true},
+		{name: "L2 source unavailable", unavailable: 3, errors: 1},
+		{name: "L2 source unavailable fail-open", failOpen: true, unavailable: 3, propose: true, errors: 1},
+	}
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			l2 := newStubL2Client(t, 10, common.Hash{0xaa})
+			l2.unavailable = tc.unavailable
+			metr := &verificationMetrics{Metricer: metrics.NoopMetrics}
+			v := newOutputVerifier(OutputVerificationConfig{FailOpen: tc.failOpen, Attempts: 3, RetryInterval: time.Millisecond},
+				l2, testlog.Logger(t, log.LvlCrit), metr)
+			root := tc.root
+			if root == (eth.Bytes32{}) {
+				root = l2.outputRoot()
+			}
+			output := &eth.OutputResponse{OutputRoot: root, BlockRef: eth.L2BlockRef{Number: 10}}
+
+			require.Equal(t, tc.propose, v.ShouldPropose(context.Background(), output, time.Second))
+			require.Equal(t, tc.mismatches, metr.mismatches)
+			require.Equal(t, tc.errors, metr.errors)
+		})
+	}
+}
+
+func TestOutputVerifierInvalidProof(t *testing.T) {
+	l2 := newStubL2Client(t, 10, common.Hash{0xaa})
+	root := l2.outputRoot()
+	// the proof of another storage root doesn't match the state root of the block
+	l2.proof.StorageHash = common.Hash{0xbb}
+	v := newOutputVerifier(OutputVerificationConfig{}, l2, testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics)
+
+	_, err := v.outputRoot(context.Background(), 10)
+	require.ErrorContains(t, err, "invalid message passer proof")
+	require.False(t, v.ShouldPropose(context.Background(), &eth.OutputResponse{OutputRoot: root, BlockRef: eth.L2BlockRef{Number: 10}}, time.Second))
+}
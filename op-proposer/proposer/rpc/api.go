@@ -3,9 +3,11 @@ package rpc
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
 )
@@ -13,6 +15,34 @@ import (
 type ProposerDriver interface {
 	StartL2OutputSubmitting() error
 	StopL2OutputSubmitting() error
+	PauseL2OutputSubmitting()
+	ResumeL2OutputSubmitting()
+	ProposalStatus(ctx context.Context) (*ProposalStatus, error)
+}
+
+// The states of the proposer in the ProposalStatus.
+const (
+	// ProposerWaiting is the state of the proposer while it waits for the next output to propose.
+	ProposerWaiting = "waiting"
+	// ProposerSubmitting is the state of the proposer while it submits a proposal tx of an output.
+	ProposerSubmitting = "submitting"
+	// ProposerPaused is the state of the proposer while it is paused by the admin.
+	ProposerPaused = "paused"
+)
+
+// ProposalStatus is the runtime status of the proposer.
+type ProposalStatus struct {
+	// Running is true if the proposal loop runs.
+	Running bool `json:"running"`
+	// State is ProposerWaiting, ProposerSubmitting or ProposerPaused.
+	State string `json:"state"`
+	// LastProposedBlock, LastOutputRoot and LastProposalTx are of the last output proposed by the proposer,
+	// zero if there is none yet.
+	LastProposedBlock eth.BlockID `json:"last_proposed_block"`
+	LastOutputRoot    eth.Bytes32 `json:"last_output_root"`
+	LastProposalTx    common.Hash `json:"last_proposal_tx"`
+	// NextBlockNumber is the number of the L2 block of the next output that the contract expects.
+	NextBlockNumber uint64 `json:"next_block_number"`
 }
 
 type adminAPI struct {
@@ -41,3 +71,19 @@ func (a *adminAPI) StartProposer(_ context.Context) error {
 func (a *adminAPI) StopProposer(ctx context.Context) error {
 	return a.b.StopL2OutputSubmitting()
 }
+
+// PauseProposer pauses the proposal of outputs, before the next proposal attempt.
+// The pause lasts until ResumeProposer is called, also across restarts of the proposer.
+func (a *adminAPI) PauseProposer(_ context.Context) error {
+	a.b.PauseL2OutputSubmitting()
+	return nil
+}
+
+func (a *adminAPI) ResumeProposer(_ context.Context) error {
+	a.b.ResumeL2OutputSubmitting()
+	return nil
+}
+
+func (a *adminAPI) ProposalStatus(ctx context.Context) (*ProposalStatus, error) {
+	return a.b.ProposalStatus(ctx)
+}
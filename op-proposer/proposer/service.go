@@ -5,11 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net"
-	"strconv"
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-proposer/flags"
 	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
 	"github.com/ethereum-optimism/optimism/op-proposer/proposer/rpc"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
@@ -34,12 +33,43 @@ type ProposerConfig struct {
 	PollInterval   time.Duration
 	NetworkTimeout time.Duration
 
+	// L2OutputOracleAddr is the address of the contract that outputs are proposed to:
+	// the L2OutputOracle, or a DisputeGameFactory, as detected at startup.
 	L2OutputOracleAddr common.Address
 	// AllowNonFinalized enables the proposal of safe, but non-finalized L2 blocks.
 	// The L1 block-hash embedded in the proposal TX is checked and should ensure the proposal
 	// is never valid on an alternative L1 chain that would produce different L2 data.
 	// This option is not necessary when higher proposal latency is acceptable and L1 is healthy.
 	AllowNonFinalized bool
+	// ProposalSource is the L2 head up to which outputs are proposed, see proposalSource.
+	ProposalSource flags.ProposalSource
+	// ProposalMaxRetries is the number of times a proposal tx that failed with a transient error is retried,
+	// with an exponential backoff of up to ProposalRetryMaxBackoff.
+	ProposalMaxRetries      uint64
+	ProposalRetryMaxBackoff time.Duration
+	// DisputeGameType is the type of the dispute games that are created per output, when proposing outputs
+	// to a DisputeGameFactory. ProposalInterval is the interval of the L2 blocks of the outputs to a
+	// DisputeGameFactory. A L2OutputOracle only accepts it if it is equal to its submission interval.
+	DisputeGameType  uint8
+	ProposalInterval uint64
+	// ProposalTimeInterval is the minimum duration between two proposals, if non-zero.
+	ProposalTimeInterval time.Duration
+	// FeeCeiling defers proposals while the L1 fees are high.
+	FeeCeiling FeeCeilingConfig
+	// OutputVerification configures the verification of output roots with the VerifyL2Client of the driver.
+	OutputVerification OutputVerificationConfig
+}
+
+// proposalSource returns the L2 head up to which outputs are proposed: the finalized head, unless
+// non-finalized proposals are allowed. Then it is the configured source, the safe head by default.
+func (c *ProposerConfig) proposalSource() flags.ProposalSource {
+	if !c.AllowNonFinalized {
+		return flags.FinalizedSource
+	}
+	if c.ProposalSource == "" {
+		return flags.SafeSource
+	}
+	return c.ProposalSource
 }
 
 type ProposerService struct {
@@ -51,14 +81,16 @@ type ProposerService struct {
 	TxManager      txmgr.TxManager
 	L1Client       *ethclient.Client
 	RollupProvider dial.RollupProvider
+	// VerifyL2Client is the L2 execution RPC that output roots are verified with, if configured
+	VerifyL2Client *ethclient.Client
 
 	driver *L2OutputSubmitter
 
 	Version string
 
-	pprofSrv   *httputil.HTTPServer
-	metricsSrv *httputil.HTTPServer
-	rpcServer  *oprpc.Server
+	pprofService *oppprof.Service
+	metricsSrv   *httputil.HTTPServer
+	rpcServer    *oprpc.Server
 
 	balanceMetricer io.Closer
 
@@ -85,6 +117,14 @@ func (ps *ProposerService) initFromCLIConfig(ctx context.Context, version string
 	ps.PollInterval = cfg.PollInterval
 	ps.NetworkTimeout = cfg.TxMgrConfig.NetworkTimeout
 	ps.AllowNonFinalized = cfg.AllowNonFinalized
+	ps.ProposalSource = cfg.ProposalSource
+	ps.ProposalMaxRetries = cfg.ProposalMaxRetries
+	ps.ProposalRetryMaxBackoff = cfg.ProposalRetryMaxBackoff
+	ps.DisputeGameType = uint8(cfg.DisputeGameType)
+	ps.ProposalInterval = cfg.ProposalInterval
+	ps.ProposalTimeInterval = cfg.ProposalTimeInterval
+	ps.FeeCeiling = cfg.FeeCeilingConfig()
+	ps.OutputVerification = cfg.OutputVerificationConfig()
 
 	if err := ps.initRPCClients(ctx, cfg); err != nil {
 		return err
@@ -92,7 +132,9 @@ func (ps *ProposerService) initFromCLIConfig(ctx context.Context, version string
 	if err := ps.initTxManager(cfg); err != nil {
 		return fmt.Errorf("failed to init Tx manager: %w", err)
 	}
-	ps.initBalanceMonitor(cfg)
+	if err := ps.initBalanceMonitor(cfg); err != nil {
+		return fmt.Errorf("failed to start balance monitor: %w", err)
+	}
 	if err := ps.initMetricsServer(cfg); err != nil {
 		return fmt.Errorf("failed to start metrics server: %w", err)
 	}
@@ -115,17 +157,27 @@ func (ps *ProposerService) initFromCLIConfig(ctx context.Context, version string
 }
 
 func (ps *ProposerService) initRPCClients(ctx context.Context, cfg *CLIConfig) error {
-	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, ps.Log, cfg.L1EthRpc)
+	l1 := cfg.Endpoints.L1()
+	l1Client, err := dial.DialEthClientWithTimeout(ctx, l1.DialTimeout, ps.Log, l1.URL)
 	if err != nil {
 		return fmt.Errorf("failed to dial L1 RPC: %w", err)
 	}
 	ps.L1Client = l1Client
 
-	rollupProvider, err := dial.NewStaticL2RollupProvider(ctx, ps.Log, cfg.RollupRpc)
+	rollup := cfg.Endpoints.Rollup()
+	rollupProvider, err := dial.NewStaticL2RollupProvider(ctx, rollup.DialTimeout, ps.Log, rollup.URL)
 	if err != nil {
 		return fmt.Errorf("failed to build L2 endpoint provider: %w", err)
 	}
 	ps.RollupProvider = rollupProvider
+
+	if cfg.L2VerifyRpc != "" {
+		verifyClient, err := dial.DialEthClientWithTimeout(ctx, l1.DialTimeout, ps.Log, cfg.L2VerifyRpc)
+		if err != nil {
+			return fmt.Errorf("failed to dial L2 verification RPC: %w", err)
+		}
+		ps.VerifyL2Client = verifyClient
+	}
 	return nil
 }
 
@@ -138,11 +190,20 @@ func (ps *ProposerService) initMetrics(cfg *CLIConfig) {
 	}
 }
 
-// initBalanceMonitor depends on Metrics, L1Client and TxManager to start background-monitoring of the Proposer balance.
-func (ps *ProposerService) initBalanceMonitor(cfg *CLIConfig) {
-	if cfg.MetricsConfig.Enabled {
-		ps.balanceMetricer = ps.Metrics.StartBalanceMetrics(ps.Log, ps.L1Client, ps.TxManager.From())
+// initBalanceMonitor depends on Metrics, L1Client and TxManager to start background-monitoring of the Proposer balance,
+// and of the balances of any additional configured accounts.
+func (ps *ProposerService) initBalanceMonitor(cfg *CLIConfig) error {
+	if !cfg.MetricsConfig.Enabled {
+		return nil
+	}
+	accounts, err := cfg.BalanceConfig.ParseAccounts()
+	if err != nil {
+		return err
 	}
+	accounts = append([]opmetrics.BalanceAccount{{Name: "proposer", Address: ps.TxManager.From()}}, accounts...)
+	ps.balanceMetricer = ps.Metrics.StartBalanceMonitor(ps.Log, ps.L1Client, accounts,
+		cfg.BalanceConfig.LowThresholdWei(), cfg.BalanceConfig.Interval)
+	return nil
 }
 
 func (ps *ProposerService) initTxManager(cfg *CLIConfig) error {
@@ -155,17 +216,12 @@ func (ps *ProposerService) initTxManager(cfg *CLIConfig) error {
 }
 
 func (ps *ProposerService) initPProf(cfg *CLIConfig) error {
+	// the pprof service is always created, so that it can be started with the admin RPC
+	ps.pprofService = oppprof.NewService(ps.Log, cfg.PprofConfig.ProfileDir)
 	if !cfg.PprofConfig.Enabled {
 		return nil
 	}
-	log.Debug("starting pprof server", "addr", net.JoinHostPort(cfg.PprofConfig.ListenAddr, strconv.Itoa(cfg.PprofConfig.ListenPort)))
-	srv, err := oppprof.StartServer(cfg.PprofConfig.ListenAddr, cfg.PprofConfig.ListenPort)
-	if err != nil {
-		return err
-	}
-	ps.pprofSrv = srv
-	log.Info("started pprof server", "addr", srv.Addr())
-	return nil
+	return ps.pprofService.Start(cfg.PprofConfig.ListenAddr, cfg.PprofConfig.ListenPort)
 }
 
 func (ps *ProposerService) initMetricsServer(cfg *CLIConfig) error {
@@ -177,6 +233,9 @@ func (ps *ProposerService) initMetricsServer(cfg *CLIConfig) error {
 	if !ok {
 		return fmt.Errorf("metrics were enabled, but metricer %T does not expose registry for metrics-server", ps.Metrics)
 	}
+	if h, ok := ps.Log.GetHandler().(opmetrics.LogLevelHandler); ok {
+		opmetrics.NewLogLevelGauge(metrics.Namespace, opmetrics.With(m.Registry()), h)
+	}
 	ps.Log.Debug("starting metrics server", "addr", cfg.MetricsConfig.ListenAddr, "port", cfg.MetricsConfig.ListenPort)
 	metricsSrv, err := opmetrics.StartServer(m.Registry(), cfg.MetricsConfig.ListenAddr, cfg.MetricsConfig.ListenPort)
 	if err != nil {
@@ -197,14 +256,18 @@ func (ps *ProposerService) initL2ooAddress(cfg *CLIConfig) error {
 }
 
 func (ps *ProposerService) initDriver() error {
-	driver, err := NewL2OutputSubmitter(DriverSetup{
+	setup := DriverSetup{
 		Log:            ps.Log,
 		Metr:           ps.Metrics,
 		Cfg:            ps.ProposerConfig,
 		Txmgr:          ps.TxManager,
 		L1Client:       ps.L1Client,
 		RollupProvider: ps.RollupProvider,
-	})
+	}
+	if ps.VerifyL2Client != nil {
+		setup.VerifyL2Client = &ethL2Client{ps.VerifyL2Client}
+	}
+	driver, err := NewL2OutputSubmitter(setup)
 	if err != nil {
 		return err
 	}
@@ -217,11 +280,15 @@ func (ps *ProposerService) initRPCServer(cfg *CLIConfig) error {
 		cfg.RPCConfig.ListenAddr,
 		cfg.RPCConfig.ListenPort,
 		ps.Version,
-		oprpc.WithLogger(ps.Log),
+		append(cfg.RPCConfig.ServerOptions(),
+			oprpc.WithLogger(ps.Log),
+			oprpc.WithMethodMetrics(ps.Metrics),
+		)...,
 	)
 	if cfg.RPCConfig.EnableAdmin {
 		adminAPI := rpc.NewAdminAPI(ps.driver, ps.Metrics, ps.Log)
 		server.AddAPI(rpc.GetAdminAPI(adminAPI))
+		server.AddAPI(oppprof.GetAdminAPI(oppprof.NewAPI(ps.pprofService)))
 		ps.Log.Info("Admin RPC enabled")
 	}
 	ps.Log.Info("Starting JSON-RPC server")
@@ -272,8 +339,8 @@ func (ps *ProposerService) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to stop RPC server: %w", err))
 		}
 	}
-	if ps.pprofSrv != nil {
-		if err := ps.pprofSrv.Stop(ctx); err != nil {
+	if ps.pprofService != nil {
+		if err := ps.pprofService.Stop(ctx); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to stop PProf server: %w", err))
 		}
 	}
@@ -297,6 +364,10 @@ func (ps *ProposerService) Stop(ctx context.Context) error {
 		ps.L1Client.Close()
 	}
 
+	if ps.VerifyL2Client != nil {
+		ps.VerifyL2Client.Close()
+	}
+
 	if ps.RollupProvider != nil {
 		ps.RollupProvider.Close()
 	}
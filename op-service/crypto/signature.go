@@ -45,11 +45,12 @@ type SignerFactory func(chainID *big.Int) SignerFn
 // SignerFactoryFromConfig considers three ways that signers are created & then creates single factory from those config options.
 // It can either take a remote signer (via opsigner.CLIConfig) or it can be provided either a mnemonic + derivation path or a private key.
 // It prefers the remote signer, then the mnemonic or private key (only one of which can be provided).
-func SignerFactoryFromConfig(l log.Logger, privateKey, mnemonic, hdPath string, signerConfig opsigner.CLIConfig) (SignerFactory, common.Address, error) {
+// The options are applied to the client of the remote signer.
+func SignerFactoryFromConfig(l log.Logger, privateKey, mnemonic, hdPath string, signerConfig opsigner.CLIConfig, opts ...opsigner.ClientOption) (SignerFactory, common.Address, error) {
 	var signer SignerFactory
 	var fromAddress common.Address
 	if signerConfig.Enabled() {
-		signerClient, err := opsigner.NewSignerClientFromConfig(l, signerConfig)
+		signerClient, err := opsigner.NewSignerClientFromConfig(l, signerConfig, opts...)
 		if err != nil {
 			l.Error("Unable to create Signer Client", "error", err)
 			return nil, common.Address{}, fmt.Errorf("failed to create the signer client: %w", err)
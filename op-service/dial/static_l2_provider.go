@@ -2,6 +2,7 @@ package dial
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
@@ -23,12 +24,12 @@ type StaticL2EndpointProvider struct {
 	ethClient *ethclient.Client
 }
 
-func NewStaticL2EndpointProvider(ctx context.Context, log log.Logger, ethClientUrl string, rollupClientUrl string) (*StaticL2EndpointProvider, error) {
-	ethClient, err := DialEthClientWithTimeout(ctx, DefaultDialTimeout, log, ethClientUrl)
+func NewStaticL2EndpointProvider(ctx context.Context, timeout time.Duration, log log.Logger, ethClientUrl string, rollupClientUrl string) (*StaticL2EndpointProvider, error) {
+	ethClient, err := DialEthClientWithTimeout(ctx, timeout, log, ethClientUrl)
 	if err != nil {
 		return nil, err
 	}
-	rollupProvider, err := NewStaticL2RollupProvider(ctx, log, rollupClientUrl)
+	rollupProvider, err := NewStaticL2RollupProvider(ctx, timeout, log, rollupClientUrl)
 	if err != nil {
 		return nil, err
 	}
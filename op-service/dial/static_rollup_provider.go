@@ -2,6 +2,7 @@ package dial
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/log"
@@ -22,8 +23,8 @@ type StaticL2RollupProvider struct {
 	rollupClient *sources.RollupClient
 }
 
-func NewStaticL2RollupProvider(ctx context.Context, log log.Logger, rollupClientUrl string) (*StaticL2RollupProvider, error) {
-	rollupClient, err := DialRollupClientWithTimeout(ctx, DefaultDialTimeout, log, rollupClientUrl)
+func NewStaticL2RollupProvider(ctx context.Context, timeout time.Duration, log log.Logger, rollupClientUrl string) (*StaticL2RollupProvider, error) {
+	rollupClient, err := DialRollupClientWithTimeout(ctx, timeout, log, rollupClientUrl)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,197 @@
+package endpoint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+)
+
+const (
+	L1RPCFlagName             = "l1-eth-rpc"
+	L2RPCFlagName             = "l2-eth-rpc"
+	RollupRPCFlagName         = "rollup-rpc"
+	L2EngineRPCFlagName       = "l2-engine-rpc"
+	L2EngineJWTSecretFlagName = "l2.jwt-secret"
+	DialTimeoutFlagName       = "dial-timeout"
+)
+
+// L1RPCFlag returns the flag of the RPC URL of the L1 execution node.
+// The aliases keep the flag names that services used before the flags were shared.
+func L1RPCFlag(envPrefix string, aliases ...string) *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:    L1RPCFlagName,
+		Aliases: aliases,
+		Usage:   "HTTP or WebSocket RPC URL of the L1 execution node (eth namespace required)",
+		EnvVars: opservice.PrefixEnvVar(envPrefix, "L1_ETH_RPC"),
+	}
+}
+
+// L2RPCFlag returns the flag of the RPC URL of the L2 execution node.
+func L2RPCFlag(envPrefix string, aliases ...string) *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:    L2RPCFlagName,
+		Aliases: aliases,
+		Usage:   "HTTP or WebSocket RPC URL of the L2 execution node (eth namespace required)",
+		EnvVars: opservice.PrefixEnvVar(envPrefix, "L2_ETH_RPC"),
+	}
+}
+
+// RollupRPCFlag returns the flag of the RPC URL of the rollup node.
+func RollupRPCFlag(envPrefix string, aliases ...string) *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:    RollupRPCFlagName,
+		Aliases: aliases,
+		Usage:   "HTTP or WebSocket RPC URL of the rollup node (optimism namespace required)",
+		EnvVars: opservice.PrefixEnvVar(envPrefix, "ROLLUP_RPC"),
+	}
+}
+
+// L2EngineRPCFlag returns the flag of the RPC URL of the engine API of the L2 execution node.
+func L2EngineRPCFlag(envPrefix string, aliases ...string) *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:    L2EngineRPCFlagName,
+		Aliases: aliases,
+		Usage:   "HTTP or WebSocket RPC URL of the engine API of the L2 execution node (engine and eth namespace required)",
+		EnvVars: opservice.PrefixEnvVar(envPrefix, "L2_ENGINE_RPC"),
+	}
+}
+
+// L2EngineJWTSecretFlag returns the flag of the path of the JWT secret of the L2 engine API.
+func L2EngineJWTSecretFlag(envPrefix string) *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:    L2EngineJWTSecretFlagName,
+		Usage:   "Path to JWT secret key. Keys are 32 bytes, hex encoded in a file. A new key will be generated if the file does not exist.",
+		EnvVars: opservice.PrefixEnvVar(envPrefix, "L2_ENGINE_AUTH"),
+	}
+}
+
+// L2EngineCLIFlags returns the flags of the authenticated engine API of the L2 execution node:
+// its RPC URL, and the path of the JWT secret.
+func L2EngineCLIFlags(envPrefix string, aliases ...string) []cli.Flag {
+	return []cli.Flag{
+		L2EngineRPCFlag(envPrefix, aliases...),
+		L2EngineJWTSecretFlag(envPrefix),
+	}
+}
+
+// DialTimeoutFlag returns the flag of the timeout of dialing the RPC endpoints.
+func DialTimeoutFlag(envPrefix string) *cli.DurationFlag {
+	return &cli.DurationFlag{
+		Name:    DialTimeoutFlagName,
+		Usage:   "Timeout of dialing the RPC endpoints, including retries, at startup",
+		Value:   dial.DefaultDialTimeout,
+		EnvVars: opservice.PrefixEnvVar(envPrefix, "DIAL_TIMEOUT"),
+	}
+}
+
+// CLIConfig is the configuration of the RPC endpoints of a service.
+// Endpoints with an empty URL are not used by the service, or not configured, which services check
+// for the endpoints they require.
+type CLIConfig struct {
+	L1RPC     string
+	L2RPC     string
+	RollupRPC string
+
+	L2EngineRPC string
+	// L2EngineJWTSecretPath is the path of the JWT secret of the L2 engine API, which is generated if the file does not exist.
+	L2EngineJWTSecretPath string
+
+	// DialTimeout is the timeout of dialing the endpoints. 0 uses dial.DefaultDialTimeout.
+	DialTimeout time.Duration
+}
+
+func DefaultCLIConfig() CLIConfig {
+	return CLIConfig{
+		DialTimeout: dial.DefaultDialTimeout,
+	}
+}
+
+// Check validates the URLs of the configured endpoints, and the JWT secret of the L2 engine API, if it exists.
+func (c CLIConfig) Check() error {
+	for _, ep := range []struct {
+		name string
+		url  string
+	}{
+		{"L1 RPC", c.L1RPC},
+		{"L2 RPC", c.L2RPC},
+		{"rollup RPC", c.RollupRPC},
+		{"L2 engine RPC", c.L2EngineRPC},
+	} {
+		if ep.url == "" {
+			continue
+		}
+		if err := ValidateURL(ep.url); err != nil {
+			return fmt.Errorf("invalid %s: %w", ep.name, err)
+		}
+	}
+	if c.L2EngineRPC != "" {
+		if c.L2EngineJWTSecretPath == "" {
+			return errors.New("L2 engine RPC requires a JWT secret path")
+		}
+		if _, err := ReadJWTSecret(c.L2EngineJWTSecretPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if c.DialTimeout < 0 {
+		return errors.New("dial timeout cannot be negative")
+	}
+	return nil
+}
+
+func (c CLIConfig) dialTimeout() time.Duration {
+	if c.DialTimeout == 0 {
+		return dial.DefaultDialTimeout
+	}
+	return c.DialTimeout
+}
+
+// L1 returns the L1 RPC endpoint.
+func (c CLIConfig) L1() RPC {
+	return RPC{URL: c.L1RPC, DialTimeout: c.dialTimeout()}
+}
+
+// L2 returns the L2 RPC endpoint.
+func (c CLIConfig) L2() RPC {
+	return RPC{URL: c.L2RPC, DialTimeout: c.dialTimeout()}
+}
+
+// Rollup returns the rollup node RPC endpoint.
+func (c CLIConfig) Rollup() RPC {
+	return RPC{URL: c.RollupRPC, DialTimeout: c.dialTimeout()}
+}
+
+// L2Engine returns the authenticated L2 engine API endpoint, with the JWT secret loaded from its path,
+// or generated and written to it, if the file does not exist.
+func (c CLIConfig) L2Engine(log log.Logger) (AuthRPC, error) {
+	secret, err := LoadOrGenerateJWTSecret(log, c.L2EngineJWTSecretPath)
+	if err != nil {
+		return AuthRPC{}, err
+	}
+	return AuthRPC{
+		RPC:       RPC{URL: c.L2EngineRPC, DialTimeout: c.dialTimeout()},
+		JWTSecret: secret,
+	}, nil
+}
+
+// ReadCLIConfig reads the endpoints from the flags. The flags that the service doesn't define are empty.
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	cfg := CLIConfig{
+		L1RPC:                 ctx.String(L1RPCFlagName),
+		L2RPC:                 ctx.String(L2RPCFlagName),
+		RollupRPC:             ctx.String(RollupRPCFlagName),
+		L2EngineRPC:           ctx.String(L2EngineRPCFlagName),
+		L2EngineJWTSecretPath: ctx.String(L2EngineJWTSecretFlagName),
+		DialTimeout:           dial.DefaultDialTimeout,
+	}
+	if ctx.IsSet(DialTimeoutFlagName) {
+		cfg.DialTimeout = ctx.Duration(DialTimeoutFlagName)
+	}
+	return cfg
+}
@@ -0,0 +1,168 @@
+package endpoint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+const testSecret = "0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+func writeSecret(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "jwt.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func validConfig(t *testing.T) CLIConfig {
+	return CLIConfig{
+		L1RPC:                 "http://l1:8545",
+		L2RPC:                 "ws://l2:8546",
+		RollupRPC:             "https://op-node.example.com/rpc",
+		L2EngineRPC:           "http://l2:8551",
+		L2EngineJWTSecretPath: writeSecret(t, testSecret),
+		DialTimeout:           time.Second,
+	}
+}
+
+func TestCheck(t *testing.T) {
+	require.NoError(t, validConfig(t).Check())
+	require.NoError(t, CLIConfig{}.Check(), "unused endpoints are not checked")
+
+	tests := []struct {
+		name      string
+		override  func(c *CLIConfig)
+		errString string
+	}{
+		{
+			name:      "malformed URL",
+			override:  func(c *CLIConfig) { c.L1RPC = "http://l1:port" },
+			errString: `invalid L1 RPC: malformed URL "http://l1:port"`,
+		},
+		{
+			name:      "no scheme",
+			override:  func(c *CLIConfig) { c.L2RPC = "/l2:8545" },
+			errString: `invalid L2 RPC: URL "/l2:8545" has no scheme, expected http, https, ws or wss`,
+		},
+		{
+			name:      "unsupported scheme",
+			override:  func(c *CLIConfig) { c.RollupRPC = "ftp://op-node:8545" },
+			errString: `invalid rollup RPC: URL "ftp://op-node:8545" has unsupported scheme "ftp", expected http, https, ws or wss`,
+		},
+		{
+			name:      "no host",
+			override:  func(c *CLIConfig) { c.L2EngineRPC = "http://:8551" },
+			errString: `invalid L2 engine RPC: URL "http://:8551" has no host`,
+		},
+		{
+			name:      "no JWT secret path",
+			override:  func(c *CLIConfig) { c.L2EngineJWTSecretPath = "" },
+			errString: "L2 engine RPC requires a JWT secret path",
+		},
+		{
+			name:      "short JWT secret",
+			override:  func(c *CLIConfig) { c.L2EngineJWTSecretPath = writeSecret(t, "0x0102") },
+			errString: "not 32 hex-formatted bytes",
+		},
+		{
+			name:      "empty JWT secret",
+			override:  func(c *CLIConfig) { c.L2EngineJWTSecretPath = writeSecret(t, "") },
+			errString: "not 32 hex-formatted bytes",
+		},
+		{
+			name:      "unreadable JWT secret",
+			override:  func(c *CLIConfig) { c.L2EngineJWTSecretPath = t.TempDir() },
+			errString: "failed to read jwt secret",
+		},
+		{
+			name:      "negative dial timeout",
+			override:  func(c *CLIConfig) { c.DialTimeout = -time.Second },
+			errString: "dial timeout cannot be negative",
+		},
+	}
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validConfig(t)
+			tc.override(&cfg)
+			require.ErrorContains(t, cfg.Check(), tc.errString)
+		})
+	}
+
+	t.Run("missing JWT secret is generated", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.L2EngineJWTSecretPath = filepath.Join(t.TempDir(), "jwt.txt")
+		require.NoError(t, cfg.Check())
+	})
+}
+
+func TestEndpoints(t *testing.T) {
+	cfg := validConfig(t)
+	require.Equal(t, RPC{URL: cfg.L1RPC, DialTimeout: time.Second}, cfg.L1())
+	require.Equal(t, RPC{URL: cfg.L2RPC, DialTimeout: time.Second}, cfg.L2())
+	require.Equal(t, RPC{URL: cfg.RollupRPC, DialTimeout: time.Second}, cfg.Rollup())
+
+	l2, err := cfg.L2Engine(testlog.Logger(t, log.LvlInfo))
+	require.NoError(t, err)
+	require.Equal(t, cfg.L2EngineRPC, l2.URL)
+	require.Equal(t, testSecret, hexutil.Encode(l2.JWTSecret[:]))
+
+	cfg.DialTimeout = 0
+	require.Equal(t, dial.DefaultDialTimeout, cfg.L1().DialTimeout)
+}
+
+func TestL2EngineGeneratesJWTSecret(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.L2EngineJWTSecretPath = filepath.Join(t.TempDir(), "jwt.txt")
+	l2, err := cfg.L2Engine(testlog.Logger(t, log.LvlInfo))
+	require.NoError(t, err)
+	require.NotEqual(t, [32]byte{}, l2.JWTSecret)
+
+	// the generated secret is persisted, and loaded on the next start
+	secret, err := ReadJWTSecret(cfg.L2EngineJWTSecretPath)
+	require.NoError(t, err)
+	require.Equal(t, l2.JWTSecret, secret)
+	l2Again, err := cfg.L2Engine(testlog.Logger(t, log.LvlInfo))
+	require.NoError(t, err)
+	require.Equal(t, l2.JWTSecret, l2Again.JWTSecret)
+
+	cfg.L2EngineJWTSecretPath = writeSecret(t, "invalid")
+	_, err = cfg.L2Engine(testlog.Logger(t, log.LvlInfo))
+	require.ErrorContains(t, err, "not 32 hex-formatted bytes", "invalid secrets are not overwritten")
+}
+
+func TestReadCLIConfig(t *testing.T) {
+	flags := []cli.Flag{
+		L1RPCFlag("TEST", "l1"),
+		RollupRPCFlag("TEST"),
+		DialTimeoutFlag("TEST"),
+	}
+	flags = append(flags, L2EngineCLIFlags("TEST", "l2")...)
+	var cfg CLIConfig
+	app := cli.NewApp()
+	app.Flags = flags
+	app.Action = func(ctx *cli.Context) error {
+		cfg = ReadCLIConfig(ctx)
+		return nil
+	}
+	args := "app --l1=http://l1:8545 --rollup-rpc=http://op-node:8545 --l2=http://l2:8551 --l2.jwt-secret=jwt.txt"
+	require.NoError(t, app.Run(strings.Fields(args)))
+
+	require.Equal(t, CLIConfig{
+		L1RPC:                 "http://l1:8545",
+		RollupRPC:             "http://op-node:8545",
+		L2EngineRPC:           "http://l2:8551",
+		L2EngineJWTSecretPath: "jwt.txt",
+		DialTimeout:           dial.DefaultDialTimeout,
+	}, cfg, "flags are read by their aliases, and flags that are not defined are empty")
+}
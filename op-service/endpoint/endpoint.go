@@ -0,0 +1,85 @@
+package endpoint
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// RPC is an RPC endpoint, with the timeout of dialing it.
+type RPC struct {
+	URL         string
+	DialTimeout time.Duration
+}
+
+// AuthRPC is an RPC endpoint that authenticates requests with a JWT secret, like the engine API.
+type AuthRPC struct {
+	RPC
+	JWTSecret [32]byte
+}
+
+// ValidateURL checks that the URL is an absolute HTTP or WebSocket URL, with a host.
+func ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("malformed URL %q: %w", rawURL, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "ws", "wss":
+	case "":
+		return fmt.Errorf("URL %q has no scheme, expected http, https, ws or wss", rawURL)
+	default:
+		return fmt.Errorf("URL %q has unsupported scheme %q, expected http, https, ws or wss", rawURL, u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("URL %q has no host", rawURL)
+	}
+	return nil
+}
+
+// ReadJWTSecret reads the 32 bytes JWT secret, hex encoded in the file at the path.
+// The error wraps os.ErrNotExist if the file does not exist.
+func ReadJWTSecret(path string) ([32]byte, error) {
+	var secret [32]byte
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return secret, errors.New("file-name of jwt secret is empty")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return secret, fmt.Errorf("failed to read jwt secret: %w", err)
+	}
+	jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(jwtSecret) != 32 {
+		return secret, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", path)
+	}
+	copy(secret[:], jwtSecret)
+	return secret, nil
+}
+
+// LoadOrGenerateJWTSecret reads the JWT secret at the path, or generates a new one,
+// and writes it to the path, if the file does not exist.
+func LoadOrGenerateJWTSecret(log log.Logger, path string) ([32]byte, error) {
+	secret, err := ReadJWTSecret(path)
+	if err == nil || !errors.Is(err, os.ErrNotExist) {
+		return secret, err
+	}
+	path = strings.TrimSpace(path)
+	log.Warn("JWT secret file does not exist, generating a new one now. Configure L2 geth with --authrpc.jwt-secret=" + fmt.Sprintf("%q", path))
+	if _, err := io.ReadFull(rand.Reader, secret[:]); err != nil {
+		return secret, fmt.Errorf("failed to generate jwt secret: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(hexutil.Encode(secret[:])), 0600); err != nil {
+		return secret, err
+	}
+	return secret, nil
+}
@@ -2,6 +2,7 @@ package eth
 
 import (
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -14,6 +15,24 @@ import (
 const (
 	BlobSize        = 4096 * 32
 	MaxBlobDataSize = 4096*31 - 4
+	// MaxBlobsPerBlobTx is the protocol limit of blobs in a single blob tx, as limited by the blob gas per block.
+	MaxBlobsPerBlobTx = params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob
+
+	// blobEncodingVersion0 packs 31 bytes of data into each field element, leaving the first byte of each field
+	// element zero, to keep the field element below the BLS modulus. The first 4 data bytes of the blob are the
+	// encoding version byte, followed by the 3-byte big-endian length of the encoded data.
+	blobEncodingVersion0 = 0
+
+	fieldElementDataSize = 31
+	blobHeaderSize       = 4
+)
+
+var (
+	ErrBlobInvalidFieldElement    = errors.New("invalid field element")
+	ErrBlobInvalidEncodingVersion = errors.New("invalid encoding version")
+	ErrBlobInvalidLength          = errors.New("invalid length for blob")
+	ErrBlobInputTooLarge          = errors.New("too much data to encode in one blob")
+	ErrBlobExtraneousData         = errors.New("non-zero data encountered past the end of the blob data")
 )
 
 type Blob [BlobSize]byte
@@ -44,6 +63,53 @@ func (b *Blob) TerminalString() string {
 	return fmt.Sprintf("%x..%x", b[:3], b[BlobSize-3:])
 }
 
+// FromData encodes the given input data into this blob, with the blob encoding version 0.
+// It returns an error, and leaves the blob unchanged, if the data exceeds MaxBlobDataSize.
+func (b *Blob) FromData(data Data) error {
+	if len(data) > MaxBlobDataSize {
+		return fmt.Errorf("%w: len=%d", ErrBlobInputTooLarge, len(data))
+	}
+	b.Clear()
+	payload := make([]byte, 0, blobHeaderSize+len(data))
+	payload = append(payload, blobEncodingVersion0, byte(len(data)>>16), byte(len(data)>>8), byte(len(data)))
+	payload = append(payload, data...)
+	for i := 0; len(payload) > 0; i++ {
+		n := copy(b[i*32+1:(i+1)*32], payload)
+		payload = payload[n:]
+	}
+	return nil
+}
+
+// ToData decodes the blob into raw byte data. See FromData for the encoding.
+// It returns an error if the blob is not a canonical encoding of any data.
+func (b *Blob) ToData() (Data, error) {
+	data := make([]byte, 0, 4096*fieldElementDataSize)
+	for i := 0; i < 4096; i++ {
+		if b[i*32] != 0 {
+			return nil, fmt.Errorf("%w: field element %d has a non-zero high-order byte", ErrBlobInvalidFieldElement, i)
+		}
+		data = append(data, b[i*32+1:(i+1)*32]...)
+	}
+	if data[0] != blobEncodingVersion0 {
+		return nil, fmt.Errorf("%w: expected version %d, got %d", ErrBlobInvalidEncodingVersion, blobEncodingVersion0, data[0])
+	}
+	length := int(data[1])<<16 | int(data[2])<<8 | int(data[3])
+	if length > MaxBlobDataSize {
+		return nil, fmt.Errorf("%w: %d", ErrBlobInvalidLength, length)
+	}
+	for _, v := range data[blobHeaderSize+length:] {
+		if v != 0 {
+			return nil, ErrBlobExtraneousData
+		}
+	}
+	return data[blobHeaderSize : blobHeaderSize+length], nil
+}
+
+// Clear zeroes the blob.
+func (b *Blob) Clear() {
+	*b = Blob{}
+}
+
 func (b *Blob) ComputeKZGCommitment() (kzg4844.Commitment, error) {
 	return kzg4844.BlobToCommitment(*b.KZGBlob())
 }
@@ -61,6 +127,13 @@ func KZGToVersionedHash(commitment kzg4844.Commitment) (out common.Hash) {
 	return out
 }
 
+// IndexedBlobHash represents a blob hash that commits to a single blob confirmed in a block.
+// The index helps us avoid unnecessary blob to blob hash conversions to find the right content in a sidecar.
+type IndexedBlobHash struct {
+	Index uint64      // absolute index in the block, a.k.a. position in sidecar blobs array
+	Hash  common.Hash // hash of the blob, used for consistency checks
+}
+
 // VerifyBlobProof verifies that the given blob and proof corresponds to the given commitment,
 // returning error if the verification fails.
 func VerifyBlobProof(blob *Blob, commitment kzg4844.Commitment, proof kzg4844.Proof) error {
@@ -0,0 +1,79 @@
+package eth
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobEncodeDecode(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	cases := []int{0, 1, 27, 28, 31, 32, 1000, MaxBlobDataSize - 1, MaxBlobDataSize}
+	for _, size := range cases {
+		data := make([]byte, size)
+		rng.Read(data)
+
+		var b Blob
+		require.NoError(t, b.FromData(data), "size %d", size)
+		for i := 0; i < 4096; i++ {
+			require.Zero(t, b[i*32], "field element %d must stay below the modulus", i)
+		}
+		decoded, err := b.ToData()
+		require.NoError(t, err, "size %d", size)
+		require.True(t, bytes.Equal(data, decoded), "size %d", size)
+	}
+}
+
+// TestBlobEncodingLayout pins the version 0 blob encoding of the derivation spec.
+func TestBlobEncodingLayout(t *testing.T) {
+	data := bytes.Repeat([]byte{0xaa}, 40)
+	var b Blob
+	require.NoError(t, b.FromData(data))
+
+	var expected Blob
+	// first field element: zero byte, version 0, 3-byte big-endian length 40, and 27 data bytes
+	expected[4] = 40
+	copy(expected[5:32], data[:27])
+	// second field element: zero byte, and the remaining 13 data bytes
+	copy(expected[33:46], data[27:])
+	require.Equal(t, expected, b)
+}
+
+func TestBlobEncodeTooLarge(t *testing.T) {
+	var b Blob
+	b[1] = 0xff
+	require.ErrorIs(t, b.FromData(make([]byte, MaxBlobDataSize+1)), ErrBlobInputTooLarge)
+	require.Equal(t, byte(0xff), b[1], "blob is unchanged")
+}
+
+func TestBlobDecodeInvalid(t *testing.T) {
+	var valid Blob
+	require.NoError(t, valid.FromData([]byte("hello")))
+
+	t.Run("high-order byte", func(t *testing.T) {
+		b := valid
+		b[32*100] = 1
+		_, err := b.ToData()
+		require.ErrorIs(t, err, ErrBlobInvalidFieldElement)
+	})
+	t.Run("version", func(t *testing.T) {
+		b := valid
+		b[1] = 1
+		_, err := b.ToData()
+		require.ErrorIs(t, err, ErrBlobInvalidEncodingVersion)
+	})
+	t.Run("length", func(t *testing.T) {
+		b := valid
+		b[2] = 0xff
+		_, err := b.ToData()
+		require.ErrorIs(t, err, ErrBlobInvalidLength)
+	})
+	t.Run("extraneous data", func(t *testing.T) {
+		b := valid
+		b[BlobSize-1] = 1
+		_, err := b.ToData()
+		require.ErrorIs(t, err, ErrBlobExtraneousData)
+	})
+}
@@ -21,6 +21,8 @@ type BlockInfo interface {
 	ReceiptHash() common.Hash
 	GasUsed() uint64
 	GasLimit() uint64
+	// ParentBeaconRoot returns the parent beacon block root, or nil if the block is pre-Dencun.
+	ParentBeaconRoot() *common.Hash
 
 	// HeaderRLP returns the RLP of the block header as per consensus rules
 	// Returns an error if the header RLP could not be written
@@ -51,6 +53,10 @@ func ToBlockID(b NumberAndHash) BlockID {
 // blockInfo is a conversion type of types.Block turning it into a BlockInfo
 type blockInfo struct{ *types.Block }
 
+func (b blockInfo) ParentBeaconRoot() *common.Hash {
+	return b.Block.BeaconRoot()
+}
+
 func (b blockInfo) HeaderRLP() ([]byte, error) {
 	return rlp.EncodeToBytes(b.Header())
 }
@@ -105,6 +111,10 @@ func (h headerBlockInfo) GasLimit() uint64 {
 	return h.Header.GasLimit
 }
 
+func (h headerBlockInfo) ParentBeaconRoot() *common.Hash {
+	return h.Header.ParentBeaconRoot
+}
+
 func (h headerBlockInfo) HeaderRLP() ([]byte, error) {
 	return rlp.EncodeToBytes(h.Header)
 }
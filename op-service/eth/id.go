@@ -1,7 +1,12 @@
 package eth
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -21,6 +26,117 @@ func (id BlockID) TerminalString() string {
 	return fmt.Sprintf("%s:%d", id.Hash.TerminalString(), id.Number)
 }
 
+// blockIDFormats describes the accepted text encodings of a BlockID, for usage in error messages.
+const blockIDFormats = "expected a decimal block number, a 0x-prefixed 32-byte block hash, or <number>:<hash>"
+
+// MarshalText encodes the block ID in the combined <number>:<hash> form.
+func (id BlockID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d:%s", id.Number, id.Hash)), nil
+}
+
+// UnmarshalText decodes a block ID from a decimal block number, a 0x-prefixed block hash,
+// or the combined <number>:<hash> form. Any part that is not specified is left zeroed.
+func (id *BlockID) UnmarshalText(text []byte) error {
+	out, _, _, err := parseBlockID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = out
+	return nil
+}
+
+type blockIDMarshaling struct {
+	Hash   common.Hash `json:"hash"`
+	Number uint64      `json:"number"`
+}
+
+// MarshalJSON encodes the block ID as JSON object.
+// This is explicit, since the TextMarshaler would otherwise change the JSON encoding to a string.
+func (id BlockID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(blockIDMarshaling(id))
+}
+
+// UnmarshalJSON decodes the block ID from a JSON object,
+// or from a JSON string in any of the formats accepted by UnmarshalText.
+func (id *BlockID) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return id.UnmarshalText([]byte(text))
+	}
+	var dec blockIDMarshaling
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	*id = BlockID(dec)
+	return nil
+}
+
+// parseBlockID parses the text encoding of a block ID, and reports which parts were specified.
+func parseBlockID(text string) (id BlockID, hasNumber bool, hasHash bool, err error) {
+	numStr, hashStr, combined := strings.Cut(text, ":")
+	if !combined {
+		if strings.HasPrefix(text, "0x") {
+			numStr, hashStr = "", text
+		} else {
+			numStr, hashStr = text, ""
+		}
+	}
+	if combined || numStr != "" {
+		num, err := strconv.ParseUint(numStr, 10, 64)
+		if err != nil {
+			return BlockID{}, false, false, fmt.Errorf("invalid block number %q in block ID %q, %s", numStr, text, blockIDFormats)
+		}
+		id.Number = num
+		hasNumber = true
+	}
+	if combined || hashStr != "" {
+		if err := id.Hash.UnmarshalText([]byte(hashStr)); err != nil {
+			return BlockID{}, false, false, fmt.Errorf("invalid block hash %q in block ID %q, %s", hashStr, text, blockIDFormats)
+		}
+		hasHash = true
+	}
+	if !hasNumber && !hasHash {
+		return BlockID{}, false, false, fmt.Errorf("empty block ID, %s", blockIDFormats)
+	}
+	return id, hasNumber, hasHash, nil
+}
+
+// L2BlockRefFetcher is the subset of the L2 client used to resolve a block ID into a full L2BlockRef.
+type L2BlockRefFetcher interface {
+	L2BlockRefByNumber(ctx context.Context, num uint64) (L2BlockRef, error)
+	L2BlockRefByHash(ctx context.Context, hash common.Hash) (L2BlockRef, error)
+}
+
+var ErrBlockIDMismatch = errors.New("block number does not match block hash")
+
+// ParseL2BlockRef parses a block ID, in any of the formats accepted by BlockID.UnmarshalText,
+// and resolves the remaining L2BlockRef fields with the given client.
+// If both a number and hash are specified, the block found by hash must match the number.
+func ParseL2BlockRef(ctx context.Context, client L2BlockRefFetcher, text string) (L2BlockRef, error) {
+	id, hasNumber, hasHash, err := parseBlockID(text)
+	if err != nil {
+		return L2BlockRef{}, err
+	}
+	if !hasHash {
+		ref, err := client.L2BlockRefByNumber(ctx, id.Number)
+		if err != nil {
+			return L2BlockRef{}, fmt.Errorf("failed to fetch L2 block %d: %w", id.Number, err)
+		}
+		return ref, nil
+	}
+	ref, err := client.L2BlockRefByHash(ctx, id.Hash)
+	if err != nil {
+		return L2BlockRef{}, fmt.Errorf("failed to fetch L2 block %s: %w", id.Hash, err)
+	}
+	if hasNumber && ref.Number != id.Number {
+		return L2BlockRef{}, fmt.Errorf("%w: block %s has number %d, expected %d", ErrBlockIDMismatch, id.Hash, ref.Number, id.Number)
+	}
+	return ref, nil
+}
+
 type L2BlockRef struct {
 	Hash           common.Hash `json:"hash"`
 	Number         uint64      `json:"number"`
@@ -0,0 +1,119 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const testHashStr = "0x0102030000000000000000000000000000000000000000000000000000000000"
+
+var testHash = common.Hash{1, 2, 3}
+
+func TestBlockIDUnmarshalText(t *testing.T) {
+	accepted := []struct {
+		input    string
+		expected BlockID
+	}{
+		{"0", BlockID{}},
+		{"123", BlockID{Number: 123}},
+		{"18446744073709551615", BlockID{Number: ^uint64(0)}},
+		{testHashStr, BlockID{Hash: testHash}},
+		{"123:" + testHashStr, BlockID{Hash: testHash, Number: 123}},
+	}
+	for _, tc := range accepted {
+		t.Run("accept "+tc.input, func(t *testing.T) {
+			var id BlockID
+			require.NoError(t, id.UnmarshalText([]byte(tc.input)))
+			require.Equal(t, tc.expected, id)
+		})
+	}
+	rejected := []string{
+		"",
+		":",
+		"-1",
+		"0x123",
+		"abc",
+		"18446744073709551616",
+		testHashStr + ":123",
+		"123:",
+		":" + testHashStr,
+		"123:" + testHashStr + "00",
+	}
+	for _, input := range rejected {
+		t.Run("reject "+input, func(t *testing.T) {
+			var id BlockID
+			err := id.UnmarshalText([]byte(input))
+			require.ErrorContains(t, err, blockIDFormats)
+		})
+	}
+}
+
+func TestBlockIDTextRoundTrip(t *testing.T) {
+	id := BlockID{Hash: testHash, Number: 42}
+	text, err := id.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "42:"+testHashStr, string(text))
+	var out BlockID
+	require.NoError(t, out.UnmarshalText(text))
+	require.Equal(t, id, out)
+}
+
+func TestBlockIDJSON(t *testing.T) {
+	id := BlockID{Hash: testHash, Number: 42}
+	data, err := json.Marshal(id)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"hash":"`+testHashStr+`","number":42}`, string(data), "must keep object encoding")
+	var out BlockID
+	require.NoError(t, json.Unmarshal(data, &out))
+	require.Equal(t, id, out)
+
+	var fromStr BlockID
+	require.NoError(t, json.Unmarshal([]byte(`"42:`+testHashStr+`"`), &fromStr))
+	require.Equal(t, id, fromStr)
+}
+
+type mockL2BlockRefFetcher struct {
+	refs []L2BlockRef
+}
+
+func (m *mockL2BlockRefFetcher) L2BlockRefByNumber(ctx context.Context, num uint64) (L2BlockRef, error) {
+	for _, ref := range m.refs {
+		if ref.Number == num {
+			return ref, nil
+		}
+	}
+	return L2BlockRef{}, errors.New("not found")
+}
+
+func (m *mockL2BlockRefFetcher) L2BlockRefByHash(ctx context.Context, hash common.Hash) (L2BlockRef, error) {
+	for _, ref := range m.refs {
+		if ref.Hash == hash {
+			return ref, nil
+		}
+	}
+	return L2BlockRef{}, errors.New("not found")
+}
+
+func TestParseL2BlockRef(t *testing.T) {
+	ref := L2BlockRef{Hash: testHash, Number: 42, ParentHash: common.Hash{4}, Time: 1000, SequenceNumber: 3}
+	client := &mockL2BlockRefFetcher{refs: []L2BlockRef{ref}}
+	ctx := context.Background()
+
+	for _, input := range []string{"42", testHashStr, "42:" + testHashStr} {
+		out, err := ParseL2BlockRef(ctx, client, input)
+		require.NoError(t, err, input)
+		require.Equal(t, ref, out, input)
+	}
+
+	_, err := ParseL2BlockRef(ctx, client, "43:"+testHashStr)
+	require.ErrorIs(t, err, ErrBlockIDMismatch)
+	_, err = ParseL2BlockRef(ctx, client, "43")
+	require.ErrorContains(t, err, "not found")
+	_, err = ParseL2BlockRef(ctx, client, "latest")
+	require.ErrorContains(t, err, blockIDFormats)
+}
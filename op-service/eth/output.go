@@ -2,6 +2,7 @@ package eth
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -14,6 +15,25 @@ type OutputResponse struct {
 	WithdrawalStorageRoot common.Hash `json:"withdrawalStorageRoot"`
 	StateRoot             common.Hash `json:"stateRoot"`
 	Status                *SyncStatus `json:"syncStatus"`
+	// MessagePasserProof is the proof of the L2ToL1MessagePasser account that the output root was computed with,
+	// verified against the state root. Only included if requested with OutputAtBlockOptions.
+	MessagePasserProof *AccountResult `json:"messagePasserProof,omitempty"`
+}
+
+// OutputAtBlockOptions are the optional parameters of the optimism_outputAtBlock RPC.
+type OutputAtBlockOptions struct {
+	// IncludeProof includes the proof of the L2ToL1MessagePasser account in the response.
+	IncludeProof bool `json:"includeProof"`
+	// StorageKeys are the L2ToL1MessagePasser storage slots to include storage proofs of, e.g. of withdrawals to prove.
+	// Ignored if IncludeProof is not set.
+	StorageKeys []common.Hash `json:"storageKeys,omitempty"`
+}
+
+// SafeHeadResponse is the safe head that was derived from the L1 chain up to and including an L1 block,
+// and the latest L1 block that advanced the safe head.
+type SafeHeadResponse struct {
+	L1Block  BlockID `json:"l1Block"`
+	SafeHead BlockID `json:"safeHead"`
 }
 
 var (
@@ -41,6 +61,9 @@ func (o *OutputV0) Version() Bytes32 {
 	return OutputVersionV0
 }
 
+// Marshal encodes the output as the versioned output-root preimage:
+//
+//	version (32 bytes) ++ state root (32 bytes) ++ message-passer storage root (32 bytes) ++ block hash (32 bytes)
 func (o *OutputV0) Marshal() []byte {
 	var buf [128]byte
 	version := o.Version()
@@ -51,15 +74,22 @@ func (o *OutputV0) Marshal() []byte {
 	return buf[:]
 }
 
+// OutputRoot returns the keccak256 hash of the marshaled L2 output
+func (o *OutputV0) OutputRoot() Bytes32 {
+	return OutputRoot(o)
+}
+
 // OutputRoot returns the keccak256 hash of the marshaled L2 output
 func OutputRoot(output Output) Bytes32 {
 	marshaled := output.Marshal()
 	return Bytes32(crypto.Keccak256Hash(marshaled))
 }
 
+// UnmarshalOutput decodes an output-root preimage, dispatching on the version prefix.
+// Unknown versions are rejected with ErrInvalidOutputVersion, malformed data with ErrInvalidOutput.
 func UnmarshalOutput(data []byte) (Output, error) {
 	if len(data) < 32 {
-		return nil, ErrInvalidOutput
+		return nil, fmt.Errorf("%w: expected at least 32 bytes for the version, got %d", ErrInvalidOutput, len(data))
 	}
 	var ver Bytes32
 	copy(ver[:], data[:32])
@@ -67,13 +97,13 @@ func UnmarshalOutput(data []byte) (Output, error) {
 	case OutputVersionV0:
 		return unmarshalOutputV0(data)
 	default:
-		return nil, ErrInvalidOutputVersion
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOutputVersion, ver)
 	}
 }
 
 func unmarshalOutputV0(data []byte) (*OutputV0, error) {
 	if len(data) != 128 {
-		return nil, ErrInvalidOutput
+		return nil, fmt.Errorf("%w: expected 128 bytes for output v0, got %d", ErrInvalidOutput, len(data))
 	}
 	var output OutputV0
 	// data[:32] is the version
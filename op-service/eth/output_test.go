@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,3 +25,27 @@ func TestOutputV0Codec(t *testing.T) {
 	_, err = UnmarshalOutput([]byte{64: 0xA})
 	require.ErrorIs(t, err, ErrInvalidOutput)
 }
+
+func TestOutputV0Root(t *testing.T) {
+	// keccak256 of the all-zero 128 byte preimage
+	require.Equal(t, "0x012893657d8eb2efad4de0a91bcd0e39ad9837745dec3ea923737ea803fc8e3d", (&OutputV0{}).OutputRoot().String())
+
+	output := &OutputV0{
+		StateRoot:                Bytes32(common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111")),
+		MessagePasserStorageRoot: Bytes32(common.HexToHash("0x2222222222222222222222222222222222222222222222222222222222222222")),
+		BlockHash:                common.HexToHash("0x3333333333333333333333333333333333333333333333333333333333333333"),
+	}
+	require.Equal(t, "0x00000000000000000000000000000000000000000000000000000000000000001111111111111111111111111111111111111111111111111111111111111111"+
+		"22222222222222222222222222222222222222222222222222222222222222223333333333333333333333333333333333333333333333333333333333333333",
+		hexutil.Encode(output.Marshal()))
+	root := output.OutputRoot()
+	require.Equal(t, "0xd50bf2ff34ced71be0d2f0be7c2433c6b39d9c3b16c95daf1ed6f24b7578a3b2", root.String())
+	require.Equal(t, root, OutputRoot(output))
+}
+
+func TestUnmarshalOutputLengths(t *testing.T) {
+	for _, size := range []int{0, 31, 32, 127, 129} {
+		_, err := UnmarshalOutput(make([]byte, size))
+		require.ErrorIs(t, err, ErrInvalidOutput, "size %d", size)
+	}
+}
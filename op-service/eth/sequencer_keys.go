@@ -0,0 +1,44 @@
+package eth
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SequencerKeys is the set of p2p sequencer keys that gossiped blocks may be signed with.
+// To rotate the key without a flag-day, the replaced key remains valid as secondary key
+// until the rotation time, after which only the primary key is accepted.
+type SequencerKeys struct {
+	// Primary is the key of the sequencer, zero if unknown.
+	Primary common.Address `json:"primary"`
+	// Secondary is the key replaced by the primary key, zero if there is no rotation.
+	Secondary common.Address `json:"secondary"`
+	// RotationTime is the unix timestamp (seconds) from which the secondary key is no longer accepted.
+	RotationTime uint64 `json:"rotationTime"`
+}
+
+// Accepts returns whether a block signed by addr is accepted at the given unix time (seconds).
+func (k SequencerKeys) Accepts(addr common.Address, now uint64) bool {
+	if addr == (common.Address{}) {
+		return false
+	}
+	return addr == k.Primary || (addr == k.Secondary && now < k.RotationTime)
+}
+
+// Check verifies that the keys are a primary key, with an optional secondary key that has a rotation time.
+func (k SequencerKeys) Check() error {
+	if k.Primary == (common.Address{}) {
+		return errors.New("missing primary sequencer key")
+	}
+	if k.Secondary == (common.Address{}) {
+		return nil
+	}
+	if k.Secondary == k.Primary {
+		return errors.New("secondary sequencer key must differ from the primary key")
+	}
+	if k.RotationTime == 0 {
+		return errors.New("secondary sequencer key requires a rotation time")
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequencerKeysCheck(t *testing.T) {
+	primary, secondary := common.Address{0xaa}, common.Address{0xbb}
+	require.NoError(t, SequencerKeys{Primary: primary}.Check())
+	require.NoError(t, SequencerKeys{Primary: primary, Secondary: secondary, RotationTime: 1000}.Check())
+
+	require.ErrorContains(t, SequencerKeys{}.Check(), "missing primary")
+	require.ErrorContains(t, SequencerKeys{Secondary: secondary, RotationTime: 1000}.Check(), "missing primary")
+	require.ErrorContains(t, SequencerKeys{Primary: primary, Secondary: primary, RotationTime: 1000}.Check(), "must differ")
+	require.ErrorContains(t, SequencerKeys{Primary: primary, Secondary: secondary}.Check(), "rotation time")
+}
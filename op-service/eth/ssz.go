@@ -8,6 +8,7 @@ import (
 	"math"
 	"sync"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -16,6 +17,7 @@ type BlockVersion int
 const ( // iota is reset to 0
 	BlockV1 BlockVersion = iota
 	BlockV2              = iota
+	BlockV3              = iota
 )
 
 // ExecutionPayload is the only SSZ type we have to marshal/unmarshal,
@@ -28,6 +30,12 @@ const blockV1FixedPart = 32 + 20 + 32 + 32 + 256 + 32 + 8 + 8 + 8 + 8 + 4 + 32 +
 // V1 + Withdrawals offset
 const blockV2FixedPart = blockV1FixedPart + 4
 
+// V2 + BlobGasUsed + ExcessBlobGas
+const blockV3FixedPart = blockV2FixedPart + 8 + 8
+
+// parentBeaconBlockRoot of the envelope, which precedes the V3 execution payload
+const envelopeV3FixedPart = 32
+
 const withdrawalSize = 8 + 8 + 20 + 8
 
 // MAX_TRANSACTIONS_PER_PAYLOAD in consensus spec
@@ -53,15 +61,20 @@ var ErrBadTransactionOffset = errors.New("transactions offset is smaller than ex
 var ErrBadWithdrawalsOffset = errors.New("withdrawals offset is smaller than transaction offset, aborting")
 
 func executionPayloadFixedPart(version BlockVersion) uint32 {
-	if version == BlockV2 {
+	switch version {
+	case BlockV3:
+		return blockV3FixedPart
+	case BlockV2:
 		return blockV2FixedPart
-	} else {
+	default:
 		return blockV1FixedPart
 	}
 }
 
 func (payload *ExecutionPayload) inferVersion() BlockVersion {
-	if payload.Withdrawals != nil {
+	if payload.BlobGasUsed != nil && payload.ExcessBlobGas != nil {
+		return BlockV3
+	} else if payload.Withdrawals != nil {
 		return BlockV2
 	} else {
 		return BlockV1
@@ -161,19 +174,34 @@ func (payload *ExecutionPayload) MarshalSSZ(w io.Writer) (n int, err error) {
 	binary.LittleEndian.PutUint32(buf[offset:offset+4], fixedSize+extraDataSize)
 	offset += 4
 
-	if payload.Withdrawals == nil && offset != fixedSize {
+	version := payload.inferVersion()
+	if version == BlockV1 && offset != fixedSize {
 		panic("transactions - fixed part size is inconsistent")
 	}
 
-	if payload.Withdrawals != nil {
+	if version >= BlockV2 {
+		if payload.Withdrawals == nil {
+			return 0, errors.New("blob gas fields without withdrawals cannot be encoded")
+		}
 		binary.LittleEndian.PutUint32(buf[offset:offset+4], fixedSize+extraDataSize+transactionSize)
 		offset += 4
 
-		if offset != fixedSize {
+		if version == BlockV2 && offset != fixedSize {
 			panic("withdrawals - fixed part size is inconsistent")
 		}
 	}
 
+	if version == BlockV3 {
+		binary.LittleEndian.PutUint64(buf[offset:offset+8], uint64(*payload.BlobGasUsed))
+		offset += 8
+		binary.LittleEndian.PutUint64(buf[offset:offset+8], uint64(*payload.ExcessBlobGas))
+		offset += 8
+
+		if offset != fixedSize {
+			panic("blob gas - fixed part size is inconsistent")
+		}
+	}
+
 	// dynamic value 1: ExtraData
 	copy(buf[offset:offset+extraDataSize], payload.ExtraData[:])
 	offset += extraDataSize
@@ -188,6 +216,41 @@ func (payload *ExecutionPayload) MarshalSSZ(w io.Writer) (n int, err error) {
 	return w.Write(buf)
 }
 
+// MarshalSSZ encodes the envelope as the parent beacon block root, followed by the SSZ encoding
+// of the V3 execution payload. Only envelopes with a parent beacon block root can be encoded.
+func (envelope *ExecutionPayloadEnvelope) MarshalSSZ(w io.Writer) (n int, err error) {
+	if envelope.ParentBeaconBlockRoot == nil {
+		return 0, errors.New("missing parent beacon block root")
+	}
+	if envelope.ExecutionPayload.inferVersion() != BlockV3 {
+		return 0, errors.New("execution payload in envelope is missing blob gas fields")
+	}
+	root := *envelope.ParentBeaconBlockRoot
+	if n, err = w.Write(root[:]); err != nil {
+		return n, err
+	}
+	payloadN, err := envelope.ExecutionPayload.MarshalSSZ(w)
+	return n + payloadN, err
+}
+
+// UnmarshalSSZ decodes the envelope, encoded as the parent beacon block root followed by the V3 execution payload.
+func (envelope *ExecutionPayloadEnvelope) UnmarshalSSZ(scope uint32, r io.Reader) error {
+	if scope < envelopeV3FixedPart {
+		return fmt.Errorf("scope too small to decode execution payload envelope: %d", scope)
+	}
+	var root common.Hash
+	if _, err := io.ReadFull(r, root[:]); err != nil {
+		return fmt.Errorf("failed to read parent beacon block root: %w", err)
+	}
+	var payload ExecutionPayload
+	if err := payload.UnmarshalSSZ(BlockV3, scope-envelopeV3FixedPart, r); err != nil {
+		return err
+	}
+	envelope.ParentBeaconBlockRoot = &root
+	envelope.ExecutionPayload = &payload
+	return nil
+}
+
 func marshalWithdrawals(out []byte, withdrawals types.Withdrawals) {
 	offset := uint32(0)
 
@@ -275,15 +338,23 @@ func (payload *ExecutionPayload) UnmarshalSSZ(version BlockVersion, scope uint32
 	}
 
 	withdrawalsOffset := scope
-	if version == BlockV2 {
+	if version >= BlockV2 {
 		withdrawalsOffset = binary.LittleEndian.Uint32(buf[offset : offset+4])
-		// No offset increment, due to this being the last field
+		offset += 4
 
 		if withdrawalsOffset < transactionsOffset {
 			return ErrBadWithdrawalsOffset
 		}
 	}
 
+	if version == BlockV3 {
+		blobGasUsed := Uint64Quantity(binary.LittleEndian.Uint64(buf[offset : offset+8]))
+		offset += 8
+		excessBlobGas := Uint64Quantity(binary.LittleEndian.Uint64(buf[offset : offset+8]))
+		payload.BlobGasUsed = &blobGasUsed
+		payload.ExcessBlobGas = &excessBlobGas
+	}
+
 	if transactionsOffset > extraDataOffset+32 || transactionsOffset > scope {
 		return fmt.Errorf("extra-data is too large: %d", transactionsOffset-extraDataOffset)
 	}
@@ -298,7 +369,7 @@ func (payload *ExecutionPayload) UnmarshalSSZ(version BlockVersion, scope uint32
 	}
 	payload.Transactions = txs
 
-	if version == BlockV2 {
+	if version >= BlockV2 {
 		if withdrawalsOffset > scope {
 			return fmt.Errorf("withdrawals offset is too large: %d", withdrawalsOffset)
 		}
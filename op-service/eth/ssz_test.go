@@ -337,3 +337,36 @@ func TestMarshalUnmarshalWithdrawals(t *testing.T) {
 		})
 	}
 }
+
+func TestMarshalUnmarshalEnvelope(t *testing.T) {
+	blobGasUsed := Uint64Quantity(2 * 131072)
+	excessBlobGas := Uint64Quantity(42)
+	input := createPayloadWithWithdrawals(&types.Withdrawals{{Index: 1, Validator: 2, Amount: 3}})
+	input.BlobGasUsed = &blobGasUsed
+	input.ExcessBlobGas = &excessBlobGas
+	root := common.Hash{0xbe, 0xac, 0x04}
+	envelope := &ExecutionPayloadEnvelope{ParentBeaconBlockRoot: &root, ExecutionPayload: input}
+
+	var buf bytes.Buffer
+	_, err := envelope.MarshalSSZ(&buf)
+	require.NoError(t, err)
+	data := buf.Bytes()
+	require.Equal(t, root[:], data[:32])
+
+	output := &ExecutionPayloadEnvelope{}
+	require.NoError(t, output.UnmarshalSSZ(uint32(len(data)), bytes.NewReader(data)))
+	require.Equal(t, envelope, output)
+
+	// the payload of the envelope is the V3 encoding, which includes the blob gas fields
+	var payloadBuf bytes.Buffer
+	_, err = input.MarshalSSZ(&payloadBuf)
+	require.NoError(t, err)
+	require.Equal(t, payloadBuf.Bytes(), data[32:])
+	var payload ExecutionPayload
+	require.NoError(t, payload.UnmarshalSSZ(BlockV3, uint32(payloadBuf.Len()), bytes.NewReader(payloadBuf.Bytes())))
+	require.Equal(t, input, &payload)
+
+	_, err = (&ExecutionPayloadEnvelope{ExecutionPayload: input}).MarshalSSZ(&buf)
+	require.ErrorContains(t, err, "missing parent beacon block root")
+	require.Error(t, output.UnmarshalSSZ(16, bytes.NewReader(data)))
+}
@@ -0,0 +1,24 @@
+package eth
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSyncStatusJSONFields pins the JSON field names, consumers of optimism_syncStatus depend on them.
+func TestSyncStatusJSONFields(t *testing.T) {
+	data, err := json.Marshal(SyncStatus{})
+	require.NoError(t, err)
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &fields))
+	expected := []string{
+		"current_l1", "current_l1_finalized", "head_l1", "safe_l1", "finalized_l1",
+		"unsafe_l2", "safe_l2", "finalized_l2", "pending_safe_l2", "queued_unsafe_l2", "engine_sync_target",
+	}
+	require.Len(t, fields, len(expected))
+	for _, name := range expected {
+		require.Contains(t, fields, name)
+	}
+}
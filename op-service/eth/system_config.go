@@ -0,0 +1,171 @@
+package eth
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MinSystemConfigGasLimit is the lowest sane L2 block gas limit:
+// every L2 block has to fit the L1 info deposit system transaction.
+const MinSystemConfigGasLimit = 1_000_000
+
+const (
+	// L1ScalarBedrock is implied pre-Ecotone, encoding just a regular-gas scalar.
+	L1ScalarBedrock = byte(0)
+	// L1ScalarEcotone is new in Ecotone, allowing configuration of both a regular and a blobs scalar.
+	L1ScalarEcotone = byte(1)
+)
+
+var (
+	ErrSystemConfigMissingBatcherAddr = errors.New("system config batcher address must not be zero")
+	ErrSystemConfigGasLimitTooLow     = fmt.Errorf("system config gas limit must be at least %d", MinSystemConfigGasLimit)
+	ErrUnknownScalarVersion           = errors.New("unknown L1 fee scalar version")
+	ErrInvalidScalarPadding           = errors.New("invalid L1 fee scalar padding")
+)
+
+// SystemConfig represents the rollup system configuration that carries over in every L2 block,
+// and may be changed through L1 system config events.
+// The initial SystemConfig at rollup genesis is embedded in the rollup configuration.
+type SystemConfig struct {
+	// BatcherAddr identifies the batch-sender address used in batch-inbox data-transaction filtering.
+	BatcherAddr common.Address `json:"batcherAddr"`
+	// Overhead identifies the L1 fee overhead, and is passed through opaquely to op-geth.
+	Overhead Bytes32 `json:"overhead"`
+	// Scalar identifies the L1 fee scalar, and is passed through opaquely to op-geth.
+	Scalar Bytes32 `json:"scalar"`
+	// GasLimit identifies the L2 block gas limit
+	GasLimit uint64 `json:"gasLimit"`
+	// More fields can be added for future SystemConfig versions.
+}
+
+type systemConfigMarshaling struct {
+	BatcherAddr *common.Address `json:"batcherAddr"`
+	Overhead    *Bytes32        `json:"overhead"`
+	Scalar      *Bytes32        `json:"scalar"`
+	GasLimit    *uint64         `json:"gasLimit"`
+}
+
+// MarshalJSON encodes the system config with the addresses and 32-byte values
+// as fixed-width 0x-prefixed hex strings, and the gas limit as a JSON number.
+func (sysCfg SystemConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(systemConfigMarshaling{
+		BatcherAddr: &sysCfg.BatcherAddr,
+		Overhead:    &sysCfg.Overhead,
+		Scalar:      &sysCfg.Scalar,
+		GasLimit:    &sysCfg.GasLimit,
+	})
+}
+
+// UnmarshalJSON decodes the system config, and requires all fields to be present.
+// Hex-encoded fields must be of the exact expected width.
+func (sysCfg *SystemConfig) UnmarshalJSON(data []byte) error {
+	var dec systemConfigMarshaling
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	if dec.BatcherAddr == nil {
+		return errors.New("missing required field 'batcherAddr' for SystemConfig")
+	}
+	if dec.Overhead == nil {
+		return errors.New("missing required field 'overhead' for SystemConfig")
+	}
+	if dec.Scalar == nil {
+		return errors.New("missing required field 'scalar' for SystemConfig")
+	}
+	if dec.GasLimit == nil {
+		return errors.New("missing required field 'gasLimit' for SystemConfig")
+	}
+	sysCfg.BatcherAddr = *dec.BatcherAddr
+	sysCfg.Overhead = *dec.Overhead
+	sysCfg.Scalar = *dec.Scalar
+	sysCfg.GasLimit = *dec.GasLimit
+	return nil
+}
+
+// Validate checks that the system config has a batcher to accept batches from,
+// and that the gas limit is sane. The L1 SystemConfig contract does not enforce an upper bound on the gas limit.
+func (sysCfg *SystemConfig) Validate() error {
+	if sysCfg.BatcherAddr == (common.Address{}) {
+		return ErrSystemConfigMissingBatcherAddr
+	}
+	if sysCfg.GasLimit < MinSystemConfigGasLimit {
+		return fmt.Errorf("%w, got %d", ErrSystemConfigGasLimitTooLow, sysCfg.GasLimit)
+	}
+	return nil
+}
+
+// String formats the system config for logging, with the scalar decoded into its components.
+func (sysCfg SystemConfig) String() string {
+	scalar := "invalid"
+	if scalars, err := DecodeScalar(sysCfg.Scalar); err == nil {
+		scalar = scalars.String()
+	}
+	return fmt.Sprintf("SystemConfig{batcher: %s, overhead: %s, scalar: %s, gasLimit: %d}",
+		sysCfg.BatcherAddr, sysCfg.Overhead, scalar, sysCfg.GasLimit)
+}
+
+// EcotoneScalars decodes the L1 fee scalar of the system config.
+func (sysCfg *SystemConfig) EcotoneScalars() (EcotoneScalars, error) {
+	return DecodeScalar(sysCfg.Scalar)
+}
+
+// EcotoneScalars are the components of the versioned L1 fee scalar.
+// Pre-Ecotone (version 0) scalars only have a BaseFeeScalar.
+type EcotoneScalars struct {
+	Version           byte
+	BlobBaseFeeScalar uint32
+	BaseFeeScalar     uint32
+}
+
+func (s EcotoneScalars) String() string {
+	return fmt.Sprintf("v%d(baseFeeScalar: %d, blobBaseFeeScalar: %d)", s.Version, s.BaseFeeScalar, s.BlobBaseFeeScalar)
+}
+
+// CheckScalar verifies the versioning and padding of the given L1 fee scalar.
+// The scalar is encoded as:
+//
+//	version (1 byte) ++ zero padding (23 bytes) ++ blob basefee scalar (4 bytes) ++ basefee scalar (4 bytes)
+//
+// Version 0 scalars must leave the blob basefee scalar zeroed.
+func CheckScalar(scalar [32]byte) error {
+	switch scalar[0] {
+	case L1ScalarBedrock:
+		if scalar != ([32]byte{28: scalar[28], 29: scalar[29], 30: scalar[30], 31: scalar[31]}) {
+			return fmt.Errorf("%w: version 0 scalar must only use the lower 4 bytes: %x", ErrInvalidScalarPadding, scalar[:])
+		}
+	case L1ScalarEcotone:
+		for _, b := range scalar[1:24] {
+			if b != 0 {
+				return fmt.Errorf("%w: version 1 scalar has non-zero padding: %x", ErrInvalidScalarPadding, scalar[:])
+			}
+		}
+	default:
+		return fmt.Errorf("%w: %d", ErrUnknownScalarVersion, scalar[0])
+	}
+	return nil
+}
+
+// DecodeScalar decodes the versioned L1 fee scalar into its components.
+func DecodeScalar(scalar [32]byte) (EcotoneScalars, error) {
+	if err := CheckScalar(scalar); err != nil {
+		return EcotoneScalars{}, err
+	}
+	return EcotoneScalars{
+		Version:           scalar[0],
+		BlobBaseFeeScalar: binary.BigEndian.Uint32(scalar[24:28]),
+		BaseFeeScalar:     binary.BigEndian.Uint32(scalar[28:32]),
+	}, nil
+}
+
+// EncodeScalar packs the scalar components into the versioned Ecotone L1 fee scalar encoding.
+// The Version of the given scalars is ignored: the output is always a version 1 scalar.
+func EncodeScalar(scalars EcotoneScalars) (scalar Bytes32) {
+	scalar[0] = L1ScalarEcotone
+	binary.BigEndian.PutUint32(scalar[24:28], scalars.BlobBaseFeeScalar)
+	binary.BigEndian.PutUint32(scalar[28:32], scalars.BaseFeeScalar)
+	return
+}
@@ -0,0 +1,96 @@
+package eth
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemConfigJSON(t *testing.T) {
+	// genesis system configs, as found in the rollup.json files of existing chains
+	fixtures := map[string]string{
+		"op-mainnet":   `{"batcherAddr":"0x6887246668a3b87f54deb3b94ba47a6f63f32985","overhead":"0x00000000000000000000000000000000000000000000000000000000000000bc","scalar":"0x00000000000000000000000000000000000000000000000000000000000a6fe0","gasLimit":30000000}`,
+		"base-mainnet": `{"batcherAddr":"0x5050f69a9786f081509234f1a7f4684b5e5b76c9","overhead":"0x00000000000000000000000000000000000000000000000000000000000000bc","scalar":"0x00000000000000000000000000000000000000000000000000000000000a6fe0","gasLimit":30000000}`,
+		"ecotone":      `{"batcherAddr":"0x6887246668a3b87f54deb3b94ba47a6f63f32985","overhead":"0x0000000000000000000000000000000000000000000000000000000000000000","scalar":"0x010000000000000000000000000000000000000000000000000c5fc500000558","gasLimit":30000000}`,
+	}
+	for name, data := range fixtures {
+		data := data
+		t.Run(name, func(t *testing.T) {
+			var sysCfg SystemConfig
+			require.NoError(t, json.Unmarshal([]byte(data), &sysCfg))
+			require.NoError(t, sysCfg.Validate())
+			out, err := json.Marshal(sysCfg)
+			require.NoError(t, err)
+			require.Equal(t, data, string(out))
+		})
+	}
+
+	t.Run("missing field", func(t *testing.T) {
+		var sysCfg SystemConfig
+		err := json.Unmarshal([]byte(`{"batcherAddr":"0x6887246668a3b87f54deb3b94ba47a6f63f32985","overhead":"0x00000000000000000000000000000000000000000000000000000000000000bc","gasLimit":30000000}`), &sysCfg)
+		require.ErrorContains(t, err, "scalar")
+	})
+	t.Run("short scalar", func(t *testing.T) {
+		var sysCfg SystemConfig
+		err := json.Unmarshal([]byte(`{"batcherAddr":"0x6887246668a3b87f54deb3b94ba47a6f63f32985","overhead":"0x00000000000000000000000000000000000000000000000000000000000000bc","scalar":"0xa6fe0","gasLimit":30000000}`), &sysCfg)
+		require.Error(t, err)
+	})
+}
+
+func TestSystemConfigValidate(t *testing.T) {
+	valid := SystemConfig{
+		BatcherAddr: common.Address{0xaa},
+		GasLimit:    30_000_000,
+	}
+	require.NoError(t, valid.Validate())
+
+	noBatcher := valid
+	noBatcher.BatcherAddr = common.Address{}
+	require.ErrorIs(t, noBatcher.Validate(), ErrSystemConfigMissingBatcherAddr)
+
+	lowGas := valid
+	lowGas.GasLimit = MinSystemConfigGasLimit - 1
+	require.ErrorIs(t, lowGas.Validate(), ErrSystemConfigGasLimitTooLow)
+
+	highGas := valid
+	highGas.GasLimit = 1_000_000_000
+	require.NoError(t, highGas.Validate())
+}
+
+func TestScalarCodec(t *testing.T) {
+	t.Run("bedrock", func(t *testing.T) {
+		scalars, err := DecodeScalar(Bytes32{31: 0xe0, 30: 0x6f, 29: 0x0a})
+		require.NoError(t, err)
+		require.Equal(t, EcotoneScalars{Version: L1ScalarBedrock, BaseFeeScalar: 684000}, scalars)
+	})
+	t.Run("ecotone", func(t *testing.T) {
+		scalars := EcotoneScalars{Version: L1ScalarEcotone, BlobBaseFeeScalar: 810949, BaseFeeScalar: 1368}
+		encoded := EncodeScalar(scalars)
+		require.Equal(t, "0x010000000000000000000000000000000000000000000000000c5fc500000558", encoded.String())
+		decoded, err := DecodeScalar(encoded)
+		require.NoError(t, err)
+		require.Equal(t, scalars, decoded)
+	})
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := DecodeScalar(Bytes32{0: 2})
+		require.ErrorIs(t, err, ErrUnknownScalarVersion)
+	})
+	t.Run("bad padding", func(t *testing.T) {
+		_, err := DecodeScalar(Bytes32{0: L1ScalarEcotone, 10: 1})
+		require.ErrorIs(t, err, ErrInvalidScalarPadding)
+		_, err = DecodeScalar(Bytes32{0: L1ScalarBedrock, 25: 1})
+		require.ErrorIs(t, err, ErrInvalidScalarPadding)
+	})
+	t.Run("string", func(t *testing.T) {
+		sysCfg := SystemConfig{
+			BatcherAddr: common.Address{0xaa},
+			Overhead:    Bytes32(hexutil.MustDecode("0x00000000000000000000000000000000000000000000000000000000000000bc")),
+			Scalar:      EncodeScalar(EcotoneScalars{BlobBaseFeeScalar: 2, BaseFeeScalar: 1}),
+			GasLimit:    30_000_000,
+		}
+		require.Contains(t, sysCfg.String(), "v1(baseFeeScalar: 1, blobBaseFeeScalar: 2)")
+	})
+}
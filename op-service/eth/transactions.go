@@ -1,14 +1,23 @@
 package eth
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+)
+
+var (
+	ErrMissingL1InfoTx = errors.New("transaction list is missing the L1 info deposit tx")
+	ErrInvalidL1InfoTx = errors.New("first transaction is not an L1 info deposit tx")
 )
 
 // EncodeTransactions encodes a list of transactions into opaque transactions.
+// Typed transactions, including deposit transactions, are encoded with their type prefix.
 func EncodeTransactions(elems []*types.Transaction) ([]hexutil.Bytes, error) {
 	out := make([]hexutil.Bytes, len(elems))
 	for i, el := range elems {
@@ -22,6 +31,7 @@ func EncodeTransactions(elems []*types.Transaction) ([]hexutil.Bytes, error) {
 }
 
 // DecodeTransactions decodes a list of opaque transactions into transactions.
+// An error is returned on the first transaction that fails to decode, it never panics on malformed input.
 func DecodeTransactions(data []hexutil.Bytes) ([]*types.Transaction, error) {
 	dest := make([]*types.Transaction, len(data))
 	for i := range dest {
@@ -34,6 +44,25 @@ func DecodeTransactions(data []hexutil.Bytes) ([]*types.Transaction, error) {
 	return dest, nil
 }
 
+// CheckFirstIsL1Info decodes the first opaque transaction, and checks that it is a deposit
+// transaction to the L1 block predeploy, as required for the first transaction of every L2 block.
+func CheckFirstIsL1Info(txs []hexutil.Bytes) (*types.Transaction, error) {
+	if len(txs) == 0 {
+		return nil, ErrMissingL1InfoTx
+	}
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(txs[0]); err != nil {
+		return nil, fmt.Errorf("failed to decode first tx to read l1 info from: %w", err)
+	}
+	if tx.Type() != types.DepositTxType {
+		return nil, fmt.Errorf("%w: unexpected tx type: %d", ErrInvalidL1InfoTx, tx.Type())
+	}
+	if to := tx.To(); to == nil || *to != predeploys.L1BlockAddr {
+		return nil, fmt.Errorf("%w: unexpected recipient: %v", ErrInvalidL1InfoTx, to)
+	}
+	return &tx, nil
+}
+
 // TransactionsToHashes computes the transaction-hash for every transaction in the input.
 func TransactionsToHashes(elems []*types.Transaction) []common.Hash {
 	out := make([]common.Hash, len(elems))
@@ -0,0 +1,99 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+)
+
+func testL1InfoTx() *types.Transaction {
+	return types.NewTx(&types.DepositTx{
+		SourceHash: common.Hash{0xaa},
+		From:       common.HexToAddress("0xdeaddeaddeaddeaddeaddeaddeaddeaddead0001"),
+		To:         &predeploys.L1BlockAddr,
+		Gas:        1_000_000,
+		Data:       []byte{1, 2, 3},
+	})
+}
+
+func testSignedTx(t *testing.T) *types.Transaction {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := types.LatestSignerForChainID(big.NewInt(10))
+	return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+		ChainID:   big.NewInt(10),
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &common.Address{0xbb},
+	})
+}
+
+func TestTransactionsRoundTrip(t *testing.T) {
+	txs := []*types.Transaction{testL1InfoTx(), testSignedTx(t)}
+	opaque, err := EncodeTransactions(txs)
+	require.NoError(t, err)
+	require.Len(t, opaque, 2)
+	require.Equal(t, byte(types.DepositTxType), opaque[0][0], "deposit type prefix")
+
+	decoded, err := DecodeTransactions(opaque)
+	require.NoError(t, err)
+	require.Equal(t, TransactionsToHashes(txs), TransactionsToHashes(decoded))
+
+	_, err = DecodeTransactions([]hexutil.Bytes{opaque[0], {0x7e, 0x01}})
+	require.ErrorContains(t, err, "tx 1")
+}
+
+func TestCheckFirstIsL1Info(t *testing.T) {
+	l1Info, err := testL1InfoTx().MarshalBinary()
+	require.NoError(t, err)
+	regular, err := testSignedTx(t).MarshalBinary()
+	require.NoError(t, err)
+
+	tx, err := CheckFirstIsL1Info([]hexutil.Bytes{l1Info, regular})
+	require.NoError(t, err)
+	require.Equal(t, testL1InfoTx().Hash(), tx.Hash())
+
+	_, err = CheckFirstIsL1Info(nil)
+	require.ErrorIs(t, err, ErrMissingL1InfoTx)
+	_, err = CheckFirstIsL1Info([]hexutil.Bytes{regular, l1Info})
+	require.ErrorIs(t, err, ErrInvalidL1InfoTx)
+
+	otherDeposit, err := types.NewTx(&types.DepositTx{To: &common.Address{0xcc}, Gas: 1000}).MarshalBinary()
+	require.NoError(t, err)
+	_, err = CheckFirstIsL1Info([]hexutil.Bytes{otherDeposit})
+	require.ErrorIs(t, err, ErrInvalidL1InfoTx)
+
+	_, err = CheckFirstIsL1Info([]hexutil.Bytes{{0x7e}})
+	require.ErrorContains(t, err, "failed to decode")
+}
+
+// FuzzDecodeTransactions checks that decoding arbitrary bytes does not panic,
+// and that successfully decoded transactions re-encode to the same bytes.
+func FuzzDecodeTransactions(f *testing.F) {
+	l1Info, err := testL1InfoTx().MarshalBinary()
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(l1Info)
+	f.Add([]byte{0x7e})
+	f.Add([]byte{0x02, 0xc0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		txs, err := DecodeTransactions([]hexutil.Bytes{data})
+		if err != nil {
+			return
+		}
+		_, _ = CheckFirstIsL1Info([]hexutil.Bytes{data})
+		out, err := EncodeTransactions(txs)
+		require.NoError(t, err)
+		require.Len(t, out, 1)
+	})
+}
@@ -2,6 +2,8 @@ package eth
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"reflect"
@@ -125,8 +127,17 @@ type Data = hexutil.Bytes
 
 type PayloadID = engine.PayloadID
 
+// PayloadInfo identifies a payload that the engine is building. The parent beacon block root is the root
+// of the attributes the payload is built with, nil if the attributes precede the engine API V3.
+type PayloadInfo struct {
+	ID                    PayloadID
+	ParentBeaconBlockRoot *common.Hash
+}
+
 type ExecutionPayloadEnvelope struct {
-	ExecutionPayload *ExecutionPayload `json:"executionPayload"`
+	// nil if not present, pre-cancun
+	ParentBeaconBlockRoot *common.Hash      `json:"parentBeaconBlockRoot,omitempty"`
+	ExecutionPayload      *ExecutionPayload `json:"executionPayload"`
 }
 
 type ExecutionPayload struct {
@@ -148,6 +159,10 @@ type ExecutionPayload struct {
 	// Array of transaction objects, each object is a byte list (DATA) representing
 	// TransactionType || TransactionPayload or LegacyTransaction as defined in EIP-2718
 	Transactions []Data `json:"transactions"`
+	// nil if not present, pre-cancun
+	BlobGasUsed *Uint64Quantity `json:"blobGasUsed,omitempty"`
+	// nil if not present, pre-cancun
+	ExcessBlobGas *Uint64Quantity `json:"excessBlobGas,omitempty"`
 }
 
 func (payload *ExecutionPayload) ID() BlockID {
@@ -169,32 +184,59 @@ func (s rawTransactions) EncodeIndex(i int, w *bytes.Buffer) {
 	w.Write(s[i])
 }
 
+// BlobVersionedHashes returns the versioned hashes of the blobs of the transactions in the payload,
+// in transaction order, as engine_newPayloadV3 expects them. The result is never nil.
+func (payload *ExecutionPayload) BlobVersionedHashes() ([]common.Hash, error) {
+	hashes := []common.Hash{}
+	for i, otx := range payload.Transactions {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(otx); err != nil {
+			return nil, fmt.Errorf("failed to decode transaction %d: %w", i, err)
+		}
+		hashes = append(hashes, tx.BlobHashes()...)
+	}
+	return hashes, nil
+}
+
 func (payload *ExecutionPayload) CanyonBlock() bool {
 	return payload.Withdrawals != nil
 }
 
 // CheckBlockHash recomputes the block hash and returns if the embedded block hash matches.
 func (payload *ExecutionPayload) CheckBlockHash() (actual common.Hash, ok bool) {
+	return payload.checkBlockHash(nil)
+}
+
+// CheckBlockHash recomputes the block hash, including the parent beacon block root if present,
+// and returns if the block hash embedded in the execution payload matches.
+func (envelope *ExecutionPayloadEnvelope) CheckBlockHash() (actual common.Hash, ok bool) {
+	return envelope.ExecutionPayload.checkBlockHash(envelope.ParentBeaconBlockRoot)
+}
+
+func (payload *ExecutionPayload) checkBlockHash(parentBeaconBlockRoot *common.Hash) (actual common.Hash, ok bool) {
 	hasher := trie.NewStackTrie(nil)
 	txHash := types.DeriveSha(rawTransactions(payload.Transactions), hasher)
 
 	header := types.Header{
-		ParentHash:  payload.ParentHash,
-		UncleHash:   types.EmptyUncleHash,
-		Coinbase:    payload.FeeRecipient,
-		Root:        common.Hash(payload.StateRoot),
-		TxHash:      txHash,
-		ReceiptHash: common.Hash(payload.ReceiptsRoot),
-		Bloom:       types.Bloom(payload.LogsBloom),
-		Difficulty:  common.Big0, // zeroed, proof-of-work legacy
-		Number:      big.NewInt(int64(payload.BlockNumber)),
-		GasLimit:    uint64(payload.GasLimit),
-		GasUsed:     uint64(payload.GasUsed),
-		Time:        uint64(payload.Timestamp),
-		Extra:       payload.ExtraData,
-		MixDigest:   common.Hash(payload.PrevRandao),
-		Nonce:       types.BlockNonce{}, // zeroed, proof-of-work legacy
-		BaseFee:     payload.BaseFeePerGas.ToBig(),
+		ParentHash:       payload.ParentHash,
+		UncleHash:        types.EmptyUncleHash,
+		Coinbase:         payload.FeeRecipient,
+		Root:             common.Hash(payload.StateRoot),
+		TxHash:           txHash,
+		ReceiptHash:      common.Hash(payload.ReceiptsRoot),
+		Bloom:            types.Bloom(payload.LogsBloom),
+		Difficulty:       common.Big0, // zeroed, proof-of-work legacy
+		Number:           big.NewInt(int64(payload.BlockNumber)),
+		GasLimit:         uint64(payload.GasLimit),
+		GasUsed:          uint64(payload.GasUsed),
+		Time:             uint64(payload.Timestamp),
+		Extra:            payload.ExtraData,
+		MixDigest:        common.Hash(payload.PrevRandao),
+		Nonce:            types.BlockNonce{}, // zeroed, proof-of-work legacy
+		BaseFee:          payload.BaseFeePerGas.ToBig(),
+		BlobGasUsed:      (*uint64)(payload.BlobGasUsed),
+		ExcessBlobGas:    (*uint64)(payload.ExcessBlobGas),
+		ParentBeaconRoot: parentBeaconBlockRoot,
 	}
 
 	if payload.CanyonBlock() {
@@ -206,18 +248,60 @@ func (payload *ExecutionPayload) CheckBlockHash() (actual common.Hash, ok bool)
 	return blockHash, blockHash == payload.BlockHash
 }
 
+// BlockAsPayload converts the block into an execution payload.
+// Withdrawals, blob gas used and excess blob gas are included when present in the block.
+// An error is returned if the block has any header data that cannot be represented by the payload,
+// or if the block hash cannot be reproduced from the payload.
+// Blocks with a parent beacon block root must be converted with BlockAsPayloadEnv instead.
 func BlockAsPayload(bl *types.Block, canyonForkTime *uint64) (*ExecutionPayload, error) {
+	if bl.BeaconRoot() != nil {
+		return nil, fmt.Errorf("block %s field parentBeaconBlockRoot cannot be represented in execution payload, convert to an envelope instead", bl.Hash())
+	}
+	payload, err := blockAsPayload(bl, canyonForkTime)
+	if err != nil {
+		return nil, err
+	}
+	if actual, ok := payload.CheckBlockHash(); !ok {
+		return nil, fmt.Errorf("block %s converted to execution payload with different block hash %s", bl.Hash(), actual)
+	}
+	return payload, nil
+}
+
+// BlockAsPayloadEnv converts the block into an execution payload envelope,
+// which also carries the parent beacon block root of the block, if any.
+// See BlockAsPayload for the conversion rules.
+func BlockAsPayloadEnv(bl *types.Block, canyonForkTime *uint64) (*ExecutionPayloadEnvelope, error) {
+	payload, err := blockAsPayload(bl, canyonForkTime)
+	if err != nil {
+		return nil, err
+	}
+	envelope := &ExecutionPayloadEnvelope{
+		ParentBeaconBlockRoot: bl.BeaconRoot(),
+		ExecutionPayload:      payload,
+	}
+	if actual, ok := envelope.CheckBlockHash(); !ok {
+		return nil, fmt.Errorf("block %s converted to execution payload envelope with different block hash %s", bl.Hash(), actual)
+	}
+	return envelope, nil
+}
+
+func blockAsPayload(bl *types.Block, canyonForkTime *uint64) (*ExecutionPayload, error) {
+	if bl.Difficulty().Sign() != 0 {
+		return nil, fmt.Errorf("block %s field difficulty %s cannot be represented in execution payload", bl.Hash(), bl.Difficulty())
+	}
+	if bl.Nonce() != 0 {
+		return nil, fmt.Errorf("block %s field nonce %d cannot be represented in execution payload", bl.Hash(), bl.Nonce())
+	}
+	if bl.UncleHash() != types.EmptyUncleHash || len(bl.Uncles()) != 0 {
+		return nil, fmt.Errorf("block %s field uncles cannot be represented in execution payload", bl.Hash())
+	}
 	baseFee, overflow := uint256.FromBig(bl.BaseFee())
 	if overflow {
 		return nil, fmt.Errorf("invalid base fee in block: %s", bl.BaseFee())
 	}
-	opaqueTxs := make([]Data, len(bl.Transactions()))
-	for i, tx := range bl.Transactions() {
-		otx, err := tx.MarshalBinary()
-		if err != nil {
-			return nil, fmt.Errorf("tx %d failed to marshal: %w", i, err)
-		}
-		opaqueTxs[i] = otx
+	opaqueTxs, err := EncodeTransactions(bl.Transactions())
+	if err != nil {
+		return nil, err
 	}
 
 	payload := &ExecutionPayload{
@@ -235,10 +319,16 @@ func BlockAsPayload(bl *types.Block, canyonForkTime *uint64) (*ExecutionPayload,
 		BaseFeePerGas: *baseFee,
 		BlockHash:     bl.Hash(),
 		Transactions:  opaqueTxs,
+		BlobGasUsed:   (*Uint64Quantity)(bl.BlobGasUsed()),
+		ExcessBlobGas: (*Uint64Quantity)(bl.ExcessBlobGas()),
 	}
 
-	if canyonForkTime != nil && uint64(payload.Timestamp) >= *canyonForkTime {
-		payload.Withdrawals = &types.Withdrawals{}
+	if bl.Header().WithdrawalsHash != nil {
+		withdrawals := make(types.Withdrawals, len(bl.Withdrawals()))
+		copy(withdrawals, bl.Withdrawals())
+		payload.Withdrawals = &withdrawals
+	} else if canyonForkTime != nil && uint64(payload.Timestamp) >= *canyonForkTime {
+		return nil, fmt.Errorf("block %s is past the Canyon fork time %d but has no withdrawals", bl.Hash(), *canyonForkTime)
 	}
 
 	return payload, nil
@@ -259,6 +349,105 @@ type PayloadAttributes struct {
 	NoTxPool bool `json:"noTxPool,omitempty"`
 	// GasLimit override
 	GasLimit *Uint64Quantity `json:"gasLimit,omitempty"`
+	// ParentBeaconBlockRoot is the parent beacon block root, only present from engine API V3 (Cancun) onwards.
+	ParentBeaconBlockRoot *common.Hash `json:"parentBeaconBlockRoot,omitempty"`
+}
+
+// EngineAPIVersion identifies the version of the engine API methods,
+// which determines the exact wire format of the payload attributes.
+type EngineAPIVersion int
+
+const (
+	EngineAPIV1 EngineAPIVersion = 1
+	EngineAPIV2 EngineAPIVersion = 2
+	EngineAPIV3 EngineAPIVersion = 3
+)
+
+var (
+	ErrMissingGasLimit       = errors.New("payload attributes must specify a gas limit")
+	ErrNoTxPoolWithoutTxs    = errors.New("payload attributes with noTxPool must specify a transactions list")
+	ErrUnsupportedAttributes = errors.New("payload attributes not supported by engine API version")
+)
+
+// Validate checks the attributes for combinations of fields that the engine cannot build a block with.
+func (a *PayloadAttributes) Validate() error {
+	if a.GasLimit == nil {
+		return ErrMissingGasLimit
+	}
+	if a.NoTxPool && a.Transactions == nil {
+		return ErrNoTxPoolWithoutTxs
+	}
+	return nil
+}
+
+type payloadAttributesV1 struct {
+	Timestamp             Uint64Quantity  `json:"timestamp"`
+	PrevRandao            Bytes32         `json:"prevRandao"`
+	SuggestedFeeRecipient common.Address  `json:"suggestedFeeRecipient"`
+	Transactions          []Data          `json:"transactions,omitempty"`
+	NoTxPool              bool            `json:"noTxPool,omitempty"`
+	GasLimit              *Uint64Quantity `json:"gasLimit,omitempty"`
+}
+
+type payloadAttributesV2 struct {
+	payloadAttributesV1
+	Withdrawals *types.Withdrawals `json:"withdrawals,omitempty"`
+}
+
+type payloadAttributesV3 struct {
+	payloadAttributesV1
+	Withdrawals           *types.Withdrawals `json:"withdrawals"`
+	ParentBeaconBlockRoot *common.Hash       `json:"parentBeaconBlockRoot"`
+}
+
+// EngineAPIVersion returns the lowest engine API version that can represent the attributes.
+func (a *PayloadAttributes) EngineAPIVersion() EngineAPIVersion {
+	if a.ParentBeaconBlockRoot != nil {
+		return EngineAPIV3
+	}
+	if a.Withdrawals != nil {
+		return EngineAPIV2
+	}
+	return EngineAPIV1
+}
+
+// MarshalVersionedJSON encodes the attributes in the exact shape of the given engine API version:
+// V1 has no withdrawals, V2 optionally has withdrawals, and V3 requires both withdrawals and
+// the parent beacon block root. An error is returned if the attributes do not fit the version.
+func (a *PayloadAttributes) MarshalVersionedJSON(version EngineAPIVersion) ([]byte, error) {
+	v1 := payloadAttributesV1{
+		Timestamp:             a.Timestamp,
+		PrevRandao:            a.PrevRandao,
+		SuggestedFeeRecipient: a.SuggestedFeeRecipient,
+		Transactions:          a.Transactions,
+		NoTxPool:              a.NoTxPool,
+		GasLimit:              a.GasLimit,
+	}
+	switch version {
+	case EngineAPIV1:
+		if a.Withdrawals != nil {
+			return nil, fmt.Errorf("%w V1: withdrawals are not supported", ErrUnsupportedAttributes)
+		}
+		if a.ParentBeaconBlockRoot != nil {
+			return nil, fmt.Errorf("%w V1: parentBeaconBlockRoot is not supported", ErrUnsupportedAttributes)
+		}
+		return json.Marshal(v1)
+	case EngineAPIV2:
+		if a.ParentBeaconBlockRoot != nil {
+			return nil, fmt.Errorf("%w V2: parentBeaconBlockRoot is not supported", ErrUnsupportedAttributes)
+		}
+		return json.Marshal(payloadAttributesV2{payloadAttributesV1: v1, Withdrawals: a.Withdrawals})
+	case EngineAPIV3:
+		if a.Withdrawals == nil {
+			return nil, fmt.Errorf("%w V3: withdrawals are required", ErrUnsupportedAttributes)
+		}
+		if a.ParentBeaconBlockRoot == nil {
+			return nil, fmt.Errorf("%w V3: parentBeaconBlockRoot is required", ErrUnsupportedAttributes)
+		}
+		return json.Marshal(payloadAttributesV3{payloadAttributesV1: v1, Withdrawals: a.Withdrawals, ParentBeaconBlockRoot: a.ParentBeaconBlockRoot})
+	default:
+		return nil, fmt.Errorf("%w %d: unknown engine API version", ErrUnsupportedAttributes, version)
+	}
 }
 
 type ExecutePayloadStatus string
@@ -297,6 +486,56 @@ type ForkchoiceState struct {
 	FinalizedBlockHash common.Hash `json:"finalizedBlockHash"`
 }
 
+var (
+	ErrMissingForkchoiceHead  = errors.New("forkchoice state must specify a head block")
+	ErrFinalizedWithoutSafe   = errors.New("forkchoice state cannot specify a finalized block without a safe block")
+	ErrInconsistentForkchoice = errors.New("inconsistent forkchoice state")
+)
+
+func (fc *ForkchoiceState) String() string {
+	return fmt.Sprintf("head=%s safe=%s fin=%s", fc.HeadBlockHash, fc.SafeBlockHash, fc.FinalizedBlockHash)
+}
+
+// TerminalString implements log.TerminalStringer, formatting a string for console
+// output during logging.
+func (fc *ForkchoiceState) TerminalString() string {
+	return fmt.Sprintf("head=%s safe=%s fin=%s", fc.HeadBlockHash.TerminalString(), fc.SafeBlockHash.TerminalString(), fc.FinalizedBlockHash.TerminalString())
+}
+
+// Validate checks the forkchoice state for consistency that can be verified without block data:
+// the head must be set, and a finalized block implies a safe block.
+// The safe and finalized blocks may be zeroed if they are not known yet.
+func (fc *ForkchoiceState) Validate() error {
+	if fc.HeadBlockHash == (common.Hash{}) {
+		return ErrMissingForkchoiceHead
+	}
+	if fc.FinalizedBlockHash != (common.Hash{}) && fc.SafeBlockHash == (common.Hash{}) {
+		return ErrFinalizedWithoutSafe
+	}
+	return nil
+}
+
+// CheckForkchoiceRefs verifies that the blocks of a forkchoice state are ordered
+// such that finalized <= safe <= head, by block number.
+func CheckForkchoiceRefs(head, safe, finalized L2BlockRef) error {
+	if safe.Number > head.Number {
+		return fmt.Errorf("%w: safe block %s is ahead of head block %s", ErrInconsistentForkchoice, safe, head)
+	}
+	if finalized.Number > safe.Number {
+		return fmt.Errorf("%w: finalized block %s is ahead of safe block %s", ErrInconsistentForkchoice, finalized, safe)
+	}
+	return nil
+}
+
+// ParsePayloadID parses a payload ID from its 0x-prefixed hex encoding, as used in the engine API.
+func ParsePayloadID(text string) (PayloadID, error) {
+	var id PayloadID
+	if err := id.UnmarshalText([]byte(text)); err != nil {
+		return PayloadID{}, fmt.Errorf("invalid payload ID %q: %w", text, err)
+	}
+	return id, nil
+}
+
 type ForkchoiceUpdatedResult struct {
 	// the result of the payload execution
 	PayloadStatus PayloadStatusV1 `json:"payloadStatus"`
@@ -304,21 +543,6 @@ type ForkchoiceUpdatedResult struct {
 	PayloadID *PayloadID `json:"payloadId"`
 }
 
-// SystemConfig represents the rollup system configuration that carries over in every L2 block,
-// and may be changed through L1 system config events.
-// The initial SystemConfig at rollup genesis is embedded in the rollup configuration.
-type SystemConfig struct {
-	// BatcherAddr identifies the batch-sender address used in batch-inbox data-transaction filtering.
-	BatcherAddr common.Address `json:"batcherAddr"`
-	// Overhead identifies the L1 fee overhead, and is passed through opaquely to op-geth.
-	Overhead Bytes32 `json:"overhead"`
-	// Scalar identifies the L1 fee scalar, and is passed through opaquely to op-geth.
-	Scalar Bytes32 `json:"scalar"`
-	// GasLimit identifies the L2 block gas limit
-	GasLimit uint64 `json:"gasLimit"`
-	// More fields can be added for future SystemConfig versions.
-}
-
 type Bytes48 [48]byte
 
 func (b *Bytes48) UnmarshalJSON(text []byte) error {
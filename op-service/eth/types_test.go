@@ -1,9 +1,14 @@
 package eth
 
 import (
+	"encoding/json"
 	"errors"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,3 +23,236 @@ func TestInputError(t *testing.T) {
 	}
 	require.ErrorIs(t, err, InputError{}, "need to detect input error with errors.Is")
 }
+
+func testBlockHeader() *types.Header {
+	return &types.Header{
+		ParentHash: common.Hash{1},
+		Coinbase:   common.Address{2},
+		Root:       common.Hash{3},
+		Difficulty: common.Big0,
+		Number:     big.NewInt(100),
+		GasLimit:   30_000_000,
+		GasUsed:    21_000,
+		Time:       1000,
+		Extra:      []byte("test"),
+		MixDigest:  common.Hash{4},
+		BaseFee:    big.NewInt(7),
+	}
+}
+
+func testBlockTxs() []*types.Transaction {
+	to := common.Address{5}
+	return []*types.Transaction{
+		types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(10), Nonce: 1, To: &to, Gas: 21_000, GasFeeCap: big.NewInt(10), GasTipCap: big.NewInt(1)}),
+	}
+}
+
+func TestBlockAsPayload(t *testing.T) {
+	canyonTime := uint64(500)
+	t.Run("pre-fork", func(t *testing.T) {
+		bl := types.NewBlock(testBlockHeader(), testBlockTxs(), nil, nil, trie.NewStackTrie(nil))
+		payload, err := BlockAsPayload(bl, nil)
+		require.NoError(t, err)
+		require.Equal(t, bl.Hash(), payload.BlockHash)
+		require.Nil(t, payload.Withdrawals)
+		require.Nil(t, payload.BlobGasUsed)
+		require.Nil(t, payload.ExcessBlobGas)
+		require.Len(t, payload.Transactions, 1)
+	})
+	t.Run("post-fork", func(t *testing.T) {
+		header := testBlockHeader()
+		blobGasUsed, excessBlobGas := uint64(131072), uint64(42)
+		header.BlobGasUsed = &blobGasUsed
+		header.ExcessBlobGas = &excessBlobGas
+		withdrawals := []*types.Withdrawal{{Index: 1, Validator: 2, Address: common.Address{6}, Amount: 3}}
+		bl := types.NewBlockWithWithdrawals(header, testBlockTxs(), nil, nil, withdrawals, trie.NewStackTrie(nil))
+		payload, err := BlockAsPayload(bl, &canyonTime)
+		require.NoError(t, err)
+		require.Equal(t, bl.Hash(), payload.BlockHash)
+		require.Equal(t, types.Withdrawals(withdrawals), *payload.Withdrawals)
+		require.Equal(t, blobGasUsed, uint64(*payload.BlobGasUsed))
+		require.Equal(t, excessBlobGas, uint64(*payload.ExcessBlobGas))
+	})
+	t.Run("canyon empty withdrawals", func(t *testing.T) {
+		bl := types.NewBlockWithWithdrawals(testBlockHeader(), nil, nil, nil, []*types.Withdrawal{}, trie.NewStackTrie(nil))
+		payload, err := BlockAsPayload(bl, &canyonTime)
+		require.NoError(t, err)
+		require.NotNil(t, payload.Withdrawals)
+		require.Empty(t, *payload.Withdrawals)
+	})
+	t.Run("canyon missing withdrawals", func(t *testing.T) {
+		bl := types.NewBlock(testBlockHeader(), nil, nil, nil, trie.NewStackTrie(nil))
+		_, err := BlockAsPayload(bl, &canyonTime)
+		require.ErrorContains(t, err, "no withdrawals")
+	})
+	t.Run("beacon root", func(t *testing.T) {
+		header := testBlockHeader()
+		header.ParentBeaconRoot = &common.Hash{7}
+		bl := types.NewBlockWithWithdrawals(header, nil, nil, nil, []*types.Withdrawal{}, trie.NewStackTrie(nil))
+		_, err := BlockAsPayload(bl, &canyonTime)
+		require.ErrorContains(t, err, "parentBeaconBlockRoot")
+		envelope, err := BlockAsPayloadEnv(bl, &canyonTime)
+		require.NoError(t, err)
+		require.Equal(t, header.ParentBeaconRoot, envelope.ParentBeaconBlockRoot)
+		require.Equal(t, bl.Hash(), envelope.ExecutionPayload.BlockHash)
+	})
+	t.Run("unsupported fields", func(t *testing.T) {
+		header := testBlockHeader()
+		header.Difficulty = big.NewInt(1)
+		_, err := BlockAsPayload(types.NewBlock(header, nil, nil, nil, trie.NewStackTrie(nil)), nil)
+		require.ErrorContains(t, err, "difficulty")
+
+		header = testBlockHeader()
+		header.Nonce = types.EncodeNonce(123)
+		_, err = BlockAsPayload(types.NewBlock(header, nil, nil, nil, trie.NewStackTrie(nil)), nil)
+		require.ErrorContains(t, err, "nonce")
+
+		header = testBlockHeader()
+		_, err = BlockAsPayload(types.NewBlock(header, nil, []*types.Header{testBlockHeader()}, nil, trie.NewStackTrie(nil)), nil)
+		require.ErrorContains(t, err, "uncles")
+	})
+	t.Run("hash mismatch", func(t *testing.T) {
+		header := testBlockHeader()
+		header.UncleHash = types.EmptyUncleHash
+		header.TxHash = types.EmptyTxsHash
+		header.WithdrawalsHash = &common.Hash{0xff} // does not commit to the withdrawals in the body
+		bl := types.NewBlockWithHeader(header).WithWithdrawals([]*types.Withdrawal{{Index: 1}})
+		_, err := BlockAsPayload(bl, &canyonTime)
+		require.ErrorContains(t, err, "different block hash")
+	})
+}
+
+func TestPayloadAttributesVersionedJSON(t *testing.T) {
+	gasLimit := Uint64Quantity(30_000_000)
+	v1 := PayloadAttributes{
+		Timestamp:             1000,
+		PrevRandao:            Bytes32{1},
+		SuggestedFeeRecipient: common.Address{2},
+		Transactions:          []Data{{0x7e, 0x01}},
+		NoTxPool:              true,
+		GasLimit:              &gasLimit,
+	}
+	v2 := v1
+	v2.Withdrawals = &types.Withdrawals{}
+	v3 := v2
+	v3.ParentBeaconBlockRoot = &common.Hash{3}
+
+	require.Equal(t, EngineAPIV1, v1.EngineAPIVersion())
+	require.Equal(t, EngineAPIV2, v2.EngineAPIVersion())
+	require.Equal(t, EngineAPIV3, v3.EngineAPIVersion())
+
+	const v1Fields = `"timestamp":"0x3e8",` +
+		`"prevRandao":"0x0100000000000000000000000000000000000000000000000000000000000000",` +
+		`"suggestedFeeRecipient":"0x0200000000000000000000000000000000000000",` +
+		`"transactions":["0x7e01"],"noTxPool":true,"gasLimit":"0x1c9c380"`
+
+	golden := []struct {
+		name     string
+		attrs    PayloadAttributes
+		version  EngineAPIVersion
+		expected string
+	}{
+		{"V1", v1, EngineAPIV1, `{` + v1Fields + `}`},
+		{"V1 attributes in V2", v1, EngineAPIV2, `{` + v1Fields + `}`},
+		{"V2", v2, EngineAPIV2, `{` + v1Fields + `,"withdrawals":[]}`},
+		{"V3", v3, EngineAPIV3, `{` + v1Fields + `,"withdrawals":[],` +
+			`"parentBeaconBlockRoot":"0x0300000000000000000000000000000000000000000000000000000000000000"}`},
+	}
+	for _, tc := range golden {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := tc.attrs.MarshalVersionedJSON(tc.version)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(data))
+		})
+	}
+
+	invalid := []struct {
+		name    string
+		attrs   PayloadAttributes
+		version EngineAPIVersion
+	}{
+		{"V2 attributes in V1", v2, EngineAPIV1},
+		{"V3 attributes in V1", v3, EngineAPIV1},
+		{"V3 attributes in V2", v3, EngineAPIV2},
+		{"V2 attributes in V3", v2, EngineAPIV3},
+		{"V1 attributes in V3", v1, EngineAPIV3},
+		{"unknown version", v1, EngineAPIVersion(4)},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.attrs.MarshalVersionedJSON(tc.version)
+			require.ErrorIs(t, err, ErrUnsupportedAttributes)
+		})
+	}
+}
+
+func TestPayloadAttributesValidate(t *testing.T) {
+	gasLimit := Uint64Quantity(30_000_000)
+	attrs := PayloadAttributes{Transactions: []Data{{0x7e}}, NoTxPool: true, GasLimit: &gasLimit}
+	require.NoError(t, attrs.Validate())
+
+	noGasLimit := attrs
+	noGasLimit.GasLimit = nil
+	require.ErrorIs(t, noGasLimit.Validate(), ErrMissingGasLimit)
+
+	noTxs := attrs
+	noTxs.Transactions = nil
+	require.ErrorIs(t, noTxs.Validate(), ErrNoTxPoolWithoutTxs)
+
+	noTxs.NoTxPool = false
+	require.NoError(t, noTxs.Validate(), "txs may come from the tx-pool")
+}
+
+func TestForkchoiceStateString(t *testing.T) {
+	fc := &ForkchoiceState{
+		HeadBlockHash:      common.Hash{0xaa},
+		SafeBlockHash:      common.Hash{0xbb},
+		FinalizedBlockHash: common.Hash{31: 0xcc},
+	}
+	require.Equal(t, "head=0xaa00000000000000000000000000000000000000000000000000000000000000 "+
+		"safe=0xbb00000000000000000000000000000000000000000000000000000000000000 "+
+		"fin=0x00000000000000000000000000000000000000000000000000000000000000cc", fc.String())
+	require.Equal(t, "head=aa0000..000000 safe=bb0000..000000 fin=000000..0000cc", fc.TerminalString())
+}
+
+func TestForkchoiceStateValidate(t *testing.T) {
+	require.NoError(t, (&ForkchoiceState{HeadBlockHash: common.Hash{1}}).Validate(), "safe and finalized may be unknown")
+	require.NoError(t, (&ForkchoiceState{HeadBlockHash: common.Hash{1}, SafeBlockHash: common.Hash{2}, FinalizedBlockHash: common.Hash{3}}).Validate())
+	require.ErrorIs(t, (&ForkchoiceState{SafeBlockHash: common.Hash{2}}).Validate(), ErrMissingForkchoiceHead)
+	require.ErrorIs(t, (&ForkchoiceState{HeadBlockHash: common.Hash{1}, FinalizedBlockHash: common.Hash{3}}).Validate(), ErrFinalizedWithoutSafe)
+}
+
+func TestCheckForkchoiceRefs(t *testing.T) {
+	ref := func(n uint64) L2BlockRef { return L2BlockRef{Hash: common.Hash{byte(n)}, Number: n} }
+	require.NoError(t, CheckForkchoiceRefs(ref(10), ref(5), ref(1)))
+	require.NoError(t, CheckForkchoiceRefs(ref(10), ref(10), ref(10)))
+	require.NoError(t, CheckForkchoiceRefs(ref(10), ref(5), L2BlockRef{}), "finalized may be unknown")
+
+	err := CheckForkchoiceRefs(ref(5), ref(10), ref(1))
+	require.ErrorIs(t, err, ErrInconsistentForkchoice)
+	require.ErrorContains(t, err, "safe block")
+	err = CheckForkchoiceRefs(ref(10), ref(5), ref(6))
+	require.ErrorIs(t, err, ErrInconsistentForkchoice)
+	require.ErrorContains(t, err, "finalized block")
+}
+
+func TestPayloadIDRoundTrip(t *testing.T) {
+	id := PayloadID{1, 2, 3, 4, 5, 6, 7, 8}
+	text, err := id.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "0x0102030405060708", string(text))
+	parsed, err := ParsePayloadID(string(text))
+	require.NoError(t, err)
+	require.Equal(t, id, parsed)
+
+	data, err := json.Marshal(&ForkchoiceUpdatedResult{PayloadID: &id})
+	require.NoError(t, err)
+	var res ForkchoiceUpdatedResult
+	require.NoError(t, json.Unmarshal(data, &res))
+	require.Equal(t, id, *res.PayloadID)
+
+	_, err = ParsePayloadID("0x01")
+	require.ErrorContains(t, err, "invalid payload ID")
+	_, err = ParsePayloadID("0102030405060708")
+	require.Error(t, err)
+}
@@ -1,31 +1,145 @@
 package log
 
-import "github.com/ethereum/go-ethereum/log"
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/log"
+)
 
 type LvlSetter interface {
 	SetLogLevel(lvl log.Lvl)
 }
 
+// LvlGetter is implemented by log handlers that can report their current log level.
+type LvlGetter interface {
+	LogLevel() log.Lvl
+}
+
+// ModuleLvlSetter is implemented by log handlers that can override the log level of modules.
+type ModuleLvlSetter interface {
+	// SetModuleLogLevel overrides the log level of the loggers with a name that starts with the prefix.
+	SetModuleLogLevel(prefix string, lvl log.Lvl)
+	// ClearModuleLogLevel removes the override of the log level for the prefix.
+	ClearModuleLogLevel(prefix string)
+}
+
+// moduleLvl is the log level override of the loggers with a name that starts with the prefix.
+type moduleLvl struct {
+	prefix string
+	lvl    log.Lvl
+}
+
 // DynamicLogHandler allow runtime-configuration of the log handler.
 type DynamicLogHandler struct {
 	log.Handler // embedded, to expose any extra methods the underlying handler might provide
-	maxLvl      log.Lvl
+	maxLvl      atomic.Int64
+
+	// modules are the log level overrides, sorted by descending prefix length, so that the longest prefix matches first.
+	modules   atomic.Pointer[[]moduleLvl]
+	modulesMu sync.Mutex
 }
 
 func NewDynamicLogHandler(lvl log.Lvl, h log.Handler) *DynamicLogHandler {
-	return &DynamicLogHandler{
+	d := &DynamicLogHandler{
 		Handler: h,
-		maxLvl:  lvl,
 	}
+	d.maxLvl.Store(int64(lvl))
+	return d
 }
 
 func (d *DynamicLogHandler) SetLogLevel(lvl log.Lvl) {
-	d.maxLvl = lvl
+	d.maxLvl.Store(int64(lvl))
+}
+
+func (d *DynamicLogHandler) LogLevel() log.Lvl {
+	return log.Lvl(d.maxLvl.Load())
+}
+
+func (d *DynamicLogHandler) SetModuleLogLevel(prefix string, lvl log.Lvl) {
+	d.updateModules(func(modules []moduleLvl) []moduleLvl {
+		return append(withoutModule(modules, prefix), moduleLvl{prefix: prefix, lvl: lvl})
+	})
+}
+
+func (d *DynamicLogHandler) ClearModuleLogLevel(prefix string) {
+	d.updateModules(func(modules []moduleLvl) []moduleLvl {
+		return withoutModule(modules, prefix)
+	})
+}
+
+// ModuleLogLevels returns the log level overrides by module prefix.
+func (d *DynamicLogHandler) ModuleLogLevels() map[string]log.Lvl {
+	out := make(map[string]log.Lvl)
+	if modules := d.modules.Load(); modules != nil {
+		for _, m := range *modules {
+			out[m.prefix] = m.lvl
+		}
+	}
+	return out
+}
+
+func (d *DynamicLogHandler) updateModules(fn func(modules []moduleLvl) []moduleLvl) {
+	d.modulesMu.Lock()
+	defer d.modulesMu.Unlock()
+	var modules []moduleLvl
+	if current := d.modules.Load(); current != nil {
+		modules = append(modules, *current...)
+	}
+	modules = fn(modules)
+	sort.SliceStable(modules, func(i, j int) bool {
+		return len(modules[i].prefix) > len(modules[j].prefix)
+	})
+	d.modules.Store(&modules)
+}
+
+func withoutModule(modules []moduleLvl, prefix string) []moduleLvl {
+	out := modules[:0]
+	for _, m := range modules {
+		if m.prefix != prefix {
+			out = append(out, m)
+		}
+	}
+	return out
 }
 
 func (d *DynamicLogHandler) Log(r *log.Record) error {
-	if r.Lvl > d.maxLvl { // lower log level values are more critical
+	if r.Lvl > d.recordLvl(r) { // lower log level values are more critical
 		return nil
 	}
 	return d.Handler.Log(r) // process the log
 }
+
+// recordLvl returns the log level of the logger of the record.
+func (d *DynamicLogHandler) recordLvl(r *log.Record) log.Lvl {
+	if modules := d.modules.Load(); modules != nil && len(*modules) > 0 {
+		name := LoggerName(r)
+		for _, m := range *modules {
+			if strings.HasPrefix(name, m.prefix) {
+				return m.lvl
+			}
+		}
+	}
+	return d.LogLevel()
+}
+
+// LoggerName returns the name of the logger of the record, which module log levels are matched against:
+// the first key-value pair of the record context, which starts with the logger context, formatted as key=value.
+// E.g. the name of a logger created with log.New("rpc", "node") is "rpc=node".
+func LoggerName(r *log.Record) string {
+	if len(r.Ctx) < 2 {
+		return ""
+	}
+	return fmt.Sprintf("%v=%v", r.Ctx[0], r.Ctx[1])
+}
+
+// NextLogLevel returns the next more verbose log level, up to trace, after which it starts over from crit.
+func NextLogLevel(lvl log.Lvl) log.Lvl {
+	if lvl >= log.LvlTrace || lvl < log.LvlCrit {
+		return log.LvlCrit
+	}
+	return lvl + 1
+}
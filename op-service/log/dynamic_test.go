@@ -1,6 +1,8 @@
 package log
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -42,3 +44,75 @@ func TestDynamicLogHandler_SetLogLevel(t *testing.T) {
 	require.Equal(t, records[4].Msg, "visible warning")
 	require.Equal(t, records[5].Msg, "another error")
 }
+
+func TestDynamicLogHandler_ModuleLogLevel(t *testing.T) {
+	var records []*log.Record
+	h := log.FuncHandler(func(r *log.Record) error {
+		records = append(records, r)
+		return nil
+	})
+	d := NewDynamicLogHandler(log.LvlInfo, h)
+	root := log.New()
+	root.SetHandler(d)
+	rpcLogger := root.New("rpc", "node")
+	healthLogger := root.New("rpc", "health")
+	p2pLogger := root.New("p2p", "gossip")
+
+	d.SetModuleLogLevel("rpc", log.LvlDebug)
+	d.SetModuleLogLevel("rpc=health", log.LvlError)
+	require.Equal(t, map[string]log.Lvl{"rpc": log.LvlDebug, "rpc=health": log.LvlError}, d.ModuleLogLevels())
+	rpcLogger.Debug("rpc debug")       // y
+	healthLogger.Info("health info")   // n, the longest prefix matches
+	healthLogger.Error("health error") // y
+	p2pLogger.Debug("p2p debug")       // n
+	p2pLogger.Info("p2p info")         // y
+	root.Debug("root debug")           // n
+
+	d.ClearModuleLogLevel("rpc")
+	rpcLogger.Debug("rpc debug hidden") // n
+	healthLogger.Warn("health warn")    // n
+
+	require.Len(t, records, 3)
+	require.Equal(t, records[0].Msg, "rpc debug")
+	require.Equal(t, records[1].Msg, "health error")
+	require.Equal(t, records[2].Msg, "p2p info")
+}
+
+func TestDynamicLogHandler_SetLogLevelConcurrently(t *testing.T) {
+	var count atomic.Int64
+	h := log.FuncHandler(func(r *log.Record) error {
+		count.Add(1)
+		return nil
+	})
+	d := NewDynamicLogHandler(log.LvlDebug, h)
+	logger := log.New()
+	logger.SetHandler(d)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			logger.Debug("debug")
+		}
+	}()
+	d.SetLogLevel(log.LvlInfo)
+	d.SetModuleLogLevel("x", log.LvlTrace)
+	wg.Wait()
+	require.Equal(t, log.LvlInfo, d.LogLevel())
+
+	// no debug records appear after the level changed
+	before := count.Load()
+	logger.Debug("debug")
+	require.Equal(t, before, count.Load())
+}
+
+func TestNextLogLevel(t *testing.T) {
+	lvl := log.LvlInfo
+	var lvls []log.Lvl
+	for i := 0; i < 6; i++ {
+		lvl = NextLogLevel(lvl)
+		lvls = append(lvls, lvl)
+	}
+	require.Equal(t, []log.Lvl{log.LvlDebug, log.LvlTrace, log.LvlCrit, log.LvlError, log.LvlWarn, log.LvlInfo}, lvls)
+}
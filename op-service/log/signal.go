@@ -0,0 +1,41 @@
+//go:build !windows
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// CycleLogLevelOnSignal cycles the log level of the log handler of the logger on every SIGUSR1, until the ctx is done.
+// It is meant for services without an admin RPC to set the log level. See NextLogLevel for the order of the levels.
+func CycleLogLevelOnSignal(ctx context.Context, logger log.Logger) {
+	h, ok := logger.GetHandler().(interface {
+		LvlSetter
+		LvlGetter
+	})
+	if !ok {
+		logger.Warn("Log handler cannot change log level, not cycling the log level on SIGUSR1", "handler", fmt.Sprintf("%T", logger.GetHandler()))
+		return
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1)
+	go func() {
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-signals:
+				lvl := NextLogLevel(h.LogLevel())
+				logger.Info("Changing log level", "lvl", lvl)
+				h.SetLogLevel(lvl)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
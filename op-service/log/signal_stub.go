@@ -0,0 +1,14 @@
+//go:build windows
+
+package log
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// CycleLogLevelOnSignal is not supported on windows, which has no SIGUSR1.
+func CycleLogLevelOnSignal(ctx context.Context, logger log.Logger) {
+	logger.Warn("Cycling the log level on SIGUSR1 is not supported on windows")
+}
@@ -0,0 +1,40 @@
+//go:build !windows
+
+package log
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCycleLogLevelOnSignal(t *testing.T) {
+	var records []*log.Record
+	recordsCh := make(chan *log.Record, 10)
+	d := NewDynamicLogHandler(log.LvlInfo, log.FuncHandler(func(r *log.Record) error {
+		recordsCh <- r
+		return nil
+	}))
+	logger := log.New()
+	logger.SetHandler(d)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	CycleLogLevelOnSignal(ctx, logger)
+
+	logger.Debug("hidden debug")
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+	require.Eventually(t, func() bool { return d.LogLevel() == log.LvlDebug }, 5*time.Second, 10*time.Millisecond)
+	logger.Debug("visible debug")
+
+	close(recordsCh)
+	for r := range recordsCh {
+		records = append(records, r)
+	}
+	require.Len(t, records, 2)
+	require.Equal(t, "Changing log level", records[0].Msg)
+	require.Equal(t, "visible debug", records[1].Msg)
+}
@@ -3,6 +3,7 @@ package metrics
 import (
 	"context"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -49,3 +50,98 @@ func LaunchBalanceMetrics(log log.Logger, r *prometheus.Registry, ns string, cli
 		return nil
 	}, 10*time.Second)
 }
+
+// BalanceAccount is an account whose balance is monitored. The name identifies the account in logs and metrics.
+type BalanceAccount struct {
+	Name    string
+	Address common.Address
+}
+
+// BalanceClient is the client that the balances of the monitored accounts are queried from.
+type BalanceClient interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// BalanceMonitor records the balances of accounts to the "account_balance" metric of the namespace, in Ether
+// (not Wei), by account name. Accounts with a balance below the low balance threshold are flagged by the
+// "account_balance_low" metric, and logged at error level when they drop below it.
+type BalanceMonitor struct {
+	log       log.Logger
+	client    BalanceClient
+	accounts  []BalanceAccount
+	threshold *big.Int
+
+	balance    *prometheus.GaugeVec
+	balanceLow *prometheus.GaugeVec
+
+	mu  sync.Mutex
+	low map[string]bool
+}
+
+// NewBalanceMonitor creates a monitor of the balances of the accounts. The low balance threshold is disabled
+// if it is nil or zero.
+func NewBalanceMonitor(log log.Logger, factory Factory, ns string, client BalanceClient, accounts []BalanceAccount, lowBalanceThreshold *big.Int) *BalanceMonitor {
+	return &BalanceMonitor{
+		log:       log,
+		client:    client,
+		accounts:  accounts,
+		threshold: lowBalanceThreshold,
+		balance: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "account_balance",
+			Help:      "Balance (in ether) of the monitored accounts, by account name",
+		}, []string{"account"}),
+		balanceLow: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "account_balance_low",
+			Help:      "1 if the balance of the monitored account is below the low balance threshold, 0 otherwise",
+		}, []string{"account"}),
+		low: make(map[string]bool),
+	}
+}
+
+// Update queries and records the balances of all accounts once.
+func (m *BalanceMonitor) Update(ctx context.Context) {
+	for _, account := range m.accounts {
+		ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+		bal, err := m.client.BalanceAt(ctx, account.Address, nil)
+		cancel()
+		if err != nil {
+			m.log.Warn("Failed to get balance of account", "account", account.Name, "address", account.Address, "err", err)
+			continue
+		}
+		m.balance.WithLabelValues(account.Name).Set(weiToEther(bal))
+		if m.threshold == nil || m.threshold.Sign() == 0 {
+			continue
+		}
+		m.recordLow(account, bal, bal.Cmp(m.threshold) < 0)
+	}
+}
+
+func (m *BalanceMonitor) recordLow(account BalanceAccount, bal *big.Int, low bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if low {
+		m.balanceLow.WithLabelValues(account.Name).Set(1)
+		if !m.low[account.Name] {
+			m.log.Error("Account balance is below the low balance threshold", "account", account.Name, "address", account.Address,
+				"balance", weiToEther(bal), "threshold", weiToEther(m.threshold))
+		}
+	} else {
+		m.balanceLow.WithLabelValues(account.Name).Set(0)
+		if m.low[account.Name] {
+			m.log.Info("Account balance is above the low balance threshold again", "account", account.Name, "address", account.Address,
+				"balance", weiToEther(bal), "threshold", weiToEther(m.threshold))
+		}
+	}
+	m.low[account.Name] = low
+}
+
+// Start starts the periodic update of the balances at the interval.
+// Close the returned loop to shut down the go routine.
+func (m *BalanceMonitor) Start(interval time.Duration) *clock.LoopFn {
+	return clock.NewLoopFn(clock.SystemClock, m.Update, func() error {
+		m.log.Info("Balance monitor shutting down")
+		return nil
+	}, interval)
+}
@@ -1,8 +1,19 @@
 package metrics
 
 import (
+	"context"
+	"errors"
 	"math/big"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
 type weiToEthTestCase struct {
@@ -38,3 +49,91 @@ func TestWeiToEther(t *testing.T) {
 	}
 
 }
+
+// fakeBalanceClient returns the balances of the accounts, or an error for unknown accounts.
+type fakeBalanceClient map[common.Address]*big.Int
+
+func (c fakeBalanceClient) BalanceAt(_ context.Context, account common.Address, _ *big.Int) (*big.Int, error) {
+	bal, ok := c[account]
+	if !ok {
+		return nil, errors.New("unknown account")
+	}
+	return bal, nil
+}
+
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(params.Ether))
+}
+
+func TestBalanceMonitorLowBalance(t *testing.T) {
+	batcher := BalanceAccount{Name: "batcher", Address: common.Address{0xaa}}
+	other := BalanceAccount{Name: "other", Address: common.Address{0xbb}}
+	client := fakeBalanceClient{batcher.Address: ether(3), other.Address: ether(10)}
+	logger := testlog.Logger(t, log.LvlInfo)
+	logs := testlog.Capture(logger)
+	m := NewBalanceMonitor(logger, With(prometheus.NewRegistry()), "test", client, []BalanceAccount{batcher, other}, ether(2))
+	requireBalance := func(account BalanceAccount, bal float64, low bool) {
+		t.Helper()
+		require.Equal(t, bal, testutil.ToFloat64(m.balance.WithLabelValues(account.Name)))
+		lowValue := 0.0
+		if low {
+			lowValue = 1
+		}
+		require.Equal(t, lowValue, testutil.ToFloat64(m.balanceLow.WithLabelValues(account.Name)))
+	}
+
+	m.Update(context.Background())
+	requireBalance(batcher, 3, false)
+	requireBalance(other, 10, false)
+	require.Nil(t, logs.FindLog(log.LvlError, "Account balance is below the low balance threshold"))
+
+	// dropping below the threshold flips the alert and logs an error once
+	client[batcher.Address] = ether(1)
+	m.Update(context.Background())
+	requireBalance(batcher, 1, true)
+	requireBalance(other, 10, false)
+	lowLog := logs.FindLog(log.LvlError, "Account balance is below the low balance threshold")
+	require.NotNil(t, lowLog)
+	require.Equal(t, "batcher", lowLog.GetContextValue("account"))
+	logs.Clear()
+	m.Update(context.Background())
+	requireBalance(batcher, 1, true)
+	require.Nil(t, logs.FindLog(log.LvlError, "Account balance is below the low balance threshold"))
+
+	// funding the account above the threshold clears the alert
+	client[batcher.Address] = ether(5)
+	m.Update(context.Background())
+	requireBalance(batcher, 5, false)
+	require.NotNil(t, logs.FindLog(log.LvlInfo, "Account balance is above the low balance threshold again"))
+
+	// failed queries keep the last recorded balance
+	delete(client, batcher.Address)
+	m.Update(context.Background())
+	requireBalance(batcher, 5, false)
+	requireBalance(other, 10, false)
+}
+
+func TestBalanceMonitorThresholdDisabled(t *testing.T) {
+	account := BalanceAccount{Name: "proposer", Address: common.Address{0xaa}}
+	client := fakeBalanceClient{account.Address: big.NewInt(0)}
+	m := NewBalanceMonitor(testlog.Logger(t, log.LvlInfo), With(prometheus.NewRegistry()), "test", client, []BalanceAccount{account}, new(big.Int))
+	m.Update(context.Background())
+	require.Equal(t, 0.0, testutil.ToFloat64(m.balanceLow.WithLabelValues(account.Name)))
+}
+
+func TestBalanceCLIConfig(t *testing.T) {
+	cfg := DefaultBalanceCLIConfig()
+	cfg.LowThreshold = 0.5
+	cfg.Accounts = []string{"challenger=0x00000000000000000000000000000000000000aa"}
+	require.NoError(t, cfg.Check())
+	require.Equal(t, new(big.Int).Div(big.NewInt(params.Ether), big.NewInt(2)), cfg.LowThresholdWei())
+	accounts, err := cfg.ParseAccounts()
+	require.NoError(t, err)
+	require.Equal(t, []BalanceAccount{{Name: "challenger", Address: common.Address{19: 0xaa}}}, accounts)
+
+	cfg.Accounts = []string{"challenger=0xinvalid"}
+	require.Error(t, cfg.Check())
+	cfg.Accounts = nil
+	cfg.LowThreshold = -1
+	require.Error(t, cfg.Check())
+}
@@ -2,10 +2,16 @@ package metrics
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"math/big"
+	"strings"
+	"time"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli/v2"
 )
 
@@ -15,6 +21,11 @@ const (
 	PortFlagName       = "metrics.port"
 	defaultListenAddr  = "0.0.0.0"
 	defaultListenPort  = 7300
+
+	BalanceIntervalFlagName     = "balance-monitor.interval"
+	BalanceLowThresholdFlagName = "balance-monitor.low-threshold"
+	BalanceAccountsFlagName     = "balance-monitor.accounts"
+	defaultBalanceInterval      = 10 * time.Second
 )
 
 func DefaultCLIConfig() CLIConfig {
@@ -72,3 +83,79 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		ListenPort: ctx.Int(PortFlagName),
 	}
 }
+
+// BalanceCLIFlags are the flags of the balance monitor of the service accounts, see BalanceMonitor.
+func BalanceCLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		&cli.DurationFlag{
+			Name:    BalanceIntervalFlagName,
+			Usage:   "Interval of the queries of the balances of the monitored accounts",
+			Value:   defaultBalanceInterval,
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "BALANCE_MONITOR_INTERVAL"),
+		},
+		&cli.Float64Flag{
+			Name:    BalanceLowThresholdFlagName,
+			Usage:   "Balance (in ether) below which the balance of a monitored account is reported as low. 0 to disable.",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "BALANCE_MONITOR_LOW_THRESHOLD"),
+		},
+		&cli.StringSliceFlag{
+			Name:    BalanceAccountsFlagName,
+			Usage:   "Accounts that are monitored in addition to the sender account of the service, as <name>=<address>",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "BALANCE_MONITOR_ACCOUNTS"),
+		},
+	}
+}
+
+type BalanceCLIConfig struct {
+	Interval time.Duration
+	// LowThreshold is the low balance threshold in ether.
+	LowThreshold float64
+	// Accounts are additional accounts to monitor, as <name>=<address>.
+	Accounts []string
+}
+
+func DefaultBalanceCLIConfig() BalanceCLIConfig {
+	return BalanceCLIConfig{
+		Interval: defaultBalanceInterval,
+	}
+}
+
+func (c BalanceCLIConfig) Check() error {
+	if c.Interval <= 0 {
+		return errors.New("balance monitor interval must be positive")
+	}
+	if c.LowThreshold < 0 || math.IsInf(c.LowThreshold, 0) || math.IsNaN(c.LowThreshold) {
+		return errors.New("invalid low balance threshold")
+	}
+	if _, err := c.ParseAccounts(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ParseAccounts parses the additional accounts to monitor.
+func (c BalanceCLIConfig) ParseAccounts() ([]BalanceAccount, error) {
+	accounts := make([]BalanceAccount, 0, len(c.Accounts))
+	for _, account := range c.Accounts {
+		name, addr, ok := strings.Cut(account, "=")
+		if !ok || name == "" || !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid balance monitor account %q, expected <name>=<address>", account)
+		}
+		accounts = append(accounts, BalanceAccount{Name: name, Address: common.HexToAddress(addr)})
+	}
+	return accounts, nil
+}
+
+// LowThresholdWei returns the low balance threshold in wei, zero if it is disabled.
+func (c BalanceCLIConfig) LowThresholdWei() *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(c.LowThreshold), big.NewFloat(params.Ether)).Int(nil)
+	return wei
+}
+
+func ReadBalanceCLIConfig(ctx *cli.Context) BalanceCLIConfig {
+	return BalanceCLIConfig{
+		Interval:     ctx.Duration(BalanceIntervalFlagName),
+		LowThreshold: ctx.Float64(BalanceLowThresholdFlagName),
+		Accounts:     ctx.StringSlice(BalanceAccountsFlagName),
+	}
+}
@@ -10,6 +10,7 @@ type Factory interface {
 	NewCounterVec(opts prometheus.CounterOpts, labelNames []string) *prometheus.CounterVec
 	NewGauge(opts prometheus.GaugeOpts) prometheus.Gauge
 	NewGaugeVec(opts prometheus.GaugeOpts, labelNames []string) *prometheus.GaugeVec
+	NewGaugeFunc(opts prometheus.GaugeOpts, function func() float64) prometheus.GaugeFunc
 	NewHistogram(opts prometheus.HistogramOpts) prometheus.Histogram
 	NewHistogramVec(opts prometheus.HistogramOpts, labelNames []string) *prometheus.HistogramVec
 	NewSummary(opts prometheus.SummaryOpts) prometheus.Summary
@@ -73,6 +74,15 @@ func (d *documentor) NewGaugeVec(opts prometheus.GaugeOpts, labelNames []string)
 	return d.factory.NewGaugeVec(opts, labelNames)
 }
 
+func (d *documentor) NewGaugeFunc(opts prometheus.GaugeOpts, function func() float64) prometheus.GaugeFunc {
+	d.metrics = append(d.metrics, DocumentedMetric{
+		Type: "gauge",
+		Name: fullName(opts.Namespace, opts.Subsystem, opts.Name),
+		Help: opts.Help,
+	})
+	return d.factory.NewGaugeFunc(opts, function)
+}
+
 func (d *documentor) NewHistogram(opts prometheus.HistogramOpts) prometheus.Histogram {
 	d.metrics = append(d.metrics, DocumentedMetric{
 		Type: "histogram",
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// LogLevelHandler is a log handler that reports its log level, which may change at runtime.
+type LogLevelHandler interface {
+	LogLevel() log.Lvl
+}
+
+// NewLogLevelGauge exports the current log level of the log handler as the log_level gauge,
+// with the values of the geth log levels, from 0 for crit up to 5 for trace.
+func NewLogLevelGauge(ns string, factory Factory, h LogLevelHandler) prometheus.GaugeFunc {
+	return factory.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: ns,
+		Name:      "log_level",
+		Help:      "Current log level, from 0 for crit up to 5 for trace",
+	}, func() float64 {
+		return float64(h.LogLevel())
+	})
+}
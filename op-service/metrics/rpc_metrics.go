@@ -16,17 +16,21 @@ const (
 
 type RPCMetricer interface {
 	RecordRPCServerRequest(method string) func()
+	RecordRPCServerNamespaceRequest(namespace string) func(failed bool)
 	RecordRPCClientRequest(method string) func(err error)
 	RecordRPCClientResponse(method string, err error)
 }
 
 // RPCMetrics tracks all the RPC metrics for the op-service RPC.
 type RPCMetrics struct {
-	RPCServerRequestsTotal          *prometheus.CounterVec
-	RPCServerRequestDurationSeconds *prometheus.HistogramVec
-	RPCClientRequestsTotal          *prometheus.CounterVec
-	RPCClientRequestDurationSeconds *prometheus.HistogramVec
-	RPCClientResponsesTotal         *prometheus.CounterVec
+	RPCServerRequestsTotal            *prometheus.CounterVec
+	RPCServerRequestDurationSeconds   *prometheus.HistogramVec
+	RPCServerNamespaceRequestsTotal   *prometheus.CounterVec
+	RPCServerNamespaceDurationSeconds *prometheus.HistogramVec
+	RPCServerNamespaceErrorsTotal     *prometheus.CounterVec
+	RPCClientRequestsTotal            *prometheus.CounterVec
+	RPCClientRequestDurationSeconds   *prometheus.HistogramVec
+	RPCClientResponsesTotal           *prometheus.CounterVec
 }
 
 // MakeRPCMetrics creates a new RPCMetrics instance with the given process name, and
@@ -50,6 +54,31 @@ func MakeRPCMetrics(ns string, factory Factory) RPCMetrics {
 		}, []string{
 			"method",
 		}),
+		RPCServerNamespaceRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: RPCServerSubsystem,
+			Name:      "namespace_requests_total",
+			Help:      "Total requests to the RPC server by method namespace",
+		}, []string{
+			"namespace",
+		}),
+		RPCServerNamespaceDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: RPCServerSubsystem,
+			Name:      "namespace_request_duration_seconds",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help:      "Histogram of RPC server request durations by method namespace",
+		}, []string{
+			"namespace",
+		}),
+		RPCServerNamespaceErrorsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: RPCServerSubsystem,
+			Name:      "namespace_errors_total",
+			Help:      "Total error responses of the RPC server by method namespace",
+		}, []string{
+			"namespace",
+		}),
 		RPCClientRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: ns,
 			Subsystem: RPCClientSubsystem,
@@ -90,6 +119,20 @@ func (m *RPCMetrics) RecordRPCServerRequest(method string) func() {
 	}
 }
 
+// RecordRPCServerNamespaceRequest records an incoming RPC call to the RPC server by the
+// namespace of its method. It bumps the requests metric, and the returned function
+// records the duration of the call, and whether it failed.
+func (m *RPCMetrics) RecordRPCServerNamespaceRequest(namespace string) func(failed bool) {
+	m.RPCServerNamespaceRequestsTotal.WithLabelValues(namespace).Inc()
+	timer := prometheus.NewTimer(m.RPCServerNamespaceDurationSeconds.WithLabelValues(namespace))
+	return func(failed bool) {
+		if failed {
+			m.RPCServerNamespaceErrorsTotal.WithLabelValues(namespace).Inc()
+		}
+		timer.ObserveDuration()
+	}
+}
+
 // RecordRPCClientRequest is a helper method to record an RPC client
 // request. It bumps the requests metric, tracks the response
 // duration, and records the response's error code.
@@ -132,6 +175,10 @@ func (n *NoopRPCMetrics) RecordRPCServerRequest(method string) func() {
 	return func() {}
 }
 
+func (n *NoopRPCMetrics) RecordRPCServerNamespaceRequest(namespace string) func(failed bool) {
+	return func(failed bool) {}
+}
+
 func (n *NoopRPCMetrics) RecordRPCClientRequest(method string) func(err error) {
 	return func(err error) {}
 }
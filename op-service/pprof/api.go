@@ -0,0 +1,44 @@
+package pprof
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// API serves the admin RPC methods to control the pprof service at runtime.
+type API struct {
+	s *Service
+}
+
+func NewAPI(s *Service) *API {
+	return &API{s: s}
+}
+
+// GetAdminAPI serves the API in the admin namespace, next to the admin API of the service.
+func GetAdminAPI(api *API) rpc.API {
+	return rpc.API{
+		Namespace: "admin",
+		Service:   api,
+	}
+}
+
+// StartPprof starts the pprof server on the given address, and returns the address it listens on.
+func (a *API) StartPprof(_ context.Context, hostname string, port uint16) (string, error) {
+	if err := a.s.Start(hostname, int(port)); err != nil {
+		return "", err
+	}
+	return a.s.Addr().String(), nil
+}
+
+// StopPprof stops the pprof server, if it is running.
+func (a *API) StopPprof(ctx context.Context) error {
+	return a.s.Stop(ctx)
+}
+
+// WriteProfile writes a CPU profile of the given number of seconds, or a heap profile,
+// to the profile directory, and returns the path of the file.
+func (a *API) WriteProfile(ctx context.Context, kind string, seconds uint32) (string, error) {
+	return a.s.WriteProfile(ctx, kind, time.Duration(seconds)*time.Second)
+}
@@ -12,6 +12,7 @@ const (
 	EnabledFlagName    = "pprof.enabled"
 	ListenAddrFlagName = "pprof.addr"
 	PortFlagName       = "pprof.port"
+	ProfileDirFlagName = "pprof.profile-dir"
 	defaultListenAddr  = "0.0.0.0"
 	defaultListenPort  = 6060
 )
@@ -43,6 +44,11 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Value:   defaultListenPort,
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "PPROF_PORT"),
 		},
+		&cli.StringFlag{
+			Name:    ProfileDirFlagName,
+			Usage:   "Directory that the admin_writeProfile RPC method writes CPU and heap profiles to",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "PPROF_PROFILE_DIR"),
+		},
 	}
 }
 
@@ -50,6 +56,8 @@ type CLIConfig struct {
 	Enabled    bool
 	ListenAddr string
 	ListenPort int
+	// ProfileDir is the directory that profiles are written to at runtime. Writing profiles is disabled if empty.
+	ProfileDir string
 }
 
 func (m CLIConfig) Check() error {
@@ -69,5 +77,6 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		Enabled:    ctx.Bool(EnabledFlagName),
 		ListenAddr: ctx.String(ListenAddrFlagName),
 		ListenPort: ctx.Int(PortFlagName),
+		ProfileDir: ctx.String(ProfileDirFlagName),
 	}
 }
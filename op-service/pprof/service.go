@@ -0,0 +1,154 @@
+package pprof
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+)
+
+// The kinds of profiles that can be written to the profile directory.
+const (
+	CPUProfile  = "cpu"
+	HeapProfile = "heap"
+)
+
+// MaxCPUProfileDuration bounds the duration of the CPU profiles that are written to the profile directory.
+const MaxCPUProfileDuration = 5 * time.Minute
+
+var (
+	ErrAlreadyRunning = errors.New("pprof server is already running")
+	ErrNoProfileDir   = errors.New("no profile directory configured")
+)
+
+// Service runs the pprof server, which can be started and stopped at runtime,
+// and writes profiles to the profile directory.
+type Service struct {
+	log        log.Logger
+	profileDir string
+
+	mu  sync.Mutex
+	srv *httputil.HTTPServer
+
+	// profileLock is held while a profile is written, since only one CPU profile can run at a time.
+	profileLock sync.Mutex
+}
+
+func NewService(log log.Logger, profileDir string) *Service {
+	return &Service{
+		log:        log,
+		profileDir: profileDir,
+	}
+}
+
+// Start starts the pprof server on the given address.
+func (s *Service) Start(hostname string, port int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.srv != nil {
+		return ErrAlreadyRunning
+	}
+	s.log.Debug("starting pprof server", "addr", net.JoinHostPort(hostname, strconv.Itoa(port)))
+	srv, err := StartServer(hostname, port)
+	if err != nil {
+		return err
+	}
+	s.srv = srv
+	s.log.Info("started pprof server", "addr", srv.Addr())
+	return nil
+}
+
+// Stop stops the pprof server and releases its address. It is a no-op if the server is not running.
+func (s *Service) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.srv == nil {
+		return nil
+	}
+	if err := s.srv.Stop(ctx); err != nil {
+		return err
+	}
+	s.log.Info("stopped pprof server", "addr", s.srv.Addr())
+	s.srv = nil
+	return nil
+}
+
+// Addr returns the address of the pprof server, or nil if it is not running.
+func (s *Service) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.srv == nil {
+		return nil
+	}
+	return s.srv.Addr()
+}
+
+// WriteProfile writes a profile of the given kind to the profile directory, and returns the path of the file.
+// A CPU profile is recorded for the given duration, or until the ctx is done.
+// The duration is ignored for heap profiles.
+func (s *Service) WriteProfile(ctx context.Context, kind string, duration time.Duration) (string, error) {
+	if s.profileDir == "" {
+		return "", ErrNoProfileDir
+	}
+	switch kind {
+	case CPUProfile:
+		if duration <= 0 || duration > MaxCPUProfileDuration {
+			return "", fmt.Errorf("CPU profile duration %v must be positive and at most %v", duration, MaxCPUProfileDuration)
+		}
+	case HeapProfile:
+	default:
+		return "", fmt.Errorf("unknown profile kind %q", kind)
+	}
+
+	s.profileLock.Lock()
+	defer s.profileLock.Unlock()
+	if err := os.MkdirAll(s.profileDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create profile directory: %w", err)
+	}
+	pattern := fmt.Sprintf("%s-%s-*.pprof", kind, time.Now().UTC().Format("20060102T150405"))
+	f, err := os.CreateTemp(s.profileDir, pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create profile file: %w", err)
+	}
+	path := filepath.Clean(f.Name())
+	if kind == CPUProfile {
+		err = writeCPUProfile(ctx, f, duration)
+	} else {
+		runtime.GC() // get up-to-date statistics
+		err = pprof.WriteHeapProfile(f)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return "", fmt.Errorf("failed to write %s profile: %w", kind, err)
+	}
+	s.log.Info("wrote profile", "kind", kind, "path", path)
+	return path, nil
+}
+
+func writeCPUProfile(ctx context.Context, f *os.File, duration time.Duration) error {
+	if err := pprof.StartCPUProfile(f); err != nil {
+		return err
+	}
+	defer pprof.StopCPUProfile()
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return nil
+}
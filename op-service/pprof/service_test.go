@@ -0,0 +1,121 @@
+package pprof
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestServiceStartStop(t *testing.T) {
+	s := NewService(testlog.Logger(t, log.LvlInfo), "")
+	require.Nil(t, s.Addr())
+	require.NoError(t, s.Start("127.0.0.1", 0))
+	require.ErrorIs(t, s.Start("127.0.0.1", 0), ErrAlreadyRunning)
+	addr := s.Addr().String()
+
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/cmdline", "/debug/pprof/heap"} {
+		res, err := http.Get(fmt.Sprintf("http://%s%s", addr, path))
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode, path)
+	}
+
+	require.NoError(t, s.Stop(context.Background()))
+	require.Nil(t, s.Addr())
+	require.NoError(t, s.Stop(context.Background()), "stopping a stopped server is a no-op")
+
+	// the port is released, and the server can be started on it again
+	listener, err := net.Listen("tcp", addr)
+	require.NoError(t, err)
+	require.NoError(t, listener.Close())
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	var portNum int
+	_, err = fmt.Sscan(port, &portNum)
+	require.NoError(t, err)
+	require.NoError(t, s.Start(host, portNum))
+	require.Equal(t, addr, s.Addr().String())
+	require.NoError(t, s.Stop(context.Background()))
+}
+
+func TestServiceWriteProfile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "profiles")
+	s := NewService(testlog.Logger(t, log.LvlInfo), dir)
+
+	path, err := s.WriteProfile(context.Background(), HeapProfile, 0)
+	require.NoError(t, err)
+	require.Equal(t, dir, filepath.Dir(path))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NotZero(t, info.Size())
+
+	start := time.Now()
+	path, err = s.WriteProfile(context.Background(), CPUProfile, 100*time.Millisecond)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	info, err = os.Stat(path)
+	require.NoError(t, err)
+	require.NotZero(t, info.Size())
+
+	// a CPU profile ends early if the ctx is done
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = s.WriteProfile(ctx, CPUProfile, MaxCPUProfileDuration)
+	require.NoError(t, err)
+
+	_, err = s.WriteProfile(context.Background(), CPUProfile, MaxCPUProfileDuration+time.Second)
+	require.ErrorContains(t, err, "duration")
+	_, err = s.WriteProfile(context.Background(), CPUProfile, 0)
+	require.ErrorContains(t, err, "duration")
+	_, err = s.WriteProfile(context.Background(), "goroutine", 0)
+	require.ErrorContains(t, err, "unknown profile kind")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	_, err = NewService(testlog.Logger(t, log.LvlInfo), "").WriteProfile(context.Background(), HeapProfile, 0)
+	require.ErrorIs(t, err, ErrNoProfileDir)
+}
+
+func TestAdminAPI(t *testing.T) {
+	dir := t.TempDir()
+	s := NewService(testlog.Logger(t, log.LvlInfo), dir)
+	t.Cleanup(func() {
+		_ = s.Stop(context.Background())
+	})
+	srv := rpc.NewServer()
+	api := GetAdminAPI(NewAPI(s))
+	require.NoError(t, srv.RegisterName(api.Namespace, api.Service))
+	client := rpc.DialInProc(srv)
+	defer client.Close()
+
+	var addr string
+	require.NoError(t, client.Call(&addr, "admin_startPprof", "127.0.0.1", 0))
+	require.Equal(t, s.Addr().String(), addr)
+	res, err := http.Get(fmt.Sprintf("http://%s/debug/pprof/", addr))
+	require.NoError(t, err)
+	_ = res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	require.NoError(t, client.Call(nil, "admin_stopPprof"))
+	require.Nil(t, s.Addr())
+	_, err = http.Get(fmt.Sprintf("http://%s/debug/pprof/", addr))
+	require.Error(t, err)
+
+	var path string
+	require.NoError(t, client.Call(&path, "admin_writeProfile", HeapProfile, 0))
+	require.Equal(t, dir, filepath.Dir(path))
+	require.FileExists(t, path)
+}
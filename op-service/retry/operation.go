@@ -2,6 +2,7 @@ package retry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -21,6 +22,28 @@ func (e *ErrFailedPermanently) Unwrap() error {
 	return e.LastErr
 }
 
+// ErrPermanent wraps an error of an Operation that must not be retried.
+// Do stops retrying immediately when the Operation returns it, and returns the wrapped error.
+type ErrPermanent struct {
+	Err error
+}
+
+func (e *ErrPermanent) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ErrPermanent) Unwrap() error {
+	return e.Err
+}
+
+// Permanent wraps the error, so that Do doesn't retry the Operation that returned it.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ErrPermanent{Err: err}
+}
+
 type pair[T, U any] struct {
 	a T
 	b U
@@ -37,7 +60,8 @@ func Do2[T, U any](ctx context.Context, maxAttempts int, strategy Strategy, op f
 
 // Do performs the provided Operation up to maxAttempts times
 // with delays in between each retry according to the provided
-// Strategy.
+// Strategy. It stops early if the Operation returns an ErrPermanent,
+// or if the context is canceled, also while waiting for the next attempt.
 func Do[T any](ctx context.Context, maxAttempts int, strategy Strategy, op func() (T, error)) (T, error) {
 	var empty, ret T
 	var err error
@@ -53,9 +77,15 @@ func Do[T any](ctx context.Context, maxAttempts int, strategy Strategy, op func(
 		if err == nil {
 			return ret, nil
 		}
+		var permanent *ErrPermanent
+		if errors.As(err, &permanent) {
+			return empty, permanent.Err
+		}
 		// Don't sleep when we are about to exit the loop & return ErrFailedPermanently
 		if i != maxAttempts-1 {
-			time.Sleep(strategy.Duration(i))
+			if err := sleep(ctx, strategy.Duration(i)); err != nil {
+				return empty, err
+			}
 		}
 	}
 	return empty, &ErrFailedPermanently{
@@ -63,3 +93,15 @@ func Do[T any](ctx context.Context, maxAttempts int, strategy Strategy, op func(
 		LastErr:  err,
 	}
 }
+
+// sleep waits for the duration, or until the context is canceled.
+func sleep(ctx context.Context, dur time.Duration) error {
+	timer := time.NewTimer(dur)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -3,6 +3,7 @@ package retry
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -32,3 +33,76 @@ func TestDo(t *testing.T) {
 	require.Equal(t, dummyErr, err.(*ErrFailedPermanently).LastErr)
 	require.True(t, time.Since(start) > 20*time.Millisecond)
 }
+
+func TestDoAttempts(t *testing.T) {
+	dummyErr := errors.New("explode")
+	var attempts int
+	_, err := Do(context.Background(), 5, Fixed(0), func() (int, error) {
+		attempts++
+		return 0, dummyErr
+	})
+	require.ErrorIs(t, err, dummyErr)
+	require.Equal(t, 5, attempts)
+
+	attempts = 0
+	res, err := Do(context.Background(), 5, Fixed(0), func() (string, error) {
+		attempts++
+		if attempts < 3 {
+			return "", dummyErr
+		}
+		return "done", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "done", res)
+	require.Equal(t, 3, attempts)
+}
+
+func TestDoPermanentError(t *testing.T) {
+	dummyErr := errors.New("explode")
+	var attempts int
+	_, err := Do(context.Background(), 5, Fixed(time.Hour), func() (int, error) {
+		attempts++
+		return 0, Permanent(dummyErr)
+	})
+	require.Equal(t, dummyErr, err)
+	require.Equal(t, 1, attempts)
+
+	attempts = 0
+	_, err = Do(context.Background(), 5, Fixed(0), func() (int, error) {
+		attempts++
+		if attempts < 2 {
+			return 0, dummyErr
+		}
+		return 0, fmt.Errorf("wrapped: %w", Permanent(dummyErr))
+	})
+	require.Equal(t, dummyErr, err)
+	require.Equal(t, 2, attempts)
+	require.NoError(t, Permanent(nil))
+}
+
+func TestDoContextCanceled(t *testing.T) {
+	dummyErr := errors.New("explode")
+
+	t.Run("between attempts", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var attempts int
+		_, err := Do(ctx, 5, Fixed(0), func() (int, error) {
+			attempts++
+			cancel()
+			return 0, dummyErr
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 1, attempts)
+	})
+
+	t.Run("while waiting", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := Do(ctx, 5, Fixed(time.Hour), func() (int, error) {
+			return 0, dummyErr
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Less(t, time.Since(start), time.Minute)
+	})
+}
@@ -24,3 +24,27 @@ func TestExponential(t *testing.T) {
 	require.Equal(t, 10*time.Second, strategy.Duration(16000))
 	require.Equal(t, 10*time.Second, strategy.Duration(math.MaxInt))
 }
+
+func TestExponentialJitter(t *testing.T) {
+	strategy := &ExponentialStrategy{
+		Min:       3 * time.Second,
+		Max:       10 * time.Second,
+		MaxJitter: 250 * time.Millisecond,
+	}
+
+	durations := []time.Duration{4, 5, 7, 10, 10}
+	for i := 0; i < 100; i++ {
+		for attempt, dur := range durations {
+			d := strategy.Duration(attempt)
+			require.GreaterOrEqual(t, d, dur*time.Second, "attempt %d", attempt)
+			require.Less(t, d, dur*time.Second+strategy.MaxJitter, "attempt %d", attempt)
+		}
+	}
+}
+
+func TestFixed(t *testing.T) {
+	strategy := Fixed(3 * time.Second)
+	for attempt := -1; attempt < 10; attempt++ {
+		require.Equal(t, 3*time.Second, strategy.Duration(attempt))
+	}
+}
@@ -3,6 +3,7 @@ package rpc
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -49,3 +50,59 @@ func (n *CommonAdminAPI) SetLogLevel(ctx context.Context, lvlStr string) error {
 	lvlSetter.SetLogLevel(lvl)
 	return nil
 }
+
+// LogLevels are the log level of the service, and the log level overrides of its modules by logger name prefix.
+type LogLevels struct {
+	Level   string            `json:"level"`
+	Modules map[string]string `json:"modules,omitempty"`
+}
+
+// LogLevel returns the current log levels of the service.
+func (n *CommonAdminAPI) LogLevel(ctx context.Context) (*LogLevels, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_logLevel")
+	defer recordDur()
+
+	h := n.log.GetHandler()
+	lvlGetter, ok := h.(oplog.LvlGetter)
+	if !ok {
+		return nil, fmt.Errorf("log handler type %T cannot report log level", h)
+	}
+	out := &LogLevels{Level: lvlName(lvlGetter.LogLevel())}
+	if d, ok := h.(*oplog.DynamicLogHandler); ok {
+		for prefix, lvl := range d.ModuleLogLevels() {
+			if out.Modules == nil {
+				out.Modules = make(map[string]string)
+			}
+			out.Modules[prefix] = lvlName(lvl)
+		}
+	}
+	return out, nil
+}
+
+// SetModuleLogLevel overrides the log level of the loggers with a name that starts with the prefix,
+// see oplog.LoggerName. The override is removed if the level is empty.
+func (n *CommonAdminAPI) SetModuleLogLevel(ctx context.Context, prefix string, lvlStr string) error {
+	recordDur := n.M.RecordRPCServerRequest("admin_setModuleLogLevel")
+	defer recordDur()
+
+	h := n.log.GetHandler()
+	setter, ok := h.(oplog.ModuleLvlSetter)
+	if !ok {
+		return fmt.Errorf("log handler type %T cannot change module log levels", h)
+	}
+	if lvlStr == "" {
+		setter.ClearModuleLogLevel(prefix)
+		return nil
+	}
+	lvl, err := log.LvlFromString(lvlStr)
+	if err != nil {
+		return err
+	}
+	setter.SetModuleLogLevel(prefix, lvl)
+	return nil
+}
+
+// lvlName returns the full name of the log level, e.g. error, as accepted by SetLogLevel.
+func lvlName(lvl log.Lvl) string {
+	return strings.ToLower(strings.TrimSpace(lvl.AlignedString()))
+}
@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+func TestAdminLogLevel(t *testing.T) {
+	var records []*log.Record
+	handler := oplog.NewDynamicLogHandler(log.LvlInfo, log.FuncHandler(func(r *log.Record) error {
+		records = append(records, r)
+		return nil
+	}))
+	logger := log.New()
+	logger.SetHandler(handler)
+
+	server := rpc.NewServer()
+	api := ToGethAdminAPI(NewCommonAdminAPI(&metrics.NoopRPCMetrics{}, logger))
+	require.NoError(t, server.RegisterName(api.Namespace, api.Service))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	var levels LogLevels
+	require.NoError(t, client.Call(&levels, "admin_logLevel"))
+	require.Equal(t, LogLevels{Level: "info"}, levels)
+
+	logger.Debug("hidden debug")
+	require.NoError(t, client.Call(nil, "admin_setLogLevel", "debug"))
+	logger.Debug("visible debug")
+	require.NoError(t, client.Call(nil, "admin_setLogLevel", "error"))
+	logger.Warn("hidden warning")
+
+	p2pLogger := logger.New("p2p", "gossip")
+	require.NoError(t, client.Call(nil, "admin_setModuleLogLevel", "p2p", "trace"))
+	p2pLogger.Trace("visible p2p trace")
+	require.NoError(t, client.Call(&levels, "admin_logLevel"))
+	require.Equal(t, LogLevels{Level: "error", Modules: map[string]string{"p2p": "trace"}}, levels)
+
+	require.NoError(t, client.Call(nil, "admin_setModuleLogLevel", "p2p", ""))
+	p2pLogger.Info("hidden p2p info")
+	require.Error(t, client.Call(nil, "admin_setLogLevel", "verbose"))
+
+	require.Len(t, records, 2)
+	require.Equal(t, "visible debug", records[0].Msg)
+	require.Equal(t, "visible p2p trace", records[1].Msg)
+}
@@ -3,15 +3,23 @@ package rpc
 import (
 	"errors"
 	"math"
+	"strings"
+	"time"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/urfave/cli/v2"
 )
 
 const (
-	ListenAddrFlagName  = "rpc.addr"
-	PortFlagName        = "rpc.port"
-	EnableAdminFlagName = "rpc.enable-admin"
+	ListenAddrFlagName   = "rpc.addr"
+	PortFlagName         = "rpc.port"
+	EnableAdminFlagName  = "rpc.enable-admin"
+	CORSOriginsFlagName  = "rpc.cors-origins"
+	VHostsFlagName       = "rpc.vhosts"
+	BasePathFlagName     = "rpc.base-path"
+	EnableWSFlagName     = "rpc.enable-ws"
+	RateLimitsFlagName   = "rpc.rate-limits"
+	DrainTimeoutFlagName = "rpc.drain-timeout"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -33,6 +41,37 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Usage:   "Enable the admin API",
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_ENABLE_ADMIN"),
 		},
+		&cli.StringSliceFlag{
+			Name:    CORSOriginsFlagName,
+			Usage:   "Origins that are allowed to make cross-origin requests. Defaults to all origins if not set.",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_CORS_ORIGINS"),
+		},
+		&cli.StringSliceFlag{
+			Name:    VHostsFlagName,
+			Usage:   "Virtual hostnames that requests are accepted from. Defaults to all hostnames if not set.",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_VHOSTS"),
+		},
+		&cli.StringFlag{
+			Name:    BasePathFlagName,
+			Usage:   "Path prefix that the RPC and healthz endpoints are served under, e.g. /batcher",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_BASE_PATH"),
+		},
+		&cli.BoolFlag{
+			Name:    EnableWSFlagName,
+			Usage:   "Serve websocket RPC connections on the same listener as HTTP",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_ENABLE_WS"),
+		},
+		&cli.StringSliceFlag{
+			Name:    RateLimitsFlagName,
+			Usage:   "Rate limits of RPC method namespaces in requests per second, as <namespace>=<rps>, e.g. admin=5",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_RATE_LIMITS"),
+		},
+		&cli.DurationFlag{
+			Name:    DrainTimeoutFlagName,
+			Usage:   "Time that in-flight requests and websocket subscriptions may take to finish when the RPC server stops",
+			Value:   DefaultDrainTimeout,
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_DRAIN_TIMEOUT"),
+		},
 	}
 }
 
@@ -40,13 +79,23 @@ type CLIConfig struct {
 	ListenAddr  string
 	ListenPort  int
 	EnableAdmin bool
+	// CORSOrigins and VHosts restrict the allowed origins and virtual hosts. The server allows all if they are empty.
+	CORSOrigins []string
+	VHosts      []string
+	BasePath    string
+	EnableWS    bool
+	// RateLimits are the rate limits of method namespaces, see ParseRateLimits.
+	RateLimits []string
+	// DrainTimeout is the time that in-flight requests and websocket connections may take to finish on shutdown.
+	DrainTimeout time.Duration
 }
 
 func DefaultCLIConfig() CLIConfig {
 	return CLIConfig{
-		ListenAddr:  "0.0.0.0",
-		ListenPort:  8545,
-		EnableAdmin: false,
+		ListenAddr:   "0.0.0.0",
+		ListenPort:   8545,
+		EnableAdmin:  false,
+		DrainTimeout: DefaultDrainTimeout,
 	}
 }
 
@@ -54,14 +103,54 @@ func (c CLIConfig) Check() error {
 	if c.ListenPort < 0 || c.ListenPort > math.MaxUint16 {
 		return errors.New("invalid RPC port")
 	}
+	if c.BasePath != "" && !strings.HasPrefix(c.BasePath, "/") {
+		return errors.New("RPC base path must start with /")
+	}
+	if _, err := ParseRateLimits(c.RateLimits); err != nil {
+		return err
+	}
+	if c.DrainTimeout < 0 {
+		return errors.New("RPC drain timeout must not be negative")
+	}
 
 	return nil
 }
 
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
-		ListenAddr:  ctx.String(ListenAddrFlagName),
-		ListenPort:  ctx.Int(PortFlagName),
-		EnableAdmin: ctx.Bool(EnableAdminFlagName),
+		ListenAddr:   ctx.String(ListenAddrFlagName),
+		ListenPort:   ctx.Int(PortFlagName),
+		EnableAdmin:  ctx.Bool(EnableAdminFlagName),
+		CORSOrigins:  ctx.StringSlice(CORSOriginsFlagName),
+		VHosts:       ctx.StringSlice(VHostsFlagName),
+		BasePath:     ctx.String(BasePathFlagName),
+		EnableWS:     ctx.Bool(EnableWSFlagName),
+		RateLimits:   ctx.StringSlice(RateLimitsFlagName),
+		DrainTimeout: ctx.Duration(DrainTimeoutFlagName),
+	}
+}
+
+// ServerOptions returns the options of the RPC server that are configured by the CLI config.
+func (c CLIConfig) ServerOptions() []ServerOption {
+	var opts []ServerOption
+	if len(c.CORSOrigins) > 0 {
+		opts = append(opts, WithCORSHosts(c.CORSOrigins))
+	}
+	if len(c.VHosts) > 0 {
+		opts = append(opts, WithVHosts(c.VHosts))
+	}
+	if c.BasePath != "" {
+		opts = append(opts, WithBasePath(c.BasePath))
+	}
+	if c.EnableWS {
+		opts = append(opts, WithWebsocketEnabled())
+	}
+	if c.DrainTimeout > 0 {
+		opts = append(opts, WithDrainTimeout(c.DrainTimeout))
+	}
+	// the rate limits are validated by Check
+	if limits, err := ParseRateLimits(c.RateLimits); err == nil && len(limits) > 0 {
+		opts = append(opts, WithRateLimits(limits))
 	}
+	return opts
 }
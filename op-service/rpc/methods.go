@@ -0,0 +1,196 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+// RateLimitExceededCode is the JSON-RPC error code of calls that exceed the rate limit of their namespace,
+// the "limit exceeded" code of EIP-1474.
+const RateLimitExceededCode = -32005
+
+// maxRequestBodySize matches the request size limit of the geth RPC server.
+const maxRequestBodySize = 5 * 1024 * 1024
+
+// jsonrpcMessage is the part of a JSON-RPC request or response that the method middlewares inspect.
+type jsonrpcMessage struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Error  json.RawMessage `json:"error,omitempty"`
+}
+
+func (msg *jsonrpcMessage) namespace() string {
+	namespace, _, _ := strings.Cut(msg.Method, "_")
+	return namespace
+}
+
+// parseMessages parses a single or batch JSON-RPC message. The second return value is whether it is a batch.
+func parseMessages(data []byte) ([]*jsonrpcMessage, bool, error) {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	if len(data) > 0 && data[0] == '[' {
+		var msgs []*jsonrpcMessage
+		err := json.Unmarshal(data, &msgs)
+		return msgs, true, err
+	}
+	var msg jsonrpcMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, false, err
+	}
+	return []*jsonrpcMessage{&msg}, false, nil
+}
+
+// readRequestCalls reads the JSON-RPC calls of the request, and restores the body for the next handler.
+// Requests that are not JSON-RPC calls over HTTP, like websocket upgrades, have no calls.
+func readRequestCalls(r *http.Request) ([]*jsonrpcMessage, bool) {
+	if r.Method != http.MethodPost || r.Body == nil {
+		return nil, false
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodySize))
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, false
+	}
+	calls, batch, err := parseMessages(body)
+	if err != nil {
+		return nil, false
+	}
+	return calls, batch
+}
+
+// NewMethodMetricsMiddleware records the calls of JSON-RPC requests over HTTP by the namespace of their method,
+// including their duration and whether they returned an error.
+func NewMethodMetricsMiddleware(m opmetrics.RPCMetricer) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls, _ := readRequestCalls(r)
+			if len(calls) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			done := make([]func(failed bool), len(calls))
+			for i, call := range calls {
+				done[i] = m.RecordRPCServerNamespaceRequest(call.namespace())
+			}
+			rw := &recordingResponseWriter{ResponseWriter: w}
+			next.ServeHTTP(rw, r)
+
+			failed := make(map[string]bool)
+			if responses, _, err := parseMessages(rw.body.Bytes()); err == nil {
+				for _, res := range responses {
+					if len(res.Error) > 0 {
+						failed[string(res.ID)] = true
+					}
+				}
+			}
+			for i, call := range calls {
+				done[i](failed[string(call.ID)])
+			}
+		})
+	}
+}
+
+// recordingResponseWriter records the response body, so that the errors of the calls can be inspected.
+type recordingResponseWriter struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingResponseWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// ParseRateLimits parses rate limits in the format <namespace>=<requests per second>, e.g. admin=5.
+func ParseRateLimits(limits []string) (map[string]float64, error) {
+	out := make(map[string]float64, len(limits))
+	for _, limit := range limits {
+		namespace, rps, ok := strings.Cut(limit, "=")
+		if !ok || namespace == "" {
+			return nil, fmt.Errorf("invalid rate limit %q, expected <namespace>=<requests per second>", limit)
+		}
+		v, err := strconv.ParseFloat(rps, 64)
+		if err != nil || v <= 0 || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("invalid requests per second of rate limit %q", limit)
+		}
+		out[namespace] = v
+	}
+	return out, nil
+}
+
+// NewRateLimitMiddleware limits the calls of JSON-RPC requests over HTTP to the given requests per second
+// by the namespace of their method, e.g. admin. The limits allow bursts of up to one second of requests.
+// If any call of a request exceeds its limit, the request is not served, and all its calls get a
+// RateLimitExceededCode error instead.
+func NewRateLimitMiddleware(limits map[string]float64) Middleware {
+	limiters := make(map[string]*rate.Limiter, len(limits))
+	for namespace, rps := range limits {
+		limiters[namespace] = rate.NewLimiter(rate.Limit(rps), int(math.Max(1, math.Ceil(rps))))
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls, batch := readRequestCalls(r)
+			now := time.Now()
+			limited := false
+			for _, call := range calls {
+				if limiter, ok := limiters[call.namespace()]; ok && !limiter.AllowN(now, 1) {
+					limited = true
+				}
+			}
+			if !limited {
+				next.ServeHTTP(w, r)
+				return
+			}
+			writeRateLimitError(w, calls, batch)
+		})
+	}
+}
+
+type jsonrpcErrorResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   jsonrpcError    `json:"error"`
+}
+
+type jsonrpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func writeRateLimitError(w http.ResponseWriter, calls []*jsonrpcMessage, batch bool) {
+	responses := make([]jsonrpcErrorResponse, len(calls))
+	for i, call := range calls {
+		id := call.ID
+		if len(id) == 0 {
+			id = json.RawMessage("null")
+		}
+		responses[i] = jsonrpcErrorResponse{
+			Version: "2.0",
+			ID:      id,
+			Error:   jsonrpcError{Code: RateLimitExceededCode, Message: "rate limit exceeded"},
+		}
+	}
+	// JSON-RPC errors are served with status OK, like the errors of the RPC server, so that clients decode them.
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	if batch {
+		_ = enc.Encode(responses)
+	} else {
+		_ = enc.Encode(responses[0])
+	}
+}
@@ -0,0 +1,132 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+type adminTestAPI struct{}
+
+func (a *adminTestAPI) Restart() error {
+	return nil
+}
+
+func (a *adminTestAPI) Fail() error {
+	return errors.New("failed")
+}
+
+// namespaceMetrics counts the recorded requests and errors by namespace.
+type namespaceMetrics struct {
+	opmetrics.NoopRPCMetrics
+	mu       sync.Mutex
+	requests map[string]int
+	errors   map[string]int
+}
+
+func (m *namespaceMetrics) RecordRPCServerNamespaceRequest(namespace string) func(failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[namespace]++
+	return func(failed bool) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if failed {
+			m.errors[namespace]++
+		}
+	}
+}
+
+func startMethodsTestServer(t *testing.T, opts ...ServerOption) *rpc.Client {
+	opts = append([]ServerOption{WithAPIs([]rpc.API{
+		{
+			Namespace: "test",
+			Service:   new(testAPI),
+		},
+		{
+			Namespace: "admin",
+			Service:   new(adminTestAPI),
+		},
+	})}, opts...)
+	server := NewServer("127.0.0.1", 10000+rand.Intn(22768), "test", opts...)
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		_ = server.Stop()
+	})
+	rpcClient, err := rpc.Dial(fmt.Sprintf("http://%s", server.endpoint))
+	require.NoError(t, err)
+	t.Cleanup(rpcClient.Close)
+	return rpcClient
+}
+
+func TestRateLimits(t *testing.T) {
+	rpcClient := startMethodsTestServer(t, WithRateLimits(map[string]float64{"admin": 5}))
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, rpcClient.Call(nil, "admin_restart"), "call %d is within the burst of the limit", i)
+	}
+	err := rpcClient.Call(nil, "admin_restart")
+	var rpcErr rpc.Error
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, RateLimitExceededCode, rpcErr.ErrorCode())
+
+	// other namespaces are not limited
+	for i := 0; i < 10; i++ {
+		var res int
+		require.NoError(t, rpcClient.Call(&res, "test_frobnicate", i))
+		require.Equal(t, 2*i, res)
+	}
+
+	// a batch is limited if any of its calls is
+	batch := []rpc.BatchElem{
+		{Method: "test_frobnicate", Args: []any{1}, Result: new(int)},
+		{Method: "admin_restart"},
+	}
+	require.NoError(t, rpcClient.BatchCall(batch))
+	for _, elem := range batch {
+		require.ErrorAs(t, elem.Error, &rpcErr)
+		require.Equal(t, RateLimitExceededCode, rpcErr.ErrorCode())
+	}
+}
+
+func TestMethodMetrics(t *testing.T) {
+	m := &namespaceMetrics{requests: make(map[string]int), errors: make(map[string]int)}
+	rpcClient := startMethodsTestServer(t,
+		WithRateLimits(map[string]float64{"admin": 1}),
+		WithMethodMetrics(m),
+	)
+
+	var res int
+	require.NoError(t, rpcClient.Call(&res, "test_frobnicate", 2))
+	require.Error(t, rpcClient.Call(nil, "admin_fail"))
+	// rate limited
+	require.Error(t, rpcClient.Call(nil, "admin_restart"))
+	batch := []rpc.BatchElem{
+		{Method: "test_frobnicate", Args: []any{1}, Result: new(int)},
+		{Method: "test_frobnicate", Args: []any{"invalid"}, Result: new(int)},
+	}
+	require.NoError(t, rpcClient.BatchCall(batch))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	require.Equal(t, map[string]int{"test": 3, "admin": 2}, m.requests)
+	require.Equal(t, map[string]int{"test": 1, "admin": 2}, m.errors)
+}
+
+func TestParseRateLimits(t *testing.T) {
+	limits, err := ParseRateLimits([]string{"admin=5", "optimism=0.5"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"admin": 5, "optimism": 0.5}, limits)
+
+	for _, invalid := range []string{"admin", "=5", "admin=", "admin=0", "admin=-1", "admin=five"} {
+		_, err := ParseRateLimits([]string{invalid})
+		require.Error(t, err, invalid)
+	}
+}
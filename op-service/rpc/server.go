@@ -4,10 +4,13 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"path"
 	"strconv"
+	"strings"
 	"time"
 
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
@@ -21,6 +24,10 @@ import (
 
 var wildcardHosts = []string{"*"}
 
+// DefaultDrainTimeout is the default time that in-flight requests and websocket connections
+// may take to finish when the server stops.
+const DefaultDrainTimeout = 5 * time.Second
+
 type Server struct {
 	endpoint       string
 	apis           []rpc.API
@@ -31,6 +38,10 @@ type Server struct {
 	jwtSecret      []byte
 	rpcPath        string
 	healthzPath    string
+	basePath       string
+	wsEnabled      bool
+	wsHandler      *websocketHandler
+	drainTimeout   time.Duration
 	httpRecorder   opmetrics.HTTPRecorder
 	httpServer     *http.Server
 	log            log.Logger
@@ -89,6 +100,30 @@ func WithHealthzPath(path string) ServerOption {
 	}
 }
 
+// WithBasePath prefixes the RPC and healthz paths with the given path,
+// for deployments behind an ingress that routes by path prefix.
+func WithBasePath(path string) ServerOption {
+	return func(b *Server) {
+		b.basePath = path
+	}
+}
+
+// WithWebsocketEnabled serves websocket RPC connections on the RPC path as well,
+// on the same listener as HTTP. The origins of websocket connections are checked against the CORS hosts.
+func WithWebsocketEnabled() ServerOption {
+	return func(b *Server) {
+		b.wsEnabled = true
+	}
+}
+
+// WithDrainTimeout sets the time that in-flight requests and websocket connections may take to finish
+// when the server stops, DefaultDrainTimeout by default.
+func WithDrainTimeout(timeout time.Duration) ServerOption {
+	return func(b *Server) {
+		b.drainTimeout = timeout
+	}
+}
+
 func WithHTTPRecorder(recorder opmetrics.HTTPRecorder) ServerOption {
 	return func(b *Server) {
 		b.httpRecorder = recorder
@@ -117,6 +152,16 @@ func WithMiddleware(middleware func(http.Handler) (hdlr http.Handler)) ServerOpt
 	}
 }
 
+// WithMethodMetrics records the requests to the RPC server by the namespace of their method.
+func WithMethodMetrics(m opmetrics.RPCMetricer) ServerOption {
+	return WithMiddleware(NewMethodMetricsMiddleware(m))
+}
+
+// WithRateLimits limits the requests per second to the RPC server by the namespace of their method.
+func WithRateLimits(limits map[string]float64) ServerOption {
+	return WithMiddleware(NewRateLimitMiddleware(limits))
+}
+
 func NewServer(host string, port int, appVersion string, opts ...ServerOption) *Server {
 	endpoint := net.JoinHostPort(host, strconv.Itoa(port))
 	bs := &Server{
@@ -127,6 +172,7 @@ func NewServer(host string, port int, appVersion string, opts ...ServerOption) *
 		vHosts:         wildcardHosts,
 		rpcPath:        "/",
 		healthzPath:    "/healthz",
+		drainTimeout:   DefaultDrainTimeout,
 		httpRecorder:   opmetrics.NoopHTTPRecorder,
 		httpServer: &http.Server{
 			Addr: endpoint,
@@ -163,15 +209,16 @@ func (b *Server) Start() error {
 	}
 
 	// rpc middleware
-	var nodeHdlr http.Handler = srv
-	for _, middleware := range b.middlewares {
-		nodeHdlr = middleware(nodeHdlr)
+	nodeHdlr := node.NewHTTPHandlerStack(b.applyMiddlewares(srv), b.corsHosts, b.vHosts, b.jwtSecret)
+	if b.wsEnabled {
+		b.wsHandler = newWebsocketHandler(srv, b.corsHosts, b.log)
+		wsHdlr := node.NewWSHandlerStack(b.applyMiddlewares(b.wsHandler), b.jwtSecret)
+		nodeHdlr = newWebsocketSwitch(wsHdlr, nodeHdlr)
 	}
-	nodeHdlr = node.NewHTTPHandlerStack(nodeHdlr, b.corsHosts, b.vHosts, b.jwtSecret)
 
 	mux := http.NewServeMux()
-	mux.Handle(b.rpcPath, nodeHdlr)
-	mux.Handle(b.healthzPath, b.healthzHandler)
+	mux.Handle(path.Join("/", b.basePath, b.rpcPath), nodeHdlr)
+	mux.Handle(path.Join("/", b.basePath, b.healthzPath), b.healthzHandler)
 
 	// http middleware
 	var handler http.Handler = mux
@@ -203,10 +250,44 @@ func (b *Server) Start() error {
 	}
 }
 
+func (b *Server) applyMiddlewares(hdlr http.Handler) http.Handler {
+	for _, middleware := range b.middlewares {
+		hdlr = middleware(hdlr)
+	}
+	return hdlr
+}
+
+// newWebsocketSwitch serves websocket upgrade requests with ws, and all other requests with next.
+func newWebsocketSwitch(ws http.Handler, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+			strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade") {
+			ws.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Stop stops the server gracefully: it stops accepting new connections, and waits up to the drain timeout
+// for the in-flight requests, and for the clients to close their websocket connections.
+// The connections that remain open after the drain timeout are closed.
 func (b *Server) Stop() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), b.drainTimeout)
 	defer cancel()
-	_ = b.httpServer.Shutdown(ctx)
+	// websocket connections are hijacked, and thus not drained by the shutdown of the HTTP server
+	wsDrained := make(chan struct{})
+	go func() {
+		defer close(wsDrained)
+		if b.wsHandler != nil {
+			b.wsHandler.drain(ctx)
+		}
+	}()
+	if err := b.httpServer.Shutdown(ctx); errors.Is(err, context.DeadlineExceeded) {
+		b.log.Warn("Closing RPC connections after drain timeout")
+		_ = b.httpServer.Close()
+	}
+	<-wsDrained
 	return nil
 }
 
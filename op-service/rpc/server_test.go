@@ -5,6 +5,7 @@ import (
 	"io"
 	"math/rand"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/rpc"
@@ -59,3 +60,98 @@ func TestBaseServer(t *testing.T) {
 		require.Equal(t, 4, res)
 	})
 }
+
+func TestServerCORSAndVHosts(t *testing.T) {
+	server := NewServer(
+		"127.0.0.1",
+		10000+rand.Intn(22768),
+		"test",
+		WithCORSHosts([]string{"https://dashboard.example.com"}),
+		WithVHosts([]string{"rpc.example.com"}),
+	)
+	require.NoError(t, server.Start())
+	defer func() {
+		_ = server.Stop()
+	}()
+	url := fmt.Sprintf("http://%s/", server.endpoint)
+
+	preflight := func(t *testing.T, origin string) *http.Response {
+		req, err := http.NewRequest(http.MethodOptions, url, nil)
+		require.NoError(t, err)
+		req.Host = "rpc.example.com"
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res
+	}
+
+	t.Run("allows preflight requests of allowed origins", func(t *testing.T) {
+		res := preflight(t, "https://dashboard.example.com")
+		require.Equal(t, "https://dashboard.example.com", res.Header.Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("rejects preflight requests of other origins", func(t *testing.T) {
+		res := preflight(t, "https://evil.example.com")
+		require.Empty(t, res.Header.Get("Access-Control-Allow-Origin"))
+	})
+
+	post := func(t *testing.T, host string) int {
+		req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"health_status"}`))
+		require.NoError(t, err)
+		req.Host = host
+		req.Header.Set("Content-Type", "application/json")
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res.StatusCode
+	}
+
+	t.Run("allows requests to allowed vhosts", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, post(t, "rpc.example.com"))
+	})
+
+	t.Run("rejects requests to other vhosts", func(t *testing.T) {
+		require.Equal(t, http.StatusForbidden, post(t, "evil.example.com"))
+	})
+}
+
+func TestServerBasePathAndWebsocket(t *testing.T) {
+	appVersion := "test"
+	server := NewServer(
+		"127.0.0.1",
+		10000+rand.Intn(22768),
+		appVersion,
+		WithBasePath("/batcher"),
+		WithWebsocketEnabled(),
+	)
+	require.NoError(t, server.Start())
+	defer func() {
+		_ = server.Stop()
+	}()
+
+	t.Run("serves healthz under the base path", func(t *testing.T) {
+		res, err := http.Get(fmt.Sprintf("http://%s/batcher/healthz", server.endpoint))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		res, err = http.Get(fmt.Sprintf("http://%s/healthz", server.endpoint))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	for _, scheme := range []string{"http", "ws"} {
+		scheme := scheme
+		t.Run("serves "+scheme+" RPC under the base path", func(t *testing.T) {
+			rpcClient, err := rpc.Dial(fmt.Sprintf("%s://%s/batcher", scheme, server.endpoint))
+			require.NoError(t, err)
+			defer rpcClient.Close()
+			var res string
+			require.NoError(t, rpcClient.Call(&res, "health_status"))
+			require.Equal(t, appVersion, res)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// wsReadLimit matches the message size limit of the websocket connections of the geth RPC server.
+	wsReadLimit = 32 * 1024 * 1024
+	// wsCloseTimeout is the time that a close frame may take to be written.
+	wsCloseTimeout = time.Second
+)
+
+// websocketHandler serves RPC over websocket connections, like the websocket handler of the geth RPC server,
+// but tracks the connections, so that they can be drained when the server stops.
+type websocketHandler struct {
+	srv      *rpc.Server
+	upgrader websocket.Upgrader
+	log      log.Logger
+
+	mu       sync.Mutex
+	conns    map[*websocket.Conn]struct{}
+	draining bool
+	wg       sync.WaitGroup
+}
+
+func newWebsocketHandler(srv *rpc.Server, allowedOrigins []string, log log.Logger) *websocketHandler {
+	return &websocketHandler{
+		srv: srv,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: originChecker(allowedOrigins),
+		},
+		log:   log,
+		conns: make(map[*websocket.Conn]struct{}),
+	}
+}
+
+func (h *websocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	if h.draining {
+		h.mu.Unlock()
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	h.wg.Add(1)
+	h.mu.Unlock()
+	defer h.wg.Done()
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.log.Debug("WebSocket upgrade failed", "err", err)
+		return
+	}
+	conn.SetReadLimit(wsReadLimit)
+	h.mu.Lock()
+	h.conns[conn] = struct{}{}
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.conns, conn)
+		h.mu.Unlock()
+	}()
+
+	codec := rpc.NewFuncCodec(conn, func(v any, _ bool) error {
+		return conn.WriteJSON(v)
+	}, conn.ReadJSON)
+	// serves the calls and subscriptions of the connection until it is closed
+	h.srv.ServeCodec(codec, 0)
+}
+
+// drain stops accepting new connections, and waits for the open connections to be closed by their clients,
+// until the ctx is done. The connections that are still open then get a close frame, and are closed.
+func (h *websocketHandler) drain(ctx context.Context) {
+	h.mu.Lock()
+	h.draining = true
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return
+	case <-ctx.Done():
+	}
+
+	h.mu.Lock()
+	h.log.Info("Closing websocket connections after drain timeout", "conns", len(h.conns))
+	msg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is shutting down")
+	for conn := range h.conns {
+		_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsCloseTimeout))
+	}
+	h.mu.Unlock()
+	// closes the codecs of the connections, which ends their subscriptions
+	h.srv.Stop()
+	<-done
+}
+
+// originChecker checks the origins of websocket connections against the allowed origins, which may include
+// the scheme or not. Requests without origin are accepted, as they are not from browsers.
+func originChecker(allowedOrigins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := strings.ToLower(r.Header.Get("Origin"))
+		if origin == "" {
+			return true
+		}
+		host := origin
+		if u, err := url.Parse(origin); err == nil && u.Host != "" {
+			host = u.Host
+		}
+		for _, allowed := range allowedOrigins {
+			allowed = strings.ToLower(allowed)
+			if allowed == "*" || allowed == origin || allowed == host {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,162 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type tickAPI struct{}
+
+// Ticks sends a notification every 10ms, until the subscription ends.
+func (t *tickAPI) Ticks(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for i := 0; ; i++ {
+			select {
+			case <-ticker.C:
+				_ = notifier.Notify(sub.ID, i)
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+func startTickServer(t *testing.T, drainTimeout time.Duration) *Server {
+	server := NewServer(
+		"127.0.0.1",
+		10000+rand.Intn(22768),
+		"test",
+		WithWebsocketEnabled(),
+		WithDrainTimeout(drainTimeout),
+		WithLogger(testlog.Logger(t, log.LvlInfo)),
+	)
+	server.AddAPI(rpc.API{
+		Namespace: "test",
+		Service:   new(tickAPI),
+	})
+	require.NoError(t, server.Start())
+	return server
+}
+
+func TestServerDrainsWebsocketSubscriptions(t *testing.T) {
+	drainTimeout := 500 * time.Millisecond
+	server := startTickServer(t, drainTimeout)
+
+	client, err := rpc.Dial(fmt.Sprintf("ws://%s", server.Endpoint()))
+	require.NoError(t, err)
+	defer client.Close()
+	ticks := make(chan int, 1000)
+	sub, err := client.Subscribe(context.Background(), "test", ticks, "ticks")
+	require.NoError(t, err)
+	<-ticks
+
+	start := time.Now()
+	stopped := make(chan error)
+	go func() {
+		stopped <- server.Stop()
+	}()
+
+	// the open subscription keeps receiving while the server drains
+	time.Sleep(drainTimeout / 5)
+	for len(ticks) > 0 {
+		<-ticks
+	}
+	select {
+	case <-ticks:
+	case <-time.After(drainTimeout / 2):
+		t.Fatal("subscription stopped before the drain timeout")
+	}
+
+	// new connections are refused while draining
+	_, err = rpc.Dial(fmt.Sprintf("ws://%s", server.Endpoint()))
+	require.Error(t, err)
+
+	require.NoError(t, <-stopped)
+	require.GreaterOrEqual(t, time.Since(start), drainTimeout)
+	select {
+	case <-sub.Err():
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription not ended after the drain timeout")
+	}
+}
+
+func TestServerSendsCloseFrameAfterDrainTimeout(t *testing.T) {
+	server := startTickServer(t, 100*time.Millisecond)
+
+	conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws://%s", server.Endpoint()), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	stopped := make(chan error)
+	go func() {
+		stopped <- server.Stop()
+	}()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), "unexpected error: %v", err)
+	require.NoError(t, <-stopped)
+}
+
+func TestServerStopsWhenWebsocketClientsDisconnect(t *testing.T) {
+	drainTimeout := 5 * time.Second
+	server := startTickServer(t, drainTimeout)
+
+	client, err := rpc.Dial(fmt.Sprintf("ws://%s", server.Endpoint()))
+	require.NoError(t, err)
+	var res string
+	require.NoError(t, client.Call(&res, "health_status"))
+
+	start := time.Now()
+	stopped := make(chan error)
+	go func() {
+		stopped <- server.Stop()
+	}()
+	time.Sleep(50 * time.Millisecond)
+	client.Close()
+
+	require.NoError(t, <-stopped)
+	require.Less(t, time.Since(start), drainTimeout)
+}
+
+func TestOriginChecker(t *testing.T) {
+	check := originChecker([]string{"example.com", "https://app.example.org"})
+	for origin, allowed := range map[string]bool{
+		"":                        true,
+		"https://example.com":     true,
+		"http://EXAMPLE.com":      true,
+		"https://app.example.org": true,
+		"http://app.example.org":  false,
+		"https://evil.com":        false,
+	} {
+		r, err := http.NewRequest(http.MethodGet, "/", nil)
+		require.NoError(t, err)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		require.Equal(t, allowed, check(r), origin)
+	}
+	r, err := http.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, err)
+	r.Header.Set("Origin", "https://evil.com")
+	require.True(t, originChecker([]string{"*"})(r))
+}
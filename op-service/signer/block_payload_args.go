@@ -0,0 +1,51 @@
+package signer
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// BlockPayloadArgs represents the arguments to sign a block payload, as gossiped over p2p by the sequencer.
+type BlockPayloadArgs struct {
+	Domain        eth.Bytes32     `json:"domain"`
+	ChainID       *hexutil.Big    `json:"chainId"`
+	PayloadHash   common.Hash     `json:"payloadHash"`
+	SenderAddress *common.Address `json:"senderAddress"`
+}
+
+func NewBlockPayloadArgs(domain [32]byte, chainID *big.Int, payloadHash common.Hash, sender common.Address) *BlockPayloadArgs {
+	return &BlockPayloadArgs{
+		Domain:        domain,
+		ChainID:       (*hexutil.Big)(chainID),
+		PayloadHash:   payloadHash,
+		SenderAddress: &sender,
+	}
+}
+
+// SigningHash returns the hash that is signed for the block payload.
+func (args *BlockPayloadArgs) SigningHash() (common.Hash, error) {
+	return BlockPayloadSigningHash(args.Domain, args.ChainID.ToInt(), args.PayloadHash)
+}
+
+// BlockPayloadSigningHash returns the hash of the domain, the chain ID and the payload hash, which is signed
+// for a block payload.
+func BlockPayloadSigningHash(domain [32]byte, chainID *big.Int, payloadHash common.Hash) (common.Hash, error) {
+	var msgInput [32 + 32 + 32]byte
+	// domain: first 32 bytes
+	copy(msgInput[:32], domain[:])
+	// chain_id: second 32 bytes
+	if chainID.BitLen() > 256 {
+		return common.Hash{}, errors.New("chain_id is too large")
+	}
+	chainID.FillBytes(msgInput[32:64])
+	// payload_hash: third 32 bytes, hash of encoded payload
+	copy(msgInput[64:], payloadHash[:])
+
+	return crypto.Keccak256Hash(msgInput[:]), nil
+}
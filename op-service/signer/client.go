@@ -4,63 +4,52 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
 	"os"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	optls "github.com/ethereum-optimism/optimism/op-service/tls"
 	"github.com/ethereum-optimism/optimism/op-service/tls/certman"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
+// ErrUnreachable is wrapped by the errors of SignTransaction when the signer could not be reached,
+// or failed to serve the request, as opposed to the signer rejecting it. Such requests can be retried.
+var ErrUnreachable = errors.New("signer unreachable")
+
+// Metricer records the latency and the errors of the calls to the signer, by RPC method.
+type Metricer interface {
+	RecordRPCClientRequest(method string) func(err error)
+}
+
 type SignerClient struct {
-	client *rpc.Client
-	status string
-	logger log.Logger
+	client  *rpc.Client
+	logger  log.Logger
+	metrics Metricer
 }
 
-func NewSignerClient(logger log.Logger, endpoint string, tlsConfig optls.CLIConfig) (*SignerClient, error) {
-	var httpClient *http.Client
-	if tlsConfig.TLSCaCert != "" {
-		logger.Info("tlsConfig specified, loading tls config")
-		caCert, err := os.ReadFile(tlsConfig.TLSCaCert)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read tls.ca: %w", err)
-		}
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM(caCert)
-
-		// certman watches for newer client certifictes and automatically reloads them
-		cm, err := certman.New(logger, tlsConfig.TLSCert, tlsConfig.TLSKey)
-		if err != nil {
-			logger.Error("failed to read tls cert or key", "err", err)
-			return nil, err
-		}
-		if err := cm.Watch(); err != nil {
-			logger.Error("failed to start certman watcher", "err", err)
-			return nil, err
-		}
+type ClientOption func(s *SignerClient)
 
-		httpClient = &http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					MinVersion: tls.VersionTLS13,
-					RootCAs:    caCertPool,
-					GetClientCertificate: func(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
-						return cm.GetCertificate(nil)
-					},
-				},
-			},
-		}
-	} else {
-		logger.Info("no tlsConfig specified, using default http client")
-		httpClient = http.DefaultClient
+// WithMetrics records the calls to the signer with the given metrics.
+func WithMetrics(m Metricer) ClientOption {
+	return func(s *SignerClient) {
+		s.metrics = m
+	}
+}
+
+func NewSignerClient(logger log.Logger, endpoint string, tlsConfig optls.CLIConfig, opts ...ClientOption) (*SignerClient, error) {
+	httpClient, err := newHTTPClient(logger, tlsConfig)
+	if err != nil {
+		return nil, err
 	}
 
 	rpcClient, err := rpc.DialOptions(context.Background(), endpoint, rpc.WithHTTPClient(httpClient))
@@ -68,35 +57,96 @@ func NewSignerClient(logger log.Logger, endpoint string, tlsConfig optls.CLIConf
 		return nil, err
 	}
 
-	signer := &SignerClient{logger: logger, client: rpcClient}
+	signer := &SignerClient{logger: logger, client: rpcClient, metrics: &metrics.NoopRPCMetrics{}}
+	for _, opt := range opts {
+		opt(signer)
+	}
 	// Check if reachable
-	version, err := signer.pingVersion()
-	if err != nil {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	if err := signer.Ready(ctx); err != nil {
+		rpcClient.Close()
 		return nil, err
 	}
-	signer.status = fmt.Sprintf("ok [version=%v]", version)
 	return signer, nil
 }
 
-func NewSignerClientFromConfig(logger log.Logger, config CLIConfig) (*SignerClient, error) {
-	return NewSignerClient(logger, config.Endpoint, config.TLSConfig)
+func NewSignerClientFromConfig(logger log.Logger, config CLIConfig, opts ...ClientOption) (*SignerClient, error) {
+	return NewSignerClient(logger, config.Endpoint, config.TLSConfig, opts...)
 }
 
-func (s *SignerClient) pingVersion() (string, error) {
-	var v string
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
-	defer cancel()
-	if err := s.client.CallContext(ctx, &v, "health_status"); err != nil {
-		return "", err
+// newHTTPClient returns a client that verifies the signer with the CA bundle, and authenticates
+// with the client certificate, if a CA bundle is configured.
+func newHTTPClient(logger log.Logger, tlsConfig optls.CLIConfig) (*http.Client, error) {
+	if tlsConfig.TLSCaCert == "" {
+		logger.Info("no tlsConfig specified, using default http client")
+		return http.DefaultClient, nil
+	}
+	logger.Info("tlsConfig specified, loading tls config")
+	caCert, err := os.ReadFile(tlsConfig.TLSCaCert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tls.ca: %w", err)
+	}
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no certificates found in tls.ca %s", tlsConfig.TLSCaCert)
 	}
-	return v, nil
+
+	// certman watches for newer client certifictes and automatically reloads them
+	cm, err := certman.New(logger, tlsConfig.TLSCert, tlsConfig.TLSKey)
+	if err != nil {
+		logger.Error("failed to read tls cert or key", "err", err)
+		return nil, err
+	}
+	if err := cm.Watch(); err != nil {
+		logger.Error("failed to start certman watcher", "err", err)
+		return nil, err
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				MinVersion: tls.VersionTLS13,
+				RootCAs:    caCertPool,
+				GetClientCertificate: func(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+					return cm.GetCertificate(nil)
+				},
+			},
+		},
+	}, nil
+}
+
+// call calls the method of the signer, and records its latency and error.
+func (s *SignerClient) call(ctx context.Context, result any, method string, args ...any) error {
+	record := s.metrics.RecordRPCClientRequest(method)
+	err := s.client.CallContext(ctx, result, method, args...)
+	record(err)
+	return err
+}
+
+// Ready is the readiness probe of the signer: it returns an error if the signer cannot be reached,
+// or is not healthy.
+func (s *SignerClient) Ready(ctx context.Context) error {
+	var version string
+	if err := s.call(ctx, &version, "health_status"); err != nil {
+		return fmt.Errorf("signer is not ready: %w", err)
+	}
+	s.logger.Debug("signer is ready", "version", version)
+	return nil
+}
+
+func (s *SignerClient) Close() {
+	s.client.Close()
 }
 
 func (s *SignerClient) SignTransaction(ctx context.Context, chainId *big.Int, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
 	args := NewTransactionArgsFromTransaction(chainId, from, tx)
 
 	var result hexutil.Bytes
-	if err := s.client.CallContext(ctx, &result, "eth_signTransaction", args); err != nil {
+	if err := s.call(ctx, &result, "eth_signTransaction", args); err != nil {
+		if isUnreachable(err) {
+			return nil, fmt.Errorf("eth_signTransaction failed: %w: %w", ErrUnreachable, err)
+		}
 		return nil, fmt.Errorf("eth_signTransaction failed: %w", err)
 	}
 
@@ -104,6 +154,73 @@ func (s *SignerClient) SignTransaction(ctx context.Context, chainId *big.Int, fr
 	if err := signed.UnmarshalBinary(result); err != nil {
 		return nil, err
 	}
+	return checkSignedTransaction(chainId, from, tx, signed)
+}
+
+// SignBlockPayload signs the hash of a block payload, with the signing domain and chain ID, for the given address.
+func (s *SignerClient) SignBlockPayload(ctx context.Context, chainID *big.Int, from common.Address, domain [32]byte, payloadHash common.Hash) (*[65]byte, error) {
+	args := NewBlockPayloadArgs(domain, chainID, payloadHash, from)
+	signingHash, err := args.SigningHash()
+	if err != nil {
+		return nil, err
+	}
+
+	var result hexutil.Bytes
+	if err := s.call(ctx, &result, "opsigner_signBlockPayload", args); err != nil {
+		if isUnreachable(err) {
+			return nil, fmt.Errorf("opsigner_signBlockPayload failed: %w: %w", ErrUnreachable, err)
+		}
+		return nil, fmt.Errorf("opsigner_signBlockPayload failed: %w", err)
+	}
+	if len(result) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d, expected %d", len(result), crypto.SignatureLength)
+	}
+	pub, err := crypto.SigToPub(signingHash[:], result)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block payload signature: %w", err)
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != from {
+		return nil, fmt.Errorf("signer signed block payload for %s, expected %s", signer, from)
+	}
+	return (*[65]byte)(result), nil
+}
+
+// checkSignedTransaction checks that the signer signed the given transaction for the given address,
+// so that a transaction that was altered by the signer is never sent. As the blob sidecar of a blob transaction
+// is not signed, the signature is applied to the given transaction, which keeps its sidecar.
+func checkSignedTransaction(chainId *big.Int, from common.Address, tx *types.Transaction, signed *types.Transaction) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainId)
+	if signed.Type() != tx.Type() || signer.Hash(signed) != signer.Hash(tx) {
+		return nil, fmt.Errorf("signer returned a different transaction %s than the one to sign", signed.Hash())
+	}
+	sender, err := types.Sender(signer, signed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature of signed transaction: %w", err)
+	}
+	if sender != from {
+		return nil, fmt.Errorf("signer signed transaction for %s, expected %s", sender, from)
+	}
+	if tx.BlobTxSidecar() == nil || signed.BlobTxSidecar() != nil {
+		return signed, nil
+	}
+	v, r, s := signed.RawSignatureValues()
+	sig := make([]byte, crypto.SignatureLength)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	sig[64] = byte(v.Uint64())
+	return tx.WithSignature(signer, sig)
+}
 
-	return signed, nil
+// isUnreachable returns whether the signing request failed before it was served by the signer: a connection
+// or timeout error, or a server error status. JSON-RPC errors and client error statuses are rejections of the request.
+func isUnreachable(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500
+	}
+	return true
 }
@@ -0,0 +1,381 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+)
+
+type stubHealthAPI struct{}
+
+func (stubHealthAPI) Status() string {
+	return "ok"
+}
+
+// stubEthAPI signs txs with its key, or fails with its err. It applies modify to the txs before signing them.
+type stubEthAPI struct {
+	chainID *big.Int
+	key     *ecdsa.PrivateKey
+	err     error
+	modify  func(args *TransactionArgs)
+}
+
+func (a *stubEthAPI) SignTransaction(args TransactionArgs) (hexutil.Bytes, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	if a.modify != nil {
+		a.modify(&args)
+	}
+	signed, err := types.SignTx(args.ToTransaction(), types.LatestSignerForChainID(a.chainID), a.key)
+	if err != nil {
+		return nil, err
+	}
+	return signed.MarshalBinary()
+}
+
+func TestSignTransactionErrors(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(10)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 1, Gas: 21000, To: &common.Address{0x01}})
+
+	api := &stubEthAPI{chainID: chainID, key: key}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("health", stubHealthAPI{}))
+	require.NoError(t, server.RegisterName("eth", api))
+	var status int
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		server.ServeHTTP(w, r)
+	}))
+	defer httpServer.Close()
+
+	client, err := NewSignerClient(log.New(), httpServer.URL, optls.CLIConfig{})
+	require.NoError(t, err)
+
+	signed, err := client.SignTransaction(context.Background(), chainID, from, tx)
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, from, sender)
+	require.Equal(t, tx.Nonce(), signed.Nonce())
+
+	api.err = errors.New("unknown account")
+	_, err = client.SignTransaction(context.Background(), chainID, from, tx)
+	require.ErrorContains(t, err, "unknown account")
+	require.NotErrorIs(t, err, ErrUnreachable, "rejected by the signer")
+	api.err = nil
+
+	status = http.StatusForbidden
+	_, err = client.SignTransaction(context.Background(), chainID, from, tx)
+	require.NotErrorIs(t, err, ErrUnreachable, "rejected by the signer")
+
+	status = http.StatusServiceUnavailable
+	_, err = client.SignTransaction(context.Background(), chainID, from, tx)
+	require.ErrorIs(t, err, ErrUnreachable)
+	status = 0
+
+	httpServer.Close()
+	_, err = client.SignTransaction(context.Background(), chainID, from, tx)
+	require.ErrorIs(t, err, ErrUnreachable)
+}
+
+// newStubSignerClient returns a client of a stub signer server with the given eth API.
+func newStubSignerClient(t *testing.T, api *stubEthAPI) *SignerClient {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("health", stubHealthAPI{}))
+	require.NoError(t, server.RegisterName("eth", api))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	client, err := NewSignerClient(log.New(), httpServer.URL, optls.CLIConfig{})
+	require.NoError(t, err)
+	return client
+}
+
+func TestSignBlobTransaction(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(10)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	client := newStubSignerClient(t, &stubEthAPI{chainID: chainID, key: key})
+
+	var blob kzg4844.Blob
+	commitment, err := kzg4844.BlobToCommitment(blob)
+	require.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+	require.NoError(t, err)
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       []kzg4844.Blob{blob},
+		Commitments: []kzg4844.Commitment{commitment},
+		Proofs:      []kzg4844.Proof{proof},
+	}
+	tx := types.NewTx(&types.BlobTx{
+		ChainID:    uint256.MustFromBig(chainID),
+		Nonce:      1,
+		GasTipCap:  uint256.NewInt(1),
+		GasFeeCap:  uint256.NewInt(10),
+		Gas:        21000,
+		To:         common.Address{0x01},
+		Value:      uint256.NewInt(0),
+		BlobFeeCap: uint256.NewInt(5),
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	})
+
+	signed, err := client.SignTransaction(context.Background(), chainID, from, tx)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), signed.Type())
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, from, sender)
+	require.Equal(t, tx.BlobHashes(), signed.BlobHashes())
+	require.Equal(t, tx.BlobGasFeeCap(), signed.BlobGasFeeCap())
+	require.Equal(t, sidecar, signed.BlobTxSidecar(), "the sidecar is kept")
+}
+
+func TestSignTransactionChecksSignedTransaction(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(10)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 1, Gas: 21000, To: &common.Address{0x01}})
+
+	api := &stubEthAPI{chainID: chainID, key: otherKey}
+	client := newStubSignerClient(t, api)
+	_, err = client.SignTransaction(context.Background(), chainID, from, tx)
+	require.ErrorContains(t, err, "expected "+from.String())
+
+	api.key = key
+	api.modify = func(args *TransactionArgs) {
+		nonce := hexutil.Uint64(2)
+		args.Nonce = &nonce
+	}
+	_, err = client.SignTransaction(context.Background(), chainID, from, tx)
+	require.ErrorContains(t, err, "different transaction")
+	require.NotErrorIs(t, err, ErrUnreachable, "altered txs are not retried")
+}
+
+// stubOpSignerAPI signs block payloads with its key, or returns its result instead if set.
+type stubOpSignerAPI struct {
+	key    *ecdsa.PrivateKey
+	result hexutil.Bytes
+}
+
+func (a *stubOpSignerAPI) SignBlockPayload(args BlockPayloadArgs) (hexutil.Bytes, error) {
+	if a.result != nil {
+		return a.result, nil
+	}
+	signingHash, err := args.SigningHash()
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(signingHash[:], a.key)
+}
+
+// requestMetrics counts the recorded requests and errors by method.
+type requestMetrics struct {
+	requests map[string]int
+	errors   map[string]int
+}
+
+func (m *requestMetrics) RecordRPCClientRequest(method string) func(err error) {
+	m.requests[method]++
+	return func(err error) {
+		if err != nil {
+			m.errors[method]++
+		}
+	}
+}
+
+func TestSignBlockPayload(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(10)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	domain := [32]byte{1}
+	payloadHash := crypto.Keccak256Hash([]byte("payload"))
+
+	api := &stubOpSignerAPI{key: key}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("health", stubHealthAPI{}))
+	require.NoError(t, server.RegisterName("opsigner", api))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	m := &requestMetrics{requests: make(map[string]int), errors: make(map[string]int)}
+	client, err := NewSignerClient(log.New(), httpServer.URL, optls.CLIConfig{}, WithMetrics(m))
+	require.NoError(t, err)
+	defer client.Close()
+
+	sig, err := client.SignBlockPayload(context.Background(), chainID, from, domain, payloadHash)
+	require.NoError(t, err)
+	signingHash, err := BlockPayloadSigningHash(domain, chainID, payloadHash)
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(signingHash[:], sig[:])
+	require.NoError(t, err)
+	require.Equal(t, from, crypto.PubkeyToAddress(*pub))
+
+	api.key = otherKey
+	_, err = client.SignBlockPayload(context.Background(), chainID, from, domain, payloadHash)
+	require.ErrorContains(t, err, "expected "+from.String())
+	api.key = key
+
+	// malformed responses
+	api.result = hexutil.Bytes{0x01, 0x02}
+	_, err = client.SignBlockPayload(context.Background(), chainID, from, domain, payloadHash)
+	require.ErrorContains(t, err, "invalid signature length")
+	api.result = make(hexutil.Bytes, crypto.SignatureLength)
+	api.result[64] = 5
+	_, err = client.SignBlockPayload(context.Background(), chainID, from, domain, payloadHash)
+	require.ErrorContains(t, err, "invalid block payload signature")
+
+	require.Equal(t, map[string]int{"health_status": 1, "opsigner_signBlockPayload": 4}, m.requests)
+	require.Empty(t, m.errors, "malformed responses are not errors of the calls")
+
+	httpServer.Close()
+	_, err = client.SignBlockPayload(context.Background(), chainID, from, domain, payloadHash)
+	require.ErrorIs(t, err, ErrUnreachable)
+	require.Error(t, client.Ready(context.Background()))
+	require.Equal(t, map[string]int{"health_status": 1, "opsigner_signBlockPayload": 1}, m.errors)
+}
+
+func TestSignerClientTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := newTestCA(t, dir, "ca")
+	newTestCA(t, dir, "other-ca")
+	serverCert := newTestCert(t, dir, "server", ca, caKey, x509.ExtKeyUsageServerAuth)
+	newTestCert(t, dir, "client", ca, caKey, x509.ExtKeyUsageClientAuth)
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("health", stubHealthAPI{}))
+	httpServer := httptest.NewUnstartedServer(server)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+	httpServer.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	httpServer.StartTLS()
+	defer httpServer.Close()
+
+	tlsConfig := optls.CLIConfig{
+		TLSCaCert: filepath.Join(dir, "ca.crt"),
+		TLSCert:   filepath.Join(dir, "client.crt"),
+		TLSKey:    filepath.Join(dir, "client.key"),
+	}
+	client, err := NewSignerClient(log.New(), httpServer.URL, tlsConfig)
+	require.NoError(t, err)
+	require.NoError(t, client.Ready(context.Background()))
+	client.Close()
+
+	// the server is not verified with another CA bundle
+	otherCfg := tlsConfig
+	otherCfg.TLSCaCert = filepath.Join(dir, "other-ca.crt")
+	_, err = NewSignerClient(log.New(), httpServer.URL, otherCfg)
+	var verifyErr *tls.CertificateVerificationError
+	require.ErrorAs(t, err, &verifyErr)
+
+	// the client is not verified by the server without a client certificate of the CA
+	otherCfg = tlsConfig
+	otherCfg.TLSCert = filepath.Join(dir, "server.crt")
+	otherCfg.TLSKey = filepath.Join(dir, "server.key")
+	_, err = NewSignerClient(log.New(), httpServer.URL, otherCfg)
+	require.Error(t, err)
+
+	invalidCA := filepath.Join(dir, "invalid-ca.crt")
+	require.NoError(t, os.WriteFile(invalidCA, []byte("not a certificate"), 0o600))
+	otherCfg = tlsConfig
+	otherCfg.TLSCaCert = invalidCA
+	_, err = NewSignerClient(log.New(), httpServer.URL, otherCfg)
+	require.ErrorContains(t, err, "no certificates found")
+}
+
+// newTestCA creates a CA certificate, and writes it to <name>.crt in the dir.
+func newTestCA(t *testing.T, dir string, name string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, name+".crt"), "CERTIFICATE", der)
+	return cert, key
+}
+
+// newTestCert creates a certificate for localhost that is signed by the CA,
+// and writes it to <name>.crt and its key to <name>.key in the dir.
+func newTestCert(t *testing.T, dir string, name string, ca *x509.Certificate, caKey *ecdsa.PrivateKey, usage x509.ExtKeyUsage) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, name+".crt"), "CERTIFICATE", der)
+	writePEM(t, filepath.Join(dir, name+".key"), "EC PRIVATE KEY", keyDer)
+	cert, err := tls.X509KeyPair(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+	)
+	require.NoError(t, err)
+	return cert
+}
+
+func writePEM(t *testing.T, path string, typ string, der []byte) {
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600))
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
 )
 
 // TransactionArgs represents the arguments to construct a new transaction
@@ -28,9 +29,14 @@ type TransactionArgs struct {
 
 	AccessList *types.AccessList `json:"accessList,omitempty"`
 	ChainID    *hexutil.Big      `json:"chainId,omitempty"`
+
+	// EIP-4844 blob transactions. The blob sidecar is not part of the signed transaction, so it isn't sent
+	// to the signer.
+	BlobFeeCap *hexutil.Big  `json:"maxFeePerBlobGas,omitempty"`
+	BlobHashes []common.Hash `json:"blobVersionedHashes,omitempty"`
 }
 
-// NewTransactionArgsFromTransaction creates a TransactionArgs struct from an EIP-1559 transaction
+// NewTransactionArgsFromTransaction creates a TransactionArgs struct from an EIP-1559 or EIP-4844 transaction
 func NewTransactionArgsFromTransaction(chainId *big.Int, from common.Address, tx *types.Transaction) *TransactionArgs {
 	data := hexutil.Bytes(tx.Data())
 	nonce := hexutil.Uint64(tx.Nonce())
@@ -48,6 +54,10 @@ func NewTransactionArgsFromTransaction(chainId *big.Int, from common.Address, tx
 		MaxPriorityFeePerGas: (*hexutil.Big)(tx.GasTipCap()),
 		AccessList:           &accesses,
 	}
+	if tx.Type() == types.BlobTxType {
+		args.BlobFeeCap = (*hexutil.Big)(tx.BlobGasFeeCap())
+		args.BlobHashes = tx.BlobHashes()
+	}
 	return args
 }
 
@@ -69,6 +79,25 @@ func (args *TransactionArgs) ToTransaction() *types.Transaction {
 	if args.AccessList != nil {
 		al = *args.AccessList
 	}
+	if args.BlobHashes != nil {
+		var to common.Address
+		if args.To != nil {
+			to = *args.To
+		}
+		return types.NewTx(&types.BlobTx{
+			To:         to,
+			ChainID:    uint256.MustFromBig((*big.Int)(args.ChainID)),
+			Nonce:      uint64(*args.Nonce),
+			Gas:        uint64(*args.Gas),
+			GasFeeCap:  uint256.MustFromBig((*big.Int)(args.MaxFeePerGas)),
+			GasTipCap:  uint256.MustFromBig((*big.Int)(args.MaxPriorityFeePerGas)),
+			Value:      uint256.MustFromBig((*big.Int)(args.Value)),
+			Data:       args.data(),
+			AccessList: al,
+			BlobFeeCap: uint256.MustFromBig((*big.Int)(args.BlobFeeCap)),
+			BlobHashes: args.BlobHashes,
+		})
+	}
 	data = &types.DynamicFeeTx{
 		To:         args.To,
 		ChainID:    (*big.Int)(args.ChainID),
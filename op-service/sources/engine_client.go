@@ -2,9 +2,11 @@ package sources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/eth/catalyst"
 	"github.com/ethereum/go-ethereum/params"
 
@@ -54,10 +56,25 @@ func (s *EngineClient) ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceS
 	llog := s.log.New("state", fc)       // local logger
 	tlog := llog.New("attr", attributes) // trace logger
 	tlog.Trace("Sharing forkchoice-updated signal")
+	method, version := "engine_forkchoiceUpdatedV2", eth.EngineAPIV2
+	var attrs any // untyped nil, encoded as null when not building a block
+	if attributes != nil {
+		if attributes.ParentBeaconBlockRoot != nil {
+			method, version = "engine_forkchoiceUpdatedV3", eth.EngineAPIV3
+		}
+		if err := attributes.Validate(); err != nil {
+			return nil, eth.InputError{Inner: err, Code: eth.InvalidPayloadAttributes}
+		}
+		data, err := attributes.MarshalVersionedJSON(version)
+		if err != nil {
+			return nil, eth.InputError{Inner: err, Code: eth.InvalidPayloadAttributes}
+		}
+		attrs = json.RawMessage(data)
+	}
 	fcCtx, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
 	var result eth.ForkchoiceUpdatedResult
-	err := s.client.CallContext(fcCtx, &result, "engine_forkchoiceUpdatedV2", fc, attributes)
+	err := s.client.CallContext(fcCtx, &result, method, fc, attrs)
 	if err == nil {
 		tlog.Trace("Shared forkchoice-updated signal")
 		if attributes != nil { // block building is optional, we only get a payload ID if we are building a block
@@ -83,16 +100,27 @@ func (s *EngineClient) ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceS
 }
 
 // NewPayload executes a full block on the execution engine.
+// Payloads with a parent beacon block root are executed with engine_newPayloadV3, together with the
+// versioned hashes of the blobs of the payload transactions, other payloads with engine_newPayloadV2.
 // This returns a PayloadStatusV1 which encodes any validation/processing error,
 // and this type of error is kept separate from the returned `error` used for RPC errors, like timeouts.
-func (s *EngineClient) NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error) {
+func (s *EngineClient) NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error) {
 	e := s.log.New("block_hash", payload.BlockHash)
 	e.Trace("sending payload for execution")
 
 	execCtx, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
 	var result eth.PayloadStatusV1
-	err := s.client.CallContext(execCtx, &result, "engine_newPayloadV2", payload)
+	var err error
+	if parentBeaconBlockRoot != nil {
+		blobHashes, hashErr := payload.BlobVersionedHashes()
+		if hashErr != nil {
+			return nil, fmt.Errorf("failed to collect blob versioned hashes of payload: %w", hashErr)
+		}
+		err = s.client.CallContext(execCtx, &result, "engine_newPayloadV3", payload, blobHashes, parentBeaconBlockRoot)
+	} else {
+		err = s.client.CallContext(execCtx, &result, "engine_newPayloadV2", payload)
+	}
 	e.Trace("Received payload execution result", "status", result.Status, "latestValidHash", result.LatestValidHash, "message", result.ValidationError)
 	if err != nil {
 		e.Error("Payload execution failed", "err", err)
@@ -102,16 +130,22 @@ func (s *EngineClient) NewPayload(ctx context.Context, payload *eth.ExecutionPay
 }
 
 // GetPayload gets the execution payload associated with the PayloadId.
+// Payloads that are built with a parent beacon block root are retrieved with engine_getPayloadV3,
+// other payloads with engine_getPayloadV2. The returned envelope carries the parent beacon block root.
 // There may be two types of error:
 // 1. `error` as eth.InputError: the payload ID may be unknown
 // 2. Other types of `error`: temporary RPC errors, like timeouts.
-func (s *EngineClient) GetPayload(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error) {
-	e := s.log.New("payload_id", payloadId)
+func (s *EngineClient) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error) {
+	e := s.log.New("payload_id", payloadInfo.ID)
 	e.Trace("getting payload")
+	method := "engine_getPayloadV2"
+	if payloadInfo.ParentBeaconBlockRoot != nil {
+		method = "engine_getPayloadV3"
+	}
 	var result eth.ExecutionPayloadEnvelope
-	err := s.client.CallContext(ctx, &result, "engine_getPayloadV2", payloadId)
+	err := s.client.CallContext(ctx, &result, method, payloadInfo.ID)
 	if err != nil {
-		e.Warn("Failed to get payload", "payload_id", payloadId, "err", err)
+		e.Warn("Failed to get payload", "payload_id", payloadInfo.ID, "err", err)
 		if rpcErr, ok := err.(rpc.Error); ok {
 			code := eth.ErrorCode(rpcErr.ErrorCode())
 			switch code {
@@ -126,8 +160,15 @@ func (s *EngineClient) GetPayload(ctx context.Context, payloadId eth.PayloadID)
 		}
 		return nil, err
 	}
+	if result.ExecutionPayload == nil {
+		return nil, fmt.Errorf("engine returned no execution payload for payload %s", payloadInfo.ID)
+	}
+	// the engine does not have to echo the parent beacon block root that the payload was built with
+	if result.ParentBeaconBlockRoot == nil {
+		result.ParentBeaconBlockRoot = payloadInfo.ParentBeaconBlockRoot
+	}
 	e.Trace("Received payload")
-	return result.ExecutionPayload, nil
+	return &result, nil
 }
 
 func (s *EngineClient) SignalSuperchainV1(ctx context.Context, recommended, required params.ProtocolVersion) (params.ProtocolVersion, error) {
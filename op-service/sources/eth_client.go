@@ -124,8 +124,8 @@ type EthClient struct {
 	headersCache *caching.LRUCache[common.Hash, eth.BlockInfo]
 
 	// cache payloads by hash
-	// common.Hash -> *eth.ExecutionPayload
-	payloadsCache *caching.LRUCache[common.Hash, *eth.ExecutionPayload]
+	// common.Hash -> *eth.ExecutionPayloadEnvelope
+	payloadsCache *caching.LRUCache[common.Hash, *eth.ExecutionPayloadEnvelope]
 }
 
 // NewEthClient returns an [EthClient], wrapping an RPC with bindings to fetch ethereum data with added error logging,
@@ -145,7 +145,7 @@ func NewEthClient(client client.RPC, log log.Logger, metrics caching.Metrics, co
 		log:               log,
 		transactionsCache: caching.NewLRUCache[common.Hash, types.Transactions](metrics, "txs", config.TransactionsCacheSize),
 		headersCache:      caching.NewLRUCache[common.Hash, eth.BlockInfo](metrics, "headers", config.HeadersCacheSize),
-		payloadsCache:     caching.NewLRUCache[common.Hash, *eth.ExecutionPayload](metrics, "payloads", config.PayloadsCacheSize),
+		payloadsCache:     caching.NewLRUCache[common.Hash, *eth.ExecutionPayloadEnvelope](metrics, "payloads", config.PayloadsCacheSize),
 	}, nil
 }
 
@@ -227,7 +227,7 @@ func (s *EthClient) blockCall(ctx context.Context, method string, id rpcBlockID)
 	return info, txs, nil
 }
 
-func (s *EthClient) payloadCall(ctx context.Context, method string, id rpcBlockID) (*eth.ExecutionPayload, error) {
+func (s *EthClient) payloadCall(ctx context.Context, method string, id rpcBlockID) (*eth.ExecutionPayloadEnvelope, error) {
 	var block *rpcBlock
 	err := s.client.CallContext(ctx, &block, method, id.Arg(), true)
 	if err != nil {
@@ -236,15 +236,15 @@ func (s *EthClient) payloadCall(ctx context.Context, method string, id rpcBlockI
 	if block == nil {
 		return nil, ethereum.NotFound
 	}
-	payload, err := block.ExecutionPayload(s.trustRPC)
+	envelope, err := block.ExecutionPayloadEnvelope(s.trustRPC)
 	if err != nil {
 		return nil, err
 	}
-	if err := id.CheckID(payload.ID()); err != nil {
+	if err := id.CheckID(envelope.ExecutionPayload.ID()); err != nil {
 		return nil, fmt.Errorf("fetched payload does not match requested ID: %w", err)
 	}
-	s.payloadsCache.Add(payload.BlockHash, payload)
-	return payload, nil
+	s.payloadsCache.Add(envelope.ExecutionPayload.BlockHash, envelope)
+	return envelope, nil
 }
 
 // ChainID fetches the chain id of the internal RPC.
@@ -293,18 +293,18 @@ func (s *EthClient) InfoAndTxsByLabel(ctx context.Context, label eth.BlockLabel)
 	return s.blockCall(ctx, "eth_getBlockByNumber", label)
 }
 
-func (s *EthClient) PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayload, error) {
+func (s *EthClient) PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayloadEnvelope, error) {
 	if payload, ok := s.payloadsCache.Get(hash); ok {
 		return payload, nil
 	}
 	return s.payloadCall(ctx, "eth_getBlockByHash", hashID(hash))
 }
 
-func (s *EthClient) PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayload, error) {
+func (s *EthClient) PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayloadEnvelope, error) {
 	return s.payloadCall(ctx, "eth_getBlockByNumber", numberID(number))
 }
 
-func (s *EthClient) PayloadByLabel(ctx context.Context, label eth.BlockLabel) (*eth.ExecutionPayload, error) {
+func (s *EthClient) PayloadByLabel(ctx context.Context, label eth.BlockLabel) (*eth.ExecutionPayloadEnvelope, error) {
 	return s.payloadCall(ctx, "eth_getBlockByNumber", label)
 }
 
@@ -355,6 +355,13 @@ func (s *EthClient) GetProof(ctx context.Context, address common.Address, storag
 	return getProofResponse, nil
 }
 
+// CodeAt returns the code of the given account at the given block tag, **without verifying the correctness of the result**.
+func (s *EthClient) CodeAt(ctx context.Context, address common.Address, blockTag string) ([]byte, error) {
+	var out hexutil.Bytes
+	err := s.client.CallContext(ctx, &out, "eth_getCode", address, blockTag)
+	return out, err
+}
+
 // GetStorageAt returns the storage value at the given address and storage slot, **without verifying the correctness of the result**.
 // This should only ever be used as alternative to GetProof when the user opts in.
 // E.g. Erigon L1 node users may have to use this, since Erigon does not support eth_getProof, see https://github.com/ledgerwatch/erigon/issues/1349
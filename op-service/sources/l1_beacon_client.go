@@ -0,0 +1,148 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	genesisMethod        = "eth/v1/beacon/genesis"
+	specMethod           = "eth/v1/config/spec"
+	sidecarsMethodPrefix = "eth/v1/beacon/blob_sidecars/"
+)
+
+// L1BeaconClient is a client for the L1 beacon-node API, to retrieve the blobs of blob txs confirmed in L1 blocks.
+type L1BeaconClient struct {
+	cl client.HTTP
+
+	initLock     sync.Mutex
+	timeToSlotFn TimeToSlotFn
+}
+
+// TimeToSlotFn returns the slot number of the given L1 block timestamp.
+type TimeToSlotFn func(timestamp uint64) (uint64, error)
+
+// NewL1BeaconClient returns a client for making requests to an L1 consensus layer node.
+func NewL1BeaconClient(cl client.HTTP) *L1BeaconClient {
+	return &L1BeaconClient{cl: cl}
+}
+
+func (cl *L1BeaconClient) apiReq(ctx context.Context, dest any, method string, query url.Values) error {
+	headers := http.Header{}
+	headers.Add("Accept", "application/json")
+	resp, err := cl.cl.Get(ctx, method, query, headers)
+	if err != nil {
+		return fmt.Errorf("%w: http Get failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return ethereum.NotFound
+	} else if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed request with status %d: %s", resp.StatusCode, string(body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+	return nil
+}
+
+// GetTimeToSlotFn returns a function that converts a timestamp to a slot number.
+// The genesis time and seconds per slot are fetched once, and cached.
+func (cl *L1BeaconClient) GetTimeToSlotFn(ctx context.Context) (TimeToSlotFn, error) {
+	cl.initLock.Lock()
+	defer cl.initLock.Unlock()
+	if cl.timeToSlotFn != nil {
+		return cl.timeToSlotFn, nil
+	}
+
+	var genesisResp eth.APIGenesisResponse
+	if err := cl.apiReq(ctx, &genesisResp, genesisMethod, nil); err != nil {
+		return nil, fmt.Errorf("failed to fetch the beacon genesis: %w", err)
+	}
+
+	var configResp eth.APIConfigResponse
+	if err := cl.apiReq(ctx, &configResp, specMethod, nil); err != nil {
+		return nil, fmt.Errorf("failed to fetch the beacon spec: %w", err)
+	}
+
+	genesisTime := uint64(genesisResp.Data.GenesisTime)
+	secondsPerSlot := uint64(configResp.Data.SecondsPerSlot)
+	if secondsPerSlot == 0 {
+		return nil, fmt.Errorf("got bad value for seconds per slot: %v", configResp.Data.SecondsPerSlot)
+	}
+	cl.timeToSlotFn = func(timestamp uint64) (uint64, error) {
+		if timestamp < genesisTime {
+			return 0, fmt.Errorf("provided timestamp (%v) precedes genesis time (%v)", timestamp, genesisTime)
+		}
+		return (timestamp - genesisTime) / secondsPerSlot, nil
+	}
+	return cl.timeToSlotFn, nil
+}
+
+// GetBlobSidecars fetches the blob sidecars of the given blob hashes, confirmed in the given L1 block.
+func (cl *L1BeaconClient) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error) {
+	if len(hashes) == 0 {
+		return []*eth.BlobSidecar{}, nil
+	}
+	slotFn, err := cl.GetTimeToSlotFn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time to slot function: %w", err)
+	}
+	slot, err := slotFn(ref.Time)
+	if err != nil {
+		return nil, fmt.Errorf("error in converting ref.Time to slot: %w", err)
+	}
+
+	query := url.Values{}
+	for _, h := range hashes {
+		query.Add("indices", strconv.FormatUint(h.Index, 10))
+	}
+	var resp eth.APIGetBlobSidecarsResponse
+	if err := cl.apiReq(ctx, &resp, sidecarsMethodPrefix+strconv.FormatUint(slot, 10), query); err != nil {
+		return nil, fmt.Errorf("failed to fetch blob sidecars for slot %v block %v: %w", slot, ref, err)
+	}
+	if len(hashes) != len(resp.Data) {
+		return nil, fmt.Errorf("expected %v sidecars for block %v but got %v", len(hashes), ref, len(resp.Data))
+	}
+	return resp.Data, nil
+}
+
+// GetBlobs fetches the blobs of the given blob hashes, confirmed in the given L1 block,
+// and verifies them against the blob hashes. The blobs are returned in the order of the hashes.
+func (cl *L1BeaconClient) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	sidecars, err := cl.GetBlobSidecars(ctx, ref, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob sidecars for L1BlockRef %s: %w", ref, err)
+	}
+	// the sidecars are matched to the requested hashes by their commitment
+	byHash := make(map[[32]byte]*eth.BlobSidecar, len(sidecars))
+	for _, sidecar := range sidecars {
+		byHash[eth.KZGToVersionedHash(kzg4844.Commitment(sidecar.KZGCommitment))] = sidecar
+	}
+	out := make([]*eth.Blob, len(hashes))
+	for i, h := range hashes {
+		sidecar, ok := byHash[h.Hash]
+		if !ok {
+			return nil, fmt.Errorf("missing sidecar of blob %d with hash %s in block %s", h.Index, h.Hash, ref)
+		}
+		if err := eth.VerifyBlobProof(&sidecar.Blob, kzg4844.Commitment(sidecar.KZGCommitment), kzg4844.Proof(sidecar.KZGProof)); err != nil {
+			return nil, errors.Join(fmt.Errorf("invalid proof of blob %d with hash %s in block %s", h.Index, h.Hash, ref), err)
+		}
+		out[i] = &sidecar.Blob
+	}
+	return out, nil
+}
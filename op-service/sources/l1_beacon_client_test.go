@@ -0,0 +1,97 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func makeTestBlobSidecar(t *testing.T, index uint64, data string) (eth.IndexedBlobHash, *eth.BlobSidecar) {
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(eth.Data(data)))
+	commit, err := blob.ComputeKZGCommitment()
+	require.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(*blob.KZGBlob(), commit)
+	require.NoError(t, err)
+	sidecar := &eth.BlobSidecar{
+		Slot:          10,
+		Blob:          blob,
+		Index:         eth.Uint64String(index),
+		KZGCommitment: eth.Bytes48(commit),
+		KZGProof:      eth.Bytes48(proof),
+	}
+	return eth.IndexedBlobHash{Index: index, Hash: eth.KZGToVersionedHash(commit)}, sidecar
+}
+
+func TestL1BeaconClient(t *testing.T) {
+	hash0, sidecar0 := makeTestBlobSidecar(t, 0, "first")
+	hash1, sidecar1 := makeTestBlobSidecar(t, 1, "second")
+
+	var sidecarsPath, indices string
+	sidecars := []*eth.BlobSidecar{sidecar1, sidecar0} // not in index order
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp any
+		switch r.URL.Path {
+		case "/eth/v1/beacon/genesis":
+			resp = eth.APIGenesisResponse{Data: eth.ReducedGenesisData{GenesisTime: 100}}
+		case "/eth/v1/config/spec":
+			resp = eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: 12}}
+		default:
+			sidecarsPath = r.URL.Path
+			indices = r.URL.RawQuery
+			resp = eth.APIGetBlobSidecarsResponse{Data: sidecars}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	cl := NewL1BeaconClient(client.NewBasicHTTPClient(srv.URL, testlog.Logger(t, log.LvlInfo)))
+	ref := eth.L1BlockRef{Hash: common.Hash{0xaa}, Number: 5, Time: 100 + 12*10}
+	ctx := context.Background()
+
+	t.Run("time to slot", func(t *testing.T) {
+		fn, err := cl.GetTimeToSlotFn(ctx)
+		require.NoError(t, err)
+		slot, err := fn(ref.Time + 11)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), slot)
+		_, err = fn(99)
+		require.Error(t, err, "timestamp before genesis")
+	})
+
+	t.Run("blobs in hash order", func(t *testing.T) {
+		blobs, err := cl.GetBlobs(ctx, ref, []eth.IndexedBlobHash{hash0, hash1})
+		require.NoError(t, err)
+		require.Equal(t, "/eth/v1/beacon/blob_sidecars/10", sidecarsPath)
+		require.Equal(t, "indices=0&indices=1", indices)
+		require.Equal(t, []*eth.Blob{&sidecar0.Blob, &sidecar1.Blob}, blobs)
+		data, err := blobs[1].ToData()
+		require.NoError(t, err)
+		require.Equal(t, eth.Data("second"), data)
+	})
+
+	t.Run("missing sidecar", func(t *testing.T) {
+		_, err := cl.GetBlobs(ctx, ref, []eth.IndexedBlobHash{hash0, {Index: 1, Hash: common.Hash{0x01}}})
+		require.ErrorContains(t, err, "missing sidecar")
+	})
+
+	t.Run("invalid proof", func(t *testing.T) {
+		bad := *sidecar0
+		bad.KZGProof = sidecar1.KZGProof
+		sidecars = []*eth.BlobSidecar{&bad}
+		defer func() { sidecars = []*eth.BlobSidecar{sidecar1, sidecar0} }()
+		_, err := cl.GetBlobs(ctx, ref, []eth.IndexedBlobHash{hash0})
+		require.ErrorContains(t, err, "invalid proof")
+	})
+}
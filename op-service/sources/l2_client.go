@@ -94,7 +94,7 @@ func NewL2Client(client client.RPC, log log.Logger, metrics caching.Metrics, con
 
 // L2BlockRefByLabel returns the [eth.L2BlockRef] for the given block label.
 func (s *L2Client) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
-	payload, err := s.PayloadByLabel(ctx, label)
+	envelope, err := s.PayloadByLabel(ctx, label)
 	if err != nil {
 		// Both geth and erigon like to serve non-standard errors for the safe and finalized heads, correct that.
 		// This happens when the chain just started and nothing is marked as safe/finalized yet.
@@ -104,7 +104,7 @@ func (s *L2Client) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel)
 		// w%: wrap to preserve ethereum.NotFound case
 		return eth.L2BlockRef{}, fmt.Errorf("failed to determine L2BlockRef of %s, could not get payload: %w", label, err)
 	}
-	ref, err := derive.PayloadToBlockRef(payload, &s.rollupCfg.Genesis)
+	ref, err := derive.PayloadToBlockRef(envelope.ExecutionPayload, &s.rollupCfg.Genesis)
 	if err != nil {
 		return eth.L2BlockRef{}, err
 	}
@@ -114,12 +114,12 @@ func (s *L2Client) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel)
 
 // L2BlockRefByNumber returns the [eth.L2BlockRef] for the given block number.
 func (s *L2Client) L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error) {
-	payload, err := s.PayloadByNumber(ctx, num)
+	envelope, err := s.PayloadByNumber(ctx, num)
 	if err != nil {
 		// w%: wrap to preserve ethereum.NotFound case
 		return eth.L2BlockRef{}, fmt.Errorf("failed to determine L2BlockRef of height %v, could not get payload: %w", num, err)
 	}
-	ref, err := derive.PayloadToBlockRef(payload, &s.rollupCfg.Genesis)
+	ref, err := derive.PayloadToBlockRef(envelope.ExecutionPayload, &s.rollupCfg.Genesis)
 	if err != nil {
 		return eth.L2BlockRef{}, err
 	}
@@ -134,12 +134,12 @@ func (s *L2Client) L2BlockRefByHash(ctx context.Context, hash common.Hash) (eth.
 		return ref, nil
 	}
 
-	payload, err := s.PayloadByHash(ctx, hash)
+	envelope, err := s.PayloadByHash(ctx, hash)
 	if err != nil {
 		// w%: wrap to preserve ethereum.NotFound case
 		return eth.L2BlockRef{}, fmt.Errorf("failed to determine block-hash of hash %v, could not get payload: %w", hash, err)
 	}
-	ref, err := derive.PayloadToBlockRef(payload, &s.rollupCfg.Genesis)
+	ref, err := derive.PayloadToBlockRef(envelope.ExecutionPayload, &s.rollupCfg.Genesis)
 	if err != nil {
 		return eth.L2BlockRef{}, err
 	}
@@ -154,12 +154,12 @@ func (s *L2Client) SystemConfigByL2Hash(ctx context.Context, hash common.Hash) (
 		return ref, nil
 	}
 
-	payload, err := s.PayloadByHash(ctx, hash)
+	envelope, err := s.PayloadByHash(ctx, hash)
 	if err != nil {
 		// w%: wrap to preserve ethereum.NotFound case
 		return eth.SystemConfig{}, fmt.Errorf("failed to determine block-hash of hash %v, could not get payload: %w", hash, err)
 	}
-	cfg, err := derive.PayloadToSystemConfig(payload, s.rollupCfg)
+	cfg, err := derive.PayloadToSystemConfig(envelope.ExecutionPayload, s.rollupCfg)
 	if err != nil {
 		return eth.SystemConfig{}, err
 	}
@@ -168,29 +168,40 @@ func (s *L2Client) SystemConfigByL2Hash(ctx context.Context, hash common.Hash) (
 }
 
 func (s *L2Client) OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error) {
+	output, _, err := s.OutputV0WithProofAtBlock(ctx, blockHash, nil)
+	return output, err
+}
+
+// OutputV0WithProofAtBlock computes the output at the given block, and returns the proof of the L2ToL1MessagePasser
+// account it was computed with, including the proofs of the given storage keys.
+// The proof is verified against the state root of the block.
+func (s *L2Client) OutputV0WithProofAtBlock(ctx context.Context, blockHash common.Hash, storageKeys []common.Hash) (*eth.OutputV0, *eth.AccountResult, error) {
 	head, err := s.InfoByHash(ctx, blockHash)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get L2 block by hash: %w", err)
+		return nil, nil, fmt.Errorf("failed to get L2 block by hash: %w", err)
 	}
 	if head == nil {
-		return nil, ethereum.NotFound
+		return nil, nil, ethereum.NotFound
 	}
 
-	proof, err := s.GetProof(ctx, predeploys.L2ToL1MessagePasserAddr, []common.Hash{}, blockHash.String())
+	if storageKeys == nil {
+		storageKeys = []common.Hash{}
+	}
+	proof, err := s.GetProof(ctx, predeploys.L2ToL1MessagePasserAddr, storageKeys, blockHash.String())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get contract proof at block %s: %w", blockHash, err)
+		return nil, nil, fmt.Errorf("failed to get contract proof at block %s: %w", blockHash, err)
 	}
 	if proof == nil {
-		return nil, fmt.Errorf("proof %w", ethereum.NotFound)
+		return nil, nil, fmt.Errorf("proof %w", ethereum.NotFound)
 	}
 	// make sure that the proof (including storage hash) that we retrieved is correct by verifying it against the state-root
 	if err := proof.Verify(head.Root()); err != nil {
-		return nil, fmt.Errorf("invalid withdrawal root hash, state root was %s: %w", head.Root(), err)
+		return nil, nil, fmt.Errorf("invalid withdrawal root hash, state root was %s: %w", head.Root(), err)
 	}
 	stateRoot := head.Root()
 	return &eth.OutputV0{
 		StateRoot:                eth.Bytes32(stateRoot),
 		MessagePasserStorageRoot: eth.Bytes32(proof.StorageHash),
 		BlockHash:                blockHash,
-	}, nil
+	}, proof, nil
 }
@@ -3,11 +3,13 @@ package sources
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
@@ -26,12 +28,40 @@ func (r *RollupClient) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth
 	return output, err
 }
 
+// OutputWithProofAtBlock returns the output at the given L2 block, including the proof of the L2ToL1MessagePasser
+// account it was computed with, and the proofs of the given storage keys, such as the slots of withdrawals to prove.
+func (r *RollupClient) OutputWithProofAtBlock(ctx context.Context, blockNum uint64, storageKeys []common.Hash) (*eth.OutputResponse, error) {
+	var output *eth.OutputResponse
+	err := r.rpc.CallContext(ctx, &output, "optimism_outputAtBlock", hexutil.Uint64(blockNum),
+		&eth.OutputAtBlockOptions{IncludeProof: true, StorageKeys: storageKeys})
+	return output, err
+}
+
+func (r *RollupClient) SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (*eth.SafeHeadResponse, error) {
+	var output *eth.SafeHeadResponse
+	err := r.rpc.CallContext(ctx, &output, "optimism_safeHeadAtL1Block", hexutil.Uint64(blockNum))
+	return output, err
+}
+
+func (r *RollupClient) SequencerKeys(ctx context.Context) (*eth.SequencerKeys, error) {
+	var output *eth.SequencerKeys
+	err := r.rpc.CallContext(ctx, &output, "optimism_sequencerKeys")
+	return output, err
+}
+
 func (r *RollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	var output *eth.SyncStatus
 	err := r.rpc.CallContext(ctx, &output, "optimism_syncStatus")
 	return output, err
 }
 
+// SubscribeSyncStatus subscribes to the sync status, starting with the current status,
+// and updated whenever the unsafe, safe or finalized L2 head changes.
+// Slow subscribers miss intermediate updates. This requires a WebSocket connection to the rollup node.
+func (r *RollupClient) SubscribeSyncStatus(ctx context.Context, ch chan<- *eth.SyncStatus) (ethereum.Subscription, error) {
+	return r.rpc.EthSubscribe(ctx, ch, "heads")
+}
+
 func (r *RollupClient) RollupConfig(ctx context.Context) (*rollup.Config, error) {
 	var output *rollup.Config
 	err := r.rpc.CallContext(ctx, &output, "optimism_rollupConfig")
@@ -60,6 +90,18 @@ func (r *RollupClient) SequencerActive(ctx context.Context) (bool, error) {
 	return result, err
 }
 
+func (r *RollupClient) SequencerStatus(ctx context.Context) (*driver.SequencerStatus, error) {
+	var result *driver.SequencerStatus
+	err := r.rpc.CallContext(ctx, &result, "admin_sequencerStatus")
+	return result, err
+}
+
+func (r *RollupClient) StateSnapshot(ctx context.Context) (*driver.StateSnapshot, error) {
+	var result *driver.StateSnapshot
+	err := r.rpc.CallContext(ctx, &result, "admin_stateSnapshot")
+	return result, err
+}
+
 func (r *RollupClient) SetLogLevel(ctx context.Context, lvl log.Lvl) error {
 	return r.rpc.CallContext(ctx, nil, "admin_setLogLevel", lvl.String())
 }
@@ -0,0 +1,249 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
+)
+
+// altSyncSourceRPC is the alt-sync source label of payloads synced from a trusted L2 RPC.
+const altSyncSourceRPC = "rpc"
+
+// syncFetchAttempts is the number of attempts to fetch a payload, before the block is dropped from the schedule.
+const syncFetchAttempts = 5
+
+var errInvalidSyncPayload = errors.New("invalid sync payload")
+
+type receivePayload = func(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) error
+
+type RPCSync interface {
+	io.Closer
+	// Start starts an additional worker syncing job
+	Start() error
+	// RequestL2Range signals that the given range should be fetched, implementing the alt-sync interface.
+	RequestL2Range(ctx context.Context, start, end eth.L2BlockRef) error
+}
+
+type SyncClientMetrics interface {
+	RecordAltSyncPayloads(source string, result string, n int)
+}
+
+// SyncClient implements the driver AltSync interface, including support for fetching an open-ended chain of L2 blocks.
+type SyncClient struct {
+	*L2Client
+
+	cfg     *rollup.Config
+	log     log.Logger
+	metrics SyncClientMetrics
+
+	requests chan uint64
+
+	// mu guards the scheduling state below
+	mu sync.Mutex
+	// inFlight deduplicates block numbers that are scheduled, but not processed yet
+	inFlight map[uint64]struct{}
+	// start is the latest unsafe head the payloads are requested on top of
+	start eth.L2BlockRef
+	// end is the first block after the latest requested range, zeroed if open-ended
+	end eth.L2BlockRef
+
+	resCtx    context.Context
+	resCancel context.CancelFunc
+
+	receivePayload receivePayload
+	wg             sync.WaitGroup
+}
+
+var _ RPCSync = (*SyncClient)(nil)
+
+type SyncClientConfig struct {
+	L2ClientConfig
+
+	// MaxInFlight bounds the number of block requests that are scheduled but not yet processed.
+	MaxInFlight int
+}
+
+func SyncClientDefaultConfig(config *rollup.Config, trustRPC bool) *SyncClientConfig {
+	return &SyncClientConfig{
+		L2ClientConfig: *L2ClientDefaultConfig(config, trustRPC),
+		MaxInFlight:    128,
+	}
+}
+
+func NewSyncClient(receiver receivePayload, client client.RPC, log log.Logger, cacheMetrics caching.Metrics, metrics SyncClientMetrics, config *SyncClientConfig) (*SyncClient, error) {
+	if config.MaxInFlight < 1 {
+		return nil, fmt.Errorf("max in-flight sync requests must be at least 1, got %d", config.MaxInFlight)
+	}
+	l2Client, err := NewL2Client(client, log, cacheMetrics, &config.L2ClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	// This resource context is shared between all workers that may be started
+	resCtx, resCancel := context.WithCancel(context.Background())
+	return &SyncClient{
+		L2Client:       l2Client,
+		cfg:            config.RollupCfg,
+		log:            log,
+		metrics:        metrics,
+		requests:       make(chan uint64, config.MaxInFlight),
+		inFlight:       make(map[uint64]struct{}),
+		resCtx:         resCtx,
+		resCancel:      resCancel,
+		receivePayload: receiver,
+	}, nil
+}
+
+// Start starts the syncing background work. This may not be called after Close().
+func (s *SyncClient) Start() error {
+	s.wg.Add(1)
+	go s.eventLoop()
+	return nil
+}
+
+// Close sends a signal to close all concurrent syncing work.
+func (s *SyncClient) Close() error {
+	s.resCancel()
+	s.wg.Wait()
+	return nil
+}
+
+// RequestL2Range schedules the blocks between start and end (both exclusive) to be fetched.
+// Blocks that are already scheduled are not requested again, and no more than the max in-flight
+// number of blocks is scheduled at a time: the remainder is picked up by a later request.
+// If the end is zeroed, the range extends to the block that is expected at the current time.
+func (s *SyncClient) RequestL2Range(ctx context.Context, start, end eth.L2BlockRef) error {
+	endNum := end.Number
+	if end == (eth.L2BlockRef{}) {
+		n, err := s.cfg.TargetBlockNumber(uint64(time.Now().Unix()))
+		if err != nil {
+			return err
+		}
+		if n <= start.Number {
+			return nil
+		}
+		endNum = n + 1 // the target block itself is missing too
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.start = start
+	s.end = end
+
+	scheduled := 0
+schedule:
+	for num := start.Number + 1; num < endNum; num++ {
+		if _, ok := s.inFlight[num]; ok {
+			continue
+		}
+		select {
+		case s.requests <- num:
+			s.inFlight[num] = struct{}{}
+			scheduled++
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			s.log.Debug("max in-flight sync requests reached, not scheduling more blocks", "start", start, "end", endNum, "current", num)
+			break schedule
+		}
+	}
+	if scheduled > 0 {
+		s.log.Info("Scheduling to fetch missing payloads from backup RPC", "start", start, "end", endNum, "scheduled", scheduled)
+		s.metrics.RecordAltSyncPayloads(altSyncSourceRPC, metrics.AltSyncRequested, scheduled)
+	}
+	return nil
+}
+
+// eventLoop fetches the scheduled blocks one at a time, in ascending order,
+// so the payloads are validated against and inserted after their parent.
+func (s *SyncClient) eventLoop() {
+	defer s.wg.Done()
+	s.log.Info("Starting sync client event loop")
+
+	var prev *eth.ExecutionPayload
+	for {
+		select {
+		case <-s.resCtx.Done():
+			s.log.Debug("Shutting down RPC sync worker")
+			return
+		case num := <-s.requests:
+			payload, err := s.syncBlock(num, prev)
+			s.mu.Lock()
+			delete(s.inFlight, num)
+			s.mu.Unlock()
+			if errors.Is(err, errInvalidSyncPayload) {
+				s.log.Warn("Dropping invalid payload from backup RPC", "num", num, "err", err)
+				s.metrics.RecordAltSyncPayloads(altSyncSourceRPC, metrics.AltSyncInvalid, 1)
+				prev = nil // the RPC may have reorged, do not verify against what it served before
+			} else if err != nil {
+				if !errors.Is(err, context.Canceled) {
+					s.log.Warn("Failed to sync payload from backup RPC", "num", num, "err", err)
+				}
+			} else if payload != nil {
+				s.metrics.RecordAltSyncPayloads(altSyncSourceRPC, metrics.AltSyncReceived, 1)
+				prev = payload
+			}
+		}
+	}
+}
+
+// syncBlock fetches and verifies the block with the given number, and sends it to the receiver.
+// No payload and no error is returned if the block does not have to be synced anymore.
+func (s *SyncClient) syncBlock(num uint64, prev *eth.ExecutionPayload) (*eth.ExecutionPayload, error) {
+	s.mu.Lock()
+	start, end := s.start, s.end
+	s.mu.Unlock()
+	if num <= start.Number {
+		// the unsafe head progressed past this block while it was scheduled
+		return nil, nil
+	}
+
+	envelope, err := retry.Do(s.resCtx, syncFetchAttempts, retry.Exponential(), func() (*eth.ExecutionPayloadEnvelope, error) {
+		ctx, cancel := context.WithTimeout(s.resCtx, 5*time.Second)
+		defer cancel()
+		envelope, err := s.PayloadByNumber(ctx, num)
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, nil // the block is not available yet, do not retry
+		}
+		return envelope, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if envelope == nil {
+		s.log.Debug("Payload is not available from backup RPC", "num", num)
+		return nil, nil
+	}
+	payload := envelope.ExecutionPayload
+
+	// The payload itself is verified by the L2 client, unless the RPC is trusted.
+	// Verify that it connects to the chain we requested it for.
+	if num == start.Number+1 && payload.ParentHash != start.Hash {
+		return nil, fmt.Errorf("%w: payload %s does not build on unsafe head %s", errInvalidSyncPayload, payload.ID(), start)
+	}
+	if prev != nil && uint64(prev.BlockNumber)+1 == num && payload.ParentHash != prev.BlockHash {
+		return nil, fmt.Errorf("%w: payload %s does not build on previous payload %s", errInvalidSyncPayload, payload.ID(), prev.ID())
+	}
+	if end != (eth.L2BlockRef{}) && num+1 == end.Number && payload.BlockHash != end.ParentHash {
+		return nil, fmt.Errorf("%w: payload %s is not the parent of the sync target %s", errInvalidSyncPayload, payload.ID(), end)
+	}
+
+	if err := s.receivePayload(s.resCtx, "", envelope); err != nil {
+		return nil, fmt.Errorf("failed to insert payload %s: %w", payload.ID(), err)
+	}
+	return payload, nil
+}
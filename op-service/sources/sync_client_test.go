@@ -0,0 +1,201 @@
+package sources
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// syncTestRPC serves blocks by number from a fixed chain.
+type syncTestRPC struct {
+	blocks map[uint64]*rpcBlock
+}
+
+func (r *syncTestRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	if method != "eth_getBlockByNumber" {
+		return ethereum.NotFound
+	}
+	num, err := hexutil.DecodeUint64(args[0].(string))
+	if err != nil {
+		return err
+	}
+	*result.(**rpcBlock) = r.blocks[num]
+	return nil
+}
+
+func (r *syncTestRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	panic("not implemented")
+}
+
+func (r *syncTestRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	panic("not implemented")
+}
+
+func (r *syncTestRPC) Close() {}
+
+func syncTestChain(n uint64) map[uint64]*rpcBlock {
+	blocks := make(map[uint64]*rpcBlock)
+	parent := common.Hash{}
+	for i := uint64(0); i < n; i++ {
+		b := &rpcBlock{rpcHeader: rpcHeader{
+			ParentHash: parent,
+			UncleHash:  types.EmptyUncleHash,
+			Number:     hexutil.Uint64(i),
+			Time:       hexutil.Uint64(i * 2),
+			BaseFee:    (*hexutil.Big)(big.NewInt(1)),
+			Hash:       common.Hash{byte(i), 0xaa},
+		}}
+		blocks[i] = b
+		parent = b.Hash
+	}
+	return blocks
+}
+
+func syncTestRef(b *rpcBlock) eth.L2BlockRef {
+	return eth.L2BlockRef{Hash: b.Hash, Number: uint64(b.Number), ParentHash: b.ParentHash, Time: uint64(b.Time)}
+}
+
+type syncTestMetrics struct {
+	mu      sync.Mutex
+	results map[string]int
+}
+
+func (m *syncTestMetrics) RecordAltSyncPayloads(source string, result string, n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results[result] += n
+}
+
+func (m *syncTestMetrics) count(result string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.results[result]
+}
+
+type syncTestReceiver struct {
+	mu       sync.Mutex
+	received []uint64
+}
+
+func (r *syncTestReceiver) receive(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, uint64(envelope.ExecutionPayload.BlockNumber))
+	return nil
+}
+
+func (r *syncTestReceiver) numbers() []uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]uint64(nil), r.received...)
+}
+
+func setupSyncClient(t *testing.T, blocks map[uint64]*rpcBlock, maxInFlight int) (*SyncClient, *syncTestReceiver, *syncTestMetrics) {
+	cfg := &rollup.Config{BlockTime: 2, SeqWindowSize: 10}
+	syncCfg := SyncClientDefaultConfig(cfg, true)
+	syncCfg.MaxInFlight = maxInFlight
+	rcv := &syncTestReceiver{}
+	m := &syncTestMetrics{results: make(map[string]int)}
+	cl, err := NewSyncClient(rcv.receive, &syncTestRPC{blocks: blocks}, testlog.Logger(t, log.LvlError), nil, m, syncCfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, cl.Close())
+	})
+	return cl, rcv, m
+}
+
+func TestSyncClientGap(t *testing.T) {
+	blocks := syncTestChain(10)
+	cl, rcv, m := setupSyncClient(t, blocks, 128)
+	ctx := context.Background()
+
+	// Overlapping requests, before the client processes anything, are deduplicated.
+	require.NoError(t, cl.RequestL2Range(ctx, syncTestRef(blocks[2]), syncTestRef(blocks[8])))
+	require.NoError(t, cl.RequestL2Range(ctx, syncTestRef(blocks[2]), syncTestRef(blocks[8])))
+	require.Equal(t, 5, m.count(metrics.AltSyncRequested))
+
+	require.NoError(t, cl.Start())
+	require.Eventually(t, func() bool {
+		return m.count(metrics.AltSyncReceived) == 5
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []uint64{3, 4, 5, 6, 7}, rcv.numbers(), "blocks are inserted in order")
+	require.Zero(t, m.count(metrics.AltSyncInvalid))
+}
+
+func TestSyncClientMaxInFlight(t *testing.T) {
+	blocks := syncTestChain(10)
+	cl, rcv, m := setupSyncClient(t, blocks, 2)
+	ctx := context.Background()
+
+	require.NoError(t, cl.RequestL2Range(ctx, syncTestRef(blocks[2]), syncTestRef(blocks[8])))
+	require.Equal(t, 2, m.count(metrics.AltSyncRequested), "bounded by the in-flight window")
+
+	require.NoError(t, cl.Start())
+	require.Eventually(t, func() bool {
+		return m.count(metrics.AltSyncReceived) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	// later requests pick up the remainder, on top of the progressed head
+	require.Eventually(t, func() bool {
+		received := rcv.numbers()
+		head := blocks[received[len(received)-1]]
+		require.NoError(t, cl.RequestL2Range(ctx, syncTestRef(head), syncTestRef(blocks[8])))
+		return m.count(metrics.AltSyncReceived) == 5
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []uint64{3, 4, 5, 6, 7}, rcv.numbers())
+}
+
+func TestSyncClientInvalid(t *testing.T) {
+	blocks := syncTestChain(10)
+	// the RPC serves a block that does not build on the previous block
+	tampered := *blocks[5]
+	tampered.ParentHash = common.Hash{0xff}
+	served := make(map[uint64]*rpcBlock)
+	for k, v := range blocks {
+		served[k] = v
+	}
+	served[5] = &tampered
+	cl, rcv, m := setupSyncClient(t, served, 128)
+	ctx := context.Background()
+
+	require.NoError(t, cl.RequestL2Range(ctx, syncTestRef(blocks[2]), syncTestRef(blocks[8])))
+	require.NoError(t, cl.Start())
+	require.Eventually(t, func() bool {
+		return m.count(metrics.AltSyncReceived)+m.count(metrics.AltSyncInvalid) == 5
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 1, m.count(metrics.AltSyncInvalid))
+	require.Equal(t, []uint64{3, 4, 6, 7}, rcv.numbers())
+}
+
+func TestSyncClientWrongStart(t *testing.T) {
+	blocks := syncTestChain(10)
+	cl, rcv, m := setupSyncClient(t, blocks, 128)
+	ctx := context.Background()
+
+	// the unsafe head we request a range for is not the parent of what the RPC serves
+	start := syncTestRef(blocks[2])
+	start.Hash = common.Hash{0xff}
+	require.NoError(t, cl.RequestL2Range(ctx, start, syncTestRef(blocks[5])))
+	require.NoError(t, cl.Start())
+	require.Eventually(t, func() bool {
+		return m.count(metrics.AltSyncReceived)+m.count(metrics.AltSyncInvalid) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 1, m.count(metrics.AltSyncInvalid))
+	require.Equal(t, []uint64{4}, rcv.numbers())
+}
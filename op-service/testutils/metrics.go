@@ -1,6 +1,8 @@
 package testutils
 
 import (
+	"time"
+
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -12,6 +14,8 @@ type TestDerivationMetrics struct {
 	FnRecordL2Ref             func(name string, ref eth.L2BlockRef)
 	FnRecordUnsafePayloads    func(length uint64, memSize uint64, next eth.BlockID)
 	FnRecordChannelInputBytes func(inputCompressedBytes int)
+	FnRecordUnsafeReorg       func(depth uint64)
+	FnRecordStageTime         func(stage string, duration time.Duration)
 }
 
 func (t *TestDerivationMetrics) RecordL1ReorgDepth(d uint64) {
@@ -56,14 +60,62 @@ func (t *TestDerivationMetrics) RecordFrame() {
 func (n *TestDerivationMetrics) RecordDerivedBatches(batchType string) {
 }
 
+func (t *TestDerivationMetrics) RecordUnsafeReorg(depth uint64) {
+	if t.FnRecordUnsafeReorg != nil {
+		t.FnRecordUnsafeReorg(depth)
+	}
+}
+
+func (t *TestDerivationMetrics) RecordDerivationStageTime(stage string, duration time.Duration) {
+	if t.FnRecordStageTime != nil {
+		t.FnRecordStageTime(stage, duration)
+	}
+}
+
 type TestRPCMetrics struct{}
 
 func (n *TestRPCMetrics) RecordRPCServerRequest(method string) func() {
 	return func() {}
 }
 
+func (n *TestRPCMetrics) RecordRPCServerNamespaceRequest(namespace string) func(failed bool) {
+	return func(failed bool) {}
+}
+
 func (n *TestRPCMetrics) RecordRPCClientRequest(method string) func(err error) {
 	return func(err error) {}
 }
 
 func (n *TestRPCMetrics) RecordRPCClientResponse(method string, err error) {}
+
+// TestSequencerMetrics implements the metrics used by the sequencer as no-op operations.
+// Optionally a test may hook into the metrics
+type TestSequencerMetrics struct {
+	FnRecordSequencerDrift              func(drift time.Duration)
+	FnRecordSequencerDriftExceededBlock func()
+	FnRecordSequencerBuildPath          func(path string)
+}
+
+func (t *TestSequencerMetrics) RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID) {
+}
+
+func (t *TestSequencerMetrics) RecordSequencerReset() {
+}
+
+func (t *TestSequencerMetrics) RecordSequencerDrift(drift time.Duration) {
+	if t.FnRecordSequencerDrift != nil {
+		t.FnRecordSequencerDrift(drift)
+	}
+}
+
+func (t *TestSequencerMetrics) RecordSequencerDriftExceededBlock() {
+	if t.FnRecordSequencerDriftExceededBlock != nil {
+		t.FnRecordSequencerDriftExceededBlock()
+	}
+}
+
+func (t *TestSequencerMetrics) RecordSequencerBuildPath(path string) {
+	if t.FnRecordSequencerBuildPath != nil {
+		t.FnRecordSequencerBuildPath(path)
+	}
+}
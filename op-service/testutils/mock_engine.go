@@ -3,6 +3,8 @@ package testutils
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -10,12 +12,12 @@ type MockEngine struct {
 	MockL2Client
 }
 
-func (m *MockEngine) GetPayload(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error) {
-	out := m.Mock.Called(payloadId)
-	return out.Get(0).(*eth.ExecutionPayload), out.Error(1)
+func (m *MockEngine) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error) {
+	out := m.Mock.Called(payloadInfo.ID)
+	return out.Get(0).(*eth.ExecutionPayloadEnvelope), out.Error(1)
 }
 
-func (m *MockEngine) ExpectGetPayload(payloadId eth.PayloadID, payload *eth.ExecutionPayload, err error) {
+func (m *MockEngine) ExpectGetPayload(payloadId eth.PayloadID, payload *eth.ExecutionPayloadEnvelope, err error) {
 	m.Mock.On("GetPayload", payloadId).Once().Return(payload, err)
 }
 
@@ -28,11 +30,11 @@ func (m *MockEngine) ExpectForkchoiceUpdate(state *eth.ForkchoiceState, attr *et
 	m.Mock.On("ForkchoiceUpdate", state, attr).Once().Return(result, err)
 }
 
-func (m *MockEngine) NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error) {
-	out := m.Mock.Called(payload)
+func (m *MockEngine) NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error) {
+	out := m.Mock.Called(payload, parentBeaconBlockRoot)
 	return out.Get(0).(*eth.PayloadStatusV1), out.Error(1)
 }
 
-func (m *MockEngine) ExpectNewPayload(payload *eth.ExecutionPayload, result *eth.PayloadStatusV1, err error) {
-	m.Mock.On("NewPayload", payload).Once().Return(result, err)
+func (m *MockEngine) ExpectNewPayload(payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash, result *eth.PayloadStatusV1, err error) {
+	m.Mock.On("NewPayload", payload, parentBeaconBlockRoot).Once().Return(result, err)
 }
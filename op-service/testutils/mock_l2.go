@@ -55,3 +55,12 @@ func (m *MockL2Client) OutputV0AtBlock(ctx context.Context, blockHash common.Has
 func (m *MockL2Client) ExpectOutputV0AtBlock(blockHash common.Hash, output *eth.OutputV0, err error) {
 	m.Mock.On("OutputV0AtBlock", blockHash).Once().Return(output, err)
 }
+
+func (m *MockL2Client) OutputV0WithProofAtBlock(ctx context.Context, blockHash common.Hash, storageKeys []common.Hash) (*eth.OutputV0, *eth.AccountResult, error) {
+	out := m.Mock.Called(blockHash, storageKeys)
+	return out.Get(0).(*eth.OutputV0), out.Get(1).(*eth.AccountResult), out.Error(2)
+}
+
+func (m *MockL2Client) ExpectOutputV0WithProofAtBlock(blockHash common.Hash, storageKeys []common.Hash, output *eth.OutputV0, proof *eth.AccountResult, err error) {
+	m.Mock.On("OutputV0WithProofAtBlock", blockHash, storageKeys).Once().Return(output, proof, err)
+}
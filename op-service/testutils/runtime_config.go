@@ -1,11 +1,27 @@
 package testutils
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
 
 type MockRuntimeConfig struct {
 	P2PSeqAddress common.Address
+
+	// P2PSeqSecondary is accepted as p2p sequencer key until P2PSeqRotationTime
+	P2PSeqSecondary    common.Address
+	P2PSeqRotationTime uint64
 }
 
 func (m *MockRuntimeConfig) P2PSequencerAddress() common.Address {
 	return m.P2PSeqAddress
 }
+
+func (m *MockRuntimeConfig) P2PSequencerKeys() eth.SequencerKeys {
+	return eth.SequencerKeys{
+		Primary:      m.P2PSeqAddress,
+		Secondary:    m.P2PSeqSecondary,
+		RotationTime: m.P2PSeqRotationTime,
+	}
+}
@@ -0,0 +1,77 @@
+package txmgr
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxBroadcaster is an endpoint that transactions are broadcast to.
+type TxBroadcaster interface {
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// BroadcastEndpoint is a broadcast-only endpoint that published transactions are broadcast to, in addition to
+// the backend of the tx manager. The name identifies the endpoint in logs and metrics.
+type BroadcastEndpoint struct {
+	Name   string
+	Client TxBroadcaster
+}
+
+// broadcastEndpointName returns the host of the RPC URL, so that credentials in the URL aren't logged.
+func broadcastEndpointName(rpcURL string) string {
+	u, err := url.Parse(rpcURL)
+	if err != nil || u.Host == "" {
+		return "invalid"
+	}
+	return u.Host
+}
+
+// sendTransaction sends the tx to the backend, and to all broadcast endpoints concurrently. It succeeds if any
+// of them accepts the tx. Otherwise, it returns the error of the backend, after all broadcasts failed or
+// timed out.
+func (m *SimpleTxManager) sendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if len(m.cfg.BroadcastEndpoints) == 0 {
+		cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+		defer cancel()
+		return m.backend.SendTransaction(cCtx, tx)
+	}
+
+	type result struct {
+		primary bool
+		err     error
+	}
+	results := make(chan result, 1+len(m.cfg.BroadcastEndpoints))
+	go func() {
+		cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+		defer cancel()
+		results <- result{primary: true, err: m.backend.SendTransaction(cCtx, tx)}
+	}()
+	for _, endpoint := range m.cfg.BroadcastEndpoints {
+		endpoint := endpoint
+		go func() {
+			cCtx, cancel := context.WithTimeout(ctx, m.cfg.BroadcastTimeout)
+			defer cancel()
+			err := endpoint.Client.SendTransaction(cCtx, tx)
+			if err != nil && !errStringMatch(err, txpool.ErrAlreadyKnown) {
+				m.l.Debug("Failed to broadcast transaction", "endpoint", endpoint.Name, "hash", tx.Hash(), "err", err)
+				m.metr.BroadcastError(endpoint.Name)
+			}
+			results <- result{err: err}
+		}()
+	}
+
+	var primaryErr error
+	for i := 0; i < cap(results); i++ {
+		res := <-results
+		if res.err == nil {
+			return nil
+		}
+		if res.primary {
+			primaryErr = res.err
+		}
+	}
+	return primaryErr
+}
@@ -0,0 +1,142 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+// stubBroadcaster is a broadcast endpoint that sends the txs with its send function, or that is down
+// and never answers if it has none.
+type stubBroadcaster struct {
+	mu   sync.Mutex
+	send sendTransactionFunc
+	sent int
+}
+
+func (b *stubBroadcaster) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.mu.Lock()
+	b.sent++
+	send := b.send
+	b.mu.Unlock()
+	if send == nil {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return send(ctx, tx)
+}
+
+// broadcastMetrics counts the broadcast errors by endpoint.
+type broadcastMetrics struct {
+	metrics.NoopTxMetrics
+	mu     sync.Mutex
+	errors map[string]int
+}
+
+func (m *broadcastMetrics) BroadcastError(endpoint string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors[endpoint]++
+}
+
+func (m *broadcastMetrics) errorCount(endpoint string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.errors[endpoint]
+}
+
+func newBroadcastTestHarness(t *testing.T, endpoints map[string]*stubBroadcaster) (*testHarness, *broadcastMetrics) {
+	conf := configWithNumConfs(1)
+	conf.NetworkTimeout = time.Second
+	conf.BroadcastTimeout = 50 * time.Millisecond
+	for name, endpoint := range endpoints {
+		conf.BroadcastEndpoints = append(conf.BroadcastEndpoints, BroadcastEndpoint{Name: name, Client: endpoint})
+	}
+	h := newTestHarnessWithConfig(t, conf)
+	metr := &broadcastMetrics{errors: make(map[string]int)}
+	h.mgr.metr = metr
+	return h, metr
+}
+
+// TestTxMgrBroadcastEndpointDown asserts that a tx is published without waiting for a broadcast endpoint
+// that is down beyond the broadcast timeout, and that the errors are recorded by endpoint.
+func TestTxMgrBroadcastEndpointDown(t *testing.T) {
+	t.Parallel()
+
+	up := &stubBroadcaster{send: func(ctx context.Context, tx *types.Transaction) error {
+		return nil
+	}}
+	down := &stubBroadcaster{}
+	h, metr := newBroadcastTestHarness(t, map[string]*stubBroadcaster{"up": up, "down": down})
+	primaryErr := errors.New("connection refused")
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		// the tx is published by a broadcast endpoint if the primary is down
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return primaryErr
+	})
+
+	tx, err := h.mgr.craftTx(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	start := time.Now()
+	require.NoError(t, h.mgr.sendTransaction(context.Background(), tx))
+	require.Less(t, time.Since(start), h.cfg.BroadcastTimeout, "the publish doesn't wait on the endpoint that is down")
+
+	// the publish fails if only the endpoint that is down could accept it, after the broadcast timeout
+	up.mu.Lock()
+	up.send = func(ctx context.Context, tx *types.Transaction) error {
+		return errors.New("internal error")
+	}
+	up.mu.Unlock()
+	start = time.Now()
+	require.ErrorIs(t, h.mgr.sendTransaction(context.Background(), tx), primaryErr)
+	require.GreaterOrEqual(t, time.Since(start), h.cfg.BroadcastTimeout)
+	require.Less(t, time.Since(start), h.cfg.NetworkTimeout)
+	require.Equal(t, 2, down.sent)
+	require.Equal(t, 1, metr.errorCount("up"))
+	require.Eventually(t, func() bool { return metr.errorCount("down") == 2 }, time.Second, time.Millisecond)
+}
+
+// TestTxMgrBroadcastAllUnderpriced asserts that the fees of a tx that all endpoints reject as underpriced
+// are bumped until it is accepted.
+func TestTxMgrBroadcastAllUnderpriced(t *testing.T) {
+	t.Parallel()
+
+	var h *testHarness
+	underpriced := func(ctx context.Context, tx *types.Transaction) error {
+		if h.gasPricer.shouldMine(tx.GasFeeCap()) {
+			return nil
+		}
+		return txpool.ErrUnderpriced
+	}
+	endpoints := map[string]*stubBroadcaster{
+		"a": {send: underpriced},
+		"b": {send: underpriced},
+	}
+	h, metr := newBroadcastTestHarness(t, endpoints)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		if err := underpriced(ctx, tx); err != nil {
+			return err
+		}
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
+	// the fees are bumped twice before the tx is accepted
+	require.Equal(t, 2, metr.errorCount("a"))
+	require.Equal(t, 2, metr.errorCount("b"))
+}
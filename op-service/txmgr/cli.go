@@ -8,11 +8,13 @@ import (
 	"time"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	opcrypto "github.com/ethereum-optimism/optimism/op-service/crypto"
 	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli/v2"
 )
 
@@ -32,6 +34,13 @@ const (
 	TxSendTimeoutFlagName             = "txmgr.send-timeout"
 	TxNotInMempoolTimeoutFlagName     = "txmgr.not-in-mempool-timeout"
 	ReceiptQueryIntervalFlagName      = "txmgr.receipt-query-interval"
+	MaxGasTipCapFlagName              = "txmgr.max-gas-tip-cap"
+	MaxGasFeeCapFlagName              = "txmgr.max-gas-fee-cap"
+	MaxFeeBumpsFlagName               = "txmgr.max-fee-bumps"
+	NonceResyncIntervalFlagName       = "txmgr.nonce-resync-interval"
+	GasLimitBufferFlagName            = "txmgr.gas-limit-buffer"
+	BroadcastRPCsFlagName             = "txmgr.broadcast-rpcs"
+	BroadcastTimeoutFlagName          = "txmgr.broadcast-timeout"
 )
 
 var (
@@ -58,6 +67,8 @@ type DefaultFlagValues struct {
 	TxSendTimeout             time.Duration
 	TxNotInMempoolTimeout     time.Duration
 	ReceiptQueryInterval      time.Duration
+	NonceResyncInterval       time.Duration
+	BroadcastTimeout          time.Duration
 }
 
 var (
@@ -70,6 +81,8 @@ var (
 		TxSendTimeout:             0 * time.Second,
 		TxNotInMempoolTimeout:     2 * time.Minute,
 		ReceiptQueryInterval:      12 * time.Second,
+		NonceResyncInterval:       time.Minute,
+		BroadcastTimeout:          2 * time.Second,
 	}
 	DefaultChallengerFlagValues = DefaultFlagValues{
 		NumConfirmations:          uint64(3),
@@ -80,6 +93,8 @@ var (
 		TxSendTimeout:             2 * time.Minute,
 		TxNotInMempoolTimeout:     1 * time.Minute,
 		ReceiptQueryInterval:      12 * time.Second,
+		NonceResyncInterval:       time.Minute,
+		BroadcastTimeout:          2 * time.Second,
 	}
 )
 
@@ -155,6 +170,43 @@ func CLIFlagsWithDefaults(envPrefix string, defaults DefaultFlagValues) []cli.Fl
 			Value:   defaults.ReceiptQueryInterval,
 			EnvVars: prefixEnvVars("TXMGR_RECEIPT_QUERY_INTERVAL"),
 		},
+		&cli.DurationFlag{
+			Name:    NonceResyncIntervalFlagName,
+			Usage:   "Interval at which the nonce is resynced with the pending nonce of the account while no transactions are sent. 0 to disable.",
+			Value:   defaults.NonceResyncInterval,
+			EnvVars: prefixEnvVars("TXMGR_NONCE_RESYNC_INTERVAL"),
+		},
+		&cli.Float64Flag{
+			Name:    MaxGasTipCapFlagName,
+			Usage:   "Maximum gas tip cap in GWei of the transactions, above which fees are not bumped. 0 to disable.",
+			EnvVars: prefixEnvVars("TXMGR_MAX_GAS_TIP_CAP"),
+		},
+		&cli.Float64Flag{
+			Name:    MaxGasFeeCapFlagName,
+			Usage:   "Maximum gas fee cap in GWei of the transactions, above which fees are not bumped. 0 to disable.",
+			EnvVars: prefixEnvVars("TXMGR_MAX_GAS_FEE_CAP"),
+		},
+		&cli.Uint64Flag{
+			Name:    MaxFeeBumpsFlagName,
+			Usage:   "Maximum number of fee bumps of a transaction, after which the published transactions are waited on. 0 to disable.",
+			EnvVars: prefixEnvVars("TXMGR_MAX_FEE_BUMPS"),
+		},
+		&cli.Uint64Flag{
+			Name:    GasLimitBufferFlagName,
+			Usage:   "Percentage that is added to the gas estimates of the transactions. 0 to disable.",
+			EnvVars: prefixEnvVars("TXMGR_GAS_LIMIT_BUFFER"),
+		},
+		&cli.StringSliceFlag{
+			Name:    BroadcastRPCsFlagName,
+			Usage:   "L1 RPC URLs that published transactions are broadcast to as well, in addition to the L1 RPC. They are not used for any queries.",
+			EnvVars: prefixEnvVars("TXMGR_BROADCAST_RPCS"),
+		},
+		&cli.DurationFlag{
+			Name:    BroadcastTimeoutFlagName,
+			Usage:   "Timeout for broadcasting a transaction to a single broadcast RPC",
+			Value:   defaults.BroadcastTimeout,
+			EnvVars: prefixEnvVars("TXMGR_BROADCAST_TIMEOUT"),
+		},
 	}, opsigner.CLIFlags(envPrefix)...)
 }
 
@@ -174,6 +226,18 @@ type CLIConfig struct {
 	NetworkTimeout            time.Duration
 	TxSendTimeout             time.Duration
 	TxNotInMempoolTimeout     time.Duration
+	NonceResyncInterval       time.Duration
+	// MaxGasTipCap and MaxGasFeeCap are the maximum fees in GWei, and MaxFeeBumps the maximum
+	// number of fee bumps, of a transaction. 0 disables the limit.
+	MaxGasTipCap float64
+	MaxGasFeeCap float64
+	MaxFeeBumps  uint64
+	// GasLimitBuffer is the percentage that is added to gas estimates.
+	GasLimitBuffer uint64
+	// BroadcastRPCURLs are the URLs of the RPCs that published transactions are broadcast to as well,
+	// with BroadcastTimeout for every broadcast.
+	BroadcastRPCURLs []string
+	BroadcastTimeout time.Duration
 }
 
 func NewCLIConfig(l1RPCURL string, defaults DefaultFlagValues) CLIConfig {
@@ -187,6 +251,8 @@ func NewCLIConfig(l1RPCURL string, defaults DefaultFlagValues) CLIConfig {
 		TxSendTimeout:             defaults.TxSendTimeout,
 		TxNotInMempoolTimeout:     defaults.TxNotInMempoolTimeout,
 		ReceiptQueryInterval:      defaults.ReceiptQueryInterval,
+		NonceResyncInterval:       defaults.NonceResyncInterval,
+		BroadcastTimeout:          defaults.BroadcastTimeout,
 		SignerCLIConfig:           opsigner.NewCLIConfig(),
 	}
 }
@@ -216,6 +282,18 @@ func (m CLIConfig) Check() error {
 	if m.SafeAbortNonceTooLowCount == 0 {
 		return errors.New("SafeAbortNonceTooLowCount must not be 0")
 	}
+	if m.NonceResyncInterval < 0 {
+		return errors.New("NonceResyncInterval must not be negative")
+	}
+	if len(m.BroadcastRPCURLs) > 0 && m.BroadcastTimeout <= 0 {
+		return errors.New("must provide BroadcastTimeout with broadcast RPCs")
+	}
+	if m.MaxGasTipCap < 0 || m.MaxGasFeeCap < 0 {
+		return errors.New("max gas tip and fee caps cannot be negative")
+	}
+	if m.MaxGasTipCap > 0 && m.MaxGasFeeCap > 0 && m.MaxGasTipCap > m.MaxGasFeeCap {
+		return errors.New("max gas tip cap cannot be higher than the max gas fee cap")
+	}
 	if err := m.SignerCLIConfig.Check(); err != nil {
 		return err
 	}
@@ -239,10 +317,22 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		NetworkTimeout:            ctx.Duration(NetworkTimeoutFlagName),
 		TxSendTimeout:             ctx.Duration(TxSendTimeoutFlagName),
 		TxNotInMempoolTimeout:     ctx.Duration(TxNotInMempoolTimeoutFlagName),
+		NonceResyncInterval:       ctx.Duration(NonceResyncIntervalFlagName),
+		MaxGasTipCap:              ctx.Float64(MaxGasTipCapFlagName),
+		MaxGasFeeCap:              ctx.Float64(MaxGasFeeCapFlagName),
+		MaxFeeBumps:               ctx.Uint64(MaxFeeBumpsFlagName),
+		GasLimitBuffer:            ctx.Uint64(GasLimitBufferFlagName),
+		BroadcastRPCURLs:          ctx.StringSlice(BroadcastRPCsFlagName),
+		BroadcastTimeout:          ctx.Duration(BroadcastTimeoutFlagName),
 	}
 }
 
 func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
+	return newConfig(cfg, l, nil)
+}
+
+// newConfig creates the config, and records the calls to a remote signer with the signer metrics, if not nil.
+func newConfig(cfg CLIConfig, l log.Logger, signerMetrics opsigner.Metricer) (Config, error) {
 	if err := cfg.Check(); err != nil {
 		return Config{}, fmt.Errorf("invalid config: %w", err)
 	}
@@ -261,6 +351,17 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 		return Config{}, fmt.Errorf("could not dial fetch L1 chain ID: %w", err)
 	}
 
+	broadcastEndpoints := make([]BroadcastEndpoint, 0, len(cfg.BroadcastRPCURLs))
+	for _, rpcURL := range cfg.BroadcastRPCURLs {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.NetworkTimeout)
+		client, err := ethclient.DialContext(ctx, rpcURL)
+		cancel()
+		if err != nil {
+			return Config{}, fmt.Errorf("could not dial broadcast RPC %s: %w", broadcastEndpointName(rpcURL), err)
+		}
+		broadcastEndpoints = append(broadcastEndpoints, BroadcastEndpoint{Name: broadcastEndpointName(rpcURL), Client: client})
+	}
+
 	// Allow backwards compatible ways of specifying the HD path
 	hdPath := cfg.HDPath
 	if hdPath == "" && cfg.SequencerHDPath != "" {
@@ -269,11 +370,23 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 		hdPath = cfg.L2OutputHDPath
 	}
 
-	signerFactory, from, err := opcrypto.SignerFactoryFromConfig(l, cfg.PrivateKey, cfg.Mnemonic, hdPath, cfg.SignerCLIConfig)
+	var signerOpts []opsigner.ClientOption
+	if signerMetrics != nil {
+		signerOpts = append(signerOpts, opsigner.WithMetrics(signerMetrics))
+	}
+	signerFactory, from, err := opcrypto.SignerFactoryFromConfig(l, cfg.PrivateKey, cfg.Mnemonic, hdPath, cfg.SignerCLIConfig, signerOpts...)
 	if err != nil {
 		return Config{}, fmt.Errorf("could not init signer: %w", err)
 	}
 
+	var maxGasTipCap, maxGasFeeCap *big.Int
+	if cfg.MaxGasTipCap > 0 {
+		maxGasTipCap = gweiToWei(cfg.MaxGasTipCap)
+	}
+	if cfg.MaxGasFeeCap > 0 {
+		maxGasFeeCap = gweiToWei(cfg.MaxGasFeeCap)
+	}
+
 	return Config{
 		Backend:                   l1,
 		ResubmissionTimeout:       cfg.ResubmissionTimeout,
@@ -285,11 +398,23 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 		ReceiptQueryInterval:      cfg.ReceiptQueryInterval,
 		NumConfirmations:          cfg.NumConfirmations,
 		SafeAbortNonceTooLowCount: cfg.SafeAbortNonceTooLowCount,
+		NonceResyncInterval:       cfg.NonceResyncInterval,
 		Signer:                    signerFactory(chainID),
 		From:                      from,
+		MaxGasTipCap:              maxGasTipCap,
+		MaxGasFeeCap:              maxGasFeeCap,
+		MaxFeeBumps:               cfg.MaxFeeBumps,
+		GasLimitBuffer:            cfg.GasLimitBuffer,
+		BroadcastEndpoints:        broadcastEndpoints,
+		BroadcastTimeout:          cfg.BroadcastTimeout,
 	}, nil
 }
 
+func gweiToWei(gwei float64) *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(params.GWei)).Int(nil)
+	return wei
+}
+
 // Config houses parameters for altering the behavior of a SimpleTxManager.
 type Config struct {
 	Backend ETHBackend
@@ -331,9 +456,33 @@ type Config struct {
 	// confirmation.
 	SafeAbortNonceTooLowCount uint64
 
+	// NonceResyncInterval is the interval at which the nonce is resynced with the pending nonce of the
+	// account while no transactions are sent, if non-zero.
+	NonceResyncInterval time.Duration
+
 	// Signer is used to sign transactions when the gas price is increased.
 	Signer opcrypto.SignerFn
 	From   common.Address
+
+	// MaxGasTipCap and MaxGasFeeCap are the maximum fees (in wei) of the transactions, if not nil.
+	// Fees are not bumped beyond them, and the published transactions are waited on instead.
+	MaxGasTipCap *big.Int
+	MaxGasFeeCap *big.Int
+
+	// MaxFeeBumps is the maximum number of fee bumps of a transaction, if non-zero.
+	MaxFeeBumps uint64
+
+	// GasLimitBuffer is the percentage that is added to the gas estimates of the transactions,
+	// so that they don't run out of gas if the state changes until they are included.
+	GasLimitBuffer uint64
+
+	// BroadcastEndpoints are the endpoints that published transactions are broadcast to as well, in addition
+	// to the Backend, with BroadcastTimeout for every broadcast. They are not used for any queries.
+	BroadcastEndpoints []BroadcastEndpoint
+	BroadcastTimeout   time.Duration
+
+	// Clock times the resubmissions and receipt queries of the sent txs. The system clock is used if nil.
+	Clock clock.Clock
 }
 
 func (m Config) Check() error {
@@ -367,5 +516,11 @@ func (m Config) Check() error {
 	if m.ChainID == nil {
 		return errors.New("must provide the ChainID")
 	}
+	if m.MaxGasTipCap != nil && m.MaxGasFeeCap != nil && m.MaxGasTipCap.Cmp(m.MaxGasFeeCap) > 0 {
+		return errors.New("MaxGasTipCap must not be higher than MaxGasFeeCap")
+	}
+	if len(m.BroadcastEndpoints) > 0 && m.BroadcastTimeout <= 0 {
+		return errors.New("must provide BroadcastTimeout with BroadcastEndpoints")
+	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package txmgr
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -22,6 +23,19 @@ func TestDefaultConfigIsValid(t *testing.T) {
 	require.NoError(t, cfg.Check())
 }
 
+func TestFeeLimitsConfig(t *testing.T) {
+	cfg := configForArgs("test", "--txmgr.max-gas-tip-cap=2", "--txmgr.max-gas-fee-cap=1.5", "--txmgr.max-fee-bumps=3")
+	require.Equal(t, 2.0, cfg.MaxGasTipCap)
+	require.Equal(t, 1.5, cfg.MaxGasFeeCap)
+	require.Equal(t, uint64(3), cfg.MaxFeeBumps)
+	require.ErrorContains(t, cfg.Check(), "max gas tip cap cannot be higher than the max gas fee cap")
+	cfg.MaxGasFeeCap = -1
+	require.ErrorContains(t, cfg.Check(), "cannot be negative")
+	cfg.MaxGasFeeCap = 3
+	require.NoError(t, cfg.Check())
+	require.Equal(t, big.NewInt(1_500_000_000), gweiToWei(1.5))
+}
+
 func configForArgs(args ...string) CLIConfig {
 	app := cli.NewApp()
 	// txmgr expects the --l1-eth-rpc option to be declared externally
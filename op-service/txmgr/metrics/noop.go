@@ -1,13 +1,27 @@
 package metrics
 
-import "github.com/ethereum/go-ethereum/core/types"
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
 
 type NoopTxMetrics struct{}
 
-func (*NoopTxMetrics) RecordNonce(uint64)                {}
-func (*NoopTxMetrics) RecordPendingTx(int64)             {}
-func (*NoopTxMetrics) RecordGasBumpCount(int)            {}
-func (*NoopTxMetrics) RecordTxConfirmationLatency(int64) {}
-func (*NoopTxMetrics) TxConfirmed(*types.Receipt)        {}
-func (*NoopTxMetrics) TxPublished(string)                {}
-func (*NoopTxMetrics) RPCError()                         {}
+func (*NoopTxMetrics) RecordNonce(uint64)                     {}
+func (*NoopTxMetrics) RecordPendingTx(int64)                  {}
+func (*NoopTxMetrics) RecordGasBumpCount(int)                 {}
+func (*NoopTxMetrics) RecordTxConfirmationLatency(int64)      {}
+func (*NoopTxMetrics) TxConfirmed(*types.Receipt)             {}
+func (*NoopTxMetrics) TxPublished(string)                     {}
+func (*NoopTxMetrics) RPCError()                              {}
+func (*NoopTxMetrics) SignerError()                           {}
+func (*NoopTxMetrics) FeeCapReached()                         {}
+func (*NoopTxMetrics) NonceResync()                           {}
+func (*NoopTxMetrics) RecordTxConfirmationTime(time.Duration) {}
+func (*NoopTxMetrics) RecordPublishAttempt(string)            {}
+func (*NoopTxMetrics) RecordBaseFee(*big.Int)                 {}
+func (*NoopTxMetrics) RecordTipCap(*big.Int)                  {}
+func (*NoopTxMetrics) TxAbandonedAtFeeCap()                   {}
+func (*NoopTxMetrics) BroadcastError(string)                  {}
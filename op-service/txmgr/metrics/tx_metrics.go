@@ -1,6 +1,9 @@
 package metrics
 
 import (
+	"math/big"
+	"time"
+
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
@@ -16,20 +19,47 @@ type TxMetricer interface {
 	TxConfirmed(*types.Receipt)
 	TxPublished(string)
 	RPCError()
+	SignerError()
+	FeeCapReached()
+	NonceResync()
+	RecordTxConfirmationTime(time.Duration)
+	RecordPublishAttempt(result string)
+	RecordBaseFee(*big.Int)
+	RecordTipCap(*big.Int)
+	TxAbandonedAtFeeCap()
+	BroadcastError(endpoint string)
 }
 
+// Results of publish attempts, see RecordPublishAttempt. A replaced tx is a tx with bumped fees that was published.
+const (
+	PublishOK          = "ok"
+	PublishReplaced    = "replaced"
+	PublishUnderpriced = "underpriced"
+	PublishFailed      = "failed"
+)
+
 type TxMetrics struct {
 	TxL1GasFee         prometheus.Gauge
 	txFees             prometheus.Counter
 	TxGasBump          prometheus.Gauge
+	txGasBumps         prometheus.Histogram
 	txFeeHistogram     prometheus.Histogram
 	LatencyConfirmedTx prometheus.Gauge
+	confirmationTime   prometheus.Histogram
+	publishAttempts    *prometheus.CounterVec
+	basefee            prometheus.Gauge
+	tipcap             prometheus.Gauge
+	feeCapAbandoned    prometheus.Counter
 	currentNonce       prometheus.Gauge
 	pendingTxs         prometheus.Gauge
 	txPublishError     *prometheus.CounterVec
 	publishEvent       *metrics.Event
 	confirmEvent       metrics.EventVec
 	rpcError           prometheus.Counter
+	signerError        prometheus.Counter
+	feeCapReached      prometheus.Counter
+	nonceResync        prometheus.Counter
+	broadcastError     *prometheus.CounterVec
 }
 
 func receiptStatusString(receipt *types.Receipt) string {
@@ -72,12 +102,50 @@ func MakeTxMetrics(ns string, factory metrics.Factory) TxMetrics {
 			Help:      "Number of times a transaction gas needed to be bumped before it got included",
 			Subsystem: "txmgr",
 		}),
+		txGasBumps: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "tx_gas_bumps",
+			Help:      "Histogram of the number of fee bumps of the confirmed transactions",
+			Subsystem: "txmgr",
+			Buckets:   []float64{0, 1, 2, 3, 4, 5, 7, 10, 15, 20},
+		}),
 		LatencyConfirmedTx: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "tx_confirmed_latency_ms",
 			Help:      "Latency of a confirmed transaction in milliseconds",
 			Subsystem: "txmgr",
 		}),
+		confirmationTime: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "tx_confirmation_seconds",
+			Help:      "Histogram of the time from the first publish of a transaction to its confirmation, in seconds",
+			Subsystem: "txmgr",
+			Buckets:   []float64{1, 6, 12, 24, 36, 60, 120, 300, 600, 1200, 3600},
+		}),
+		publishAttempts: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "tx_publish_attempt_count",
+			Help:      "Count of transaction publish attempts, by result: ok, replaced, underpriced or failed",
+			Subsystem: "txmgr",
+		}, []string{"result"}),
+		basefee: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "basefee_wei",
+			Help:      "Latest L1 basefee that transaction fees are based on, in wei",
+			Subsystem: "txmgr",
+		}),
+		tipcap: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "tipcap_wei",
+			Help:      "Latest suggested L1 tip cap that transaction fees are based on, in wei",
+			Subsystem: "txmgr",
+		}),
+		feeCapAbandoned: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "fee_cap_abandoned_count",
+			Help:      "Count of transactions that were abandoned after their fees reached the configured fee limits",
+			Subsystem: "txmgr",
+		}),
 		currentNonce: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "current_nonce",
@@ -104,6 +172,30 @@ func MakeTxMetrics(ns string, factory metrics.Factory) TxMetrics {
 			Help:      "Temporary: Count of RPC errors (like timeouts) that have occurred",
 			Subsystem: "txmgr",
 		}),
+		signerError: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "signer_error_count",
+			Help:      "Count of failures to reach the remote signer, including retried ones",
+			Subsystem: "txmgr",
+		}),
+		feeCapReached: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "fee_cap_reached_count",
+			Help:      "Count of fee bumps that were skipped, or fees that were capped, because of the configured fee limits",
+			Subsystem: "txmgr",
+		}),
+		nonceResync: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "nonce_resync_count",
+			Help:      "Count of nonce resyncs, because the nonce was used by transactions sent from outside of the txmgr",
+			Subsystem: "txmgr",
+		}),
+		broadcastError: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "broadcast_error_count",
+			Help:      "Count of errors of broadcasting transactions to the broadcast endpoints, by endpoint",
+			Subsystem: "txmgr",
+		}, []string{"endpoint"}),
 	}
 }
 
@@ -115,9 +207,10 @@ func (t *TxMetrics) RecordPendingTx(pending int64) {
 	t.pendingTxs.Set(float64(pending))
 }
 
-// TxConfirmed records lots of information about the confirmed transaction
+// TxConfirmed records lots of information about the confirmed transaction.
+// The fee includes the blob fee of blob transactions.
 func (t *TxMetrics) TxConfirmed(receipt *types.Receipt) {
-	fee := float64(receipt.EffectiveGasPrice.Uint64() * receipt.GasUsed / params.GWei)
+	fee := float64(receiptFee(receipt) / params.GWei)
 	t.confirmEvent.Record(receiptStatusString(receipt))
 	t.TxL1GasFee.Set(fee)
 	t.txFees.Add(fee)
@@ -127,6 +220,33 @@ func (t *TxMetrics) TxConfirmed(receipt *types.Receipt) {
 
 func (t *TxMetrics) RecordGasBumpCount(times int) {
 	t.TxGasBump.Set(float64(times))
+	t.txGasBumps.Observe(float64(times))
+}
+
+func (t *TxMetrics) RecordTxConfirmationTime(d time.Duration) {
+	t.confirmationTime.Observe(d.Seconds())
+}
+
+func (t *TxMetrics) RecordPublishAttempt(result string) {
+	t.publishAttempts.WithLabelValues(result).Inc()
+}
+
+func (t *TxMetrics) RecordBaseFee(basefee *big.Int) {
+	f, _ := new(big.Float).SetInt(basefee).Float64()
+	t.basefee.Set(f)
+}
+
+func (t *TxMetrics) RecordTipCap(tipcap *big.Int) {
+	f, _ := new(big.Float).SetInt(tipcap).Float64()
+	t.tipcap.Set(f)
+}
+
+func (t *TxMetrics) TxAbandonedAtFeeCap() {
+	t.feeCapAbandoned.Inc()
+}
+
+func (t *TxMetrics) BroadcastError(endpoint string) {
+	t.broadcastError.WithLabelValues(endpoint).Inc()
 }
 
 func (t *TxMetrics) RecordTxConfirmationLatency(latency int64) {
@@ -144,3 +264,24 @@ func (t *TxMetrics) TxPublished(errString string) {
 func (t *TxMetrics) RPCError() {
 	t.rpcError.Inc()
 }
+
+func (t *TxMetrics) SignerError() {
+	t.signerError.Inc()
+}
+
+func (t *TxMetrics) FeeCapReached() {
+	t.feeCapReached.Inc()
+}
+
+func (t *TxMetrics) NonceResync() {
+	t.nonceResync.Inc()
+}
+
+// receiptFee returns the fee in wei that was paid for the tx of the receipt, including its blob fee.
+func receiptFee(receipt *types.Receipt) uint64 {
+	fee := receipt.EffectiveGasPrice.Uint64() * receipt.GasUsed
+	if receipt.BlobGasPrice != nil {
+		fee += receipt.BlobGasPrice.Uint64() * receipt.BlobGasUsed
+	}
+	return fee
+}
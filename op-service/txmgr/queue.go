@@ -20,6 +20,8 @@ type TxReceipt[T any] struct {
 
 type Queue[T any] struct {
 	ctx        context.Context
+	cancel     context.CancelFunc
+	abandoned  chan struct{}
 	txMgr      TxManager
 	maxPending uint64
 	groupLock  sync.Mutex
@@ -36,8 +38,11 @@ func NewQueue[T any](ctx context.Context, txMgr TxManager, maxPending uint64) *Q
 		// ensure we don't overflow as errgroup only accepts int; in reality this will never be an issue
 		maxPending = math.MaxInt
 	}
+	ctx, cancel := context.WithCancel(ctx)
 	return &Queue[T]{
 		ctx:        ctx,
+		cancel:     cancel,
+		abandoned:  make(chan struct{}),
 		txMgr:      txMgr,
 		maxPending: maxPending,
 	}
@@ -51,6 +56,22 @@ func (q *Queue[T]) Wait() {
 	_ = q.group.Wait()
 }
 
+// Abandon stops sending the pending txs, and waits for their sends to return, as opposed to Wait,
+// which waits for the pending txs to complete. The receipts of the abandoned txs may be dropped,
+// so that Abandon doesn't block on unread receipt channels.
+// The Queue cannot be used anymore after Abandon.
+func (q *Queue[T]) Abandon() {
+	q.groupLock.Lock()
+	select {
+	case <-q.abandoned:
+	default:
+		close(q.abandoned)
+	}
+	q.groupLock.Unlock()
+	q.cancel()
+	q.Wait()
+}
+
 // Send will wait until the number of pending txs is below the max pending,
 // and then send the next tx.
 //
@@ -82,10 +103,13 @@ func (q *Queue[T]) TrySend(id T, candidate TxCandidate, receiptCh chan TxReceipt
 
 func (q *Queue[T]) sendTx(ctx context.Context, id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) error {
 	receipt, err := q.txMgr.Send(ctx, candidate)
-	receiptCh <- TxReceipt[T]{
+	select {
+	case receiptCh <- TxReceipt[T]{
 		ID:      id,
 		Receipt: receipt,
 		Err:     err,
+	}:
+	case <-q.abandoned:
 	}
 	return err
 }
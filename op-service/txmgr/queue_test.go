@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 	"github.com/ethereum/go-ethereum/common"
@@ -176,6 +178,7 @@ func TestSend(t *testing.T) {
 			mgr := &SimpleTxManager{
 				chainID: conf.ChainID,
 				name:    "TEST",
+				clock:   clock.SystemClock,
 				cfg:     conf,
 				backend: backend,
 				l:       testlog.Logger(t, log.LvlCrit),
@@ -237,3 +240,158 @@ func TestSend(t *testing.T) {
 		})
 	}
 }
+
+// heldReceiptBackend doesn't return the receipts of the held txs, as if they were not mined yet.
+type heldReceiptBackend struct {
+	*mockBackend
+	heldMu sync.Mutex
+	held   map[common.Hash]bool
+}
+
+func (b *heldReceiptBackend) hold(txHash common.Hash, held bool) {
+	b.heldMu.Lock()
+	defer b.heldMu.Unlock()
+	b.held[txHash] = held
+}
+
+func (b *heldReceiptBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	b.heldMu.Lock()
+	held := b.held[txHash]
+	b.heldMu.Unlock()
+	if held {
+		return nil, nil
+	}
+	return b.mockBackend.TransactionReceipt(ctx, txHash)
+}
+
+// TestQueueStuckLowestNonce asserts that only the fees of the lowest pending tx are bumped, as the txs with
+// higher nonces cannot be included before it.
+func TestQueueStuckLowestNonce(t *testing.T) {
+	t.Parallel()
+
+	conf := configWithNumConfs(1)
+	conf.ResubmissionTimeout = 20 * time.Millisecond
+	conf.ReceiptQueryInterval = time.Millisecond
+	h := newTestHarnessWithConfig(t, conf)
+
+	var (
+		mu     sync.Mutex
+		sends  = make(map[uint64]int)
+		mined  = make(map[uint64]bool)
+		queued int // sends of the tx with nonce 1 while the tx with nonce 0 is stuck
+	)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		nonce := tx.Nonce()
+		sends[nonce]++
+		if nonce == 1 && !mined[0] {
+			queued++
+		}
+		// the tx with nonce 0 is only included after two fee bumps
+		if (nonce == 0 && sends[0] >= 3) || (nonce == 1 && mined[0]) {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+			mined[nonce] = true
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	queue := NewQueue[int](ctx, h.mgr, 2)
+	receiptCh := make(chan TxReceipt[int], 2)
+	for i := 0; i < 2; i++ {
+		queue.Send(i, h.createTxCandidate(), receiptCh)
+	}
+	for i := 0; i < 2; i++ {
+		r := <-receiptCh
+		require.NoError(t, r.Err)
+	}
+	queue.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 3, sends[0])
+	require.Equal(t, 1, queued, "the tx with the higher nonce is not bumped while the lower nonce is stuck")
+	require.True(t, mined[1])
+}
+
+// TestQueueOutOfOrderReceipts asserts that the receipts of txs are returned when they are found, even if the
+// receipt of a tx with a higher nonce arrives before the receipt of a tx with a lower nonce.
+func TestQueueOutOfOrderReceipts(t *testing.T) {
+	t.Parallel()
+
+	conf := configWithNumConfs(1)
+	conf.ResubmissionTimeout = time.Hour
+	conf.ReceiptQueryInterval = time.Millisecond
+	h := newTestHarnessWithConfig(t, conf)
+	backend := &heldReceiptBackend{mockBackend: h.backend, held: make(map[common.Hash]bool)}
+	h.mgr.backend = backend
+
+	var (
+		mu     sync.Mutex
+		hashes = make(map[uint64]common.Hash)
+	)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		txHash := tx.Hash()
+		// the receipt of the tx with nonce 0 is held back
+		backend.hold(txHash, tx.Nonce() == 0)
+		hashes[tx.Nonce()] = txHash
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	queue := NewQueue[int](ctx, h.mgr, 2)
+	receiptCh := make(chan TxReceipt[int], 2)
+	for i := 0; i < 2; i++ {
+		queue.Send(i, h.createTxCandidate(), receiptCh)
+	}
+
+	r := <-receiptCh
+	require.NoError(t, r.Err)
+	mu.Lock()
+	require.Equal(t, hashes[1], r.Receipt.TxHash, "the receipt of the higher nonce arrives first")
+	backend.hold(hashes[0], false)
+	mu.Unlock()
+
+	r = <-receiptCh
+	require.NoError(t, r.Err)
+	mu.Lock()
+	require.Equal(t, hashes[0], r.Receipt.TxHash)
+	mu.Unlock()
+	queue.Wait()
+}
+
+// TestQueueAbandon asserts that abandoning the queue stops the pending sends, even if their receipts are not read.
+func TestQueueAbandon(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	// the txs are never mined
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		return nil
+	})
+
+	queue := NewQueue[int](context.Background(), h.mgr, 2)
+	receiptCh := make(chan TxReceipt[int])
+	for i := 0; i < 2; i++ {
+		queue.Send(i, h.createTxCandidate(), receiptCh)
+	}
+
+	abandoned := make(chan struct{})
+	go func() {
+		queue.Abandon()
+		queue.Abandon()
+		close(abandoned)
+	}()
+	select {
+	case <-abandoned:
+	case <-time.After(10 * time.Second):
+		t.Fatal("pending sends were not abandoned")
+	}
+}
@@ -0,0 +1,207 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ReplacementResult is the result of replacing the tx at a nonce.
+type ReplacementResult struct {
+	// Receipt is the receipt of the tx that was confirmed at the nonce.
+	Receipt *types.Receipt
+	// Replaced is whether the replacement was confirmed, and not the tx it replaced.
+	Replaced bool
+}
+
+// Cancel cancels the tx at the given nonce, by replacing it with a zero value self-transfer.
+// See [SimpleTxManager.Replace].
+func (m *SimpleTxManager) Cancel(ctx context.Context, nonce uint64) (*ReplacementResult, error) {
+	candidate := TxCandidate{
+		To:       &m.cfg.From,
+		GasLimit: params.TxGas,
+	}
+	if tx := m.inflightTxAt(nonce); tx != nil && tx.Type() == types.BlobTxType {
+		// a blob tx can only be replaced by another blob tx
+		candidate.Blobs = []*eth.Blob{new(eth.Blob)}
+	}
+	return m.Replace(ctx, nonce, candidate)
+}
+
+// Replace replaces the tx at the given nonce, e.g. a tx that is stuck, by a tx of the candidate, and waits until
+// either is confirmed. The fees of the replacement are bumped over the fees of the tx that is being sent at the
+// nonce, if any, while the fees of that tx aren't bumped anymore. If the replacement is confirmed, the Send of the
+// replaced tx returns [ErrTxReplaced].
+//
+// The replaced tx may still be confirmed before its replacement, which the result reports. If no tx is being sent
+// at the nonce, e.g. because the tx was sent by a previous instance of the tx manager, a confirmed tx at the nonce
+// is reported as an error that wraps [core.ErrNonceTooLow].
+// If the replacement would exceed the max fees, it is not sent and [ErrFeeCapReached] is returned.
+func (m *SimpleTxManager) Replace(ctx context.Context, nonce uint64, candidate TxCandidate) (*ReplacementResult, error) {
+	if m.cfg.TxSendTimeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.cfg.TxSendTimeout)
+		defer cancel()
+	}
+
+	orig, origTx := m.startReplacing(nonce)
+	if orig != nil {
+		defer m.stopReplacing(orig)
+	} else if err := m.checkNonceUsed(nonce); err != nil {
+		return nil, err
+	}
+	tx, err := m.craftReplacement(ctx, nonce, candidate, origTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the replacement tx: %w", err)
+	}
+	m.l.Info("Replacing transaction", "nonce", nonce, "hash", tx.Hash(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
+
+	resubmissionTimeout := m.cfg.ResubmissionTimeout
+	if candidate.ResubmissionTimeout != 0 {
+		resubmissionTimeout = candidate.ResubmissionTimeout
+	}
+	sendCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		receipt *types.Receipt
+		err     error
+	}
+	sent := make(chan result, 1)
+	go func() {
+		receipt, err := m.sendTxWithResubmission(sendCtx, tx, resubmissionTimeout, candidate.GasLimit != 0, nil)
+		sent <- result{receipt, err}
+	}()
+
+	var confirmed chan *types.Receipt
+	if orig != nil {
+		confirmed = orig.confirmed
+	}
+	select {
+	case res := <-sent:
+		if orig != nil && errors.Is(res.err, core.ErrNonceTooLow) {
+			// the replaced tx was confirmed first, wait until its Send sees it
+			select {
+			case receipt := <-confirmed:
+				return &ReplacementResult{Receipt: receipt}, nil
+			case <-orig.done:
+			case <-ctx.Done():
+			}
+		}
+		if res.err != nil {
+			return nil, res.err
+		}
+		if orig != nil {
+			close(orig.replaced)
+		}
+		return &ReplacementResult{Receipt: res.receipt, Replaced: true}, nil
+	case receipt := <-confirmed:
+		m.l.Info("Replaced transaction was confirmed before its replacement", "nonce", nonce, "hash", receipt.TxHash)
+		cancel()
+		<-sent
+		return &ReplacementResult{Receipt: receipt}, nil
+	}
+}
+
+// craftReplacement creates the signed replacement tx of the candidate at the given nonce. If the tx it replaces
+// is known, the fees of the replacement are bumped over its fees by at least the required price bump.
+func (m *SimpleTxManager) craftReplacement(ctx context.Context, nonce uint64, candidate TxCandidate, orig *types.Transaction) (*types.Transaction, error) {
+	isBlobTx := len(candidate.Blobs) > 0
+	if orig != nil && isBlobTx != (orig.Type() == types.BlobTxType) {
+		return nil, errors.New("a blob tx can only be replaced by a blob tx, and a non-blob tx by a non-blob tx")
+	}
+	txMessage, err := m.makeTxMessage(ctx, candidate)
+	if err != nil {
+		return nil, err
+	}
+	switch x := txMessage.(type) {
+	case *types.DynamicFeeTx:
+		x.Nonce = nonce
+		if orig != nil {
+			x.GasTipCap = maxBig(x.GasTipCap, calcThresholdValue(orig.GasTipCap(), false))
+			x.GasFeeCap = maxBig(x.GasFeeCap, calcThresholdValue(orig.GasFeeCap(), false))
+			if err := m.checkMaxFees(x.GasTipCap, x.GasFeeCap); err != nil {
+				return nil, err
+			}
+		}
+	case *types.BlobTx:
+		x.Nonce = nonce
+		if orig != nil {
+			tip := maxBig(x.GasTipCap.ToBig(), calcThresholdValue(orig.GasTipCap(), true))
+			feeCap := maxBig(x.GasFeeCap.ToBig(), calcThresholdValue(orig.GasFeeCap(), true))
+			if err := m.checkMaxFees(tip, feeCap); err != nil {
+				return nil, err
+			}
+			x.GasTipCap = uint256.MustFromBig(tip)
+			x.GasFeeCap = uint256.MustFromBig(feeCap)
+			x.BlobFeeCap = uint256.MustFromBig(maxBig(x.BlobFeeCap.ToBig(), calcThresholdValue(orig.BlobGasFeeCap(), true)))
+		}
+	default:
+		return nil, fmt.Errorf("unrecognized tx type: %T", txMessage)
+	}
+	return m.sign(ctx, types.NewTx(txMessage))
+}
+
+// checkMaxFees returns an error wrapping [ErrFeeCapReached] if the fees are over the configured max fees.
+func (m *SimpleTxManager) checkMaxFees(tip, feeCap *big.Int) error {
+	if m.cfg.MaxGasTipCap != nil && tip.Cmp(m.cfg.MaxGasTipCap) > 0 {
+		return fmt.Errorf("%w: replacement tip cap %v is over the max tip cap %v", ErrFeeCapReached, tip, m.cfg.MaxGasTipCap)
+	}
+	if m.cfg.MaxGasFeeCap != nil && feeCap.Cmp(m.cfg.MaxGasFeeCap) > 0 {
+		return fmt.Errorf("%w: replacement fee cap %v is over the max fee cap %v", ErrFeeCapReached, feeCap, m.cfg.MaxGasFeeCap)
+	}
+	return nil
+}
+
+// checkNonceUsed returns an error if the nonce was not used by the tx manager yet, as there is nothing to replace.
+func (m *SimpleTxManager) checkNonceUsed(nonce uint64) error {
+	m.nonceLock.RLock()
+	defer m.nonceLock.RUnlock()
+	if m.nonce != nil && nonce > *m.nonce {
+		return fmt.Errorf("nonce %d was not used yet, the last used nonce is %d", nonce, *m.nonce)
+	}
+	return nil
+}
+
+// startReplacing stops the fee bumps of the tx that is being sent at the nonce, if any, and returns it
+// with its latest published tx.
+func (m *SimpleTxManager) startReplacing(nonce uint64) (*inflightTx, *types.Transaction) {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	inflight, ok := m.inflight[nonce]
+	if !ok {
+		return nil, nil
+	}
+	inflight.replacing = true
+	return inflight, inflight.tx
+}
+
+// stopReplacing resumes the fee bumps of the tx, if it wasn't replaced.
+func (m *SimpleTxManager) stopReplacing(inflight *inflightTx) {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	inflight.replacing = false
+}
+
+func (m *SimpleTxManager) inflightTxAt(nonce uint64) *types.Transaction {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	if inflight, ok := m.inflight[nonce]; ok {
+		return inflight.tx
+	}
+	return nil
+}
+
+func maxBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
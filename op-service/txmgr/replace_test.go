@@ -0,0 +1,274 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// replacementSender records the txs that are sent, and calls the sender of the replacements, which are
+// the txs to the given address, or of the replaced txs.
+type replacementSender struct {
+	mu   sync.Mutex
+	sent []*types.Transaction
+	// published is closed once the first replaced tx is sent
+	published chan struct{}
+}
+
+func newReplacementSender(h *testHarness, isReplacement func(tx *types.Transaction) bool, replacement, replaced sendTransactionFunc) *replacementSender {
+	s := &replacementSender{published: make(chan struct{})}
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.sent = append(s.sent, tx)
+		if isReplacement(tx) {
+			return replacement(ctx, tx)
+		}
+		if len(s.sent) == 1 {
+			close(s.published)
+		}
+		return replaced(ctx, tx)
+	})
+	return s
+}
+
+// last returns the last sent replacement, and the last sent replaced tx before it.
+func (s *replacementSender) last(isReplacement func(tx *types.Transaction) bool) (*types.Transaction, *types.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var replacement, replaced *types.Transaction
+	for _, tx := range s.sent {
+		if isReplacement(tx) {
+			replacement = tx
+		} else if replacement == nil {
+			replaced = tx
+		}
+	}
+	return replacement, replaced
+}
+
+func replaceTestConfig() Config {
+	conf := configWithNumConfs(1)
+	conf.ResubmissionTimeout = 10 * time.Millisecond
+	conf.ReceiptQueryInterval = time.Millisecond
+	return conf
+}
+
+// sendInBackground sends the candidate, and waits until its first tx is sent.
+func sendInBackground(t *testing.T, ctx context.Context, h *testHarness, s *replacementSender, candidate TxCandidate) chan error {
+	errs := make(chan error, 1)
+	go func() {
+		_, err := h.mgr.Send(ctx, candidate)
+		errs <- err
+	}()
+	select {
+	case <-s.published:
+	case <-ctx.Done():
+		t.Fatal("tx was not sent")
+	}
+	return errs
+}
+
+// TestTxMgrCancel asserts that a stuck tx is replaced by a self-transfer with bumped fees, and that the
+// Send of the stuck tx returns ErrTxReplaced.
+func TestTxMgrCancel(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarnessWithConfig(t, replaceTestConfig())
+	isCancel := func(tx *types.Transaction) bool {
+		return *tx.To() == h.cfg.From && tx.Value().Sign() == 0 && len(tx.Data()) == 0
+	}
+	s := newReplacementSender(h, isCancel,
+		func(ctx context.Context, tx *types.Transaction) error {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+			return nil
+		},
+		func(ctx context.Context, tx *types.Transaction) error {
+			return nil // stuck
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	errs := sendInBackground(t, ctx, h, s, h.createTxCandidate())
+
+	res, err := h.mgr.Cancel(ctx, 0)
+	require.NoError(t, err)
+	require.True(t, res.Replaced)
+	cancelTx, stuckTx := s.last(isCancel)
+	require.Equal(t, cancelTx.Hash(), res.Receipt.TxHash)
+	require.Equal(t, uint64(0), cancelTx.Nonce())
+	require.GreaterOrEqual(t, cancelTx.GasTipCap().Cmp(calcThresholdValue(stuckTx.GasTipCap(), false)), 0)
+	require.GreaterOrEqual(t, cancelTx.GasFeeCap().Cmp(calcThresholdValue(stuckTx.GasFeeCap(), false)), 0)
+	require.ErrorIs(t, <-errs, ErrTxReplaced)
+}
+
+// TestTxMgrCancelOriginalConfirmsFirst asserts that the result of a cancellation reports the tx it cancels
+// if that tx is confirmed before the cancellation, and that the Send of the tx returns its receipt.
+func TestTxMgrCancelOriginalConfirmsFirst(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarnessWithConfig(t, replaceTestConfig())
+	isCancel := func(tx *types.Transaction) bool {
+		return *tx.To() == h.cfg.From
+	}
+	var last, mined *types.Transaction
+	s := newReplacementSender(h, isCancel,
+		func(ctx context.Context, tx *types.Transaction) error {
+			// the tx that is cancelled is included first
+			if mined == nil {
+				mined = last
+				txHash := mined.Hash()
+				h.backend.mine(&txHash, mined.GasFeeCap())
+			}
+			return core.ErrNonceTooLow
+		},
+		func(ctx context.Context, tx *types.Transaction) error {
+			last = tx
+			return nil
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipts := make(chan *types.Receipt, 1)
+	go func() {
+		receipt, _ := h.mgr.Send(ctx, h.createTxCandidate())
+		receipts <- receipt
+	}()
+	<-s.published
+
+	res, err := h.mgr.Cancel(ctx, 0)
+	require.NoError(t, err)
+	require.False(t, res.Replaced)
+	s.mu.Lock()
+	require.Equal(t, mined.Hash(), res.Receipt.TxHash)
+	s.mu.Unlock()
+	require.Equal(t, res.Receipt, <-receipts)
+}
+
+// TestTxMgrReplace asserts that a stuck tx is replaced by the tx of a new candidate at the same nonce.
+func TestTxMgrReplace(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarnessWithConfig(t, replaceTestConfig())
+	candidate := h.createTxCandidate()
+	replacement := h.createTxCandidate()
+	replacement.TxData = []byte{0x01, 0x02}
+	isReplacement := func(tx *types.Transaction) bool {
+		return string(tx.Data()) == string(replacement.TxData)
+	}
+	s := newReplacementSender(h, isReplacement,
+		func(ctx context.Context, tx *types.Transaction) error {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+			return nil
+		},
+		func(ctx context.Context, tx *types.Transaction) error {
+			return nil
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	errs := sendInBackground(t, ctx, h, s, candidate)
+
+	res, err := h.mgr.Replace(ctx, 0, replacement)
+	require.NoError(t, err)
+	require.True(t, res.Replaced)
+	replacementTx, _ := s.last(isReplacement)
+	require.Equal(t, replacementTx.Hash(), res.Receipt.TxHash)
+	require.Equal(t, uint64(0), replacementTx.Nonce())
+	require.ErrorIs(t, <-errs, ErrTxReplaced)
+}
+
+// TestTxMgrCancelBlobTx asserts that a blob tx is cancelled by a blob self-transfer, with the blob fee
+// cap bumped as well.
+func TestTxMgrCancelBlobTx(t *testing.T) {
+	t.Parallel()
+
+	h, candidate := newBlobTestHarness(t, replaceTestConfig())
+	isCancel := func(tx *types.Transaction) bool {
+		return *tx.To() == h.cfg.From
+	}
+	s := newReplacementSender(h, isCancel,
+		func(ctx context.Context, tx *types.Transaction) error {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+			return nil
+		},
+		func(ctx context.Context, tx *types.Transaction) error {
+			return nil
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	errs := sendInBackground(t, ctx, h, s, candidate)
+
+	res, err := h.mgr.Cancel(ctx, 0)
+	require.NoError(t, err)
+	require.True(t, res.Replaced)
+	cancelTx, stuckTx := s.last(isCancel)
+	require.Equal(t, uint8(types.BlobTxType), cancelTx.Type())
+	require.Len(t, cancelTx.BlobHashes(), 1)
+	require.GreaterOrEqual(t, cancelTx.BlobGasFeeCap().Cmp(calcThresholdValue(stuckTx.BlobGasFeeCap(), true)), 0)
+	require.ErrorIs(t, <-errs, ErrTxReplaced)
+}
+
+func TestTxMgrReplaceErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nonce not used", func(t *testing.T) {
+		h := newTestHarnessWithConfig(t, replaceTestConfig())
+		h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+			return nil
+		})
+		_, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+		require.NoError(t, err)
+
+		_, err = h.mgr.Cancel(context.Background(), 1)
+		require.ErrorContains(t, err, "was not used yet")
+	})
+
+	t.Run("different tx type", func(t *testing.T) {
+		h := newTestHarnessWithConfig(t, replaceTestConfig())
+		s := newReplacementSender(h, func(*types.Transaction) bool { return false }, nil,
+			func(ctx context.Context, tx *types.Transaction) error {
+				return nil
+			})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		errs := sendInBackground(t, ctx, h, s, h.createTxCandidate())
+
+		_, blobCandidate := newBlobTestHarness(t, replaceTestConfig())
+		_, err := h.mgr.Replace(ctx, 0, blobCandidate)
+		require.ErrorContains(t, err, "a blob tx can only be replaced by a blob tx")
+		cancel()
+		require.ErrorIs(t, <-errs, context.Canceled)
+	})
+
+	t.Run("fee cap reached", func(t *testing.T) {
+		conf := replaceTestConfig()
+		// the fee cap of the first tx
+		conf.MaxGasFeeCap = big.NewInt(19)
+		h := newTestHarnessWithConfig(t, conf)
+		s := newReplacementSender(h, func(*types.Transaction) bool { return false }, nil,
+			func(ctx context.Context, tx *types.Transaction) error {
+				return nil
+			})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		errs := sendInBackground(t, ctx, h, s, h.createTxCandidate())
+
+		_, err := h.mgr.Cancel(ctx, 0)
+		require.ErrorIs(t, err, ErrFeeCapReached)
+		cancel()
+		require.ErrorIs(t, <-errs, context.Canceled)
+	})
+}
@@ -28,7 +28,9 @@ type SendState struct {
 	safeAbortNonceTooLowCount uint64 // nonce too low error
 
 	// Miscellaneous tracking
-	bumpCount int // number of times we have bumped the gas price
+	bumpCount      int  // number of times we have bumped the gas price
+	feeCapReached  bool // whether a fee bump was skipped because of the configured fee limits
+	gasLimitPinned bool // whether the gas limit of the tx was set by the caller, and is never re-estimated
 }
 
 // NewSendStateWithNow creates a new send state with the provided clock.
@@ -93,6 +95,14 @@ func (s *SendState) TxNotMined(txHash common.Hash) {
 	}
 }
 
+// IsNonceTooLow returns true if enough ErrNonceTooLow errors were recorded to give up on the nonce.
+func (s *SendState) IsNonceTooLow() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.nonceTooLowCount >= s.safeAbortNonceTooLowCount
+}
+
 // ShouldAbortImmediately returns true if the txmgr should give up on trying a
 // given txn with the target nonce.
 // This occurs when the set of errors recorded indicates that no further progress can be made
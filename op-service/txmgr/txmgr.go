@@ -12,26 +12,63 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
 
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
 
 const (
 	// Geth requires a minimum fee bump of 10% for tx resubmission
 	priceBump int64 = 10
+	// Geth requires a minimum fee bump of 100% for blob tx resubmission
+	blobPriceBump int64 = 100
 )
 
 // new = old * (100 + priceBump) / 100
 var (
-	priceBumpPercent = big.NewInt(100 + priceBump)
-	oneHundred       = big.NewInt(100)
+	priceBumpPercent     = big.NewInt(100 + priceBump)
+	blobPriceBumpPercent = big.NewInt(100 + blobPriceBump)
+	oneHundred           = big.NewInt(100)
 )
 
+// signingAttempts is the number of attempts to sign a tx if a remote signer cannot be reached,
+// with signingRetryStrategy in between the attempts.
+var (
+	signingAttempts                     = 5
+	signingRetryStrategy retry.Strategy = retry.Exponential()
+)
+
+var ErrBlobsBeforeCancun = errors.New("txmgr cannot send blob txs before L1 activated Cancun")
+
+// errAlreadyReserved is the error of the geth tx pool when a tx of a different type, blob or non-blob,
+// than the pending txs of the account is sent. Such txs cannot replace each other.
+var errAlreadyReserved = errors.New("address already reserved")
+
+// nonceResyncAttempts is the number of times a single Send sends a tx whose nonce is too low, because
+// another process sent txs from the same account. The nonce is resynced in between the attempts.
+var nonceResyncAttempts = 3
+
+// errAbortNonceTooLow is returned when sending a tx is aborted because its nonce is too low.
+var errAbortNonceTooLow = fmt.Errorf("aborted transaction sending: %w", core.ErrNonceTooLow)
+
+// ErrFeeCapReached is returned when bumping the fees of a tx would exceed the configured max fees.
+var ErrFeeCapReached = errors.New("fee cap reached")
+
+// ErrTxReplaced is returned by Send when its tx was replaced by a tx of [SimpleTxManager.Replace] or
+// [SimpleTxManager.Cancel] that was confirmed instead.
+var ErrTxReplaced = errors.New("transaction replaced")
+
 // TxManager is an interface that allows callers to reliably publish txs,
 // bumping the gas price if needed, and obtain the receipt of the resulting tx.
 //
@@ -104,11 +141,24 @@ type SimpleTxManager struct {
 	nonceLock sync.RWMutex
 
 	pending atomic.Int64
+
+	// clock times the resubmissions and receipt queries of the sent txs
+	clock clock.Clock
+
+	// inflight tracks the txs that are being sent, by nonce
+	inflight     map[uint64]*inflightTx
+	inflightLock sync.Mutex
+
+	// closed stops the nonce resync loop
+	closed    chan struct{}
+	closeOnce sync.Once
 }
 
 // NewSimpleTxManager initializes a new SimpleTxManager with the passed Config.
+// The calls to a remote signer are recorded if the metrics also implement the signer metrics.
 func NewSimpleTxManager(name string, l log.Logger, m metrics.TxMetricer, cfg CLIConfig) (*SimpleTxManager, error) {
-	conf, err := NewConfig(cfg, l)
+	signerMetrics, _ := m.(opsigner.Metricer)
+	conf, err := newConfig(cfg, l, signerMetrics)
 	if err != nil {
 		return nil, err
 	}
@@ -120,14 +170,23 @@ func NewSimpleTxManagerFromConfig(name string, l log.Logger, m metrics.TxMetrice
 	if err := conf.Check(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
-	return &SimpleTxManager{
+	mgr := &SimpleTxManager{
 		chainID: conf.ChainID,
 		name:    name,
 		cfg:     conf,
 		backend: conf.Backend,
 		l:       l.New("service", name),
 		metr:    m,
-	}, nil
+		clock:   conf.Clock,
+		closed:  make(chan struct{}),
+	}
+	if mgr.clock == nil {
+		mgr.clock = clock.SystemClock
+	}
+	if conf.NonceResyncInterval != 0 {
+		go mgr.nonceResyncLoop()
+	}
+	return mgr, nil
 }
 
 func (m *SimpleTxManager) From() common.Address {
@@ -139,6 +198,11 @@ func (m *SimpleTxManager) BlockNumber(ctx context.Context) (uint64, error) {
 }
 
 func (m *SimpleTxManager) Close() {
+	m.closeOnce.Do(func() {
+		if m.closed != nil {
+			close(m.closed)
+		}
+	})
 	m.backend.Close()
 }
 
@@ -149,10 +213,16 @@ type TxCandidate struct {
 	TxData []byte
 	// To is the recipient of the constructed tx. Nil means contract creation.
 	To *common.Address
-	// GasLimit is the gas limit to be used in the constructed tx.
+	// GasLimit is the gas limit to be used in the constructed tx. If non-zero, the gas limit is pinned:
+	// it is neither estimated when the tx is constructed, nor re-estimated when the tx is resubmitted.
 	GasLimit uint64
 	// Value is the value to be used in the constructed tx.
 	Value *big.Int
+	// Blobs to send along in the tx. A blob tx is constructed if there are any.
+	Blobs []*eth.Blob
+	// ResubmissionTimeout overrides the configured resubmission timeout for this tx, if non-zero.
+	// A shorter timeout bumps the fees of a tx that isn't included more aggressively.
+	ResubmissionTimeout time.Duration
 }
 
 // Send is used to publish a transaction with incrementally higher gas prices
@@ -183,17 +253,55 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 		ctx, cancel = context.WithTimeout(ctx, m.cfg.TxSendTimeout)
 		defer cancel()
 	}
-	tx, err := retry.Do(ctx, 30, retry.Fixed(2*time.Second), func() (*types.Transaction, error) {
-		tx, err := m.craftTx(ctx, candidate)
+	// the txs that were published for the candidate, in all attempts
+	var published []*types.Transaction
+	for attempt := 1; ; attempt++ {
+		tx, err := retry.Do(ctx, 30, retry.Fixed(2*time.Second), func() (*types.Transaction, error) {
+			tx, err := m.craftTx(ctx, candidate)
+			if err != nil {
+				m.l.Warn("Failed to create a transaction, will retry", "err", err)
+			}
+			return tx, err
+		})
 		if err != nil {
-			m.l.Warn("Failed to create a transaction, will retry", "err", err)
+			return nil, fmt.Errorf("failed to create the tx: %w", err)
 		}
-		return tx, err
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create the tx: %w", err)
+		resubmissionTimeout := m.cfg.ResubmissionTimeout
+		if candidate.ResubmissionTimeout != 0 {
+			resubmissionTimeout = candidate.ResubmissionTimeout
+		}
+		receipt, err := m.sendTxWithResubmission(ctx, tx, resubmissionTimeout, candidate.GasLimit != 0, &published)
+		if !errors.Is(err, errAbortNonceTooLow) {
+			return receipt, err
+		}
+		// The nonce may be too low because a tx that was published for the candidate was included, although
+		// its receipt wasn't seen yet. It must not be rebuilt then, as the candidate would be sent twice.
+		included, ierr := m.includedTx(ctx, published)
+		if ierr != nil {
+			m.l.Warn("Failed to check whether a published transaction was included", "err", ierr)
+			return nil, err
+		}
+		if included != nil {
+			m.l.Info("Published transaction was included, waiting for its confirmation", "hash", included.Hash(), "nonce", included.Nonce())
+			return m.waitConfirmed(ctx, included)
+		}
+		if attempt >= nonceResyncAttempts {
+			return nil, err
+		}
+		// If another process used the nonce, the tx is rebuilt with a nonce resynced to the pending nonce.
+		pending, perr := m.pendingNonce(ctx)
+		if perr != nil {
+			m.l.Warn("Failed to get the pending nonce", "err", perr)
+			return nil, err
+		}
+		if pending <= tx.Nonce() {
+			return nil, err
+		}
+		m.l.Warn("Nonce of the transaction was used by another transaction, retrying with a resynced nonce",
+			"nonce", tx.Nonce(), "pending_nonce", pending, "attempt", attempt)
+		m.resyncNonce(pending)
+		m.metr.NonceResync()
 	}
-	return m.sendTx(ctx, tx)
 }
 
 // craftTx creates the signed transaction
@@ -202,44 +310,113 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 // NOTE: If the [TxCandidate.GasLimit] is non-zero, it will be used as the transaction's gas.
 // NOTE: Otherwise, the [SimpleTxManager] will query the specified backend for an estimate.
 func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, error) {
-	gasTipCap, basefee, err := m.suggestGasPriceCaps(ctx)
+	txMessage, err := m.makeTxMessage(ctx, candidate)
+	if err != nil {
+		return nil, err
+	}
+	return m.signWithNextNonce(ctx, txMessage)
+}
+
+// makeTxMessage creates the unsigned tx message of the candidate, without a nonce, with the fees suggested
+// by the current fee market conditions and the gas limit of the candidate, or an estimate if it isn't set.
+func (m *SimpleTxManager) makeTxMessage(ctx context.Context, candidate TxCandidate) (types.TxData, error) {
+	gasTipCap, basefee, blobBaseFee, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
 		m.metr.RPCError()
 		return nil, fmt.Errorf("failed to get gas price info: %w", err)
 	}
 	gasFeeCap := calcGasFeeCap(basefee, gasTipCap)
-
-	rawTx := &types.DynamicFeeTx{
-		ChainID:   m.chainID,
-		To:        candidate.To,
-		GasTipCap: gasTipCap,
-		GasFeeCap: gasFeeCap,
-		Data:      candidate.TxData,
-		Value:     candidate.Value,
+	if cappedTip, cappedFee := m.capFees(gasTipCap, gasFeeCap); cappedTip != gasTipCap || cappedFee != gasFeeCap {
+		m.l.Warn("Suggested fees are over the max fees, capping them", "gasTipCap", gasTipCap, "gasFeeCap", gasFeeCap,
+			"maxGasTipCap", m.cfg.MaxGasTipCap, "maxGasFeeCap", m.cfg.MaxGasFeeCap)
+		m.metr.FeeCapReached()
+		gasTipCap, gasFeeCap = cappedTip, cappedFee
 	}
 
-	m.l.Info("Creating tx", "to", rawTx.To, "from", m.cfg.From)
+	m.l.Info("Creating tx", "to", candidate.To, "from", m.cfg.From, "blobs", len(candidate.Blobs))
 
+	gasLimit := candidate.GasLimit
 	// If the gas limit is set, we can use that as the gas
-	if candidate.GasLimit != 0 {
-		rawTx.Gas = candidate.GasLimit
-	} else {
+	if gasLimit == 0 {
 		// Calculate the intrinsic gas for the transaction
-		gas, err := m.backend.EstimateGas(ctx, ethereum.CallMsg{
+		gas, err := m.estimateGas(ctx, ethereum.CallMsg{
 			From:      m.cfg.From,
 			To:        candidate.To,
 			GasTipCap: gasTipCap,
 			GasFeeCap: gasFeeCap,
-			Data:      rawTx.Data,
-			Value:     rawTx.Value,
+			Data:      candidate.TxData,
+			Value:     candidate.Value,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to estimate gas: %w", err)
 		}
-		rawTx.Gas = gas
+		gasLimit = gas
+	}
+
+	var txMessage types.TxData
+	if len(candidate.Blobs) > 0 {
+		if candidate.To == nil {
+			return nil, errors.New("blob txs cannot deploy contracts")
+		}
+		if blobBaseFee == nil {
+			return nil, ErrBlobsBeforeCancun
+		}
+		sidecar, blobHashes, err := MakeSidecar(candidate.Blobs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to make sidecar: %w", err)
+		}
+		value := new(uint256.Int)
+		if candidate.Value != nil {
+			if value.SetFromBig(candidate.Value) {
+				return nil, fmt.Errorf("tx value %v overflows uint256", candidate.Value)
+			}
+		}
+		txMessage = &types.BlobTx{
+			ChainID:    uint256.MustFromBig(m.chainID),
+			To:         *candidate.To,
+			GasTipCap:  uint256.MustFromBig(gasTipCap),
+			GasFeeCap:  uint256.MustFromBig(gasFeeCap),
+			Gas:        gasLimit,
+			Value:      value,
+			Data:       candidate.TxData,
+			BlobFeeCap: uint256.MustFromBig(calcBlobFeeCap(blobBaseFee)),
+			BlobHashes: blobHashes,
+			Sidecar:    sidecar,
+		}
+	} else {
+		txMessage = &types.DynamicFeeTx{
+			ChainID:   m.chainID,
+			To:        candidate.To,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Gas:       gasLimit,
+			Data:      candidate.TxData,
+			Value:     candidate.Value,
+		}
 	}
+	return txMessage, nil
+}
 
-	return m.signWithNextNonce(ctx, rawTx)
+// MakeSidecar builds the sidecar of a blob tx, with the KZG commitments and proofs of the given blobs,
+// and returns it together with the versioned hashes of the blobs.
+func MakeSidecar(blobs []*eth.Blob) (*types.BlobTxSidecar, []common.Hash, error) {
+	sidecar := &types.BlobTxSidecar{}
+	blobHashes := make([]common.Hash, 0, len(blobs))
+	for i, blob := range blobs {
+		sidecar.Blobs = append(sidecar.Blobs, *blob.KZGBlob())
+		commitment, err := blob.ComputeKZGCommitment()
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot compute KZG commitment of blob %d: %w", i, err)
+		}
+		proof, err := kzg4844.ComputeBlobProof(*blob.KZGBlob(), commitment)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot compute KZG proof of blob %d: %w", i, err)
+		}
+		sidecar.Commitments = append(sidecar.Commitments, commitment)
+		sidecar.Proofs = append(sidecar.Proofs, proof)
+		blobHashes = append(blobHashes, eth.KZGToVersionedHash(commitment))
+	}
+	return sidecar, blobHashes, nil
 }
 
 // signWithNextNonce returns a signed transaction with the next available nonce.
@@ -247,7 +424,13 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 // then subsequent calls simply increment this number. If the transaction manager
 // is reset, it will query the eth_getTransactionCount nonce again. If signing
 // fails, the nonce is not incremented.
-func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, rawTx *types.DynamicFeeTx) (*types.Transaction, error) {
+func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, txMessage types.TxData) (*types.Transaction, error) {
+	switch txMessage.(type) {
+	case *types.DynamicFeeTx, *types.BlobTx:
+	default:
+		return nil, fmt.Errorf("unrecognized tx type: %T", txMessage)
+	}
+
 	m.nonceLock.Lock()
 	defer m.nonceLock.Unlock()
 
@@ -265,10 +448,13 @@ func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, rawTx *types.Dy
 		*m.nonce++
 	}
 
-	rawTx.Nonce = *m.nonce
-	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
-	defer cancel()
-	tx, err := m.cfg.Signer(ctx, m.cfg.From, types.NewTx(rawTx))
+	switch x := txMessage.(type) {
+	case *types.DynamicFeeTx:
+		x.Nonce = *m.nonce
+	case *types.BlobTx:
+		x.Nonce = *m.nonce
+	}
+	tx, err := m.sign(ctx, types.NewTx(txMessage))
 	if err != nil {
 		// decrement the nonce, so we can retry signing with the same nonce next time
 		// signWithNextNonce is called
@@ -279,6 +465,33 @@ func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, rawTx *types.Dy
 	return tx, err
 }
 
+// sign signs the tx with the configured signer. If a remote signer cannot be reached, signing is retried
+// up to signingAttempts times, and every failed attempt is recorded. Rejections by the signer are not retried.
+func (m *SimpleTxManager) sign(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	for i := 0; ; i++ {
+		cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+		signed, err := m.cfg.Signer(cCtx, m.cfg.From, tx)
+		cancel()
+		if err == nil && signed.Type() != tx.Type() {
+			// e.g. a blob tx must never be replaced by a non-blob tx, which the tx pool rejects
+			return nil, fmt.Errorf("signer changed the tx type from %d to %d", tx.Type(), signed.Type())
+		}
+		if err == nil || !errors.Is(err, opsigner.ErrUnreachable) {
+			return signed, err
+		}
+		m.metr.SignerError()
+		if i+1 >= signingAttempts {
+			return nil, fmt.Errorf("failed to reach the signer after %d attempts: %w", signingAttempts, err)
+		}
+		m.l.Warn("Failed to reach the signer, retrying", "attempt", i+1, "err", err)
+		select {
+		case <-time.After(signingRetryStrategy.Duration(i)):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
 // resetNonce resets the internal nonce tracking. This is called if any pending send
 // returns an error.
 func (m *SimpleTxManager) resetNonce() {
@@ -287,20 +500,84 @@ func (m *SimpleTxManager) resetNonce() {
 	m.nonce = nil
 }
 
+// pendingNonce returns the pending nonce of the account, which includes the txs in the mempool.
+func (m *SimpleTxManager) pendingNonce(ctx context.Context) (uint64, error) {
+	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	pending, err := m.backend.PendingNonceAt(cCtx, m.cfg.From)
+	if err != nil {
+		m.metr.RPCError()
+		return 0, fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+	return pending, nil
+}
+
+// resyncNonce moves the next nonce forward to the pending nonce of the account if it is behind, e.g. because
+// another process sent txs from the same account. It returns whether the nonce was moved.
+// Nothing is done without a cached nonce, as the nonce is then fetched for the next tx anyway.
+func (m *SimpleTxManager) resyncNonce(pending uint64) bool {
+	m.nonceLock.Lock()
+	defer m.nonceLock.Unlock()
+	// the cached nonce is the nonce of the last tx
+	if m.nonce == nil || *m.nonce+1 >= pending {
+		return false
+	}
+	m.l.Warn("Nonce is behind the pending nonce, resyncing", "next_nonce", *m.nonce+1, "pending_nonce", pending)
+	last := pending - 1
+	m.nonce = &last
+	return true
+}
+
+// nonceResyncLoop resyncs the nonce every NonceResyncInterval while no txs are sent, until the tx manager is closed.
+func (m *SimpleTxManager) nonceResyncLoop() {
+	ticker := time.NewTicker(m.cfg.NonceResyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if m.pending.Load() > 0 {
+				continue
+			}
+			pending, err := m.pendingNonce(context.Background())
+			if err != nil {
+				m.l.Warn("Failed to resync the nonce", "err", err)
+			} else if m.resyncNonce(pending) {
+				m.metr.NonceResync()
+			}
+		case <-m.closed:
+			return
+		}
+	}
+}
+
 // send submits the same transaction several times with increasing gas prices as necessary.
 // It waits for the transaction to be confirmed on chain.
 func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	return m.sendTxWithResubmission(ctx, tx, m.cfg.ResubmissionTimeout, false, nil)
+}
+
+// sendTxWithResubmission is like sendTx, but resubmits the transaction with bumped fees after the given
+// resubmission timeout instead of the configured one. The gas limit of a tx with a pinned gas limit is
+// never re-estimated. If published is non-nil, every published tx is appended to it.
+func (m *SimpleTxManager) sendTxWithResubmission(ctx context.Context, tx *types.Transaction, resubmissionTimeout time.Duration, gasLimitPinned bool, published *[]*types.Transaction) (*types.Receipt, error) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	sendState := NewSendState(m.cfg.SafeAbortNonceTooLowCount, m.cfg.TxNotInMempoolTimeout)
+	sendState := NewSendStateWithNow(m.cfg.SafeAbortNonceTooLowCount, m.cfg.TxNotInMempoolTimeout, m.clock.Now)
+	sendState.gasLimitPinned = gasLimitPinned
+	inflight := m.trackInflight(tx, sendState)
+	defer m.untrackInflight(inflight)
 	receiptChan := make(chan *types.Receipt, 1)
 	publishAndWait := func(tx *types.Transaction, bumpFees bool) *types.Transaction {
 		wg.Add(1)
-		tx, published := m.publishTx(ctx, tx, sendState, bumpFees)
-		if published {
+		tx, ok := m.publishTx(ctx, tx, sendState, bumpFees)
+		if ok {
+			if published != nil {
+				*published = append(*published, tx)
+			}
+			m.setInflightTx(inflight, tx)
 			go func() {
 				defer wg.Done()
 				m.waitForTx(ctx, tx, sendState, receiptChan)
@@ -312,14 +589,15 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 	}
 
 	// Immediately publish a transaction before starting the resumbission loop
+	start := m.clock.Now()
 	tx = publishAndWait(tx, false)
 
-	ticker := time.NewTicker(m.cfg.ResubmissionTimeout)
+	ticker := m.clock.NewTicker(resubmissionTimeout)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticker.Ch():
 			// Don't resubmit a transaction if it has been mined, but we are waiting for the conf depth.
 			if sendState.IsWaitingForConfirmation() {
 				continue
@@ -327,21 +605,113 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 			// If we see lots of unrecoverable errors (and no pending transactions) abort sending the transaction.
 			if sendState.ShouldAbortImmediately() {
 				m.l.Warn("Aborting transaction submission")
+				if sendState.feeCapReached {
+					m.metr.TxAbandonedAtFeeCap()
+				}
+				if sendState.IsNonceTooLow() {
+					return nil, errAbortNonceTooLow
+				}
 				return nil, errors.New("aborted transaction sending")
 			}
+			// The fees of a tx that is being replaced are not bumped, so that the replacement is confirmed.
+			if m.isReplacing(inflight) {
+				continue
+			}
+			// A tx cannot be included before the txs with lower nonces, so only the fees of the lowest
+			// tx that isn't mined yet are bumped.
+			if nonce, ok := m.lowerNonceNotMined(tx.Nonce()); ok {
+				m.l.Debug("Not bumping fees, waiting on a transaction with a lower nonce", "nonce", tx.Nonce(), "lower_nonce", nonce)
+				continue
+			}
 			tx = publishAndWait(tx, true)
 
 		case <-ctx.Done():
+			if sendState.feeCapReached {
+				m.metr.TxAbandonedAtFeeCap()
+			}
 			return nil, ctx.Err()
 
+		case <-inflight.replaced:
+			return nil, ErrTxReplaced
+
 		case receipt := <-receiptChan:
+			inflight.confirmed <- receipt
 			m.metr.RecordGasBumpCount(sendState.bumpCount)
+			m.metr.RecordTxConfirmationTime(m.clock.Now().Sub(start))
 			m.metr.TxConfirmed(receipt)
 			return receipt, nil
 		}
 	}
 }
 
+// inflightTx is a tx that is being sent.
+type inflightTx struct {
+	sendState *SendState
+	// tx is the latest published tx, guarded by the inflight lock
+	tx *types.Transaction
+	// replacing is set while a replacement of the tx is being sent, guarded by the inflight lock
+	replacing bool
+	// replaced is closed once a replacement of the tx is confirmed
+	replaced chan struct{}
+	// confirmed receives the receipt of the tx once it is confirmed
+	confirmed chan *types.Receipt
+	// done is closed once the tx isn't sent anymore
+	done chan struct{}
+}
+
+// trackInflight tracks the tx while it is being sent.
+func (m *SimpleTxManager) trackInflight(tx *types.Transaction, sendState *SendState) *inflightTx {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	if m.inflight == nil {
+		m.inflight = make(map[uint64]*inflightTx)
+	}
+	inflight := &inflightTx{
+		sendState: sendState,
+		tx:        tx,
+		replaced:  make(chan struct{}),
+		confirmed: make(chan *types.Receipt, 1),
+		done:      make(chan struct{}),
+	}
+	m.inflight[tx.Nonce()] = inflight
+	return inflight
+}
+
+// untrackInflight stops tracking the tx, unless a later tx with the same nonce is tracked meanwhile.
+func (m *SimpleTxManager) untrackInflight(inflight *inflightTx) {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	close(inflight.done)
+	nonce := inflight.tx.Nonce()
+	if m.inflight[nonce] == inflight {
+		delete(m.inflight, nonce)
+	}
+}
+
+func (m *SimpleTxManager) setInflightTx(inflight *inflightTx, tx *types.Transaction) {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	inflight.tx = tx
+}
+
+func (m *SimpleTxManager) isReplacing(inflight *inflightTx) bool {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	return inflight.replacing
+}
+
+// lowerNonceNotMined returns a nonce lower than the given nonce of a tx that is being sent, but isn't mined yet.
+func (m *SimpleTxManager) lowerNonceNotMined(nonce uint64) (uint64, bool) {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	for n, inflight := range m.inflight {
+		if n < nonce && !inflight.sendState.IsWaitingForConfirmation() {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
 // publishTx publishes the transaction to the transaction pool. If it receives any underpriced errors
 // it will bump the fees and retry.
 // Returns the latest fee bumped tx, and a boolean indicating whether the tx was sent or not
@@ -355,8 +725,19 @@ func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction,
 
 	for {
 		if bumpFeesImmediately {
-			newTx, err := m.increaseGasPrice(ctx, tx)
-			if err != nil {
+			if m.cfg.MaxFeeBumps != 0 && uint64(sendState.bumpCount) >= m.cfg.MaxFeeBumps {
+				l.Warn("Not bumping fees, max fee bumps reached, waiting on the published transactions", "bumps", sendState.bumpCount)
+				m.metr.FeeCapReached()
+				sendState.feeCapReached = true
+				return tx, false
+			}
+			newTx, err := m.increaseGasPrice(ctx, tx, !sendState.gasLimitPinned)
+			if errors.Is(err, ErrFeeCapReached) {
+				l.Warn("Not bumping fees, waiting on the published transactions", "err", err)
+				m.metr.FeeCapReached()
+				sendState.feeCapReached = true
+				return tx, false
+			} else if err != nil {
 				l.Error("unable to increase gas", "err", err)
 				m.metr.TxPublished("bump_failed")
 				return tx, false
@@ -373,16 +754,20 @@ func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction,
 			return tx, false
 		}
 
-		cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
-		err := m.backend.SendTransaction(cCtx, tx)
-		cancel()
+		err := m.sendTransaction(ctx, tx)
 		sendState.ProcessSendError(err)
 
 		if err == nil {
 			m.metr.TxPublished("")
+			if sendState.bumpCount > 0 {
+				m.metr.RecordPublishAttempt(metrics.PublishReplaced)
+			} else {
+				m.metr.RecordPublishAttempt(metrics.PublishOK)
+			}
 			log.Info("Transaction successfully published")
 			return tx, true
 		}
+		m.metr.RecordPublishAttempt(publishResult(err))
 
 		switch {
 		case errStringMatch(err, core.ErrNonceTooLow):
@@ -403,6 +788,28 @@ func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction,
 			l.Warn("transaction is underpriced", "err", err)
 			m.metr.TxPublished("tx_underpriced")
 			continue // retry with fee bump
+		case errStringMatch(err, core.ErrBlobFeeCapTooLow):
+			l.Warn("blob fee cap of the transaction is too low", "err", err, "blobFeeCap", tx.BlobGasFeeCap())
+			m.metr.TxPublished("tx_blob_fee_cap_too_low")
+			continue // retry with fee bump
+		case isGasLimitError(err):
+			l.Warn("transaction ran out of gas", "err", err, "gasLimit", tx.Gas())
+			m.metr.TxPublished("tx_out_of_gas")
+			if sendState.gasLimitPinned {
+				break
+			}
+			// the state changed since the gas of the tx was estimated, so resubmit it with a new estimate
+			if newTx, err := m.reestimateGas(ctx, tx); err != nil {
+				l.Warn("Not resubmitting transaction with a re-estimated gas limit", "err", err)
+			} else {
+				tx = newTx
+				bumpFeesImmediately = false
+				l = updateLogFields(tx)
+				continue
+			}
+		case errStringMatch(err, errAlreadyReserved):
+			l.Error("transaction type conflicts with a pending transaction of the account", "err", err, "type", tx.Type())
+			m.metr.TxPublished("tx_already_reserved")
 		default:
 			m.metr.RPCError()
 			l.Error("unable to publish transaction", "err", err)
@@ -414,10 +821,23 @@ func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction,
 	}
 }
 
+// publishResult returns the result of a publish attempt that failed with the given error, for the metrics.
+func publishResult(err error) string {
+	switch {
+	case errStringMatch(err, txpool.ErrAlreadyKnown):
+		return metrics.PublishOK
+	case errStringMatch(err, txpool.ErrReplaceUnderpriced), errStringMatch(err, txpool.ErrUnderpriced),
+		errStringMatch(err, core.ErrBlobFeeCapTooLow):
+		return metrics.PublishUnderpriced
+	default:
+		return metrics.PublishFailed
+	}
+}
+
 // waitForTx calls waitMined, and then sends the receipt to receiptChan in a non-blocking way if a receipt is found
 // for the transaction. It should be called in a separate goroutine.
 func (m *SimpleTxManager) waitForTx(ctx context.Context, tx *types.Transaction, sendState *SendState, receiptChan chan *types.Receipt) {
-	t := time.Now()
+	t := m.clock.Now()
 	// Poll for the transaction to be ready & then send the result to receiptChan
 	receipt, err := m.waitMined(ctx, tx, sendState)
 	if err != nil {
@@ -427,7 +847,7 @@ func (m *SimpleTxManager) waitForTx(ctx context.Context, tx *types.Transaction,
 	}
 	select {
 	case receiptChan <- receipt:
-		m.metr.RecordTxConfirmationLatency(time.Since(t).Milliseconds())
+		m.metr.RecordTxConfirmationLatency(m.clock.Now().Sub(t).Milliseconds())
 	default:
 	}
 }
@@ -435,13 +855,13 @@ func (m *SimpleTxManager) waitForTx(ctx context.Context, tx *types.Transaction,
 // waitMined waits for the transaction to be mined or for the context to be cancelled.
 func (m *SimpleTxManager) waitMined(ctx context.Context, tx *types.Transaction, sendState *SendState) (*types.Receipt, error) {
 	txHash := tx.Hash()
-	queryTicker := time.NewTicker(m.cfg.ReceiptQueryInterval)
+	queryTicker := m.clock.NewTicker(m.cfg.ReceiptQueryInterval)
 	defer queryTicker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
-		case <-queryTicker.C:
+		case <-queryTicker.Ch():
 			if receipt := m.queryReceipt(ctx, txHash, sendState); receipt != nil {
 				return receipt, nil
 			}
@@ -449,6 +869,39 @@ func (m *SimpleTxManager) waitMined(ctx context.Context, tx *types.Transaction,
 	}
 }
 
+// includedTx returns the first of the given txs that was included in a block, or nil if none was.
+func (m *SimpleTxManager) includedTx(ctx context.Context, txs []*types.Transaction) (*types.Transaction, error) {
+	for _, tx := range txs {
+		cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+		receipt, err := m.backend.TransactionReceipt(cCtx, tx.Hash())
+		cancel()
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		} else if err != nil {
+			m.metr.RPCError()
+			return nil, fmt.Errorf("failed to get the receipt of tx %v: %w", tx.Hash(), err)
+		}
+		if receipt != nil {
+			return tx, nil
+		}
+	}
+	return nil, nil
+}
+
+// waitConfirmed waits for the included tx to reach the confirmation depth, and returns its receipt.
+func (m *SimpleTxManager) waitConfirmed(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	sendState := NewSendStateWithNow(m.cfg.SafeAbortNonceTooLowCount, m.cfg.TxNotInMempoolTimeout, m.clock.Now)
+	receipt := m.queryReceipt(ctx, tx.Hash(), sendState)
+	if receipt == nil {
+		var err error
+		if receipt, err = m.waitMined(ctx, tx, sendState); err != nil {
+			return nil, err
+		}
+	}
+	m.metr.TxConfirmed(receipt)
+	return receipt, nil
+}
+
 // queryReceipt queries for the receipt and returns the receipt if it has passed the confirmation depth
 func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash, sendState *SendState) *types.Receipt {
 	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
@@ -503,15 +956,18 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 // are at least `priceBump` percent higher than the previous ones to satisfy Geth's replacement
 // rules, and no lower than the values returned by the fee suggestion algorithm to ensure it
 // doesn't linger in the mempool. Finally to avoid runaway price increases, fees are capped at a
-// `feeLimitMultiplier` multiple of the suggested values.
-func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
-	m.l.Info("bumping gas price for tx", "hash", tx.Hash(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap(), "gaslimit", tx.Gas())
-	tip, basefee, err := m.suggestGasPriceCaps(ctx)
+// `feeLimitMultiplier` multiple of the suggested values. Blob txs keep their blobs, and have all
+// their fees, including the blob fee cap, bumped by at least `blobPriceBump` percent instead.
+// The gas limit is re-estimated if reestimateGas is set.
+func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transaction, reestimateGas bool) (*types.Transaction, error) {
+	m.l.Info("bumping gas price for tx", "hash", tx.Hash(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap(), "gaslimit", tx.Gas(), "blobFeeCap", tx.BlobGasFeeCap())
+	tip, basefee, blobBaseFee, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
 		m.l.Warn("failed to get suggested gas tip and basefee", "err", err)
 		return nil, err
 	}
-	bumpedTip, bumpedFee := updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, basefee, m.l)
+	isBlobTx := tx.Type() == types.BlobTxType
+	bumpedTip, bumpedFee := updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, basefee, isBlobTx, m.l)
 
 	// Make sure increase is at most [FeeLimitMultiplier] the suggested values
 	maxTip := new(big.Int).Mul(tip, big.NewInt(int64(m.cfg.FeeLimitMultiplier)))
@@ -522,43 +978,53 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 	if bumpedFee.Cmp(maxFee) > 0 {
 		return nil, fmt.Errorf("bumped fee cap %v is over %dx multiple of the suggested value", bumpedFee, m.cfg.FeeLimitMultiplier)
 	}
-	rawTx := &types.DynamicFeeTx{
-		ChainID:    tx.ChainId(),
-		Nonce:      tx.Nonce(),
-		GasTipCap:  bumpedTip,
-		GasFeeCap:  bumpedFee,
-		To:         tx.To(),
-		Value:      tx.Value(),
-		Data:       tx.Data(),
-		AccessList: tx.AccessList(),
+	// Make sure increase is at most the configured max fees
+	if m.cfg.MaxGasTipCap != nil && bumpedTip.Cmp(m.cfg.MaxGasTipCap) > 0 {
+		return nil, fmt.Errorf("%w: bumped tip cap %v is over the max tip cap %v", ErrFeeCapReached, bumpedTip, m.cfg.MaxGasTipCap)
+	}
+	if m.cfg.MaxGasFeeCap != nil && bumpedFee.Cmp(m.cfg.MaxGasFeeCap) > 0 {
+		return nil, fmt.Errorf("%w: bumped fee cap %v is over the max fee cap %v", ErrFeeCapReached, bumpedFee, m.cfg.MaxGasFeeCap)
 	}
 
-	// Re-estimate gaslimit in case things have changed or a previous gaslimit estimate was wrong
-	gas, err := m.backend.EstimateGas(ctx, ethereum.CallMsg{
-		From:      m.cfg.From,
-		To:        rawTx.To,
-		GasTipCap: bumpedTip,
-		GasFeeCap: bumpedFee,
-		Data:      rawTx.Data,
-	})
-	if err != nil {
-		// If this is a transaction resubmission, we sometimes see this outcome because the
-		// original tx can get included in a block just before the above call. In this case the
-		// error is due to the tx reverting with message "block number must be equal to next
-		// expected block number"
-		m.l.Warn("failed to re-estimate gas", "err", err, "gaslimit", tx.Gas(),
-			"gasFeeCap", bumpedFee, "gasTipCap", bumpedTip)
-		return nil, err
+	// Re-estimate gaslimit in case things have changed or a previous gaslimit estimate was wrong,
+	// unless the gas limit is pinned
+	gas := tx.Gas()
+	if reestimateGas {
+		gas, err = m.estimateGas(ctx, ethereum.CallMsg{
+			From:      m.cfg.From,
+			To:        tx.To(),
+			GasTipCap: bumpedTip,
+			GasFeeCap: bumpedFee,
+			Data:      tx.Data(),
+		})
+		if err != nil {
+			// If this is a transaction resubmission, we sometimes see this outcome because the
+			// original tx can get included in a block just before the above call. In this case the
+			// error is due to the tx reverting with message "block number must be equal to next
+			// expected block number"
+			m.l.Warn("failed to re-estimate gas", "err", err, "gaslimit", tx.Gas(),
+				"gasFeeCap", bumpedFee, "gasTipCap", bumpedTip)
+			return nil, err
+		}
+		if tx.Gas() != gas {
+			m.l.Info("re-estimated gas differs", "oldgas", tx.Gas(), "newgas", gas,
+				"gasFeeCap", bumpedFee, "gasTipCap", bumpedTip)
+		}
 	}
-	if tx.Gas() != gas {
-		m.l.Info("re-estimated gas differs", "oldgas", tx.Gas(), "newgas", gas,
-			"gasFeeCap", bumpedFee, "gasTipCap", bumpedTip)
+
+	var bumpedBlobFee *big.Int
+	if isBlobTx {
+		if blobBaseFee == nil {
+			return nil, ErrBlobsBeforeCancun
+		}
+		bumpedBlobFee = updateBlobFee(tx.BlobGasFeeCap(), blobBaseFee, m.l)
+		maxBlobFee := new(big.Int).Mul(calcBlobFeeCap(blobBaseFee), big.NewInt(int64(m.cfg.FeeLimitMultiplier)))
+		if bumpedBlobFee.Cmp(maxBlobFee) > 0 {
+			return nil, fmt.Errorf("bumped blob fee cap %v is over %dx multiple of the suggested value", bumpedBlobFee, m.cfg.FeeLimitMultiplier)
+		}
 	}
-	rawTx.Gas = gas
 
-	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
-	defer cancel()
-	newTx, err := m.cfg.Signer(ctx, m.cfg.From, types.NewTx(rawTx))
+	newTx, err := m.sign(ctx, types.NewTx(resubmissionMessage(tx, bumpedTip, bumpedFee, bumpedBlobFee, gas)))
 	if err != nil {
 		m.l.Warn("failed to sign new transaction", "err", err)
 		return tx, nil
@@ -566,49 +1032,138 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 	return newTx, nil
 }
 
-// suggestGasPriceCaps suggests what the new tip & new basefee should be based on the current L1 conditions
-func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, error) {
+// reestimateGas returns the tx with the same fees and a new gas estimate, if the estimate is higher than
+// the gas limit of the tx.
+func (m *SimpleTxManager) reestimateGas(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	gas, err := m.estimateGas(ctx, ethereum.CallMsg{
+		From:      m.cfg.From,
+		To:        tx.To(),
+		GasTipCap: tx.GasTipCap(),
+		GasFeeCap: tx.GasFeeCap(),
+		Data:      tx.Data(),
+		Value:     tx.Value(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-estimate gas: %w", err)
+	}
+	if gas <= tx.Gas() {
+		return nil, fmt.Errorf("re-estimated gas %d is not higher than the gas limit %d", gas, tx.Gas())
+	}
+	m.l.Info("Re-estimated gas", "oldgas", tx.Gas(), "newgas", gas)
+	var blobFeeCap *big.Int
+	if tx.Type() == types.BlobTxType {
+		blobFeeCap = tx.BlobGasFeeCap()
+	}
+	return m.sign(ctx, types.NewTx(resubmissionMessage(tx, tx.GasTipCap(), tx.GasFeeCap(), blobFeeCap, gas)))
+}
+
+// estimateGas estimates the gas of the call, with the configured gas limit buffer added.
+func (m *SimpleTxManager) estimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	gas, err := m.backend.EstimateGas(ctx, msg)
+	if err != nil {
+		return 0, err
+	}
+	return gas + gas*m.cfg.GasLimitBuffer/100, nil
+}
+
+// resubmissionMessage returns the tx message to resubmit the tx with, with the given fees and gas limit.
+// The blob fee cap is only used for blob txs.
+func resubmissionMessage(tx *types.Transaction, gasTipCap, gasFeeCap, blobFeeCap *big.Int, gas uint64) types.TxData {
+	if tx.Type() == types.BlobTxType {
+		return &types.BlobTx{
+			ChainID:    uint256.MustFromBig(tx.ChainId()),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  uint256.MustFromBig(gasTipCap),
+			GasFeeCap:  uint256.MustFromBig(gasFeeCap),
+			Gas:        gas,
+			To:         *tx.To(),
+			Value:      uint256.MustFromBig(tx.Value()),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+			BlobFeeCap: uint256.MustFromBig(blobFeeCap),
+			BlobHashes: tx.BlobHashes(),
+			Sidecar:    tx.BlobTxSidecar(),
+		}
+	}
+	return &types.DynamicFeeTx{
+		ChainID:    tx.ChainId(),
+		Nonce:      tx.Nonce(),
+		GasTipCap:  gasTipCap,
+		GasFeeCap:  gasFeeCap,
+		Gas:        gas,
+		To:         tx.To(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	}
+}
+
+// suggestGasPriceCaps suggests what the new tip, new basefee & new blob basefee should be based on the current L1 conditions.
+// The blob basefee is nil if L1 did not activate Cancun yet.
+func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, *big.Int, error) {
 	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
 	tip, err := m.backend.SuggestGasTipCap(cCtx)
 	if err != nil {
 		m.metr.RPCError()
-		return nil, nil, fmt.Errorf("failed to fetch the suggested gas tip cap: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to fetch the suggested gas tip cap: %w", err)
 	} else if tip == nil {
-		return nil, nil, errors.New("the suggested tip was nil")
+		return nil, nil, nil, errors.New("the suggested tip was nil")
 	}
 	cCtx, cancel = context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
 	head, err := m.backend.HeaderByNumber(cCtx, nil)
 	if err != nil {
 		m.metr.RPCError()
-		return nil, nil, fmt.Errorf("failed to fetch the suggested basefee: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to fetch the suggested basefee: %w", err)
 	} else if head.BaseFee == nil {
-		return nil, nil, errors.New("txmgr does not support pre-london blocks that do not have a basefee")
+		return nil, nil, nil, errors.New("txmgr does not support pre-london blocks that do not have a basefee")
+	}
+	var blobBaseFee *big.Int
+	if head.ExcessBlobGas != nil {
+		blobBaseFee = eip4844.CalcBlobFee(*head.ExcessBlobGas)
 	}
-	return tip, head.BaseFee, nil
+	m.metr.RecordTipCap(tip)
+	m.metr.RecordBaseFee(head.BaseFee)
+	return tip, head.BaseFee, blobBaseFee, nil
 }
 
-// calcThresholdValue returns x * priceBumpPercent / 100
-func calcThresholdValue(x *big.Int) *big.Int {
-	threshold := new(big.Int).Mul(priceBumpPercent, x)
+// calcThresholdValue returns x * priceBumpPercent / 100, or x * blobPriceBumpPercent / 100 for blob txs
+func calcThresholdValue(x *big.Int, isBlobTx bool) *big.Int {
+	percent := priceBumpPercent
+	if isBlobTx {
+		percent = blobPriceBumpPercent
+	}
+	threshold := new(big.Int).Mul(percent, x)
 	threshold = threshold.Div(threshold, oneHundred)
 	return threshold
 }
 
+// updateBlobFee returns the blob fee cap to replace a blob tx with: the max of the fee cap that satisfies
+// geth's required blob fee bump, and the fee cap suggested by the new blob basefee.
+func updateBlobFee(oldBlobFeeCap, newBlobBaseFee *big.Int, lgr log.Logger) *big.Int {
+	threshold := calcThresholdValue(oldBlobFeeCap, true)
+	newBlobFeeCap := calcBlobFeeCap(newBlobBaseFee)
+	lgr.Debug("Updating blob fee cap", "old_blobFeeCap", oldBlobFeeCap, "threshold_blobFeeCap", threshold, "new_blobFeeCap", newBlobFeeCap)
+	if newBlobFeeCap.Cmp(threshold) >= 0 {
+		return newBlobFeeCap
+	}
+	return threshold
+}
+
 // updateFees takes an old transaction's tip & fee cap plus a new tip & basefee, and returns
 // a suggested tip and fee cap such that:
 //
-//	(a) each satisfies geth's required tx-replacement fee bumps (we use a 10% increase), and
+//	(a) each satisfies geth's required tx-replacement fee bumps (we use a 10% increase, or 100% for blob txs), and
 //	(b) gasTipCap is no less than new tip, and
 //	(c) gasFeeCap is no less than calcGasFee(newBaseFee, newTip)
-func updateFees(oldTip, oldFeeCap, newTip, newBaseFee *big.Int, lgr log.Logger) (*big.Int, *big.Int) {
+func updateFees(oldTip, oldFeeCap, newTip, newBaseFee *big.Int, isBlobTx bool, lgr log.Logger) (*big.Int, *big.Int) {
 	newFeeCap := calcGasFeeCap(newBaseFee, newTip)
 	lgr = lgr.New("old_gasTipCap", oldTip, "old_gasFeeCap", oldFeeCap,
 		"new_gasTipCap", newTip, "new_gasFeeCap", newFeeCap,
 		"new_basefee", newBaseFee)
-	thresholdTip := calcThresholdValue(oldTip)
-	thresholdFeeCap := calcThresholdValue(oldFeeCap)
+	thresholdTip := calcThresholdValue(oldTip, isBlobTx)
+	thresholdFeeCap := calcThresholdValue(oldFeeCap, isBlobTx)
 	if newTip.Cmp(thresholdTip) >= 0 && newFeeCap.Cmp(thresholdFeeCap) >= 0 {
 		lgr.Debug("Using new tip and feecap")
 		return newTip, newFeeCap
@@ -641,6 +1196,33 @@ func calcGasFeeCap(baseFee, gasTipCap *big.Int) *big.Int {
 	)
 }
 
+// capFees returns the tip and fee caps, capped at the configured max fees. The tip cap is capped at
+// the fee cap too, so that the tx stays valid. The same values are returned if they are within the limits.
+func (m *SimpleTxManager) capFees(gasTipCap, gasFeeCap *big.Int) (*big.Int, *big.Int) {
+	if m.cfg.MaxGasFeeCap != nil && gasFeeCap.Cmp(m.cfg.MaxGasFeeCap) > 0 {
+		gasFeeCap = m.cfg.MaxGasFeeCap
+	}
+	if m.cfg.MaxGasTipCap != nil && gasTipCap.Cmp(m.cfg.MaxGasTipCap) > 0 {
+		gasTipCap = m.cfg.MaxGasTipCap
+	}
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		gasTipCap = gasFeeCap
+	}
+	return gasTipCap, gasFeeCap
+}
+
+// calcBlobFeeCap computes a suggested blob fee cap that is twice the current blob basefee,
+// to absorb blob basefee increases until the tx is included.
+func calcBlobFeeCap(blobBaseFee *big.Int) *big.Int {
+	return new(big.Int).Mul(blobBaseFee, big.NewInt(2))
+}
+
+// isGasLimitError returns whether the error of a publish indicates that the gas limit of the tx is too low.
+func isGasLimitError(err error) bool {
+	return errStringMatch(err, core.ErrIntrinsicGas) || errStringMatch(err, vm.ErrOutOfGas) ||
+		errStringMatch(err, vm.ErrExecutionReverted)
+}
+
 // errStringMatch returns true if err.Error() is a substring in target.Error() or if both are nil.
 // It can accept nil errors without issue.
 func errStringMatch(err, target error) bool {
@@ -5,20 +5,31 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
 )
 
 type sendTransactionFunc func(ctx context.Context, tx *types.Transaction) error
@@ -44,6 +55,7 @@ func newTestHarnessWithConfig(t *testing.T, cfg Config) *testHarness {
 	mgr := &SimpleTxManager{
 		chainID: cfg.ChainID,
 		name:    "TEST",
+		clock:   clock.SystemClock,
 		cfg:     cfg,
 		backend: cfg.Backend,
 		l:       testlog.Logger(t, log.LvlCrit),
@@ -94,6 +106,7 @@ type gasPricer struct {
 	mineAtEpoch   int64
 	baseGasTipFee *big.Int
 	baseBaseFee   *big.Int
+	excessBlobGas *uint64
 	err           error
 	mu            sync.Mutex
 }
@@ -157,6 +170,12 @@ type mockBackend struct {
 
 	// minedTxs maps the hash of a mined transaction to its details.
 	minedTxs map[common.Hash]minedTxInfo
+
+	// nonce is the nonce of the account, which is the nonce of its next tx.
+	nonce uint64
+
+	// gasEstimate is the gas estimate of all txs if non-zero, the basefee otherwise.
+	gasEstimate uint64
 }
 
 // newMockBackend initializes a new mockBackend.
@@ -188,6 +207,22 @@ func (b *mockBackend) mine(txHash *common.Hash, gasFeeCap *big.Int) {
 	}
 }
 
+// setNonce sets the nonce of the account, e.g. to simulate txs that are sent by another process.
+func (b *mockBackend) setNonce(nonce uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nonce = nonce
+}
+
+// setGasEstimate sets the gas estimate of all txs, e.g. to simulate state changes.
+func (b *mockBackend) setGasEstimate(gas uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.gasEstimate = gas
+}
+
 // BlockNumber returns the most recent block number.
 func (b *mockBackend) BlockNumber(ctx context.Context) (uint64, error) {
 	b.mu.RLock()
@@ -203,7 +238,8 @@ func (b *mockBackend) CallContract(ctx context.Context, call ethereum.CallMsg, b
 
 func (b *mockBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
 	return &types.Header{
-		BaseFee: b.g.basefee(),
+		BaseFee:       b.g.basefee(),
+		ExcessBlobGas: b.g.excessBlobGas,
 	}, nil
 }
 
@@ -214,6 +250,11 @@ func (b *mockBackend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (ui
 	if msg.GasFeeCap.Cmp(msg.GasTipCap) < 0 {
 		return 0, core.ErrTipAboveFeeCap
 	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.gasEstimate != 0 {
+		return b.gasEstimate, nil
+	}
 	return b.g.basefee().Uint64(), nil
 }
 
@@ -230,11 +271,17 @@ func (b *mockBackend) SendTransaction(ctx context.Context, tx *types.Transaction
 }
 
 func (b *mockBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
-	return 0, nil
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.nonce, nil
 }
 
 func (b *mockBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
-	return 0, nil
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.nonce, nil
 }
 
 func (*mockBackend) ChainID(ctx context.Context) (*big.Int, error) {
@@ -356,6 +403,66 @@ func TestTxMgrConfirmsAtHigherGasPrice(t *testing.T) {
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
 }
 
+// confirmationTimeMetrics records the confirmation time of the last confirmed tx.
+type confirmationTimeMetrics struct {
+	metrics.NoopTxMetrics
+	confirmationTime time.Duration
+}
+
+func (m *confirmationTimeMetrics) RecordTxConfirmationTime(d time.Duration) {
+	m.confirmationTime = d
+}
+
+// TestTxMgrResubmitsWithClock asserts that the resubmissions and receipt queries are timed by the clock
+// of the tx manager, so that hour long resubmission timeouts pass instantly with a deterministic clock.
+func TestTxMgrResubmitsWithClock(t *testing.T) {
+	t.Parallel()
+
+	conf := configWithNumConfs(1)
+	conf.ResubmissionTimeout = time.Hour
+	conf.ReceiptQueryInterval = time.Minute
+	h := newTestHarnessWithConfig(t, conf)
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	h.mgr.clock = clk
+	metr := &confirmationTimeMetrics{}
+	h.mgr.metr = metr
+
+	gasTipCap, gasFeeCap := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		if h.gasPricer.shouldMine(tx.GasFeeCap()) {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		// advance the clock until the tx is confirmed
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				clk.AdvanceTime(conf.ReceiptQueryInterval)
+				runtime.Gosched()
+			}
+		}
+	}()
+	receipt, err := h.mgr.sendTx(ctx, tx)
+	close(done)
+	require.NoError(t, err)
+	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
+	// the fees are bumped twice, after a resubmission timeout each, before the tx is mined
+	require.GreaterOrEqual(t, metr.confirmationTime, 2*conf.ResubmissionTimeout)
+}
+
 // errRpcFailure is a sentinel error used in testing to fail publications.
 var errRpcFailure = errors.New("rpc failure")
 
@@ -409,6 +516,38 @@ func TestTxMgr_CraftTx(t *testing.T) {
 	require.Equal(t, candidate.GasLimit, tx.Gas())
 }
 
+// TestTxMgr_CraftBlobTx ensures that the tx manager creates blob transactions, with a sidecar of the
+// candidate blobs, once L1 activated Cancun.
+func TestTxMgr_CraftBlobTx(t *testing.T) {
+	t.Parallel()
+	cfg := configWithNumConfs(1)
+	cfg.ChainID = big.NewInt(900)
+	h := newTestHarnessWithConfig(t, cfg)
+	candidate := h.createTxCandidate()
+	candidate.TxData = nil
+	var blob eth.Blob
+	require.NoError(t, blob.FromData([]byte("batch data")))
+	candidate.Blobs = []*eth.Blob{&blob, &blob}
+
+	_, err := h.mgr.craftTx(context.Background(), candidate)
+	require.ErrorIs(t, err, ErrBlobsBeforeCancun)
+
+	excessBlobGas := uint64(10 * params.BlobTxBlobGasPerBlob)
+	h.gasPricer.excessBlobGas = &excessBlobGas
+	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), tx.Type())
+	require.Equal(t, *candidate.To, *tx.To())
+	require.Equal(t, calcBlobFeeCap(eip4844.CalcBlobFee(excessBlobGas)), tx.BlobGasFeeCap())
+	require.Equal(t, uint64(2*params.BlobTxBlobGasPerBlob), tx.BlobGas())
+	require.Len(t, tx.BlobHashes(), 2)
+	require.NotNil(t, tx.BlobTxSidecar())
+	require.Equal(t, tx.BlobHashes(), tx.BlobTxSidecar().BlobHashes())
+	for i, blob := range tx.BlobTxSidecar().Blobs {
+		require.NoError(t, kzg4844.VerifyBlobProof(blob, tx.BlobTxSidecar().Commitments[i], tx.BlobTxSidecar().Proofs[i]))
+	}
+}
+
 // TestTxMgr_EstimateGas ensures that the tx manager will estimate
 // the gas when candidate gas limit is zero in [CraftTx].
 func TestTxMgr_EstimateGas(t *testing.T) {
@@ -490,6 +629,69 @@ func TestTxMgr_SigningFails(t *testing.T) {
 	require.Equal(t, lastNonce+1, tx.Nonce())
 }
 
+// signerErrorMetrics counts the recorded signer errors.
+type signerErrorMetrics struct {
+	metrics.NoopTxMetrics
+	signerErrors int
+}
+
+func (m *signerErrorMetrics) SignerError() {
+	m.signerErrors++
+}
+
+// TestTxMgr_SigningRetriesUnreachableSigner asserts that signing is retried, and the failures are recorded,
+// only if the signer cannot be reached. It mutates the signing retry strategy, so it must not run in parallel.
+func TestTxMgr_SigningRetriesUnreachableSigner(t *testing.T) {
+	defer func(strategy retry.Strategy) { signingRetryStrategy = strategy }(signingRetryStrategy)
+	signingRetryStrategy = retry.Fixed(0)
+
+	errRejected := errors.New("signer rejected the tx")
+	errUnreachable := fmt.Errorf("eth_signTransaction failed: %w: connection refused", opsigner.ErrUnreachable)
+	tests := []struct {
+		name string
+		// results are the results of the signing attempts, the last result repeats
+		results      []error
+		err          error
+		signs        int
+		signerErrors int
+	}{
+		{name: "signed", results: []error{nil}, signs: 1},
+		{name: "reached after failures", results: []error{errUnreachable, errUnreachable, nil}, signs: 3, signerErrors: 2},
+		{name: "unreachable", results: []error{errUnreachable}, err: opsigner.ErrUnreachable,
+			signs: signingAttempts, signerErrors: signingAttempts},
+		{name: "rejected", results: []error{errRejected}, err: errRejected, signs: 1},
+	}
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			var signs int
+			cfg := configWithNumConfs(1)
+			cfg.NetworkTimeout = time.Second
+			cfg.Signer = func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				err := tc.results[min(signs, len(tc.results)-1)]
+				signs++
+				if err != nil {
+					return nil, err
+				}
+				return tx, nil
+			}
+			h := newTestHarnessWithConfig(t, cfg)
+			metr := &signerErrorMetrics{}
+			h.mgr.metr = metr
+
+			tx, err := h.mgr.craftTx(context.Background(), h.createTxCandidate())
+			require.Equal(t, tc.signs, signs)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, tx)
+			}
+			require.Equal(t, tc.signerErrors, metr.signerErrors)
+		})
+	}
+}
+
 // TestTxMgrOnlyOnePublicationSucceeds asserts that the tx manager will return a
 // receipt so long as at least one of the publications is able to succeed with a
 // simulated rpc failure.
@@ -698,6 +900,7 @@ type failingBackend struct {
 	returnSuccessBlockNumber bool
 	returnSuccessReceipt     bool
 	baseFee, gasTip          *big.Int
+	excessBlobGas            *uint64
 }
 
 // BlockNumber for the failingBackend returns errRpcFailure on the first
@@ -729,7 +932,8 @@ func (b *failingBackend) TransactionReceipt(
 
 func (b *failingBackend) HeaderByNumber(_ context.Context, _ *big.Int) (*types.Header, error) {
 	return &types.Header{
-		BaseFee: b.baseFee,
+		BaseFee:       b.baseFee,
+		ExcessBlobGas: b.excessBlobGas,
 	}, nil
 }
 
@@ -780,6 +984,7 @@ func TestWaitMinedReturnsReceiptAfterFailure(t *testing.T) {
 			SafeAbortNonceTooLowCount: 3,
 		},
 		name:    "TEST",
+		clock:   clock.SystemClock,
 		backend: &borkedBackend,
 		l:       testlog.Logger(t, log.LvlCrit),
 		metr:    &metrics.NoopTxMetrics{},
@@ -817,6 +1022,7 @@ func doGasPriceIncrease(t *testing.T, txTipCap, txFeeCap, newTip, newBaseFee int
 			From: common.Address{},
 		},
 		name:    "TEST",
+		clock:   clock.SystemClock,
 		backend: &borkedBackend,
 		l:       testlog.Logger(t, log.LvlCrit),
 		metr:    &metrics.NoopTxMetrics{},
@@ -826,7 +1032,7 @@ func doGasPriceIncrease(t *testing.T, txTipCap, txFeeCap, newTip, newBaseFee int
 		GasTipCap: big.NewInt(txTipCap),
 		GasFeeCap: big.NewInt(txFeeCap),
 	})
-	newTx, err := mgr.increaseGasPrice(context.Background(), tx)
+	newTx, err := mgr.increaseGasPrice(context.Background(), tx, true)
 	require.NoError(t, err)
 	return tx, newTx
 }
@@ -894,6 +1100,58 @@ func TestIncreaseGasPrice(t *testing.T) {
 	}
 }
 
+func TestIncreaseGasPriceBlobTx(t *testing.T) {
+	excessBlobGas := uint64(0) // blob basefee of 1
+	mgr := &SimpleTxManager{
+		cfg: Config{
+			FeeLimitMultiplier: 5,
+			Signer: func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, nil
+			},
+		},
+		name:  "TEST",
+		clock: clock.SystemClock,
+		backend: &failingBackend{
+			gasTip:        big.NewInt(101),
+			baseFee:       big.NewInt(460),
+			excessBlobGas: &excessBlobGas,
+		},
+		l:    testlog.Logger(t, log.LvlCrit),
+		metr: &metrics.NoopTxMetrics{},
+	}
+	var blob eth.Blob
+	sidecar, blobHashes, err := MakeSidecar([]*eth.Blob{&blob})
+	require.NoError(t, err)
+	tx := types.NewTx(&types.BlobTx{
+		ChainID:    uint256.NewInt(900),
+		GasTipCap:  uint256.NewInt(100),
+		GasFeeCap:  uint256.NewInt(1000),
+		Value:      uint256.NewInt(0),
+		BlobFeeCap: uint256.NewInt(2),
+		BlobHashes: blobHashes,
+		Sidecar:    sidecar,
+	})
+
+	newTx, err := mgr.increaseGasPrice(context.Background(), tx, true)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), newTx.Type())
+	require.Equal(t, big.NewInt(200), newTx.GasTipCap(), "blob tx tip must be bumped by 100%")
+	require.Equal(t, big.NewInt(2000), newTx.GasFeeCap(), "blob tx fee cap must be bumped by 100%")
+	require.Equal(t, big.NewInt(4), newTx.BlobGasFeeCap(), "blob fee cap must be bumped by 100%")
+	require.Equal(t, blobHashes, newTx.BlobHashes())
+	require.Equal(t, sidecar, newTx.BlobTxSidecar(), "the sidecar is kept")
+
+	// the blob fee cap is limited like the other fees
+	tx = newTx
+	for i := 0; i < 2; i++ {
+		tx, err = mgr.increaseGasPrice(context.Background(), tx, true)
+		if err != nil {
+			break
+		}
+	}
+	require.ErrorContains(t, err, "is over 5x multiple of the suggested value")
+}
+
 // TestIncreaseGasPriceNotExponential asserts that if the L1 basefee & tip remain the
 // same, repeated calls to IncreaseGasPrice do not continually increase the gas price.
 func TestIncreaseGasPriceNotExponential(t *testing.T) {
@@ -919,6 +1177,7 @@ func TestIncreaseGasPriceNotExponential(t *testing.T) {
 			From: common.Address{},
 		},
 		name:    "TEST",
+		clock:   clock.SystemClock,
 		backend: &borkedBackend,
 		l:       testlog.Logger(t, log.LvlCrit),
 		metr:    &metrics.NoopTxMetrics{},
@@ -931,7 +1190,7 @@ func TestIncreaseGasPriceNotExponential(t *testing.T) {
 	// Run IncreaseGasPrice a bunch of times in a row to simulate a very fast resubmit loop.
 	ctx := context.Background()
 	for {
-		newTx, err := mgr.increaseGasPrice(ctx, tx)
+		newTx, err := mgr.increaseGasPrice(ctx, tx, true)
 		if err != nil {
 			break
 		}
@@ -941,7 +1200,7 @@ func TestIncreaseGasPriceNotExponential(t *testing.T) {
 	require.Equal(t, lastTip.Int64(), int64(36))
 	require.Equal(t, lastFee.Int64(), int64(493))
 	// Confirm that fees stop rising
-	_, err := mgr.increaseGasPrice(ctx, tx)
+	_, err := mgr.increaseGasPrice(ctx, tx, true)
 	require.Error(t, err)
 }
 
@@ -967,6 +1226,41 @@ func TestErrStringMatch(t *testing.T) {
 	}
 }
 
+// TestTxMgrCandidateResubmissionTimeout asserts that the resubmission timeout of a tx candidate
+// overrides the configured resubmission timeout, so that its fees are bumped sooner.
+func TestTxMgrCandidateResubmissionTimeout(t *testing.T) {
+	t.Parallel()
+
+	conf := configWithNumConfs(1)
+	conf.ResubmissionTimeout = time.Hour
+	h := newTestHarnessWithConfig(t, conf)
+
+	var (
+		published int
+		minedFee  *big.Int
+	)
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		published++
+		// only the second fee bump is included
+		if published == 3 {
+			txHash := tx.Hash()
+			minedFee = tx.GasFeeCap()
+			h.backend.mine(&txHash, minedFee)
+		}
+		return nil
+	}
+	h.backend.setTxSender(sendTx)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	candidate := h.createTxCandidate()
+	candidate.ResubmissionTimeout = 10 * time.Millisecond
+	receipt, err := h.mgr.Send(ctx, candidate)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	require.Equal(t, minedFee.Uint64(), receipt.GasUsed, "the bumped tx is included well before the configured resubmission timeout")
+}
+
 func TestNonceReset(t *testing.T) {
 	conf := configWithNumConfs(1)
 	conf.SafeAbortNonceTooLowCount = 1
@@ -1003,3 +1297,568 @@ func TestNonceReset(t *testing.T) {
 	// internal nonce tracking should be reset every 3rd tx
 	require.Equal(t, []uint64{0, 0, 1, 2, 0, 1, 2, 0}, nonces)
 }
+
+// feeCapMetrics counts the recorded fee caps reached.
+type feeCapMetrics struct {
+	metrics.NoopTxMetrics
+	feeCapsReached int
+}
+
+func (m *feeCapMetrics) FeeCapReached() {
+	m.feeCapsReached++
+}
+
+// TestTxMgrFeeLimits asserts that the fees of a tx that is never mined are bumped up to the configured
+// limits, after which the published txs are waited on, and that no tx over the limits is ever signed.
+func TestTxMgrFeeLimits(t *testing.T) {
+	tests := []struct {
+		name                       string
+		maxGasTipCap, maxGasFeeCap *big.Int
+		maxFeeBumps                uint64
+		// signs is the number of signed fee bumps, with the fees of the gas pricer growing per epoch
+		signs int
+	}{
+		// the fee cap is 19 per epoch, bumped at epochs 2 and 3, and capped at epoch 4
+		{name: "max gas fee cap", maxGasFeeCap: big.NewInt(60), signs: 2},
+		// the tip cap is 5 per epoch, bumped at epochs 2, 3 and 4, and capped at epoch 5
+		{name: "max gas tip cap", maxGasTipCap: big.NewInt(20), signs: 3},
+		{name: "max fee bumps", maxFeeBumps: 4, signs: 4},
+	}
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var signed []*types.Transaction
+			cfg := configWithNumConfs(1)
+			cfg.ResubmissionTimeout = 10 * time.Millisecond
+			cfg.NetworkTimeout = time.Second
+			cfg.MaxGasTipCap, cfg.MaxGasFeeCap, cfg.MaxFeeBumps = tc.maxGasTipCap, tc.maxGasFeeCap, tc.maxFeeBumps
+			cfg.Signer = func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				signed = append(signed, tx)
+				return tx, nil
+			}
+			h := newTestHarnessWithConfig(t, cfg)
+			metr := &feeCapMetrics{}
+			h.mgr.metr = metr
+			h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+				return nil
+			})
+
+			gasTipCap, gasFeeCap := h.gasPricer.sample()
+			tx := types.NewTx(&types.DynamicFeeTx{
+				GasTipCap: gasTipCap,
+				GasFeeCap: gasFeeCap,
+			})
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+			_, err := h.mgr.sendTx(ctx, tx)
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+
+			require.Len(t, signed, tc.signs)
+			for _, tx := range signed {
+				if tc.maxGasTipCap != nil {
+					require.LessOrEqual(t, tx.GasTipCap().Cmp(tc.maxGasTipCap), 0, "tip cap over the limit")
+				}
+				if tc.maxGasFeeCap != nil {
+					require.LessOrEqual(t, tx.GasFeeCap().Cmp(tc.maxGasFeeCap), 0, "fee cap over the limit")
+				}
+			}
+			require.Positive(t, metr.feeCapsReached, "fee cap reached is recorded while waiting")
+		})
+	}
+}
+
+// TestTxMgr_CraftTxFeeLimits asserts that the fees of a new tx are capped at the configured limits.
+func TestTxMgr_CraftTxFeeLimits(t *testing.T) {
+	t.Parallel()
+	cfg := configWithNumConfs(1)
+	cfg.MaxGasTipCap = big.NewInt(3)
+	cfg.MaxGasFeeCap = big.NewInt(10)
+	h := newTestHarnessWithConfig(t, cfg)
+	metr := &feeCapMetrics{}
+	h.mgr.metr = metr
+
+	// the suggested tip cap is 5, and fee cap 19
+	tx, err := h.mgr.craftTx(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3), tx.GasTipCap())
+	require.Equal(t, big.NewInt(10), tx.GasFeeCap())
+	require.Equal(t, 1, metr.feeCapsReached)
+
+	cfg.MaxGasTipCap = big.NewInt(20)
+	h = newTestHarnessWithConfig(t, cfg)
+	tx, err = h.mgr.craftTx(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(5), tx.GasTipCap())
+	require.Equal(t, big.NewInt(10), tx.GasFeeCap())
+}
+
+// nonceResyncMetrics counts the recorded nonce resyncs.
+type nonceResyncMetrics struct {
+	metrics.NoopTxMetrics
+	resyncs atomic.Int64
+}
+
+func (m *nonceResyncMetrics) NonceResync() {
+	m.resyncs.Add(1)
+}
+
+// mineNextNonce returns a sendTransactionFunc that mines txs with the nonce of the account, and rejects txs with
+// a lower nonce, as they were replaced by other txs. It collects the nonces of the mined txs.
+func (h testHarness) mineNextNonce(mined *[]uint64) sendTransactionFunc {
+	return func(ctx context.Context, tx *types.Transaction) error {
+		nonce, _ := h.backend.PendingNonceAt(ctx, h.cfg.From)
+		if tx.Nonce() < nonce {
+			return core.ErrNonceTooLow
+		}
+		if tx.Nonce() == nonce {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+			h.backend.setNonce(nonce + 1)
+			*mined = append(*mined, tx.Nonce())
+		}
+		return nil
+	}
+}
+
+// TestTxMgrResyncsNonceAfterExternalSends asserts that txs confirm when other processes send txs from the same
+// account in between them, as the nonce is resynced and the txs are rebuilt within the same Send.
+func TestTxMgrResyncsNonceAfterExternalSends(t *testing.T) {
+	t.Parallel()
+
+	conf := configWithNumConfs(1)
+	conf.SafeAbortNonceTooLowCount = 1
+	conf.ResubmissionTimeout = 10 * time.Millisecond
+	conf.ReceiptQueryInterval = time.Millisecond
+	h := newTestHarnessWithConfig(t, conf)
+	metr := &nonceResyncMetrics{}
+	h.mgr.metr = metr
+
+	var mined []uint64
+	h.backend.setTxSender(h.mineNextNonce(&mined))
+
+	// the number of txs that are sent by another process before each tx
+	externalTxs := []uint64{0, 1, 0, 1, 2, 0}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, n := range externalTxs {
+		nonce, _ := h.backend.PendingNonceAt(ctx, h.cfg.From)
+		h.backend.setNonce(nonce + n)
+		receipt, err := h.mgr.Send(ctx, h.createTxCandidate())
+		require.NoError(t, err)
+		require.NotNil(t, receipt)
+	}
+
+	require.Equal(t, []uint64{0, 2, 3, 5, 8, 9}, mined)
+	require.EqualValues(t, 3, metr.resyncs.Load())
+}
+
+// TestTxMgrNonceResyncAttempts asserts that a Send gives up if the nonce is used by other processes every time.
+func TestTxMgrNonceResyncAttempts(t *testing.T) {
+	t.Parallel()
+
+	conf := configWithNumConfs(1)
+	conf.SafeAbortNonceTooLowCount = 1
+	conf.ResubmissionTimeout = 10 * time.Millisecond
+	conf.ReceiptQueryInterval = time.Millisecond
+	h := newTestHarnessWithConfig(t, conf)
+
+	var mined []uint64
+	mineNextNonce := h.mineNextNonce(&mined)
+	sends := 0
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		sends++
+		// another process sends a tx with the nonce first
+		nonce, _ := h.backend.PendingNonceAt(ctx, h.cfg.From)
+		h.backend.setNonce(nonce + 1)
+		return mineNextNonce(ctx, tx)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.ErrorIs(t, err, core.ErrNonceTooLow)
+	require.Equal(t, nonceResyncAttempts, sends)
+	require.Empty(t, mined)
+}
+
+// TestTxMgrNoResyncAfterPublishedTxIncluded asserts that a Send doesn't rebuild its tx when its nonce is too low
+// because a tx that it published earlier was included, although the receipt of that tx wasn't seen yet.
+func TestTxMgrNoResyncAfterPublishedTxIncluded(t *testing.T) {
+	t.Parallel()
+
+	conf := configWithNumConfs(1)
+	conf.SafeAbortNonceTooLowCount = 1
+	conf.ResubmissionTimeout = 10 * time.Millisecond
+	// the receipts aren't queried while the tx is in flight
+	conf.ReceiptQueryInterval = time.Hour
+	h := newTestHarnessWithConfig(t, conf)
+
+	var mined []uint64
+	mineNextNonce := h.mineNextNonce(&mined)
+	var sent []common.Hash
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		sent = append(sent, tx.Hash())
+		// the first tx is mined, and the fee bumped tx is rejected
+		return mineNextNonce(ctx, tx)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	require.Len(t, sent, 2)
+	require.Equal(t, sent[0], receipt.TxHash)
+	require.Equal(t, []uint64{0}, mined)
+}
+
+// TestTxMgrResyncsNonceWhenIdle asserts that the nonce is resynced while no txs are sent, so that the next tx
+// is sent with the pending nonce right away.
+func TestTxMgrResyncsNonceWhenIdle(t *testing.T) {
+	t.Parallel()
+
+	conf := configWithNumConfs(1)
+	conf.NonceResyncInterval = 10 * time.Millisecond
+	h := newTestHarnessWithConfig(t, conf)
+	metr := &nonceResyncMetrics{}
+	h.mgr.metr = metr
+	h.mgr.closed = make(chan struct{})
+	go h.mgr.nonceResyncLoop()
+	defer h.mgr.Close()
+
+	var mined []uint64
+	sends := 0
+	mineNextNonce := h.mineNextNonce(&mined)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		sends++
+		return mineNextNonce(ctx, tx)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+
+	h.backend.setNonce(3)
+	require.Eventually(t, func() bool { return metr.resyncs.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	_, err = h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0, 3}, mined)
+	require.Equal(t, 2, sends, "the tx is sent with the resynced nonce right away")
+}
+
+// newBlobTestHarness returns a test harness of an L1 that activated Cancun, and a blob tx candidate.
+func newBlobTestHarness(t *testing.T, conf Config) (*testHarness, TxCandidate) {
+	conf.ChainID = big.NewInt(900)
+	h := newTestHarnessWithConfig(t, conf)
+	excessBlobGas := uint64(10 * params.BlobTxBlobGasPerBlob)
+	h.gasPricer.excessBlobGas = &excessBlobGas
+	candidate := h.createTxCandidate()
+	candidate.TxData = nil
+	var blob eth.Blob
+	require.NoError(t, blob.FromData([]byte("batch data")))
+	candidate.Blobs = []*eth.Blob{&blob}
+	return h, candidate
+}
+
+// TestTxMgrBlobFeeCapTooLow asserts that the fees of a blob tx, including its blob fee cap, are bumped
+// if the blob fee cap is below the blob basefee.
+func TestTxMgrBlobFeeCapTooLow(t *testing.T) {
+	t.Parallel()
+
+	h, candidate := newBlobTestHarness(t, configWithNumConfs(1))
+	blobBaseFee := eip4844.CalcBlobFee(*h.gasPricer.excessBlobGas)
+	// the blob fee cap of the crafted tx is twice the blob basefee
+	minBlobFeeCap := new(big.Int).Mul(blobBaseFee, big.NewInt(4))
+
+	var sent []*types.Transaction
+	var mined *types.Transaction
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		sent = append(sent, tx)
+		if tx.BlobGasFeeCap().Cmp(minBlobFeeCap) < 0 {
+			return core.ErrBlobFeeCapTooLow
+		}
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		mined = tx
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, candidate)
+	require.NoError(t, err)
+	require.Equal(t, mined.Hash(), receipt.TxHash)
+	require.Len(t, sent, 2, "the blob fee cap is bumped right away")
+	require.Equal(t, uint8(types.BlobTxType), mined.Type())
+	require.Equal(t, minBlobFeeCap, mined.BlobGasFeeCap())
+	require.Equal(t, sent[0].BlobTxSidecar(), mined.BlobTxSidecar())
+}
+
+// TestTxMgrBlobTxReplacementRejected asserts that a blob tx replacement that is rejected by the tx pool,
+// because not all of its fees are bumped enough, is bumped again and replaces the blob tx.
+func TestTxMgrBlobTxReplacementRejected(t *testing.T) {
+	t.Parallel()
+
+	conf := configWithNumConfs(1)
+	conf.ResubmissionTimeout = 10 * time.Millisecond
+	conf.ReceiptQueryInterval = time.Millisecond
+	h, candidate := newBlobTestHarness(t, conf)
+
+	// the tx pool requires a 200% bump of all fees to replace a blob tx
+	bumped := func(newFee, oldFee *big.Int) bool {
+		return newFee.Cmp(new(big.Int).Mul(oldFee, big.NewInt(3))) >= 0
+	}
+	var (
+		pending  *types.Transaction
+		first    *types.Transaction
+		rejected int
+	)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		if tx.Type() != types.BlobTxType {
+			return errAlreadyReserved
+		}
+		if pending == nil {
+			pending, first = tx, tx
+			return nil
+		}
+		if !bumped(tx.GasTipCap(), pending.GasTipCap()) || !bumped(tx.GasFeeCap(), pending.GasFeeCap()) ||
+			!bumped(tx.BlobGasFeeCap(), pending.BlobGasFeeCap()) {
+			rejected++
+			return txpool.ErrReplaceUnderpriced
+		}
+		pending = tx
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, candidate)
+	require.NoError(t, err)
+	require.Equal(t, pending.Hash(), receipt.TxHash)
+	require.Equal(t, 1, rejected, "the first replacement only bumps the fees by 100%")
+	require.True(t, bumped(pending.BlobGasFeeCap(), first.BlobGasFeeCap()))
+	require.Equal(t, first.BlobHashes(), pending.BlobHashes())
+}
+
+// TestTxMgr_SignKeepsTxType asserts that a tx that the signer changed the type of is never sent,
+// so that a blob tx is never replaced by a non-blob tx.
+func TestTxMgr_SignKeepsTxType(t *testing.T) {
+	t.Parallel()
+
+	conf := configWithNumConfs(1)
+	conf.Signer = func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		return types.NewTx(&types.DynamicFeeTx{ChainID: tx.ChainId(), Nonce: tx.Nonce(), To: tx.To()}), nil
+	}
+	h, candidate := newBlobTestHarness(t, conf)
+
+	_, err := h.mgr.craftTx(context.Background(), candidate)
+	require.ErrorContains(t, err, "signer changed the tx type")
+}
+
+// lifecycleMetrics records the tx lifecycle metrics.
+type lifecycleMetrics struct {
+	metrics.NoopTxMetrics
+	mu                sync.Mutex
+	publishAttempts   []string
+	gasBumps          []int
+	confirmationTimes []time.Duration
+	basefee, tipcap   *big.Int
+	abandoned         int
+}
+
+func (m *lifecycleMetrics) RecordPublishAttempt(result string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.publishAttempts = append(m.publishAttempts, result)
+}
+
+func (m *lifecycleMetrics) RecordGasBumpCount(bumps int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gasBumps = append(m.gasBumps, bumps)
+}
+
+func (m *lifecycleMetrics) RecordTxConfirmationTime(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.confirmationTimes = append(m.confirmationTimes, d)
+}
+
+func (m *lifecycleMetrics) RecordBaseFee(basefee *big.Int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.basefee = basefee
+}
+
+func (m *lifecycleMetrics) RecordTipCap(tipcap *big.Int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tipcap = tipcap
+}
+
+func (m *lifecycleMetrics) TxAbandonedAtFeeCap() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.abandoned++
+}
+
+// TestTxMgrLifecycleMetrics asserts the metrics of a tx that is underpriced, and confirmed after its fees are bumped twice.
+func TestTxMgrLifecycleMetrics(t *testing.T) {
+	t.Parallel()
+
+	conf := configWithNumConfs(1)
+	conf.ResubmissionTimeout = 10 * time.Millisecond
+	conf.ReceiptQueryInterval = time.Millisecond
+	h := newTestHarnessWithConfig(t, conf)
+	metr := &lifecycleMetrics{}
+	h.mgr.metr = metr
+
+	sends := 0
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		sends++
+		if sends == 1 {
+			return txpool.ErrUnderpriced
+		}
+		// mined at the fees of the third epoch, after two fee bumps
+		if h.gasPricer.shouldMine(tx.GasFeeCap()) {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+
+	metr.mu.Lock()
+	defer metr.mu.Unlock()
+	require.Equal(t, []string{metrics.PublishUnderpriced, metrics.PublishReplaced, metrics.PublishReplaced}, metr.publishAttempts)
+	require.Equal(t, []int{2}, metr.gasBumps)
+	require.Len(t, metr.confirmationTimes, 1)
+	require.Positive(t, metr.confirmationTimes[0])
+	tip, feeCap := h.gasPricer.feesForEpoch(h.gasPricer.mineAtEpoch)
+	require.Equal(t, tip, metr.tipcap)
+	require.Equal(t, new(big.Int).Sub(feeCap, tip), new(big.Int).Mul(metr.basefee, big.NewInt(2)))
+	require.Zero(t, metr.abandoned)
+}
+
+// TestTxMgrAbandonedAtFeeCapMetrics asserts that a tx that is abandoned after reaching the max fees is counted.
+func TestTxMgrAbandonedAtFeeCapMetrics(t *testing.T) {
+	t.Parallel()
+
+	conf := configWithNumConfs(1)
+	conf.ResubmissionTimeout = 10 * time.Millisecond
+	// the fee cap of the second epoch is 38, and 57 for the third epoch
+	conf.MaxGasFeeCap = big.NewInt(40)
+	h := newTestHarnessWithConfig(t, conf)
+	metr := &lifecycleMetrics{}
+	h.mgr.metr = metr
+	// the tx is never mined
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	metr.mu.Lock()
+	defer metr.mu.Unlock()
+	require.Equal(t, []string{metrics.PublishOK, metrics.PublishReplaced}, metr.publishAttempts)
+	require.Empty(t, metr.gasBumps)
+	require.Equal(t, 1, metr.abandoned)
+}
+
+// TestTxMgrGasLimitBuffer asserts that the configured buffer is added to the gas estimates.
+func TestTxMgrGasLimitBuffer(t *testing.T) {
+	t.Parallel()
+
+	conf := configWithNumConfs(1)
+	conf.GasLimitBuffer = 20
+	h := newTestHarnessWithConfig(t, conf)
+	h.backend.setGasEstimate(100_000)
+
+	tx, err := h.mgr.craftTx(context.Background(), TxCandidate{To: &common.Address{}})
+	require.NoError(t, err)
+	require.Equal(t, uint64(120_000), tx.Gas())
+
+	// the buffer is added to estimates of resubmissions too
+	h.backend.setGasEstimate(200_000)
+	tx, err = h.mgr.increaseGasPrice(context.Background(), tx, true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(240_000), tx.Gas())
+}
+
+// TestTxMgrReestimatesGasWhenOutOfGas asserts that a tx that runs out of gas, because the state changed since
+// its gas was estimated, is resubmitted with a new estimate and the same fees.
+func TestTxMgrReestimatesGasWhenOutOfGas(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	h.backend.setGasEstimate(21_000)
+	var sent []*types.Transaction
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		sent = append(sent, tx)
+		if tx.Gas() < 30_000 {
+			h.backend.setGasEstimate(30_000)
+			return fmt.Errorf("%w: have %d, want %d", core.ErrIntrinsicGas, tx.Gas(), 30_000)
+		}
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, TxCandidate{To: &common.Address{}})
+	require.NoError(t, err)
+	require.Len(t, sent, 2)
+	require.Equal(t, sent[1].Hash(), receipt.TxHash)
+	require.Equal(t, uint64(30_000), sent[1].Gas())
+	require.Equal(t, sent[0].GasFeeCap(), sent[1].GasFeeCap())
+	require.Equal(t, sent[0].Nonce(), sent[1].Nonce())
+}
+
+// TestTxMgrPinnedGasLimit asserts that the gas limit of a candidate is never re-estimated, when the fees of
+// the tx are bumped or the tx runs out of gas.
+func TestTxMgrPinnedGasLimit(t *testing.T) {
+	t.Parallel()
+
+	conf := configWithNumConfs(1)
+	conf.ResubmissionTimeout = 10 * time.Millisecond
+	conf.ReceiptQueryInterval = time.Millisecond
+	h := newTestHarnessWithConfig(t, conf)
+	var sent []*types.Transaction
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		sent = append(sent, tx)
+		// the estimate changes between the attempts
+		h.backend.setGasEstimate(uint64(100_000 + len(sent)))
+		if len(sent) == 1 {
+			return core.ErrIntrinsicGas
+		}
+		if h.gasPricer.shouldMine(tx.GasFeeCap()) {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	candidate := TxCandidate{To: &common.Address{}, GasLimit: 50_000}
+	receipt, err := h.mgr.Send(ctx, candidate)
+	require.NoError(t, err)
+	require.Equal(t, sent[len(sent)-1].Hash(), receipt.TxHash)
+	require.Greater(t, len(sent), 2)
+	for _, tx := range sent {
+		require.Equal(t, candidate.GasLimit, tx.Gas())
+	}
+}
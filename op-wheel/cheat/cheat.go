@@ -4,14 +4,20 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gofrs/flock"
 
+	"github.com/ethereum-optimism/optimism/op-bindings/foundry"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -23,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
 )
@@ -36,12 +43,20 @@ type Cheater struct {
 	Blockchain *core.BlockChain
 	// The Cheater avoids making writes if this is set to True, and opens the DB as readonly.
 	ReadOnly bool
+	// Log records the changes to the head block.
+	Log log.Logger
+	// lock of the geth node instance directory, held while cheating, nil if the directory has no lock file.
+	lock *flock.Flock
 }
 
 func OpenGethRawDB(dataDirPath string, readOnly bool) (ethdb.Database, error) {
+	dbType := rawdb.PreexistingDatabase(dataDirPath)
+	if dbType == "" {
+		dbType = "leveldb"
+	}
 	// don't use readonly mode in actual DB, it doesn't work with Geth.
 	db, err := rawdb.Open(rawdb.OpenOptions{
-		Type:              "leveldb",
+		Type:              dbType,
 		Directory:         dataDirPath,
 		AncientsDirectory: filepath.Join(dataDirPath, "ancient"),
 		Namespace:         "",
@@ -55,26 +70,64 @@ func OpenGethRawDB(dataDirPath string, readOnly bool) (ethdb.Database, error) {
 	return db, nil
 }
 
-// OpenGethDB opens a geth database to apply cheats to.
-func OpenGethDB(dataDirPath string, readOnly bool) (*Cheater, error) {
+// lockNodeDir locks the instance directory of the geth node that owns the chain db, to refuse cheating
+// against a live node, and to prevent the node from starting while cheating.
+// Geth keeps a LOCK file in the instance directory, the parent of the chain db directory.
+func lockNodeDir(dataDirPath string) (*flock.Flock, error) {
+	lockPath := filepath.Join(filepath.Dir(filepath.Clean(dataDirPath)), "LOCK")
+	if _, err := os.Stat(lockPath); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	lock := flock.New(lockPath)
+	locked, err := lock.TryLock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock geth node directory: %w", err)
+	}
+	if !locked {
+		return nil, fmt.Errorf("geth node directory is locked by %s, the node must be stopped before cheating", lockPath)
+	}
+	return lock, nil
+}
+
+// OpenGethDB opens a geth database to apply cheats to. The geth node must not be running.
+func OpenGethDB(lgr log.Logger, dataDirPath string, readOnly bool) (*Cheater, error) {
+	lock, err := lockNodeDir(dataDirPath)
+	if err != nil {
+		return nil, err
+	}
 	db, err := OpenGethRawDB(dataDirPath, readOnly)
 	if err != nil {
+		unlock(lock)
 		return nil, err
 	}
-	ch, err := core.NewBlockChain(db, nil, nil, nil,
+	// The state snapshot is not maintained by the cheats, geth regenerates it when it finds the snapshot
+	// does not match the cheated head state. Without snapshot the cheated state can also be opened read-only.
+	cacheConfig := core.DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.SnapshotLimit = 0
+	ch, err := core.NewBlockChain(db, cacheConfig, nil, nil,
 		beacon.New(ethash.NewFullFaker()), vm.Config{}, nil, nil)
 	if err != nil {
 		_ = db.Close()
+		unlock(lock)
 		return nil, fmt.Errorf("failed to open blockchain around chain db: %w", err)
 	}
 	return &Cheater{
 		DB:         db,
 		Blockchain: ch,
 		ReadOnly:   readOnly,
+		Log:        lgr,
+		lock:       lock,
 	}, nil
 }
 
+func unlock(lock *flock.Flock) {
+	if lock != nil {
+		_ = lock.Unlock()
+	}
+}
+
 func (ch *Cheater) Close() error {
+	defer unlock(ch.lock)
 	return ch.DB.Close()
 }
 
@@ -165,6 +218,8 @@ func (ch *Cheater) RunAndClose(fn HeadFn) error {
 		_ = ch.Close()
 		return fmt.Errorf("failed to update chain indexes and markers: %w", err)
 	}
+	ch.Log.Info("Updated head block", "number", preID.Number,
+		"prev_hash", preID.Hash, "hash", blockHash, "prev_state_root", preHeader.Root, "state_root", stateRoot)
 	// Technically there are more in-memory things to update in real geth,
 	// to which we don't even have public API access, but that's fine, we're done.
 	// *And we did update the finalized marker, which is flushed from memory to disk on shutdown in geth.
@@ -176,9 +231,11 @@ func (ch *Cheater) RunAndClose(fn HeadFn) error {
 }
 
 // StorageSet modifies the storage of the given address at the given key to the given value.
-func StorageSet(address common.Address, key common.Hash, value common.Hash) HeadFn {
+func StorageSet(lgr log.Logger, address common.Address, key common.Hash, value common.Hash) HeadFn {
 	return func(_ *types.Header, headState *state.StateDB) error {
+		prev := headState.GetState(address, key)
 		headState.SetState(address, key, value)
+		lgr.Info("Set storage", "address", address, "key", key, "before", prev, "after", value)
 		return nil
 	}
 }
@@ -391,27 +448,59 @@ func OvmOwners(conf *OvmOwnersConfig) HeadFn {
 	}
 }
 
-func SetBalance(addr common.Address, amount *big.Int) HeadFn {
+func SetBalance(lgr log.Logger, addr common.Address, amount *big.Int) HeadFn {
 	return func(_ *types.Header, headState *state.StateDB) error {
+		prev := headState.GetBalance(addr)
 		headState.SetBalance(addr, amount)
+		lgr.Info("Set balance", "address", addr, "before", prev, "after", amount)
 		return nil
 	}
 }
 
-func SetCode(addr common.Address, code hexutil.Bytes) HeadFn {
+func SetCode(lgr log.Logger, addr common.Address, code hexutil.Bytes) HeadFn {
 	return func(_ *types.Header, headState *state.StateDB) error {
+		prevHash, prevSize := headState.GetCodeHash(addr), headState.GetCodeSize(addr)
 		headState.SetCode(addr, code)
+		lgr.Info("Set code", "address", addr, "before", prevHash, "before_size", prevSize,
+			"after", headState.GetCodeHash(addr), "after_size", len(code))
 		return nil
 	}
 }
 
-func SetNonce(addr common.Address, nonce uint64) HeadFn {
+func SetNonce(lgr log.Logger, addr common.Address, nonce uint64) HeadFn {
 	return func(_ *types.Header, headState *state.StateDB) error {
+		prev := headState.GetNonce(addr)
 		headState.SetNonce(addr, nonce)
+		lgr.Info("Set nonce", "address", addr, "before", prev, "after", nonce)
 		return nil
 	}
 }
 
+// ReadCode reads contract code from a file, either a forge artifact of which the deployed bytecode is used,
+// or a hex string.
+func ReadCode(path string) (hexutil.Bytes, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read code file: %w", err)
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var artifact foundry.Artifact
+		if err := json.Unmarshal(data, &artifact); err != nil {
+			return nil, fmt.Errorf("failed to decode artifact: %w", err)
+		}
+		if len(artifact.DeployedBytecode.Object) == 0 {
+			return nil, fmt.Errorf("artifact %s has no deployed bytecode", path)
+		}
+		return artifact.DeployedBytecode.Object, nil
+	}
+	code, err := hex.DecodeString(strings.TrimPrefix(string(data), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hex code: %w", err)
+	}
+	return code, nil
+}
+
 // blockBodyKey returns the database key to use for storing the body of a block.
 // This function was copied from Geth's core/rawdb/accessors_chain.go.
 func blockBodyKey(number uint64, hash common.Hash) []byte {
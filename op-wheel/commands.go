@@ -54,6 +54,11 @@ var (
 		Required: true,
 		EnvVars:  prefixEnvVars("ENGINE"),
 	}
+	EngineOpenEndpoint = &cli.StringFlag{
+		Name:    "engine.open",
+		Usage:   "Unauthenticated RPC endpoint of the engine with the debug namespace, can be HTTP/WS/IPC",
+		EnvVars: prefixEnvVars("ENGINE_OPEN"),
+	}
 	EngineJWTPath = &cli.StringFlag{
 		Name:      "engine.jwt-secret",
 		Usage:     "Path to JWT secret file used to authenticate Engine API communication with.",
@@ -104,8 +109,11 @@ func ParseBuildingArgs(ctx *cli.Context) *engine.BlockBuildingSettings {
 
 func CheatAction(readOnly bool, fn func(ctx *cli.Context, ch *cheat.Cheater) error) cli.ActionFunc {
 	return func(ctx *cli.Context) error {
+		// cheats are always logged, independent of the geth log level
+		lgr := log.New()
+		lgr.SetHandler(log.StreamHandler(ctx.App.ErrWriter, log.TerminalFormat(false)))
 		dataDir := ctx.String(DataDirFlag.Name)
-		ch, err := cheat.OpenGethDB(dataDir, readOnly)
+		ch, err := cheat.OpenGethDB(lgr, dataDir, readOnly)
 		if err != nil {
 			return fmt.Errorf("failed to open geth db: %w", err)
 		}
@@ -206,6 +214,10 @@ func bigFlag(name string, usage string) *cli.GenericFlag {
 	return textFlag[*big.Int](name, usage, new(big.Int))
 }
 
+func blockFlag(name string, usage string) *cli.GenericFlag {
+	return textFlag[*engine.BlockArg](name, usage, new(engine.BlockArg))
+}
+
 func addrFlagValue(name string, ctx *cli.Context) common.Address {
 	return *ctx.Generic(name).(*TextFlag[*common.Address]).Value
 }
@@ -222,6 +234,10 @@ func bigFlagValue(name string, ctx *cli.Context) *big.Int {
 	return ctx.Generic(name).(*TextFlag[*big.Int]).Value
 }
 
+func blockFlagValue(name string, ctx *cli.Context) *engine.BlockArg {
+	return ctx.Generic(name).(*TextFlag[*engine.BlockArg]).Value
+}
+
 var (
 	CheatStorageGetCmd = &cli.Command{
 		Name:    "get",
@@ -245,7 +261,7 @@ var (
 			hashFlag("value", "the value to write"),
 		},
 		Action: CheatAction(false, func(ctx *cli.Context, ch *cheat.Cheater) error {
-			return ch.RunAndClose(cheat.StorageSet(addrFlagValue("address", ctx), hashFlagValue("key", ctx), hashFlagValue("value", ctx)))
+			return ch.RunAndClose(cheat.StorageSet(ch.Log, addrFlagValue("address", ctx), hashFlagValue("key", ctx), hashFlagValue("value", ctx)))
 		}),
 	}
 	CheatStorageReadAll = &cli.Command{
@@ -284,36 +300,62 @@ var (
 		},
 	}
 	CheatSetBalanceCmd = &cli.Command{
-		Name: "balance",
+		Name:    "balance",
+		Aliases: []string{"set-balance"},
 		Flags: []cli.Flag{
 			DataDirFlag,
 			addrFlag("address", "Address to change balance of"),
 			bigFlag("balance", "New balance of the account"),
 		},
 		Action: CheatAction(false, func(ctx *cli.Context, ch *cheat.Cheater) error {
-			return ch.RunAndClose(cheat.SetBalance(addrFlagValue("address", ctx), bigFlagValue("balance", ctx)))
+			return ch.RunAndClose(cheat.SetBalance(ch.Log, addrFlagValue("address", ctx), bigFlagValue("balance", ctx)))
 		}),
 	}
 	CheatSetCodeCmd = &cli.Command{
-		Name: "code",
+		Name:    "code",
+		Aliases: []string{"set-code"},
 		Flags: []cli.Flag{
 			DataDirFlag,
 			addrFlag("address", "Address to change code of"),
-			bytesFlag("code", "New code of the account"),
+			&cli.GenericFlag{
+				Name:    "code",
+				Usage:   "New code of the account, hex encoded",
+				EnvVars: prefixEnvVars("CODE"),
+				Value:   &TextFlag[*hexutil.Bytes]{Value: new(hexutil.Bytes)},
+			},
+			&cli.StringFlag{
+				Name:      "code-file",
+				Usage:     "Path to the new code of the account, a forge artifact or hex encoded file. Alternative to --code.",
+				TakesFile: true,
+				EnvVars:   prefixEnvVars("CODE_FILE"),
+			},
 		},
 		Action: CheatAction(false, func(ctx *cli.Context, ch *cheat.Cheater) error {
-			return ch.RunAndClose(cheat.SetCode(addrFlagValue("address", ctx), bytesFlagValue("code", ctx)))
+			if ctx.IsSet("code") == ctx.IsSet("code-file") {
+				_ = ch.Close()
+				return fmt.Errorf("expected exactly one of --code and --code-file")
+			}
+			code := bytesFlagValue("code", ctx)
+			if path := ctx.String("code-file"); path != "" {
+				var err error
+				if code, err = cheat.ReadCode(path); err != nil {
+					_ = ch.Close()
+					return err
+				}
+			}
+			return ch.RunAndClose(cheat.SetCode(ch.Log, addrFlagValue("address", ctx), code))
 		}),
 	}
 	CheatSetNonceCmd = &cli.Command{
-		Name: "nonce",
+		Name:    "nonce",
+		Aliases: []string{"set-nonce"},
 		Flags: []cli.Flag{
 			DataDirFlag,
 			addrFlag("address", "Address to change nonce of"),
 			bigFlag("nonce", "New nonce of the account"),
 		},
 		Action: CheatAction(false, func(ctx *cli.Context, ch *cheat.Cheater) error {
-			return ch.RunAndClose(cheat.SetNonce(addrFlagValue("address", ctx), bigFlagValue("balance", ctx).Uint64()))
+			return ch.RunAndClose(cheat.SetNonce(ch.Log, addrFlagValue("address", ctx), bigFlagValue("nonce", ctx).Uint64()))
 		}),
 	}
 	CheatOvmOwnersCmd = &cli.Command{
@@ -469,30 +511,47 @@ var (
 
 	EngineSetForkchoiceCmd = &cli.Command{
 		Name:        "set-forkchoice",
-		Description: "Set forkchoice, specify unsafe, safe and finalized blocks by number",
+		Description: "Set forkchoice, specify unsafe, safe and finalized blocks by number or hash",
 		Flags: []cli.Flag{
-			EngineEndpoint, EngineJWTPath,
-			&cli.Uint64Flag{
-				Name:     "unsafe",
-				Usage:    "Block number of block to set as latest block",
-				Required: true,
-				EnvVars:  prefixEnvVars("UNSAFE"),
-			},
-			&cli.Uint64Flag{
-				Name:     "safe",
-				Usage:    "Block number of block to set as safe block",
-				Required: true,
-				EnvVars:  prefixEnvVars("SAFE"),
+			EngineEndpoint, EngineJWTPath, EngineOpenEndpoint,
+			blockFlag("unsafe", "Block number or hash of block to set as latest block"),
+			blockFlag("safe", "Block number or hash of block to set as safe block"),
+			blockFlag("finalized", "Block number or hash of block to set as finalized block"),
+			&cli.BoolFlag{
+				Name:    "force",
+				Usage:   "Allow moving the unsafe, safe or finalized block backwards",
+				EnvVars: prefixEnvVars("FORCE"),
 			},
-			&cli.Uint64Flag{
-				Name:     "finalized",
-				Usage:    "Block number of block to set as finalized block",
-				Required: true,
-				EnvVars:  prefixEnvVars("FINALIZED"),
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Usage:   "Print the resolved blocks and the requests to send, without sending them",
+				EnvVars: prefixEnvVars("DRY_RUN"),
 			},
 		},
-		Action: EngineAction(func(ctx *cli.Context, client client.RPC) error {
-			return engine.SetForkchoice(ctx.Context, client, ctx.Uint64("finalized"), ctx.Uint64("safe"), ctx.Uint64("unsafe"))
+		Action: EngineAction(func(ctx *cli.Context, engineClient client.RPC) error {
+			update, err := engine.PrepareForkchoice(ctx.Context, engineClient,
+				blockFlagValue("finalized", ctx), blockFlagValue("safe", ctx), blockFlagValue("unsafe", ctx))
+			if err != nil {
+				return err
+			}
+			if ctx.Bool("dry-run") {
+				return update.DryRun(ctx.App.Writer)
+			}
+			var open client.RPC
+			if endpoint := ctx.String(EngineOpenEndpoint.Name); endpoint != "" {
+				rpcClient, err := rpc.DialContext(ctx.Context, endpoint)
+				if err != nil {
+					return fmt.Errorf("failed to dial open engine endpoint %q: %w", endpoint, err)
+				}
+				open = client.NewBaseRPCClient(rpcClient)
+			}
+			stat, err := engine.SetForkchoice(ctx.Context, engineClient, open, update, ctx.Bool("force"))
+			if err != nil {
+				return err
+			}
+			enc := json.NewEncoder(ctx.App.Writer)
+			enc.SetIndent("", "  ")
+			return enc.Encode(stat)
 		}),
 	}
 
@@ -528,7 +587,8 @@ var (
 var CheatCmd = &cli.Command{
 	Name:  "cheat",
 	Usage: "Cheating commands to modify a Geth database.",
-	Description: "Each sub-command opens a Geth database, applies the cheat, and then saves and closes the database." +
+	Description: "Each sub-command opens a Geth database, applies the cheat, and then saves and closes the database. " +
+		"The Geth node must be stopped while cheating. " +
 		"The Geth node will live in its own false reality, other nodes cannot sync the cheated state if they process the blocks.",
 	Subcommands: []*cli.Command{
 		CheatStorageCmd,
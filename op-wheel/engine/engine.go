@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"strconv"
 	"strings"
 	"time"
 
@@ -310,32 +311,188 @@ func Copy(ctx context.Context, copyFrom client.RPC, copyTo client.RPC) error {
 	return nil
 }
 
-func SetForkchoice(ctx context.Context, client client.RPC, finalizedNum, safeNum, unsafeNum uint64) error {
-	if unsafeNum < safeNum {
-		return fmt.Errorf("cannot set unsafe (%d) < safe (%d)", unsafeNum, safeNum)
+// BlockArg selects a block either by number or by hash.
+type BlockArg struct {
+	Number uint64
+	Hash   common.Hash
+	ByHash bool
+}
+
+// UnmarshalText parses a 32 byte hex string as block hash, and anything else as block number.
+func (a *BlockArg) UnmarshalText(text []byte) error {
+	v := strings.TrimSpace(string(text))
+	if len(v) == 2+2*common.HashLength && strings.HasPrefix(v, "0x") {
+		var h common.Hash
+		if err := h.UnmarshalText([]byte(v)); err != nil {
+			return fmt.Errorf("invalid block hash %q: %w", v, err)
+		}
+		*a = BlockArg{Hash: h, ByHash: true}
+		return nil
+	}
+	n, err := strconv.ParseUint(v, 0, 64)
+	if err != nil {
+		return fmt.Errorf("invalid block number or hash %q: %w", v, err)
+	}
+	*a = BlockArg{Number: n}
+	return nil
+}
+
+func (a *BlockArg) String() string {
+	if a.ByHash {
+		return a.Hash.String()
+	}
+	return strconv.FormatUint(a.Number, 10)
+}
+
+func (a *BlockArg) header(ctx context.Context, client client.RPC) (*types.Header, error) {
+	var header *types.Header
+	var err error
+	if a.ByHash {
+		header, err = getHeader(ctx, client, "eth_getBlockByHash", a.Hash.String())
+	} else {
+		header, err = getHeader(ctx, client, "eth_getBlockByNumber", hexutil.Uint64(a.Number).String())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block %s not found", a)
+	}
+	return header, nil
+}
+
+// ForkchoiceUpdate is a forkchoice change, with the blocks resolved against the engine status it applies to.
+type ForkchoiceUpdate struct {
+	Unsafe    eth.L1BlockRef `json:"unsafe"`
+	Safe      eth.L1BlockRef `json:"safe"`
+	Finalized eth.L1BlockRef `json:"finalized"`
+	// Current is the engine status before the update
+	Current *StatusData `json:"current"`
+}
+
+// State is the forkchoice state sent to the engine
+func (u *ForkchoiceUpdate) State() engine.ForkchoiceStateV1 {
+	return engine.ForkchoiceStateV1{
+		HeadBlockHash:      u.Unsafe.Hash,
+		SafeBlockHash:      u.Safe.Hash,
+		FinalizedBlockHash: u.Finalized.Hash,
+	}
+}
+
+// Rewinds lists the heads that the update moves backwards, compared to the current engine status.
+func (u *ForkchoiceUpdate) Rewinds() []string {
+	var out []string
+	if u.RewindsUnsafe() {
+		out = append(out, "unsafe")
+	}
+	if u.Safe.Number < u.Current.Safe.Number {
+		out = append(out, "safe")
+	}
+	if u.Finalized.Number < u.Current.Finalized.Number {
+		out = append(out, "finalized")
+	}
+	return out
+}
+
+// RewindsUnsafe returns true if the update moves the unsafe head backwards. The engine does not rewind its chain on a
+// forkchoice update to a canonical ancestor, the chain has to be rewound with debug_setHead first.
+func (u *ForkchoiceUpdate) RewindsUnsafe() bool {
+	return u.Unsafe.Number < u.Current.Head.Number
+}
+
+type rpcRequest struct {
+	Method string `json:"method"`
+	Params []any  `json:"params"`
+}
+
+func (u *ForkchoiceUpdate) requests() []rpcRequest {
+	var out []rpcRequest
+	if u.RewindsUnsafe() {
+		out = append(out, rpcRequest{Method: "debug_setHead", Params: []any{hexutil.Uint64(u.Unsafe.Number)}})
 	}
-	if safeNum < finalizedNum {
-		return fmt.Errorf("cannot set safe (%d) < finalized (%d)", safeNum, finalizedNum)
+	return append(out, rpcRequest{Method: "engine_forkchoiceUpdatedV2", Params: []any{u.State(), nil}})
+}
+
+// DryRun writes the forkchoice update, and the exact requests that SetForkchoice would send, as JSON.
+func (u *ForkchoiceUpdate) DryRun(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		*ForkchoiceUpdate
+		Rewinds  []string     `json:"rewinds"`
+		Requests []rpcRequest `json:"requests"`
+	}{
+		ForkchoiceUpdate: u,
+		Rewinds:          u.Rewinds(),
+		Requests:         u.requests(),
+	})
+}
+
+// PrepareForkchoice resolves the blocks of a forkchoice update, and checks that finalized <= safe <= unsafe.
+func PrepareForkchoice(ctx context.Context, client client.RPC, finalized, safe, unsafe *BlockArg) (*ForkchoiceUpdate, error) {
+	current, err := Status(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get engine status: %w", err)
 	}
-	head, err := getHeader(ctx, client, "eth_getBlockByNumber", "latest")
+	unsafeHeader, err := unsafe.header(ctx, client)
 	if err != nil {
-		return fmt.Errorf("failed to get latest block: %w", err)
+		return nil, fmt.Errorf("failed to get block %s to mark unsafe: %w", unsafe, err)
 	}
-	if unsafeNum > head.Number.Uint64() {
-		return fmt.Errorf("cannot set unsafe (%d) > latest (%d)", unsafeNum, head.Number.Uint64())
+	safeHeader, err := safe.header(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %s to mark safe: %w", safe, err)
 	}
-	finalizedHeader, err := getHeader(ctx, client, "eth_getBlockByNumber", hexutil.Uint64(finalizedNum).String())
+	finalizedHeader, err := finalized.header(ctx, client)
 	if err != nil {
-		return fmt.Errorf("failed to get block %d to mark finalized: %w", finalizedNum, err)
+		return nil, fmt.Errorf("failed to get block %s to mark finalized: %w", finalized, err)
+	}
+	update := &ForkchoiceUpdate{
+		Unsafe:    eth.InfoToL1BlockRef(eth.HeaderBlockInfo(unsafeHeader)),
+		Safe:      eth.InfoToL1BlockRef(eth.HeaderBlockInfo(safeHeader)),
+		Finalized: eth.InfoToL1BlockRef(eth.HeaderBlockInfo(finalizedHeader)),
+		Current:   current,
+	}
+	if update.Unsafe.Number < update.Safe.Number {
+		return nil, fmt.Errorf("cannot set unsafe (%d) < safe (%d)", update.Unsafe.Number, update.Safe.Number)
+	}
+	if update.Safe.Number < update.Finalized.Number {
+		return nil, fmt.Errorf("cannot set safe (%d) < finalized (%d)", update.Safe.Number, update.Finalized.Number)
+	}
+	return update, nil
+}
+
+// SetForkchoice applies the forkchoice update, and verifies the engine status afterwards.
+// Moving any of the heads backwards requires force. Moving the unsafe head backwards also requires
+// the open RPC of the engine, with the debug namespace enabled, to rewind the chain.
+func SetForkchoice(ctx context.Context, client client.RPC, open client.RPC, update *ForkchoiceUpdate, force bool) (*StatusData, error) {
+	if rewinds := update.Rewinds(); len(rewinds) > 0 && !force {
+		return nil, fmt.Errorf("update moves %s backwards, force is required", strings.Join(rewinds, ", "))
+	}
+	if update.RewindsUnsafe() {
+		if open == nil {
+			return nil, fmt.Errorf("cannot rewind unsafe from %d to %d without the open engine RPC", update.Current.Head.Number, update.Unsafe.Number)
+		}
+		if err := open.CallContext(ctx, nil, "debug_setHead", hexutil.Uint64(update.Unsafe.Number)); err != nil {
+			return nil, fmt.Errorf("failed to rewind chain to %d: %w", update.Unsafe.Number, err)
+		}
+	}
+	if err := updateForkchoice(ctx, client, update.Unsafe.Hash, update.Safe.Hash, update.Finalized.Hash); err != nil {
+		return nil, fmt.Errorf("failed to update forkchoice: %w", err)
 	}
-	safeHeader, err := getHeader(ctx, client, "eth_getBlockByNumber", hexutil.Uint64(safeNum).String())
+	status, err := Status(ctx, client)
 	if err != nil {
-		return fmt.Errorf("failed to get block %d to mark safe: %w", safeNum, err)
+		return nil, fmt.Errorf("failed to get engine status after forkchoice update: %w", err)
 	}
-	if err := updateForkchoice(ctx, client, head.Hash(), safeHeader.Hash(), finalizedHeader.Hash()); err != nil {
-		return fmt.Errorf("failed to update forkchoice: %w", err)
+	if status.Head.Hash != update.Unsafe.Hash {
+		return status, fmt.Errorf("engine reports head %s, expected %s", status.Head, update.Unsafe)
 	}
-	return nil
+	if status.Safe.Hash != update.Safe.Hash {
+		return status, fmt.Errorf("engine reports safe %s, expected %s", status.Safe, update.Safe)
+	}
+	if status.Finalized.Hash != update.Finalized.Hash {
+		return status, fmt.Errorf("engine reports finalized %s, expected %s", status.Finalized, update.Finalized)
+	}
+	return status, nil
 }
 
 func RawJSONInteraction(ctx context.Context, client client.RPC, method string, args []string, input io.Reader, output io.Writer) error {